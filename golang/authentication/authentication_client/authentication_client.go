@@ -2,6 +2,7 @@ package authentication_client
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"time"
@@ -19,6 +20,10 @@ import (
 // //////////////////////////////////////////////////////////////////////////////
 var (
 	tokensPath = config.GetConfigDir() + "/tokens"
+
+	// ErrMfaRequired is returned by Authenticate for an account that needs a
+	// second factor; use Login and VerifyMfa instead.
+	ErrMfaRequired = errors.New("multi-factor authentication required")
 )
 
 type Authentication_Client struct {
@@ -269,12 +274,53 @@ func (client *Authentication_Client) Logout() error {
 
 // Authenticate a user.
 func (client *Authentication_Client) Authenticate(name string, password string) (string, error) {
+	rsp, err := client.Login(name, password)
+	if err != nil {
+		return "", err
+	}
+	if rsp.MfaRequired {
+		return "", ErrMfaRequired
+	}
+	return rsp.Token, nil
+}
+
+/**
+ * AuthenticateFrom is Authenticate on behalf of a client connected to the
+ * caller, such as a WebDAV user. The client address is forwarded
+ * (x-forwarded-for), so that sign-in lockout counts its failures rather
+ * than the caller's.
+ */
+func (client *Authentication_Client) AuthenticateFrom(name string, password string, clientAddr string) (string, error) {
+	ctx := client.GetCtx()
+	if clientAddr != "" {
+		ctx = metadata.AppendToOutgoingContext(ctx, "x-forwarded-for", clientAddr)
+	}
+	rsp, err := client.login(ctx, name, password)
+	if err != nil {
+		return "", err
+	}
+	if rsp.MfaRequired {
+		return "", ErrMfaRequired
+	}
+	return rsp.Token, nil
+}
+
+/**
+ * Authenticate the first step of a login: the response holds either the
+ * session token or, when the account needs a second factor, the mfa token
+ * to pass to VerifyMfa.
+ */
+func (client *Authentication_Client) Login(name string, password string) (*authenticationpb.AuthenticateRsp, error) {
+	return client.login(client.GetCtx(), name, password)
+}
+
+func (client *Authentication_Client) login(ctx context.Context, name string, password string) (*authenticationpb.AuthenticateRsp, error) {
 
 	// Get the mac address of the server.
 	macAddress, err := config.GetMacAddress()
 	if err != nil {
 		log.Println("fail to get mac address with error ", err)
-		return "", err
+		return nil, err
 	}
 
 	// In case of other domain than localhost I will rip off the token file
@@ -282,7 +328,7 @@ func (client *Authentication_Client) Authenticate(name string, password string)
 	err = Utility.CreateDirIfNotExist(tokensPath)
 	if err != nil {
 		log.Println("fail to create dir ", tokensPath, " with error ", err)
-		return "", err
+		return nil, err
 	}
 
 	rqst := &authenticationpb.AuthenticateRqst{
@@ -291,17 +337,17 @@ func (client *Authentication_Client) Authenticate(name string, password string)
 		Issuer:   macAddress,
 	}
 
-	rsp, err := client.c.Authenticate(client.GetCtx(), rqst)
+	rsp, err := client.c.Authenticate(ctx, rqst)
 	if err != nil {
 		log.Println("fail to authenticate ", name, " on domain ", client.GetAddress(), " with error ", err)
-		return "", err
+		return nil, err
 	}
 
-	if len(rsp.Token) == 0 {
-		return "", fmt.Errorf("fail to authenticate %s on domain %s", name, client.GetAddress())
+	if len(rsp.Token) == 0 && !rsp.MfaRequired {
+		return nil, fmt.Errorf("fail to authenticate %s on domain %s", name, client.GetAddress())
 	}
 
-	return rsp.Token, nil
+	return rsp, nil
 }
 
 /**
@@ -383,3 +429,142 @@ func (client *Authentication_Client) ValidateToken(token string) (string, int64,
 
 	return rsp.ClientId, rsp.Expired, nil
 }
+
+/**
+ * Complete a login with a TOTP or recovery code, or a WebAuthn assertion
+ * (JSON), and return the session token.
+ */
+func (client *Authentication_Client) VerifyMfa(mfaToken, code, webauthnAssertion string) (string, error) {
+	rqst := &authenticationpb.VerifyMfaRequest{MfaToken: mfaToken, Code: code, WebauthnAssertion: webauthnAssertion}
+
+	rsp, err := client.c.VerifyMfa(client.GetCtx(), rqst)
+	if err != nil {
+		return "", err
+	}
+
+	return rsp.Token, nil
+}
+
+/**
+ * Start a TOTP enrollment; returns the secret and its otpauth:// URI.
+ * mfaToken is only needed when enrolling from the first step of a login.
+ */
+func (client *Authentication_Client) EnrollTotp(accountId, mfaToken string) (string, string, error) {
+	rqst := &authenticationpb.EnrollTotpRequest{AccountId: accountId, MfaToken: mfaToken}
+
+	rsp, err := client.c.EnrollTotp(client.GetCtx(), rqst)
+	if err != nil {
+		return "", "", err
+	}
+
+	return rsp.Secret, rsp.Uri, nil
+}
+
+/**
+ * Activate the TOTP secret; returns the recovery codes of a first factor and,
+ * with an mfaToken, the session token.
+ */
+func (client *Authentication_Client) ConfirmTotp(accountId, code, mfaToken string) ([]string, string, error) {
+	rqst := &authenticationpb.ConfirmTotpRequest{AccountId: accountId, Code: code, MfaToken: mfaToken}
+
+	rsp, err := client.c.ConfirmTotp(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return rsp.RecoveryCodes, rsp.Token, nil
+}
+
+/**
+ * Replace the recovery codes of an account.
+ */
+func (client *Authentication_Client) RegenerateRecoveryCodes(accountId string) ([]string, error) {
+	rqst := &authenticationpb.RegenerateRecoveryCodesRequest{AccountId: accountId}
+
+	rsp, err := client.c.RegenerateRecoveryCodes(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.RecoveryCodes, nil
+}
+
+/**
+ * Return the second factors enrolled by an account.
+ */
+func (client *Authentication_Client) GetMfaStatus(accountId string) (*authenticationpb.GetMfaStatusResponse, error) {
+	rqst := &authenticationpb.GetMfaStatusRequest{AccountId: accountId}
+
+	return client.c.GetMfaStatus(client.GetCtx(), rqst)
+}
+
+/**
+ * Set the accounts and roles that must use a second factor.
+ */
+func (client *Authentication_Client) SetMfaPolicy(accounts, roles []string) error {
+	rqst := &authenticationpb.SetMfaPolicyRequest{Policy: &authenticationpb.MfaPolicy{Accounts: accounts, Roles: roles}}
+
+	_, err := client.c.SetMfaPolicy(client.GetCtx(), rqst)
+	return err
+}
+
+/**
+ * Return the MFA policy.
+ */
+func (client *Authentication_Client) GetMfaPolicy() (*authenticationpb.MfaPolicy, error) {
+	rsp, err := client.c.GetMfaPolicy(client.GetCtx(), &authenticationpb.GetMfaPolicyRequest{})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Policy, nil
+}
+
+/**
+ * Remove every second factor of an account.
+ */
+func (client *Authentication_Client) ResetMfa(accountId string) error {
+	_, err := client.c.ResetMfa(client.GetCtx(), &authenticationpb.ResetMfaRequest{AccountId: accountId})
+	return err
+}
+
+/**
+ * Lift the sign-in lockout of an account and/or a client address.
+ */
+func (client *Authentication_Client) UnlockAccount(accountId, ip string) error {
+	_, err := client.c.UnlockAccount(client.GetCtx(), &authenticationpb.UnlockAccountRequest{AccountId: accountId, Ip: ip})
+	return err
+}
+
+/**
+ * Return the locked accounts and addresses; includeFailing adds those with
+ * recent failures that are not locked yet.
+ */
+func (client *Authentication_Client) ListLockedAccounts(includeFailing bool) ([]*authenticationpb.Lockout, error) {
+	rsp, err := client.c.ListLockedAccounts(client.GetCtx(), &authenticationpb.ListLockedAccountsRequest{IncludeFailing: includeFailing})
+	if err != nil {
+		return nil, err
+	}
+
+	return rsp.Lockouts, nil
+}
+
+/**
+ * Register an OpenID Connect client. The secret is empty for a public client
+ * and is only returned here.
+ */
+func (client *Authentication_Client) RegisterOidcClient(name string, redirectUris []string, publicClient bool, description string) (clientId, clientSecret string, err error) {
+	rqst := &authenticationpb.RegisterOidcClientRequest{
+		Name:         name,
+		RedirectUris: redirectUris,
+		PublicClient: publicClient,
+		Description:  description,
+	}
+
+	rsp, err := client.c.RegisterOidcClient(client.GetCtx(), rqst)
+	if err != nil {
+		return "", "", err
+	}
+
+	return rsp.ClientId, rsp.ClientSecret, nil
+}
@@ -67,6 +67,15 @@ type Config struct {
 	WatchSessionsDelay int    `json:"WatchSessionsDelay"`
 	SessionTimeout     int    `json:"SessionTimeout"`
 	LdapConnectionId   string `json:"LdapConnectionId"`
+	MfaTimeout         int    `json:"MfaTimeout"`
+	LockoutThreshold   int    `json:"LockoutThreshold"`
+	LockoutIpThreshold int    `json:"LockoutIpThreshold"`
+	LockoutWindow      int    `json:"LockoutWindow"`
+	LockoutDuration    int    `json:"LockoutDuration"`
+	LockoutMaxDuration int    `json:"LockoutMaxDuration"`
+	OidcPort           int    `json:"OidcPort"`
+	OidcIssuer         string `json:"OidcIssuer"`
+	OidcRefreshTimeout int    `json:"OidcRefreshTimeout"`
 	AdminEmail         string `json:"AdminEmail"`
 	RootPassword       string `json:"RootPassword"`
 }
@@ -121,6 +130,13 @@ func DefaultConfig() *Config {
 		WatchSessionsDelay: 60,
 		SessionTimeout:     15,
 		LdapConnectionId:   "",
+		MfaTimeout:         5,
+		LockoutThreshold:   5,
+		LockoutIpThreshold: 20,
+		LockoutWindow:      15,
+		LockoutDuration:    1,
+		LockoutMaxDuration: 60,
+		OidcRefreshTimeout: 30,
 		RootPassword:       "adminadmin",
 	}
 
@@ -189,6 +205,15 @@ func (c *Config) Clone() *Config {
 		WatchSessionsDelay: c.WatchSessionsDelay,
 		SessionTimeout:     c.SessionTimeout,
 		LdapConnectionId:   c.LdapConnectionId,
+		MfaTimeout:         c.MfaTimeout,
+		LockoutThreshold:   c.LockoutThreshold,
+		LockoutIpThreshold: c.LockoutIpThreshold,
+		LockoutWindow:      c.LockoutWindow,
+		LockoutDuration:    c.LockoutDuration,
+		LockoutMaxDuration: c.LockoutMaxDuration,
+		OidcPort:           c.OidcPort,
+		OidcIssuer:         c.OidcIssuer,
+		OidcRefreshTimeout: c.OidcRefreshTimeout,
 		AdminEmail:         c.AdminEmail,
 		RootPassword:       c.RootPassword,
 	}
@@ -102,6 +102,18 @@ func logInternal(op string, err error, kv ...any) error {
 	)
 }
 
+// errWrongCredentials is the status of a sign-in refused for its credentials,
+// the same whether the account exists or not. Only those refusals count
+// towards the lockout (lockout.go).
+var errWrongCredentials = status.Error(codes.Unauthenticated, "wrong account name or password")
+
+// wrongCredentials logs a refused sign-in and returns errWrongCredentials.
+func wrongCredentials(op string, err error, kv ...any) error {
+	args := append(kv, "err", err)
+	slog.Info(op, args...)
+	return errWrongCredentials
+}
+
 // ValidateToken validates the provided JWT token and returns the associated client ID and expiration time.
 func (srv *server) ValidateToken(ctx context.Context, rqst *authenticationpb.ValidateTokenRqst) (*authenticationpb.ValidateTokenRsp, error) {
 	claims, err := security.ValidateToken(rqst.Token)
@@ -344,16 +356,50 @@ func (srv *server) validateGoogleToken(accessToken string) (bool, error) {
 	return true, nil
 }
 
-// authenticate authenticates either root (sa) via config or a regular account (password / LDAP / OAuth).
-// This is the sole session-JWT issuance point. bcrypt validation → GenerateToken.
-// Any path that mints session tokens outside this function violates the trust contract.
-//
+// principal is an account whose credentials were verified, before a session
+// is issued for it.
+type principal struct {
+	id, name, email, uuid string
+	roles                 []string
+	root                  bool
+}
+
+// authenticate authenticates either root (sa) via config or a regular account
+// (password / LDAP / OAuth) and issues its session, without a second factor.
+// Logins go through login instead, which enforces MFA.
 func (srv *server) authenticate(accountId, pwd, issuer string) (string, error) {
+	p, err := srv.verifyCredentials(accountId, pwd)
+	if err != nil {
+		return "", err
+	}
+	return srv.issueSession(p, issuer)
+}
+
+// login verifies the credentials and either issues the session or, when the
+// account needs a second factor, the mfa_pending token of VerifyMfa.
+func (srv *server) login(accountId, pwd, issuer string) (*authenticationpb.AuthenticateRsp, error) {
+	p, err := srv.verifyCredentials(accountId, pwd)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := srv.challengeMfa(p, issuer)
+	if err != nil || rsp != nil {
+		return rsp, err
+	}
+	tokenString, err := srv.issueSession(p, issuer)
+	if err != nil {
+		return nil, err
+	}
+	return &authenticationpb.AuthenticateRsp{Token: tokenString}, nil
+}
+
+// verifyCredentials checks the password of root (sa) or a regular account.
+func (srv *server) verifyCredentials(accountId, pwd string) (*principal, error) {
 	// Root path — credentials stored in etcd so any cluster instance can authenticate sa.
 	if accountId == "sa" || strings.HasPrefix(accountId, "sa@") {
 		creds, err := config.GetRootCredentials()
 		if err != nil {
-			return "", logInternal("authenticate:root:etcd", err)
+			return nil, logInternal("authenticate:root:etcd", err)
 		}
 
 		password := creds.RootPassword
@@ -370,7 +416,7 @@ func (srv *server) authenticate(accountId, pwd, issuer string) (string, error) {
 					"reason":   "wrong_password",
 					"service":  "authentication",
 				})
-				return "", logInternal("authenticate:root:validate", err)
+				return nil, wrongCredentials("authenticate:root:validate", err)
 			}
 		} else {
 			if pwd != effective {
@@ -380,7 +426,7 @@ func (srv *server) authenticate(accountId, pwd, issuer string) (string, error) {
 					"reason":   "wrong_password",
 					"service":  "authentication",
 				})
-				return "", logInternal("authenticate:root:defaultMismatch", errors.New("the given password doesn't match the existing one"))
+				return nil, wrongCredentials("authenticate:root:defaultMismatch", errors.New("the given password doesn't match the existing one"))
 			}
 			// Upgrade legacy plaintext to bcrypt once authentication succeeds.
 			if hash, herr := bcrypt.GenerateFromPassword([]byte(pwd), bcrypt.DefaultCost); herr == nil {
@@ -391,59 +437,49 @@ func (srv *server) authenticate(accountId, pwd, issuer string) (string, error) {
 			}
 		}
 
-		tokenString, err := security.GenerateToken(srv.SessionTimeout, issuer, "sa", "sa", creds.AdminEmail)
-		if err != nil {
-			return "", logInternal("authenticate:root:generate", err)
-		}
-
-		// prepare home folder and resource owner mapping for sa (domain-free)
-		path := "/users/sa"
-		Utility.CreateDirIfNotExist(dataPath + "/files" + path)
-		if rbacErr := srv.addResourceOwner(tokenString, path, "file", "sa", rbacpb.SubjectType_ACCOUNT); rbacErr != nil {
-			slog.Warn("authenticate:root:addResourceOwner failed (non-fatal)", "path", path, "err", rbacErr)
-		}
-
-		slog.Info("authenticate:root:ok")
-		return tokenString, nil
+		return &principal{id: "sa", name: "sa", email: creds.AdminEmail, root: true}, nil
 	}
 
 	// Regular account path
 	account, err := srv.getAccount(accountId)
+	if status.Code(err) == codes.NotFound {
+		return nil, wrongCredentials("authenticate:unknownAccount", err, "accountId", accountId)
+	}
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
 	if pwd == "" {
 		// OAuth path
 		if account.RefreshToken == "" {
-			return "", errors.New("no password or refresh token provided")
+			return nil, wrongCredentials("authenticate:noPassword", errors.New("no password or refresh token provided"), "accountId", account.Id)
 		}
 
 		refreshURL := fmt.Sprintf("https://%s/refresh_google_token?refresh_token=%s", srv.Domain, account.RefreshToken)
 		resp, err := http.Get(refreshURL)
 		if err != nil {
-			return "", fmt.Errorf("failed to call refresh token API: %w", err)
+			return nil, fmt.Errorf("failed to call refresh token API: %w", err)
 		}
 		defer resp.Body.Close()
 
 		body, err := io.ReadAll(resp.Body)
 		if err != nil {
-			return "", fmt.Errorf("failed to read response: %w", err)
+			return nil, fmt.Errorf("failed to read response: %w", err)
 		}
 
 		var result map[string]string
 		if err = json.Unmarshal(body, &result); err != nil {
-			return "", fmt.Errorf("failed to parse JSON response: %w", err)
+			return nil, fmt.Errorf("failed to parse JSON response: %w", err)
 		}
 
 		accessToken, exists := result["access_token"]
 		if !exists {
-			return "", errors.New("no access token found in response")
+			return nil, errors.New("no access token found in response")
 		}
 
 		valid, err := srv.validateGoogleToken(accessToken)
 		if err != nil || !valid {
-			return "", fmt.Errorf("invalid Google token: %w", err)
+			return nil, fmt.Errorf("invalid Google token: %w", err)
 		}
 	} else {
 		// Password path (+ optional LDAP fallback)
@@ -457,32 +493,59 @@ func (srv *server) authenticate(accountId, pwd, issuer string) (string, error) {
 			})
 			if len(srv.LdapConnectionId) != 0 {
 				if err := srv.authenticateLdap(account.Name, pwd); err != nil {
+					if status.Code(err) == codes.Unauthenticated {
+						return nil, wrongCredentials("authenticate:ldapRejected", err, "accountId", account.Id)
+					}
 					slog.Warn("authenticate:ldapFailed", "accountId", account.Id, "err", err)
-					return "", err
+					return nil, err
 				}
 				// sync password from LDAP
 				token, err := security.GetLocalToken(srv.Mac)
 				if err != nil {
-					return "", err
+					return nil, err
 				}
 				if err = srv.changeAccountPassword(account.Id, token, "", pwd); err != nil {
 					slog.Warn("authenticate:syncPassword", "accountId", account.Id, "err", err)
-					return "", err
+					return nil, err
 				}
 			} else {
-				return "", err
+				return nil, errWrongCredentials
 			}
 		}
 	}
 
+	return &principal{id: account.Id, name: account.Name, email: account.Email, uuid: account.Uuid, roles: account.Roles}, nil
+}
+
+// issueSession mints the session token of a verified principal.
+// This is the sole session-JWT issuance point. bcrypt validation → GenerateToken.
+// Any path that mints session tokens outside this function violates the trust contract.
+func (srv *server) issueSession(p *principal, issuer string) (string, error) {
+	if p.root {
+		tokenString, err := security.GenerateToken(srv.SessionTimeout, issuer, "sa", "sa", p.email)
+		if err != nil {
+			return "", logInternal("authenticate:root:generate", err)
+		}
+
+		// prepare home folder and resource owner mapping for sa (domain-free)
+		path := "/users/sa"
+		Utility.CreateDirIfNotExist(dataPath + "/files" + path)
+		if rbacErr := srv.addResourceOwner(tokenString, path, "file", "sa", rbacpb.SubjectType_ACCOUNT); rbacErr != nil {
+			slog.Warn("authenticate:root:addResourceOwner failed (non-fatal)", "path", path, "err", rbacErr)
+		}
+
+		slog.Info("authenticate:root:ok")
+		return tokenString, nil
+	}
+
 	// create session + token
 	session := new(resourcepb.Session)
-	sid := normalizeAccountId(account.Id)
+	sid := normalizeAccountId(p.id)
 	session.AccountId = sid
 
 	// Carry the account's opaque membership identity (Account.uuid) in the token —
 	// additive; authorization still uses account.Id until RBAC/storage dual-read.
-	tokenString, err := security.GenerateTokenWithAccountUUID(srv.SessionTimeout, issuer, account.Id, account.Name, account.Email, account.Uuid)
+	tokenString, err := security.GenerateTokenWithAccountUUID(srv.SessionTimeout, issuer, p.id, p.name, p.email, p.uuid)
 	if err != nil {
 		return "", logInternal("authenticate:generate", err)
 	}
@@ -526,31 +589,52 @@ func getAuthenticationClient(address string) (*authentication_client.Authenticat
 	return client.(*authentication_client.Authentication_Client), nil
 }
 
-// Authenticate authenticates an account and returns a signed token.
+// Authenticate authenticates an account and returns a signed token, or the
+// mfa_pending token of VerifyMfa. Refused credentials count towards the
+// lockout of the account and of the caller's address (lockout.go).
 func (srv *server) Authenticate(ctx context.Context, rqst *authenticationpb.AuthenticateRqst) (*authenticationpb.AuthenticateRsp, error) {
+	accountId := normalizeAccountId(rqst.Name)
+	ip := clientIP(ctx)
+	if err := srv.checkLockout(accountId, ip); err != nil {
+		return nil, err
+	}
+
+	rsp, err := srv.authenticateRqst(rqst)
+	if err != nil {
+		// A backend that failed says nothing about the password.
+		if status.Code(err) == codes.Unauthenticated {
+			srv.recordFailure(accountId, ip, "wrong_password")
+		}
+		return nil, err
+	}
+	// A correct password alone does not clear the counter of an account that
+	// still has to pass its second factor.
+	if rsp.Token != "" {
+		srv.clearFailures(accountId)
+	}
+	return rsp, nil
+}
+
+func (srv *server) authenticateRqst(rqst *authenticationpb.AuthenticateRqst) (*authenticationpb.AuthenticateRsp, error) {
 	var (
-		tokenString string
-		err         error
+		rsp *authenticationpb.AuthenticateRsp
+		err error
 	)
 
 	// Normalize first so that "sa@domain" inputs are treated identically to "sa".
 	rqst.Name = normalizeAccountId(rqst.Name)
 
 	if rqst.Name == "sa" {
-		tokenString, err = srv.authenticate(rqst.Name, rqst.Password, srv.Mac)
-		if err != nil {
-			return nil, err
-		}
-		return &authenticationpb.AuthenticateRsp{Token: tokenString}, nil
+		return srv.login(rqst.Name, rqst.Password, srv.Mac)
 	}
 
 	if len(rqst.Issuer) == 0 {
 		rqst.Issuer = srv.Mac
 	}
 	if rqst.Issuer == srv.Mac {
-		tokenString, err = srv.authenticate(rqst.Name, rqst.Password, rqst.Issuer)
+		rsp, err = srv.login(rqst.Name, rqst.Password, rqst.Issuer)
 		if err == nil {
-			return &authenticationpb.AuthenticateRsp{Token: tokenString}, nil
+			return rsp, nil
 		}
 	}
 
@@ -589,9 +673,9 @@ func (srv *server) Authenticate(ctx context.Context, rqst *authenticationpb.Auth
 						authClient, err := getAuthenticationClient(address)
 						if err == nil {
 							defer authClient.Close()
-							tokenString, err := authClient.Authenticate(account.Id, rqst.Password)
+							rsp, err := authClient.Login(account.Id, rqst.Password)
 							if err == nil {
-								return &authenticationpb.AuthenticateRsp{Token: tokenString}, nil
+								return rsp, nil
 							}
 						}
 					}
@@ -599,10 +683,13 @@ func (srv *server) Authenticate(ctx context.Context, rqst *authenticationpb.Auth
 			}
 		}
 
+		if status.Code(err) == codes.Unauthenticated {
+			return nil, err
+		}
 		return nil, logInternal("Authenticate:failed", errors.New("failed to authenticate user "+rqst.Name+" from "+rqst.Issuer))
 	}
 
-	return &authenticationpb.AuthenticateRsp{Token: tokenString}, nil
+	return &authenticationpb.AuthenticateRsp{}, nil
 }
 
 // GeneratePeerToken generates a token for a peer identified by MAC, issued for the caller.
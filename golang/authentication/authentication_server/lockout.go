@@ -0,0 +1,441 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_authentication
+// @awareness file_role=sign_in_brute_force_lockout
+// @awareness risk=high
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"net"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"github.com/globulario/services/golang/config"
+	globular "github.com/globulario/services/golang/globular_service"
+	"github.com/globulario/services/golang/security"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// ---- Sign-in lockout --------------------------------------------------------
+//
+// Failed sign-ins are counted per account and per client address in etcd, so
+// every authentication instance sees the same counters. Once an account
+// reaches LockoutThreshold failures within LockoutWindow (an address
+// LockoutIpThreshold), it is locked for LockoutDuration, doubled at every
+// further failure up to LockoutMaxDuration. A locked account is refused before
+// its password is checked; a session issued to it clears its counter.
+//
+// Lockouts are temporary on purpose: anyone can lock an account by guessing
+// at it, so the lock only slows guessing down and an admin can lift it with
+// UnlockAccount.
+
+// etcd key schema:
+//
+//	/globular/auth/lockout/accounts/{account_id}  lockoutRecord (JSON), leased
+//	/globular/auth/lockout/ips/{ip}               lockoutRecord (JSON), leased
+//
+// The keys are named after whatever account or address a caller submits, so
+// each write carries a lease of LockoutWindow or LockoutMaxDuration, whichever
+// is longer, renewed on every failure: past it the record would be reset
+// anyway.
+const (
+	etcdLockoutPrefix        = "/globular/auth/lockout/"
+	etcdLockoutAccountPrefix = etcdLockoutPrefix + "accounts/"
+	etcdLockoutIpPrefix      = etcdLockoutPrefix + "ips/"
+
+	etcdLockoutTimeout    = 3 * time.Second
+	lockoutUpdateAttempts = 8
+
+	defaultLockoutThreshold   = 5
+	defaultLockoutIpThreshold = 20
+	defaultLockoutWindow      = 15 // minutes
+	defaultLockoutDuration    = 1  // minutes, first lock
+	defaultLockoutMaxDuration = 60 // minutes
+)
+
+// lockoutRecord counts the recent failed sign-ins of an account or address.
+type lockoutRecord struct {
+	Failures    int   `json:"failures"`
+	LastFailure int64 `json:"last_failure"`
+	LockedUntil int64 `json:"locked_until,omitempty"`
+}
+
+// lockoutStore persists lockout records under their etcd keys.
+type lockoutStore interface {
+	get(key string) (*lockoutRecord, error)
+	// update applies fn to the record at key (a zero record if absent) and
+	// writes it back atomically, to expire ttl after this write.
+	update(key string, ttl time.Duration, fn func(*lockoutRecord)) (*lockoutRecord, error)
+	delete(key string) error
+	list() (map[string]*lockoutRecord, error)
+}
+
+// etcdLockoutStore keeps lockout records in etcd.
+type etcdLockoutStore struct{}
+
+func (etcdLockoutStore) get(key string) (*lockoutRecord, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdLockoutTimeout)
+	defer cancel()
+	res, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("etcd get %s: %w", key, err)
+	}
+	rec := &lockoutRecord{}
+	if len(res.Kvs) > 0 {
+		if err := json.Unmarshal(res.Kvs[0].Value, rec); err != nil {
+			return nil, fmt.Errorf("unmarshal lockout record: %w", err)
+		}
+	}
+	return rec, nil
+}
+
+// update is a compare-and-swap loop on the key's mod revision, so that two
+// instances counting failures at the same time both get counted. The key's
+// lease is renewed, or granted when it has none or it already ran out.
+func (etcdLockoutStore) update(key string, ttl time.Duration, fn func(*lockoutRecord)) (*lockoutRecord, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	for attempt := 0; attempt < lockoutUpdateAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), etcdLockoutTimeout)
+		res, err := c.Get(ctx, key)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("etcd get %s: %w", key, err)
+		}
+		rec := &lockoutRecord{}
+		var rev int64
+		var lease clientv3.LeaseID
+		if len(res.Kvs) > 0 {
+			rev, lease = res.Kvs[0].ModRevision, clientv3.LeaseID(res.Kvs[0].Lease)
+			if err := json.Unmarshal(res.Kvs[0].Value, rec); err != nil {
+				slog.Warn("lockout: discarding unreadable record", "key", key, "err", err)
+				rec = &lockoutRecord{}
+			}
+		}
+		fn(rec)
+		data, err := json.Marshal(rec)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("marshal lockout record: %w", err)
+		}
+		if lease != 0 {
+			if _, err := c.KeepAliveOnce(ctx, lease); err != nil {
+				lease = 0
+			}
+		}
+		if lease == 0 {
+			granted, err := c.Grant(ctx, int64(ttl/time.Second)+1)
+			if err != nil {
+				cancel()
+				return nil, fmt.Errorf("etcd grant: %w", err)
+			}
+			lease = granted.ID
+		}
+		txn, err := c.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", rev)).
+			Then(clientv3.OpPut(key, string(data), clientv3.WithLease(lease))).
+			Commit()
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("etcd txn: %w", err)
+		}
+		if txn.Succeeded {
+			return rec, nil
+		}
+	}
+	return nil, fmt.Errorf("lockout %s: too many concurrent updates", key)
+}
+
+func (etcdLockoutStore) delete(key string) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdLockoutTimeout)
+	defer cancel()
+	_, err = c.Delete(ctx, key)
+	return err
+}
+
+func (etcdLockoutStore) list() (map[string]*lockoutRecord, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdLockoutTimeout)
+	defer cancel()
+	res, err := c.Get(ctx, etcdLockoutPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd get %s: %w", etcdLockoutPrefix, err)
+	}
+	out := make(map[string]*lockoutRecord, len(res.Kvs))
+	for _, kv := range res.Kvs {
+		rec := &lockoutRecord{}
+		if err := json.Unmarshal(kv.Value, rec); err != nil {
+			continue
+		}
+		out[string(kv.Key)] = rec
+	}
+	return out, nil
+}
+
+func (srv *server) lockoutStore() lockoutStore {
+	if srv.lockouts == nil {
+		srv.lockouts = etcdLockoutStore{}
+	}
+	return srv.lockouts
+}
+
+// lockoutPolicy is the effective configuration, with defaults applied.
+type lockoutPolicy struct {
+	threshold, ipThreshold int
+	window, base, max      time.Duration
+}
+
+func (srv *server) lockoutPolicy() lockoutPolicy {
+	orDefault := func(v, def int) int {
+		if v == 0 {
+			return def
+		}
+		return v
+	}
+	p := lockoutPolicy{
+		threshold:   orDefault(srv.LockoutThreshold, defaultLockoutThreshold),
+		ipThreshold: orDefault(srv.LockoutIpThreshold, defaultLockoutIpThreshold),
+		window:      time.Duration(orDefault(srv.LockoutWindow, defaultLockoutWindow)) * time.Minute,
+		base:        time.Duration(orDefault(srv.LockoutDuration, defaultLockoutDuration)) * time.Minute,
+		max:         time.Duration(orDefault(srv.LockoutMaxDuration, defaultLockoutMaxDuration)) * time.Minute,
+	}
+	if p.max < p.base {
+		p.max = p.base
+	}
+	return p
+}
+
+// retention is how long a record stays useful after its last failure: until
+// both its lock and the window after which its count resets are over.
+func (p lockoutPolicy) retention() time.Duration {
+	return max(p.window, p.max)
+}
+
+// fail counts one failure at now and locks the record once threshold is
+// reached: base for the first lock, doubling with every further failure.
+// A negative threshold disables locking.
+func (rec *lockoutRecord) fail(now time.Time, threshold int, p lockoutPolicy) {
+	if rec.LockedUntil <= now.Unix() && now.Sub(time.Unix(rec.LastFailure, 0)) > p.window {
+		rec.Failures = 0
+	}
+	rec.Failures++
+	rec.LastFailure = now.Unix()
+	if threshold <= 0 || rec.Failures < threshold {
+		return
+	}
+	d := p.base
+	for i := threshold; i < rec.Failures && d < p.max; i++ {
+		d *= 2
+	}
+	d = min(d, p.max)
+	rec.LockedUntil = now.Add(d).Unix()
+}
+
+func (rec *lockoutRecord) locked(now time.Time) bool {
+	return rec.LockedUntil > now.Unix()
+}
+
+// errLocked is the status of a refused sign-in. It is the same whether the
+// account exists or not.
+func errLocked(until int64) error {
+	wait := max(time.Until(time.Unix(until, 0)).Round(time.Second), time.Second)
+	return status.Errorf(codes.ResourceExhausted, "too many failed sign-in attempts; try again in %s", wait)
+}
+
+// clientIP returns the address of the caller. Behind the local gateway the
+// peer is a loopback address and the client is the last hop the gateway
+// appended to x-forwarded-for; loopback callers themselves are not tracked.
+func clientIP(ctx context.Context) string {
+	p, ok := peer.FromContext(ctx)
+	if !ok || p.Addr == nil || p.Addr.Network() == "unix" {
+		return ""
+	}
+	host, _, err := net.SplitHostPort(p.Addr.String())
+	if err != nil {
+		host = p.Addr.String()
+	}
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return ""
+	}
+	if ip.IsLoopback() {
+		md, _ := metadata.FromIncomingContext(ctx)
+		fwd := md.Get("x-forwarded-for")
+		if len(fwd) == 0 {
+			return ""
+		}
+		hops := strings.Split(fwd[len(fwd)-1], ",")
+		ip = net.ParseIP(strings.TrimSpace(hops[len(hops)-1]))
+		if ip == nil || ip.IsLoopback() {
+			return ""
+		}
+	}
+	return ip.String()
+}
+
+func lockoutKeys(accountId, ip string) []string {
+	var keys []string
+	if accountId != "" {
+		keys = append(keys, etcdLockoutAccountPrefix+accountId)
+	}
+	if ip != "" {
+		keys = append(keys, etcdLockoutIpPrefix+ip)
+	}
+	return keys
+}
+
+// checkLockout refuses a sign-in while its account or address is locked.
+// An unreachable store does not block sign-ins: the credentials are checked
+// against the same etcd anyway.
+func (srv *server) checkLockout(accountId, ip string) error {
+	now := time.Now()
+	for _, key := range lockoutKeys(accountId, ip) {
+		rec, err := srv.lockoutStore().get(key)
+		if err != nil {
+			slog.Warn("lockout:check", "key", key, "err", err)
+			continue
+		}
+		if rec.locked(now) {
+			slog.Info("lockout:refused", "accountId", accountId, "ip", ip, "until", rec.LockedUntil)
+			return errLocked(rec.LockedUntil)
+		}
+	}
+	return nil
+}
+
+// recordFailure counts a failed sign-in (a wrong password or second factor)
+// against the account and the address.
+func (srv *server) recordFailure(accountId, ip, reason string) {
+	p := srv.lockoutPolicy()
+	now := time.Now()
+	for _, key := range lockoutKeys(accountId, ip) {
+		threshold := p.threshold
+		if strings.HasPrefix(key, etcdLockoutIpPrefix) {
+			threshold = p.ipThreshold
+		}
+		wasLocked := false
+		rec, err := srv.lockoutStore().update(key, p.retention(), func(rec *lockoutRecord) {
+			wasLocked = rec.locked(now)
+			rec.fail(now, threshold, p)
+		})
+		if err != nil {
+			slog.Warn("lockout:record", "key", key, "err", err)
+			continue
+		}
+		if rec.locked(now) && !wasLocked {
+			slog.Warn("lockout:locked", "key", key, "failures", rec.Failures, "until", rec.LockedUntil)
+			globular.PublishEvent("alert.auth.locked", map[string]interface{}{
+				"severity":     "WARNING",
+				"account":      accountId,
+				"ip":           ip,
+				"subject":      strings.TrimPrefix(strings.TrimPrefix(key, etcdLockoutAccountPrefix), etcdLockoutIpPrefix),
+				"failures":     rec.Failures,
+				"locked_until": rec.LockedUntil,
+				"reason":       reason,
+				"service":      "authentication",
+			})
+		}
+	}
+}
+
+// clearFailures resets the account counter once it has been issued a session.
+// The address counter is left to expire: one good password from an address
+// says nothing about its other attempts.
+func (srv *server) clearFailures(accountId string) {
+	if accountId == "" {
+		return
+	}
+	if err := srv.lockoutStore().delete(etcdLockoutAccountPrefix + accountId); err != nil {
+		slog.Warn("lockout:clear", "accountId", accountId, "err", err)
+	}
+}
+
+// UnlockAccount lifts the lockout of an account and/or an address.
+func (srv *server) UnlockAccount(ctx context.Context, rqst *authenticationpb.UnlockAccountRequest) (*authenticationpb.UnlockAccountResponse, error) {
+	accountId := normalizeAccountId(rqst.AccountId)
+	ip := strings.TrimSpace(rqst.Ip)
+	if ip != "" {
+		parsed := net.ParseIP(ip)
+		if parsed == nil {
+			return nil, status.Errorf(codes.InvalidArgument, "invalid ip %q", rqst.Ip)
+		}
+		ip = parsed.String()
+	}
+	keys := lockoutKeys(accountId, ip)
+	if len(keys) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "account_id or ip is required")
+	}
+	clientId, _, _ := security.GetClientId(ctx)
+
+	for _, key := range keys {
+		if err := srv.lockoutStore().delete(key); err != nil {
+			return nil, logInternal("UnlockAccount:delete", err, "key", key)
+		}
+	}
+	slog.Info("UnlockAccount:ok", "accountId", accountId, "ip", ip, "by", clientId)
+	globular.PublishEvent("alert.auth.unlocked", map[string]interface{}{
+		"severity": "INFO",
+		"account":  accountId,
+		"ip":       ip,
+		"by":       normalizeAccountId(clientId),
+		"service":  "authentication",
+	})
+	return &authenticationpb.UnlockAccountResponse{}, nil
+}
+
+// ListLockedAccounts returns the locked accounts and addresses, most recent
+// failure first.
+func (srv *server) ListLockedAccounts(ctx context.Context, rqst *authenticationpb.ListLockedAccountsRequest) (*authenticationpb.ListLockedAccountsResponse, error) {
+	recs, err := srv.lockoutStore().list()
+	if err != nil {
+		return nil, logInternal("ListLockedAccounts:list", err)
+	}
+	now := time.Now()
+	window := srv.lockoutPolicy().window
+	rsp := &authenticationpb.ListLockedAccountsResponse{}
+	for key, rec := range recs {
+		locked := rec.locked(now)
+		if !locked && (!rqst.IncludeFailing || now.Sub(time.Unix(rec.LastFailure, 0)) > window) {
+			continue
+		}
+		l := &authenticationpb.Lockout{Failures: int32(rec.Failures), LastFailure: rec.LastFailure}
+		if locked {
+			l.LockedUntil = rec.LockedUntil
+		}
+		switch {
+		case strings.HasPrefix(key, etcdLockoutAccountPrefix):
+			l.AccountId = strings.TrimPrefix(key, etcdLockoutAccountPrefix)
+		case strings.HasPrefix(key, etcdLockoutIpPrefix):
+			l.Ip = strings.TrimPrefix(key, etcdLockoutIpPrefix)
+		default:
+			continue
+		}
+		rsp.Lockouts = append(rsp.Lockouts, l)
+	}
+	sort.Slice(rsp.Lockouts, func(i, j int) bool {
+		return rsp.Lockouts[i].LastFailure > rsp.Lockouts[j].LastFailure
+	})
+	return rsp, nil
+}
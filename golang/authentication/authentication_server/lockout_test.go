@@ -0,0 +1,187 @@
+package main
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// memLockoutStore is an in-memory lockoutStore.
+type memLockoutStore map[string]*lockoutRecord
+
+func (m memLockoutStore) get(key string) (*lockoutRecord, error) {
+	if r, ok := m[key]; ok {
+		c := *r
+		return &c, nil
+	}
+	return &lockoutRecord{}, nil
+}
+
+func (m memLockoutStore) update(key string, _ time.Duration, fn func(*lockoutRecord)) (*lockoutRecord, error) {
+	r, _ := m.get(key)
+	fn(r)
+	m[key] = r
+	c := *r
+	return &c, nil
+}
+
+func (m memLockoutStore) delete(key string) error { delete(m, key); return nil }
+
+func (m memLockoutStore) list() (map[string]*lockoutRecord, error) { return m, nil }
+
+func TestLockoutBackoff(t *testing.T) {
+	p := lockoutPolicy{threshold: 3, window: 15 * time.Minute, base: time.Minute, max: 10 * time.Minute}
+	now := time.Unix(1_000_000, 0)
+	rec := &lockoutRecord{}
+
+	for i := 1; i < 3; i++ {
+		rec.fail(now, p.threshold, p)
+		if rec.locked(now) {
+			t.Fatalf("locked after %d failures", i)
+		}
+	}
+	// Locks double with every failure past the threshold, up to max.
+	for _, want := range []time.Duration{time.Minute, 2 * time.Minute, 4 * time.Minute, 8 * time.Minute, 10 * time.Minute, 10 * time.Minute} {
+		rec.fail(now, p.threshold, p)
+		if got := time.Unix(rec.LockedUntil, 0).Sub(now); got != want {
+			t.Errorf("after %d failures: locked for %s, want %s", rec.Failures, got, want)
+		}
+	}
+
+	// Failures are forgotten once the lock and the window have passed.
+	later := now.Add(p.max + p.window + time.Second)
+	rec.fail(later, p.threshold, p)
+	if rec.Failures != 1 || rec.locked(later) {
+		t.Errorf("after the window: %+v", rec)
+	}
+
+	// A disabled threshold never locks.
+	rec = &lockoutRecord{}
+	for range 10 {
+		rec.fail(now, -1, p)
+	}
+	if rec.locked(now) {
+		t.Error("locked with locking disabled")
+	}
+}
+
+// A record left alone for retention() is reset by its next failure, so its
+// lease can drop it by then.
+func TestLockoutRetention(t *testing.T) {
+	for _, p := range []lockoutPolicy{
+		{threshold: 3, window: 15 * time.Minute, base: time.Minute, max: 60 * time.Minute},
+		{threshold: 3, window: 60 * time.Minute, base: time.Minute, max: 10 * time.Minute},
+	} {
+		now := time.Unix(1_000_000, 0)
+		rec := &lockoutRecord{}
+		for range 10 {
+			rec.fail(now, p.threshold, p)
+		}
+		after := now.Add(p.retention() + time.Second)
+		if rec.locked(after) {
+			t.Errorf("%+v: still locked after retention", p)
+		}
+		if rec.fail(after, p.threshold, p); rec.Failures != 1 {
+			t.Errorf("%+v: %d failures after retention, want 1", p, rec.Failures)
+		}
+	}
+}
+
+func TestLockoutAccountAndAddress(t *testing.T) {
+	store := memLockoutStore{}
+	srv := &server{lockouts: store, LockoutThreshold: 3, LockoutIpThreshold: 5}
+
+	for range 3 {
+		if err := srv.checkLockout("alice", "203.0.113.7"); err != nil {
+			t.Fatalf("refused before the threshold: %v", err)
+		}
+		srv.recordFailure("alice", "203.0.113.7", "wrong_password")
+	}
+	err := srv.checkLockout("alice", "198.51.100.1")
+	if status.Code(err) != codes.ResourceExhausted {
+		t.Fatalf("locked account: err = %v", err)
+	}
+	// The address has not reached its own threshold.
+	if err := srv.checkLockout("bob", "203.0.113.7"); err != nil {
+		t.Fatalf("address locked early: %v", err)
+	}
+	srv.recordFailure("bob", "203.0.113.7", "wrong_password")
+	srv.recordFailure("carol", "203.0.113.7", "wrong_password")
+	if status.Code(srv.checkLockout("dave", "203.0.113.7")) != codes.ResourceExhausted {
+		t.Fatal("address not locked after its threshold")
+	}
+
+	rsp, err := srv.ListLockedAccounts(context.Background(), &authenticationpb.ListLockedAccountsRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var accounts, ips []string
+	for _, l := range rsp.Lockouts {
+		if l.LockedUntil == 0 {
+			t.Errorf("unlocked entry listed: %v", l)
+		}
+		if l.AccountId != "" {
+			accounts = append(accounts, l.AccountId)
+		} else {
+			ips = append(ips, l.Ip)
+		}
+	}
+	if len(accounts) != 1 || accounts[0] != "alice" || len(ips) != 1 || ips[0] != "203.0.113.7" {
+		t.Errorf("locked accounts %v, ips %v", accounts, ips)
+	}
+	rsp, _ = srv.ListLockedAccounts(context.Background(), &authenticationpb.ListLockedAccountsRequest{IncludeFailing: true})
+	if len(rsp.Lockouts) != 4 {
+		t.Errorf("with failing: %d entries, want 4", len(rsp.Lockouts))
+	}
+
+	if _, err := srv.UnlockAccount(context.Background(), &authenticationpb.UnlockAccountRequest{AccountId: "alice", Ip: "203.0.113.7"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.checkLockout("alice", "203.0.113.7"); err != nil {
+		t.Errorf("still locked after UnlockAccount: %v", err)
+	}
+	if _, err := srv.UnlockAccount(context.Background(), &authenticationpb.UnlockAccountRequest{Ip: "not-an-ip"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad ip: err = %v", err)
+	}
+
+	// A session clears the account counter.
+	srv.recordFailure("erin", "", "wrong_password")
+	srv.clearFailures("erin")
+	if _, ok := store[etcdLockoutAccountPrefix+"erin"]; ok {
+		t.Error("counter kept after a session was issued")
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	ctxFrom := func(addr string, fwd ...string) context.Context {
+		tcp, _ := net.ResolveTCPAddr("tcp", addr)
+		ctx := peer.NewContext(context.Background(), &peer.Peer{Addr: tcp})
+		if len(fwd) > 0 {
+			ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", fwd[0]))
+		}
+		return ctx
+	}
+	cases := []struct {
+		ctx  context.Context
+		want string
+	}{
+		{context.Background(), ""},
+		{ctxFrom("203.0.113.7:5555"), "203.0.113.7"},
+		{ctxFrom("203.0.113.7:5555", "198.51.100.1"), "203.0.113.7"}, // remote peers can't choose their address
+		{ctxFrom("127.0.0.1:5555"), ""},
+		{ctxFrom("127.0.0.1:5555", "10.0.0.1, 198.51.100.1"), "198.51.100.1"},
+		{ctxFrom("[::1]:5555", "garbage"), ""},
+	}
+	for i, c := range cases {
+		if got := clientIP(c.ctx); got != c.want {
+			t.Errorf("case %d: clientIP = %q, want %q", i, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,870 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_authentication
+// @awareness file_role=multi_factor_enrollment_and_second_step_of_login
+// @awareness implements=globular.platform:intent.authentication.single_issuance_point_for_session_tokens
+// @awareness risk=critical
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"github.com/globulario/services/golang/config"
+	globular "github.com/globulario/services/golang/globular_service"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// ---- Multi-factor authentication --------------------------------------------
+//
+// Login is two steps for accounts that need a second factor: Authenticate
+// checks the password and returns a short-lived mfa_pending token instead of
+// a session, and VerifyMfa exchanges it with a TOTP code, a recovery code or
+// a WebAuthn assertion for the session token. Enrollments live in etcd so
+// every authentication instance sees them; TOTP secrets are sealed with the
+// cluster data key (security.SealSecret), recovery codes are bcrypt hashes
+// and passkeys only hold public keys.
+
+// etcd key schema:
+//
+//	/globular/auth/mfa/accounts/{account_id}  mfaRecord (JSON)
+//	/globular/auth/mfa/pending/{jti}          pendingState (JSON), leased until the token expires
+//	/globular/auth/mfa/policy                 MfaPolicy (protojson)
+const (
+	etcdMfaAccountPrefix = "/globular/auth/mfa/accounts/"
+	etcdMfaPendingPrefix = "/globular/auth/mfa/pending/"
+	etcdMfaPolicyKey     = "/globular/auth/mfa/policy"
+	etcdMfaTimeout       = 3 * time.Second
+	mfaUpdateAttempts    = 8
+
+	// mfaSecretPurpose separates the key sealing TOTP secrets from other uses.
+	mfaSecretPurpose = "authentication/totp"
+
+	defaultMfaTimeout    = 5 // minutes an mfa_pending token lives
+	mfaMaxAttempts       = 5 // second factors tried with one mfa_pending token
+	webAuthnChallengeTTL = 5 * time.Minute
+)
+
+// mfaRecord is the second-factor enrollment of one account.
+type mfaRecord struct {
+	AccountId        string                `json:"account_id"`
+	TotpSecret       string                `json:"totp_secret,omitempty"`  // sealed
+	TotpPending      string                `json:"totp_pending,omitempty"` // sealed, until ConfirmTotp
+	TotpLastStep     int64                 `json:"totp_last_step,omitempty"`
+	RecoveryCodes    []string              `json:"recovery_codes,omitempty"` // bcrypt hashes
+	WebAuthn         []*webAuthnCredential `json:"webauthn,omitempty"`
+	Challenge        string                `json:"challenge,omitempty"` // pending passkey registration
+	ChallengeExpires int64                 `json:"challenge_expires,omitempty"`
+}
+
+// enrolled reports whether the account has a usable second factor.
+func (r *mfaRecord) enrolled() bool {
+	return r.TotpSecret != "" || len(r.WebAuthn) > 0
+}
+
+func (r *mfaRecord) methods() []string {
+	var m []string
+	if r.TotpSecret != "" {
+		m = append(m, "totp")
+	}
+	if len(r.WebAuthn) > 0 {
+		m = append(m, "webauthn")
+	}
+	if len(r.RecoveryCodes) > 0 {
+		m = append(m, "recovery")
+	}
+	return m
+}
+
+// pendingState counts the second factors tried with one mfa_pending token.
+type pendingState struct {
+	Attempts int  `json:"attempts"`
+	Used     bool `json:"used,omitempty"`
+}
+
+// mfaStore persists enrollments, the attempts of mfa_pending tokens and the
+// policy.
+type mfaStore interface {
+	// getRecord returns an empty record for an account without enrollment.
+	getRecord(accountId string) (*mfaRecord, error)
+	// updateRecord applies fn to the record of an account and writes it back
+	// atomically. When fn fails nothing is written and its error is returned
+	// as is.
+	updateRecord(accountId string, fn func(*mfaRecord) error) (*mfaRecord, error)
+	deleteRecord(accountId string) error
+	// updatePending does the same for the state of the mfa_pending token jti,
+	// which is forgotten once the token expires.
+	updatePending(jti string, expires time.Time, fn func(*pendingState) error) (*pendingState, error)
+	getPolicy() (*authenticationpb.MfaPolicy, error)
+	putPolicy(p *authenticationpb.MfaPolicy) error
+}
+
+// etcdMfaStore keeps enrollments and the policy in etcd.
+type etcdMfaStore struct{}
+
+func (etcdMfaStore) get(key string) ([]byte, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdMfaTimeout)
+	defer cancel()
+	res, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("etcd get %s: %w", key, err)
+	}
+	if len(res.Kvs) == 0 {
+		return nil, nil
+	}
+	return res.Kvs[0].Value, nil
+}
+
+func (etcdMfaStore) put(key string, data []byte) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdMfaTimeout)
+	defer cancel()
+	_, err = c.Put(ctx, key, string(data))
+	return err
+}
+
+func (s etcdMfaStore) getRecord(accountId string) (*mfaRecord, error) {
+	data, err := s.get(etcdMfaAccountPrefix + accountId)
+	if err != nil {
+		return nil, err
+	}
+	rec := &mfaRecord{AccountId: accountId}
+	if data != nil {
+		if err := json.Unmarshal(data, rec); err != nil {
+			return nil, fmt.Errorf("unmarshal mfa record: %w", err)
+		}
+	}
+	return rec, nil
+}
+
+// update is a compare-and-swap loop on the key's mod revision, as in
+// etcdLockoutStore.update, so that two instances changing the same record
+// (say, verifying the same TOTP code) cannot both succeed on a stale read.
+// fn gets the current value, nil if absent, and returns the new one. A key
+// created with a non-zero expires is attached to a lease that ends then.
+func (etcdMfaStore) update(key string, expires time.Time, fn func([]byte) ([]byte, error)) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	for attempt := 0; attempt < mfaUpdateAttempts; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), etcdMfaTimeout)
+		res, err := c.Get(ctx, key)
+		if err != nil {
+			cancel()
+			return fmt.Errorf("etcd get %s: %w", key, err)
+		}
+		var current []byte
+		var rev int64
+		if len(res.Kvs) > 0 {
+			current, rev = res.Kvs[0].Value, res.Kvs[0].ModRevision
+		}
+		data, err := fn(current)
+		if err != nil {
+			cancel()
+			return err
+		}
+		var opts []clientv3.OpOption
+		if !expires.IsZero() {
+			if rev == 0 {
+				lease, err := c.Grant(ctx, int64(time.Until(expires)/time.Second)+1)
+				if err != nil {
+					cancel()
+					return fmt.Errorf("etcd grant: %w", err)
+				}
+				opts = append(opts, clientv3.WithLease(lease.ID))
+			} else {
+				opts = append(opts, clientv3.WithIgnoreLease())
+			}
+		}
+		txn, err := c.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", rev)).
+			Then(clientv3.OpPut(key, string(data), opts...)).
+			Commit()
+		cancel()
+		if err != nil {
+			return fmt.Errorf("etcd txn: %w", err)
+		}
+		if txn.Succeeded {
+			return nil
+		}
+	}
+	return fmt.Errorf("mfa %s: too many concurrent updates", key)
+}
+
+func (s etcdMfaStore) updateRecord(accountId string, fn func(*mfaRecord) error) (*mfaRecord, error) {
+	var rec *mfaRecord
+	err := s.update(etcdMfaAccountPrefix+accountId, time.Time{}, func(data []byte) ([]byte, error) {
+		rec = &mfaRecord{AccountId: accountId}
+		if data != nil {
+			if err := json.Unmarshal(data, rec); err != nil {
+				return nil, fmt.Errorf("unmarshal mfa record: %w", err)
+			}
+		}
+		if err := fn(rec); err != nil {
+			return nil, err
+		}
+		return json.Marshal(rec)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
+
+func (s etcdMfaStore) updatePending(jti string, expires time.Time, fn func(*pendingState) error) (*pendingState, error) {
+	var st *pendingState
+	err := s.update(etcdMfaPendingPrefix+jti, expires, func(data []byte) ([]byte, error) {
+		st = &pendingState{}
+		if data != nil {
+			if err := json.Unmarshal(data, st); err != nil {
+				return nil, fmt.Errorf("unmarshal mfa pending state: %w", err)
+			}
+		}
+		if err := fn(st); err != nil {
+			return nil, err
+		}
+		return json.Marshal(st)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+func (etcdMfaStore) deleteRecord(accountId string) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdMfaTimeout)
+	defer cancel()
+	_, err = c.Delete(ctx, etcdMfaAccountPrefix+accountId)
+	return err
+}
+
+func (s etcdMfaStore) getPolicy() (*authenticationpb.MfaPolicy, error) {
+	data, err := s.get(etcdMfaPolicyKey)
+	if err != nil {
+		return nil, err
+	}
+	p := &authenticationpb.MfaPolicy{}
+	if data != nil {
+		if err := protojson.Unmarshal(data, p); err != nil {
+			return nil, fmt.Errorf("unmarshal mfa policy: %w", err)
+		}
+	}
+	return p, nil
+}
+
+func (s etcdMfaStore) putPolicy(p *authenticationpb.MfaPolicy) error {
+	data, err := protojson.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("marshal mfa policy: %w", err)
+	}
+	return s.put(etcdMfaPolicyKey, data)
+}
+
+func (srv *server) mfaStore() mfaStore {
+	if srv.mfa == nil {
+		srv.mfa = etcdMfaStore{}
+	}
+	return srv.mfa
+}
+
+// Refusals of an mfa_pending token: errMfaTokenSpent when it was used or
+// tried mfaMaxAttempts times, errMfaTokenUsed when another request got its
+// session first.
+var (
+	errMfaTokenSpent = status.Error(codes.Unauthenticated, "the mfa token is no longer usable; sign in again")
+	errMfaTokenUsed  = status.Error(codes.Unauthenticated, "the mfa token was already used")
+)
+
+// takeMfaAttempt counts a second factor tried with an mfa_pending token
+// before it is checked, so that concurrent guesses on several instances
+// share the mfaMaxAttempts limit.
+func (srv *server) takeMfaAttempt(claims *security.Claims) error {
+	_, err := srv.mfaStore().updatePending(claims.RegisteredClaims.ID, claims.ExpiresAt.Time, func(st *pendingState) error {
+		if st.Used || st.Attempts >= mfaMaxAttempts {
+			return errMfaTokenSpent
+		}
+		st.Attempts++
+		return nil
+	})
+	if err != nil && err != errMfaTokenSpent {
+		return logInternal("mfa:attempt", err, "jti", claims.RegisteredClaims.ID)
+	}
+	return err
+}
+
+// consumeMfaToken marks an mfa_pending token used; only one request gets
+// the session of a token.
+func (srv *server) consumeMfaToken(claims *security.Claims) error {
+	_, err := srv.mfaStore().updatePending(claims.RegisteredClaims.ID, claims.ExpiresAt.Time, func(st *pendingState) error {
+		if st.Used {
+			return errMfaTokenUsed
+		}
+		st.Used = true
+		return nil
+	})
+	if err != nil && err != errMfaTokenUsed {
+		return logInternal("mfa:consume", err, "jti", claims.RegisteredClaims.ID)
+	}
+	return err
+}
+
+// rpID is the WebAuthn relying party id and the TOTP issuer: the cluster domain.
+func (srv *server) rpID() string {
+	if srv.Domain != "" {
+		return srv.Domain
+	}
+	domain, _ := config.GetDomain()
+	return domain
+}
+
+// loginChallenge derives the WebAuthn challenge of a login from its
+// mfa_pending token, so no state is kept between the two steps.
+func loginChallenge(claims *security.Claims) []byte {
+	sum := sha256.Sum256([]byte("webauthn.get:" + claims.RegisteredClaims.ID))
+	return sum[:]
+}
+
+// mfaRequired applies the policy: accounts with a factor always need it;
+// others when they are listed, or hold a listed role.
+func (srv *server) mfaRequired(p *principal, rec *mfaRecord) (bool, error) {
+	if rec.enrolled() {
+		return true, nil
+	}
+	policy, err := srv.mfaStore().getPolicy()
+	if err != nil {
+		return false, err
+	}
+	for _, a := range policy.Accounts {
+		if normalizeAccountId(a) == p.id {
+			return true, nil
+		}
+	}
+	for _, want := range policy.Roles {
+		for _, r := range p.roles {
+			if normalizeAccountId(r) == normalizeAccountId(want) {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// challengeMfa returns the first-step response of a login that needs a
+// second factor, or nil when the session can be issued right away.
+func (srv *server) challengeMfa(p *principal, issuer string) (*authenticationpb.AuthenticateRsp, error) {
+	rec, err := srv.mfaStore().getRecord(p.id)
+	if err != nil {
+		return nil, logInternal("authenticate:mfa:record", err, "accountId", p.id)
+	}
+	required, err := srv.mfaRequired(p, rec)
+	if err != nil {
+		return nil, logInternal("authenticate:mfa:policy", err, "accountId", p.id)
+	}
+	if !required {
+		return nil, nil
+	}
+
+	timeout := srv.MfaTimeout
+	if timeout <= 0 {
+		timeout = defaultMfaTimeout
+	}
+	token, err := security.GenerateMFAPendingToken(timeout, issuer, p.id, p.name, p.email, p.uuid)
+	if err != nil {
+		return nil, logInternal("authenticate:mfa:generate", err)
+	}
+	rsp := &authenticationpb.AuthenticateRsp{
+		MfaRequired:           true,
+		MfaToken:              token,
+		MfaMethods:            rec.methods(),
+		MfaEnrollmentRequired: !rec.enrolled(),
+	}
+	if len(rec.WebAuthn) > 0 {
+		claims, err := security.ValidateMFAPendingToken(token)
+		if err != nil {
+			return nil, logInternal("authenticate:mfa:validate", err)
+		}
+		if rsp.WebauthnOptions, err = webAuthnRequestOptions(srv.rpID(), loginChallenge(claims), rec.WebAuthn); err != nil {
+			return nil, logInternal("authenticate:mfa:options", err)
+		}
+	}
+	slog.Info("authenticate:mfa:pending", "accountId", p.id, "methods", rsp.MfaMethods)
+	return rsp, nil
+}
+
+// pendingPrincipal rebuilds the principal and issuer of an mfa_pending token.
+func (srv *server) pendingPrincipal(claims *security.Claims) (*principal, string) {
+	p := &principal{
+		id:    normalizeAccountId(claims.ID),
+		name:  claims.Username,
+		email: claims.Email,
+		uuid:  claims.AccountUUID,
+	}
+	p.root = p.id == "sa"
+	issuer := srv.Mac
+	if len(claims.Audience) > 0 && claims.Audience[0] != "" {
+		issuer = claims.Audience[0]
+	}
+	return p, issuer
+}
+
+// mfaCaller resolves the account a self-service MFA request acts on. With an
+// mfa_token it is the token's account, and only while nothing is enrolled
+// yet: a password alone must not be enough to add a factor next to an
+// existing one. Otherwise the caller's session decides, and sa may act on
+// any account.
+func (srv *server) mfaCaller(ctx context.Context, accountId, mfaToken string) (string, *security.Claims, error) {
+	accountId = normalizeAccountId(accountId)
+	if mfaToken != "" {
+		claims, err := security.ValidateMFAPendingToken(mfaToken)
+		if err != nil {
+			return "", nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+		}
+		id := normalizeAccountId(claims.ID)
+		if accountId != "" && accountId != id {
+			return "", nil, status.Error(codes.PermissionDenied, "the mfa token belongs to another account")
+		}
+		rec, err := srv.mfaStore().getRecord(id)
+		if err != nil {
+			return "", nil, logInternal("mfa:record", err, "accountId", id)
+		}
+		if rec.enrolled() {
+			return "", nil, status.Error(codes.PermissionDenied, "a second factor is already enrolled; complete the login with it first")
+		}
+		return id, claims, nil
+	}
+
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil || clientId == "" {
+		return "", nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+	caller := normalizeAccountId(clientId)
+	if accountId == "" {
+		return caller, nil, nil
+	}
+	if accountId != caller && caller != "sa" {
+		return "", nil, status.Error(codes.PermissionDenied, "you can't manage another account's second factors")
+	}
+	return accountId, nil, nil
+}
+
+// finishEnrollment applies an enrollment to the record of an account, gives
+// it its first recovery codes and, when the enrollment was made from the
+// first step of a login, issues the session. change returns errors meant for
+// the caller as is.
+func (srv *server) finishEnrollment(accountId string, pending *security.Claims, change func(*mfaRecord) error) (recoveryCodes []string, token string, err error) {
+	var refused error
+	_, err = srv.mfaStore().updateRecord(accountId, func(rec *mfaRecord) error {
+		// mfaCaller checked this before; another request may have enrolled since.
+		if pending != nil && rec.enrolled() {
+			refused = status.Error(codes.PermissionDenied, "a second factor is already enrolled; complete the login with it first")
+			return refused
+		}
+		if refused = change(rec); refused != nil {
+			return refused
+		}
+		recoveryCodes = nil
+		if len(rec.RecoveryCodes) == 0 {
+			var err error
+			if recoveryCodes, rec.RecoveryCodes, err = newRecoveryCodes(); err != nil {
+				return fmt.Errorf("recovery codes: %w", err)
+			}
+		}
+		return nil
+	})
+	if refused != nil {
+		return nil, "", refused
+	}
+	if err != nil {
+		return nil, "", logInternal("mfa:save", err, "accountId", accountId)
+	}
+	if pending != nil {
+		if err := srv.consumeMfaToken(pending); err != nil {
+			return nil, "", err
+		}
+		p, issuer := srv.pendingPrincipal(pending)
+		if token, err = srv.issueSession(p, issuer); err != nil {
+			return nil, "", err
+		}
+	}
+	return recoveryCodes, token, nil
+}
+
+// Outcomes of the second factor check of VerifyMfa.
+var (
+	errMfaNotEnrolled = errors.New("no second factor enrolled")
+	errMfaRejected    = errors.New("second factor rejected")
+	errMfaNoTotp      = errors.New("totp secret cannot be opened")
+)
+
+// VerifyMfa completes a login with its second factor.
+func (srv *server) VerifyMfa(ctx context.Context, rqst *authenticationpb.VerifyMfaRequest) (*authenticationpb.VerifyMfaResponse, error) {
+	claims, err := security.ValidateMFAPendingToken(rqst.MfaToken)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "invalid or expired mfa token")
+	}
+	if rqst.WebauthnAssertion == "" && rqst.Code == "" {
+		return nil, status.Error(codes.InvalidArgument, "a code or a webauthn assertion is required")
+	}
+	p, issuer := srv.pendingPrincipal(claims)
+	ip := clientIP(ctx)
+	if err := srv.checkLockout(p.id, ip); err != nil {
+		return nil, err
+	}
+	if err := srv.takeMfaAttempt(claims); err != nil {
+		return nil, err
+	}
+
+	// The check runs inside the record update, so a TOTP step or a recovery
+	// code is spent by exactly one request across instances.
+	method := ""
+	var verifyErr error
+	_, err = srv.mfaStore().updateRecord(p.id, func(rec *mfaRecord) error {
+		if !rec.enrolled() {
+			return errMfaNotEnrolled
+		}
+		ok := false
+		switch {
+		case rqst.WebauthnAssertion != "":
+			method = "webauthn"
+			var cred *webAuthnCredential
+			if cred, verifyErr = verifyAssertion(srv.rpID(), loginChallenge(claims), rqst.WebauthnAssertion, rec.WebAuthn); verifyErr == nil {
+				cred.LastUsed = time.Now().Unix()
+				ok = true
+			}
+		case isTotpCode(rqst.Code) && rec.TotpSecret != "":
+			method = "totp"
+			secret, err := security.OpenSecret(mfaSecretPurpose, rec.TotpSecret)
+			if err != nil {
+				// The code cannot be checked; a recovery code still can.
+				slog.Warn("VerifyMfa:openSecret", "accountId", p.id, "err", err)
+				method = "recovery"
+				if rec.RecoveryCodes, ok = useRecoveryCode(rec.RecoveryCodes, rqst.Code); !ok {
+					return errMfaNoTotp
+				}
+				break
+			}
+			var step int64
+			if step, ok = verifyTotp(string(secret), rqst.Code, time.Now(), rec.TotpLastStep); ok {
+				rec.TotpLastStep = step
+			}
+		default:
+			method = "recovery"
+			rec.RecoveryCodes, ok = useRecoveryCode(rec.RecoveryCodes, rqst.Code)
+		}
+		if !ok {
+			return errMfaRejected
+		}
+		return nil
+	})
+	switch {
+	case err == errMfaNotEnrolled:
+		return nil, status.Error(codes.FailedPrecondition, "no second factor is enrolled; enroll one with the mfa token")
+	case err == errMfaNoTotp:
+		return nil, status.Error(codes.FailedPrecondition, "TOTP codes cannot be checked right now; use a recovery code or a passkey")
+	case err == errMfaRejected:
+		srv.recordFailure(p.id, ip, "wrong_second_factor")
+		slog.Info("VerifyMfa:failed", "accountId", p.id, "method", method, "err", verifyErr)
+		globular.PublishEvent("alert.auth.failed", map[string]interface{}{
+			"severity": "WARNING",
+			"account":  p.id,
+			"reason":   "wrong_second_factor",
+			"method":   method,
+			"service":  "authentication",
+		})
+		return nil, status.Error(codes.Unauthenticated, "the second factor was not accepted")
+	case err != nil:
+		return nil, logInternal("VerifyMfa:save", err, "accountId", p.id)
+	}
+	if err := srv.consumeMfaToken(claims); err != nil {
+		return nil, err
+	}
+
+	token, err := srv.issueSession(p, issuer)
+	if err != nil {
+		return nil, err
+	}
+	srv.clearFailures(p.id)
+	slog.Info("VerifyMfa:ok", "accountId", p.id, "method", method)
+	return &authenticationpb.VerifyMfaResponse{Token: token}, nil
+}
+
+// EnrollTotp creates a TOTP secret, pending until ConfirmTotp.
+func (srv *server) EnrollTotp(ctx context.Context, rqst *authenticationpb.EnrollTotpRequest) (*authenticationpb.EnrollTotpResponse, error) {
+	id, _, err := srv.mfaCaller(ctx, rqst.AccountId, rqst.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	secret, err := newTotpSecret()
+	if err != nil {
+		return nil, logInternal("EnrollTotp:secret", err)
+	}
+	sealed, err := security.SealSecret(mfaSecretPurpose, []byte(secret))
+	if err != nil {
+		return nil, logInternal("EnrollTotp:seal", err)
+	}
+	if _, err := srv.mfaStore().updateRecord(id, func(rec *mfaRecord) error {
+		rec.TotpPending = sealed
+		return nil
+	}); err != nil {
+		return nil, logInternal("EnrollTotp:save", err, "accountId", id)
+	}
+
+	slog.Info("EnrollTotp:ok", "accountId", id)
+	return &authenticationpb.EnrollTotpResponse{Secret: secret, Uri: totpURI(srv.rpID(), id, secret)}, nil
+}
+
+// ConfirmTotp activates the pending secret once a code generated from it checks out.
+func (srv *server) ConfirmTotp(ctx context.Context, rqst *authenticationpb.ConfirmTotpRequest) (*authenticationpb.ConfirmTotpResponse, error) {
+	id, pending, err := srv.mfaCaller(ctx, rqst.AccountId, rqst.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, token, err := srv.finishEnrollment(id, pending, func(rec *mfaRecord) error {
+		if rec.TotpPending == "" {
+			return status.Error(codes.FailedPrecondition, "no TOTP enrollment in progress; call EnrollTotp first")
+		}
+		secret, err := security.OpenSecret(mfaSecretPurpose, rec.TotpPending)
+		if err != nil {
+			return logInternal("ConfirmTotp:openSecret", err, "accountId", id)
+		}
+		step, ok := verifyTotp(string(secret), rqst.Code, time.Now(), 0)
+		if !ok {
+			return status.Error(codes.InvalidArgument, "the code does not match the new secret")
+		}
+		rec.TotpSecret, rec.TotpPending, rec.TotpLastStep = rec.TotpPending, "", step
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("ConfirmTotp:ok", "accountId", id)
+	return &authenticationpb.ConfirmTotpResponse{RecoveryCodes: recoveryCodes, Token: token}, nil
+}
+
+// RegenerateRecoveryCodes replaces the recovery codes of an enrolled account.
+func (srv *server) RegenerateRecoveryCodes(ctx context.Context, rqst *authenticationpb.RegenerateRecoveryCodesRequest) (*authenticationpb.RegenerateRecoveryCodesResponse, error) {
+	id, _, err := srv.mfaCaller(ctx, rqst.AccountId, "")
+	if err != nil {
+		return nil, err
+	}
+
+	recoveryCodes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		return nil, logInternal("RegenerateRecoveryCodes:generate", err)
+	}
+	_, err = srv.mfaStore().updateRecord(id, func(rec *mfaRecord) error {
+		if !rec.enrolled() {
+			return errMfaNotEnrolled
+		}
+		rec.RecoveryCodes = hashes
+		return nil
+	})
+	if err == errMfaNotEnrolled {
+		return nil, status.Error(codes.FailedPrecondition, "no second factor is enrolled")
+	}
+	if err != nil {
+		return nil, logInternal("RegenerateRecoveryCodes:save", err, "accountId", id)
+	}
+	slog.Info("RegenerateRecoveryCodes:ok", "accountId", id)
+	return &authenticationpb.RegenerateRecoveryCodesResponse{RecoveryCodes: recoveryCodes}, nil
+}
+
+// BeginWebAuthnRegistration returns the options to create a passkey.
+func (srv *server) BeginWebAuthnRegistration(ctx context.Context, rqst *authenticationpb.BeginWebAuthnRegistrationRequest) (*authenticationpb.BeginWebAuthnRegistrationResponse, error) {
+	id, pending, err := srv.mfaCaller(ctx, rqst.AccountId, rqst.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+	displayName := id
+	if pending != nil && pending.Username != "" {
+		displayName = pending.Username
+	}
+
+	challenge := make([]byte, 32)
+	if _, err := rand.Read(challenge); err != nil {
+		return nil, logInternal("BeginWebAuthnRegistration:challenge", err)
+	}
+	var options string
+	_, err = srv.mfaStore().updateRecord(id, func(rec *mfaRecord) error {
+		var err error
+		if options, err = webAuthnCreationOptions(srv.rpID(), id, displayName, challenge, rec.WebAuthn); err != nil {
+			return fmt.Errorf("options: %w", err)
+		}
+		rec.Challenge = b64url(challenge)
+		rec.ChallengeExpires = time.Now().Add(webAuthnChallengeTTL).Unix()
+		return nil
+	})
+	if err != nil {
+		return nil, logInternal("BeginWebAuthnRegistration:save", err, "accountId", id)
+	}
+	return &authenticationpb.BeginWebAuthnRegistrationResponse{Options: options}, nil
+}
+
+// FinishWebAuthnRegistration verifies the new credential against the
+// challenge of BeginWebAuthnRegistration and stores it.
+func (srv *server) FinishWebAuthnRegistration(ctx context.Context, rqst *authenticationpb.FinishWebAuthnRegistrationRequest) (*authenticationpb.FinishWebAuthnRegistrationResponse, error) {
+	id, pending, err := srv.mfaCaller(ctx, rqst.AccountId, rqst.MfaToken)
+	if err != nil {
+		return nil, err
+	}
+
+	// A challenge is good for one attempt: a credential that does not verify
+	// still clears it.
+	var cred *webAuthnCredential
+	var refused, invalid error
+	_, err = srv.mfaStore().updateRecord(id, func(rec *mfaRecord) error {
+		if rec.Challenge == "" || time.Now().Unix() > rec.ChallengeExpires {
+			refused = status.Error(codes.FailedPrecondition, "no passkey registration in progress; call BeginWebAuthnRegistration first")
+			return refused
+		}
+		challenge, _ := decodeB64url(rec.Challenge)
+		rec.Challenge, rec.ChallengeExpires = "", 0
+		cred, invalid = verifyRegistration(srv.rpID(), challenge, rqst.Credential)
+		return nil
+	})
+	if refused != nil {
+		return nil, refused
+	}
+	if err != nil {
+		return nil, logInternal("FinishWebAuthnRegistration:clearChallenge", err, "accountId", id)
+	}
+	if invalid != nil {
+		return nil, status.Error(codes.InvalidArgument, invalid.Error())
+	}
+	cred.Name = strings.TrimSpace(rqst.Name)
+	cred.Created = time.Now().Unix()
+
+	recoveryCodes, token, err := srv.finishEnrollment(id, pending, func(rec *mfaRecord) error {
+		for _, c := range rec.WebAuthn {
+			if c.ID == cred.ID {
+				return status.Error(codes.AlreadyExists, "this passkey is already registered")
+			}
+		}
+		rec.WebAuthn = append(rec.WebAuthn, cred)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	slog.Info("FinishWebAuthnRegistration:ok", "accountId", id, "credential", cred.ID)
+	return &authenticationpb.FinishWebAuthnRegistrationResponse{CredentialId: cred.ID, RecoveryCodes: recoveryCodes, Token: token}, nil
+}
+
+// GetMfaStatus describes the enrolled factors of an account.
+func (srv *server) GetMfaStatus(ctx context.Context, rqst *authenticationpb.GetMfaStatusRequest) (*authenticationpb.GetMfaStatusResponse, error) {
+	id, _, err := srv.mfaCaller(ctx, rqst.AccountId, "")
+	if err != nil {
+		return nil, err
+	}
+	rec, err := srv.mfaStore().getRecord(id)
+	if err != nil {
+		return nil, logInternal("GetMfaStatus:record", err, "accountId", id)
+	}
+
+	p := &principal{id: id, root: id == "sa"}
+	if !p.root {
+		if account, err := srv.getAccount(id); err == nil {
+			p.roles = account.Roles
+		}
+	}
+	required, err := srv.mfaRequired(p, rec)
+	if err != nil {
+		return nil, logInternal("GetMfaStatus:policy", err)
+	}
+
+	rsp := &authenticationpb.GetMfaStatusResponse{
+		Totp:              rec.TotpSecret != "",
+		RecoveryCodesLeft: int32(len(rec.RecoveryCodes)),
+		Required:          required,
+	}
+	for _, c := range rec.WebAuthn {
+		rsp.WebauthnCredentials = append(rsp.WebauthnCredentials, &authenticationpb.WebAuthnCredential{
+			Id: c.ID, Name: c.Name, Created: c.Created, LastUsed: c.LastUsed,
+		})
+	}
+	return rsp, nil
+}
+
+// SetMfaPolicy replaces the cluster MFA policy.
+func (srv *server) SetMfaPolicy(ctx context.Context, rqst *authenticationpb.SetMfaPolicyRequest) (*authenticationpb.SetMfaPolicyResponse, error) {
+	policy := rqst.Policy
+	if policy == nil {
+		policy = &authenticationpb.MfaPolicy{}
+	}
+	clean := &authenticationpb.MfaPolicy{}
+	for _, a := range policy.Accounts {
+		if a = normalizeAccountId(strings.TrimSpace(a)); a != "" && !Utility.Contains(clean.Accounts, a) {
+			clean.Accounts = append(clean.Accounts, a)
+		}
+	}
+	for _, r := range policy.Roles {
+		if r = normalizeAccountId(strings.TrimSpace(r)); r != "" && !Utility.Contains(clean.Roles, r) {
+			clean.Roles = append(clean.Roles, r)
+		}
+	}
+	if err := srv.mfaStore().putPolicy(clean); err != nil {
+		return nil, logInternal("SetMfaPolicy:save", err)
+	}
+	slog.Info("SetMfaPolicy:ok", "accounts", clean.Accounts, "roles", clean.Roles)
+	return &authenticationpb.SetMfaPolicyResponse{}, nil
+}
+
+// GetMfaPolicy returns the cluster MFA policy.
+func (srv *server) GetMfaPolicy(ctx context.Context, rqst *authenticationpb.GetMfaPolicyRequest) (*authenticationpb.GetMfaPolicyResponse, error) {
+	policy, err := srv.mfaStore().getPolicy()
+	if err != nil {
+		return nil, logInternal("GetMfaPolicy:read", err)
+	}
+	return &authenticationpb.GetMfaPolicyResponse{Policy: policy}, nil
+}
+
+// ResetMfa removes every factor and recovery code of an account, so it can
+// sign in with its password again (and enroll anew if the policy requires it).
+func (srv *server) ResetMfa(ctx context.Context, rqst *authenticationpb.ResetMfaRequest) (*authenticationpb.ResetMfaResponse, error) {
+	id := normalizeAccountId(rqst.AccountId)
+	if id == "" {
+		return nil, status.Error(codes.InvalidArgument, "account_id is required")
+	}
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, status.Error(codes.Unauthenticated, "authentication required")
+	}
+
+	if err := srv.mfaStore().deleteRecord(id); err != nil {
+		return nil, logInternal("ResetMfa:delete", err, "accountId", id)
+	}
+	slog.Warn("ResetMfa:ok", "accountId", id, "by", clientId)
+	globular.PublishEvent("alert.auth.mfa_reset", map[string]interface{}{
+		"severity": "WARNING",
+		"account":  id,
+		"by":       normalizeAccountId(clientId),
+		"service":  "authentication",
+	})
+	return &authenticationpb.ResetMfaResponse{}, nil
+}
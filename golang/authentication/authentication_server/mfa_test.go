@@ -0,0 +1,486 @@
+package main
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/json"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"github.com/globulario/services/golang/security"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestTotpRFC6238Vectors(t *testing.T) {
+	// RFC 6238 appendix B, SHA-1, truncated to six digits.
+	secret := totpEncoding.EncodeToString([]byte("12345678901234567890"))
+	vectors := []struct {
+		unix int64
+		code string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+	for _, v := range vectors {
+		step, ok := verifyTotp(secret, v.code, time.Unix(v.unix, 0), 0)
+		if !ok || step != v.unix/totpPeriod {
+			t.Errorf("t=%d: verifyTotp(%s) = %d, %v", v.unix, v.code, step, ok)
+		}
+	}
+
+	// One step of clock skew is accepted, two are not.
+	if _, ok := verifyTotp(secret, "287082", time.Unix(59+totpPeriod, 0), 0); !ok {
+		t.Error("code of the previous step refused")
+	}
+	if _, ok := verifyTotp(secret, "287082", time.Unix(59+2*totpPeriod, 0), 0); ok {
+		t.Error("code two steps old accepted")
+	}
+
+	// A used step cannot be replayed.
+	if _, ok := verifyTotp(secret, "287082", time.Unix(59, 0), 1); ok {
+		t.Error("replayed code accepted")
+	}
+	if _, ok := verifyTotp(secret, "000000", time.Unix(59, 0), 0); ok {
+		t.Error("wrong code accepted")
+	}
+}
+
+func TestTotpURI(t *testing.T) {
+	uri := totpURI("example.com", "alice", "JBSWY3DPEHPK3PXP")
+	if !strings.HasPrefix(uri, "otpauth://totp/example.com:alice?") ||
+		!strings.Contains(uri, "secret=JBSWY3DPEHPK3PXP") ||
+		!strings.Contains(uri, "issuer=example.com") {
+		t.Errorf("uri = %s", uri)
+	}
+}
+
+func TestRecoveryCodesAreSingleUse(t *testing.T) {
+	codes, hashes, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(codes) != recoveryCodeCount || len(hashes) != recoveryCodeCount {
+		t.Fatalf("got %d codes, %d hashes", len(codes), len(hashes))
+	}
+	for _, h := range hashes {
+		for _, c := range codes {
+			if h == c {
+				t.Fatal("recovery code stored in the clear")
+			}
+		}
+	}
+
+	left, ok := useRecoveryCode(hashes, " "+strings.ToUpper(codes[3])+" ")
+	if !ok || len(left) != recoveryCodeCount-1 {
+		t.Fatalf("useRecoveryCode = %d left, %v", len(left), ok)
+	}
+	if _, ok := useRecoveryCode(left, codes[3]); ok {
+		t.Error("recovery code used twice")
+	}
+	if len(hashes) != recoveryCodeCount {
+		t.Error("useRecoveryCode modified its input")
+	}
+}
+
+func TestCBORDecode(t *testing.T) {
+	v, rest, err := cborDecode([]byte{0xa3, 0x01, 0x18, 0x64, 0x20, 0x43, 1, 2, 3, 0x62, 'h', 'i', 0x82, 0xf5, 0xf6, 0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(rest, []byte{0xff}) {
+		t.Errorf("rest = %x", rest)
+	}
+	m := v.(map[any]any)
+	if m[int64(1)] != int64(100) {
+		t.Errorf("m[1] = %v", m[int64(1)])
+	}
+	if b, _ := m[int64(-1)].([]byte); !bytes.Equal(b, []byte{1, 2, 3}) {
+		t.Errorf("m[-1] = %v", m[int64(-1)])
+	}
+	if a, _ := m["hi"].([]any); len(a) != 2 || a[0] != true || a[1] != nil {
+		t.Errorf("m[hi] = %v", m["hi"])
+	}
+
+	for _, bad := range [][]byte{
+		{},
+		{0x43, 1, 2},                   // truncated byte string
+		{0x9f, 0x01, 0xff},             // indefinite array
+		{0xfb, 0, 0, 0, 0, 0, 0, 0, 0}, // float
+		{0xa1, 0x80, 0x01},             // array as map key
+		bytes.Repeat([]byte{0x81}, cborMaxDepth+2),                // too deep
+		{0x9b, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0xff, 0}, // huge length
+	} {
+		if _, _, err := cborDecode(bad); err == nil {
+			t.Errorf("cborDecode(%x) succeeded", bad)
+		}
+	}
+}
+
+func TestOriginAllowed(t *testing.T) {
+	cases := []struct {
+		origin string
+		want   bool
+	}{
+		{"https://example.com", true},
+		{"https://console.example.com:8443", true},
+		{"http://example.com", false},
+		{"https://example.com.evil.net", false},
+		{"https://badexample.com", false},
+		{"http://localhost:8080", false},
+	}
+	for _, c := range cases {
+		if got := originAllowed(c.origin, "example.com"); got != c.want {
+			t.Errorf("originAllowed(%q) = %v, want %v", c.origin, got, c.want)
+		}
+	}
+	if !originAllowed("http://localhost:8080", "localhost") {
+		t.Error("http://localhost refused for rp localhost")
+	}
+}
+
+// cborEncode encodes the subset of CBOR a test authenticator needs.
+func cborEncode(v any) []byte {
+	head := func(major byte, n uint64) []byte {
+		switch {
+		case n < 24:
+			return []byte{major<<5 | byte(n)}
+		case n < 256:
+			return []byte{major<<5 | 24, byte(n)}
+		default:
+			b := []byte{major<<5 | 25, 0, 0}
+			binary.BigEndian.PutUint16(b[1:], uint16(n))
+			return b
+		}
+	}
+	switch x := v.(type) {
+	case int:
+		if x < 0 {
+			return head(1, uint64(-1-x))
+		}
+		return head(0, uint64(x))
+	case []byte:
+		return append(head(2, uint64(len(x))), x...)
+	case string:
+		return append(head(3, uint64(len(x))), x...)
+	case map[any]any:
+		out := head(5, uint64(len(x)))
+		keys := make([]string, 0, len(x))
+		enc := map[string][]byte{}
+		for k, v := range x {
+			kb := cborEncode(k)
+			keys = append(keys, string(kb))
+			enc[string(kb)] = cborEncode(v)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			out = append(append(out, k...), enc[k]...)
+		}
+		return out
+	}
+	panic("cborEncode: unsupported type")
+}
+
+// softAuthenticator is an ES256 passkey held in memory.
+type softAuthenticator struct {
+	key    *ecdsa.PrivateKey
+	credID []byte
+	count  uint32
+}
+
+func newSoftAuthenticator(t *testing.T) *softAuthenticator {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	id := make([]byte, 16)
+	rand.Read(id)
+	return &softAuthenticator{key: key, credID: id}
+}
+
+func (a *softAuthenticator) clientData(typ string, challenge []byte, origin string) []byte {
+	b, _ := json.Marshal(map[string]string{"type": typ, "challenge": b64url(challenge), "origin": origin})
+	return b
+}
+
+func (a *softAuthenticator) authData(rpID string, flags byte) []byte {
+	h := sha256.Sum256([]byte(rpID))
+	ad := append(h[:], flags, 0, 0, 0, 0)
+	binary.BigEndian.PutUint32(ad[33:], a.count)
+	return ad
+}
+
+func (a *softAuthenticator) register(rpID string, challenge []byte, origin string) string {
+	pub, _ := a.key.PublicKey.Bytes() // 0x04 | X | Y
+	coseKey := cborEncode(map[any]any{1: 2, 3: coseES256, -1: 1, -2: pub[1:33], -3: pub[33:]})
+	ad := a.authData(rpID, flagUserPresent|flagAttestedData)
+	ad = append(ad, make([]byte, 16)...) // AAGUID
+	ad = binary.BigEndian.AppendUint16(ad, uint16(len(a.credID)))
+	ad = append(append(ad, a.credID...), coseKey...)
+	att := cborEncode(map[any]any{"fmt": "none", "attStmt": map[any]any{}, "authData": ad})
+	b, _ := json.Marshal(map[string]any{
+		"id": b64url(a.credID), "rawId": b64url(a.credID), "type": "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64url(a.clientData("webauthn.create", challenge, origin)),
+			"attestationObject": b64url(att),
+		},
+	})
+	return string(b)
+}
+
+func (a *softAuthenticator) assert(t *testing.T, rpID string, challenge []byte, origin string) string {
+	t.Helper()
+	a.count++
+	ad := a.authData(rpID, flagUserPresent)
+	cd := a.clientData("webauthn.get", challenge, origin)
+	cdHash := sha256.Sum256(cd)
+	digest := sha256.Sum256(append(append([]byte(nil), ad...), cdHash[:]...))
+	sig, err := ecdsa.SignASN1(rand.Reader, a.key, digest[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, _ := json.Marshal(map[string]any{
+		"id": b64url(a.credID), "rawId": b64url(a.credID), "type": "public-key",
+		"response": map[string]string{
+			"clientDataJSON":    b64url(cd),
+			"authenticatorData": b64url(ad),
+			"signature":         b64url(sig),
+		},
+	})
+	return string(b)
+}
+
+func TestWebAuthnRegistrationAndAssertion(t *testing.T) {
+	const rpID, origin = "example.com", "https://console.example.com"
+	auth := newSoftAuthenticator(t)
+
+	challenge := []byte("registration-challenge-0123456789")
+	if _, err := verifyRegistration(rpID, []byte("another challenge"), auth.register(rpID, challenge, origin)); err == nil {
+		t.Error("registration accepted with a wrong challenge")
+	}
+	if _, err := verifyRegistration(rpID, challenge, auth.register(rpID, challenge, "https://evil.net")); err == nil {
+		t.Error("registration accepted from a foreign origin")
+	}
+	if _, err := verifyRegistration("other.org", challenge, auth.register("other.org", challenge, origin)); err == nil {
+		t.Error("registration accepted for another relying party")
+	}
+	cred, err := verifyRegistration(rpID, challenge, auth.register(rpID, challenge, origin))
+	if err != nil {
+		t.Fatalf("verifyRegistration: %v", err)
+	}
+	if cred.ID != b64url(auth.credID) || cred.Alg != coseES256 {
+		t.Fatalf("credential = %+v", cred)
+	}
+	creds := []*webAuthnCredential{cred}
+
+	login := []byte("login-challenge")
+	assertion := auth.assert(t, rpID, login, origin)
+	got, err := verifyAssertion(rpID, login, assertion, creds)
+	if err != nil {
+		t.Fatalf("verifyAssertion: %v", err)
+	}
+	if got.SignCount != 1 {
+		t.Errorf("SignCount = %d, want 1", got.SignCount)
+	}
+
+	// The same assertion again fails on its counter.
+	if _, err := verifyAssertion(rpID, login, assertion, creds); err == nil {
+		t.Error("replayed assertion accepted")
+	}
+	if _, err := verifyAssertion(rpID, []byte("other"), auth.assert(t, rpID, login, origin), creds); err == nil {
+		t.Error("assertion accepted for another challenge")
+	}
+	if _, err := verifyAssertion(rpID, login, newSoftAuthenticator(t).assert(t, rpID, login, origin), creds); err == nil {
+		t.Error("assertion of an unknown credential accepted")
+	}
+
+	// A valid structure signed by another key is refused.
+	forged := newSoftAuthenticator(t)
+	forged.credID, forged.count = auth.credID, auth.count+5
+	if _, err := verifyAssertion(rpID, login, forged.assert(t, rpID, login, origin), creds); err == nil {
+		t.Error("forged signature accepted")
+	}
+}
+
+func TestWebAuthnOptions(t *testing.T) {
+	cred := &webAuthnCredential{ID: "Y3JlZA"}
+	options, err := webAuthnRequestOptions("example.com", []byte("abc"), []*webAuthnCredential{cred})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var o struct {
+		Challenge        string `json:"challenge"`
+		RpId             string `json:"rpId"`
+		AllowCredentials []struct {
+			ID string `json:"id"`
+		} `json:"allowCredentials"`
+	}
+	if err := json.Unmarshal([]byte(options), &o); err != nil {
+		t.Fatal(err)
+	}
+	if o.Challenge != b64url([]byte("abc")) || o.RpId != "example.com" || len(o.AllowCredentials) != 1 || o.AllowCredentials[0].ID != "Y3JlZA" {
+		t.Errorf("options = %s", options)
+	}
+}
+
+// memMfaStore is an in-memory mfaStore. Updates work on copies, so a
+// failed one leaves the stored value alone as the etcd store does.
+type memMfaStore struct {
+	records map[string]*mfaRecord
+	pending map[string]*pendingState
+	policy  *authenticationpb.MfaPolicy
+}
+
+func (m *memMfaStore) getRecord(id string) (*mfaRecord, error) {
+	if r, ok := m.records[id]; ok {
+		return r, nil
+	}
+	return &mfaRecord{AccountId: id}, nil
+}
+func (m *memMfaStore) updateRecord(id string, fn func(*mfaRecord) error) (*mfaRecord, error) {
+	r, _ := m.getRecord(id)
+	c := *r
+	if err := fn(&c); err != nil {
+		return nil, err
+	}
+	m.records[id] = &c
+	return &c, nil
+}
+func (m *memMfaStore) deleteRecord(id string) error { delete(m.records, id); return nil }
+func (m *memMfaStore) updatePending(jti string, _ time.Time, fn func(*pendingState) error) (*pendingState, error) {
+	if m.pending == nil {
+		m.pending = make(map[string]*pendingState)
+	}
+	c := pendingState{}
+	if st, ok := m.pending[jti]; ok {
+		c = *st
+	}
+	if err := fn(&c); err != nil {
+		return nil, err
+	}
+	m.pending[jti] = &c
+	return &c, nil
+}
+func (m *memMfaStore) getPolicy() (*authenticationpb.MfaPolicy, error) {
+	if m.policy == nil {
+		return &authenticationpb.MfaPolicy{}, nil
+	}
+	return m.policy, nil
+}
+func (m *memMfaStore) putPolicy(p *authenticationpb.MfaPolicy) error { m.policy = p; return nil }
+
+func TestMfaRequired(t *testing.T) {
+	store := &memMfaStore{records: map[string]*mfaRecord{
+		"bob": {AccountId: "bob", TotpSecret: "sealed"},
+	}}
+	srv := &server{mfa: store}
+	_, err := srv.SetMfaPolicy(t.Context(), &authenticationpb.SetMfaPolicyRequest{
+		Policy: &authenticationpb.MfaPolicy{Accounts: []string{" carol@example.com ", "carol"}, Roles: []string{"role:admin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p := store.policy; len(p.Accounts) != 1 || p.Accounts[0] != "carol" {
+		t.Errorf("stored policy = %v", p)
+	}
+
+	cases := []struct {
+		p    *principal
+		want bool
+	}{
+		{&principal{id: "alice"}, false},
+		{&principal{id: "bob"}, true},   // enrolled
+		{&principal{id: "carol"}, true}, // listed
+		{&principal{id: "dave", roles: []string{"role:admin@example.com"}}, true},
+		{&principal{id: "erin", roles: []string{"role:user"}}, false},
+	}
+	for _, c := range cases {
+		rec, _ := store.getRecord(c.p.id)
+		got, err := srv.mfaRequired(c.p, rec)
+		if err != nil || got != c.want {
+			t.Errorf("mfaRequired(%s) = %v, %v; want %v", c.p.id, got, err, c.want)
+		}
+	}
+
+	// A login that needs no second factor gets no challenge.
+	rsp, err := srv.challengeMfa(&principal{id: "alice"}, "issuer")
+	if err != nil || rsp != nil {
+		t.Errorf("challengeMfa(alice) = %v, %v", rsp, err)
+	}
+}
+
+func TestMfaTokenAttempts(t *testing.T) {
+	srv := &server{mfa: &memMfaStore{records: map[string]*mfaRecord{}}}
+	token := func(jti string) *security.Claims {
+		return &security.Claims{RegisteredClaims: jwt.RegisteredClaims{ID: jti, ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+	}
+
+	a := token("a")
+	for range mfaMaxAttempts {
+		if err := srv.takeMfaAttempt(a); err != nil {
+			t.Fatalf("token burnt early: %v", err)
+		}
+	}
+	if err := srv.takeMfaAttempt(a); err != errMfaTokenSpent {
+		t.Errorf("attempt past the limit: %v", err)
+	}
+
+	b := token("b")
+	if err := srv.takeMfaAttempt(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.consumeMfaToken(b); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.consumeMfaToken(b); err != errMfaTokenUsed {
+		t.Errorf("second consume: %v", err)
+	}
+	if err := srv.takeMfaAttempt(b); err != errMfaTokenSpent {
+		t.Errorf("attempt with a used token: %v", err)
+	}
+}
+
+func TestMfaRecordUpdate(t *testing.T) {
+	store := &memMfaStore{records: map[string]*mfaRecord{
+		"bob": {AccountId: "bob", TotpSecret: "sealed", RecoveryCodes: []string{"x"}},
+	}}
+	srv := &server{mfa: store}
+
+	// A failed update writes nothing.
+	if _, err := srv.mfaStore().updateRecord("alice", func(rec *mfaRecord) error {
+		if !rec.enrolled() {
+			return errMfaNotEnrolled
+		}
+		return nil
+	}); err != errMfaNotEnrolled {
+		t.Errorf("update of alice: %v", err)
+	}
+	if _, ok := store.records["alice"]; ok {
+		t.Error("failed update was written")
+	}
+
+	// An enrollment made with an mfa_pending token is refused once another
+	// factor got enrolled in the meantime.
+	pending := &security.Claims{RegisteredClaims: jwt.RegisteredClaims{ID: "p", ExpiresAt: jwt.NewNumericDate(time.Now().Add(time.Minute))}}
+	_, _, err := srv.finishEnrollment("bob", pending, func(rec *mfaRecord) error {
+		rec.WebAuthn = append(rec.WebAuthn, &webAuthnCredential{ID: "k"})
+		return nil
+	})
+	if status.Code(err) != codes.PermissionDenied {
+		t.Errorf("second enrollment: %v", err)
+	}
+	if rec, _ := store.getRecord("bob"); len(rec.WebAuthn) != 0 {
+		t.Error("second enrollment was written")
+	}
+}
@@ -0,0 +1,587 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_authentication
+// @awareness file_role=openid_connect_provider_grants_tokens_and_client_registration
+// @awareness risk=critical
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"regexp"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/resource/resourcepb"
+	"github.com/globulario/services/golang/security"
+	"github.com/golang-jwt/jwt/v5"
+	clientv3 "go.etcd.io/etcd/client/v3"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ---- OpenID Connect provider ------------------------------------------------
+//
+// With OidcPort set, the service also serves an OpenID Connect provider so
+// that self-hosted applications can sign their users in with Globular
+// accounts (oidc_http.go). Clients are resource Applications with redirect
+// URIs, registered with RegisterOidcClient; a confidential client's
+// application password is the bcrypt hash of its secret, a public client has
+// none and relies on PKCE alone.
+//
+// ID and access tokens are signed with the node's Ed25519 issuer key, the key
+// cluster sessions are signed with, and published on the JWKS endpoint with
+// the keys of the other nodes. They carry no session claims, so they cannot be
+// replayed against Globular services. Authorization codes and refresh tokens
+// are random handles kept in etcd under their SHA-256, so every instance can
+// redeem them; both are single use.
+
+// etcd key schema:
+//
+//	/globular/auth/oidc/codes/{sha256(code)}                 oidcGrant (JSON), leased
+//	/globular/auth/oidc/refresh/{sha256(token)}              oidcGrant (JSON), leased
+//	/globular/auth/oidc/consents/{account_id}/{client_id}    granted scopes (JSON)
+const (
+	etcdOidcPrefix        = "/globular/auth/oidc/"
+	etcdOidcCodePrefix    = etcdOidcPrefix + "codes/"
+	etcdOidcRefreshPrefix = etcdOidcPrefix + "refresh/"
+	etcdOidcConsentPrefix = etcdOidcPrefix + "consents/"
+	etcdOidcTimeout       = 3 * time.Second
+
+	oidcCodeTTL               = 2 * time.Minute
+	defaultOidcRefreshTimeout = 30 // days a refresh token lives
+)
+
+// oidcScopes are the scopes the provider understands; others are dropped.
+var oidcScopes = []string{"openid", "profile", "email"}
+
+var oidcClientIdExp = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{1,63}$`)
+
+// Token signing and the published key set, replaced in tests.
+var (
+	signOidcClaims = security.SignIssuerClaims
+	oidcPublicKeys = security.IssuerPublicKeys
+)
+
+// oidcGrant is what an authorization code or a refresh token stands for.
+type oidcGrant struct {
+	ClientId      string   `json:"client_id"`
+	AccountId     string   `json:"account_id"`
+	Scopes        []string `json:"scopes"`
+	AuthTime      int64    `json:"auth_time"`
+	RedirectUri   string   `json:"redirect_uri,omitempty"`   // codes only
+	CodeChallenge string   `json:"code_challenge,omitempty"` // codes only, S256
+	Nonce         string   `json:"nonce,omitempty"`
+}
+
+// oidcStore persists grants and consents.
+type oidcStore interface {
+	putGrant(key string, g *oidcGrant, ttl time.Duration) error
+	// takeGrant removes and returns the grant, or nil when it does not exist
+	// (anymore): of two concurrent redemptions only one gets it.
+	takeGrant(key string) (*oidcGrant, error)
+	consent(accountId, clientId string) ([]string, error)
+	setConsent(accountId, clientId string, scopes []string) error
+}
+
+// etcdOidcStore keeps grants and consents in etcd; grants expire with their
+// lease.
+type etcdOidcStore struct{}
+
+func (etcdOidcStore) putGrant(key string, g *oidcGrant, ttl time.Duration) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	data, err := json.Marshal(g)
+	if err != nil {
+		return fmt.Errorf("marshal oidc grant: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdOidcTimeout)
+	defer cancel()
+	lease, err := c.Grant(ctx, int64(ttl/time.Second))
+	if err != nil {
+		return fmt.Errorf("etcd grant: %w", err)
+	}
+	if _, err := c.Put(ctx, key, string(data), clientv3.WithLease(lease.ID)); err != nil {
+		return fmt.Errorf("etcd put %s: %w", key, err)
+	}
+	return nil
+}
+
+func (etcdOidcStore) takeGrant(key string) (*oidcGrant, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdOidcTimeout)
+	defer cancel()
+	res, err := c.Delete(ctx, key, clientv3.WithPrevKV())
+	if err != nil {
+		return nil, fmt.Errorf("etcd delete %s: %w", key, err)
+	}
+	if res.Deleted != 1 || len(res.PrevKvs) == 0 {
+		return nil, nil
+	}
+	g := &oidcGrant{}
+	if err := json.Unmarshal(res.PrevKvs[0].Value, g); err != nil {
+		return nil, fmt.Errorf("unmarshal oidc grant: %w", err)
+	}
+	return g, nil
+}
+
+func (etcdOidcStore) consent(accountId, clientId string) ([]string, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdOidcTimeout)
+	defer cancel()
+	key := etcdOidcConsentPrefix + accountId + "/" + clientId
+	res, err := c.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("etcd get %s: %w", key, err)
+	}
+	if len(res.Kvs) == 0 {
+		return nil, nil
+	}
+	var scopes []string
+	if err := json.Unmarshal(res.Kvs[0].Value, &scopes); err != nil {
+		return nil, fmt.Errorf("unmarshal oidc consent: %w", err)
+	}
+	return scopes, nil
+}
+
+func (etcdOidcStore) setConsent(accountId, clientId string, scopes []string) error {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd unavailable: %w", err)
+	}
+	data, err := json.Marshal(scopes)
+	if err != nil {
+		return fmt.Errorf("marshal oidc consent: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdOidcTimeout)
+	defer cancel()
+	key := etcdOidcConsentPrefix + accountId + "/" + clientId
+	if _, err := c.Put(ctx, key, string(data)); err != nil {
+		return fmt.Errorf("etcd put %s: %w", key, err)
+	}
+	return nil
+}
+
+func (srv *server) oidcStore() oidcStore {
+	if srv.oidc == nil {
+		srv.oidc = etcdOidcStore{}
+	}
+	return srv.oidc
+}
+
+// oidcBackend reaches the rest of the cluster: client applications and
+// accounts in the resource service, and Globular sessions.
+type oidcBackend interface {
+	client(clientId string) (*resourcepb.Application, error)
+	registerClient(token string, app *resourcepb.Application) error
+	account(accountId string) (*resourcepb.Account, error)
+	session(token string) (*security.Claims, error)
+}
+
+type resourceOidcBackend struct{ srv *server }
+
+func (b resourceOidcBackend) client(clientId string) (*resourcepb.Application, error) {
+	resourceClient, err := b.srv.getResourceClient(b.srv.GetAddress())
+	if err != nil {
+		return nil, err
+	}
+	q, err := json.Marshal(map[string]string{"_id": clientId})
+	if err != nil {
+		return nil, err
+	}
+	apps, err := resourceClient.GetApplications(string(q))
+	if err != nil {
+		return nil, err
+	}
+	if len(apps) == 0 {
+		return nil, nil
+	}
+	return apps[0], nil
+}
+
+func (b resourceOidcBackend) registerClient(token string, app *resourcepb.Application) error {
+	resourceClient, err := b.srv.getResourceClient(b.srv.GetAddress())
+	if err != nil {
+		return err
+	}
+	return resourceClient.RegisterApplication(token, app)
+}
+
+// account returns the account of accountId; sa has no resource account and
+// gets its email from the root credentials.
+func (b resourceOidcBackend) account(accountId string) (*resourcepb.Account, error) {
+	if accountId == "sa" {
+		a := &resourcepb.Account{Id: "sa", Name: "sa"}
+		if creds, err := config.GetRootCredentials(); err == nil {
+			a.Email = creds.AdminEmail
+		}
+		return a, nil
+	}
+	return b.srv.getAccount(accountId)
+}
+
+func (b resourceOidcBackend) session(token string) (*security.Claims, error) {
+	return security.ValidateToken(token)
+}
+
+func (srv *server) oidcBackend() oidcBackend {
+	if srv.oidcBackend_ == nil {
+		srv.oidcBackend_ = resourceOidcBackend{srv: srv}
+	}
+	return srv.oidcBackend_
+}
+
+// oidcClient looks up the client application of a request. Ids that
+// RegisterOidcClient would refuse are unknown without a lookup, so request
+// input never reaches the resource query as anything but a plain id.
+func (srv *server) oidcClient(clientId string) (*resourcepb.Application, error) {
+	if !oidcClientIdExp.MatchString(clientId) {
+		return nil, nil
+	}
+	return srv.oidcBackend().client(clientId)
+}
+
+// oidcIssuer is the issuer identifier: OidcIssuer, or the provider's own
+// address on this node.
+func (srv *server) oidcIssuer() string {
+	if srv.OidcIssuer != "" {
+		return strings.TrimSuffix(srv.OidcIssuer, "/")
+	}
+	scheme := "http"
+	if srv.TLS {
+		scheme = "https"
+	}
+	return fmt.Sprintf("%s://%s:%d", scheme, srv.rpID(), srv.OidcPort)
+}
+
+func (srv *server) oidcRefreshTTL() time.Duration {
+	days := srv.OidcRefreshTimeout
+	if days <= 0 {
+		days = defaultOidcRefreshTimeout
+	}
+	return time.Duration(days) * 24 * time.Hour
+}
+
+// randomHandle returns 32 random bytes, base64url encoded.
+func randomHandle() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return b64url(b), nil
+}
+
+// grantKey is the etcd key of a code or refresh token: only its hash is kept.
+func grantKey(prefix, handle string) string {
+	sum := sha256.Sum256([]byte(handle))
+	return prefix + hex.EncodeToString(sum[:])
+}
+
+// verifyPkce checks an S256 code verifier against its challenge (RFC 7636).
+func verifyPkce(verifier, challenge string) bool {
+	if len(verifier) < 43 || len(verifier) > 128 {
+		return false
+	}
+	sum := sha256.Sum256([]byte(verifier))
+	return subtle.ConstantTimeCompare([]byte(base64.RawURLEncoding.EncodeToString(sum[:])), []byte(challenge)) == 1
+}
+
+// isOidcClient tells whether app was registered with RegisterOidcClient.
+func isOidcClient(app *resourcepb.Application) bool {
+	return app != nil && len(app.RedirectUris) > 0
+}
+
+// isPublicOidcClient tells whether the client has no secret. Confidential
+// clients keep the bcrypt hash of their secret as application password.
+func isPublicOidcClient(app *resourcepb.Application) bool {
+	return !isBcryptHash(app.Password)
+}
+
+// validateRedirectUri accepts absolute https URIs without fragment, and http
+// ones on the loopback interface for native and development clients.
+func validateRedirectUri(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || !u.IsAbs() || u.Host == "" {
+		return fmt.Errorf("redirect uri %q is not an absolute URL", raw)
+	}
+	if u.Fragment != "" {
+		return fmt.Errorf("redirect uri %q must not have a fragment", raw)
+	}
+	switch u.Scheme {
+	case "https":
+		return nil
+	case "http":
+		host := u.Hostname()
+		if ip := net.ParseIP(host); host == "localhost" || (ip != nil && ip.IsLoopback()) {
+			return nil
+		}
+	}
+	return fmt.Errorf("redirect uri %q must use https (http is only allowed on loopback)", raw)
+}
+
+// RegisterOidcClient registers an OpenID Connect client as a resource
+// Application and returns its id and, for a confidential client, its secret.
+func (srv *server) RegisterOidcClient(ctx context.Context, rqst *authenticationpb.RegisterOidcClientRequest) (*authenticationpb.RegisterOidcClientResponse, error) {
+	_, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !oidcClientIdExp.MatchString(rqst.Name) {
+		return nil, status.Error(codes.InvalidArgument, "the client name must be 2 to 64 letters, digits, '.', '_' or '-'")
+	}
+	if len(rqst.RedirectUris) == 0 {
+		return nil, status.Error(codes.InvalidArgument, "at least one redirect uri is required")
+	}
+	for _, uri := range rqst.RedirectUris {
+		if err := validateRedirectUri(uri); err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	existing, err := srv.oidcClient(rqst.Name)
+	if err != nil {
+		return nil, logInternal("RegisterOidcClient:lookup", err)
+	}
+	if existing != nil {
+		return nil, status.Errorf(codes.AlreadyExists, "application %s already exists", rqst.Name)
+	}
+
+	app := &resourcepb.Application{
+		Id:           rqst.Name,
+		Name:         rqst.Name,
+		Description:  rqst.Description,
+		Version:      "0.0.1",
+		RedirectUris: slices.Clone(rqst.RedirectUris),
+	}
+	rsp := &authenticationpb.RegisterOidcClientResponse{ClientId: rqst.Name}
+	if !rqst.PublicClient {
+		secret, err := randomHandle()
+		if err != nil {
+			return nil, logInternal("RegisterOidcClient:secret", err)
+		}
+		hash, err := bcrypt.GenerateFromPassword([]byte(secret), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, logInternal("RegisterOidcClient:hash", err)
+		}
+		app.Password = string(hash)
+		rsp.ClientSecret = secret
+	}
+	if err := srv.oidcBackend().registerClient(token, app); err != nil {
+		return nil, logInternal("RegisterOidcClient:create", err)
+	}
+	return rsp, nil
+}
+
+// ---- Tokens ------------------------------------------------------------------
+
+var errOidcInvalidGrant = errors.New("invalid_grant")
+
+// oidcTokens is the token endpoint response.
+type oidcTokens struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	ExpiresIn    int64  `json:"expires_in"`
+	IdToken      string `json:"id_token,omitempty"`
+	RefreshToken string `json:"refresh_token,omitempty"`
+	Scope        string `json:"scope"`
+}
+
+// issueCode stores g and returns its authorization code.
+func (srv *server) issueCode(g *oidcGrant) (string, error) {
+	code, err := randomHandle()
+	if err != nil {
+		return "", err
+	}
+	if err := srv.oidcStore().putGrant(grantKey(etcdOidcCodePrefix, code), g, oidcCodeTTL); err != nil {
+		return "", err
+	}
+	return code, nil
+}
+
+// redeemCode exchanges an authorization code. The code is consumed whatever
+// the outcome, so a code sent with a wrong verifier cannot be retried.
+func (srv *server) redeemCode(app *resourcepb.Application, code, redirectUri, verifier string) (*oidcTokens, error) {
+	g, err := srv.oidcStore().takeGrant(grantKey(etcdOidcCodePrefix, code))
+	if err != nil {
+		return nil, err
+	}
+	if g == nil || g.ClientId != app.Id || g.RedirectUri != redirectUri || !verifyPkce(verifier, g.CodeChallenge) {
+		return nil, errOidcInvalidGrant
+	}
+	return srv.issueOidcTokens(app, g)
+}
+
+// redeemRefresh rotates a refresh token: the presented token is consumed and
+// a new one returned with the new access and ID tokens. scope may narrow the
+// original grant.
+func (srv *server) redeemRefresh(app *resourcepb.Application, refreshToken, scope string) (*oidcTokens, error) {
+	g, err := srv.oidcStore().takeGrant(grantKey(etcdOidcRefreshPrefix, refreshToken))
+	if err != nil {
+		return nil, err
+	}
+	if g == nil || g.ClientId != app.Id {
+		return nil, errOidcInvalidGrant
+	}
+	if scope != "" {
+		requested := strings.Fields(scope)
+		for _, s := range requested {
+			if !slices.Contains(g.Scopes, s) {
+				return nil, errOidcInvalidGrant
+			}
+		}
+		g.Scopes = requested
+	}
+	g.Nonce = ""
+	return srv.issueOidcTokens(app, g)
+}
+
+// issueOidcTokens signs the access and ID tokens of a grant and stores a new
+// refresh token for it.
+func (srv *server) issueOidcTokens(app *resourcepb.Application, g *oidcGrant) (*oidcTokens, error) {
+	account, err := srv.oidcBackend().account(g.AccountId)
+	if err != nil || account == nil {
+		// The account went away since the grant was made.
+		return nil, errOidcInvalidGrant
+	}
+
+	now := time.Now()
+	ttl := time.Duration(srv.SessionTimeout) * time.Minute
+	if ttl <= 0 {
+		ttl = 15 * time.Minute
+	}
+	issuer := srv.oidcIssuer()
+	jti, err := randomHandle()
+	if err != nil {
+		return nil, err
+	}
+
+	access := jwt.MapClaims{
+		"iss":       issuer,
+		"sub":       account.Id,
+		"aud":       app.Id,
+		"client_id": app.Id,
+		"scope":     strings.Join(g.Scopes, " "),
+		"token_use": "access",
+		"jti":       jti,
+		"iat":       now.Unix(),
+		"exp":       now.Add(ttl).Unix(),
+	}
+	accessToken, err := signOidcClaims(access)
+	if err != nil {
+		return nil, err
+	}
+
+	id := oidcUserClaims(account, g.Scopes)
+	id["iss"] = issuer
+	id["aud"] = app.Id
+	id["azp"] = app.Id
+	id["iat"] = now.Unix()
+	id["exp"] = now.Add(ttl).Unix()
+	id["auth_time"] = g.AuthTime
+	if g.Nonce != "" {
+		id["nonce"] = g.Nonce
+	}
+	idToken, err := signOidcClaims(id)
+	if err != nil {
+		return nil, err
+	}
+
+	refreshToken, err := randomHandle()
+	if err != nil {
+		return nil, err
+	}
+	refresh := &oidcGrant{ClientId: app.Id, AccountId: g.AccountId, Scopes: g.Scopes, AuthTime: g.AuthTime}
+	if err := srv.oidcStore().putGrant(grantKey(etcdOidcRefreshPrefix, refreshToken), refresh, srv.oidcRefreshTTL()); err != nil {
+		return nil, err
+	}
+
+	return &oidcTokens{
+		AccessToken:  accessToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int64(ttl / time.Second),
+		IdToken:      idToken,
+		RefreshToken: refreshToken,
+		Scope:        strings.Join(g.Scopes, " "),
+	}, nil
+}
+
+// oidcUserClaims are the claims describing an account, shared by ID tokens
+// and the userinfo endpoint. Groups and roles come from the resource service
+// and are always present so relying parties can map them to their own
+// permissions.
+func oidcUserClaims(account *resourcepb.Account, scopes []string) jwt.MapClaims {
+	claims := jwt.MapClaims{
+		"sub":    account.Id,
+		"groups": nonNil(account.Groups),
+		"roles":  nonNil(account.Roles),
+	}
+	if slices.Contains(scopes, "profile") {
+		claims["preferred_username"] = account.Id
+		name := strings.TrimSpace(account.FirstName + " " + account.LastName)
+		if name == "" {
+			name = account.Name
+		}
+		claims["name"] = name
+		if account.FirstName != "" {
+			claims["given_name"] = account.FirstName
+		}
+		if account.LastName != "" {
+			claims["family_name"] = account.LastName
+		}
+	}
+	if slices.Contains(scopes, "email") && account.Email != "" {
+		claims["email"] = account.Email
+	}
+	return claims
+}
+
+func nonNil(s []string) []string {
+	if s == nil {
+		return []string{}
+	}
+	return s
+}
+
+// parseAccessToken verifies an access token issued by this provider.
+func (srv *server) parseAccessToken(raw string) (jwt.MapClaims, error) {
+	keys, err := oidcPublicKeys()
+	if err != nil {
+		return nil, err
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(t *jwt.Token) (any, error) {
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+		return key, nil
+	}, jwt.WithValidMethods([]string{jwt.SigningMethodEdDSA.Alg()}), jwt.WithIssuer(srv.oidcIssuer()), jwt.WithExpirationRequired())
+	if err != nil {
+		return nil, err
+	}
+	if claims["token_use"] != "access" {
+		return nil, errors.New("not an access token")
+	}
+	return claims, nil
+}
@@ -0,0 +1,662 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_authentication
+// @awareness file_role=openid_connect_provider_http_endpoints_login_and_consent
+// @awareness risk=critical
+package main
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"html/template"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authenticationpb"
+	"github.com/globulario/services/golang/resource/resourcepb"
+	"github.com/globulario/services/golang/security"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+// The browser keeps its Globular session in oidcSessionCookie, so a user
+// signed in once is not asked again by the next application. Forms carry a
+// copy of oidcCsrfCookie (double-submit) and the authorization request itself
+// in hidden fields; the provider keeps no state between pages.
+const (
+	oidcSessionCookie = "globular_oidc_session"
+	oidcCsrfCookie    = "globular_oidc_csrf"
+)
+
+// startOidc serves the OpenID Connect provider on OidcPort until ctx is done.
+// A zero port disables it.
+func (srv *server) startOidc(ctx context.Context) {
+	if srv.OidcPort == 0 {
+		return
+	}
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(srv.OidcPort))
+	if err != nil {
+		logger.Error("oidc: cannot listen", "port", srv.OidcPort, "err", err)
+		return
+	}
+	hs := &http.Server{
+		Handler:           srv.oidcHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = hs.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		var err error
+		if srv.TLS && srv.CertFile != "" && srv.KeyFile != "" {
+			logger.Info("oidc provider listening", "addr", ln.Addr().String(), "issuer", srv.oidcIssuer(), "tls", true)
+			err = hs.ServeTLS(ln, filepath.Clean(srv.CertFile), filepath.Clean(srv.KeyFile))
+		} else {
+			logger.Info("oidc provider listening", "addr", ln.Addr().String(), "issuer", srv.oidcIssuer(), "tls", false)
+			err = hs.Serve(ln)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error("oidc server error", "err", err)
+		}
+	}()
+}
+
+func (srv *server) oidcHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", srv.handleOidcDiscovery)
+	mux.HandleFunc("GET /oauth2/jwks", srv.handleOidcJwks)
+	mux.HandleFunc("GET /oauth2/authorize", srv.handleOidcAuthorize)
+	mux.HandleFunc("POST /oauth2/authorize", srv.handleOidcAuthorize)
+	mux.HandleFunc("POST /oauth2/token", srv.handleOidcToken)
+	mux.HandleFunc("GET /oauth2/userinfo", srv.handleOidcUserinfo)
+	mux.HandleFunc("POST /oauth2/userinfo", srv.handleOidcUserinfo)
+	return mux
+}
+
+func writeJSON(w http.ResponseWriter, code int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func (srv *server) handleOidcDiscovery(w http.ResponseWriter, r *http.Request) {
+	issuer := srv.oidcIssuer()
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                         issuer,
+		"authorization_endpoint":                         issuer + "/oauth2/authorize",
+		"token_endpoint":                                 issuer + "/oauth2/token",
+		"userinfo_endpoint":                              issuer + "/oauth2/userinfo",
+		"jwks_uri":                                       issuer + "/oauth2/jwks",
+		"response_types_supported":                       []string{"code"},
+		"response_modes_supported":                       []string{"query"},
+		"grant_types_supported":                          []string{"authorization_code", "refresh_token"},
+		"subject_types_supported":                        []string{"public"},
+		"id_token_signing_alg_values_supported":          []string{"EdDSA"},
+		"scopes_supported":                               oidcScopes,
+		"claims_supported":                               []string{"sub", "name", "given_name", "family_name", "preferred_username", "email", "groups", "roles", "nonce", "auth_time"},
+		"token_endpoint_auth_methods_supported":          []string{"client_secret_basic", "client_secret_post", "none"},
+		"code_challenge_methods_supported":               []string{"S256"},
+		"prompt_values_supported":                        []string{"none", "login", "consent"},
+		"authorization_response_iss_parameter_supported": true,
+	})
+}
+
+func (srv *server) handleOidcJwks(w http.ResponseWriter, r *http.Request) {
+	keys, err := oidcPublicKeys()
+	if err != nil {
+		logger.Error("oidc: load public keys", "err", err)
+		writeJSON(w, http.StatusServiceUnavailable, map[string]string{"error": "temporarily_unavailable"})
+		return
+	}
+	kids := make([]string, 0, len(keys))
+	for kid := range keys {
+		kids = append(kids, kid)
+	}
+	sort.Strings(kids)
+	jwks := make([]map[string]string, 0, len(kids))
+	for _, kid := range kids {
+		jwks = append(jwks, map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   b64url([]byte(keys[kid])),
+			"kid": kid,
+			"use": "sig",
+			"alg": "EdDSA",
+		})
+	}
+	w.Header().Set("Cache-Control", "public, max-age=300")
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"keys": jwks})
+}
+
+// ---- Authorization endpoint ----------------------------------------------------
+
+// authorizeRequest is an OAuth 2.0 authorization request.
+type authorizeRequest struct {
+	ResponseType        string
+	ClientId            string
+	RedirectUri         string
+	Scope               string
+	State               string
+	Nonce               string
+	CodeChallenge       string
+	CodeChallengeMethod string
+	Prompt              string
+
+	scopes []string // understood scopes of Scope
+	app    *resourcepb.Application
+}
+
+func parseAuthorizeRequest(v url.Values) *authorizeRequest {
+	return &authorizeRequest{
+		ResponseType:        v.Get("response_type"),
+		ClientId:            v.Get("client_id"),
+		RedirectUri:         v.Get("redirect_uri"),
+		Scope:               v.Get("scope"),
+		State:               v.Get("state"),
+		Nonce:               v.Get("nonce"),
+		CodeChallenge:       v.Get("code_challenge"),
+		CodeChallengeMethod: v.Get("code_challenge_method"),
+		Prompt:              v.Get("prompt"),
+	}
+}
+
+// hidden are the request parameters the pages carry forward.
+func (a *authorizeRequest) hidden() map[string]string {
+	return map[string]string{
+		"response_type":         a.ResponseType,
+		"client_id":             a.ClientId,
+		"redirect_uri":          a.RedirectUri,
+		"scope":                 a.Scope,
+		"state":                 a.State,
+		"nonce":                 a.Nonce,
+		"code_challenge":        a.CodeChallenge,
+		"code_challenge_method": a.CodeChallengeMethod,
+		"prompt":                a.Prompt,
+	}
+}
+
+func (a *authorizeRequest) prompts(p string) bool {
+	return slices.Contains(strings.Fields(a.Prompt), p)
+}
+
+// redirect sends the authorization response back to the client.
+func (srv *server) redirect(w http.ResponseWriter, r *http.Request, a *authorizeRequest, params url.Values) {
+	if a.State != "" {
+		params.Set("state", a.State)
+	}
+	params.Set("iss", srv.oidcIssuer())
+	sep := "?"
+	if strings.Contains(a.RedirectUri, "?") {
+		sep = "&"
+	}
+	http.Redirect(w, r, a.RedirectUri+sep+params.Encode(), http.StatusFound)
+}
+
+func (srv *server) redirectError(w http.ResponseWriter, r *http.Request, a *authorizeRequest, code, description string) {
+	srv.redirect(w, r, a, url.Values{"error": {code}, "error_description": {description}})
+}
+
+func (srv *server) handleOidcAuthorize(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		srv.renderOidcError(w, http.StatusBadRequest, "The request could not be read.")
+		return
+	}
+	a := parseAuthorizeRequest(r.Form)
+
+	// Until the redirect uri is known to belong to the client, errors are
+	// shown here rather than sent to it.
+	app, err := srv.oidcClient(a.ClientId)
+	if err != nil {
+		logger.Error("oidc: client lookup", "client", a.ClientId, "err", err)
+		srv.renderOidcError(w, http.StatusServiceUnavailable, "The application could not be looked up; try again later.")
+		return
+	}
+	if a.ClientId == "" || !isOidcClient(app) {
+		srv.renderOidcError(w, http.StatusBadRequest, "Unknown application.")
+		return
+	}
+	if !slices.Contains(app.RedirectUris, a.RedirectUri) {
+		srv.renderOidcError(w, http.StatusBadRequest, "The redirect URI is not registered for this application.")
+		return
+	}
+	a.app = app
+
+	if a.ResponseType != "code" {
+		srv.redirectError(w, r, a, "unsupported_response_type", "only the authorization code flow is supported")
+		return
+	}
+	for _, s := range strings.Fields(a.Scope) {
+		if slices.Contains(oidcScopes, s) && !slices.Contains(a.scopes, s) {
+			a.scopes = append(a.scopes, s)
+		}
+	}
+	if !slices.Contains(a.scopes, "openid") {
+		srv.redirectError(w, r, a, "invalid_scope", "the openid scope is required")
+		return
+	}
+	if a.CodeChallenge == "" || a.CodeChallengeMethod != "S256" {
+		srv.redirectError(w, r, a, "invalid_request", "PKCE with code_challenge_method S256 is required")
+		return
+	}
+	if a.prompts("none") && len(strings.Fields(a.Prompt)) > 1 {
+		srv.redirectError(w, r, a, "invalid_request", "prompt=none cannot be combined with other values")
+		return
+	}
+
+	if r.Method == http.MethodGet {
+		srv.authorize(w, r, a, srv.oidcSession(r), false)
+		return
+	}
+
+	// Form submissions from the pages below.
+	if !validCsrf(r) {
+		srv.renderOidcError(w, http.StatusBadRequest, "The form expired; go back to the application and try again.")
+		return
+	}
+	switch r.PostForm.Get("action") {
+	case "login":
+		srv.oidcLogin(w, r, a)
+	case "mfa":
+		srv.oidcVerifyMfa(w, r, a)
+	case "consent":
+		claims := srv.oidcSession(r)
+		if claims == nil {
+			srv.renderLogin(w, r, a, "Your session expired; sign in again.")
+			return
+		}
+		if r.PostForm.Get("decision") != "allow" {
+			srv.redirectError(w, r, a, "access_denied", "the user denied the request")
+			return
+		}
+		granted, err := srv.oidcStore().consent(claims.ID, a.ClientId)
+		if err == nil {
+			for _, s := range a.scopes {
+				if !slices.Contains(granted, s) {
+					granted = append(granted, s)
+				}
+			}
+			err = srv.oidcStore().setConsent(claims.ID, a.ClientId, granted)
+		}
+		if err != nil {
+			logger.Error("oidc: store consent", "account", claims.ID, "client", a.ClientId, "err", err)
+			srv.redirectError(w, r, a, "server_error", "the consent could not be saved")
+			return
+		}
+		srv.issueAuthorization(w, r, a, claims)
+	default:
+		srv.renderOidcError(w, http.StatusBadRequest, "Unknown action.")
+	}
+}
+
+// authorize goes on with a valid request: sign in unless there is a session
+// (and the client did not ask for a fresh login), ask for consent unless the
+// scopes were granted before, then send the code.
+func (srv *server) authorize(w http.ResponseWriter, r *http.Request, a *authorizeRequest, claims *security.Claims, fresh bool) {
+	if claims == nil || (a.prompts("login") && !fresh) {
+		if a.prompts("none") {
+			srv.redirectError(w, r, a, "login_required", "the user is not signed in")
+			return
+		}
+		srv.renderLogin(w, r, a, "")
+		return
+	}
+
+	granted, err := srv.oidcStore().consent(claims.ID, a.ClientId)
+	if err != nil {
+		logger.Error("oidc: read consent", "account", claims.ID, "client", a.ClientId, "err", err)
+		srv.redirectError(w, r, a, "server_error", "the consent could not be read")
+		return
+	}
+	covered := true
+	for _, s := range a.scopes {
+		if !slices.Contains(granted, s) {
+			covered = false
+			break
+		}
+	}
+	if !covered || a.prompts("consent") {
+		if a.prompts("none") {
+			srv.redirectError(w, r, a, "consent_required", "the user has not granted these scopes")
+			return
+		}
+		srv.renderConsent(w, r, a, claims)
+		return
+	}
+	srv.issueAuthorization(w, r, a, claims)
+}
+
+func (srv *server) issueAuthorization(w http.ResponseWriter, r *http.Request, a *authorizeRequest, claims *security.Claims) {
+	var authTime int64
+	if claims.IssuedAt != nil {
+		authTime = claims.IssuedAt.Unix()
+	}
+	code, err := srv.issueCode(&oidcGrant{
+		ClientId:      a.ClientId,
+		AccountId:     claims.ID,
+		Scopes:        a.scopes,
+		AuthTime:      authTime,
+		RedirectUri:   a.RedirectUri,
+		CodeChallenge: a.CodeChallenge,
+		Nonce:         a.Nonce,
+	})
+	if err != nil {
+		logger.Error("oidc: issue code", "client", a.ClientId, "err", err)
+		srv.redirectError(w, r, a, "server_error", "the authorization code could not be issued")
+		return
+	}
+	srv.redirect(w, r, a, url.Values{"code": {code}})
+}
+
+// oidcSession returns the claims of the browser's Globular session, or nil.
+func (srv *server) oidcSession(r *http.Request) *security.Claims {
+	c, err := r.Cookie(oidcSessionCookie)
+	if err != nil || c.Value == "" {
+		return nil
+	}
+	claims, err := srv.oidcBackend().session(c.Value)
+	if err != nil || claims.ID == "" {
+		return nil
+	}
+	return claims
+}
+
+func (srv *server) setOidcSession(w http.ResponseWriter, token string) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     oidcSessionCookie,
+		Value:    token,
+		Path:     "/oauth2/",
+		HttpOnly: true,
+		Secure:   srv.TLS,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+// requestContext carries the browser's address into the gRPC handlers, so
+// that sign-ins from the login page count towards its lockout.
+func requestContext(r *http.Request) context.Context {
+	ctx := r.Context()
+	if addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr); err == nil {
+		ctx = peer.NewContext(ctx, &peer.Peer{Addr: addr})
+	}
+	if fwd := r.Header.Get("X-Forwarded-For"); fwd != "" {
+		ctx = metadata.NewIncomingContext(ctx, metadata.Pairs("x-forwarded-for", fwd))
+	}
+	return ctx
+}
+
+func (srv *server) oidcLogin(w http.ResponseWriter, r *http.Request, a *authorizeRequest) {
+	rsp, err := srv.Authenticate(requestContext(r), &authenticationpb.AuthenticateRqst{
+		Name:     r.PostForm.Get("username"),
+		Password: r.PostForm.Get("password"),
+	})
+	switch {
+	case status.Code(err) == codes.ResourceExhausted:
+		srv.renderLogin(w, r, a, status.Convert(err).Message())
+	case err != nil:
+		srv.renderLogin(w, r, a, "Wrong user name or password.")
+	case rsp.MfaEnrollmentRequired:
+		srv.renderLogin(w, r, a, "Your account requires a second factor; enroll one in Globular before signing in here.")
+	case rsp.MfaRequired:
+		srv.renderMfa(w, r, a, rsp.MfaToken, "")
+	default:
+		srv.finishOidcLogin(w, r, a, rsp.Token)
+	}
+}
+
+func (srv *server) oidcVerifyMfa(w http.ResponseWriter, r *http.Request, a *authorizeRequest) {
+	mfaToken := r.PostForm.Get("mfa_token")
+	rsp, err := srv.VerifyMfa(requestContext(r), &authenticationpb.VerifyMfaRequest{
+		MfaToken: mfaToken,
+		Code:     strings.TrimSpace(r.PostForm.Get("code")),
+	})
+	switch {
+	case status.Code(err) == codes.Unauthenticated:
+		srv.renderLogin(w, r, a, status.Convert(err).Message())
+	case err != nil:
+		srv.renderMfa(w, r, a, mfaToken, "Wrong code.")
+	default:
+		srv.finishOidcLogin(w, r, a, rsp.Token)
+	}
+}
+
+func (srv *server) finishOidcLogin(w http.ResponseWriter, r *http.Request, a *authorizeRequest, token string) {
+	claims, err := srv.oidcBackend().session(token)
+	if err != nil {
+		logger.Error("oidc: validate new session", "err", err)
+		srv.redirectError(w, r, a, "server_error", "the session could not be established")
+		return
+	}
+	srv.setOidcSession(w, token)
+	srv.authorize(w, r, a, claims, true)
+}
+
+// ---- Token endpoint ------------------------------------------------------------
+
+func tokenError(w http.ResponseWriter, code int, errCode, description string) {
+	if code == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", `Basic realm="oauth2"`)
+	}
+	writeJSON(w, code, map[string]string{"error": errCode, "error_description": description})
+}
+
+// authenticateOidcClient identifies the client of a token request with
+// client_secret_basic, client_secret_post or, for public clients, its id.
+func (srv *server) authenticateOidcClient(r *http.Request) (*resourcepb.Application, error) {
+	id, secret, basic := r.BasicAuth()
+	if basic {
+		// RFC 6749 §2.3.1: the credentials are form-urlencoded.
+		id, _ = url.QueryUnescape(id)
+		secret, _ = url.QueryUnescape(secret)
+	} else {
+		id, secret = r.PostForm.Get("client_id"), r.PostForm.Get("client_secret")
+	}
+	if id == "" {
+		return nil, errors.New("client authentication is required")
+	}
+	app, err := srv.oidcClient(id)
+	if err != nil {
+		return nil, err
+	}
+	if !isOidcClient(app) {
+		return nil, errors.New("unknown client")
+	}
+	if isPublicOidcClient(app) {
+		if secret != "" {
+			return nil, errors.New("public clients have no secret")
+		}
+		return app, nil
+	}
+	if secret == "" || bcrypt.CompareHashAndPassword([]byte(app.Password), []byte(secret)) != nil {
+		return nil, errors.New("wrong client credentials")
+	}
+	return app, nil
+}
+
+func (srv *server) handleOidcToken(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		tokenError(w, http.StatusBadRequest, "invalid_request", "the request could not be read")
+		return
+	}
+	app, err := srv.authenticateOidcClient(r)
+	if err != nil {
+		tokenError(w, http.StatusUnauthorized, "invalid_client", err.Error())
+		return
+	}
+
+	var tokens *oidcTokens
+	switch r.PostForm.Get("grant_type") {
+	case "authorization_code":
+		tokens, err = srv.redeemCode(app, r.PostForm.Get("code"), r.PostForm.Get("redirect_uri"), r.PostForm.Get("code_verifier"))
+	case "refresh_token":
+		tokens, err = srv.redeemRefresh(app, r.PostForm.Get("refresh_token"), r.PostForm.Get("scope"))
+	default:
+		tokenError(w, http.StatusBadRequest, "unsupported_grant_type", "only authorization_code and refresh_token are supported")
+		return
+	}
+	if errors.Is(err, errOidcInvalidGrant) {
+		tokenError(w, http.StatusBadRequest, "invalid_grant", "the grant is invalid, expired or was already used")
+		return
+	}
+	if err != nil {
+		logger.Error("oidc: token", "client", app.Id, "err", err)
+		tokenError(w, http.StatusInternalServerError, "server_error", "the tokens could not be issued")
+		return
+	}
+	writeJSON(w, http.StatusOK, tokens)
+}
+
+// ---- UserInfo endpoint ---------------------------------------------------------
+
+func (srv *server) handleOidcUserinfo(w http.ResponseWriter, r *http.Request) {
+	raw, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		w.Header().Set("WWW-Authenticate", `Bearer realm="oauth2"`)
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_request"})
+		return
+	}
+	claims, err := srv.parseAccessToken(strings.TrimSpace(raw))
+	if err != nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+	sub, _ := claims["sub"].(string)
+	scope, _ := claims["scope"].(string)
+	account, err := srv.oidcBackend().account(sub)
+	if err != nil || account == nil {
+		w.Header().Set("WWW-Authenticate", `Bearer error="invalid_token"`)
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_token"})
+		return
+	}
+	writeJSON(w, http.StatusOK, oidcUserClaims(account, strings.Fields(scope)))
+}
+
+// ---- Pages ---------------------------------------------------------------------
+
+var oidcPages = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en"><head><meta charset="utf-8"><meta name="viewport" content="width=device-width, initial-scale=1">
+<title>Globular sign-in</title>
+<style>body{font-family:sans-serif;max-width:24rem;margin:4rem auto;padding:0 1rem}input,button{display:block;width:100%;margin:.5rem 0;padding:.5rem;box-sizing:border-box}.error{color:#b00020}</style>
+</head><body>
+{{if .Error}}<p class="error">{{.Error}}</p>{{end}}
+{{if eq .Page "login"}}
+<h1>Sign in</h1>
+<p>to continue to <strong>{{.Client}}</strong></p>
+<form method="post" action="/oauth2/authorize">{{template "hidden" .}}
+<input type="hidden" name="action" value="login">
+<input name="username" placeholder="User name" autocomplete="username" required autofocus>
+<input name="password" type="password" placeholder="Password" autocomplete="current-password" required>
+<button type="submit">Sign in</button></form>
+{{else if eq .Page "mfa"}}
+<h1>Verification</h1>
+<p>Enter the code from your authenticator app, or a recovery code.</p>
+<form method="post" action="/oauth2/authorize">{{template "hidden" .}}
+<input type="hidden" name="action" value="mfa">
+<input type="hidden" name="mfa_token" value="{{.MfaToken}}">
+<input name="code" placeholder="Code" autocomplete="one-time-code" required autofocus>
+<button type="submit">Verify</button></form>
+{{else if eq .Page "consent"}}
+<h1>Allow access?</h1>
+<p><strong>{{.Client}}</strong> wants to know who you are ({{.User}}){{if .Description}}: {{.Description}}{{end}}.</p>
+<ul>{{range .Scopes}}<li>{{.}}</li>{{end}}</ul>
+<form method="post" action="/oauth2/authorize">{{template "hidden" .}}
+<input type="hidden" name="action" value="consent">
+<button type="submit" name="decision" value="allow">Allow</button>
+<button type="submit" name="decision" value="deny">Deny</button></form>
+{{end}}
+</body></html>
+{{define "hidden"}}<input type="hidden" name="csrf" value="{{.Csrf}}">{{range $k, $v := .Hidden}}<input type="hidden" name="{{$k}}" value="{{$v}}">{{end}}{{end}}`))
+
+// scopeDescriptions are shown on the consent page.
+var scopeDescriptions = map[string]string{
+	"openid":  "Your user name, groups and roles",
+	"profile": "Your name",
+	"email":   "Your email address",
+}
+
+type oidcPage struct {
+	Page, Error, Client, Description, User, MfaToken, Csrf string
+	Hidden                                                 map[string]string
+	Scopes                                                 []string
+}
+
+// renderPage writes a form page, setting the CSRF cookie it echoes.
+func (srv *server) renderPage(w http.ResponseWriter, r *http.Request, a *authorizeRequest, p *oidcPage) {
+	csrf := ""
+	if c, err := r.Cookie(oidcCsrfCookie); err == nil && len(c.Value) >= 43 {
+		csrf = c.Value
+	} else {
+		var err error
+		if csrf, err = randomHandle(); err != nil {
+			srv.renderOidcError(w, http.StatusInternalServerError, "Internal error.")
+			return
+		}
+		http.SetCookie(w, &http.Cookie{
+			Name:     oidcCsrfCookie,
+			Value:    csrf,
+			Path:     "/oauth2/",
+			HttpOnly: true,
+			Secure:   srv.TLS,
+			SameSite: http.SameSiteStrictMode,
+		})
+	}
+	p.Csrf = csrf
+	p.Hidden = a.hidden()
+	p.Client = a.app.Name
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'; form-action 'self'; frame-ancestors 'none'")
+	if err := oidcPages.Execute(w, p); err != nil {
+		logger.Error("oidc: render page", "page", p.Page, "err", err)
+	}
+}
+
+func (srv *server) renderLogin(w http.ResponseWriter, r *http.Request, a *authorizeRequest, msg string) {
+	srv.renderPage(w, r, a, &oidcPage{Page: "login", Error: msg})
+}
+
+func (srv *server) renderMfa(w http.ResponseWriter, r *http.Request, a *authorizeRequest, mfaToken, msg string) {
+	srv.renderPage(w, r, a, &oidcPage{Page: "mfa", Error: msg, MfaToken: mfaToken})
+}
+
+func (srv *server) renderConsent(w http.ResponseWriter, r *http.Request, a *authorizeRequest, claims *security.Claims) {
+	scopes := make([]string, 0, len(a.scopes))
+	for _, s := range a.scopes {
+		scopes = append(scopes, scopeDescriptions[s])
+	}
+	srv.renderPage(w, r, a, &oidcPage{Page: "consent", User: claims.ID, Description: a.app.Description, Scopes: scopes})
+}
+
+func (srv *server) renderOidcError(w http.ResponseWriter, code int, msg string) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = oidcPages.Execute(w, &oidcPage{Error: msg})
+}
+
+// validCsrf checks the double-submitted CSRF token of a form.
+func validCsrf(r *http.Request) bool {
+	c, err := r.Cookie(oidcCsrfCookie)
+	if err != nil || c.Value == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(c.Value), []byte(r.PostForm.Get("csrf"))) == 1
+}
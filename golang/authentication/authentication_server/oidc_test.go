@@ -0,0 +1,524 @@
+package main
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/resource/resourcepb"
+	"github.com/globulario/services/golang/security"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// memOidcStore is an in-memory oidcStore.
+type memOidcStore struct {
+	grants   map[string]*oidcGrant
+	expires  map[string]time.Time
+	consents map[string][]string
+}
+
+func newMemOidcStore() *memOidcStore {
+	return &memOidcStore{grants: map[string]*oidcGrant{}, expires: map[string]time.Time{}, consents: map[string][]string{}}
+}
+
+func (m *memOidcStore) putGrant(key string, g *oidcGrant, ttl time.Duration) error {
+	c := *g
+	m.grants[key], m.expires[key] = &c, time.Now().Add(ttl)
+	return nil
+}
+
+func (m *memOidcStore) takeGrant(key string) (*oidcGrant, error) {
+	g, ok := m.grants[key]
+	delete(m.grants, key)
+	if !ok || time.Now().After(m.expires[key]) {
+		return nil, nil
+	}
+	return g, nil
+}
+
+func (m *memOidcStore) consent(accountId, clientId string) ([]string, error) {
+	return m.consents[accountId+"/"+clientId], nil
+}
+
+func (m *memOidcStore) setConsent(accountId, clientId string, scopes []string) error {
+	m.consents[accountId+"/"+clientId] = scopes
+	return nil
+}
+
+// fakeOidcBackend serves clients, accounts and sessions from maps.
+type fakeOidcBackend struct {
+	apps     map[string]*resourcepb.Application
+	accounts map[string]*resourcepb.Account
+	sessions map[string]*security.Claims
+	lookups  []string // client ids looked up
+}
+
+func (f *fakeOidcBackend) client(id string) (*resourcepb.Application, error) {
+	f.lookups = append(f.lookups, id)
+	return f.apps[id], nil
+}
+
+func (f *fakeOidcBackend) registerClient(_ string, app *resourcepb.Application) error {
+	f.apps[app.Id] = app
+	return nil
+}
+
+func (f *fakeOidcBackend) account(id string) (*resourcepb.Account, error) {
+	if a, ok := f.accounts[id]; ok {
+		return a, nil
+	}
+	return nil, errors.New("no account " + id)
+}
+
+func (f *fakeOidcBackend) session(token string) (*security.Claims, error) {
+	if c, ok := f.sessions[token]; ok {
+		return c, nil
+	}
+	return nil, errors.New("invalid session")
+}
+
+const (
+	testIssuer   = "https://id.example.test"
+	notesUri     = "https://notes.example.test/callback"
+	wikiUri      = "https://wiki.example.test/oidc"
+	wikiSecret   = "wiki-secret"
+	testVerifier = "dBjftJeZ4CVP-mB92K27uhbUJU1p1r_wW1gFWFOEjXk-extra-entropy"
+)
+
+// newOidcTestServer returns a provider with a public client (notes), a
+// confidential one (wiki), the account alice signed in as session "sess",
+// and its httptest server.
+func newOidcTestServer(t *testing.T) (*server, *httptest.Server) {
+	t.Helper()
+	pub, priv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	prevSign, prevKeys := signOidcClaims, oidcPublicKeys
+	signOidcClaims = func(claims jwt.Claims) (string, error) {
+		token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+		token.Header["kid"] = "k1"
+		return token.SignedString(priv)
+	}
+	oidcPublicKeys = func() (map[string]ed25519.PublicKey, error) {
+		return map[string]ed25519.PublicKey{"k1": pub}, nil
+	}
+	t.Cleanup(func() { signOidcClaims, oidcPublicKeys = prevSign, prevKeys })
+
+	hash, _ := bcrypt.GenerateFromPassword([]byte(wikiSecret), bcrypt.MinCost)
+	srv := &server{OidcIssuer: testIssuer + "/", SessionTimeout: 15}
+	srv.oidc = newMemOidcStore()
+	srv.oidcBackend_ = &fakeOidcBackend{
+		apps: map[string]*resourcepb.Application{
+			"notes": {Id: "notes", Name: "notes", Password: "notes", RedirectUris: []string{notesUri}},
+			"wiki":  {Id: "wiki", Name: "wiki", Password: string(hash), RedirectUris: []string{wikiUri}},
+			"plain": {Id: "plain", Name: "plain", Password: "plain"},
+		},
+		accounts: map[string]*resourcepb.Account{
+			"alice": {Id: "alice", Name: "alice", Email: "alice@example.test", FirstName: "Alice", LastName: "Liddell",
+				Groups: []string{"editors"}, Roles: []string{"writer"}},
+		},
+		sessions: map[string]*security.Claims{
+			"sess": {ID: "alice", RegisteredClaims: jwt.RegisteredClaims{IssuedAt: jwt.NewNumericDate(time.Unix(1_700_000_000, 0))}},
+		},
+	}
+	hs := httptest.NewServer(srv.oidcHandler())
+	t.Cleanup(hs.Close)
+	return srv, hs
+}
+
+func noRedirect(*http.Request, []*http.Request) error { return http.ErrUseLastResponse }
+
+func challengeOf(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+func authorizeQuery(clientId, redirectUri string, extra ...string) url.Values {
+	q := url.Values{
+		"response_type":         {"code"},
+		"client_id":             {clientId},
+		"redirect_uri":          {redirectUri},
+		"scope":                 {"openid profile email unknown"},
+		"state":                 {"st4te"},
+		"nonce":                 {"n0nce"},
+		"code_challenge":        {challengeOf(testVerifier)},
+		"code_challenge_method": {"S256"},
+	}
+	for i := 0; i+1 < len(extra); i += 2 {
+		q.Set(extra[i], extra[i+1])
+	}
+	return q
+}
+
+// authorize sends an authorization request with the session cookie and
+// returns the response without following redirects.
+func authorize(t *testing.T, hs *httptest.Server, q url.Values, session string) *http.Response {
+	t.Helper()
+	req, _ := http.NewRequest(http.MethodGet, hs.URL+"/oauth2/authorize?"+q.Encode(), nil)
+	if session != "" {
+		req.AddCookie(&http.Cookie{Name: oidcSessionCookie, Value: session})
+	}
+	rsp, err := (&http.Client{CheckRedirect: noRedirect}).Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rsp.Body.Close()
+	return rsp
+}
+
+// redirectParams checks that rsp redirects to uri and returns its query.
+func redirectParams(t *testing.T, rsp *http.Response, uri string) url.Values {
+	t.Helper()
+	if rsp.StatusCode != http.StatusFound {
+		t.Fatalf("status %d, want a redirect", rsp.StatusCode)
+	}
+	loc, err := url.Parse(rsp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := loc.Scheme + "://" + loc.Host + loc.Path; got != uri {
+		t.Fatalf("redirected to %s, want %s", got, uri)
+	}
+	return loc.Query()
+}
+
+// token posts a token request; a non-empty secret uses client_secret_basic.
+func token(t *testing.T, hs *httptest.Server, clientId, secret string, form url.Values) (int, map[string]any) {
+	t.Helper()
+	if secret == "" {
+		form.Set("client_id", clientId)
+	}
+	req, _ := http.NewRequest(http.MethodPost, hs.URL+"/oauth2/token", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if secret != "" {
+		req.SetBasicAuth(clientId, secret)
+	}
+	rsp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsp.Body.Close()
+	body := map[string]any{}
+	if err := json.NewDecoder(rsp.Body).Decode(&body); err != nil {
+		t.Fatal(err)
+	}
+	return rsp.StatusCode, body
+}
+
+func codeForm(code, redirectUri, verifier string) url.Values {
+	return url.Values{"grant_type": {"authorization_code"}, "code": {code}, "redirect_uri": {redirectUri}, "code_verifier": {verifier}}
+}
+
+// verifyIdToken checks an ID token against the published JWKS.
+func verifyIdToken(t *testing.T, hs *httptest.Server, raw string) jwt.MapClaims {
+	t.Helper()
+	rsp, err := http.Get(hs.URL + "/oauth2/jwks")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsp.Body.Close()
+	var jwks struct {
+		Keys []map[string]string `json:"keys"`
+	}
+	if err := json.NewDecoder(rsp.Body).Decode(&jwks); err != nil {
+		t.Fatal(err)
+	}
+	claims := jwt.MapClaims{}
+	_, err = jwt.ParseWithClaims(raw, claims, func(tok *jwt.Token) (any, error) {
+		for _, k := range jwks.Keys {
+			if k["kid"] == tok.Header["kid"] && k["kty"] == "OKP" && k["crv"] == "Ed25519" && k["alg"] == "EdDSA" {
+				x, err := base64.RawURLEncoding.DecodeString(k["x"])
+				return ed25519.PublicKey(x), err
+			}
+		}
+		return nil, errors.New("key not published")
+	}, jwt.WithIssuer(testIssuer), jwt.WithAudience("wiki"))
+	if err != nil {
+		t.Fatalf("id token: %v", err)
+	}
+	return claims
+}
+
+func TestOidcDiscovery(t *testing.T) {
+	_, hs := newOidcTestServer(t)
+	rsp, err := http.Get(hs.URL + "/.well-known/openid-configuration")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer rsp.Body.Close()
+	var doc map[string]any
+	if err := json.NewDecoder(rsp.Body).Decode(&doc); err != nil {
+		t.Fatal(err)
+	}
+	if doc["issuer"] != testIssuer || doc["token_endpoint"] != testIssuer+"/oauth2/token" || doc["jwks_uri"] != testIssuer+"/oauth2/jwks" {
+		t.Errorf("discovery: %v", doc)
+	}
+}
+
+func TestOidcCodeFlow(t *testing.T) {
+	srv, hs := newOidcTestServer(t)
+	srv.oidc.setConsent("alice", "wiki", []string{"openid", "profile", "email"})
+
+	params := redirectParams(t, authorize(t, hs, authorizeQuery("wiki", wikiUri), "sess"), wikiUri)
+	if params.Get("state") != "st4te" || params.Get("iss") != testIssuer || params.Get("code") == "" {
+		t.Fatalf("authorization response: %v", params)
+	}
+	code := params.Get("code")
+
+	status, body := token(t, hs, "wiki", wikiSecret, codeForm(code, wikiUri, testVerifier))
+	if status != http.StatusOK {
+		t.Fatalf("token: %d %v", status, body)
+	}
+	if body["token_type"] != "Bearer" || body["scope"] != "openid profile email" {
+		t.Errorf("token response: %v", body)
+	}
+
+	id := verifyIdToken(t, hs, body["id_token"].(string))
+	if id["sub"] != "alice" || id["nonce"] != "n0nce" || id["email"] != "alice@example.test" || id["name"] != "Alice Liddell" {
+		t.Errorf("id token claims: %v", id)
+	}
+	if id["auth_time"] != float64(1_700_000_000) {
+		t.Errorf("auth_time = %v", id["auth_time"])
+	}
+	if g, _ := id["groups"].([]any); len(g) != 1 || g[0] != "editors" {
+		t.Errorf("groups = %v", id["groups"])
+	}
+	if r, _ := id["roles"].([]any); len(r) != 1 || r[0] != "writer" {
+		t.Errorf("roles = %v", id["roles"])
+	}
+
+	// The access token opens userinfo; the ID token does not.
+	userinfo := func(bearer string) (int, map[string]any) {
+		req, _ := http.NewRequest(http.MethodGet, hs.URL+"/oauth2/userinfo", nil)
+		req.Header.Set("Authorization", "Bearer "+bearer)
+		rsp, err := http.DefaultClient.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer rsp.Body.Close()
+		out := map[string]any{}
+		_ = json.NewDecoder(rsp.Body).Decode(&out)
+		return rsp.StatusCode, out
+	}
+	if status, info := userinfo(body["access_token"].(string)); status != http.StatusOK || info["sub"] != "alice" || info["email"] != "alice@example.test" {
+		t.Errorf("userinfo: %d %v", status, info)
+	}
+	if status, _ := userinfo(body["id_token"].(string)); status != http.StatusUnauthorized {
+		t.Errorf("userinfo with an id token: %d", status)
+	}
+
+	// A code is single use.
+	if status, body := token(t, hs, "wiki", wikiSecret, codeForm(code, wikiUri, testVerifier)); status != http.StatusBadRequest || body["error"] != "invalid_grant" {
+		t.Errorf("code reuse: %d %v", status, body)
+	}
+
+	// Refresh tokens rotate: the new one works once, the old one never again.
+	refresh := body["refresh_token"].(string)
+	form := url.Values{"grant_type": {"refresh_token"}, "refresh_token": {refresh}, "scope": {"openid"}}
+	status, rotated := token(t, hs, "wiki", wikiSecret, form)
+	if status != http.StatusOK || rotated["refresh_token"] == refresh || rotated["scope"] != "openid" {
+		t.Fatalf("refresh: %d %v", status, rotated)
+	}
+	if id := verifyIdToken(t, hs, rotated["id_token"].(string)); id["email"] != nil || id["nonce"] != nil {
+		t.Errorf("narrowed id token: %v", id)
+	}
+	if status, body := token(t, hs, "wiki", wikiSecret, form); status != http.StatusBadRequest || body["error"] != "invalid_grant" {
+		t.Errorf("refresh reuse: %d %v", status, body)
+	}
+	// A grant cannot be widened on refresh.
+	form = url.Values{"grant_type": {"refresh_token"}, "refresh_token": {rotated["refresh_token"].(string)}, "scope": {"openid email"}}
+	if status, _ := token(t, hs, "wiki", wikiSecret, form); status != http.StatusBadRequest {
+		t.Errorf("widened refresh: %d", status)
+	}
+}
+
+func TestOidcTokenRejections(t *testing.T) {
+	srv, hs := newOidcTestServer(t)
+	srv.oidc.setConsent("alice", "notes", []string{"openid", "profile", "email"})
+	srv.oidc.setConsent("alice", "wiki", []string{"openid", "profile", "email"})
+	code := func(clientId, uri string) string {
+		return redirectParams(t, authorize(t, hs, authorizeQuery(clientId, uri), "sess"), uri).Get("code")
+	}
+
+	// A public client redeems with PKCE alone, but needs the right verifier.
+	if status, body := token(t, hs, "notes", "", codeForm(code("notes", notesUri), notesUri, testVerifier+"x")); status != http.StatusBadRequest || body["error"] != "invalid_grant" {
+		t.Errorf("wrong verifier: %d %v", status, body)
+	}
+	if status, body := token(t, hs, "notes", "", codeForm(code("notes", notesUri), notesUri, testVerifier)); status != http.StatusOK {
+		t.Errorf("public client: %d %v", status, body)
+	}
+
+	// A confidential client must authenticate, as itself.
+	if status, body := token(t, hs, "wiki", "", codeForm(code("wiki", wikiUri), wikiUri, testVerifier)); status != http.StatusUnauthorized || body["error"] != "invalid_client" {
+		t.Errorf("missing secret: %d %v", status, body)
+	}
+	if status, _ := token(t, hs, "wiki", "wrong", codeForm(code("wiki", wikiUri), wikiUri, testVerifier)); status != http.StatusUnauthorized {
+		t.Errorf("wrong secret: %d", status)
+	}
+	if status, _ := token(t, hs, "notes", "", codeForm(code("wiki", wikiUri), wikiUri, testVerifier)); status != http.StatusBadRequest {
+		t.Errorf("code of another client: %d", status)
+	}
+	// The redirect uri of the exchange must be the one of the request.
+	if status, _ := token(t, hs, "wiki", wikiSecret, codeForm(code("wiki", wikiUri), wikiUri+"/other", testVerifier)); status != http.StatusBadRequest {
+		t.Errorf("redirect uri mismatch: %d", status)
+	}
+}
+
+func TestOidcClientIdInjection(t *testing.T) {
+	srv, hs := newOidcTestServer(t)
+	backend := srv.oidcBackend().(*fakeOidcBackend)
+
+	// Client ids come from the request; ones that could not have been
+	// registered are unknown without ever reaching the resource query.
+	for _, id := range []string{`wiki","$ne":"`, `{"$gt":""}`, `$where`, `wiki"`} {
+		backend.lookups = nil
+		if rsp := authorize(t, hs, authorizeQuery(id, wikiUri), "sess"); rsp.StatusCode != http.StatusBadRequest {
+			t.Errorf("authorize %q: status %d, want 400", id, rsp.StatusCode)
+		}
+		if status, body := token(t, hs, id, wikiSecret, codeForm("code", wikiUri, testVerifier)); status != http.StatusUnauthorized || body["error"] != "invalid_client" {
+			t.Errorf("token %q: %d %v", id, status, body)
+		}
+		if len(backend.lookups) != 0 {
+			t.Errorf("%q was looked up: %q", id, backend.lookups)
+		}
+	}
+}
+
+func TestOidcAuthorizeErrors(t *testing.T) {
+	srv, hs := newOidcTestServer(t)
+
+	// Errors about the client or its redirect uri are never redirected.
+	for _, q := range []url.Values{
+		authorizeQuery("wiki", "https://evil.example.test/cb"),
+		authorizeQuery("wiki", wikiUri+"/"),
+		authorizeQuery("nobody", wikiUri),
+		authorizeQuery("plain", wikiUri), // an application that is not an OIDC client
+	} {
+		if rsp := authorize(t, hs, q, "sess"); rsp.StatusCode != http.StatusBadRequest {
+			t.Errorf("%s %s: status %d, want 400", q.Get("client_id"), q.Get("redirect_uri"), rsp.StatusCode)
+		}
+	}
+
+	for _, tc := range []struct {
+		name    string
+		q       url.Values
+		session string
+		want    string
+	}{
+		{"no pkce", authorizeQuery("wiki", wikiUri, "code_challenge", ""), "sess", "invalid_request"},
+		{"plain pkce", authorizeQuery("wiki", wikiUri, "code_challenge_method", "plain"), "sess", "invalid_request"},
+		{"no openid", authorizeQuery("wiki", wikiUri, "scope", "profile"), "sess", "invalid_scope"},
+		{"implicit", authorizeQuery("wiki", wikiUri, "response_type", "token"), "sess", "unsupported_response_type"},
+		{"not signed in", authorizeQuery("wiki", wikiUri, "prompt", "none"), "", "login_required"},
+		{"expired session", authorizeQuery("wiki", wikiUri, "prompt", "none"), "stale", "login_required"},
+		{"no consent", authorizeQuery("wiki", wikiUri, "prompt", "none"), "sess", "consent_required"},
+	} {
+		params := redirectParams(t, authorize(t, hs, tc.q, tc.session), wikiUri)
+		if params.Get("error") != tc.want || params.Get("state") != "st4te" {
+			t.Errorf("%s: %v, want %s", tc.name, params, tc.want)
+		}
+	}
+
+	// Consent to fewer scopes than requested does not cover the request.
+	srv.oidc.setConsent("alice", "wiki", []string{"openid"})
+	if params := redirectParams(t, authorize(t, hs, authorizeQuery("wiki", wikiUri, "prompt", "none"), "sess"), wikiUri); params.Get("error") != "consent_required" {
+		t.Errorf("partial consent: %v", params)
+	}
+
+	// Without a session or with prompt=login, the login page is shown.
+	for _, rsp := range []*http.Response{
+		authorize(t, hs, authorizeQuery("wiki", wikiUri), ""),
+		authorize(t, hs, authorizeQuery("wiki", wikiUri, "prompt", "login"), "sess"),
+	} {
+		if rsp.StatusCode != http.StatusOK || !strings.HasPrefix(rsp.Header.Get("Content-Type"), "text/html") {
+			t.Errorf("login page: %d %s", rsp.StatusCode, rsp.Header.Get("Content-Type"))
+		}
+	}
+}
+
+func TestOidcConsentForm(t *testing.T) {
+	srv, hs := newOidcTestServer(t)
+	client := &http.Client{CheckRedirect: noRedirect}
+
+	post := func(csrfCookie, csrfField, decision string) *http.Response {
+		form := authorizeQuery("wiki", wikiUri)
+		form.Set("action", "consent")
+		form.Set("decision", decision)
+		form.Set("csrf", csrfField)
+		req, _ := http.NewRequest(http.MethodPost, hs.URL+"/oauth2/authorize", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.AddCookie(&http.Cookie{Name: oidcSessionCookie, Value: "sess"})
+		if csrfCookie != "" {
+			req.AddCookie(&http.Cookie{Name: oidcCsrfCookie, Value: csrfCookie})
+		}
+		rsp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rsp.Body.Close()
+		return rsp
+	}
+
+	// The consent page sets the CSRF cookie its form echoes.
+	rsp := authorize(t, hs, authorizeQuery("wiki", wikiUri), "sess")
+	var csrf string
+	for _, c := range rsp.Cookies() {
+		if c.Name == oidcCsrfCookie {
+			csrf = c.Value
+		}
+	}
+	if rsp.StatusCode != http.StatusOK || csrf == "" {
+		t.Fatalf("consent page: %d, csrf %q", rsp.StatusCode, csrf)
+	}
+
+	if rsp := post("", csrf, "allow"); rsp.StatusCode != http.StatusBadRequest {
+		t.Errorf("without the csrf cookie: %d", rsp.StatusCode)
+	}
+	if rsp := post(csrf, csrf+"x", "allow"); rsp.StatusCode != http.StatusBadRequest {
+		t.Errorf("with a wrong csrf token: %d", rsp.StatusCode)
+	}
+	if params := redirectParams(t, post(csrf, csrf, "deny"), wikiUri); params.Get("error") != "access_denied" {
+		t.Errorf("deny: %v", params)
+	}
+	if params := redirectParams(t, post(csrf, csrf, "allow"), wikiUri); params.Get("code") == "" {
+		t.Errorf("allow: %v", params)
+	}
+	granted, _ := srv.oidc.consent("alice", "wiki")
+	if !slices.Equal(granted, []string{"openid", "profile", "email"}) {
+		t.Errorf("stored consent = %v", granted)
+	}
+	// The next request goes straight through.
+	if params := redirectParams(t, authorize(t, hs, authorizeQuery("wiki", wikiUri, "prompt", "none"), "sess"), wikiUri); params.Get("code") == "" {
+		t.Errorf("after consent: %v", params)
+	}
+}
+
+func TestValidateRedirectUri(t *testing.T) {
+	for uri, ok := range map[string]bool{
+		"https://app.example.test/cb":      true,
+		"https://app.example.test/cb?x=1":  true,
+		"http://localhost:8080/cb":         true,
+		"http://127.0.0.1/cb":              true,
+		"http://[::1]:9000/cb":             true,
+		"http://app.example.test/cb":       false,
+		"https://app.example.test/cb#frag": false,
+		"/cb":                              false,
+		"javascript:alert(1)":              false,
+	} {
+		if err := validateRedirectUri(uri); (err == nil) != ok {
+			t.Errorf("validateRedirectUri(%q) = %v", uri, err)
+		}
+	}
+}
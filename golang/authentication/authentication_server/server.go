@@ -7,6 +7,7 @@
 package main
 
 import (
+	"context"
 	"encoding/json"
 	"flag"
 	"fmt"
@@ -20,6 +21,7 @@ import (
 	"github.com/globulario/services/golang/authentication/authenticationpb"
 	"github.com/globulario/services/golang/config"
 	globular "github.com/globulario/services/golang/globular_service"
+	"github.com/globulario/services/golang/interceptors"
 	"github.com/globulario/services/golang/policy"
 	"github.com/globulario/services/golang/resource/resourcepb"
 	Utility "github.com/globulario/utility"
@@ -96,6 +98,20 @@ type server struct {
 	WatchSessionsDelay int
 	SessionTimeout     int
 	LdapConnectionId   string
+	MfaTimeout         int // minutes an mfa_pending token lives
+
+	// Sign-in lockout (see lockout.go); zero means the default, a negative
+	// threshold disables locking.
+	LockoutThreshold   int // failures that lock an account
+	LockoutIpThreshold int // failures that lock a client address
+	LockoutWindow      int // minutes after which failures are forgotten
+	LockoutDuration    int // minutes of the first lock, doubled per further failure
+	LockoutMaxDuration int // minutes a lock lasts at most
+
+	// OpenID Connect provider (see oidc.go); a zero port disables it.
+	OidcPort           int
+	OidcIssuer         string // issuer URL; defaults to the provider's address on this node
+	OidcRefreshTimeout int    // days a refresh token lives
 
 	// gRPC runtime
 	grpcServer *grpc.Server
@@ -103,6 +119,14 @@ type server struct {
 	// Background controls
 	exitCh           chan struct{}
 	authentications_ []string
+
+	// Multi-factor authentication (see mfa.go)
+	mfa mfaStore
+
+	lockouts lockoutStore
+
+	oidc         oidcStore
+	oidcBackend_ oidcBackend
 }
 
 // --- Getters/Setters required by Globular (unchanged signatures) ---
@@ -209,6 +233,15 @@ func (srv *server) Init() error {
 	if err := globular.InitService(srv); err != nil {
 		return err
 	}
+	// The second step of a login and enrollment from it carry an mfa_pending
+	// token instead of a session; the handlers check it themselves.
+	interceptors.AllowUnauthenticated(
+		"/authentication.AuthenticationService/VerifyMfa",
+		"/authentication.AuthenticationService/EnrollTotp",
+		"/authentication.AuthenticationService/ConfirmTotp",
+		"/authentication.AuthenticationService/BeginWebAuthnRegistration",
+		"/authentication.AuthenticationService/FinishWebAuthnRegistration",
+	)
 	gs, err := globular.InitGrpcServer(srv) // interceptors wired internally
 	if err != nil {
 		return err
@@ -243,6 +276,14 @@ func (srv *server) StartService() error {
 		return err
 	}
 
+	// The OIDC provider stops with the service.
+	oidcCtx, stopOidc := context.WithCancel(context.Background())
+	go func(exit chan struct{}) {
+		<-exit
+		stopOidc()
+	}(srv.exitCh)
+	srv.startOidc(oidcCtx)
+
 	return globular.StartService(srv, srv.grpcServer)
 }
 
@@ -356,6 +397,13 @@ func initializeServerDefaults() *server {
 
 	s.WatchSessionsDelay = 60
 	s.SessionTimeout = 15
+	s.MfaTimeout = defaultMfaTimeout
+	s.LockoutThreshold = defaultLockoutThreshold
+	s.LockoutIpThreshold = defaultLockoutIpThreshold
+	s.LockoutWindow = defaultLockoutWindow
+	s.LockoutDuration = defaultLockoutDuration
+	s.LockoutMaxDuration = defaultLockoutMaxDuration
+	s.OidcRefreshTimeout = defaultOidcRefreshTimeout
 	s.Process = -1
 	s.ProxyProcess = -1
 	s.KeepAlive = true
@@ -476,6 +524,19 @@ func main() {
 		{Method: "/authentication.AuthenticationService/GeneratePeerToken", Action: "auth.peer.token"},
 		{Method: "/authentication.AuthenticationService/SetRootPassword", Action: "auth.root.password"},
 		{Method: "/authentication.AuthenticationService/SetRootEmail", Action: "auth.root.email"},
+		{Method: "/authentication.AuthenticationService/VerifyMfa", Action: "auth.mfa.verify"},
+		{Method: "/authentication.AuthenticationService/EnrollTotp", Action: "auth.mfa.enroll"},
+		{Method: "/authentication.AuthenticationService/ConfirmTotp", Action: "auth.mfa.enroll"},
+		{Method: "/authentication.AuthenticationService/RegenerateRecoveryCodes", Action: "auth.mfa.enroll"},
+		{Method: "/authentication.AuthenticationService/BeginWebAuthnRegistration", Action: "auth.mfa.enroll"},
+		{Method: "/authentication.AuthenticationService/FinishWebAuthnRegistration", Action: "auth.mfa.enroll"},
+		{Method: "/authentication.AuthenticationService/GetMfaStatus", Action: "auth.mfa.status"},
+		{Method: "/authentication.AuthenticationService/SetMfaPolicy", Action: "auth.mfa.policy"},
+		{Method: "/authentication.AuthenticationService/GetMfaPolicy", Action: "auth.mfa.policy.read"},
+		{Method: "/authentication.AuthenticationService/ResetMfa", Action: "auth.mfa.reset"},
+		{Method: "/authentication.AuthenticationService/UnlockAccount", Action: "auth.lockout.unlock"},
+		{Method: "/authentication.AuthenticationService/ListLockedAccounts", Action: "auth.lockout.list"},
+		{Method: "/authentication.AuthenticationService/RegisterOidcClient", Action: "auth.oidc.clients"},
 	})
 
 	// Handle --describe and --health flags
@@ -0,0 +1,412 @@
+package main
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+)
+
+// ---- WebAuthn (passkeys) ----------------------------------------------------
+//
+// Registration and assertion follow WebAuthn Level 2 §7 with attestation
+// "none": the authenticator's attestation statement is not verified, only the
+// credential it creates. Options and responses travel as the JSON forms of
+// the browser API (binary fields base64url encoded), so a web client can hand
+// them to PublicKeyCredential.parse*OptionsFromJSON and toJSON().
+
+// COSE algorithm identifiers offered at registration.
+const (
+	coseES256 = -7
+	coseEdDSA = -8
+	coseRS256 = -257
+)
+
+// Authenticator data flags.
+const (
+	flagUserPresent  = 0x01
+	flagAttestedData = 0x40
+)
+
+const webAuthnTimeoutMs = 300000
+
+// webAuthnCredential is a registered passkey as stored in the MFA record.
+type webAuthnCredential struct {
+	ID        string `json:"id"` // base64url credential id
+	Name      string `json:"name,omitempty"`
+	PublicKey []byte `json:"public_key"` // PKIX DER
+	Alg       int64  `json:"alg"`        // COSE algorithm
+	SignCount uint32 `json:"sign_count"`
+	Created   int64  `json:"created"`
+	LastUsed  int64  `json:"last_used,omitempty"`
+}
+
+// b64url encodes binary fields of the WebAuthn JSON forms.
+func b64url(b []byte) string { return base64.RawURLEncoding.EncodeToString(b) }
+
+// decodeB64url accepts base64url with or without padding, and standard base64,
+// which some clients send for binary fields.
+func decodeB64url(s string) ([]byte, error) {
+	s = strings.TrimRight(s, "=")
+	s = strings.NewReplacer("+", "-", "/", "_").Replace(s)
+	return base64.RawURLEncoding.DecodeString(s)
+}
+
+func credentialDescriptors(creds []*webAuthnCredential) []map[string]any {
+	out := make([]map[string]any, 0, len(creds))
+	for _, c := range creds {
+		out = append(out, map[string]any{"type": "public-key", "id": c.ID})
+	}
+	return out
+}
+
+// webAuthnCreationOptions returns PublicKeyCredentialCreationOptions for a
+// new passkey of accountId; existing ones are excluded.
+func webAuthnCreationOptions(rpID, accountId, displayName string, challenge []byte, existing []*webAuthnCredential) (string, error) {
+	if displayName == "" {
+		displayName = accountId
+	}
+	opts := map[string]any{
+		"rp":        map[string]any{"id": rpID, "name": rpID},
+		"user":      map[string]any{"id": b64url([]byte(accountId)), "name": accountId, "displayName": displayName},
+		"challenge": b64url(challenge),
+		"pubKeyCredParams": []map[string]any{
+			{"type": "public-key", "alg": coseES256},
+			{"type": "public-key", "alg": coseEdDSA},
+			{"type": "public-key", "alg": coseRS256},
+		},
+		"timeout":            webAuthnTimeoutMs,
+		"attestation":        "none",
+		"excludeCredentials": credentialDescriptors(existing),
+		"authenticatorSelection": map[string]any{
+			"residentKey":      "preferred",
+			"userVerification": "preferred",
+		},
+	}
+	b, err := json.Marshal(opts)
+	return string(b), err
+}
+
+// webAuthnRequestOptions returns PublicKeyCredentialRequestOptions allowing
+// the given credentials.
+func webAuthnRequestOptions(rpID string, challenge []byte, creds []*webAuthnCredential) (string, error) {
+	opts := map[string]any{
+		"rpId":             rpID,
+		"challenge":        b64url(challenge),
+		"timeout":          webAuthnTimeoutMs,
+		"allowCredentials": credentialDescriptors(creds),
+		"userVerification": "preferred",
+	}
+	b, err := json.Marshal(opts)
+	return string(b), err
+}
+
+// originAllowed accepts https origins on rpID or one of its subdomains, and
+// http on localhost for development.
+func originAllowed(origin, rpID string) bool {
+	u, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	host := strings.ToLower(u.Hostname())
+	rpID = strings.ToLower(rpID)
+	if u.Scheme != "https" && !(u.Scheme == "http" && host == "localhost") {
+		return false
+	}
+	return host == rpID || strings.HasSuffix(host, "."+rpID)
+}
+
+// checkClientData verifies the collected client data of a ceremony.
+func checkClientData(raw []byte, typ string, challenge []byte, rpID string) error {
+	var cd struct {
+		Type      string `json:"type"`
+		Challenge string `json:"challenge"`
+		Origin    string `json:"origin"`
+	}
+	if err := json.Unmarshal(raw, &cd); err != nil {
+		return fmt.Errorf("webauthn: client data: %w", err)
+	}
+	if cd.Type != typ {
+		return fmt.Errorf("webauthn: client data type %q, want %q", cd.Type, typ)
+	}
+	got, err := decodeB64url(cd.Challenge)
+	if err != nil || !bytes.Equal(got, challenge) {
+		return errors.New("webauthn: challenge mismatch")
+	}
+	if !originAllowed(cd.Origin, rpID) {
+		return fmt.Errorf("webauthn: origin %q not allowed for %s", cd.Origin, rpID)
+	}
+	return nil
+}
+
+// authenticatorData is the parsed authData of a ceremony.
+type authenticatorData struct {
+	raw       []byte
+	rpIDHash  []byte
+	flags     byte
+	signCount uint32
+	credID    []byte // registration only
+	coseKey   []byte // registration only
+}
+
+func parseAuthenticatorData(b []byte) (*authenticatorData, error) {
+	if len(b) < 37 {
+		return nil, errors.New("webauthn: authenticator data too short")
+	}
+	ad := &authenticatorData{
+		raw:       b,
+		rpIDHash:  b[:32],
+		flags:     b[32],
+		signCount: binary.BigEndian.Uint32(b[33:37]),
+	}
+	if ad.flags&flagAttestedData != 0 {
+		rest := b[37:]
+		if len(rest) < 18 {
+			return nil, errors.New("webauthn: attested credential data too short")
+		}
+		n := int(binary.BigEndian.Uint16(rest[16:18]))
+		rest = rest[18:]
+		if len(rest) < n {
+			return nil, errors.New("webauthn: credential id truncated")
+		}
+		ad.credID, rest = rest[:n], rest[n:]
+		_, after, err := cborDecode(rest)
+		if err != nil {
+			return nil, fmt.Errorf("webauthn: credential public key: %w", err)
+		}
+		ad.coseKey = rest[:len(rest)-len(after)]
+	}
+	return ad, nil
+}
+
+func (ad *authenticatorData) check(rpID string) error {
+	want := sha256.Sum256([]byte(rpID))
+	if !bytes.Equal(ad.rpIDHash, want[:]) {
+		return errors.New("webauthn: relying party id mismatch")
+	}
+	if ad.flags&flagUserPresent == 0 {
+		return errors.New("webauthn: user not present")
+	}
+	return nil
+}
+
+// parseCOSEKey converts a COSE_Key (RFC 9053) to a public key and its algorithm.
+func parseCOSEKey(b []byte) (crypto.PublicKey, int64, error) {
+	v, _, err := cborDecode(b)
+	if err != nil {
+		return nil, 0, err
+	}
+	m, ok := v.(map[any]any)
+	if !ok {
+		return nil, 0, errors.New("webauthn: COSE key is not a map")
+	}
+	kty, _ := m[int64(1)].(int64)
+	alg, _ := m[int64(3)].(int64)
+	bytesAt := func(label int64) []byte { b, _ := m[label].([]byte); return b }
+
+	switch {
+	case kty == 2 && alg == coseES256:
+		if crv, _ := m[int64(-1)].(int64); crv != 1 {
+			return nil, 0, errors.New("webauthn: ES256 key not on P-256")
+		}
+		x, y := bytesAt(-2), bytesAt(-3)
+		if len(x) != 32 || len(y) != 32 {
+			return nil, 0, errors.New("webauthn: malformed EC2 key")
+		}
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), append(append([]byte{4}, x...), y...))
+		if err != nil {
+			return nil, 0, fmt.Errorf("webauthn: %w", err)
+		}
+		return pub, alg, nil
+	case kty == 1 && alg == coseEdDSA:
+		if crv, _ := m[int64(-1)].(int64); crv != 6 {
+			return nil, 0, errors.New("webauthn: EdDSA key is not Ed25519")
+		}
+		x := bytesAt(-2)
+		if len(x) != ed25519.PublicKeySize {
+			return nil, 0, errors.New("webauthn: malformed OKP key")
+		}
+		return ed25519.PublicKey(x), alg, nil
+	case kty == 3 && alg == coseRS256:
+		n, e := bytesAt(-1), bytesAt(-2)
+		if len(n) < 256 || len(e) == 0 || len(e) > 4 {
+			return nil, 0, errors.New("webauthn: malformed RSA key")
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, alg, nil
+	}
+	return nil, 0, fmt.Errorf("webauthn: unsupported key type %d / algorithm %d", kty, alg)
+}
+
+// verifyRegistration checks a RegistrationResponseJSON against the challenge
+// of the ceremony and returns the new credential.
+func verifyRegistration(rpID string, challenge []byte, credentialJSON string) (*webAuthnCredential, error) {
+	var rsp struct {
+		ID       string `json:"id"`
+		RawID    string `json:"rawId"`
+		Type     string `json:"type"`
+		Response struct {
+			ClientDataJSON    string `json:"clientDataJSON"`
+			AttestationObject string `json:"attestationObject"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal([]byte(credentialJSON), &rsp); err != nil {
+		return nil, fmt.Errorf("webauthn: credential: %w", err)
+	}
+	if rsp.Type != "public-key" {
+		return nil, fmt.Errorf("webauthn: credential type %q", rsp.Type)
+	}
+	clientData, err := decodeB64url(rsp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed clientDataJSON")
+	}
+	if err := checkClientData(clientData, "webauthn.create", challenge, rpID); err != nil {
+		return nil, err
+	}
+
+	attObj, err := decodeB64url(rsp.Response.AttestationObject)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed attestationObject")
+	}
+	v, _, err := cborDecode(attObj)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: attestation object: %w", err)
+	}
+	att, ok := v.(map[any]any)
+	if !ok {
+		return nil, errors.New("webauthn: attestation object is not a map")
+	}
+	raw, _ := att["authData"].([]byte)
+	ad, err := parseAuthenticatorData(raw)
+	if err != nil {
+		return nil, err
+	}
+	if err := ad.check(rpID); err != nil {
+		return nil, err
+	}
+	if ad.credID == nil {
+		return nil, errors.New("webauthn: no attested credential data")
+	}
+	if rawID, err := decodeB64url(rsp.RawID); err != nil || !bytes.Equal(rawID, ad.credID) {
+		return nil, errors.New("webauthn: rawId does not match the attested credential")
+	}
+
+	pub, alg, err := parseCOSEKey(ad.coseKey)
+	if err != nil {
+		return nil, err
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, fmt.Errorf("webauthn: %w", err)
+	}
+	return &webAuthnCredential{
+		ID:        b64url(ad.credID),
+		PublicKey: der,
+		Alg:       alg,
+		SignCount: ad.signCount,
+	}, nil
+}
+
+// verifyAssertion checks an AuthenticationResponseJSON made with one of
+// creds against challenge, and returns that credential with its signature
+// counter advanced.
+func verifyAssertion(rpID string, challenge []byte, assertionJSON string, creds []*webAuthnCredential) (*webAuthnCredential, error) {
+	var rsp struct {
+		RawID    string `json:"rawId"`
+		Type     string `json:"type"`
+		Response struct {
+			ClientDataJSON    string `json:"clientDataJSON"`
+			AuthenticatorData string `json:"authenticatorData"`
+			Signature         string `json:"signature"`
+		} `json:"response"`
+	}
+	if err := json.Unmarshal([]byte(assertionJSON), &rsp); err != nil {
+		return nil, fmt.Errorf("webauthn: assertion: %w", err)
+	}
+	if rsp.Type != "public-key" {
+		return nil, fmt.Errorf("webauthn: credential type %q", rsp.Type)
+	}
+	rawID, err := decodeB64url(rsp.RawID)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed rawId")
+	}
+	var cred *webAuthnCredential
+	for _, c := range creds {
+		if c.ID == b64url(rawID) {
+			cred = c
+			break
+		}
+	}
+	if cred == nil {
+		return nil, errors.New("webauthn: unknown credential")
+	}
+
+	clientData, err := decodeB64url(rsp.Response.ClientDataJSON)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed clientDataJSON")
+	}
+	if err := checkClientData(clientData, "webauthn.get", challenge, rpID); err != nil {
+		return nil, err
+	}
+	rawAD, err := decodeB64url(rsp.Response.AuthenticatorData)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed authenticatorData")
+	}
+	ad, err := parseAuthenticatorData(rawAD)
+	if err != nil {
+		return nil, err
+	}
+	if err := ad.check(rpID); err != nil {
+		return nil, err
+	}
+	sig, err := decodeB64url(rsp.Response.Signature)
+	if err != nil {
+		return nil, errors.New("webauthn: malformed signature")
+	}
+
+	cdHash := sha256.Sum256(clientData)
+	signed := append(append([]byte(nil), rawAD...), cdHash[:]...)
+	if err := verifySignature(cred, signed, sig); err != nil {
+		return nil, err
+	}
+
+	// A counter that does not move forward suggests a cloned authenticator;
+	// authenticators without a counter always report zero.
+	if (ad.signCount != 0 || cred.SignCount != 0) && ad.signCount <= cred.SignCount {
+		return nil, errors.New("webauthn: signature counter did not increase")
+	}
+	cred.SignCount = ad.signCount
+	return cred, nil
+}
+
+func verifySignature(cred *webAuthnCredential, signed, sig []byte) error {
+	pub, err := x509.ParsePKIXPublicKey(cred.PublicKey)
+	if err != nil {
+		return fmt.Errorf("webauthn: stored key: %w", err)
+	}
+	digest := sha256.Sum256(signed)
+	ok := false
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		ok = cred.Alg == coseES256 && ecdsa.VerifyASN1(k, digest[:], sig)
+	case ed25519.PublicKey:
+		ok = cred.Alg == coseEdDSA && ed25519.Verify(k, signed, sig)
+	case *rsa.PublicKey:
+		ok = cred.Alg == coseRS256 && rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], sig) == nil
+	}
+	if !ok {
+		return errors.New("webauthn: invalid signature")
+	}
+	return nil
+}
@@ -88,11 +88,18 @@ func (x *AuthenticateRqst) GetIssuer() string {
 }
 
 // AuthenticateRsp is the response to an authentication request.
+// When the account needs a second factor, token is empty and mfa_token must
+// be exchanged for a session token with VerifyMfa.
 type AuthenticateRsp struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state                 protoimpl.MessageState `protogen:"open.v1"`
+	Token                 string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	MfaRequired           bool                   `protobuf:"varint,2,opt,name=mfa_required,json=mfaRequired,proto3" json:"mfa_required,omitempty"`
+	MfaToken              string                 `protobuf:"bytes,3,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`                                           // Short-lived token with the "mfa_pending" scope; not a session.
+	MfaMethods            []string               `protobuf:"bytes,4,rep,name=mfa_methods,json=mfaMethods,proto3" json:"mfa_methods,omitempty"`                                     // Enrolled second factors: "totp", "webauthn", "recovery".
+	WebauthnOptions       string                 `protobuf:"bytes,5,opt,name=webauthn_options,json=webauthnOptions,proto3" json:"webauthn_options,omitempty"`                      // PublicKeyCredentialRequestOptions (JSON) when a passkey is enrolled.
+	MfaEnrollmentRequired bool                   `protobuf:"varint,6,opt,name=mfa_enrollment_required,json=mfaEnrollmentRequired,proto3" json:"mfa_enrollment_required,omitempty"` // The policy requires MFA and none is enrolled yet.
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *AuthenticateRsp) Reset() {
@@ -132,6 +139,41 @@ func (x *AuthenticateRsp) GetToken() string {
 	return ""
 }
 
+func (x *AuthenticateRsp) GetMfaRequired() bool {
+	if x != nil {
+		return x.MfaRequired
+	}
+	return false
+}
+
+func (x *AuthenticateRsp) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+func (x *AuthenticateRsp) GetMfaMethods() []string {
+	if x != nil {
+		return x.MfaMethods
+	}
+	return nil
+}
+
+func (x *AuthenticateRsp) GetWebauthnOptions() string {
+	if x != nil {
+		return x.WebauthnOptions
+	}
+	return ""
+}
+
+func (x *AuthenticateRsp) GetMfaEnrollmentRequired() bool {
+	if x != nil {
+		return x.MfaEnrollmentRequired
+	}
+	return false
+}
+
 // ValidateTokenRqst is a request to validate an authentication token.
 type ValidateTokenRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -712,31 +754,31 @@ func (x *GeneratePeerTokenResponse) GetToken() string {
 	return ""
 }
 
-// IssueClientCertificateResponse carries a newly issued client certificate and its signing CA.
-// The caller must store the private key securely; the server does NOT persist it.
-type IssueClientCertificateResponse struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	CaCrtPem      []byte                 `protobuf:"bytes,1,opt,name=ca_crt_pem,json=caCrtPem,proto3" json:"ca_crt_pem,omitempty"`             // PEM-encoded cluster CA certificate
-	ClientCrtPem  []byte                 `protobuf:"bytes,2,opt,name=client_crt_pem,json=clientCrtPem,proto3" json:"client_crt_pem,omitempty"` // PEM-encoded client certificate (signed by CA)
-	ClientKeyPem  []byte                 `protobuf:"bytes,3,opt,name=client_key_pem,json=clientKeyPem,proto3" json:"client_key_pem,omitempty"` // PEM-encoded client private key
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+// VerifyMfaRequest completes a login with a second factor: a TOTP code, a
+// recovery code, or a WebAuthn assertion.
+type VerifyMfaRequest struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	MfaToken          string                 `protobuf:"bytes,1,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	Code              string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	WebauthnAssertion string                 `protobuf:"bytes,3,opt,name=webauthn_assertion,json=webauthnAssertion,proto3" json:"webauthn_assertion,omitempty"` // AuthenticationResponseJSON from navigator.credentials.get.
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
 }
 
-func (x *IssueClientCertificateResponse) Reset() {
-	*x = IssueClientCertificateResponse{}
+func (x *VerifyMfaRequest) Reset() {
+	*x = VerifyMfaRequest{}
 	mi := &file_authentication_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *IssueClientCertificateResponse) String() string {
+func (x *VerifyMfaRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*IssueClientCertificateResponse) ProtoMessage() {}
+func (*VerifyMfaRequest) ProtoMessage() {}
 
-func (x *IssueClientCertificateResponse) ProtoReflect() protoreflect.Message {
+func (x *VerifyMfaRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_authentication_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -748,59 +790,1569 @@ func (x *IssueClientCertificateResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use IssueClientCertificateResponse.ProtoReflect.Descriptor instead.
-func (*IssueClientCertificateResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use VerifyMfaRequest.ProtoReflect.Descriptor instead.
+func (*VerifyMfaRequest) Descriptor() ([]byte, []int) {
 	return file_authentication_proto_rawDescGZIP(), []int{14}
 }
 
-func (x *IssueClientCertificateResponse) GetCaCrtPem() []byte {
+func (x *VerifyMfaRequest) GetMfaToken() string {
 	if x != nil {
-		return x.CaCrtPem
+		return x.MfaToken
 	}
-	return nil
+	return ""
 }
 
-func (x *IssueClientCertificateResponse) GetClientCrtPem() []byte {
+func (x *VerifyMfaRequest) GetCode() string {
 	if x != nil {
-		return x.ClientCrtPem
+		return x.Code
 	}
-	return nil
+	return ""
 }
 
-func (x *IssueClientCertificateResponse) GetClientKeyPem() []byte {
+func (x *VerifyMfaRequest) GetWebauthnAssertion() string {
 	if x != nil {
-		return x.ClientKeyPem
+		return x.WebauthnAssertion
+	}
+	return ""
+}
+
+// VerifyMfaResponse carries the session token.
+type VerifyMfaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Token         string                 `protobuf:"bytes,1,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *VerifyMfaResponse) Reset() {
+	*x = VerifyMfaResponse{}
+	mi := &file_authentication_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *VerifyMfaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*VerifyMfaResponse) ProtoMessage() {}
+
+func (x *VerifyMfaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use VerifyMfaResponse.ProtoReflect.Descriptor instead.
+func (*VerifyMfaResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *VerifyMfaResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// EnrollTotpRequest starts a TOTP enrollment. mfa_token may replace a session
+// when the policy requires MFA for an account that has none enrolled.
+type EnrollTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,2,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTotpRequest) Reset() {
+	*x = EnrollTotpRequest{}
+	mi := &file_authentication_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTotpRequest) ProtoMessage() {}
+
+func (x *EnrollTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTotpRequest.ProtoReflect.Descriptor instead.
+func (*EnrollTotpRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *EnrollTotpRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *EnrollTotpRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+// EnrollTotpResponse carries the new secret; it is active after ConfirmTotp.
+type EnrollTotpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Secret        string                 `protobuf:"bytes,1,opt,name=secret,proto3" json:"secret,omitempty"` // Base32, for manual entry.
+	Uri           string                 `protobuf:"bytes,2,opt,name=uri,proto3" json:"uri,omitempty"`       // otpauth:// URI, for QR codes.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EnrollTotpResponse) Reset() {
+	*x = EnrollTotpResponse{}
+	mi := &file_authentication_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EnrollTotpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EnrollTotpResponse) ProtoMessage() {}
+
+func (x *EnrollTotpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EnrollTotpResponse.ProtoReflect.Descriptor instead.
+func (*EnrollTotpResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *EnrollTotpResponse) GetSecret() string {
+	if x != nil {
+		return x.Secret
+	}
+	return ""
+}
+
+func (x *EnrollTotpResponse) GetUri() string {
+	if x != nil {
+		return x.Uri
+	}
+	return ""
+}
+
+// ConfirmTotpRequest activates a pending TOTP secret with a current code.
+type ConfirmTotpRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Code          string                 `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,3,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTotpRequest) Reset() {
+	*x = ConfirmTotpRequest{}
+	mi := &file_authentication_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTotpRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTotpRequest) ProtoMessage() {}
+
+func (x *ConfirmTotpRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTotpRequest.ProtoReflect.Descriptor instead.
+func (*ConfirmTotpRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *ConfirmTotpRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *ConfirmTotpRequest) GetCode() string {
+	if x != nil {
+		return x.Code
+	}
+	return ""
+}
+
+func (x *ConfirmTotpRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+// ConfirmTotpResponse returns fresh recovery codes, shown once, and a session
+// token when the enrollment was made with an mfa_token.
+type ConfirmTotpResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConfirmTotpResponse) Reset() {
+	*x = ConfirmTotpResponse{}
+	mi := &file_authentication_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConfirmTotpResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConfirmTotpResponse) ProtoMessage() {}
+
+func (x *ConfirmTotpResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConfirmTotpResponse.ProtoReflect.Descriptor instead.
+func (*ConfirmTotpResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *ConfirmTotpResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
 	}
 	return nil
 }
 
-var File_authentication_proto protoreflect.FileDescriptor
+func (x *ConfirmTotpResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
 
-const file_authentication_proto_rawDesc = "" +
-	"\n" +
-	"\x14authentication.proto\x12\x0eauthentication\x1a\x1bgoogle/protobuf/empty.proto\x1a\x13globular_auth.proto\"i\n" +
-	"\x10AuthenticateRqst\x12!\n" +
-	"\x04name\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\aaccountR\x04name\x12\x1a\n" +
-	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x16\n" +
-	"\x06issuer\x18\x03 \x01(\tR\x06issuer\"'\n" +
-	"\x0fAuthenticateRsp\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\")\n" +
-	"\x11ValidateTokenRqst\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"H\n" +
-	"\x10ValidateTokenRsp\x12\x1a\n" +
-	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x18\n" +
-	"\aexpired\x18\x02 \x01(\x03R\aexpired\"(\n" +
-	"\x10RefreshTokenRqst\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"'\n" +
-	"\x0fRefreshTokenRsp\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x85\x01\n" +
-	"\x12SetPasswordRequest\x12+\n" +
-	"\taccountId\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\aaccountR\taccountId\x12 \n" +
-	"\voldPassword\x18\x02 \x01(\tR\voldPassword\x12 \n" +
-	"\vnewPassword\x18\x03 \x01(\tR\vnewPassword\"+\n" +
-	"\x13SetPasswordResponse\x12\x14\n" +
+// RegenerateRecoveryCodesRequest replaces the recovery codes of an account.
+type RegenerateRecoveryCodesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesRequest) Reset() {
+	*x = RegenerateRecoveryCodesRequest{}
+	mi := &file_authentication_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesRequest) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesRequest.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *RegenerateRecoveryCodesRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+// RegenerateRecoveryCodesResponse returns the new codes, shown once.
+type RegenerateRecoveryCodesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	RecoveryCodes []string               `protobuf:"bytes,1,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegenerateRecoveryCodesResponse) Reset() {
+	*x = RegenerateRecoveryCodesResponse{}
+	mi := &file_authentication_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegenerateRecoveryCodesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegenerateRecoveryCodesResponse) ProtoMessage() {}
+
+func (x *RegenerateRecoveryCodesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegenerateRecoveryCodesResponse.ProtoReflect.Descriptor instead.
+func (*RegenerateRecoveryCodesResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *RegenerateRecoveryCodesResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+// BeginWebAuthnRegistrationRequest starts a passkey registration.
+type BeginWebAuthnRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	MfaToken      string                 `protobuf:"bytes,2,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnRegistrationRequest) Reset() {
+	*x = BeginWebAuthnRegistrationRequest{}
+	mi := &file_authentication_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *BeginWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *BeginWebAuthnRegistrationRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *BeginWebAuthnRegistrationRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+// BeginWebAuthnRegistrationResponse carries the options for navigator.credentials.create.
+type BeginWebAuthnRegistrationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Options       string                 `protobuf:"bytes,1,opt,name=options,proto3" json:"options,omitempty"` // PublicKeyCredentialCreationOptions (JSON).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BeginWebAuthnRegistrationResponse) Reset() {
+	*x = BeginWebAuthnRegistrationResponse{}
+	mi := &file_authentication_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BeginWebAuthnRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BeginWebAuthnRegistrationResponse) ProtoMessage() {}
+
+func (x *BeginWebAuthnRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BeginWebAuthnRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*BeginWebAuthnRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *BeginWebAuthnRegistrationResponse) GetOptions() string {
+	if x != nil {
+		return x.Options
+	}
+	return ""
+}
+
+// FinishWebAuthnRegistrationRequest stores the credential created by the authenticator.
+type FinishWebAuthnRegistrationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Credential    string                 `protobuf:"bytes,2,opt,name=credential,proto3" json:"credential,omitempty"` // RegistrationResponseJSON from navigator.credentials.create.
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`             // Label shown in GetMfaStatus.
+	MfaToken      string                 `protobuf:"bytes,4,opt,name=mfa_token,json=mfaToken,proto3" json:"mfa_token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnRegistrationRequest) Reset() {
+	*x = FinishWebAuthnRegistrationRequest{}
+	mi := &file_authentication_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnRegistrationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnRegistrationRequest) ProtoMessage() {}
+
+func (x *FinishWebAuthnRegistrationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnRegistrationRequest.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnRegistrationRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetCredential() string {
+	if x != nil {
+		return x.Credential
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationRequest) GetMfaToken() string {
+	if x != nil {
+		return x.MfaToken
+	}
+	return ""
+}
+
+// FinishWebAuthnRegistrationResponse returns recovery codes when this is the
+// first factor of the account, and a session token when made with an mfa_token.
+type FinishWebAuthnRegistrationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CredentialId  string                 `protobuf:"bytes,1,opt,name=credential_id,json=credentialId,proto3" json:"credential_id,omitempty"`
+	RecoveryCodes []string               `protobuf:"bytes,2,rep,name=recovery_codes,json=recoveryCodes,proto3" json:"recovery_codes,omitempty"`
+	Token         string                 `protobuf:"bytes,3,opt,name=token,proto3" json:"token,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FinishWebAuthnRegistrationResponse) Reset() {
+	*x = FinishWebAuthnRegistrationResponse{}
+	mi := &file_authentication_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FinishWebAuthnRegistrationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FinishWebAuthnRegistrationResponse) ProtoMessage() {}
+
+func (x *FinishWebAuthnRegistrationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FinishWebAuthnRegistrationResponse.ProtoReflect.Descriptor instead.
+func (*FinishWebAuthnRegistrationResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *FinishWebAuthnRegistrationResponse) GetCredentialId() string {
+	if x != nil {
+		return x.CredentialId
+	}
+	return ""
+}
+
+func (x *FinishWebAuthnRegistrationResponse) GetRecoveryCodes() []string {
+	if x != nil {
+		return x.RecoveryCodes
+	}
+	return nil
+}
+
+func (x *FinishWebAuthnRegistrationResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+// WebAuthnCredential describes a registered passkey.
+type WebAuthnCredential struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Base64url credential id.
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Created       int64                  `protobuf:"varint,3,opt,name=created,proto3" json:"created,omitempty"`
+	LastUsed      int64                  `protobuf:"varint,4,opt,name=last_used,json=lastUsed,proto3" json:"last_used,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WebAuthnCredential) Reset() {
+	*x = WebAuthnCredential{}
+	mi := &file_authentication_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WebAuthnCredential) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WebAuthnCredential) ProtoMessage() {}
+
+func (x *WebAuthnCredential) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WebAuthnCredential.ProtoReflect.Descriptor instead.
+func (*WebAuthnCredential) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *WebAuthnCredential) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *WebAuthnCredential) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+func (x *WebAuthnCredential) GetLastUsed() int64 {
+	if x != nil {
+		return x.LastUsed
+	}
+	return 0
+}
+
+// GetMfaStatusRequest asks for the enrollment of an account.
+type GetMfaStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMfaStatusRequest) Reset() {
+	*x = GetMfaStatusRequest{}
+	mi := &file_authentication_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMfaStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMfaStatusRequest) ProtoMessage() {}
+
+func (x *GetMfaStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMfaStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetMfaStatusRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetMfaStatusRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+// GetMfaStatusResponse describes the enrolled factors and the policy outcome.
+type GetMfaStatusResponse struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Totp                bool                   `protobuf:"varint,1,opt,name=totp,proto3" json:"totp,omitempty"`
+	WebauthnCredentials []*WebAuthnCredential  `protobuf:"bytes,2,rep,name=webauthn_credentials,json=webauthnCredentials,proto3" json:"webauthn_credentials,omitempty"`
+	RecoveryCodesLeft   int32                  `protobuf:"varint,3,opt,name=recovery_codes_left,json=recoveryCodesLeft,proto3" json:"recovery_codes_left,omitempty"`
+	Required            bool                   `protobuf:"varint,4,opt,name=required,proto3" json:"required,omitempty"` // MFA is required at login for this account.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *GetMfaStatusResponse) Reset() {
+	*x = GetMfaStatusResponse{}
+	mi := &file_authentication_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMfaStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMfaStatusResponse) ProtoMessage() {}
+
+func (x *GetMfaStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMfaStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetMfaStatusResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *GetMfaStatusResponse) GetTotp() bool {
+	if x != nil {
+		return x.Totp
+	}
+	return false
+}
+
+func (x *GetMfaStatusResponse) GetWebauthnCredentials() []*WebAuthnCredential {
+	if x != nil {
+		return x.WebauthnCredentials
+	}
+	return nil
+}
+
+func (x *GetMfaStatusResponse) GetRecoveryCodesLeft() int32 {
+	if x != nil {
+		return x.RecoveryCodesLeft
+	}
+	return 0
+}
+
+func (x *GetMfaStatusResponse) GetRequired() bool {
+	if x != nil {
+		return x.Required
+	}
+	return false
+}
+
+// MfaPolicy lists the accounts and roles that must log in with a second
+// factor. Accounts with an enrolled factor always need it.
+type MfaPolicy struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Accounts      []string               `protobuf:"bytes,1,rep,name=accounts,proto3" json:"accounts,omitempty"`
+	Roles         []string               `protobuf:"bytes,2,rep,name=roles,proto3" json:"roles,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MfaPolicy) Reset() {
+	*x = MfaPolicy{}
+	mi := &file_authentication_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MfaPolicy) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MfaPolicy) ProtoMessage() {}
+
+func (x *MfaPolicy) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MfaPolicy.ProtoReflect.Descriptor instead.
+func (*MfaPolicy) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *MfaPolicy) GetAccounts() []string {
+	if x != nil {
+		return x.Accounts
+	}
+	return nil
+}
+
+func (x *MfaPolicy) GetRoles() []string {
+	if x != nil {
+		return x.Roles
+	}
+	return nil
+}
+
+// SetMfaPolicyRequest replaces the cluster MFA policy.
+type SetMfaPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *MfaPolicy             `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMfaPolicyRequest) Reset() {
+	*x = SetMfaPolicyRequest{}
+	mi := &file_authentication_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMfaPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMfaPolicyRequest) ProtoMessage() {}
+
+func (x *SetMfaPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMfaPolicyRequest.ProtoReflect.Descriptor instead.
+func (*SetMfaPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *SetMfaPolicyRequest) GetPolicy() *MfaPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+type SetMfaPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetMfaPolicyResponse) Reset() {
+	*x = SetMfaPolicyResponse{}
+	mi := &file_authentication_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetMfaPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetMfaPolicyResponse) ProtoMessage() {}
+
+func (x *SetMfaPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetMfaPolicyResponse.ProtoReflect.Descriptor instead.
+func (*SetMfaPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{31}
+}
+
+type GetMfaPolicyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMfaPolicyRequest) Reset() {
+	*x = GetMfaPolicyRequest{}
+	mi := &file_authentication_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMfaPolicyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMfaPolicyRequest) ProtoMessage() {}
+
+func (x *GetMfaPolicyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMfaPolicyRequest.ProtoReflect.Descriptor instead.
+func (*GetMfaPolicyRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{32}
+}
+
+type GetMfaPolicyResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Policy        *MfaPolicy             `protobuf:"bytes,1,opt,name=policy,proto3" json:"policy,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetMfaPolicyResponse) Reset() {
+	*x = GetMfaPolicyResponse{}
+	mi := &file_authentication_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetMfaPolicyResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetMfaPolicyResponse) ProtoMessage() {}
+
+func (x *GetMfaPolicyResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetMfaPolicyResponse.ProtoReflect.Descriptor instead.
+func (*GetMfaPolicyResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetMfaPolicyResponse) GetPolicy() *MfaPolicy {
+	if x != nil {
+		return x.Policy
+	}
+	return nil
+}
+
+// ResetMfaRequest removes every factor and recovery code of an account.
+type ResetMfaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetMfaRequest) Reset() {
+	*x = ResetMfaRequest{}
+	mi := &file_authentication_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetMfaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetMfaRequest) ProtoMessage() {}
+
+func (x *ResetMfaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetMfaRequest.ProtoReflect.Descriptor instead.
+func (*ResetMfaRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *ResetMfaRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+type ResetMfaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ResetMfaResponse) Reset() {
+	*x = ResetMfaResponse{}
+	mi := &file_authentication_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ResetMfaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ResetMfaResponse) ProtoMessage() {}
+
+func (x *ResetMfaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ResetMfaResponse.ProtoReflect.Descriptor instead.
+func (*ResetMfaResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{35}
+}
+
+// Lockout is the failed sign-in state of an account or a client address.
+type Lockout struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"` // set for an account lockout
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`                                // set for an address lockout
+	Failures      int32                  `protobuf:"varint,3,opt,name=failures,proto3" json:"failures,omitempty"`                   // consecutive failures within the lockout window
+	LastFailure   int64                  `protobuf:"varint,4,opt,name=last_failure,json=lastFailure,proto3" json:"last_failure,omitempty"`
+	LockedUntil   int64                  `protobuf:"varint,5,opt,name=locked_until,json=lockedUntil,proto3" json:"locked_until,omitempty"` // unix seconds; 0 when not locked
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Lockout) Reset() {
+	*x = Lockout{}
+	mi := &file_authentication_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Lockout) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Lockout) ProtoMessage() {}
+
+func (x *Lockout) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Lockout.ProtoReflect.Descriptor instead.
+func (*Lockout) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *Lockout) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *Lockout) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+func (x *Lockout) GetFailures() int32 {
+	if x != nil {
+		return x.Failures
+	}
+	return 0
+}
+
+func (x *Lockout) GetLastFailure() int64 {
+	if x != nil {
+		return x.LastFailure
+	}
+	return 0
+}
+
+func (x *Lockout) GetLockedUntil() int64 {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return 0
+}
+
+// UnlockAccountRequest clears the failed sign-ins of an account, an address, or both.
+type UnlockAccountRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AccountId     string                 `protobuf:"bytes,1,opt,name=account_id,json=accountId,proto3" json:"account_id,omitempty"`
+	Ip            string                 `protobuf:"bytes,2,opt,name=ip,proto3" json:"ip,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountRequest) Reset() {
+	*x = UnlockAccountRequest{}
+	mi := &file_authentication_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountRequest) ProtoMessage() {}
+
+func (x *UnlockAccountRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountRequest.ProtoReflect.Descriptor instead.
+func (*UnlockAccountRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *UnlockAccountRequest) GetAccountId() string {
+	if x != nil {
+		return x.AccountId
+	}
+	return ""
+}
+
+func (x *UnlockAccountRequest) GetIp() string {
+	if x != nil {
+		return x.Ip
+	}
+	return ""
+}
+
+type UnlockAccountResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UnlockAccountResponse) Reset() {
+	*x = UnlockAccountResponse{}
+	mi := &file_authentication_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UnlockAccountResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UnlockAccountResponse) ProtoMessage() {}
+
+func (x *UnlockAccountResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UnlockAccountResponse.ProtoReflect.Descriptor instead.
+func (*UnlockAccountResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{38}
+}
+
+type ListLockedAccountsRequest struct {
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	IncludeFailing bool                   `protobuf:"varint,1,opt,name=include_failing,json=includeFailing,proto3" json:"include_failing,omitempty"` // also list entries with failures that are not locked yet
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
+}
+
+func (x *ListLockedAccountsRequest) Reset() {
+	*x = ListLockedAccountsRequest{}
+	mi := &file_authentication_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLockedAccountsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLockedAccountsRequest) ProtoMessage() {}
+
+func (x *ListLockedAccountsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLockedAccountsRequest.ProtoReflect.Descriptor instead.
+func (*ListLockedAccountsRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ListLockedAccountsRequest) GetIncludeFailing() bool {
+	if x != nil {
+		return x.IncludeFailing
+	}
+	return false
+}
+
+type ListLockedAccountsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lockouts      []*Lockout             `protobuf:"bytes,1,rep,name=lockouts,proto3" json:"lockouts,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListLockedAccountsResponse) Reset() {
+	*x = ListLockedAccountsResponse{}
+	mi := &file_authentication_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListLockedAccountsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListLockedAccountsResponse) ProtoMessage() {}
+
+func (x *ListLockedAccountsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListLockedAccountsResponse.ProtoReflect.Descriptor instead.
+func (*ListLockedAccountsResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{40}
+}
+
+func (x *ListLockedAccountsResponse) GetLockouts() []*Lockout {
+	if x != nil {
+		return x.Lockouts
+	}
+	return nil
+}
+
+// RegisterOidcClientRequest registers an application as an OpenID Connect
+// client of the authentication service. The client is stored as a resource
+// Application whose id is the client id.
+type RegisterOidcClientRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                      // client id; letters, digits, '.', '_' and '-'
+	RedirectUris  []string               `protobuf:"bytes,2,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`  // exact redirect URIs the client may use
+	PublicClient  bool                   `protobuf:"varint,3,opt,name=public_client,json=publicClient,proto3" json:"public_client,omitempty"` // no secret; PKCE alone protects the code exchange
+	Description   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterOidcClientRequest) Reset() {
+	*x = RegisterOidcClientRequest{}
+	mi := &file_authentication_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterOidcClientRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterOidcClientRequest) ProtoMessage() {}
+
+func (x *RegisterOidcClientRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterOidcClientRequest.ProtoReflect.Descriptor instead.
+func (*RegisterOidcClientRequest) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *RegisterOidcClientRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *RegisterOidcClientRequest) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
+func (x *RegisterOidcClientRequest) GetPublicClient() bool {
+	if x != nil {
+		return x.PublicClient
+	}
+	return false
+}
+
+func (x *RegisterOidcClientRequest) GetDescription() string {
+	if x != nil {
+		return x.Description
+	}
+	return ""
+}
+
+type RegisterOidcClientResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ClientId      string                 `protobuf:"bytes,1,opt,name=client_id,json=clientId,proto3" json:"client_id,omitempty"`
+	ClientSecret  string                 `protobuf:"bytes,2,opt,name=client_secret,json=clientSecret,proto3" json:"client_secret,omitempty"` // empty for public clients; shown only once
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterOidcClientResponse) Reset() {
+	*x = RegisterOidcClientResponse{}
+	mi := &file_authentication_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterOidcClientResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterOidcClientResponse) ProtoMessage() {}
+
+func (x *RegisterOidcClientResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterOidcClientResponse.ProtoReflect.Descriptor instead.
+func (*RegisterOidcClientResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *RegisterOidcClientResponse) GetClientId() string {
+	if x != nil {
+		return x.ClientId
+	}
+	return ""
+}
+
+func (x *RegisterOidcClientResponse) GetClientSecret() string {
+	if x != nil {
+		return x.ClientSecret
+	}
+	return ""
+}
+
+// IssueClientCertificateResponse carries a newly issued client certificate and its signing CA.
+// The caller must store the private key securely; the server does NOT persist it.
+type IssueClientCertificateResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	CaCrtPem      []byte                 `protobuf:"bytes,1,opt,name=ca_crt_pem,json=caCrtPem,proto3" json:"ca_crt_pem,omitempty"`             // PEM-encoded cluster CA certificate
+	ClientCrtPem  []byte                 `protobuf:"bytes,2,opt,name=client_crt_pem,json=clientCrtPem,proto3" json:"client_crt_pem,omitempty"` // PEM-encoded client certificate (signed by CA)
+	ClientKeyPem  []byte                 `protobuf:"bytes,3,opt,name=client_key_pem,json=clientKeyPem,proto3" json:"client_key_pem,omitempty"` // PEM-encoded client private key
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IssueClientCertificateResponse) Reset() {
+	*x = IssueClientCertificateResponse{}
+	mi := &file_authentication_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IssueClientCertificateResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IssueClientCertificateResponse) ProtoMessage() {}
+
+func (x *IssueClientCertificateResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_authentication_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IssueClientCertificateResponse.ProtoReflect.Descriptor instead.
+func (*IssueClientCertificateResponse) Descriptor() ([]byte, []int) {
+	return file_authentication_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *IssueClientCertificateResponse) GetCaCrtPem() []byte {
+	if x != nil {
+		return x.CaCrtPem
+	}
+	return nil
+}
+
+func (x *IssueClientCertificateResponse) GetClientCrtPem() []byte {
+	if x != nil {
+		return x.ClientCrtPem
+	}
+	return nil
+}
+
+func (x *IssueClientCertificateResponse) GetClientKeyPem() []byte {
+	if x != nil {
+		return x.ClientKeyPem
+	}
+	return nil
+}
+
+var File_authentication_proto protoreflect.FileDescriptor
+
+const file_authentication_proto_rawDesc = "" +
+	"\n" +
+	"\x14authentication.proto\x12\x0eauthentication\x1a\x1bgoogle/protobuf/empty.proto\x1a\x13globular_auth.proto\"i\n" +
+	"\x10AuthenticateRqst\x12!\n" +
+	"\x04name\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\x04name\x12\x1a\n" +
+	"\bpassword\x18\x02 \x01(\tR\bpassword\x12\x16\n" +
+	"\x06issuer\x18\x03 \x01(\tR\x06issuer\"\xeb\x01\n" +
+	"\x0fAuthenticateRsp\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\x12!\n" +
+	"\fmfa_required\x18\x02 \x01(\bR\vmfaRequired\x12\x1b\n" +
+	"\tmfa_token\x18\x03 \x01(\tR\bmfaToken\x12\x1f\n" +
+	"\vmfa_methods\x18\x04 \x03(\tR\n" +
+	"mfaMethods\x12)\n" +
+	"\x10webauthn_options\x18\x05 \x01(\tR\x0fwebauthnOptions\x126\n" +
+	"\x17mfa_enrollment_required\x18\x06 \x01(\bR\x15mfaEnrollmentRequired\")\n" +
+	"\x11ValidateTokenRqst\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"H\n" +
+	"\x10ValidateTokenRsp\x12\x1a\n" +
+	"\bclientId\x18\x01 \x01(\tR\bclientId\x12\x18\n" +
+	"\aexpired\x18\x02 \x01(\x03R\aexpired\"(\n" +
+	"\x10RefreshTokenRqst\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"'\n" +
+	"\x0fRefreshTokenRsp\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"\x85\x01\n" +
+	"\x12SetPasswordRequest\x12+\n" +
+	"\taccountId\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12 \n" +
+	"\voldPassword\x18\x02 \x01(\tR\voldPassword\x12 \n" +
+	"\vnewPassword\x18\x03 \x01(\tR\vnewPassword\"+\n" +
+	"\x13SetPasswordResponse\x12\x14\n" +
 	"\x05token\x18\x01 \x01(\tR\x05token\"\\\n" +
 	"\x16SetRootPasswordRequest\x12 \n" +
 	"\voldPassword\x18\x01 \x01(\tR\voldPassword\x12 \n" +
@@ -817,13 +2369,114 @@ const file_authentication_proto_rawDesc = "" +
 	"\x8a\xb5\x18\x06\n" +
 	"\x04peerR\x03mac\"1\n" +
 	"\x19GeneratePeerTokenResponse\x12\x14\n" +
-	"\x05token\x18\x01 \x01(\tR\x05token\"\x8a\x01\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"r\n" +
+	"\x10VerifyMfaRequest\x12\x1b\n" +
+	"\tmfa_token\x18\x01 \x01(\tR\bmfaToken\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12-\n" +
+	"\x12webauthn_assertion\x18\x03 \x01(\tR\x11webauthnAssertion\")\n" +
+	"\x11VerifyMfaResponse\x12\x14\n" +
+	"\x05token\x18\x01 \x01(\tR\x05token\"^\n" +
+	"\x11EnrollTotpRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12\x1b\n" +
+	"\tmfa_token\x18\x02 \x01(\tR\bmfaToken\">\n" +
+	"\x12EnrollTotpResponse\x12\x16\n" +
+	"\x06secret\x18\x01 \x01(\tR\x06secret\x12\x10\n" +
+	"\x03uri\x18\x02 \x01(\tR\x03uri\"s\n" +
+	"\x12ConfirmTotpRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12\x12\n" +
+	"\x04code\x18\x02 \x01(\tR\x04code\x12\x1b\n" +
+	"\tmfa_token\x18\x03 \x01(\tR\bmfaToken\"R\n" +
+	"\x13ConfirmTotpResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\"N\n" +
+	"\x1eRegenerateRecoveryCodesRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\"H\n" +
+	"\x1fRegenerateRecoveryCodesResponse\x12%\n" +
+	"\x0erecovery_codes\x18\x01 \x03(\tR\rrecoveryCodes\"m\n" +
+	" BeginWebAuthnRegistrationRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12\x1b\n" +
+	"\tmfa_token\x18\x02 \x01(\tR\bmfaToken\"=\n" +
+	"!BeginWebAuthnRegistrationResponse\x12\x18\n" +
+	"\aoptions\x18\x01 \x01(\tR\aoptions\"\xa2\x01\n" +
+	"!FinishWebAuthnRegistrationRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12\x1e\n" +
+	"\n" +
+	"credential\x18\x02 \x01(\tR\n" +
+	"credential\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x1b\n" +
+	"\tmfa_token\x18\x04 \x01(\tR\bmfaToken\"\x86\x01\n" +
+	"\"FinishWebAuthnRegistrationResponse\x12#\n" +
+	"\rcredential_id\x18\x01 \x01(\tR\fcredentialId\x12%\n" +
+	"\x0erecovery_codes\x18\x02 \x03(\tR\rrecoveryCodes\x12\x14\n" +
+	"\x05token\x18\x03 \x01(\tR\x05token\"o\n" +
+	"\x12WebAuthnCredential\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\acreated\x18\x03 \x01(\x03R\acreated\x12\x1b\n" +
+	"\tlast_used\x18\x04 \x01(\x03R\blastUsed\"C\n" +
+	"\x13GetMfaStatusRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\"\xcd\x01\n" +
+	"\x14GetMfaStatusResponse\x12\x12\n" +
+	"\x04totp\x18\x01 \x01(\bR\x04totp\x12U\n" +
+	"\x14webauthn_credentials\x18\x02 \x03(\v2\".authentication.WebAuthnCredentialR\x13webauthnCredentials\x12.\n" +
+	"\x13recovery_codes_left\x18\x03 \x01(\x05R\x11recoveryCodesLeft\x12\x1a\n" +
+	"\brequired\x18\x04 \x01(\bR\brequired\"=\n" +
+	"\tMfaPolicy\x12\x1a\n" +
+	"\baccounts\x18\x01 \x03(\tR\baccounts\x12\x14\n" +
+	"\x05roles\x18\x02 \x03(\tR\x05roles\"H\n" +
+	"\x13SetMfaPolicyRequest\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.authentication.MfaPolicyR\x06policy\"\x16\n" +
+	"\x14SetMfaPolicyResponse\"\x15\n" +
+	"\x13GetMfaPolicyRequest\"I\n" +
+	"\x14GetMfaPolicyResponse\x121\n" +
+	"\x06policy\x18\x01 \x01(\v2\x19.authentication.MfaPolicyR\x06policy\"?\n" +
+	"\x0fResetMfaRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\"\x12\n" +
+	"\x10ResetMfaResponse\"\x9a\x01\n" +
+	"\aLockout\x12\x1d\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tR\taccountId\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\x12\x1a\n" +
+	"\bfailures\x18\x03 \x01(\x05R\bfailures\x12!\n" +
+	"\flast_failure\x18\x04 \x01(\x03R\vlastFailure\x12!\n" +
+	"\flocked_until\x18\x05 \x01(\x03R\vlockedUntil\"T\n" +
+	"\x14UnlockAccountRequest\x12,\n" +
+	"\n" +
+	"account_id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\aaccountR\taccountId\x12\x0e\n" +
+	"\x02ip\x18\x02 \x01(\tR\x02ip\"\x17\n" +
+	"\x15UnlockAccountResponse\"D\n" +
+	"\x19ListLockedAccountsRequest\x12'\n" +
+	"\x0finclude_failing\x18\x01 \x01(\bR\x0eincludeFailing\"Q\n" +
+	"\x1aListLockedAccountsResponse\x123\n" +
+	"\blockouts\x18\x01 \x03(\v2\x17.authentication.LockoutR\blockouts\"\x9b\x01\n" +
+	"\x19RegisterOidcClientRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12#\n" +
+	"\rredirect_uris\x18\x02 \x03(\tR\fredirectUris\x12#\n" +
+	"\rpublic_client\x18\x03 \x01(\bR\fpublicClient\x12 \n" +
+	"\vdescription\x18\x04 \x01(\tR\vdescription\"^\n" +
+	"\x1aRegisterOidcClientResponse\x12\x1b\n" +
+	"\tclient_id\x18\x01 \x01(\tR\bclientId\x12#\n" +
+	"\rclient_secret\x18\x02 \x01(\tR\fclientSecret\"\x8a\x01\n" +
 	"\x1eIssueClientCertificateResponse\x12\x1c\n" +
 	"\n" +
 	"ca_crt_pem\x18\x01 \x01(\fR\bcaCrtPem\x12$\n" +
 	"\x0eclient_crt_pem\x18\x02 \x01(\fR\fclientCrtPem\x12$\n" +
-	"\x0eclient_key_pem\x18\x03 \x01(\fR\fclientKeyPem2\x9c\n" +
-	"\n" +
+	"\x0eclient_key_pem\x18\x03 \x01(\fR\fclientKeyPem2\xd8\x1b\n" +
 	"\x15AuthenticationService\x12\x99\x01\n" +
 	"\fAuthenticate\x12 .authentication.AuthenticateRqst\x1a\x1f.authentication.AuthenticateRsp\"F\x82\xb5\x18B\n" +
 	"\x11auth.authenticate\x12\x04read\x1a\x1f/authentication/accounts/{name}*\x06viewer\x12\x95\x01\n" +
@@ -840,7 +2493,34 @@ const file_authentication_proto_rawDesc = "" +
 	"\fSetRootEmail\x12#.authentication.SetRootEmailRequest\x1a$.authentication.SetRootEmailResponse\"9\x82\xb5\x185\n" +
 	"\x0fauth.root.email\x12\x05admin\x1a\x14/authentication/root*\x05admin\x12\xab\x01\n" +
 	"\x16IssueClientCertificate\x12\x16.google.protobuf.Empty\x1a..authentication.IssueClientCertificateResponse\"I\x82\xb5\x18E\n" +
-	"\x16auth.certificate.issue\x12\x05write\x1a\x1c/authentication/certificates*\x06editorBGZEgithub.com/globulario/services/golang/authentication/authenticationpbb\x06proto3"
+	"\x16auth.certificate.issue\x12\x05write\x1a\x1c/authentication/certificates*\x06editor\x12\x8d\x01\n" +
+	"\tVerifyMfa\x12 .authentication.VerifyMfaRequest\x1a!.authentication.VerifyMfaResponse\";\x82\xb5\x187\n" +
+	"\x0fauth.mfa.verify\x12\x04read\x1a\x16/authentication/tokens*\x06viewer\x12\xa0\x01\n" +
+	"\n" +
+	"EnrollTotp\x12!.authentication.EnrollTotpRequest\x1a\".authentication.EnrollTotpResponse\"K\x82\xb5\x18G\n" +
+	"\x0fauth.mfa.enroll\x12\x05write\x1a%/authentication/accounts/{account_id}*\x06editor\x12\xa3\x01\n" +
+	"\vConfirmTotp\x12\".authentication.ConfirmTotpRequest\x1a#.authentication.ConfirmTotpResponse\"K\x82\xb5\x18G\n" +
+	"\x0fauth.mfa.enroll\x12\x05write\x1a%/authentication/accounts/{account_id}*\x06editor\x12\xc7\x01\n" +
+	"\x17RegenerateRecoveryCodes\x12..authentication.RegenerateRecoveryCodesRequest\x1a/.authentication.RegenerateRecoveryCodesResponse\"K\x82\xb5\x18G\n" +
+	"\x0fauth.mfa.enroll\x12\x05write\x1a%/authentication/accounts/{account_id}*\x06editor\x12\xcd\x01\n" +
+	"\x19BeginWebAuthnRegistration\x120.authentication.BeginWebAuthnRegistrationRequest\x1a1.authentication.BeginWebAuthnRegistrationResponse\"K\x82\xb5\x18G\n" +
+	"\x0fauth.mfa.enroll\x12\x05write\x1a%/authentication/accounts/{account_id}*\x06editor\x12\xd0\x01\n" +
+	"\x1aFinishWebAuthnRegistration\x121.authentication.FinishWebAuthnRegistrationRequest\x1a2.authentication.FinishWebAuthnRegistrationResponse\"K\x82\xb5\x18G\n" +
+	"\x0fauth.mfa.enroll\x12\x05write\x1a%/authentication/accounts/{account_id}*\x06editor\x12\xa5\x01\n" +
+	"\fGetMfaStatus\x12#.authentication.GetMfaStatusRequest\x1a$.authentication.GetMfaStatusResponse\"J\x82\xb5\x18F\n" +
+	"\x0fauth.mfa.status\x12\x04read\x1a%/authentication/accounts/{account_id}*\x06viewer\x12\x93\x01\n" +
+	"\fSetMfaPolicy\x12#.authentication.SetMfaPolicyRequest\x1a$.authentication.SetMfaPolicyResponse\"8\x82\xb5\x184\n" +
+	"\x0fauth.mfa.policy\x12\x05admin\x1a\x13/authentication/mfa*\x05admin\x12\x97\x01\n" +
+	"\fGetMfaPolicy\x12#.authentication.GetMfaPolicyRequest\x1a$.authentication.GetMfaPolicyResponse\"<\x82\xb5\x188\n" +
+	"\x14auth.mfa.policy.read\x12\x04read\x1a\x13/authentication/mfa*\x05admin\x12\x98\x01\n" +
+	"\bResetMfa\x12\x1f.authentication.ResetMfaRequest\x1a .authentication.ResetMfaResponse\"I\x82\xb5\x18E\n" +
+	"\x0eauth.mfa.reset\x12\x05admin\x1a%/authentication/accounts/{account_id}*\x05admin\x12\x9f\x01\n" +
+	"\rUnlockAccount\x12$.authentication.UnlockAccountRequest\x1a%.authentication.UnlockAccountResponse\"A\x82\xb5\x18=\n" +
+	"\x13auth.lockout.unlock\x12\x05admin\x1a\x18/authentication/lockouts*\x05admin\x12\xab\x01\n" +
+	"\x12ListLockedAccounts\x12).authentication.ListLockedAccountsRequest\x1a*.authentication.ListLockedAccountsResponse\">\x82\xb5\x18:\n" +
+	"\x11auth.lockout.list\x12\x04read\x1a\x18/authentication/lockouts*\x05admin\x12\xb0\x01\n" +
+	"\x12RegisterOidcClient\x12).authentication.RegisterOidcClientRequest\x1a*.authentication.RegisterOidcClientResponse\"C\x82\xb5\x18?\n" +
+	"\x11auth.oidc.clients\x12\x05admin\x1a\x1c/authentication/oidc/clients*\x05adminBGZEgithub.com/globulario/services/golang/authentication/authenticationpbb\x06proto3"
 
 var (
 	file_authentication_proto_rawDescOnce sync.Once
@@ -854,47 +2534,106 @@ func file_authentication_proto_rawDescGZIP() []byte {
 	return file_authentication_proto_rawDescData
 }
 
-var file_authentication_proto_msgTypes = make([]protoimpl.MessageInfo, 15)
+var file_authentication_proto_msgTypes = make([]protoimpl.MessageInfo, 44)
 var file_authentication_proto_goTypes = []any{
-	(*AuthenticateRqst)(nil),               // 0: authentication.AuthenticateRqst
-	(*AuthenticateRsp)(nil),                // 1: authentication.AuthenticateRsp
-	(*ValidateTokenRqst)(nil),              // 2: authentication.ValidateTokenRqst
-	(*ValidateTokenRsp)(nil),               // 3: authentication.ValidateTokenRsp
-	(*RefreshTokenRqst)(nil),               // 4: authentication.RefreshTokenRqst
-	(*RefreshTokenRsp)(nil),                // 5: authentication.RefreshTokenRsp
-	(*SetPasswordRequest)(nil),             // 6: authentication.SetPasswordRequest
-	(*SetPasswordResponse)(nil),            // 7: authentication.SetPasswordResponse
-	(*SetRootPasswordRequest)(nil),         // 8: authentication.SetRootPasswordRequest
-	(*SetRootPasswordResponse)(nil),        // 9: authentication.SetRootPasswordResponse
-	(*SetRootEmailRequest)(nil),            // 10: authentication.SetRootEmailRequest
-	(*SetRootEmailResponse)(nil),           // 11: authentication.SetRootEmailResponse
-	(*GeneratePeerTokenRequest)(nil),       // 12: authentication.GeneratePeerTokenRequest
-	(*GeneratePeerTokenResponse)(nil),      // 13: authentication.GeneratePeerTokenResponse
-	(*IssueClientCertificateResponse)(nil), // 14: authentication.IssueClientCertificateResponse
-	(*emptypb.Empty)(nil),                  // 15: google.protobuf.Empty
+	(*AuthenticateRqst)(nil),                   // 0: authentication.AuthenticateRqst
+	(*AuthenticateRsp)(nil),                    // 1: authentication.AuthenticateRsp
+	(*ValidateTokenRqst)(nil),                  // 2: authentication.ValidateTokenRqst
+	(*ValidateTokenRsp)(nil),                   // 3: authentication.ValidateTokenRsp
+	(*RefreshTokenRqst)(nil),                   // 4: authentication.RefreshTokenRqst
+	(*RefreshTokenRsp)(nil),                    // 5: authentication.RefreshTokenRsp
+	(*SetPasswordRequest)(nil),                 // 6: authentication.SetPasswordRequest
+	(*SetPasswordResponse)(nil),                // 7: authentication.SetPasswordResponse
+	(*SetRootPasswordRequest)(nil),             // 8: authentication.SetRootPasswordRequest
+	(*SetRootPasswordResponse)(nil),            // 9: authentication.SetRootPasswordResponse
+	(*SetRootEmailRequest)(nil),                // 10: authentication.SetRootEmailRequest
+	(*SetRootEmailResponse)(nil),               // 11: authentication.SetRootEmailResponse
+	(*GeneratePeerTokenRequest)(nil),           // 12: authentication.GeneratePeerTokenRequest
+	(*GeneratePeerTokenResponse)(nil),          // 13: authentication.GeneratePeerTokenResponse
+	(*VerifyMfaRequest)(nil),                   // 14: authentication.VerifyMfaRequest
+	(*VerifyMfaResponse)(nil),                  // 15: authentication.VerifyMfaResponse
+	(*EnrollTotpRequest)(nil),                  // 16: authentication.EnrollTotpRequest
+	(*EnrollTotpResponse)(nil),                 // 17: authentication.EnrollTotpResponse
+	(*ConfirmTotpRequest)(nil),                 // 18: authentication.ConfirmTotpRequest
+	(*ConfirmTotpResponse)(nil),                // 19: authentication.ConfirmTotpResponse
+	(*RegenerateRecoveryCodesRequest)(nil),     // 20: authentication.RegenerateRecoveryCodesRequest
+	(*RegenerateRecoveryCodesResponse)(nil),    // 21: authentication.RegenerateRecoveryCodesResponse
+	(*BeginWebAuthnRegistrationRequest)(nil),   // 22: authentication.BeginWebAuthnRegistrationRequest
+	(*BeginWebAuthnRegistrationResponse)(nil),  // 23: authentication.BeginWebAuthnRegistrationResponse
+	(*FinishWebAuthnRegistrationRequest)(nil),  // 24: authentication.FinishWebAuthnRegistrationRequest
+	(*FinishWebAuthnRegistrationResponse)(nil), // 25: authentication.FinishWebAuthnRegistrationResponse
+	(*WebAuthnCredential)(nil),                 // 26: authentication.WebAuthnCredential
+	(*GetMfaStatusRequest)(nil),                // 27: authentication.GetMfaStatusRequest
+	(*GetMfaStatusResponse)(nil),               // 28: authentication.GetMfaStatusResponse
+	(*MfaPolicy)(nil),                          // 29: authentication.MfaPolicy
+	(*SetMfaPolicyRequest)(nil),                // 30: authentication.SetMfaPolicyRequest
+	(*SetMfaPolicyResponse)(nil),               // 31: authentication.SetMfaPolicyResponse
+	(*GetMfaPolicyRequest)(nil),                // 32: authentication.GetMfaPolicyRequest
+	(*GetMfaPolicyResponse)(nil),               // 33: authentication.GetMfaPolicyResponse
+	(*ResetMfaRequest)(nil),                    // 34: authentication.ResetMfaRequest
+	(*ResetMfaResponse)(nil),                   // 35: authentication.ResetMfaResponse
+	(*Lockout)(nil),                            // 36: authentication.Lockout
+	(*UnlockAccountRequest)(nil),               // 37: authentication.UnlockAccountRequest
+	(*UnlockAccountResponse)(nil),              // 38: authentication.UnlockAccountResponse
+	(*ListLockedAccountsRequest)(nil),          // 39: authentication.ListLockedAccountsRequest
+	(*ListLockedAccountsResponse)(nil),         // 40: authentication.ListLockedAccountsResponse
+	(*RegisterOidcClientRequest)(nil),          // 41: authentication.RegisterOidcClientRequest
+	(*RegisterOidcClientResponse)(nil),         // 42: authentication.RegisterOidcClientResponse
+	(*IssueClientCertificateResponse)(nil),     // 43: authentication.IssueClientCertificateResponse
+	(*emptypb.Empty)(nil),                      // 44: google.protobuf.Empty
 }
 var file_authentication_proto_depIdxs = []int32{
-	0,  // 0: authentication.AuthenticationService.Authenticate:input_type -> authentication.AuthenticateRqst
-	2,  // 1: authentication.AuthenticationService.ValidateToken:input_type -> authentication.ValidateTokenRqst
-	4,  // 2: authentication.AuthenticationService.RefreshToken:input_type -> authentication.RefreshTokenRqst
-	12, // 3: authentication.AuthenticationService.GeneratePeerToken:input_type -> authentication.GeneratePeerTokenRequest
-	6,  // 4: authentication.AuthenticationService.SetPassword:input_type -> authentication.SetPasswordRequest
-	8,  // 5: authentication.AuthenticationService.SetRootPassword:input_type -> authentication.SetRootPasswordRequest
-	10, // 6: authentication.AuthenticationService.SetRootEmail:input_type -> authentication.SetRootEmailRequest
-	15, // 7: authentication.AuthenticationService.IssueClientCertificate:input_type -> google.protobuf.Empty
-	1,  // 8: authentication.AuthenticationService.Authenticate:output_type -> authentication.AuthenticateRsp
-	3,  // 9: authentication.AuthenticationService.ValidateToken:output_type -> authentication.ValidateTokenRsp
-	5,  // 10: authentication.AuthenticationService.RefreshToken:output_type -> authentication.RefreshTokenRsp
-	13, // 11: authentication.AuthenticationService.GeneratePeerToken:output_type -> authentication.GeneratePeerTokenResponse
-	7,  // 12: authentication.AuthenticationService.SetPassword:output_type -> authentication.SetPasswordResponse
-	9,  // 13: authentication.AuthenticationService.SetRootPassword:output_type -> authentication.SetRootPasswordResponse
-	11, // 14: authentication.AuthenticationService.SetRootEmail:output_type -> authentication.SetRootEmailResponse
-	14, // 15: authentication.AuthenticationService.IssueClientCertificate:output_type -> authentication.IssueClientCertificateResponse
-	8,  // [8:16] is the sub-list for method output_type
-	0,  // [0:8] is the sub-list for method input_type
-	0,  // [0:0] is the sub-list for extension type_name
-	0,  // [0:0] is the sub-list for extension extendee
-	0,  // [0:0] is the sub-list for field type_name
+	26, // 0: authentication.GetMfaStatusResponse.webauthn_credentials:type_name -> authentication.WebAuthnCredential
+	29, // 1: authentication.SetMfaPolicyRequest.policy:type_name -> authentication.MfaPolicy
+	29, // 2: authentication.GetMfaPolicyResponse.policy:type_name -> authentication.MfaPolicy
+	36, // 3: authentication.ListLockedAccountsResponse.lockouts:type_name -> authentication.Lockout
+	0,  // 4: authentication.AuthenticationService.Authenticate:input_type -> authentication.AuthenticateRqst
+	2,  // 5: authentication.AuthenticationService.ValidateToken:input_type -> authentication.ValidateTokenRqst
+	4,  // 6: authentication.AuthenticationService.RefreshToken:input_type -> authentication.RefreshTokenRqst
+	12, // 7: authentication.AuthenticationService.GeneratePeerToken:input_type -> authentication.GeneratePeerTokenRequest
+	6,  // 8: authentication.AuthenticationService.SetPassword:input_type -> authentication.SetPasswordRequest
+	8,  // 9: authentication.AuthenticationService.SetRootPassword:input_type -> authentication.SetRootPasswordRequest
+	10, // 10: authentication.AuthenticationService.SetRootEmail:input_type -> authentication.SetRootEmailRequest
+	44, // 11: authentication.AuthenticationService.IssueClientCertificate:input_type -> google.protobuf.Empty
+	14, // 12: authentication.AuthenticationService.VerifyMfa:input_type -> authentication.VerifyMfaRequest
+	16, // 13: authentication.AuthenticationService.EnrollTotp:input_type -> authentication.EnrollTotpRequest
+	18, // 14: authentication.AuthenticationService.ConfirmTotp:input_type -> authentication.ConfirmTotpRequest
+	20, // 15: authentication.AuthenticationService.RegenerateRecoveryCodes:input_type -> authentication.RegenerateRecoveryCodesRequest
+	22, // 16: authentication.AuthenticationService.BeginWebAuthnRegistration:input_type -> authentication.BeginWebAuthnRegistrationRequest
+	24, // 17: authentication.AuthenticationService.FinishWebAuthnRegistration:input_type -> authentication.FinishWebAuthnRegistrationRequest
+	27, // 18: authentication.AuthenticationService.GetMfaStatus:input_type -> authentication.GetMfaStatusRequest
+	30, // 19: authentication.AuthenticationService.SetMfaPolicy:input_type -> authentication.SetMfaPolicyRequest
+	32, // 20: authentication.AuthenticationService.GetMfaPolicy:input_type -> authentication.GetMfaPolicyRequest
+	34, // 21: authentication.AuthenticationService.ResetMfa:input_type -> authentication.ResetMfaRequest
+	37, // 22: authentication.AuthenticationService.UnlockAccount:input_type -> authentication.UnlockAccountRequest
+	39, // 23: authentication.AuthenticationService.ListLockedAccounts:input_type -> authentication.ListLockedAccountsRequest
+	41, // 24: authentication.AuthenticationService.RegisterOidcClient:input_type -> authentication.RegisterOidcClientRequest
+	1,  // 25: authentication.AuthenticationService.Authenticate:output_type -> authentication.AuthenticateRsp
+	3,  // 26: authentication.AuthenticationService.ValidateToken:output_type -> authentication.ValidateTokenRsp
+	5,  // 27: authentication.AuthenticationService.RefreshToken:output_type -> authentication.RefreshTokenRsp
+	13, // 28: authentication.AuthenticationService.GeneratePeerToken:output_type -> authentication.GeneratePeerTokenResponse
+	7,  // 29: authentication.AuthenticationService.SetPassword:output_type -> authentication.SetPasswordResponse
+	9,  // 30: authentication.AuthenticationService.SetRootPassword:output_type -> authentication.SetRootPasswordResponse
+	11, // 31: authentication.AuthenticationService.SetRootEmail:output_type -> authentication.SetRootEmailResponse
+	43, // 32: authentication.AuthenticationService.IssueClientCertificate:output_type -> authentication.IssueClientCertificateResponse
+	15, // 33: authentication.AuthenticationService.VerifyMfa:output_type -> authentication.VerifyMfaResponse
+	17, // 34: authentication.AuthenticationService.EnrollTotp:output_type -> authentication.EnrollTotpResponse
+	19, // 35: authentication.AuthenticationService.ConfirmTotp:output_type -> authentication.ConfirmTotpResponse
+	21, // 36: authentication.AuthenticationService.RegenerateRecoveryCodes:output_type -> authentication.RegenerateRecoveryCodesResponse
+	23, // 37: authentication.AuthenticationService.BeginWebAuthnRegistration:output_type -> authentication.BeginWebAuthnRegistrationResponse
+	25, // 38: authentication.AuthenticationService.FinishWebAuthnRegistration:output_type -> authentication.FinishWebAuthnRegistrationResponse
+	28, // 39: authentication.AuthenticationService.GetMfaStatus:output_type -> authentication.GetMfaStatusResponse
+	31, // 40: authentication.AuthenticationService.SetMfaPolicy:output_type -> authentication.SetMfaPolicyResponse
+	33, // 41: authentication.AuthenticationService.GetMfaPolicy:output_type -> authentication.GetMfaPolicyResponse
+	35, // 42: authentication.AuthenticationService.ResetMfa:output_type -> authentication.ResetMfaResponse
+	38, // 43: authentication.AuthenticationService.UnlockAccount:output_type -> authentication.UnlockAccountResponse
+	40, // 44: authentication.AuthenticationService.ListLockedAccounts:output_type -> authentication.ListLockedAccountsResponse
+	42, // 45: authentication.AuthenticationService.RegisterOidcClient:output_type -> authentication.RegisterOidcClientResponse
+	25, // [25:46] is the sub-list for method output_type
+	4,  // [4:25] is the sub-list for method input_type
+	4,  // [4:4] is the sub-list for extension type_name
+	4,  // [4:4] is the sub-list for extension extendee
+	0,  // [0:4] is the sub-list for field type_name
 }
 
 func init() { file_authentication_proto_init() }
@@ -908,7 +2647,7 @@ func file_authentication_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_authentication_proto_rawDesc), len(file_authentication_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   15,
+			NumMessages:   44,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
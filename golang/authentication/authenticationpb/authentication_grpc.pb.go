@@ -23,14 +23,27 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	AuthenticationService_Authenticate_FullMethodName           = "/authentication.AuthenticationService/Authenticate"
-	AuthenticationService_ValidateToken_FullMethodName          = "/authentication.AuthenticationService/ValidateToken"
-	AuthenticationService_RefreshToken_FullMethodName           = "/authentication.AuthenticationService/RefreshToken"
-	AuthenticationService_GeneratePeerToken_FullMethodName      = "/authentication.AuthenticationService/GeneratePeerToken"
-	AuthenticationService_SetPassword_FullMethodName            = "/authentication.AuthenticationService/SetPassword"
-	AuthenticationService_SetRootPassword_FullMethodName        = "/authentication.AuthenticationService/SetRootPassword"
-	AuthenticationService_SetRootEmail_FullMethodName           = "/authentication.AuthenticationService/SetRootEmail"
-	AuthenticationService_IssueClientCertificate_FullMethodName = "/authentication.AuthenticationService/IssueClientCertificate"
+	AuthenticationService_Authenticate_FullMethodName               = "/authentication.AuthenticationService/Authenticate"
+	AuthenticationService_ValidateToken_FullMethodName              = "/authentication.AuthenticationService/ValidateToken"
+	AuthenticationService_RefreshToken_FullMethodName               = "/authentication.AuthenticationService/RefreshToken"
+	AuthenticationService_GeneratePeerToken_FullMethodName          = "/authentication.AuthenticationService/GeneratePeerToken"
+	AuthenticationService_SetPassword_FullMethodName                = "/authentication.AuthenticationService/SetPassword"
+	AuthenticationService_SetRootPassword_FullMethodName            = "/authentication.AuthenticationService/SetRootPassword"
+	AuthenticationService_SetRootEmail_FullMethodName               = "/authentication.AuthenticationService/SetRootEmail"
+	AuthenticationService_IssueClientCertificate_FullMethodName     = "/authentication.AuthenticationService/IssueClientCertificate"
+	AuthenticationService_VerifyMfa_FullMethodName                  = "/authentication.AuthenticationService/VerifyMfa"
+	AuthenticationService_EnrollTotp_FullMethodName                 = "/authentication.AuthenticationService/EnrollTotp"
+	AuthenticationService_ConfirmTotp_FullMethodName                = "/authentication.AuthenticationService/ConfirmTotp"
+	AuthenticationService_RegenerateRecoveryCodes_FullMethodName    = "/authentication.AuthenticationService/RegenerateRecoveryCodes"
+	AuthenticationService_BeginWebAuthnRegistration_FullMethodName  = "/authentication.AuthenticationService/BeginWebAuthnRegistration"
+	AuthenticationService_FinishWebAuthnRegistration_FullMethodName = "/authentication.AuthenticationService/FinishWebAuthnRegistration"
+	AuthenticationService_GetMfaStatus_FullMethodName               = "/authentication.AuthenticationService/GetMfaStatus"
+	AuthenticationService_SetMfaPolicy_FullMethodName               = "/authentication.AuthenticationService/SetMfaPolicy"
+	AuthenticationService_GetMfaPolicy_FullMethodName               = "/authentication.AuthenticationService/GetMfaPolicy"
+	AuthenticationService_ResetMfa_FullMethodName                   = "/authentication.AuthenticationService/ResetMfa"
+	AuthenticationService_UnlockAccount_FullMethodName              = "/authentication.AuthenticationService/UnlockAccount"
+	AuthenticationService_ListLockedAccounts_FullMethodName         = "/authentication.AuthenticationService/ListLockedAccounts"
+	AuthenticationService_RegisterOidcClient_FullMethodName         = "/authentication.AuthenticationService/RegisterOidcClient"
 )
 
 // AuthenticationServiceClient is the client API for AuthenticationService service.
@@ -57,6 +70,32 @@ type AuthenticationServiceClient interface {
 	// for the authenticated caller. Caller must be authenticated via JWT token.
 	// The private key is generated server-side and returned in PEM form; it is NOT persisted.
 	IssueClientCertificate(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IssueClientCertificateResponse, error)
+	// VerifyMfa exchanges the mfa_token of Authenticate and a second factor for a session token.
+	VerifyMfa(ctx context.Context, in *VerifyMfaRequest, opts ...grpc.CallOption) (*VerifyMfaResponse, error)
+	// EnrollTotp creates a TOTP secret for an account, pending confirmation.
+	EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error)
+	// ConfirmTotp activates the pending TOTP secret once the user proves they hold it.
+	ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error)
+	// RegenerateRecoveryCodes replaces the recovery codes of an account.
+	RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error)
+	// BeginWebAuthnRegistration returns the options to create a passkey.
+	BeginWebAuthnRegistration(ctx context.Context, in *BeginWebAuthnRegistrationRequest, opts ...grpc.CallOption) (*BeginWebAuthnRegistrationResponse, error)
+	// FinishWebAuthnRegistration verifies and stores a new passkey.
+	FinishWebAuthnRegistration(ctx context.Context, in *FinishWebAuthnRegistrationRequest, opts ...grpc.CallOption) (*FinishWebAuthnRegistrationResponse, error)
+	// GetMfaStatus describes the second factors enrolled for an account.
+	GetMfaStatus(ctx context.Context, in *GetMfaStatusRequest, opts ...grpc.CallOption) (*GetMfaStatusResponse, error)
+	// SetMfaPolicy sets which accounts and roles must use a second factor.
+	SetMfaPolicy(ctx context.Context, in *SetMfaPolicyRequest, opts ...grpc.CallOption) (*SetMfaPolicyResponse, error)
+	// GetMfaPolicy returns the cluster MFA policy.
+	GetMfaPolicy(ctx context.Context, in *GetMfaPolicyRequest, opts ...grpc.CallOption) (*GetMfaPolicyResponse, error)
+	// ResetMfa removes the second factors of an account, e.g. after a lost device.
+	ResetMfa(ctx context.Context, in *ResetMfaRequest, opts ...grpc.CallOption) (*ResetMfaResponse, error)
+	// UnlockAccount lifts a sign-in lockout before it expires.
+	UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error)
+	// ListLockedAccounts returns the accounts and addresses locked out after failed sign-ins.
+	ListLockedAccounts(ctx context.Context, in *ListLockedAccountsRequest, opts ...grpc.CallOption) (*ListLockedAccountsResponse, error)
+	// RegisterOidcClient registers an OpenID Connect client and returns its credentials.
+	RegisterOidcClient(ctx context.Context, in *RegisterOidcClientRequest, opts ...grpc.CallOption) (*RegisterOidcClientResponse, error)
 }
 
 type authenticationServiceClient struct {
@@ -147,6 +186,136 @@ func (c *authenticationServiceClient) IssueClientCertificate(ctx context.Context
 	return out, nil
 }
 
+func (c *authenticationServiceClient) VerifyMfa(ctx context.Context, in *VerifyMfaRequest, opts ...grpc.CallOption) (*VerifyMfaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(VerifyMfaResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_VerifyMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) EnrollTotp(ctx context.Context, in *EnrollTotpRequest, opts ...grpc.CallOption) (*EnrollTotpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EnrollTotpResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_EnrollTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) ConfirmTotp(ctx context.Context, in *ConfirmTotpRequest, opts ...grpc.CallOption) (*ConfirmTotpResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConfirmTotpResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_ConfirmTotp_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) RegenerateRecoveryCodes(ctx context.Context, in *RegenerateRecoveryCodesRequest, opts ...grpc.CallOption) (*RegenerateRecoveryCodesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegenerateRecoveryCodesResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_RegenerateRecoveryCodes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) BeginWebAuthnRegistration(ctx context.Context, in *BeginWebAuthnRegistrationRequest, opts ...grpc.CallOption) (*BeginWebAuthnRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginWebAuthnRegistrationResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_BeginWebAuthnRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) FinishWebAuthnRegistration(ctx context.Context, in *FinishWebAuthnRegistrationRequest, opts ...grpc.CallOption) (*FinishWebAuthnRegistrationResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(FinishWebAuthnRegistrationResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_FinishWebAuthnRegistration_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) GetMfaStatus(ctx context.Context, in *GetMfaStatusRequest, opts ...grpc.CallOption) (*GetMfaStatusResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMfaStatusResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_GetMfaStatus_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) SetMfaPolicy(ctx context.Context, in *SetMfaPolicyRequest, opts ...grpc.CallOption) (*SetMfaPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetMfaPolicyResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_SetMfaPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) GetMfaPolicy(ctx context.Context, in *GetMfaPolicyRequest, opts ...grpc.CallOption) (*GetMfaPolicyResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetMfaPolicyResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_GetMfaPolicy_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) ResetMfa(ctx context.Context, in *ResetMfaRequest, opts ...grpc.CallOption) (*ResetMfaResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ResetMfaResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_ResetMfa_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) UnlockAccount(ctx context.Context, in *UnlockAccountRequest, opts ...grpc.CallOption) (*UnlockAccountResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UnlockAccountResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_UnlockAccount_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) ListLockedAccounts(ctx context.Context, in *ListLockedAccountsRequest, opts ...grpc.CallOption) (*ListLockedAccountsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListLockedAccountsResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_ListLockedAccounts_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *authenticationServiceClient) RegisterOidcClient(ctx context.Context, in *RegisterOidcClientRequest, opts ...grpc.CallOption) (*RegisterOidcClientResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RegisterOidcClientResponse)
+	err := c.cc.Invoke(ctx, AuthenticationService_RegisterOidcClient_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // AuthenticationServiceServer is the server API for AuthenticationService service.
 // All implementations should embed UnimplementedAuthenticationServiceServer
 // for forward compatibility.
@@ -171,6 +340,32 @@ type AuthenticationServiceServer interface {
 	// for the authenticated caller. Caller must be authenticated via JWT token.
 	// The private key is generated server-side and returned in PEM form; it is NOT persisted.
 	IssueClientCertificate(context.Context, *emptypb.Empty) (*IssueClientCertificateResponse, error)
+	// VerifyMfa exchanges the mfa_token of Authenticate and a second factor for a session token.
+	VerifyMfa(context.Context, *VerifyMfaRequest) (*VerifyMfaResponse, error)
+	// EnrollTotp creates a TOTP secret for an account, pending confirmation.
+	EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error)
+	// ConfirmTotp activates the pending TOTP secret once the user proves they hold it.
+	ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error)
+	// RegenerateRecoveryCodes replaces the recovery codes of an account.
+	RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error)
+	// BeginWebAuthnRegistration returns the options to create a passkey.
+	BeginWebAuthnRegistration(context.Context, *BeginWebAuthnRegistrationRequest) (*BeginWebAuthnRegistrationResponse, error)
+	// FinishWebAuthnRegistration verifies and stores a new passkey.
+	FinishWebAuthnRegistration(context.Context, *FinishWebAuthnRegistrationRequest) (*FinishWebAuthnRegistrationResponse, error)
+	// GetMfaStatus describes the second factors enrolled for an account.
+	GetMfaStatus(context.Context, *GetMfaStatusRequest) (*GetMfaStatusResponse, error)
+	// SetMfaPolicy sets which accounts and roles must use a second factor.
+	SetMfaPolicy(context.Context, *SetMfaPolicyRequest) (*SetMfaPolicyResponse, error)
+	// GetMfaPolicy returns the cluster MFA policy.
+	GetMfaPolicy(context.Context, *GetMfaPolicyRequest) (*GetMfaPolicyResponse, error)
+	// ResetMfa removes the second factors of an account, e.g. after a lost device.
+	ResetMfa(context.Context, *ResetMfaRequest) (*ResetMfaResponse, error)
+	// UnlockAccount lifts a sign-in lockout before it expires.
+	UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error)
+	// ListLockedAccounts returns the accounts and addresses locked out after failed sign-ins.
+	ListLockedAccounts(context.Context, *ListLockedAccountsRequest) (*ListLockedAccountsResponse, error)
+	// RegisterOidcClient registers an OpenID Connect client and returns its credentials.
+	RegisterOidcClient(context.Context, *RegisterOidcClientRequest) (*RegisterOidcClientResponse, error)
 }
 
 // UnimplementedAuthenticationServiceServer should be embedded to have
@@ -204,6 +399,45 @@ func (UnimplementedAuthenticationServiceServer) SetRootEmail(context.Context, *S
 func (UnimplementedAuthenticationServiceServer) IssueClientCertificate(context.Context, *emptypb.Empty) (*IssueClientCertificateResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method IssueClientCertificate not implemented")
 }
+func (UnimplementedAuthenticationServiceServer) VerifyMfa(context.Context, *VerifyMfaRequest) (*VerifyMfaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method VerifyMfa not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) EnrollTotp(context.Context, *EnrollTotpRequest) (*EnrollTotpResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EnrollTotp not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) ConfirmTotp(context.Context, *ConfirmTotpRequest) (*ConfirmTotpResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConfirmTotp not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) RegenerateRecoveryCodes(context.Context, *RegenerateRecoveryCodesRequest) (*RegenerateRecoveryCodesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegenerateRecoveryCodes not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) BeginWebAuthnRegistration(context.Context, *BeginWebAuthnRegistrationRequest) (*BeginWebAuthnRegistrationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method BeginWebAuthnRegistration not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) FinishWebAuthnRegistration(context.Context, *FinishWebAuthnRegistrationRequest) (*FinishWebAuthnRegistrationResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method FinishWebAuthnRegistration not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) GetMfaStatus(context.Context, *GetMfaStatusRequest) (*GetMfaStatusResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMfaStatus not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) SetMfaPolicy(context.Context, *SetMfaPolicyRequest) (*SetMfaPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetMfaPolicy not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) GetMfaPolicy(context.Context, *GetMfaPolicyRequest) (*GetMfaPolicyResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetMfaPolicy not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) ResetMfa(context.Context, *ResetMfaRequest) (*ResetMfaResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ResetMfa not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) UnlockAccount(context.Context, *UnlockAccountRequest) (*UnlockAccountResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method UnlockAccount not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) ListLockedAccounts(context.Context, *ListLockedAccountsRequest) (*ListLockedAccountsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListLockedAccounts not implemented")
+}
+func (UnimplementedAuthenticationServiceServer) RegisterOidcClient(context.Context, *RegisterOidcClientRequest) (*RegisterOidcClientResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RegisterOidcClient not implemented")
+}
 func (UnimplementedAuthenticationServiceServer) testEmbeddedByValue() {}
 
 // UnsafeAuthenticationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -368,6 +602,240 @@ func _AuthenticationService_IssueClientCertificate_Handler(srv interface{}, ctx
 	return interceptor(ctx, in, info, handler)
 }
 
+func _AuthenticationService_VerifyMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(VerifyMfaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).VerifyMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_VerifyMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).VerifyMfa(ctx, req.(*VerifyMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_EnrollTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EnrollTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).EnrollTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_EnrollTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).EnrollTotp(ctx, req.(*EnrollTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_ConfirmTotp_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmTotpRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).ConfirmTotp(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_ConfirmTotp_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).ConfirmTotp(ctx, req.(*ConfirmTotpRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_RegenerateRecoveryCodes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegenerateRecoveryCodesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).RegenerateRecoveryCodes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_RegenerateRecoveryCodes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).RegenerateRecoveryCodes(ctx, req.(*RegenerateRecoveryCodesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_BeginWebAuthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginWebAuthnRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).BeginWebAuthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_BeginWebAuthnRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).BeginWebAuthnRegistration(ctx, req.(*BeginWebAuthnRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_FinishWebAuthnRegistration_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FinishWebAuthnRegistrationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).FinishWebAuthnRegistration(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_FinishWebAuthnRegistration_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).FinishWebAuthnRegistration(ctx, req.(*FinishWebAuthnRegistrationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_GetMfaStatus_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMfaStatusRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).GetMfaStatus(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_GetMfaStatus_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).GetMfaStatus(ctx, req.(*GetMfaStatusRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_SetMfaPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetMfaPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).SetMfaPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_SetMfaPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).SetMfaPolicy(ctx, req.(*SetMfaPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_GetMfaPolicy_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetMfaPolicyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).GetMfaPolicy(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_GetMfaPolicy_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).GetMfaPolicy(ctx, req.(*GetMfaPolicyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_ResetMfa_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ResetMfaRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).ResetMfa(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_ResetMfa_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).ResetMfa(ctx, req.(*ResetMfaRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_UnlockAccount_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(UnlockAccountRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).UnlockAccount(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_UnlockAccount_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).UnlockAccount(ctx, req.(*UnlockAccountRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_ListLockedAccounts_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListLockedAccountsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).ListLockedAccounts(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_ListLockedAccounts_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).ListLockedAccounts(ctx, req.(*ListLockedAccountsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _AuthenticationService_RegisterOidcClient_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RegisterOidcClientRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(AuthenticationServiceServer).RegisterOidcClient(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: AuthenticationService_RegisterOidcClient_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(AuthenticationServiceServer).RegisterOidcClient(ctx, req.(*RegisterOidcClientRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // AuthenticationService_ServiceDesc is the grpc.ServiceDesc for AuthenticationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -407,6 +875,58 @@ var AuthenticationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "IssueClientCertificate",
 			Handler:    _AuthenticationService_IssueClientCertificate_Handler,
 		},
+		{
+			MethodName: "VerifyMfa",
+			Handler:    _AuthenticationService_VerifyMfa_Handler,
+		},
+		{
+			MethodName: "EnrollTotp",
+			Handler:    _AuthenticationService_EnrollTotp_Handler,
+		},
+		{
+			MethodName: "ConfirmTotp",
+			Handler:    _AuthenticationService_ConfirmTotp_Handler,
+		},
+		{
+			MethodName: "RegenerateRecoveryCodes",
+			Handler:    _AuthenticationService_RegenerateRecoveryCodes_Handler,
+		},
+		{
+			MethodName: "BeginWebAuthnRegistration",
+			Handler:    _AuthenticationService_BeginWebAuthnRegistration_Handler,
+		},
+		{
+			MethodName: "FinishWebAuthnRegistration",
+			Handler:    _AuthenticationService_FinishWebAuthnRegistration_Handler,
+		},
+		{
+			MethodName: "GetMfaStatus",
+			Handler:    _AuthenticationService_GetMfaStatus_Handler,
+		},
+		{
+			MethodName: "SetMfaPolicy",
+			Handler:    _AuthenticationService_SetMfaPolicy_Handler,
+		},
+		{
+			MethodName: "GetMfaPolicy",
+			Handler:    _AuthenticationService_GetMfaPolicy_Handler,
+		},
+		{
+			MethodName: "ResetMfa",
+			Handler:    _AuthenticationService_ResetMfa_Handler,
+		},
+		{
+			MethodName: "UnlockAccount",
+			Handler:    _AuthenticationService_UnlockAccount_Handler,
+		},
+		{
+			MethodName: "ListLockedAccounts",
+			Handler:    _AuthenticationService_ListLockedAccounts_Handler,
+		},
+		{
+			MethodName: "RegisterOidcClient",
+			Handler:    _AuthenticationService_RegisterOidcClient_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "authentication.proto",
@@ -62,3 +62,11 @@ type ServiceRuntime struct{}
 // +globular:schema:invariants="Paths must be absolute; only the file service writes entries"
 // +globular:schema:since_version="0.0.1"
 type PublicDirRegistryEntry struct{}
+
+// +globular:schema:key="/globular/file/share-links/{link_id}"
+// +globular:schema:writer="file"
+// +globular:schema:readers="gateway"
+// +globular:schema:description="File share links. JSON with shared path, permissions, creator, expiry, bcrypt password hash, download limit and count. Read by the gateway to verify /share/ capability URLs."
+// +globular:schema:invariants="Link id is the jti of the signed share token; revoked entries are kept until expiry, then swept; download count only grows"
+// +globular:schema:since_version="0.0.1"
+type ShareLinkRegistryEntry struct{}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// ShareLinkEntry is a share link created by the file service. The signed
+// share token only carries the link id, path and expiry; everything that can
+// change after the token is handed out (revocation, download count) lives
+// here and is checked by the gateway on every access.
+//
+//go:schemalint:ignore — schema owned by marker type in schema_annotations.go
+type ShareLinkEntry struct {
+	ID           string   `json:"id"`
+	Path         string   `json:"path"`
+	IsDir        bool     `json:"is_dir"`
+	Permissions  []string `json:"permissions"` // "read", "list"
+	CreatedBy    string   `json:"created_by"`
+	CreatedAt    int64    `json:"created_at"`              // unix seconds
+	ExpiresAt    int64    `json:"expires_at"`              // unix seconds
+	PasswordHash string   `json:"password_hash,omitempty"` // bcrypt, empty when no password
+	MaxDownloads int64    `json:"max_downloads,omitempty"` // 0 means unlimited
+	Downloads    int64    `json:"downloads"`
+	Revoked      bool     `json:"revoked,omitempty"`
+	RevokedBy    string   `json:"revoked_by,omitempty"`
+}
+
+// ErrShareLinkNotFound is returned when no share link has the given id.
+var ErrShareLinkNotFound = errors.New("share link not found")
+
+const shareLinksPrefix = "/globular/file/share-links/"
+
+// PutShareLink writes a share link entry to the cluster registry.
+func PutShareLink(entry ShareLinkEntry) error {
+	if entry.ID == "" {
+		return errors.New("share link id is empty")
+	}
+	cli, err := GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd client: %w", err)
+	}
+	data, err := json.Marshal(entry)
+	if err != nil {
+		return fmt.Errorf("marshal share link entry: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = cli.Put(ctx, shareLinksPrefix+entry.ID, string(data))
+	return err
+}
+
+// GetShareLink reads a single share link entry.
+func GetShareLink(id string) (*ShareLinkEntry, error) {
+	cli, err := GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := cli.Get(ctx, shareLinksPrefix+id)
+	if err != nil {
+		return nil, fmt.Errorf("etcd get: %w", err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, ErrShareLinkNotFound
+	}
+	var e ShareLinkEntry
+	if err := json.Unmarshal(resp.Kvs[0].Value, &e); err != nil {
+		return nil, fmt.Errorf("unmarshal share link entry: %w", err)
+	}
+	return &e, nil
+}
+
+// ListShareLinks reads all share link entries in the cluster.
+func ListShareLinks() ([]ShareLinkEntry, error) {
+	cli, err := GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	resp, err := cli.Get(ctx, shareLinksPrefix, clientv3.WithPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("etcd get: %w", err)
+	}
+	var entries []ShareLinkEntry
+	for _, kv := range resp.Kvs {
+		var e ShareLinkEntry
+		if err := json.Unmarshal(kv.Value, &e); err != nil {
+			continue // skip corrupt entries
+		}
+		entries = append(entries, e)
+	}
+	return entries, nil
+}
+
+// UpdateShareLink applies fn to the entry with the given id and writes it
+// back only if nobody changed it in between, retrying on conflict. It is how
+// downloads are counted against MaxDownloads without races between gateways.
+// If fn returns an error the entry is left untouched and the error returned.
+func UpdateShareLink(id string, fn func(*ShareLinkEntry) error) (*ShareLinkEntry, error) {
+	cli, err := GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd client: %w", err)
+	}
+	key := shareLinksPrefix + id
+	for attempt := 0; attempt < 8; attempt++ {
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		resp, err := cli.Get(ctx, key)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("etcd get: %w", err)
+		}
+		if len(resp.Kvs) == 0 {
+			cancel()
+			return nil, ErrShareLinkNotFound
+		}
+		var e ShareLinkEntry
+		if err := json.Unmarshal(resp.Kvs[0].Value, &e); err != nil {
+			cancel()
+			return nil, fmt.Errorf("unmarshal share link entry: %w", err)
+		}
+		if err := fn(&e); err != nil {
+			cancel()
+			return nil, err
+		}
+		data, err := json.Marshal(e)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("marshal share link entry: %w", err)
+		}
+		txn, err := cli.Txn(ctx).
+			If(clientv3.Compare(clientv3.ModRevision(key), "=", resp.Kvs[0].ModRevision)).
+			Then(clientv3.OpPut(key, string(data))).
+			Commit()
+		cancel()
+		if err != nil {
+			return nil, fmt.Errorf("etcd txn: %w", err)
+		}
+		if txn.Succeeded {
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("share link %s: too many concurrent updates", id)
+}
+
+// DeleteShareLink removes a share link entry from the cluster registry.
+func DeleteShareLink(id string) error {
+	cli, err := GetEtcdClient()
+	if err != nil {
+		return fmt.Errorf("etcd client: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	_, err = cli.Delete(ctx, shareLinksPrefix+id)
+	return err
+}
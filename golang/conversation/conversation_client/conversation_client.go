@@ -502,3 +502,82 @@ func (client *Conversation_Client) SendMessage(conversation_uuid string, msg *co
 
 	return nil
 }
+
+// Return a page of the conversation history, oldest first: the latest
+// messages, or the ones sent before or after a given message uuid.
+func (client *Conversation_Client) GetMessages(conversation_uuid string, before string, after string, limit int32) ([]*conversationpb.Message, error) {
+	return client.findMessages(&conversationpb.FindMessagesRequest{
+		Conversation: conversation_uuid,
+		Before:       before,
+		After:        after,
+		Limit:        limit,
+	})
+}
+
+// Return the replies to a message, oldest first, sent after a given reply uuid.
+func (client *Conversation_Client) GetReplies(conversation_uuid string, message_uuid string, after string, limit int32) ([]*conversationpb.Message, error) {
+	return client.findMessages(&conversationpb.FindMessagesRequest{
+		Conversation: conversation_uuid,
+		InReplyTo:    message_uuid,
+		After:        after,
+		Limit:        limit,
+	})
+}
+
+/**
+ * Find the messages of a conversation that contain keywords, best match first.
+ */
+func (client *Conversation_Client) FindMessages(conversation_uuid string, keywords []string, language string, offset int32, limit int32) ([]*conversationpb.Message, error) {
+	return client.findMessages(&conversationpb.FindMessagesRequest{
+		Conversation: conversation_uuid,
+		Keywords:     keywords,
+		Language:     language,
+		Offset:       offset,
+		Limit:        limit,
+	})
+}
+
+func (client *Conversation_Client) findMessages(rqst *conversationpb.FindMessagesRequest) ([]*conversationpb.Message, error) {
+	stream, err := client.c.FindMessages(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	msgs := make([]*conversationpb.Message, 0)
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return msgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		msgs = append(msgs, rsp.Message)
+	}
+}
+
+// Mark a message, and the ones sent before it, as read by the authenticated account.
+func (client *Conversation_Client) SetMessageRead(conversation_uuid string, message_uuid string) error {
+	rqst := &conversationpb.SetMessageReadRqst{
+		Conversation: conversation_uuid,
+		Message:      message_uuid,
+	}
+
+	_, err := client.c.SetMessageRead(client.GetCtx(), rqst)
+	return err
+}
+
+// Change the text of a message sent by the authenticated account.
+func (client *Conversation_Client) EditMessage(conversation_uuid string, message_uuid string, text string) (*conversationpb.Message, error) {
+	rqst := &conversationpb.EditMessageRqst{
+		Conversation: conversation_uuid,
+		Uuid:         message_uuid,
+		Text:         text,
+	}
+
+	rsp, err := client.c.EditMessage(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Message, nil
+}
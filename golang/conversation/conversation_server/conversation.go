@@ -44,6 +44,10 @@ func (srv *server) getConversationConnection(id string) (*storage_store.Badger_s
 	}
 	srv.conversations.Store(dbPath, conn)
 	slog.Info("conversation db opened", "path", dbPath)
+
+	if err := srv.ensureMessageIndex(id, conn); err != nil {
+		slog.Error("conversation message index not built", "conversation", id, "err", err)
+	}
 	return conn, nil
 }
 
@@ -203,13 +207,24 @@ func (srv *server) CreateConversation(ctx context.Context, rqst *conversationpb.
 }
 
 /*
-GetConversations returns all conversations in which the given account is indexed as a participant.
+GetConversations returns all conversations in which the given account is indexed
+as a participant, with the number of messages the account has not read yet.
 */
 func (srv *server) GetConversations(ctx context.Context, rqst *conversationpb.GetConversationsRequest) (*conversationpb.GetConversationsResponse, error) {
 	cs, err := srv.getConversations(rqst.Creator)
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
+	for _, c := range cs.Conversations {
+		conn, err := srv.getConversationConnection(c.Uuid)
+		if err != nil {
+			slog.Warn("unread count unavailable", "conversation", c.Uuid, "err", err)
+			continue
+		}
+		if c.UnreadCount, c.LastReadMessage, err = srv.unreadMessages(conn, rqst.Creator); err != nil {
+			slog.Warn("unread count unavailable", "conversation", c.Uuid, "err", err)
+		}
+	}
 	return &conversationpb.GetConversationsResponse{Conversations: cs}, nil
 }
 
@@ -396,8 +411,9 @@ func (srv *server) Disconnect(ctx context.Context, rqst *conversationpb.Disconne
 }
 
 /*
-JoinConversation streams the backlog (if any) and then attaches the client to
-live messages for the given conversation. A valid JWT must be sent via metadata.
+JoinConversation streams the latest messages (if any) and then attaches the
+client to live messages for the given conversation. A valid JWT must be sent
+via metadata.
 */
 func (srv *server) JoinConversation(rqst *conversationpb.JoinConversationRequest, stream conversationpb.ConversationService_JoinConversationServer) error {
 	var clientId string
@@ -417,8 +433,7 @@ func (srv *server) JoinConversation(rqst *conversationpb.JoinConversationRequest
 	join := map[string]interface{}{"action": "join", "name": rqst.ConversationUuid, "uuid": rqst.ConnectionUuid, "clientId": clientId}
 	srv.actions <- join
 
-	conn, err := srv.getConversationConnection(rqst.ConversationUuid)
-	if err != nil {
+	if _, err := srv.getConversationConnection(rqst.ConversationUuid); err != nil {
 		return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
@@ -431,30 +446,21 @@ func (srv *server) JoinConversation(rqst *conversationpb.JoinConversationRequest
 		return status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
-	// Backlog (if any)
-	if data, err := conn.GetItem(rqst.ConversationUuid + "/*"); err == nil && data != nil {
-		results := []interface{}{}
-		if err := json.Unmarshal(data, &results); err != nil {
-			return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-		}
-		if len(results) == 0 {
-			_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: nil, Conversation: conv})
-			slog.Info("join conversation (empty backlog)", "conversation", rqst.ConversationUuid, "clientId", clientId)
-			return nil
-		}
-		for i := range results {
-			m, err := srv.getMessage(
-				results[i].(map[string]interface{})["conversation"].(string),
-				results[i].(map[string]interface{})["uuid"].(string),
-			)
-			if err != nil {
-				return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-			}
-			if i == 0 {
-				_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: m, Conversation: conv})
-			} else {
-				_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: m})
-			}
+	// Backlog: the latest messages, older ones are paged with FindMessages.
+	msgs, err := srv.historyMessages(rqst.ConversationUuid, "", "", "", defaultMessagePage)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if len(msgs) == 0 {
+		_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: nil, Conversation: conv})
+		slog.Info("join conversation (empty backlog)", "conversation", rqst.ConversationUuid, "clientId", clientId)
+		return nil
+	}
+	for i, m := range msgs {
+		if i == 0 {
+			_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: m, Conversation: conv})
+		} else {
+			_ = stream.Send(&conversationpb.JoinConversationResponse{Msg: m})
 		}
 	}
 
@@ -733,43 +739,6 @@ func (srv *server) GetSentInvitations(ctx context.Context, rqst *conversationpb.
 
 //////////////// Messages //////////////////////////////////////////
 
-func (srv *server) sendMessage(msg *conversationpb.Message) error {
-	conn, err := srv.getConversationConnection(msg.Conversation)
-	if err != nil {
-		return err
-	}
-
-	js, err := protojson.Marshal(msg)
-	if err != nil {
-		return err
-	}
-
-	if err := conn.SetItem(msg.Conversation+"/"+msg.Uuid, []byte(js)); err != nil {
-		return err
-	}
-
-	Utility.CreateDirIfNotExist(srv.Root + "/conversations/" + msg.Conversation + "/search_data")
-	srv.search_engine.IndexJsonObject(
-		srv.Root+"/conversations/"+msg.Conversation+"/search_data",
-		string(js), msg.Language, "uuid", []string{"text"}, string(js),
-	)
-
-	conv, err := srv.getConversation(msg.Conversation)
-	if err != nil {
-		return err
-	}
-	conv.LastMessageTime = time.Now().Unix()
-	if err := srv.saveConversation(conv); err != nil {
-		return err
-	}
-
-	send := map[string]interface{}{"action": "send_message", "name": msg.Conversation, "message": msg}
-	srv.actions <- send
-
-	slog.Info("message sent", "conversation", msg.Conversation, "uuid", msg.Uuid, "author", msg.Author)
-	return nil
-}
-
 /*
 SendMessage persists and broadcasts a message to participants of a conversation.
 */
@@ -792,34 +761,47 @@ func (srv *server) DeleteMessage(ctx context.Context, rqst *conversationpb.Delet
 }
 
 /*
-FindMessages streams messages that match a query. (Not implemented in original code.)
+FindMessages streams a page of the conversation history, oldest first: the
+latest messages, or the ones before or after a message. With in_reply_to it
+pages through the replies to that message instead. With keywords it streams
+the messages whose text matches them, best match first. The caller must
+participate in the conversation.
 */
 func (srv *server) FindMessages(rqst *conversationpb.FindMessagesRequest, stream conversationpb.ConversationService_FindMessagesServer) error {
-	return status.Errorf(codes.Unimplemented, "FindMessages is not implemented")
-}
-
-func (srv *server) getMessage(conversation, uuid string) (*conversationpb.Message, error) {
-	conn, err := srv.getConversationConnection(conversation)
+	clientId, _, err := security.GetClientId(stream.Context())
 	if err != nil {
-		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		return status.Errorf(codes.Unauthenticated, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
-	data, err := conn.GetItem(conversation + "/" + uuid)
-	if err != nil {
-		return nil, err
+	if err := srv.checkParticipant(rqst.Conversation, clientId); err != nil {
+		return err
 	}
-	msg := new(conversationpb.Message)
-	if err := protojson.Unmarshal(data, msg); err != nil {
-		return nil, err
+	if rqst.Before != "" && rqst.After != "" {
+		return status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("before and after cannot be used together")))
 	}
-	return msg, nil
-}
 
-func (srv *server) deleteMessages(conversation, uuid string) error {
-	conn, err := srv.getConversationConnection(conversation)
+	limit := rqst.Limit
+	if limit <= 0 {
+		limit = defaultMessagePage
+	} else if limit > maxMessagePage {
+		limit = maxMessagePage
+	}
+
+	var msgs []*conversationpb.Message
+	if len(rqst.Keywords) > 0 {
+		msgs, err = srv.searchMessages(rqst.Conversation, rqst.Keywords, rqst.Language, rqst.InReplyTo, rqst.Offset, limit)
+	} else {
+		msgs, err = srv.historyMessages(rqst.Conversation, rqst.InReplyTo, rqst.Before, rqst.After, int(limit))
+	}
 	if err != nil {
-		return err
+		return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
-	return conn.RemoveItem(conversation + "/" + uuid + "*")
+
+	for _, msg := range msgs {
+		if err := stream.Send(&conversationpb.FindMessagesResponse{Message: msg}); err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 /*
@@ -833,13 +815,15 @@ func (srv *server) LikeMessage(ctx context.Context, rqst *conversationpb.LikeMes
 	if msg.Author == rqst.Account {
 		return nil, status.Errorf(codes.FailedPrecondition, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("cannot like your own message")))
 	}
-	if !Utility.Contains(msg.Likes, rqst.Account) {
-		msg.Dislikes = Utility.RemoveString(msg.Dislikes, rqst.Account)
-		msg.Likes = append(msg.Likes, rqst.Account)
-	} else {
-		msg.Likes = Utility.RemoveString(msg.Likes, rqst.Account)
-	}
-	if err := srv.sendMessage(msg); err != nil {
+	if _, err := srv.modifyMessage(rqst.Conversation, rqst.Message, func(msg *conversationpb.Message) bool {
+		if !Utility.Contains(msg.Likes, rqst.Account) {
+			msg.Dislikes = Utility.RemoveString(msg.Dislikes, rqst.Account)
+			msg.Likes = append(msg.Likes, rqst.Account)
+		} else {
+			msg.Likes = Utility.RemoveString(msg.Likes, rqst.Account)
+		}
+		return true
+	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 	return &conversationpb.LikeMessageResponse{}, nil
@@ -856,24 +840,83 @@ func (srv *server) DislikeMessage(ctx context.Context, rqst *conversationpb.Disl
 	if msg.Author == rqst.Account {
 		return nil, status.Errorf(codes.FailedPrecondition, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("cannot dislike your own message")))
 	}
-	if !Utility.Contains(msg.Dislikes, rqst.Account) {
-		msg.Likes = Utility.RemoveString(msg.Likes, rqst.Account)
-		msg.Dislikes = append(msg.Dislikes, rqst.Account)
-	} else {
-		msg.Dislikes = Utility.RemoveString(msg.Dislikes, rqst.Account)
-	}
-	if err := srv.sendMessage(msg); err != nil {
+	if _, err := srv.modifyMessage(rqst.Conversation, rqst.Message, func(msg *conversationpb.Message) bool {
+		if !Utility.Contains(msg.Dislikes, rqst.Account) {
+			msg.Likes = Utility.RemoveString(msg.Likes, rqst.Account)
+			msg.Dislikes = append(msg.Dislikes, rqst.Account)
+		} else {
+			msg.Dislikes = Utility.RemoveString(msg.Dislikes, rqst.Account)
+		}
+		return true
+	}); err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 	return &conversationpb.DislikeMessageResponse{}, nil
 }
 
 /*
-SetMessageRead marks messages as read for the authenticated account.
-(Not implemented in original code.)
+SetMessageRead marks a message, and every message sent before it, as read by
+the authenticated account. The read marker never moves backward; the account
+is added to the readers of the message, which is sent again to the clients.
 */
 func (srv *server) SetMessageRead(ctx context.Context, rqst *conversationpb.SetMessageReadRqst) (*conversationpb.SetMessageReadResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "SetMessageRead is not implemented")
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if rqst.Account != "" && rqst.Account != clientId {
+		return nil, status.Errorf(codes.PermissionDenied, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("you are not authenticated as "+rqst.Account)))
+	}
+	if err := srv.checkParticipant(rqst.Conversation, clientId); err != nil {
+		return nil, err
+	}
+
+	conn, err := srv.getConversationConnection(rqst.Conversation)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if err := srv.markRead(conn, clientId, rqst.Message); err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if _, err := srv.modifyMessage(rqst.Conversation, rqst.Message, func(msg *conversationpb.Message) bool {
+		if msg.Author == clientId || Utility.Contains(msg.Readers, clientId) {
+			return false
+		}
+		msg.Readers = append(msg.Readers, clientId)
+		return true
+	}); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	return &conversationpb.SetMessageReadResponse{}, nil
+}
+
+/*
+EditMessage replaces the text of a message. Only its author can edit it; the
+previous text is kept in the message history and the search index follows.
+*/
+func (srv *server) EditMessage(ctx context.Context, rqst *conversationpb.EditMessageRqst) (*conversationpb.EditMessageResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, status.Errorf(codes.Unauthenticated, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if strings.TrimSpace(rqst.Text) == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("the message text is empty")))
+	}
+
+	msg, err := srv.getMessage(rqst.Conversation, rqst.Uuid)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if msg.Author != clientId {
+		return nil, status.Errorf(codes.PermissionDenied, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("only the author can edit a message")))
+	}
+
+	msg, err = srv.editMessage(rqst.Conversation, rqst.Uuid, rqst.Text)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	slog.Info("message edited", "conversation", rqst.Conversation, "uuid", rqst.Uuid, "author", clientId)
+	return &conversationpb.EditMessageResponse{Message: msg}, nil
 }
 
 //////////////// Single conversation helpers //////////////////////
@@ -890,6 +933,18 @@ func (srv *server) getConversation(uuid string) (*conversationpb.Conversation, e
 	return c, nil
 }
 
+// checkParticipant fails unless account participates in the conversation.
+func (srv *server) checkParticipant(conversation, account string) error {
+	c, err := srv.getConversation(conversation)
+	if err != nil {
+		return status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if !Utility.Contains(c.Participants, account) {
+		return status.Errorf(codes.PermissionDenied, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(account+" does not participate in the conversation")))
+	}
+	return nil
+}
+
 /*
 GetConversation returns the conversation by its ID.
 */
@@ -0,0 +1,576 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/conversation/conversationpb"
+	"github.com/globulario/services/golang/storage/storage_store"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+//////////////// Message index ////////////////////////////////////
+
+/*
+Messages are stored under "<conversation>/<uuid>" in the conversation store.
+Next to them the store keeps an index that orders them by a sequence number
+given when they are sent:
+
+	seq                             the last sequence number given
+	order/<seq>/<uuid>              the author, for every message
+	thread/<parent>/<seq>/<uuid>    the author, for every reply to parent
+	pos/<uuid>                      the sequence number of the message
+	read/<account>                  "<seq>/<uuid>" of the last message read
+
+None of those prefixes can collide with a conversation uuid.
+*/
+const (
+	messageSeqKey      = "seq"
+	messageIndexKey    = "index_version"
+	messageOrderPrefix = "order/"
+	messageThreadRoot  = "thread/"
+	messagePosPrefix   = "pos/"
+	readMarkerPrefix   = "read/"
+
+	// defaultMessagePage is the number of messages returned by a history
+	// request without limit, and the backlog sent when joining.
+	defaultMessagePage = 50
+
+	// maxMessagePage bounds the limit of a history request.
+	maxMessagePage = 500
+)
+
+// seqKey formats a sequence number so the keys sort in sending order.
+func seqKey(seq int64) string {
+	return fmt.Sprintf("%020d", seq)
+}
+
+func messageThreadPrefix(parent string) string {
+	return messageThreadRoot + parent + "/"
+}
+
+// messageIndexKeys returns the index keys of a message with the given sequence number.
+func messageIndexKeys(msg *conversationpb.Message, seq int64) []string {
+	keys := []string{
+		messageOrderPrefix + seqKey(seq) + "/" + msg.Uuid,
+		messagePosPrefix + msg.Uuid,
+	}
+	if msg.InReplyTo != "" {
+		keys = append(keys, messageThreadPrefix(msg.InReplyTo)+seqKey(seq)+"/"+msg.Uuid)
+	}
+	return keys
+}
+
+/*
+indexMessage gives the message the next sequence number of its conversation.
+A message already indexed keeps its number; isNew tells which case it was.
+*/
+func (srv *server) indexMessage(conn *storage_store.Badger_store, msg *conversationpb.Message) (seq int64, isNew bool, err error) {
+	if seq, err := messageSeq(conn, msg.Uuid); err == nil {
+		return seq, false, nil
+	}
+	seq, err = conn.Increment(messageSeqKey, 1)
+	if err != nil {
+		return 0, false, err
+	}
+	keys := messageIndexKeys(msg, seq)
+	items := make(map[string][]byte, len(keys))
+	for _, k := range keys {
+		items[k] = []byte(msg.Author)
+	}
+	items[messagePosPrefix+msg.Uuid] = []byte(strconv.FormatInt(seq, 10))
+	if err := conn.SetItems(items); err != nil {
+		return 0, false, err
+	}
+	return seq, true, nil
+}
+
+// unindexMessage removes the index keys of a message.
+func (srv *server) unindexMessage(conn *storage_store.Badger_store, msg *conversationpb.Message) error {
+	seq, err := messageSeq(conn, msg.Uuid)
+	if err != nil {
+		return nil // never indexed
+	}
+	return conn.RemoveItems(messageIndexKeys(msg, seq))
+}
+
+// messageSeq returns the sequence number of a message.
+func messageSeq(conn *storage_store.Badger_store, uuid string) (int64, error) {
+	data, err := conn.GetItem(messagePosPrefix + uuid)
+	if err != nil {
+		return 0, err
+	}
+	if data == nil {
+		return 0, errors.New("no message " + uuid + " in the conversation")
+	}
+	return strconv.ParseInt(string(data), 10, 64)
+}
+
+/*
+ensureMessageIndex indexes the messages of a conversation written before the
+index existed, in the order they were created, and counts their replies.
+*/
+func (srv *server) ensureMessageIndex(conversation string, conn *storage_store.Badger_store) error {
+	if v, err := conn.GetItem(messageIndexKey); err != nil {
+		return err
+	} else if v != nil {
+		return nil
+	}
+
+	msgs := []*conversationpb.Message{}
+	token := ""
+	for {
+		page, err := conn.Scan(storage_store.ScanOptions{Prefix: conversation + "/", PageToken: token})
+		if err != nil {
+			return err
+		}
+		for _, it := range page.Items {
+			msg := new(conversationpb.Message)
+			if err := protojson.Unmarshal(it.Value, msg); err != nil {
+				slog.Warn("skip unreadable message", "conversation", conversation, "key", it.Key, "err", err)
+				continue
+			}
+			msgs = append(msgs, msg)
+		}
+		if page.NextPageToken == "" {
+			break
+		}
+		token = page.NextPageToken
+	}
+	sort.SliceStable(msgs, func(i, j int) bool { return msgs[i].CreationTime < msgs[j].CreationTime })
+
+	replies := map[string]int32{}
+	for _, msg := range msgs {
+		if _, isNew, err := srv.indexMessage(conn, msg); err != nil {
+			return err
+		} else if isNew && msg.InReplyTo != "" {
+			replies[msg.InReplyTo]++
+		}
+	}
+	for _, msg := range msgs {
+		if n, ok := replies[msg.Uuid]; ok && msg.ReplyCount != n {
+			msg.ReplyCount = n
+			if err := srv.saveMessage(conn, msg); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(msgs) > 0 {
+		slog.Info("message index built", "conversation", conversation, "messages", len(msgs))
+	}
+	return conn.SetItem(messageIndexKey, []byte("1"))
+}
+
+/*
+pageIndex returns, oldest first, up to limit message uuids of the index under
+prefix: the ones sent right after the sequence number after when it is set,
+else the ones sent right before before when it is set, else the latest ones.
+*/
+func pageIndex(conn *storage_store.Badger_store, prefix string, before, after int64, limit int) ([]string, error) {
+	uuidOf := func(key string) string {
+		return key[strings.LastIndex(key, "/")+1:]
+	}
+
+	if after > 0 {
+		page, err := conn.Scan(storage_store.ScanOptions{Prefix: prefix, Start: prefix + seqKey(after+1), Limit: limit, KeysOnly: true})
+		if err != nil {
+			return nil, err
+		}
+		out := make([]string, 0, len(page.Items))
+		for _, it := range page.Items {
+			out = append(out, uuidOf(it.Key))
+		}
+		return out, nil
+	}
+
+	end := before
+	if end <= 0 {
+		last, err := conn.Increment(messageSeqKey, 0)
+		if err != nil {
+			return nil, err
+		}
+		end = last + 1
+	}
+
+	// The store only scans forward: look at a window of sequence numbers
+	// before end, and widen it until it holds enough messages.
+	for span := int64(limit); ; span *= 4 {
+		lo := end - span
+		if lo < 1 {
+			lo = 1
+		}
+		keys := []string{}
+		token := ""
+		for {
+			page, err := conn.Scan(storage_store.ScanOptions{Prefix: prefix, Start: prefix + seqKey(lo), End: prefix + seqKey(end), PageToken: token, KeysOnly: true})
+			if err != nil {
+				return nil, err
+			}
+			for _, it := range page.Items {
+				keys = append(keys, it.Key)
+			}
+			if page.NextPageToken == "" {
+				break
+			}
+			token = page.NextPageToken
+		}
+		if len(keys) >= limit || lo == 1 {
+			if len(keys) > limit {
+				keys = keys[len(keys)-limit:]
+			}
+			out := make([]string, 0, len(keys))
+			for _, k := range keys {
+				out = append(out, uuidOf(k))
+			}
+			return out, nil
+		}
+	}
+}
+
+//////////////// Message records ///////////////////////////////////
+
+// saveMessage writes a message and indexes its text for search.
+func (srv *server) saveMessage(conn *storage_store.Badger_store, msg *conversationpb.Message) error {
+	js, err := protojson.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetItem(msg.Conversation+"/"+msg.Uuid, js); err != nil {
+		return err
+	}
+
+	// Only the current text is searchable, not the edit history.
+	doc := proto.Clone(msg).(*conversationpb.Message)
+	doc.History = nil
+	docJs, err := protojson.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	Utility.CreateDirIfNotExist(srv.Root + "/conversations/" + msg.Conversation + "/search_data")
+	if err := srv.search_engine.IndexJsonObject(
+		srv.Root+"/conversations/"+msg.Conversation+"/search_data",
+		string(docJs), msg.Language, "uuid", []string{"text"}, string(docJs),
+	); err != nil {
+		slog.Warn("message not indexed for search", "conversation", msg.Conversation, "uuid", msg.Uuid, "err", err)
+	}
+	return nil
+}
+
+// broadcastMessage pushes a new or changed message to the clients of its conversation.
+func (srv *server) broadcastMessage(msg *conversationpb.Message) {
+	srv.actions <- map[string]interface{}{"action": "send_message", "name": msg.Conversation, "message": msg}
+}
+
+/*
+modifyMessage applies change to a stored message, saves it and broadcasts it
+when change reports a modification. Changes of messages are serialized so
+concurrent likes, reads, edits and replies do not overwrite each other.
+*/
+func (srv *server) modifyMessage(conversation, uuid string, change func(msg *conversationpb.Message) bool) (*conversationpb.Message, error) {
+	conn, err := srv.getConversationConnection(conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	srv.messagesMu.Lock()
+	msg, err := srv.getMessage(conversation, uuid)
+	if err != nil {
+		srv.messagesMu.Unlock()
+		return nil, err
+	}
+	changed := change(msg)
+	if changed {
+		err = srv.saveMessage(conn, msg)
+	}
+	srv.messagesMu.Unlock()
+
+	if err != nil {
+		return nil, err
+	}
+	if changed {
+		srv.broadcastMessage(msg)
+	}
+	return msg, nil
+}
+
+//////////////// Read markers //////////////////////////////////////
+
+// readMarker returns the sequence number and uuid of the last message read by account.
+func readMarker(conn *storage_store.Badger_store, account string) (int64, string, error) {
+	data, err := conn.GetItem(readMarkerPrefix + account)
+	if err != nil || data == nil {
+		return 0, "", err
+	}
+	seq, uuid, _ := strings.Cut(string(data), "/")
+	n, err := strconv.ParseInt(seq, 10, 64)
+	if err != nil {
+		return 0, "", err
+	}
+	return n, uuid, nil
+}
+
+// markRead moves the read marker of account to the message, never backward.
+func (srv *server) markRead(conn *storage_store.Badger_store, account, uuid string) error {
+	seq, err := messageSeq(conn, uuid)
+	if err != nil {
+		return err
+	}
+
+	srv.messagesMu.Lock()
+	defer srv.messagesMu.Unlock()
+	cur, _, err := readMarker(conn, account)
+	if err != nil {
+		return err
+	}
+	if seq <= cur {
+		return nil
+	}
+	return conn.SetItem(readMarkerPrefix+account, []byte(seqKey(seq)+"/"+uuid))
+}
+
+/*
+unreadMessages counts the messages sent by others after the read marker of
+account, and returns the uuid of the last message it read.
+*/
+func (srv *server) unreadMessages(conn *storage_store.Badger_store, account string) (int32, string, error) {
+	seq, last, err := readMarker(conn, account)
+	if err != nil {
+		return 0, "", err
+	}
+	var count int32
+	token := ""
+	for {
+		page, err := conn.Scan(storage_store.ScanOptions{Prefix: messageOrderPrefix, Start: messageOrderPrefix + seqKey(seq+1), PageToken: token})
+		if err != nil {
+			return 0, "", err
+		}
+		for _, it := range page.Items {
+			if string(it.Value) != account {
+				count++
+			}
+		}
+		if page.NextPageToken == "" {
+			return count, last, nil
+		}
+		token = page.NextPageToken
+	}
+}
+
+//////////////// Sending and finding ///////////////////////////////
+
+/*
+sendMessage stores a new message, gives it its place in the conversation
+history, counts it as a reply of its parent and sends it to the participants.
+The author has read what they send.
+*/
+func (srv *server) sendMessage(msg *conversationpb.Message) error {
+	if msg.Uuid == "" {
+		msg.Uuid = Utility.RandomUUID()
+	}
+	if msg.CreationTime == 0 {
+		msg.CreationTime = time.Now().Unix()
+	}
+
+	conn, err := srv.getConversationConnection(msg.Conversation)
+	if err != nil {
+		return err
+	}
+	if msg.InReplyTo != "" {
+		if msg.InReplyTo == msg.Uuid {
+			return errors.New("a message cannot reply to itself")
+		}
+		if _, err := srv.getMessage(msg.Conversation, msg.InReplyTo); err != nil {
+			return err
+		}
+	}
+
+	if err := srv.saveMessage(conn, msg); err != nil {
+		return err
+	}
+	_, isNew, err := srv.indexMessage(conn, msg)
+	if err != nil {
+		return err
+	}
+	if isNew && msg.InReplyTo != "" {
+		if _, err := srv.modifyMessage(msg.Conversation, msg.InReplyTo, func(parent *conversationpb.Message) bool {
+			parent.ReplyCount++
+			return true
+		}); err != nil {
+			return err
+		}
+	}
+	if msg.Author != "" {
+		if err := srv.markRead(conn, msg.Author, msg.Uuid); err != nil {
+			return err
+		}
+	}
+
+	conv, err := srv.getConversation(msg.Conversation)
+	if err != nil {
+		return err
+	}
+	conv.LastMessageTime = time.Now().Unix()
+	if err := srv.saveConversation(conv); err != nil {
+		return err
+	}
+
+	srv.broadcastMessage(msg)
+
+	slog.Info("message sent", "conversation", msg.Conversation, "uuid", msg.Uuid, "author", msg.Author)
+	return nil
+}
+
+/*
+historyMessages returns a page of the history of a conversation, or of the
+replies to a message, oldest first. before and after are message uuids.
+*/
+func (srv *server) historyMessages(conversation, inReplyTo, before, after string, limit int) ([]*conversationpb.Message, error) {
+	conn, err := srv.getConversationConnection(conversation)
+	if err != nil {
+		return nil, err
+	}
+
+	var beforeSeq, afterSeq int64
+	if before != "" {
+		if beforeSeq, err = messageSeq(conn, before); err != nil {
+			return nil, err
+		}
+	}
+	if after != "" {
+		if afterSeq, err = messageSeq(conn, after); err != nil {
+			return nil, err
+		}
+	}
+	prefix := messageOrderPrefix
+	if inReplyTo != "" {
+		prefix = messageThreadPrefix(inReplyTo)
+	}
+
+	uuids, err := pageIndex(conn, prefix, beforeSeq, afterSeq, limit)
+	if err != nil {
+		return nil, err
+	}
+	msgs := make([]*conversationpb.Message, 0, len(uuids))
+	for _, uuid := range uuids {
+		msg, err := srv.getMessage(conversation, uuid)
+		if err != nil {
+			slog.Warn("indexed message not found", "conversation", conversation, "uuid", uuid, "err", err)
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+/*
+searchMessages returns the messages of a conversation whose text matches the
+keywords, best match first, read back from the store so they are current.
+*/
+func (srv *server) searchMessages(conversation string, keywords []string, language, inReplyTo string, offset, limit int32) ([]*conversationpb.Message, error) {
+	q := strings.TrimSpace(strings.Join(keywords, " "))
+	if q == "" {
+		return nil, errors.New("no keywords to search")
+	}
+	path := srv.Root + "/conversations/" + conversation + "/search_data"
+	if !Utility.Exists(path) {
+		return []*conversationpb.Message{}, nil
+	}
+
+	// The search engine reports an empty result as an error.
+	results, err := srv.search_engine.SearchDocuments([]string{path}, language, []string{"text"}, q, offset, limit, 0)
+	if err != nil || results == nil {
+		return []*conversationpb.Message{}, nil
+	}
+
+	msgs := make([]*conversationpb.Message, 0, len(results.Results))
+	for _, r := range results.Results {
+		msg, err := srv.getMessage(conversation, r.DocId)
+		if err != nil {
+			continue // deleted since it was indexed
+		}
+		if inReplyTo != "" && msg.InReplyTo != inReplyTo {
+			continue
+		}
+		msgs = append(msgs, msg)
+	}
+	return msgs, nil
+}
+
+// editMessage replaces the text of a message, keeping the previous one in its history.
+func (srv *server) editMessage(conversation, uuid, text string) (*conversationpb.Message, error) {
+	return srv.modifyMessage(conversation, uuid, func(msg *conversationpb.Message) bool {
+		if msg.Text == text {
+			return false
+		}
+		written := msg.CreationTime
+		if msg.EditedTime != 0 {
+			written = msg.EditedTime
+		}
+		msg.History = append(msg.History, &conversationpb.MessageRevision{Text: msg.Text, Time: written})
+		msg.Text = text
+		msg.EditedTime = time.Now().Unix()
+		return true
+	})
+}
+
+func (srv *server) getMessage(conversation, uuid string) (*conversationpb.Message, error) {
+	conn, err := srv.getConversationConnection(conversation)
+	if err != nil {
+		return nil, err
+	}
+	data, err := conn.GetItem(conversation + "/" + uuid)
+	if err != nil {
+		return nil, err
+	}
+	if data == nil {
+		return nil, errors.New("no message " + uuid + " in conversation " + conversation)
+	}
+	msg := new(conversationpb.Message)
+	if err := protojson.Unmarshal(data, msg); err != nil {
+		return nil, err
+	}
+	return msg, nil
+}
+
+// deleteMessages removes a message, its index keys and its search document.
+func (srv *server) deleteMessages(conversation, uuid string) error {
+	conn, err := srv.getConversationConnection(conversation)
+	if err != nil {
+		return err
+	}
+	msg, err := srv.getMessage(conversation, uuid)
+	if err != nil {
+		return err
+	}
+
+	if err := srv.unindexMessage(conn, msg); err != nil {
+		return err
+	}
+	if err := conn.RemoveItem(conversation + "/" + uuid); err != nil {
+		return err
+	}
+	if err := srv.search_engine.DeleteDocument(srv.Root+"/conversations/"+conversation+"/search_data", uuid); err != nil {
+		slog.Warn("message not removed from search", "conversation", conversation, "uuid", uuid, "err", err)
+	}
+
+	if msg.InReplyTo != "" {
+		if _, err := srv.modifyMessage(conversation, msg.InReplyTo, func(parent *conversationpb.Message) bool {
+			if parent.ReplyCount == 0 {
+				return false
+			}
+			parent.ReplyCount--
+			return true
+		}); err != nil {
+			slog.Warn("reply count not updated", "conversation", conversation, "parent", msg.InReplyTo, "err", err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,194 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/globulario/services/golang/conversation/conversationpb"
+	"github.com/globulario/services/golang/search/search_engine"
+	"github.com/globulario/services/golang/storage/storage_store"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// newTestServer returns a server on a temporary root holding conversation
+// "c1" between alice and bob. Broadcast messages are dropped.
+func newTestServer(t *testing.T) *server {
+	srv := &server{
+		Root:          t.TempDir(),
+		search_engine: search_engine.NewBleveSearchEngine(),
+		actions:       make(chan map[string]interface{}),
+	}
+	store := storage_store.NewBadger_store()
+	if err := store.Open(`{"path":"` + srv.Root + `", "name":"conversations"}`); err != nil {
+		t.Fatal(err)
+	}
+	srv.store = store
+	go func() {
+		for range srv.actions {
+		}
+	}()
+	t.Cleanup(func() {
+		srv.closeConversationConnection("c1")
+		_ = srv.search_engine.CloseAll()
+		_ = store.Close()
+	})
+
+	if err := srv.saveConversation(&conversationpb.Conversation{Uuid: "c1", Name: "test", Participants: []string{"alice", "bob"}}); err != nil {
+		t.Fatal(err)
+	}
+	return srv
+}
+
+func send(t *testing.T, srv *server, author, text, inReplyTo string) *conversationpb.Message {
+	t.Helper()
+	msg := &conversationpb.Message{Conversation: "c1", Author: author, Text: text, InReplyTo: inReplyTo, Language: "en"}
+	if err := srv.sendMessage(msg); err != nil {
+		t.Fatal(err)
+	}
+	return msg
+}
+
+func texts(msgs []*conversationpb.Message) string {
+	out := make([]string, 0, len(msgs))
+	for _, m := range msgs {
+		out = append(out, m.Text)
+	}
+	return strings.Join(out, ",")
+}
+
+func TestMessageHistory(t *testing.T) {
+	srv := newTestServer(t)
+	sent := []*conversationpb.Message{}
+	for i := 1; i <= 12; i++ {
+		sent = append(sent, send(t, srv, "alice", fmt.Sprint(i), ""))
+	}
+
+	page, err := srv.historyMessages("c1", "", "", "", 5)
+	if err != nil || texts(page) != "8,9,10,11,12" {
+		t.Fatalf("latest = %s, %v", texts(page), err)
+	}
+	if page, _ = srv.historyMessages("c1", "", page[0].Uuid, "", 5); texts(page) != "3,4,5,6,7" {
+		t.Errorf("before 8 = %s", texts(page))
+	}
+	if page, _ = srv.historyMessages("c1", "", sent[1].Uuid, "", 5); texts(page) != "1" {
+		t.Errorf("before 2 = %s", texts(page))
+	}
+	if page, _ = srv.historyMessages("c1", "", "", sent[9].Uuid, 5); texts(page) != "11,12" {
+		t.Errorf("after 10 = %s", texts(page))
+	}
+
+	// Deleted messages leave the history; the page still fills up.
+	for _, i := range []int{9, 10} {
+		if err := srv.deleteMessages("c1", sent[i].Uuid); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if page, _ = srv.historyMessages("c1", "", "", "", 5); texts(page) != "6,7,8,9,12" {
+		t.Errorf("latest after delete = %s", texts(page))
+	}
+	if _, err := srv.getMessage("c1", sent[9].Uuid); err == nil {
+		t.Error("deleted message still stored")
+	}
+}
+
+func TestReadMarkersAndThreads(t *testing.T) {
+	srv := newTestServer(t)
+	m1 := send(t, srv, "alice", "question", "")
+	m2 := send(t, srv, "bob", "answer", m1.Uuid)
+	m3 := send(t, srv, "bob", "other", "")
+	conn, _ := srv.getConversationConnection("c1")
+
+	if n, last, err := srv.unreadMessages(conn, "alice"); err != nil || n != 2 || last != m1.Uuid {
+		t.Fatalf("alice unread = %d, %q, %v", n, last, err)
+	}
+	if err := srv.markRead(conn, "alice", m3.Uuid); err != nil {
+		t.Fatal(err)
+	}
+	// The marker never moves backward.
+	_ = srv.markRead(conn, "alice", m2.Uuid)
+	if n, last, _ := srv.unreadMessages(conn, "alice"); n != 0 || last != m3.Uuid {
+		t.Errorf("alice unread after read = %d, %q", n, last)
+	}
+	if n, _, _ := srv.unreadMessages(conn, "carol"); n != 3 {
+		t.Errorf("carol unread = %d", n)
+	}
+
+	if parent, _ := srv.getMessage("c1", m1.Uuid); parent.ReplyCount != 1 {
+		t.Errorf("reply count = %d", parent.ReplyCount)
+	}
+	if replies, _ := srv.historyMessages("c1", m1.Uuid, "", "", 10); texts(replies) != "answer" {
+		t.Errorf("replies = %s", texts(replies))
+	}
+	if err := srv.sendMessage(&conversationpb.Message{Conversation: "c1", Author: "bob", Text: "x", InReplyTo: "nope"}); err == nil {
+		t.Error("reply to an unknown message")
+	}
+	_ = srv.deleteMessages("c1", m2.Uuid)
+	if parent, _ := srv.getMessage("c1", m1.Uuid); parent.ReplyCount != 0 {
+		t.Errorf("reply count after delete = %d", parent.ReplyCount)
+	}
+}
+
+func TestEditAndSearchMessages(t *testing.T) {
+	srv := newTestServer(t)
+	msg := send(t, srv, "alice", "the quick brown fox", "")
+	send(t, srv, "bob", "nothing to see", "")
+
+	edited, err := srv.editMessage("c1", msg.Uuid, "a lazy dog")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if edited.EditedTime == 0 || len(edited.History) != 1 || edited.History[0].Text != "the quick brown fox" || edited.History[0].Time != msg.CreationTime {
+		t.Fatalf("edited = %+v", edited)
+	}
+
+	found, err := srv.searchMessages("c1", []string{"dog"}, "en", "", 0, 10)
+	if err != nil || texts(found) != "a lazy dog" {
+		t.Fatalf("search dog = %s, %v", texts(found), err)
+	}
+	if found, _ = srv.searchMessages("c1", []string{"fox"}, "en", "", 0, 10); len(found) != 0 {
+		t.Errorf("search fox = %s", texts(found))
+	}
+	_ = srv.deleteMessages("c1", msg.Uuid)
+	if found, _ = srv.searchMessages("c1", []string{"dog"}, "en", "", 0, 10); len(found) != 0 {
+		t.Errorf("search after delete = %s", texts(found))
+	}
+}
+
+func TestMessageIndexBackfill(t *testing.T) {
+	srv := newTestServer(t)
+
+	// Messages written before the index existed, in no particular key order.
+	conn := storage_store.NewBadger_store()
+	if err := conn.Open(srv.Root + "/conversations/c1"); err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []*conversationpb.Message{
+		{Uuid: "b", Conversation: "c1", Author: "bob", Text: "second", CreationTime: 20},
+		{Uuid: "a", Conversation: "c1", Author: "alice", Text: "first", CreationTime: 10},
+		{Uuid: "c", Conversation: "c1", Author: "alice", Text: "third", CreationTime: 30, InReplyTo: "a"},
+	} {
+		js, _ := protojson.Marshal(m)
+		if err := conn.SetItem("c1/"+m.Uuid, js); err != nil {
+			t.Fatal(err)
+		}
+	}
+	_ = conn.Close()
+
+	page, err := srv.historyMessages("c1", "", "", "", 10)
+	if err != nil || texts(page) != "first,second,third" {
+		t.Fatalf("history = %s, %v", texts(page), err)
+	}
+	if page[0].ReplyCount != 1 {
+		t.Errorf("reply count = %d", page[0].ReplyCount)
+	}
+	if next := send(t, srv, "bob", "fourth", ""); next.Uuid == "" {
+		t.Fatal("no uuid")
+	}
+	if page, _ = srv.historyMessages("c1", "", "c", "", 10); texts(page) != "first,second" {
+		t.Errorf("before third = %s", texts(page))
+	}
+	if page, _ = srv.historyMessages("c1", "", "", "c", 10); texts(page) != "fourth" {
+		t.Errorf("after third = %s", texts(page))
+	}
+}
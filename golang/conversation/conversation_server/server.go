@@ -106,7 +106,8 @@ type server struct {
 
 	search_engine *search_engine.BleveSearchEngine
 	store         storage_store.Store
-	conversations sync.Map // map[accountId]map[conversationName]*conversationpb.Conversation
+	conversations sync.Map   // map[accountId]map[conversationName]*conversationpb.Conversation
+	messagesMu    sync.Mutex // serializes read-modify-write of stored messages
 }
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -222,7 +223,7 @@ func (srv *server) Init() error {
 	if srv.Root == "" {
 		srv.Root = os.TempDir()
 	}
-	srv.search_engine = new(search_engine.BleveSearchEngine)
+	srv.search_engine = search_engine.NewBleveSearchEngine()
 	srv.store = storage_store.NewBadger_store()
 	if err := srv.store.Open(`{"path":"` + srv.Root + `", "name":"conversations"}`); err != nil {
 		logger.Error("opening root store failed", "path", srv.Root, "err", err)
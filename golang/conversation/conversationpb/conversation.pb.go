@@ -224,7 +224,13 @@ type Message struct {
 	// * The dislike like list
 	Dislikes []string `protobuf:"bytes,9,rep,name=dislikes,proto3" json:"dislikes,omitempty"`
 	// * The list of participants who has read the message
-	Readers       []string `protobuf:"bytes,10,rep,name=readers,proto3" json:"readers,omitempty"`
+	Readers []string `protobuf:"bytes,10,rep,name=readers,proto3" json:"readers,omitempty"`
+	// * The last time the author edited the text, 0 if never edited *
+	EditedTime int64 `protobuf:"varint,11,opt,name=edited_time,json=editedTime,proto3" json:"edited_time,omitempty"`
+	// * The previous versions of the text, oldest first *
+	History []*MessageRevision `protobuf:"bytes,12,rep,name=history,proto3" json:"history,omitempty"`
+	// * The number of messages that reply to this one *
+	ReplyCount    int32 `protobuf:"varint,13,opt,name=reply_count,json=replyCount,proto3" json:"reply_count,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -329,6 +335,81 @@ func (x *Message) GetReaders() []string {
 	return nil
 }
 
+func (x *Message) GetEditedTime() int64 {
+	if x != nil {
+		return x.EditedTime
+	}
+	return 0
+}
+
+func (x *Message) GetHistory() []*MessageRevision {
+	if x != nil {
+		return x.History
+	}
+	return nil
+}
+
+func (x *Message) GetReplyCount() int32 {
+	if x != nil {
+		return x.ReplyCount
+	}
+	return 0
+}
+
+// * A previous version of an edited message
+type MessageRevision struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Text  string                 `protobuf:"bytes,1,opt,name=text,proto3" json:"text,omitempty"`
+	// * When that text was written (sent or edited) *
+	Time          int64 `protobuf:"varint,2,opt,name=time,proto3" json:"time,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MessageRevision) Reset() {
+	*x = MessageRevision{}
+	mi := &file_conversation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MessageRevision) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MessageRevision) ProtoMessage() {}
+
+func (x *MessageRevision) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MessageRevision.ProtoReflect.Descriptor instead.
+func (*MessageRevision) Descriptor() ([]byte, []int) {
+	return file_conversation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *MessageRevision) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *MessageRevision) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
 type Conversation struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
 	// * Need to be unique... and trust me it will!
@@ -349,14 +430,18 @@ type Conversation struct {
 	// * Pending invitations
 	Invitations *Invitations `protobuf:"bytes,9,opt,name=invitations,proto3" json:"invitations,omitempty"`
 	// The mac address where the conversation is store.
-	Mac           string `protobuf:"bytes,10,opt,name=mac,proto3" json:"mac,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	Mac string `protobuf:"bytes,10,opt,name=mac,proto3" json:"mac,omitempty"`
+	// * Messages of others the account has not read yet (set by GetConversations) *
+	UnreadCount int32 `protobuf:"varint,11,opt,name=unread_count,json=unreadCount,proto3" json:"unread_count,omitempty"`
+	// * The last message the account has read (set by GetConversations) *
+	LastReadMessage string `protobuf:"bytes,12,opt,name=last_read_message,json=lastReadMessage,proto3" json:"last_read_message,omitempty"`
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
 }
 
 func (x *Conversation) Reset() {
 	*x = Conversation{}
-	mi := &file_conversation_proto_msgTypes[3]
+	mi := &file_conversation_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -368,7 +453,7 @@ func (x *Conversation) String() string {
 func (*Conversation) ProtoMessage() {}
 
 func (x *Conversation) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[3]
+	mi := &file_conversation_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -381,7 +466,7 @@ func (x *Conversation) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Conversation.ProtoReflect.Descriptor instead.
 func (*Conversation) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{3}
+	return file_conversation_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *Conversation) GetUuid() string {
@@ -454,6 +539,20 @@ func (x *Conversation) GetMac() string {
 	return ""
 }
 
+func (x *Conversation) GetUnreadCount() int32 {
+	if x != nil {
+		return x.UnreadCount
+	}
+	return 0
+}
+
+func (x *Conversation) GetLastReadMessage() string {
+	if x != nil {
+		return x.LastReadMessage
+	}
+	return ""
+}
+
 // * List of conversation
 type Conversations struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -464,7 +563,7 @@ type Conversations struct {
 
 func (x *Conversations) Reset() {
 	*x = Conversations{}
-	mi := &file_conversation_proto_msgTypes[4]
+	mi := &file_conversation_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -476,7 +575,7 @@ func (x *Conversations) String() string {
 func (*Conversations) ProtoMessage() {}
 
 func (x *Conversations) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[4]
+	mi := &file_conversation_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -489,7 +588,7 @@ func (x *Conversations) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Conversations.ProtoReflect.Descriptor instead.
 func (*Conversations) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{4}
+	return file_conversation_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *Conversations) GetConversations() []*Conversation {
@@ -509,7 +608,7 @@ type ConnectRequest struct {
 
 func (x *ConnectRequest) Reset() {
 	*x = ConnectRequest{}
-	mi := &file_conversation_proto_msgTypes[5]
+	mi := &file_conversation_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -521,7 +620,7 @@ func (x *ConnectRequest) String() string {
 func (*ConnectRequest) ProtoMessage() {}
 
 func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[5]
+	mi := &file_conversation_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -534,7 +633,7 @@ func (x *ConnectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectRequest.ProtoReflect.Descriptor instead.
 func (*ConnectRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{5}
+	return file_conversation_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *ConnectRequest) GetUuid() string {
@@ -554,7 +653,7 @@ type ConnectResponse struct {
 
 func (x *ConnectResponse) Reset() {
 	*x = ConnectResponse{}
-	mi := &file_conversation_proto_msgTypes[6]
+	mi := &file_conversation_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -566,7 +665,7 @@ func (x *ConnectResponse) String() string {
 func (*ConnectResponse) ProtoMessage() {}
 
 func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[6]
+	mi := &file_conversation_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -579,7 +678,7 @@ func (x *ConnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ConnectResponse.ProtoReflect.Descriptor instead.
 func (*ConnectResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{6}
+	return file_conversation_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *ConnectResponse) GetMsg() *Message {
@@ -598,7 +697,7 @@ type DisconnectRequest struct {
 
 func (x *DisconnectRequest) Reset() {
 	*x = DisconnectRequest{}
-	mi := &file_conversation_proto_msgTypes[7]
+	mi := &file_conversation_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -610,7 +709,7 @@ func (x *DisconnectRequest) String() string {
 func (*DisconnectRequest) ProtoMessage() {}
 
 func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[7]
+	mi := &file_conversation_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -623,7 +722,7 @@ func (x *DisconnectRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectRequest.ProtoReflect.Descriptor instead.
 func (*DisconnectRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{7}
+	return file_conversation_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *DisconnectRequest) GetUuid() string {
@@ -642,7 +741,7 @@ type DisconnectResponse struct {
 
 func (x *DisconnectResponse) Reset() {
 	*x = DisconnectResponse{}
-	mi := &file_conversation_proto_msgTypes[8]
+	mi := &file_conversation_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -654,7 +753,7 @@ func (x *DisconnectResponse) String() string {
 func (*DisconnectResponse) ProtoMessage() {}
 
 func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[8]
+	mi := &file_conversation_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -667,7 +766,7 @@ func (x *DisconnectResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DisconnectResponse.ProtoReflect.Descriptor instead.
 func (*DisconnectResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{8}
+	return file_conversation_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DisconnectResponse) GetResult() bool {
@@ -692,7 +791,7 @@ type CreateConversationRequest struct {
 
 func (x *CreateConversationRequest) Reset() {
 	*x = CreateConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[9]
+	mi := &file_conversation_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -704,7 +803,7 @@ func (x *CreateConversationRequest) String() string {
 func (*CreateConversationRequest) ProtoMessage() {}
 
 func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[9]
+	mi := &file_conversation_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -717,7 +816,7 @@ func (x *CreateConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateConversationRequest.ProtoReflect.Descriptor instead.
 func (*CreateConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{9}
+	return file_conversation_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *CreateConversationRequest) GetName() string {
@@ -750,7 +849,7 @@ type CreateConversationResponse struct {
 
 func (x *CreateConversationResponse) Reset() {
 	*x = CreateConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[10]
+	mi := &file_conversation_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -762,7 +861,7 @@ func (x *CreateConversationResponse) String() string {
 func (*CreateConversationResponse) ProtoMessage() {}
 
 func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[10]
+	mi := &file_conversation_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -775,7 +874,7 @@ func (x *CreateConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateConversationResponse.ProtoReflect.Descriptor instead.
 func (*CreateConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{10}
+	return file_conversation_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *CreateConversationResponse) GetConversation() *Conversation {
@@ -794,7 +893,7 @@ type DeleteConversationRequest struct {
 
 func (x *DeleteConversationRequest) Reset() {
 	*x = DeleteConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[11]
+	mi := &file_conversation_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -806,7 +905,7 @@ func (x *DeleteConversationRequest) String() string {
 func (*DeleteConversationRequest) ProtoMessage() {}
 
 func (x *DeleteConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[11]
+	mi := &file_conversation_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -819,7 +918,7 @@ func (x *DeleteConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteConversationRequest.ProtoReflect.Descriptor instead.
 func (*DeleteConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{11}
+	return file_conversation_proto_rawDescGZIP(), []int{12}
 }
 
 func (x *DeleteConversationRequest) GetConversationUuid() string {
@@ -837,7 +936,7 @@ type DeleteConversationResponse struct {
 
 func (x *DeleteConversationResponse) Reset() {
 	*x = DeleteConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[12]
+	mi := &file_conversation_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -849,7 +948,7 @@ func (x *DeleteConversationResponse) String() string {
 func (*DeleteConversationResponse) ProtoMessage() {}
 
 func (x *DeleteConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[12]
+	mi := &file_conversation_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -862,7 +961,7 @@ func (x *DeleteConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteConversationResponse.ProtoReflect.Descriptor instead.
 func (*DeleteConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{12}
+	return file_conversation_proto_rawDescGZIP(), []int{13}
 }
 
 type SendInvitationRequest struct {
@@ -874,7 +973,7 @@ type SendInvitationRequest struct {
 
 func (x *SendInvitationRequest) Reset() {
 	*x = SendInvitationRequest{}
-	mi := &file_conversation_proto_msgTypes[13]
+	mi := &file_conversation_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -886,7 +985,7 @@ func (x *SendInvitationRequest) String() string {
 func (*SendInvitationRequest) ProtoMessage() {}
 
 func (x *SendInvitationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[13]
+	mi := &file_conversation_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +998,7 @@ func (x *SendInvitationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendInvitationRequest.ProtoReflect.Descriptor instead.
 func (*SendInvitationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{13}
+	return file_conversation_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *SendInvitationRequest) GetInvitation() *Invitation {
@@ -917,7 +1016,7 @@ type SendInvitationResponse struct {
 
 func (x *SendInvitationResponse) Reset() {
 	*x = SendInvitationResponse{}
-	mi := &file_conversation_proto_msgTypes[14]
+	mi := &file_conversation_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -929,7 +1028,7 @@ func (x *SendInvitationResponse) String() string {
 func (*SendInvitationResponse) ProtoMessage() {}
 
 func (x *SendInvitationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[14]
+	mi := &file_conversation_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -942,7 +1041,7 @@ func (x *SendInvitationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendInvitationResponse.ProtoReflect.Descriptor instead.
 func (*SendInvitationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{14}
+	return file_conversation_proto_rawDescGZIP(), []int{15}
 }
 
 type AcceptInvitationRequest struct {
@@ -954,7 +1053,7 @@ type AcceptInvitationRequest struct {
 
 func (x *AcceptInvitationRequest) Reset() {
 	*x = AcceptInvitationRequest{}
-	mi := &file_conversation_proto_msgTypes[15]
+	mi := &file_conversation_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -966,7 +1065,7 @@ func (x *AcceptInvitationRequest) String() string {
 func (*AcceptInvitationRequest) ProtoMessage() {}
 
 func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[15]
+	mi := &file_conversation_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -979,7 +1078,7 @@ func (x *AcceptInvitationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptInvitationRequest.ProtoReflect.Descriptor instead.
 func (*AcceptInvitationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{15}
+	return file_conversation_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *AcceptInvitationRequest) GetInvitation() *Invitation {
@@ -997,7 +1096,7 @@ type AcceptInvitationResponse struct {
 
 func (x *AcceptInvitationResponse) Reset() {
 	*x = AcceptInvitationResponse{}
-	mi := &file_conversation_proto_msgTypes[16]
+	mi := &file_conversation_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1009,7 +1108,7 @@ func (x *AcceptInvitationResponse) String() string {
 func (*AcceptInvitationResponse) ProtoMessage() {}
 
 func (x *AcceptInvitationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[16]
+	mi := &file_conversation_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1022,7 +1121,7 @@ func (x *AcceptInvitationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use AcceptInvitationResponse.ProtoReflect.Descriptor instead.
 func (*AcceptInvitationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{16}
+	return file_conversation_proto_rawDescGZIP(), []int{17}
 }
 
 type DeclineInvitationRequest struct {
@@ -1034,7 +1133,7 @@ type DeclineInvitationRequest struct {
 
 func (x *DeclineInvitationRequest) Reset() {
 	*x = DeclineInvitationRequest{}
-	mi := &file_conversation_proto_msgTypes[17]
+	mi := &file_conversation_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1046,7 +1145,7 @@ func (x *DeclineInvitationRequest) String() string {
 func (*DeclineInvitationRequest) ProtoMessage() {}
 
 func (x *DeclineInvitationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[17]
+	mi := &file_conversation_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1059,7 +1158,7 @@ func (x *DeclineInvitationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeclineInvitationRequest.ProtoReflect.Descriptor instead.
 func (*DeclineInvitationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{17}
+	return file_conversation_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *DeclineInvitationRequest) GetInvitation() *Invitation {
@@ -1077,7 +1176,7 @@ type DeclineInvitationResponse struct {
 
 func (x *DeclineInvitationResponse) Reset() {
 	*x = DeclineInvitationResponse{}
-	mi := &file_conversation_proto_msgTypes[18]
+	mi := &file_conversation_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1089,7 +1188,7 @@ func (x *DeclineInvitationResponse) String() string {
 func (*DeclineInvitationResponse) ProtoMessage() {}
 
 func (x *DeclineInvitationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[18]
+	mi := &file_conversation_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1102,7 +1201,7 @@ func (x *DeclineInvitationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeclineInvitationResponse.ProtoReflect.Descriptor instead.
 func (*DeclineInvitationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{18}
+	return file_conversation_proto_rawDescGZIP(), []int{19}
 }
 
 type RevokeInvitationRequest struct {
@@ -1114,7 +1213,7 @@ type RevokeInvitationRequest struct {
 
 func (x *RevokeInvitationRequest) Reset() {
 	*x = RevokeInvitationRequest{}
-	mi := &file_conversation_proto_msgTypes[19]
+	mi := &file_conversation_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1126,7 +1225,7 @@ func (x *RevokeInvitationRequest) String() string {
 func (*RevokeInvitationRequest) ProtoMessage() {}
 
 func (x *RevokeInvitationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[19]
+	mi := &file_conversation_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1139,7 +1238,7 @@ func (x *RevokeInvitationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeInvitationRequest.ProtoReflect.Descriptor instead.
 func (*RevokeInvitationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{19}
+	return file_conversation_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *RevokeInvitationRequest) GetInvitation() *Invitation {
@@ -1157,7 +1256,7 @@ type RevokeInvitationResponse struct {
 
 func (x *RevokeInvitationResponse) Reset() {
 	*x = RevokeInvitationResponse{}
-	mi := &file_conversation_proto_msgTypes[20]
+	mi := &file_conversation_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1169,7 +1268,7 @@ func (x *RevokeInvitationResponse) String() string {
 func (*RevokeInvitationResponse) ProtoMessage() {}
 
 func (x *RevokeInvitationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[20]
+	mi := &file_conversation_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1182,7 +1281,7 @@ func (x *RevokeInvitationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RevokeInvitationResponse.ProtoReflect.Descriptor instead.
 func (*RevokeInvitationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{20}
+	return file_conversation_proto_rawDescGZIP(), []int{21}
 }
 
 type GetReceivedInvitationsRequest struct {
@@ -1194,7 +1293,7 @@ type GetReceivedInvitationsRequest struct {
 
 func (x *GetReceivedInvitationsRequest) Reset() {
 	*x = GetReceivedInvitationsRequest{}
-	mi := &file_conversation_proto_msgTypes[21]
+	mi := &file_conversation_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1206,7 +1305,7 @@ func (x *GetReceivedInvitationsRequest) String() string {
 func (*GetReceivedInvitationsRequest) ProtoMessage() {}
 
 func (x *GetReceivedInvitationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[21]
+	mi := &file_conversation_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1219,7 +1318,7 @@ func (x *GetReceivedInvitationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReceivedInvitationsRequest.ProtoReflect.Descriptor instead.
 func (*GetReceivedInvitationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{21}
+	return file_conversation_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetReceivedInvitationsRequest) GetAccount() string {
@@ -1238,7 +1337,7 @@ type GetReceivedInvitationsResponse struct {
 
 func (x *GetReceivedInvitationsResponse) Reset() {
 	*x = GetReceivedInvitationsResponse{}
-	mi := &file_conversation_proto_msgTypes[22]
+	mi := &file_conversation_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1250,7 +1349,7 @@ func (x *GetReceivedInvitationsResponse) String() string {
 func (*GetReceivedInvitationsResponse) ProtoMessage() {}
 
 func (x *GetReceivedInvitationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[22]
+	mi := &file_conversation_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1263,7 +1362,7 @@ func (x *GetReceivedInvitationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetReceivedInvitationsResponse.ProtoReflect.Descriptor instead.
 func (*GetReceivedInvitationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{22}
+	return file_conversation_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *GetReceivedInvitationsResponse) GetInvitations() *Invitations {
@@ -1282,7 +1381,7 @@ type GetSentInvitationsRequest struct {
 
 func (x *GetSentInvitationsRequest) Reset() {
 	*x = GetSentInvitationsRequest{}
-	mi := &file_conversation_proto_msgTypes[23]
+	mi := &file_conversation_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1294,7 +1393,7 @@ func (x *GetSentInvitationsRequest) String() string {
 func (*GetSentInvitationsRequest) ProtoMessage() {}
 
 func (x *GetSentInvitationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[23]
+	mi := &file_conversation_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1307,7 +1406,7 @@ func (x *GetSentInvitationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSentInvitationsRequest.ProtoReflect.Descriptor instead.
 func (*GetSentInvitationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{23}
+	return file_conversation_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *GetSentInvitationsRequest) GetAccount() string {
@@ -1326,7 +1425,7 @@ type GetSentInvitationsResponse struct {
 
 func (x *GetSentInvitationsResponse) Reset() {
 	*x = GetSentInvitationsResponse{}
-	mi := &file_conversation_proto_msgTypes[24]
+	mi := &file_conversation_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1338,7 +1437,7 @@ func (x *GetSentInvitationsResponse) String() string {
 func (*GetSentInvitationsResponse) ProtoMessage() {}
 
 func (x *GetSentInvitationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[24]
+	mi := &file_conversation_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1351,7 +1450,7 @@ func (x *GetSentInvitationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetSentInvitationsResponse.ProtoReflect.Descriptor instead.
 func (*GetSentInvitationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{24}
+	return file_conversation_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *GetSentInvitationsResponse) GetInvitations() *Invitations {
@@ -1374,7 +1473,7 @@ type FindConversationsRequest struct {
 
 func (x *FindConversationsRequest) Reset() {
 	*x = FindConversationsRequest{}
-	mi := &file_conversation_proto_msgTypes[25]
+	mi := &file_conversation_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1386,7 +1485,7 @@ func (x *FindConversationsRequest) String() string {
 func (*FindConversationsRequest) ProtoMessage() {}
 
 func (x *FindConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[25]
+	mi := &file_conversation_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1399,7 +1498,7 @@ func (x *FindConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindConversationsRequest.ProtoReflect.Descriptor instead.
 func (*FindConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{25}
+	return file_conversation_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *FindConversationsRequest) GetQuery() string {
@@ -1446,7 +1545,7 @@ type FindConversationsResponse struct {
 
 func (x *FindConversationsResponse) Reset() {
 	*x = FindConversationsResponse{}
-	mi := &file_conversation_proto_msgTypes[26]
+	mi := &file_conversation_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1458,7 +1557,7 @@ func (x *FindConversationsResponse) String() string {
 func (*FindConversationsResponse) ProtoMessage() {}
 
 func (x *FindConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[26]
+	mi := &file_conversation_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1471,7 +1570,7 @@ func (x *FindConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindConversationsResponse.ProtoReflect.Descriptor instead.
 func (*FindConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{26}
+	return file_conversation_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *FindConversationsResponse) GetConversations() []*Conversation {
@@ -1493,7 +1592,7 @@ type JoinConversationRequest struct {
 
 func (x *JoinConversationRequest) Reset() {
 	*x = JoinConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[27]
+	mi := &file_conversation_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1505,7 +1604,7 @@ func (x *JoinConversationRequest) String() string {
 func (*JoinConversationRequest) ProtoMessage() {}
 
 func (x *JoinConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[27]
+	mi := &file_conversation_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1518,7 +1617,7 @@ func (x *JoinConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinConversationRequest.ProtoReflect.Descriptor instead.
 func (*JoinConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{27}
+	return file_conversation_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *JoinConversationRequest) GetConversationUuid() string {
@@ -1545,7 +1644,7 @@ type JoinConversationResponse struct {
 
 func (x *JoinConversationResponse) Reset() {
 	*x = JoinConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[28]
+	mi := &file_conversation_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1557,7 +1656,7 @@ func (x *JoinConversationResponse) String() string {
 func (*JoinConversationResponse) ProtoMessage() {}
 
 func (x *JoinConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[28]
+	mi := &file_conversation_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1570,7 +1669,7 @@ func (x *JoinConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use JoinConversationResponse.ProtoReflect.Descriptor instead.
 func (*JoinConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{28}
+	return file_conversation_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *JoinConversationResponse) GetMsg() *Message {
@@ -1599,7 +1698,7 @@ type KickoutFromConversationRequest struct {
 
 func (x *KickoutFromConversationRequest) Reset() {
 	*x = KickoutFromConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[29]
+	mi := &file_conversation_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1611,7 +1710,7 @@ func (x *KickoutFromConversationRequest) String() string {
 func (*KickoutFromConversationRequest) ProtoMessage() {}
 
 func (x *KickoutFromConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[29]
+	mi := &file_conversation_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1624,7 +1723,7 @@ func (x *KickoutFromConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickoutFromConversationRequest.ProtoReflect.Descriptor instead.
 func (*KickoutFromConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{29}
+	return file_conversation_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *KickoutFromConversationRequest) GetConversationUuid() string {
@@ -1649,7 +1748,7 @@ type KickoutFromConversationResponse struct {
 
 func (x *KickoutFromConversationResponse) Reset() {
 	*x = KickoutFromConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[30]
+	mi := &file_conversation_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1661,7 +1760,7 @@ func (x *KickoutFromConversationResponse) String() string {
 func (*KickoutFromConversationResponse) ProtoMessage() {}
 
 func (x *KickoutFromConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[30]
+	mi := &file_conversation_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1674,7 +1773,7 @@ func (x *KickoutFromConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KickoutFromConversationResponse.ProtoReflect.Descriptor instead.
 func (*KickoutFromConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{30}
+	return file_conversation_proto_rawDescGZIP(), []int{31}
 }
 
 type GetConversationRequest struct {
@@ -1686,7 +1785,7 @@ type GetConversationRequest struct {
 
 func (x *GetConversationRequest) Reset() {
 	*x = GetConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[31]
+	mi := &file_conversation_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1698,7 +1797,7 @@ func (x *GetConversationRequest) String() string {
 func (*GetConversationRequest) ProtoMessage() {}
 
 func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[31]
+	mi := &file_conversation_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1711,7 +1810,7 @@ func (x *GetConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationRequest.ProtoReflect.Descriptor instead.
 func (*GetConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{31}
+	return file_conversation_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetConversationRequest) GetId() string {
@@ -1730,7 +1829,7 @@ type GetConversationResponse struct {
 
 func (x *GetConversationResponse) Reset() {
 	*x = GetConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[32]
+	mi := &file_conversation_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1742,7 +1841,7 @@ func (x *GetConversationResponse) String() string {
 func (*GetConversationResponse) ProtoMessage() {}
 
 func (x *GetConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[32]
+	mi := &file_conversation_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1755,7 +1854,7 @@ func (x *GetConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationResponse.ProtoReflect.Descriptor instead.
 func (*GetConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{32}
+	return file_conversation_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *GetConversationResponse) GetConversation() *Conversation {
@@ -1774,7 +1873,7 @@ type GetConversationsRequest struct {
 
 func (x *GetConversationsRequest) Reset() {
 	*x = GetConversationsRequest{}
-	mi := &file_conversation_proto_msgTypes[33]
+	mi := &file_conversation_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1786,7 +1885,7 @@ func (x *GetConversationsRequest) String() string {
 func (*GetConversationsRequest) ProtoMessage() {}
 
 func (x *GetConversationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[33]
+	mi := &file_conversation_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1799,7 +1898,7 @@ func (x *GetConversationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationsRequest.ProtoReflect.Descriptor instead.
 func (*GetConversationsRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{33}
+	return file_conversation_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *GetConversationsRequest) GetCreator() string {
@@ -1818,7 +1917,7 @@ type GetConversationsResponse struct {
 
 func (x *GetConversationsResponse) Reset() {
 	*x = GetConversationsResponse{}
-	mi := &file_conversation_proto_msgTypes[34]
+	mi := &file_conversation_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1830,7 +1929,7 @@ func (x *GetConversationsResponse) String() string {
 func (*GetConversationsResponse) ProtoMessage() {}
 
 func (x *GetConversationsResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[34]
+	mi := &file_conversation_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1843,7 +1942,7 @@ func (x *GetConversationsResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetConversationsResponse.ProtoReflect.Descriptor instead.
 func (*GetConversationsResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{34}
+	return file_conversation_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *GetConversationsResponse) GetConversations() *Conversations {
@@ -1865,7 +1964,7 @@ type LeaveConversationRequest struct {
 
 func (x *LeaveConversationRequest) Reset() {
 	*x = LeaveConversationRequest{}
-	mi := &file_conversation_proto_msgTypes[35]
+	mi := &file_conversation_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1877,7 +1976,7 @@ func (x *LeaveConversationRequest) String() string {
 func (*LeaveConversationRequest) ProtoMessage() {}
 
 func (x *LeaveConversationRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[35]
+	mi := &file_conversation_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1890,7 +1989,7 @@ func (x *LeaveConversationRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaveConversationRequest.ProtoReflect.Descriptor instead.
 func (*LeaveConversationRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{35}
+	return file_conversation_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *LeaveConversationRequest) GetConversationUuid() string {
@@ -1916,7 +2015,7 @@ type LeaveConversationResponse struct {
 
 func (x *LeaveConversationResponse) Reset() {
 	*x = LeaveConversationResponse{}
-	mi := &file_conversation_proto_msgTypes[36]
+	mi := &file_conversation_proto_msgTypes[37]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1928,7 +2027,7 @@ func (x *LeaveConversationResponse) String() string {
 func (*LeaveConversationResponse) ProtoMessage() {}
 
 func (x *LeaveConversationResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[36]
+	mi := &file_conversation_proto_msgTypes[37]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1941,7 +2040,7 @@ func (x *LeaveConversationResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LeaveConversationResponse.ProtoReflect.Descriptor instead.
 func (*LeaveConversationResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{36}
+	return file_conversation_proto_rawDescGZIP(), []int{37}
 }
 
 func (x *LeaveConversationResponse) GetConversation() *Conversation {
@@ -1961,7 +2060,7 @@ type SendMessageRequest struct {
 
 func (x *SendMessageRequest) Reset() {
 	*x = SendMessageRequest{}
-	mi := &file_conversation_proto_msgTypes[37]
+	mi := &file_conversation_proto_msgTypes[38]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1973,7 +2072,7 @@ func (x *SendMessageRequest) String() string {
 func (*SendMessageRequest) ProtoMessage() {}
 
 func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[37]
+	mi := &file_conversation_proto_msgTypes[38]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1986,7 +2085,7 @@ func (x *SendMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageRequest.ProtoReflect.Descriptor instead.
 func (*SendMessageRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{37}
+	return file_conversation_proto_rawDescGZIP(), []int{38}
 }
 
 func (x *SendMessageRequest) GetMsg() *Message {
@@ -2004,7 +2103,7 @@ type SendMessageResponse struct {
 
 func (x *SendMessageResponse) Reset() {
 	*x = SendMessageResponse{}
-	mi := &file_conversation_proto_msgTypes[38]
+	mi := &file_conversation_proto_msgTypes[39]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2016,7 +2115,7 @@ func (x *SendMessageResponse) String() string {
 func (*SendMessageResponse) ProtoMessage() {}
 
 func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[38]
+	mi := &file_conversation_proto_msgTypes[39]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2029,7 +2128,7 @@ func (x *SendMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SendMessageResponse.ProtoReflect.Descriptor instead.
 func (*SendMessageResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{38}
+	return file_conversation_proto_rawDescGZIP(), []int{39}
 }
 
 type DeleteMessageRequest struct {
@@ -2043,7 +2142,7 @@ type DeleteMessageRequest struct {
 
 func (x *DeleteMessageRequest) Reset() {
 	*x = DeleteMessageRequest{}
-	mi := &file_conversation_proto_msgTypes[39]
+	mi := &file_conversation_proto_msgTypes[40]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2055,7 +2154,7 @@ func (x *DeleteMessageRequest) String() string {
 func (*DeleteMessageRequest) ProtoMessage() {}
 
 func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[39]
+	mi := &file_conversation_proto_msgTypes[40]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2068,7 +2167,7 @@ func (x *DeleteMessageRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMessageRequest.ProtoReflect.Descriptor instead.
 func (*DeleteMessageRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{39}
+	return file_conversation_proto_rawDescGZIP(), []int{40}
 }
 
 func (x *DeleteMessageRequest) GetConversation() string {
@@ -2093,7 +2192,7 @@ type DeleteMessageResponse struct {
 
 func (x *DeleteMessageResponse) Reset() {
 	*x = DeleteMessageResponse{}
-	mi := &file_conversation_proto_msgTypes[40]
+	mi := &file_conversation_proto_msgTypes[41]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2105,7 +2204,7 @@ func (x *DeleteMessageResponse) String() string {
 func (*DeleteMessageResponse) ProtoMessage() {}
 
 func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[40]
+	mi := &file_conversation_proto_msgTypes[41]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2118,19 +2217,34 @@ func (x *DeleteMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteMessageResponse.ProtoReflect.Descriptor instead.
 func (*DeleteMessageResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{40}
+	return file_conversation_proto_rawDescGZIP(), []int{41}
 }
 
 type FindMessagesRequest struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Keywords      []string               `protobuf:"bytes,1,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// * Words to search in the message text, the history when empty
+	Keywords []string `protobuf:"bytes,1,rep,name=keywords,proto3" json:"keywords,omitempty"`
+	// * The conversation uuid
+	Conversation string `protobuf:"bytes,2,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	// * Return the messages sent before that message uuid
+	Before string `protobuf:"bytes,3,opt,name=before,proto3" json:"before,omitempty"`
+	// * Return the messages sent after that message uuid
+	After string `protobuf:"bytes,4,opt,name=after,proto3" json:"after,omitempty"`
+	// * The maximum number of messages, 50 by default
+	Limit int32 `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`
+	// * The number of search results to skip
+	Offset int32 `protobuf:"varint,6,opt,name=offset,proto3" json:"offset,omitempty"`
+	// * Only the replies to that message uuid
+	InReplyTo string `protobuf:"bytes,7,opt,name=in_reply_to,json=inReplyTo,proto3" json:"in_reply_to,omitempty"`
+	// * The language of the keywords
+	Language      string `protobuf:"bytes,8,opt,name=language,proto3" json:"language,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *FindMessagesRequest) Reset() {
 	*x = FindMessagesRequest{}
-	mi := &file_conversation_proto_msgTypes[41]
+	mi := &file_conversation_proto_msgTypes[42]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2142,7 +2256,7 @@ func (x *FindMessagesRequest) String() string {
 func (*FindMessagesRequest) ProtoMessage() {}
 
 func (x *FindMessagesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[41]
+	mi := &file_conversation_proto_msgTypes[42]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2155,7 +2269,7 @@ func (x *FindMessagesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindMessagesRequest.ProtoReflect.Descriptor instead.
 func (*FindMessagesRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{41}
+	return file_conversation_proto_rawDescGZIP(), []int{42}
 }
 
 func (x *FindMessagesRequest) GetKeywords() []string {
@@ -2165,6 +2279,55 @@ func (x *FindMessagesRequest) GetKeywords() []string {
 	return nil
 }
 
+func (x *FindMessagesRequest) GetConversation() string {
+	if x != nil {
+		return x.Conversation
+	}
+	return ""
+}
+
+func (x *FindMessagesRequest) GetBefore() string {
+	if x != nil {
+		return x.Before
+	}
+	return ""
+}
+
+func (x *FindMessagesRequest) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *FindMessagesRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *FindMessagesRequest) GetOffset() int32 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *FindMessagesRequest) GetInReplyTo() string {
+	if x != nil {
+		return x.InReplyTo
+	}
+	return ""
+}
+
+func (x *FindMessagesRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
 type FindMessagesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
@@ -2174,7 +2337,7 @@ type FindMessagesResponse struct {
 
 func (x *FindMessagesResponse) Reset() {
 	*x = FindMessagesResponse{}
-	mi := &file_conversation_proto_msgTypes[42]
+	mi := &file_conversation_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2186,7 +2349,7 @@ func (x *FindMessagesResponse) String() string {
 func (*FindMessagesResponse) ProtoMessage() {}
 
 func (x *FindMessagesResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[42]
+	mi := &file_conversation_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2199,7 +2362,7 @@ func (x *FindMessagesResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use FindMessagesResponse.ProtoReflect.Descriptor instead.
 func (*FindMessagesResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{42}
+	return file_conversation_proto_rawDescGZIP(), []int{43}
 }
 
 func (x *FindMessagesResponse) GetMessage() *Message {
@@ -2220,7 +2383,7 @@ type LikeMessageRqst struct {
 
 func (x *LikeMessageRqst) Reset() {
 	*x = LikeMessageRqst{}
-	mi := &file_conversation_proto_msgTypes[43]
+	mi := &file_conversation_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2232,7 +2395,7 @@ func (x *LikeMessageRqst) String() string {
 func (*LikeMessageRqst) ProtoMessage() {}
 
 func (x *LikeMessageRqst) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[43]
+	mi := &file_conversation_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2245,7 +2408,7 @@ func (x *LikeMessageRqst) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeMessageRqst.ProtoReflect.Descriptor instead.
 func (*LikeMessageRqst) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{43}
+	return file_conversation_proto_rawDescGZIP(), []int{44}
 }
 
 func (x *LikeMessageRqst) GetConversation() string {
@@ -2277,7 +2440,7 @@ type LikeMessageResponse struct {
 
 func (x *LikeMessageResponse) Reset() {
 	*x = LikeMessageResponse{}
-	mi := &file_conversation_proto_msgTypes[44]
+	mi := &file_conversation_proto_msgTypes[45]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2289,7 +2452,7 @@ func (x *LikeMessageResponse) String() string {
 func (*LikeMessageResponse) ProtoMessage() {}
 
 func (x *LikeMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[44]
+	mi := &file_conversation_proto_msgTypes[45]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2302,7 +2465,7 @@ func (x *LikeMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use LikeMessageResponse.ProtoReflect.Descriptor instead.
 func (*LikeMessageResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{44}
+	return file_conversation_proto_rawDescGZIP(), []int{45}
 }
 
 type DislikeMessageRqst struct {
@@ -2316,7 +2479,7 @@ type DislikeMessageRqst struct {
 
 func (x *DislikeMessageRqst) Reset() {
 	*x = DislikeMessageRqst{}
-	mi := &file_conversation_proto_msgTypes[45]
+	mi := &file_conversation_proto_msgTypes[46]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2328,7 +2491,7 @@ func (x *DislikeMessageRqst) String() string {
 func (*DislikeMessageRqst) ProtoMessage() {}
 
 func (x *DislikeMessageRqst) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[45]
+	mi := &file_conversation_proto_msgTypes[46]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2341,7 +2504,7 @@ func (x *DislikeMessageRqst) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DislikeMessageRqst.ProtoReflect.Descriptor instead.
 func (*DislikeMessageRqst) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{45}
+	return file_conversation_proto_rawDescGZIP(), []int{46}
 }
 
 func (x *DislikeMessageRqst) GetConversation() string {
@@ -2373,7 +2536,7 @@ type DislikeMessageResponse struct {
 
 func (x *DislikeMessageResponse) Reset() {
 	*x = DislikeMessageResponse{}
-	mi := &file_conversation_proto_msgTypes[46]
+	mi := &file_conversation_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2385,7 +2548,7 @@ func (x *DislikeMessageResponse) String() string {
 func (*DislikeMessageResponse) ProtoMessage() {}
 
 func (x *DislikeMessageResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[46]
+	mi := &file_conversation_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2398,7 +2561,7 @@ func (x *DislikeMessageResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DislikeMessageResponse.ProtoReflect.Descriptor instead.
 func (*DislikeMessageResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{46}
+	return file_conversation_proto_rawDescGZIP(), []int{47}
 }
 
 type SetMessageReadRqst struct {
@@ -2412,7 +2575,7 @@ type SetMessageReadRqst struct {
 
 func (x *SetMessageReadRqst) Reset() {
 	*x = SetMessageReadRqst{}
-	mi := &file_conversation_proto_msgTypes[47]
+	mi := &file_conversation_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2424,7 +2587,7 @@ func (x *SetMessageReadRqst) String() string {
 func (*SetMessageReadRqst) ProtoMessage() {}
 
 func (x *SetMessageReadRqst) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[47]
+	mi := &file_conversation_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2437,7 +2600,7 @@ func (x *SetMessageReadRqst) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetMessageReadRqst.ProtoReflect.Descriptor instead.
 func (*SetMessageReadRqst) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{47}
+	return file_conversation_proto_rawDescGZIP(), []int{48}
 }
 
 func (x *SetMessageReadRqst) GetConversation() string {
@@ -2469,7 +2632,7 @@ type SetMessageReadResponse struct {
 
 func (x *SetMessageReadResponse) Reset() {
 	*x = SetMessageReadResponse{}
-	mi := &file_conversation_proto_msgTypes[48]
+	mi := &file_conversation_proto_msgTypes[49]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2481,7 +2644,7 @@ func (x *SetMessageReadResponse) String() string {
 func (*SetMessageReadResponse) ProtoMessage() {}
 
 func (x *SetMessageReadResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[48]
+	mi := &file_conversation_proto_msgTypes[49]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2494,7 +2657,111 @@ func (x *SetMessageReadResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetMessageReadResponse.ProtoReflect.Descriptor instead.
 func (*SetMessageReadResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{48}
+	return file_conversation_proto_rawDescGZIP(), []int{49}
+}
+
+type EditMessageRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Conversation  string                 `protobuf:"bytes,1,opt,name=conversation,proto3" json:"conversation,omitempty"`
+	Uuid          string                 `protobuf:"bytes,2,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Text          string                 `protobuf:"bytes,3,opt,name=text,proto3" json:"text,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageRqst) Reset() {
+	*x = EditMessageRqst{}
+	mi := &file_conversation_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageRqst) ProtoMessage() {}
+
+func (x *EditMessageRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageRqst.ProtoReflect.Descriptor instead.
+func (*EditMessageRqst) Descriptor() ([]byte, []int) {
+	return file_conversation_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *EditMessageRqst) GetConversation() string {
+	if x != nil {
+		return x.Conversation
+	}
+	return ""
+}
+
+func (x *EditMessageRqst) GetUuid() string {
+	if x != nil {
+		return x.Uuid
+	}
+	return ""
+}
+
+func (x *EditMessageRqst) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+type EditMessageResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Message       *Message               `protobuf:"bytes,1,opt,name=message,proto3" json:"message,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EditMessageResponse) Reset() {
+	*x = EditMessageResponse{}
+	mi := &file_conversation_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EditMessageResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EditMessageResponse) ProtoMessage() {}
+
+func (x *EditMessageResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_conversation_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EditMessageResponse.ProtoReflect.Descriptor instead.
+func (*EditMessageResponse) Descriptor() ([]byte, []int) {
+	return file_conversation_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *EditMessageResponse) GetMessage() *Message {
+	if x != nil {
+		return x.Message
+	}
+	return nil
 }
 
 type StopRequest struct {
@@ -2505,7 +2772,7 @@ type StopRequest struct {
 
 func (x *StopRequest) Reset() {
 	*x = StopRequest{}
-	mi := &file_conversation_proto_msgTypes[49]
+	mi := &file_conversation_proto_msgTypes[52]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2517,7 +2784,7 @@ func (x *StopRequest) String() string {
 func (*StopRequest) ProtoMessage() {}
 
 func (x *StopRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[49]
+	mi := &file_conversation_proto_msgTypes[52]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2530,7 +2797,7 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
 func (*StopRequest) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{49}
+	return file_conversation_proto_rawDescGZIP(), []int{52}
 }
 
 type StopResponse struct {
@@ -2541,7 +2808,7 @@ type StopResponse struct {
 
 func (x *StopResponse) Reset() {
 	*x = StopResponse{}
-	mi := &file_conversation_proto_msgTypes[50]
+	mi := &file_conversation_proto_msgTypes[53]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2553,7 +2820,7 @@ func (x *StopResponse) String() string {
 func (*StopResponse) ProtoMessage() {}
 
 func (x *StopResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_conversation_proto_msgTypes[50]
+	mi := &file_conversation_proto_msgTypes[53]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2566,7 +2833,7 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
 func (*StopResponse) Descriptor() ([]byte, []int) {
-	return file_conversation_proto_rawDescGZIP(), []int{50}
+	return file_conversation_proto_rawDescGZIP(), []int{53}
 }
 
 var File_conversation_proto protoreflect.FileDescriptor
@@ -2583,7 +2850,7 @@ const file_conversation_proto_rawDesc = "" +
 	"\x04name\x18\x05 \x01(\tR\x04name\x12\x10\n" +
 	"\x03mac\x18\x06 \x01(\tR\x03mac\"I\n" +
 	"\vInvitations\x12:\n" +
-	"\vinvitations\x18\x01 \x03(\v2\x18.conversation.InvitationR\vinvitations\"\x9a\x02\n" +
+	"\vinvitations\x18\x01 \x03(\v2\x18.conversation.InvitationR\vinvitations\"\x95\x03\n" +
 	"\aMessage\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\"\n" +
 	"\fconversation\x18\x02 \x01(\tR\fconversation\x12#\n" +
@@ -2595,7 +2862,15 @@ const file_conversation_proto_rawDesc = "" +
 	"\x05likes\x18\b \x03(\tR\x05likes\x12\x1a\n" +
 	"\bdislikes\x18\t \x03(\tR\bdislikes\x12\x18\n" +
 	"\areaders\x18\n" +
-	" \x03(\tR\areaders\"\xce\x02\n" +
+	" \x03(\tR\areaders\x12\x1f\n" +
+	"\vedited_time\x18\v \x01(\x03R\n" +
+	"editedTime\x127\n" +
+	"\ahistory\x18\f \x03(\v2\x1d.conversation.MessageRevisionR\ahistory\x12\x1f\n" +
+	"\vreply_count\x18\r \x01(\x05R\n" +
+	"replyCount\"9\n" +
+	"\x0fMessageRevision\x12\x12\n" +
+	"\x04text\x18\x01 \x01(\tR\x04text\x12\x12\n" +
+	"\x04time\x18\x02 \x01(\x03R\x04time\"\x9d\x03\n" +
 	"\fConversation\x12\x12\n" +
 	"\x04uuid\x18\x01 \x01(\tR\x04uuid\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
@@ -2607,7 +2882,9 @@ const file_conversation_proto_rawDesc = "" +
 	"\fparticipants\x18\b \x03(\tR\fparticipants\x12;\n" +
 	"\vinvitations\x18\t \x01(\v2\x19.conversation.InvitationsR\vinvitations\x12\x10\n" +
 	"\x03mac\x18\n" +
-	" \x01(\tR\x03mac\"Q\n" +
+	" \x01(\tR\x03mac\x12!\n" +
+	"\funread_count\x18\v \x01(\x05R\vunreadCount\x12*\n" +
+	"\x11last_read_message\x18\f \x01(\tR\x0flastReadMessage\"Q\n" +
 	"\rConversations\x12@\n" +
 	"\rconversations\x18\x01 \x03(\v2\x1a.conversation.ConversationR\rconversations\"$\n" +
 	"\x0eConnectRequest\x12\x12\n" +
@@ -2703,9 +2980,17 @@ const file_conversation_proto_rawDesc = "" +
 	"\fconversation\x10\x01R\fconversation\x12!\n" +
 	"\x04uuid\x18\x02 \x01(\tB\r\x8a\xb5\x18\t\n" +
 	"\amessageR\x04uuid\"\x17\n" +
-	"\x15DeleteMessageResponse\"1\n" +
+	"\x15DeleteMessageResponse\"\x83\x02\n" +
 	"\x13FindMessagesRequest\x12\x1a\n" +
-	"\bkeywords\x18\x01 \x03(\tR\bkeywords\"G\n" +
+	"\bkeywords\x18\x01 \x03(\tR\bkeywords\x128\n" +
+	"\fconversation\x18\x02 \x01(\tB\x14\x8a\xb5\x18\x10\n" +
+	"\fconversation\x10\x01R\fconversation\x12\x16\n" +
+	"\x06before\x18\x03 \x01(\tR\x06before\x12\x14\n" +
+	"\x05after\x18\x04 \x01(\tR\x05after\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x16\n" +
+	"\x06offset\x18\x06 \x01(\x05R\x06offset\x12\x1e\n" +
+	"\vin_reply_to\x18\a \x01(\tR\tinReplyTo\x12\x1a\n" +
+	"\blanguage\x18\b \x01(\tR\blanguage\"G\n" +
 	"\x14FindMessagesResponse\x12/\n" +
 	"\amessage\x18\x01 \x01(\v2\x15.conversation.MessageR\amessage\"\x8e\x01\n" +
 	"\x0fLikeMessageRqst\x128\n" +
@@ -2728,13 +3013,21 @@ const file_conversation_proto_rawDesc = "" +
 	"\amessage\x18\x02 \x01(\tB\r\x8a\xb5\x18\t\n" +
 	"\amessageR\amessage\x12\x18\n" +
 	"\aaccount\x18\x03 \x01(\tR\aaccount\"\x18\n" +
-	"\x16SetMessageReadResponse\"\r\n" +
+	"\x16SetMessageReadResponse\"\x82\x01\n" +
+	"\x0fEditMessageRqst\x128\n" +
+	"\fconversation\x18\x01 \x01(\tB\x14\x8a\xb5\x18\x10\n" +
+	"\fconversation\x10\x01R\fconversation\x12!\n" +
+	"\x04uuid\x18\x02 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\amessageR\x04uuid\x12\x12\n" +
+	"\x04text\x18\x03 \x01(\tR\x04text\"F\n" +
+	"\x13EditMessageResponse\x12/\n" +
+	"\amessage\x18\x01 \x01(\v2\x15.conversation.MessageR\amessage\"\r\n" +
 	"\vStopRequest\"\x0e\n" +
 	"\fStopResponse*.\n" +
 	"\x11ConversationState\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x00\x12\r\n" +
-	"\tSUSPENDED\x10\x012\xbb \n" +
+	"\tSUSPENDED\x10\x012\x97\"\n" +
 	"\x13ConversationService\x12t\n" +
 	"\x04Stop\x12\x19.conversation.StopRequest\x1a\x1a.conversation.StopResponse\"5\x82\xb5\x181\n" +
 	"\x12conversation.admin\x12\x05admin\x1a\r/conversation*\x05admin\x12\x8d\x01\n" +
@@ -2774,15 +3067,17 @@ const file_conversation_proto_rawDesc = "" +
 	"\vSendMessage\x12 .conversation.SendMessageRequest\x1a!.conversation.SendMessageResponse\"d\x82\xb5\x18`\n" +
 	"\x1aconversation.message.write\x12\x05write\"3/conversation/conversations/{conversation}/messages*\x06editor\x12\xc6\x01\n" +
 	"\rDeleteMessage\x12\".conversation.DeleteMessageRequest\x1a#.conversation.DeleteMessageResponse\"l\x82\xb5\x18h\n" +
-	"\x1bconversation.message.delete\x12\x06delete\x1a:/conversation/conversations/{conversation}/messages/{uuid}*\x05admin\x12\x9e\x01\n" +
-	"\fFindMessages\x12!.conversation.FindMessagesRequest\x1a\".conversation.FindMessagesResponse\"E\x82\xb5\x18A\n" +
-	"\x19conversation.message.read\x12\x04read\"\x16/conversation/messages*\x06viewer0\x01\x12\xbf\x01\n" +
+	"\x1bconversation.message.delete\x12\x06delete\x1a:/conversation/conversations/{conversation}/messages/{uuid}*\x05admin\x12\xbb\x01\n" +
+	"\fFindMessages\x12!.conversation.FindMessagesRequest\x1a\".conversation.FindMessagesResponse\"b\x82\xb5\x18^\n" +
+	"\x19conversation.message.read\x12\x04read\"3/conversation/conversations/{conversation}/messages*\x06viewer0\x01\x12\xbf\x01\n" +
 	"\vLikeMessage\x12\x1d.conversation.LikeMessageRqst\x1a!.conversation.LikeMessageResponse\"n\x82\xb5\x18j\n" +
 	"\x1aconversation.message.react\x12\x05write\x1a=/conversation/conversations/{conversation}/messages/{message}*\x06editor\x12\xc8\x01\n" +
 	"\x0eDislikeMessage\x12 .conversation.DislikeMessageRqst\x1a$.conversation.DislikeMessageResponse\"n\x82\xb5\x18j\n" +
 	"\x1aconversation.message.react\x12\x05write\x1a=/conversation/conversations/{conversation}/messages/{message}*\x06editor\x12\xc8\x01\n" +
 	"\x0eSetMessageRead\x12 .conversation.SetMessageReadRqst\x1a$.conversation.SetMessageReadResponse\"n\x82\xb5\x18j\n" +
-	"\x1aconversation.message.write\x12\x05write\x1a=/conversation/conversations/{conversation}/messages/{message}*\x06editorBCZAgithub.com/globulario/services/golang/conversation/conversationpbb\x06proto3"
+	"\x1aconversation.message.write\x12\x05write\x1a=/conversation/conversations/{conversation}/messages/{message}*\x06editor\x12\xbc\x01\n" +
+	"\vEditMessage\x12\x1d.conversation.EditMessageRqst\x1a!.conversation.EditMessageResponse\"k\x82\xb5\x18g\n" +
+	"\x1aconversation.message.write\x12\x05write\x1a:/conversation/conversations/{conversation}/messages/{uuid}*\x06editorBCZAgithub.com/globulario/services/golang/conversation/conversationpbb\x06proto3"
 
 var (
 	file_conversation_proto_rawDescOnce sync.Once
@@ -2797,132 +3092,139 @@ func file_conversation_proto_rawDescGZIP() []byte {
 }
 
 var file_conversation_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 51)
+var file_conversation_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
 var file_conversation_proto_goTypes = []any{
 	(ConversationState)(0),                  // 0: conversation.ConversationState
 	(*Invitation)(nil),                      // 1: conversation.Invitation
 	(*Invitations)(nil),                     // 2: conversation.Invitations
 	(*Message)(nil),                         // 3: conversation.Message
-	(*Conversation)(nil),                    // 4: conversation.Conversation
-	(*Conversations)(nil),                   // 5: conversation.Conversations
-	(*ConnectRequest)(nil),                  // 6: conversation.ConnectRequest
-	(*ConnectResponse)(nil),                 // 7: conversation.ConnectResponse
-	(*DisconnectRequest)(nil),               // 8: conversation.DisconnectRequest
-	(*DisconnectResponse)(nil),              // 9: conversation.DisconnectResponse
-	(*CreateConversationRequest)(nil),       // 10: conversation.CreateConversationRequest
-	(*CreateConversationResponse)(nil),      // 11: conversation.CreateConversationResponse
-	(*DeleteConversationRequest)(nil),       // 12: conversation.DeleteConversationRequest
-	(*DeleteConversationResponse)(nil),      // 13: conversation.DeleteConversationResponse
-	(*SendInvitationRequest)(nil),           // 14: conversation.SendInvitationRequest
-	(*SendInvitationResponse)(nil),          // 15: conversation.SendInvitationResponse
-	(*AcceptInvitationRequest)(nil),         // 16: conversation.AcceptInvitationRequest
-	(*AcceptInvitationResponse)(nil),        // 17: conversation.AcceptInvitationResponse
-	(*DeclineInvitationRequest)(nil),        // 18: conversation.DeclineInvitationRequest
-	(*DeclineInvitationResponse)(nil),       // 19: conversation.DeclineInvitationResponse
-	(*RevokeInvitationRequest)(nil),         // 20: conversation.RevokeInvitationRequest
-	(*RevokeInvitationResponse)(nil),        // 21: conversation.RevokeInvitationResponse
-	(*GetReceivedInvitationsRequest)(nil),   // 22: conversation.GetReceivedInvitationsRequest
-	(*GetReceivedInvitationsResponse)(nil),  // 23: conversation.GetReceivedInvitationsResponse
-	(*GetSentInvitationsRequest)(nil),       // 24: conversation.GetSentInvitationsRequest
-	(*GetSentInvitationsResponse)(nil),      // 25: conversation.GetSentInvitationsResponse
-	(*FindConversationsRequest)(nil),        // 26: conversation.FindConversationsRequest
-	(*FindConversationsResponse)(nil),       // 27: conversation.FindConversationsResponse
-	(*JoinConversationRequest)(nil),         // 28: conversation.JoinConversationRequest
-	(*JoinConversationResponse)(nil),        // 29: conversation.JoinConversationResponse
-	(*KickoutFromConversationRequest)(nil),  // 30: conversation.KickoutFromConversationRequest
-	(*KickoutFromConversationResponse)(nil), // 31: conversation.KickoutFromConversationResponse
-	(*GetConversationRequest)(nil),          // 32: conversation.GetConversationRequest
-	(*GetConversationResponse)(nil),         // 33: conversation.GetConversationResponse
-	(*GetConversationsRequest)(nil),         // 34: conversation.GetConversationsRequest
-	(*GetConversationsResponse)(nil),        // 35: conversation.GetConversationsResponse
-	(*LeaveConversationRequest)(nil),        // 36: conversation.LeaveConversationRequest
-	(*LeaveConversationResponse)(nil),       // 37: conversation.LeaveConversationResponse
-	(*SendMessageRequest)(nil),              // 38: conversation.SendMessageRequest
-	(*SendMessageResponse)(nil),             // 39: conversation.SendMessageResponse
-	(*DeleteMessageRequest)(nil),            // 40: conversation.DeleteMessageRequest
-	(*DeleteMessageResponse)(nil),           // 41: conversation.DeleteMessageResponse
-	(*FindMessagesRequest)(nil),             // 42: conversation.FindMessagesRequest
-	(*FindMessagesResponse)(nil),            // 43: conversation.FindMessagesResponse
-	(*LikeMessageRqst)(nil),                 // 44: conversation.LikeMessageRqst
-	(*LikeMessageResponse)(nil),             // 45: conversation.LikeMessageResponse
-	(*DislikeMessageRqst)(nil),              // 46: conversation.DislikeMessageRqst
-	(*DislikeMessageResponse)(nil),          // 47: conversation.DislikeMessageResponse
-	(*SetMessageReadRqst)(nil),              // 48: conversation.SetMessageReadRqst
-	(*SetMessageReadResponse)(nil),          // 49: conversation.SetMessageReadResponse
-	(*StopRequest)(nil),                     // 50: conversation.StopRequest
-	(*StopResponse)(nil),                    // 51: conversation.StopResponse
+	(*MessageRevision)(nil),                 // 4: conversation.MessageRevision
+	(*Conversation)(nil),                    // 5: conversation.Conversation
+	(*Conversations)(nil),                   // 6: conversation.Conversations
+	(*ConnectRequest)(nil),                  // 7: conversation.ConnectRequest
+	(*ConnectResponse)(nil),                 // 8: conversation.ConnectResponse
+	(*DisconnectRequest)(nil),               // 9: conversation.DisconnectRequest
+	(*DisconnectResponse)(nil),              // 10: conversation.DisconnectResponse
+	(*CreateConversationRequest)(nil),       // 11: conversation.CreateConversationRequest
+	(*CreateConversationResponse)(nil),      // 12: conversation.CreateConversationResponse
+	(*DeleteConversationRequest)(nil),       // 13: conversation.DeleteConversationRequest
+	(*DeleteConversationResponse)(nil),      // 14: conversation.DeleteConversationResponse
+	(*SendInvitationRequest)(nil),           // 15: conversation.SendInvitationRequest
+	(*SendInvitationResponse)(nil),          // 16: conversation.SendInvitationResponse
+	(*AcceptInvitationRequest)(nil),         // 17: conversation.AcceptInvitationRequest
+	(*AcceptInvitationResponse)(nil),        // 18: conversation.AcceptInvitationResponse
+	(*DeclineInvitationRequest)(nil),        // 19: conversation.DeclineInvitationRequest
+	(*DeclineInvitationResponse)(nil),       // 20: conversation.DeclineInvitationResponse
+	(*RevokeInvitationRequest)(nil),         // 21: conversation.RevokeInvitationRequest
+	(*RevokeInvitationResponse)(nil),        // 22: conversation.RevokeInvitationResponse
+	(*GetReceivedInvitationsRequest)(nil),   // 23: conversation.GetReceivedInvitationsRequest
+	(*GetReceivedInvitationsResponse)(nil),  // 24: conversation.GetReceivedInvitationsResponse
+	(*GetSentInvitationsRequest)(nil),       // 25: conversation.GetSentInvitationsRequest
+	(*GetSentInvitationsResponse)(nil),      // 26: conversation.GetSentInvitationsResponse
+	(*FindConversationsRequest)(nil),        // 27: conversation.FindConversationsRequest
+	(*FindConversationsResponse)(nil),       // 28: conversation.FindConversationsResponse
+	(*JoinConversationRequest)(nil),         // 29: conversation.JoinConversationRequest
+	(*JoinConversationResponse)(nil),        // 30: conversation.JoinConversationResponse
+	(*KickoutFromConversationRequest)(nil),  // 31: conversation.KickoutFromConversationRequest
+	(*KickoutFromConversationResponse)(nil), // 32: conversation.KickoutFromConversationResponse
+	(*GetConversationRequest)(nil),          // 33: conversation.GetConversationRequest
+	(*GetConversationResponse)(nil),         // 34: conversation.GetConversationResponse
+	(*GetConversationsRequest)(nil),         // 35: conversation.GetConversationsRequest
+	(*GetConversationsResponse)(nil),        // 36: conversation.GetConversationsResponse
+	(*LeaveConversationRequest)(nil),        // 37: conversation.LeaveConversationRequest
+	(*LeaveConversationResponse)(nil),       // 38: conversation.LeaveConversationResponse
+	(*SendMessageRequest)(nil),              // 39: conversation.SendMessageRequest
+	(*SendMessageResponse)(nil),             // 40: conversation.SendMessageResponse
+	(*DeleteMessageRequest)(nil),            // 41: conversation.DeleteMessageRequest
+	(*DeleteMessageResponse)(nil),           // 42: conversation.DeleteMessageResponse
+	(*FindMessagesRequest)(nil),             // 43: conversation.FindMessagesRequest
+	(*FindMessagesResponse)(nil),            // 44: conversation.FindMessagesResponse
+	(*LikeMessageRqst)(nil),                 // 45: conversation.LikeMessageRqst
+	(*LikeMessageResponse)(nil),             // 46: conversation.LikeMessageResponse
+	(*DislikeMessageRqst)(nil),              // 47: conversation.DislikeMessageRqst
+	(*DislikeMessageResponse)(nil),          // 48: conversation.DislikeMessageResponse
+	(*SetMessageReadRqst)(nil),              // 49: conversation.SetMessageReadRqst
+	(*SetMessageReadResponse)(nil),          // 50: conversation.SetMessageReadResponse
+	(*EditMessageRqst)(nil),                 // 51: conversation.EditMessageRqst
+	(*EditMessageResponse)(nil),             // 52: conversation.EditMessageResponse
+	(*StopRequest)(nil),                     // 53: conversation.StopRequest
+	(*StopResponse)(nil),                    // 54: conversation.StopResponse
 }
 var file_conversation_proto_depIdxs = []int32{
 	1,  // 0: conversation.Invitations.invitations:type_name -> conversation.Invitation
-	2,  // 1: conversation.Conversation.invitations:type_name -> conversation.Invitations
-	4,  // 2: conversation.Conversations.conversations:type_name -> conversation.Conversation
-	3,  // 3: conversation.ConnectResponse.msg:type_name -> conversation.Message
-	4,  // 4: conversation.CreateConversationResponse.conversation:type_name -> conversation.Conversation
-	1,  // 5: conversation.SendInvitationRequest.invitation:type_name -> conversation.Invitation
-	1,  // 6: conversation.AcceptInvitationRequest.invitation:type_name -> conversation.Invitation
-	1,  // 7: conversation.DeclineInvitationRequest.invitation:type_name -> conversation.Invitation
-	1,  // 8: conversation.RevokeInvitationRequest.invitation:type_name -> conversation.Invitation
-	2,  // 9: conversation.GetReceivedInvitationsResponse.invitations:type_name -> conversation.Invitations
-	2,  // 10: conversation.GetSentInvitationsResponse.invitations:type_name -> conversation.Invitations
-	4,  // 11: conversation.FindConversationsResponse.conversations:type_name -> conversation.Conversation
-	3,  // 12: conversation.JoinConversationResponse.msg:type_name -> conversation.Message
-	4,  // 13: conversation.JoinConversationResponse.conversation:type_name -> conversation.Conversation
-	4,  // 14: conversation.GetConversationResponse.conversation:type_name -> conversation.Conversation
-	5,  // 15: conversation.GetConversationsResponse.conversations:type_name -> conversation.Conversations
-	4,  // 16: conversation.LeaveConversationResponse.conversation:type_name -> conversation.Conversation
-	3,  // 17: conversation.SendMessageRequest.msg:type_name -> conversation.Message
-	3,  // 18: conversation.FindMessagesResponse.message:type_name -> conversation.Message
-	50, // 19: conversation.ConversationService.Stop:input_type -> conversation.StopRequest
-	6,  // 20: conversation.ConversationService.Connect:input_type -> conversation.ConnectRequest
-	8,  // 21: conversation.ConversationService.Disconnect:input_type -> conversation.DisconnectRequest
-	10, // 22: conversation.ConversationService.CreateConversation:input_type -> conversation.CreateConversationRequest
-	12, // 23: conversation.ConversationService.DeleteConversation:input_type -> conversation.DeleteConversationRequest
-	26, // 24: conversation.ConversationService.FindConversations:input_type -> conversation.FindConversationsRequest
-	28, // 25: conversation.ConversationService.JoinConversation:input_type -> conversation.JoinConversationRequest
-	36, // 26: conversation.ConversationService.LeaveConversation:input_type -> conversation.LeaveConversationRequest
-	32, // 27: conversation.ConversationService.GetConversation:input_type -> conversation.GetConversationRequest
-	34, // 28: conversation.ConversationService.GetConversations:input_type -> conversation.GetConversationsRequest
-	30, // 29: conversation.ConversationService.KickoutFromConversation:input_type -> conversation.KickoutFromConversationRequest
-	14, // 30: conversation.ConversationService.SendInvitation:input_type -> conversation.SendInvitationRequest
-	16, // 31: conversation.ConversationService.AcceptInvitation:input_type -> conversation.AcceptInvitationRequest
-	18, // 32: conversation.ConversationService.DeclineInvitation:input_type -> conversation.DeclineInvitationRequest
-	20, // 33: conversation.ConversationService.RevokeInvitation:input_type -> conversation.RevokeInvitationRequest
-	22, // 34: conversation.ConversationService.GetReceivedInvitations:input_type -> conversation.GetReceivedInvitationsRequest
-	24, // 35: conversation.ConversationService.GetSentInvitations:input_type -> conversation.GetSentInvitationsRequest
-	38, // 36: conversation.ConversationService.SendMessage:input_type -> conversation.SendMessageRequest
-	40, // 37: conversation.ConversationService.DeleteMessage:input_type -> conversation.DeleteMessageRequest
-	42, // 38: conversation.ConversationService.FindMessages:input_type -> conversation.FindMessagesRequest
-	44, // 39: conversation.ConversationService.LikeMessage:input_type -> conversation.LikeMessageRqst
-	46, // 40: conversation.ConversationService.DislikeMessage:input_type -> conversation.DislikeMessageRqst
-	48, // 41: conversation.ConversationService.SetMessageRead:input_type -> conversation.SetMessageReadRqst
-	51, // 42: conversation.ConversationService.Stop:output_type -> conversation.StopResponse
-	7,  // 43: conversation.ConversationService.Connect:output_type -> conversation.ConnectResponse
-	9,  // 44: conversation.ConversationService.Disconnect:output_type -> conversation.DisconnectResponse
-	11, // 45: conversation.ConversationService.CreateConversation:output_type -> conversation.CreateConversationResponse
-	13, // 46: conversation.ConversationService.DeleteConversation:output_type -> conversation.DeleteConversationResponse
-	27, // 47: conversation.ConversationService.FindConversations:output_type -> conversation.FindConversationsResponse
-	29, // 48: conversation.ConversationService.JoinConversation:output_type -> conversation.JoinConversationResponse
-	37, // 49: conversation.ConversationService.LeaveConversation:output_type -> conversation.LeaveConversationResponse
-	33, // 50: conversation.ConversationService.GetConversation:output_type -> conversation.GetConversationResponse
-	35, // 51: conversation.ConversationService.GetConversations:output_type -> conversation.GetConversationsResponse
-	31, // 52: conversation.ConversationService.KickoutFromConversation:output_type -> conversation.KickoutFromConversationResponse
-	15, // 53: conversation.ConversationService.SendInvitation:output_type -> conversation.SendInvitationResponse
-	17, // 54: conversation.ConversationService.AcceptInvitation:output_type -> conversation.AcceptInvitationResponse
-	19, // 55: conversation.ConversationService.DeclineInvitation:output_type -> conversation.DeclineInvitationResponse
-	21, // 56: conversation.ConversationService.RevokeInvitation:output_type -> conversation.RevokeInvitationResponse
-	23, // 57: conversation.ConversationService.GetReceivedInvitations:output_type -> conversation.GetReceivedInvitationsResponse
-	25, // 58: conversation.ConversationService.GetSentInvitations:output_type -> conversation.GetSentInvitationsResponse
-	39, // 59: conversation.ConversationService.SendMessage:output_type -> conversation.SendMessageResponse
-	41, // 60: conversation.ConversationService.DeleteMessage:output_type -> conversation.DeleteMessageResponse
-	43, // 61: conversation.ConversationService.FindMessages:output_type -> conversation.FindMessagesResponse
-	45, // 62: conversation.ConversationService.LikeMessage:output_type -> conversation.LikeMessageResponse
-	47, // 63: conversation.ConversationService.DislikeMessage:output_type -> conversation.DislikeMessageResponse
-	49, // 64: conversation.ConversationService.SetMessageRead:output_type -> conversation.SetMessageReadResponse
-	42, // [42:65] is the sub-list for method output_type
-	19, // [19:42] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	4,  // 1: conversation.Message.history:type_name -> conversation.MessageRevision
+	2,  // 2: conversation.Conversation.invitations:type_name -> conversation.Invitations
+	5,  // 3: conversation.Conversations.conversations:type_name -> conversation.Conversation
+	3,  // 4: conversation.ConnectResponse.msg:type_name -> conversation.Message
+	5,  // 5: conversation.CreateConversationResponse.conversation:type_name -> conversation.Conversation
+	1,  // 6: conversation.SendInvitationRequest.invitation:type_name -> conversation.Invitation
+	1,  // 7: conversation.AcceptInvitationRequest.invitation:type_name -> conversation.Invitation
+	1,  // 8: conversation.DeclineInvitationRequest.invitation:type_name -> conversation.Invitation
+	1,  // 9: conversation.RevokeInvitationRequest.invitation:type_name -> conversation.Invitation
+	2,  // 10: conversation.GetReceivedInvitationsResponse.invitations:type_name -> conversation.Invitations
+	2,  // 11: conversation.GetSentInvitationsResponse.invitations:type_name -> conversation.Invitations
+	5,  // 12: conversation.FindConversationsResponse.conversations:type_name -> conversation.Conversation
+	3,  // 13: conversation.JoinConversationResponse.msg:type_name -> conversation.Message
+	5,  // 14: conversation.JoinConversationResponse.conversation:type_name -> conversation.Conversation
+	5,  // 15: conversation.GetConversationResponse.conversation:type_name -> conversation.Conversation
+	6,  // 16: conversation.GetConversationsResponse.conversations:type_name -> conversation.Conversations
+	5,  // 17: conversation.LeaveConversationResponse.conversation:type_name -> conversation.Conversation
+	3,  // 18: conversation.SendMessageRequest.msg:type_name -> conversation.Message
+	3,  // 19: conversation.FindMessagesResponse.message:type_name -> conversation.Message
+	3,  // 20: conversation.EditMessageResponse.message:type_name -> conversation.Message
+	53, // 21: conversation.ConversationService.Stop:input_type -> conversation.StopRequest
+	7,  // 22: conversation.ConversationService.Connect:input_type -> conversation.ConnectRequest
+	9,  // 23: conversation.ConversationService.Disconnect:input_type -> conversation.DisconnectRequest
+	11, // 24: conversation.ConversationService.CreateConversation:input_type -> conversation.CreateConversationRequest
+	13, // 25: conversation.ConversationService.DeleteConversation:input_type -> conversation.DeleteConversationRequest
+	27, // 26: conversation.ConversationService.FindConversations:input_type -> conversation.FindConversationsRequest
+	29, // 27: conversation.ConversationService.JoinConversation:input_type -> conversation.JoinConversationRequest
+	37, // 28: conversation.ConversationService.LeaveConversation:input_type -> conversation.LeaveConversationRequest
+	33, // 29: conversation.ConversationService.GetConversation:input_type -> conversation.GetConversationRequest
+	35, // 30: conversation.ConversationService.GetConversations:input_type -> conversation.GetConversationsRequest
+	31, // 31: conversation.ConversationService.KickoutFromConversation:input_type -> conversation.KickoutFromConversationRequest
+	15, // 32: conversation.ConversationService.SendInvitation:input_type -> conversation.SendInvitationRequest
+	17, // 33: conversation.ConversationService.AcceptInvitation:input_type -> conversation.AcceptInvitationRequest
+	19, // 34: conversation.ConversationService.DeclineInvitation:input_type -> conversation.DeclineInvitationRequest
+	21, // 35: conversation.ConversationService.RevokeInvitation:input_type -> conversation.RevokeInvitationRequest
+	23, // 36: conversation.ConversationService.GetReceivedInvitations:input_type -> conversation.GetReceivedInvitationsRequest
+	25, // 37: conversation.ConversationService.GetSentInvitations:input_type -> conversation.GetSentInvitationsRequest
+	39, // 38: conversation.ConversationService.SendMessage:input_type -> conversation.SendMessageRequest
+	41, // 39: conversation.ConversationService.DeleteMessage:input_type -> conversation.DeleteMessageRequest
+	43, // 40: conversation.ConversationService.FindMessages:input_type -> conversation.FindMessagesRequest
+	45, // 41: conversation.ConversationService.LikeMessage:input_type -> conversation.LikeMessageRqst
+	47, // 42: conversation.ConversationService.DislikeMessage:input_type -> conversation.DislikeMessageRqst
+	49, // 43: conversation.ConversationService.SetMessageRead:input_type -> conversation.SetMessageReadRqst
+	51, // 44: conversation.ConversationService.EditMessage:input_type -> conversation.EditMessageRqst
+	54, // 45: conversation.ConversationService.Stop:output_type -> conversation.StopResponse
+	8,  // 46: conversation.ConversationService.Connect:output_type -> conversation.ConnectResponse
+	10, // 47: conversation.ConversationService.Disconnect:output_type -> conversation.DisconnectResponse
+	12, // 48: conversation.ConversationService.CreateConversation:output_type -> conversation.CreateConversationResponse
+	14, // 49: conversation.ConversationService.DeleteConversation:output_type -> conversation.DeleteConversationResponse
+	28, // 50: conversation.ConversationService.FindConversations:output_type -> conversation.FindConversationsResponse
+	30, // 51: conversation.ConversationService.JoinConversation:output_type -> conversation.JoinConversationResponse
+	38, // 52: conversation.ConversationService.LeaveConversation:output_type -> conversation.LeaveConversationResponse
+	34, // 53: conversation.ConversationService.GetConversation:output_type -> conversation.GetConversationResponse
+	36, // 54: conversation.ConversationService.GetConversations:output_type -> conversation.GetConversationsResponse
+	32, // 55: conversation.ConversationService.KickoutFromConversation:output_type -> conversation.KickoutFromConversationResponse
+	16, // 56: conversation.ConversationService.SendInvitation:output_type -> conversation.SendInvitationResponse
+	18, // 57: conversation.ConversationService.AcceptInvitation:output_type -> conversation.AcceptInvitationResponse
+	20, // 58: conversation.ConversationService.DeclineInvitation:output_type -> conversation.DeclineInvitationResponse
+	22, // 59: conversation.ConversationService.RevokeInvitation:output_type -> conversation.RevokeInvitationResponse
+	24, // 60: conversation.ConversationService.GetReceivedInvitations:output_type -> conversation.GetReceivedInvitationsResponse
+	26, // 61: conversation.ConversationService.GetSentInvitations:output_type -> conversation.GetSentInvitationsResponse
+	40, // 62: conversation.ConversationService.SendMessage:output_type -> conversation.SendMessageResponse
+	42, // 63: conversation.ConversationService.DeleteMessage:output_type -> conversation.DeleteMessageResponse
+	44, // 64: conversation.ConversationService.FindMessages:output_type -> conversation.FindMessagesResponse
+	46, // 65: conversation.ConversationService.LikeMessage:output_type -> conversation.LikeMessageResponse
+	48, // 66: conversation.ConversationService.DislikeMessage:output_type -> conversation.DislikeMessageResponse
+	50, // 67: conversation.ConversationService.SetMessageRead:output_type -> conversation.SetMessageReadResponse
+	52, // 68: conversation.ConversationService.EditMessage:output_type -> conversation.EditMessageResponse
+	45, // [45:69] is the sub-list for method output_type
+	21, // [21:45] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_conversation_proto_init() }
@@ -2936,7 +3238,7 @@ func file_conversation_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_conversation_proto_rawDesc), len(file_conversation_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   51,
+			NumMessages:   54,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -45,6 +45,7 @@ const (
 	ConversationService_LikeMessage_FullMethodName             = "/conversation.ConversationService/LikeMessage"
 	ConversationService_DislikeMessage_FullMethodName          = "/conversation.ConversationService/DislikeMessage"
 	ConversationService_SetMessageRead_FullMethodName          = "/conversation.ConversationService/SetMessageRead"
+	ConversationService_EditMessage_FullMethodName             = "/conversation.ConversationService/EditMessage"
 )
 
 // ConversationServiceClient is the client API for ConversationService service.
@@ -92,7 +93,8 @@ type ConversationServiceClient interface {
 	SendMessage(ctx context.Context, in *SendMessageRequest, opts ...grpc.CallOption) (*SendMessageResponse, error)
 	// Delete message.
 	DeleteMessage(ctx context.Context, in *DeleteMessageRequest, opts ...grpc.CallOption) (*DeleteMessageResponse, error)
-	// Retreive a conversation by keywords or name...
+	// Page through the history of a conversation, a thread or the messages
+	// that match keywords.
 	FindMessages(ctx context.Context, in *FindMessagesRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[FindMessagesResponse], error)
 	// append a like message
 	LikeMessage(ctx context.Context, in *LikeMessageRqst, opts ...grpc.CallOption) (*LikeMessageResponse, error)
@@ -100,6 +102,8 @@ type ConversationServiceClient interface {
 	DislikeMessage(ctx context.Context, in *DislikeMessageRqst, opts ...grpc.CallOption) (*DislikeMessageResponse, error)
 	// set message as read
 	SetMessageRead(ctx context.Context, in *SetMessageReadRqst, opts ...grpc.CallOption) (*SetMessageReadResponse, error)
+	// Change the text of a message, the previous text is kept in its history.
+	EditMessage(ctx context.Context, in *EditMessageRqst, opts ...grpc.CallOption) (*EditMessageResponse, error)
 }
 
 type conversationServiceClient struct {
@@ -367,6 +371,16 @@ func (c *conversationServiceClient) SetMessageRead(ctx context.Context, in *SetM
 	return out, nil
 }
 
+func (c *conversationServiceClient) EditMessage(ctx context.Context, in *EditMessageRqst, opts ...grpc.CallOption) (*EditMessageResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(EditMessageResponse)
+	err := c.cc.Invoke(ctx, ConversationService_EditMessage_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ConversationServiceServer is the server API for ConversationService service.
 // All implementations should embed UnimplementedConversationServiceServer
 // for forward compatibility.
@@ -412,7 +426,8 @@ type ConversationServiceServer interface {
 	SendMessage(context.Context, *SendMessageRequest) (*SendMessageResponse, error)
 	// Delete message.
 	DeleteMessage(context.Context, *DeleteMessageRequest) (*DeleteMessageResponse, error)
-	// Retreive a conversation by keywords or name...
+	// Page through the history of a conversation, a thread or the messages
+	// that match keywords.
 	FindMessages(*FindMessagesRequest, grpc.ServerStreamingServer[FindMessagesResponse]) error
 	// append a like message
 	LikeMessage(context.Context, *LikeMessageRqst) (*LikeMessageResponse, error)
@@ -420,6 +435,8 @@ type ConversationServiceServer interface {
 	DislikeMessage(context.Context, *DislikeMessageRqst) (*DislikeMessageResponse, error)
 	// set message as read
 	SetMessageRead(context.Context, *SetMessageReadRqst) (*SetMessageReadResponse, error)
+	// Change the text of a message, the previous text is kept in its history.
+	EditMessage(context.Context, *EditMessageRqst) (*EditMessageResponse, error)
 }
 
 // UnimplementedConversationServiceServer should be embedded to have
@@ -498,6 +515,9 @@ func (UnimplementedConversationServiceServer) DislikeMessage(context.Context, *D
 func (UnimplementedConversationServiceServer) SetMessageRead(context.Context, *SetMessageReadRqst) (*SetMessageReadResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method SetMessageRead not implemented")
 }
+func (UnimplementedConversationServiceServer) EditMessage(context.Context, *EditMessageRqst) (*EditMessageResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method EditMessage not implemented")
+}
 func (UnimplementedConversationServiceServer) testEmbeddedByValue() {}
 
 // UnsafeConversationServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -911,6 +931,24 @@ func _ConversationService_SetMessageRead_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ConversationService_EditMessage_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(EditMessageRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ConversationServiceServer).EditMessage(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ConversationService_EditMessage_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ConversationServiceServer).EditMessage(ctx, req.(*EditMessageRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ConversationService_ServiceDesc is the grpc.ServiceDesc for ConversationService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -998,6 +1036,10 @@ var ConversationService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "SetMessageRead",
 			Handler:    _ConversationService_SetMessageRead_Handler,
 		},
+		{
+			MethodName: "EditMessage",
+			Handler:    _ConversationService_EditMessage_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
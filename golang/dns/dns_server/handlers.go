@@ -2327,6 +2327,17 @@ func (srv *server) RemoveCaa(ctx context.Context, rqst *dnspb.RemoveCaaRequest)
 
 type handler struct{}
 
+// splitTXT cuts a TXT value into the 255-byte character-strings a record is
+// made of; resolvers concatenate them back (DKIM keys are longer than one).
+func splitTXT(value string) []string {
+	parts := make([]string, 0, len(value)/255+1)
+	for len(value) > 255 {
+		parts = append(parts, value[:255])
+		value = value[255:]
+	}
+	return append(parts, value)
+}
+
 // ServeDNS handles incoming DNS queries and formulates appropriate DNS responses
 // based on the query type. It supports various DNS record types including A, AAAA,
 // AFSDB, CAA, CNAME, TXT, NS, MX, SOA, and URI. For each supported query type, it
@@ -2470,7 +2481,7 @@ func (hd *handler) ServeDNS(w dns.ResponseWriter, r *dns.Msg) {
 		for _, txtValue := range values {
 			msg.Answer = append(msg.Answer, &dns.TXT{
 				Hdr: dns.RR_Header{Name: r.Question[0].Name, Rrtype: dns.TypeTXT, Class: dns.ClassINET, Ttl: ttl},
-				Txt: []string{txtValue},
+				Txt: splitTXT(txtValue),
 			})
 		}
 		if err := w.WriteMsg(msg); err != nil && srv.Logger != nil {
@@ -4,9 +4,11 @@ import (
 	"context"
 	"log/slog"
 	"os"
+	"strings"
 	"testing"
 
 	"github.com/globulario/services/golang/dns/dnspb"
+	"github.com/miekg/dns"
 )
 
 // stubStore is a minimal storage_store.Store whose GetItem always returns the
@@ -74,6 +76,23 @@ func TestGetDomains_FallsBackToInMemoryCacheWhenStoreFails(t *testing.T) {
 	}
 }
 
+// TestSplitTXT checks that values longer than one character-string (DKIM
+// keys) are served as several strings that resolvers concatenate back.
+func TestSplitTXT(t *testing.T) {
+	long := strings.Repeat("k", 600)
+	parts := splitTXT(long)
+	if len(parts) != 3 || len(parts[0]) != 255 || len(parts[2]) != 90 || strings.Join(parts, "") != long {
+		t.Fatalf("splitTXT(600 bytes) = %d parts", len(parts))
+	}
+	if parts := splitTXT("v=spf1 -all"); len(parts) != 1 || parts[0] != "v=spf1 -all" {
+		t.Fatalf("splitTXT(short) = %q", parts)
+	}
+	rr := &dns.TXT{Hdr: dns.RR_Header{Name: "sel._domainkey.example.com.", Rrtype: dns.TypeTXT, Class: dns.ClassINET}, Txt: parts}
+	if _, err := dns.PackRR(rr, make([]byte, 1024), 0, nil, false); err != nil {
+		t.Fatalf("pack split TXT: %v", err)
+	}
+}
+
 type assertErr string
 
 func (e assertErr) Error() string { return string(e) }
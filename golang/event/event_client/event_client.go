@@ -12,6 +12,8 @@ import (
 	globular "github.com/globulario/services/golang/globular_client"
 	Utility "github.com/globulario/utility"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 ////////////////////////////////////////////////////////////////////////////////
@@ -429,11 +431,20 @@ const publishCircuitCooldown = 30 * time.Second
 
 // Publish and event over the network
 func (client *Event_Client) Publish(name string, data []byte) error {
+	_, err := client.PublishEvent(&eventpb.Event{Name: name, Data: data})
+	return err
+}
+
+// PublishEvent publishes an event with its envelope (correlation id, content
+// type, schema id, idempotency key, headers). The publisher field is set by
+// the server. It returns true when the server dropped the event as a
+// duplicate of an earlier publish with the same idempotency key.
+func (client *Event_Client) PublishEvent(evt *eventpb.Event) (bool, error) {
 	// Circuit breaker: skip RPC if recently failed.
 	if openUntil := publishCircuitOpenUntil.Load(); openUntil > 0 {
 		if time.Now().UnixNano() < openUntil {
 			publishDropCount.Add(1)
-			return errors.New("event publish circuit breaker open")
+			return false, errors.New("event publish circuit breaker open")
 		}
 		// Circuit closed — log how many were suppressed during the window.
 		dropped := publishDropCount.Swap(0)
@@ -444,22 +455,20 @@ func (client *Event_Client) Publish(name string, data []byte) error {
 		}
 	}
 
-	rqst := &eventpb.PublishRequest{
-		Evt: &eventpb.Event{
-			Name: name,
-			Data: data,
-		},
-	}
+	rqst := &eventpb.PublishRequest{Evt: evt}
 
 	ctx, cancel := context.WithTimeout(client.GetCtx(), 5*time.Second)
 	defer cancel()
 
-	_, err := client.c.Publish(ctx, rqst)
+	rsp, err := client.c.Publish(ctx, rqst)
 	if err != nil {
-		publishCircuitOpenUntil.Store(time.Now().Add(publishCircuitCooldown).UnixNano())
-		return err
+		// Rejected payloads say nothing about the server's health.
+		if status.Code(err) != codes.InvalidArgument {
+			publishCircuitOpenUntil.Store(time.Now().Add(publishCircuitCooldown).UnixNano())
+		}
+		return false, err
 	}
-	return nil
+	return rsp.Duplicate, nil
 }
 
 // streamRecvTimeout is the maximum time to wait for any message (event or
@@ -689,6 +698,117 @@ func (client *Event_Client) UnSubscribeCtx(ctx context.Context, name, uuid strin
     return nil
 }
 
+// CreateConsumerGroup creates a durable consumer group on a channel pattern.
+// A zero ack timeout or max deliveries takes the server default. It returns
+// false when the group already exists.
+func (client *Event_Client) CreateConsumerGroup(name, channel string, ackTimeout time.Duration, maxDeliveries int) (bool, error) {
+	rsp, err := client.c.CreateConsumerGroup(client.GetCtx(), &eventpb.CreateConsumerGroupRequest{
+		Group: &eventpb.ConsumerGroup{
+			Name:              name,
+			Channel:           channel,
+			AckTimeoutSeconds: uint32(ackTimeout / time.Second),
+			MaxDeliveries:     uint32(maxDeliveries),
+		},
+	})
+	if err != nil {
+		return false, err
+	}
+	return rsp.Result, nil
+}
+
+// DeleteConsumerGroup drops a consumer group and its pending events.
+func (client *Event_Client) DeleteConsumerGroup(name string) error {
+	_, err := client.c.DeleteConsumerGroup(client.GetCtx(), &eventpb.DeleteConsumerGroupRequest{Name: name})
+	return err
+}
+
+// ListConsumerGroups returns the consumer groups with their pending counts.
+func (client *Event_Client) ListConsumerGroups() ([]*eventpb.ConsumerGroup, error) {
+	rsp, err := client.c.ListConsumerGroups(client.GetCtx(), &eventpb.ListConsumerGroupsRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Groups, nil
+}
+
+// Ack acknowledges a delivery of a consumer group.
+func (client *Event_Client) Ack(group, deliveryId string) error {
+	_, err := client.c.Ack(client.GetCtx(), &eventpb.AckRequest{Group: group, DeliveryId: deliveryId})
+	return err
+}
+
+// Nack rejects a delivery of a consumer group; it is redelivered after delay.
+func (client *Event_Client) Nack(group, deliveryId string, delay time.Duration) error {
+	_, err := client.c.Nack(client.GetCtx(), &eventpb.NackRequest{
+		Group:               group,
+		DeliveryId:          deliveryId,
+		RequeueDelaySeconds: uint32(delay / time.Second),
+	})
+	return err
+}
+
+// Consume joins a consumer group as member and calls fct for each delivery,
+// one at a time. A delivery is acked when fct returns nil and nacked
+// otherwise. Consume returns when ctx is done or the stream breaks; the
+// caller reconnects by calling it again with the same member, and events
+// not acked by then are redelivered.
+func (client *Event_Client) Consume(ctx context.Context, group, member string, fct func(*eventpb.Delivery) error) error {
+	if ctx == nil {
+		ctx = client.GetCtx()
+	}
+	stream, err := client.c.Consume(ctx, &eventpb.ConsumeRequest{Group: group, Member: member})
+	if err != nil {
+		return err
+	}
+	for {
+		rsp, err := stream.Recv()
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		delivery := rsp.GetDelivery()
+		if delivery == nil {
+			continue // keep alive
+		}
+		if err := fct(delivery); err != nil {
+			slog.Warn("event client: delivery rejected", "group", group, "event", delivery.GetEvt().GetName(), "attempt", delivery.GetAttempt(), "err", err)
+			err = client.Nack(group, delivery.DeliveryId, 0)
+		} else {
+			err = client.Ack(group, delivery.DeliveryId)
+		}
+		if err != nil {
+			slog.Warn("event client: delivery settle failed; it will be redelivered", "group", group, "err", err)
+		}
+	}
+}
+
+// RegisterSchema registers a JSON schema for the payloads of a channel.
+// Events published on the channel are validated from then on.
+func (client *Event_Client) RegisterSchema(channel, schemaId, definition string) error {
+	_, err := client.c.RegisterSchema(client.GetCtx(), &eventpb.RegisterSchemaRequest{
+		Schema: &eventpb.EventSchema{Channel: channel, SchemaId: schemaId, Definition: definition},
+	})
+	return err
+}
+
+// DeleteSchema removes a schema of a channel.
+func (client *Event_Client) DeleteSchema(channel, schemaId string) error {
+	_, err := client.c.DeleteSchema(client.GetCtx(), &eventpb.DeleteSchemaRequest{Channel: channel, SchemaId: schemaId})
+	return err
+}
+
+// ListSchemas returns the schemas of a channel, or of all channels when
+// channel is empty.
+func (client *Event_Client) ListSchemas(channel string) ([]*eventpb.EventSchema, error) {
+	rsp, err := client.c.ListSchemas(client.GetCtx(), &eventpb.ListSchemasRequest{Channel: channel})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Schemas, nil
+}
+
 // matchesPattern returns true if pattern matches eventName.
 // Supports trailing wildcard: "service.*" matches "service.started".
 func matchesPattern(pattern, eventName string) bool {
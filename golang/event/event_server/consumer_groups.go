@@ -0,0 +1,497 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/event/eventpb"
+	"github.com/gocql/gocql"
+)
+
+// ---------------------------------------------------------------------------
+// Consumer groups
+//
+// OnEvent/Subscribe fan every event out to every live subscriber and forget
+// it. A consumer group is the durable alternative for workers:
+//
+//   The group cursor lives in ScyllaDB, not in the worker. Every event
+//   service instance advances the cursors of all groups on each tick, copying
+//   the matching events into the group's pending set, whether or not a
+//   member is connected. A worker restarting during a deploy finds its
+//   events pending when it comes back.
+//
+//   Each pending event is delivered to ONE member of the group and stays
+//   pending until it is acknowledged. A delivery that is neither acked nor
+//   nacked before the ack timeout is delivered again, possibly to another
+//   member. After maxDeliveries attempts the event is published on the
+//   dead-letter channel "dead_letter.<group>" and dropped from the group.
+//
+//   Delivery is at-least-once: a member that crashes after processing but
+//   before acking gets the event again. Members must be idempotent.
+//
+// Storage: one partition per group. Static columns hold the group definition
+// and its cursor, clustering rows hold the pending events keyed by their
+// event TimeUUID. Cursor moves and the pending rows they create are written
+// in a single conditional batch on the partition, so concurrent instances
+// never skip or double-insert a range. Claiming a pending row for delivery
+// is a lightweight transaction on its attempt counter, so exactly one
+// instance delivers each attempt.
+// ---------------------------------------------------------------------------
+
+const (
+	groupPollInterval = 1 * time.Second
+
+	defaultAckTimeout    = 30 * time.Second
+	defaultMaxDeliveries = 5
+
+	// Pending events outlive the 1 hour event TTL: that is the point of a
+	// group. They are kept 7 days, like instance cursors.
+	pendingTTL = 604800
+
+	// Bounds per group and per tick, in the spirit of the poll guardrails.
+	// Fill batches are kept small because they are LWT batches carrying the
+	// event payloads.
+	maxGroupEventsPerFill     = 100
+	maxGroupDeliveriesPerTick = 100
+	maxPendingScan            = 1000 // rows per duePending page
+
+	deadLetterPrefix = "dead_letter."
+)
+
+const createGroupDeliveriesTableCQL = `
+CREATE TABLE IF NOT EXISTS globular_events.group_deliveries (
+    group_name     text,
+    seq            timeuuid,
+    channel        text static,
+    cursor         timeuuid static,
+    ack_timeout_ms bigint static,
+    max_deliveries int static,
+    name           text,
+    data           blob,
+    envelope       blob,
+    attempts       int,
+    deadline       timestamp,
+    PRIMARY KEY ((group_name), seq)
+) WITH CLUSTERING ORDER BY (seq ASC)
+`
+
+var (
+	errGroupNotFound      = errors.New("consumer group not found")
+	errInvalidDeliveryID  = errors.New("invalid delivery id")
+	errInvalidGroupName   = errors.New("invalid consumer group name")
+	errMissingGroupMember = errors.New("missing consumer group member")
+)
+
+// consumerGroup is the definition and cursor of a group.
+type consumerGroup struct {
+	name          string
+	channel       string
+	cursor        gocql.UUID
+	ackTimeout    time.Duration
+	maxDeliveries int
+}
+
+// withDefaults fills the unset settings of a group.
+func (g consumerGroup) withDefaults() consumerGroup {
+	if g.ackTimeout <= 0 {
+		g.ackTimeout = defaultAckTimeout
+	}
+	if g.maxDeliveries <= 0 {
+		g.maxDeliveries = defaultMaxDeliveries
+	}
+	return g
+}
+
+// validGroupName rejects names that cannot be used in a dead-letter channel
+// name or a member key.
+func validGroupName(name string) bool {
+	return name != "" && !strings.ContainsAny(name, "/* \t\n")
+}
+
+// deadLetterChannel returns the channel dead-lettered events of a group are
+// published on.
+func deadLetterChannel(group string) string {
+	return deadLetterPrefix + group
+}
+
+// groupDelivery is a pending event of a group.
+type groupDelivery struct {
+	seq      gocql.UUID
+	name     string
+	data     []byte
+	envelope []byte
+	attempts int
+	deadline time.Time
+}
+
+// due tells if the event must be delivered (again): it was never delivered,
+// its ack timeout expired, or it was nacked.
+func (d groupDelivery) due(now time.Time) bool {
+	return d.deadline.IsZero() || !now.Before(d.deadline)
+}
+
+// deliveryID identifies one attempt of a pending event, so a late Nack of a
+// superseded attempt is ignored.
+func deliveryID(seq gocql.UUID, attempt int) string {
+	return seq.String() + ":" + strconv.Itoa(attempt)
+}
+
+func parseDeliveryID(id string) (gocql.UUID, int, error) {
+	s, a, ok := strings.Cut(id, ":")
+	if !ok {
+		return gocql.UUID{}, 0, errInvalidDeliveryID
+	}
+	seq, err := gocql.ParseUUID(s)
+	if err != nil {
+		return gocql.UUID{}, 0, errInvalidDeliveryID
+	}
+	attempt, err := strconv.Atoi(a)
+	if err != nil || attempt < 1 {
+		return gocql.UUID{}, 0, errInvalidDeliveryID
+	}
+	return seq, attempt, nil
+}
+
+// deadLetter is the payload of an event published on a dead-letter channel.
+type deadLetter struct {
+	Group      string    `json:"group"`
+	Name       string    `json:"name"`
+	Data       []byte    `json:"data"`
+	Deliveries int       `json:"deliveries"`
+	Published  time.Time `json:"published"`
+}
+
+// createGroup creates a group whose cursor starts now. It returns false
+// when the group already exists.
+func (sb *scyllaBus) createGroup(g consumerGroup) (bool, error) {
+	if sb.session == nil {
+		return false, fmt.Errorf("scylla not connected")
+	}
+	g = g.withDefaults()
+	applied, err := sb.session.Query(
+		`INSERT INTO group_deliveries (group_name, channel, cursor, ack_timeout_ms, max_deliveries) VALUES (?, ?, ?, ?, ?) IF NOT EXISTS`,
+		g.name, g.channel, gocql.MinTimeUUID(time.Now()), g.ackTimeout.Milliseconds(), g.maxDeliveries,
+	).MapScanCAS(map[string]interface{}{})
+	return applied, err
+}
+
+// deleteGroup drops a group and its pending events.
+func (sb *scyllaBus) deleteGroup(name string) error {
+	if sb.session == nil {
+		return fmt.Errorf("scylla not connected")
+	}
+	return sb.session.Query(`DELETE FROM group_deliveries WHERE group_name = ?`, name).Exec()
+}
+
+// group reads the definition and cursor of a group.
+func (sb *scyllaBus) group(name string) (consumerGroup, error) {
+	if sb.session == nil {
+		return consumerGroup{}, fmt.Errorf("scylla not connected")
+	}
+	g := consumerGroup{name: name}
+	var ackTimeoutMs int64
+	err := sb.session.Query(
+		`SELECT channel, cursor, ack_timeout_ms, max_deliveries FROM group_deliveries WHERE group_name = ? LIMIT 1`, name,
+	).Scan(&g.channel, &g.cursor, &ackTimeoutMs, &g.maxDeliveries)
+	if errors.Is(err, gocql.ErrNotFound) || (err == nil && g.channel == "") {
+		return consumerGroup{}, errGroupNotFound
+	}
+	if err != nil {
+		return consumerGroup{}, err
+	}
+	g.ackTimeout = time.Duration(ackTimeoutMs) * time.Millisecond
+	return g.withDefaults(), nil
+}
+
+// listGroups returns every group of the cluster.
+func (sb *scyllaBus) listGroups() ([]consumerGroup, error) {
+	if sb.session == nil {
+		return nil, fmt.Errorf("scylla not connected")
+	}
+	iter := sb.session.Query(
+		`SELECT DISTINCT group_name, channel, cursor, ack_timeout_ms, max_deliveries FROM group_deliveries`,
+	).Iter()
+	var groups []consumerGroup
+	var g consumerGroup
+	var ackTimeoutMs int64
+	for iter.Scan(&g.name, &g.channel, &g.cursor, &ackTimeoutMs, &g.maxDeliveries) {
+		if g.channel == "" {
+			continue // pending rows of a group deleted mid-batch
+		}
+		g.ackTimeout = time.Duration(ackTimeoutMs) * time.Millisecond
+		groups = append(groups, g.withDefaults())
+	}
+	return groups, iter.Close()
+}
+
+// pendingCount returns the number of events of a group not acked yet.
+func (sb *scyllaBus) pendingCount(name string) (uint64, error) {
+	if sb.session == nil {
+		return 0, fmt.Errorf("scylla not connected")
+	}
+	iter := sb.session.Query(`SELECT seq FROM group_deliveries WHERE group_name = ?`, name).Iter()
+	var n uint64
+	var seq gocql.UUID
+	for iter.Scan(&seq) {
+		if seq != (gocql.UUID{}) {
+			n++
+		}
+	}
+	return n, iter.Close()
+}
+
+// fillGroup moves the cursor of a group forward, making the matching events
+// after it pending. It returns false when another instance moved the cursor
+// first; the range is then left to that instance.
+func (sb *scyllaBus) fillGroup(g consumerGroup) (bool, error) {
+	if sb.session == nil {
+		return false, fmt.Errorf("scylla not connected")
+	}
+
+	buckets := bucketsFrom(g.cursor.Time())
+	if len(buckets) > maxBucketsPerPoll {
+		buckets = buckets[:maxBucketsPerPoll]
+	}
+
+	cursor := g.cursor
+	var matched []pollEvent
+	seen := 0
+scan:
+	for _, bucket := range buckets {
+		iter := sb.session.Query(
+			`SELECT seq, name, data, envelope FROM events WHERE bucket = ? AND seq > ?`,
+			bucket, g.cursor,
+		).Iter()
+		var seq gocql.UUID
+		var name string
+		var data, envelope []byte
+		for iter.Scan(&seq, &name, &data, &envelope) {
+			seen++
+			cursor = seq
+			if matchesChannel(g.channel, name) {
+				matched = append(matched, pollEvent{
+					seq:      seq,
+					name:     name,
+					data:     append([]byte(nil), data...),
+					envelope: append([]byte(nil), envelope...),
+				})
+				if len(matched) >= maxGroupEventsPerFill {
+					iter.Close()
+					break scan
+				}
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return false, err
+		}
+	}
+
+	// Skip buckets fully in the past when they held nothing, as pollOnce
+	// does; the current bucket is still open for writes.
+	if seen == 0 && len(buckets) > 0 {
+		if t, err := time.Parse("2006-01-02T15:04", buckets[len(buckets)-1]); err == nil {
+			advanced := t.Add(time.Minute)
+			if advanced.Before(time.Now().UTC().Truncate(time.Minute)) && advanced.After(cursor.Time()) {
+				cursor = gocql.MinTimeUUID(advanced)
+			}
+		}
+	}
+	if cursor == g.cursor {
+		return true, nil
+	}
+
+	batch := sb.session.NewBatch(gocql.LoggedBatch)
+	batch.Query(`UPDATE group_deliveries SET cursor = ? WHERE group_name = ? IF cursor = ?`, cursor, g.name, g.cursor)
+	for _, ev := range matched {
+		batch.Query(
+			`INSERT INTO group_deliveries (group_name, seq, name, data, envelope, attempts) VALUES (?, ?, ?, ?, ?, 0) USING TTL ?`,
+			g.name, ev.seq, ev.name, ev.data, ev.envelope, pendingTTL,
+		)
+	}
+	applied, iter, err := sb.session.ExecuteBatchCAS(batch)
+	if iter != nil {
+		iter.Close()
+	}
+	return applied, err
+}
+
+// duePending returns the pending events of a group that must be delivered,
+// oldest first. It reads the partition maxPendingScan rows at a time, each
+// page starting after the last seq of the previous one, until it has limit
+// events or reaches the end: in-flight events at the head of the partition
+// do not hide the due ones behind them.
+func (sb *scyllaBus) duePending(name string, now time.Time, limit int) ([]groupDelivery, error) {
+	if sb.session == nil {
+		return nil, fmt.Errorf("scylla not connected")
+	}
+	var due []groupDelivery
+	var after gocql.UUID
+	for {
+		var iter *gocql.Iter
+		if after == (gocql.UUID{}) {
+			iter = sb.session.Query(
+				`SELECT seq, name, data, envelope, attempts, deadline FROM group_deliveries WHERE group_name = ? LIMIT ?`,
+				name, maxPendingScan,
+			).Iter()
+		} else {
+			iter = sb.session.Query(
+				`SELECT seq, name, data, envelope, attempts, deadline FROM group_deliveries WHERE group_name = ? AND seq > ? LIMIT ?`,
+				name, after, maxPendingScan,
+			).Iter()
+		}
+		rows, last := 0, after
+		var d groupDelivery
+		for len(due) < limit && iter.Scan(&d.seq, &d.name, &d.data, &d.envelope, &d.attempts, &d.deadline) {
+			rows++
+			if d.seq != (gocql.UUID{}) {
+				last = d.seq
+			}
+			if d.seq == (gocql.UUID{}) || d.name == "" {
+				continue // the static row, or a row whose event cells expired
+			}
+			if d.due(now) {
+				d.data = append([]byte(nil), d.data...)
+				d.envelope = append([]byte(nil), d.envelope...)
+				due = append(due, d)
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, err
+		}
+		if len(due) >= limit || rows < maxPendingScan || last == after {
+			return due, nil
+		}
+		after = last
+	}
+}
+
+// claim takes the next attempt of a pending event. Exactly one caller wins
+// per attempt; it must deliver (or dead-letter) the event before the
+// returned deadline.
+func (sb *scyllaBus) claim(g consumerGroup, d groupDelivery, now time.Time) (bool, error) {
+	if sb.session == nil {
+		return false, fmt.Errorf("scylla not connected")
+	}
+	return sb.session.Query(
+		`UPDATE group_deliveries USING TTL ? SET attempts = ?, deadline = ? WHERE group_name = ? AND seq = ? IF attempts = ?`,
+		pendingTTL, d.attempts+1, now.Add(g.ackTimeout), g.name, d.seq, d.attempts,
+	).MapScanCAS(map[string]interface{}{})
+}
+
+// ack drops a pending event. A late ack of an attempt that was already
+// redelivered still counts: the event was processed.
+func (sb *scyllaBus) ack(group, id string) (bool, error) {
+	if sb.session == nil {
+		return false, fmt.Errorf("scylla not connected")
+	}
+	seq, _, err := parseDeliveryID(id)
+	if err != nil {
+		return false, err
+	}
+	return sb.session.Query(
+		`DELETE FROM group_deliveries WHERE group_name = ? AND seq = ? IF EXISTS`, group, seq,
+	).MapScanCAS(map[string]interface{}{})
+}
+
+// nack makes a delivered event due again after delay. It is ignored unless
+// the attempt is still the current one.
+func (sb *scyllaBus) nack(group, id string, delay time.Duration) (bool, error) {
+	if sb.session == nil {
+		return false, fmt.Errorf("scylla not connected")
+	}
+	seq, attempt, err := parseDeliveryID(id)
+	if err != nil {
+		return false, err
+	}
+	return sb.session.Query(
+		`UPDATE group_deliveries USING TTL ? SET deadline = ? WHERE group_name = ? AND seq = ? IF attempts = ?`,
+		pendingTTL, time.Now().Add(delay), group, seq, attempt,
+	).MapScanCAS(map[string]interface{}{})
+}
+
+// deadLetter publishes a claimed event on the dead-letter channel of its
+// group and drops it from the group. If the publish fails the event stays
+// pending and is dead-lettered again after the ack timeout.
+func (sb *scyllaBus) deadLetter(group string, d groupDelivery) error {
+	data, err := json.Marshal(deadLetter{
+		Group:      group,
+		Name:       d.name,
+		Data:       d.data,
+		Deliveries: d.attempts,
+		Published:  d.seq.Time().UTC(),
+	})
+	if err != nil {
+		return err
+	}
+	// The dead letter keeps the correlation id of the event it carries.
+	original := pollEvent{seq: d.seq, name: d.name, data: d.data, envelope: d.envelope}.event()
+	if _, err := sb.publishEvent(&eventpb.Event{
+		Name:          deadLetterChannel(group),
+		Data:          data,
+		ContentType:   "application/json",
+		CorrelationId: original.GetCorrelationId(),
+	}); err != nil {
+		return err
+	}
+	return sb.session.Query(`DELETE FROM group_deliveries WHERE group_name = ? AND seq = ?`, group, d.seq).Exec()
+}
+
+// groupMembers tracks the members of the groups consuming through this
+// instance, and hands deliveries to them round-robin.
+type groupMembers struct {
+	members map[string][]string // group -> member keys, in join order
+	next    map[string]int      // group -> index of the next member
+}
+
+func newGroupMembers() *groupMembers {
+	return &groupMembers{members: make(map[string][]string), next: make(map[string]int)}
+}
+
+// memberKey identifies a member across groups.
+func memberKey(group, member string) string {
+	return group + "/" + member
+}
+
+func (m *groupMembers) add(group, key string) {
+	for _, k := range m.members[group] {
+		if k == key {
+			return
+		}
+	}
+	m.members[group] = append(m.members[group], key)
+}
+
+func (m *groupMembers) remove(group, key string) {
+	keys := m.members[group]
+	for i, k := range keys {
+		if k == key {
+			keys = append(keys[:i:i], keys[i+1:]...)
+			break
+		}
+	}
+	if len(keys) == 0 {
+		delete(m.members, group)
+		delete(m.next, group)
+		return
+	}
+	m.members[group] = keys
+}
+
+func (m *groupMembers) has(group string) bool {
+	return len(m.members[group]) > 0
+}
+
+// pick returns the member key the next delivery of a group goes to.
+func (m *groupMembers) pick(group string) (string, bool) {
+	keys := m.members[group]
+	if len(keys) == 0 {
+		return "", false
+	}
+	i := m.next[group] % len(keys)
+	m.next[group] = i + 1
+	return keys[i], true
+}
@@ -0,0 +1,205 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// TestDeliveryID verifies delivery ids round-trip and reject garbage.
+func TestDeliveryID(t *testing.T) {
+	seq := gocql.TimeUUID()
+	gotSeq, attempt, err := parseDeliveryID(deliveryID(seq, 3))
+	if err != nil || gotSeq != seq || attempt != 3 {
+		t.Fatalf("parseDeliveryID = %v, %d, %v", gotSeq, attempt, err)
+	}
+	for _, id := range []string{"", "nope", seq.String(), seq.String() + ":0", "x:1"} {
+		if _, _, err := parseDeliveryID(id); !errors.Is(err, errInvalidDeliveryID) {
+			t.Errorf("parseDeliveryID(%q) = %v, want errInvalidDeliveryID", id, err)
+		}
+	}
+}
+
+// TestDeliveryDue verifies when a pending event is handed out again.
+func TestDeliveryDue(t *testing.T) {
+	now := time.Now()
+	cases := []struct {
+		name     string
+		deadline time.Time
+		want     bool
+	}{
+		{"never delivered", time.Time{}, true},
+		{"in flight", now.Add(time.Second), false},
+		{"ack timeout expired", now.Add(-time.Second), true},
+		{"nacked without delay", now, true},
+	}
+	for _, tc := range cases {
+		if got := (groupDelivery{deadline: tc.deadline}).due(now); got != tc.want {
+			t.Errorf("%s: due = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+// TestGroupMembersRoundRobin verifies deliveries rotate over the members of
+// a group and that leaving members are skipped.
+func TestGroupMembersRoundRobin(t *testing.T) {
+	m := newGroupMembers()
+	a, b, c := memberKey("billing", "a"), memberKey("billing", "b"), memberKey("billing", "c")
+	m.add("billing", a)
+	m.add("billing", b)
+	m.add("billing", b)
+	m.add("billing", c)
+
+	var got []string
+	for i := 0; i < 4; i++ {
+		key, _ := m.pick("billing")
+		got = append(got, key)
+	}
+	if got[0] != a || got[1] != b || got[2] != c || got[3] != a {
+		t.Errorf("picks = %q, want a b c a", got)
+	}
+
+	m.remove("billing", b)
+	if key, _ := m.pick("billing"); key != c {
+		t.Errorf("pick after remove = %q, want %q", key, c)
+	}
+	m.remove("billing", a)
+	m.remove("billing", c)
+	if m.has("billing") {
+		t.Error("group still has members after all left")
+	}
+	if _, ok := m.pick("billing"); ok {
+		t.Error("pick on a group without members must fail")
+	}
+}
+
+func TestValidGroupName(t *testing.T) {
+	for name, want := range map[string]bool{"billing": true, "mail-workers": true, "": false, "a/b": false, "all*": false, "with space": false} {
+		if got := validGroupName(name); got != want {
+			t.Errorf("validGroupName(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+// TestScyllaBusConsumerGroup walks an event through a group: pending after
+// publish, claimed once, redelivered after a nack, dead-lettered after the
+// last attempt.
+func TestScyllaBusConsumerGroup(t *testing.T) {
+	bus := newScyllaBus(logger)
+	if err := bus.connect(); err != nil {
+		t.Skipf("ScyllaDB unavailable, skipping: %v", err)
+	}
+	defer bus.close()
+
+	name := "test-" + gocql.TimeUUID().String()[:8]
+	created, err := bus.createGroup(consumerGroup{name: name, channel: "group_test.*", maxDeliveries: 2})
+	if err != nil || !created {
+		t.Fatalf("createGroup = %v, %v", created, err)
+	}
+	defer bus.deleteGroup(name)
+	if created, _ := bus.createGroup(consumerGroup{name: name, channel: "other"}); created {
+		t.Error("second createGroup must report the group exists")
+	}
+
+	if err := bus.publish("group_test.job", []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+	if err := bus.publish("unrelated.job", []byte("2")); err != nil {
+		t.Fatal(err)
+	}
+	g, err := bus.group(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := bus.fillGroup(g); err != nil || !ok {
+		t.Fatalf("fillGroup = %v, %v", ok, err)
+	}
+	if ok, _ := bus.fillGroup(g); ok {
+		t.Error("fillGroup with a stale cursor must not apply")
+	}
+
+	now := time.Now()
+	due, err := bus.duePending(name, now, 10)
+	if err != nil || len(due) != 1 || due[0].name != "group_test.job" {
+		t.Fatalf("duePending = %+v, %v; want the matching event only", due, err)
+	}
+	d := due[0]
+	if won, err := bus.claim(g, d, now); err != nil || !won {
+		t.Fatalf("claim = %v, %v", won, err)
+	}
+	if won, _ := bus.claim(g, d, now); won {
+		t.Error("an attempt must be claimed once")
+	}
+	if due, _ := bus.duePending(name, now, 10); len(due) != 0 {
+		t.Errorf("in-flight event is due: %+v", due)
+	}
+
+	if ok, err := bus.nack(name, deliveryID(d.seq, 1), 0); err != nil || !ok {
+		t.Fatalf("nack = %v, %v", ok, err)
+	}
+	due, _ = bus.duePending(name, time.Now(), 10)
+	if len(due) != 1 || due[0].attempts != 1 {
+		t.Fatalf("nacked event not due again: %+v", due)
+	}
+
+	// Out of attempts: the event goes to the dead-letter channel.
+	d = due[0]
+	d.attempts = g.maxDeliveries
+	if err := bus.deadLetter(name, d); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := bus.pendingCount(name); n != 0 {
+		t.Errorf("pendingCount = %d after dead-letter, want 0", n)
+	}
+	events, _ := bus.queryEvents(deadLetterChannel(name), gocql.MinTimeUUID(now.Add(-time.Minute)), 10)
+	if len(events) != 1 {
+		t.Fatalf("dead-letter events = %d, want 1", len(events))
+	}
+	var dl deadLetter
+	if err := json.Unmarshal(events[0].data, &dl); err != nil || dl.Name != "group_test.job" || dl.Deliveries != 2 {
+		t.Errorf("dead letter = %+v, %v", dl, err)
+	}
+}
+
+// TestScyllaBusDuePendingPages puts more in-flight events than a page holds
+// ahead of a due one, which must still be found.
+func TestScyllaBusDuePendingPages(t *testing.T) {
+	bus := newScyllaBus(logger)
+	if err := bus.connect(); err != nil {
+		t.Skipf("ScyllaDB unavailable, skipping: %v", err)
+	}
+	defer bus.close()
+
+	name := "test-" + gocql.TimeUUID().String()[:8]
+	if created, err := bus.createGroup(consumerGroup{name: name, channel: "group_test.*"}); err != nil || !created {
+		t.Fatalf("createGroup = %v, %v", created, err)
+	}
+	defer bus.deleteGroup(name)
+
+	now := time.Now()
+	start := now.Add(-time.Hour)
+	insert := `INSERT INTO group_deliveries (group_name, seq, name, data, envelope, attempts, deadline) VALUES (?, ?, ?, ?, ?, ?, ?) USING TTL ?`
+	batch := bus.session.NewBatch(gocql.UnloggedBatch)
+	for i := range maxPendingScan + 10 {
+		seq := gocql.UUIDFromTime(start.Add(time.Duration(i) * time.Millisecond))
+		batch.Query(insert, name, seq, "group_test.inflight", []byte("x"), []byte{}, 1, now.Add(time.Hour), pendingTTL)
+		if batch.Size() == 100 {
+			if err := bus.session.ExecuteBatch(batch); err != nil {
+				t.Fatal(err)
+			}
+			batch = bus.session.NewBatch(gocql.UnloggedBatch)
+		}
+	}
+	batch.Query(insert, name, gocql.UUIDFromTime(now), "group_test.due", []byte("y"), []byte{}, 1, now.Add(-time.Second), pendingTTL)
+	if err := bus.session.ExecuteBatch(batch); err != nil {
+		t.Fatal(err)
+	}
+
+	due, err := bus.duePending(name, now, 10)
+	if err != nil || len(due) != 1 || due[0].name != "group_test.due" {
+		t.Fatalf("duePending = %d events, %v; want the due one behind the in-flight ones", len(due), err)
+	}
+}
@@ -0,0 +1,112 @@
+package main
+
+import (
+	"context"
+	"time"
+
+	"github.com/globulario/services/golang/event/eventpb"
+	"github.com/globulario/services/golang/security"
+	"github.com/gocql/gocql"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// ---------------------------------------------------------------------------
+// Event envelopes
+//
+// Besides name and data, an event carries an optional envelope: publisher,
+// correlation id, content type, schema id, idempotency key and headers. The
+// envelope is stored next to the event as a marshaled eventpb.Event with
+// name and data cleared, so new envelope fields need no schema change.
+// Events published before envelopes existed simply have none.
+// ---------------------------------------------------------------------------
+
+const (
+	// idempotencyWindow is how long an idempotency key is remembered per
+	// channel. It covers publisher retries, not replays days later.
+	idempotencyWindow = 10 * time.Minute
+
+	// correlationMetadataKey is the gRPC metadata a caller propagates its
+	// correlation/trace id in.
+	correlationMetadataKey = "x-correlation-id"
+)
+
+const createIdempotencyKeysTableCQL = `
+CREATE TABLE IF NOT EXISTS globular_events.idempotency_keys (
+    channel text,
+    key     text,
+    seq     timeuuid,
+    PRIMARY KEY ((channel, key))
+)
+`
+
+// ensureColumn adds a column to a table of the event keyspace if it is
+// missing. CREATE TABLE IF NOT EXISTS leaves existing tables untouched.
+func ensureColumn(sess *gocql.Session, table, column, typ string) error {
+	var name string
+	err := sess.Query(
+		`SELECT column_name FROM system_schema.columns WHERE keyspace_name = ? AND table_name = ? AND column_name = ?`,
+		eventKeyspace, table, column,
+	).Scan(&name)
+	if err == nil {
+		return nil
+	}
+	if err != gocql.ErrNotFound {
+		return err
+	}
+	return sess.Query("ALTER TABLE " + eventKeyspace + "." + table + " ADD " + column + " " + typ).Exec()
+}
+
+// marshalEnvelope returns the envelope of an event, or nil when it has none.
+func marshalEnvelope(evt *eventpb.Event) ([]byte, error) {
+	env := proto.Clone(evt).(*eventpb.Event)
+	env.Name, env.Data = "", nil
+	if proto.Size(env) == 0 {
+		return nil, nil
+	}
+	return proto.Marshal(env)
+}
+
+// event rebuilds the published event from its stored row. A corrupt
+// envelope is dropped rather than losing the event.
+func (ev pollEvent) event() *eventpb.Event {
+	evt := &eventpb.Event{}
+	if len(ev.envelope) > 0 {
+		if err := proto.Unmarshal(ev.envelope, evt); err != nil {
+			evt = &eventpb.Event{}
+		}
+	}
+	evt.Name, evt.Data = ev.name, ev.data
+	return evt
+}
+
+// persisted converts a stored event for QueryEvents.
+func (ev pollEvent) persisted() *eventpb.PersistedEvent {
+	return &eventpb.PersistedEvent{
+		Name:     ev.name,
+		Data:     ev.data,
+		Ts:       timestamppb.New(ev.seq.Time()),
+		Sequence: uint64(ev.seq.Time().UnixNano()),
+		Evt:      ev.event(),
+	}
+}
+
+// stamp fills the envelope fields the server owns. The publisher always
+// comes from the authenticated caller, never from the request, so it cannot
+// be spoofed; the correlation id defaults to the call metadata.
+func stamp(ctx context.Context, evt *eventpb.Event) *eventpb.Event {
+	evt = proto.Clone(evt).(*eventpb.Event)
+	evt.Publisher = ""
+	if authCtx := security.FromContext(ctx); authCtx != nil && authCtx.Subject != "" {
+		evt.Publisher = authCtx.Subject
+	}
+	if evt.CorrelationId == "" {
+		if md, ok := metadata.FromIncomingContext(ctx); ok {
+			if ids := md.Get(correlationMetadataKey); len(ids) > 0 {
+				evt.CorrelationId = ids[0]
+			}
+		}
+	}
+	return evt
+}
@@ -0,0 +1,102 @@
+package main
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/event/eventpb"
+	"github.com/globulario/services/golang/security"
+	"github.com/gocql/gocql"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+)
+
+// TestEnvelopeRoundTrip verifies the envelope survives storage and that
+// events without one store nothing extra.
+func TestEnvelopeRoundTrip(t *testing.T) {
+	if env, err := marshalEnvelope(&eventpb.Event{Name: "a", Data: []byte("1")}); err != nil || env != nil {
+		t.Fatalf("marshalEnvelope(no envelope) = %v, %v; want nil", env, err)
+	}
+
+	in := &eventpb.Event{
+		Name:           "orders.created",
+		Data:           []byte(`{"id":1}`),
+		Publisher:      "sa",
+		CorrelationId:  "trace-1",
+		ContentType:    "application/json",
+		SchemaId:       "orders.created/v1",
+		IdempotencyKey: "order-1",
+		Headers:        map[string]string{"tenant": "acme"},
+	}
+	env, err := marshalEnvelope(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	out := pollEvent{seq: gocql.TimeUUID(), name: in.Name, data: in.Data, envelope: env}.event()
+	if !proto.Equal(in, out) {
+		t.Errorf("event() = %v, want %v", out, in)
+	}
+
+	// A corrupt envelope loses the envelope, not the event.
+	out = pollEvent{name: "x", data: []byte("d"), envelope: []byte{0xff, 0xff}}.event()
+	if out.Name != "x" || string(out.Data) != "d" || out.CorrelationId != "" {
+		t.Errorf("event() with a corrupt envelope = %v", out)
+	}
+}
+
+// TestStampPublisher verifies the publisher comes from the caller identity,
+// never from the request, and the correlation id from the call metadata.
+func TestStampPublisher(t *testing.T) {
+	ctx := (&security.AuthContext{Subject: "alice"}).ToContext(context.Background())
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(correlationMetadataKey, "trace-7"))
+
+	evt := stamp(ctx, &eventpb.Event{Name: "n", Publisher: "admin"})
+	if evt.Publisher != "alice" || evt.CorrelationId != "trace-7" {
+		t.Errorf("stamp = %v, want publisher alice and correlation trace-7", evt)
+	}
+	if evt := stamp(ctx, &eventpb.Event{Name: "n", CorrelationId: "mine"}); evt.CorrelationId != "mine" {
+		t.Errorf("stamp overrode the correlation id: %q", evt.CorrelationId)
+	}
+	if evt := stamp(context.Background(), &eventpb.Event{Name: "n", Publisher: "admin"}); evt.Publisher != "" {
+		t.Errorf("unauthenticated publisher = %q, want empty", evt.Publisher)
+	}
+}
+
+// TestScyllaBusIdempotency verifies duplicates of an idempotency key are
+// dropped within the window, per channel.
+func TestScyllaBusIdempotency(t *testing.T) {
+	bus := newScyllaBus(logger)
+	if err := bus.connect(); err != nil {
+		t.Skipf("ScyllaDB unavailable, skipping: %v", err)
+	}
+	defer bus.close()
+
+	key := "key-" + gocql.TimeUUID().String()
+	evt := &eventpb.Event{Name: "idem.test", Data: []byte("1"), IdempotencyKey: key, CorrelationId: "c-1"}
+	if dup, err := bus.publishEvent(evt); err != nil || dup {
+		t.Fatalf("first publish = %v, %v", dup, err)
+	}
+	if dup, err := bus.publishEvent(evt); err != nil || !dup {
+		t.Errorf("second publish = %v, %v; want duplicate", dup, err)
+	}
+	other := proto.Clone(evt).(*eventpb.Event)
+	other.Name = "idem.other"
+	if dup, err := bus.publishEvent(other); err != nil || dup {
+		t.Errorf("same key on another channel = %v, %v; want published", dup, err)
+	}
+
+	events, _ := bus.queryEvents("idem.test", gocql.MinTimeUUID(time.Now().Add(-time.Minute)), 100)
+	n := 0
+	for _, ev := range events {
+		if e := ev.event(); e.IdempotencyKey == key {
+			n++
+			if e.CorrelationId != "c-1" {
+				t.Errorf("stored correlation id = %q", e.CorrelationId)
+			}
+		}
+	}
+	if n != 1 {
+		t.Errorf("stored %d events with the key, want 1", n)
+	}
+}
@@ -58,6 +58,10 @@ func (srv *server) run() {
 	quits := make(map[string]chan bool)                             // uuid -> quit
 	ka := make(chan *eventpb.KeepAlive)
 
+	members := newGroupMembers()                                     // group -> member keys
+	consumers := make(map[string]eventpb.EventService_ConsumeServer) // member key -> stream
+	consumerQuits := make(map[string]chan bool)                      // member key -> quit
+
 	ticker := time.NewTicker(15 * time.Second)
 	defer ticker.Stop()
 
@@ -69,6 +73,10 @@ func (srv *server) run() {
 	reconnectTicker := time.NewTicker(10 * time.Second)
 	defer reconnectTicker.Stop()
 
+	// Consumer groups — advance group cursors and deliver pending events.
+	groupTicker := time.NewTicker(groupPollInterval)
+	defer groupTicker.Stop()
+
 	done := make(chan bool)
 
 	go func() {
@@ -96,6 +104,14 @@ func (srv *server) run() {
 				delete(quits, uuid)
 				delete(streams, uuid)
 			}
+			for key, q := range consumerQuits {
+				select {
+				case q <- true:
+				default:
+				}
+				delete(consumerQuits, key)
+				delete(consumers, key)
+			}
 			srv.logger.Info("event loop stopped",
 				"service", srv.Name,
 				"id", srv.Id,
@@ -118,6 +134,12 @@ func (srv *server) run() {
 			if len(toDelete) > 0 {
 				srv.cleanupSubscribers(toDelete, channels, quits, streams)
 			}
+			for key, stream := range consumers {
+				if err := stream.Send(&eventpb.ConsumeResponse{Data: &eventpb.ConsumeResponse_Ka{Ka: ka_}}); err != nil {
+					srv.logger.Warn("keepalive send failed; will drop consumer", "member", key, "err", err)
+					srv.dropConsumer(key, members, consumers, consumerQuits)
+				}
+			}
 
 		case <-reconnectTicker.C:
 			// Periodically try to (re)connect the ScyllaDB bus.
@@ -138,12 +160,18 @@ func (srv *server) run() {
 			}
 			events := srv.bus.pollOnce()
 			for _, ev := range events {
-				srv.dispatchToLocal(ev.name, ev.data, channels, streams, quits)
+				srv.dispatchToLocal(ev.event(), channels, streams, quits)
 			}
 			// Commit cursor AFTER dispatch. Also save when pollOnce advanced
 			// past empty catch-up buckets (cursor moves even with 0 events).
 			srv.bus.saveCursor()
 
+		case <-groupTicker.C:
+			if srv.bus == nil {
+				continue
+			}
+			srv.serveGroups(members, consumers, consumerQuits)
+
 		case a := <-srv.actions:
 			action, _ := a["action"].(string)
 			switch action {
@@ -203,6 +231,35 @@ func (srv *server) run() {
 				srv.cleanupSubscribers([]string{uuid}, channels, quits, streams)
 				srv.logger.Info("stream quit", "uuid", uuid)
 
+			case "consume":
+				stream, _ := a["stream"].(eventpb.EventService_ConsumeServer)
+				group, _ := a["group"].(string)
+				key, _ := a["key"].(string)
+				qc, ok := a["quit"].(chan bool)
+				if stream == nil || group == "" || key == "" || !ok {
+					srv.logger.Error("invalid consume request", "group", group, "member", key, "has_stream", stream != nil, "has_quit", ok)
+					continue
+				}
+				if _, exists := consumers[key]; exists {
+					// Same member reconnecting: the old stream is dead.
+					srv.dropConsumer(key, members, consumers, consumerQuits)
+				}
+				consumers[key] = stream
+				consumerQuits[key] = qc
+				members.add(group, key)
+				srv.logger.Info("consumer joined", "group", group, "member", key)
+
+			case "leave":
+				key, _ := a["key"].(string)
+				qc, _ := a["quit"].(chan bool)
+				// Only the stream that registered the key may remove it; a
+				// reconnected member has replaced it already.
+				if key == "" || consumerQuits[key] != qc {
+					continue
+				}
+				srv.dropConsumer(key, members, consumers, consumerQuits)
+				srv.logger.Info("consumer left", "member", key)
+
 			default:
 				srv.logger.Warn("unknown action", "action", action)
 			}
@@ -213,7 +270,7 @@ func (srv *server) run() {
 // dispatchToLocal sends an event to all local subscribers whose channel
 // pattern matches the event name.
 func (srv *server) dispatchToLocal(
-	name string, data []byte,
+	evt *eventpb.Event,
 	channels map[string][]string,
 	streams map[string]eventpb.EventService_OnEventServer,
 	quits map[string]chan bool,
@@ -221,7 +278,7 @@ func (srv *server) dispatchToLocal(
 	seen := make(map[string]bool)
 	var matchedUUIDs []string
 	for pattern, puuids := range channels {
-		if matchesChannel(pattern, name) {
+		if matchesChannel(pattern, evt.Name) {
 			for _, u := range puuids {
 				if !seen[u] {
 					seen[u] = true
@@ -241,12 +298,10 @@ func (srv *server) dispatchToLocal(
 			continue
 		}
 		err := stream.Send(&eventpb.OnEventResponse{
-			Data: &eventpb.OnEventResponse_Evt{
-				Evt: &eventpb.Event{Name: name, Data: data},
-			},
+			Data: &eventpb.OnEventResponse_Evt{Evt: evt},
 		})
 		if err != nil {
-			srv.logger.Warn("event send failed; will drop subscriber", "channel", name, "uuid", uuid, "err", err)
+			srv.logger.Warn("event send failed; will drop subscriber", "channel", evt.Name, "uuid", uuid, "err", err)
 			toDelete = append(toDelete, uuid)
 		}
 	}
@@ -292,6 +347,97 @@ func (srv *server) cleanupSubscribers(
 	}
 }
 
+// serveGroups advances the cursors of all consumer groups and hands their
+// due events to the members consuming through this instance. A delivery
+// that cannot be sent is left to expire and is redelivered after the ack
+// timeout, possibly by another instance.
+func (srv *server) serveGroups(
+	members *groupMembers,
+	consumers map[string]eventpb.EventService_ConsumeServer,
+	consumerQuits map[string]chan bool,
+) {
+	groups, err := srv.bus.listGroups()
+	if err != nil {
+		srv.logger.Warn("list consumer groups failed", "err", err)
+		return
+	}
+	for _, g := range groups {
+		if _, err := srv.bus.fillGroup(g); err != nil {
+			srv.logger.Warn("consumer group cursor update failed", "group", g.name, "err", err)
+		}
+		if !members.has(g.name) {
+			continue
+		}
+
+		now := time.Now()
+		due, err := srv.bus.duePending(g.name, now, maxGroupDeliveriesPerTick)
+		if err != nil {
+			srv.logger.Warn("read pending deliveries failed", "group", g.name, "err", err)
+			continue
+		}
+		for _, d := range due {
+			won, err := srv.bus.claim(g, d, now)
+			if err != nil {
+				srv.logger.Warn("claim delivery failed", "group", g.name, "seq", d.seq, "err", err)
+				continue
+			}
+			if !won {
+				continue // another instance took this attempt
+			}
+			if d.attempts >= g.maxDeliveries {
+				if err := srv.bus.deadLetter(g.name, d); err != nil {
+					srv.logger.Warn("dead-letter failed", "group", g.name, "event", d.name, "err", err)
+				} else {
+					srv.logger.Warn("event dead-lettered", "group", g.name, "event", d.name, "deliveries", d.attempts)
+				}
+				continue
+			}
+
+			key, ok := members.pick(g.name)
+			if !ok {
+				break
+			}
+			attempt := d.attempts + 1
+			err = consumers[key].Send(&eventpb.ConsumeResponse{
+				Data: &eventpb.ConsumeResponse_Delivery{
+					Delivery: &eventpb.Delivery{
+						DeliveryId: deliveryID(d.seq, attempt),
+						Evt:        pollEvent{seq: d.seq, name: d.name, data: d.data, envelope: d.envelope}.event(),
+						Ts:         timestamppb.New(d.seq.Time()),
+						Attempt:    uint32(attempt),
+					},
+				},
+			})
+			if err != nil {
+				srv.logger.Warn("delivery send failed; will drop consumer", "group", g.name, "member", key, "err", err)
+				srv.dropConsumer(key, members, consumers, consumerQuits)
+				if !members.has(g.name) {
+					break
+				}
+			}
+		}
+	}
+}
+
+// dropConsumer removes a member stream and releases its Consume call.
+func (srv *server) dropConsumer(
+	key string,
+	members *groupMembers,
+	consumers map[string]eventpb.EventService_ConsumeServer,
+	consumerQuits map[string]chan bool,
+) {
+	group, _, _ := strings.Cut(key, "/")
+	members.remove(group, key)
+	delete(consumers, key)
+	if q, ok := consumerQuits[key]; ok {
+		select {
+		case q <- true:
+		default:
+		}
+		delete(consumerQuits, key)
+	}
+}
+
 // matchesChannel returns true if the subscription pattern matches the event name.
 func matchesChannel(pattern, eventName string) bool {
 	if pattern == eventName {
@@ -394,6 +540,10 @@ func (srv *server) Publish(ctx context.Context, rqst *eventpb.PublishRequest) (*
 		srv.logger.Error("Publish: invalid request", "err", errMissingChanName)
 		return &eventpb.PublishResponse{Result: false}, errMissingChanName
 	}
+	evt := stamp(ctx, rqst.Evt)
+	if err := srv.validateEvent(evt); err != nil {
+		return &eventpb.PublishResponse{Result: false}, err
+	}
 	if srv.bus == nil {
 		// Rate-limit this log to avoid CPU burn from flood of publish calls.
 		now := time.Now().UnixNano()
@@ -403,11 +553,15 @@ func (srv *server) Publish(ctx context.Context, rqst *eventpb.PublishRequest) (*
 		}
 		return &eventpb.PublishResponse{Result: false}, errors.New("event bus not connected")
 	}
-	if err := srv.bus.publish(rqst.Evt.Name, rqst.Evt.Data); err != nil {
-		srv.logger.Error("Publish: ScyllaDB write failed", "event", rqst.Evt.Name, "err", err)
+	duplicate, err := srv.bus.publishEvent(evt)
+	if err != nil {
+		srv.logger.Error("Publish: ScyllaDB write failed", "event", evt.Name, "err", err)
 		return &eventpb.PublishResponse{Result: false}, err
 	}
-	return &eventpb.PublishResponse{Result: true}, nil
+	if duplicate {
+		srv.logger.Debug("Publish: duplicate dropped", "event", evt.Name, "idempotency_key", evt.IdempotencyKey)
+	}
+	return &eventpb.PublishResponse{Result: true, Duplicate: duplicate}, nil
 }
 
 // QueryEvents reads events from ScyllaDB with true cursor semantics.
@@ -462,12 +616,7 @@ func (srv *server) QueryEvents(_ context.Context, rqst *eventpb.QueryEventsReque
 
 	var out []*eventpb.PersistedEvent
 	for _, ev := range events {
-		out = append(out, &eventpb.PersistedEvent{
-			Name:     ev.name,
-			Data:     ev.data,
-			Ts:       timestamppb.New(ev.seq.Time()),
-			Sequence: uint64(ev.seq.Time().UnixNano()),
-		})
+		out = append(out, ev.persisted())
 	}
 
 	var latestSeq uint64
@@ -480,3 +629,241 @@ func (srv *server) QueryEvents(_ context.Context, rqst *eventpb.QueryEventsReque
 		LatestSequence: latestSeq,
 	}, nil
 }
+
+// requireBus returns the bus of the RPCs whose state only exists in
+// ScyllaDB, so there is no local-only fallback.
+func (srv *server) requireBus() (*scyllaBus, error) {
+	if err := srv.requireHealthy(); err != nil {
+		return nil, err
+	}
+	if srv.bus == nil {
+		return nil, status.Error(codes.Unavailable, "event bus not connected")
+	}
+	return srv.bus, nil
+}
+
+// groupErr maps a consumer group error to a status.
+func groupErr(err error) error {
+	switch {
+	case errors.Is(err, errGroupNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, errInvalidDeliveryID), errors.Is(err, errInvalidGroupName), errors.Is(err, errMissingGroupMember):
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	return status.Error(codes.Internal, err.Error())
+}
+
+// CreateConsumerGroup creates a group whose cursor starts at the current
+// time: it receives the events published from now on.
+func (srv *server) CreateConsumerGroup(_ context.Context, rqst *eventpb.CreateConsumerGroupRequest) (*eventpb.CreateConsumerGroupResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	g := rqst.GetGroup()
+	if !validGroupName(g.GetName()) {
+		return nil, groupErr(errInvalidGroupName)
+	}
+	if g.GetChannel() == "" {
+		return nil, status.Error(codes.InvalidArgument, errMissingChanName.Error())
+	}
+	created, err := bus.createGroup(consumerGroup{
+		name:          g.GetName(),
+		channel:       g.GetChannel(),
+		ackTimeout:    time.Duration(g.GetAckTimeoutSeconds()) * time.Second,
+		maxDeliveries: int(g.GetMaxDeliveries()),
+	})
+	if err != nil {
+		srv.logger.Error("CreateConsumerGroup failed", "group", g.GetName(), "err", err)
+		return nil, groupErr(err)
+	}
+	srv.logger.Info("CreateConsumerGroup", "group", g.GetName(), "channel", g.GetChannel(), "created", created)
+	return &eventpb.CreateConsumerGroupResponse{Result: created}, nil
+}
+
+// DeleteConsumerGroup drops a group and its pending events.
+func (srv *server) DeleteConsumerGroup(_ context.Context, rqst *eventpb.DeleteConsumerGroupRequest) (*eventpb.DeleteConsumerGroupResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	if !validGroupName(rqst.GetName()) {
+		return nil, groupErr(errInvalidGroupName)
+	}
+	if err := bus.deleteGroup(rqst.GetName()); err != nil {
+		srv.logger.Error("DeleteConsumerGroup failed", "group", rqst.GetName(), "err", err)
+		return nil, groupErr(err)
+	}
+	srv.logger.Info("DeleteConsumerGroup", "group", rqst.GetName())
+	return &eventpb.DeleteConsumerGroupResponse{Result: true}, nil
+}
+
+// ListConsumerGroups returns the groups with their pending event counts.
+func (srv *server) ListConsumerGroups(_ context.Context, _ *eventpb.ListConsumerGroupsRequest) (*eventpb.ListConsumerGroupsResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	groups, err := bus.listGroups()
+	if err != nil {
+		return nil, groupErr(err)
+	}
+	out := make([]*eventpb.ConsumerGroup, 0, len(groups))
+	for _, g := range groups {
+		pending, err := bus.pendingCount(g.name)
+		if err != nil {
+			return nil, groupErr(err)
+		}
+		out = append(out, &eventpb.ConsumerGroup{
+			Name:              g.name,
+			Channel:           g.channel,
+			AckTimeoutSeconds: uint32(g.ackTimeout / time.Second),
+			MaxDeliveries:     uint32(g.maxDeliveries),
+			Pending:           pending,
+		})
+	}
+	return &eventpb.ListConsumerGroupsResponse{Groups: out}, nil
+}
+
+// Consume joins a consumer group and streams the deliveries handed to this
+// member until the client goes away. Each delivery must be acknowledged with
+// Ack or rejected with Nack before the group's ack timeout.
+func (srv *server) Consume(rqst *eventpb.ConsumeRequest, stream eventpb.EventService_ConsumeServer) error {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return err
+	}
+	if stream == nil {
+		srv.logger.Error("Consume: missing stream", "err", errMissingStream)
+		return errMissingStream
+	}
+	if rqst.GetMember() == "" {
+		return groupErr(errMissingGroupMember)
+	}
+	if !validGroupName(rqst.GetGroup()) {
+		return groupErr(errInvalidGroupName)
+	}
+	if _, err := bus.group(rqst.GetGroup()); err != nil {
+		return groupErr(err)
+	}
+
+	key := memberKey(rqst.GetGroup(), rqst.GetMember())
+	quit := make(chan bool, 1)
+	srv.actions <- map[string]interface{}{
+		"action": "consume",
+		"stream": stream,
+		"group":  rqst.GetGroup(),
+		"key":    key,
+		"quit":   quit,
+	}
+	srv.logger.Info("Consume: joined", "group", rqst.GetGroup(), "member", rqst.GetMember())
+
+	select {
+	case <-quit:
+	case <-stream.Context().Done():
+		srv.actions <- map[string]interface{}{"action": "leave", "key": key, "quit": quit}
+	}
+	srv.logger.Info("Consume: stream ended", "group", rqst.GetGroup(), "member", rqst.GetMember())
+	return nil
+}
+
+// Ack drops a delivered event from its group.
+func (srv *server) Ack(_ context.Context, rqst *eventpb.AckRequest) (*eventpb.AckResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	acked, err := bus.ack(rqst.GetGroup(), rqst.GetDeliveryId())
+	if err != nil {
+		return nil, groupErr(err)
+	}
+	return &eventpb.AckResponse{Result: acked}, nil
+}
+
+// Nack makes a delivered event due again after the requeue delay. It is
+// ignored when the delivery was already superseded by a redelivery.
+func (srv *server) Nack(_ context.Context, rqst *eventpb.NackRequest) (*eventpb.NackResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	delay := time.Duration(rqst.GetRequeueDelaySeconds()) * time.Second
+	nacked, err := bus.nack(rqst.GetGroup(), rqst.GetDeliveryId(), delay)
+	if err != nil {
+		return nil, groupErr(err)
+	}
+	return &eventpb.NackResponse{Result: nacked}, nil
+}
+
+// validateEvent checks a published event against the schemas of its
+// channel. Channels without schemas, and publishes while the bus is down
+// (they fail anyway), are not validated.
+func (srv *server) validateEvent(evt *eventpb.Event) error {
+	if srv.bus == nil || srv.schemas == nil {
+		return nil
+	}
+	schemas, err := srv.schemas.get(evt.Name, srv.bus.listSchemas)
+	if err != nil {
+		return status.Errorf(codes.Unavailable, "load schemas of %s: %v", evt.Name, err)
+	}
+	if err := validateEvent(evt, schemas); err != nil {
+		return status.Errorf(codes.InvalidArgument, "%s: %v", evt.Name, err)
+	}
+	return nil
+}
+
+// RegisterSchema registers a payload schema for a channel. From then on,
+// events published on the channel are validated.
+func (srv *server) RegisterSchema(_ context.Context, rqst *eventpb.RegisterSchemaRequest) (*eventpb.RegisterSchemaResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	s := rqst.GetSchema()
+	if s.GetChannel() == "" || s.GetSchemaId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel and schema_id are required")
+	}
+	if _, err := parseSchema(s.GetDefinition()); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err := bus.registerSchema(s.GetChannel(), s.GetSchemaId(), s.GetDefinition()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if srv.schemas != nil {
+		srv.schemas.invalidate(s.GetChannel())
+	}
+	srv.logger.Info("RegisterSchema", "channel", s.GetChannel(), "schema_id", s.GetSchemaId())
+	return &eventpb.RegisterSchemaResponse{Result: true}, nil
+}
+
+// DeleteSchema removes a schema of a channel.
+func (srv *server) DeleteSchema(_ context.Context, rqst *eventpb.DeleteSchemaRequest) (*eventpb.DeleteSchemaResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	if rqst.GetChannel() == "" || rqst.GetSchemaId() == "" {
+		return nil, status.Error(codes.InvalidArgument, "channel and schema_id are required")
+	}
+	if err := bus.deleteSchema(rqst.GetChannel(), rqst.GetSchemaId()); err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	if srv.schemas != nil {
+		srv.schemas.invalidate(rqst.GetChannel())
+	}
+	srv.logger.Info("DeleteSchema", "channel", rqst.GetChannel(), "schema_id", rqst.GetSchemaId())
+	return &eventpb.DeleteSchemaResponse{Result: true}, nil
+}
+
+// ListSchemas returns the registered schemas of a channel, or of all.
+func (srv *server) ListSchemas(_ context.Context, rqst *eventpb.ListSchemasRequest) (*eventpb.ListSchemasResponse, error) {
+	bus, err := srv.requireBus()
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := bus.listSchemas(rqst.GetChannel())
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	return &eventpb.ListSchemasResponse{Schemas: schemas}, nil
+}
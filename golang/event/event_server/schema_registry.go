@@ -0,0 +1,331 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/event/eventpb"
+)
+
+// ---------------------------------------------------------------------------
+// Schema registry
+//
+// Channels opt in to payload validation by registering schemas. A channel
+// without schemas is never validated, so existing publishers are unaffected.
+// Schemas are JSON Schema documents restricted to the keywords most event
+// payloads need: type, properties, required, items, enum and
+// additionalProperties. Unknown keywords are ignored, as JSON Schema does.
+//
+// Each instance caches the schemas of a channel for schemaCacheTTL, so a
+// registration reaches the other instances within that delay.
+// ---------------------------------------------------------------------------
+
+const schemaCacheTTL = 30 * time.Second
+
+const createSchemasTableCQL = `
+CREATE TABLE IF NOT EXISTS globular_events.schemas (
+    channel    text,
+    schema_id  text,
+    definition text,
+    PRIMARY KEY ((channel), schema_id)
+)
+`
+
+var (
+	errSchemaRequired = errors.New("channel has several schemas; schema_id is required")
+	errUnknownSchema  = errors.New("schema_id is not registered for this channel")
+	errNotJSON        = errors.New("channel has schemas; payload must be JSON")
+)
+
+// jsonSchema is the supported subset of JSON Schema.
+type jsonSchema struct {
+	Type                 json.RawMessage        `json:"type"`
+	Properties           map[string]*jsonSchema `json:"properties"`
+	Required             []string               `json:"required"`
+	Items                *jsonSchema            `json:"items"`
+	Enum                 []interface{}          `json:"enum"`
+	AdditionalProperties json.RawMessage        `json:"additionalProperties"`
+
+	types      []string
+	additional *jsonSchema // nil: any property allowed
+	closed     bool        // additionalProperties: false
+}
+
+// parseSchema compiles a schema definition.
+func parseSchema(definition string) (*jsonSchema, error) {
+	var s jsonSchema
+	dec := json.NewDecoder(strings.NewReader(definition))
+	dec.UseNumber()
+	if err := dec.Decode(&s); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	if err := s.compile(); err != nil {
+		return nil, fmt.Errorf("invalid schema: %w", err)
+	}
+	return &s, nil
+}
+
+func (s *jsonSchema) compile() error {
+	if len(s.Type) > 0 {
+		var one string
+		if err := json.Unmarshal(s.Type, &one); err == nil {
+			s.types = []string{one}
+		} else if err := json.Unmarshal(s.Type, &s.types); err != nil {
+			return errors.New("type must be a string or an array of strings")
+		}
+		for _, t := range s.types {
+			switch t {
+			case "object", "array", "string", "number", "integer", "boolean", "null":
+			default:
+				return fmt.Errorf("unknown type %q", t)
+			}
+		}
+	}
+	if len(s.AdditionalProperties) > 0 {
+		var allowed bool
+		if err := json.Unmarshal(s.AdditionalProperties, &allowed); err == nil {
+			s.closed = !allowed
+		} else {
+			s.additional = &jsonSchema{}
+			dec := json.NewDecoder(bytes.NewReader(s.AdditionalProperties))
+			dec.UseNumber()
+			if err := dec.Decode(s.additional); err != nil {
+				return errors.New("additionalProperties must be a boolean or a schema")
+			}
+			if err := s.additional.compile(); err != nil {
+				return err
+			}
+		}
+	}
+	for _, p := range s.Properties {
+		if err := p.compile(); err != nil {
+			return err
+		}
+	}
+	if s.Items != nil {
+		return s.Items.compile()
+	}
+	return nil
+}
+
+// typeOf returns the JSON Schema type of a value decoded with UseNumber.
+func typeOf(v interface{}) string {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		return "object"
+	case []interface{}:
+		return "array"
+	case string:
+		return "string"
+	case json.Number:
+		if _, err := v.Int64(); err == nil {
+			return "integer"
+		}
+		return "number"
+	case bool:
+		return "boolean"
+	}
+	return "null"
+}
+
+// validate checks a value against the schema; path locates the value in
+// error messages.
+func (s *jsonSchema) validate(v interface{}, path string) error {
+	if len(s.types) > 0 {
+		t := typeOf(v)
+		ok := false
+		for _, want := range s.types {
+			if want == t || (want == "number" && t == "integer") {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: %s is not of type %s", path, t, strings.Join(s.types, " or "))
+		}
+	}
+	if len(s.Enum) > 0 {
+		ok := false
+		for _, e := range s.Enum {
+			if reflect.DeepEqual(e, v) {
+				ok = true
+				break
+			}
+		}
+		if !ok {
+			return fmt.Errorf("%s: value is not one of the enum values", path)
+		}
+	}
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for _, name := range s.Required {
+			if _, ok := v[name]; !ok {
+				return fmt.Errorf("%s: missing required property %q", path, name)
+			}
+		}
+		names := make([]string, 0, len(v))
+		for name := range v {
+			names = append(names, name)
+		}
+		sort.Strings(names) // stable error messages
+		for _, name := range names {
+			sub, ok := s.Properties[name]
+			switch {
+			case ok:
+			case s.closed:
+				return fmt.Errorf("%s: unexpected property %q", path, name)
+			case s.additional != nil:
+				sub = s.additional
+			default:
+				continue
+			}
+			if err := sub.validate(v[name], path+"."+name); err != nil {
+				return err
+			}
+		}
+	case []interface{}:
+		if s.Items != nil {
+			for i, item := range v {
+				if err := s.Items.validate(item, fmt.Sprintf("%s[%d]", path, i)); err != nil {
+					return err
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// isJSONContentType tells if a content type can carry a JSON payload. An
+// empty content type is assumed to be JSON on channels with schemas.
+func isJSONContentType(ct string) bool {
+	ct = strings.TrimSpace(strings.ToLower(strings.SplitN(ct, ";", 2)[0]))
+	return ct == "" || ct == "application/json" || strings.HasSuffix(ct, "+json")
+}
+
+// validateEvent checks an event against the schemas of its channel and
+// fills its schema_id when the channel has a single schema. A nil or empty
+// schema set means the channel did not opt in.
+func validateEvent(evt *eventpb.Event, schemas map[string]*jsonSchema) error {
+	if len(schemas) == 0 {
+		return nil
+	}
+	if evt.SchemaId == "" {
+		if len(schemas) > 1 {
+			return errSchemaRequired
+		}
+		for id := range schemas {
+			evt.SchemaId = id
+		}
+	}
+	schema, ok := schemas[evt.SchemaId]
+	if !ok {
+		return fmt.Errorf("%w: %s", errUnknownSchema, evt.SchemaId)
+	}
+	if !isJSONContentType(evt.ContentType) {
+		return errNotJSON
+	}
+	var payload interface{}
+	dec := json.NewDecoder(bytes.NewReader(evt.Data))
+	dec.UseNumber()
+	if err := dec.Decode(&payload); err != nil {
+		return errNotJSON
+	}
+	return schema.validate(payload, "$")
+}
+
+// registerSchema stores a schema of a channel, replacing the one with the
+// same id.
+func (sb *scyllaBus) registerSchema(channel, id, definition string) error {
+	if sb.session == nil {
+		return fmt.Errorf("scylla not connected")
+	}
+	return sb.session.Query(
+		`INSERT INTO schemas (channel, schema_id, definition) VALUES (?, ?, ?)`, channel, id, definition,
+	).Exec()
+}
+
+func (sb *scyllaBus) deleteSchema(channel, id string) error {
+	if sb.session == nil {
+		return fmt.Errorf("scylla not connected")
+	}
+	return sb.session.Query(`DELETE FROM schemas WHERE channel = ? AND schema_id = ?`, channel, id).Exec()
+}
+
+// listSchemas returns the schemas of a channel, or of all channels when
+// channel is empty.
+func (sb *scyllaBus) listSchemas(channel string) ([]*eventpb.EventSchema, error) {
+	if sb.session == nil {
+		return nil, fmt.Errorf("scylla not connected")
+	}
+	q := sb.session.Query(`SELECT channel, schema_id, definition FROM schemas`)
+	if channel != "" {
+		q = sb.session.Query(`SELECT channel, schema_id, definition FROM schemas WHERE channel = ?`, channel)
+	}
+	iter := q.Iter()
+	var out []*eventpb.EventSchema
+	var c, id, def string
+	for iter.Scan(&c, &id, &def) {
+		out = append(out, &eventpb.EventSchema{Channel: c, SchemaId: id, Definition: def})
+	}
+	return out, iter.Close()
+}
+
+// schemaCache keeps the compiled schemas of the channels published on
+// through this instance.
+type schemaCache struct {
+	mu       sync.Mutex
+	channels map[string]cachedSchemas
+}
+
+type cachedSchemas struct {
+	schemas map[string]*jsonSchema
+	loaded  time.Time
+}
+
+func newSchemaCache() *schemaCache {
+	return &schemaCache{channels: make(map[string]cachedSchemas)}
+}
+
+// get returns the schemas of a channel, loading them when they are not
+// cached or are older than schemaCacheTTL.
+func (c *schemaCache) get(channel string, load func(string) ([]*eventpb.EventSchema, error)) (map[string]*jsonSchema, error) {
+	c.mu.Lock()
+	cached, ok := c.channels[channel]
+	c.mu.Unlock()
+	if ok && time.Since(cached.loaded) < schemaCacheTTL {
+		return cached.schemas, nil
+	}
+
+	defs, err := load(channel)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make(map[string]*jsonSchema, len(defs))
+	for _, def := range defs {
+		s, err := parseSchema(def.GetDefinition())
+		if err != nil {
+			// Registration validates definitions; skip one that no longer
+			// compiles rather than blocking the channel.
+			continue
+		}
+		schemas[def.GetSchemaId()] = s
+	}
+	c.mu.Lock()
+	c.channels[channel] = cachedSchemas{schemas: schemas, loaded: time.Now()}
+	c.mu.Unlock()
+	return schemas, nil
+}
+
+// invalidate drops the cached schemas of a channel.
+func (c *schemaCache) invalidate(channel string) {
+	c.mu.Lock()
+	delete(c.channels, channel)
+	c.mu.Unlock()
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/globulario/services/golang/event/eventpb"
+)
+
+const orderSchema = `{
+  "type": "object",
+  "required": ["id", "items"],
+  "additionalProperties": false,
+  "properties": {
+    "id":     {"type": "integer"},
+    "status": {"enum": ["new", "paid"]},
+    "items":  {"type": "array", "items": {"type": "object", "required": ["sku"], "properties": {"sku": {"type": "string"}, "qty": {"type": "number"}}}},
+    "note":   {"type": ["string", "null"]}
+  }
+}`
+
+func TestParseSchema(t *testing.T) {
+	if _, err := parseSchema(orderSchema); err != nil {
+		t.Fatal(err)
+	}
+	for _, def := range []string{`{`, `{"type": "uuid"}`, `{"type": 3}`, `{"additionalProperties": 1}`, `{"properties": {"a": {"type": "map"}}}`} {
+		if _, err := parseSchema(def); err == nil {
+			t.Errorf("parseSchema(%s) succeeded", def)
+		}
+	}
+}
+
+func TestValidateEvent(t *testing.T) {
+	s, err := parseSchema(orderSchema)
+	if err != nil {
+		t.Fatal(err)
+	}
+	one := map[string]*jsonSchema{"orders/v1": s}
+	cases := []struct {
+		name    string
+		evt     *eventpb.Event
+		schemas map[string]*jsonSchema
+		ok      bool
+	}{
+		{"channel without schemas", &eventpb.Event{Data: []byte("not json")}, nil, true},
+		{"valid", &eventpb.Event{Data: []byte(`{"id": 1, "status": "paid", "items": [{"sku": "a", "qty": 1.5}], "note": null}`)}, one, true},
+		{"missing required", &eventpb.Event{Data: []byte(`{"id": 1}`)}, one, false},
+		{"wrong type", &eventpb.Event{Data: []byte(`{"id": 1.5, "items": []}`)}, one, false},
+		{"enum", &eventpb.Event{Data: []byte(`{"id": 1, "items": [], "status": "lost"}`)}, one, false},
+		{"nested item", &eventpb.Event{Data: []byte(`{"id": 1, "items": [{"qty": 1}]}`)}, one, false},
+		{"closed object", &eventpb.Event{Data: []byte(`{"id": 1, "items": [], "extra": true}`)}, one, false},
+		{"not json", &eventpb.Event{Data: []byte(`id=1`)}, one, false},
+		{"binary content type", &eventpb.Event{ContentType: "application/octet-stream", Data: []byte(`{"id": 1, "items": []}`)}, one, false},
+		{"json suffix content type", &eventpb.Event{ContentType: "application/vnd.order+json; charset=utf-8", Data: []byte(`{"id": 1, "items": []}`)}, one, true},
+		{"unknown schema", &eventpb.Event{SchemaId: "orders/v9", Data: []byte(`{"id": 1, "items": []}`)}, one, false},
+	}
+	for _, tc := range cases {
+		err := validateEvent(tc.evt, tc.schemas)
+		if (err == nil) != tc.ok {
+			t.Errorf("%s: validateEvent = %v, want ok=%v", tc.name, err, tc.ok)
+		}
+	}
+
+	// A single schema names itself; several require the publisher to choose.
+	evt := &eventpb.Event{Data: []byte(`{"id": 1, "items": []}`)}
+	if err := validateEvent(evt, one); err != nil || evt.SchemaId != "orders/v1" {
+		t.Errorf("schema_id = %q, %v; want orders/v1", evt.SchemaId, err)
+	}
+	two := map[string]*jsonSchema{"orders/v1": s, "orders/v2": {}}
+	if err := validateEvent(&eventpb.Event{Data: []byte(`{}`)}, two); !errors.Is(err, errSchemaRequired) {
+		t.Errorf("several schemas without schema_id = %v, want errSchemaRequired", err)
+	}
+}
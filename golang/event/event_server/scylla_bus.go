@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/event/eventpb"
 	"github.com/gocql/gocql"
 )
 
@@ -108,6 +109,7 @@ CREATE TABLE IF NOT EXISTS globular_events.events (
     seq      timeuuid,
     name     text,
     data     blob,
+    envelope blob,
     PRIMARY KEY ((bucket), seq)
 ) WITH CLUSTERING ORDER BY (seq ASC)
   AND default_time_to_live = 3600
@@ -185,6 +187,23 @@ func (sb *scyllaBus) connect() error {
 		initSess.Close()
 		return fmt.Errorf("create cursors table: %w", err)
 	}
+	// Tables created before envelopes existed lack the envelope column.
+	if err := ensureColumn(initSess, "events", "envelope", "blob"); err != nil {
+		initSess.Close()
+		return fmt.Errorf("add events envelope column: %w", err)
+	}
+	if err := initSess.Query(createGroupDeliveriesTableCQL).Exec(); err != nil {
+		initSess.Close()
+		return fmt.Errorf("create group deliveries table: %w", err)
+	}
+	if err := initSess.Query(createIdempotencyKeysTableCQL).Exec(); err != nil {
+		initSess.Close()
+		return fmt.Errorf("create idempotency keys table: %w", err)
+	}
+	if err := initSess.Query(createSchemasTableCQL).Exec(); err != nil {
+		initSess.Close()
+		return fmt.Errorf("create schemas table: %w", err)
+	}
 	initSess.Close()
 
 	// Reconnect with keyspace.
@@ -297,19 +316,50 @@ func bucketsFrom(from time.Time) []string {
 	return buckets
 }
 
-// publish inserts an event into ScyllaDB.
+// publish inserts an event without envelope into ScyllaDB.
 // Returns nil on success, meaning the event is DURABLY STORED.
 // This does NOT mean any subscriber has received it.
 func (sb *scyllaBus) publish(name string, data []byte) error {
+	_, err := sb.publishEvent(&eventpb.Event{Name: name, Data: data})
+	return err
+}
+
+// publishEvent inserts an event and its envelope into ScyllaDB. An event
+// whose idempotency key was already published on the same channel within
+// idempotencyWindow is dropped and reported as a duplicate.
+func (sb *scyllaBus) publishEvent(evt *eventpb.Event) (duplicate bool, err error) {
 	if sb.session == nil {
-		return fmt.Errorf("scylla not connected")
+		return false, fmt.Errorf("scylla not connected")
+	}
+	envelope, err := marshalEnvelope(evt)
+	if err != nil {
+		return false, err
 	}
 	bucket := currentBucket()
 	seq := gocql.TimeUUID()
-	return sb.session.Query(
-		`INSERT INTO events (bucket, seq, name, data) VALUES (?, ?, ?, ?)`,
-		bucket, seq, name, data,
+
+	if key := evt.GetIdempotencyKey(); key != "" {
+		fresh, err := sb.session.Query(
+			`INSERT INTO idempotency_keys (channel, key, seq) VALUES (?, ?, ?) IF NOT EXISTS USING TTL ?`,
+			evt.GetName(), key, seq, int(idempotencyWindow/time.Second),
+		).MapScanCAS(map[string]interface{}{})
+		if err != nil {
+			return false, err
+		}
+		if !fresh {
+			return true, nil
+		}
+	}
+
+	err = sb.session.Query(
+		`INSERT INTO events (bucket, seq, name, data, envelope) VALUES (?, ?, ?, ?, ?)`,
+		bucket, seq, evt.GetName(), evt.GetData(), envelope,
 	).Exec()
+	if err != nil && evt.GetIdempotencyKey() != "" {
+		// Release the key so the publisher can retry.
+		_ = sb.session.Query(`DELETE FROM idempotency_keys WHERE channel = ? AND key = ?`, evt.GetName(), evt.GetIdempotencyKey()).Exec()
+	}
+	return false, err
 }
 
 // pollOnce reads events newer than lastSeq, advancing the cursor incrementally.
@@ -351,18 +401,19 @@ func (sb *scyllaBus) pollOnce() []pollEvent {
 
 	for _, bucket := range scanBuckets {
 		iter := sb.session.Query(
-			`SELECT seq, name, data FROM events WHERE bucket = ? AND seq > ?`,
+			`SELECT seq, name, data, envelope FROM events WHERE bucket = ? AND seq > ?`,
 			bucket, sb.lastSeq,
 		).Iter()
 
 		var seq gocql.UUID
 		var name string
-		var data []byte
-		for iter.Scan(&seq, &name, &data) {
+		var data, envelope []byte
+		for iter.Scan(&seq, &name, &data, &envelope) {
 			events = append(events, pollEvent{
-				seq:  seq,
-				name: name,
-				data: append([]byte(nil), data...), // defensive copy
+				seq:      seq,
+				name:     name,
+				data:     append([]byte(nil), data...), // defensive copy
+				envelope: append([]byte(nil), envelope...),
 			})
 			if seq.Time().After(sb.lastSeq.Time()) {
 				sb.lastSeq = seq
@@ -427,21 +478,22 @@ func (sb *scyllaBus) queryEvents(nameFilter string, afterSeq gocql.UUID, limit i
 
 	for _, bucket := range buckets {
 		iter := sb.session.Query(
-			`SELECT seq, name, data FROM events WHERE bucket = ? AND seq > ?`,
+			`SELECT seq, name, data, envelope FROM events WHERE bucket = ? AND seq > ?`,
 			bucket, afterSeq,
 		).Iter()
 
 		var seq gocql.UUID
 		var name string
-		var data []byte
-		for iter.Scan(&seq, &name, &data) {
+		var data, envelope []byte
+		for iter.Scan(&seq, &name, &data, &envelope) {
 			if nameFilter != "" && !strings.HasPrefix(name, nameFilter) {
 				continue
 			}
 			events = append(events, pollEvent{
-				seq:  seq,
-				name: name,
-				data: append([]byte(nil), data...),
+				seq:      seq,
+				name:     name,
+				data:     append([]byte(nil), data...),
+				envelope: append([]byte(nil), envelope...),
 			})
 			if seq.Time().After(latestSeq.Time()) {
 				latestSeq = seq
@@ -502,7 +554,8 @@ func allowRateLimitedLog(nextAllowed *atomic.Int64, interval time.Duration) bool
 }
 
 type pollEvent struct {
-	seq  gocql.UUID
-	name string
-	data []byte
+	seq      gocql.UUID
+	name     string
+	data     []byte
+	envelope []byte // marshaled eventpb.Event without name and data, may be empty
 }
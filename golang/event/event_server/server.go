@@ -94,6 +94,7 @@ type server struct {
 	actions    chan map[string]interface{}
 	exit       chan bool
 	bus        *scyllaBus
+	schemas    *schemaCache
 	depHealth  *dephealth.Watchdog
 
 	logger *slog.Logger
@@ -284,6 +285,7 @@ func initializeServerDefaults() *server {
 	srv.AllowAllOrigins = allow_all_origins
 	srv.AllowedOrigins = allowed_origins
 	srv.logger = logger
+	srv.schemas = newSchemaCache()
 	srv.ensureRuntimeChannels()
 
 	srv.Domain, srv.Address = globular.GetDefaultDomainAddress(srv.Port)
@@ -323,6 +325,9 @@ func printUsage() {
 	fmt.Println("  • Multiple subscribers per event channel")
 	fmt.Println("  • Event publishing with filtering")
 	fmt.Println("  • Subscription management (subscribe/unsubscribe)")
+	fmt.Println("  • Durable consumer groups with ack/nack, redelivery and dead-lettering")
+	fmt.Println("  • Event envelopes: publisher, correlation id, schema id, idempotency key")
+	fmt.Println("  • Optional per-channel JSON schema validation")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  # Start with auto-generated ID and default config")
@@ -393,6 +398,15 @@ func main() {
 		{Method: "/event.EventService/QueryEvents", Action: "event.query"},
 		{Method: "/event.EventService/Publish", Action: "event.publish"},
 		{Method: "/event.EventService/Stop", Action: "event.stop"},
+		{Method: "/event.EventService/CreateConsumerGroup", Action: "event.group.create"},
+		{Method: "/event.EventService/DeleteConsumerGroup", Action: "event.group.delete"},
+		{Method: "/event.EventService/ListConsumerGroups", Action: "event.group.list"},
+		{Method: "/event.EventService/Consume", Action: "event.group.consume"},
+		{Method: "/event.EventService/Ack", Action: "event.group.ack"},
+		{Method: "/event.EventService/Nack", Action: "event.group.nack"},
+		{Method: "/event.EventService/RegisterSchema", Action: "event.schema.register"},
+		{Method: "/event.EventService/DeleteSchema", Action: "event.schema.delete"},
+		{Method: "/event.EventService/ListSchemas", Action: "event.schema.list"},
 	})
 
 	// Handle --describe flag
@@ -60,13 +60,20 @@ func (*KeepAlive) Descriptor() ([]byte, []int) {
 	return file_event_proto_rawDescGZIP(), []int{0}
 }
 
-// Event represents a generic event with a name and data.
+// Event represents a generic event with a name and data, and the envelope
+// describing them. Envelope fields are optional.
 type Event struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"` // The event name.
-	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"` // The event data, can be anything.
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
+	state          protoimpl.MessageState `protogen:"open.v1"`
+	Name           string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                                                 // The event name.
+	Data           []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                                                                                 // The event data, can be anything.
+	Publisher      string                 `protobuf:"bytes,3,opt,name=publisher,proto3" json:"publisher,omitempty"`                                                                       // Who published the event; set by the server from the caller identity.
+	CorrelationId  string                 `protobuf:"bytes,4,opt,name=correlation_id,json=correlationId,proto3" json:"correlation_id,omitempty"`                                          // Correlation/trace id; defaults to the x-correlation-id metadata of the Publish call.
+	ContentType    string                 `protobuf:"bytes,5,opt,name=content_type,json=contentType,proto3" json:"content_type,omitempty"`                                                // Media type of data, e.g. "application/json".
+	SchemaId       string                 `protobuf:"bytes,6,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`                                                         // Schema of data; checked against the registry when the channel has schemas.
+	IdempotencyKey string                 `protobuf:"bytes,7,opt,name=idempotency_key,json=idempotencyKey,proto3" json:"idempotency_key,omitempty"`                                       // Publishes of the same key on a channel within the dedup window are dropped.
+	Headers        map[string]string      `protobuf:"bytes,8,rep,name=headers,proto3" json:"headers,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Free-form headers.
+	unknownFields  protoimpl.UnknownFields
+	sizeCache      protoimpl.SizeCache
 }
 
 func (x *Event) Reset() {
@@ -113,6 +120,48 @@ func (x *Event) GetData() []byte {
 	return nil
 }
 
+func (x *Event) GetPublisher() string {
+	if x != nil {
+		return x.Publisher
+	}
+	return ""
+}
+
+func (x *Event) GetCorrelationId() string {
+	if x != nil {
+		return x.CorrelationId
+	}
+	return ""
+}
+
+func (x *Event) GetContentType() string {
+	if x != nil {
+		return x.ContentType
+	}
+	return ""
+}
+
+func (x *Event) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *Event) GetIdempotencyKey() string {
+	if x != nil {
+		return x.IdempotencyKey
+	}
+	return ""
+}
+
+func (x *Event) GetHeaders() map[string]string {
+	if x != nil {
+		return x.Headers
+	}
+	return nil
+}
+
 // QuitRequest message to request stopping a stream or connection.
 type QuitRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -575,7 +624,8 @@ func (x *PublishRequest) GetEvt() *Event {
 // PublishResponse message as a result of PublishRequest.
 type PublishResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // Result of the publish request.
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`       // Result of the publish request.
+	Duplicate     bool                   `protobuf:"varint,2,opt,name=duplicate,proto3" json:"duplicate,omitempty"` // The idempotency key was already published; the event was dropped.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -617,6 +667,13 @@ func (x *PublishResponse) GetResult() bool {
 	return false
 }
 
+func (x *PublishResponse) GetDuplicate() bool {
+	if x != nil {
+		return x.Duplicate
+	}
+	return false
+}
+
 // StopRequest message to stop the server.
 type StopRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -697,6 +754,7 @@ type PersistedEvent struct {
 	Data          []byte                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
 	Ts            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ts,proto3" json:"ts,omitempty"`
 	Sequence      uint64                 `protobuf:"varint,4,opt,name=sequence,proto3" json:"sequence,omitempty"` // monotonic, for cursor pagination
+	Evt           *Event                 `protobuf:"bytes,5,opt,name=evt,proto3" json:"evt,omitempty"`            // the event with its envelope
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -759,6 +817,13 @@ func (x *PersistedEvent) GetSequence() uint64 {
 	return 0
 }
 
+func (x *PersistedEvent) GetEvt() *Event {
+	if x != nil {
+		return x.Evt
+	}
+	return nil
+}
+
 type QueryEventsRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	NameFilter    string                 `protobuf:"bytes,1,opt,name=name_filter,json=nameFilter,proto3" json:"name_filter,omitempty"`           // prefix filter, empty = all
@@ -871,139 +936,1356 @@ func (x *QueryEventsResponse) GetLatestSequence() uint64 {
 	return 0
 }
 
-var File_event_proto protoreflect.FileDescriptor
+type ConsumerGroup struct {
+	state             protoimpl.MessageState `protogen:"open.v1"`
+	Name              string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`                                                       // The group name.
+	Channel           string                 `protobuf:"bytes,2,opt,name=channel,proto3" json:"channel,omitempty"`                                                 // Channel or pattern ("orders.*", "*").
+	AckTimeoutSeconds uint32                 `protobuf:"varint,3,opt,name=ack_timeout_seconds,json=ackTimeoutSeconds,proto3" json:"ack_timeout_seconds,omitempty"` // Redelivery timeout, 0 = server default (30s).
+	MaxDeliveries     uint32                 `protobuf:"varint,4,opt,name=max_deliveries,json=maxDeliveries,proto3" json:"max_deliveries,omitempty"`               // Attempts before dead-lettering, 0 = server default (5).
+	Pending           uint64                 `protobuf:"varint,5,opt,name=pending,proto3" json:"pending,omitempty"`                                                // Events not acknowledged yet (ListConsumerGroups only).
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
 
-const file_event_proto_rawDesc = "" +
-	"\n" +
-	"\vevent.proto\x12\x05event\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13globular_auth.proto\"\v\n" +
-	"\tKeepAlive\"/\n" +
-	"\x05Event\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\"!\n" +
-	"\vQuitRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"&\n" +
-	"\fQuitResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"$\n" +
-	"\x0eOnEventRequest\x12\x12\n" +
-	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"_\n" +
-	"\x0fOnEventResponse\x12 \n" +
-	"\x03evt\x18\x01 \x01(\v2\f.event.EventH\x00R\x03evt\x12\"\n" +
-	"\x02ka\x18\x02 \x01(\v2\x10.event.KeepAliveH\x00R\x02kaB\x06\n" +
-	"\x04data\":\n" +
-	"\x10SubscribeRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04uuid\x18\x02 \x01(\tR\x04uuid\"+\n" +
-	"\x11SubscribeResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"<\n" +
-	"\x12UnSubscribeRequest\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04uuid\x18\x02 \x01(\tR\x04uuid\"-\n" +
-	"\x13UnSubscribeResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"0\n" +
-	"\x0ePublishRequest\x12\x1e\n" +
-	"\x03evt\x18\x01 \x01(\v2\f.event.EventR\x03evt\")\n" +
-	"\x0fPublishResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\r\n" +
-	"\vStopRequest\"\x0e\n" +
-	"\fStopResponse\"\x80\x01\n" +
-	"\x0ePersistedEvent\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\fR\x04data\x12*\n" +
-	"\x02ts\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\x12\x1a\n" +
-	"\bsequence\x18\x04 \x01(\x04R\bsequence\"r\n" +
-	"\x12QueryEventsRequest\x12\x1f\n" +
-	"\vname_filter\x18\x01 \x01(\tR\n" +
-	"nameFilter\x12\x14\n" +
-	"\x05limit\x18\x02 \x01(\rR\x05limit\x12%\n" +
-	"\x0eafter_sequence\x18\x03 \x01(\x04R\rafterSequence\"m\n" +
-	"\x13QueryEventsResponse\x12-\n" +
-	"\x06events\x18\x01 \x03(\v2\x15.event.PersistedEventR\x06events\x12'\n" +
-	"\x0flatest_sequence\x18\x02 \x01(\x04R\x0elatestSequence2\xee\x05\n" +
-	"\fEventService\x12W\n" +
-	"\x04Stop\x12\x12.event.StopRequest\x1a\x13.event.StopResponse\"&\x82\xb5\x18\"\n" +
-	"\n" +
-	"event.stop\x12\x05admin\x1a\x06/event*\x05admin\x12k\n" +
-	"\aOnEvent\x12\x15.event.OnEventRequest\x1a\x16.event.OnEventResponse\"/\x82\xb5\x18+\n" +
-	"\x13event.stream.listen\x12\x04read\x1a\x06/event*\x06viewer0\x01\x12_\n" +
-	"\x04Quit\x12\x12.event.QuitRequest\x1a\x13.event.QuitResponse\".\x82\xb5\x18*\n" +
-	"\x11event.stream.quit\x12\x05write\x1a\x06/event*\x06editor\x12l\n" +
-	"\tSubscribe\x12\x17.event.SubscribeRequest\x1a\x18.event.SubscribeResponse\",\x82\xb5\x18(\n" +
-	"\x0fevent.subscribe\x12\x05write\x1a\x06/event*\x06editor\x12t\n" +
-	"\vUnSubscribe\x12\x19.event.UnSubscribeRequest\x1a\x1a.event.UnSubscribeResponse\".\x82\xb5\x18*\n" +
-	"\x11event.unsubscribe\x12\x05write\x1a\x06/event*\x06editor\x12d\n" +
-	"\aPublish\x12\x15.event.PublishRequest\x1a\x16.event.PublishResponse\"*\x82\xb5\x18&\n" +
-	"\revent.publish\x12\x05write\x1a\x06/event*\x06editor\x12m\n" +
-	"\vQueryEvents\x12\x19.event.QueryEventsRequest\x1a\x1a.event.QueryEventsResponse\"'\x82\xb5\x18#\n" +
-	"\vevent.query\x12\x04read\x1a\x06/event*\x06viewerB5Z3github.com/globulario/services/golang/event/eventpbb\x06proto3"
+func (x *ConsumerGroup) Reset() {
+	*x = ConsumerGroup{}
+	mi := &file_event_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
 
-var (
-	file_event_proto_rawDescOnce sync.Once
-	file_event_proto_rawDescData []byte
-)
+func (x *ConsumerGroup) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
 
-func file_event_proto_rawDescGZIP() []byte {
-	file_event_proto_rawDescOnce.Do(func() {
-		file_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)))
-	})
-	return file_event_proto_rawDescData
+func (*ConsumerGroup) ProtoMessage() {}
+
+func (x *ConsumerGroup) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
 }
 
-var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 17)
-var file_event_proto_goTypes = []any{
-	(*KeepAlive)(nil),             // 0: event.KeepAlive
-	(*Event)(nil),                 // 1: event.Event
-	(*QuitRequest)(nil),           // 2: event.QuitRequest
-	(*QuitResponse)(nil),          // 3: event.QuitResponse
-	(*OnEventRequest)(nil),        // 4: event.OnEventRequest
-	(*OnEventResponse)(nil),       // 5: event.OnEventResponse
-	(*SubscribeRequest)(nil),      // 6: event.SubscribeRequest
-	(*SubscribeResponse)(nil),     // 7: event.SubscribeResponse
-	(*UnSubscribeRequest)(nil),    // 8: event.UnSubscribeRequest
-	(*UnSubscribeResponse)(nil),   // 9: event.UnSubscribeResponse
-	(*PublishRequest)(nil),        // 10: event.PublishRequest
-	(*PublishResponse)(nil),       // 11: event.PublishResponse
-	(*StopRequest)(nil),           // 12: event.StopRequest
-	(*StopResponse)(nil),          // 13: event.StopResponse
-	(*PersistedEvent)(nil),        // 14: event.PersistedEvent
-	(*QueryEventsRequest)(nil),    // 15: event.QueryEventsRequest
-	(*QueryEventsResponse)(nil),   // 16: event.QueryEventsResponse
-	(*timestamppb.Timestamp)(nil), // 17: google.protobuf.Timestamp
+// Deprecated: Use ConsumerGroup.ProtoReflect.Descriptor instead.
+func (*ConsumerGroup) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{17}
 }
-var file_event_proto_depIdxs = []int32{
-	1,  // 0: event.OnEventResponse.evt:type_name -> event.Event
-	0,  // 1: event.OnEventResponse.ka:type_name -> event.KeepAlive
-	1,  // 2: event.PublishRequest.evt:type_name -> event.Event
-	17, // 3: event.PersistedEvent.ts:type_name -> google.protobuf.Timestamp
-	14, // 4: event.QueryEventsResponse.events:type_name -> event.PersistedEvent
-	12, // 5: event.EventService.Stop:input_type -> event.StopRequest
-	4,  // 6: event.EventService.OnEvent:input_type -> event.OnEventRequest
-	2,  // 7: event.EventService.Quit:input_type -> event.QuitRequest
-	6,  // 8: event.EventService.Subscribe:input_type -> event.SubscribeRequest
-	8,  // 9: event.EventService.UnSubscribe:input_type -> event.UnSubscribeRequest
-	10, // 10: event.EventService.Publish:input_type -> event.PublishRequest
-	15, // 11: event.EventService.QueryEvents:input_type -> event.QueryEventsRequest
-	13, // 12: event.EventService.Stop:output_type -> event.StopResponse
-	5,  // 13: event.EventService.OnEvent:output_type -> event.OnEventResponse
-	3,  // 14: event.EventService.Quit:output_type -> event.QuitResponse
-	7,  // 15: event.EventService.Subscribe:output_type -> event.SubscribeResponse
-	9,  // 16: event.EventService.UnSubscribe:output_type -> event.UnSubscribeResponse
-	11, // 17: event.EventService.Publish:output_type -> event.PublishResponse
-	16, // 18: event.EventService.QueryEvents:output_type -> event.QueryEventsResponse
-	12, // [12:19] is the sub-list for method output_type
-	5,  // [5:12] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+
+func (x *ConsumerGroup) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
 }
 
-func init() { file_event_proto_init() }
-func file_event_proto_init() {
-	if File_event_proto != nil {
-		return
+func (x *ConsumerGroup) GetChannel() string {
+	if x != nil {
+		return x.Channel
 	}
-	file_event_proto_msgTypes[5].OneofWrappers = []any{
-		(*OnEventResponse_Evt)(nil),
-		(*OnEventResponse_Ka)(nil),
+	return ""
+}
+
+func (x *ConsumerGroup) GetAckTimeoutSeconds() uint32 {
+	if x != nil {
+		return x.AckTimeoutSeconds
+	}
+	return 0
+}
+
+func (x *ConsumerGroup) GetMaxDeliveries() uint32 {
+	if x != nil {
+		return x.MaxDeliveries
+	}
+	return 0
+}
+
+func (x *ConsumerGroup) GetPending() uint64 {
+	if x != nil {
+		return x.Pending
+	}
+	return 0
+}
+
+type CreateConsumerGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         *ConsumerGroup         `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConsumerGroupRequest) Reset() {
+	*x = CreateConsumerGroupRequest{}
+	mi := &file_event_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConsumerGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConsumerGroupRequest) ProtoMessage() {}
+
+func (x *CreateConsumerGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConsumerGroupRequest.ProtoReflect.Descriptor instead.
+func (*CreateConsumerGroupRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *CreateConsumerGroupRequest) GetGroup() *ConsumerGroup {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+type CreateConsumerGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // False when the group already exists.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateConsumerGroupResponse) Reset() {
+	*x = CreateConsumerGroupResponse{}
+	mi := &file_event_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateConsumerGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateConsumerGroupResponse) ProtoMessage() {}
+
+func (x *CreateConsumerGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateConsumerGroupResponse.ProtoReflect.Descriptor instead.
+func (*CreateConsumerGroupResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *CreateConsumerGroupResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type DeleteConsumerGroupRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConsumerGroupRequest) Reset() {
+	*x = DeleteConsumerGroupRequest{}
+	mi := &file_event_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConsumerGroupRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConsumerGroupRequest) ProtoMessage() {}
+
+func (x *DeleteConsumerGroupRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConsumerGroupRequest.ProtoReflect.Descriptor instead.
+func (*DeleteConsumerGroupRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *DeleteConsumerGroupRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+type DeleteConsumerGroupResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteConsumerGroupResponse) Reset() {
+	*x = DeleteConsumerGroupResponse{}
+	mi := &file_event_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteConsumerGroupResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteConsumerGroupResponse) ProtoMessage() {}
+
+func (x *DeleteConsumerGroupResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteConsumerGroupResponse.ProtoReflect.Descriptor instead.
+func (*DeleteConsumerGroupResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *DeleteConsumerGroupResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type ListConsumerGroupsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsumerGroupsRequest) Reset() {
+	*x = ListConsumerGroupsRequest{}
+	mi := &file_event_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsumerGroupsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsumerGroupsRequest) ProtoMessage() {}
+
+func (x *ListConsumerGroupsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsumerGroupsRequest.ProtoReflect.Descriptor instead.
+func (*ListConsumerGroupsRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{22}
+}
+
+type ListConsumerGroupsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Groups        []*ConsumerGroup       `protobuf:"bytes,1,rep,name=groups,proto3" json:"groups,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListConsumerGroupsResponse) Reset() {
+	*x = ListConsumerGroupsResponse{}
+	mi := &file_event_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListConsumerGroupsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListConsumerGroupsResponse) ProtoMessage() {}
+
+func (x *ListConsumerGroupsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListConsumerGroupsResponse.ProtoReflect.Descriptor instead.
+func (*ListConsumerGroupsResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ListConsumerGroupsResponse) GetGroups() []*ConsumerGroup {
+	if x != nil {
+		return x.Groups
+	}
+	return nil
+}
+
+type ConsumeRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	Member        string                 `protobuf:"bytes,2,opt,name=member,proto3" json:"member,omitempty"` // Unique identifier of the consuming worker.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeRequest) Reset() {
+	*x = ConsumeRequest{}
+	mi := &file_event_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeRequest) ProtoMessage() {}
+
+func (x *ConsumeRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeRequest.ProtoReflect.Descriptor instead.
+func (*ConsumeRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *ConsumeRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *ConsumeRequest) GetMember() string {
+	if x != nil {
+		return x.Member
+	}
+	return ""
+}
+
+// Delivery of an event to one member of a group.
+type Delivery struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	DeliveryId    string                 `protobuf:"bytes,1,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"` // Pass to Ack or Nack.
+	Evt           *Event                 `protobuf:"bytes,2,opt,name=evt,proto3" json:"evt,omitempty"`
+	Ts            *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=ts,proto3" json:"ts,omitempty"`            // Publication time.
+	Attempt       uint32                 `protobuf:"varint,4,opt,name=attempt,proto3" json:"attempt,omitempty"` // 1 for the first delivery.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Delivery) Reset() {
+	*x = Delivery{}
+	mi := &file_event_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Delivery) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Delivery) ProtoMessage() {}
+
+func (x *Delivery) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Delivery.ProtoReflect.Descriptor instead.
+func (*Delivery) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *Delivery) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *Delivery) GetEvt() *Event {
+	if x != nil {
+		return x.Evt
+	}
+	return nil
+}
+
+func (x *Delivery) GetTs() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Ts
+	}
+	return nil
+}
+
+func (x *Delivery) GetAttempt() uint32 {
+	if x != nil {
+		return x.Attempt
+	}
+	return 0
+}
+
+type ConsumeResponse struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Data:
+	//
+	//	*ConsumeResponse_Delivery
+	//	*ConsumeResponse_Ka
+	Data          isConsumeResponse_Data `protobuf_oneof:"data"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeResponse) Reset() {
+	*x = ConsumeResponse{}
+	mi := &file_event_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeResponse) ProtoMessage() {}
+
+func (x *ConsumeResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeResponse.ProtoReflect.Descriptor instead.
+func (*ConsumeResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *ConsumeResponse) GetData() isConsumeResponse_Data {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+func (x *ConsumeResponse) GetDelivery() *Delivery {
+	if x != nil {
+		if x, ok := x.Data.(*ConsumeResponse_Delivery); ok {
+			return x.Delivery
+		}
+	}
+	return nil
+}
+
+func (x *ConsumeResponse) GetKa() *KeepAlive {
+	if x != nil {
+		if x, ok := x.Data.(*ConsumeResponse_Ka); ok {
+			return x.Ka
+		}
+	}
+	return nil
+}
+
+type isConsumeResponse_Data interface {
+	isConsumeResponse_Data()
+}
+
+type ConsumeResponse_Delivery struct {
+	Delivery *Delivery `protobuf:"bytes,1,opt,name=delivery,proto3,oneof"`
+}
+
+type ConsumeResponse_Ka struct {
+	Ka *KeepAlive `protobuf:"bytes,2,opt,name=ka,proto3,oneof"`
+}
+
+func (*ConsumeResponse_Delivery) isConsumeResponse_Data() {}
+
+func (*ConsumeResponse_Ka) isConsumeResponse_Data() {}
+
+type AckRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	DeliveryId    string                 `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckRequest) Reset() {
+	*x = AckRequest{}
+	mi := &file_event_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckRequest) ProtoMessage() {}
+
+func (x *AckRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckRequest.ProtoReflect.Descriptor instead.
+func (*AckRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *AckRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *AckRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+type AckResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // False when the delivery was already acknowledged or dead-lettered.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AckResponse) Reset() {
+	*x = AckResponse{}
+	mi := &file_event_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AckResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AckResponse) ProtoMessage() {}
+
+func (x *AckResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AckResponse.ProtoReflect.Descriptor instead.
+func (*AckResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *AckResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type NackRequest struct {
+	state               protoimpl.MessageState `protogen:"open.v1"`
+	Group               string                 `protobuf:"bytes,1,opt,name=group,proto3" json:"group,omitempty"`
+	DeliveryId          string                 `protobuf:"bytes,2,opt,name=delivery_id,json=deliveryId,proto3" json:"delivery_id,omitempty"`
+	RequeueDelaySeconds uint32                 `protobuf:"varint,3,opt,name=requeue_delay_seconds,json=requeueDelaySeconds,proto3" json:"requeue_delay_seconds,omitempty"` // Delay before redelivery, 0 = immediately.
+	unknownFields       protoimpl.UnknownFields
+	sizeCache           protoimpl.SizeCache
+}
+
+func (x *NackRequest) Reset() {
+	*x = NackRequest{}
+	mi := &file_event_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackRequest) ProtoMessage() {}
+
+func (x *NackRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackRequest.ProtoReflect.Descriptor instead.
+func (*NackRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *NackRequest) GetGroup() string {
+	if x != nil {
+		return x.Group
+	}
+	return ""
+}
+
+func (x *NackRequest) GetDeliveryId() string {
+	if x != nil {
+		return x.DeliveryId
+	}
+	return ""
+}
+
+func (x *NackRequest) GetRequeueDelaySeconds() uint32 {
+	if x != nil {
+		return x.RequeueDelaySeconds
+	}
+	return 0
+}
+
+type NackResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *NackResponse) Reset() {
+	*x = NackResponse{}
+	mi := &file_event_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *NackResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*NackResponse) ProtoMessage() {}
+
+func (x *NackResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use NackResponse.ProtoReflect.Descriptor instead.
+func (*NackResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *NackResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type EventSchema struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`                   // Exact channel name.
+	SchemaId      string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"` // e.g. "orders.created/v2".
+	Definition    string                 `protobuf:"bytes,3,opt,name=definition,proto3" json:"definition,omitempty"`             // JSON Schema (type, properties, required, items, enum, additionalProperties).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EventSchema) Reset() {
+	*x = EventSchema{}
+	mi := &file_event_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EventSchema) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EventSchema) ProtoMessage() {}
+
+func (x *EventSchema) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EventSchema.ProtoReflect.Descriptor instead.
+func (*EventSchema) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *EventSchema) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *EventSchema) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+func (x *EventSchema) GetDefinition() string {
+	if x != nil {
+		return x.Definition
+	}
+	return ""
+}
+
+type RegisterSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schema        *EventSchema           `protobuf:"bytes,1,opt,name=schema,proto3" json:"schema,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterSchemaRequest) Reset() {
+	*x = RegisterSchemaRequest{}
+	mi := &file_event_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterSchemaRequest) ProtoMessage() {}
+
+func (x *RegisterSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterSchemaRequest.ProtoReflect.Descriptor instead.
+func (*RegisterSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *RegisterSchemaRequest) GetSchema() *EventSchema {
+	if x != nil {
+		return x.Schema
+	}
+	return nil
+}
+
+type RegisterSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RegisterSchemaResponse) Reset() {
+	*x = RegisterSchemaResponse{}
+	mi := &file_event_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RegisterSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RegisterSchemaResponse) ProtoMessage() {}
+
+func (x *RegisterSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RegisterSchemaResponse.ProtoReflect.Descriptor instead.
+func (*RegisterSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *RegisterSchemaResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type DeleteSchemaRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"`
+	SchemaId      string                 `protobuf:"bytes,2,opt,name=schema_id,json=schemaId,proto3" json:"schema_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSchemaRequest) Reset() {
+	*x = DeleteSchemaRequest{}
+	mi := &file_event_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSchemaRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSchemaRequest) ProtoMessage() {}
+
+func (x *DeleteSchemaRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSchemaRequest.ProtoReflect.Descriptor instead.
+func (*DeleteSchemaRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *DeleteSchemaRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+func (x *DeleteSchemaRequest) GetSchemaId() string {
+	if x != nil {
+		return x.SchemaId
+	}
+	return ""
+}
+
+type DeleteSchemaResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteSchemaResponse) Reset() {
+	*x = DeleteSchemaResponse{}
+	mi := &file_event_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteSchemaResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteSchemaResponse) ProtoMessage() {}
+
+func (x *DeleteSchemaResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteSchemaResponse.ProtoReflect.Descriptor instead.
+func (*DeleteSchemaResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *DeleteSchemaResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+type ListSchemasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Channel       string                 `protobuf:"bytes,1,opt,name=channel,proto3" json:"channel,omitempty"` // Empty = all channels.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchemasRequest) Reset() {
+	*x = ListSchemasRequest{}
+	mi := &file_event_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchemasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemasRequest) ProtoMessage() {}
+
+func (x *ListSchemasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemasRequest.ProtoReflect.Descriptor instead.
+func (*ListSchemasRequest) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *ListSchemasRequest) GetChannel() string {
+	if x != nil {
+		return x.Channel
+	}
+	return ""
+}
+
+type ListSchemasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Schemas       []*EventSchema         `protobuf:"bytes,1,rep,name=schemas,proto3" json:"schemas,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListSchemasResponse) Reset() {
+	*x = ListSchemasResponse{}
+	mi := &file_event_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListSchemasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListSchemasResponse) ProtoMessage() {}
+
+func (x *ListSchemasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_event_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListSchemasResponse.ProtoReflect.Descriptor instead.
+func (*ListSchemasResponse) Descriptor() ([]byte, []int) {
+	return file_event_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListSchemasResponse) GetSchemas() []*EventSchema {
+	if x != nil {
+		return x.Schemas
+	}
+	return nil
+}
+
+var File_event_proto protoreflect.FileDescriptor
+
+const file_event_proto_rawDesc = "" +
+	"\n" +
+	"\vevent.proto\x12\x05event\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x13globular_auth.proto\"\v\n" +
+	"\tKeepAlive\"\xce\x02\n" +
+	"\x05Event\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12\x1c\n" +
+	"\tpublisher\x18\x03 \x01(\tR\tpublisher\x12%\n" +
+	"\x0ecorrelation_id\x18\x04 \x01(\tR\rcorrelationId\x12!\n" +
+	"\fcontent_type\x18\x05 \x01(\tR\vcontentType\x12\x1b\n" +
+	"\tschema_id\x18\x06 \x01(\tR\bschemaId\x12'\n" +
+	"\x0fidempotency_key\x18\a \x01(\tR\x0eidempotencyKey\x123\n" +
+	"\aheaders\x18\b \x03(\v2\x19.event.Event.HeadersEntryR\aheaders\x1a:\n" +
+	"\fHeadersEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"!\n" +
+	"\vQuitRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"&\n" +
+	"\fQuitResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"$\n" +
+	"\x0eOnEventRequest\x12\x12\n" +
+	"\x04uuid\x18\x01 \x01(\tR\x04uuid\"_\n" +
+	"\x0fOnEventResponse\x12 \n" +
+	"\x03evt\x18\x01 \x01(\v2\f.event.EventH\x00R\x03evt\x12\"\n" +
+	"\x02ka\x18\x02 \x01(\v2\x10.event.KeepAliveH\x00R\x02kaB\x06\n" +
+	"\x04data\":\n" +
+	"\x10SubscribeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\"+\n" +
+	"\x11SubscribeResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"<\n" +
+	"\x12UnSubscribeRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04uuid\x18\x02 \x01(\tR\x04uuid\"-\n" +
+	"\x13UnSubscribeResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"0\n" +
+	"\x0ePublishRequest\x12\x1e\n" +
+	"\x03evt\x18\x01 \x01(\v2\f.event.EventR\x03evt\"G\n" +
+	"\x0fPublishResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\x12\x1c\n" +
+	"\tduplicate\x18\x02 \x01(\bR\tduplicate\"\r\n" +
+	"\vStopRequest\"\x0e\n" +
+	"\fStopResponse\"\xa0\x01\n" +
+	"\x0ePersistedEvent\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\fR\x04data\x12*\n" +
+	"\x02ts\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\x12\x1a\n" +
+	"\bsequence\x18\x04 \x01(\x04R\bsequence\x12\x1e\n" +
+	"\x03evt\x18\x05 \x01(\v2\f.event.EventR\x03evt\"r\n" +
+	"\x12QueryEventsRequest\x12\x1f\n" +
+	"\vname_filter\x18\x01 \x01(\tR\n" +
+	"nameFilter\x12\x14\n" +
+	"\x05limit\x18\x02 \x01(\rR\x05limit\x12%\n" +
+	"\x0eafter_sequence\x18\x03 \x01(\x04R\rafterSequence\"m\n" +
+	"\x13QueryEventsResponse\x12-\n" +
+	"\x06events\x18\x01 \x03(\v2\x15.event.PersistedEventR\x06events\x12'\n" +
+	"\x0flatest_sequence\x18\x02 \x01(\x04R\x0elatestSequence\"\xae\x01\n" +
+	"\rConsumerGroup\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x18\n" +
+	"\achannel\x18\x02 \x01(\tR\achannel\x12.\n" +
+	"\x13ack_timeout_seconds\x18\x03 \x01(\rR\x11ackTimeoutSeconds\x12%\n" +
+	"\x0emax_deliveries\x18\x04 \x01(\rR\rmaxDeliveries\x12\x18\n" +
+	"\apending\x18\x05 \x01(\x04R\apending\"H\n" +
+	"\x1aCreateConsumerGroupRequest\x12*\n" +
+	"\x05group\x18\x01 \x01(\v2\x14.event.ConsumerGroupR\x05group\"5\n" +
+	"\x1bCreateConsumerGroupResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"H\n" +
+	"\x1aDeleteConsumerGroupRequest\x12*\n" +
+	"\x04name\x18\x01 \x01(\tB\x16\x8a\xb5\x18\x12\n" +
+	"\x0econsumer_group\x10\x01R\x04name\"5\n" +
+	"\x1bDeleteConsumerGroupResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\x1b\n" +
+	"\x19ListConsumerGroupsRequest\"J\n" +
+	"\x1aListConsumerGroupsResponse\x12,\n" +
+	"\x06groups\x18\x01 \x03(\v2\x14.event.ConsumerGroupR\x06groups\"V\n" +
+	"\x0eConsumeRequest\x12,\n" +
+	"\x05group\x18\x01 \x01(\tB\x16\x8a\xb5\x18\x12\n" +
+	"\x0econsumer_group\x10\x01R\x05group\x12\x16\n" +
+	"\x06member\x18\x02 \x01(\tR\x06member\"\x91\x01\n" +
+	"\bDelivery\x12\x1f\n" +
+	"\vdelivery_id\x18\x01 \x01(\tR\n" +
+	"deliveryId\x12\x1e\n" +
+	"\x03evt\x18\x02 \x01(\v2\f.event.EventR\x03evt\x12*\n" +
+	"\x02ts\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\x02ts\x12\x18\n" +
+	"\aattempt\x18\x04 \x01(\rR\aattempt\"l\n" +
+	"\x0fConsumeResponse\x12-\n" +
+	"\bdelivery\x18\x01 \x01(\v2\x0f.event.DeliveryH\x00R\bdelivery\x12\"\n" +
+	"\x02ka\x18\x02 \x01(\v2\x10.event.KeepAliveH\x00R\x02kaB\x06\n" +
+	"\x04data\"[\n" +
+	"\n" +
+	"AckRequest\x12,\n" +
+	"\x05group\x18\x01 \x01(\tB\x16\x8a\xb5\x18\x12\n" +
+	"\x0econsumer_group\x10\x01R\x05group\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId\"%\n" +
+	"\vAckResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\x90\x01\n" +
+	"\vNackRequest\x12,\n" +
+	"\x05group\x18\x01 \x01(\tB\x16\x8a\xb5\x18\x12\n" +
+	"\x0econsumer_group\x10\x01R\x05group\x12\x1f\n" +
+	"\vdelivery_id\x18\x02 \x01(\tR\n" +
+	"deliveryId\x122\n" +
+	"\x15requeue_delay_seconds\x18\x03 \x01(\rR\x13requeueDelaySeconds\"&\n" +
+	"\fNackResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"d\n" +
+	"\vEventSchema\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\x12\x1e\n" +
+	"\n" +
+	"definition\x18\x03 \x01(\tR\n" +
+	"definition\"C\n" +
+	"\x15RegisterSchemaRequest\x12*\n" +
+	"\x06schema\x18\x01 \x01(\v2\x12.event.EventSchemaR\x06schema\"0\n" +
+	"\x16RegisterSchemaResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"L\n" +
+	"\x13DeleteSchemaRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\x12\x1b\n" +
+	"\tschema_id\x18\x02 \x01(\tR\bschemaId\".\n" +
+	"\x14DeleteSchemaResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\".\n" +
+	"\x12ListSchemasRequest\x12\x18\n" +
+	"\achannel\x18\x01 \x01(\tR\achannel\"C\n" +
+	"\x13ListSchemasResponse\x12,\n" +
+	"\aschemas\x18\x01 \x03(\v2\x12.event.EventSchemaR\aschemas2\xec\x0e\n" +
+	"\fEventService\x12W\n" +
+	"\x04Stop\x12\x12.event.StopRequest\x1a\x13.event.StopResponse\"&\x82\xb5\x18\"\n" +
+	"\n" +
+	"event.stop\x12\x05admin\x1a\x06/event*\x05admin\x12k\n" +
+	"\aOnEvent\x12\x15.event.OnEventRequest\x1a\x16.event.OnEventResponse\"/\x82\xb5\x18+\n" +
+	"\x13event.stream.listen\x12\x04read\x1a\x06/event*\x06viewer0\x01\x12_\n" +
+	"\x04Quit\x12\x12.event.QuitRequest\x1a\x13.event.QuitResponse\".\x82\xb5\x18*\n" +
+	"\x11event.stream.quit\x12\x05write\x1a\x06/event*\x06editor\x12l\n" +
+	"\tSubscribe\x12\x17.event.SubscribeRequest\x1a\x18.event.SubscribeResponse\",\x82\xb5\x18(\n" +
+	"\x0fevent.subscribe\x12\x05write\x1a\x06/event*\x06editor\x12t\n" +
+	"\vUnSubscribe\x12\x19.event.UnSubscribeRequest\x1a\x1a.event.UnSubscribeResponse\".\x82\xb5\x18*\n" +
+	"\x11event.unsubscribe\x12\x05write\x1a\x06/event*\x06editor\x12d\n" +
+	"\aPublish\x12\x15.event.PublishRequest\x1a\x16.event.PublishResponse\"*\x82\xb5\x18&\n" +
+	"\revent.publish\x12\x05write\x1a\x06/event*\x06editor\x12m\n" +
+	"\vQueryEvents\x12\x19.event.QueryEventsRequest\x1a\x1a.event.QueryEventsResponse\"'\x82\xb5\x18#\n" +
+	"\vevent.query\x12\x04read\x1a\x06/event*\x06viewer\x12\x8c\x01\n" +
+	"\x13CreateConsumerGroup\x12!.event.CreateConsumerGroupRequest\x1a\".event.CreateConsumerGroupResponse\".\x82\xb5\x18*\n" +
+	"\x12event.group.create\x12\x05admin\x1a\x06/event*\x05admin\x12\x9a\x01\n" +
+	"\x13DeleteConsumerGroup\x12!.event.DeleteConsumerGroupRequest\x1a\".event.DeleteConsumerGroupResponse\"<\x82\xb5\x188\n" +
+	"\x12event.group.delete\x12\x05admin\x1a\x14/event/groups/{name}*\x05admin\x12\x87\x01\n" +
+	"\x12ListConsumerGroups\x12 .event.ListConsumerGroupsRequest\x1a!.event.ListConsumerGroupsResponse\",\x82\xb5\x18(\n" +
+	"\x10event.group.list\x12\x04read\x1a\x06/event*\x06viewer\x12z\n" +
+	"\aConsume\x12\x15.event.ConsumeRequest\x1a\x16.event.ConsumeResponse\">\x82\xb5\x18:\n" +
+	"\x13event.group.consume\x12\x04read\x1a\x15/event/groups/{group}*\x06editor0\x01\x12i\n" +
+	"\x03Ack\x12\x11.event.AckRequest\x1a\x12.event.AckResponse\";\x82\xb5\x187\n" +
+	"\x0fevent.group.ack\x12\x05write\x1a\x15/event/groups/{group}*\x06editor\x12m\n" +
+	"\x04Nack\x12\x12.event.NackRequest\x1a\x13.event.NackResponse\"<\x82\xb5\x188\n" +
+	"\x10event.group.nack\x12\x05write\x1a\x15/event/groups/{group}*\x06editor\x12\x80\x01\n" +
+	"\x0eRegisterSchema\x12\x1c.event.RegisterSchemaRequest\x1a\x1d.event.RegisterSchemaResponse\"1\x82\xb5\x18-\n" +
+	"\x15event.schema.register\x12\x05admin\x1a\x06/event*\x05admin\x12x\n" +
+	"\fDeleteSchema\x12\x1a.event.DeleteSchemaRequest\x1a\x1b.event.DeleteSchemaResponse\"/\x82\xb5\x18+\n" +
+	"\x13event.schema.delete\x12\x05admin\x1a\x06/event*\x05admin\x12s\n" +
+	"\vListSchemas\x12\x19.event.ListSchemasRequest\x1a\x1a.event.ListSchemasResponse\"-\x82\xb5\x18)\n" +
+	"\x11event.schema.list\x12\x04read\x1a\x06/event*\x06viewerB5Z3github.com/globulario/services/golang/event/eventpbb\x06proto3"
+
+var (
+	file_event_proto_rawDescOnce sync.Once
+	file_event_proto_rawDescData []byte
+)
+
+func file_event_proto_rawDescGZIP() []byte {
+	file_event_proto_rawDescOnce.Do(func() {
+		file_event_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)))
+	})
+	return file_event_proto_rawDescData
+}
+
+var file_event_proto_msgTypes = make([]protoimpl.MessageInfo, 39)
+var file_event_proto_goTypes = []any{
+	(*KeepAlive)(nil),                   // 0: event.KeepAlive
+	(*Event)(nil),                       // 1: event.Event
+	(*QuitRequest)(nil),                 // 2: event.QuitRequest
+	(*QuitResponse)(nil),                // 3: event.QuitResponse
+	(*OnEventRequest)(nil),              // 4: event.OnEventRequest
+	(*OnEventResponse)(nil),             // 5: event.OnEventResponse
+	(*SubscribeRequest)(nil),            // 6: event.SubscribeRequest
+	(*SubscribeResponse)(nil),           // 7: event.SubscribeResponse
+	(*UnSubscribeRequest)(nil),          // 8: event.UnSubscribeRequest
+	(*UnSubscribeResponse)(nil),         // 9: event.UnSubscribeResponse
+	(*PublishRequest)(nil),              // 10: event.PublishRequest
+	(*PublishResponse)(nil),             // 11: event.PublishResponse
+	(*StopRequest)(nil),                 // 12: event.StopRequest
+	(*StopResponse)(nil),                // 13: event.StopResponse
+	(*PersistedEvent)(nil),              // 14: event.PersistedEvent
+	(*QueryEventsRequest)(nil),          // 15: event.QueryEventsRequest
+	(*QueryEventsResponse)(nil),         // 16: event.QueryEventsResponse
+	(*ConsumerGroup)(nil),               // 17: event.ConsumerGroup
+	(*CreateConsumerGroupRequest)(nil),  // 18: event.CreateConsumerGroupRequest
+	(*CreateConsumerGroupResponse)(nil), // 19: event.CreateConsumerGroupResponse
+	(*DeleteConsumerGroupRequest)(nil),  // 20: event.DeleteConsumerGroupRequest
+	(*DeleteConsumerGroupResponse)(nil), // 21: event.DeleteConsumerGroupResponse
+	(*ListConsumerGroupsRequest)(nil),   // 22: event.ListConsumerGroupsRequest
+	(*ListConsumerGroupsResponse)(nil),  // 23: event.ListConsumerGroupsResponse
+	(*ConsumeRequest)(nil),              // 24: event.ConsumeRequest
+	(*Delivery)(nil),                    // 25: event.Delivery
+	(*ConsumeResponse)(nil),             // 26: event.ConsumeResponse
+	(*AckRequest)(nil),                  // 27: event.AckRequest
+	(*AckResponse)(nil),                 // 28: event.AckResponse
+	(*NackRequest)(nil),                 // 29: event.NackRequest
+	(*NackResponse)(nil),                // 30: event.NackResponse
+	(*EventSchema)(nil),                 // 31: event.EventSchema
+	(*RegisterSchemaRequest)(nil),       // 32: event.RegisterSchemaRequest
+	(*RegisterSchemaResponse)(nil),      // 33: event.RegisterSchemaResponse
+	(*DeleteSchemaRequest)(nil),         // 34: event.DeleteSchemaRequest
+	(*DeleteSchemaResponse)(nil),        // 35: event.DeleteSchemaResponse
+	(*ListSchemasRequest)(nil),          // 36: event.ListSchemasRequest
+	(*ListSchemasResponse)(nil),         // 37: event.ListSchemasResponse
+	nil,                                 // 38: event.Event.HeadersEntry
+	(*timestamppb.Timestamp)(nil),       // 39: google.protobuf.Timestamp
+}
+var file_event_proto_depIdxs = []int32{
+	38, // 0: event.Event.headers:type_name -> event.Event.HeadersEntry
+	1,  // 1: event.OnEventResponse.evt:type_name -> event.Event
+	0,  // 2: event.OnEventResponse.ka:type_name -> event.KeepAlive
+	1,  // 3: event.PublishRequest.evt:type_name -> event.Event
+	39, // 4: event.PersistedEvent.ts:type_name -> google.protobuf.Timestamp
+	1,  // 5: event.PersistedEvent.evt:type_name -> event.Event
+	14, // 6: event.QueryEventsResponse.events:type_name -> event.PersistedEvent
+	17, // 7: event.CreateConsumerGroupRequest.group:type_name -> event.ConsumerGroup
+	17, // 8: event.ListConsumerGroupsResponse.groups:type_name -> event.ConsumerGroup
+	1,  // 9: event.Delivery.evt:type_name -> event.Event
+	39, // 10: event.Delivery.ts:type_name -> google.protobuf.Timestamp
+	25, // 11: event.ConsumeResponse.delivery:type_name -> event.Delivery
+	0,  // 12: event.ConsumeResponse.ka:type_name -> event.KeepAlive
+	31, // 13: event.RegisterSchemaRequest.schema:type_name -> event.EventSchema
+	31, // 14: event.ListSchemasResponse.schemas:type_name -> event.EventSchema
+	12, // 15: event.EventService.Stop:input_type -> event.StopRequest
+	4,  // 16: event.EventService.OnEvent:input_type -> event.OnEventRequest
+	2,  // 17: event.EventService.Quit:input_type -> event.QuitRequest
+	6,  // 18: event.EventService.Subscribe:input_type -> event.SubscribeRequest
+	8,  // 19: event.EventService.UnSubscribe:input_type -> event.UnSubscribeRequest
+	10, // 20: event.EventService.Publish:input_type -> event.PublishRequest
+	15, // 21: event.EventService.QueryEvents:input_type -> event.QueryEventsRequest
+	18, // 22: event.EventService.CreateConsumerGroup:input_type -> event.CreateConsumerGroupRequest
+	20, // 23: event.EventService.DeleteConsumerGroup:input_type -> event.DeleteConsumerGroupRequest
+	22, // 24: event.EventService.ListConsumerGroups:input_type -> event.ListConsumerGroupsRequest
+	24, // 25: event.EventService.Consume:input_type -> event.ConsumeRequest
+	27, // 26: event.EventService.Ack:input_type -> event.AckRequest
+	29, // 27: event.EventService.Nack:input_type -> event.NackRequest
+	32, // 28: event.EventService.RegisterSchema:input_type -> event.RegisterSchemaRequest
+	34, // 29: event.EventService.DeleteSchema:input_type -> event.DeleteSchemaRequest
+	36, // 30: event.EventService.ListSchemas:input_type -> event.ListSchemasRequest
+	13, // 31: event.EventService.Stop:output_type -> event.StopResponse
+	5,  // 32: event.EventService.OnEvent:output_type -> event.OnEventResponse
+	3,  // 33: event.EventService.Quit:output_type -> event.QuitResponse
+	7,  // 34: event.EventService.Subscribe:output_type -> event.SubscribeResponse
+	9,  // 35: event.EventService.UnSubscribe:output_type -> event.UnSubscribeResponse
+	11, // 36: event.EventService.Publish:output_type -> event.PublishResponse
+	16, // 37: event.EventService.QueryEvents:output_type -> event.QueryEventsResponse
+	19, // 38: event.EventService.CreateConsumerGroup:output_type -> event.CreateConsumerGroupResponse
+	21, // 39: event.EventService.DeleteConsumerGroup:output_type -> event.DeleteConsumerGroupResponse
+	23, // 40: event.EventService.ListConsumerGroups:output_type -> event.ListConsumerGroupsResponse
+	26, // 41: event.EventService.Consume:output_type -> event.ConsumeResponse
+	28, // 42: event.EventService.Ack:output_type -> event.AckResponse
+	30, // 43: event.EventService.Nack:output_type -> event.NackResponse
+	33, // 44: event.EventService.RegisterSchema:output_type -> event.RegisterSchemaResponse
+	35, // 45: event.EventService.DeleteSchema:output_type -> event.DeleteSchemaResponse
+	37, // 46: event.EventService.ListSchemas:output_type -> event.ListSchemasResponse
+	31, // [31:47] is the sub-list for method output_type
+	15, // [15:31] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
+}
+
+func init() { file_event_proto_init() }
+func file_event_proto_init() {
+	if File_event_proto != nil {
+		return
+	}
+	file_event_proto_msgTypes[5].OneofWrappers = []any{
+		(*OnEventResponse_Evt)(nil),
+		(*OnEventResponse_Ka)(nil),
+	}
+	file_event_proto_msgTypes[26].OneofWrappers = []any{
+		(*ConsumeResponse_Delivery)(nil),
+		(*ConsumeResponse_Ka)(nil),
 	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
@@ -1011,7 +2293,7 @@ func file_event_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_event_proto_rawDesc), len(file_event_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   17,
+			NumMessages:   39,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
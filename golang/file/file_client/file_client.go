@@ -1,6 +1,8 @@
 package file_client
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"io"
 	"os"
 	"time"
@@ -301,10 +303,19 @@ func (client *File_Client) ReadFile(token string, path interface{}) ([]byte, err
 		ctx = metadata.NewOutgoingContext(context.Background(), md)
 	}
 
-	rqst := &filepb.ReadFileRequest{
+	return client.readFile(ctx, &filepb.ReadFileRequest{
 		Path: Utility.ToString(path),
-	}
+	})
+}
+
+/**
+ * Read length bytes of a file from offset; a length of 0 reads to the end.
+ */
+func (client *File_Client) ReadFileRange(token, path string, offset, length int64) ([]byte, error) {
+	return client.readFile(client.tokenCtx(token), &filepb.ReadFileRequest{Path: path, Offset: offset, Length: length})
+}
 
+func (client *File_Client) readFile(ctx context.Context, rqst *filepb.ReadFileRequest) ([]byte, error) {
 	stream, err := client.c.ReadFile(ctx, rqst)
 	if err != nil {
 		return nil, err
@@ -584,3 +595,211 @@ func (client *File_Client) GetPublicDirs() ([]string, error) {
 	}
 	return rsp.Dirs, nil
 }
+
+// tokenCtx returns the client context carrying token, when given.
+func (client *File_Client) tokenCtx(token string) context.Context {
+	ctx := client.GetCtx()
+	if len(token) > 0 {
+		md, _ := metadata.FromOutgoingContext(ctx)
+
+		if len(md.Get("token")) != 0 {
+			md.Set("token", token)
+		}
+		ctx = metadata.NewOutgoingContext(context.Background(), md)
+	}
+	return ctx
+}
+
+/**
+ * Return the previous contents kept for a file, newest first.
+ */
+func (client *File_Client) ListFileVersions(token, path string) ([]*filepb.FileVersion, error) {
+	rsp, err := client.c.ListFileVersions(client.tokenCtx(token), &filepb.ListFileVersionsRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Versions, nil
+}
+
+/**
+ * Put a previous content of a file back. Return the version the replaced
+ * content became, nil if it was not kept.
+ */
+func (client *File_Client) RestoreFileVersion(token, path, versionId string) (*filepb.FileVersion, error) {
+	rsp, err := client.c.RestoreFileVersion(client.tokenCtx(token), &filepb.RestoreFileVersionRequest{Path: path, VersionId: versionId})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Previous, nil
+}
+
+/**
+ * Return the deleted items of the home of path (e.g. /users/alice).
+ */
+func (client *File_Client) ListTrash(token, path string) ([]*filepb.TrashItem, error) {
+	rsp, err := client.c.ListTrash(client.tokenCtx(token), &filepb.ListTrashRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Items, nil
+}
+
+/**
+ * Put a deleted item back, at destination when given, otherwise where it was
+ * deleted from. Return the restored path.
+ */
+func (client *File_Client) RestoreFromTrash(token, path, id, destination string) (string, error) {
+	rsp, err := client.c.RestoreFromTrash(client.tokenCtx(token), &filepb.RestoreFromTrashRequest{Path: path, Id: id, Destination: destination})
+	if err != nil {
+		return "", err
+	}
+	return rsp.Path, nil
+}
+
+/**
+ * Purge deleted items of the home of path, all of them when no id is given.
+ * Return the number of items and bytes removed.
+ */
+func (client *File_Client) EmptyTrash(token, path string, ids ...string) (int32, int64, error) {
+	rsp, err := client.c.EmptyTrash(client.tokenCtx(token), &filepb.EmptyTrashRequest{Path: path, Ids: ids})
+	if err != nil {
+		return 0, 0, err
+	}
+	return rsp.Removed, rsp.Freed, nil
+}
+
+/**
+ * Start a resumable upload to path. size and sha256 (hex) are optional;
+ * when given they are checked on commit. Return the upload id.
+ */
+func (client *File_Client) BeginUpload(token, path string, size int64, checksum string) (string, error) {
+	rsp, err := client.c.BeginUpload(client.tokenCtx(token), &filepb.BeginUploadRequest{Path: path, Size: size, Sha256: checksum})
+	if err != nil {
+		return "", err
+	}
+	return rsp.UploadId, nil
+}
+
+/**
+ * Append data at offset to an upload. Return the committed offset.
+ */
+func (client *File_Client) UploadChunk(token, uploadId string, offset int64, data []byte) (int64, error) {
+	rsp, err := client.c.UploadChunk(client.tokenCtx(token), &filepb.UploadChunkRequest{UploadId: uploadId, Offset: offset, Data: data})
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Offset, nil
+}
+
+/**
+ * Return the committed offset of an upload, where to resume from.
+ */
+func (client *File_Client) GetUploadStatus(token, uploadId string) (int64, error) {
+	rsp, err := client.c.GetUploadStatus(client.tokenCtx(token), &filepb.GetUploadStatusRequest{UploadId: uploadId})
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Offset, nil
+}
+
+/**
+ * Check the SHA-256 (hex) of an upload and write the file.
+ */
+func (client *File_Client) CommitUpload(token, uploadId, checksum string) error {
+	_, err := client.c.CommitUpload(client.tokenCtx(token), &filepb.CommitUploadRequest{UploadId: uploadId, Sha256: checksum})
+	return err
+}
+
+/**
+ * Drop an upload and its staged data.
+ */
+func (client *File_Client) AbortUpload(token, uploadId string) error {
+	_, err := client.c.AbortUpload(client.tokenCtx(token), &filepb.AbortUploadRequest{UploadId: uploadId})
+	return err
+}
+
+/**
+ * Upload a local file to dest in chunks of chunkSize bytes (1 MB when 0).
+ * A failed chunk is retried from the committed offset, up to 3 times in a row.
+ */
+func (client *File_Client) UploadLocalFile(token, localPath, dest string, chunkSize int) error {
+	if chunkSize <= 0 {
+		chunkSize = 1 << 20
+	}
+	f, err := os.Open(localPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	h := sha256.New()
+	size, err := io.Copy(h, f)
+	if err != nil {
+		return err
+	}
+	sum := hex.EncodeToString(h.Sum(nil))
+
+	id, err := client.BeginUpload(token, dest, size, sum)
+	if err != nil {
+		return err
+	}
+	buf := make([]byte, chunkSize)
+	offset, failures := int64(0), 0
+	for offset < size {
+		n, err := f.ReadAt(buf, offset)
+		if err != nil && err != io.EOF {
+			return err
+		}
+		next, err := client.UploadChunk(token, id, offset, buf[:n])
+		if err != nil {
+			if failures++; failures > 3 {
+				return err
+			}
+			if next, err = client.GetUploadStatus(token, id); err != nil {
+				return err
+			}
+		} else {
+			failures = 0
+		}
+		offset = next
+	}
+	return client.CommitUpload(token, id, sum)
+}
+
+/**
+ * Create an expiring share link to a file or directory. permissions defaults
+ * to read (and list for a directory), expiresAt (unix seconds) to 7 days,
+ * password and maxDownloads to none. Return the link, its token and its
+ * gateway URL; the token can't be read back later.
+ */
+func (client *File_Client) CreateShareLink(token, path string, permissions []string, expiresAt int64, password string, maxDownloads int64) (*filepb.ShareLink, string, string, error) {
+	rsp, err := client.c.CreateShareLink(client.tokenCtx(token), &filepb.CreateShareLinkRequest{
+		Path:         path,
+		Permissions:  permissions,
+		ExpiresAt:    expiresAt,
+		Password:     password,
+		MaxDownloads: maxDownloads,
+	})
+	if err != nil {
+		return nil, "", "", err
+	}
+	return rsp.Link, rsp.Token, rsp.Url, nil
+}
+
+/**
+ * Return the share links of path and its children, newest first.
+ */
+func (client *File_Client) ListShareLinks(token, path string) ([]*filepb.ShareLink, error) {
+	rsp, err := client.c.ListShareLinks(client.tokenCtx(token), &filepb.ListShareLinksRequest{Path: path})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Links, nil
+}
+
+/**
+ * Revoke a share link of path or its children.
+ */
+func (client *File_Client) RevokeShareLink(token, path, id string) error {
+	_, err := client.c.RevokeShareLink(client.tokenCtx(token), &filepb.RevokeShareLinkRequest{Path: path, Id: id})
+	return err
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/globulario/services/golang/config"
 	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/rbac/rbacpb"
 	"github.com/globulario/services/golang/security"
 	"github.com/globulario/services/golang/title/title_client"
 	"github.com/globulario/services/golang/title/titlepb"
@@ -766,8 +767,9 @@ func (srv *server) Copy(ctx context.Context, rqst *filepb.CopyRequest) (*filepb.
 						}
 					}
 				} else {
-					// Copy the file
+					// Copy the file, keeping a file it replaces as a version.
 					destFile := filepath.Join(destPath, filepath.Base(srcFile))
+					srv.versionBeforeWrite(ctx, token, destFile)
 					if err := srv.storageCopyFile(ctx, srcFile, destFile); err != nil {
 						slog.Error("copy file failed", "src", srcFile, "dest", destPath, "err", err)
 						return nil, err
@@ -906,7 +908,7 @@ func (srv *server) CreateDir(ctx context.Context, rqst *filepb.CreateDirRequest)
 }
 
 func (srv *server) DeleteDir(ctx context.Context, rqst *filepb.DeleteDirRequest) (*filepb.DeleteDirResponse, error) {
-	_, token, err := security.GetClientId(ctx)
+	clientId, token, err := security.GetClientId(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -952,9 +954,16 @@ func (srv *server) DeleteDir(ctx context.Context, rqst *filepb.DeleteDirRequest)
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
+	// Permissions and owners go with the directory to the trash.
+	var kept []*rbacpb.Permissions
+	owners := srv.retentionOwners(rbacClient, path)
+
 	// Recursively remove all sub-dir and file permissions
 	if permissions, err := rbacClient.GetResourcePermissionsByResourceType("file"); err == nil {
 		for _, p := range permissions {
+			if p.Path == path || strings.HasPrefix(p.Path, path+"/") {
+				kept = append(kept, p)
+			}
 			if strings.HasPrefix(p.Path, path) {
 				if err := rbacClient.DeleteResourcePermissions(token, p.GetPath()); err != nil {
 					slog.Warn("delete sub-permission failed", "path", p.GetPath(), "err", err)
@@ -968,10 +977,16 @@ func (srv *server) DeleteDir(ctx context.Context, rqst *filepb.DeleteDirRequest)
 		slog.Warn("delete dir permission failed", "path", path, "err", err)
 	}
 
-	// Remove the directory itself
-	if err := srv.storageRemoveAll(ctx, path); err != nil {
+	// Remove the directory itself, or keep it in the trash.
+	trashed, err := srv.trashBeforeDelete(ctx, clientId, token, path, owners, kept)
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
+	if !trashed {
+		if err := srv.storageRemoveAll(ctx, path); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
 
 	slog.Info("dir deleted", "path", path)
 	return &filepb.DeleteDirResponse{Result: true}, nil
@@ -1033,8 +1048,11 @@ func (srv *server) Move(ctx context.Context, rqst *filepb.MoveRequest) (*filepb.
 				}
 			}
 
-			// Move the file/dir
+			// Move the file/dir, keeping a file it replaces as a version.
 			target := filepath.Join(dest, filepath.Base(from))
+			if info != nil && !info.IsDir() {
+				srv.versionBeforeWrite(ctx, token, target)
+			}
 			if err := srv.storageMove(ctx, from, target); err != nil {
 				slog.Error("move failed", "from", from, "dest", dest, "err", err)
 				continue
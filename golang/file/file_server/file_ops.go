@@ -19,6 +19,7 @@ import (
 	"github.com/globulario/services/golang/file/file_client"
 	"github.com/globulario/services/golang/file/filepb"
 	"github.com/globulario/services/golang/globular_client"
+	"github.com/globulario/services/golang/rbac/rbacpb"
 	"github.com/globulario/services/golang/security"
 	"github.com/globulario/services/golang/title/titlepb"
 	Utility "github.com/globulario/utility"
@@ -155,8 +156,11 @@ func (srv *server) ReadFile(rqst *filepb.ReadFileRequest, stream filepb.FileServ
 
 	// Check if this path belongs to a remote node's public dir.
 	nodeID, _ := config.GetMacAddress()
+	if rqst.GetOffset() < 0 || rqst.GetLength() < 0 {
+		return status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("invalid range offset %d length %d", rqst.GetOffset(), rqst.GetLength())))
+	}
 	if remote := srv.clusterDirs.findRemoteDir(srv.formatPath(p), nodeID); remote != nil {
-		return srv.proxyReadFile(p, remote.NodeAddress, stream)
+		return srv.proxyReadFile(p, rqst.GetOffset(), rqst.GetLength(), remote.NodeAddress, stream)
 	}
 
 	f, err := srv.storageOpen(stream.Context(), p)
@@ -165,9 +169,28 @@ func (srv *server) ReadFile(rqst *filepb.ReadFileRequest, stream filepb.FileServ
 	}
 
 	defer f.Close()
+
+	// Byte range: seek to the offset and stop after length bytes.
+	var r io.Reader = f
+	if offset := rqst.GetOffset(); offset > 0 {
+		size, err := f.Seek(0, io.SeekEnd)
+		if err != nil {
+			return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		if offset > size {
+			return status.Errorf(codes.OutOfRange, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("offset %d is past the end of %s (%d bytes)", offset, p, size)))
+		}
+		if _, err := f.Seek(offset, io.SeekStart); err != nil {
+			return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
+	if length := rqst.GetLength(); length > 0 {
+		r = io.LimitReader(f, length)
+	}
+
 	buf := make([]byte, 5*1024)
 	for {
-		n, err := f.Read(buf)
+		n, err := r.Read(buf)
 		if n > 0 {
 			_ = stream.Send(&filepb.ReadFileResponse{Data: buf[:n]})
 		}
@@ -199,6 +222,9 @@ func (srv *server) SaveFile(stream filepb.FileService_SaveFileServer) error {
 					return status.Errorf(codes.Internal, "%s",
 						Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 				}
+				// Keep the content being overwritten as a version.
+				_, token, _ := security.GetClientId(ctx)
+				srv.versionBeforeWrite(ctx, token, path)
 				if err := srv.storageWriteFile(ctx, path, data, 0o644); err != nil {
 					return status.Errorf(codes.Internal, "%s",
 						Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
@@ -227,7 +253,7 @@ func (srv *server) SaveFile(stream filepb.FileService_SaveFileServer) error {
 
 // DeleteFile removes a single file and updates related state.
 func (srv *server) DeleteFile(ctx context.Context, rqst *filepb.DeleteFileRequest) (*filepb.DeleteFileResponse, error) {
-	_, token, err := security.GetClientId(ctx)
+	clientId, token, err := security.GetClientId(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -238,12 +264,22 @@ func (srv *server) DeleteFile(ctx context.Context, rqst *filepb.DeleteFileReques
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
+
+	// Permissions and owners go with the file to the trash.
+	var perms []*rbacpb.Permissions
+	if perm, err := rbac.GetResourcePermissions(p); err == nil && perm != nil {
+		perms = append(perms, perm)
+	}
+	owners := srv.retentionOwners(rbac, p)
 	_ = rbac.DeleteResourcePermissions(token, rqst.GetPath())
 
 	dir := filepath.Dir(p)
 	name := strings.TrimSuffix(filepath.Base(p), filepath.Ext(p))
 	hidden := filepath.Join(dir, ".hidden", name)
-	if srv.storageForPath(hidden).Exists(ctx, hidden) {
+	// An empty name would be the whole .hidden folder, which may hold the
+	// versions and trash of the home.
+	retained := name == "" || name == trashFolder || name == versionsFolder
+	if !retained && srv.storageForPath(hidden).Exists(ctx, hidden) {
 		_ = srv.storageRemoveAll(ctx, hidden)
 	}
 	_ = dissociateFileWithTitle(rqst.GetPath(), srv.Domain)
@@ -258,9 +294,15 @@ func (srv *server) DeleteFile(ctx context.Context, rqst *filepb.DeleteFileReques
 		_ = srv.storageRemove(ctx, filepath.Join(dir, "video.m3u"))
 		_ = srv.generatePlaylist(dir, token)
 	}
-	if err := srv.storageRemove(ctx, p); err != nil {
+	trashed, err := srv.trashBeforeDelete(ctx, clientId, token, p, owners, perms)
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
+	if !trashed {
+		if err := srv.storageRemove(ctx, p); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+	}
 	return &filepb.DeleteFileResponse{Result: true}, nil
 }
 
@@ -348,6 +390,8 @@ func (srv *server) CreateLnk(ctx context.Context, rqst *filepb.CreateLnkRequest)
 func (srv *server) WriteExcelFile(ctx context.Context, rqst *filepb.WriteExcelFileRequest) (*filepb.WriteExcelFileResponse, error) {
 	p := rqst.Path
 	if srv.storageForPath(p).Exists(ctx, p) {
+		_, token, _ := security.GetClientId(ctx)
+		srv.versionBeforeWrite(ctx, token, p)
 		if err := srv.storageRemove(ctx, p); err != nil {
 			return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 		}
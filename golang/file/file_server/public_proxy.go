@@ -76,14 +76,14 @@ func (srv *server) startClusterDirWatcher(ctx context.Context) {
 }
 
 // proxyReadFile proxies a ReadFile call to a remote node's file service.
-func (srv *server) proxyReadFile(path, nodeAddress string, stream filepb.FileService_ReadFileServer) error {
+func (srv *server) proxyReadFile(path string, offset, length int64, nodeAddress string, stream filepb.FileService_ReadFileServer) error {
 	client, err := srv.GetFileClient(nodeAddress)
 	if err != nil {
 		return fmt.Errorf("connect to remote node %s: %w", nodeAddress, err)
 	}
 
 	token, _ := security.GetLocalToken(srv.Mac)
-	data, err := client.ReadFile(token, path)
+	data, err := client.ReadFileRange(token, path, offset, length)
 	if err != nil {
 		return fmt.Errorf("remote ReadFile %s via %s: %w", path, nodeAddress, err)
 	}
@@ -0,0 +1,600 @@
+// --- retention.go ---
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/rbac/rbac_client"
+	"github.com/globulario/services/golang/rbac/rbacpb"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// -----------------------------------------------------------------------------
+// Versions and trash
+//
+// Overwritten contents and deleted items are kept under the .hidden folder of
+// the path's home instead of being destroyed:
+//
+//	<home>/.hidden/.versions/<path relative to home>/<version id>
+//	<home>/.hidden/.trash/<item id>/<base name>
+//	<home>/.hidden/.trash/<item id>.json
+//
+// The home is /users/<id> for user paths, the registered public directory for
+// public paths and the first path segment otherwise, so retained data stays on
+// the backend (OS or MinIO) of the original. Retained files get the owners of
+// the original as RBAC owners, which counts their size against the owners'
+// allocated space until they are purged.
+// -----------------------------------------------------------------------------
+
+const (
+	versionsFolder = ".versions"
+	trashFolder    = ".trash"
+
+	// retentionSweepInterval is how often expired trash items are purged.
+	retentionSweepInterval = time.Hour
+)
+
+var (
+	errNoVersion   = errors.New("no such version")
+	errNoTrashItem = errors.New("no such trash item")
+)
+
+// trashItem is the metadata saved next to a deleted item.
+type trashItem struct {
+	ID           string            `json:"id"`
+	OriginalPath string            `json:"original_path"`
+	Name         string            `json:"name"`
+	IsDir        bool              `json:"is_dir"`
+	Size         int64             `json:"size"`
+	DeletedAt    int64             `json:"deleted_at"`
+	DeletedBy    string            `json:"deleted_by"`
+	ExpiresAt    int64             `json:"expires_at"`
+	Permissions  []json.RawMessage `json:"permissions,omitempty"` // RBAC permissions of the item and its content
+}
+
+func (item *trashItem) toProto() *filepb.TrashItem {
+	return &filepb.TrashItem{
+		Id:           item.ID,
+		OriginalPath: item.OriginalPath,
+		Name:         item.Name,
+		IsDir:        item.IsDir,
+		Size:         item.Size,
+		DeletedAt:    item.DeletedAt,
+		DeletedBy:    item.DeletedBy,
+		ExpiresAt:    item.ExpiresAt,
+	}
+}
+
+// retentionHome returns the directory whose .hidden folder keeps the versions
+// and trash of path, or "" when path is not retained: a home itself, the root
+// or anything inside a .hidden folder (thumbnails, previews, retained data).
+func (srv *server) retentionHome(path string) string {
+	path = srv.formatPath(path)
+	if home := srv.homeOf(path); home != path {
+		return home
+	}
+	return ""
+}
+
+// homeOf returns the home path belongs to, which may be path itself.
+func (srv *server) homeOf(path string) string {
+	path = srv.formatPath(path)
+	if path == "" || path == "/" || strings.Contains(path+"/", "/.hidden/") {
+		return ""
+	}
+	home := ""
+	for _, root := range srv.Public {
+		root = srv.formatPath(root)
+		if root != "" && (path == root || strings.HasPrefix(path, root+"/")) && len(root) > len(home) {
+			home = root
+		}
+	}
+	if home != "" {
+		return home
+	}
+	parts := strings.SplitN(strings.TrimPrefix(path, "/"), "/", 3)
+	if parts[0] != "users" {
+		return "/" + parts[0]
+	}
+	if len(parts) < 2 {
+		return ""
+	}
+	return "/users/" + parts[1]
+}
+
+func (srv *server) versionsDir(path string) string {
+	path = srv.formatPath(path)
+	home := srv.retentionHome(path)
+	return filepath.ToSlash(filepath.Join(home, ".hidden", versionsFolder, strings.TrimPrefix(path, home)))
+}
+
+func trashDir(home string) string {
+	return filepath.ToSlash(filepath.Join(home, ".hidden", trashFolder))
+}
+
+// keepVersion copies the current content of the file at path to a new
+// version. It returns nil when there is nothing to keep.
+func (srv *server) keepVersion(ctx context.Context, path string) (*filepb.FileVersion, error) {
+	path = srv.formatPath(path)
+	if srv.retentionHome(path) == "" {
+		return nil, nil
+	}
+	info, err := srv.storageStat(ctx, path)
+	if err != nil || info.IsDir() {
+		return nil, nil
+	}
+	dir := srv.versionsDir(path)
+	now := time.Now()
+	id := fmt.Sprintf("%020d", now.UnixNano())
+	for srv.pathExists(ctx, dir+"/"+id) {
+		now = now.Add(time.Nanosecond)
+		id = fmt.Sprintf("%020d", now.UnixNano())
+	}
+	if err := srv.storageCopyFile(ctx, path, dir+"/"+id); err != nil {
+		return nil, fmt.Errorf("keep version of %s: %w", path, err)
+	}
+	return &filepb.FileVersion{Id: id, Path: path, Size: info.Size(), Created: now.Unix()}, nil
+}
+
+// listVersions returns the versions of the file at path, newest first.
+func (srv *server) listVersions(ctx context.Context, path string) ([]*filepb.FileVersion, error) {
+	path = srv.formatPath(path)
+	if srv.retentionHome(path) == "" {
+		return nil, nil
+	}
+	dir := srv.versionsDir(path)
+	if !srv.pathExists(ctx, dir) {
+		return nil, nil
+	}
+	entries, err := srv.storageReadDir(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]*filepb.FileVersion, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() {
+			continue // versions of files below a path that was once a file
+		}
+		nanos, err := strconv.ParseInt(e.Name(), 10, 64)
+		if err != nil {
+			continue
+		}
+		v := &filepb.FileVersion{Id: e.Name(), Path: path, Created: time.Unix(0, nanos).Unix()}
+		if info, err := e.Info(); err == nil {
+			v.Size = info.Size()
+		}
+		versions = append(versions, v)
+	}
+	sort.Slice(versions, func(i, j int) bool { return versions[i].Id > versions[j].Id })
+	return versions, nil
+}
+
+// versionPath returns where version id of path is stored.
+func (srv *server) versionPath(ctx context.Context, path, id string) (string, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." || srv.retentionHome(path) == "" {
+		return "", errNoVersion
+	}
+	p := srv.versionsDir(path) + "/" + id
+	if info, err := srv.storageStat(ctx, p); err != nil || info.IsDir() {
+		return "", errNoVersion
+	}
+	return p, nil
+}
+
+// staleVersions returns the stored versions of path beyond the keep newest.
+func (srv *server) staleVersions(ctx context.Context, path string, keep int) []string {
+	versions, err := srv.listVersions(ctx, path)
+	if err != nil || len(versions) <= keep {
+		return nil
+	}
+	if keep < 0 {
+		keep = 0
+	}
+	dir := srv.versionsDir(path)
+	stale := make([]string, 0, len(versions)-keep)
+	for _, v := range versions[keep:] {
+		stale = append(stale, dir+"/"+v.Id)
+	}
+	return stale
+}
+
+// moveToTrash moves the file or directory at path to the trash of its home
+// and saves item, completed with the id, name, size and dates. It returns
+// false when path is not retained.
+func (srv *server) moveToTrash(ctx context.Context, path string, item *trashItem) (bool, error) {
+	path = srv.formatPath(path)
+	home := srv.retentionHome(path)
+	if home == "" {
+		return false, nil
+	}
+	info, err := srv.storageStat(ctx, path)
+	if err != nil {
+		return false, err
+	}
+	now := time.Now()
+	item.ID = fmt.Sprintf("%d-%s", now.UnixNano(), Utility.RandomUUID()[:8])
+	item.OriginalPath = path
+	item.Name = filepath.Base(path)
+	item.IsDir = info.IsDir()
+	item.Size = info.Size()
+	if item.IsDir {
+		item.Size = 0
+		for _, f := range srv.retainedFiles(ctx, path) {
+			if fi, err := srv.storageStat(ctx, f); err == nil {
+				item.Size += fi.Size()
+			}
+		}
+	}
+	item.DeletedAt = now.Unix()
+	if srv.TrashRetentionDays > 0 {
+		item.ExpiresAt = now.Add(time.Duration(srv.TrashRetentionDays) * 24 * time.Hour).Unix()
+	}
+
+	dir := trashDir(home)
+	if err := srv.storageMove(ctx, path, dir+"/"+item.ID+"/"+item.Name); err != nil {
+		return false, fmt.Errorf("move %s to trash: %w", path, err)
+	}
+	if err := srv.saveTrashItem(ctx, home, item); err != nil {
+		return true, err
+	}
+	return true, nil
+}
+
+func (srv *server) saveTrashItem(ctx context.Context, home string, item *trashItem) error {
+	data, err := json.Marshal(item)
+	if err != nil {
+		return err
+	}
+	return srv.storageWriteFile(ctx, trashDir(home)+"/"+item.ID+".json", data, 0o644)
+}
+
+// trashedPath returns where the data of item is kept.
+func trashedPath(home string, item *trashItem) string {
+	return trashDir(home) + "/" + item.ID + "/" + item.Name
+}
+
+// listTrash returns the items in the trash of home, newest first.
+func (srv *server) listTrash(ctx context.Context, home string) ([]*trashItem, error) {
+	dir := trashDir(home)
+	if !srv.pathExists(ctx, dir) {
+		return nil, nil
+	}
+	entries, err := srv.storageReadDir(ctx, dir)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]*trashItem, 0, len(entries))
+	for _, e := range entries {
+		if e.IsDir() || !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		item, err := srv.getTrashItem(ctx, home, strings.TrimSuffix(e.Name(), ".json"))
+		if err != nil {
+			logger.Warn("skip unreadable trash item", "home", home, "name", e.Name(), "err", err)
+			continue
+		}
+		items = append(items, item)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if items[i].DeletedAt != items[j].DeletedAt {
+			return items[i].DeletedAt > items[j].DeletedAt
+		}
+		return items[i].ID > items[j].ID
+	})
+	return items, nil
+}
+
+func (srv *server) getTrashItem(ctx context.Context, home, id string) (*trashItem, error) {
+	if id == "" || strings.ContainsAny(id, `/\`) || id == "." || id == ".." {
+		return nil, errNoTrashItem
+	}
+	p := trashDir(home) + "/" + id + ".json"
+	if !srv.pathExists(ctx, p) {
+		return nil, errNoTrashItem
+	}
+	data, err := srv.storageReadFile(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	item := new(trashItem)
+	if err := json.Unmarshal(data, item); err != nil {
+		return nil, err
+	}
+	return item, nil
+}
+
+// restoreTarget returns where item is restored: destination when set,
+// otherwise its original path. The target must be in the same home.
+func (srv *server) restoreTarget(home string, item *trashItem, destination string) (string, error) {
+	target := item.OriginalPath
+	if destination != "" {
+		target = srv.formatPath(destination)
+	}
+	if srv.retentionHome(target) != home {
+		return "", fmt.Errorf("cannot restore %s to %s: outside of %s", item.OriginalPath, target, home)
+	}
+	return target, nil
+}
+
+// removeTrashItem removes the data and metadata of item.
+func (srv *server) removeTrashItem(ctx context.Context, home string, item *trashItem) error {
+	if err := srv.storageRemoveAll(ctx, trashDir(home)+"/"+item.ID); err != nil {
+		return err
+	}
+	return srv.storageRemove(ctx, trashDir(home)+"/"+item.ID+".json")
+}
+
+// retainedFiles returns the files at or below path.
+func (srv *server) retainedFiles(ctx context.Context, path string) []string {
+	info, err := srv.storageStat(ctx, path)
+	if err != nil {
+		return nil
+	}
+	if !info.IsDir() {
+		return []string{path}
+	}
+	entries, err := srv.storageReadDir(ctx, path)
+	if err != nil {
+		return nil
+	}
+	files := make([]string, 0, len(entries))
+	for _, e := range entries {
+		files = append(files, srv.retainedFiles(ctx, path+"/"+e.Name())...)
+	}
+	return files
+}
+
+// -----------------------------------------------------------------------------
+// Space accounting
+// -----------------------------------------------------------------------------
+
+// retentionOwners returns the owners of path, or of its nearest ancestor with
+// owners, which retained copies of path are charged to.
+func (srv *server) retentionOwners(rbac *rbac_client.Rbac_Client, path string) *rbacpb.Permission {
+	if rbac == nil {
+		return nil
+	}
+	home := srv.retentionHome(path)
+	for p := srv.formatPath(path); p != "" && p != "/" && p != "."; p = filepath.ToSlash(filepath.Dir(p)) {
+		if perms, err := rbac.GetResourcePermissions(p); err == nil && perms.GetOwners() != nil {
+			o := perms.GetOwners()
+			if len(o.Accounts)+len(o.Groups)+len(o.Applications)+len(o.Organizations)+len(o.NodeIdentities) > 0 {
+				return o
+			}
+		}
+		if p == home {
+			break
+		}
+	}
+	return nil
+}
+
+// hasRoom reports whether the accounts owning retained data can store size
+// more bytes.
+func hasRoom(rbac *rbac_client.Rbac_Client, owners *rbacpb.Permission, size int64) bool {
+	if rbac == nil || owners == nil {
+		return true
+	}
+	for _, a := range owners.Accounts {
+		if ok, err := rbac.ValidateSubjectSpace(a, rbacpb.SubjectType_ACCOUNT, uint64(size)); err == nil && !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// chargeRetained makes owners the owners of the retained files at or below
+// path, so their size counts against the owners' space.
+func (srv *server) chargeRetained(ctx context.Context, rbac *rbac_client.Rbac_Client, token string, owners *rbacpb.Permission, path string) {
+	if rbac == nil || owners == nil {
+		return
+	}
+	for _, f := range srv.retainedFiles(ctx, path) {
+		if err := rbac.SetResourcePermissions(token, f, "file", &rbacpb.Permissions{Owners: owners}); err != nil {
+			logger.Warn("charge retained file failed", "path", f, "err", err)
+		}
+	}
+}
+
+// discardRetained releases the space of the retained files at or below each
+// path and removes them. Permissions go first: RBAC only refunds the size of
+// files that still exist.
+func (srv *server) discardRetained(ctx context.Context, rbac *rbac_client.Rbac_Client, token string, paths ...string) {
+	for _, p := range paths {
+		srv.releaseRetained(ctx, rbac, token, p)
+		if err := srv.storageRemoveAll(ctx, p); err != nil {
+			logger.Warn("remove retained data failed", "path", p, "err", err)
+		}
+	}
+}
+
+func (srv *server) releaseRetained(ctx context.Context, rbac *rbac_client.Rbac_Client, token, path string) {
+	if rbac == nil {
+		return
+	}
+	for _, f := range srv.retainedFiles(ctx, path) {
+		_ = rbac.DeleteResourcePermissions(token, f)
+	}
+}
+
+// -----------------------------------------------------------------------------
+// Hooks used by the write and delete handlers
+// -----------------------------------------------------------------------------
+
+// versionBeforeWrite keeps the current content of path as a version before it
+// is overwritten, charged to the owners of path. When the owners are out of
+// space the oldest versions make room; when there is still none, no version
+// is kept. Errors are logged: they never fail the write.
+func (srv *server) versionBeforeWrite(ctx context.Context, token, path string) *filepb.FileVersion {
+	if srv.MaxFileVersions <= 0 || srv.retentionHome(path) == "" {
+		return nil
+	}
+	info, err := srv.storageStat(ctx, path)
+	if err != nil || info.IsDir() {
+		return nil
+	}
+	rbac, err := getRbacClient()
+	if err != nil {
+		rbac = nil
+	}
+	owners := srv.retentionOwners(rbac, path)
+	stale := srv.staleVersions(ctx, path, 0)
+	for !hasRoom(rbac, owners, info.Size()) {
+		if len(stale) == 0 {
+			logger.Info("no space left for a new version", "path", path)
+			return nil
+		}
+		srv.discardRetained(ctx, rbac, token, stale[len(stale)-1])
+		stale = stale[:len(stale)-1]
+	}
+
+	v, err := srv.keepVersion(ctx, path)
+	if err != nil {
+		logger.Warn("keep version failed", "path", path, "err", err)
+		return nil
+	}
+	if v == nil {
+		return nil
+	}
+	srv.chargeRetained(ctx, rbac, token, owners, srv.versionsDir(path)+"/"+v.Id)
+	srv.discardRetained(ctx, rbac, token, srv.staleVersions(ctx, path, srv.MaxFileVersions)...)
+	return v
+}
+
+// trashBeforeDelete moves path to the trash when deleted items are kept.
+// perms are the RBAC permissions of path and its content, already removed
+// from the original paths by the caller. It returns false when the caller
+// must delete path itself.
+func (srv *server) trashBeforeDelete(ctx context.Context, clientId, token, path string, owners *rbacpb.Permission, perms []*rbacpb.Permissions) (bool, error) {
+	if srv.TrashRetentionDays <= 0 || srv.retentionHome(path) == "" {
+		return false, nil
+	}
+	item := &trashItem{DeletedBy: clientId}
+	for _, p := range perms {
+		if data, err := protojson.Marshal(p); err == nil {
+			item.Permissions = append(item.Permissions, data)
+		}
+	}
+	ok, err := srv.moveToTrash(ctx, path, item)
+	if !ok || err != nil {
+		return ok, err
+	}
+	rbac, err := getRbacClient()
+	if err == nil {
+		srv.chargeRetained(ctx, rbac, token, owners, trashedPath(srv.retentionHome(path), item))
+	}
+	return true, nil
+}
+
+// restoreTrashItem moves item back to target and gives back the saved
+// permissions, moved from the original path to target.
+func (srv *server) restoreTrashItem(ctx context.Context, token, home string, item *trashItem, target string) error {
+	rbac, err := getRbacClient()
+	if err != nil {
+		rbac = nil
+	}
+	perms := make([]*rbacpb.Permissions, 0, len(item.Permissions))
+	for _, data := range item.Permissions {
+		p := new(rbacpb.Permissions)
+		if err := protojson.Unmarshal(data, p); err == nil {
+			perms = append(perms, p)
+		}
+	}
+
+	src := trashedPath(home, item)
+	srv.releaseRetained(ctx, rbac, token, src)
+	if err := srv.storageMove(ctx, src, target); err != nil {
+		// Still in the trash: charge it again.
+		var owners *rbacpb.Permission
+		if len(perms) > 0 && perms[0].Path == item.OriginalPath {
+			owners = perms[0].Owners
+		}
+		srv.chargeRetained(ctx, rbac, token, owners, src)
+		return err
+	}
+	if err := srv.removeTrashItem(ctx, home, item); err != nil {
+		logger.Warn("remove restored trash item failed", "id", item.ID, "err", err)
+	}
+	if rbac == nil {
+		return nil
+	}
+	for _, p := range perms {
+		path := target + strings.TrimPrefix(p.Path, item.OriginalPath)
+		if err := rbac.SetResourcePermissions(token, path, p.ResourceType, p); err != nil {
+			logger.Warn("restore permissions failed", "path", path, "err", err)
+		}
+	}
+	return nil
+}
+
+// purgeTrash removes the items of home whose retention has expired. It
+// returns the number of items and bytes removed.
+func (srv *server) purgeTrash(ctx context.Context, token, home string) (int, int64) {
+	items, err := srv.listTrash(ctx, home)
+	if err != nil || len(items) == 0 {
+		return 0, 0
+	}
+	rbac, err := getRbacClient()
+	if err != nil {
+		rbac = nil
+	}
+	now := time.Now().Unix()
+	removed, freed := 0, int64(0)
+	for _, item := range items {
+		if item.ExpiresAt == 0 || item.ExpiresAt > now {
+			continue
+		}
+		srv.releaseRetained(ctx, rbac, token, trashedPath(home, item))
+		if err := srv.removeTrashItem(ctx, home, item); err != nil {
+			logger.Warn("purge trash item failed", "home", home, "id", item.ID, "err", err)
+			continue
+		}
+		removed++
+		freed += item.Size
+	}
+	return removed, freed
+}
+
+// startRetentionSweeper purges expired trash items of the user homes and
+// public directories every retentionSweepInterval.
+func (srv *server) startRetentionSweeper(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(retentionSweepInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+			if srv.TrashRetentionDays <= 0 {
+				continue
+			}
+			token, _ := security.GetLocalToken(srv.Mac)
+			homes := append([]string{}, srv.Public...)
+			if entries, err := srv.storageReadDir(ctx, "/users"); err == nil {
+				for _, e := range entries {
+					if e.IsDir() {
+						homes = append(homes, "/users/"+e.Name())
+					}
+				}
+			}
+			for _, home := range homes {
+				if n, freed := srv.purgeTrash(ctx, token, srv.formatPath(home)); n > 0 {
+					logger.Info("purged expired trash items", "home", home, "items", n, "bytes", freed)
+				}
+			}
+		}
+	}()
+}
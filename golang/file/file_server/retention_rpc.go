@@ -0,0 +1,236 @@
+// --- retention_rpc.go ---
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/rbac/rbacpb"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// ListFileVersions returns the previous contents kept for a file, newest first.
+func (srv *server) ListFileVersions(ctx context.Context, rqst *filepb.ListFileVersionsRequest) (*filepb.ListFileVersionsResponse, error) {
+	path := srv.formatPath(rqst.GetPath())
+	if path == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	versions, err := srv.listVersions(ctx, path)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	return &filepb.ListFileVersionsResponse{Versions: versions}, nil
+}
+
+// RestoreFileVersion puts a previous content of a file back. The content it
+// replaces is kept as a version first.
+func (srv *server) RestoreFileVersion(ctx context.Context, rqst *filepb.RestoreFileVersionRequest) (*filepb.RestoreFileVersionResponse, error) {
+	_, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := srv.formatPath(rqst.GetPath())
+	if path == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	src, err := srv.versionPath(ctx, path, rqst.GetVersionId())
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%w %q of %s", err, rqst.GetVersionId(), path)))
+	}
+
+	previous := srv.versionBeforeWrite(ctx, token, path)
+	if err := srv.storageCopyFile(ctx, src, path); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	srv.cacheRemove(path)
+	return &filepb.RestoreFileVersionResponse{Previous: previous}, nil
+}
+
+// ListTrash returns the deleted items of the home of a path, newest first.
+// Expired items are purged first. The caller needs read on the home, and
+// only sees the items it could read at their original path.
+func (srv *server) ListTrash(ctx context.Context, rqst *filepb.ListTrashRequest) (*filepb.ListTrashResponse, error) {
+	clientId, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home, err := srv.trashHome(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireTrashAccess(clientId, "file.list", "read", home); err != nil {
+		return nil, err
+	}
+	srv.purgeTrash(ctx, token, home)
+	items, err := srv.listTrash(ctx, home)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	items, err = allowedTrash(clientId, "file.list", "read", items)
+	if err != nil {
+		return nil, err
+	}
+	rsp := &filepb.ListTrashResponse{Items: make([]*filepb.TrashItem, 0, len(items))}
+	for _, item := range items {
+		rsp.Items = append(rsp.Items, item.toProto())
+	}
+	return rsp, nil
+}
+
+// RestoreFromTrash puts a deleted item back at its original path, or at the
+// requested destination in the same home, with its permissions. The caller
+// needs write on the home, on the original path and on the destination.
+func (srv *server) RestoreFromTrash(ctx context.Context, rqst *filepb.RestoreFromTrashRequest) (*filepb.RestoreFromTrashResponse, error) {
+	clientId, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home, err := srv.trashHome(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireTrashAccess(clientId, "file.write", "write", home); err != nil {
+		return nil, err
+	}
+	item, err := srv.getTrashItem(ctx, home, rqst.GetId())
+	if err != nil {
+		code := codes.Internal
+		if errors.Is(err, errNoTrashItem) {
+			code = codes.NotFound
+		}
+		return nil, status.Errorf(code, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%w %q", err, rqst.GetId())))
+	}
+	target, err := srv.restoreTarget(home, item, rqst.GetDestination())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if err := requireTrashAccess(clientId, "file.write", "write", item.OriginalPath, target); err != nil {
+		return nil, err
+	}
+	if srv.pathExists(ctx, target) {
+		return nil, status.Errorf(codes.AlreadyExists, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%s already exists", target)))
+	}
+	if err := srv.restoreTrashItem(ctx, token, home, item, target); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	srv.cacheRemove(target)
+	return &filepb.RestoreFromTrashResponse{Path: target}, nil
+}
+
+// EmptyTrash purges deleted items for good. The caller needs delete on the
+// home and on the original path of each item. Without ids it purges every
+// item the caller could delete and leaves the others.
+func (srv *server) EmptyTrash(ctx context.Context, rqst *filepb.EmptyTrashRequest) (*filepb.EmptyTrashResponse, error) {
+	clientId, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	home, err := srv.trashHome(rqst.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	if err := requireTrashAccess(clientId, "file.delete", "delete", home); err != nil {
+		return nil, err
+	}
+
+	var items []*trashItem
+	if len(rqst.GetIds()) == 0 {
+		all, err := srv.listTrash(ctx, home)
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		if items, err = allowedTrash(clientId, "file.delete", "delete", all); err != nil {
+			return nil, err
+		}
+	}
+	for _, id := range rqst.GetIds() {
+		item, err := srv.getTrashItem(ctx, home, id)
+		if err != nil {
+			code := codes.Internal
+			if errors.Is(err, errNoTrashItem) {
+				code = codes.NotFound
+			}
+			return nil, status.Errorf(code, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%w %q", err, id)))
+		}
+		if err := requireTrashAccess(clientId, "file.delete", "delete", item.OriginalPath); err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+	}
+
+	rbac, err := getRbacClient()
+	if err != nil {
+		rbac = nil
+	}
+	rsp := new(filepb.EmptyTrashResponse)
+	for _, item := range items {
+		srv.releaseRetained(ctx, rbac, token, trashedPath(home, item))
+		if err := srv.removeTrashItem(ctx, home, item); err != nil {
+			return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		rsp.Removed++
+		rsp.Freed += item.Size
+	}
+	return rsp, nil
+}
+
+// trashHome returns the home whose trash the trash RPCs work on.
+func (srv *server) trashHome(path string) (string, error) {
+	home := srv.homeOf(path)
+	if home == "" {
+		return "", status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("no trash for path %q", path)))
+	}
+	return home, nil
+}
+
+// authorizeTrash reports whether subject may act on path. The trash RPCs
+// are checked on their request path only, which can be any path of the
+// home, so the handlers check the home and the items themselves. Tests
+// replace it.
+var authorizeTrash = func(subject, action, permission, path string) (bool, error) {
+	client, err := getRbacClient()
+	if err != nil {
+		return false, err
+	}
+	allowed, _, err := client.ValidateAction(action, subject, rbacpb.SubjectType_ACCOUNT, []*rbacpb.ResourceInfos{{Path: path, Permission: permission}})
+	if err != nil {
+		// A denial comes back as an error too.
+		return false, nil
+	}
+	return allowed, nil
+}
+
+// requireTrashAccess fails with PermissionDenied unless subject may act on
+// every path.
+func requireTrashAccess(subject, action, permission string, paths ...string) error {
+	for _, path := range paths {
+		allowed, err := authorizeTrash(subject, action, permission, path)
+		if err != nil {
+			return status.Errorf(codes.Unavailable, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		if !allowed {
+			return status.Errorf(codes.PermissionDenied, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%s permission on %s is required", permission, path)))
+		}
+	}
+	return nil
+}
+
+// allowedTrash returns the items subject may act on at their original path.
+func allowedTrash(subject, action, permission string, items []*trashItem) ([]*trashItem, error) {
+	allowed := make([]*trashItem, 0, len(items))
+	for _, item := range items {
+		ok, err := authorizeTrash(subject, action, permission, item.OriginalPath)
+		if err != nil {
+			return nil, status.Errorf(codes.Unavailable, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		if ok {
+			allowed = append(allowed, item)
+		}
+	}
+	return allowed, nil
+}
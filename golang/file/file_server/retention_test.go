@@ -0,0 +1,184 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/globulario/services/golang/storage_backend"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func newRetentionTestServer(t *testing.T) *server {
+	root := t.TempDir()
+	return &server{
+		Root:               root,
+		storage:            storage_backend.NewOSStorage(root),
+		publicStorage:      storage_backend.NewOSStorage(root),
+		MaxFileVersions:    2,
+		TrashRetentionDays: 30,
+	}
+}
+
+func TestRetentionHome(t *testing.T) {
+	srv := &server{Public: []string{"/mnt/share", "/mnt/share/media"}}
+	for path, want := range map[string]string{
+		"/users/alice/docs/a.txt":       "/users/alice",
+		"/users/alice":                  "",
+		"/users":                        "",
+		"/applications/app/index.html":  "/applications",
+		"/mnt/share/media/movie.mp4":    "/mnt/share/media",
+		"/mnt/share/notes.txt":          "/mnt/share",
+		"/users/alice/.hidden/a/t.jpg":  "",
+		"/users/alice/.hidden/.trash/x": "",
+	} {
+		if got := srv.retentionHome(path); got != want {
+			t.Errorf("retentionHome(%q) = %q, want %q", path, got, want)
+		}
+	}
+	if got := srv.homeOf("/users/alice"); got != "/users/alice" {
+		t.Errorf("homeOf(/users/alice) = %q", got)
+	}
+}
+
+func TestFileVersions(t *testing.T) {
+	srv := newRetentionTestServer(t)
+	ctx := context.Background()
+	path := "/users/alice/docs/report.txt"
+
+	for _, content := range []string{"one", "two", "three"} {
+		if err := srv.storageMkdirAll(ctx, "/users/alice/docs", 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := srv.keepVersion(ctx, path); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.storageWriteFile(ctx, path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first write had nothing to keep.
+	versions, err := srv.listVersions(ctx, path)
+	if err != nil || len(versions) != 2 {
+		t.Fatalf("versions = %v, %v", versions, err)
+	}
+	src, err := srv.versionPath(ctx, path, versions[0].Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := srv.storageReadFile(ctx, src); string(data) != "two" {
+		t.Errorf("newest version = %q", data)
+	}
+	if stale := srv.staleVersions(ctx, path, 1); len(stale) != 1 || stale[0] != srv.versionsDir(path)+"/"+versions[1].Id {
+		t.Errorf("stale versions = %v", stale)
+	}
+	for _, id := range []string{"", "..", "../report.txt", "123"} {
+		if _, err := srv.versionPath(ctx, path, id); !errors.Is(err, errNoVersion) {
+			t.Errorf("versionPath(%q): err = %v", id, err)
+		}
+	}
+}
+
+func TestTrash(t *testing.T) {
+	srv := newRetentionTestServer(t)
+	ctx := context.Background()
+	home := "/users/bob"
+	for path, content := range map[string]string{
+		"/users/bob/a.txt":          "aaa",
+		"/users/bob/photos/1.jpg":   "11",
+		"/users/bob/photos/x/2.jpg": "2222",
+	} {
+		if err := srv.storageMkdirAll(ctx, filepath.Dir(path), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := srv.storageWriteFile(ctx, path, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	file := &trashItem{DeletedBy: "bob"}
+	if ok, err := srv.moveToTrash(ctx, "/users/bob/a.txt", file); !ok || err != nil {
+		t.Fatalf("trash file = %v, %v", ok, err)
+	}
+	dir := &trashItem{DeletedBy: "bob"}
+	if ok, err := srv.moveToTrash(ctx, "/users/bob/photos", dir); !ok || err != nil {
+		t.Fatalf("trash dir = %v, %v", ok, err)
+	}
+	if ok, _ := srv.moveToTrash(ctx, home, &trashItem{}); ok {
+		t.Error("home moved to its own trash")
+	}
+	if srv.pathExists(ctx, "/users/bob/a.txt") || srv.pathExists(ctx, "/users/bob/photos") {
+		t.Error("trashed items still in place")
+	}
+
+	items, err := srv.listTrash(ctx, home)
+	if err != nil || len(items) != 2 {
+		t.Fatalf("trash = %v, %v", items, err)
+	}
+	if items[0].ID != dir.ID || !items[0].IsDir || items[0].Size != 6 || items[1].Size != 3 || items[1].ExpiresAt <= items[1].DeletedAt {
+		t.Errorf("trash items = %+v, %+v", items[0], items[1])
+	}
+
+	if _, err := srv.restoreTarget(home, file, "/users/carol/a.txt"); err == nil {
+		t.Error("restored to another home")
+	}
+	target, err := srv.restoreTarget(home, file, "")
+	if err != nil || target != "/users/bob/a.txt" {
+		t.Fatalf("restore target = %q, %v", target, err)
+	}
+	if err := srv.storageMove(ctx, trashedPath(home, file), target); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.removeTrashItem(ctx, home, file); err != nil {
+		t.Fatal(err)
+	}
+	if data, _ := srv.storageReadFile(ctx, target); string(data) != "aaa" {
+		t.Errorf("restored content = %q", data)
+	}
+	if _, err := srv.getTrashItem(ctx, home, file.ID); !errors.Is(err, errNoTrashItem) {
+		t.Errorf("restored item still in trash: %v", err)
+	}
+	if items, _ := srv.listTrash(ctx, home); len(items) != 1 {
+		t.Errorf("trash after restore = %v", items)
+	}
+}
+
+func TestTrashAccess(t *testing.T) {
+	// alice shared /users/alice/shared/doc.txt with bob, nothing else.
+	prev := authorizeTrash
+	t.Cleanup(func() { authorizeTrash = prev })
+	authorizeTrash = func(subject, action, permission, path string) (bool, error) {
+		if subject == "alice" {
+			return true, nil
+		}
+		return path == "/users/alice/shared/doc.txt", nil
+	}
+
+	if err := requireTrashAccess("alice", "file.delete", "delete", "/users/alice"); err != nil {
+		t.Errorf("owner refused: %v", err)
+	}
+	if err := requireTrashAccess("bob", "file.delete", "delete", "/users/alice"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("bob on alice's home = %v, want PermissionDenied", err)
+	}
+	if err := requireTrashAccess("bob", "file.write", "write", "/users/alice/shared/doc.txt", "/users/alice/taxes.pdf"); status.Code(err) != codes.PermissionDenied {
+		t.Errorf("bob restoring to another path = %v, want PermissionDenied", err)
+	}
+
+	items := []*trashItem{
+		{ID: "1", OriginalPath: "/users/alice/shared/doc.txt"},
+		{ID: "2", OriginalPath: "/users/alice/taxes.pdf"},
+	}
+	if got, err := allowedTrash("bob", "file.list", "read", items); err != nil || len(got) != 1 || got[0].ID != "1" {
+		t.Errorf("bob sees %v, %v; want item 1 only", got, err)
+	}
+	if got, _ := allowedTrash("alice", "file.list", "read", items); len(got) != 2 {
+		t.Errorf("alice sees %v, want both items", got)
+	}
+
+	authorizeTrash = func(string, string, string, string) (bool, error) { return false, errors.New("rbac down") }
+	if _, err := allowedTrash("alice", "file.list", "read", items); status.Code(err) != codes.Unavailable {
+		t.Errorf("rbac outage = %v, want Unavailable", err)
+	}
+}
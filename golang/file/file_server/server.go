@@ -20,6 +20,7 @@ import (
 	"github.com/globulario/services/golang/event/eventpb"
 	"github.com/globulario/services/golang/file/file_client"
 	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/file/sharelink"
 	"github.com/globulario/services/golang/globular_client"
 	globular "github.com/globulario/services/golang/globular_service"
 	"github.com/globulario/services/golang/media/media_client"
@@ -113,12 +114,26 @@ type server struct {
 	CacheReplicationFactor int
 	Public                 []string
 
+	// Versions and trash (see retention.go).
+	MaxFileVersions    int // Previous contents kept per file; 0 disables versioning.
+	TrashRetentionDays int // Days deleted items stay in the trash; 0 deletes at once.
+
+	// WebDAV listener port (see webdav.go); 0 disables it.
+	WebDavPort int
+
+	// Share link listener port (see share_links.go); 0 disables it.
+	ShareLinkPort int
+
 	MinioConfig *config.MinioProxyConfig
 
 	minioClient *minio.Client
 
 	checksumCache sync.Map
 
+	uploadLocks sync.Map // upload id -> *sync.Mutex
+
+	shareLinks sharelink.Store // share link records; the cluster registry when nil
+
 	// Cluster-wide public dir cache (populated by etcd watcher).
 	clusterDirs clusterDirCache
 }
@@ -729,6 +744,8 @@ func main() {
 	}
 	s.Public = []string{}
 	s.CacheReplicationFactor = 1
+	s.MaxFileVersions = 10
+	s.TrashRetentionDays = 30
 	s.Process = -1
 	s.ProxyProcess = -1
 	s.KeepAlive = true
@@ -867,6 +884,11 @@ func main() {
 	defer clusterCancel()
 	s.startClusterDirWatcher(clusterCtx)
 	go s.migrateLocalUsersToMinio(clusterCtx)
+	s.startRetentionSweeper(clusterCtx)
+	s.startUploadSweeper(clusterCtx)
+	s.startShareLinkSweeper(clusterCtx)
+	s.startWebDAV(clusterCtx)
+	s.startShareLinks(clusterCtx)
 
 	// Select cache backend
 	logger.Debug("selecting cache backend", "type", s.CacheType)
@@ -0,0 +1,334 @@
+// --- share_links.go ---
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"path"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/file/sharelink"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// -----------------------------------------------------------------------------
+// Share links
+//
+// A share link gives access to a file or directory to whoever holds its
+// token, without an account. The token is signed by the cluster and carries
+// the link id, path and expiry; the link record (config.ShareLinkEntry)
+// carries the password hash, download limit and revocation, and is checked by
+// the handler of package sharelink, served on ShareLinkPort, on every access.
+// -----------------------------------------------------------------------------
+
+const (
+	// defaultShareLinkTTL is the life of a link created without an expiry.
+	defaultShareLinkTTL = 7 * 24 * time.Hour
+)
+
+var errNoShareLink = errors.New("no such share link")
+
+// mintShareToken signs share tokens and validateShareToken checks them;
+// replaced in tests.
+var (
+	mintShareToken     = security.MintShareToken
+	validateShareToken = security.ValidateShareToken
+)
+
+// shareStore returns where link records are kept, the cluster registry
+// unless set otherwise.
+func (srv *server) shareStore() sharelink.Store {
+	if srv.shareLinks != nil {
+		return srv.shareLinks
+	}
+	return sharelink.EtcdStore{}
+}
+
+// shareFS reads shared files through the storage routing of the service.
+type shareFS struct{ srv *server }
+
+func (f shareFS) Stat(ctx context.Context, p string) (fs.FileInfo, error) {
+	return f.srv.storageStat(ctx, p)
+}
+
+func (f shareFS) Open(ctx context.Context, p string) (io.ReadSeekCloser, error) {
+	return f.srv.storageOpen(ctx, p)
+}
+
+func (f shareFS) ReadDir(ctx context.Context, p string) ([]fs.DirEntry, error) {
+	return f.srv.storageReadDir(ctx, p)
+}
+
+// startShareLinks serves share links on ShareLinkPort under sharelink.Prefix.
+// A port of 0 disables it.
+func (srv *server) startShareLinks(ctx context.Context) {
+	if srv.ShareLinkPort == 0 {
+		return
+	}
+	srv.listenHTTP(ctx, "share links", srv.ShareLinkPort, srv.shareLinkHandler())
+}
+
+func (srv *server) shareLinkHandler() http.Handler {
+	mux := http.NewServeMux()
+	h := sharelink.NewHandler(srv.shareStore(), shareFS{srv})
+	h.Validate = validateShareToken
+	mux.Handle(sharelink.Prefix, h)
+	return mux
+}
+
+// shareLinkURL is the address a token is served at, or "" when this service
+// does not serve share links.
+func (srv *server) shareLinkURL(token string) string {
+	if srv.ShareLinkPort == 0 {
+		return ""
+	}
+	scheme := "http"
+	if srv.TLS {
+		scheme = "https"
+	}
+	host := srv.Domain
+	if host == "" {
+		host, _ = config.GetDomain()
+	}
+	return fmt.Sprintf("%s://%s:%d%s%s", scheme, host, srv.ShareLinkPort, sharelink.Prefix, token)
+}
+
+// sharePermissions validates the permissions asked for a link. A file can
+// only be read; a directory can also be listed.
+func sharePermissions(perms []string, isDir bool) ([]string, error) {
+	if len(perms) == 0 {
+		if isDir {
+			return []string{"read", "list"}, nil
+		}
+		return []string{"read"}, nil
+	}
+	out := make([]string, 0, len(perms))
+	for _, p := range perms {
+		switch p {
+		case "read":
+		case "list":
+			if !isDir {
+				return nil, errors.New("list permission only applies to a directory")
+			}
+		default:
+			return nil, fmt.Errorf("unknown share permission %q", p)
+		}
+		if !slices.Contains(out, p) {
+			out = append(out, p)
+		}
+	}
+	return out, nil
+}
+
+// createShareLink saves a link record for path and signs its token.
+func (srv *server) createShareLink(ctx context.Context, clientId string, rqst *filepb.CreateShareLinkRequest) (*filepb.CreateShareLinkResponse, error) {
+	p := srv.formatPath(rqst.GetPath())
+	if p == "" || p == "/" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	if strings.Contains(p, "/.hidden") {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("hidden files cannot be shared")))
+	}
+	info, err := srv.storageStat(ctx, p)
+	if err != nil {
+		return nil, status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%s: %w", p, err)))
+	}
+	perms, err := sharePermissions(rqst.GetPermissions(), info.IsDir())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if rqst.GetMaxDownloads() < 0 {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("max_downloads is negative")))
+	}
+
+	now := time.Now()
+	expiresAt := now.Add(defaultShareLinkTTL)
+	if rqst.GetExpiresAt() != 0 {
+		expiresAt = time.Unix(rqst.GetExpiresAt(), 0)
+	}
+	link := config.ShareLinkEntry{
+		ID:           Utility.RandomUUID(),
+		Path:         p,
+		IsDir:        info.IsDir(),
+		Permissions:  perms,
+		CreatedBy:    clientId,
+		CreatedAt:    now.Unix(),
+		ExpiresAt:    expiresAt.Unix(),
+		MaxDownloads: rqst.GetMaxDownloads(),
+	}
+	token, err := mintShareToken(link.ID, clientId, p, perms, expiresAt)
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if rqst.GetPassword() != "" {
+		hash, err := bcrypt.GenerateFromPassword([]byte(rqst.GetPassword()), bcrypt.DefaultCost)
+		if err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+		}
+		link.PasswordHash = string(hash)
+	}
+	if err := srv.shareStore().Put(link); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	return &filepb.CreateShareLinkResponse{
+		Link:  shareLinkToProto(&link),
+		Token: token,
+		Url:   srv.shareLinkURL(token),
+	}, nil
+}
+
+// listShareLinks returns the links on p and below, newest first.
+func (srv *server) listShareLinks(p string) ([]*filepb.ShareLink, error) {
+	links, err := srv.shareStore().List()
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(links, func(i, j int) bool { return links[i].CreatedAt > links[j].CreatedAt })
+	out := make([]*filepb.ShareLink, 0, len(links))
+	for i := range links {
+		if sharedUnder(links[i].Path, p) {
+			out = append(out, shareLinkToProto(&links[i]))
+		}
+	}
+	return out, nil
+}
+
+// revokeShareLink marks a link revoked. The record is kept until it
+// expires so the link still shows as revoked; its token stops working at
+// once. p must be the shared path or one of its parents.
+func (srv *server) revokeShareLink(clientId, p, id string) (*filepb.ShareLink, error) {
+	link, err := srv.shareStore().Update(id, func(link *config.ShareLinkEntry) error {
+		if !sharedUnder(link.Path, p) {
+			return errNoShareLink
+		}
+		link.Revoked = true
+		link.RevokedBy = clientId
+		return nil
+	})
+	if errors.Is(err, sharelink.ErrNotFound) {
+		err = errNoShareLink
+	}
+	if err != nil {
+		return nil, err
+	}
+	return shareLinkToProto(link), nil
+}
+
+// purgeShareLinks deletes the records of expired links and returns how
+// many were removed.
+func (srv *server) purgeShareLinks() int {
+	links, err := srv.shareStore().List()
+	if err != nil {
+		return 0
+	}
+	now := time.Now().Unix()
+	n := 0
+	for _, link := range links {
+		if link.ExpiresAt <= now && srv.shareStore().Delete(link.ID) == nil {
+			n++
+		}
+	}
+	return n
+}
+
+// startShareLinkSweeper removes expired link records every hour.
+func (srv *server) startShareLinkSweeper(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := srv.purgeShareLinks(); n > 0 {
+					logger.Info("purged expired share links", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// sharedUnder tells whether shared is p or inside it.
+func sharedUnder(shared, p string) bool {
+	p = strings.TrimSuffix(path.Clean(p), "/")
+	return shared == p || strings.HasPrefix(shared, p+"/")
+}
+
+func shareLinkToProto(link *config.ShareLinkEntry) *filepb.ShareLink {
+	return &filepb.ShareLink{
+		Id:           link.ID,
+		Path:         link.Path,
+		IsDir:        link.IsDir,
+		Permissions:  link.Permissions,
+		CreatedBy:    link.CreatedBy,
+		CreatedAt:    link.CreatedAt,
+		ExpiresAt:    link.ExpiresAt,
+		HasPassword:  link.PasswordHash != "",
+		MaxDownloads: link.MaxDownloads,
+		Downloads:    link.Downloads,
+		Revoked:      link.Revoked,
+	}
+}
+
+// CreateShareLink creates an expiring link to a file or directory for
+// people without an account. The token is only returned here.
+func (srv *server) CreateShareLink(ctx context.Context, rqst *filepb.CreateShareLinkRequest) (*filepb.CreateShareLinkResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	rsp, err := srv.createShareLink(ctx, clientId, rqst)
+	if err != nil {
+		return nil, err
+	}
+	logger.Info("share link created", "id", rsp.Link.Id, "path", rsp.Link.Path, "by", clientId, "expires_at", rsp.Link.ExpiresAt)
+	return rsp, nil
+}
+
+// ListShareLinks returns the share links of a path and its children.
+func (srv *server) ListShareLinks(ctx context.Context, rqst *filepb.ListShareLinksRequest) (*filepb.ListShareLinksResponse, error) {
+	p := srv.formatPath(rqst.GetPath())
+	if p == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	links, err := srv.listShareLinks(p)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	return &filepb.ListShareLinksResponse{Links: links}, nil
+}
+
+// RevokeShareLink revokes a share link of a path or its children.
+func (srv *server) RevokeShareLink(ctx context.Context, rqst *filepb.RevokeShareLinkRequest) (*filepb.RevokeShareLinkResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	p := srv.formatPath(rqst.GetPath())
+	if p == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	link, err := srv.revokeShareLink(clientId, p, rqst.GetId())
+	if errors.Is(err, errNoShareLink) {
+		return nil, status.Errorf(codes.NotFound, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("%w %q under %s", err, rqst.GetId(), p)))
+	}
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	logger.Info("share link revoked", "id", link.Id, "path", link.Path, "by", clientId)
+	return &filepb.RevokeShareLinkResponse{Link: link}, nil
+}
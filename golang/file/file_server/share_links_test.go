@@ -0,0 +1,142 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/file/sharelink"
+	"github.com/globulario/services/golang/security"
+	"github.com/golang-jwt/jwt/v5"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestShareLinks(t *testing.T) {
+	srv := newRetentionTestServer(t)
+	store := sharelink.NewMemoryStore()
+	srv.shareLinks = store
+	srv.Domain, srv.ShareLinkPort = "files.example.test", 8443
+	ctx := context.Background()
+	mint := mintShareToken
+	mintShareToken = func(id, creator, path string, perms []string, expiresAt time.Time) (string, error) {
+		return "tok-" + id, nil
+	}
+	t.Cleanup(func() { mintShareToken = mint })
+
+	if err := srv.storageMkdirAll(ctx, "/users/ann/photos", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.storageWriteFile(ctx, "/users/ann/photos/1.jpg", []byte("jpg"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := srv.createShareLink(ctx, "ann", &filepb.CreateShareLinkRequest{Path: "/users/ann/missing"}); status.Code(err) != codes.NotFound {
+		t.Errorf("missing path: err = %v", err)
+	}
+	if _, err := srv.createShareLink(ctx, "ann", &filepb.CreateShareLinkRequest{Path: "/users/ann/photos/1.jpg", Permissions: []string{"list"}}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("list on a file: err = %v", err)
+	}
+
+	dir, err := srv.createShareLink(ctx, "ann", &filepb.CreateShareLinkRequest{Path: "/users/ann/photos", Password: "secret", MaxDownloads: 3})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dir.Url != "http://files.example.test:8443/share/"+dir.Token || !dir.Link.IsDir || !dir.Link.HasPassword || len(dir.Link.Permissions) != 2 {
+		t.Errorf("dir link = %v", dir)
+	}
+	if dir.Link.ExpiresAt-dir.Link.CreatedAt != int64(defaultShareLinkTTL/time.Second) {
+		t.Errorf("default expiry = %d", dir.Link.ExpiresAt-dir.Link.CreatedAt)
+	}
+	if rec, _ := store.Get(dir.Link.Id); rec == nil || rec.PasswordHash == "" || rec.PasswordHash == "secret" {
+		t.Errorf("stored record = %+v", rec)
+	}
+	file, err := srv.createShareLink(ctx, "ann", &filepb.CreateShareLinkRequest{Path: "/users/ann/photos/1.jpg", ExpiresAt: time.Now().Add(time.Hour).Unix()})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	links, err := srv.listShareLinks("/users/ann/photos")
+	if err != nil || len(links) != 2 {
+		t.Fatalf("links = %v, %v", links, err)
+	}
+	if links, _ := srv.listShareLinks("/users/ann/photos/1.jpg"); len(links) != 1 || links[0].Id != file.Link.Id {
+		t.Errorf("file links = %v", links)
+	}
+	if links, _ := srv.listShareLinks("/users/an"); len(links) != 0 {
+		t.Errorf("sibling prefix matched: %v", links)
+	}
+
+	if _, err := srv.revokeShareLink("bob", "/users/bob", file.Link.Id); !errors.Is(err, errNoShareLink) {
+		t.Errorf("revoke from another path: err = %v", err)
+	}
+	revoked, err := srv.revokeShareLink("ann", "/users/ann", file.Link.Id)
+	if err != nil || !revoked.Revoked {
+		t.Fatalf("revoke = %v, %v", revoked, err)
+	}
+
+	// Only expired records are swept.
+	if _, err := store.Update(dir.Link.Id, func(l *config.ShareLinkEntry) error { l.ExpiresAt = time.Now().Unix() - 1; return nil }); err != nil {
+		t.Fatal(err)
+	}
+	if n := srv.purgeShareLinks(); n != 1 {
+		t.Errorf("purged = %d", n)
+	}
+	if links, _ := srv.listShareLinks("/"); len(links) != 1 || links[0].Id != file.Link.Id {
+		t.Errorf("links after purge = %v", links)
+	}
+}
+
+func TestShareLinkHandler(t *testing.T) {
+	srv := newRetentionTestServer(t)
+	srv.shareLinks = sharelink.NewMemoryStore()
+	ctx := context.Background()
+	mint, validate := mintShareToken, validateShareToken
+	mintShareToken = func(id, creator, path string, perms []string, expiresAt time.Time) (string, error) {
+		return "tok-" + id, nil
+	}
+	validateShareToken = func(token string) (*security.ShareClaims, error) {
+		id := strings.TrimPrefix(token, "tok-")
+		link, err := srv.shareLinks.Get(id)
+		if err != nil {
+			return nil, err
+		}
+		return &security.ShareClaims{Path: link.Path, RegisteredClaims: jwt.RegisteredClaims{ID: id}}, nil
+	}
+	t.Cleanup(func() { mintShareToken, validateShareToken = mint, validate })
+
+	if err := srv.storageMkdirAll(ctx, "/users/ann", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.storageWriteFile(ctx, "/users/ann/notes.txt", []byte("hello"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	rsp, err := srv.createShareLink(ctx, "ann", &filepb.CreateShareLinkRequest{Path: "/users/ann/notes.txt", MaxDownloads: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The link is served by the handler mounted on ShareLinkPort.
+	h := srv.shareLinkHandler()
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, sharelink.Prefix+rsp.Token, nil))
+	if w.Code != http.StatusOK || w.Body.String() != "hello" {
+		t.Fatalf("GET link = %d %q", w.Code, w.Body.String())
+	}
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest(http.MethodGet, sharelink.Prefix+rsp.Token, nil))
+	if w.Code != http.StatusGone {
+		t.Errorf("GET past the limit = %d", w.Code)
+	}
+
+	// Without a listener there is no URL to hand out.
+	if rsp.Url != "" {
+		t.Errorf("url without ShareLinkPort = %q", rsp.Url)
+	}
+}
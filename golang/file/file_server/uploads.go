@@ -0,0 +1,388 @@
+// --- uploads.go ---
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/file/filepb"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// -----------------------------------------------------------------------------
+// Resumable uploads
+//
+// An upload session is staged on the local disk of the node that began it,
+// whatever the backend of the destination, as two files under <Root>/uploads:
+//
+//	<id>.json  the session (destination, expected size and checksum, owner)
+//	<id>.part  the data received so far; its size is the committed offset
+//
+// CommitUpload streams the staged data to the destination backend. Sessions
+// are not shared between instances: every call of a session must reach that
+// node, and one that lands on another gets NotFound.
+// -----------------------------------------------------------------------------
+
+const (
+	// uploadSessionTTL is how long a session lives without new chunks.
+	uploadSessionTTL = 24 * time.Hour
+)
+
+var (
+	errNoUpload       = errors.New("no such upload")
+	errUploadOwner    = errors.New("upload belongs to another subject")
+	errUploadOffset   = errors.New("chunk does not start at or before the committed offset")
+	errUploadTooLarge = errors.New("chunk goes past the declared size")
+)
+
+// uploadSession is the saved state of an upload.
+type uploadSession struct {
+	ID      string `json:"id"`
+	Path    string `json:"path"`
+	Size    int64  `json:"size"`
+	Sha256  string `json:"sha256,omitempty"`
+	Owner   string `json:"owner"`
+	Created int64  `json:"created"`
+}
+
+func (srv *server) uploadsDir() string {
+	return filepath.Join(srv.Root, "uploads")
+}
+
+func (srv *server) uploadFiles(id string) (meta, part string) {
+	dir := srv.uploadsDir()
+	return filepath.Join(dir, id+".json"), filepath.Join(dir, id+".part")
+}
+
+// lockUpload serializes the operations on one session.
+func (srv *server) lockUpload(id string) func() {
+	v, _ := srv.uploadLocks.LoadOrStore(id, new(sync.Mutex))
+	m := v.(*sync.Mutex)
+	m.Lock()
+	return m.Unlock
+}
+
+// beginUpload creates a session for path owned by owner.
+func (srv *server) beginUpload(path, owner string, size int64, sum string) (*uploadSession, error) {
+	if size < 0 {
+		return nil, fmt.Errorf("invalid size %d", size)
+	}
+	if sum != "" {
+		if b, err := hex.DecodeString(sum); err != nil || len(b) != sha256.Size {
+			return nil, fmt.Errorf("invalid sha256 %q", sum)
+		}
+	}
+	if err := os.MkdirAll(srv.uploadsDir(), 0o755); err != nil {
+		return nil, err
+	}
+	s := &uploadSession{
+		ID:      Utility.RandomUUID(),
+		Path:    path,
+		Size:    size,
+		Sha256:  strings.ToLower(sum),
+		Owner:   owner,
+		Created: time.Now().Unix(),
+	}
+	data, err := json.Marshal(s)
+	if err != nil {
+		return nil, err
+	}
+	meta, part := srv.uploadFiles(s.ID)
+	if err := os.WriteFile(part, nil, 0o644); err != nil {
+		return nil, err
+	}
+	if err := os.WriteFile(meta, data, 0o644); err != nil {
+		_ = os.Remove(part)
+		return nil, err
+	}
+	return s, nil
+}
+
+// getUpload returns the session id of owner with its committed offset and
+// expiration time. Expired sessions are removed.
+func (srv *server) getUpload(id, owner string) (*uploadSession, int64, time.Time, error) {
+	if id == "" || strings.ContainsAny(id, `/\.`) {
+		return nil, 0, time.Time{}, errNoUpload
+	}
+	meta, part := srv.uploadFiles(id)
+	data, err := os.ReadFile(meta)
+	if err != nil {
+		return nil, 0, time.Time{}, errNoUpload
+	}
+	s := new(uploadSession)
+	if err := json.Unmarshal(data, s); err != nil {
+		return nil, 0, time.Time{}, err
+	}
+	if s.Owner != owner {
+		return nil, 0, time.Time{}, errUploadOwner
+	}
+	info, err := os.Stat(part)
+	if err != nil {
+		return nil, 0, time.Time{}, errNoUpload
+	}
+	expires := info.ModTime().Add(uploadSessionTTL)
+	if time.Now().After(expires) {
+		srv.removeUpload(id)
+		return nil, 0, time.Time{}, errNoUpload
+	}
+	return s, info.Size(), expires, nil
+}
+
+// appendChunk writes data at offset in the staged file of s and returns the
+// new committed offset. Bytes before the committed offset were received
+// already (a retried chunk) and are skipped.
+func (srv *server) appendChunk(s *uploadSession, offset int64, data []byte) (int64, error) {
+	_, part := srv.uploadFiles(s.ID)
+	f, err := os.OpenFile(part, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+	info, err := f.Stat()
+	if err != nil {
+		return 0, err
+	}
+	committed := info.Size()
+	if offset < 0 || offset > committed {
+		return committed, fmt.Errorf("%w: offset %d, committed %d", errUploadOffset, offset, committed)
+	}
+	skip := committed - offset
+	if skip >= int64(len(data)) {
+		now := time.Now()
+		_ = os.Chtimes(part, now, now)
+		return committed, nil
+	}
+	data = data[skip:]
+	if s.Size > 0 && committed+int64(len(data)) > s.Size {
+		return committed, fmt.Errorf("%w: %d > %d", errUploadTooLarge, committed+int64(len(data)), s.Size)
+	}
+	n, err := f.Write(data)
+	if err == nil {
+		err = f.Sync()
+	}
+	return committed + int64(n), err
+}
+
+// stagedChecksum returns the SHA-256 (hex) and size of the data of s.
+func (srv *server) stagedChecksum(s *uploadSession) (string, int64, error) {
+	_, part := srv.uploadFiles(s.ID)
+	f, err := os.Open(part)
+	if err != nil {
+		return "", 0, err
+	}
+	defer f.Close()
+	h := sha256.New()
+	n, err := io.Copy(h, f)
+	if err != nil {
+		return "", 0, err
+	}
+	return hex.EncodeToString(h.Sum(nil)), n, nil
+}
+
+// commitUpload checks the data of s against sum and the checksum given to
+// BeginUpload, then writes it to the destination and drops the session.
+func (srv *server) commitUpload(ctx context.Context, token string, s *uploadSession, sum string) (*filepb.CommitUploadResponse, error) {
+	got, n, err := srv.stagedChecksum(s)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if s.Size > 0 && n != s.Size {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("upload incomplete: %d of %d bytes", n, s.Size)))
+	}
+	if sum == "" && s.Sha256 == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("sha256 is required")))
+	}
+	for _, want := range []string{sum, s.Sha256} {
+		if want != "" && !strings.EqualFold(want, got) {
+			return nil, status.Errorf(codes.DataLoss, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), fmt.Errorf("checksum mismatch: expected %s, got %s", want, got)))
+		}
+	}
+
+	_, part := srv.uploadFiles(s.ID)
+	src, err := os.Open(part)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	defer src.Close()
+	if err := srv.storageMkdirAll(ctx, filepath.Dir(s.Path), 0o755); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	srv.versionBeforeWrite(ctx, token, s.Path)
+	dst, err := srv.storageCreate(ctx, s.Path)
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if _, err := io.Copy(dst, src); err != nil {
+		_ = dst.Close()
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	if err := dst.Close(); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	srv.removeUpload(s.ID)
+	return &filepb.CommitUploadResponse{Path: s.Path, Size: n, Sha256: got}, nil
+}
+
+func (srv *server) removeUpload(id string) {
+	meta, part := srv.uploadFiles(id)
+	_ = os.Remove(part)
+	_ = os.Remove(meta)
+	srv.uploadLocks.Delete(id)
+}
+
+// purgeUploads removes the sessions that received nothing for uploadSessionTTL.
+func (srv *server) purgeUploads() int {
+	entries, err := os.ReadDir(srv.uploadsDir())
+	if err != nil {
+		return 0
+	}
+	removed := 0
+	for _, e := range entries {
+		if !strings.HasSuffix(e.Name(), ".json") {
+			continue
+		}
+		id := strings.TrimSuffix(e.Name(), ".json")
+		_, part := srv.uploadFiles(id)
+		info, err := os.Stat(part)
+		if err == nil && time.Since(info.ModTime()) < uploadSessionTTL {
+			continue
+		}
+		srv.removeUpload(id)
+		removed++
+	}
+	return removed
+}
+
+// startUploadSweeper purges expired upload sessions every hour.
+func (srv *server) startUploadSweeper(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if n := srv.purgeUploads(); n > 0 {
+					logger.Info("purged expired upload sessions", "count", n)
+				}
+			}
+		}
+	}()
+}
+
+// uploadError maps session errors to gRPC status errors.
+func uploadError(err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, errNoUpload):
+		code = codes.NotFound
+	case errors.Is(err, errUploadOwner):
+		code = codes.PermissionDenied
+	case errors.Is(err, errUploadOffset):
+		code = codes.FailedPrecondition
+	case errors.Is(err, errUploadTooLarge):
+		code = codes.OutOfRange
+	}
+	return status.Errorf(code, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+}
+
+// BeginUpload starts a resumable upload to a path.
+func (srv *server) BeginUpload(ctx context.Context, rqst *filepb.BeginUploadRequest) (*filepb.BeginUploadResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	path := srv.formatPath(rqst.GetPath())
+	if path == "" {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New("path is empty")))
+	}
+	s, err := srv.beginUpload(path, clientId, rqst.GetSize(), rqst.GetSha256())
+	if err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	return &filepb.BeginUploadResponse{UploadId: s.ID, ExpiresAt: time.Now().Add(uploadSessionTTL).Unix()}, nil
+}
+
+// UploadChunk appends a chunk to an upload and returns the committed offset.
+func (srv *server) UploadChunk(ctx context.Context, rqst *filepb.UploadChunkRequest) (*filepb.UploadChunkResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlock := srv.lockUpload(rqst.GetUploadId())
+	defer unlock()
+	s, _, _, err := srv.getUpload(rqst.GetUploadId(), clientId)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	offset, err := srv.appendChunk(s, rqst.GetOffset(), rqst.GetData())
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return &filepb.UploadChunkResponse{Offset: offset}, nil
+}
+
+// GetUploadStatus returns the committed offset of an upload, where a client
+// resumes after a dropped connection.
+func (srv *server) GetUploadStatus(ctx context.Context, rqst *filepb.GetUploadStatusRequest) (*filepb.GetUploadStatusResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	s, offset, expires, err := srv.getUpload(rqst.GetUploadId(), clientId)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	return &filepb.GetUploadStatusResponse{Path: s.Path, Offset: offset, Size: s.Size, ExpiresAt: expires.Unix()}, nil
+}
+
+// CommitUpload checks the checksum of an upload and writes the file.
+func (srv *server) CommitUpload(ctx context.Context, rqst *filepb.CommitUploadRequest) (*filepb.CommitUploadResponse, error) {
+	clientId, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlock := srv.lockUpload(rqst.GetUploadId())
+	defer unlock()
+	s, _, _, err := srv.getUpload(rqst.GetUploadId(), clientId)
+	if err != nil {
+		return nil, uploadError(err)
+	}
+	rsp, err := srv.commitUpload(ctx, token, s, rqst.GetSha256())
+	if err != nil {
+		return nil, err
+	}
+	srv.cacheRemove(rsp.Path)
+	srv.cacheRemove(filepath.Dir(rsp.Path))
+	srv.publishReloadDirEvent(filepath.Dir(rsp.Path))
+	return rsp, nil
+}
+
+// AbortUpload drops an upload and its staged data.
+func (srv *server) AbortUpload(ctx context.Context, rqst *filepb.AbortUploadRequest) (*filepb.AbortUploadResponse, error) {
+	clientId, _, err := security.GetClientId(ctx)
+	if err != nil {
+		return nil, err
+	}
+	unlock := srv.lockUpload(rqst.GetUploadId())
+	defer unlock()
+	if _, _, _, err := srv.getUpload(rqst.GetUploadId(), clientId); err != nil {
+		return nil, uploadError(err)
+	}
+	srv.removeUpload(rqst.GetUploadId())
+	return &filepb.AbortUploadResponse{}, nil
+}
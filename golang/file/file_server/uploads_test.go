@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestResumableUpload(t *testing.T) {
+	srv := newRetentionTestServer(t)
+	srv.MaxFileVersions = 0
+	ctx := context.Background()
+	content := []byte("hello resumable world")
+	h := sha256.Sum256(content)
+	sum := hex.EncodeToString(h[:])
+
+	if _, err := srv.beginUpload("/users/ann/a.bin", "ann", 0, "nothex"); err == nil {
+		t.Error("invalid checksum accepted")
+	}
+	s, err := srv.beginUpload("/users/ann/big/a.bin", "ann", int64(len(content)), sum)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, _, err := srv.getUpload(s.ID, "bob"); !errors.Is(err, errUploadOwner) {
+		t.Errorf("other subject: err = %v", err)
+	}
+	if _, _, _, err := srv.getUpload("../x", "ann"); !errors.Is(err, errNoUpload) {
+		t.Errorf("bad id: err = %v", err)
+	}
+
+	if off, err := srv.appendChunk(s, 0, content[:8]); err != nil || off != 8 {
+		t.Fatalf("first chunk = %d, %v", off, err)
+	}
+	// A gap is refused; a retried chunk only adds what is new.
+	if off, err := srv.appendChunk(s, 10, content[10:]); !errors.Is(err, errUploadOffset) || off != 8 {
+		t.Errorf("gap = %d, %v", off, err)
+	}
+	if off, err := srv.appendChunk(s, 4, content[4:12]); err != nil || off != 12 {
+		t.Errorf("overlapping chunk = %d, %v", off, err)
+	}
+	if _, err := srv.appendChunk(s, 12, append(append([]byte{}, content[12:]...), '!')); !errors.Is(err, errUploadTooLarge) {
+		t.Errorf("chunk past size: err = %v", err)
+	}
+	if _, off, _, err := srv.getUpload(s.ID, "ann"); err != nil || off != 12 {
+		t.Fatalf("status = %d, %v", off, err)
+	}
+
+	if _, err := srv.commitUpload(ctx, "", s, sum); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("incomplete commit: err = %v", err)
+	}
+	if _, err := srv.appendChunk(s, 12, content[12:]); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.commitUpload(ctx, "", s, sum[:len(sum)-1]+"0"); status.Code(err) != codes.DataLoss {
+		t.Errorf("bad checksum commit: err = %v", err)
+	}
+	rsp, err := srv.commitUpload(ctx, "", s, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Size != int64(len(content)) || rsp.Sha256 != sum {
+		t.Errorf("commit = %v", rsp)
+	}
+	if data, _ := srv.storageReadFile(ctx, "/users/ann/big/a.bin"); string(data) != string(content) {
+		t.Errorf("written = %q", data)
+	}
+	if _, _, _, err := srv.getUpload(s.ID, "ann"); !errors.Is(err, errNoUpload) {
+		t.Errorf("committed session still there: %v", err)
+	}
+}
@@ -0,0 +1,850 @@
+// --- webdav.go ---
+package main
+
+import (
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"net"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/authentication/authentication_client"
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/file/filepb"
+	globular_client "github.com/globulario/services/golang/globular_client"
+	"github.com/globulario/services/golang/interceptors"
+	"github.com/globulario/services/golang/rbac/rbacpb"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"golang.org/x/net/webdav"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+)
+
+// -----------------------------------------------------------------------------
+// WebDAV
+//
+// A WebDAV front-end so desktop clients can mount a home folder, e.g.
+// https://<node>:<WebDavPort>/users/alice. It serves the same paths as the
+// gRPC service, through the same storage routing (formatPath, public dirs,
+// MinIO), and goes through the gRPC handlers or the same hooks for every
+// change, so versions, trash, owners, cache entries and reload_dir_event
+// notifications stay in step with the web clients.
+//
+// Requests carry a session token (Authorization: Bearer or a "token" header)
+// or basic auth, exchanged for a token with the authentication service. Each
+// request is checked against RBAC with the action and permission the gRPC
+// method doing the same thing requires.
+// -----------------------------------------------------------------------------
+
+const (
+	// davTokenMargin is how long before its expiry a cached basic-auth token
+	// is exchanged again.
+	davTokenMargin = time.Minute
+
+	// Same lifetimes as the interceptor permission cache.
+	davGrantTTL = 60 * time.Second
+	davDenyTTL  = 30 * time.Second
+
+	// davCacheSize caps each of the token and permission caches.
+	davCacheSize = 4096
+)
+
+var errDavHidden = fmt.Errorf("%w: hidden folder", os.ErrNotExist)
+
+// davRange carries the Content-Range of a PUT to the file system, which then
+// writes into the existing content instead of replacing it.
+type davRangeKey struct{}
+
+// davToken returns the session token the request was authenticated with.
+func davToken(ctx context.Context) string {
+	if md, ok := metadata.FromIncomingContext(ctx); ok {
+		if vals := md.Get("token"); len(vals) > 0 {
+			return vals[0]
+		}
+	}
+	return ""
+}
+
+// isHiddenPath tells whether p is inside a .hidden folder (thumbnails,
+// versions, trash), which WebDAV never shows.
+func isHiddenPath(p string) bool {
+	return strings.Contains(p+"/", "/.hidden/")
+}
+
+// -----------------------------------------------------------------------------
+// File system
+// -----------------------------------------------------------------------------
+
+// davFS implements webdav.FileSystem over the service storage.
+type davFS struct {
+	srv *server
+
+	// changed runs after a path was written or created: it drops the cache
+	// entries, sets the owner of new paths and tells clients to reload the
+	// parent directory. Replaced in tests.
+	changed func(ctx context.Context, p string, created bool)
+}
+
+func newDavFS(srv *server) *davFS {
+	return &davFS{srv: srv, changed: srv.davChanged}
+}
+
+func (d *davFS) resolve(name string) (string, error) {
+	p := d.srv.formatPath(path.Clean("/" + name))
+	if isHiddenPath(p) {
+		return "", errDavHidden
+	}
+	return p, nil
+}
+
+func (d *davFS) Stat(ctx context.Context, name string) (os.FileInfo, error) {
+	p, err := d.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	info, err := d.srv.storageStat(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return davInfo{info}, nil
+}
+
+func (d *davFS) Mkdir(ctx context.Context, name string, perm os.FileMode) error {
+	p, err := d.resolve(name)
+	if err != nil {
+		return err
+	}
+	if d.srv.pathExists(ctx, p) {
+		return os.ErrExist
+	}
+	if parent, err := d.srv.storageStat(ctx, path.Dir(p)); err != nil || !parent.IsDir() {
+		return os.ErrNotExist
+	}
+	if err := d.srv.storageMkdirAll(ctx, p, 0o755); err != nil {
+		return err
+	}
+	d.changed(ctx, p, true)
+	return nil
+}
+
+func (d *davFS) OpenFile(ctx context.Context, name string, flag int, perm os.FileMode) (webdav.File, error) {
+	p, err := d.resolve(name)
+	if err != nil {
+		return nil, err
+	}
+	if flag&(os.O_CREATE|os.O_TRUNC) != 0 {
+		return d.create(ctx, p)
+	}
+	info, err := d.srv.storageStat(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	if info.IsDir() {
+		return &davDir{fs: d, path: p, info: davInfo{info}}, nil
+	}
+	f, err := d.srv.storageOpen(ctx, p)
+	if err != nil {
+		return nil, err
+	}
+	return &davFile{ReadSeekCloser: f, info: davInfo{info}}, nil
+}
+
+// create stages a write to p in a local temp file; it is committed to the
+// storage backend on Close. A PUT with a Content-Range starts from the
+// existing content.
+func (d *davFS) create(ctx context.Context, p string) (webdav.File, error) {
+	if parent, err := d.srv.storageStat(ctx, path.Dir(p)); err != nil || !parent.IsDir() {
+		return nil, os.ErrNotExist
+	}
+	if err := os.MkdirAll(d.srv.uploadsDir(), 0o755); err != nil {
+		return nil, err
+	}
+	tmp, err := os.CreateTemp(d.srv.uploadsDir(), "dav-*.part")
+	if err != nil {
+		return nil, err
+	}
+	w := &davWriter{fs: d, ctx: ctx, path: p, tmp: tmp, existed: d.srv.pathExists(ctx, p)}
+	if start, ok := ctx.Value(davRangeKey{}).(int64); ok && w.existed {
+		src, err := d.srv.storageOpen(ctx, p)
+		if err == nil {
+			_, err = io.Copy(tmp, src)
+			src.Close()
+		}
+		if err == nil {
+			_, err = tmp.Seek(start, io.SeekStart)
+		}
+		if err != nil {
+			w.discard()
+			return nil, err
+		}
+	}
+	return w, nil
+}
+
+// RemoveAll deletes through the gRPC handlers, so the item goes to the trash
+// with its permissions and title associations are dropped.
+func (d *davFS) RemoveAll(ctx context.Context, name string) error {
+	p, err := d.resolve(name)
+	if err != nil {
+		return err
+	}
+	info, err := d.srv.storageStat(ctx, p)
+	if err != nil {
+		return err
+	}
+	if info.IsDir() {
+		_, err = d.srv.DeleteDir(ctx, &filepb.DeleteDirRequest{Path: p})
+	} else {
+		_, err = d.srv.DeleteFile(ctx, &filepb.DeleteFileRequest{Path: p})
+	}
+	if err != nil {
+		return err
+	}
+	d.srv.publishReloadDirEvent(path.Dir(p))
+	return nil
+}
+
+// Rename moves through the gRPC Rename and Move handlers, which carry the
+// permissions, title associations and .hidden data along. Move keeps the
+// base name, so a move that also renames goes through a free name in the
+// source directory. That takes up to three calls, which are not atomic
+// together: when one fails, the ones before it are undone in reverse order,
+// so the source is left where it was unless the undo fails too (which is
+// logged).
+func (d *davFS) Rename(ctx context.Context, oldName, newName string) error {
+	from, err := d.resolve(oldName)
+	if err != nil {
+		return err
+	}
+	to, err := d.resolve(newName)
+	if err != nil {
+		return err
+	}
+	if !d.srv.pathExists(ctx, from) {
+		return os.ErrNotExist
+	}
+	if d.srv.pathExists(ctx, to) {
+		return os.ErrExist
+	}
+
+	var undo []func() error
+	fail := func(err error) error {
+		for i := len(undo) - 1; i >= 0; i-- {
+			if uerr := undo[i](); uerr != nil {
+				logger.Error("webdav rename: undo failed", "from", from, "to", to, "err", uerr)
+			}
+		}
+		return err
+	}
+	rename := func(dir, oldName, newName string) error {
+		if _, err := d.srv.Rename(ctx, &filepb.RenameRequest{Path: dir, OldName: oldName, NewName: newName}); err != nil {
+			return err
+		}
+		undo = append(undo, func() error {
+			_, err := d.srv.Rename(ctx, &filepb.RenameRequest{Path: dir, OldName: newName, NewName: oldName})
+			return err
+		})
+		return nil
+	}
+
+	src := from
+	if path.Dir(from) != path.Dir(to) {
+		if path.Base(from) != path.Base(to) {
+			name := path.Base(to)
+			if d.srv.pathExists(ctx, path.Join(path.Dir(from), name)) {
+				name = ".dav-" + Utility.RandomUUID()[:8] + "-" + name
+			}
+			if err := rename(path.Dir(from), path.Base(from), name); err != nil {
+				return fail(err)
+			}
+			src = path.Join(path.Dir(from), name)
+		}
+		if _, err := d.srv.Move(ctx, &filepb.MoveRequest{Path: path.Dir(to), Files: []string{src}}); err != nil {
+			return fail(err)
+		}
+		moved := path.Join(path.Dir(to), path.Base(src))
+		undo = append(undo, func() error {
+			_, err := d.srv.Move(ctx, &filepb.MoveRequest{Path: path.Dir(from), Files: []string{moved}})
+			return err
+		})
+		src = moved
+	}
+	if src != to {
+		if err := rename(path.Dir(to), path.Base(src), path.Base(to)); err != nil {
+			return fail(err)
+		}
+	}
+	if !d.srv.pathExists(ctx, to) {
+		return fail(fmt.Errorf("move %s to %s failed", from, to))
+	}
+	d.srv.cacheRemove(from)
+	d.srv.cacheRemove(path.Dir(from))
+	d.srv.cacheRemove(path.Dir(to))
+	d.srv.publishReloadDirEvent(path.Dir(from))
+	if path.Dir(to) != path.Dir(from) {
+		d.srv.publishReloadDirEvent(path.Dir(to))
+	}
+	return nil
+}
+
+// davChanged is what the gRPC write paths do after a change: drop the
+// cache entries, own new paths and notify clients.
+func (srv *server) davChanged(ctx context.Context, p string, created bool) {
+	srv.cacheRemove(p)
+	srv.cacheRemove(path.Dir(p))
+	if created {
+		if err := srv.setOwner(davToken(ctx), p); err != nil {
+			logger.Warn("webdav: set owner failed", "path", p, "err", err)
+		}
+	}
+	srv.publishReloadDirEvent(path.Dir(p))
+}
+
+// davInfo lets PROPFIND get the content type from the extension instead of
+// opening every file.
+type davInfo struct{ fs.FileInfo }
+
+func (i davInfo) ContentType(ctx context.Context) (string, error) {
+	if t := mime.TypeByExtension(path.Ext(i.Name())); t != "" {
+		return t, nil
+	}
+	return "", webdav.ErrNotImplemented
+}
+
+// davFile is an open file for reading.
+type davFile struct {
+	io.ReadSeekCloser
+	info davInfo
+}
+
+func (f *davFile) Readdir(int) ([]fs.FileInfo, error) { return nil, os.ErrInvalid }
+func (f *davFile) Stat() (fs.FileInfo, error)         { return f.info, nil }
+func (f *davFile) Write([]byte) (int, error)          { return 0, os.ErrPermission }
+
+// davDir is an open directory.
+type davDir struct {
+	fs      *davFS
+	path    string
+	info    davInfo
+	entries []fs.FileInfo
+	read    bool
+}
+
+func (d *davDir) Close() error                   { return nil }
+func (d *davDir) Read([]byte) (int, error)       { return 0, os.ErrInvalid }
+func (d *davDir) Seek(int64, int) (int64, error) { return 0, os.ErrInvalid }
+func (d *davDir) Write([]byte) (int, error)      { return 0, os.ErrInvalid }
+func (d *davDir) Stat() (fs.FileInfo, error)     { return d.info, nil }
+
+// Readdir lists the directory without its .hidden folder.
+func (d *davDir) Readdir(count int) ([]fs.FileInfo, error) {
+	if !d.read {
+		entries, err := d.fs.srv.storageReadDir(context.Background(), d.path)
+		if err != nil {
+			return nil, err
+		}
+		for _, e := range entries {
+			if e.Name() == ".hidden" {
+				continue
+			}
+			info, err := e.Info()
+			if err != nil {
+				continue
+			}
+			d.entries = append(d.entries, davInfo{info})
+		}
+		d.read = true
+	}
+	if count <= 0 {
+		out := d.entries
+		d.entries = nil
+		return out, nil
+	}
+	if len(d.entries) == 0 {
+		return nil, io.EOF
+	}
+	n := min(count, len(d.entries))
+	out := d.entries[:n]
+	d.entries = d.entries[n:]
+	return out, nil
+}
+
+// davWriter stages a write and commits it on Close.
+type davWriter struct {
+	fs      *davFS
+	ctx     context.Context
+	path    string
+	tmp     *os.File
+	existed bool
+	closed  bool
+}
+
+func (w *davWriter) Read(p []byte) (int, error)                { return w.tmp.Read(p) }
+func (w *davWriter) Seek(off int64, whence int) (int64, error) { return w.tmp.Seek(off, whence) }
+func (w *davWriter) Write(p []byte) (int, error)               { return w.tmp.Write(p) }
+func (w *davWriter) Readdir(int) ([]fs.FileInfo, error)        { return nil, os.ErrInvalid }
+
+func (w *davWriter) Stat() (fs.FileInfo, error) {
+	info, err := w.tmp.Stat()
+	if err != nil {
+		return nil, err
+	}
+	return davInfo{renamedInfo{info, path.Base(w.path)}}, nil
+}
+
+// Close writes the staged data to the storage backend. The content it
+// replaces is kept as a version, as for SaveFile.
+func (w *davWriter) Close() error {
+	if w.closed {
+		return nil
+	}
+	w.closed = true
+	defer w.discard()
+	if _, err := w.tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	srv := w.fs.srv
+	if w.existed {
+		srv.versionBeforeWrite(w.ctx, davToken(w.ctx), w.path)
+	}
+	dst, err := srv.storageCreate(w.ctx, w.path)
+	if err != nil {
+		return err
+	}
+	if _, err := io.Copy(dst, w.tmp); err != nil {
+		dst.Close()
+		return err
+	}
+	if err := dst.Close(); err != nil {
+		return err
+	}
+	w.fs.changed(w.ctx, w.path, !w.existed)
+	return nil
+}
+
+func (w *davWriter) discard() {
+	w.tmp.Close()
+	os.Remove(w.tmp.Name())
+}
+
+type renamedInfo struct {
+	fs.FileInfo
+	name string
+}
+
+func (i renamedInfo) Name() string { return i.name }
+
+// -----------------------------------------------------------------------------
+// HTTP handler
+// -----------------------------------------------------------------------------
+
+// davHandler authenticates and authorizes requests before handing them to
+// the WebDAV handler.
+type davHandler struct {
+	srv *server
+	dav *webdav.Handler
+
+	// authenticate returns the subject and session token of a request.
+	authenticate func(r *http.Request) (string, string, error)
+	// authorize checks an action on a path for a subject against RBAC.
+	authorize func(subject, action, permission, p string) (bool, error)
+
+	// login exchanges basic-auth credentials for a session token on behalf
+	// of the client at addr.
+	login func(user, password, addr string) (string, error)
+
+	tokens davCache // sha256(user:password) -> session token, successful logins only
+	perms  davCache // subject|action|permission|path -> allowed
+}
+
+// davCache is a map of entries that expire, holding at most davCacheSize of
+// them: when it is full, expired entries are swept and, if that is not
+// enough, the entry closest to expiry is dropped.
+type davCache struct {
+	mu      sync.Mutex
+	entries map[string]davCacheEntry
+}
+
+type davCacheEntry struct {
+	value   any
+	expires time.Time
+}
+
+func (c *davCache) get(key string) (any, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if !time.Now().Before(e.expires) {
+		delete(c.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+func (c *davCache) put(key string, value any, expires time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if c.entries == nil {
+		c.entries = make(map[string]davCacheEntry)
+	}
+	if _, ok := c.entries[key]; !ok && len(c.entries) >= davCacheSize {
+		now := time.Now()
+		for k, e := range c.entries {
+			if !now.Before(e.expires) {
+				delete(c.entries, k)
+			}
+		}
+		if len(c.entries) >= davCacheSize {
+			var oldest string
+			for k, e := range c.entries {
+				if oldest == "" || e.expires.Before(c.entries[oldest].expires) {
+					oldest = k
+				}
+			}
+			delete(c.entries, oldest)
+		}
+	}
+	c.entries[key] = davCacheEntry{value: value, expires: expires}
+}
+
+func (srv *server) newDavHandler() *davHandler {
+	h := &davHandler{
+		srv: srv,
+		dav: &webdav.Handler{
+			FileSystem: newDavFS(srv),
+			LockSystem: webdav.NewMemLS(),
+			Logger: func(r *http.Request, err error) {
+				if err != nil {
+					logger.Debug("webdav request failed", "method", r.Method, "path", r.URL.Path, "err", err)
+				}
+			},
+		},
+	}
+	h.authenticate = h.authenticateRequest
+	h.authorize = h.authorizeRBAC
+	h.login = loginFrom
+	return h
+}
+
+// davAccess is the RBAC action and permission a WebDAV method needs on the
+// request path, the same as the gRPC method doing the same thing.
+func davAccess(method string) (action, permission string) {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return "file.read", "read"
+	case "PROPFIND":
+		return "file.list", "read"
+	case http.MethodDelete:
+		return "file.delete", "delete"
+	case "COPY":
+		return "file.read", "read"
+	default: // PUT, MKCOL, MOVE, PROPPATCH, LOCK, UNLOCK
+		return "file.write", "write"
+	}
+}
+
+func (h *davHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	if r.Method == http.MethodOptions {
+		h.dav.ServeHTTP(w, r)
+		return
+	}
+	p := h.srv.formatPath(path.Clean("/" + r.URL.Path))
+	if isHiddenPath(p) {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	readOnly := r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == "PROPFIND"
+
+	subject, token, err := h.authenticate(r)
+	if err != nil {
+		// Public directories can be read without an account.
+		if readOnly && h.srv.isPublic(p) && r.Header.Get("Authorization") == "" {
+			h.dav.ServeHTTP(w, r)
+			return
+		}
+		w.Header().Set("WWW-Authenticate", `Basic realm="Globular", charset="UTF-8"`)
+		http.Error(w, "authentication required", http.StatusUnauthorized)
+		return
+	}
+
+	// The checks: the request path, plus the destination of MOVE and COPY.
+	action, permission := davAccess(r.Method)
+	checks := [][3]string{{action, permission, p}}
+	if r.Method == "MOVE" || r.Method == "COPY" {
+		dst, err := h.destination(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		checks = append(checks, [3]string{"file.write", "write", dst})
+	}
+	for _, c := range checks {
+		if readOnly && h.srv.isPublic(c[2]) {
+			continue
+		}
+		allowed, err := h.allowed(subject, c[0], c[1], c[2])
+		reason := "rbac_granted"
+		if !allowed {
+			reason = "rbac_denied"
+		}
+		interceptors.LogAuthzDecision(davAuditContext(r), &security.AuthContext{
+			Subject:       subject,
+			PrincipalType: "user",
+			AuthMethod:    "webdav",
+			GRPCMethod:    "WEBDAV " + r.Method,
+		}, allowed, reason, c[2], c[1], start)
+		if err != nil {
+			http.Error(w, "authorization unavailable", http.StatusServiceUnavailable)
+			return
+		}
+		if !allowed {
+			http.Error(w, "permission denied", http.StatusForbidden)
+			return
+		}
+	}
+
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("token", token))
+	if r.Method == http.MethodPut && r.Header.Get("Content-Range") != "" {
+		offset, err := h.rangeStart(ctx, r, p)
+		if err != nil {
+			w.Header().Set("Content-Range", "bytes */*")
+			http.Error(w, err.Error(), http.StatusRequestedRangeNotSatisfiable)
+			return
+		}
+		ctx = context.WithValue(ctx, davRangeKey{}, offset)
+	}
+	h.dav.ServeHTTP(w, r.WithContext(ctx))
+}
+
+// destination returns the formatted path of the Destination header.
+func (h *davHandler) destination(r *http.Request) (string, error) {
+	u, err := url.Parse(r.Header.Get("Destination"))
+	if err != nil || u.Path == "" {
+		return "", errors.New("invalid Destination header")
+	}
+	p := h.srv.formatPath(path.Clean("/" + u.Path))
+	if isHiddenPath(p) {
+		return "", errors.New("invalid Destination header")
+	}
+	return p, nil
+}
+
+// rangeStart parses the Content-Range of a partial PUT, "bytes start-end/total".
+// The range must start within or right at the end of the existing content.
+func (h *davHandler) rangeStart(ctx context.Context, r *http.Request, p string) (int64, error) {
+	spec, ok := strings.CutPrefix(r.Header.Get("Content-Range"), "bytes ")
+	if !ok {
+		return 0, errors.New("invalid Content-Range")
+	}
+	rng, _, _ := strings.Cut(spec, "/")
+	first, last, ok := strings.Cut(rng, "-")
+	if !ok {
+		return 0, errors.New("invalid Content-Range")
+	}
+	start, err1 := strconv.ParseInt(first, 10, 64)
+	end, err2 := strconv.ParseInt(last, 10, 64)
+	if err1 != nil || err2 != nil || start < 0 || end < start {
+		return 0, errors.New("invalid Content-Range")
+	}
+	var size int64
+	if info, err := h.srv.storageStat(ctx, p); err == nil {
+		if info.IsDir() {
+			return 0, errors.New("not a file")
+		}
+		size = info.Size()
+	}
+	if start > size {
+		return 0, fmt.Errorf("range starts at %d, past the end of the file (%d)", start, size)
+	}
+	return start, nil
+}
+
+func (h *davHandler) allowed(subject, action, permission, p string) (bool, error) {
+	key := subject + "|" + action + "|" + permission + "|" + p
+	if v, ok := h.perms.get(key); ok {
+		return v.(bool), nil
+	}
+	allowed, err := h.authorize(subject, action, permission, p)
+	if err != nil {
+		return false, err
+	}
+	ttl := davDenyTTL
+	if allowed {
+		ttl = davGrantTTL
+	}
+	h.perms.put(key, allowed, time.Now().Add(ttl))
+	return allowed, nil
+}
+
+func (h *davHandler) authorizeRBAC(subject, action, permission, p string) (bool, error) {
+	client, err := getRbacClient()
+	if err != nil {
+		return false, err
+	}
+	allowed, _, err := client.ValidateAction(action, subject, rbacpb.SubjectType_ACCOUNT, []*rbacpb.ResourceInfos{{Path: p, Permission: permission}})
+	if err != nil {
+		// A denial comes back as an error too.
+		return false, nil
+	}
+	return allowed, nil
+}
+
+// authenticateRequest accepts a session token, or basic auth exchanged for a
+// token with the authentication service. Basic auth is only taken over TLS
+// or from the local host.
+func (h *davHandler) authenticateRequest(r *http.Request) (string, string, error) {
+	token := strings.TrimSpace(r.Header.Get("token"))
+	if auth := r.Header.Get("Authorization"); token == "" && len(auth) > 7 && strings.EqualFold(auth[:7], "bearer ") {
+		token = strings.TrimSpace(auth[7:])
+	}
+	if token == "" {
+		user, password, ok := r.BasicAuth()
+		if !ok {
+			return "", "", errors.New("no credentials")
+		}
+		if r.TLS == nil && !isLoopbackRequest(r) {
+			return "", "", errors.New("basic auth requires TLS")
+		}
+		var err error
+		if token, err = h.exchange(user, password, clientAddr(r)); err != nil {
+			return "", "", err
+		}
+	}
+	ctx := metadata.NewIncomingContext(r.Context(), metadata.Pairs("token", token))
+	subject, token, err := security.GetClientId(ctx)
+	if err != nil {
+		return "", "", err
+	}
+	return subject, token, nil
+}
+
+// exchange returns a session token for basic-auth credentials, cached until
+// shortly before it expires so clients sending credentials on every request
+// don't log in each time. Failed logins are not cached; they reach the
+// authentication service, and its lockout, every time.
+func (h *davHandler) exchange(user, password, addr string) (string, error) {
+	sum := sha256.Sum256([]byte(user + ":" + password))
+	key := string(sum[:])
+	if v, ok := h.tokens.get(key); ok {
+		return v.(string), nil
+	}
+	token, err := h.login(user, password, addr)
+	if err != nil {
+		return "", err
+	}
+	claims, err := security.ValidateToken(token)
+	if err != nil {
+		return "", err
+	}
+	expires := time.Now().Add(davGrantTTL)
+	if claims.ExpiresAt != nil {
+		expires = claims.ExpiresAt.Time.Add(-davTokenMargin)
+	}
+	h.tokens.put(key, token, expires)
+	return token, nil
+}
+
+// loginFrom signs in with the authentication service, forwarding the
+// address of the WebDAV client so that failures count against it and not
+// against the file server's own address.
+func loginFrom(user, password, addr string) (string, error) {
+	client, err := getAuthenticationClient()
+	if err != nil {
+		return "", err
+	}
+	return client.AuthenticateFrom(user, password, addr)
+}
+
+// clientAddr is the IP address of the client of r.
+func clientAddr(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+func isLoopbackRequest(r *http.Request) bool {
+	ip := net.ParseIP(clientAddr(r))
+	return ip != nil && ip.IsLoopback()
+}
+
+// davAuditContext carries the client address the way the audit log reads
+// it from gRPC calls.
+func davAuditContext(r *http.Request) context.Context {
+	addr, err := net.ResolveTCPAddr("tcp", r.RemoteAddr)
+	if err != nil {
+		return r.Context()
+	}
+	return peer.NewContext(r.Context(), &peer.Peer{Addr: addr})
+}
+
+func getAuthenticationClient() (*authentication_client.Authentication_Client, error) {
+	address, _ := config.GetAddress()
+	Utility.RegisterFunction("NewAuthenticationService_Client", authentication_client.NewAuthenticationService_Client)
+	c, err := globular_client.GetClient(address, "authentication.AuthenticationService", "NewAuthenticationService_Client")
+	if err != nil {
+		return nil, err
+	}
+	return c.(*authentication_client.Authentication_Client), nil
+}
+
+// startWebDAV serves WebDAV on WebDavPort, over TLS when the service uses
+// TLS. A port of 0 disables it.
+func (srv *server) startWebDAV(ctx context.Context) {
+	if srv.WebDavPort == 0 {
+		return
+	}
+	srv.listenHTTP(ctx, "webdav", srv.WebDavPort, srv.newDavHandler())
+}
+
+// listenHTTP serves h on port until ctx is done, over TLS when the service
+// uses TLS.
+func (srv *server) listenHTTP(ctx context.Context, name string, port int, h http.Handler) {
+	ln, err := net.Listen("tcp", ":"+strconv.Itoa(port))
+	if err != nil {
+		logger.Error(name+": cannot listen", "port", port, "err", err)
+		return
+	}
+	hs := &http.Server{
+		Handler:           h,
+		ReadHeaderTimeout: 10 * time.Second,
+		IdleTimeout:       120 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		_ = hs.Shutdown(shutdownCtx)
+	}()
+	go func() {
+		var err error
+		if srv.TLS && srv.CertFile != "" && srv.KeyFile != "" {
+			logger.Info(name+" listening", "addr", ln.Addr().String(), "tls", true)
+			err = hs.ServeTLS(ln, filepath.Clean(srv.CertFile), filepath.Clean(srv.KeyFile))
+		} else {
+			logger.Info(name+" listening", "addr", ln.Addr().String(), "tls", false)
+			err = hs.Serve(ln)
+		}
+		if err != nil && err != http.ErrServerClosed {
+			logger.Error(name+" server error", "err", err)
+		}
+	}()
+}
@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newDavTestHandler serves a test server as "ann", who may do anything
+// but delete and write under /users/bob.
+func newDavTestHandler(t *testing.T) (*server, *davHandler, *[]string) {
+	srv := newRetentionTestServer(t)
+	srv.MaxFileVersions = 0
+	ctx := context.Background()
+	if err := srv.storageMkdirAll(ctx, "/users/ann/.hidden/a.txt", 0o755); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.storageMkdirAll(ctx, "/users/bob", 0o755); err != nil {
+		t.Fatal(err)
+	}
+
+	h := srv.newDavHandler()
+	var changed []string
+	h.dav.FileSystem.(*davFS).changed = func(ctx context.Context, p string, created bool) {
+		if davToken(ctx) != "tok" {
+			t.Errorf("change of %s without the request token", p)
+		}
+		if created {
+			p += " (new)"
+		}
+		changed = append(changed, p)
+	}
+	h.authenticate = func(r *http.Request) (string, string, error) {
+		if r.Header.Get("Authorization") != "Bearer tok" {
+			return "", "", errors.New("no credentials")
+		}
+		return "ann", "tok", nil
+	}
+	h.authorize = func(subject, action, permission, p string) (bool, error) {
+		return action != "file.delete" && !(permission == "write" && strings.HasPrefix(p, "/users/bob")), nil
+	}
+	return srv, h, &changed
+}
+
+func dav(h http.Handler, method, url, body string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(method, url, strings.NewReader(body))
+	r.Header.Set("Authorization", "Bearer tok")
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestWebDAVReadWrite(t *testing.T) {
+	srv, h, changed := newDavTestHandler(t)
+
+	if w := dav(h, http.MethodPut, "/users/ann/a.txt", "hello"); w.Code != http.StatusCreated {
+		t.Fatalf("PUT = %d %s", w.Code, w.Body.String())
+	}
+	if w := dav(h, http.MethodGet, "/users/ann/a.txt", "", "Range", "bytes=1-3"); w.Code != http.StatusPartialContent || w.Body.String() != "ell" {
+		t.Errorf("GET range = %d %q", w.Code, w.Body.String())
+	}
+
+	// A ranged PUT writes into the existing content.
+	if w := dav(h, http.MethodPut, "/users/ann/a.txt", " world", "Content-Range", "bytes 5-10/11"); w.Code != http.StatusCreated {
+		t.Fatalf("ranged PUT = %d %s", w.Code, w.Body.String())
+	}
+	if data, _ := srv.storageReadFile(context.Background(), "/users/ann/a.txt"); string(data) != "hello world" {
+		t.Errorf("content = %q", data)
+	}
+	if w := dav(h, http.MethodPut, "/users/ann/a.txt", "x", "Content-Range", "bytes 20-20/21"); w.Code != http.StatusRequestedRangeNotSatisfiable {
+		t.Errorf("PUT past the end = %d", w.Code)
+	}
+
+	if w := dav(h, "MKCOL", "/users/ann/docs", ""); w.Code != http.StatusCreated {
+		t.Errorf("MKCOL = %d", w.Code)
+	}
+	if w := dav(h, "MKCOL", "/users/ann/docs", ""); w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("MKCOL existing = %d", w.Code)
+	}
+	if got := strings.Join(*changed, ","); got != "/users/ann/a.txt (new),/users/ann/a.txt,/users/ann/docs (new)" {
+		t.Errorf("changes = %s", got)
+	}
+
+	w := dav(h, "PROPFIND", "/users/ann", "", "Depth", "1")
+	if w.Code != http.StatusMultiStatus {
+		t.Fatalf("PROPFIND = %d", w.Code)
+	}
+	body := w.Body.String()
+	if !strings.Contains(body, "/users/ann/a.txt") || !strings.Contains(body, "/users/ann/docs/") || strings.Contains(body, ".hidden") {
+		t.Errorf("PROPFIND body = %s", body)
+	}
+	if w := dav(h, http.MethodGet, "/users/ann/.hidden/a.txt", ""); w.Code != http.StatusNotFound {
+		t.Errorf("GET hidden = %d", w.Code)
+	}
+}
+
+func TestWebDAVAuth(t *testing.T) {
+	_, h, _ := newDavTestHandler(t)
+
+	r := httptest.NewRequest("PROPFIND", "/users/ann", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusUnauthorized || !strings.HasPrefix(w.Header().Get("WWW-Authenticate"), "Basic") {
+		t.Errorf("anonymous = %d %q", w.Code, w.Header().Get("WWW-Authenticate"))
+	}
+	r = httptest.NewRequest(http.MethodOptions, "/users/ann", nil)
+	w = httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusOK || w.Header().Get("DAV") == "" {
+		t.Errorf("OPTIONS = %d", w.Code)
+	}
+
+	dav(h, http.MethodPut, "/users/ann/a.txt", "a")
+	for _, c := range []struct {
+		method, url string
+		header      []string
+		code        int
+	}{
+		{http.MethodDelete, "/users/ann/a.txt", nil, http.StatusForbidden},
+		{http.MethodPut, "/users/bob/a.txt", nil, http.StatusForbidden},
+		{"MOVE", "/users/ann/a.txt", []string{"Destination", "http://example.com/users/bob/a.txt"}, http.StatusForbidden},
+		{"COPY", "/users/ann/a.txt", []string{"Destination", "http://example.com/users/bob/a.txt"}, http.StatusForbidden},
+		{"COPY", "/users/ann/a.txt", []string{"Destination", "http://example.com/users/ann/.hidden/a.txt"}, http.StatusBadRequest},
+		{http.MethodGet, "/users/bob", nil, http.StatusMethodNotAllowed},
+	} {
+		if w := dav(h, c.method, c.url, "", c.header...); w.Code != c.code {
+			t.Errorf("%s %s = %d, want %d", c.method, c.url, w.Code, c.code)
+		}
+	}
+}
+
+func TestDavAccess(t *testing.T) {
+	for method, want := range map[string]string{
+		"GET":       "file.read/read",
+		"PROPFIND":  "file.list/read",
+		"PUT":       "file.write/write",
+		"MKCOL":     "file.write/write",
+		"MOVE":      "file.write/write",
+		"COPY":      "file.read/read",
+		"LOCK":      "file.write/write",
+		"DELETE":    "file.delete/delete",
+		"PROPPATCH": "file.write/write",
+	} {
+		action, permission := davAccess(method)
+		if got := action + "/" + permission; got != want {
+			t.Errorf("davAccess(%s) = %s, want %s", method, got, want)
+		}
+	}
+}
+
+func TestDavCache(t *testing.T) {
+	var c davCache
+	c.put("old", 1, time.Now().Add(-time.Second))
+	if _, ok := c.get("old"); ok {
+		t.Error("expired entry returned")
+	}
+
+	for i := range davCacheSize {
+		c.put(fmt.Sprint(i), i, time.Now().Add(time.Hour+time.Duration(i)*time.Second))
+	}
+	c.put("new", -1, time.Now().Add(2*time.Hour))
+	if len(c.entries) != davCacheSize {
+		t.Errorf("%d entries, cap is %d", len(c.entries), davCacheSize)
+	}
+	if _, ok := c.get("0"); ok {
+		t.Error("entry closest to expiry kept")
+	}
+	if v, ok := c.get("new"); !ok || v != -1 {
+		t.Errorf("new entry = %v, %v", v, ok)
+	}
+}
+
+func TestDavExchange(t *testing.T) {
+	_, h, _ := newDavTestHandler(t)
+	var addrs []string
+	h.login = func(user, password, addr string) (string, error) {
+		addrs = append(addrs, addr)
+		return "", errors.New("wrong password")
+	}
+
+	// Failed logins are not cached: each one reaches the authentication
+	// service with the client's address, for its lockout to count.
+	for range 2 {
+		if _, err := h.exchange("ann", "guess", "203.0.113.7"); err == nil {
+			t.Fatal("exchange succeeded")
+		}
+	}
+	if strings.Join(addrs, ",") != "203.0.113.7,203.0.113.7" {
+		t.Errorf("login addresses = %v", addrs)
+	}
+	if len(h.tokens.entries) != 0 {
+		t.Errorf("%d tokens cached", len(h.tokens.entries))
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.RemoteAddr = "203.0.113.7:51234"
+	if got := clientAddr(r); got != "203.0.113.7" {
+		t.Errorf("clientAddr = %q", got)
+	}
+}
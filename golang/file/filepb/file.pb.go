@@ -1027,7 +1027,9 @@ func (x *GetFileMetadataResponse) GetResult() *structpb.Struct {
 // Request to read a file as binary data.
 type ReadFileRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // The path of the file to read.
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`      // The path of the file to read.
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"` // First byte to read.
+	Length        int64                  `protobuf:"varint,3,opt,name=length,proto3" json:"length,omitempty"` // Number of bytes to read; 0 reads to the end of the file.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1069,6 +1071,20 @@ func (x *ReadFileRequest) GetPath() string {
 	return ""
 }
 
+func (x *ReadFileRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *ReadFileRequest) GetLength() int64 {
+	if x != nil {
+		return x.Length
+	}
+	return 0
+}
+
 // Response containing the file's binary data.
 type ReadFileResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2540,27 +2556,29 @@ func (x *IndexFileResponse) GetTotal() int32 {
 	return 0
 }
 
-// StopRequest is the request message to stop the server.
-type StopRequest struct {
+type BeginUploadRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`     // Destination path of the file.
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`    // Total size in bytes, 0 if unknown.
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"` // Expected SHA-256 of the file (hex), checked on commit; optional.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopRequest) Reset() {
-	*x = StopRequest{}
+func (x *BeginUploadRequest) Reset() {
+	*x = BeginUploadRequest{}
 	mi := &file_file_proto_msgTypes[47]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopRequest) String() string {
+func (x *BeginUploadRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopRequest) ProtoMessage() {}
+func (*BeginUploadRequest) ProtoMessage() {}
 
-func (x *StopRequest) ProtoReflect() protoreflect.Message {
+func (x *BeginUploadRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_file_proto_msgTypes[47]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2572,32 +2590,54 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
-func (*StopRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BeginUploadRequest.ProtoReflect.Descriptor instead.
+func (*BeginUploadRequest) Descriptor() ([]byte, []int) {
 	return file_file_proto_rawDescGZIP(), []int{47}
 }
 
-// StopResponse is the response message when the server is stopped.
-type StopResponse struct {
+func (x *BeginUploadRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *BeginUploadRequest) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *BeginUploadRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type BeginUploadResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"`     // Session ID.
+	ExpiresAt     int64                  `protobuf:"varint,2,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // When the session expires without new chunks (unix seconds).
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopResponse) Reset() {
-	*x = StopResponse{}
+func (x *BeginUploadResponse) Reset() {
+	*x = BeginUploadResponse{}
 	mi := &file_file_proto_msgTypes[48]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopResponse) String() string {
+func (x *BeginUploadResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopResponse) ProtoMessage() {}
+func (*BeginUploadResponse) ProtoMessage() {}
 
-func (x *StopResponse) ProtoReflect() protoreflect.Message {
+func (x *BeginUploadResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_file_proto_msgTypes[48]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2609,223 +2649,1936 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
-func (*StopResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BeginUploadResponse.ProtoReflect.Descriptor instead.
+func (*BeginUploadResponse) Descriptor() ([]byte, []int) {
 	return file_file_proto_rawDescGZIP(), []int{48}
 }
 
-var File_file_proto protoreflect.FileDescriptor
+func (x *BeginUploadResponse) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
 
-const file_file_proto_rawDesc = "" +
-	"\n" +
-	"\n" +
-	"file.proto\x12\x04file\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13globular_auth.proto\"\a\n" +
-	"\x05Empty\"\xb7\x02\n" +
-	"\bFileInfo\x12\x12\n" +
-	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
-	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
-	"\x04mode\x18\x03 \x01(\rR\x04mode\x12\x1b\n" +
-	"\tmode_time\x18\x04 \x01(\x03R\bmodeTime\x12\x15\n" +
-	"\x06is_dir\x18\x05 \x01(\bR\x05isDir\x12\x12\n" +
-	"\x04path\x18\x06 \x01(\tR\x04path\x12\x12\n" +
-	"\x04mime\x18\a \x01(\tR\x04mime\x12\x1c\n" +
-	"\tthumbnail\x18\b \x01(\tR\tthumbnail\x12\x1a\n" +
-	"\bchecksum\x18\t \x01(\tR\bchecksum\x123\n" +
-	"\bmetadata\x18\n" +
-	" \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12$\n" +
-	"\x05files\x18\v \x03(\v2\x0e.file.FileInfoR\x05files\"\xa2\x01\n" +
-	"\x0eReadDirRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x1c\n" +
-	"\trecursive\x18\x02 \x01(\bR\trecursive\x12&\n" +
-	"\x0ethumbnailWidth\x18\x03 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
-	"\x0fthumbnailHeight\x18\x04 \x01(\x05R\x0fthumbnailHeight\"5\n" +
-	"\x0fReadDirResponse\x12\"\n" +
-	"\x04info\x18\x01 \x01(\v2\x0e.file.FileInfoR\x04info\"H\n" +
-	"\x10CreateDirRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"+\n" +
-	"\x11CreateDirResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"4\n" +
-	"\x10DeleteDirRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\"+\n" +
-	"\x11DeleteDirResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"g\n" +
-	"\rRenameRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x19\n" +
-	"\bnew_name\x18\x02 \x01(\tR\anewName\x12\x19\n" +
-	"\bold_name\x18\x03 \x01(\tR\aoldName\"(\n" +
-	"\x0eRenameResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"E\n" +
-	"\vCopyRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x14\n" +
-	"\x05files\x18\x02 \x03(\tR\x05files\"&\n" +
-	"\fCopyResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"E\n" +
-	"\vMoveRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x14\n" +
-	"\x05files\x18\x02 \x03(\tR\x05files\"&\n" +
-	"\fMoveResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\x88\x01\n" +
-	"\x12GetFileInfoRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12&\n" +
-	"\x0ethumbnailWidth\x18\x02 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
-	"\x0fthumbnailHeight\x18\x03 \x01(\x05R\x0fthumbnailHeight\"9\n" +
-	"\x13GetFileInfoResponse\x12\"\n" +
-	"\x04info\x18\x01 \x01(\v2\x0e.file.FileInfoR\x04info\":\n" +
-	"\x16GetFileMetadataRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\"J\n" +
-	"\x17GetFileMetadataResponse\x12/\n" +
-	"\x06result\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06result\"3\n" +
-	"\x0fReadFileRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\"&\n" +
-	"\x10ReadFileResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"E\n" +
-	"\x0fSaveFileRequest\x12\x14\n" +
-	"\x04path\x18\x01 \x01(\tH\x00R\x04path\x12\x14\n" +
-	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\x06\n" +
-	"\x04file\"*\n" +
-	"\x10SaveFileResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"5\n" +
-	"\x11DeleteFileRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\",\n" +
-	"\x12DeleteFileResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\xa8\x01\n" +
-	"\x14GetThumbnailsRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x1c\n" +
-	"\trecursive\x18\x02 \x01(\bR\trecursive\x12&\n" +
-	"\x0ethumbnailWidth\x18\x03 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
-	"\x0fthumbnailHeight\x18\x04 \x01(\x05R\x0fthumbnailHeight\"+\n" +
-	"\x15GetThumbnailsResponse\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"L\n" +
-	"\x14CreateArchiveRequest\x12 \n" +
-	"\x05paths\x18\x01 \x03(\tB\n" +
-	"\x8a\xb5\x18\x06\n" +
-	"\x04pathR\x05paths\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\"/\n" +
-	"\x15CreateArchiveResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"Z\n" +
-	"\x10CreateLnkRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
-	"\x03lnk\x18\x03 \x01(\tR\x03lnk\"\x13\n" +
-	"\x11CreateLnkResponse\"\xa7\x01\n" +
-	"\rPublicDirInfo\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12'\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x13.file.PublicDirTypeR\x04type\x12\x17\n" +
-	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12!\n" +
-	"\fnode_address\x18\x04 \x01(\tR\vnodeAddress\x12\x1d\n" +
-	"\n" +
-	"local_path\x18\x05 \x01(\tR\tlocalPath\"`\n" +
-	"\x13AddPublicDirRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12'\n" +
-	"\x04type\x18\x02 \x01(\x0e2\x13.file.PublicDirTypeR\x04type\"?\n" +
-	"\x14AddPublicDirResponse\x12'\n" +
-	"\x04info\x18\x01 \x01(\v2\x13.file.PublicDirInfoR\x04info\":\n" +
-	"\x16RemovePublicDirRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\"\x19\n" +
-	"\x17RemovePublicDirResponse\"\x16\n" +
-	"\x14GetPublicDirsRequest\"a\n" +
-	"\x15GetPublicDirsResponse\x12\x12\n" +
-	"\x04dirs\x18\x01 \x03(\tR\x04dirs\x124\n" +
-	"\vpublic_dirs\x18\x02 \x03(\v2\x13.file.PublicDirInfoR\n" +
-	"publicDirs\"M\n" +
-	"\x15WriteExcelFileRequest\x12 \n" +
-	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04path\x12\x12\n" +
-	"\x04data\x18\x02 \x01(\tR\x04data\"0\n" +
-	"\x16WriteExcelFileResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"#\n" +
-	"\rHtmlToPdfRqst\x12\x12\n" +
-	"\x04html\x18\x01 \x01(\tR\x04html\"%\n" +
-	"\x11HtmlToPdfResponse\x12\x10\n" +
-	"\x03pdf\x18\x01 \x01(\fR\x03pdf\"\x89\x01\n" +
-	"\x11UploadFileRequest\x12\x10\n" +
-	"\x03url\x18\x01 \x01(\tR\x03url\x12 \n" +
-	"\x04dest\x18\x02 \x01(\tB\f\x8a\xb5\x18\b\n" +
-	"\x04path\x10\x01R\x04dest\x12\x12\n" +
-	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
-	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x14\n" +
-	"\x05isDir\x18\x05 \x01(\bR\x05isDir\"Z\n" +
-	"\x12UploadFileResponse\x12\x1a\n" +
-	"\buploaded\x18\x01 \x01(\x03R\buploaded\x12\x14\n" +
-	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
-	"\x04info\x18\x03 \x01(\tR\x04info\"F\n" +
-	"\x12FindIndexesRequest\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
-	"\trecursive\x18\x02 \x01(\bR\trecursive\"+\n" +
-	"\x13FindIndexesResponse\x12\x14\n" +
-	"\x05paths\x18\x01 \x03(\tR\x05paths\"Z\n" +
-	"\x10IndexFileRequest\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
-	"\trecursive\x18\x02 \x01(\bR\trecursive\x12\x14\n" +
-	"\x05force\x18\x03 \x01(\bR\x05force\"\x89\x01\n" +
-	"\x11IndexFileResponse\x12\x12\n" +
-	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
-	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
-	"\amessage\x18\x03 \x01(\tR\amessage\x12\x18\n" +
-	"\aindexed\x18\x04 \x01(\x05R\aindexed\x12\x14\n" +
-	"\x05total\x18\x05 \x01(\x05R\x05total\"\r\n" +
-	"\vStopRequest\"\x0e\n" +
-	"\fStopResponse*T\n" +
-	"\rPublicDirType\x12\x14\n" +
-	"\x10PUBLIC_DIR_LOCAL\x10\x00\x12\x14\n" +
-	"\x10PUBLIC_DIR_MINIO\x10\x01\x12\x17\n" +
-	"\x13PUBLIC_DIR_EXTERNAL\x10\x022\xd1\x14\n" +
-	"\vFileService\x12T\n" +
-	"\x04Stop\x12\x11.file.StopRequest\x1a\x12.file.StopResponse\"%\x82\xb5\x18!\n" +
-	"\n" +
-	"file.admin\x12\x05admin\x1a\x05/file*\x05admin\x12z\n" +
-	"\fAddPublicDir\x12\x19.file.AddPublicDirRequest\x1a\x1a.file.AddPublicDirResponse\"3\x82\xb5\x18/\n" +
-	"\ffile.publish\x12\x05admin\x1a\x11/file/path/{path}*\x05admin\x12\x83\x01\n" +
-	"\x0fRemovePublicDir\x12\x1c.file.RemovePublicDirRequest\x1a\x1d.file.RemovePublicDirResponse\"3\x82\xb5\x18/\n" +
-	"\ffile.publish\x12\x05admin\x1a\x11/file/path/{path}*\x05admin\x12}\n" +
-	"\rGetPublicDirs\x12\x1a.file.GetPublicDirsRequest\x1a\x1b.file.GetPublicDirsResponse\"3\x82\xb5\x18/\n" +
-	"\ffile.publish\x12\x04read\"\x11/file/public-dirs*\x06viewer\x12j\n" +
-	"\aReadDir\x12\x14.file.ReadDirRequest\x1a\x15.file.ReadDirResponse\"0\x82\xb5\x18,\n" +
-	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer0\x01\x12p\n" +
-	"\tCreateDir\x12\x16.file.CreateDirRequest\x1a\x17.file.CreateDirResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
-	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12p\n" +
-	"\tCreateLnk\x12\x16.file.CreateLnkRequest\x1a\x17.file.CreateLnkResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
-	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12q\n" +
-	"\tDeleteDir\x12\x16.file.DeleteDirRequest\x1a\x17.file.DeleteDirResponse\"3\x82\xb5\x18/\n" +
-	"\vfile.delete\x12\x06delete\x1a\x11/file/path/{path}*\x05admin\x12g\n" +
-	"\x06Rename\x12\x13.file.RenameRequest\x1a\x14.file.RenameResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
-	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12a\n" +
-	"\x04Move\x12\x11.file.MoveRequest\x1a\x12.file.MoveResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
-	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12a\n" +
-	"\x04Copy\x12\x11.file.CopyRequest\x1a\x12.file.CopyResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
-	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12u\n" +
-	"\rCreateArchive\x12\x1a.file.CreateArchiveRequest\x1a\x1b.file.CreateArchiveResponse\"+\x82\xb5\x18'\n" +
-	"\n" +
-	"file.write\x12\x05write\"\n" +
-	"/file/path*\x06editor\x12t\n" +
-	"\vGetFileInfo\x12\x18.file.GetFileInfoRequest\x1a\x19.file.GetFileInfoResponse\"0\x82\xb5\x18,\n" +
-	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12\x80\x01\n" +
-	"\x0fGetFileMetadata\x12\x1c.file.GetFileMetadataRequest\x1a\x1d.file.GetFileMetadataResponse\"0\x82\xb5\x18,\n" +
-	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12m\n" +
-	"\bReadFile\x12\x15.file.ReadFileRequest\x1a\x16.file.ReadFileResponse\"0\x82\xb5\x18,\n" +
-	"\tfile.read\x12\x04read\x1a\x11/file/path/{path}*\x06viewer0\x01\x12o\n" +
-	"\bSaveFile\x12\x15.file.SaveFileRequest\x1a\x16.file.SaveFileResponse\"2\x82\xb5\x18.\n" +
-	"\n" +
+func (x *BeginUploadResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type UploadChunkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // Session ID.
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                    // Offset of data in the file; must not be past the committed offset.
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`                         // Chunk data; bytes already committed are skipped.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunkRequest) Reset() {
+	*x = UploadChunkRequest{}
+	mi := &file_file_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunkRequest) ProtoMessage() {}
+
+func (x *UploadChunkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunkRequest.ProtoReflect.Descriptor instead.
+func (*UploadChunkRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *UploadChunkRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *UploadChunkRequest) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *UploadChunkRequest) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+type UploadChunkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Offset        int64                  `protobuf:"varint,1,opt,name=offset,proto3" json:"offset,omitempty"` // Committed offset after the chunk.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UploadChunkResponse) Reset() {
+	*x = UploadChunkResponse{}
+	mi := &file_file_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UploadChunkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UploadChunkResponse) ProtoMessage() {}
+
+func (x *UploadChunkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UploadChunkResponse.ProtoReflect.Descriptor instead.
+func (*UploadChunkResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *UploadChunkResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+type GetUploadStatusRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // Session ID.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusRequest) Reset() {
+	*x = GetUploadStatusRequest{}
+	mi := &file_file_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusRequest) ProtoMessage() {}
+
+func (x *GetUploadStatusRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusRequest.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *GetUploadStatusRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type GetUploadStatusResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                             // Destination path of the file.
+	Offset        int64                  `protobuf:"varint,2,opt,name=offset,proto3" json:"offset,omitempty"`                        // Committed offset: the next chunk starts here.
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`                            // Total size given to BeginUpload, 0 if unknown.
+	ExpiresAt     int64                  `protobuf:"varint,4,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"` // When the session expires without new chunks (unix seconds).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetUploadStatusResponse) Reset() {
+	*x = GetUploadStatusResponse{}
+	mi := &file_file_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetUploadStatusResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetUploadStatusResponse) ProtoMessage() {}
+
+func (x *GetUploadStatusResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetUploadStatusResponse.ProtoReflect.Descriptor instead.
+func (*GetUploadStatusResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *GetUploadStatusResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *GetUploadStatusResponse) GetOffset() int64 {
+	if x != nil {
+		return x.Offset
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *GetUploadStatusResponse) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type CommitUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // Session ID.
+	Sha256        string                 `protobuf:"bytes,2,opt,name=sha256,proto3" json:"sha256,omitempty"`                     // SHA-256 of the whole file (hex); required unless given to BeginUpload.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitUploadRequest) Reset() {
+	*x = CommitUploadRequest{}
+	mi := &file_file_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitUploadRequest) ProtoMessage() {}
+
+func (x *CommitUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitUploadRequest.ProtoReflect.Descriptor instead.
+func (*CommitUploadRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *CommitUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+func (x *CommitUploadRequest) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type CommitUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`     // Path of the written file.
+	Size          int64                  `protobuf:"varint,2,opt,name=size,proto3" json:"size,omitempty"`    // Size in bytes.
+	Sha256        string                 `protobuf:"bytes,3,opt,name=sha256,proto3" json:"sha256,omitempty"` // SHA-256 of the file (hex).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitUploadResponse) Reset() {
+	*x = CommitUploadResponse{}
+	mi := &file_file_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitUploadResponse) ProtoMessage() {}
+
+func (x *CommitUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitUploadResponse.ProtoReflect.Descriptor instead.
+func (*CommitUploadResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *CommitUploadResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CommitUploadResponse) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *CommitUploadResponse) GetSha256() string {
+	if x != nil {
+		return x.Sha256
+	}
+	return ""
+}
+
+type AbortUploadRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	UploadId      string                 `protobuf:"bytes,1,opt,name=upload_id,json=uploadId,proto3" json:"upload_id,omitempty"` // Session ID.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadRequest) Reset() {
+	*x = AbortUploadRequest{}
+	mi := &file_file_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadRequest) ProtoMessage() {}
+
+func (x *AbortUploadRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadRequest.ProtoReflect.Descriptor instead.
+func (*AbortUploadRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *AbortUploadRequest) GetUploadId() string {
+	if x != nil {
+		return x.UploadId
+	}
+	return ""
+}
+
+type AbortUploadResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AbortUploadResponse) Reset() {
+	*x = AbortUploadResponse{}
+	mi := &file_file_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AbortUploadResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AbortUploadResponse) ProtoMessage() {}
+
+func (x *AbortUploadResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AbortUploadResponse.ProtoReflect.Descriptor instead.
+func (*AbortUploadResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{56}
+}
+
+// A previous content of a file, kept when the file was overwritten.
+type FileVersion struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`            // Version ID, sortable by creation time.
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`        // Path of the file.
+	Size          int64                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`       // Size in bytes.
+	Created       int64                  `protobuf:"varint,4,opt,name=created,proto3" json:"created,omitempty"` // When the content was replaced (unix seconds).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FileVersion) Reset() {
+	*x = FileVersion{}
+	mi := &file_file_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FileVersion) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FileVersion) ProtoMessage() {}
+
+func (x *FileVersion) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FileVersion.ProtoReflect.Descriptor instead.
+func (*FileVersion) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *FileVersion) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *FileVersion) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *FileVersion) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *FileVersion) GetCreated() int64 {
+	if x != nil {
+		return x.Created
+	}
+	return 0
+}
+
+type ListFileVersionsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Path of the file.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsRequest) Reset() {
+	*x = ListFileVersionsRequest{}
+	mi := &file_file_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsRequest) ProtoMessage() {}
+
+func (x *ListFileVersionsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsRequest.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListFileVersionsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListFileVersionsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Versions      []*FileVersion         `protobuf:"bytes,1,rep,name=versions,proto3" json:"versions,omitempty"` // Newest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListFileVersionsResponse) Reset() {
+	*x = ListFileVersionsResponse{}
+	mi := &file_file_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListFileVersionsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListFileVersionsResponse) ProtoMessage() {}
+
+func (x *ListFileVersionsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListFileVersionsResponse.ProtoReflect.Descriptor instead.
+func (*ListFileVersionsResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *ListFileVersionsResponse) GetVersions() []*FileVersion {
+	if x != nil {
+		return x.Versions
+	}
+	return nil
+}
+
+// Request to put a previous content back; the current content is kept as a version.
+type RestoreFileVersionRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                            // Path of the file.
+	VersionId     string                 `protobuf:"bytes,2,opt,name=version_id,json=versionId,proto3" json:"version_id,omitempty"` // Version to restore.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileVersionRequest) Reset() {
+	*x = RestoreFileVersionRequest{}
+	mi := &file_file_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileVersionRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileVersionRequest) ProtoMessage() {}
+
+func (x *RestoreFileVersionRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileVersionRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFileVersionRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *RestoreFileVersionRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreFileVersionRequest) GetVersionId() string {
+	if x != nil {
+		return x.VersionId
+	}
+	return ""
+}
+
+type RestoreFileVersionResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Previous      *FileVersion           `protobuf:"bytes,1,opt,name=previous,proto3" json:"previous,omitempty"` // The version the replaced content became, unset if it was not kept.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFileVersionResponse) Reset() {
+	*x = RestoreFileVersionResponse{}
+	mi := &file_file_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFileVersionResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFileVersionResponse) ProtoMessage() {}
+
+func (x *RestoreFileVersionResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFileVersionResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFileVersionResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *RestoreFileVersionResponse) GetPrevious() *FileVersion {
+	if x != nil {
+		return x.Previous
+	}
+	return nil
+}
+
+// A file or directory deleted by DeleteFile or DeleteDir.
+type TrashItem struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                         // Trash item ID.
+	OriginalPath  string                 `protobuf:"bytes,2,opt,name=original_path,json=originalPath,proto3" json:"original_path,omitempty"` // Where the item was deleted from.
+	Name          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`                                     // Base name of the item.
+	IsDir         bool                   `protobuf:"varint,4,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`                     // True for a directory.
+	Size          int64                  `protobuf:"varint,5,opt,name=size,proto3" json:"size,omitempty"`                                    // Size in bytes, the files of a directory included.
+	DeletedAt     int64                  `protobuf:"varint,6,opt,name=deleted_at,json=deletedAt,proto3" json:"deleted_at,omitempty"`         // Deletion time (unix seconds).
+	DeletedBy     string                 `protobuf:"bytes,7,opt,name=deleted_by,json=deletedBy,proto3" json:"deleted_by,omitempty"`          // Subject that deleted the item.
+	ExpiresAt     int64                  `protobuf:"varint,8,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`         // When the item is purged (unix seconds).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *TrashItem) Reset() {
+	*x = TrashItem{}
+	mi := &file_file_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *TrashItem) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*TrashItem) ProtoMessage() {}
+
+func (x *TrashItem) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use TrashItem.ProtoReflect.Descriptor instead.
+func (*TrashItem) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *TrashItem) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *TrashItem) GetOriginalPath() string {
+	if x != nil {
+		return x.OriginalPath
+	}
+	return ""
+}
+
+func (x *TrashItem) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *TrashItem) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *TrashItem) GetSize() int64 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *TrashItem) GetDeletedAt() int64 {
+	if x != nil {
+		return x.DeletedAt
+	}
+	return 0
+}
+
+func (x *TrashItem) GetDeletedBy() string {
+	if x != nil {
+		return x.DeletedBy
+	}
+	return ""
+}
+
+func (x *TrashItem) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+type ListTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Any path of the home whose trash to list, e.g. /users/alice.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashRequest) Reset() {
+	*x = ListTrashRequest{}
+	mi := &file_file_proto_msgTypes[63]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashRequest) ProtoMessage() {}
+
+func (x *ListTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[63]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashRequest.ProtoReflect.Descriptor instead.
+func (*ListTrashRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{63}
+}
+
+func (x *ListTrashRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*TrashItem           `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Newest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListTrashResponse) Reset() {
+	*x = ListTrashResponse{}
+	mi := &file_file_proto_msgTypes[64]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListTrashResponse) ProtoMessage() {}
+
+func (x *ListTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[64]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListTrashResponse.ProtoReflect.Descriptor instead.
+func (*ListTrashResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{64}
+}
+
+func (x *ListTrashResponse) GetItems() []*TrashItem {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type RestoreFromTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`               // Any path of the home the item was deleted from.
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`                   // Trash item ID.
+	Destination   string                 `protobuf:"bytes,3,opt,name=destination,proto3" json:"destination,omitempty"` // Restore to this path instead of the original one.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFromTrashRequest) Reset() {
+	*x = RestoreFromTrashRequest{}
+	mi := &file_file_proto_msgTypes[65]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFromTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFromTrashRequest) ProtoMessage() {}
+
+func (x *RestoreFromTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[65]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFromTrashRequest.ProtoReflect.Descriptor instead.
+func (*RestoreFromTrashRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{65}
+}
+
+func (x *RestoreFromTrashRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RestoreFromTrashRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RestoreFromTrashRequest) GetDestination() string {
+	if x != nil {
+		return x.Destination
+	}
+	return ""
+}
+
+type RestoreFromTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Path of the restored item.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RestoreFromTrashResponse) Reset() {
+	*x = RestoreFromTrashResponse{}
+	mi := &file_file_proto_msgTypes[66]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RestoreFromTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RestoreFromTrashResponse) ProtoMessage() {}
+
+func (x *RestoreFromTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[66]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RestoreFromTrashResponse.ProtoReflect.Descriptor instead.
+func (*RestoreFromTrashResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{66}
+}
+
+func (x *RestoreFromTrashResponse) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type EmptyTrashRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Any path of the home whose trash to empty.
+	Ids           []string               `protobuf:"bytes,2,rep,name=ids,proto3" json:"ids,omitempty"`   // Items to purge; all items when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyTrashRequest) Reset() {
+	*x = EmptyTrashRequest{}
+	mi := &file_file_proto_msgTypes[67]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashRequest) ProtoMessage() {}
+
+func (x *EmptyTrashRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[67]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashRequest.ProtoReflect.Descriptor instead.
+func (*EmptyTrashRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{67}
+}
+
+func (x *EmptyTrashRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *EmptyTrashRequest) GetIds() []string {
+	if x != nil {
+		return x.Ids
+	}
+	return nil
+}
+
+type EmptyTrashResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Removed       int32                  `protobuf:"varint,1,opt,name=removed,proto3" json:"removed,omitempty"` // Number of items purged.
+	Freed         int64                  `protobuf:"varint,2,opt,name=freed,proto3" json:"freed,omitempty"`     // Bytes freed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *EmptyTrashResponse) Reset() {
+	*x = EmptyTrashResponse{}
+	mi := &file_file_proto_msgTypes[68]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *EmptyTrashResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*EmptyTrashResponse) ProtoMessage() {}
+
+func (x *EmptyTrashResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[68]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use EmptyTrashResponse.ProtoReflect.Descriptor instead.
+func (*EmptyTrashResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{68}
+}
+
+func (x *EmptyTrashResponse) GetRemoved() int32 {
+	if x != nil {
+		return x.Removed
+	}
+	return 0
+}
+
+func (x *EmptyTrashResponse) GetFreed() int64 {
+	if x != nil {
+		return x.Freed
+	}
+	return 0
+}
+
+type ShareLink struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                          // Link ID, also the ID of the token.
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                      // Shared file or directory.
+	IsDir         bool                   `protobuf:"varint,3,opt,name=is_dir,json=isDir,proto3" json:"is_dir,omitempty"`                      // True for a directory.
+	Permissions   []string               `protobuf:"bytes,4,rep,name=permissions,proto3" json:"permissions,omitempty"`                        // "read" and, for a directory, "list".
+	CreatedBy     string                 `protobuf:"bytes,5,opt,name=created_by,json=createdBy,proto3" json:"created_by,omitempty"`           // Subject that created the link.
+	CreatedAt     int64                  `protobuf:"varint,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`          // Creation time (unix seconds).
+	ExpiresAt     int64                  `protobuf:"varint,7,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // Expiry time (unix seconds).
+	HasPassword   bool                   `protobuf:"varint,8,opt,name=has_password,json=hasPassword,proto3" json:"has_password,omitempty"`    // True when a password is required.
+	MaxDownloads  int64                  `protobuf:"varint,9,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"` // Download limit, 0 for none.
+	Downloads     int64                  `protobuf:"varint,10,opt,name=downloads,proto3" json:"downloads,omitempty"`                          // Downloads so far.
+	Revoked       bool                   `protobuf:"varint,11,opt,name=revoked,proto3" json:"revoked,omitempty"`                              // True once revoked.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ShareLink) Reset() {
+	*x = ShareLink{}
+	mi := &file_file_proto_msgTypes[69]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ShareLink) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ShareLink) ProtoMessage() {}
+
+func (x *ShareLink) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[69]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ShareLink.ProtoReflect.Descriptor instead.
+func (*ShareLink) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{69}
+}
+
+func (x *ShareLink) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ShareLink) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *ShareLink) GetIsDir() bool {
+	if x != nil {
+		return x.IsDir
+	}
+	return false
+}
+
+func (x *ShareLink) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *ShareLink) GetCreatedBy() string {
+	if x != nil {
+		return x.CreatedBy
+	}
+	return ""
+}
+
+func (x *ShareLink) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *ShareLink) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *ShareLink) GetHasPassword() bool {
+	if x != nil {
+		return x.HasPassword
+	}
+	return false
+}
+
+func (x *ShareLink) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+func (x *ShareLink) GetDownloads() int64 {
+	if x != nil {
+		return x.Downloads
+	}
+	return 0
+}
+
+func (x *ShareLink) GetRevoked() bool {
+	if x != nil {
+		return x.Revoked
+	}
+	return false
+}
+
+type CreateShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`                                      // File or directory to share.
+	Permissions   []string               `protobuf:"bytes,2,rep,name=permissions,proto3" json:"permissions,omitempty"`                        // "read", "list"; defaults to read, plus list for a directory.
+	ExpiresAt     int64                  `protobuf:"varint,3,opt,name=expires_at,json=expiresAt,proto3" json:"expires_at,omitempty"`          // Expiry time (unix seconds); defaults to 7 days, at most 365.
+	Password      string                 `protobuf:"bytes,4,opt,name=password,proto3" json:"password,omitempty"`                              // Password asked on access; optional.
+	MaxDownloads  int64                  `protobuf:"varint,5,opt,name=max_downloads,json=maxDownloads,proto3" json:"max_downloads,omitempty"` // Download limit, 0 for none.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkRequest) Reset() {
+	*x = CreateShareLinkRequest{}
+	mi := &file_file_proto_msgTypes[70]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkRequest) ProtoMessage() {}
+
+func (x *CreateShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[70]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{70}
+}
+
+func (x *CreateShareLinkRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetPermissions() []string {
+	if x != nil {
+		return x.Permissions
+	}
+	return nil
+}
+
+func (x *CreateShareLinkRequest) GetExpiresAt() int64 {
+	if x != nil {
+		return x.ExpiresAt
+	}
+	return 0
+}
+
+func (x *CreateShareLinkRequest) GetPassword() string {
+	if x != nil {
+		return x.Password
+	}
+	return ""
+}
+
+func (x *CreateShareLinkRequest) GetMaxDownloads() int64 {
+	if x != nil {
+		return x.MaxDownloads
+	}
+	return 0
+}
+
+type CreateShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"`   // The created link.
+	Token         string                 `protobuf:"bytes,2,opt,name=token,proto3" json:"token,omitempty"` // Signed share token; only returned here.
+	Url           string                 `protobuf:"bytes,3,opt,name=url,proto3" json:"url,omitempty"`     // Address of the link when the file server serves share links (ShareLinkPort); empty otherwise.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateShareLinkResponse) Reset() {
+	*x = CreateShareLinkResponse{}
+	mi := &file_file_proto_msgTypes[71]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateShareLinkResponse) ProtoMessage() {}
+
+func (x *CreateShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[71]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*CreateShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{71}
+}
+
+func (x *CreateShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+func (x *CreateShareLinkResponse) GetToken() string {
+	if x != nil {
+		return x.Token
+	}
+	return ""
+}
+
+func (x *CreateShareLinkResponse) GetUrl() string {
+	if x != nil {
+		return x.Url
+	}
+	return ""
+}
+
+type ListShareLinksRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // List links on this path and below.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksRequest) Reset() {
+	*x = ListShareLinksRequest{}
+	mi := &file_file_proto_msgTypes[72]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksRequest) ProtoMessage() {}
+
+func (x *ListShareLinksRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[72]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksRequest.ProtoReflect.Descriptor instead.
+func (*ListShareLinksRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{72}
+}
+
+func (x *ListShareLinksRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListShareLinksResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Links         []*ShareLink           `protobuf:"bytes,1,rep,name=links,proto3" json:"links,omitempty"` // Newest first.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListShareLinksResponse) Reset() {
+	*x = ListShareLinksResponse{}
+	mi := &file_file_proto_msgTypes[73]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListShareLinksResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListShareLinksResponse) ProtoMessage() {}
+
+func (x *ListShareLinksResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[73]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListShareLinksResponse.ProtoReflect.Descriptor instead.
+func (*ListShareLinksResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{73}
+}
+
+func (x *ListShareLinksResponse) GetLinks() []*ShareLink {
+	if x != nil {
+		return x.Links
+	}
+	return nil
+}
+
+type RevokeShareLinkRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"` // Path the link shares, or a parent of it.
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`     // Link ID.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkRequest) Reset() {
+	*x = RevokeShareLinkRequest{}
+	mi := &file_file_proto_msgTypes[74]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkRequest) ProtoMessage() {}
+
+func (x *RevokeShareLinkRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[74]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkRequest.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{74}
+}
+
+func (x *RevokeShareLinkRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *RevokeShareLinkRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type RevokeShareLinkResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Link          *ShareLink             `protobuf:"bytes,1,opt,name=link,proto3" json:"link,omitempty"` // The revoked link.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeShareLinkResponse) Reset() {
+	*x = RevokeShareLinkResponse{}
+	mi := &file_file_proto_msgTypes[75]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeShareLinkResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeShareLinkResponse) ProtoMessage() {}
+
+func (x *RevokeShareLinkResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[75]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeShareLinkResponse.ProtoReflect.Descriptor instead.
+func (*RevokeShareLinkResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{75}
+}
+
+func (x *RevokeShareLinkResponse) GetLink() *ShareLink {
+	if x != nil {
+		return x.Link
+	}
+	return nil
+}
+
+// StopRequest is the request message to stop the server.
+type StopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_file_proto_msgTypes[76]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[76]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{76}
+}
+
+// StopResponse is the response message when the server is stopped.
+type StopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
+	mi := &file_file_proto_msgTypes[77]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopResponse) ProtoMessage() {}
+
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_file_proto_msgTypes[77]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return file_file_proto_rawDescGZIP(), []int{77}
+}
+
+var File_file_proto protoreflect.FileDescriptor
+
+const file_file_proto_rawDesc = "" +
+	"\n" +
+	"\n" +
+	"file.proto\x12\x04file\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13globular_auth.proto\"\a\n" +
+	"\x05Empty\"\xb7\x02\n" +
+	"\bFileInfo\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x12\n" +
+	"\x04mode\x18\x03 \x01(\rR\x04mode\x12\x1b\n" +
+	"\tmode_time\x18\x04 \x01(\x03R\bmodeTime\x12\x15\n" +
+	"\x06is_dir\x18\x05 \x01(\bR\x05isDir\x12\x12\n" +
+	"\x04path\x18\x06 \x01(\tR\x04path\x12\x12\n" +
+	"\x04mime\x18\a \x01(\tR\x04mime\x12\x1c\n" +
+	"\tthumbnail\x18\b \x01(\tR\tthumbnail\x12\x1a\n" +
+	"\bchecksum\x18\t \x01(\tR\bchecksum\x123\n" +
+	"\bmetadata\x18\n" +
+	" \x01(\v2\x17.google.protobuf.StructR\bmetadata\x12$\n" +
+	"\x05files\x18\v \x03(\v2\x0e.file.FileInfoR\x05files\"\xa2\x01\n" +
+	"\x0eReadDirRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\x12&\n" +
+	"\x0ethumbnailWidth\x18\x03 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
+	"\x0fthumbnailHeight\x18\x04 \x01(\x05R\x0fthumbnailHeight\"5\n" +
+	"\x0fReadDirResponse\x12\"\n" +
+	"\x04info\x18\x01 \x01(\v2\x0e.file.FileInfoR\x04info\"H\n" +
+	"\x10CreateDirRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"+\n" +
+	"\x11CreateDirResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"4\n" +
+	"\x10DeleteDirRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\"+\n" +
+	"\x11DeleteDirResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"g\n" +
+	"\rRenameRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x19\n" +
+	"\bnew_name\x18\x02 \x01(\tR\anewName\x12\x19\n" +
+	"\bold_name\x18\x03 \x01(\tR\aoldName\"(\n" +
+	"\x0eRenameResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"E\n" +
+	"\vCopyRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x14\n" +
+	"\x05files\x18\x02 \x03(\tR\x05files\"&\n" +
+	"\fCopyResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"E\n" +
+	"\vMoveRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x14\n" +
+	"\x05files\x18\x02 \x03(\tR\x05files\"&\n" +
+	"\fMoveResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\x88\x01\n" +
+	"\x12GetFileInfoRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12&\n" +
+	"\x0ethumbnailWidth\x18\x02 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
+	"\x0fthumbnailHeight\x18\x03 \x01(\x05R\x0fthumbnailHeight\"9\n" +
+	"\x13GetFileInfoResponse\x12\"\n" +
+	"\x04info\x18\x01 \x01(\v2\x0e.file.FileInfoR\x04info\":\n" +
+	"\x16GetFileMetadataRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\"J\n" +
+	"\x17GetFileMetadataResponse\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06result\"c\n" +
+	"\x0fReadFileRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x16\n" +
+	"\x06length\x18\x03 \x01(\x03R\x06length\"&\n" +
+	"\x10ReadFileResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"E\n" +
+	"\x0fSaveFileRequest\x12\x14\n" +
+	"\x04path\x18\x01 \x01(\tH\x00R\x04path\x12\x14\n" +
+	"\x04data\x18\x02 \x01(\fH\x00R\x04dataB\x06\n" +
+	"\x04file\"*\n" +
+	"\x10SaveFileResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"5\n" +
+	"\x11DeleteFileRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\",\n" +
+	"\x12DeleteFileResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xa8\x01\n" +
+	"\x14GetThumbnailsRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\x12&\n" +
+	"\x0ethumbnailWidth\x18\x03 \x01(\x05R\x0ethumbnailWidth\x12(\n" +
+	"\x0fthumbnailHeight\x18\x04 \x01(\x05R\x0fthumbnailHeight\"+\n" +
+	"\x15GetThumbnailsResponse\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"L\n" +
+	"\x14CreateArchiveRequest\x12 \n" +
+	"\x05paths\x18\x01 \x03(\tB\n" +
+	"\x8a\xb5\x18\x06\n" +
+	"\x04pathR\x05paths\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\"/\n" +
+	"\x15CreateArchiveResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"Z\n" +
+	"\x10CreateLnkRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x10\n" +
+	"\x03lnk\x18\x03 \x01(\tR\x03lnk\"\x13\n" +
+	"\x11CreateLnkResponse\"\xa7\x01\n" +
+	"\rPublicDirInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12'\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x13.file.PublicDirTypeR\x04type\x12\x17\n" +
+	"\anode_id\x18\x03 \x01(\tR\x06nodeId\x12!\n" +
+	"\fnode_address\x18\x04 \x01(\tR\vnodeAddress\x12\x1d\n" +
+	"\n" +
+	"local_path\x18\x05 \x01(\tR\tlocalPath\"`\n" +
+	"\x13AddPublicDirRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12'\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x13.file.PublicDirTypeR\x04type\"?\n" +
+	"\x14AddPublicDirResponse\x12'\n" +
+	"\x04info\x18\x01 \x01(\v2\x13.file.PublicDirInfoR\x04info\":\n" +
+	"\x16RemovePublicDirRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\"\x19\n" +
+	"\x17RemovePublicDirResponse\"\x16\n" +
+	"\x14GetPublicDirsRequest\"a\n" +
+	"\x15GetPublicDirsResponse\x12\x12\n" +
+	"\x04dirs\x18\x01 \x03(\tR\x04dirs\x124\n" +
+	"\vpublic_dirs\x18\x02 \x03(\v2\x13.file.PublicDirInfoR\n" +
+	"publicDirs\"M\n" +
+	"\x15WriteExcelFileRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\"0\n" +
+	"\x16WriteExcelFileResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"#\n" +
+	"\rHtmlToPdfRqst\x12\x12\n" +
+	"\x04html\x18\x01 \x01(\tR\x04html\"%\n" +
+	"\x11HtmlToPdfResponse\x12\x10\n" +
+	"\x03pdf\x18\x01 \x01(\fR\x03pdf\"\x89\x01\n" +
+	"\x11UploadFileRequest\x12\x10\n" +
+	"\x03url\x18\x01 \x01(\tR\x03url\x12 \n" +
+	"\x04dest\x18\x02 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04dest\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x16\n" +
+	"\x06domain\x18\x04 \x01(\tR\x06domain\x12\x14\n" +
+	"\x05isDir\x18\x05 \x01(\bR\x05isDir\"Z\n" +
+	"\x12UploadFileResponse\x12\x1a\n" +
+	"\buploaded\x18\x01 \x01(\x03R\buploaded\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x03R\x05total\x12\x12\n" +
+	"\x04info\x18\x03 \x01(\tR\x04info\"F\n" +
+	"\x12FindIndexesRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\"+\n" +
+	"\x13FindIndexesResponse\x12\x14\n" +
+	"\x05paths\x18\x01 \x03(\tR\x05paths\"Z\n" +
+	"\x10IndexFileRequest\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1c\n" +
+	"\trecursive\x18\x02 \x01(\bR\trecursive\x12\x14\n" +
+	"\x05force\x18\x03 \x01(\bR\x05force\"\x89\x01\n" +
+	"\x11IndexFileResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06status\x18\x02 \x01(\tR\x06status\x12\x18\n" +
+	"\amessage\x18\x03 \x01(\tR\amessage\x12\x18\n" +
+	"\aindexed\x18\x04 \x01(\x05R\aindexed\x12\x14\n" +
+	"\x05total\x18\x05 \x01(\x05R\x05total\"b\n" +
+	"\x12BeginUploadRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"Q\n" +
+	"\x13BeginUploadResponse\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x02 \x01(\x03R\texpiresAt\"]\n" +
+	"\x12UploadChunkRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\fR\x04data\"-\n" +
+	"\x13UploadChunkResponse\x12\x16\n" +
+	"\x06offset\x18\x01 \x01(\x03R\x06offset\"5\n" +
+	"\x16GetUploadStatusRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"x\n" +
+	"\x17GetUploadStatusResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x16\n" +
+	"\x06offset\x18\x02 \x01(\x03R\x06offset\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x04 \x01(\x03R\texpiresAt\"J\n" +
+	"\x13CommitUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\x12\x16\n" +
+	"\x06sha256\x18\x02 \x01(\tR\x06sha256\"V\n" +
+	"\x14CommitUploadResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x02 \x01(\x03R\x04size\x12\x16\n" +
+	"\x06sha256\x18\x03 \x01(\tR\x06sha256\"1\n" +
+	"\x12AbortUploadRequest\x12\x1b\n" +
+	"\tupload_id\x18\x01 \x01(\tR\buploadId\"\x15\n" +
+	"\x13AbortUploadResponse\"_\n" +
+	"\vFileVersion\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x03R\x04size\x12\x18\n" +
+	"\acreated\x18\x04 \x01(\x03R\acreated\";\n" +
+	"\x17ListFileVersionsRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\"I\n" +
+	"\x18ListFileVersionsResponse\x12-\n" +
+	"\bversions\x18\x01 \x03(\v2\x11.file.FileVersionR\bversions\"\\\n" +
+	"\x19RestoreFileVersionRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x1d\n" +
+	"\n" +
+	"version_id\x18\x02 \x01(\tR\tversionId\"K\n" +
+	"\x1aRestoreFileVersionResponse\x12-\n" +
+	"\bprevious\x18\x01 \x01(\v2\x11.file.FileVersionR\bprevious\"\xdc\x01\n" +
+	"\tTrashItem\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\roriginal_path\x18\x02 \x01(\tR\foriginalPath\x12\x12\n" +
+	"\x04name\x18\x03 \x01(\tR\x04name\x12\x15\n" +
+	"\x06is_dir\x18\x04 \x01(\bR\x05isDir\x12\x12\n" +
+	"\x04size\x18\x05 \x01(\x03R\x04size\x12\x1d\n" +
+	"\n" +
+	"deleted_at\x18\x06 \x01(\x03R\tdeletedAt\x12\x1d\n" +
+	"\n" +
+	"deleted_by\x18\a \x01(\tR\tdeletedBy\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\b \x01(\x03R\texpiresAt\"4\n" +
+	"\x10ListTrashRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\":\n" +
+	"\x11ListTrashResponse\x12%\n" +
+	"\x05items\x18\x01 \x03(\v2\x0f.file.TrashItemR\x05items\"m\n" +
+	"\x17RestoreFromTrashRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12 \n" +
+	"\vdestination\x18\x03 \x01(\tR\vdestination\".\n" +
+	"\x18RestoreFromTrashResponse\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\"G\n" +
+	"\x11EmptyTrashRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x10\n" +
+	"\x03ids\x18\x02 \x03(\tR\x03ids\"D\n" +
+	"\x12EmptyTrashResponse\x12\x18\n" +
+	"\aremoved\x18\x01 \x01(\x05R\aremoved\x12\x14\n" +
+	"\x05freed\x18\x02 \x01(\x03R\x05freed\"\xc5\x02\n" +
+	"\tShareLink\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x12\x15\n" +
+	"\x06is_dir\x18\x03 \x01(\bR\x05isDir\x12 \n" +
+	"\vpermissions\x18\x04 \x03(\tR\vpermissions\x12\x1d\n" +
+	"\n" +
+	"created_by\x18\x05 \x01(\tR\tcreatedBy\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\a \x01(\x03R\texpiresAt\x12!\n" +
+	"\fhas_password\x18\b \x01(\bR\vhasPassword\x12#\n" +
+	"\rmax_downloads\x18\t \x01(\x03R\fmaxDownloads\x12\x1c\n" +
+	"\tdownloads\x18\n" +
+	" \x01(\x03R\tdownloads\x12\x18\n" +
+	"\arevoked\x18\v \x01(\bR\arevoked\"\xbc\x01\n" +
+	"\x16CreateShareLinkRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12 \n" +
+	"\vpermissions\x18\x02 \x03(\tR\vpermissions\x12\x1d\n" +
+	"\n" +
+	"expires_at\x18\x03 \x01(\x03R\texpiresAt\x12\x1a\n" +
+	"\bpassword\x18\x04 \x01(\tR\bpassword\x12#\n" +
+	"\rmax_downloads\x18\x05 \x01(\x03R\fmaxDownloads\"f\n" +
+	"\x17CreateShareLinkResponse\x12#\n" +
+	"\x04link\x18\x01 \x01(\v2\x0f.file.ShareLinkR\x04link\x12\x14\n" +
+	"\x05token\x18\x02 \x01(\tR\x05token\x12\x10\n" +
+	"\x03url\x18\x03 \x01(\tR\x03url\"9\n" +
+	"\x15ListShareLinksRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\"?\n" +
+	"\x16ListShareLinksResponse\x12%\n" +
+	"\x05links\x18\x01 \x03(\v2\x0f.file.ShareLinkR\x05links\"J\n" +
+	"\x16RevokeShareLinkRequest\x12 \n" +
+	"\x04path\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
+	"\x04path\x10\x01R\x04path\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\">\n" +
+	"\x17RevokeShareLinkResponse\x12#\n" +
+	"\x04link\x18\x01 \x01(\v2\x0f.file.ShareLinkR\x04link\"\r\n" +
+	"\vStopRequest\"\x0e\n" +
+	"\fStopResponse*T\n" +
+	"\rPublicDirType\x12\x14\n" +
+	"\x10PUBLIC_DIR_LOCAL\x10\x00\x12\x14\n" +
+	"\x10PUBLIC_DIR_MINIO\x10\x01\x12\x17\n" +
+	"\x13PUBLIC_DIR_EXTERNAL\x10\x022\x94!\n" +
+	"\vFileService\x12T\n" +
+	"\x04Stop\x12\x11.file.StopRequest\x1a\x12.file.StopResponse\"%\x82\xb5\x18!\n" +
+	"\n" +
+	"file.admin\x12\x05admin\x1a\x05/file*\x05admin\x12z\n" +
+	"\fAddPublicDir\x12\x19.file.AddPublicDirRequest\x1a\x1a.file.AddPublicDirResponse\"3\x82\xb5\x18/\n" +
+	"\ffile.publish\x12\x05admin\x1a\x11/file/path/{path}*\x05admin\x12\x83\x01\n" +
+	"\x0fRemovePublicDir\x12\x1c.file.RemovePublicDirRequest\x1a\x1d.file.RemovePublicDirResponse\"3\x82\xb5\x18/\n" +
+	"\ffile.publish\x12\x05admin\x1a\x11/file/path/{path}*\x05admin\x12}\n" +
+	"\rGetPublicDirs\x12\x1a.file.GetPublicDirsRequest\x1a\x1b.file.GetPublicDirsResponse\"3\x82\xb5\x18/\n" +
+	"\ffile.publish\x12\x04read\"\x11/file/public-dirs*\x06viewer\x12j\n" +
+	"\aReadDir\x12\x14.file.ReadDirRequest\x1a\x15.file.ReadDirResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer0\x01\x12p\n" +
+	"\tCreateDir\x12\x16.file.CreateDirRequest\x1a\x17.file.CreateDirResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12p\n" +
+	"\tCreateLnk\x12\x16.file.CreateLnkRequest\x1a\x17.file.CreateLnkResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12q\n" +
+	"\tDeleteDir\x12\x16.file.DeleteDirRequest\x1a\x17.file.DeleteDirResponse\"3\x82\xb5\x18/\n" +
+	"\vfile.delete\x12\x06delete\x1a\x11/file/path/{path}*\x05admin\x12g\n" +
+	"\x06Rename\x12\x13.file.RenameRequest\x1a\x14.file.RenameResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12a\n" +
+	"\x04Move\x12\x11.file.MoveRequest\x1a\x12.file.MoveResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12a\n" +
+	"\x04Copy\x12\x11.file.CopyRequest\x1a\x12.file.CopyResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12u\n" +
+	"\rCreateArchive\x12\x1a.file.CreateArchiveRequest\x1a\x1b.file.CreateArchiveResponse\"+\x82\xb5\x18'\n" +
+	"\n" +
+	"file.write\x12\x05write\"\n" +
+	"/file/path*\x06editor\x12t\n" +
+	"\vGetFileInfo\x12\x18.file.GetFileInfoRequest\x1a\x19.file.GetFileInfoResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12\x80\x01\n" +
+	"\x0fGetFileMetadata\x12\x1c.file.GetFileMetadataRequest\x1a\x1d.file.GetFileMetadataResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12m\n" +
+	"\bReadFile\x12\x15.file.ReadFileRequest\x1a\x16.file.ReadFileResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.read\x12\x04read\x1a\x11/file/path/{path}*\x06viewer0\x01\x12o\n" +
+	"\bSaveFile\x12\x15.file.SaveFileRequest\x1a\x16.file.SaveFileResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
 	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor(\x01\x12t\n" +
 	"\n" +
 	"DeleteFile\x12\x17.file.DeleteFileRequest\x1a\x18.file.DeleteFileResponse\"3\x82\xb5\x18/\n" +
@@ -2845,7 +4598,44 @@ const file_file_proto_rawDesc = "" +
 	"\n" +
 	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor0\x01\x12h\n" +
 	"\vFindIndexes\x12\x18.file.FindIndexesRequest\x1a\x19.file.FindIndexesResponse\"$\x82\xb5\x18 \n" +
-	"\tfile.read\x12\x04read\"\x05/file*\x06viewerB3Z1github.com/globulario/services/golang/file/filepbb\x06proto3"
+	"\tfile.read\x12\x04read\"\x05/file*\x06viewer\x12v\n" +
+	"\vBeginUpload\x12\x18.file.BeginUploadRequest\x1a\x19.file.BeginUploadResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12j\n" +
+	"\vUploadChunk\x12\x18.file.UploadChunkRequest\x1a\x19.file.UploadChunkResponse\"&\x82\xb5\x18\"\n" +
+	"\n" +
+	"file.write\x12\x05write\"\x05/file*\x06editor\x12v\n" +
+	"\x0fGetUploadStatus\x12\x1c.file.GetUploadStatusRequest\x1a\x1d.file.GetUploadStatusResponse\"&\x82\xb5\x18\"\n" +
+	"\n" +
+	"file.write\x12\x05write\"\x05/file*\x06editor\x12m\n" +
+	"\fCommitUpload\x12\x19.file.CommitUploadRequest\x1a\x1a.file.CommitUploadResponse\"&\x82\xb5\x18\"\n" +
+	"\n" +
+	"file.write\x12\x05write\"\x05/file*\x06editor\x12j\n" +
+	"\vAbortUpload\x12\x18.file.AbortUploadRequest\x1a\x19.file.AbortUploadResponse\"&\x82\xb5\x18\"\n" +
+	"\n" +
+	"file.write\x12\x05write\"\x05/file*\x06editor\x12\x83\x01\n" +
+	"\x10ListFileVersions\x12\x1d.file.ListFileVersionsRequest\x1a\x1e.file.ListFileVersionsResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.read\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12\x8b\x01\n" +
+	"\x12RestoreFileVersion\x12\x1f.file.RestoreFileVersionRequest\x1a .file.RestoreFileVersionResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12n\n" +
+	"\tListTrash\x12\x16.file.ListTrashRequest\x1a\x17.file.ListTrashResponse\"0\x82\xb5\x18,\n" +
+	"\tfile.list\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12\x85\x01\n" +
+	"\x10RestoreFromTrash\x12\x1d.file.RestoreFromTrashRequest\x1a\x1e.file.RestoreFromTrashResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.write\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12t\n" +
+	"\n" +
+	"EmptyTrash\x12\x17.file.EmptyTrashRequest\x1a\x18.file.EmptyTrashResponse\"3\x82\xb5\x18/\n" +
+	"\vfile.delete\x12\x06delete\x1a\x11/file/path/{path}*\x05admin\x12\x82\x01\n" +
+	"\x0fCreateShareLink\x12\x1c.file.CreateShareLinkRequest\x1a\x1d.file.CreateShareLinkResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.share\x12\x05write\x1a\x11/file/path/{path}*\x06editor\x12~\n" +
+	"\x0eListShareLinks\x12\x1b.file.ListShareLinksRequest\x1a\x1c.file.ListShareLinksResponse\"1\x82\xb5\x18-\n" +
+	"\n" +
+	"file.share\x12\x04read\x1a\x11/file/path/{path}*\x06viewer\x12\x82\x01\n" +
+	"\x0fRevokeShareLink\x12\x1c.file.RevokeShareLinkRequest\x1a\x1d.file.RevokeShareLinkResponse\"2\x82\xb5\x18.\n" +
+	"\n" +
+	"file.share\x12\x05write\x1a\x11/file/path/{path}*\x06editorB3Z1github.com/globulario/services/golang/file/filepbb\x06proto3"
 
 var (
 	file_file_proto_rawDescOnce sync.Once
@@ -2860,121 +4650,182 @@ func file_file_proto_rawDescGZIP() []byte {
 }
 
 var file_file_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_file_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
+var file_file_proto_msgTypes = make([]protoimpl.MessageInfo, 78)
 var file_file_proto_goTypes = []any{
-	(PublicDirType)(0),              // 0: file.PublicDirType
-	(*Empty)(nil),                   // 1: file.Empty
-	(*FileInfo)(nil),                // 2: file.FileInfo
-	(*ReadDirRequest)(nil),          // 3: file.ReadDirRequest
-	(*ReadDirResponse)(nil),         // 4: file.ReadDirResponse
-	(*CreateDirRequest)(nil),        // 5: file.CreateDirRequest
-	(*CreateDirResponse)(nil),       // 6: file.CreateDirResponse
-	(*DeleteDirRequest)(nil),        // 7: file.DeleteDirRequest
-	(*DeleteDirResponse)(nil),       // 8: file.DeleteDirResponse
-	(*RenameRequest)(nil),           // 9: file.RenameRequest
-	(*RenameResponse)(nil),          // 10: file.RenameResponse
-	(*CopyRequest)(nil),             // 11: file.CopyRequest
-	(*CopyResponse)(nil),            // 12: file.CopyResponse
-	(*MoveRequest)(nil),             // 13: file.MoveRequest
-	(*MoveResponse)(nil),            // 14: file.MoveResponse
-	(*GetFileInfoRequest)(nil),      // 15: file.GetFileInfoRequest
-	(*GetFileInfoResponse)(nil),     // 16: file.GetFileInfoResponse
-	(*GetFileMetadataRequest)(nil),  // 17: file.GetFileMetadataRequest
-	(*GetFileMetadataResponse)(nil), // 18: file.GetFileMetadataResponse
-	(*ReadFileRequest)(nil),         // 19: file.ReadFileRequest
-	(*ReadFileResponse)(nil),        // 20: file.ReadFileResponse
-	(*SaveFileRequest)(nil),         // 21: file.SaveFileRequest
-	(*SaveFileResponse)(nil),        // 22: file.SaveFileResponse
-	(*DeleteFileRequest)(nil),       // 23: file.DeleteFileRequest
-	(*DeleteFileResponse)(nil),      // 24: file.DeleteFileResponse
-	(*GetThumbnailsRequest)(nil),    // 25: file.GetThumbnailsRequest
-	(*GetThumbnailsResponse)(nil),   // 26: file.GetThumbnailsResponse
-	(*CreateArchiveRequest)(nil),    // 27: file.CreateArchiveRequest
-	(*CreateArchiveResponse)(nil),   // 28: file.CreateArchiveResponse
-	(*CreateLnkRequest)(nil),        // 29: file.CreateLnkRequest
-	(*CreateLnkResponse)(nil),       // 30: file.CreateLnkResponse
-	(*PublicDirInfo)(nil),           // 31: file.PublicDirInfo
-	(*AddPublicDirRequest)(nil),     // 32: file.AddPublicDirRequest
-	(*AddPublicDirResponse)(nil),    // 33: file.AddPublicDirResponse
-	(*RemovePublicDirRequest)(nil),  // 34: file.RemovePublicDirRequest
-	(*RemovePublicDirResponse)(nil), // 35: file.RemovePublicDirResponse
-	(*GetPublicDirsRequest)(nil),    // 36: file.GetPublicDirsRequest
-	(*GetPublicDirsResponse)(nil),   // 37: file.GetPublicDirsResponse
-	(*WriteExcelFileRequest)(nil),   // 38: file.WriteExcelFileRequest
-	(*WriteExcelFileResponse)(nil),  // 39: file.WriteExcelFileResponse
-	(*HtmlToPdfRqst)(nil),           // 40: file.HtmlToPdfRqst
-	(*HtmlToPdfResponse)(nil),       // 41: file.HtmlToPdfResponse
-	(*UploadFileRequest)(nil),       // 42: file.UploadFileRequest
-	(*UploadFileResponse)(nil),      // 43: file.UploadFileResponse
-	(*FindIndexesRequest)(nil),      // 44: file.FindIndexesRequest
-	(*FindIndexesResponse)(nil),     // 45: file.FindIndexesResponse
-	(*IndexFileRequest)(nil),        // 46: file.IndexFileRequest
-	(*IndexFileResponse)(nil),       // 47: file.IndexFileResponse
-	(*StopRequest)(nil),             // 48: file.StopRequest
-	(*StopResponse)(nil),            // 49: file.StopResponse
-	(*structpb.Struct)(nil),         // 50: google.protobuf.Struct
+	(PublicDirType)(0),                 // 0: file.PublicDirType
+	(*Empty)(nil),                      // 1: file.Empty
+	(*FileInfo)(nil),                   // 2: file.FileInfo
+	(*ReadDirRequest)(nil),             // 3: file.ReadDirRequest
+	(*ReadDirResponse)(nil),            // 4: file.ReadDirResponse
+	(*CreateDirRequest)(nil),           // 5: file.CreateDirRequest
+	(*CreateDirResponse)(nil),          // 6: file.CreateDirResponse
+	(*DeleteDirRequest)(nil),           // 7: file.DeleteDirRequest
+	(*DeleteDirResponse)(nil),          // 8: file.DeleteDirResponse
+	(*RenameRequest)(nil),              // 9: file.RenameRequest
+	(*RenameResponse)(nil),             // 10: file.RenameResponse
+	(*CopyRequest)(nil),                // 11: file.CopyRequest
+	(*CopyResponse)(nil),               // 12: file.CopyResponse
+	(*MoveRequest)(nil),                // 13: file.MoveRequest
+	(*MoveResponse)(nil),               // 14: file.MoveResponse
+	(*GetFileInfoRequest)(nil),         // 15: file.GetFileInfoRequest
+	(*GetFileInfoResponse)(nil),        // 16: file.GetFileInfoResponse
+	(*GetFileMetadataRequest)(nil),     // 17: file.GetFileMetadataRequest
+	(*GetFileMetadataResponse)(nil),    // 18: file.GetFileMetadataResponse
+	(*ReadFileRequest)(nil),            // 19: file.ReadFileRequest
+	(*ReadFileResponse)(nil),           // 20: file.ReadFileResponse
+	(*SaveFileRequest)(nil),            // 21: file.SaveFileRequest
+	(*SaveFileResponse)(nil),           // 22: file.SaveFileResponse
+	(*DeleteFileRequest)(nil),          // 23: file.DeleteFileRequest
+	(*DeleteFileResponse)(nil),         // 24: file.DeleteFileResponse
+	(*GetThumbnailsRequest)(nil),       // 25: file.GetThumbnailsRequest
+	(*GetThumbnailsResponse)(nil),      // 26: file.GetThumbnailsResponse
+	(*CreateArchiveRequest)(nil),       // 27: file.CreateArchiveRequest
+	(*CreateArchiveResponse)(nil),      // 28: file.CreateArchiveResponse
+	(*CreateLnkRequest)(nil),           // 29: file.CreateLnkRequest
+	(*CreateLnkResponse)(nil),          // 30: file.CreateLnkResponse
+	(*PublicDirInfo)(nil),              // 31: file.PublicDirInfo
+	(*AddPublicDirRequest)(nil),        // 32: file.AddPublicDirRequest
+	(*AddPublicDirResponse)(nil),       // 33: file.AddPublicDirResponse
+	(*RemovePublicDirRequest)(nil),     // 34: file.RemovePublicDirRequest
+	(*RemovePublicDirResponse)(nil),    // 35: file.RemovePublicDirResponse
+	(*GetPublicDirsRequest)(nil),       // 36: file.GetPublicDirsRequest
+	(*GetPublicDirsResponse)(nil),      // 37: file.GetPublicDirsResponse
+	(*WriteExcelFileRequest)(nil),      // 38: file.WriteExcelFileRequest
+	(*WriteExcelFileResponse)(nil),     // 39: file.WriteExcelFileResponse
+	(*HtmlToPdfRqst)(nil),              // 40: file.HtmlToPdfRqst
+	(*HtmlToPdfResponse)(nil),          // 41: file.HtmlToPdfResponse
+	(*UploadFileRequest)(nil),          // 42: file.UploadFileRequest
+	(*UploadFileResponse)(nil),         // 43: file.UploadFileResponse
+	(*FindIndexesRequest)(nil),         // 44: file.FindIndexesRequest
+	(*FindIndexesResponse)(nil),        // 45: file.FindIndexesResponse
+	(*IndexFileRequest)(nil),           // 46: file.IndexFileRequest
+	(*IndexFileResponse)(nil),          // 47: file.IndexFileResponse
+	(*BeginUploadRequest)(nil),         // 48: file.BeginUploadRequest
+	(*BeginUploadResponse)(nil),        // 49: file.BeginUploadResponse
+	(*UploadChunkRequest)(nil),         // 50: file.UploadChunkRequest
+	(*UploadChunkResponse)(nil),        // 51: file.UploadChunkResponse
+	(*GetUploadStatusRequest)(nil),     // 52: file.GetUploadStatusRequest
+	(*GetUploadStatusResponse)(nil),    // 53: file.GetUploadStatusResponse
+	(*CommitUploadRequest)(nil),        // 54: file.CommitUploadRequest
+	(*CommitUploadResponse)(nil),       // 55: file.CommitUploadResponse
+	(*AbortUploadRequest)(nil),         // 56: file.AbortUploadRequest
+	(*AbortUploadResponse)(nil),        // 57: file.AbortUploadResponse
+	(*FileVersion)(nil),                // 58: file.FileVersion
+	(*ListFileVersionsRequest)(nil),    // 59: file.ListFileVersionsRequest
+	(*ListFileVersionsResponse)(nil),   // 60: file.ListFileVersionsResponse
+	(*RestoreFileVersionRequest)(nil),  // 61: file.RestoreFileVersionRequest
+	(*RestoreFileVersionResponse)(nil), // 62: file.RestoreFileVersionResponse
+	(*TrashItem)(nil),                  // 63: file.TrashItem
+	(*ListTrashRequest)(nil),           // 64: file.ListTrashRequest
+	(*ListTrashResponse)(nil),          // 65: file.ListTrashResponse
+	(*RestoreFromTrashRequest)(nil),    // 66: file.RestoreFromTrashRequest
+	(*RestoreFromTrashResponse)(nil),   // 67: file.RestoreFromTrashResponse
+	(*EmptyTrashRequest)(nil),          // 68: file.EmptyTrashRequest
+	(*EmptyTrashResponse)(nil),         // 69: file.EmptyTrashResponse
+	(*ShareLink)(nil),                  // 70: file.ShareLink
+	(*CreateShareLinkRequest)(nil),     // 71: file.CreateShareLinkRequest
+	(*CreateShareLinkResponse)(nil),    // 72: file.CreateShareLinkResponse
+	(*ListShareLinksRequest)(nil),      // 73: file.ListShareLinksRequest
+	(*ListShareLinksResponse)(nil),     // 74: file.ListShareLinksResponse
+	(*RevokeShareLinkRequest)(nil),     // 75: file.RevokeShareLinkRequest
+	(*RevokeShareLinkResponse)(nil),    // 76: file.RevokeShareLinkResponse
+	(*StopRequest)(nil),                // 77: file.StopRequest
+	(*StopResponse)(nil),               // 78: file.StopResponse
+	(*structpb.Struct)(nil),            // 79: google.protobuf.Struct
 }
 var file_file_proto_depIdxs = []int32{
-	50, // 0: file.FileInfo.metadata:type_name -> google.protobuf.Struct
+	79, // 0: file.FileInfo.metadata:type_name -> google.protobuf.Struct
 	2,  // 1: file.FileInfo.files:type_name -> file.FileInfo
 	2,  // 2: file.ReadDirResponse.info:type_name -> file.FileInfo
 	2,  // 3: file.GetFileInfoResponse.info:type_name -> file.FileInfo
-	50, // 4: file.GetFileMetadataResponse.result:type_name -> google.protobuf.Struct
+	79, // 4: file.GetFileMetadataResponse.result:type_name -> google.protobuf.Struct
 	0,  // 5: file.PublicDirInfo.type:type_name -> file.PublicDirType
 	0,  // 6: file.AddPublicDirRequest.type:type_name -> file.PublicDirType
 	31, // 7: file.AddPublicDirResponse.info:type_name -> file.PublicDirInfo
 	31, // 8: file.GetPublicDirsResponse.public_dirs:type_name -> file.PublicDirInfo
-	48, // 9: file.FileService.Stop:input_type -> file.StopRequest
-	32, // 10: file.FileService.AddPublicDir:input_type -> file.AddPublicDirRequest
-	34, // 11: file.FileService.RemovePublicDir:input_type -> file.RemovePublicDirRequest
-	36, // 12: file.FileService.GetPublicDirs:input_type -> file.GetPublicDirsRequest
-	3,  // 13: file.FileService.ReadDir:input_type -> file.ReadDirRequest
-	5,  // 14: file.FileService.CreateDir:input_type -> file.CreateDirRequest
-	29, // 15: file.FileService.CreateLnk:input_type -> file.CreateLnkRequest
-	7,  // 16: file.FileService.DeleteDir:input_type -> file.DeleteDirRequest
-	9,  // 17: file.FileService.Rename:input_type -> file.RenameRequest
-	13, // 18: file.FileService.Move:input_type -> file.MoveRequest
-	11, // 19: file.FileService.Copy:input_type -> file.CopyRequest
-	27, // 20: file.FileService.CreateArchive:input_type -> file.CreateArchiveRequest
-	15, // 21: file.FileService.GetFileInfo:input_type -> file.GetFileInfoRequest
-	17, // 22: file.FileService.GetFileMetadata:input_type -> file.GetFileMetadataRequest
-	19, // 23: file.FileService.ReadFile:input_type -> file.ReadFileRequest
-	21, // 24: file.FileService.SaveFile:input_type -> file.SaveFileRequest
-	23, // 25: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
-	25, // 26: file.FileService.GetThumbnails:input_type -> file.GetThumbnailsRequest
-	42, // 27: file.FileService.UploadFile:input_type -> file.UploadFileRequest
-	38, // 28: file.FileService.WriteExcelFile:input_type -> file.WriteExcelFileRequest
-	40, // 29: file.FileService.HtmlToPdf:input_type -> file.HtmlToPdfRqst
-	46, // 30: file.FileService.IndexFile:input_type -> file.IndexFileRequest
-	44, // 31: file.FileService.FindIndexes:input_type -> file.FindIndexesRequest
-	49, // 32: file.FileService.Stop:output_type -> file.StopResponse
-	33, // 33: file.FileService.AddPublicDir:output_type -> file.AddPublicDirResponse
-	35, // 34: file.FileService.RemovePublicDir:output_type -> file.RemovePublicDirResponse
-	37, // 35: file.FileService.GetPublicDirs:output_type -> file.GetPublicDirsResponse
-	4,  // 36: file.FileService.ReadDir:output_type -> file.ReadDirResponse
-	6,  // 37: file.FileService.CreateDir:output_type -> file.CreateDirResponse
-	30, // 38: file.FileService.CreateLnk:output_type -> file.CreateLnkResponse
-	8,  // 39: file.FileService.DeleteDir:output_type -> file.DeleteDirResponse
-	10, // 40: file.FileService.Rename:output_type -> file.RenameResponse
-	14, // 41: file.FileService.Move:output_type -> file.MoveResponse
-	12, // 42: file.FileService.Copy:output_type -> file.CopyResponse
-	28, // 43: file.FileService.CreateArchive:output_type -> file.CreateArchiveResponse
-	16, // 44: file.FileService.GetFileInfo:output_type -> file.GetFileInfoResponse
-	18, // 45: file.FileService.GetFileMetadata:output_type -> file.GetFileMetadataResponse
-	20, // 46: file.FileService.ReadFile:output_type -> file.ReadFileResponse
-	22, // 47: file.FileService.SaveFile:output_type -> file.SaveFileResponse
-	24, // 48: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
-	26, // 49: file.FileService.GetThumbnails:output_type -> file.GetThumbnailsResponse
-	43, // 50: file.FileService.UploadFile:output_type -> file.UploadFileResponse
-	39, // 51: file.FileService.WriteExcelFile:output_type -> file.WriteExcelFileResponse
-	41, // 52: file.FileService.HtmlToPdf:output_type -> file.HtmlToPdfResponse
-	47, // 53: file.FileService.IndexFile:output_type -> file.IndexFileResponse
-	45, // 54: file.FileService.FindIndexes:output_type -> file.FindIndexesResponse
-	32, // [32:55] is the sub-list for method output_type
-	9,  // [9:32] is the sub-list for method input_type
-	9,  // [9:9] is the sub-list for extension type_name
-	9,  // [9:9] is the sub-list for extension extendee
-	0,  // [0:9] is the sub-list for field type_name
+	58, // 9: file.ListFileVersionsResponse.versions:type_name -> file.FileVersion
+	58, // 10: file.RestoreFileVersionResponse.previous:type_name -> file.FileVersion
+	63, // 11: file.ListTrashResponse.items:type_name -> file.TrashItem
+	70, // 12: file.CreateShareLinkResponse.link:type_name -> file.ShareLink
+	70, // 13: file.ListShareLinksResponse.links:type_name -> file.ShareLink
+	70, // 14: file.RevokeShareLinkResponse.link:type_name -> file.ShareLink
+	77, // 15: file.FileService.Stop:input_type -> file.StopRequest
+	32, // 16: file.FileService.AddPublicDir:input_type -> file.AddPublicDirRequest
+	34, // 17: file.FileService.RemovePublicDir:input_type -> file.RemovePublicDirRequest
+	36, // 18: file.FileService.GetPublicDirs:input_type -> file.GetPublicDirsRequest
+	3,  // 19: file.FileService.ReadDir:input_type -> file.ReadDirRequest
+	5,  // 20: file.FileService.CreateDir:input_type -> file.CreateDirRequest
+	29, // 21: file.FileService.CreateLnk:input_type -> file.CreateLnkRequest
+	7,  // 22: file.FileService.DeleteDir:input_type -> file.DeleteDirRequest
+	9,  // 23: file.FileService.Rename:input_type -> file.RenameRequest
+	13, // 24: file.FileService.Move:input_type -> file.MoveRequest
+	11, // 25: file.FileService.Copy:input_type -> file.CopyRequest
+	27, // 26: file.FileService.CreateArchive:input_type -> file.CreateArchiveRequest
+	15, // 27: file.FileService.GetFileInfo:input_type -> file.GetFileInfoRequest
+	17, // 28: file.FileService.GetFileMetadata:input_type -> file.GetFileMetadataRequest
+	19, // 29: file.FileService.ReadFile:input_type -> file.ReadFileRequest
+	21, // 30: file.FileService.SaveFile:input_type -> file.SaveFileRequest
+	23, // 31: file.FileService.DeleteFile:input_type -> file.DeleteFileRequest
+	25, // 32: file.FileService.GetThumbnails:input_type -> file.GetThumbnailsRequest
+	42, // 33: file.FileService.UploadFile:input_type -> file.UploadFileRequest
+	38, // 34: file.FileService.WriteExcelFile:input_type -> file.WriteExcelFileRequest
+	40, // 35: file.FileService.HtmlToPdf:input_type -> file.HtmlToPdfRqst
+	46, // 36: file.FileService.IndexFile:input_type -> file.IndexFileRequest
+	44, // 37: file.FileService.FindIndexes:input_type -> file.FindIndexesRequest
+	48, // 38: file.FileService.BeginUpload:input_type -> file.BeginUploadRequest
+	50, // 39: file.FileService.UploadChunk:input_type -> file.UploadChunkRequest
+	52, // 40: file.FileService.GetUploadStatus:input_type -> file.GetUploadStatusRequest
+	54, // 41: file.FileService.CommitUpload:input_type -> file.CommitUploadRequest
+	56, // 42: file.FileService.AbortUpload:input_type -> file.AbortUploadRequest
+	59, // 43: file.FileService.ListFileVersions:input_type -> file.ListFileVersionsRequest
+	61, // 44: file.FileService.RestoreFileVersion:input_type -> file.RestoreFileVersionRequest
+	64, // 45: file.FileService.ListTrash:input_type -> file.ListTrashRequest
+	66, // 46: file.FileService.RestoreFromTrash:input_type -> file.RestoreFromTrashRequest
+	68, // 47: file.FileService.EmptyTrash:input_type -> file.EmptyTrashRequest
+	71, // 48: file.FileService.CreateShareLink:input_type -> file.CreateShareLinkRequest
+	73, // 49: file.FileService.ListShareLinks:input_type -> file.ListShareLinksRequest
+	75, // 50: file.FileService.RevokeShareLink:input_type -> file.RevokeShareLinkRequest
+	78, // 51: file.FileService.Stop:output_type -> file.StopResponse
+	33, // 52: file.FileService.AddPublicDir:output_type -> file.AddPublicDirResponse
+	35, // 53: file.FileService.RemovePublicDir:output_type -> file.RemovePublicDirResponse
+	37, // 54: file.FileService.GetPublicDirs:output_type -> file.GetPublicDirsResponse
+	4,  // 55: file.FileService.ReadDir:output_type -> file.ReadDirResponse
+	6,  // 56: file.FileService.CreateDir:output_type -> file.CreateDirResponse
+	30, // 57: file.FileService.CreateLnk:output_type -> file.CreateLnkResponse
+	8,  // 58: file.FileService.DeleteDir:output_type -> file.DeleteDirResponse
+	10, // 59: file.FileService.Rename:output_type -> file.RenameResponse
+	14, // 60: file.FileService.Move:output_type -> file.MoveResponse
+	12, // 61: file.FileService.Copy:output_type -> file.CopyResponse
+	28, // 62: file.FileService.CreateArchive:output_type -> file.CreateArchiveResponse
+	16, // 63: file.FileService.GetFileInfo:output_type -> file.GetFileInfoResponse
+	18, // 64: file.FileService.GetFileMetadata:output_type -> file.GetFileMetadataResponse
+	20, // 65: file.FileService.ReadFile:output_type -> file.ReadFileResponse
+	22, // 66: file.FileService.SaveFile:output_type -> file.SaveFileResponse
+	24, // 67: file.FileService.DeleteFile:output_type -> file.DeleteFileResponse
+	26, // 68: file.FileService.GetThumbnails:output_type -> file.GetThumbnailsResponse
+	43, // 69: file.FileService.UploadFile:output_type -> file.UploadFileResponse
+	39, // 70: file.FileService.WriteExcelFile:output_type -> file.WriteExcelFileResponse
+	41, // 71: file.FileService.HtmlToPdf:output_type -> file.HtmlToPdfResponse
+	47, // 72: file.FileService.IndexFile:output_type -> file.IndexFileResponse
+	45, // 73: file.FileService.FindIndexes:output_type -> file.FindIndexesResponse
+	49, // 74: file.FileService.BeginUpload:output_type -> file.BeginUploadResponse
+	51, // 75: file.FileService.UploadChunk:output_type -> file.UploadChunkResponse
+	53, // 76: file.FileService.GetUploadStatus:output_type -> file.GetUploadStatusResponse
+	55, // 77: file.FileService.CommitUpload:output_type -> file.CommitUploadResponse
+	57, // 78: file.FileService.AbortUpload:output_type -> file.AbortUploadResponse
+	60, // 79: file.FileService.ListFileVersions:output_type -> file.ListFileVersionsResponse
+	62, // 80: file.FileService.RestoreFileVersion:output_type -> file.RestoreFileVersionResponse
+	65, // 81: file.FileService.ListTrash:output_type -> file.ListTrashResponse
+	67, // 82: file.FileService.RestoreFromTrash:output_type -> file.RestoreFromTrashResponse
+	69, // 83: file.FileService.EmptyTrash:output_type -> file.EmptyTrashResponse
+	72, // 84: file.FileService.CreateShareLink:output_type -> file.CreateShareLinkResponse
+	74, // 85: file.FileService.ListShareLinks:output_type -> file.ListShareLinksResponse
+	76, // 86: file.FileService.RevokeShareLink:output_type -> file.RevokeShareLinkResponse
+	51, // [51:87] is the sub-list for method output_type
+	15, // [15:51] is the sub-list for method input_type
+	15, // [15:15] is the sub-list for extension type_name
+	15, // [15:15] is the sub-list for extension extendee
+	0,  // [0:15] is the sub-list for field type_name
 }
 
 func init() { file_file_proto_init() }
@@ -2992,7 +4843,7 @@ func file_file_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_file_proto_rawDesc), len(file_file_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   49,
+			NumMessages:   78,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -0,0 +1,354 @@
+package sharelink
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/fs"
+	"log/slog"
+	"mime"
+	"net/http"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/interceptors"
+	"github.com/globulario/services/golang/security"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+	"google.golang.org/grpc/peer"
+)
+
+// Prefix is the URL path the gateway mounts the handler on. A link URL is
+// Prefix + token, optionally followed by a path inside a shared directory.
+const Prefix = "/share/"
+
+// PasswordHeader carries the password of a protected link. The password can
+// also be sent as the "password" form value or with basic auth.
+const PasswordHeader = "X-Share-Password"
+
+// Audit reasons, see interceptors.AuditDecision.
+const (
+	reasonGranted   = "share_link_granted"
+	reasonInvalid   = "share_link_invalid"
+	reasonExpired   = "share_link_expired"
+	reasonRevoked   = "share_link_revoked"
+	reasonPassword  = "share_link_password"
+	reasonExhausted = "share_link_exhausted"
+	reasonDenied    = "share_link_denied"
+)
+
+var errExhausted = errors.New("share link download limit reached")
+
+// FS is the part of the storage backend the handler reads from, with
+// cluster paths such as /users/alice/photos. storage_backend.Storage
+// satisfies it.
+type FS interface {
+	Stat(ctx context.Context, path string) (fs.FileInfo, error)
+	Open(ctx context.Context, path string) (io.ReadSeekCloser, error)
+	ReadDir(ctx context.Context, path string) ([]fs.DirEntry, error)
+}
+
+// Handler serves share links: it verifies the token and the link record,
+// then streams the file, or the directory as a zip. Every access, granted or
+// not, goes to the authz audit log with the link id as subject.
+type Handler struct {
+	Store Store
+	FS    FS
+
+	// Validate verifies a token; security.ValidateShareToken by default.
+	Validate func(token string) (*security.ShareClaims, error)
+}
+
+// NewHandler returns a Handler reading links from store and files from fsys.
+func NewHandler(store Store, fsys FS) *Handler {
+	return &Handler{Store: store, FS: fsys, Validate: security.ValidateShareToken}
+}
+
+// ServeHTTP handles GET, HEAD and POST (password form) on Prefix + token[/sub/path].
+// A directory link answers with a zip of the directory, or with its listing
+// as JSON when the "list" query parameter is set and the link allows it.
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	start := time.Now()
+	switch r.Method {
+	case http.MethodGet, http.MethodHead, http.MethodPost:
+	default:
+		w.Header().Set("Allow", "GET, HEAD, POST")
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	token, sub, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, Prefix), "/")
+	audit := func(id, target string, allowed bool, reason string) {
+		subject := "share:unknown"
+		if id != "" {
+			subject = "share:" + id
+		}
+		interceptors.LogAuthzDecision(auditContext(r), &security.AuthContext{
+			Subject:       subject,
+			PrincipalType: "anonymous",
+			AuthMethod:    "share_link",
+			GRPCMethod:    r.Method + " " + Prefix,
+		}, allowed, reason, target, "read", start)
+	}
+
+	validate := h.Validate
+	if validate == nil {
+		validate = security.ValidateShareToken
+	}
+	claims, err := validate(token)
+	if err != nil {
+		if errors.Is(err, jwt.ErrTokenExpired) {
+			audit("", "", false, reasonExpired)
+			http.Error(w, "share link expired", http.StatusGone)
+			return
+		}
+		audit("", "", false, reasonInvalid)
+		http.Error(w, "share link not found", http.StatusNotFound)
+		return
+	}
+
+	link, err := h.Store.Get(claims.ID)
+	if err != nil {
+		if errors.Is(err, ErrNotFound) {
+			audit(claims.ID, claims.Path, false, reasonRevoked)
+			http.Error(w, "share link revoked", http.StatusGone)
+			return
+		}
+		slog.Error("share link lookup failed", "id", claims.ID, "err", err)
+		http.Error(w, "share link unavailable", http.StatusServiceUnavailable)
+		return
+	}
+	if link.Path != claims.Path {
+		audit(claims.ID, claims.Path, false, reasonInvalid)
+		http.Error(w, "share link not found", http.StatusNotFound)
+		return
+	}
+	if link.Revoked {
+		audit(link.ID, link.Path, false, reasonRevoked)
+		http.Error(w, "share link revoked", http.StatusGone)
+		return
+	}
+	if time.Now().Unix() >= link.ExpiresAt {
+		audit(link.ID, link.Path, false, reasonExpired)
+		http.Error(w, "share link expired", http.StatusGone)
+		return
+	}
+	if link.PasswordHash != "" {
+		if bcrypt.CompareHashAndPassword([]byte(link.PasswordHash), []byte(password(r))) != nil {
+			audit(link.ID, link.Path, false, reasonPassword)
+			w.Header().Set("WWW-Authenticate", `Basic realm="share link"`)
+			http.Error(w, "password required", http.StatusUnauthorized)
+			return
+		}
+	}
+
+	target, ok := resolve(link, sub)
+	if !ok {
+		audit(link.ID, link.Path+"/"+sub, false, reasonDenied)
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	info, err := h.FS.Stat(r.Context(), target)
+	if err != nil {
+		audit(link.ID, target, false, reasonDenied)
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+
+	if info.IsDir() && r.URL.Query().Has("list") {
+		if !slices.Contains(link.Permissions, "list") {
+			audit(link.ID, target, false, reasonDenied)
+			http.Error(w, "listing not allowed", http.StatusForbidden)
+			return
+		}
+		audit(link.ID, target, true, reasonGranted)
+		h.serveList(w, r, target)
+		return
+	}
+	if !slices.Contains(link.Permissions, "read") {
+		audit(link.ID, target, false, reasonDenied)
+		http.Error(w, "download not allowed", http.StatusForbidden)
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		// HEAD serves no bytes and is not counted, but an exhausted link
+		// answers it the same way as GET.
+		if link.MaxDownloads > 0 && link.Downloads >= link.MaxDownloads {
+			audit(link.ID, target, false, reasonExhausted)
+			http.Error(w, "download limit reached", http.StatusGone)
+			return
+		}
+	} else {
+		if _, err := h.Store.Update(link.ID, consume); err != nil {
+			if errors.Is(err, errExhausted) {
+				audit(link.ID, target, false, reasonExhausted)
+				http.Error(w, "download limit reached", http.StatusGone)
+				return
+			}
+			slog.Error("share link download count failed", "id", link.ID, "err", err)
+			http.Error(w, "share link unavailable", http.StatusServiceUnavailable)
+			return
+		}
+	}
+	audit(link.ID, target, true, reasonGranted)
+
+	if info.IsDir() {
+		h.serveZip(w, r, target)
+		return
+	}
+	f, err := h.FS.Open(r.Context(), target)
+	if err != nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	defer f.Close()
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("inline", map[string]string{"filename": info.Name()}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+}
+
+// consume counts a download against the limit of a link, refusing it once
+// the limit is reached or the link was revoked in the meantime. Every GET
+// that serves bytes counts, ranged or not: a range that were free could read
+// the whole file piecewise past the limit.
+func consume(link *config.ShareLinkEntry) error {
+	if link.Revoked {
+		return errExhausted
+	}
+	if link.MaxDownloads > 0 && link.Downloads >= link.MaxDownloads {
+		return errExhausted
+	}
+	link.Downloads++
+	return nil
+}
+
+// resolve returns the path a request addresses: the shared path itself, or
+// a path inside a shared directory. Hidden folders are never served.
+func resolve(link *config.ShareLinkEntry, sub string) (string, bool) {
+	if sub == "" {
+		return link.Path, true
+	}
+	if !link.IsDir {
+		return "", false
+	}
+	rel := path.Clean("/" + sub)
+	for _, part := range strings.Split(rel, "/") {
+		if part == ".hidden" {
+			return "", false
+		}
+	}
+	return path.Join(link.Path, rel), true
+}
+
+func password(r *http.Request) string {
+	if p := r.Header.Get(PasswordHeader); p != "" {
+		return p
+	}
+	if _, p, ok := r.BasicAuth(); ok {
+		return p
+	}
+	return r.PostFormValue("password")
+}
+
+type listEntry struct {
+	Name    string `json:"name"`
+	IsDir   bool   `json:"is_dir"`
+	Size    int64  `json:"size"`
+	ModTime int64  `json:"mod_time"`
+}
+
+func (h *Handler) serveList(w http.ResponseWriter, r *http.Request, dir string) {
+	entries, err := h.FS.ReadDir(r.Context(), dir)
+	if err != nil {
+		http.Error(w, "not found", http.StatusNotFound)
+		return
+	}
+	list := make([]listEntry, 0, len(entries))
+	for _, e := range entries {
+		if e.Name() == ".hidden" {
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			continue
+		}
+		list = append(list, listEntry{Name: e.Name(), IsDir: e.IsDir(), Size: info.Size(), ModTime: info.ModTime().Unix()})
+	}
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("Cache-Control", "private, no-store")
+	_ = json.NewEncoder(w).Encode(list)
+}
+
+func (h *Handler) serveZip(w http.ResponseWriter, r *http.Request, dir string) {
+	name := path.Base(dir) + ".zip"
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": name}))
+	w.Header().Set("Cache-Control", "private, no-store")
+	zw := zip.NewWriter(w)
+	if err := h.zipDir(r.Context(), zw, dir, path.Base(dir)); err != nil {
+		// Headers are gone already; a truncated archive is all the client gets.
+		slog.Warn("share link zip failed", "dir", dir, "err", err)
+	}
+	_ = zw.Close()
+}
+
+func (h *Handler) zipDir(ctx context.Context, zw *zip.Writer, dir, prefix string) error {
+	entries, err := h.FS.ReadDir(ctx, dir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if e.Name() == ".hidden" {
+			continue
+		}
+		p := path.Join(dir, e.Name())
+		name := prefix + "/" + e.Name()
+		if e.IsDir() {
+			if err := h.zipDir(ctx, zw, p, name); err != nil {
+				return err
+			}
+			continue
+		}
+		info, err := e.Info()
+		if err != nil {
+			return err
+		}
+		header, err := zip.FileInfoHeader(info)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		header.Method = zip.Deflate
+		dst, err := zw.CreateHeader(header)
+		if err != nil {
+			return err
+		}
+		src, err := h.FS.Open(ctx, p)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(dst, src)
+		src.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditContext carries the client address of r the way the audit log reads
+// it from gRPC calls.
+func auditContext(r *http.Request) context.Context {
+	return peer.NewContext(r.Context(), &peer.Peer{Addr: remoteAddr(r.RemoteAddr)})
+}
+
+type remoteAddr string
+
+func (a remoteAddr) Network() string { return "tcp" }
+func (a remoteAddr) String() string  { return string(a) }
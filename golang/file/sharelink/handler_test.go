@@ -0,0 +1,203 @@
+package sharelink
+
+import (
+	"archive/zip"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/security"
+	"github.com/globulario/services/golang/storage_backend"
+	"github.com/golang-jwt/jwt/v5"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// newTestHandler serves the files under a temp dir. Tokens are "tok-<id>",
+// "expired" or anything else, which is invalid.
+func newTestHandler(t *testing.T, links ...config.ShareLinkEntry) (*Handler, *MemoryStore) {
+	root := t.TempDir()
+	for name, content := range map[string]string{
+		"users/ann/photos/1.jpg":           "one",
+		"users/ann/photos/trip/2.jpg":      "two",
+		"users/ann/photos/.hidden/1/t.jpg": "thumb",
+		"users/ann/notes.txt":              "0123456789",
+		"users/ann/private/secret.txt":     "secret",
+	} {
+		p := filepath.Join(root, name)
+		if err := os.MkdirAll(filepath.Dir(p), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(p, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	store := NewMemoryStore()
+	for _, link := range links {
+		if err := store.Put(link); err != nil {
+			t.Fatal(err)
+		}
+	}
+	h := NewHandler(store, storage_backend.NewOSStorage(root))
+	h.Validate = func(token string) (*security.ShareClaims, error) {
+		if token == "expired" {
+			return nil, fmt.Errorf("share token: parse: %w", jwt.ErrTokenExpired)
+		}
+		id, ok := strings.CutPrefix(token, "tok-")
+		if !ok {
+			return nil, errors.New("share token: signature invalid")
+		}
+		link, err := store.Get(id)
+		if err != nil {
+			// The record is gone; the token itself is still valid.
+			return &security.ShareClaims{Path: "/gone", RegisteredClaims: jwt.RegisteredClaims{ID: id}}, nil
+		}
+		return &security.ShareClaims{Path: link.Path, RegisteredClaims: jwt.RegisteredClaims{ID: id}}, nil
+	}
+	return h, store
+}
+
+func get(h http.Handler, url string, header ...string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodGet, url, nil)
+	for i := 0; i+1 < len(header); i += 2 {
+		r.Header.Set(header[i], header[i+1])
+	}
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	return w
+}
+
+func TestShareFile(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("pw"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	exp := time.Now().Add(time.Hour).Unix()
+	h, store := newTestHandler(t,
+		config.ShareLinkEntry{ID: "f", Path: "/users/ann/notes.txt", Permissions: []string{"read"}, ExpiresAt: exp, MaxDownloads: 2},
+		config.ShareLinkEntry{ID: "p", Path: "/users/ann/notes.txt", Permissions: []string{"read"}, ExpiresAt: exp, PasswordHash: string(hash)},
+		config.ShareLinkEntry{ID: "r", Path: "/users/ann/notes.txt", Permissions: []string{"read"}, ExpiresAt: exp, Revoked: true},
+		config.ShareLinkEntry{ID: "old", Path: "/users/ann/notes.txt", Permissions: []string{"read"}, ExpiresAt: time.Now().Add(-time.Minute).Unix()},
+	)
+
+	for url, code := range map[string]int{
+		"/share/bogus":           http.StatusNotFound,
+		"/share/expired":         http.StatusGone,
+		"/share/tok-r":           http.StatusGone,
+		"/share/tok-old":         http.StatusGone,
+		"/share/tok-missing":     http.StatusGone,
+		"/share/tok-p":           http.StatusUnauthorized,
+		"/share/tok-f/other.txt": http.StatusNotFound,
+	} {
+		if w := get(h, url); w.Code != code {
+			t.Errorf("GET %s = %d, want %d", url, w.Code, code)
+		}
+	}
+	if w := get(h, "/share/tok-p", PasswordHeader, "pw"); w.Code != http.StatusOK || w.Body.String() != "0123456789" {
+		t.Errorf("with password = %d %q", w.Code, w.Body.String())
+	}
+
+	// Every GET counts, ranged or not.
+	if w := get(h, "/share/tok-f"); w.Code != http.StatusOK || w.Body.String() != "0123456789" {
+		t.Fatalf("download = %d %q", w.Code, w.Body.String())
+	}
+	if w := get(h, "/share/tok-f", "Range", "bytes=4-"); w.Code != http.StatusPartialContent || w.Body.String() != "456789" {
+		t.Errorf("range = %d %q", w.Code, w.Body.String())
+	}
+	// Once the limit is used up, nothing is served: no full download, no
+	// suffix or mid-file range, no HEAD.
+	for _, rng := range []string{"", "bytes=0-", "bytes=-3", "bytes=1-", "bytes=2-5"} {
+		var header []string
+		if rng != "" {
+			header = []string{"Range", rng}
+		}
+		if w := get(h, "/share/tok-f", header...); w.Code != http.StatusGone {
+			t.Errorf("GET past the limit with range %q = %d", rng, w.Code)
+		}
+	}
+	head := httptest.NewRecorder()
+	h.ServeHTTP(head, httptest.NewRequest(http.MethodHead, "/share/tok-f", nil))
+	if head.Code != http.StatusGone {
+		t.Errorf("HEAD past the limit = %d", head.Code)
+	}
+	if link, _ := store.Get("f"); link.Downloads != 2 {
+		t.Errorf("downloads = %d", link.Downloads)
+	}
+
+	r := httptest.NewRequest(http.MethodDelete, "/share/tok-f", nil)
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, r)
+	if w.Code != http.StatusMethodNotAllowed {
+		t.Errorf("DELETE = %d", w.Code)
+	}
+}
+
+func TestShareDir(t *testing.T) {
+	exp := time.Now().Add(time.Hour).Unix()
+	h, _ := newTestHandler(t,
+		config.ShareLinkEntry{ID: "d", Path: "/users/ann/photos", IsDir: true, Permissions: []string{"read", "list"}, ExpiresAt: exp},
+		config.ShareLinkEntry{ID: "z", Path: "/users/ann/photos", IsDir: true, Permissions: []string{"read"}, ExpiresAt: exp},
+	)
+
+	w := get(h, "/share/tok-d")
+	if w.Code != http.StatusOK || w.Header().Get("Content-Type") != "application/zip" {
+		t.Fatalf("zip = %d %s", w.Code, w.Header().Get("Content-Type"))
+	}
+	zr, err := zip.NewReader(bytes.NewReader(w.Body.Bytes()), int64(w.Body.Len()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+	}
+	sort.Strings(names)
+	if strings.Join(names, ",") != "photos/1.jpg,photos/trip/2.jpg" {
+		t.Errorf("zip entries = %v", names)
+	}
+	f, _ := zr.File[0].Open()
+	if data, _ := io.ReadAll(f); len(data) == 0 {
+		t.Error("empty zip entry")
+	}
+
+	if w := get(h, "/share/tok-d/trip/2.jpg"); w.Code != http.StatusOK || w.Body.String() != "two" {
+		t.Errorf("file in dir = %d %q", w.Code, w.Body.String())
+	}
+	for _, url := range []string{"/share/tok-d/../notes.txt", "/share/tok-d/../../ann/private/secret.txt", "/share/tok-d/.hidden/1/t.jpg"} {
+		if w := get(h, url); w.Code != http.StatusNotFound {
+			t.Errorf("GET %s = %d", url, w.Code)
+		}
+	}
+	if w := get(h, "/share/tok-d?list"); w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `"name":"trip"`) || strings.Contains(w.Body.String(), ".hidden") {
+		t.Errorf("list = %d %s", w.Code, w.Body.String())
+	}
+	if w := get(h, "/share/tok-z?list"); w.Code != http.StatusForbidden {
+		t.Errorf("list without permission = %d", w.Code)
+	}
+}
+
+func TestMemoryStoreUpdate(t *testing.T) {
+	store := NewMemoryStore()
+	if _, err := store.Update("x", consume); !errors.Is(err, ErrNotFound) {
+		t.Errorf("missing: err = %v", err)
+	}
+	_ = store.Put(config.ShareLinkEntry{ID: "x", MaxDownloads: 1})
+	if _, err := store.Update("x", consume); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Update("x", consume); !errors.Is(err, errExhausted) {
+		t.Errorf("exhausted: err = %v", err)
+	}
+	if link, _ := store.Get("x"); link.Downloads != 1 {
+		t.Errorf("downloads = %d", link.Downloads)
+	}
+}
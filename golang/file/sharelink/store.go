@@ -0,0 +1,112 @@
+// Package sharelink holds the share link records written by the file service
+// and the HTTP handler the gateway mounts on /share/ to serve them.
+//
+// A share link is a signed token (see security.MintShareToken) plus a record
+// in the cluster registry. The token proves the link was issued by the
+// cluster and bounds its life; the record carries what can change after the
+// token is handed out: revocation and the download count.
+package sharelink
+
+import (
+	"errors"
+	"sync"
+
+	"github.com/globulario/services/golang/config"
+)
+
+// ErrNotFound is returned when no share link has the given id.
+var ErrNotFound = config.ErrShareLinkNotFound
+
+// Store persists share link records.
+type Store interface {
+	Put(link config.ShareLinkEntry) error
+	Get(id string) (*config.ShareLinkEntry, error)
+	List() ([]config.ShareLinkEntry, error)
+	// Update applies fn and writes the result back atomically; when fn
+	// returns an error nothing is written.
+	Update(id string, fn func(*config.ShareLinkEntry) error) (*config.ShareLinkEntry, error)
+	Delete(id string) error
+}
+
+// EtcdStore keeps share links in the cluster registry, where every gateway
+// and file service instance sees them.
+type EtcdStore struct{}
+
+func (EtcdStore) Put(link config.ShareLinkEntry) error          { return config.PutShareLink(link) }
+func (EtcdStore) Get(id string) (*config.ShareLinkEntry, error) { return config.GetShareLink(id) }
+func (EtcdStore) List() ([]config.ShareLinkEntry, error)        { return config.ListShareLinks() }
+func (EtcdStore) Delete(id string) error                        { return config.DeleteShareLink(id) }
+
+func (EtcdStore) Update(id string, fn func(*config.ShareLinkEntry) error) (*config.ShareLinkEntry, error) {
+	return config.UpdateShareLink(id, fn)
+}
+
+// MemoryStore keeps share links in memory. It is meant for tests and
+// single-node tools.
+type MemoryStore struct {
+	mu    sync.Mutex
+	links map[string]config.ShareLinkEntry
+}
+
+// NewMemoryStore returns an empty MemoryStore.
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{links: make(map[string]config.ShareLinkEntry)}
+}
+
+func (m *MemoryStore) Put(link config.ShareLinkEntry) error {
+	if link.ID == "" {
+		return errors.New("share link id is empty")
+	}
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.links[link.ID] = clone(link)
+	return nil
+}
+
+func (m *MemoryStore) Get(id string) (*config.ShareLinkEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	link, ok := m.links[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	link = clone(link)
+	return &link, nil
+}
+
+func (m *MemoryStore) List() ([]config.ShareLinkEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	links := make([]config.ShareLinkEntry, 0, len(m.links))
+	for _, link := range m.links {
+		links = append(links, clone(link))
+	}
+	return links, nil
+}
+
+func (m *MemoryStore) Update(id string, fn func(*config.ShareLinkEntry) error) (*config.ShareLinkEntry, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	link, ok := m.links[id]
+	if !ok {
+		return nil, ErrNotFound
+	}
+	link = clone(link)
+	if err := fn(&link); err != nil {
+		return nil, err
+	}
+	m.links[id] = clone(link)
+	return &link, nil
+}
+
+func (m *MemoryStore) Delete(id string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.links, id)
+	return nil
+}
+
+func clone(link config.ShareLinkEntry) config.ShareLinkEntry {
+	link.Permissions = append([]string(nil), link.Permissions...)
+	return link
+}
@@ -63,6 +63,7 @@ var (
 	authLoginUser     string
 	authLoginPassword string
 	authLoginSave     bool
+	authLoginCode     string
 	rootOld           string
 	rootNew           string
 	rootConfirm       string
@@ -100,6 +101,22 @@ var (
 			}
 
 			token := resp.GetToken()
+			if resp.GetMfaRequired() {
+				if resp.GetMfaEnrollmentRequired() {
+					return errors.New("multi-factor authentication is required for this account: enroll a second factor first")
+				}
+				if authLoginCode == "" {
+					return fmt.Errorf("multi-factor authentication required (%s): rerun with --code", strings.Join(resp.GetMfaMethods(), ", "))
+				}
+				vresp, err := authMfaClientFactory(conn).VerifyMfa(ctxWithTimeout(), &authenticationpb.VerifyMfaRequest{
+					MfaToken: resp.GetMfaToken(),
+					Code:     authLoginCode,
+				})
+				if err != nil {
+					return fmt.Errorf("second factor rejected: %w", err)
+				}
+				token = vresp.GetToken()
+			}
 			if token == "" {
 				return errors.New("server returned an empty token")
 			}
@@ -237,6 +254,7 @@ require mTLS will work without a --ca flag or manual certificate setup.`,
 func init() {
 	authLoginCmd.Flags().StringVar(&authLoginUser, "user", "", "User email or name")
 	authLoginCmd.Flags().StringVar(&authLoginPassword, "password", "", "User password")
+	authLoginCmd.Flags().StringVar(&authLoginCode, "code", "", "TOTP or recovery code, for accounts with multi-factor authentication")
 	authLoginCmd.Flags().BoolVar(&authLoginSave, "save-token", false, "Persist token to ~/.config/globular/token (discouraged; explicit opt-in)")
 
 	authRootPassCmd.Flags().StringVar(&rootOld, "old", "", "Current root password")
@@ -310,3 +328,13 @@ type authInstallCertsClient interface {
 var authInstallCertsClientFactory = func(conn grpc.ClientConnInterface) authInstallCertsClient {
 	return authenticationpb.NewAuthenticationServiceClient(conn)
 }
+
+// authMfaClient completes a login that needs a second factor.
+// Kept separate from authServiceClient so existing test fakes are unaffected.
+type authMfaClient interface {
+	VerifyMfa(ctx context.Context, in *authenticationpb.VerifyMfaRequest, opts ...grpc.CallOption) (*authenticationpb.VerifyMfaResponse, error)
+}
+
+var authMfaClientFactory = func(conn grpc.ClientConnInterface) authMfaClient {
+	return authenticationpb.NewAuthenticationServiceClient(conn)
+}
@@ -192,3 +192,49 @@ func TestAuthLoginPersistsTokenWhenExplicitlyRequested(t *testing.T) {
 		t.Fatalf("unexpected token content: %q", string(b))
 	}
 }
+
+type fakeMfaLoginClient struct {
+	fakeAuthClient
+	verified *authenticationpb.VerifyMfaRequest
+}
+
+func (f *fakeMfaLoginClient) Authenticate(ctx context.Context, in *authenticationpb.AuthenticateRqst, opts ...grpc.CallOption) (*authenticationpb.AuthenticateRsp, error) {
+	f.authCalled = true
+	return &authenticationpb.AuthenticateRsp{MfaRequired: true, MfaToken: "pending", MfaMethods: []string{"totp", "recovery"}}, nil
+}
+
+func (f *fakeMfaLoginClient) VerifyMfa(ctx context.Context, in *authenticationpb.VerifyMfaRequest, opts ...grpc.CallOption) (*authenticationpb.VerifyMfaResponse, error) {
+	f.verified = in
+	return &authenticationpb.VerifyMfaResponse{Token: "session"}, nil
+}
+
+func TestAuthLoginWithSecondFactor(t *testing.T) {
+	fc := &fakeMfaLoginClient{}
+	oldClient, oldMfa, oldConn := authClientFactory, authMfaClientFactory, authConnFactory
+	oldUser, oldPass, oldSave, oldCode := authLoginUser, authLoginPassword, authLoginSave, authLoginCode
+	defer func() {
+		authClientFactory, authMfaClientFactory, authConnFactory = oldClient, oldMfa, oldConn
+		authLoginUser, authLoginPassword, authLoginSave, authLoginCode = oldUser, oldPass, oldSave, oldCode
+	}()
+	authClientFactory = func(conn grpc.ClientConnInterface) authServiceClient { return fc }
+	authMfaClientFactory = func(conn grpc.ClientConnInterface) authMfaClient { return fc }
+	authConnFactory = func() (grpc.ClientConnInterface, func(), error) { return authFakeConn{}, func() {}, nil }
+	authLoginUser, authLoginPassword, authLoginSave = "alice", "StrongPassword123!", false
+
+	authLoginCode = ""
+	err := authLoginCmd.RunE(nil, nil)
+	if err == nil || !strings.Contains(err.Error(), "--code") {
+		t.Fatalf("expected a --code hint, got %v", err)
+	}
+	if fc.verified != nil {
+		t.Fatal("VerifyMfa called without a code")
+	}
+
+	authLoginCode = "123456"
+	if err := authLoginCmd.RunE(nil, nil); err != nil {
+		t.Fatalf("login failed: %v", err)
+	}
+	if fc.verified == nil || fc.verified.MfaToken != "pending" || fc.verified.Code != "123456" {
+		t.Fatalf("VerifyMfa request = %+v", fc.verified)
+	}
+}
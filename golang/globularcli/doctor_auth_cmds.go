@@ -234,6 +234,11 @@ func isMethodAllowlisted(method string) bool {
 		"/grpc.reflection.v1alpha.ServerReflection/ServerReflectionInfo",
 		"/authentication.AuthenticationService/Authenticate",
 		"/authentication.AuthenticationService/RefreshToken",
+		"/authentication.AuthenticationService/VerifyMfa",
+		"/authentication.AuthenticationService/EnrollTotp",
+		"/authentication.AuthenticationService/ConfirmTotp",
+		"/authentication.AuthenticationService/BeginWebAuthnRegistration",
+		"/authentication.AuthenticationService/FinishWebAuthnRegistration",
 	}
 	for _, m := range allowlisted {
 		if m == method {
@@ -0,0 +1,184 @@
+// persistence_cmds.go: CLI commands for persistence schema migrations.
+//
+//   globular persistence migrate        --connection <id> --database <db> --dir <path>
+//   globular persistence migrate plan   --connection <id> --database <db> --dir <path>
+//   globular persistence migrate status --connection <id> --database <db>
+//
+// Migration files are named <version>_<name>.sql (SQL store), .cql (Scylla)
+// or .json (MongoDB command array). The whole directory is sent; the service
+// runs the scripts of its own dialect that its ledger does not list yet.
+
+package main
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/grpc"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/persistence/persistence_client"
+	"github.com/globulario/services/golang/persistence/persistencepb"
+)
+
+func resolvePersistenceAddr() string {
+	return config.ResolveServiceAddr("persistence.PersistenceService", "")
+}
+
+var (
+	persistenceCmd = &cobra.Command{
+		Use:   "persistence",
+		Short: "Manage persistence connections",
+	}
+
+	migrateConnection string
+	migrateDatabase   string
+	migrateDir        string
+
+	persistenceMigrateCmd = &cobra.Command{
+		Use:   "migrate",
+		Short: "Apply the pending schema migrations of a directory to a database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrations, err := loadMigrationDir()
+			if err != nil {
+				return err
+			}
+			cc, client, err := dialPersistence()
+			if err != nil {
+				return err
+			}
+			defer cc.Close()
+
+			rsp, err := client.ApplyMigrations(ctxWithTimeout(), &persistencepb.ApplyMigrationsRqst{
+				Id:         migrateConnection,
+				Database:   migrateDatabase,
+				Migrations: migrations,
+			})
+			if err != nil {
+				return fmt.Errorf("ApplyMigrations: %w", err)
+			}
+			if len(rsp.GetApplied()) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "database %q is up to date\n", migrateDatabase)
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tDURATION")
+			for _, a := range rsp.GetApplied() {
+				fmt.Fprintf(w, "%d\t%s\t%s\n", a.GetVersion(), a.GetName(), time.Duration(a.GetDurationMs())*time.Millisecond)
+			}
+			return w.Flush()
+		},
+	}
+
+	persistenceMigratePlanCmd = &cobra.Command{
+		Use:   "plan",
+		Short: "List the migrations of a directory that migrate would apply",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			migrations, err := loadMigrationDir()
+			if err != nil {
+				return err
+			}
+			cc, client, err := dialPersistence()
+			if err != nil {
+				return err
+			}
+			defer cc.Close()
+
+			rsp, err := client.PlanMigrations(ctxWithTimeout(), &persistencepb.PlanMigrationsRqst{
+				Id:         migrateConnection,
+				Database:   migrateDatabase,
+				Migrations: migrations,
+			})
+			if err != nil {
+				return fmt.Errorf("PlanMigrations: %w", err)
+			}
+			if len(rsp.GetPending()) == 0 {
+				fmt.Fprintf(cmd.OutOrStdout(), "database %q is up to date (%s)\n", migrateDatabase, rsp.GetDialect())
+				return nil
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tDIALECT\tCHECKSUM")
+			for _, m := range rsp.GetPending() {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%.12s\n", m.GetVersion(), m.GetName(), m.GetDialect(), m.GetChecksum())
+			}
+			return w.Flush()
+		},
+	}
+
+	persistenceMigrateStatusCmd = &cobra.Command{
+		Use:   "status",
+		Short: "List the migrations applied to a database",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if migrateConnection == "" || migrateDatabase == "" {
+				return errors.New("--connection and --database are required")
+			}
+			cc, client, err := dialPersistence()
+			if err != nil {
+				return err
+			}
+			defer cc.Close()
+
+			rsp, err := client.ListMigrations(ctxWithTimeout(), &persistencepb.ListMigrationsRqst{
+				Id:       migrateConnection,
+				Database: migrateDatabase,
+			})
+			if err != nil {
+				return fmt.Errorf("ListMigrations: %w", err)
+			}
+
+			w := tabwriter.NewWriter(cmd.OutOrStdout(), 0, 0, 2, ' ', 0)
+			fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED\tCHECKSUM")
+			for _, a := range rsp.GetMigrations() {
+				fmt.Fprintf(w, "%d\t%s\t%s\t%.12s\n", a.GetVersion(), a.GetName(),
+					time.UnixMilli(a.GetAppliedAt()).Format(time.RFC3339), a.GetChecksum())
+			}
+			return w.Flush()
+		},
+	}
+)
+
+// loadMigrationDir validates the flags and reads the migration files.
+func loadMigrationDir() ([]*persistencepb.Migration, error) {
+	if migrateConnection == "" || migrateDatabase == "" {
+		return nil, errors.New("--connection and --database are required")
+	}
+	if migrateDir == "" {
+		return nil, errors.New("--dir is required")
+	}
+	migrations, err := persistence_client.LoadMigrations(os.DirFS(migrateDir), ".")
+	if err != nil {
+		return nil, err
+	}
+	if len(migrations) == 0 {
+		return nil, fmt.Errorf("no migration files found in %s", migrateDir)
+	}
+	return migrations, nil
+}
+
+func dialPersistence() (*grpc.ClientConn, persistencepb.PersistenceServiceClient, error) {
+	addr := resolvePersistenceAddr()
+	if addr == "" {
+		return nil, nil, fmt.Errorf("persistence service not discoverable — ensure the cluster is running")
+	}
+	cc, err := dialGRPC(addr)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cc, persistencepb.NewPersistenceServiceClient(cc), nil
+}
+
+func init() {
+	persistenceMigrateCmd.PersistentFlags().StringVar(&migrateConnection, "connection", "", "Persistence connection id")
+	persistenceMigrateCmd.PersistentFlags().StringVar(&migrateDatabase, "database", "", "Database (keyspace) to migrate")
+	persistenceMigrateCmd.PersistentFlags().StringVar(&migrateDir, "dir", "", "Directory holding the migration files")
+
+	persistenceMigrateCmd.AddCommand(persistenceMigratePlanCmd, persistenceMigrateStatusCmd)
+	persistenceCmd.AddCommand(persistenceMigrateCmd)
+	rootCmd.AddCommand(persistenceCmd)
+}
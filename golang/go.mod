@@ -21,6 +21,7 @@ require (
 	github.com/emicklei/proto v1.13.2
 	github.com/globulario/globular-installer v0.0.0-20260629010219-cdedae875719
 	github.com/globulario/utility v0.1.9
+	github.com/go-asn1-ber/asn1-ber v1.5.5
 	github.com/go-ldap/ldap/v3 v3.4.8
 	github.com/go-sql-driver/mysql v1.8.1
 	github.com/gocolly/colly/v2 v2.1.0
@@ -48,6 +49,7 @@ require (
 	go.etcd.io/etcd/client/v3 v3.5.14
 	go.mongodb.org/mongo-driver v1.16.0
 	golang.org/x/crypto v0.48.0
+	golang.org/x/net v0.51.0
 	google.golang.org/grpc v1.81.1
 	google.golang.org/protobuf v1.36.11
 	gopkg.in/gomail.v2 v2.0.0-20160411212932-81ebce5c23df
@@ -73,7 +75,6 @@ require (
 	github.com/txn2/txeh v1.5.5 // indirect
 	go.opentelemetry.io/auto/sdk v1.2.1 // indirect
 	go.opentelemetry.io/otel/metric v1.43.0 // indirect
-	golang.org/x/net v0.51.0 // indirect
 	golang.org/x/text v0.34.0 // indirect
 )
 
@@ -137,7 +138,6 @@ require (
 	github.com/emersion/go-sasl v0.0.0-20200509203442-7bfe0ed36a21 // indirect
 	github.com/glendc/go-external-ip v0.1.0 // indirect
 	github.com/go-acme/lego/v4 v4.25.2
-	github.com/go-asn1-ber/asn1-ber v1.5.5 // indirect
 	github.com/go-llsqlite/adapter v0.0.0-20230927005056-7f5ce7f0c916 // indirect
 	github.com/go-llsqlite/crawshaw v0.5.2-0.20240425034140-f30eb7704568 // indirect
 	github.com/go-logr/logr v1.4.3 // indirect
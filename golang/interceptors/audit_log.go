@@ -51,6 +51,13 @@ var PolicyVersion = "unknown"
 // - "bootstrap_method_blocked": Denied because method not in bootstrap allowlist
 // - "cluster_id_missing": Denied because cluster_id required after initialization
 // - "cluster_id_mismatch": Denied because cluster_id doesn't match local cluster
+// - "share_link_granted": Allowed by a valid file share link (HTTP /share/)
+// - "share_link_invalid": Denied because the share token is malformed or not signed by the cluster
+// - "share_link_expired": Denied because the share link expired
+// - "share_link_revoked": Denied because the share link was revoked or removed
+// - "share_link_password": Denied because the share link password is missing or wrong
+// - "share_link_exhausted": Denied because the share link download limit is reached
+// - "share_link_denied": Denied because the path or operation is outside the share link
 type AuditDecision struct {
 	// When was this decision made
 	Timestamp time.Time `json:"timestamp"`
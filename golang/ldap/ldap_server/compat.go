@@ -0,0 +1,148 @@
+//go:build !js
+
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"net"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	ldap "github.com/vjeantet/ldapserver"
+)
+
+// ---- goldap compatibility ---------------------------------------------------
+//
+// goldap only decodes DER: a BOOLEAN TRUE must be 0xFF, where BER clients
+// such as go-ldap send 0x01, and an extensible match must carry its
+// dnAttributes flag, which every client leaves out when it is FALSE. Such
+// requests are dropped and the client waits forever, so connections are
+// wrapped to rewrite inbound messages before goldap sees them.
+
+// Filter choice tags (RFC 4511 §4.5.1).
+const (
+	filterAnd        = 0
+	filterOr         = 1
+	filterNot        = 2
+	filterExtensible = 9
+	dnAttributesTag  = 4
+)
+
+// withFilterCompat is a ListenAndServe option; pass it after any option that
+// replaces the listener so it sees plaintext.
+func withFilterCompat(sv *ldap.Server) {
+	if sv.Listener != nil {
+		sv.Listener = compatListener{sv.Listener}
+	}
+}
+
+type compatListener struct{ net.Listener }
+
+func (l compatListener) Accept() (net.Conn, error) {
+	c, err := l.Listener.Accept()
+	if err != nil {
+		return nil, err
+	}
+	return &compatConn{Conn: c, r: bufio.NewReader(c)}, nil
+}
+
+// compatConn hands out inbound messages one at a time, rewritten if needed.
+type compatConn struct {
+	net.Conn
+	r   *bufio.Reader
+	buf bytes.Buffer
+}
+
+func (c *compatConn) Read(p []byte) (int, error) {
+	if c.buf.Len() == 0 {
+		var raw bytes.Buffer
+		packet, err := ber.ReadPacket(io.TeeReader(c.r, &raw))
+		if err != nil {
+			if raw.Len() > 0 {
+				// Let the server see, and report, what could not be parsed.
+				return copy(p, raw.Bytes()), nil
+			}
+			return 0, err
+		}
+		if fixMessage(packet) {
+			reencode(packet)
+			c.buf.Write(packet.Bytes())
+		} else {
+			c.buf.Write(raw.Bytes())
+		}
+	}
+	return c.buf.Read(p)
+}
+
+// fixMessage rewrites an inbound message into what goldap accepts and
+// reports whether it changed anything.
+func fixMessage(message *ber.Packet) bool {
+	changed := fixBooleans(message)
+	if len(message.Children) >= 2 {
+		op := message.Children[1]
+		if op.ClassType == ber.ClassApplication && op.Tag == ldapv3.ApplicationSearchRequest && len(op.Children) >= 7 {
+			changed = fixFilter(op.Children[6]) || changed
+		}
+	}
+	return changed
+}
+
+// fixBooleans encodes every universal BOOLEAN TRUE as 0xFF.
+func fixBooleans(p *ber.Packet) bool {
+	if p.TagType == ber.TypeConstructed {
+		changed := false
+		for _, c := range p.Children {
+			changed = fixBooleans(c) || changed
+		}
+		return changed
+	}
+	if p.ClassType != ber.ClassUniversal || p.Tag != ber.TagBoolean || p.Data.Len() != 1 {
+		return false
+	}
+	if b := p.Data.Bytes()[0]; b == 0x00 || b == 0xFF {
+		return false
+	}
+	p.Data = bytes.NewBuffer([]byte{0xFF})
+	return true
+}
+
+func fixFilter(f *ber.Packet) bool {
+	if f.ClassType != ber.ClassContext {
+		return false
+	}
+	changed := false
+	switch f.Tag {
+	case filterAnd, filterOr, filterNot:
+		for _, c := range f.Children {
+			changed = fixFilter(c) || changed
+		}
+	case filterExtensible:
+		for _, c := range f.Children {
+			if c.ClassType == ber.ClassContext && c.Tag == dnAttributesTag {
+				if c.Data.Len() == 1 && c.Data.Bytes()[0] != 0x00 && c.Data.Bytes()[0] != 0xFF {
+					c.Data = bytes.NewBuffer([]byte{0xFF})
+					return true
+				}
+				return false
+			}
+		}
+		f.Children = append(f.Children, ber.NewBoolean(ber.ClassContext, ber.TypePrimitive, dnAttributesTag, false, "dnAttributes"))
+		changed = true
+	}
+	return changed
+}
+
+// reencode rebuilds the contents of constructed packets from their children,
+// which ber does not do when a child is added below the top level.
+func reencode(p *ber.Packet) {
+	if p.TagType != ber.TypeConstructed {
+		return
+	}
+	p.Data = new(bytes.Buffer)
+	for _, c := range p.Children {
+		reencode(c)
+		p.Data.Write(c.Bytes())
+	}
+}
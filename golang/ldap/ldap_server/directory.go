@@ -0,0 +1,350 @@
+//go:build !js
+
+package main
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	lmsg "github.com/lor00x/goldap/message"
+	ldap "github.com/vjeantet/ldapserver"
+
+	"github.com/globulario/services/golang/resource/resourcepb"
+)
+
+// ---- Directory model --------------------------------------------------------
+//
+// Every search is answered from a snapshot of the resource service laid out as
+//
+//	<baseDN>
+//	├── ou=people  uid=<account>  (inetOrgPerson, memberOf)
+//	├── ou=groups  cn=<group>     (groupOfNames, member, memberOf)
+//	├── ou=roles   cn=<role>      (groupOfNames + globularRole, member, memberOf)
+//	└── ou=orgs    o=<org>        (organization, member, uniqueMember for roles)
+//
+// Membership is recorded on both sides in the resource service and either side
+// may be stale, so the union of both is published.
+
+// operationalAttrs are only returned when asked for by name or with "+".
+var operationalAttrs = map[string]bool{
+	"memberof":        true,
+	"entrydn":         true,
+	"entryuuid":       true,
+	"hassubordinates": true,
+}
+
+// dnAttrs hold DNs and are compared after DN normalization.
+var dnAttrs = map[string]bool{
+	"member":         true,
+	"uniquemember":   true,
+	"memberof":       true,
+	"entrydn":        true,
+	"namingcontexts": true,
+}
+
+// attrAliases maps alternative names and OIDs onto the names used in entries.
+var attrAliases = map[string]string{
+	"commonname":                 "cn",
+	"2.5.4.3":                    "cn",
+	"surname":                    "sn",
+	"2.5.4.4":                    "sn",
+	"userid":                     "uid",
+	"0.9.2342.19200300.100.1.1":  "uid",
+	"rfc822mailbox":              "mail",
+	"0.9.2342.19200300.100.1.3":  "mail",
+	"organizationname":           "o",
+	"2.5.4.10":                   "o",
+	"organizationalunitname":     "ou",
+	"2.5.4.11":                   "ou",
+	"domaincomponent":            "dc",
+	"0.9.2342.19200300.100.1.25": "dc",
+	"2.5.4.0":                    "objectclass",
+	"2.5.4.31":                   "member",
+}
+
+// attrKey is the lookup key of an attribute description: lower-cased, without
+// options such as ";binary", aliases resolved.
+func attrKey(desc string) string {
+	k := strings.ToLower(strings.TrimSpace(desc))
+	if i := strings.IndexByte(k, ';'); i >= 0 {
+		k = k[:i]
+	}
+	if a, ok := attrAliases[k]; ok {
+		return a
+	}
+	return k
+}
+
+type dirAttr struct {
+	name   string
+	values []string
+}
+
+// dirEntry is one directory object with multi-valued attributes.
+type dirEntry struct {
+	dn    string
+	attrs []dirAttr
+}
+
+// add appends values to the named attribute, skipping empty ones.
+func (e *dirEntry) add(name string, values ...string) {
+	var vs []string
+	for _, v := range values {
+		if v != "" {
+			vs = append(vs, v)
+		}
+	}
+	if len(vs) == 0 {
+		return
+	}
+	key := attrKey(name)
+	for i := range e.attrs {
+		if attrKey(e.attrs[i].name) == key {
+			e.attrs[i].values = append(e.attrs[i].values, vs...)
+			return
+		}
+	}
+	e.attrs = append(e.attrs, dirAttr{name: name, values: vs})
+}
+
+// get returns the values of an attribute, nil when absent.
+func (e *dirEntry) get(desc string) []string {
+	key := attrKey(desc)
+	for _, a := range e.attrs {
+		if attrKey(a.name) == key {
+			return a.values
+		}
+	}
+	return nil
+}
+
+// result renders e as a SearchResultEntry holding the requested attributes
+// (RFC 4511 §4.5.1.8): none means all user attributes, "*" all user
+// attributes, "+" all operational ones and "1.1" none at all.
+func (e *dirEntry) result(requested lmsg.AttributeSelection, typesOnly bool) lmsg.SearchResultEntry {
+	all, ops := len(requested) == 0, false
+	named := map[string]bool{}
+	for _, r := range requested {
+		switch s := string(r); s {
+		case "*":
+			all = true
+		case "+":
+			ops = true
+		case "1.1":
+		default:
+			named[attrKey(s)] = true
+		}
+	}
+
+	out := ldap.NewSearchResultEntry(e.dn)
+	for _, a := range e.attrs {
+		key := attrKey(a.name)
+		if !named[key] && !(operationalAttrs[key] && ops) && !(!operationalAttrs[key] && all) {
+			continue
+		}
+		if typesOnly {
+			out.AddAttribute(lmsg.AttributeDescription(a.name))
+			continue
+		}
+		out.AddAttribute(lmsg.AttributeDescription(a.name), vals(a.values...)...)
+	}
+	return out
+}
+
+// rootDSE describes the server itself (RFC 4512 §5.1).
+func (lf *ldapFacade) rootDSE() *dirEntry {
+	e := &dirEntry{dn: ""}
+	e.add("objectClass", "top")
+	e.add("namingContexts", lf.baseDN)
+	e.add("supportedLDAPVersion", "3")
+	e.add("supportedControl", pagedResultsOID)
+	e.add("vendorName", "Globular")
+	return e
+}
+
+// directory loads the current accounts, groups, roles and organizations.
+func (lf *ldapFacade) directory() ([]*dirEntry, error) {
+	accounts, err := lf.rc.GetAccounts("{}")
+	if err != nil {
+		return nil, fmt.Errorf("get accounts: %w", err)
+	}
+	groups, err := lf.rc.GetGroups("{}")
+	if err != nil {
+		return nil, fmt.Errorf("get groups: %w", err)
+	}
+	roles, err := lf.rc.GetRoles("{}")
+	if err != nil {
+		return nil, fmt.Errorf("get roles: %w", err)
+	}
+	orgs, err := lf.rc.GetOrganizations("{}")
+	if err != nil {
+		return nil, fmt.Errorf("get organizations: %w", err)
+	}
+	return buildDirectory(lf.baseDN, accounts, groups, roles, orgs), nil
+}
+
+// localID strips the "@domain" suffix the resource service adds to references.
+func localID(ref string) string {
+	if i := strings.IndexByte(ref, '@'); i >= 0 {
+		return ref[:i]
+	}
+	return ref
+}
+
+// buildDirectory lays the resource objects out as directory entries.
+func buildDirectory(baseDN string, accounts []*resourcepb.Account, groups []*resourcepb.Group, roles []*resourcepb.Role, orgs []*resourcepb.Organization) []*dirEntry {
+	peopleDN := func(id string) string { return fmt.Sprintf("uid=%s,ou=people,%s", id, baseDN) }
+	groupDN := func(id string) string { return fmt.Sprintf("cn=%s,ou=groups,%s", id, baseDN) }
+	roleDN := func(id string) string { return fmt.Sprintf("cn=%s,ou=roles,%s", id, baseDN) }
+	orgDN := func(id string) string { return fmt.Sprintf("o=%s,ou=orgs,%s", id, baseDN) }
+
+	// Only link objects that exist; references can outlive their target.
+	exists := map[string]bool{}
+	for _, a := range accounts {
+		exists[peopleDN(a.Id)] = true
+	}
+	for _, g := range groups {
+		exists[groupDN(g.Id)] = true
+	}
+	for _, r := range roles {
+		exists[roleDN(r.Id)] = true
+	}
+	for _, o := range orgs {
+		exists[orgDN(o.Id)] = true
+	}
+
+	members := map[string]map[string]bool{}  // container DN -> member DNs
+	memberOf := map[string]map[string]bool{} // member DN -> container DNs
+	link := func(member, container string) {
+		if !exists[member] || !exists[container] {
+			return
+		}
+		if members[container] == nil {
+			members[container] = map[string]bool{}
+		}
+		if memberOf[member] == nil {
+			memberOf[member] = map[string]bool{}
+		}
+		members[container][member] = true
+		memberOf[member][container] = true
+	}
+	each := func(refs []string, f func(id string)) {
+		for _, ref := range refs {
+			if id := localID(ref); id != "" {
+				f(id)
+			}
+		}
+	}
+
+	for _, a := range accounts {
+		dn := peopleDN(a.Id)
+		each(a.Groups, func(id string) { link(dn, groupDN(id)) })
+		each(a.Roles, func(id string) { link(dn, roleDN(id)) })
+		each(a.Organizations, func(id string) { link(dn, orgDN(id)) })
+	}
+	for _, g := range groups {
+		dn := groupDN(g.Id)
+		each(g.Accounts, func(id string) { link(peopleDN(id), dn) })
+		each(g.Roles, func(id string) { link(dn, roleDN(id)) })
+		each(g.Organizations, func(id string) { link(dn, orgDN(id)) })
+	}
+	for _, r := range roles {
+		dn := roleDN(r.Id)
+		each(r.Accounts, func(id string) { link(peopleDN(id), dn) })
+		each(r.Groups, func(id string) { link(groupDN(id), dn) })
+		each(r.Organizations, func(id string) { link(dn, orgDN(id)) })
+	}
+	for _, o := range orgs {
+		dn := orgDN(o.Id)
+		each(o.Accounts, func(id string) { link(peopleDN(id), dn) })
+		each(o.Groups, func(id string) { link(groupDN(id), dn) })
+		each(o.Roles, func(id string) { link(roleDN(id), dn) })
+	}
+	sorted := func(set map[string]bool) []string {
+		out := make([]string, 0, len(set))
+		for v := range set {
+			out = append(out, v)
+		}
+		sort.Strings(out)
+		return out
+	}
+
+	var entries []*dirEntry
+	newEntry := func(dn string, classes ...string) *dirEntry {
+		e := &dirEntry{dn: dn}
+		e.add("objectClass", classes...)
+		entries = append(entries, e)
+		return e
+	}
+	finish := func(e *dirEntry, uuid string, leaf bool) {
+		e.add("entryDN", e.dn)
+		e.add("entryUUID", uuid)
+		if leaf {
+			e.add("hasSubordinates", "FALSE")
+		} else {
+			e.add("hasSubordinates", "TRUE")
+		}
+		e.add("member", sorted(members[e.dn])...)
+		e.add("memberOf", sorted(memberOf[e.dn])...)
+	}
+
+	base := newEntry(baseDN, "top", "domain", "dcObject")
+	base.add("dc", strings.TrimPrefix(strings.SplitN(baseDN, ",", 2)[0], "dc="))
+	finish(base, "", false)
+	for _, ou := range []string{"people", "groups", "roles", "orgs"} {
+		e := newEntry(fmt.Sprintf("ou=%s,%s", ou, baseDN), "top", "organizationalUnit")
+		e.add("ou", ou)
+		finish(e, "", false)
+	}
+
+	for _, a := range accounts {
+		e := newEntry(peopleDN(a.Id), "top", "person", "organizationalPerson", "inetOrgPerson")
+		e.add("uid", a.Id)
+		e.add("cn", first(nonEmpty(a.Name, a.Id)))
+		// sn is mandatory for person.
+		e.add("sn", first(nonEmpty(a.LastName, a.Name, a.Id)))
+		e.add("givenName", a.FirstName)
+		e.add("displayName", first(nonEmpty(strings.TrimSpace(a.FirstName+" "+a.LastName), a.Name, a.Id)))
+		e.add("mail", a.Email)
+		finish(e, a.Uuid, true)
+	}
+	for _, g := range groups {
+		e := newEntry(groupDN(g.Id), "top", "groupOfNames")
+		e.add("cn", g.Id)
+		e.add("description", first(nonEmpty(g.Description, g.Name)))
+		finish(e, g.Uuid, true)
+	}
+	for _, r := range roles {
+		e := newEntry(roleDN(r.Id), "top", "groupOfNames", "globularRole")
+		e.add("cn", r.Id)
+		e.add("description", first(nonEmpty(r.Description, r.Name)))
+		e.add("globularAction", r.Actions...)
+		finish(e, "", true)
+	}
+	for _, o := range orgs {
+		e := newEntry(orgDN(o.Id), "top", "organization")
+		e.add("o", o.Id)
+		e.add("description", first(nonEmpty(o.Description, o.Name)))
+		e.add("mail", o.Email)
+		finish(e, "", true)
+		// Roles have always been listed as uniqueMember too.
+		for _, dn := range sorted(members[e.dn]) {
+			if strings.HasSuffix(dn, ",ou=roles,"+baseDN) {
+				e.add("uniqueMember", dn)
+			}
+		}
+	}
+	return entries
+}
+
+// nonEmpty drops empty strings, keeping order.
+func nonEmpty(vs ...string) []string {
+	out := vs[:0:0]
+	for _, v := range vs {
+		if v != "" {
+			out = append(out, v)
+		}
+	}
+	return out
+}
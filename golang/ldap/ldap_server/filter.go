@@ -0,0 +1,205 @@
+//go:build !js
+
+package main
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	lmsg "github.com/lor00x/goldap/message"
+)
+
+// ---- Search filters (RFC 4511 §4.5.1.7, RFC 4515) ---------------------------
+
+// Matching rules understood by extensible matches.
+const (
+	ruleCaseIgnore = "2.5.13.2"
+	ruleCaseExact  = "2.5.13.5"
+	ruleDN         = "2.5.13.1"
+	ruleIA5Ignore  = "1.3.6.1.4.1.1466.109.114.2"
+	ruleBitAnd     = "1.2.840.113556.1.4.803"
+	ruleBitOr      = "1.2.840.113556.1.4.804"
+	ruleInChain    = "1.2.840.113556.1.4.1941" // groups do not nest here, so same as equality
+)
+
+// matchFilter evaluates a parsed search filter against e. Attribute names are
+// case-insensitive and ignore options; values compare with caseIgnoreMatch,
+// or as normalized DNs for DN-valued attributes. A missing attribute never
+// matches, so (!(a=b)) selects entries without a.
+func matchFilter(f lmsg.Filter, e *dirEntry) bool {
+	switch f := f.(type) {
+	case lmsg.FilterAnd:
+		for _, c := range f {
+			if !matchFilter(c, e) {
+				return false
+			}
+		}
+		return true
+
+	case lmsg.FilterOr:
+		for _, c := range f {
+			if matchFilter(c, e) {
+				return true
+			}
+		}
+		return false
+
+	case lmsg.FilterNot:
+		return !matchFilter(f.Filter, e)
+
+	case lmsg.FilterPresent:
+		return len(e.get(string(f))) > 0
+
+	case lmsg.FilterEqualityMatch:
+		attr, want := string(f.AttributeDesc()), string(f.AssertionValue())
+		return anyValue(e, attr, func(v string) bool { return equalFold(attr, v, want) })
+
+	case lmsg.FilterApproxMatch:
+		attr, want := string(f.AttributeDesc()), approx(string(f.AssertionValue()))
+		return anyValue(e, attr, func(v string) bool { return approx(v) == want })
+
+	case lmsg.FilterGreaterOrEqual:
+		attr, want := string(f.AttributeDesc()), string(f.AssertionValue())
+		return anyValue(e, attr, func(v string) bool { return compareValues(v, want) >= 0 })
+
+	case lmsg.FilterLessOrEqual:
+		attr, want := string(f.AttributeDesc()), string(f.AssertionValue())
+		return anyValue(e, attr, func(v string) bool { return compareValues(v, want) <= 0 })
+
+	case lmsg.FilterSubstrings:
+		return anyValue(e, string(f.Type_()), func(v string) bool { return matchSubstrings(v, f.Substrings()) })
+
+	case lmsg.FilterExtensibleMatch:
+		return matchExtensible(f, e)
+	}
+	return false
+}
+
+func anyValue(e *dirEntry, attr string, match func(v string) bool) bool {
+	for _, v := range e.get(attr) {
+		if match(v) {
+			return true
+		}
+	}
+	return false
+}
+
+// equalFold compares two values of attr with its equality rule.
+func equalFold(attr, a, b string) bool {
+	if dnAttrs[attrKey(attr)] {
+		return normDN(a) == normDN(b)
+	}
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// approx folds case and drops whitespace, the usual approximate match.
+func approx(v string) string {
+	return strings.ToLower(strings.Join(strings.Fields(v), ""))
+}
+
+// compareValues orders integers numerically and anything else, including
+// GeneralizedTime, case-insensitively.
+func compareValues(a, b string) int {
+	if x, err := strconv.ParseInt(strings.TrimSpace(a), 10, 64); err == nil {
+		if y, err := strconv.ParseInt(strings.TrimSpace(b), 10, 64); err == nil {
+			switch {
+			case x < y:
+				return -1
+			case x > y:
+				return 1
+			}
+			return 0
+		}
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// matchSubstrings applies initial*any*...*final, in order, without case.
+func matchSubstrings(v string, parts []lmsg.Substring) bool {
+	v = strings.ToLower(v)
+	for _, p := range parts {
+		switch s := p.(type) {
+		case lmsg.SubstringInitial:
+			sub := strings.ToLower(string(s))
+			if !strings.HasPrefix(v, sub) {
+				return false
+			}
+			v = v[len(sub):]
+		case lmsg.SubstringAny:
+			sub := strings.ToLower(string(s))
+			i := strings.Index(v, sub)
+			if i < 0 {
+				return false
+			}
+			v = v[i+len(sub):]
+		case lmsg.SubstringFinal:
+			if !strings.HasSuffix(v, strings.ToLower(string(s))) {
+				return false
+			}
+			v = ""
+		}
+	}
+	return true
+}
+
+// matchExtensible handles (attr:rule:=value), (attr:dn:=value) and
+// (:rule:=value). goldap keeps the assertion's fields unexported, so they are
+// read through reflection.
+func matchExtensible(f lmsg.FilterExtensibleMatch, e *dirEntry) bool {
+	rv := reflect.ValueOf(f)
+	var rule, attr string
+	if p := rv.FieldByName("matchingRule"); p.IsValid() && !p.IsNil() {
+		rule = strings.ToLower(p.Elem().String())
+	}
+	if p := rv.FieldByName("type_"); p.IsValid() && !p.IsNil() {
+		attr = p.Elem().String()
+	}
+	want := rv.FieldByName("matchValue").String()
+	dnAttributes := rv.FieldByName("dnAttributes").Bool()
+
+	var match func(attr, v string) bool
+	switch rule {
+	case "", ruleCaseIgnore, "caseignorematch", ruleIA5Ignore, "caseignoreia5match", ruleDN, "distinguishednamematch", ruleInChain:
+		match = func(attr, v string) bool { return equalFold(attr, v, want) }
+	case ruleCaseExact, "caseexactmatch":
+		match = func(_, v string) bool { return v == want }
+	case ruleBitAnd, ruleBitOr:
+		mask, err := strconv.ParseUint(strings.TrimSpace(want), 10, 64)
+		if err != nil {
+			return false
+		}
+		match = func(_, v string) bool {
+			n, err := strconv.ParseUint(strings.TrimSpace(v), 10, 64)
+			if err != nil {
+				return false
+			}
+			if rule == ruleBitAnd {
+				return n&mask == mask
+			}
+			return n&mask != 0
+		}
+	default:
+		return false
+	}
+
+	for _, a := range e.attrs {
+		if attr != "" && attrKey(a.name) != attrKey(attr) {
+			continue
+		}
+		for _, v := range a.values {
+			if match(a.name, v) {
+				return true
+			}
+		}
+	}
+	if dnAttributes {
+		for _, rdn := range strings.Split(e.dn, ",") {
+			k, v, ok := strings.Cut(rdn, "=")
+			if ok && (attr == "" || attrKey(k) == attrKey(attr)) && match(k, strings.TrimSpace(v)) {
+				return true
+			}
+		}
+	}
+	return false
+}
@@ -128,8 +128,14 @@ func (srv *server) Authenticate(ctx context.Context, rqst *ldappb.AuthenticateRq
 		// I will made use of bind to authenticate the user.
 		_, err := srv.connect(id, login, pwd)
 		if err != nil {
+			// A refused bind is not a failing directory: callers count the first
+			// as a wrong password.
+			code := codes.Internal
+			if ldap.IsErrorWithCode(err, ldap.LDAPResultInvalidCredentials) {
+				code = codes.Unauthenticated
+			}
 			return nil, status.Errorf(
-				codes.Internal,
+				code,
 				"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 		}
 	} else {
@@ -4,9 +4,9 @@ package main
 
 import (
 	"crypto/tls"
+	"errors"
 	"fmt"
 	"log"
-	"reflect" // ← add this
 	"strings"
 	"sync"
 	"time"
@@ -19,10 +19,21 @@ import (
 	resource_client "github.com/globulario/services/golang/resource/resource_client"
 )
 
-// ---- SEARCH helpers: scope / filter / reflection ----------------------------
+// ---- SEARCH helpers: scope -------------------------------------------------
 
-// case-insensitive DN compare helpers
-func normDN(s string) string { return strings.ToLower(strings.TrimSpace(s)) }
+// normDN lower-cases a DN and drops the spaces around its separators, so
+// "UID=Alice, ou=People,..." compares equal to "uid=alice,ou=people,...".
+func normDN(s string) string {
+	parts := strings.Split(strings.ToLower(s), ",")
+	for i, p := range parts {
+		if k, v, ok := strings.Cut(p, "="); ok {
+			parts[i] = strings.TrimSpace(k) + "=" + strings.TrimSpace(v)
+		} else {
+			parts[i] = strings.TrimSpace(p)
+		}
+	}
+	return strings.Join(parts, ",")
+}
 
 func parentDN(dn string) string {
 	parts := strings.SplitN(dn, ",", 2)
@@ -54,191 +65,6 @@ func inScope(entryDN, baseDN string, scope int) bool {
 	}
 }
 
-// very small filter parser: supports (attr=value) and (&(a=b)(c=d))
-// recognized attrs: cn, uid, o, objectclass
-func parseEqFilters(f string) map[string]string {
-	f = strings.TrimSpace(f)
-	if f == "" || f == "(objectClass=*)" {
-		return nil
-	}
-	out := map[string]string{}
-	lower := strings.ToLower(f)
-
-	// strip outer (& ... ) if present
-	if strings.HasPrefix(lower, "(&") && strings.HasSuffix(lower, ")") {
-		inner := strings.TrimSuffix(strings.TrimPrefix(lower, "(&"), ")")
-		// split into sub-filters "(a=b)(c=d)"
-		for len(inner) > 0 {
-			if inner[0] != '(' {
-				break
-			}
-			end := strings.IndexByte(inner[1:], ')')
-			if end < 0 {
-				break
-			}
-			seg := inner[1 : 1+end]
-			if kv := strings.SplitN(seg, "=", 2); len(kv) == 2 {
-				k := strings.TrimSpace(kv[0])
-				v := strings.TrimSpace(kv[1])
-				if k != "" && v != "" {
-					out[k] = v
-				}
-			}
-			inner = inner[2+end:]
-		}
-		return out
-	}
-
-	// single (a=b)
-	if strings.HasPrefix(lower, "(") && strings.HasSuffix(lower, ")") {
-		body := strings.TrimSuffix(strings.TrimPrefix(lower, "("), ")")
-		if kv := strings.SplitN(body, "=", 2); len(kv) == 2 {
-			k := strings.TrimSpace(kv[0])
-			v := strings.TrimSpace(kv[1])
-			if k != "" && v != "" {
-				out[k] = v
-			}
-		}
-	}
-	return out
-}
-
-func matchesEq(attrs map[string]string, eq map[string]string) bool {
-	if len(eq) == 0 {
-		return true
-	}
-	for k, v := range eq {
-		k = strings.ToLower(k)
-		if k == "objectclass" {
-			// allow "objectClass" constraint when present
-			if got, ok := attrs["objectclass"]; !ok || !strings.Contains(strings.ToLower(got), strings.ToLower(v)) {
-				return false
-			}
-			continue
-		}
-		got, ok := attrs[k]
-		if !ok {
-			return false
-		}
-		if strings.ToLower(got) != strings.ToLower(v) {
-			return false
-		}
-	}
-	return true
-}
-
-// reflect helpers to fetch IDs from resource_client if such methods exist.
-// They safely return empty on absence / mismatch, so code still compiles & runs.
-
-func (lf *ldapFacade) callIDs(method string, args ...interface{}) []string {
-	rv := reflect.ValueOf(lf.rc)
-	m := rv.MethodByName(method)
-	if !m.IsValid() {
-		return nil
-	}
-
-	// build args
-	in := make([]reflect.Value, len(args))
-	for i, a := range args {
-		in[i] = reflect.ValueOf(a)
-	}
-	out := m.Call(in)
-	if len(out) == 0 {
-		return nil
-	}
-
-	// expect ([]string, error) or ([]T, error) or just []string / []T
-	var slice reflect.Value
-	if out[0].Kind() == reflect.Slice {
-		slice = out[0]
-	} else {
-		return nil
-	}
-
-	// try to detect trailing error
-	if len(out) > 1 && out[len(out)-1].Type().String() == "error" {
-		if !out[len(out)-1].IsNil() {
-			return nil
-		}
-	}
-
-	ids := []string{}
-	for i := 0; i < slice.Len(); i++ {
-		el := slice.Index(i).Interface()
-		switch v := el.(type) {
-		case string:
-			ids = append(ids, v)
-		default:
-			// try field "Id" / "ID"
-			rv := reflect.ValueOf(v)
-			if rv.Kind() == reflect.Ptr {
-				rv = rv.Elem()
-			}
-			if rv.Kind() == reflect.Struct {
-				f := rv.FieldByName("Id")
-				if !f.IsValid() {
-					f = rv.FieldByName("ID")
-				}
-				if f.IsValid() && f.Kind() == reflect.String {
-					ids = append(ids, f.String())
-				}
-			}
-		}
-	}
-	return ids
-}
-
-// ORG
-func (lf *ldapFacade) orgAccountIDs(orgID string) []string {
-	if ids := lf.callIDs("GetOrganizationAccounts", orgID); len(ids) > 0 {
-		return ids
-	}
-	if ids := lf.callIDs("GetOrganizationAccounts", lf.baseDN, orgID); len(ids) > 0 {
-		return ids
-	}
-	return nil
-}
-func (lf *ldapFacade) orgGroupIDs(orgID string) []string {
-	if ids := lf.callIDs("GetOrganizationGroups", orgID); len(ids) > 0 {
-		return ids
-	}
-	if ids := lf.callIDs("GetOrganizationGroups", lf.baseDN, orgID); len(ids) > 0 {
-		return ids
-	}
-	return nil
-}
-func (lf *ldapFacade) orgRoleIDs(orgID string) []string {
-	if ids := lf.callIDs("GetOrganizationRoles", orgID); len(ids) > 0 {
-		return ids
-	}
-	if ids := lf.callIDs("GetOrganizationRoles", lf.baseDN, orgID); len(ids) > 0 {
-		return ids
-	}
-	return nil
-}
-
-// GROUP
-func (lf *ldapFacade) groupAccountIDs(groupID string) []string {
-	if ids := lf.callIDs("GetGroupMemberAccounts", groupID); len(ids) > 0 {
-		return ids
-	}
-	if ids := lf.callIDs("GetGroupAccounts", groupID); len(ids) > 0 {
-		return ids
-	}
-	return nil
-}
-
-// ROLE
-func (lf *ldapFacade) roleAccountIDs(roleID string) []string {
-	if ids := lf.callIDs("GetRoleAccounts", roleID); len(ids) > 0 {
-		return ids
-	}
-	if ids := lf.callIDs("GetAccountsWithRole", roleID); len(ids) > 0 {
-		return ids
-	}
-	return nil
-}
-
 // ---- Public entrypoint ------------------------------------------------------
 
 // StartLDAPFacade starts plain LDAP on :389 and LDAPS on :636.
@@ -283,7 +109,7 @@ func (s *server) StartLDAPFacade() error {
 		if addr == "" {
 			addr = ":389"
 		}
-		if err := srv.ListenAndServe(addr); err != nil {
+		if err := srv.ListenAndServe(addr, withFilterCompat); err != nil {
 			log.Println("LDAP server error:", err)
 		} else {
 			log.Printf("LDAP listening on %s (base DN %s)\n", addr, baseDN)
@@ -321,7 +147,7 @@ func (s *server) StartLDAPFacade() error {
 			}
 		}
 
-		if err := srv.ListenAndServe(addr, wrapTLS); err != nil {
+		if err := srv.ListenAndServe(addr, wrapTLS, withFilterCompat); err != nil {
 			log.Println("LDAPS server error:", err)
 		} else {
 			log.Printf("LDAPS listening on %s (base DN %s)\n", addr, baseDN)
@@ -448,203 +274,23 @@ func (lf *ldapFacade) onBind(w ldap.ResponseWriter, m *ldap.Message) {
 	w.Write(res)
 }
 
-// getFilterString tries r.FilterString(), else falls back to fmt.
-func getFilterString(r *lmsg.SearchRequest) string {
-	if fs, ok := any(r).(interface{ FilterString() string }); ok {
-		return fs.FilterString()
-	}
-	return fmt.Sprintf("%v", r.Filter())
-}
-
-// Search: supports subtree under ou=people, ou=groups, ou=roles, ou=orgs
+// Search: evaluates the request over the whole directory; see directory.go for
+// the layout and filter.go for the supported filters.
 func (lf *ldapFacade) onSearch(w ldap.ResponseWriter, m *ldap.Message) {
 	r := m.GetSearchRequest()
-	base := string(r.BaseObject())
-	scopeInt := int(r.Scope())
-	filterStr := getFilterString(&r)
-	eq := parseEqFilters(filterStr)
-
-	send := func(e lmsg.ProtocolOp) { w.Write(e) }
-	sendDone := func() { w.Write(ldap.NewSearchResultDoneResponse(ldap.LDAPResultSuccess)) }
-
-	lowerBase := strings.ToLower(base)
-
-	// Root/base object
-	if normDN(lowerBase) == normDN(lf.baseDN) && scopeInt == scopeBaseObject {
-		e := ldap.NewSearchResultEntry(lf.baseDN)
-		for _, v := range vals("top", "domain") {
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), v)
-		}
-		send(e)
-		for _, ou := range []string{"people", "groups", "roles", "orgs"} {
-			dn := fmt.Sprintf("ou=%s,%s", ou, lf.baseDN)
-			ouEntry := ldap.NewSearchResultEntry(dn)
-			ouEntry.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("organizationalUnit"))
-			ouEntry.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("top"))
-			ouEntry.AddAttribute(lmsg.AttributeDescription("ou"), lmsg.AttributeValue(ou))
-			if inScope(dn, base, scopeInt) && matchesEq(map[string]string{"objectclass": "organizationalUnit"}, eq) {
-				send(ouEntry)
-			}
-		}
-		sendDone()
-		return
-	}
-
-	shouldSend := func(dn string, attrs map[string]string) bool {
-		return inScope(dn, base, scopeInt) && matchesEq(attrs, eq)
-	}
-
-	// People
-	if strings.Contains(lowerBase, "ou=people,") {
-		accounts, _ := lf.rc.GetAccounts("{}")
-		for _, a := range accounts {
-			dn := fmt.Sprintf("uid=%s,ou=people,%s", a.Id, lf.baseDN)
-			attrs := map[string]string{
-				"uid":         a.Id,
-				"cn":          a.Name,
-				"objectclass": "inetOrgPerson organizationalPerson person top",
-			}
-			if !shouldSend(dn, attrs) {
-				continue
-			}
-
-			e := ldap.NewSearchResultEntry(dn)
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("inetOrgPerson"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("organizationalPerson"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("person"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("top"))
-			e.AddAttribute(lmsg.AttributeDescription("uid"), lmsg.AttributeValue(a.Id))
-			if a.Name != "" {
-				e.AddAttribute(lmsg.AttributeDescription("cn"), lmsg.AttributeValue(a.Name))
-				e.AddAttribute(lmsg.AttributeDescription("sn"), lmsg.AttributeValue(a.Name))
-			}
-			if a.Email != "" {
-				e.AddAttribute(lmsg.AttributeDescription("mail"), lmsg.AttributeValue(a.Email))
-			}
-			send(e)
-		}
-		sendDone()
-		return
-	}
-
-	// Groups
-	if strings.Contains(lowerBase, "ou=groups,") {
-		groups, _ := lf.rc.GetGroups("{}")
-		for _, g := range groups {
-			dn := fmt.Sprintf("cn=%s,ou=groups,%s", g.Id, lf.baseDN)
-			attrs := map[string]string{
-				"cn":          g.Id,
-				"objectclass": "groupOfNames top",
-			}
-			if !shouldSend(dn, attrs) {
-				continue
-			}
-
-			e := ldap.NewSearchResultEntry(dn)
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("groupOfNames"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("top"))
-			e.AddAttribute(lmsg.AttributeDescription("cn"), lmsg.AttributeValue(g.Id))
-
-			// emit members (accounts) if discoverable
-			if ids := lf.groupAccountIDs(g.Id); len(ids) > 0 {
-				for _, uid := range ids {
-					memDN := fmt.Sprintf("uid=%s,ou=people,%s", uid, lf.baseDN)
-					e.AddAttribute(lmsg.AttributeDescription("member"), lmsg.AttributeValue(memDN))
-				}
-			}
-			send(e)
-		}
-		sendDone()
-		return
-	}
 
-	// Roles
-	if strings.Contains(lowerBase, "ou=roles,") {
-		roles, _ := lf.rc.GetRoles("{}")
-		for _, r0 := range roles {
-			dn := fmt.Sprintf("cn=%s,ou=roles,%s", r0.Id, lf.baseDN)
-			attrs := map[string]string{
-				"cn":          r0.Id,
-				"objectclass": "globularRole top",
-			}
-			if !shouldSend(dn, attrs) {
-				continue
-			}
-
-			e := ldap.NewSearchResultEntry(dn)
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("top"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("globularRole"))
-			e.AddAttribute(lmsg.AttributeDescription("cn"), lmsg.AttributeValue(r0.Id))
-			if len(r0.Actions) > 0 {
-				for _, action := range vals(r0.Actions...) {
-					e.AddAttribute(lmsg.AttributeDescription("globularAction"), action)
-				}
-			}
-			// members (accounts) if discoverable
-			if ids := lf.roleAccountIDs(r0.Id); len(ids) > 0 {
-				for _, uid := range ids {
-					memDN := fmt.Sprintf("uid=%s,ou=people,%s", uid, lf.baseDN)
-					e.AddAttribute(lmsg.AttributeDescription("member"), lmsg.AttributeValue(memDN))
-				}
-			}
-			send(e)
-		}
-		sendDone()
-		return
-	}
-
-	// Orgs
-	if strings.Contains(lowerBase, "ou=orgs,") {
-		orgs, _ := lf.rc.GetOrganizations("{}")
-		for _, o := range orgs {
-			dn := fmt.Sprintf("o=%s,ou=orgs,%s", o.Id, lf.baseDN)
-			attrs := map[string]string{
-				"o":           o.Id,
-				"objectclass": "organization top",
-			}
-			if !shouldSend(dn, attrs) {
-				continue
-			}
-
-			e := ldap.NewSearchResultEntry(dn)
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("organization"))
-			e.AddAttribute(lmsg.AttributeDescription("objectClass"), lmsg.AttributeValue("top"))
-			e.AddAttribute(lmsg.AttributeDescription("o"), lmsg.AttributeValue(o.Id))
-			if o.Description != "" {
-				e.AddAttribute(lmsg.AttributeDescription("description"), lmsg.AttributeValue(o.Description))
-			}
-			if o.Email != "" {
-				e.AddAttribute(lmsg.AttributeDescription("mail"), lmsg.AttributeValue(o.Email))
-			}
-
-			// members (accounts, groups, roles) if discoverable
-			if accs := lf.orgAccountIDs(o.Id); len(accs) > 0 {
-				for _, uid := range accs {
-					memDN := fmt.Sprintf("uid=%s,ou=people,%s", uid, lf.baseDN)
-					e.AddAttribute(lmsg.AttributeDescription("member"), lmsg.AttributeValue(memDN))
-				}
-			}
-			if grps := lf.orgGroupIDs(o.Id); len(grps) > 0 {
-				for _, gid := range grps {
-					memDN := fmt.Sprintf("cn=%s,ou=groups,%s", gid, lf.baseDN)
-					e.AddAttribute(lmsg.AttributeDescription("member"), lmsg.AttributeValue(memDN))
-				}
-			}
-			if roles := lf.orgRoleIDs(o.Id); len(roles) > 0 {
-				for _, rid := range roles {
-					memDN := fmt.Sprintf("cn=%s,ou=roles,%s", rid, lf.baseDN)
-					e.AddAttribute(lmsg.AttributeDescription("uniqueMember"), lmsg.AttributeValue(memDN))
-					e.AddAttribute(lmsg.AttributeDescription("member"), lmsg.AttributeValue(memDN))
-				}
-			}
-			send(e)
+	var dir []*dirEntry
+	if string(r.BaseObject()) != "" || int(r.Scope()) != scopeBaseObject {
+		var err error
+		if dir, err = lf.directory(); err != nil {
+			log.Printf("LDAP SEARCH error: %v", err)
+			done := lmsg.LDAPResult(ldap.NewSearchResultDoneResponse(ldap.LDAPResultUnavailable))
+			done.SetDiagnosticMessage("directory unavailable")
+			w.Write(lmsg.SearchResultDone(done))
+			return
 		}
-		sendDone()
-		return
 	}
-
-	// default
-	sendDone()
+	writeSearch(w, m, lf.search(&r, m.Controls(), dir))
 }
 
 // Add: user / group / role / org
@@ -755,110 +401,140 @@ func (lf *ldapFacade) onModify(w ldap.ResponseWriter, m *ldap.Message) {
 	}
 
 	r := m.GetModifyRequest()
-	kind, id := lf.parseDN(string(r.Object()))
+	dn := string(r.Object())
+	kind, id := lf.parseDN(dn)
 	if kind == "" {
 		w.Write(ldap.NewModifyResponse(ldap.LDAPResultUnwillingToPerform))
 		return
 	}
 
+	var err error
 	switch kind {
 	case "user":
-		acc, err := lf.rc.GetAccount(id)
-		if err != nil || acc == nil {
+		acc, e := lf.rc.GetAccount(id)
+		if e != nil || acc == nil {
 			w.Write(ldap.NewModifyResponse(ldap.LDAPResultNoSuchObject))
 			return
 		}
 		for _, ch := range r.Changes() {
-			typ := strings.ToLower(string(ch.Modification().Type_()))
-			switch typ {
+			v := first(toStrings(ch.Modification().Vals()))
+			if ch.Operation() == lmsg.ModifyRequestChangeOperationDelete {
+				v = ""
+			}
+			switch attrKey(string(ch.Modification().Type_())) {
 			case "mail":
-				acc.Email = first(toStrings(ch.Modification().Vals()))
+				acc.Email = v
 			case "cn":
-				acc.Name = first(toStrings(ch.Modification().Vals()))
+				acc.Name = v
+			case "givenname":
+				acc.FirstName = v
+			case "sn":
+				acc.LastName = v
 			case "userpassword":
-				newPwd := first(toStrings(ch.Modification().Vals()))
-				_ = lf.rc.SetAccountPassword(id, cs.token, "", newPwd)
+				if err = lf.rc.SetAccountPassword(id, cs.token, "", v); err != nil {
+					writeModifyResult(w, err)
+					return
+				}
 			}
 		}
 		err = lf.rc.SetAccount(cs.token, acc)
-		writeModifyResult(w, err)
 
 	case "group":
 		for _, ch := range r.Changes() {
-			op := ch.Operation()
-			if strings.ToLower(string(ch.Modification().Type_())) == "member" {
-				for _, v := range toStrings(ch.Modification().Vals()) {
-					_, uid := lf.parseDN(v)
-					if uid == "" {
-						continue
-					}
-					if op == lmsg.ModifyRequestChangeOperationAdd {
-						_ = lf.rc.AddGroupMemberAccount(cs.token, id, uid)
-					} else if op == lmsg.ModifyRequestChangeOperationDelete {
-						_ = lf.rc.RemoveGroupMemberAccount(cs.token, id, uid)
-					}
-				}
+			if attrKey(string(ch.Modification().Type_())) != "member" {
+				continue
 			}
+			err = errors.Join(err, lf.applyMembers(dn, ch,
+				accountsOnly(func(uid string) error { return lf.rc.AddGroupMemberAccount(cs.token, id, uid) }),
+				accountsOnly(func(uid string) error { return lf.rc.RemoveGroupMemberAccount(cs.token, id, uid) })))
 		}
-		w.Write(ldap.NewModifyResponse(ldap.LDAPResultSuccess))
 
 	case "role":
 		for _, ch := range r.Changes() {
-			t := strings.ToLower(string(ch.Modification().Type_()))
-			switch t {
+			switch attrKey(string(ch.Modification().Type_())) {
 			case "globularaction":
-				if ch.Operation() == lmsg.ModifyRequestChangeOperationAdd {
-					_ = lf.rc.AddRoleActions(cs.token, id, toStrings(ch.Modification().Vals()))
-				} else if ch.Operation() == lmsg.ModifyRequestChangeOperationDelete {
+				switch ch.Operation() {
+				case lmsg.ModifyRequestChangeOperationAdd:
+					err = errors.Join(err, lf.rc.AddRoleActions(cs.token, id, toStrings(ch.Modification().Vals())))
+				case lmsg.ModifyRequestChangeOperationDelete:
 					for _, a := range toStrings(ch.Modification().Vals()) {
-						_ = lf.rc.RemoveRoleAction(cs.token, id, a)
+						err = errors.Join(err, lf.rc.RemoveRoleAction(cs.token, id, a))
 					}
 				}
 			case "member":
-				// Expect member values as user DNs like uid=<user>,ou=people,<baseDN>
-				for _, v := range toStrings(ch.Modification().Vals()) {
-					_, uid := lf.parseDN(v)
-					if uid == "" {
-						continue
-					}
-					if ch.Operation() == lmsg.ModifyRequestChangeOperationAdd {
-						// TODO: replace with your actual resource client method
-						_ = lf.rc.AddAccountRole(cs.token, uid, id)
-					} else if ch.Operation() == lmsg.ModifyRequestChangeOperationDelete {
-						// TODO: replace with your actual resource client method
-						_ = lf.rc.RemoveAccountRole(cs.token, uid, id)
-					}
-				}
+				// Role members are accounts: uid=<user>,ou=people,<baseDN>
+				err = errors.Join(err, lf.applyMembers(dn, ch,
+					accountsOnly(func(uid string) error { return lf.rc.AddAccountRole(cs.token, uid, id) }),
+					accountsOnly(func(uid string) error { return lf.rc.RemoveAccountRole(cs.token, uid, id) })))
 			}
 		}
-		w.Write(ldap.NewModifyResponse(ldap.LDAPResultSuccess))
+
 	case "org":
 		for _, ch := range r.Changes() {
-			t := strings.ToLower(string(ch.Modification().Type_()))
-			switch t {
+			switch attrKey(string(ch.Modification().Type_())) {
 			// Accept both "member" and "uniqueMember" for compatibility
 			case "member", "uniquemember":
-				for _, v := range toStrings(ch.Modification().Vals()) {
-					kindRef, refID := lf.parseDN(v) // user / group / role by OU in the DN
-					if refID == "" {
-						continue
-					}
-					switch ch.Operation() {
-					case lmsg.ModifyRequestChangeOperationAdd:
-						if err := lf.addOrgMember(cs.token, id, kindRef, refID); err != nil {
-							log.Printf("LDAP ORG add member %s (%s/%s): %v", id, kindRef, refID, err)
-						}
-					case lmsg.ModifyRequestChangeOperationDelete:
-						if err := lf.removeOrgMember(cs.token, id, kindRef, refID); err != nil {
-							log.Printf("LDAP ORG remove member %s (%s/%s): %v", id, kindRef, refID, err)
-						}
-					}
-				}
-				// (optional) allow org profile tweaks later (description/mail)
+				err = errors.Join(err, lf.applyMembers(dn, ch,
+					func(kindRef, refID string) error { return lf.addOrgMember(cs.token, id, kindRef, refID) },
+					func(kindRef, refID string) error { return lf.removeOrgMember(cs.token, id, kindRef, refID) }))
 			}
 		}
-		w.Write(ldap.NewModifyResponse(ldap.LDAPResultSuccess))
 	}
+	writeModifyResult(w, err)
+}
+
+// applyMembers runs one member change through add and remove, which receive
+// the kind and id of each member DN. A replace becomes the difference between
+// the new values and the members the entry has now.
+func (lf *ldapFacade) applyMembers(dn string, ch lmsg.ModifyRequestChange, add, remove func(kind, id string) error) error {
+	values := toStrings(ch.Modification().Vals())
+	var adds, removes []string
+	switch ch.Operation() {
+	case lmsg.ModifyRequestChangeOperationAdd:
+		adds = values
+	case lmsg.ModifyRequestChangeOperationDelete:
+		removes = values
+	case lmsg.ModifyRequestChangeOperationReplace:
+		dir, err := lf.directory()
+		if err != nil {
+			return err
+		}
+		var current []string
+		for _, e := range dir {
+			if normDN(e.dn) == normDN(dn) {
+				current = e.get("member")
+			}
+		}
+		want := map[string]bool{}
+		for _, v := range values {
+			want[normDN(v)] = true
+		}
+		have := map[string]bool{}
+		for _, v := range current {
+			have[normDN(v)] = true
+			if !want[normDN(v)] {
+				removes = append(removes, v)
+			}
+		}
+		for _, v := range values {
+			if !have[normDN(v)] {
+				adds = append(adds, v)
+			}
+		}
+	}
+
+	var err error
+	for _, v := range adds {
+		if kind, id := lf.parseDN(v); id != "" {
+			err = errors.Join(err, add(kind, id))
+		}
+	}
+	for _, v := range removes {
+		if kind, id := lf.parseDN(v); id != "" {
+			err = errors.Join(err, remove(kind, id))
+		}
+	}
+	return err
 }
 
 // Delete: remove user / group / role / org
@@ -891,6 +567,16 @@ func (lf *ldapFacade) onDelete(w ldap.ResponseWriter, m *ldap.Message) {
 	writeDeleteResult(w, err)
 }
 
+// accountsOnly adapts a membership call that takes account ids.
+func accountsOnly(f func(id string) error) func(kind, id string) error {
+	return func(kind, id string) error {
+		if kind != "user" {
+			return fmt.Errorf("only accounts can be members, got %s %q", kind, id)
+		}
+		return f(id)
+	}
+}
+
 // ---- small helpers ----------------------------------------------------------
 
 func (lf *ldapFacade) mustSession(m *ldap.Message) *connState {
@@ -0,0 +1,173 @@
+//go:build !js
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"strconv"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	lmsg "github.com/lor00x/goldap/message"
+	ldap "github.com/vjeantet/ldapserver"
+)
+
+// pagedResultsOID is the Simple Paged Results control (RFC 2696).
+const pagedResultsOID = ldapv3.ControlTypePaging
+
+// searchResult is the outcome of one search request.
+type searchResult struct {
+	entries []lmsg.SearchResultEntry
+	code    int
+	message string
+	paging  *ldapv3.ControlPaging // response control, nil unless paging was requested
+}
+
+// pagedRequest reads the paged results control of a request. The cookie is
+// the offset of the next page in the result set, as decimal text.
+func pagedRequest(controls *lmsg.Controls) (size int, offset int, ok bool, err error) {
+	if controls == nil {
+		return 0, 0, false, nil
+	}
+	for _, c := range *controls {
+		oid := string(c.ControlType())
+		if oid != pagedResultsOID {
+			if c.Criticality() {
+				return 0, 0, false, fmt.Errorf("unsupported critical control %s", oid)
+			}
+			continue
+		}
+		if c.ControlValue() == nil {
+			return 0, 0, false, fmt.Errorf("paged results control without value")
+		}
+		p, err := ber.DecodePacketErr([]byte(*c.ControlValue()))
+		if err != nil || len(p.Children) != 2 {
+			return 0, 0, false, fmt.Errorf("malformed paged results control")
+		}
+		n, _ := p.Children[0].Value.(int64)
+		if cookie := p.Children[1].Data.String(); cookie != "" {
+			if offset, err = strconv.Atoi(cookie); err != nil || offset < 0 {
+				return 0, 0, false, fmt.Errorf("invalid paged results cookie")
+			}
+		}
+		return int(n), offset, true, nil
+	}
+	return 0, 0, false, nil
+}
+
+// search evaluates r against the directory: base and scope, filter, size
+// limit and paging, then attribute selection.
+func (lf *ldapFacade) search(r *lmsg.SearchRequest, controls *lmsg.Controls, dir []*dirEntry) searchResult {
+	pageSize, offset, paged, err := pagedRequest(controls)
+	if err != nil {
+		return searchResult{code: ldap.LDAPResultUnavailableCriticalExtension, message: err.Error()}
+	}
+
+	base := string(r.BaseObject())
+	scope := int(r.Scope())
+	if base == "" {
+		if scope == scopeBaseObject {
+			dir = []*dirEntry{lf.rootDSE()}
+		} else {
+			// An empty base searches the whole naming context.
+			base, scope = lf.baseDN, scopeWholeSubtree
+		}
+	}
+
+	found := false
+	for _, e := range dir {
+		if normDN(e.dn) == normDN(base) {
+			found = true
+			break
+		}
+	}
+	if !found {
+		return searchResult{code: ldap.LDAPResultNoSuchObject, message: "no such object: " + base}
+	}
+
+	var matched []*dirEntry
+	for _, e := range dir {
+		if inScope(e.dn, base, scope) && matchFilter(r.Filter(), e) {
+			matched = append(matched, e)
+		}
+	}
+
+	res := searchResult{code: ldap.LDAPResultSuccess}
+	if paged {
+		res.paging = ldapv3.NewControlPaging(uint32(len(matched)))
+		if pageSize == 0 {
+			// A zero size abandons the paged search (RFC 2696 §3).
+			return res
+		}
+		if offset > len(matched) {
+			offset = len(matched)
+		}
+		end := min(offset+pageSize, len(matched))
+		if end < len(matched) {
+			res.paging.SetCookie([]byte(strconv.Itoa(end)))
+		}
+		matched = matched[offset:end]
+	}
+	if limit := int(r.SizeLimit()); limit > 0 && len(matched) > limit {
+		matched = matched[:limit]
+		res.code = ldap.LDAPResultSizeLimitExceeded
+	}
+
+	for _, e := range matched {
+		res.entries = append(res.entries, e.result(r.Attributes(), bool(r.TypesOnly())))
+	}
+	return res
+}
+
+// writeSearch sends the entries and the SearchResultDone of res. The
+// ResponseWriter of ldapserver cannot attach response controls, so a paged
+// reply is encoded here and written to the connection directly, entries
+// included to keep them ahead of the done message.
+func writeSearch(w ldap.ResponseWriter, m *ldap.Message, res searchResult) {
+	if res.paging == nil || m.Client == nil {
+		for _, e := range res.entries {
+			w.Write(e)
+		}
+		done := lmsg.LDAPResult(ldap.NewSearchResultDoneResponse(res.code))
+		done.SetDiagnosticMessage(res.message)
+		w.Write(lmsg.SearchResultDone(done))
+		return
+	}
+
+	conn := m.Client.GetConn()
+	id := m.MessageID().Int()
+	for _, e := range res.entries {
+		msg := lmsg.NewLDAPMessageWithProtocolOp(e)
+		msg.SetMessageID(id)
+		data, err := msg.Write()
+		if err != nil {
+			log.Printf("LDAP SEARCH encode entry: %v", err)
+			continue
+		}
+		if _, err := conn.Write(data.Bytes()); err != nil {
+			log.Printf("LDAP SEARCH write: %v", err)
+			return
+		}
+	}
+	if _, err := conn.Write(encodeSearchDone(id, res.code, res.message, res.paging)); err != nil {
+		log.Printf("LDAP SEARCH write: %v", err)
+	}
+}
+
+// encodeSearchDone encodes a SearchResultDone message carrying a control.
+func encodeSearchDone(id, code int, message string, control ldapv3.Control) []byte {
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Response")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(id), "Message ID"))
+
+	done := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldapv3.ApplicationSearchResultDone, nil, "Search Result Done")
+	done.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(code), "Result Code"))
+	done.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, "", "Matched DN"))
+	done.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, message, "Diagnostic Message"))
+	packet.AppendChild(done)
+
+	controls := ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+	controls.AppendChild(control.Encode())
+	packet.AppendChild(controls)
+	return packet.Bytes()
+}
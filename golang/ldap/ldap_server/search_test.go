@@ -0,0 +1,315 @@
+//go:build !js
+
+package main
+
+import (
+	"bufio"
+	"net"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	ber "github.com/go-asn1-ber/asn1-ber"
+	ldapv3 "github.com/go-ldap/ldap/v3"
+	lmsg "github.com/lor00x/goldap/message"
+	ldap "github.com/vjeantet/ldapserver"
+
+	"github.com/globulario/services/golang/resource/resourcepb"
+)
+
+const testBase = "dc=example,dc=com"
+
+func testDirectory() []*dirEntry {
+	accounts := []*resourcepb.Account{
+		{Id: "alice", Name: "alice", FirstName: "Alice", LastName: "Liddell", Email: "alice@example.com", Uuid: "u-1", Groups: []string{"devs@example.com"}},
+		{Id: "bob", Name: "bob", Email: "bob@example.com", Roles: []string{"admin"}},
+		{Id: "carol", Name: "carol", Organizations: []string{"acme"}},
+	}
+	groups := []*resourcepb.Group{
+		{Id: "devs", Name: "Developers", Accounts: []string{"bob@example.com", "ghost"}},
+		{Id: "ops", Name: "Operations"},
+	}
+	roles := []*resourcepb.Role{{Id: "admin", Actions: []string{"/resource.ResourceService/DeleteAccount"}}}
+	orgs := []*resourcepb.Organization{{Id: "acme", Groups: []string{"ops"}}}
+	return buildDirectory(testBase, accounts, groups, roles, orgs)
+}
+
+// searchRequest encodes a search the way a client would and decodes it with
+// goldap, so filters and controls go through the real parser.
+func searchRequest(t *testing.T, base string, scope int, filter string, sizeLimit int, attrs []string, controls ...ldapv3.Control) (*lmsg.SearchRequest, *lmsg.Controls) {
+	t.Helper()
+	f, err := ldapv3.CompileFilter(filter)
+	if err != nil {
+		t.Fatalf("compile %s: %v", filter, err)
+	}
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(1), "Message ID"))
+	req := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldapv3.ApplicationSearchRequest, nil, "Search Request")
+	req.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, base, "Base DN"))
+	req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(scope), "Scope"))
+	req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, int64(0), "Deref Aliases"))
+	req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(sizeLimit), "Size Limit"))
+	req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(0), "Time Limit"))
+	req.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, false, "Types Only"))
+	req.AppendChild(f)
+	list := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes")
+	for _, a := range attrs {
+		list.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, a, "Attribute"))
+	}
+	req.AppendChild(list)
+	packet.AppendChild(req)
+	if len(controls) > 0 {
+		cs := ber.Encode(ber.ClassContext, ber.TypeConstructed, 0, nil, "Controls")
+		for _, c := range controls {
+			cs.AppendChild(c.Encode())
+		}
+		packet.AppendChild(cs)
+	}
+
+	// As compatConn does for every connection.
+	if fixMessage(packet) {
+		reencode(packet)
+	}
+	msg, err := lmsg.ReadLDAPMessage(lmsg.NewBytes(0, packet.Bytes()))
+	if err != nil {
+		t.Fatalf("decode %s: %v", filter, err)
+	}
+	r := msg.ProtocolOp().(lmsg.SearchRequest)
+	return &r, msg.Controls()
+}
+
+// decodeEntry encodes e as sent on the wire and returns its DN and the
+// number of values of each attribute.
+func decodeEntry(e lmsg.SearchResultEntry) (string, map[string]int) {
+	data, err := lmsg.NewLDAPMessageWithProtocolOp(e).Write()
+	if err != nil {
+		panic(err)
+	}
+	p := ber.DecodePacket(data.Bytes()).Children[1]
+	attrs := map[string]int{}
+	for _, a := range p.Children[1].Children {
+		attrs[a.Children[0].Data.String()] = len(a.Children[1].Children)
+	}
+	return p.Children[0].Data.String(), attrs
+}
+
+func names(res searchResult) []string {
+	var out []string
+	for _, e := range res.entries {
+		dn, _ := decodeEntry(e)
+		rdn, _, _ := strings.Cut(dn, ",")
+		_, v, _ := strings.Cut(rdn, "=")
+		out = append(out, v)
+	}
+	sort.Strings(out)
+	return out
+}
+
+func TestSearchFilters(t *testing.T) {
+	lf := &ldapFacade{baseDN: testBase}
+	dir := testDirectory()
+	people := "ou=people," + testBase
+
+	tests := []struct {
+		filter string
+		want   []string
+	}{
+		{"(objectClass=*)", []string{"alice", "bob", "carol"}},
+		{"(uid=ALICE)", []string{"alice"}},
+		{"(|(uid=alice)(mail=bob@example.com))", []string{"alice", "bob"}},
+		{"(&(objectClass=inetOrgPerson)(!(uid=bob)))", []string{"alice", "carol"}},
+		{"(mail=*@example.com)", []string{"alice", "bob"}},
+		{"(cn=a*i*e)", []string{"alice"}},
+		{"(givenName=*)", []string{"alice"}},
+		{"(uid>=bob)", []string{"bob", "carol"}},
+		{"(uid<=bob)", []string{"alice", "bob"}},
+		{"(displayName~=alice  liddell)", []string{"alice"}},
+		{"(memberOf=CN=devs, ou=groups," + testBase + ")", []string{"alice", "bob"}},
+		{"(memberOf=cn=admin,ou=roles," + testBase + ")", []string{"bob"}},
+		{"(memberOf=o=acme,ou=orgs," + testBase + ")", []string{"carol"}},
+		{"(memberOf:1.2.840.113556.1.4.1941:=cn=devs,ou=groups," + testBase + ")", []string{"alice", "bob"}},
+		{"(uid:caseExactMatch:=Alice)", nil},
+		{"(entryUUID=u-1)", []string{"alice"}},
+		{"(userPassword=*)", nil},
+	}
+	for _, tt := range tests {
+		r, controls := searchRequest(t, people, scopeSingleLevel, tt.filter, 0, nil)
+		res := lf.search(r, controls, dir)
+		if res.code != ldap.LDAPResultSuccess {
+			t.Fatalf("%s: code %d (%s)", tt.filter, res.code, res.message)
+		}
+		if got := names(res); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: got %v, want %v", tt.filter, got, tt.want)
+		}
+	}
+}
+
+func TestSearchMembers(t *testing.T) {
+	dir := testDirectory()
+	get := func(dn, attr string) []string {
+		for _, e := range dir {
+			if e.dn == dn {
+				return e.get(attr)
+			}
+		}
+		t.Fatalf("no entry %s", dn)
+		return nil
+	}
+
+	// Both sides of the membership are merged; dangling references are dropped.
+	devs := get("cn=devs,ou=groups,"+testBase, "member")
+	want := []string{"uid=alice,ou=people," + testBase, "uid=bob,ou=people," + testBase}
+	if !reflect.DeepEqual(devs, want) {
+		t.Errorf("devs members = %v, want %v", devs, want)
+	}
+	acme := get("o=acme,ou=orgs,"+testBase, "member")
+	want = []string{"cn=ops,ou=groups," + testBase, "uid=carol,ou=people," + testBase}
+	if !reflect.DeepEqual(acme, want) {
+		t.Errorf("acme members = %v, want %v", acme, want)
+	}
+	if got := get("cn=ops,ou=groups,"+testBase, "memberOf"); !reflect.DeepEqual(got, []string{"o=acme,ou=orgs," + testBase}) {
+		t.Errorf("ops memberOf = %v", got)
+	}
+}
+
+func TestSearchScopeAndAttributes(t *testing.T) {
+	lf := &ldapFacade{baseDN: testBase}
+	dir := testDirectory()
+
+	r, c := searchRequest(t, testBase, scopeSingleLevel, "(objectClass=organizationalUnit)", 0, []string{"1.1"})
+	res := lf.search(r, c, dir)
+	if got := names(res); !reflect.DeepEqual(got, []string{"groups", "orgs", "people", "roles"}) {
+		t.Errorf("one level: %v", got)
+	}
+	if _, attrs := decodeEntry(res.entries[0]); len(attrs) != 0 {
+		t.Errorf("1.1 returned attributes: %v", attrs)
+	}
+
+	attrsOf := func(attrs ...string) map[string]int {
+		r, c := searchRequest(t, "uid=alice,ou=people,"+testBase, scopeBaseObject, "(objectClass=*)", 0, attrs)
+		res := lf.search(r, c, dir)
+		if len(res.entries) != 1 {
+			t.Fatalf("%v: %d entries", attrs, len(res.entries))
+		}
+		_, out := decodeEntry(res.entries[0])
+		return out
+	}
+	if got := attrsOf(); got["memberOf"] != 0 || got["uid"] != 1 {
+		t.Errorf("default attributes: %v", got)
+	}
+	if got := attrsOf("uid", "memberOf"); len(got) != 2 || got["memberOf"] != 1 {
+		t.Errorf("named attributes: %v", got)
+	}
+	if got := attrsOf("+"); got["uid"] != 0 || got["entryUUID"] != 1 || got["memberOf"] != 1 {
+		t.Errorf("operational attributes: %v", got)
+	}
+
+	r, c = searchRequest(t, "ou=nobody,"+testBase, scopeWholeSubtree, "(objectClass=*)", 0, nil)
+	if res := lf.search(r, c, dir); res.code != ldap.LDAPResultNoSuchObject {
+		t.Errorf("missing base: code %d", res.code)
+	}
+
+	r, c = searchRequest(t, "", scopeBaseObject, "(objectClass=*)", 0, []string{"supportedControl", "namingContexts"})
+	res = lf.search(r, c, dir)
+	if len(res.entries) != 1 {
+		t.Fatalf("root DSE: %d entries", len(res.entries))
+	}
+	if _, attrs := decodeEntry(res.entries[0]); len(attrs) != 2 {
+		t.Errorf("root DSE attributes: %v", attrs)
+	}
+
+	r, c = searchRequest(t, testBase, scopeWholeSubtree, "(objectClass=*)", 2, nil)
+	if res := lf.search(r, c, dir); res.code != ldap.LDAPResultSizeLimitExceeded || len(res.entries) != 2 {
+		t.Errorf("size limit: code %d, %d entries", res.code, len(res.entries))
+	}
+}
+
+func TestSearchPaging(t *testing.T) {
+	lf := &ldapFacade{baseDN: testBase}
+	dir := testDirectory()
+
+	var got []string
+	paging := ldapv3.NewControlPaging(2)
+	for page := 0; ; page++ {
+		if page > 3 {
+			t.Fatal("paging does not end")
+		}
+		r, c := searchRequest(t, testBase, scopeWholeSubtree, "(|(objectClass=person)(objectClass=groupOfNames))", 0, []string{"cn"}, paging)
+		res := lf.search(r, c, dir)
+		if res.paging == nil || res.code != ldap.LDAPResultSuccess {
+			t.Fatalf("page %d: code %d, paging %v", page, res.code, res.paging)
+		}
+		if len(res.entries) > 2 {
+			t.Fatalf("page %d has %d entries", page, len(res.entries))
+		}
+		got = append(got, names(res)...)
+		if len(res.paging.Cookie) == 0 {
+			break
+		}
+		paging.SetCookie(res.paging.Cookie)
+	}
+	sort.Strings(got)
+	if want := []string{"admin", "alice", "bob", "carol", "devs", "ops"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("paged results = %v, want %v", got, want)
+	}
+
+	// The done message carries the control back to the client.
+	data := encodeSearchDone(7, ldap.LDAPResultSuccess, "", &ldapv3.ControlPaging{PagingSize: 6, Cookie: []byte("2")})
+	p, err := ber.DecodePacketErr(data)
+	if err != nil || len(p.Children) != 3 {
+		t.Fatalf("decode done: %v", err)
+	}
+	ctrl, err := ldapv3.DecodeControl(p.Children[2].Children[0])
+	if err != nil {
+		t.Fatalf("decode control: %v", err)
+	}
+	if pc, ok := ctrl.(*ldapv3.ControlPaging); !ok || string(pc.Cookie) != "2" {
+		t.Errorf("control = %v", ctrl)
+	}
+
+	unknown := &ldapv3.ControlString{ControlType: "1.2.3.4", Criticality: true}
+	r, c := searchRequest(t, testBase, scopeWholeSubtree, "(objectClass=*)", 0, nil, unknown)
+	if res := lf.search(r, c, dir); res.code != ldap.LDAPResultUnavailableCriticalExtension {
+		t.Errorf("critical control: code %d", res.code)
+	}
+}
+
+func TestCompatConnRewritesRequests(t *testing.T) {
+	client, server := net.Pipe()
+	defer client.Close()
+	conn := &compatConn{Conn: server, r: bufio.NewReader(server)}
+
+	f, _ := ldapv3.CompileFilter("(memberOf:1.2.840.113556.1.4.1941:=cn=devs,ou=groups," + testBase + ")")
+	packet := ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "LDAP Request")
+	packet.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, int64(2), "Message ID"))
+	req := ber.Encode(ber.ClassApplication, ber.TypeConstructed, ldapv3.ApplicationSearchRequest, nil, "Search Request")
+	req.AppendChild(ber.NewString(ber.ClassUniversal, ber.TypePrimitive, ber.TagOctetString, testBase, "Base DN"))
+	for _, v := range []int64{2, 0} {
+		req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagEnumerated, v, ""))
+	}
+	for _, v := range []int64{0, 0} {
+		req.AppendChild(ber.NewInteger(ber.ClassUniversal, ber.TypePrimitive, ber.TagInteger, v, ""))
+	}
+	req.AppendChild(ber.NewBoolean(ber.ClassUniversal, ber.TypePrimitive, ber.TagBoolean, true, "Types Only"))
+	req.AppendChild(f)
+	req.AppendChild(ber.Encode(ber.ClassUniversal, ber.TypeConstructed, ber.TagSequence, nil, "Attributes"))
+	packet.AppendChild(req)
+	go func() { _, _ = client.Write(packet.Bytes()) }()
+
+	raw, err := ber.ReadPacket(conn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg, err := lmsg.ReadLDAPMessage(lmsg.NewBytes(0, raw.Bytes()))
+	if err != nil {
+		t.Fatalf("goldap rejects rewritten request: %v", err)
+	}
+	r := msg.ProtocolOp().(lmsg.SearchRequest)
+	if !r.TypesOnly() {
+		t.Error("typesOnly lost")
+	}
+	if _, ok := r.Filter().(lmsg.FilterExtensibleMatch); !ok {
+		t.Errorf("filter = %T", r.Filter())
+	}
+}
@@ -401,6 +401,32 @@ func (client *Log_Client) GetLogCtx(ctx context.Context, query string) ([]*logpb
 	return infos, nil
 }
 
+/**
+ * Query persisted logs with a typed filter, a time range and a full-text
+ * match. Pass the returned NextPageToken back to get the next page.
+ */
+func (client *Log_Client) QueryLogs(rqst *logpb.QueryLogsRqst) (*logpb.QueryLogsRsp, error) {
+	return client.QueryLogsCtx(client.GetCtx(), rqst)
+}
+
+// QueryLogsCtx is like QueryLogs, but uses the provided context.
+func (client *Log_Client) QueryLogsCtx(ctx context.Context, rqst *logpb.QueryLogsRqst) (*logpb.QueryLogsRsp, error) {
+	return client.c.QueryLogs(ctx, rqst)
+}
+
+/**
+ * Return log counts bucketed by time and grouped by level, application,
+ * method, component or node.
+ */
+func (client *Log_Client) LogStats(rqst *logpb.LogStatsRqst) (*logpb.LogStatsRsp, error) {
+	return client.LogStatsCtx(client.GetCtx(), rqst)
+}
+
+// LogStatsCtx is like LogStats, but uses the provided context.
+func (client *Log_Client) LogStatsCtx(ctx context.Context, rqst *logpb.LogStatsRqst) (*logpb.LogStatsRsp, error) {
+	return client.c.LogStats(ctx, rqst)
+}
+
 /**
  * Delete a given log.
  */
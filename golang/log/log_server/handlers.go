@@ -189,6 +189,9 @@ func (srv *server) log(info *logpb.LogInfo) error {
 	}
 
 	info.Id = makeDeterministicID(info, level)
+	if srv.stats != nil {
+		srv.stats.add(info, level)
+	}
 
 	info.Occurences = 1
 	if isPersistent {
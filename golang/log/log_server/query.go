@@ -0,0 +1,419 @@
+package main
+
+import (
+	"context"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/globulario/services/golang/log/logpb"
+	"github.com/globulario/services/golang/storage/storage_store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Typed queries
+//
+// QueryLogs and LogStats take a LogExpr: a boolean tree of field matches over
+// level, application, method, component and node. Before reading entries, the
+// expression is evaluated against the (level, application) pairs of the
+// registry with the other fields unknown, so only the key prefixes that can
+// match are scanned.
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	defaultQueryLimit = 100
+	maxQueryLimit     = 1000
+	maxExprDepth      = 32
+)
+
+var errInvalidPageToken = errors.New("invalid page token")
+
+// tri is the result of an expression over partially known fields.
+type tri int
+
+const (
+	triFalse tri = iota
+	triTrue
+	triUnknown
+)
+
+// fieldName is the name of a field in stats groups.
+func fieldName(f logpb.LogField) string {
+	return strings.ToLower(strings.TrimPrefix(f.String(), "FIELD_"))
+}
+
+// normalizeLevel accepts the usual spellings of a level.
+func normalizeLevel(v string) string {
+	v = strings.ToLower(v)
+	if v == "warn" {
+		return "warning"
+	}
+	return v
+}
+
+// validateExpr rejects expressions the evaluator cannot run and normalizes
+// level values in place.
+func validateExpr(e *logpb.LogExpr, depth int) error {
+	if e == nil {
+		return nil
+	}
+	if depth > maxExprDepth {
+		return fmt.Errorf("filter is nested deeper than %d", maxExprDepth)
+	}
+	switch x := e.Expr.(type) {
+	case *logpb.LogExpr_Match:
+		m := x.Match
+		if m.GetField() == logpb.LogField_FIELD_UNSPECIFIED {
+			return errors.New("filter match without field")
+		}
+		if len(m.GetValues()) == 0 {
+			return fmt.Errorf("filter match on %s without values", fieldName(m.GetField()))
+		}
+		if m.GetField() == logpb.LogField_FIELD_LEVEL {
+			for i, v := range m.Values {
+				m.Values[i] = normalizeLevel(v)
+			}
+		}
+	case *logpb.LogExpr_And:
+		for _, sub := range x.And.GetExprs() {
+			if err := validateExpr(sub, depth+1); err != nil {
+				return err
+			}
+		}
+	case *logpb.LogExpr_Or:
+		for _, sub := range x.Or.GetExprs() {
+			if err := validateExpr(sub, depth+1); err != nil {
+				return err
+			}
+		}
+	case *logpb.LogExpr_Not:
+		return validateExpr(x.Not, depth+1)
+	}
+	return nil
+}
+
+// matchValue tells if v matches a filter value; a trailing "*" matches by
+// prefix.
+func matchValue(pattern, v string) bool {
+	if strings.HasSuffix(pattern, "*") {
+		return strings.HasPrefix(v, strings.TrimSuffix(pattern, "*"))
+	}
+	return pattern == v
+}
+
+// evalExpr evaluates an expression; get returns the value of a field and
+// whether it is known.
+func evalExpr(e *logpb.LogExpr, get func(logpb.LogField) (string, bool)) tri {
+	if e == nil {
+		return triTrue
+	}
+	switch x := e.Expr.(type) {
+	case *logpb.LogExpr_Match:
+		v, known := get(x.Match.GetField())
+		if !known {
+			return triUnknown
+		}
+		for _, p := range x.Match.GetValues() {
+			if matchValue(p, v) {
+				return triTrue
+			}
+		}
+		return triFalse
+	case *logpb.LogExpr_And:
+		res := triTrue
+		for _, sub := range x.And.GetExprs() {
+			switch evalExpr(sub, get) {
+			case triFalse:
+				return triFalse
+			case triUnknown:
+				res = triUnknown
+			}
+		}
+		return res
+	case *logpb.LogExpr_Or:
+		res := triFalse
+		for _, sub := range x.Or.GetExprs() {
+			switch evalExpr(sub, get) {
+			case triTrue:
+				return triTrue
+			case triUnknown:
+				res = triUnknown
+			}
+		}
+		return res
+	case *logpb.LogExpr_Not:
+		switch evalExpr(x.Not, get) {
+		case triTrue:
+			return triFalse
+		case triFalse:
+			return triTrue
+		}
+		return triUnknown
+	}
+	return triTrue
+}
+
+// infoField returns a field of an entry.
+func infoField(info *logpb.LogInfo, level string, f logpb.LogField) string {
+	switch f {
+	case logpb.LogField_FIELD_LEVEL:
+		return level
+	case logpb.LogField_FIELD_APPLICATION:
+		return info.GetApplication()
+	case logpb.LogField_FIELD_METHOD:
+		return info.GetMethod()
+	case logpb.LogField_FIELD_COMPONENT:
+		return info.GetComponent()
+	case logpb.LogField_FIELD_NODE:
+		return info.GetNodeId()
+	}
+	return ""
+}
+
+// matchText tells if every term appears in the message, method or a field
+// value of the entry. terms are lower case.
+func matchText(info *logpb.LogInfo, terms []string) bool {
+	if len(terms) == 0 {
+		return true
+	}
+	var b strings.Builder
+	b.WriteString(info.GetMessage())
+	b.WriteByte('\n')
+	b.WriteString(info.GetMethod())
+	for _, v := range info.GetFields() {
+		b.WriteByte('\n')
+		b.WriteString(v)
+	}
+	haystack := strings.ToLower(b.String())
+	for _, term := range terms {
+		if !strings.Contains(haystack, term) {
+			return false
+		}
+	}
+	return true
+}
+
+// timeRange resolves the since/until of a request; zero values mean the
+// start of retention and now.
+func (srv *server) timeRange(sinceMs, untilMs int64) (int64, int64) {
+	nowMs := time.Now().UnixMilli()
+	if untilMs <= 0 {
+		untilMs = nowMs
+	}
+	if sinceMs <= 0 {
+		sinceMs = nowMs - int64(srv.retentionDays())*86400000
+	}
+	if sinceMs > untilMs {
+		sinceMs, untilMs = untilMs, sinceMs
+	}
+	return sinceMs, untilMs
+}
+
+// forEachItem visits the items of a store with a key prefix and in the
+// range [start, end), using a native scan when the store has one. An empty
+// end has no upper bound. val is nil when keysOnly is set.
+func forEachItem(store storage_store.Store, prefix, start, end string, keysOnly bool, fn func(key string, val []byte) error) error {
+	if scanner, ok := store.(storage_store.Scanner); ok {
+		opts := storage_store.ScanOptions{Prefix: prefix, Start: start, End: end, KeysOnly: keysOnly}
+		for {
+			page, err := scanner.Scan(opts)
+			if err != nil {
+				return err
+			}
+			for _, item := range page.Items {
+				if err := fn(item.Key, item.Value); err != nil {
+					return err
+				}
+			}
+			if page.NextPageToken == "" {
+				return nil
+			}
+			opts.PageToken = page.NextPageToken
+		}
+	}
+
+	keys, err := store.GetAllKeys()
+	if err != nil {
+		return err
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		if !strings.HasPrefix(key, prefix) || key < start || (end != "" && key >= end) {
+			continue
+		}
+		var val []byte
+		if !keysOnly {
+			if val, err = store.GetItem(key); err != nil || len(val) == 0 {
+				continue
+			}
+		}
+		if err := fn(key, val); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// queryCandidates returns the (level, application) pairs of the registry the
+// filter can match.
+func (srv *server) queryCandidates(filter *logpb.LogExpr) ([][2]string, error) {
+	reg, err := srv.getStore("log_registry")
+	if err != nil {
+		return nil, err
+	}
+	keys, err := reg.GetAllKeys()
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(keys)
+	var pairs [][2]string
+	for _, rk := range keys {
+		level, app, ok := parseRegistryKey(rk)
+		if !ok {
+			continue
+		}
+		res := evalExpr(filter, func(f logpb.LogField) (string, bool) {
+			switch f {
+			case logpb.LogField_FIELD_LEVEL:
+				return level, true
+			case logpb.LogField_FIELD_APPLICATION:
+				return app, true
+			}
+			return "", false
+		})
+		if res != triFalse {
+			pairs = append(pairs, [2]string{level, app})
+		}
+	}
+	return pairs, nil
+}
+
+// logPageToken is the position after the last entry of a page.
+func logPageToken(info *logpb.LogInfo) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(strconv.FormatInt(info.TimestampMs, 10) + ":" + info.Id))
+}
+
+func parseLogPageToken(token string) (int64, string, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return 0, "", errInvalidPageToken
+	}
+	ts, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return 0, "", errInvalidPageToken
+	}
+	ms, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return 0, "", errInvalidPageToken
+	}
+	return ms, id, nil
+}
+
+// queryLogs runs a typed query over the persisted entries.
+func (srv *server) queryLogs(rqst *logpb.QueryLogsRqst) (*logpb.QueryLogsRsp, error) {
+	if err := validateExpr(rqst.GetFilter(), 0); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	limit := int(rqst.GetLimit())
+	if limit <= 0 {
+		limit = defaultQueryLimit
+	}
+	if limit > maxQueryLimit {
+		limit = maxQueryLimit
+	}
+	var afterTs int64
+	var afterId string
+	if rqst.GetPageToken() != "" {
+		var err error
+		if afterTs, afterId, err = parseLogPageToken(rqst.GetPageToken()); err != nil {
+			return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+		}
+	}
+	sinceMs, untilMs := srv.timeRange(rqst.GetSinceMs(), rqst.GetUntilMs())
+	startBucket, endBucket := dayBucket(sinceMs), dayBucket(untilMs)
+	terms := strings.Fields(strings.ToLower(rqst.GetText()))
+
+	entries, err := srv.getStore("log_entries")
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "store unavailable: %v", err)
+	}
+	pairs, err := srv.queryCandidates(rqst.GetFilter())
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "registry unavailable: %v", err)
+	}
+
+	// after tells if an entry sorts after the page token.
+	after := func(info *logpb.LogInfo) bool {
+		if rqst.GetPageToken() == "" {
+			return true
+		}
+		if info.TimestampMs != afterTs {
+			return (info.TimestampMs > afterTs) != rqst.GetDescending()
+		}
+		return info.Id != afterId && (info.Id > afterId) != rqst.GetDescending()
+	}
+
+	seen := make(map[string]struct{})
+	var out []*logpb.LogInfo
+	for _, p := range pairs {
+		level := p[0]
+		err := forEachItem(entries, p[0]+":"+p[1]+":", "", "", false, func(key string, raw []byte) error {
+			lvl, app, bucket, _, ok := parseEntryKey(key)
+			if !ok || lvl != p[0] || app != p[1] || bucket < startBucket || bucket > endBucket {
+				return nil
+			}
+			info := new(logpb.LogInfo)
+			if err := protojson.Unmarshal(raw, info); err != nil {
+				return nil
+			}
+			if _, dup := seen[info.Id]; dup {
+				return nil
+			}
+			seen[info.Id] = struct{}{}
+			if info.TimestampMs < sinceMs || info.TimestampMs > untilMs || !after(info) {
+				return nil
+			}
+			match := evalExpr(rqst.GetFilter(), func(f logpb.LogField) (string, bool) {
+				return infoField(info, level, f), true
+			})
+			if match != triTrue || !matchText(info, terms) {
+				return nil
+			}
+			info.Level = stringToLevel(level)
+			out = append(out, info)
+			return nil
+		})
+		if err != nil {
+			return nil, status.Errorf(codes.Internal, "scan %s/%s: %v", p[0], p[1], err)
+		}
+	}
+
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if a.TimestampMs != b.TimestampMs {
+			return (a.TimestampMs < b.TimestampMs) != rqst.GetDescending()
+		}
+		return (a.Id < b.Id) != rqst.GetDescending()
+	})
+	rsp := &logpb.QueryLogsRsp{}
+	if len(out) > limit {
+		out = out[:limit]
+		rsp.NextPageToken = logPageToken(out[limit-1])
+	}
+	rsp.Infos = out
+	return rsp, nil
+}
+
+// QueryLogs queries the persisted (error and fatal) entries with a boolean
+// filter over level, application, method, component and node, a time range
+// and a full-text match.
+func (srv *server) QueryLogs(ctx context.Context, rqst *logpb.QueryLogsRqst) (*logpb.QueryLogsRsp, error) {
+	return srv.queryLogs(rqst)
+}
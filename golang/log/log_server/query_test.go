@@ -0,0 +1,185 @@
+package main
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/log/logpb"
+	"github.com/globulario/services/golang/storage/storage_store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+// newTestServer returns a server on Badger stores in a temporary directory.
+func newTestServer(t *testing.T) *server {
+	t.Helper()
+	srv := &server{CacheType: "BADGER", Root: t.TempDir(), stores: new(sync.Map), stats: newLogStats()}
+	t.Cleanup(func() {
+		srv.stores.Range(func(_, v interface{}) bool {
+			_ = v.(storage_store.Store).Close()
+			return true
+		})
+	})
+	return srv
+}
+
+// putEntry persists an entry the way log() does, without publishing it.
+func putEntry(t *testing.T, srv *server, info *logpb.LogInfo) {
+	t.Helper()
+	level := levelToString(info.Level)
+	info.Id = makeDeterministicID(info, level)
+	entries, err := srv.getStore("log_entries")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, _ := protojson.Marshal(info)
+	if err := entries.SetItem(entryKey(level, info.Application, dayBucket(info.TimestampMs), info.Id), data); err != nil {
+		t.Fatal(err)
+	}
+	reg, _ := srv.getStore("log_registry")
+	if err := reg.SetItem(registryKey(level, info.Application), []byte("1")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func match(f logpb.LogField, values ...string) *logpb.LogExpr {
+	return &logpb.LogExpr{Expr: &logpb.LogExpr_Match{Match: &logpb.LogMatch{Field: f, Values: values}}}
+}
+
+func and(exprs ...*logpb.LogExpr) *logpb.LogExpr {
+	return &logpb.LogExpr{Expr: &logpb.LogExpr_And{And: &logpb.LogExprList{Exprs: exprs}}}
+}
+
+func or(exprs ...*logpb.LogExpr) *logpb.LogExpr {
+	return &logpb.LogExpr{Expr: &logpb.LogExpr_Or{Or: &logpb.LogExprList{Exprs: exprs}}}
+}
+
+func not(e *logpb.LogExpr) *logpb.LogExpr {
+	return &logpb.LogExpr{Expr: &logpb.LogExpr_Not{Not: e}}
+}
+
+// TestEvalExprUnknown verifies that unknown fields keep a candidate unless
+// the known fields already decide the expression.
+func TestEvalExprUnknown(t *testing.T) {
+	known := func(f logpb.LogField) (string, bool) {
+		switch f {
+		case logpb.LogField_FIELD_LEVEL:
+			return "error", true
+		case logpb.LogField_FIELD_APPLICATION:
+			return "file.FileService", true
+		}
+		return "", false
+	}
+	cases := []struct {
+		name string
+		expr *logpb.LogExpr
+		want tri
+	}{
+		{"nil", nil, triTrue},
+		{"level", match(logpb.LogField_FIELD_LEVEL, "error", "fatal"), triTrue},
+		{"app prefix", match(logpb.LogField_FIELD_APPLICATION, "file.*"), triTrue},
+		{"other app", match(logpb.LogField_FIELD_APPLICATION, "rbac.RbacService"), triFalse},
+		{"method unknown", match(logpb.LogField_FIELD_METHOD, "/x"), triUnknown},
+		{"and decided", and(match(logpb.LogField_FIELD_LEVEL, "info"), match(logpb.LogField_FIELD_METHOD, "/x")), triFalse},
+		{"and unknown", and(match(logpb.LogField_FIELD_LEVEL, "error"), match(logpb.LogField_FIELD_METHOD, "/x")), triUnknown},
+		{"or decided", or(match(logpb.LogField_FIELD_LEVEL, "error"), match(logpb.LogField_FIELD_METHOD, "/x")), triTrue},
+		{"not", not(match(logpb.LogField_FIELD_LEVEL, "error")), triFalse},
+		{"not unknown", not(match(logpb.LogField_FIELD_NODE, "n1")), triUnknown},
+	}
+	for _, tc := range cases {
+		if got := evalExpr(tc.expr, known); got != tc.want {
+			t.Errorf("%s: evalExpr = %v, want %v", tc.name, got, tc.want)
+		}
+	}
+}
+
+func TestValidateExpr(t *testing.T) {
+	e := match(logpb.LogField_FIELD_LEVEL, "WARN")
+	if err := validateExpr(e, 0); err != nil {
+		t.Fatal(err)
+	}
+	if v := e.GetMatch().Values[0]; v != "warning" {
+		t.Errorf("level value = %q, want warning", v)
+	}
+	for name, bad := range map[string]*logpb.LogExpr{
+		"no field":  match(logpb.LogField_FIELD_UNSPECIFIED, "x"),
+		"no values": match(logpb.LogField_FIELD_NODE),
+		"nested":    not(and(or(match(logpb.LogField_FIELD_NODE)))),
+	} {
+		if err := validateExpr(bad, 0); err == nil {
+			t.Errorf("%s: validateExpr accepted the expression", name)
+		}
+	}
+	deep := match(logpb.LogField_FIELD_NODE, "n")
+	for i := 0; i <= maxExprDepth; i++ {
+		deep = not(deep)
+	}
+	if err := validateExpr(deep, 0); err == nil {
+		t.Error("validateExpr accepted an expression deeper than the limit")
+	}
+}
+
+// TestQueryLogs verifies filtering, full-text match, ordering and paging.
+func TestQueryLogs(t *testing.T) {
+	srv := newTestServer(t)
+	now := time.Now().UnixMilli()
+	entries := []*logpb.LogInfo{
+		{Level: logpb.LogLevel_ERROR_MESSAGE, Application: "file.FileService", Method: "/file.FileService/ReadDir", Line: "1", Component: "fs", NodeId: "n1", Message: "permission denied on /users", TimestampMs: now - 4000},
+		{Level: logpb.LogLevel_ERROR_MESSAGE, Application: "file.FileService", Method: "/file.FileService/Upload", Line: "2", Component: "fs", NodeId: "n2", Message: "disk full", TimestampMs: now - 3000},
+		{Level: logpb.LogLevel_FATAL_MESSAGE, Application: "rbac.RbacService", Method: "/rbac.RbacService/Validate", Line: "3", Component: "acl", NodeId: "n1", Message: "permission store corrupt", TimestampMs: now - 2000},
+		{Level: logpb.LogLevel_ERROR_MESSAGE, Application: "rbac.RbacService", Method: "/rbac.RbacService/Validate", Line: "4", Component: "acl", NodeId: "n2", Message: "old", TimestampMs: now - 30*86400000},
+	}
+	for _, info := range entries {
+		putEntry(t, srv, info)
+	}
+
+	rsp, err := srv.queryLogs(&logpb.QueryLogsRqst{
+		Filter: or(
+			and(match(logpb.LogField_FIELD_APPLICATION, "file.*"), not(match(logpb.LogField_FIELD_NODE, "n2"))),
+			match(logpb.LogField_FIELD_LEVEL, "fatal"),
+		),
+		Text: "PERMISSION",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Infos) != 2 || rsp.Infos[0].Line != "1" || rsp.Infos[1].Line != "3" {
+		t.Fatalf("infos = %v, want lines 1 and 3", rsp.Infos)
+	}
+	if rsp.Infos[1].Level != logpb.LogLevel_FATAL_MESSAGE {
+		t.Errorf("level = %v, want fatal", rsp.Infos[1].Level)
+	}
+
+	// Entries outside retention are not returned by default; paging walks
+	// the rest newest first.
+	var lines []string
+	rqst := &logpb.QueryLogsRqst{Descending: true, Limit: 2}
+	for {
+		rsp, err := srv.queryLogs(rqst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, info := range rsp.Infos {
+			lines = append(lines, info.Line)
+		}
+		if rsp.NextPageToken == "" {
+			break
+		}
+		rqst.PageToken = rsp.NextPageToken
+	}
+	if len(lines) != 3 || lines[0] != "3" || lines[1] != "2" || lines[2] != "1" {
+		t.Errorf("paged lines = %v, want [3 2 1]", lines)
+	}
+
+	rsp, err = srv.queryLogs(&logpb.QueryLogsRqst{SinceMs: now - 31*86400000, UntilMs: now - 29*86400000})
+	if err != nil || len(rsp.Infos) != 1 || rsp.Infos[0].Line != "4" {
+		t.Errorf("time range query = %v, %v; want line 4", rsp.GetInfos(), err)
+	}
+
+	_, err = srv.queryLogs(&logpb.QueryLogsRqst{PageToken: "!"})
+	if status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad page token: err = %v, want InvalidArgument", err)
+	}
+}
@@ -132,6 +132,9 @@ type server struct {
 	// Retention
 	RetentionHours int // default 7d
 
+	// Per-minute counters for LogStats
+	stats *logStats
+
 	logger *slog.Logger
 }
 
@@ -460,6 +463,7 @@ func (srv *server) pruneExpiredEntries() {
 	if pruned > 0 {
 		logger.Info("retention cleanup", "pruned", pruned)
 	}
+	srv.pruneExpiredStats()
 }
 
 func initializeServerDefaults() *server {
@@ -492,6 +496,7 @@ func initializeServerDefaults() *server {
 		CacheAddress:           cfg.CacheAddress,
 		CacheReplicationFactor: cfg.CacheReplicationFactor,
 		stores:                 new(sync.Map),
+		stats:                  newLogStats(),
 		logger:                 logger,
 	}
 
@@ -544,6 +549,8 @@ func main() {
 		{Method: "/log.LogService/GetLog", Action: "log.read"},
 		{Method: "/log.LogService/DeleteLog", Action: "log.delete"},
 		{Method: "/log.LogService/ClearAllLog", Action: "log.clear"},
+		{Method: "/log.LogService/QueryLogs", Action: "log.read"},
+		{Method: "/log.LogService/LogStats", Action: "log.stats"},
 	})
 
 	if *showDescribe {
@@ -623,6 +630,7 @@ func main() {
 
 	// Start retention cleanup goroutine
 	srv.startRetentionCleanup()
+	srv.startStatsFlush()
 
 	// Prometheus metrics
 	srv.logCount = prometheus.NewCounterVec(
@@ -687,6 +695,8 @@ func printUsage() {
 	fmt.Println("  - Prometheus metrics integration (/metrics endpoint)")
 	fmt.Println("  - Role-based access control (viewer, writer, operator, admin)")
 	fmt.Println("  - Structured logging with level, application, method, and node_id tracking")
+	fmt.Println("  - Typed queries with boolean filters, time range and full-text match")
+	fmt.Println("  - Per-minute stats grouped by level, application, method, component and node")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
 	fmt.Println("  # Start with default configuration")
@@ -0,0 +1,275 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/log/logpb"
+	"github.com/globulario/services/golang/storage/storage_store"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+////////////////////////////////////////////////////////////////////////////////
+// Stats
+//
+// Every accepted entry, at any level, bumps a per-minute counter keyed by its
+// level, application, method, component and node. Counters are accumulated in
+// memory and flushed to the "log_stats" store every statsFlushInterval.
+//
+// Stats key: {minute, 10 digits}|{level}|{application}|{method}|{component}|{node}
+// with each dimension query-escaped, so keys sort by minute and a time range
+// is a single key range.
+////////////////////////////////////////////////////////////////////////////////
+
+const (
+	statsStoreName     = "log_stats"
+	statsFlushInterval = 10 * time.Second
+	maxStatsBuckets    = 10000
+)
+
+// statsDims are the dimensions of a stats key, in key order.
+var statsDims = []logpb.LogField{
+	logpb.LogField_FIELD_LEVEL,
+	logpb.LogField_FIELD_APPLICATION,
+	logpb.LogField_FIELD_METHOD,
+	logpb.LogField_FIELD_COMPONENT,
+	logpb.LogField_FIELD_NODE,
+}
+
+// logStats accumulates counter deltas between flushes.
+type logStats struct {
+	mu      sync.Mutex
+	pending map[string]int64
+}
+
+func newLogStats() *logStats {
+	return &logStats{pending: make(map[string]int64)}
+}
+
+func statsMinuteKey(minute int64) string {
+	return fmt.Sprintf("%010d", minute)
+}
+
+// statsKey returns the counter key of an entry.
+func statsKey(info *logpb.LogInfo, level string) string {
+	parts := make([]string, 0, len(statsDims)+1)
+	parts = append(parts, statsMinuteKey(info.TimestampMs/60000))
+	for _, f := range statsDims {
+		parts = append(parts, url.QueryEscape(infoField(info, level, f)))
+	}
+	return strings.Join(parts, "|")
+}
+
+// parseStatsKey returns the minute and the dimension values of a counter key.
+func parseStatsKey(key string) (int64, map[logpb.LogField]string, bool) {
+	parts := strings.Split(key, "|")
+	if len(parts) != len(statsDims)+1 {
+		return 0, nil, false
+	}
+	minute, err := strconv.ParseInt(parts[0], 10, 64)
+	if err != nil {
+		return 0, nil, false
+	}
+	dims := make(map[logpb.LogField]string, len(statsDims))
+	for i, f := range statsDims {
+		v, err := url.QueryUnescape(parts[i+1])
+		if err != nil {
+			return 0, nil, false
+		}
+		dims[f] = v
+	}
+	return minute, dims, true
+}
+
+// add counts an entry.
+func (s *logStats) add(info *logpb.LogInfo, level string) {
+	key := statsKey(info, level)
+	s.mu.Lock()
+	s.pending[key]++
+	s.mu.Unlock()
+}
+
+// flush writes the pending deltas to the store. Deltas that cannot be
+// written are kept for the next flush.
+func (s *logStats) flush(store storage_store.Store) error {
+	s.mu.Lock()
+	pending := s.pending
+	s.pending = make(map[string]int64)
+	s.mu.Unlock()
+
+	var firstErr error
+	for key, delta := range pending {
+		if err := incrementCounter(store, key, delta); err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			s.mu.Lock()
+			s.pending[key] += delta
+			s.mu.Unlock()
+		}
+	}
+	return firstErr
+}
+
+// incrementCounter adds delta to a counter, atomically when the store
+// supports it. Otherwise a single flusher per store is assumed.
+func incrementCounter(store storage_store.Store, key string, delta int64) error {
+	if atomic, ok := store.(storage_store.AtomicStore); ok {
+		_, err := atomic.Increment(key, delta)
+		return err
+	}
+	var n int64
+	if raw, err := store.GetItem(key); err == nil && len(raw) > 0 {
+		if n, err = strconv.ParseInt(string(raw), 10, 64); err != nil {
+			return storage_store.ErrNotInteger
+		}
+	}
+	return store.SetItem(key, []byte(strconv.FormatInt(n+delta, 10)))
+}
+
+// flushStats flushes the pending counters of the server.
+func (srv *server) flushStats() error {
+	if srv.stats == nil {
+		return nil
+	}
+	store, err := srv.getStore(statsStoreName)
+	if err != nil {
+		return err
+	}
+	return srv.stats.flush(store)
+}
+
+// startStatsFlush runs a background goroutine that flushes the counters.
+func (srv *server) startStatsFlush() {
+	go func() {
+		ticker := time.NewTicker(statsFlushInterval)
+		defer ticker.Stop()
+		for range ticker.C {
+			if err := srv.flushStats(); err != nil {
+				logger.Warn("stats flush failed", "err", err)
+			}
+		}
+	}()
+}
+
+// pruneExpiredStats removes the counters older than the retention period.
+func (srv *server) pruneExpiredStats() {
+	store, err := srv.getStore(statsStoreName)
+	if err != nil {
+		return
+	}
+	cutoff := time.Now().Add(-time.Duration(srv.retentionDays()) * 24 * time.Hour).UnixMilli()
+	var expired []string
+	_ = forEachItem(store, "", "", statsMinuteKey(cutoff/60000), true, func(key string, _ []byte) error {
+		expired = append(expired, key)
+		return nil
+	})
+	for _, k := range expired {
+		_ = store.RemoveItem(k)
+	}
+}
+
+// logStatsSeries accumulates the points of one group.
+type logStatsSeries struct {
+	group  map[string]string
+	points map[int64]int64
+	total  int64
+}
+
+// queryStats answers a LogStats request from the stored counters.
+func (srv *server) queryStats(rqst *logpb.LogStatsRqst) (*logpb.LogStatsRsp, error) {
+	if err := validateExpr(rqst.GetFilter(), 0); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	bucketMs := int64(rqst.GetBucketSeconds()) * 1000
+	if bucketMs < 0 || bucketMs%60000 != 0 {
+		return nil, status.Error(codes.InvalidArgument, "bucket_seconds must be a multiple of 60")
+	}
+	for _, f := range rqst.GetGroupBy() {
+		if f == logpb.LogField_FIELD_UNSPECIFIED {
+			return nil, status.Error(codes.InvalidArgument, "group_by without field")
+		}
+	}
+	sinceMs, untilMs := srv.timeRange(rqst.GetSinceMs(), rqst.GetUntilMs())
+	if bucketMs > 0 && (untilMs-sinceMs)/bucketMs >= maxStatsBuckets {
+		return nil, status.Errorf(codes.InvalidArgument, "more than %d buckets; use a larger bucket_seconds", maxStatsBuckets)
+	}
+
+	if err := srv.flushStats(); err != nil {
+		logger.Warn("stats flush failed", "err", err)
+	}
+	store, err := srv.getStore(statsStoreName)
+	if err != nil {
+		return nil, status.Errorf(codes.Unavailable, "store unavailable: %v", err)
+	}
+
+	series := make(map[string]*logStatsSeries)
+	var order []string
+	start, end := statsMinuteKey(sinceMs/60000), statsMinuteKey(untilMs/60000+1)
+	err = forEachItem(store, "", start, end, false, func(key string, raw []byte) error {
+		minute, dims, ok := parseStatsKey(key)
+		if !ok {
+			return nil
+		}
+		count, err := strconv.ParseInt(string(raw), 10, 64)
+		if err != nil || count == 0 {
+			return nil
+		}
+		match := evalExpr(rqst.GetFilter(), func(f logpb.LogField) (string, bool) {
+			return dims[f], true
+		})
+		if match != triTrue {
+			return nil
+		}
+
+		group := make(map[string]string, len(rqst.GetGroupBy()))
+		id := make([]string, 0, len(rqst.GetGroupBy()))
+		for _, f := range rqst.GetGroupBy() {
+			group[fieldName(f)] = dims[f]
+			id = append(id, url.QueryEscape(dims[f]))
+		}
+		gk := strings.Join(id, "|")
+		s, ok := series[gk]
+		if !ok {
+			s = &logStatsSeries{group: group, points: make(map[int64]int64)}
+			series[gk] = s
+			order = append(order, gk)
+		}
+		bucket := sinceMs
+		if bucketMs > 0 {
+			bucket = minute * 60000 / bucketMs * bucketMs
+		}
+		s.points[bucket] += count
+		s.total += count
+		return nil
+	})
+	if err != nil {
+		return nil, status.Errorf(codes.Internal, "scan stats: %v", err)
+	}
+
+	sort.Strings(order)
+	rsp := &logpb.LogStatsRsp{}
+	for _, gk := range order {
+		s := series[gk]
+		out := &logpb.LogStatsSeries{Group: s.group, Total: s.total}
+		for start, count := range s.points {
+			out.Points = append(out.Points, &logpb.LogStatsPoint{StartMs: start, Count: count})
+		}
+		sort.Slice(out.Points, func(i, j int) bool { return out.Points[i].StartMs < out.Points[j].StartMs })
+		rsp.Series = append(rsp.Series, out)
+	}
+	return rsp, nil
+}
+
+// LogStats returns entry counts bucketed by time and grouped by any of level,
+// application, method, component and node.
+func (srv *server) LogStats(ctx context.Context, rqst *logpb.LogStatsRqst) (*logpb.LogStatsRsp, error) {
+	return srv.queryStats(rqst)
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/log/logpb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestStatsKeyRoundTrip(t *testing.T) {
+	info := &logpb.LogInfo{Application: "a|b", Method: "/x.Y/Z", Component: "c d", NodeId: "n1", TimestampMs: 90061000}
+	minute, dims, ok := parseStatsKey(statsKey(info, "error"))
+	if !ok || minute != 1501 {
+		t.Fatalf("parseStatsKey = %d, %v", minute, ok)
+	}
+	if dims[logpb.LogField_FIELD_APPLICATION] != "a|b" || dims[logpb.LogField_FIELD_COMPONENT] != "c d" || dims[logpb.LogField_FIELD_LEVEL] != "error" {
+		t.Errorf("dims = %v", dims)
+	}
+}
+
+// TestLogStats counts errors per application per 5 minutes.
+func TestLogStats(t *testing.T) {
+	srv := newTestServer(t)
+	base := time.Now().Add(-time.Hour).Truncate(5 * time.Minute).UnixMilli()
+	add := func(level, app string, offset time.Duration) {
+		srv.stats.add(&logpb.LogInfo{Application: app, Method: "/m", NodeId: "n1", TimestampMs: base + offset.Milliseconds()}, level)
+	}
+	add("error", "file", 0)
+	add("error", "file", time.Minute)
+	add("error", "file", 6*time.Minute)
+	add("error", "rbac", 2*time.Minute)
+	add("info", "file", time.Minute)
+	if err := srv.flushStats(); err != nil {
+		t.Fatal(err)
+	}
+	// Counters accumulate across flushes.
+	add("error", "file", time.Minute)
+
+	rsp, err := srv.queryStats(&logpb.LogStatsRqst{
+		Filter:        match(logpb.LogField_FIELD_LEVEL, "error"),
+		SinceMs:       base,
+		UntilMs:       base + 10*time.Minute.Milliseconds(),
+		BucketSeconds: 300,
+		GroupBy:       []logpb.LogField{logpb.LogField_FIELD_APPLICATION},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.Series) != 2 {
+		t.Fatalf("series = %v, want file and rbac", rsp.Series)
+	}
+	file, rbac := rsp.Series[0], rsp.Series[1]
+	if file.Group["application"] != "file" || file.Total != 4 || len(file.Points) != 2 ||
+		file.Points[0].StartMs != base || file.Points[0].Count != 3 || file.Points[1].Count != 1 {
+		t.Errorf("file series = %v", file)
+	}
+	if rbac.Group["application"] != "rbac" || rbac.Total != 1 {
+		t.Errorf("rbac series = %v", rbac)
+	}
+
+	// Without buckets and groups: a single total.
+	rsp, err = srv.queryStats(&logpb.LogStatsRqst{SinceMs: base, UntilMs: base + 10*time.Minute.Milliseconds()})
+	if err != nil || len(rsp.Series) != 1 || rsp.Series[0].Total != 6 || len(rsp.Series[0].Points) != 1 {
+		t.Errorf("total = %v, %v; want 6 in one point", rsp.GetSeries(), err)
+	}
+
+	for name, rqst := range map[string]*logpb.LogStatsRqst{
+		"bucket":   {BucketSeconds: 90},
+		"too many": {SinceMs: 1, UntilMs: base, BucketSeconds: 60},
+		"group by": {GroupBy: []logpb.LogField{logpb.LogField_FIELD_UNSPECIFIED}},
+	} {
+		if _, err := srv.queryStats(rqst); status.Code(err) != codes.InvalidArgument {
+			t.Errorf("%s: err = %v, want InvalidArgument", name, err)
+		}
+	}
+}
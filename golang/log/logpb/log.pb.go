@@ -81,6 +81,65 @@ func (LogLevel) EnumDescriptor() ([]byte, []int) {
 	return file_log_proto_rawDescGZIP(), []int{0}
 }
 
+// LogField names a dimension of a log entry for filters and grouping.
+type LogField int32
+
+const (
+	LogField_FIELD_UNSPECIFIED LogField = 0
+	LogField_FIELD_LEVEL       LogField = 1 // "fatal", "error", "warning", "info", "debug", "trace".
+	LogField_FIELD_APPLICATION LogField = 2
+	LogField_FIELD_METHOD      LogField = 3
+	LogField_FIELD_COMPONENT   LogField = 4
+	LogField_FIELD_NODE        LogField = 5
+)
+
+// Enum value maps for LogField.
+var (
+	LogField_name = map[int32]string{
+		0: "FIELD_UNSPECIFIED",
+		1: "FIELD_LEVEL",
+		2: "FIELD_APPLICATION",
+		3: "FIELD_METHOD",
+		4: "FIELD_COMPONENT",
+		5: "FIELD_NODE",
+	}
+	LogField_value = map[string]int32{
+		"FIELD_UNSPECIFIED": 0,
+		"FIELD_LEVEL":       1,
+		"FIELD_APPLICATION": 2,
+		"FIELD_METHOD":      3,
+		"FIELD_COMPONENT":   4,
+		"FIELD_NODE":        5,
+	}
+)
+
+func (x LogField) Enum() *LogField {
+	p := new(LogField)
+	*p = x
+	return p
+}
+
+func (x LogField) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (LogField) Descriptor() protoreflect.EnumDescriptor {
+	return file_log_proto_enumTypes[1].Descriptor()
+}
+
+func (LogField) Type() protoreflect.EnumType {
+	return &file_log_proto_enumTypes[1]
+}
+
+func (x LogField) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use LogField.Descriptor instead.
+func (LogField) EnumDescriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{1}
+}
+
 // LogInfo represents a single log entry.
 type LogInfo struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -566,6 +625,603 @@ func (x *ClearAllLogRsp) GetResult() bool {
 	return false
 }
 
+// LogMatch is true when the field equals one of the values. A value ending
+// with "*" matches by prefix.
+type LogMatch struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         LogField               `protobuf:"varint,1,opt,name=field,proto3,enum=log.LogField" json:"field,omitempty"`
+	Values        []string               `protobuf:"bytes,2,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogMatch) Reset() {
+	*x = LogMatch{}
+	mi := &file_log_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogMatch) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogMatch) ProtoMessage() {}
+
+func (x *LogMatch) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogMatch.ProtoReflect.Descriptor instead.
+func (*LogMatch) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *LogMatch) GetField() LogField {
+	if x != nil {
+		return x.Field
+	}
+	return LogField_FIELD_UNSPECIFIED
+}
+
+func (x *LogMatch) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
+// LogExprList is the operand list of an and/or expression.
+type LogExprList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Exprs         []*LogExpr             `protobuf:"bytes,1,rep,name=exprs,proto3" json:"exprs,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogExprList) Reset() {
+	*x = LogExprList{}
+	mi := &file_log_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogExprList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogExprList) ProtoMessage() {}
+
+func (x *LogExprList) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogExprList.ProtoReflect.Descriptor instead.
+func (*LogExprList) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *LogExprList) GetExprs() []*LogExpr {
+	if x != nil {
+		return x.Exprs
+	}
+	return nil
+}
+
+// LogExpr is a boolean filter over the dimensions of log entries. An unset
+// expression matches everything.
+type LogExpr struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// Types that are valid to be assigned to Expr:
+	//
+	//	*LogExpr_Match
+	//	*LogExpr_And
+	//	*LogExpr_Or
+	//	*LogExpr_Not
+	Expr          isLogExpr_Expr `protobuf_oneof:"expr"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogExpr) Reset() {
+	*x = LogExpr{}
+	mi := &file_log_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogExpr) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogExpr) ProtoMessage() {}
+
+func (x *LogExpr) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogExpr.ProtoReflect.Descriptor instead.
+func (*LogExpr) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *LogExpr) GetExpr() isLogExpr_Expr {
+	if x != nil {
+		return x.Expr
+	}
+	return nil
+}
+
+func (x *LogExpr) GetMatch() *LogMatch {
+	if x != nil {
+		if x, ok := x.Expr.(*LogExpr_Match); ok {
+			return x.Match
+		}
+	}
+	return nil
+}
+
+func (x *LogExpr) GetAnd() *LogExprList {
+	if x != nil {
+		if x, ok := x.Expr.(*LogExpr_And); ok {
+			return x.And
+		}
+	}
+	return nil
+}
+
+func (x *LogExpr) GetOr() *LogExprList {
+	if x != nil {
+		if x, ok := x.Expr.(*LogExpr_Or); ok {
+			return x.Or
+		}
+	}
+	return nil
+}
+
+func (x *LogExpr) GetNot() *LogExpr {
+	if x != nil {
+		if x, ok := x.Expr.(*LogExpr_Not); ok {
+			return x.Not
+		}
+	}
+	return nil
+}
+
+type isLogExpr_Expr interface {
+	isLogExpr_Expr()
+}
+
+type LogExpr_Match struct {
+	Match *LogMatch `protobuf:"bytes,1,opt,name=match,proto3,oneof"`
+}
+
+type LogExpr_And struct {
+	And *LogExprList `protobuf:"bytes,2,opt,name=and,proto3,oneof"`
+}
+
+type LogExpr_Or struct {
+	Or *LogExprList `protobuf:"bytes,3,opt,name=or,proto3,oneof"`
+}
+
+type LogExpr_Not struct {
+	Not *LogExpr `protobuf:"bytes,4,opt,name=not,proto3,oneof"`
+}
+
+func (*LogExpr_Match) isLogExpr_Expr() {}
+
+func (*LogExpr_And) isLogExpr_Expr() {}
+
+func (*LogExpr_Or) isLogExpr_Expr() {}
+
+func (*LogExpr_Not) isLogExpr_Expr() {}
+
+// QueryLogsRqst is the request format for a typed log query.
+type QueryLogsRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *LogExpr               `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`                        // Boolean filter; unset = all entries.
+	SinceMs       int64                  `protobuf:"varint,2,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"`      // Inclusive lower bound (unix ms); 0 = start of retention.
+	UntilMs       int64                  `protobuf:"varint,3,opt,name=until_ms,json=untilMs,proto3" json:"until_ms,omitempty"`      // Inclusive upper bound (unix ms); 0 = now.
+	Text          string                 `protobuf:"bytes,4,opt,name=text,proto3" json:"text,omitempty"`                            // Full-text match: every term must appear in the message, method or a field value (case-insensitive).
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                         // Page size; 0 = 100.
+	Descending    bool                   `protobuf:"varint,6,opt,name=descending,proto3" json:"descending,omitempty"`               // Newest first.
+	PageToken     string                 `protobuf:"bytes,7,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token of the previous page.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLogsRqst) Reset() {
+	*x = QueryLogsRqst{}
+	mi := &file_log_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLogsRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLogsRqst) ProtoMessage() {}
+
+func (x *QueryLogsRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLogsRqst.ProtoReflect.Descriptor instead.
+func (*QueryLogsRqst) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *QueryLogsRqst) GetFilter() *LogExpr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *QueryLogsRqst) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *QueryLogsRqst) GetUntilMs() int64 {
+	if x != nil {
+		return x.UntilMs
+	}
+	return 0
+}
+
+func (x *QueryLogsRqst) GetText() string {
+	if x != nil {
+		return x.Text
+	}
+	return ""
+}
+
+func (x *QueryLogsRqst) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *QueryLogsRqst) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+func (x *QueryLogsRqst) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+// QueryLogsRsp is one page of a typed log query.
+type QueryLogsRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Infos         []*LogInfo             `protobuf:"bytes,1,rep,name=infos,proto3" json:"infos,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *QueryLogsRsp) Reset() {
+	*x = QueryLogsRsp{}
+	mi := &file_log_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *QueryLogsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*QueryLogsRsp) ProtoMessage() {}
+
+func (x *QueryLogsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use QueryLogsRsp.ProtoReflect.Descriptor instead.
+func (*QueryLogsRsp) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *QueryLogsRsp) GetInfos() []*LogInfo {
+	if x != nil {
+		return x.Infos
+	}
+	return nil
+}
+
+func (x *QueryLogsRsp) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// LogStatsRqst asks for log counts bucketed by time and grouped by fields.
+type LogStatsRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Filter        *LogExpr               `protobuf:"bytes,1,opt,name=filter,proto3" json:"filter,omitempty"`
+	SinceMs       int64                  `protobuf:"varint,2,opt,name=since_ms,json=sinceMs,proto3" json:"since_ms,omitempty"`                          // 0 = start of retention.
+	UntilMs       int64                  `protobuf:"varint,3,opt,name=until_ms,json=untilMs,proto3" json:"until_ms,omitempty"`                          // 0 = now.
+	BucketSeconds int64                  `protobuf:"varint,4,opt,name=bucket_seconds,json=bucketSeconds,proto3" json:"bucket_seconds,omitempty"`        // Bucket width, a multiple of 60; 0 = one bucket for the whole range.
+	GroupBy       []LogField             `protobuf:"varint,5,rep,packed,name=group_by,json=groupBy,proto3,enum=log.LogField" json:"group_by,omitempty"` // Dimensions of the series; empty = a single series.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStatsRqst) Reset() {
+	*x = LogStatsRqst{}
+	mi := &file_log_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStatsRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStatsRqst) ProtoMessage() {}
+
+func (x *LogStatsRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStatsRqst.ProtoReflect.Descriptor instead.
+func (*LogStatsRqst) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *LogStatsRqst) GetFilter() *LogExpr {
+	if x != nil {
+		return x.Filter
+	}
+	return nil
+}
+
+func (x *LogStatsRqst) GetSinceMs() int64 {
+	if x != nil {
+		return x.SinceMs
+	}
+	return 0
+}
+
+func (x *LogStatsRqst) GetUntilMs() int64 {
+	if x != nil {
+		return x.UntilMs
+	}
+	return 0
+}
+
+func (x *LogStatsRqst) GetBucketSeconds() int64 {
+	if x != nil {
+		return x.BucketSeconds
+	}
+	return 0
+}
+
+func (x *LogStatsRqst) GetGroupBy() []LogField {
+	if x != nil {
+		return x.GroupBy
+	}
+	return nil
+}
+
+// LogStatsPoint is the count of one time bucket.
+type LogStatsPoint struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	StartMs       int64                  `protobuf:"varint,1,opt,name=start_ms,json=startMs,proto3" json:"start_ms,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStatsPoint) Reset() {
+	*x = LogStatsPoint{}
+	mi := &file_log_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStatsPoint) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStatsPoint) ProtoMessage() {}
+
+func (x *LogStatsPoint) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStatsPoint.ProtoReflect.Descriptor instead.
+func (*LogStatsPoint) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LogStatsPoint) GetStartMs() int64 {
+	if x != nil {
+		return x.StartMs
+	}
+	return 0
+}
+
+func (x *LogStatsPoint) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// LogStatsSeries is the counts of one group.
+type LogStatsSeries struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Group         map[string]string      `protobuf:"bytes,1,rep,name=group,proto3" json:"group,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"` // Field name ("level", "application", ...) -> value.
+	Points        []*LogStatsPoint       `protobuf:"bytes,2,rep,name=points,proto3" json:"points,omitempty"`                                                                         // Non-empty buckets, oldest first.
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStatsSeries) Reset() {
+	*x = LogStatsSeries{}
+	mi := &file_log_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStatsSeries) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStatsSeries) ProtoMessage() {}
+
+func (x *LogStatsSeries) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStatsSeries.ProtoReflect.Descriptor instead.
+func (*LogStatsSeries) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *LogStatsSeries) GetGroup() map[string]string {
+	if x != nil {
+		return x.Group
+	}
+	return nil
+}
+
+func (x *LogStatsSeries) GetPoints() []*LogStatsPoint {
+	if x != nil {
+		return x.Points
+	}
+	return nil
+}
+
+func (x *LogStatsSeries) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+// LogStatsRsp is the response format of LogStats.
+type LogStatsRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Series        []*LogStatsSeries      `protobuf:"bytes,1,rep,name=series,proto3" json:"series,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LogStatsRsp) Reset() {
+	*x = LogStatsRsp{}
+	mi := &file_log_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LogStatsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LogStatsRsp) ProtoMessage() {}
+
+func (x *LogStatsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_log_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LogStatsRsp.ProtoReflect.Descriptor instead.
+func (*LogStatsRsp) Descriptor() ([]byte, []int) {
+	return file_log_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *LogStatsRsp) GetSeries() []*LogStatsSeries {
+	if x != nil {
+		return x.Series
+	}
+	return nil
+}
+
 var File_log_proto protoreflect.FileDescriptor
 
 const file_log_proto_rawDesc = "" +
@@ -606,14 +1262,66 @@ const file_log_proto_rawDesc = "" +
 	"\x0fClearAllLogRqst\x12\x14\n" +
 	"\x05query\x18\x01 \x01(\tR\x05query\"(\n" +
 	"\x0eClearAllLogRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result*z\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"G\n" +
+	"\bLogMatch\x12#\n" +
+	"\x05field\x18\x01 \x01(\x0e2\r.log.LogFieldR\x05field\x12\x16\n" +
+	"\x06values\x18\x02 \x03(\tR\x06values\"1\n" +
+	"\vLogExprList\x12\"\n" +
+	"\x05exprs\x18\x01 \x03(\v2\f.log.LogExprR\x05exprs\"\xa4\x01\n" +
+	"\aLogExpr\x12%\n" +
+	"\x05match\x18\x01 \x01(\v2\r.log.LogMatchH\x00R\x05match\x12$\n" +
+	"\x03and\x18\x02 \x01(\v2\x10.log.LogExprListH\x00R\x03and\x12\"\n" +
+	"\x02or\x18\x03 \x01(\v2\x10.log.LogExprListH\x00R\x02or\x12 \n" +
+	"\x03not\x18\x04 \x01(\v2\f.log.LogExprH\x00R\x03notB\x06\n" +
+	"\x04expr\"\xd4\x01\n" +
+	"\rQueryLogsRqst\x12$\n" +
+	"\x06filter\x18\x01 \x01(\v2\f.log.LogExprR\x06filter\x12\x19\n" +
+	"\bsince_ms\x18\x02 \x01(\x03R\asinceMs\x12\x19\n" +
+	"\buntil_ms\x18\x03 \x01(\x03R\auntilMs\x12\x12\n" +
+	"\x04text\x18\x04 \x01(\tR\x04text\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x06 \x01(\bR\n" +
+	"descending\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\a \x01(\tR\tpageToken\"Z\n" +
+	"\fQueryLogsRsp\x12\"\n" +
+	"\x05infos\x18\x01 \x03(\v2\f.log.LogInfoR\x05infos\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\xbb\x01\n" +
+	"\fLogStatsRqst\x12$\n" +
+	"\x06filter\x18\x01 \x01(\v2\f.log.LogExprR\x06filter\x12\x19\n" +
+	"\bsince_ms\x18\x02 \x01(\x03R\asinceMs\x12\x19\n" +
+	"\buntil_ms\x18\x03 \x01(\x03R\auntilMs\x12%\n" +
+	"\x0ebucket_seconds\x18\x04 \x01(\x03R\rbucketSeconds\x12(\n" +
+	"\bgroup_by\x18\x05 \x03(\x0e2\r.log.LogFieldR\agroupBy\"@\n" +
+	"\rLogStatsPoint\x12\x19\n" +
+	"\bstart_ms\x18\x01 \x01(\x03R\astartMs\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xc2\x01\n" +
+	"\x0eLogStatsSeries\x124\n" +
+	"\x05group\x18\x01 \x03(\v2\x1e.log.LogStatsSeries.GroupEntryR\x05group\x12*\n" +
+	"\x06points\x18\x02 \x03(\v2\x12.log.LogStatsPointR\x06points\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x1a8\n" +
+	"\n" +
+	"GroupEntry\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\":\n" +
+	"\vLogStatsRsp\x12+\n" +
+	"\x06series\x18\x01 \x03(\v2\x13.log.LogStatsSeriesR\x06series*z\n" +
 	"\bLogLevel\x12\x11\n" +
 	"\rFATAL_MESSAGE\x10\x00\x12\x11\n" +
 	"\rERROR_MESSAGE\x10\x01\x12\x10\n" +
 	"\fWARN_MESSAGE\x10\x02\x12\x10\n" +
 	"\fINFO_MESSAGE\x10\x03\x12\x11\n" +
 	"\rDEBUG_MESSAGE\x10\x04\x12\x11\n" +
-	"\rTRACE_MESSAGE\x10\x052\x80\x03\n" +
+	"\rTRACE_MESSAGE\x10\x05*\x80\x01\n" +
+	"\bLogField\x12\x15\n" +
+	"\x11FIELD_UNSPECIFIED\x10\x00\x12\x0f\n" +
+	"\vFIELD_LEVEL\x10\x01\x12\x15\n" +
+	"\x11FIELD_APPLICATION\x10\x02\x12\x10\n" +
+	"\fFIELD_METHOD\x10\x03\x12\x13\n" +
+	"\x0fFIELD_COMPONENT\x10\x04\x12\x0e\n" +
+	"\n" +
+	"FIELD_NODE\x10\x052\xbe\x04\n" +
 	"\n" +
 	"LogService\x12N\n" +
 	"\x03Log\x12\f.log.LogRqst\x1a\v.log.LogRsp\",\x82\xb5\x18(\n" +
@@ -624,7 +1332,11 @@ const file_log_proto_rawDesc = "" +
 	"\n" +
 	"log.delete\x12\x06delete\"\f/log/entries*\x05admin\x12f\n" +
 	"\vClearAllLog\x12\x14.log.ClearAllLogRqst\x1a\x13.log.ClearAllLogRsp\",\x82\xb5\x18(\n" +
-	"\tlog.clear\x12\x06delete\"\f/log/entries*\x05adminB1Z/github.com/globulario/services/golang/log/logpbb\x06proto3"
+	"\tlog.clear\x12\x06delete\"\f/log/entries*\x05admin\x12^\n" +
+	"\tQueryLogs\x12\x12.log.QueryLogsRqst\x1a\x11.log.QueryLogsRsp\"*\x82\xb5\x18&\n" +
+	"\blog.read\x12\x04read\"\f/log/entries*\x06viewer\x12\\\n" +
+	"\bLogStats\x12\x11.log.LogStatsRqst\x1a\x10.log.LogStatsRsp\"+\x82\xb5\x18'\n" +
+	"\tlog.stats\x12\x04read\"\f/log/entries*\x06viewerB1Z/github.com/globulario/services/golang/log/logpbb\x06proto3"
 
 var (
 	file_log_proto_rawDescOnce sync.Once
@@ -638,40 +1350,68 @@ func file_log_proto_rawDescGZIP() []byte {
 	return file_log_proto_rawDescData
 }
 
-var file_log_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_log_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_log_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_log_proto_msgTypes = make([]protoimpl.MessageInfo, 20)
 var file_log_proto_goTypes = []any{
 	(LogLevel)(0),           // 0: log.LogLevel
-	(*LogInfo)(nil),         // 1: log.LogInfo
-	(*LogRqst)(nil),         // 2: log.LogRqst
-	(*LogRsp)(nil),          // 3: log.LogRsp
-	(*DeleteLogRqst)(nil),   // 4: log.DeleteLogRqst
-	(*DeleteLogRsp)(nil),    // 5: log.DeleteLogRsp
-	(*GetLogRqst)(nil),      // 6: log.GetLogRqst
-	(*GetLogRsp)(nil),       // 7: log.GetLogRsp
-	(*ClearAllLogRqst)(nil), // 8: log.ClearAllLogRqst
-	(*ClearAllLogRsp)(nil),  // 9: log.ClearAllLogRsp
-	nil,                     // 10: log.LogInfo.FieldsEntry
+	(LogField)(0),           // 1: log.LogField
+	(*LogInfo)(nil),         // 2: log.LogInfo
+	(*LogRqst)(nil),         // 3: log.LogRqst
+	(*LogRsp)(nil),          // 4: log.LogRsp
+	(*DeleteLogRqst)(nil),   // 5: log.DeleteLogRqst
+	(*DeleteLogRsp)(nil),    // 6: log.DeleteLogRsp
+	(*GetLogRqst)(nil),      // 7: log.GetLogRqst
+	(*GetLogRsp)(nil),       // 8: log.GetLogRsp
+	(*ClearAllLogRqst)(nil), // 9: log.ClearAllLogRqst
+	(*ClearAllLogRsp)(nil),  // 10: log.ClearAllLogRsp
+	(*LogMatch)(nil),        // 11: log.LogMatch
+	(*LogExprList)(nil),     // 12: log.LogExprList
+	(*LogExpr)(nil),         // 13: log.LogExpr
+	(*QueryLogsRqst)(nil),   // 14: log.QueryLogsRqst
+	(*QueryLogsRsp)(nil),    // 15: log.QueryLogsRsp
+	(*LogStatsRqst)(nil),    // 16: log.LogStatsRqst
+	(*LogStatsPoint)(nil),   // 17: log.LogStatsPoint
+	(*LogStatsSeries)(nil),  // 18: log.LogStatsSeries
+	(*LogStatsRsp)(nil),     // 19: log.LogStatsRsp
+	nil,                     // 20: log.LogInfo.FieldsEntry
+	nil,                     // 21: log.LogStatsSeries.GroupEntry
 }
 var file_log_proto_depIdxs = []int32{
 	0,  // 0: log.LogInfo.level:type_name -> log.LogLevel
-	10, // 1: log.LogInfo.fields:type_name -> log.LogInfo.FieldsEntry
-	1,  // 2: log.LogRqst.info:type_name -> log.LogInfo
-	1,  // 3: log.DeleteLogRqst.log:type_name -> log.LogInfo
-	1,  // 4: log.GetLogRsp.infos:type_name -> log.LogInfo
-	2,  // 5: log.LogService.Log:input_type -> log.LogRqst
-	6,  // 6: log.LogService.GetLog:input_type -> log.GetLogRqst
-	4,  // 7: log.LogService.DeleteLog:input_type -> log.DeleteLogRqst
-	8,  // 8: log.LogService.ClearAllLog:input_type -> log.ClearAllLogRqst
-	3,  // 9: log.LogService.Log:output_type -> log.LogRsp
-	7,  // 10: log.LogService.GetLog:output_type -> log.GetLogRsp
-	5,  // 11: log.LogService.DeleteLog:output_type -> log.DeleteLogRsp
-	9,  // 12: log.LogService.ClearAllLog:output_type -> log.ClearAllLogRsp
-	9,  // [9:13] is the sub-list for method output_type
-	5,  // [5:9] is the sub-list for method input_type
-	5,  // [5:5] is the sub-list for extension type_name
-	5,  // [5:5] is the sub-list for extension extendee
-	0,  // [0:5] is the sub-list for field type_name
+	20, // 1: log.LogInfo.fields:type_name -> log.LogInfo.FieldsEntry
+	2,  // 2: log.LogRqst.info:type_name -> log.LogInfo
+	2,  // 3: log.DeleteLogRqst.log:type_name -> log.LogInfo
+	2,  // 4: log.GetLogRsp.infos:type_name -> log.LogInfo
+	1,  // 5: log.LogMatch.field:type_name -> log.LogField
+	13, // 6: log.LogExprList.exprs:type_name -> log.LogExpr
+	11, // 7: log.LogExpr.match:type_name -> log.LogMatch
+	12, // 8: log.LogExpr.and:type_name -> log.LogExprList
+	12, // 9: log.LogExpr.or:type_name -> log.LogExprList
+	13, // 10: log.LogExpr.not:type_name -> log.LogExpr
+	13, // 11: log.QueryLogsRqst.filter:type_name -> log.LogExpr
+	2,  // 12: log.QueryLogsRsp.infos:type_name -> log.LogInfo
+	13, // 13: log.LogStatsRqst.filter:type_name -> log.LogExpr
+	1,  // 14: log.LogStatsRqst.group_by:type_name -> log.LogField
+	21, // 15: log.LogStatsSeries.group:type_name -> log.LogStatsSeries.GroupEntry
+	17, // 16: log.LogStatsSeries.points:type_name -> log.LogStatsPoint
+	18, // 17: log.LogStatsRsp.series:type_name -> log.LogStatsSeries
+	3,  // 18: log.LogService.Log:input_type -> log.LogRqst
+	7,  // 19: log.LogService.GetLog:input_type -> log.GetLogRqst
+	5,  // 20: log.LogService.DeleteLog:input_type -> log.DeleteLogRqst
+	9,  // 21: log.LogService.ClearAllLog:input_type -> log.ClearAllLogRqst
+	14, // 22: log.LogService.QueryLogs:input_type -> log.QueryLogsRqst
+	16, // 23: log.LogService.LogStats:input_type -> log.LogStatsRqst
+	4,  // 24: log.LogService.Log:output_type -> log.LogRsp
+	8,  // 25: log.LogService.GetLog:output_type -> log.GetLogRsp
+	6,  // 26: log.LogService.DeleteLog:output_type -> log.DeleteLogRsp
+	10, // 27: log.LogService.ClearAllLog:output_type -> log.ClearAllLogRsp
+	15, // 28: log.LogService.QueryLogs:output_type -> log.QueryLogsRsp
+	19, // 29: log.LogService.LogStats:output_type -> log.LogStatsRsp
+	24, // [24:30] is the sub-list for method output_type
+	18, // [18:24] is the sub-list for method input_type
+	18, // [18:18] is the sub-list for extension type_name
+	18, // [18:18] is the sub-list for extension extendee
+	0,  // [0:18] is the sub-list for field type_name
 }
 
 func init() { file_log_proto_init() }
@@ -679,13 +1419,19 @@ func file_log_proto_init() {
 	if File_log_proto != nil {
 		return
 	}
+	file_log_proto_msgTypes[11].OneofWrappers = []any{
+		(*LogExpr_Match)(nil),
+		(*LogExpr_And)(nil),
+		(*LogExpr_Or)(nil),
+		(*LogExpr_Not)(nil),
+	}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_log_proto_rawDesc), len(file_log_proto_rawDesc)),
-			NumEnums:      1,
-			NumMessages:   10,
+			NumEnums:      2,
+			NumMessages:   20,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
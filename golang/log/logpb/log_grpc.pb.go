@@ -23,6 +23,8 @@ const (
 	LogService_GetLog_FullMethodName      = "/log.LogService/GetLog"
 	LogService_DeleteLog_FullMethodName   = "/log.LogService/DeleteLog"
 	LogService_ClearAllLog_FullMethodName = "/log.LogService/ClearAllLog"
+	LogService_QueryLogs_FullMethodName   = "/log.LogService/QueryLogs"
+	LogService_LogStats_FullMethodName    = "/log.LogService/LogStats"
 )
 
 // LogServiceClient is the client API for LogService service.
@@ -40,6 +42,11 @@ type LogServiceClient interface {
 	DeleteLog(ctx context.Context, in *DeleteLogRqst, opts ...grpc.CallOption) (*DeleteLogRsp, error)
 	// Clears all logs or logs matching a specific query pattern.
 	ClearAllLog(ctx context.Context, in *ClearAllLogRqst, opts ...grpc.CallOption) (*ClearAllLogRsp, error)
+	// Queries persisted log entries with a boolean filter, time range and
+	// full-text match, page by page.
+	QueryLogs(ctx context.Context, in *QueryLogsRqst, opts ...grpc.CallOption) (*QueryLogsRsp, error)
+	// Counts log entries of every level, bucketed by time and grouped by fields.
+	LogStats(ctx context.Context, in *LogStatsRqst, opts ...grpc.CallOption) (*LogStatsRsp, error)
 }
 
 type logServiceClient struct {
@@ -99,6 +106,26 @@ func (c *logServiceClient) ClearAllLog(ctx context.Context, in *ClearAllLogRqst,
 	return out, nil
 }
 
+func (c *logServiceClient) QueryLogs(ctx context.Context, in *QueryLogsRqst, opts ...grpc.CallOption) (*QueryLogsRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(QueryLogsRsp)
+	err := c.cc.Invoke(ctx, LogService_QueryLogs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *logServiceClient) LogStats(ctx context.Context, in *LogStatsRqst, opts ...grpc.CallOption) (*LogStatsRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(LogStatsRsp)
+	err := c.cc.Invoke(ctx, LogService_LogStats_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // LogServiceServer is the server API for LogService service.
 // All implementations should embed UnimplementedLogServiceServer
 // for forward compatibility.
@@ -114,6 +141,11 @@ type LogServiceServer interface {
 	DeleteLog(context.Context, *DeleteLogRqst) (*DeleteLogRsp, error)
 	// Clears all logs or logs matching a specific query pattern.
 	ClearAllLog(context.Context, *ClearAllLogRqst) (*ClearAllLogRsp, error)
+	// Queries persisted log entries with a boolean filter, time range and
+	// full-text match, page by page.
+	QueryLogs(context.Context, *QueryLogsRqst) (*QueryLogsRsp, error)
+	// Counts log entries of every level, bucketed by time and grouped by fields.
+	LogStats(context.Context, *LogStatsRqst) (*LogStatsRsp, error)
 }
 
 // UnimplementedLogServiceServer should be embedded to have
@@ -135,6 +167,12 @@ func (UnimplementedLogServiceServer) DeleteLog(context.Context, *DeleteLogRqst)
 func (UnimplementedLogServiceServer) ClearAllLog(context.Context, *ClearAllLogRqst) (*ClearAllLogRsp, error) {
 	return nil, status.Error(codes.Unimplemented, "method ClearAllLog not implemented")
 }
+func (UnimplementedLogServiceServer) QueryLogs(context.Context, *QueryLogsRqst) (*QueryLogsRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method QueryLogs not implemented")
+}
+func (UnimplementedLogServiceServer) LogStats(context.Context, *LogStatsRqst) (*LogStatsRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method LogStats not implemented")
+}
 func (UnimplementedLogServiceServer) testEmbeddedByValue() {}
 
 // UnsafeLogServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -220,6 +258,42 @@ func _LogService_ClearAllLog_Handler(srv interface{}, ctx context.Context, dec f
 	return interceptor(ctx, in, info, handler)
 }
 
+func _LogService_QueryLogs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QueryLogsRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServiceServer).QueryLogs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogService_QueryLogs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServiceServer).QueryLogs(ctx, req.(*QueryLogsRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _LogService_LogStats_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LogStatsRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(LogServiceServer).LogStats(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: LogService_LogStats_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(LogServiceServer).LogStats(ctx, req.(*LogStatsRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // LogService_ServiceDesc is the grpc.ServiceDesc for LogService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -239,6 +313,14 @@ var LogService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ClearAllLog",
 			Handler:    _LogService_ClearAllLog_Handler,
 		},
+		{
+			MethodName: "QueryLogs",
+			Handler:    _LogService_QueryLogs_Handler,
+		},
+		{
+			MethodName: "LogStats",
+			Handler:    _LogService_LogStats_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
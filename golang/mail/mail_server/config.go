@@ -49,6 +49,10 @@ type Config struct {
 	IMAP_ALT_Port      int                   `json:"IMAP_ALT_Port"`
 	Password           string                `json:"Password"`
 	DbIpV4             string                `json:"DbIpV4"`
+	DkimSelector       string                `json:"DkimSelector"`
+	InboundPolicy      string                `json:"InboundPolicy"`
+	OutboundRateLimit  int                   `json:"OutboundRateLimit"`
+	SpoolMaxAgeHours   int                   `json:"SpoolMaxAgeHours"`
 
 	Permissions []any `json:"Permissions"`
 }
@@ -82,6 +86,10 @@ func DefaultConfig() *Config {
 		IMAP_ALT_Port:      993,
 		Password:           "",
 		DbIpV4:             "",
+		DkimSelector:       "globular",
+		InboundPolicy:      "tag",
+		OutboundRateLimit:  60,
+		SpoolMaxAgeHours:   120,
 	}
 	cfg.Domain, cfg.Address = globular.GetDefaultDomainAddress(cfg.Port)
 	return cfg
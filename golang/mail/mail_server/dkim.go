@@ -0,0 +1,159 @@
+package main
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/dns/dns_client"
+	"github.com/globulario/services/golang/globular_client"
+	"github.com/globulario/services/golang/mail/mail_server/smtp"
+	"github.com/globulario/services/golang/pki"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// dkimRecordTTL is the TTL of the published DKIM key record.
+const dkimRecordTTL = 3600
+
+// The DKIM key is shared by every mail node of the cluster: there is one
+// <selector>._domainkey record per domain, so all the nodes must sign with
+// the key it publishes.
+//
+//	/globular/mail/dkim/{domain}/{selector}  RSA private key (PKCS#8 PEM)
+const (
+	etcdDkimPrefix  = "/globular/mail/dkim/"
+	etcdDkimTimeout = 3 * time.Second
+)
+
+// dkimKeyStore holds the cluster's DKIM keys.
+type dkimKeyStore interface {
+	// get returns the key stored under name, nil if there is none.
+	get(name string) ([]byte, error)
+	// create stores key under name unless one already is, and returns the
+	// stored key.
+	create(name string, key []byte) ([]byte, error)
+}
+
+// etcdDkimKeyStore keeps DKIM keys in etcd.
+type etcdDkimKeyStore struct{}
+
+func (etcdDkimKeyStore) get(name string) ([]byte, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdDkimTimeout)
+	defer cancel()
+	res, err := c.Get(ctx, name)
+	if err != nil {
+		return nil, fmt.Errorf("etcd get %s: %w", name, err)
+	}
+	if len(res.Kvs) == 0 {
+		return nil, nil
+	}
+	return res.Kvs[0].Value, nil
+}
+
+func (etcdDkimKeyStore) create(name string, key []byte) ([]byte, error) {
+	c, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, fmt.Errorf("etcd unavailable: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), etcdDkimTimeout)
+	defer cancel()
+	txn, err := c.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(name), "=", 0)).
+		Then(clientv3.OpPut(name, string(key))).
+		Else(clientv3.OpGet(name)).
+		Commit()
+	if err != nil {
+		return nil, fmt.Errorf("etcd txn: %w", err)
+	}
+	if txn.Succeeded {
+		return key, nil
+	}
+	kvs := txn.Responses[0].GetResponseRange().Kvs
+	if len(kvs) == 0 {
+		return nil, fmt.Errorf("dkim key %s vanished", name)
+	}
+	return kvs[0].Value, nil
+}
+
+func (srv *server) dkimKeyStore() dkimKeyStore {
+	if srv.dkimKeys == nil {
+		srv.dkimKeys = etcdDkimKeyStore{}
+	}
+	return srv.dkimKeys
+}
+
+// dkimKey returns the cluster's key for the domain and selector. The first
+// node to need it offers the key of its PKI directory, created if missing,
+// so a key published before keys were shared stays valid; every other node
+// takes the stored one.
+func (srv *server) dkimKey(pkiDir string) (*rsa.PrivateKey, error) {
+	name := etcdDkimPrefix + srv.Domain + "/" + srv.DkimSelector
+	stored, err := srv.dkimKeyStore().get(name)
+	if err != nil {
+		return nil, err
+	}
+	if stored == nil {
+		_, local, err := pki.EnsureDKIMKey(pkiDir, srv.DkimSelector)
+		if err != nil {
+			return nil, err
+		}
+		data, err := pki.EncodeDKIMKey(local)
+		if err != nil {
+			return nil, err
+		}
+		if stored, err = srv.dkimKeyStore().create(name, data); err != nil {
+			return nil, err
+		}
+	}
+	key, err := pki.DecodeDKIMKey(stored)
+	if err != nil {
+		return nil, fmt.Errorf("dkim key %s: %w", name, err)
+	}
+	return key, nil
+}
+
+// setupDkim loads (or creates) the cluster's DKIM key and publishes its
+// public half through the DNS service. It returns nil when signing is
+// disabled or the key is unavailable; mail still flows unsigned.
+func (srv *server) setupDkim() *smtp.DKIMSigner {
+	if srv.DkimSelector == "" || srv.Domain == "" {
+		return nil
+	}
+	key, err := srv.dkimKey(config.GetCanonicalPKIDir())
+	if err != nil {
+		srv.logger.Warn("dkim key unavailable, outgoing mail is not signed", "selector", srv.DkimSelector, "err", err)
+		return nil
+	}
+	if err := srv.publishDkimRecord(&key.PublicKey); err != nil {
+		// Signing still happens; receivers verify once the record is served.
+		srv.logger.Warn("dkim record not published", "name", srv.DkimSelector+"._domainkey."+srv.Domain, "err", err)
+	}
+	srv.logger.Info("dkim signing enabled", "domain", srv.Domain, "selector", srv.DkimSelector)
+	return &smtp.DKIMSigner{Domain: srv.Domain, Selector: srv.DkimSelector, Key: key}
+}
+
+// publishDkimRecord sets the <selector>._domainkey.<domain> TXT record.
+func (srv *server) publishDkimRecord(pub *rsa.PublicKey) error {
+	record, err := pki.DKIMRecord(pub)
+	if err != nil {
+		return err
+	}
+	Utility.RegisterFunction("NewDnsService_Client", dns_client.NewDnsService_Client)
+	client, err := globular_client.GetClient(srv.Address, "dns.DnsService", "NewDnsService_Client")
+	if err != nil {
+		return err
+	}
+	token, err := security.GetLocalToken(srv.Mac)
+	if err != nil {
+		return err
+	}
+	return client.(*dns_client.Dns_Client).SetText(token, srv.DkimSelector+"._domainkey."+srv.Domain, []string{record}, dkimRecordTTL)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/globulario/services/golang/pki"
+)
+
+// memDkimKeyStore is an in-memory dkimKeyStore.
+type memDkimKeyStore map[string][]byte
+
+func (m memDkimKeyStore) get(name string) ([]byte, error) { return m[name], nil }
+
+func (m memDkimKeyStore) create(name string, key []byte) ([]byte, error) {
+	if stored, ok := m[name]; ok {
+		return stored, nil
+	}
+	m[name] = key
+	return key, nil
+}
+
+// Every mail node signs with the key of the one published record.
+func TestDkimKeyShared(t *testing.T) {
+	store := memDkimKeyStore{}
+	first := &server{Domain: "example.com", DkimSelector: "globular", dkimKeys: store}
+	second := &server{Domain: "example.com", DkimSelector: "globular", dkimKeys: store}
+
+	// The first node offers the key it already has.
+	dir := t.TempDir()
+	_, local, err := pki.EnsureDKIMKey(dir, "globular")
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := first.dkimKey(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !a.Equal(local) {
+		t.Error("the first node did not keep its existing key")
+	}
+
+	// Another node, with a key of its own, signs with the stored one.
+	b, err := second.dkimKey(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !b.Equal(a) {
+		t.Error("nodes sign with different keys")
+	}
+
+	// Another selector gets another key.
+	other := &server{Domain: "example.com", DkimSelector: "other", dkimKeys: store}
+	c, err := other.dkimKey(t.TempDir())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.Equal(a) {
+		t.Error("selectors share a key")
+	}
+}
@@ -111,7 +111,14 @@ type server struct {
 	// Persistence DB address (e.g., 0.0.0.0:27017)
 	DbIpV4 string
 
-	logger *slog.Logger
+	// Outbound signing and inbound checks
+	DkimSelector      string // DKIM key selector; the record is <selector>._domainkey.<domain>
+	InboundPolicy     string // "tag" or "reject" for mail failing SPF/DMARC
+	OutboundRateLimit int    // deliveries per recipient domain per minute
+	SpoolMaxAgeHours  int    // hours a message is retried before it bounces
+
+	logger   *slog.Logger
+	dkimKeys dkimKeyStore // etcd unless set by tests
 }
 
 // -----------------------------------------------------------------------------
@@ -622,6 +629,10 @@ func (srv *server) StartService() error {
 
 		imap.Store = store
 		smtp.Store = store
+		smtp.InboundPolicy = srv.InboundPolicy
+		smtp.RateLimit = srv.OutboundRateLimit
+		smtp.MaxQueueAge = time.Duration(srv.SpoolMaxAgeHours) * time.Hour
+		smtp.Dkim = srv.setupDkim()
 
 		nbTry := 10
 		for ; nbTry > 0; nbTry-- {
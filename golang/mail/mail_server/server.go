@@ -65,6 +65,10 @@ func initializeServerDefaults() *server {
 		IMAP_ALT_Port:       cfg.IMAP_ALT_Port,
 		Password:            cfg.Password,
 		DbIpV4:              cfg.DbIpV4,
+		DkimSelector:        cfg.DkimSelector,
+		InboundPolicy:       cfg.InboundPolicy,
+		OutboundRateLimit:   cfg.OutboundRateLimit,
+		SpoolMaxAgeHours:    cfg.SpoolMaxAgeHours,
 		logger:              logger,
 	}
 
@@ -0,0 +1,461 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"net"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Resolver is the subset of *net.Resolver used by the DKIM, SPF and DMARC
+// checks; tests substitute a fake.
+type Resolver interface {
+	LookupTXT(ctx context.Context, name string) ([]string, error)
+	LookupIPAddr(ctx context.Context, host string) ([]net.IPAddr, error)
+	LookupMX(ctx context.Context, name string) ([]*net.MX, error)
+}
+
+// dkimSignedHeaders are the header fields signed when present. From is
+// always signed, as RFC 6376 requires.
+var dkimSignedHeaders = []string{
+	"From", "To", "Cc", "Subject", "Date", "Message-ID", "Reply-To",
+	"In-Reply-To", "References", "MIME-Version", "Content-Type", "Content-Transfer-Encoding",
+}
+
+// DKIMSigner signs outgoing messages with rsa-sha256 and relaxed/relaxed
+// canonicalization (RFC 6376).
+type DKIMSigner struct {
+	Domain   string // d= tag
+	Selector string // s= tag; the key is published at <Selector>._domainkey.<Domain>
+	Key      *rsa.PrivateKey
+}
+
+// Sign returns msg with a DKIM-Signature header prepended. Line endings are
+// normalized to CRLF first, so the signed bytes are the ones sent.
+func (s *DKIMSigner) Sign(msg []byte) ([]byte, error) {
+	if s == nil || s.Key == nil || s.Domain == "" || s.Selector == "" {
+		return nil, errors.New("dkim signer is not configured")
+	}
+	msg = normalizeCRLF(msg)
+	headers, body := splitMessage(msg)
+
+	signed := make([]string, 0, len(dkimSignedHeaders))
+	for _, name := range dkimSignedHeaders {
+		if headerIndex(headers, name) >= 0 || name == "From" {
+			signed = append(signed, strings.ToLower(name))
+		}
+	}
+
+	bh := sha256.Sum256(relaxedBody(body))
+	value := fmt.Sprintf("v=1; a=rsa-sha256; c=relaxed/relaxed; d=%s; s=%s; t=%d; h=%s; bh=%s; b=",
+		s.Domain, s.Selector, time.Now().Unix(), strings.Join(signed, ":"), base64.StdEncoding.EncodeToString(bh[:]))
+
+	h := sha256.New()
+	for _, field := range selectHeaders(headers, signed) {
+		h.Write([]byte(relaxedHeader(field)))
+	}
+	h.Write([]byte(strings.TrimSuffix(relaxedHeader("DKIM-Signature: "+value+"\r\n"), "\r\n")))
+
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.Key, crypto.SHA256, h.Sum(nil))
+	if err != nil {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteString("DKIM-Signature: " + value + foldBase64(base64.StdEncoding.EncodeToString(sig)) + "\r\n")
+	out.Write(msg)
+	return out.Bytes(), nil
+}
+
+// foldBase64 breaks a long base64 value over continuation lines. Folding
+// whitespace inside b= is ignored by verifiers.
+func foldBase64(s string) string {
+	var b strings.Builder
+	for len(s) > 72 {
+		b.WriteString(s[:72])
+		b.WriteString("\r\n\t")
+		s = s[72:]
+	}
+	b.WriteString(s)
+	return b.String()
+}
+
+// DKIM results, as used in Authentication-Results (RFC 8601).
+const (
+	DKIMNone      = "none"
+	DKIMPass      = "pass"
+	DKIMFail      = "fail"
+	DKIMTempError = "temperror"
+	DKIMPermError = "permerror"
+)
+
+// DKIMResult is the outcome of checking one DKIM-Signature header.
+type DKIMResult struct {
+	Domain   string // d= of the signature
+	Selector string
+	Result   string
+	Err      error // why the result is not pass
+}
+
+// VerifyDKIM checks every DKIM-Signature header of msg. A message without
+// signatures yields a single DKIMNone result.
+func VerifyDKIM(ctx context.Context, r Resolver, msg []byte) []DKIMResult {
+	msg = normalizeCRLF(msg)
+	headers, body := splitMessage(msg)
+
+	results := []DKIMResult{}
+	for i, field := range headers {
+		if !strings.EqualFold(headerName(field), "DKIM-Signature") {
+			continue
+		}
+		res := verifySignature(ctx, r, headers, i, body)
+		results = append(results, res)
+	}
+	if len(results) == 0 {
+		results = append(results, DKIMResult{Result: DKIMNone})
+	}
+	return results
+}
+
+func verifySignature(ctx context.Context, r Resolver, headers []string, sigIndex int, body []byte) DKIMResult {
+	field := headers[sigIndex]
+	tags, err := parseTags(headerValue(field))
+	res := DKIMResult{Domain: tags["d"], Selector: tags["s"], Result: DKIMPermError}
+	if err != nil {
+		res.Err = err
+		return res
+	}
+	for _, t := range []string{"v", "a", "b", "bh", "d", "h", "s"} {
+		if tags[t] == "" {
+			res.Err = fmt.Errorf("dkim: missing %s= tag", t)
+			return res
+		}
+	}
+	if tags["v"] != "1" {
+		res.Err = errors.New("dkim: unsupported version")
+		return res
+	}
+	signed := strings.Split(tags["h"], ":")
+	hasFrom := false
+	for i := range signed {
+		signed[i] = strings.ToLower(strings.TrimSpace(signed[i]))
+		hasFrom = hasFrom || signed[i] == "from"
+	}
+	if !hasFrom {
+		res.Err = errors.New("dkim: From is not signed")
+		return res
+	}
+	if x := tags["x"]; x != "" {
+		if exp, err := strconv.ParseInt(x, 10, 64); err == nil && time.Now().Unix() > exp {
+			res.Result, res.Err = DKIMFail, errors.New("dkim: signature expired")
+			return res
+		}
+	}
+
+	headerCanon, bodyCanon := "simple", "simple"
+	if c := tags["c"]; c != "" {
+		hc, bc, found := strings.Cut(c, "/")
+		headerCanon = hc
+		if found {
+			bodyCanon = bc
+		}
+	}
+	canonHeader := map[string]func(string) string{"simple": func(s string) string { return s }, "relaxed": relaxedHeader}[headerCanon]
+	canonBody := map[string]func([]byte) []byte{"simple": simpleBody, "relaxed": relaxedBody}[bodyCanon]
+	if canonHeader == nil || canonBody == nil {
+		res.Err = fmt.Errorf("dkim: unsupported canonicalization %q", tags["c"])
+		return res
+	}
+
+	cb := canonBody(body)
+	if l := tags["l"]; l != "" {
+		n, err := strconv.Atoi(l)
+		if err != nil || n < 0 || n > len(cb) {
+			res.Err = errors.New("dkim: invalid l= tag")
+			return res
+		}
+		cb = cb[:n]
+	}
+	bh, err := base64.StdEncoding.DecodeString(stripWSP(tags["bh"]))
+	if err != nil {
+		res.Err = errors.New("dkim: invalid bh= tag")
+		return res
+	}
+	if sum := sha256.Sum256(cb); !bytes.Equal(sum[:], bh) {
+		res.Result, res.Err = DKIMFail, errors.New("dkim: body hash mismatch")
+		return res
+	}
+
+	sig, err := base64.StdEncoding.DecodeString(stripWSP(tags["b"]))
+	if err != nil {
+		res.Err = errors.New("dkim: invalid b= tag")
+		return res
+	}
+	h := sha256.New()
+	for _, f := range selectHeaders(headers, signed) {
+		h.Write([]byte(canonHeader(f)))
+	}
+	h.Write([]byte(strings.TrimSuffix(canonHeader(emptySignature(field)), "\r\n")))
+	digest := h.Sum(nil)
+
+	key, err := lookupDKIMKey(ctx, r, tags["s"], tags["d"])
+	if err != nil {
+		var tmp interface{ Temporary() bool }
+		if errors.As(err, &tmp) && tmp.Temporary() {
+			res.Result = DKIMTempError
+		}
+		res.Err = err
+		return res
+	}
+
+	switch tags["a"] {
+	case "rsa-sha256":
+		pub, ok := key.(*rsa.PublicKey)
+		if !ok {
+			res.Err = errors.New("dkim: key type does not match a= tag")
+			return res
+		}
+		if err := rsa.VerifyPKCS1v15(pub, crypto.SHA256, digest, sig); err != nil {
+			res.Result, res.Err = DKIMFail, errors.New("dkim: bad signature")
+			return res
+		}
+	case "ed25519-sha256":
+		pub, ok := key.(ed25519.PublicKey)
+		if !ok {
+			res.Err = errors.New("dkim: key type does not match a= tag")
+			return res
+		}
+		if !ed25519.Verify(pub, digest, sig) {
+			res.Result, res.Err = DKIMFail, errors.New("dkim: bad signature")
+			return res
+		}
+	default:
+		res.Err = fmt.Errorf("dkim: unsupported algorithm %q", tags["a"])
+		return res
+	}
+	res.Result = DKIMPass
+	return res
+}
+
+// lookupDKIMKey fetches and parses the key record <selector>._domainkey.<domain>.
+func lookupDKIMKey(ctx context.Context, r Resolver, selector, domain string) (crypto.PublicKey, error) {
+	txts, err := r.LookupTXT(ctx, selector+"._domainkey."+domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		tags, err := parseTags(txt)
+		if err != nil || (tags["v"] != "" && tags["v"] != "DKIM1") {
+			continue
+		}
+		p := stripWSP(tags["p"])
+		if p == "" {
+			return nil, errors.New("dkim: key revoked")
+		}
+		der, err := base64.StdEncoding.DecodeString(p)
+		if err != nil {
+			return nil, errors.New("dkim: invalid key record")
+		}
+		switch tags["k"] {
+		case "", "rsa":
+			if pub, err := x509.ParsePKIXPublicKey(der); err == nil {
+				return pub, nil
+			}
+			if pub, err := x509.ParsePKCS1PublicKey(der); err == nil {
+				return pub, nil
+			}
+			return nil, errors.New("dkim: invalid rsa key")
+		case "ed25519":
+			if len(der) != ed25519.PublicKeySize {
+				return nil, errors.New("dkim: invalid ed25519 key")
+			}
+			return ed25519.PublicKey(der), nil
+		default:
+			return nil, fmt.Errorf("dkim: unsupported key type %q", tags["k"])
+		}
+	}
+	return nil, errors.New("dkim: no key record for " + selector + "._domainkey." + domain)
+}
+
+// sigValueRe matches the b= tag of a DKIM-Signature, not bh=.
+var sigValueRe = regexp.MustCompile(`(^|;)([ \t\r\n]*b[ \t\r\n]*=)[^;]*`)
+
+// emptySignature returns the DKIM-Signature field with its b= value removed,
+// which is what the signature covers.
+func emptySignature(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.TrimSuffix(value, "\r\n")
+	return name + ":" + sigValueRe.ReplaceAllString(value, "$1$2") + "\r\n"
+}
+
+// parseTags parses a tag=value list (RFC 6376 §3.2).
+func parseTags(s string) (map[string]string, error) {
+	tags := map[string]string{}
+	for _, part := range strings.Split(s, ";") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		k, v, ok := strings.Cut(part, "=")
+		if !ok {
+			return tags, fmt.Errorf("dkim: malformed tag %q", part)
+		}
+		k = strings.TrimSpace(k)
+		if _, dup := tags[k]; dup {
+			return tags, fmt.Errorf("dkim: duplicate tag %q", k)
+		}
+		tags[k] = strings.TrimSpace(v)
+	}
+	return tags, nil
+}
+
+// -----------------------------------------------------------------------------
+// Message parsing and canonicalization
+// -----------------------------------------------------------------------------
+
+// normalizeCRLF turns bare LF line endings into CRLF.
+func normalizeCRLF(msg []byte) []byte {
+	if bytes.Count(msg, []byte("\n")) == bytes.Count(msg, []byte("\r\n")) {
+		return msg
+	}
+	msg = bytes.ReplaceAll(msg, []byte("\r\n"), []byte("\n"))
+	return bytes.ReplaceAll(msg, []byte("\n"), []byte("\r\n"))
+}
+
+// splitMessage returns the header fields of a CRLF message, each with its
+// continuation lines and final CRLF, and the body after the blank line.
+func splitMessage(msg []byte) ([]string, []byte) {
+	var headers []string
+	for len(msg) > 0 {
+		if bytes.HasPrefix(msg, []byte("\r\n")) {
+			return headers, msg[2:]
+		}
+		line := string(msg) + "\r\n"
+		if end := bytes.Index(msg, []byte("\r\n")); end >= 0 {
+			line = string(msg[:end+2])
+		}
+		msg = msg[min(len(line), len(msg)):]
+		if (line[0] == ' ' || line[0] == '\t') && len(headers) > 0 {
+			headers[len(headers)-1] += line
+		} else {
+			headers = append(headers, line)
+		}
+	}
+	return headers, nil
+}
+
+func headerName(field string) string {
+	name, _, _ := strings.Cut(field, ":")
+	return strings.TrimSpace(name)
+}
+
+func headerValue(field string) string {
+	_, value, _ := strings.Cut(field, ":")
+	return value
+}
+
+// headerIndex returns the index of the last field called name, or -1.
+func headerIndex(headers []string, name string) int {
+	for i := len(headers) - 1; i >= 0; i-- {
+		if strings.EqualFold(headerName(headers[i]), name) {
+			return i
+		}
+	}
+	return -1
+}
+
+// selectHeaders picks the fields listed in h=, bottom-up for repeated names
+// as RFC 6376 §5.4.2 requires. Names with no field left contribute nothing.
+func selectHeaders(headers []string, names []string) []string {
+	used := make([]bool, len(headers))
+	out := make([]string, 0, len(names))
+	for _, name := range names {
+		for i := len(headers) - 1; i >= 0; i-- {
+			if !used[i] && strings.EqualFold(headerName(headers[i]), name) {
+				used[i] = true
+				out = append(out, headers[i])
+				break
+			}
+		}
+	}
+	return out
+}
+
+// relaxedHeader applies the relaxed header canonicalization.
+func relaxedHeader(field string) string {
+	name, value, _ := strings.Cut(field, ":")
+	value = strings.NewReplacer("\r\n", "").Replace(value)
+	return strings.ToLower(strings.TrimSpace(name)) + ":" + strings.TrimSpace(compressWSP(value)) + "\r\n"
+}
+
+// relaxedBody applies the relaxed body canonicalization.
+func relaxedBody(body []byte) []byte {
+	lines := strings.Split(string(body), "\r\n")
+	for i, l := range lines {
+		lines[i] = strings.TrimRight(compressWSP(l), " ")
+	}
+	return joinBody(lines)
+}
+
+// simpleBody applies the simple body canonicalization.
+func simpleBody(body []byte) []byte {
+	out := joinBody(strings.Split(string(body), "\r\n"))
+	if len(out) == 0 {
+		return []byte("\r\n")
+	}
+	return out
+}
+
+// joinBody drops trailing empty lines and terminates each line with CRLF.
+func joinBody(lines []string) []byte {
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	var b bytes.Buffer
+	for _, l := range lines {
+		b.WriteString(l)
+		b.WriteString("\r\n")
+	}
+	return b.Bytes()
+}
+
+func compressWSP(s string) string {
+	var b strings.Builder
+	space := false
+	for _, c := range s {
+		if c == ' ' || c == '\t' {
+			space = true
+			continue
+		}
+		if space {
+			b.WriteByte(' ')
+			space = false
+		}
+		b.WriteRune(c)
+	}
+	if space {
+		b.WriteByte(' ')
+	}
+	return b.String()
+}
+
+func stripWSP(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r == ' ' || r == '\t' || r == '\r' || r == '\n' {
+			return -1
+		}
+		return r
+	}, s)
+}
@@ -0,0 +1,124 @@
+package smtp
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"net"
+	"strings"
+	"testing"
+)
+
+// fakeResolver answers from maps; missing names are NXDOMAIN.
+type fakeResolver struct {
+	txt map[string][]string
+	ip  map[string][]string
+	mx  map[string][]string
+}
+
+func notFound(name string) error {
+	return &net.DNSError{Err: "no such host", Name: name, IsNotFound: true}
+}
+
+func (f *fakeResolver) LookupTXT(_ context.Context, name string) ([]string, error) {
+	if v, ok := f.txt[name]; ok {
+		return v, nil
+	}
+	return nil, notFound(name)
+}
+
+func (f *fakeResolver) LookupIPAddr(_ context.Context, host string) ([]net.IPAddr, error) {
+	v, ok := f.ip[host]
+	if !ok {
+		return nil, notFound(host)
+	}
+	addrs := make([]net.IPAddr, 0, len(v))
+	for _, ip := range v {
+		addrs = append(addrs, net.IPAddr{IP: net.ParseIP(ip)})
+	}
+	return addrs, nil
+}
+
+func (f *fakeResolver) LookupMX(_ context.Context, name string) ([]*net.MX, error) {
+	v, ok := f.mx[name]
+	if !ok {
+		return nil, notFound(name)
+	}
+	mxs := make([]*net.MX, 0, len(v))
+	for _, h := range v {
+		mxs = append(mxs, &net.MX{Host: h + "."})
+	}
+	return mxs, nil
+}
+
+// The canonicalization example of RFC 6376 §3.4.5.
+func TestDKIMCanonicalization(t *testing.T) {
+	msg := []byte("A: X\r\nB : Y\t\r\n\tZ  \r\n\r\n C \r\nD \t E\r\n\r\n\r\n")
+	headers, body := splitMessage(msg)
+
+	relaxed := ""
+	for _, h := range headers {
+		relaxed += relaxedHeader(h)
+	}
+	if relaxed != "a:X\r\nb:Y Z\r\n" {
+		t.Errorf("relaxed headers = %q", relaxed)
+	}
+	if got := string(relaxedBody(body)); got != " C\r\nD E\r\n" {
+		t.Errorf("relaxed body = %q", got)
+	}
+	if got := strings.Join(headers, ""); got != "A: X\r\nB : Y\t\r\n\tZ  \r\n" {
+		t.Errorf("simple headers = %q", got)
+	}
+	if got := string(simpleBody(body)); got != " C \r\nD \t E\r\n" {
+		t.Errorf("simple body = %q", got)
+	}
+	if got := string(simpleBody(nil)); got != "\r\n" {
+		t.Errorf("simple empty body = %q", got)
+	}
+}
+
+func TestDKIMSignAndVerify(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	der, _ := x509.MarshalPKIXPublicKey(&key.PublicKey)
+	r := &fakeResolver{txt: map[string][]string{
+		"mail._domainkey.example.com": {"v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der)},
+	}}
+	signer := &DKIMSigner{Domain: "example.com", Selector: "mail", Key: key}
+
+	// Bare LF line endings and trailing whitespace are what clients send.
+	msg := []byte("From: Alice <alice@example.com>\nTo: bob@example.org\nSubject:  Hello   there\n\nHi Bob,  \n\n\n")
+	signed, err := signer.Sign(msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res := VerifyDKIM(context.Background(), r, signed); len(res) != 1 || res[0].Result != DKIMPass || res[0].Domain != "example.com" {
+		t.Fatalf("verify = %+v", res)
+	}
+
+	// Relays may refold headers and add a Received line on top.
+	relayed := strings.Replace(string(signed), "Subject:  Hello   there", "Subject: Hello\r\n there", 1)
+	relayed = "Received: from relay\r\n" + relayed
+	if res := VerifyDKIM(context.Background(), r, []byte(relayed)); res[0].Result != DKIMPass {
+		t.Errorf("verify after relay = %+v", res)
+	}
+
+	tampered := strings.Replace(string(signed), "Hi Bob", "Hi Eve", 1)
+	if res := VerifyDKIM(context.Background(), r, []byte(tampered)); res[0].Result != DKIMFail {
+		t.Errorf("tampered body = %+v", res)
+	}
+	forged := strings.Replace(string(signed), "alice@example.com", "mallory@example.com", 1)
+	if res := VerifyDKIM(context.Background(), r, []byte(forged)); res[0].Result != DKIMFail {
+		t.Errorf("tampered From = %+v", res)
+	}
+	if res := VerifyDKIM(context.Background(), &fakeResolver{}, signed); res[0].Result != DKIMPermError {
+		t.Errorf("missing key = %+v", res)
+	}
+	if res := VerifyDKIM(context.Background(), r, msg); res[0].Result != DKIMNone {
+		t.Errorf("unsigned = %+v", res)
+	}
+}
@@ -0,0 +1,216 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"net"
+	"net/mail"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/publicsuffix"
+)
+
+// DMARC results and dispositions (RFC 7489).
+const (
+	DMARCNone      = "none"
+	DMARCPass      = "pass"
+	DMARCFail      = "fail"
+	DMARCTempError = "temperror"
+	DMARCPermError = "permerror"
+
+	DispositionNone       = "none"
+	DispositionQuarantine = "quarantine"
+	DispositionReject     = "reject"
+)
+
+// DMARCResult is the outcome of a DMARC evaluation.
+type DMARCResult struct {
+	Domain      string // RFC5322.From domain
+	Result      string
+	Policy      string // published p= (or sp=) policy, empty without a record
+	Disposition string // what the receiver should do, after pct= sampling
+}
+
+// dmarcRecord is a parsed _dmarc TXT record.
+type dmarcRecord struct {
+	p, sp     string
+	adkim     string // "r" or "s"
+	aspf      string
+	pct       int
+	subdomain bool // found at the organizational domain, not the From domain
+}
+
+// CheckDMARC evaluates the DMARC policy of fromDomain given the SPF result
+// for the envelope domain and the DKIM results of the message.
+func CheckDMARC(ctx context.Context, r Resolver, fromDomain string, spf SPFResult, spfDomain string, dkim []DKIMResult) DMARCResult {
+	fromDomain = strings.ToLower(strings.TrimSuffix(fromDomain, "."))
+	res := DMARCResult{Domain: fromDomain, Result: DMARCNone, Disposition: DispositionNone}
+
+	rec, err := lookupDMARC(ctx, r, fromDomain)
+	if err != nil {
+		var dnsErr *net.DNSError
+		switch {
+		case errors.Is(err, errNoDMARC):
+		case errors.As(err, &dnsErr):
+			res.Result = DMARCTempError
+		default:
+			res.Result = DMARCPermError
+		}
+		return res
+	}
+
+	res.Policy = rec.p
+	if rec.subdomain && rec.sp != "" {
+		res.Policy = rec.sp
+	}
+
+	aligned := spf == SPFPass && domainsAlign(spfDomain, fromDomain, rec.aspf)
+	for _, d := range dkim {
+		if d.Result == DKIMPass && domainsAlign(d.Domain, fromDomain, rec.adkim) {
+			aligned = true
+		}
+	}
+	if aligned {
+		res.Result = DMARCPass
+		return res
+	}
+
+	res.Result = DMARCFail
+	res.Disposition = res.Policy
+	if rec.pct < 100 && rand.IntN(100) >= rec.pct {
+		// Messages outside the sample get the next weaker treatment (§6.6.4).
+		switch res.Disposition {
+		case DispositionReject:
+			res.Disposition = DispositionQuarantine
+		case DispositionQuarantine:
+			res.Disposition = DispositionNone
+		}
+	}
+	return res
+}
+
+var errNoDMARC = errors.New("dmarc: no policy record")
+
+// lookupDMARC fetches the policy of domain, falling back to its
+// organizational domain.
+func lookupDMARC(ctx context.Context, r Resolver, domain string) (*dmarcRecord, error) {
+	rec, err := fetchDMARC(ctx, r, domain)
+	if err == nil || (err != errNoDMARC && !isNotFound(err)) {
+		return rec, err
+	}
+	org := organizationalDomain(domain)
+	if org == domain {
+		return nil, errNoDMARC
+	}
+	rec, err = fetchDMARC(ctx, r, org)
+	if err != nil {
+		if isNotFound(err) {
+			return nil, errNoDMARC
+		}
+		return nil, err
+	}
+	rec.subdomain = true
+	return rec, nil
+}
+
+func fetchDMARC(ctx context.Context, r Resolver, domain string) (*dmarcRecord, error) {
+	txts, err := r.LookupTXT(ctx, "_dmarc."+domain)
+	if err != nil {
+		return nil, err
+	}
+	for _, txt := range txts {
+		tags, err := parseTags(txt)
+		if err != nil || tags["v"] != "DMARC1" {
+			continue
+		}
+		rec := &dmarcRecord{p: strings.ToLower(tags["p"]), sp: strings.ToLower(tags["sp"]), adkim: "r", aspf: "r", pct: 100}
+		switch rec.p {
+		case DispositionNone, DispositionQuarantine, DispositionReject:
+		default:
+			return nil, fmt.Errorf("dmarc: invalid p= tag %q", tags["p"])
+		}
+		if tags["adkim"] == "s" {
+			rec.adkim = "s"
+		}
+		if tags["aspf"] == "s" {
+			rec.aspf = "s"
+		}
+		if n, err := strconv.Atoi(tags["pct"]); err == nil && n >= 0 && n <= 100 {
+			rec.pct = n
+		}
+		return rec, nil
+	}
+	return nil, errNoDMARC
+}
+
+// domainsAlign applies relaxed ("r") or strict ("s") identifier alignment.
+func domainsAlign(a, b, mode string) bool {
+	a = strings.ToLower(strings.TrimSuffix(a, "."))
+	b = strings.ToLower(strings.TrimSuffix(b, "."))
+	if a == "" || b == "" {
+		return false
+	}
+	if mode == "s" {
+		return a == b
+	}
+	return organizationalDomain(a) == organizationalDomain(b)
+}
+
+// organizationalDomain returns the registrable domain of domain (RFC 7489
+// section 3.2): its public suffix plus one label, so that names under
+// suffixes such as co.uk do not align with each other. A domain that is
+// itself a public suffix is its own organizational domain.
+func organizationalDomain(domain string) string {
+	org, err := publicsuffix.EffectiveTLDPlusOne(domain)
+	if err != nil {
+		return domain
+	}
+	return org
+}
+
+// fromDomain returns the domain of the RFC5322.From header of msg.
+func fromDomain(msg []byte) (string, error) {
+	headers, _ := splitMessage(normalizeCRLF(msg))
+	i := headerIndex(headers, "From")
+	if i < 0 {
+		return "", errors.New("message has no From header")
+	}
+	addrs, err := mail.ParseAddressList(strings.TrimSpace(headerValue(headers[i])))
+	if err != nil || len(addrs) == 0 {
+		return "", errors.New("invalid From header")
+	}
+	_, domain, err := splitAddress(addrs[0].Address)
+	return strings.ToLower(domain), err
+}
+
+// authenticationResults formats the Authentication-Results header (RFC 8601)
+// recorded on inbound mail.
+func authenticationResults(host string, spf SPFResult, mailFrom string, dkim []DKIMResult, dmarc DMARCResult) string {
+	var b strings.Builder
+	b.WriteString("Authentication-Results: " + host)
+	b.WriteString(";\r\n\tspf=" + string(spf))
+	if mailFrom != "" {
+		b.WriteString(" smtp.mailfrom=" + mailFrom)
+	}
+	for _, d := range dkim {
+		b.WriteString(";\r\n\tdkim=" + d.Result)
+		if d.Domain != "" {
+			b.WriteString(" header.d=" + d.Domain)
+		}
+		if d.Selector != "" {
+			b.WriteString(" header.s=" + d.Selector)
+		}
+	}
+	b.WriteString(";\r\n\tdmarc=" + dmarc.Result)
+	if dmarc.Policy != "" {
+		b.WriteString(" (p=" + dmarc.Policy + " dis=" + dmarc.Disposition + ")")
+	}
+	if dmarc.Domain != "" {
+		b.WriteString(" header.from=" + dmarc.Domain)
+	}
+	b.WriteString("\r\n")
+	return b.String()
+}
@@ -0,0 +1,72 @@
+package smtp
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"time"
+
+	Utility "github.com/globulario/utility"
+)
+
+// bounceMessage builds the delivery status notification (RFC 3464) telling
+// the sender of e that its message could not be delivered. The original
+// headers are returned, not the body.
+func bounceMessage(domain string, e *SpoolEntry, original []byte, now time.Time) []byte {
+	boundary := Utility.RandomUUID()
+	status, action := "5.0.0", "permanently failed"
+	if !e.Permanent {
+		status, action = "4.4.7", "could not be delivered in time"
+	}
+	headers, _ := splitMessage(normalizeCRLF(original))
+	date := now.Format(time.RFC1123Z)
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "From: Mail Delivery System <MAILER-DAEMON@%s>\r\n", domain)
+	fmt.Fprintf(&b, "To: <%s>\r\n", e.From)
+	b.WriteString("Subject: Undelivered Mail Returned to Sender\r\n")
+	fmt.Fprintf(&b, "Date: %s\r\n", date)
+	fmt.Fprintf(&b, "Message-ID: <%s@%s>\r\n", Utility.RandomUUID(), domain)
+	b.WriteString("Auto-Submitted: auto-replied\r\n")
+	b.WriteString("MIME-Version: 1.0\r\n")
+	fmt.Fprintf(&b, "Content-Type: multipart/report; report-type=delivery-status; boundary=\"%s\"\r\n\r\n", boundary)
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/plain; charset=utf-8\r\n\r\n", boundary)
+	fmt.Fprintf(&b, "Your message to <%s> %s after %d attempt(s).\r\n\r\n", e.To, action, e.Attempts)
+	fmt.Fprintf(&b, "Last error: %s\r\n\r\n", e.LastError)
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: message/delivery-status\r\n\r\n", boundary)
+	fmt.Fprintf(&b, "Reporting-MTA: dns; %s\r\n", domain)
+	fmt.Fprintf(&b, "Arrival-Date: %s\r\n\r\n", e.Created.Format(time.RFC1123Z))
+	fmt.Fprintf(&b, "Final-Recipient: rfc822; %s\r\n", e.To)
+	b.WriteString("Action: failed\r\n")
+	fmt.Fprintf(&b, "Status: %s\r\n", status)
+	fmt.Fprintf(&b, "Diagnostic-Code: smtp; %s\r\n", strings.ReplaceAll(e.LastError, "\n", " "))
+	fmt.Fprintf(&b, "Last-Attempt-Date: %s\r\n\r\n", date)
+
+	fmt.Fprintf(&b, "--%s\r\nContent-Type: text/rfc822-headers\r\n\r\n", boundary)
+	for _, h := range headers {
+		b.WriteString(h)
+	}
+	fmt.Fprintf(&b, "\r\n--%s--\r\n", boundary)
+	return b.Bytes()
+}
+
+// bounce returns a failed message to its sender: into the INBOX of a local
+// account, through the spool otherwise. Bounces themselves never bounce.
+func bounce(domain string, e *SpoolEntry, original []byte) {
+	if e.From == "" {
+		logger.Warn("spool: dropping undeliverable bounce", "id", e.Id, "to", e.To)
+		return
+	}
+	dsn := bounceMessage(domain, e, original, time.Now())
+	if hasAccount(e.From) {
+		if err := saveMessage(e.From, "INBOX", dsn, []string{}); err != nil {
+			logger.Warn("spool: bounce save failed", "id", e.Id, "to", e.From, "err", err)
+		}
+		return
+	}
+	if err := queueOutgoing("", e.From, dsn); err != nil {
+		logger.Warn("spool: bounce queue failed", "id", e.Id, "to", e.From, "err", err)
+	}
+}
@@ -0,0 +1,64 @@
+package smtp
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"net"
+	"strings"
+	"time"
+)
+
+const (
+	junkMailbox    = "Junk"           // where suspicious inbound mail is filed
+	inboundTimeout = 20 * time.Second // DNS budget for the SPF, DKIM and DMARC checks
+)
+
+// checkInbound runs SPF, DKIM and DMARC on a message from a remote sender.
+// It returns the message with an Authentication-Results header prepended
+// and the mailbox it belongs in, or an SMTP reply when the policy rejects it.
+func checkInbound(domain string, remoteAddr net.Addr, from string, data []byte) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), inboundTimeout)
+	defer cancel()
+
+	// A null reverse-path (bounces) is checked against the HELO name.
+	sender, spfDomain := from, ""
+	if _, d, err := splitAddress(from); err == nil {
+		spfDomain = d
+	} else if helo := heloName(data); helo != "" {
+		sender, spfDomain = "postmaster@"+helo, helo
+	}
+	spf := SPFNone
+	if tcp, ok := remoteAddr.(*net.TCPAddr); ok && spfDomain != "" {
+		spf = CheckSPF(ctx, resolver, tcp.IP, spfDomain, sender)
+	}
+	dkim := VerifyDKIM(ctx, resolver, data)
+	dmarc := DMARCResult{Result: DMARCNone, Disposition: DispositionNone}
+	if d, err := fromDomain(data); err == nil {
+		dmarc = CheckDMARC(ctx, resolver, d, spf, spfDomain, dkim)
+	}
+
+	// Without a DMARC policy, a hard SPF failure is the only signal.
+	suspicious := dmarc.Disposition != DispositionNone || (dmarc.Result == DMARCNone && spf == SPFFail)
+	logger.Info("smtp inbound: checked", "from", from, "remote", remoteAddr.String(), "spf", spf, "dmarc", dmarc.Result, "disposition", dmarc.Disposition)
+
+	if suspicious && strings.EqualFold(InboundPolicy, "reject") && dmarc.Disposition != DispositionQuarantine {
+		return nil, "", errors.New("550 5.7.1 Message rejected by sender policy (spf=" + string(spf) + ", dmarc=" + dmarc.Result + ")")
+	}
+	mailBox := "INBOX"
+	if suspicious {
+		mailBox = junkMailbox
+	}
+	return append([]byte(authenticationResults(domain, spf, from, dkim, dmarc)), data...), mailBox, nil
+}
+
+// heloName extracts the HELO/EHLO name from the Received header the SMTP
+// server puts on top of every message ("Received: from <helo> (...").
+func heloName(data []byte) string {
+	const prefix = "Received: from "
+	if !bytes.HasPrefix(data, []byte(prefix)) {
+		return ""
+	}
+	name, _, _ := strings.Cut(string(data[len(prefix):min(len(data), len(prefix)+256)]), " ")
+	return strings.TrimSpace(name)
+}
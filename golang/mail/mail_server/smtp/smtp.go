@@ -1,14 +1,17 @@
 package smtp
 
 import (
-	"bytes"
+	"context"
 	"crypto/tls"
 	"errors"
+	"fmt"
 	"io"
 	"log/slog"
 	"net"
 	"net/smtp"
+	"net/textproto"
 	"os"
+	"path/filepath"
 	"strconv"
 	"strings"
 	"time"
@@ -41,6 +44,24 @@ var (
 	Backend_port    int
 )
 
+// Delivery settings; set them before StartSmtp.
+var (
+	SpoolDir      string        // outbound queue; defaults to <data dir>/mail/spool
+	Dkim          *DKIMSigner   // signs mail sent by local accounts when set
+	InboundPolicy string        // "tag" (default) files failing mail as Junk; "reject" refuses what DMARC says to reject
+	RateLimit     int           // outbound deliveries per recipient domain per minute; 0 disables
+	MaxQueueAge   time.Duration // how long delivery is retried before the message bounces; 0 means 5 days
+
+	spool    *Spool                         // outbound queue, once StartSmtp ran
+	resolver Resolver = net.DefaultResolver // DNS used by the inbound checks
+)
+
+// Outbound connection limits.
+const (
+	dialTimeout    = 30 * time.Second
+	sessionTimeout = 5 * time.Minute
+)
+
 // Sender represents an email sender (outbound SMTP).
 type Sender struct {
 	Hostname string
@@ -57,103 +78,146 @@ func splitAddress(address string) (localPart, domain string, err error) {
 }
 
 // Send relays a message to each recipient's MX host using SMTP.
-// It prefers port 587 (STARTTLS), then 465 (implicit TLS attempted via STARTTLS),
-// then 25 (plain). Public prototype preserved.
+// It prefers port 587 (STARTTLS), then 465 (implicit TLS), then 25 (plain).
+// Public prototype preserved.
 func (s *Sender) Send(from string, to []string, r io.Reader) error {
+	msg, err := io.ReadAll(r)
+	if err != nil {
+		return err
+	}
 	for _, addr := range to {
-		_, domain, err := splitAddress(addr)
-		if err != nil {
-			logger.Warn("smtp send: invalid recipient", "addr", addr, "err", err)
+		if err := s.deliver(from, addr, msg); err != nil {
 			return err
 		}
+	}
+	return nil
+}
 
-		// Lookup MX records for the recipient domain.
-		mxs, err := net.LookupMX(domain)
-		if err != nil {
+// deliver relays msg to one recipient. Failures that retrying cannot fix
+// (a 5xx reply, a domain without mail servers) are *PermanentError.
+func (s *Sender) deliver(from string, to string, msg []byte) error {
+	_, domain, err := splitAddress(to)
+	if err != nil {
+		logger.Warn("smtp send: invalid recipient", "addr", to, "err", err)
+		return &PermanentError{Err: err}
+	}
+
+	// Lookup MX records for the recipient domain.
+	mxs, err := net.LookupMX(domain)
+	if err != nil {
+		if !isNotFound(err) {
 			logger.Error("smtp send: MX lookup failed", "domain", domain, "err", err)
 			return err
 		}
-		if len(mxs) == 0 {
-			mxs = []*net.MX{{Host: domain}}
+		// No MX: the domain itself is the implicit MX (RFC 5321 §5.1).
+		if _, err := net.LookupHost(domain); err != nil {
+			if isNotFound(err) {
+				return &PermanentError{Err: errors.New("domain " + domain + " does not exist")}
+			}
+			return err
 		}
+		mxs = []*net.MX{{Host: domain}}
+	}
+	if len(mxs) == 0 {
+		mxs = []*net.MX{{Host: domain}}
+	}
+	if len(mxs) == 1 && (mxs[0].Host == "." || mxs[0].Host == "") {
+		return &PermanentError{Err: errors.New("domain " + domain + " does not accept mail")}
+	}
 
-		var success bool
-		for _, mx := range mxs {
-			host := strings.TrimSuffix(mx.Host, ".")
-			for _, port := range []int{587, 465, 25} {
-				addrPort := host + ":" + strconv.Itoa(port)
-
-				c, err := smtp.Dial(addrPort)
-				if err != nil {
-					logger.Warn("smtp send: dial failed", "mx", host, "port", port, "err", err)
-					continue
-				}
-
-				// Ensure we close this client.
-				func() {
-					defer func() {
-						_ = c.Quit()
-					}()
-
-					// STARTTLS policy for 587/465 (best effort; 465 typically needs tls.Dial, but we keep behavior).
-					if port == 587 || port == 465 {
-						tlsConfig := &tls.Config{
-							ServerName:         host,
-							InsecureSkipVerify: false,
-						}
-						if err := c.StartTLS(tlsConfig); err != nil {
-							logger.Warn("smtp send: STARTTLS failed", "mx", host, "port", port, "err", err)
-							return
-						}
-					}
-
-					if err := c.Hello(s.Hostname); err != nil {
-						logger.Warn("smtp send: HELO/EHLO failed", "mx", host, "port", port, "err", err)
-						return
-					}
-					if err := c.Mail(from); err != nil {
-						logger.Warn("smtp send: MAIL FROM failed", "from", from, "mx", host, "port", port, "err", err)
-						return
-					}
-					if err := c.Rcpt(addr); err != nil {
-						logger.Warn("smtp send: RCPT TO failed", "to", addr, "mx", host, "port", port, "err", err)
-						return
-					}
-					wc, err := c.Data()
-					if err != nil {
-						logger.Warn("smtp send: DATA failed", "mx", host, "port", port, "err", err)
-						return
-					}
-					if _, err := io.Copy(wc, r); err != nil {
-						_ = wc.Close()
-						logger.Warn("smtp send: write body failed", "mx", host, "port", port, "err", err)
-						return
-					}
-					if err := wc.Close(); err != nil {
-						logger.Warn("smtp send: close data failed", "mx", host, "port", port, "err", err)
-						return
-					}
-
-					success = true
-				}()
-
-				if success {
-					break
-				}
+	lastErr := errors.New("failed to send to any MX servers for domain " + domain)
+	for _, mx := range mxs {
+		host := strings.TrimSuffix(mx.Host, ".")
+		for _, port := range []int{587, 465, 25} {
+			err := s.sendTo(host, port, from, to, msg)
+			if err == nil {
+				return nil
 			}
-			if success {
-				break
+			var perm *PermanentError
+			if errors.As(err, &perm) && port == 25 {
+				// A 5xx reply from the relay port is final; other MX hosts
+				// would answer the same. Submission ports want AUTH instead.
+				return err
 			}
+			lastErr = err
 		}
+	}
+	logger.Error("smtp send: all MX attempts failed", "domain", domain, "err", lastErr)
+	return lastErr
+}
+
+// sendTo runs one SMTP transaction against host:port.
+func (s *Sender) sendTo(host string, port int, from, to string, msg []byte) error {
+	addrPort := host + ":" + strconv.Itoa(port)
+	dialer := &net.Dialer{Timeout: dialTimeout}
+	var conn net.Conn
+	var err error
+	if port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addrPort, &tls.Config{ServerName: host})
+	} else {
+		conn, err = dialer.Dial("tcp", addrPort)
+	}
+	if err != nil {
+		logger.Warn("smtp send: dial failed", "mx", host, "port", port, "err", err)
+		return err
+	}
+	_ = conn.SetDeadline(time.Now().Add(sessionTimeout))
+
+	c, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		logger.Warn("smtp send: greeting failed", "mx", host, "port", port, "err", err)
+		return err
+	}
+	defer func() {
+		_ = c.Quit()
+	}()
 
-		if !success {
-			logger.Error("smtp send: all MX attempts failed", "domain", domain)
-			return errors.New("failed to send to any MX servers for domain " + domain)
+	if err := c.Hello(s.Hostname); err != nil {
+		logger.Warn("smtp send: HELO/EHLO failed", "mx", host, "port", port, "err", err)
+		return err
+	}
+	if port == 587 {
+		if err := c.StartTLS(&tls.Config{ServerName: host}); err != nil {
+			logger.Warn("smtp send: STARTTLS failed", "mx", host, "port", port, "err", err)
+			return err
 		}
 	}
+	if err := c.Mail(from); err != nil {
+		logger.Warn("smtp send: MAIL FROM failed", "from", from, "mx", host, "port", port, "err", err)
+		return deliveryError(err)
+	}
+	if err := c.Rcpt(to); err != nil {
+		logger.Warn("smtp send: RCPT TO failed", "to", to, "mx", host, "port", port, "err", err)
+		return deliveryError(err)
+	}
+	wc, err := c.Data()
+	if err != nil {
+		logger.Warn("smtp send: DATA failed", "mx", host, "port", port, "err", err)
+		return deliveryError(err)
+	}
+	if _, err := wc.Write(msg); err != nil {
+		_ = wc.Close()
+		logger.Warn("smtp send: write body failed", "mx", host, "port", port, "err", err)
+		return err
+	}
+	if err := wc.Close(); err != nil {
+		logger.Warn("smtp send: close data failed", "mx", host, "port", port, "err", err)
+		return deliveryError(err)
+	}
 	return nil
 }
 
+// deliveryError turns a 5xx reply into a *PermanentError, keeping the code
+// in the message for the bounce.
+func deliveryError(err error) error {
+	var tpErr *textproto.Error
+	if errors.As(err, &tpErr) && tpErr.Code >= 500 {
+		return &PermanentError{Err: fmt.Errorf("%d %s", tpErr.Code, tpErr.Msg)}
+	}
+	return err
+}
+
 // hasAccount checks if a user email has an associated account.
 func hasAccount(email string) bool {
 	query := `{"email":"` + email + `"}`
@@ -195,11 +259,19 @@ func startSmtp(domain string, port int, keyFile string, certFile string) {
 			AuthMechs:    map[string]bool{},
 			AuthRequired: false,
 			Handler: func(remoteAddr net.Addr, from string, to []string, data []byte) error {
+				local := hasAccount(from)
+				inbound, mailBox := data, "INBOX"
+				if !local {
+					var err error
+					if inbound, mailBox, err = checkInbound(domain, remoteAddr, from, data); err != nil {
+						return err
+					}
+				}
 				for _, rcpt := range to {
 					if hasAccount(rcpt) {
-						incoming <- map[string]interface{}{"msg": data, "from": from, "to": rcpt}
+						incoming <- map[string]interface{}{"msg": inbound, "from": from, "to": rcpt, "mailbox": mailBox}
 					}
-					if hasAccount(from) {
+					if local {
 						outgoing <- map[string]interface{}{"msg": data, "from": from, "to": rcpt}
 					}
 				}
@@ -242,6 +314,13 @@ func saveMessage(email string, mailBox string, body []byte, flags []string) erro
 		logger.Error("saveMessage: account lookup failed", "email", email, "err", err)
 		return errors.New("failed to save message: account lookup error")
 	}
+	db := info["name"].(string) + "_db"
+	if mailBox == junkMailbox {
+		// IMAP lists only registered mailboxes; INBOX is registered
+		// implicitly only while there are none.
+		ensureMailbox(db, "INBOX")
+		ensureMailbox(db, junkMailbox)
+	}
 
 	data := map[string]interface{}{
 		"Date":  time.Now(),
@@ -251,7 +330,7 @@ func saveMessage(email string, mailBox string, body []byte, flags []string) erro
 		"Uid":   time.Now().Unix(),
 	}
 
-	_, err = Store.InsertOne("local_resource", info["name"].(string)+"_db", mailBox, data, "")
+	_, err = Store.InsertOne("local_resource", db, mailBox, data, "")
 	if err != nil {
 		logger.Error("saveMessage: insert failed", "email", email, "mailbox", mailBox, "err", err)
 		return err
@@ -259,6 +338,55 @@ func saveMessage(email string, mailBox string, body []byte, flags []string) erro
 	return nil
 }
 
+// ensureMailbox registers a mailbox in the MailBoxes collection of db.
+func ensureMailbox(db string, name string) {
+	query := `{"Name":"` + name + `"}`
+	if count, err := Store.Count("local_resource", db, "MailBoxes", query, ""); err == nil && count > 0 {
+		return
+	}
+	box := map[string]interface{}{"Name": name, "Delimiter": "/"}
+	if _, err := Store.InsertOne("local_resource", db, "MailBoxes", box, ""); err != nil {
+		logger.Warn("ensureMailbox: insert failed", "db", db, "mailbox", name, "err", err)
+	}
+}
+
+// queueOutgoing signs msg when DKIM is configured and spools it for to.
+func queueOutgoing(from string, to string, msg []byte) error {
+	if spool == nil {
+		return errors.New("outbound spool is not running")
+	}
+	if Dkim != nil {
+		signed, err := Dkim.Sign(msg)
+		if err != nil {
+			logger.Warn("dkim sign failed, sending unsigned", "from", from, "err", err)
+		} else {
+			msg = signed
+		}
+	}
+	_, err := spool.Enqueue(from, to, msg)
+	return err
+}
+
+// startSpool opens the outbound queue and starts its delivery worker.
+func startSpool(domain string) error {
+	dir := SpoolDir
+	if dir == "" {
+		dir = filepath.Join(config.GetDataDir(), "mail", "spool")
+	}
+	sp, err := NewSpool(dir)
+	if err != nil {
+		return err
+	}
+	sender := &Sender{Hostname: domain}
+	sp.MaxAge = MaxQueueAge
+	sp.RateLimit = RateLimit
+	sp.Deliver = sender.deliver
+	sp.Bounce = func(e *SpoolEntry, msg []byte) { bounce(domain, e, msg) }
+	spool = sp
+	go sp.Run(context.Background())
+	return nil
+}
+
 // StartSmtp initializes the persistence client and starts SMTP servers on the
 // provided ports (plain and TLS variants). Public prototype preserved.
 func StartSmtp(
@@ -301,23 +429,25 @@ func StartSmtp(
 		return
 	}
 
+	if err := startSpool(domain); err != nil {
+		logger.Error("smtp start: outbound spool unavailable", "dir", SpoolDir, "err", err)
+	}
+
 	// Message processing goroutine
 	go func() {
 		for {
 			select {
 			case data := <-incoming:
-				if err := saveMessage(data["to"].(string), "INBOX", data["msg"].([]byte), []string{}); err != nil {
+				if err := saveMessage(data["to"].(string), data["mailbox"].(string), data["msg"].([]byte), []string{}); err != nil {
 					logger.Warn("incoming save failed", "to", data["to"], "err", err)
 				}
 
 			case data := <-outgoing:
-				sender := &Sender{Hostname: domain}
-				if err := sender.Send(
-					data["from"].(string),
-					[]string{data["to"].(string)},
-					bytes.NewReader(data["msg"].([]byte)),
-				); err != nil {
-					logger.Warn("outgoing send failed", "from", data["from"], "to", data["to"], "err", err)
+				// Local recipients already got the message through incoming.
+				if !hasAccount(data["to"].(string)) {
+					if err := queueOutgoing(data["from"].(string), data["to"].(string), data["msg"].([]byte)); err != nil {
+						logger.Warn("outgoing queue failed", "from", data["from"], "to", data["to"], "err", err)
+					}
 				}
 				if err := saveMessage(data["from"].(string), "OUTBOX", data["msg"].([]byte), []string{}); err != nil {
 					logger.Warn("outgoing save failed", "from", data["from"], "err", err)
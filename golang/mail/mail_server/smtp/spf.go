@@ -0,0 +1,369 @@
+package smtp
+
+import (
+	"context"
+	"errors"
+	"net"
+	"strconv"
+	"strings"
+)
+
+// SPFResult is the outcome of an SPF check (RFC 7208 §2.6).
+type SPFResult string
+
+const (
+	SPFNone      SPFResult = "none"
+	SPFNeutral   SPFResult = "neutral"
+	SPFPass      SPFResult = "pass"
+	SPFFail      SPFResult = "fail"
+	SPFSoftFail  SPFResult = "softfail"
+	SPFTempError SPFResult = "temperror"
+	SPFPermError SPFResult = "permerror"
+)
+
+// spfLookupLimit caps the mechanisms and modifiers that cause DNS queries.
+const spfLookupLimit = 10
+
+// errSPFPerm marks a malformed record or an exceeded limit.
+var errSPFPerm = errors.New("spf: permanent error")
+
+// spfCheck holds the state of one check_host evaluation.
+type spfCheck struct {
+	ctx     context.Context
+	r       Resolver
+	ip      net.IP
+	sender  string
+	lookups int
+}
+
+// CheckSPF evaluates the SPF policy of domain for a message from sender
+// received from ip. Supported: all, include, a, mx, ip4, ip6, exists and
+// ptr (never matches) mechanisms, the redirect modifier, dual CIDR lengths
+// and the basic s/l/o/d/i/h macros without transformers.
+func CheckSPF(ctx context.Context, r Resolver, ip net.IP, domain, sender string) SPFResult {
+	c := &spfCheck{ctx: ctx, r: r, ip: ip, sender: sender}
+	return c.checkHost(strings.TrimSuffix(domain, "."))
+}
+
+func (c *spfCheck) checkHost(domain string) SPFResult {
+	if domain == "" || !strings.Contains(domain, ".") {
+		return SPFNone
+	}
+	txts, err := c.r.LookupTXT(c.ctx, domain)
+	if err != nil {
+		if isNotFound(err) {
+			return SPFNone
+		}
+		return SPFTempError
+	}
+	record := ""
+	for _, t := range txts {
+		if strings.EqualFold(t, "v=spf1") || strings.HasPrefix(strings.ToLower(t), "v=spf1 ") {
+			if record != "" {
+				return SPFPermError
+			}
+			record = t
+		}
+	}
+	if record == "" {
+		return SPFNone
+	}
+
+	redirect := ""
+	for _, term := range strings.Fields(record)[1:] {
+		if name, value, ok := spfModifier(term); ok {
+			if name == "redirect" {
+				if redirect != "" {
+					return SPFPermError
+				}
+				redirect = value
+			}
+			continue
+		}
+
+		result := SPFPass
+		switch term[0] {
+		case '+':
+			term = term[1:]
+		case '-':
+			result, term = SPFFail, term[1:]
+		case '~':
+			result, term = SPFSoftFail, term[1:]
+		case '?':
+			result, term = SPFNeutral, term[1:]
+		}
+		match, err := c.mechanism(term, domain)
+		if err != nil {
+			if errors.Is(err, errSPFPerm) {
+				return SPFPermError
+			}
+			return SPFTempError
+		}
+		if match {
+			return result
+		}
+	}
+
+	if redirect != "" {
+		if err := c.count(); err != nil {
+			return SPFPermError
+		}
+		target, err := c.expand(redirect, domain)
+		if err != nil {
+			return SPFPermError
+		}
+		if res := c.checkHost(target); res != SPFNone {
+			return res
+		}
+		return SPFPermError
+	}
+	return SPFNeutral
+}
+
+// mechanism reports whether the mechanism term matches the client IP.
+func (c *spfCheck) mechanism(term, domain string) (bool, error) {
+	name, spec := term, ""
+	if i := strings.IndexAny(term, ":/"); i >= 0 {
+		name, spec = term[:i], term[i:]
+	}
+
+	switch strings.ToLower(name) {
+	case "all":
+		return spec == "", spfPerm(spec != "")
+
+	case "include":
+		if err := c.count(); err != nil {
+			return false, err
+		}
+		target, err := c.target(spec, domain)
+		if err != nil {
+			return false, err
+		}
+		switch c.checkHost(target) {
+		case SPFPass:
+			return true, nil
+		case SPFTempError:
+			return false, errors.New("spf: include temperror")
+		case SPFPermError, SPFNone:
+			return false, errSPFPerm
+		}
+		return false, nil
+
+	case "a", "mx":
+		if err := c.count(); err != nil {
+			return false, err
+		}
+		target, cidr4, cidr6, err := c.dualCIDR(spec, domain)
+		if err != nil {
+			return false, err
+		}
+		hosts := []string{target}
+		if strings.EqualFold(name, "mx") {
+			mxs, err := c.r.LookupMX(c.ctx, target)
+			if err != nil && !isNotFound(err) {
+				return false, err
+			}
+			if len(mxs) > spfLookupLimit {
+				return false, errSPFPerm
+			}
+			hosts = hosts[:0]
+			for _, mx := range mxs {
+				hosts = append(hosts, strings.TrimSuffix(mx.Host, "."))
+			}
+		}
+		for _, host := range hosts {
+			addrs, err := c.r.LookupIPAddr(c.ctx, host)
+			if err != nil && !isNotFound(err) {
+				return false, err
+			}
+			for _, a := range addrs {
+				if ipInCIDR(c.ip, a.IP, cidr4, cidr6) {
+					return true, nil
+				}
+			}
+		}
+		return false, nil
+
+	case "ip4", "ip6":
+		if !strings.HasPrefix(spec, ":") {
+			return false, errSPFPerm
+		}
+		network := spec[1:]
+		if !strings.Contains(network, "/") {
+			if strings.EqualFold(name, "ip4") {
+				network += "/32"
+			} else {
+				network += "/128"
+			}
+		}
+		_, ipnet, err := net.ParseCIDR(network)
+		if err != nil || (strings.EqualFold(name, "ip4") != (ipnet.IP.To4() != nil)) {
+			return false, errSPFPerm
+		}
+		return ipnet.Contains(c.ip), nil
+
+	case "exists":
+		if err := c.count(); err != nil {
+			return false, err
+		}
+		target, err := c.target(spec, domain)
+		if err != nil {
+			return false, err
+		}
+		addrs, err := c.r.LookupIPAddr(c.ctx, target)
+		if err != nil && !isNotFound(err) {
+			return false, err
+		}
+		for _, a := range addrs {
+			if a.IP.To4() != nil {
+				return true, nil
+			}
+		}
+		return false, nil
+
+	case "ptr":
+		// Deprecated by RFC 7208 §5.5; counted but never matched.
+		return false, c.count()
+	}
+	return false, errSPFPerm
+}
+
+// count charges one DNS-querying term against the lookup limit.
+func (c *spfCheck) count() error {
+	c.lookups++
+	if c.lookups > spfLookupLimit {
+		return errSPFPerm
+	}
+	return nil
+}
+
+// target returns the expanded domain-spec of a required ":domain".
+func (c *spfCheck) target(spec, domain string) (string, error) {
+	if !strings.HasPrefix(spec, ":") || len(spec) < 2 {
+		return "", errSPFPerm
+	}
+	return c.expand(spec[1:], domain)
+}
+
+// dualCIDR parses "[:domain][/cidr4][//cidr6]".
+func (c *spfCheck) dualCIDR(spec, domain string) (string, int, int, error) {
+	target := domain
+	if strings.HasPrefix(spec, ":") {
+		spec = spec[1:]
+		name := spec
+		if i := strings.Index(spec, "/"); i >= 0 {
+			name, spec = spec[:i], spec[i:]
+		} else {
+			spec = ""
+		}
+		var err error
+		if target, err = c.expand(name, domain); err != nil {
+			return "", 0, 0, err
+		}
+	}
+	cidr4, cidr6 := 32, 128
+	if i := strings.Index(spec, "//"); i >= 0 {
+		n, err := strconv.Atoi(spec[i+2:])
+		if err != nil || n < 0 || n > 128 {
+			return "", 0, 0, errSPFPerm
+		}
+		cidr6, spec = n, spec[:i]
+	}
+	if spec != "" {
+		n, err := strconv.Atoi(strings.TrimPrefix(spec, "/"))
+		if err != nil || !strings.HasPrefix(spec, "/") || n < 0 || n > 32 {
+			return "", 0, 0, errSPFPerm
+		}
+		cidr4 = n
+	}
+	return target, cidr4, cidr6, nil
+}
+
+// expand substitutes the simple macros of a domain-spec.
+func (c *spfCheck) expand(spec, domain string) (string, error) {
+	if !strings.Contains(spec, "%") {
+		return spec, nil
+	}
+	local, senderDomain, err := splitAddress(c.sender)
+	if err != nil {
+		local, senderDomain = "postmaster", c.sender
+	}
+	var b strings.Builder
+	for i := 0; i < len(spec); i++ {
+		if spec[i] != '%' {
+			b.WriteByte(spec[i])
+			continue
+		}
+		if i+1 >= len(spec) {
+			return "", errSPFPerm
+		}
+		i++
+		switch spec[i] {
+		case '%':
+			b.WriteByte('%')
+		case '_':
+			b.WriteByte(' ')
+		case '-':
+			b.WriteString("%20")
+		case '{':
+			end := strings.IndexByte(spec[i:], '}')
+			if end != 2 {
+				return "", errSPFPerm // transformers and delimiters are not supported
+			}
+			switch spec[i+1] {
+			case 's', 'S':
+				b.WriteString(c.sender)
+			case 'l', 'L':
+				b.WriteString(local)
+			case 'o', 'O':
+				b.WriteString(senderDomain)
+			case 'd', 'D', 'h', 'H':
+				b.WriteString(domain)
+			case 'i', 'I':
+				b.WriteString(c.ip.String())
+			default:
+				return "", errSPFPerm
+			}
+			i += end
+		default:
+			return "", errSPFPerm
+		}
+	}
+	return b.String(), nil
+}
+
+// spfModifier splits a name=value modifier term.
+func spfModifier(term string) (string, string, bool) {
+	name, value, ok := strings.Cut(term, "=")
+	if !ok || name == "" || strings.ContainsAny(name, ":/") {
+		return "", "", false
+	}
+	return strings.ToLower(name), value, true
+}
+
+func spfPerm(bad bool) error {
+	if bad {
+		return errSPFPerm
+	}
+	return nil
+}
+
+// ipInCIDR reports whether ip and addr share their first cidr4 (IPv4) or
+// cidr6 (IPv6) bits.
+func ipInCIDR(ip, addr net.IP, cidr4, cidr6 int) bool {
+	if ip4, a4 := ip.To4(), addr.To4(); ip4 != nil || a4 != nil {
+		if ip4 == nil || a4 == nil {
+			return false
+		}
+		mask := net.CIDRMask(cidr4, 32)
+		return ip4.Mask(mask).Equal(a4.Mask(mask))
+	}
+	mask := net.CIDRMask(cidr6, 128)
+	return ip.Mask(mask).Equal(addr.Mask(mask))
+}
+
+// isNotFound reports a DNS name or record that does not exist.
+func isNotFound(err error) bool {
+	var dnsErr *net.DNSError
+	return errors.As(err, &dnsErr) && dnsErr.IsNotFound
+}
@@ -0,0 +1,112 @@
+package smtp
+
+import (
+	"context"
+	"net"
+	"strings"
+	"testing"
+)
+
+func TestCheckSPF(t *testing.T) {
+	r := &fakeResolver{
+		txt: map[string][]string{
+			"example.com":      {"google-site-verification=x", "v=spf1 ip4:192.0.2.0/24 a:mail.example.com mx include:_spf.partner.net -all"},
+			"_spf.partner.net": {"v=spf1 ip6:2001:db8::/32 ~all"},
+			"soft.example":     {"v=spf1 ~all"},
+			"redirect.example": {"v=spf1 redirect=example.com"},
+			"macro.example":    {"v=spf1 exists:%{l}.allow.macro.example -all"},
+			"double.example":   {"v=spf1 -all", "v=spf1 +all"},
+			"loop.example":     {"v=spf1 include:loop.example -all"},
+			"bad.example":      {"v=spf1 ip4:not-an-ip -all"},
+		},
+		ip: map[string][]string{
+			"mail.example.com":          {"198.51.100.7"},
+			"mx.example.com":            {"203.0.113.9"},
+			"alice.allow.macro.example": {"127.0.0.2"},
+		},
+		mx: map[string][]string{"example.com": {"mx.example.com"}},
+	}
+
+	cases := []struct {
+		ip, domain, sender string
+		want               SPFResult
+	}{
+		{"192.0.2.44", "example.com", "a@example.com", SPFPass},
+		{"198.51.100.7", "example.com", "a@example.com", SPFPass},
+		{"203.0.113.9", "example.com", "a@example.com", SPFPass},
+		{"2001:db8::1", "example.com", "a@example.com", SPFPass},
+		{"10.0.0.1", "example.com", "a@example.com", SPFFail},
+		{"10.0.0.1", "soft.example", "a@soft.example", SPFSoftFail},
+		{"192.0.2.1", "redirect.example", "a@redirect.example", SPFPass},
+		{"10.0.0.1", "redirect.example", "a@redirect.example", SPFFail},
+		{"10.0.0.1", "macro.example", "alice@macro.example", SPFPass},
+		{"10.0.0.1", "macro.example", "eve@macro.example", SPFFail},
+		{"10.0.0.1", "double.example", "a@double.example", SPFPermError},
+		{"10.0.0.1", "loop.example", "a@loop.example", SPFPermError},
+		{"10.0.0.1", "bad.example", "a@bad.example", SPFPermError},
+		{"10.0.0.1", "nowhere.example", "a@nowhere.example", SPFNone},
+	}
+	for _, c := range cases {
+		if got := CheckSPF(context.Background(), r, net.ParseIP(c.ip), c.domain, c.sender); got != c.want {
+			t.Errorf("CheckSPF(%s, %s) = %s, want %s", c.ip, c.domain, got, c.want)
+		}
+	}
+}
+
+func TestCheckDMARC(t *testing.T) {
+	r := &fakeResolver{txt: map[string][]string{
+		"_dmarc.example.com": {"v=DMARC1; p=reject; sp=quarantine; aspf=s"},
+		"_dmarc.lax.org":     {"v=DMARC1; p=none"},
+		"_dmarc.bank.co.uk":  {"v=DMARC1; p=reject"},
+	}}
+	signedBy := func(d, res string) []DKIMResult { return []DKIMResult{{Domain: d, Result: res}} }
+	ctx := context.Background()
+
+	cases := []struct {
+		name                string
+		from                string
+		spf                 SPFResult
+		spfDomain           string
+		dkim                []DKIMResult
+		result, disposition string
+	}{
+		{"aligned spf", "example.com", SPFPass, "example.com", signedBy("", DKIMNone), DMARCPass, DispositionNone},
+		{"strict spf misaligned", "example.com", SPFPass, "bounce.example.com", signedBy("", DKIMNone), DMARCFail, DispositionReject},
+		{"relaxed dkim", "example.com", SPFFail, "evil.net", signedBy("mail.example.com", DKIMPass), DMARCPass, DispositionNone},
+		{"foreign dkim", "example.com", SPFNone, "", signedBy("evil.net", DKIMPass), DMARCFail, DispositionReject},
+		{"subdomain policy", "news.example.com", SPFNone, "", signedBy("", DKIMNone), DMARCFail, DispositionQuarantine},
+		{"monitor only", "lax.org", SPFFail, "lax.org", signedBy("", DKIMNone), DMARCFail, DispositionNone},
+		{"no policy", "other.net", SPFFail, "other.net", signedBy("", DKIMNone), DMARCNone, DispositionNone},
+		{"public suffix dkim", "bank.co.uk", SPFNone, "", signedBy("evil.co.uk", DKIMPass), DMARCFail, DispositionReject},
+		{"public suffix spf", "bank.co.uk", SPFPass, "evil.co.uk", signedBy("", DKIMNone), DMARCFail, DispositionReject},
+		{"relaxed under public suffix", "bank.co.uk", SPFPass, "mail.bank.co.uk", signedBy("", DKIMNone), DMARCPass, DispositionNone},
+	}
+	for _, c := range cases {
+		got := CheckDMARC(ctx, r, c.from, c.spf, c.spfDomain, c.dkim)
+		if got.Result != c.result || got.Disposition != c.disposition {
+			t.Errorf("%s: got %s/%s, want %s/%s", c.name, got.Result, got.Disposition, c.result, c.disposition)
+		}
+	}
+
+	for _, c := range []struct {
+		a, b  string
+		align bool
+	}{
+		{"bank.co.uk", "evil.co.uk", false},
+		{"mail.bank.co.uk", "bank.co.uk", true},
+		{"a.example.com", "b.example.com", true},
+		{"co.uk", "co.uk", true},
+		{"co.uk", "bank.co.uk", false},
+	} {
+		if got := domainsAlign(c.a, c.b, "r"); got != c.align {
+			t.Errorf("relaxed alignment of %s and %s = %v", c.a, c.b, got)
+		}
+	}
+
+	ar := authenticationResults("mx.local", SPFPass, "a@example.com", signedBy("example.com", DKIMPass), DMARCResult{Domain: "example.com", Result: DMARCPass, Policy: "reject", Disposition: DispositionNone})
+	for _, want := range []string{"spf=pass smtp.mailfrom=a@example.com", "dkim=pass header.d=example.com", "dmarc=pass (p=reject dis=none) header.from=example.com"} {
+		if !strings.Contains(ar, want) {
+			t.Errorf("Authentication-Results %q lacks %q", ar, want)
+		}
+	}
+}
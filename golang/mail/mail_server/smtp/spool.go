@@ -0,0 +1,300 @@
+package smtp
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	Utility "github.com/globulario/utility"
+)
+
+// Spool defaults.
+const (
+	defaultSpoolMaxAge = 5 * 24 * time.Hour
+	spoolFirstRetry    = 5 * time.Minute
+	spoolMaxRetry      = 4 * time.Hour
+	spoolPollInterval  = 30 * time.Second
+)
+
+// SpoolEntry is one queued message for one recipient. It is stored as
+// <Id>.json next to the message itself, <Id>.eml.
+type SpoolEntry struct {
+	Id          string    `json:"id"`
+	From        string    `json:"from"` // empty for bounces (null reverse-path)
+	To          string    `json:"to"`
+	Created     time.Time `json:"created"`
+	Attempts    int       `json:"attempts"`
+	NextAttempt time.Time `json:"next_attempt"`
+	LastError   string    `json:"last_error,omitempty"`
+	Permanent   bool      `json:"permanent,omitempty"` // the last error was permanent, not a timeout
+}
+
+// Spool is the durable outbound queue. Messages survive restarts, failed
+// deliveries are retried with exponential backoff, and messages that fail
+// permanently or stay undeliverable for MaxAge are handed to Bounce.
+type Spool struct {
+	dir string
+
+	MaxAge    time.Duration                           // give up after this long; 0 means 5 days
+	RateLimit int                                     // deliveries per recipient domain per minute; 0 disables
+	Deliver   func(from, to string, msg []byte) error // sends one message; see PermanentError
+	Bounce    func(e *SpoolEntry, msg []byte)         // called once for each message given up on
+
+	now  func() time.Time
+	mu   sync.Mutex             // serializes processDue and guards sent
+	sent map[string][]time.Time // domain -> recent delivery times, for RateLimit
+	wake chan struct{}
+}
+
+// PermanentError marks a delivery failure that retrying will not fix, such
+// as a 5xx reply or a domain that does not exist.
+type PermanentError struct{ Err error }
+
+func (e *PermanentError) Error() string { return e.Err.Error() }
+func (e *PermanentError) Unwrap() error { return e.Err }
+
+// NewSpool opens (creating if needed) the spool kept in dir.
+func NewSpool(dir string) (*Spool, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("create spool dir: %w", err)
+	}
+	// Drop writes interrupted by a crash; the entry was never acknowledged.
+	if tmps, err := filepath.Glob(filepath.Join(dir, "*.tmp")); err == nil {
+		for _, t := range tmps {
+			_ = os.Remove(t)
+		}
+	}
+	if msgs, err := filepath.Glob(filepath.Join(dir, "*.eml")); err == nil {
+		for _, m := range msgs {
+			if _, err := os.Stat(strings.TrimSuffix(m, ".eml") + ".json"); os.IsNotExist(err) {
+				_ = os.Remove(m)
+			}
+		}
+	}
+	return &Spool{dir: dir, now: time.Now, sent: map[string][]time.Time{}, wake: make(chan struct{}, 1)}, nil
+}
+
+// Enqueue stores msg for delivery to one recipient and wakes the worker.
+// The message is on disk when Enqueue returns.
+func (s *Spool) Enqueue(from, to string, msg []byte) (*SpoolEntry, error) {
+	now := s.now()
+	e := &SpoolEntry{Id: fmt.Sprintf("%d-%s", now.UnixNano(), Utility.RandomUUID()), From: from, To: to, Created: now, NextAttempt: now}
+	if err := writeFileAtomic(s.path(e.Id, ".eml"), msg); err != nil {
+		return nil, err
+	}
+	// The metadata file is written last: an entry exists once it is there.
+	if err := s.save(e); err != nil {
+		_ = os.Remove(s.path(e.Id, ".eml"))
+		return nil, err
+	}
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+	return e, nil
+}
+
+// Entries returns the queued entries, soonest attempt first.
+func (s *Spool) Entries() ([]*SpoolEntry, error) {
+	files, err := filepath.Glob(filepath.Join(s.dir, "*.json"))
+	if err != nil {
+		return nil, err
+	}
+	entries := make([]*SpoolEntry, 0, len(files))
+	for _, f := range files {
+		data, err := os.ReadFile(f)
+		if err != nil {
+			continue
+		}
+		e := new(SpoolEntry)
+		if err := json.Unmarshal(data, e); err != nil || e.Id == "" {
+			logger.Warn("spool: skip unreadable entry", "file", f, "err", err)
+			continue
+		}
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].NextAttempt.Before(entries[j].NextAttempt) })
+	return entries, nil
+}
+
+// Run delivers due messages until ctx is done.
+func (s *Spool) Run(ctx context.Context) {
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.wake:
+		case <-timer.C:
+		}
+		wait := spoolPollInterval
+		if next := s.processDue(); !next.IsZero() {
+			if d := next.Sub(s.now()); d < wait {
+				wait = max(d, time.Second)
+			}
+		}
+		if !timer.Stop() {
+			select {
+			case <-timer.C:
+			default:
+			}
+		}
+		timer.Reset(wait)
+	}
+}
+
+// processDue attempts every entry whose time has come and returns when the
+// next one is due (zero if the spool is empty).
+func (s *Spool) processDue() time.Time {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	entries, err := s.Entries()
+	if err != nil {
+		logger.Error("spool: list failed", "dir", s.dir, "err", err)
+		return time.Time{}
+	}
+	var next time.Time
+	for _, e := range entries {
+		now := s.now()
+		if e.NextAttempt.After(now) {
+			if next.IsZero() || e.NextAttempt.Before(next) {
+				next = e.NextAttempt
+			}
+			continue
+		}
+		_, domain, _ := splitAddress(e.To)
+		if !s.allow(strings.ToLower(domain), now) {
+			// Over the domain's budget; try again once the window slides.
+			if retry := now.Add(time.Minute / time.Duration(s.RateLimit)); next.IsZero() || retry.Before(next) {
+				next = retry
+			}
+			continue
+		}
+		if n := s.attempt(e); !n.IsZero() && (next.IsZero() || n.Before(next)) {
+			next = n
+		}
+	}
+	return next
+}
+
+// attempt delivers one entry and records the outcome. It returns the time
+// of the next attempt, or zero when the entry left the spool.
+func (s *Spool) attempt(e *SpoolEntry) time.Time {
+	msg, err := os.ReadFile(s.path(e.Id, ".eml"))
+	if err != nil {
+		logger.Error("spool: message missing, dropping entry", "id", e.Id, "err", err)
+		s.remove(e.Id)
+		return time.Time{}
+	}
+
+	err = s.Deliver(e.From, e.To, msg)
+	now := s.now()
+	e.Attempts++
+	if err == nil {
+		logger.Info("spool: delivered", "id", e.Id, "to", e.To, "attempts", e.Attempts)
+		s.remove(e.Id)
+		return time.Time{}
+	}
+	e.LastError = err.Error()
+
+	var perm *PermanentError
+	maxAge := s.MaxAge
+	if maxAge <= 0 {
+		maxAge = defaultSpoolMaxAge
+	}
+	e.Permanent = errors.As(err, &perm)
+	if e.Permanent || now.Sub(e.Created) >= maxAge {
+		logger.Warn("spool: giving up", "id", e.Id, "to", e.To, "attempts", e.Attempts, "err", err)
+		if s.Bounce != nil {
+			s.Bounce(e, msg)
+		}
+		s.remove(e.Id)
+		return time.Time{}
+	}
+
+	e.NextAttempt = now.Add(retryDelay(e.Attempts))
+	logger.Warn("spool: delivery deferred", "id", e.Id, "to", e.To, "attempts", e.Attempts, "next", e.NextAttempt, "err", err)
+	if err := s.save(e); err != nil {
+		logger.Error("spool: save entry failed", "id", e.Id, "err", err)
+	}
+	return e.NextAttempt
+}
+
+// allow applies the per-domain rate limit and counts a delivery if allowed.
+func (s *Spool) allow(domain string, now time.Time) bool {
+	if s.RateLimit <= 0 {
+		return true
+	}
+	recent := s.sent[domain][:0]
+	for _, t := range s.sent[domain] {
+		if now.Sub(t) < time.Minute {
+			recent = append(recent, t)
+		}
+	}
+	if len(recent) >= s.RateLimit {
+		s.sent[domain] = recent
+		return false
+	}
+	s.sent[domain] = append(recent, now)
+	return true
+}
+
+// retryDelay is the wait after the given number of failed attempts:
+// 5 minutes doubling up to 4 hours.
+func retryDelay(attempts int) time.Duration {
+	d := spoolFirstRetry
+	for i := 1; i < attempts && d < spoolMaxRetry; i++ {
+		d *= 2
+	}
+	return min(d, spoolMaxRetry)
+}
+
+func (s *Spool) path(id, ext string) string {
+	return filepath.Join(s.dir, id+ext)
+}
+
+func (s *Spool) save(e *SpoolEntry) error {
+	data, err := json.Marshal(e)
+	if err != nil {
+		return err
+	}
+	return writeFileAtomic(s.path(e.Id, ".json"), data)
+}
+
+func (s *Spool) remove(id string) {
+	_ = os.Remove(s.path(id, ".json"))
+	_ = os.Remove(s.path(id, ".eml"))
+}
+
+// writeFileAtomic writes data to path through a synced temporary file.
+func writeFileAtomic(path string, data []byte) error {
+	tmp := path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(data); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		_ = f.Close()
+		_ = os.Remove(tmp)
+		return err
+	}
+	if err := f.Close(); err != nil {
+		_ = os.Remove(tmp)
+		return err
+	}
+	return os.Rename(tmp, path)
+}
@@ -0,0 +1,130 @@
+package smtp
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+// newTestSpool returns a spool on a temporary directory with a fake clock.
+func newTestSpool(t *testing.T, dir string, now *time.Time) *Spool {
+	t.Helper()
+	s, err := NewSpool(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	s.now = func() time.Time { return *now }
+	return s
+}
+
+func TestSpoolRetryAndBounce(t *testing.T) {
+	dir := t.TempDir()
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestSpool(t, dir, &now)
+	s.MaxAge = 24 * time.Hour
+
+	attempts := 0
+	s.Deliver = func(from, to string, msg []byte) error {
+		attempts++
+		return errors.New("connection refused")
+	}
+	var bounced *SpoolEntry
+	s.Bounce = func(e *SpoolEntry, msg []byte) { bounced = e }
+
+	if _, err := s.Enqueue("alice@example.com", "bob@example.org", []byte("Subject: hi\r\n\r\nbody\r\n")); err != nil {
+		t.Fatal(err)
+	}
+	next := s.processDue()
+	if attempts != 1 || !next.Equal(now.Add(5*time.Minute)) {
+		t.Fatalf("first attempt: attempts=%d next=%v", attempts, next)
+	}
+
+	// Nothing is due before the backoff expires.
+	now = now.Add(4 * time.Minute)
+	s.processDue()
+	if attempts != 1 {
+		t.Fatalf("retried early: %d", attempts)
+	}
+
+	// The queue survives a restart and the delay doubles.
+	now = now.Add(time.Minute)
+	s = newTestSpool(t, dir, &now)
+	s.MaxAge = 24 * time.Hour
+	s.Deliver = func(from, to string, msg []byte) error { attempts++; return errors.New("timeout") }
+	s.Bounce = func(e *SpoolEntry, msg []byte) { bounced = e }
+	if next = s.processDue(); attempts != 2 || !next.Equal(now.Add(10*time.Minute)) {
+		t.Fatalf("second attempt: attempts=%d next=%v", attempts, next)
+	}
+
+	// Past MaxAge the message bounces and leaves the spool.
+	now = now.Add(25 * time.Hour)
+	s.processDue()
+	if bounced == nil || bounced.Attempts != 3 || bounced.Permanent || bounced.LastError != "timeout" {
+		t.Fatalf("bounced = %+v", bounced)
+	}
+	if entries, _ := s.Entries(); len(entries) != 0 {
+		t.Errorf("entries left: %d", len(entries))
+	}
+	if retryDelay(20) != 4*time.Hour {
+		t.Errorf("retryDelay cap = %v", retryDelay(20))
+	}
+}
+
+func TestSpoolPermanentFailure(t *testing.T) {
+	now := time.Now()
+	s := newTestSpool(t, t.TempDir(), &now)
+	s.Deliver = func(from, to string, msg []byte) error {
+		return &PermanentError{Err: errors.New("550 no such user")}
+	}
+	var bounced []byte
+	s.Bounce = func(e *SpoolEntry, msg []byte) {
+		bounced = bounceMessage("example.com", e, msg, now)
+	}
+	_, _ = s.Enqueue("alice@example.com", "nobody@example.org", []byte("From: alice@example.com\r\nSubject: lost\r\n\r\nsecret body\r\n"))
+	s.processDue()
+
+	dsn := string(bounced)
+	for _, want := range []string{
+		"To: <alice@example.com>",
+		"report-type=delivery-status",
+		"Final-Recipient: rfc822; nobody@example.org",
+		"Status: 5.0.0",
+		"Diagnostic-Code: smtp; 550 no such user",
+		"Subject: lost",
+	} {
+		if !strings.Contains(dsn, want) {
+			t.Errorf("bounce lacks %q", want)
+		}
+	}
+	if strings.Contains(dsn, "secret body") {
+		t.Error("bounce returns the original body")
+	}
+}
+
+func TestSpoolRateLimit(t *testing.T) {
+	now := time.Now()
+	s := newTestSpool(t, t.TempDir(), &now)
+	s.RateLimit = 2
+	delivered := map[string]int{}
+	s.Deliver = func(from, to string, msg []byte) error {
+		_, domain, _ := splitAddress(to)
+		delivered[domain]++
+		return nil
+	}
+	for _, to := range []string{"a@big.example", "b@big.example", "c@big.example", "d@small.example"} {
+		_, _ = s.Enqueue("alice@example.com", to, []byte("x"))
+	}
+
+	next := s.processDue()
+	if delivered["big.example"] != 2 || delivered["small.example"] != 1 || next.IsZero() {
+		t.Fatalf("first pass: %v next=%v", delivered, next)
+	}
+	now = now.Add(time.Minute)
+	if s.processDue(); delivered["big.example"] != 3 {
+		t.Errorf("after a minute: %v", delivered)
+	}
+	if entries, _ := s.Entries(); len(entries) != 0 {
+		t.Errorf("entries left: %d", len(entries))
+	}
+}
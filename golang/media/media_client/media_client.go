@@ -2,6 +2,7 @@ package media_client
 
 import (
 	"context"
+	"io"
 	"time"
 
 	globular "github.com/globulario/services/golang/globular_client"
@@ -261,4 +262,49 @@ func (client *Media_Client) CreateVttFile(path string, fps float32) error {
 	rqst := mediapb.CreateVttFileRequest{Path: path, Fps: fps}
 	_, err := client.c.CreateVttFile(client.GetCtx(), &rqst)
 	return err
-}
\ No newline at end of file
+}
+
+// List the media jobs in the given states (all when empty) under path.
+func (client *Media_Client) ListMediaJobs(path string, states ...mediapb.MediaJobState) ([]*mediapb.MediaJob, error) {
+	rsp, err := client.c.ListMediaJobs(client.GetCtx(), &mediapb.ListMediaJobsRequest{Path: path, States: states})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Jobs, nil
+}
+
+// Watch a media job; fn receives each update until the job ends.
+func (client *Media_Client) WatchMediaJob(id string, fn func(*mediapb.MediaJob)) error {
+	stream, err := client.c.WatchMediaJob(client.GetCtx(), &mediapb.WatchMediaJobRequest{Id: id})
+	if err != nil {
+		return err
+	}
+	for {
+		rsp, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		fn(rsp.Job)
+	}
+}
+
+// Cancel a queued or running media job.
+func (client *Media_Client) CancelMediaJob(id string) (*mediapb.MediaJob, error) {
+	rsp, err := client.c.CancelMediaJob(client.GetCtx(), &mediapb.CancelMediaJobRequest{Id: id})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Job, nil
+}
+
+// Change the priority of a queued or running media job.
+func (client *Media_Client) ReprioritizeMediaJob(id string, priority int32) (*mediapb.MediaJob, error) {
+	rsp, err := client.c.ReprioritizeMediaJob(client.GetCtx(), &mediapb.ReprioritizeMediaJobRequest{Id: id, Priority: priority})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Job, nil
+}
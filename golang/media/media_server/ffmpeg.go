@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"bytes"
 	"context"
 	"encoding/json"
@@ -21,18 +22,77 @@ import (
 	Utility "github.com/globulario/utility"
 )
 
-func (srv *server) runFfmpeg(workdir string, args []string) error {
+// runFfmpeg runs ffmpeg in workdir, within the MAX_FFMPEG_INSTANCE limit.
+// ffmpeg is killed when ctx is canceled, and its progress is reported to the
+// job running it, if any (see withJobProgress).
+func (srv *server) runFfmpeg(ctx context.Context, workdir string, args []string) error {
 	acquired := false
 	if srv != nil && srv.ffmpegTokens != nil {
-		srv.ffmpegTokens <- struct{}{}
-		acquired = true
+		select {
+		case srv.ffmpegTokens <- struct{}{}:
+			acquired = true
+		case <-ctx.Done():
+			return ctx.Err()
+		}
 	}
 	if acquired {
 		defer func() { <-srv.ffmpegTokens }()
 	}
-	wait := make(chan error, 1)
-	go Utility.RunCmd("ffmpeg", workdir, args, wait)
-	return <-wait
+	progress := jobProgressFrom(ctx)
+	if progress == nil && ctx.Done() == nil {
+		wait := make(chan error, 1)
+		go Utility.RunCmd("ffmpeg", workdir, args, wait)
+		return <-wait
+	}
+
+	// Total duration of the first input, to turn out_time into a percentage.
+	var total float64
+	if progress != nil {
+		for i := 0; i+1 < len(args); i++ {
+			if args[i] == "-i" {
+				input := args[i+1]
+				if !filepath.IsAbs(input) {
+					input = filepath.Join(workdir, input)
+				}
+				if d, err := probeVideoDuration(input); err == nil {
+					total = float64(d)
+				}
+				break
+			}
+		}
+	}
+
+	cmd := exec.CommandContext(ctx, "ffmpeg", append([]string{"-progress", "pipe:1", "-nostats"}, args...)...)
+	cmd.Dir = workdir
+	var stderr bytes.Buffer
+	cmd.Stderr = &stderr
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("ffmpeg %s: %w", strings.Join(args, " "), err)
+	}
+	sc := bufio.NewScanner(stdout)
+	for sc.Scan() {
+		key, value, ok := strings.Cut(sc.Text(), "=")
+		if !ok || progress == nil || total <= 0 {
+			continue
+		}
+		// out_time_us (out_time_ms is in microseconds too).
+		if key == "out_time_us" || key == "out_time_ms" {
+			if us, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64); err == nil && us > 0 {
+				progress(min(float64(us)/1e6/total*100, 100))
+			}
+		}
+	}
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		return fmt.Errorf("ffmpeg %s </br> %v: %s", strings.Join(args, " "), err, strings.TrimSpace(stderr.String()))
+	}
+	return nil
 }
 
 func probeVideoDuration(local string) (int, error) {
@@ -81,7 +141,7 @@ func (srv *server) getVideoDuration(path string) int {
 // immediately when streaming over HTTP (progressive download).
 // It does a fast remux with `-c copy`, so it is cheap compared to a full re-encode.
 // You can also call this on MP4s downloaded via yt-dlp (e.g. in uploadedVideo).
-func (srv *server) ensureFastStartMP4(path string) error {
+func (srv *server) ensureFastStartMP4(ctx context.Context, path string) error {
 	ext := strings.ToLower(filepath.Ext(path))
 	if ext != ".mp4" {
 		return nil
@@ -130,7 +190,7 @@ func (srv *server) ensureFastStartMP4(path string) error {
 			filepath.Base(tmpPath),
 		}
 
-		if err := srv.runFfmpeg(dir, args); err != nil {
+		if err := srv.runFfmpeg(ctx, dir, args); err != nil {
 			return err
 		}
 
@@ -147,7 +207,6 @@ func (srv *server) ensureFastStartMP4(path string) error {
 		}
 
 		if wf.IsMinio {
-			ctx := context.Background()
 			if err := srv.minioUploadFile(ctx, wf.LogicalPath, localPath, "video/mp4"); err != nil {
 				return err
 			}
@@ -217,29 +276,6 @@ func rewriteInPlace(dst, src string) error {
 	return out.Sync()
 }
 
-func (srv *server) getStartTime() time.Time {
-	values := strings.Split(srv.StartVideoConversionHour, ":")
-	now := time.Now()
-	if len(values) == 2 {
-		return time.Date(now.Year(), now.Month(), now.Day(), Utility.ToInt(values[0]), Utility.ToInt(values[1]), 0, 0, now.Location())
-	}
-	return now
-}
-
-func (srv *server) isExpired() bool {
-	values := strings.Split(srv.MaximumVideoConversionDelay, ":")
-	if len(values) != 2 {
-		return false
-	}
-	delay := time.Duration(Utility.ToInt(values[0]))*time.Hour + time.Duration(Utility.ToInt(values[1]))*time.Minute
-	if delay == 0 {
-		return false
-	}
-	start := srv.getStartTime()
-	end := start.Add(delay)
-	return time.Now().After(end)
-}
-
 // getStreamFrameRateInterval returns FPS as an integer derived from r_frame_rate.
 func (srv *server) getStreamFrameRateInterval(path string) (int, error) {
 	fps := -1
@@ -312,7 +348,7 @@ func (srv *server) getTrackInfos(path, streamType string) []interface{} {
 //   - MP4: short, silent H.264 clip using either NVENC or libx264.
 //
 // It will skip work if outputs already exist unless `force` is true.
-func (srv *server) generateVideoPreview(path string, fps, scale, duration int, force bool) error {
+func (srv *server) generateVideoPreview(ctx context.Context, path string, fps, scale, duration int, force bool) error {
 
 	logicalPath := filepath.ToSlash(path)
 
@@ -408,7 +444,7 @@ func (srv *server) generateVideoPreview(path string, fps, scale, duration int, f
 				"preview.gif",
 			}
 			logger.Info("ffmpeg: generate GIF preview", "src", inputLogical, "out", gifLogical, "fps", fps, "scale", scale, "t", duration)
-			if err := srv.runFfmpeg(localOutDir, gifArgs); err != nil {
+			if err := srv.runFfmpeg(ctx, localOutDir, gifArgs); err != nil {
 				_ = os.Remove(gifOut)
 				return fmt.Errorf("generateVideoPreview: GIF generation failed for %q: %w", inputLogical, err)
 			}
@@ -436,7 +472,7 @@ func (srv *server) generateVideoPreview(path string, fps, scale, duration int, f
 			}
 
 			logger.Info("ffmpeg: generate MP4 preview", "src", inputLogical, "out", mp4Logical, "venc", venc, "scale", scale, "t", duration)
-			if err := srv.runFfmpeg(localOutDir, mp4Args); err != nil {
+			if err := srv.runFfmpeg(ctx, localOutDir, mp4Args); err != nil {
 				logger.Warn("ffmpeg: MP4 preview failed; retrying with libx264 if applicable", "src", inputLogical, "err", err)
 				if srv.hasEnableCudaNvcc() {
 					mp4ArgsRetry := append([]string(nil), mp4Args...)
@@ -446,7 +482,7 @@ func (srv *server) generateVideoPreview(path string, fps, scale, duration int, f
 							break
 						}
 					}
-					if err2 := srv.runFfmpeg(localOutDir, mp4ArgsRetry); err2 != nil {
+					if err2 := srv.runFfmpeg(ctx, localOutDir, mp4ArgsRetry); err2 != nil {
 						return fmt.Errorf("generateVideoPreview: MP4 generation failed for %q: %w", inputLogical, err2)
 					}
 				} else {
@@ -456,7 +492,6 @@ func (srv *server) generateVideoPreview(path string, fps, scale, duration int, f
 		}
 
 		if wf.IsMinio {
-			ctx := context.Background()
 			if err := srv.minioUploadDir(ctx, logicalOutDir, localOutDir); err != nil {
 				return fmt.Errorf("generateVideoPreview: upload to minio failed: %w", err)
 			}
@@ -475,7 +510,7 @@ func (srv *server) generateVideoPreview(path string, fps, scale, duration int, f
 //   - force: if true, regenerates timeline even if it already exists
 //
 // Returns an error if the input is invalid or the ffmpeg step fails.
-func (srv *server) createVideoTimeLine(path string, width int, fps float32, force bool) error {
+func (srv *server) createVideoTimeLine(ctx context.Context, path string, width int, fps float32, force bool) error {
 	logicalOrig := filepath.ToSlash(path)
 
 	fmt.Println("------------------------> create time line ", logicalOrig)
@@ -592,7 +627,7 @@ func (srv *server) createVideoTimeLine(path string, width int, fps float32, forc
 			"duration_sec", durationSec)
 
 		runFFmpeg := func() error {
-			return srv.runFfmpeg(outDir, args)
+			return srv.runFfmpeg(ctx, outDir, args)
 		}
 
 		if err := runFFmpeg(); err != nil {
@@ -614,7 +649,6 @@ func (srv *server) createVideoTimeLine(path string, width int, fps float32, forc
 		}
 
 		if wf.IsMinio {
-			ctx := context.Background()
 			if err := srv.minioUploadDir(ctx, logicalOutput, outDir); err != nil {
 				return fmt.Errorf("createVideoTimeLine: upload failed for %q: %w", logicalOutput, err)
 			}
@@ -683,7 +717,7 @@ func (srv *server) previewHasImages(logicalDir string) (bool, error) {
 //     the previous behavior: scale=<height>:-1.
 //
 // The `nb` parameter is currently unused (kept for API compatibility).
-func (srv *server) createVideoPreview(path string, nb, height int, force bool) error {
+func (srv *server) createVideoPreview(ctx context.Context, path string, nb, height int, force bool) error {
 	logicalPath := filepath.ToSlash(path)
 	if strings.Contains(logicalPath, ".hidden") || strings.Contains(logicalPath, ".temp") {
 		return nil
@@ -765,7 +799,7 @@ func (srv *server) createVideoPreview(path string, nb, height int, force bool) e
 				"-vf", "scale=" + Utility.ToString(height) + ":-1,fps=.250",
 				"preview_%05d.jpg",
 			}
-			if err := srv.runFfmpeg(outDir, args); err == nil {
+			if err := srv.runFfmpeg(ctx, outDir, args); err == nil {
 				runErr = nil
 				break
 			} else {
@@ -778,7 +812,6 @@ func (srv *server) createVideoPreview(path string, nb, height int, force bool) e
 		}
 
 		if wf.IsMinio {
-			ctx := context.Background()
 			if err := srv.minioUploadDir(ctx, logicalOutDir, outDir); err != nil {
 				return err
 			}
@@ -796,9 +829,8 @@ func (srv *server) createVideoPreview(path string, nb, height int, force bool) e
 
 // createVideoMpeg4H264 converts any input to MP4/H.264, mapping audio/subtitle tracks.
 // (Public method signature preserved.)
-func (srv *server) createVideoMpeg4H264(path string) (string, error) {
+func (srv *server) createVideoMpeg4H264(ctx context.Context, path string) (string, error) {
 	cache.RemoveItem(path)
-	_ = srv.extractSubtitleTracks(path)
 
 	path = filepath.ToSlash(path)
 	base := filepath.Base(path)
@@ -887,7 +919,7 @@ func (srv *server) createVideoMpeg4H264(path string) (string, error) {
 	}
 	args = append(args, "-movflags", "+faststart", out)
 
-	if err := srv.runFfmpeg(filepath.Dir(path), args); err != nil {
+	if err := srv.runFfmpeg(ctx, filepath.Dir(path), args); err != nil {
 		return "", err
 	}
 	_ = os.Remove(path)
@@ -931,10 +963,18 @@ func (srv *server) hasEnableCudaNvcc() bool {
 
 // createHlsStream builds VOD HLS renditions and a master playlist from an input video.
 //
+// The video is encoded once per rung of the ladder (HlsLadder, or 1080/720/480/360p)
+// that fits the source, with keyframes aligned on segment boundaries so players can
+// switch between them. Each audio track is a rendition of the "audio" group and the
+// VTT files found in <dest>/subs are renditions of the "subs" group. With HlsDash the
+// segments are CMAF (fMP4) and a DASH manifest is written over the same files.
+//
+// An H.264/AAC source that fits a single rung is only segmented, as before.
+//
 // segment_target_duration: target segment length in seconds (EXT-X-TARGETDURATION)
 // max_bitrate_ratio:       peak bitrate multiplier for -maxrate (e.g., 1.07)
 // rate_monitor_buffer_ratio: buffer size multiplier for -bufsize (e.g., 1.5)
-func (srv *server) createHlsStream(src, dest string, segmentTarget int, maxBitrateRatio, rateMonitorBufferRatio float32) error {
+func (srv *server) createHlsStream(ctx context.Context, src, dest string, segmentTarget int, maxBitrateRatio, rateMonitorBufferRatio float32) error {
 	src = filepath.ToSlash(src)
 	dest = filepath.ToSlash(dest)
 
@@ -950,8 +990,9 @@ func (srv *server) createHlsStream(src, dest string, segmentTarget int, maxBitra
 		return err
 	}
 
-	var vCodec string
-	audioCodecs := make(map[string]struct{})
+	var vCodec, encodingLong string
+	var audioStreams []map[string]interface{}
+	allAac := true // no audio or only AAC
 
 	if streams, ok := streamInfos["streams"].([]interface{}); ok {
 		for _, s := range streams {
@@ -962,51 +1003,65 @@ func (srv *server) createHlsStream(src, dest string, segmentTarget int, maxBitra
 			ct, _ := sm["codec_type"].(string)
 			switch ct {
 			case "video":
+				if afr, _ := sm["avg_frame_rate"].(string); afr == "0/0" {
+					continue
+				}
+				if cn, _ := sm["codec_name"].(string); cn == "png" {
+					continue
+				}
 				if vCodec == "" {
 					vCodec, _ = sm["codec_name"].(string)
+					encodingLong, _ = sm["codec_long_name"].(string)
 				}
 			case "audio":
-				if cn, _ := sm["codec_name"].(string); cn != "" {
-					audioCodecs[strings.ToLower(cn)] = struct{}{}
+				audioStreams = append(audioStreams, sm)
+				if cn, _ := sm["codec_name"].(string); strings.ToLower(cn) != "aac" {
+					allAac = false
 				}
 			}
 		}
 	}
-
-	// --- FAST PATH: already H.264 + AAC => segment only --------------------
-	allAac := len(audioCodecs) == 0 // no audio or only AAC
-	for c := range audioCodecs {
-		if c != "aac" {
-			allAac = false
-			break
-		}
+	if encodingLong == "" {
+		return errors.New("no usable video stream found")
 	}
 
-	if strings.ToLower(vCodec) == "h264" && allAac {
+	width, height := srv.getVideoResolution(src)
+	variants := hlsLadder(srv.HlsLadder, width, height, maxBitrateRatio)
+	subs := hlsSubtitles(dest)
+	fmp4 := srv.HlsDash
+
+	// --- FAST PATH: already H.264 + AAC and a single rung => segment only ---
+	if strings.ToLower(vCodec) == "h264" && allAac && len(variants) == 1 && !fmp4 {
 		logger.Info("createHlsStream: using fast copy HLS path",
-			"src", src, "dest", dest, "vcodec", vCodec, "audioCodecs", audioCodecs)
+			"src", src, "dest", dest, "vcodec", vCodec)
 
+		v := variants[0]
+		v.Name, v.Width, v.Height, v.Level = "source", width, height, ""
+		if format, ok := streamInfos["format"].(map[string]interface{}); ok {
+			if kbps := Utility.ToInt(format["bit_rate"]) / 1000; kbps > 0 {
+				v.Bitrate, v.MaxRate = kbps, int(float32(kbps)*maxBitrateRatio)
+			}
+		}
 		args := []string{
 			"-hide_banner", "-y",
 			"-i", src,
+			"-map", "0:v:0", "-map", "0:a?",
 			"-codec:v", "copy",
 			"-codec:a", "copy",
 			"-start_number", "0",
 			"-hls_time", Utility.ToString(segmentTarget),
 			"-hls_playlist_type", "vod",
-			"-hls_segment_filename",
-			filepath.ToSlash(filepath.Join(dest, "segment_%04d.ts")),
-			filepath.ToSlash(filepath.Join(dest, "playlist.m3u8")),
+			"-hls_segment_filename", "source_%04d.ts",
+			"source.m3u8",
 		}
-
-		if runErr := srv.runFfmpeg(filepath.Dir(src), args); runErr != nil {
+		if runErr := srv.runFfmpeg(ctx, dest, args); runErr != nil {
 			logger.Error("createHlsStream: fast copy HLS failed", "src", src, "dest", dest, "err", runErr)
 			return runErr
 		}
-		return nil
+		return srv.writeHlsPlaylists(src, dest, []hlsVariant{v}, nil, subs, false, segmentTarget)
 	}
 
-	// --- SLOW PATH: full re-encode with ladder (your current logic) -------
+	// --- SLOW PATH: encode the ladder --------------------------------------
 	keyint, err := srv.getStreamFrameRateInterval(src)
 	if err != nil || keyint <= 0 {
 		if err != nil {
@@ -1015,140 +1070,160 @@ func (srv *server) createHlsStream(src, dest string, segmentTarget int, maxBitra
 		keyint = 25
 	}
 
-	encodingLong := ""
-	if streams, ok := streamInfos["streams"].([]interface{}); ok {
-		for _, s := range streams {
-			sm, _ := s.(map[string]interface{})
-			if sm == nil {
-				continue
-			}
-			if ct, _ := sm["codec_type"].(string); ct != "video" {
-				continue
-			}
-			if afr, _ := sm["avg_frame_rate"].(string); afr == "0/0" {
-				continue
-			}
-			if cn, _ := sm["codec_name"].(string); cn == "png" {
-				continue
-			}
-			if cl, _ := sm["codec_long_name"].(string); cl != "" {
-				encodingLong = cl
-				break
-			}
-		}
-	}
-	if encodingLong == "" {
-		return errors.New("no usable video stream found")
-	}
-
-	args := []string{"-hide_banner", "-y", "-i", src, "-c:v"}
+	var encoder []string
+	gpu := srv.hasEnableCudaNvcc()
 	switch {
-	case srv.hasEnableCudaNvcc():
+	case gpu:
 		if strings.Contains(encodingLong, "H.264") || strings.Contains(encodingLong, "MPEG-4 part 2") ||
 			strings.Contains(encodingLong, "Alliance for Open Media AV1") {
-			args = append(args, "h264_nvenc")
+			encoder = []string{"-c:v", "h264_nvenc"}
 		} else if strings.Contains(encodingLong, "H.265") || strings.Contains(encodingLong, "Motion JPEG") {
-			args = append(args, "h264_nvenc", "-pix_fmt", "yuv420p")
+			encoder = []string{"-c:v", "h264_nvenc", "-pix_fmt", "yuv420p"}
 		} else {
 			return fmt.Errorf("no GPU encoder mapping for source codec %q", encodingLong)
 		}
+		encoder = append(encoder, "-preset", "p4", "-forced-idr", "1")
 	default:
 		if strings.Contains(encodingLong, "H.264") || strings.Contains(encodingLong, "MPEG-4 part 2") {
-			args = append(args, "libx264")
+			encoder = []string{"-c:v", "libx264"}
 		} else if strings.Contains(encodingLong, "H.265") || strings.Contains(encodingLong, "Motion JPEG") || strings.Contains(encodingLong, "Alliance for Open Media AV1") {
-			args = append(args, "libx264", "-pix_fmt", "yuv420p")
+			encoder = []string{"-c:v", "libx264", "-pix_fmt", "yuv420p"}
 		} else {
 			return fmt.Errorf("no CPU encoder mapping for source codec %q", encodingLong)
 		}
+		encoder = append(encoder, "-sc_threshold", "0")
 	}
 
-	// Decide ladder from input width (same as you already do).
-	w, _ := srv.getVideoResolution(src)
-	renditions := make([]map[string]string, 0, 6)
-	if w >= 426 {
-		renditions = append(renditions, map[string]string{"res": "426x240", "vbit": "800k", "abit": "96k"})
-	}
-	if w >= 640 {
-		renditions = append(renditions, map[string]string{"res": "640x360", "vbit": "1400k", "abit": "128k"})
-	}
-	if w >= 842 {
-		renditions = append(renditions, map[string]string{"res": "842x480", "vbit": "1700k", "abit": "128k"})
-	}
-	if w >= 1280 {
-		renditions = append(renditions, map[string]string{"res": "1280x720", "vbit": "2800k", "abit": "128k"})
+	// HLS muxer options shared by every output.
+	hlsOpts := func(name string) []string {
+		opts := []string{
+			"-f", "hls",
+			"-start_number", "0",
+			"-hls_time", Utility.ToString(segmentTarget),
+			"-hls_playlist_type", "vod",
+			"-hls_flags", "independent_segments",
+		}
+		if fmp4 {
+			opts = append(opts,
+				"-hls_segment_type", "fmp4",
+				"-hls_fmp4_init_filename", name+"_init.mp4",
+				"-hls_segment_filename", name+"_%04d.m4s",
+			)
+		} else {
+			opts = append(opts, "-hls_segment_filename", name+"_%04d.ts")
+		}
+		return append(opts, name+".m3u8")
 	}
-	if w >= 1920 {
-		renditions = append(renditions, map[string]string{"res": "1920x1080", "vbit": "5000k", "abit": "192k"})
+
+	// One decode, split and scaled per rung.
+	var filter strings.Builder
+	fmt.Fprintf(&filter, "[0:v:0]split=%d", len(variants))
+	for i := range variants {
+		fmt.Fprintf(&filter, "[v%d]", i)
 	}
-	if len(renditions) == 0 {
-		renditions = append(renditions, map[string]string{"res": "426x240", "vbit": "800k", "abit": "96k"})
+	for i, v := range variants {
+		fmt.Fprintf(&filter, ";[v%d]scale=%d:%d[v%dout]", i, v.Width, v.Height, i)
 	}
 
-	staticParams := []string{
-		"-profile:v", "main",
-		"-sc_threshold", "0",
-		"-g", Utility.ToString(keyint),
-		"-keyint_min", Utility.ToString(keyint),
-		"-hls_time", Utility.ToString(segmentTarget),
-		"-hls_playlist_type", "vod",
+	args := []string{"-hide_banner", "-y", "-i", src, "-filter_complex", filter.String()}
+	for i, v := range variants {
+		args = append(args, "-map", fmt.Sprintf("[v%dout]", i), "-an", "-sn")
+		args = append(args, encoder...)
+		args = append(args,
+			"-profile:v", "main",
+			"-level:v", v.Level,
+			"-g", Utility.ToString(keyint),
+			"-keyint_min", Utility.ToString(keyint),
+			"-force_key_frames", fmt.Sprintf("expr:gte(t,n_forced*%d)", segmentTarget),
+			"-b:v", fmt.Sprintf("%dk", v.Bitrate),
+			"-maxrate", fmt.Sprintf("%dk", v.MaxRate),
+			"-bufsize", fmt.Sprintf("%dk", int(float32(v.Bitrate)*rateMonitorBufferRatio)),
+		)
+		args = append(args, hlsOpts(v.Name)...)
 	}
 
-	var master strings.Builder
-	master.WriteString("#EXTM3U\n#EXT-X-VERSION:3\n")
-
-	for _, r := range renditions {
-		res := r["res"]
-		vbit := r["vbit"]
-		abit := r["abit"]
-
-		parts := strings.Split(res, "x")
-		if len(parts) != 2 {
-			logger.Warn("createHlsStream: invalid resolution rung, skipping", "res", res)
-			continue
+	audioKbps := srv.HlsAudioBitrate
+	if audioKbps <= 0 {
+		audioKbps = defaultHlsAudioBitrate
+	}
+	audio := make([]hlsAudio, 0, len(audioStreams))
+	for i, sm := range audioStreams {
+		tags, _ := sm["tags"].(map[string]interface{})
+		lang := hlsLanguage(tags)
+		name, _ := tags["title"].(string)
+		if name = strings.TrimSpace(strings.ReplaceAll(name, `"`, "'")); name == "" {
+			name = lang
 		}
-		width := parts[0]
-		height := parts[1]
-		name := height + "p"
-
-		numStr := strings.TrimSuffix(vbit, "k")
-		vk := Utility.ToInt(numStr)
-		maxrate := int(float32(vk) * maxBitrateRatio)
-		bufsize := int(float32(vk) * rateMonitorBufferRatio)
-
-		scale := fmt.Sprintf("scale=-2:min(%s\\,if(mod(ih\\,2)\\,ih-1\\,ih))", width)
+		if name == "" {
+			name = fmt.Sprintf("Track %d", i+1)
+		}
+		a := hlsAudio{
+			Name:     name,
+			Language: lang,
+			URI:      fmt.Sprintf("audio_%d.m3u8", i),
+			Bitrate:  audioKbps,
+			Default:  i == 0,
+		}
+		audio = append(audio, a)
 
-		args = append(args, staticParams...)
 		args = append(args,
-			"-vf", scale,
-			"-map", "0:v:0",
-			"-map", "0:a:0?", "-c:a:0", "aac",
-			"-b:v", vbit,
-			"-maxrate", fmt.Sprintf("%dk", maxrate),
-			"-bufsize", fmt.Sprintf("%dk", bufsize),
-			"-b:a", abit,
-			"-hls_segment_filename", filepath.ToSlash(filepath.Join(dest, name+"_%04d.ts")),
-			filepath.ToSlash(filepath.Join(dest, name+".m3u8")),
+			"-map", fmt.Sprintf("0:a:%d", i), "-vn", "-sn",
+			"-c:a", "aac", "-ac", "2", "-ar", "48000",
+			"-b:a", fmt.Sprintf("%dk", audioKbps),
 		)
-
-		master.WriteString(fmt.Sprintf("#EXT-X-STREAM-INF:BANDWIDTH=%d,RESOLUTION=%s\n%s\n", vk*1000, res, name+".m3u8"))
+		args = append(args, hlsOpts(strings.TrimSuffix(a.URI, ".m3u8"))...)
 	}
 
 	logger.Info("ffmpeg: create HLS",
 		"src", src, "dest", dest,
 		"keyint", keyint,
 		"segment", segmentTarget,
-		"rungs", len(renditions),
+		"rungs", len(variants),
+		"audio", len(audio),
+		"subtitles", len(subs),
+		"gpu", gpu,
+		"dash", fmp4,
 	)
 
-	if runErr := srv.runFfmpeg(filepath.Dir(src), args); runErr != nil {
+	if runErr := srv.runFfmpeg(ctx, dest, args); runErr != nil {
 		logger.Error("createHlsStream: ffmpeg failed", "src", src, "dest", dest, "err", runErr)
 		return runErr
 	}
+	return srv.writeHlsPlaylists(src, dest, variants, audio, subs, fmp4, segmentTarget)
+}
 
-	if err := os.WriteFile(filepath.Join(dest, "playlist.m3u8"), []byte(master.String()), 0644); err != nil {
+// writeHlsPlaylists writes the subtitle playlists, the master playlist and,
+// for CMAF segments, the DASH manifest of a stream folder.
+func (srv *server) writeHlsPlaylists(src, dest string, variants []hlsVariant, audio []hlsAudio, subs []hlsSubtitle, fmp4 bool, segmentTarget int) error {
+	if len(subs) > 0 {
+		duration, err := probeVideoDuration(src)
+		if err != nil {
+			logger.Warn("createHlsStream: duration probe failed, skipping subtitles", "src", src, "err", err)
+			subs = nil
+		}
+		for _, s := range subs {
+			if err := os.WriteFile(filepath.Join(dest, s.URI), []byte(hlsSubtitlePlaylist(s.VTT, float64(duration))), 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	master := hlsMasterPlaylist(variants, audio, subs, fmp4)
+	if err := os.WriteFile(filepath.Join(dest, "playlist.m3u8"), []byte(master), 0644); err != nil {
 		logger.Error("createHlsStream: write master playlist failed", "dest", dest, "err", err)
 		return err
 	}
+
+	if fmp4 {
+		mpd, err := dashManifest(dest, variants, audio, subs, segmentTarget)
+		if err != nil {
+			logger.Error("createHlsStream: DASH manifest failed", "dest", dest, "err", err)
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dest, dashManifestName), []byte(mpd), 0644); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
@@ -1159,7 +1234,7 @@ func (srv *server) createHlsStream(src, dest string, segmentTarget int, maxBitra
 //   - Uses a temp workdir and moves the finished folder into place atomically.
 //   - Keeps the public signature unchanged.
 //   - Structured logging replaces fmt prints.
-func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
+func (srv *server) createHlsStreamFromMpeg4H264(ctx context.Context, path string) error {
 	// Evict any cached entry for the input file.
 	cache.RemoveItem(path)
 
@@ -1171,7 +1246,6 @@ func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
 
 	src := localSrc
 	if isMinio {
-		ctx := context.Background()
 		tmp, cleanup, err := srv.minioDownloadToTemp(ctx, logical)
 		if err != nil {
 			return fmt.Errorf("createHlsStreamFromMpeg4H264: download failed: %w", err)
@@ -1214,6 +1288,25 @@ func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
 		return err
 	}
 
+	// Subtitle tracks extracted beside the source become the subtitle
+	// renditions of the stream.
+	subDir := filepath.ToSlash(filepath.Join(filepath.Dir(logical), ".hidden", filepath.Base(logicalBase), "__subtitles__"))
+	if entries, err := srv.readDirEntries(subDir); err == nil {
+		for _, e := range entries {
+			if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".vtt") {
+				continue
+			}
+			data, err := srv.readFile(subDir + "/" + e.Name())
+			if err != nil {
+				logger.Warn("createHlsStreamFromMpeg4H264: read subtitle track failed", "file", e.Name(), "err", err)
+				continue
+			}
+			if err := Utility.CreateDirIfNotExist(filepath.Join(tmpOut, hlsSubtitleDir)); err == nil {
+				_ = os.WriteFile(filepath.Join(tmpOut, hlsSubtitleDir, e.Name()), data, 0644)
+			}
+		}
+	}
+
 	// Best-effort cleanup for temp artifacts.
 	defer func() {
 		if rmErr := os.Remove(tmpFile); rmErr != nil && !os.IsNotExist(rmErr) {
@@ -1231,7 +1324,7 @@ func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
 	}
 
 	// Build the HLS ladder in temp dir.
-	if err := srv.createHlsStream(tmpFile, tmpOut, 4, 1.07, 1.5); err != nil {
+	if err := srv.createHlsStream(ctx, tmpFile, tmpOut, 4, 1.07, 1.5); err != nil {
 		logger.Error("createHlsStreamFromMpeg4H264: HLS creation failed", "src", logical, "tmpOut", tmpOut, "err", err)
 		return err
 	}
@@ -1247,7 +1340,6 @@ func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
 			return err
 		}
 	} else {
-		ctx := context.Background()
 		if err := srv.minioUploadDir(ctx, logicalBase, tmpOut); err != nil {
 			logger.Error("createHlsStreamFromMpeg4H264: upload output to minio failed", "logical", logicalBase, "err", err)
 			return err
@@ -1283,17 +1375,22 @@ func (srv *server) createHlsStreamFromMpeg4H264(path string) error {
 	return err
 }
 
+var (
+	errNoSubtitles    = errors.New("no subtitle track found")
+	errSubtitlesExist = errors.New("subtitle tracks already exist")
+)
+
 // extractSubtitleTracks dumps all text-based subtitle streams to individual .vtt files
 // beside the input, under: <dir>/.hidden/<basename>/__subtitles__/.
 // If there are 0 subtitle tracks it returns an error, if there is exactly 1 it
 // returns nil (nothing to split), matching the original behavior.
-func (srv *server) extractSubtitleTracks(videoPath string) error {
+func (srv *server) extractSubtitleTracks(ctx context.Context, videoPath string) error {
 	videoPath = filepath.ToSlash(videoPath)
 
 	// Probe subtitle streams ("s").
 	tracks := srv.getTrackInfos(videoPath, "s")
 	if len(tracks) == 0 {
-		return fmt.Errorf("no subtitle track found for %q", videoPath)
+		return fmt.Errorf("%w for %q", errNoSubtitles, videoPath)
 	}
 	if len(tracks) == 1 {
 		// Only one language/track -> nothing to split.
@@ -1311,7 +1408,7 @@ func (srv *server) extractSubtitleTracks(videoPath string) error {
 
 	// If already extracted, don't redo work.
 	if srv.pathExists(dest) {
-		return fmt.Errorf("subtitle tracks for %q already exist at %s", base, dest)
+		return fmt.Errorf("%w for %q at %s", errSubtitlesExist, base, dest)
 	}
 	if err := Utility.CreateDirIfNotExist(dest); err != nil {
 		logger.Error("extractSubtitleTracks: mkdir failed", "dest", dest, "err", err)
@@ -1374,7 +1471,7 @@ func (srv *server) extractSubtitleTracks(videoPath string) error {
 	logger.Info("ffmpeg: extract subtitles", "src", videoPath, "dest", dest, "streams", mapped)
 
 	// Run ffmpeg in destination directory so output files land there.
-	if err := srv.runFfmpeg(dest, args); err != nil {
+	if err := srv.runFfmpeg(ctx, dest, args); err != nil {
 		logger.Error("ffmpeg: subtitle extraction failed", "src", videoPath, "dest", dest, "err", err)
 		return fmt.Errorf("subtitle extraction failed for %q: %w", videoPath, err)
 	}
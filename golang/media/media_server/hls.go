@@ -0,0 +1,390 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	Utility "github.com/globulario/utility"
+)
+
+// HlsRendition is a rung of the adaptive-bitrate ladder built by
+// createHlsStream. The width follows the source aspect ratio.
+type HlsRendition struct {
+	Height       int // Output height in pixels.
+	VideoBitrate int // Target video bitrate in kbit/s.
+}
+
+// defaultHlsLadder is used when HlsLadder is not configured.
+var defaultHlsLadder = []HlsRendition{
+	{Height: 1080, VideoBitrate: 5000},
+	{Height: 720, VideoBitrate: 2800},
+	{Height: 480, VideoBitrate: 1400},
+	{Height: 360, VideoBitrate: 800},
+}
+
+const (
+	defaultHlsAudioBitrate = 128 // kbit/s, per audio rendition
+	hlsSubtitleDir         = "subs"
+	dashManifestName       = "manifest.mpd"
+)
+
+// hlsVariant is a video rendition of a stream.
+type hlsVariant struct {
+	Name          string // Playlist and segment prefix, e.g. "720p".
+	Width, Height int
+	Bitrate       int    // kbit/s
+	MaxRate       int    // kbit/s, peak
+	Level         string // H.264 level, e.g. "3.1"; empty when the video is copied.
+}
+
+// hlsAudio is an audio rendition, in the "audio" group of the master playlist.
+type hlsAudio struct {
+	Name     string
+	Language string
+	URI      string // Media playlist.
+	Bitrate  int    // kbit/s
+	Default  bool
+}
+
+// hlsSubtitle is a WebVTT track, in the "subs" group of the master playlist.
+type hlsSubtitle struct {
+	Name     string
+	Language string
+	URI      string // Media playlist wrapping the VTT file.
+	VTT      string // VTT file, relative to the stream folder.
+}
+
+// hlsLadder picks the rungs of ladder that fit a srcW x srcH source, highest
+// first. Rungs are never upscaled: a source smaller than every rung gets one
+// rendition at its own height with the bitrate of the lowest rung.
+func hlsLadder(ladder []HlsRendition, srcW, srcH int, maxBitrateRatio float32) []hlsVariant {
+	if len(ladder) == 0 {
+		ladder = defaultHlsLadder
+	}
+	rungs := append([]HlsRendition(nil), ladder...)
+	sort.Slice(rungs, func(i, j int) bool { return rungs[i].Height > rungs[j].Height })
+
+	var picked []HlsRendition
+	for _, r := range rungs {
+		if r.Height <= 0 || r.VideoBitrate <= 0 {
+			continue
+		}
+		if srcH <= 0 || r.Height <= srcH {
+			picked = append(picked, r)
+		}
+	}
+	if len(picked) == 0 {
+		lowest := rungs[len(rungs)-1]
+		picked = []HlsRendition{{Height: srcH - srcH%2, VideoBitrate: lowest.VideoBitrate}}
+	}
+
+	out := make([]hlsVariant, 0, len(picked))
+	for _, r := range picked {
+		width := int(math.Round(float64(r.Height) * 16 / 9))
+		if srcW > 0 && srcH > 0 {
+			width = int(math.Round(float64(srcW) * float64(r.Height) / float64(srcH)))
+		}
+		width -= width % 2
+		out = append(out, hlsVariant{
+			Name:    fmt.Sprintf("%dp", r.Height),
+			Width:   width,
+			Height:  r.Height,
+			Bitrate: r.VideoBitrate,
+			MaxRate: int(float32(r.VideoBitrate) * maxBitrateRatio),
+			Level:   h264Level(r.Height),
+		})
+	}
+	return out
+}
+
+// h264Level returns the lowest common H.264 level holding a rendition.
+func h264Level(height int) string {
+	switch {
+	case height > 1080:
+		return "5.1"
+	case height > 720:
+		return "4.0"
+	case height > 480:
+		return "3.1"
+	default:
+		return "3.0"
+	}
+}
+
+// codecs returns the RFC 6381 codecs of a Main profile rendition.
+func (v hlsVariant) codecs() string {
+	if v.Level == "" {
+		return ""
+	}
+	major, minor, _ := strings.Cut(v.Level, ".")
+	return fmt.Sprintf("avc1.4d40%02x", Utility.ToInt(major)*10+Utility.ToInt(minor))
+}
+
+// hlsLanguage returns the language tag of a stream, empty when unknown.
+func hlsLanguage(tags map[string]interface{}) string {
+	lang, _ := tags["language"].(string)
+	lang = strings.TrimSpace(lang)
+	if lang == "" || lang == "und" {
+		return ""
+	}
+	return lang
+}
+
+// hlsSubtitles lists the VTT files copied to <dest>/subs. The files are named
+// <video>.<lang>.vtt, as written by extractSubtitleTracks.
+func hlsSubtitles(dest string) []hlsSubtitle {
+	entries, err := os.ReadDir(filepath.Join(dest, hlsSubtitleDir))
+	if err != nil {
+		return nil
+	}
+	var subs []hlsSubtitle
+	for _, e := range entries {
+		if e.IsDir() || !strings.EqualFold(filepath.Ext(e.Name()), ".vtt") {
+			continue
+		}
+		stem := strings.TrimSuffix(e.Name(), filepath.Ext(e.Name()))
+		lang := ""
+		if dot := strings.LastIndex(stem, "."); dot >= 0 {
+			lang = stem[dot+1:]
+		}
+		name := lang
+		if lang == "und" {
+			lang = ""
+		}
+		if name == "" {
+			name = stem
+		}
+		subs = append(subs, hlsSubtitle{
+			Name:     name,
+			Language: lang,
+			URI:      fmt.Sprintf("subs_%d.m3u8", len(subs)),
+			VTT:      hlsSubtitleDir + "/" + e.Name(),
+		})
+	}
+	return subs
+}
+
+// hlsSubtitlePlaylist wraps a whole VTT file in a one-segment media playlist.
+func hlsSubtitlePlaylist(vtt string, duration float64) string {
+	target := int(math.Ceil(duration))
+	if target < 1 {
+		target = 1
+	}
+	return fmt.Sprintf("#EXTM3U\n#EXT-X-VERSION:3\n#EXT-X-TARGETDURATION:%d\n#EXT-X-MEDIA-SEQUENCE:0\n#EXT-X-PLAYLIST-TYPE:VOD\n#EXTINF:%.3f,\n%s\n#EXT-X-ENDLIST\n",
+		target, duration, vtt)
+}
+
+// hlsMasterPlaylist writes the master playlist: audio and subtitle media
+// groups, then one stream per video rendition. When audio is empty the
+// audio is muxed with the video (or there is none).
+func hlsMasterPlaylist(variants []hlsVariant, audio []hlsAudio, subs []hlsSubtitle, fmp4 bool) string {
+	var b strings.Builder
+	version := 4
+	if fmp4 {
+		version = 7
+	}
+	fmt.Fprintf(&b, "#EXTM3U\n#EXT-X-VERSION:%d\n#EXT-X-INDEPENDENT-SEGMENTS\n", version)
+
+	yesNo := func(v bool) string {
+		if v {
+			return "YES"
+		}
+		return "NO"
+	}
+	audioKbps := 0
+	for _, a := range audio {
+		fmt.Fprintf(&b, "#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID=\"audio\",NAME=%q,", a.Name)
+		if a.Language != "" {
+			fmt.Fprintf(&b, "LANGUAGE=%q,", a.Language)
+		}
+		fmt.Fprintf(&b, "DEFAULT=%s,AUTOSELECT=YES,CHANNELS=\"2\",URI=%q\n", yesNo(a.Default), a.URI)
+		audioKbps = max(audioKbps, a.Bitrate)
+	}
+	for _, s := range subs {
+		fmt.Fprintf(&b, "#EXT-X-MEDIA:TYPE=SUBTITLES,GROUP-ID=\"subs\",NAME=%q,", s.Name)
+		if s.Language != "" {
+			fmt.Fprintf(&b, "LANGUAGE=%q,", s.Language)
+		}
+		fmt.Fprintf(&b, "DEFAULT=NO,AUTOSELECT=YES,FORCED=NO,URI=%q\n", s.URI)
+	}
+
+	for _, v := range variants {
+		fmt.Fprintf(&b, "#EXT-X-STREAM-INF:BANDWIDTH=%d,AVERAGE-BANDWIDTH=%d,RESOLUTION=%dx%d",
+			(v.MaxRate+audioKbps)*1000, (v.Bitrate+audioKbps)*1000, v.Width, v.Height)
+		if c := v.codecs(); c != "" {
+			if len(audio) > 0 {
+				c += ",mp4a.40.2"
+			}
+			fmt.Fprintf(&b, ",CODECS=%q", c)
+		}
+		if len(audio) > 0 {
+			b.WriteString(",AUDIO=\"audio\"")
+		}
+		if len(subs) > 0 {
+			b.WriteString(",SUBTITLES=\"subs\"")
+		}
+		fmt.Fprintf(&b, "\n%s.m3u8\n", v.Name)
+	}
+	return b.String()
+}
+
+// hlsSegment is a segment of a media playlist.
+type hlsSegment struct {
+	URI      string
+	Duration float64 // seconds
+}
+
+// parseHlsMediaPlaylist returns the init segment (EXT-X-MAP) and the
+// segments of a media playlist.
+func parseHlsMediaPlaylist(data []byte) (string, []hlsSegment, error) {
+	var initURI string
+	var segs []hlsSegment
+	var pending *float64
+	sc := bufio.NewScanner(bytes.NewReader(data))
+	for sc.Scan() {
+		line := strings.TrimSpace(sc.Text())
+		switch {
+		case line == "":
+		case strings.HasPrefix(line, "#EXT-X-MAP:"):
+			for _, attr := range strings.Split(strings.TrimPrefix(line, "#EXT-X-MAP:"), ",") {
+				if v, ok := strings.CutPrefix(attr, "URI="); ok {
+					initURI = strings.Trim(v, `"`)
+				}
+			}
+		case strings.HasPrefix(line, "#EXTINF:"):
+			d, _, _ := strings.Cut(strings.TrimPrefix(line, "#EXTINF:"), ",")
+			f, err := strconv.ParseFloat(strings.TrimSpace(d), 64)
+			if err != nil {
+				return "", nil, fmt.Errorf("bad EXTINF %q: %w", line, err)
+			}
+			pending = &f
+		case strings.HasPrefix(line, "#"):
+		default:
+			if pending == nil {
+				return "", nil, fmt.Errorf("segment %q has no EXTINF", line)
+			}
+			segs = append(segs, hlsSegment{URI: line, Duration: *pending})
+			pending = nil
+		}
+	}
+	if len(segs) == 0 {
+		return "", nil, fmt.Errorf("no segments")
+	}
+	return initURI, segs, sc.Err()
+}
+
+// dashManifest writes a static MPEG-DASH manifest over the CMAF segments of
+// the HLS renditions in dest, so both formats share the same files.
+func dashManifest(dest string, variants []hlsVariant, audio []hlsAudio, subs []hlsSubtitle, segmentTarget int) (string, error) {
+	type representation struct {
+		id, attrs string
+		init      string
+		segs      []hlsSegment
+	}
+	load := func(playlist string) (string, []hlsSegment, error) {
+		data, err := os.ReadFile(filepath.Join(dest, playlist))
+		if err != nil {
+			return "", nil, err
+		}
+		initURI, segs, err := parseHlsMediaPlaylist(data)
+		if err != nil {
+			return "", nil, fmt.Errorf("%s: %w", playlist, err)
+		}
+		if initURI == "" {
+			return "", nil, fmt.Errorf("%s: no init segment, segments are not CMAF", playlist)
+		}
+		return initURI, segs, nil
+	}
+
+	var b strings.Builder
+	var duration float64
+	writeRep := func(r representation) {
+		fmt.Fprintf(&b, "      <Representation id=%q %s>\n", r.id, r.attrs)
+		b.WriteString("        <SegmentList timescale=\"1000\">\n")
+		fmt.Fprintf(&b, "          <Initialization sourceURL=%q/>\n", xmlEscape(r.init))
+		b.WriteString("          <SegmentTimeline>\n")
+		var t, total int64
+		for i, s := range r.segs {
+			d := int64(math.Round(s.Duration * 1000))
+			if i == 0 {
+				fmt.Fprintf(&b, "            <S t=\"%d\" d=\"%d\"/>\n", t, d)
+			} else {
+				fmt.Fprintf(&b, "            <S d=\"%d\"/>\n", d)
+			}
+			total += d
+		}
+		b.WriteString("          </SegmentTimeline>\n")
+		for _, s := range r.segs {
+			fmt.Fprintf(&b, "          <SegmentURL media=%q/>\n", xmlEscape(s.URI))
+		}
+		b.WriteString("        </SegmentList>\n      </Representation>\n")
+		duration = math.Max(duration, float64(total)/1000)
+	}
+
+	b.WriteString("    <AdaptationSet id=\"0\" contentType=\"video\" mimeType=\"video/mp4\" segmentAlignment=\"true\" startWithSAP=\"1\">\n")
+	for _, v := range variants {
+		initURI, segs, err := load(v.Name + ".m3u8")
+		if err != nil {
+			return "", err
+		}
+		writeRep(representation{
+			id:    v.Name,
+			attrs: fmt.Sprintf("codecs=%q bandwidth=\"%d\" width=\"%d\" height=\"%d\"", v.codecs(), v.Bitrate*1000, v.Width, v.Height),
+			init:  initURI,
+			segs:  segs,
+		})
+	}
+	b.WriteString("    </AdaptationSet>\n")
+
+	for i, a := range audio {
+		fmt.Fprintf(&b, "    <AdaptationSet id=\"%d\" contentType=\"audio\" mimeType=\"audio/mp4\" segmentAlignment=\"true\" startWithSAP=\"1\"", i+1)
+		if a.Language != "" {
+			fmt.Fprintf(&b, " lang=%q", xmlEscape(a.Language))
+		}
+		b.WriteString(">\n")
+		if a.Default {
+			b.WriteString("      <Role schemeIdUri=\"urn:mpeg:dash:role:2011\" value=\"main\"/>\n")
+		}
+		initURI, segs, err := load(a.URI)
+		if err != nil {
+			return "", err
+		}
+		writeRep(representation{
+			id:    strings.TrimSuffix(a.URI, ".m3u8"),
+			attrs: fmt.Sprintf("codecs=\"mp4a.40.2\" bandwidth=\"%d\" audioSamplingRate=\"48000\"", a.Bitrate*1000),
+			init:  initURI,
+			segs:  segs,
+		})
+		b.WriteString("    </AdaptationSet>\n")
+	}
+
+	for i, s := range subs {
+		fmt.Fprintf(&b, "    <AdaptationSet id=\"%d\" contentType=\"text\" mimeType=\"text/vtt\"", len(audio)+1+i)
+		if s.Language != "" {
+			fmt.Fprintf(&b, " lang=%q", xmlEscape(s.Language))
+		}
+		fmt.Fprintf(&b, ">\n      <Representation id=\"sub_%d\" bandwidth=\"256\">\n        <BaseURL>%s</BaseURL>\n      </Representation>\n    </AdaptationSet>\n", i, xmlEscape(s.VTT))
+	}
+
+	var mpd strings.Builder
+	mpd.WriteString("<?xml version=\"1.0\" encoding=\"UTF-8\"?>\n")
+	fmt.Fprintf(&mpd, "<MPD xmlns=\"urn:mpeg:dash:schema:mpd:2011\" profiles=\"urn:mpeg:dash:profile:isoff-main:2011\" type=\"static\" mediaPresentationDuration=\"PT%.3fS\" minBufferTime=\"PT%dS\">\n", duration, max(segmentTarget, 1))
+	mpd.WriteString("  <Period id=\"0\" start=\"PT0S\">\n")
+	mpd.WriteString(b.String())
+	mpd.WriteString("  </Period>\n</MPD>\n")
+	return mpd.String(), nil
+}
+
+func xmlEscape(s string) string {
+	var b strings.Builder
+	_ = xml.EscapeText(&b, []byte(s))
+	return b.String()
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestHlsLadder(t *testing.T) {
+	names := func(vs []hlsVariant) string {
+		var out []string
+		for _, v := range vs {
+			out = append(out, v.Name)
+		}
+		return strings.Join(out, ",")
+	}
+
+	v := hlsLadder(nil, 1920, 1080, 1.07)
+	if names(v) != "1080p,720p,480p,360p" {
+		t.Fatalf("1080p source = %s", names(v))
+	}
+	if v[1].Width != 1280 || v[1].MaxRate != 2996 || v[1].codecs() != "avc1.4d401f" {
+		t.Errorf("720p = %+v %s", v[1], v[1].codecs())
+	}
+	// 4:3 source: widths follow its aspect and stay even.
+	if v := hlsLadder(nil, 1440, 1080, 1); v[3].Width != 480 {
+		t.Errorf("4:3 360p width = %d", v[3].Width)
+	}
+	if v := hlsLadder(nil, 1280, 720, 1); names(v) != "720p,480p,360p" {
+		t.Errorf("720p source = %s", names(v))
+	}
+	// Smaller than every rung: one rendition at the source size.
+	if v := hlsLadder(nil, 320, 241, 1); names(v) != "240p" || v[0].Bitrate != 800 {
+		t.Errorf("small source = %+v", v)
+	}
+	custom := []HlsRendition{{Height: 360, VideoBitrate: 600}, {Height: 0}, {Height: 720, VideoBitrate: 2000}}
+	if v := hlsLadder(custom, 1920, 1080, 1); names(v) != "720p,360p" {
+		t.Errorf("custom ladder = %s", names(v))
+	}
+}
+
+func TestHlsMasterPlaylist(t *testing.T) {
+	variants := hlsLadder([]HlsRendition{{Height: 720, VideoBitrate: 2800}}, 1280, 720, 1.1)
+	audio := []hlsAudio{
+		{Name: "English", Language: "eng", URI: "audio_0.m3u8", Bitrate: 128, Default: true},
+		{Name: "Track 2", URI: "audio_1.m3u8", Bitrate: 128},
+	}
+	dir := t.TempDir()
+	_ = os.MkdirAll(filepath.Join(dir, "subs"), 0o755)
+	for _, name := range []string{"movie.fre.vtt", "movie.und.vtt", "notes.txt"} {
+		_ = os.WriteFile(filepath.Join(dir, "subs", name), []byte("WEBVTT\n"), 0o644)
+	}
+	subs := hlsSubtitles(dir)
+	if len(subs) != 2 || subs[0].Language != "fre" || subs[1].Language != "" || subs[1].Name != "und" {
+		t.Fatalf("subtitles = %+v", subs)
+	}
+
+	master := hlsMasterPlaylist(variants, audio, subs, false)
+	for _, want := range []string{
+		"#EXT-X-VERSION:4\n",
+		`#EXT-X-MEDIA:TYPE=AUDIO,GROUP-ID="audio",NAME="English",LANGUAGE="eng",DEFAULT=YES,AUTOSELECT=YES,CHANNELS="2",URI="audio_0.m3u8"`,
+		`NAME="Track 2",DEFAULT=NO`,
+		`#EXT-X-MEDIA:TYPE=SUBTITLES,GROUP-ID="subs",NAME="fre",LANGUAGE="fre",DEFAULT=NO,AUTOSELECT=YES,FORCED=NO,URI="subs_0.m3u8"`,
+		`#EXT-X-STREAM-INF:BANDWIDTH=3208000,AVERAGE-BANDWIDTH=2928000,RESOLUTION=1280x720,CODECS="avc1.4d401f,mp4a.40.2",AUDIO="audio",SUBTITLES="subs"` + "\n720p.m3u8\n",
+	} {
+		if !strings.Contains(master, want) {
+			t.Errorf("master playlist misses %q:\n%s", want, master)
+		}
+	}
+
+	// Copied video with muxed audio: no codecs, no audio group.
+	source := []hlsVariant{{Name: "source", Width: 640, Height: 360, Bitrate: 900, MaxRate: 900}}
+	if master := hlsMasterPlaylist(source, nil, nil, false); !strings.HasSuffix(master, "#EXT-X-STREAM-INF:BANDWIDTH=900000,AVERAGE-BANDWIDTH=900000,RESOLUTION=640x360\nsource.m3u8\n") {
+		t.Errorf("copy master playlist:\n%s", master)
+	}
+}
+
+func TestDashManifest(t *testing.T) {
+	dir := t.TempDir()
+	media := func(name string) string {
+		return "#EXTM3U\n#EXT-X-VERSION:7\n#EXT-X-TARGETDURATION:4\n#EXT-X-MAP:URI=\"" + name + "_init.mp4\"\n" +
+			"#EXTINF:4.000000,\n" + name + "_0000.m4s\n#EXTINF:2.500000,\n" + name + "_0001.m4s\n#EXT-X-ENDLIST\n"
+	}
+	for _, name := range []string{"720p", "audio_0"} {
+		if err := os.WriteFile(filepath.Join(dir, name+".m3u8"), []byte(media(name)), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	variants := hlsLadder([]HlsRendition{{Height: 720, VideoBitrate: 2800}}, 1280, 720, 1)
+	audio := []hlsAudio{{Name: "en", Language: "en", URI: "audio_0.m3u8", Bitrate: 128, Default: true}}
+	subs := []hlsSubtitle{{Name: "fr", Language: "fr", URI: "subs_0.m3u8", VTT: "subs/movie.fr.vtt"}}
+
+	mpd, err := dashManifest(dir, variants, audio, subs, 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		`mediaPresentationDuration="PT6.500S"`,
+		`<Representation id="720p" codecs="avc1.4d401f" bandwidth="2800000" width="1280" height="720">`,
+		`<Initialization sourceURL="720p_init.mp4"/>`,
+		`<S t="0" d="4000"/>`,
+		`<S d="2500"/>`,
+		`<SegmentURL media="720p_0001.m4s"/>`,
+		`contentType="audio" mimeType="audio/mp4" segmentAlignment="true" startWithSAP="1" lang="en">`,
+		`<Representation id="audio_0" codecs="mp4a.40.2"`,
+		`<BaseURL>subs/movie.fr.vtt</BaseURL>`,
+	} {
+		if !strings.Contains(mpd, want) {
+			t.Errorf("manifest misses %q:\n%s", want, mpd)
+		}
+	}
+
+	// Transport stream segments cannot be shared with DASH.
+	_ = os.WriteFile(filepath.Join(dir, "720p.m3u8"), []byte("#EXTM3U\n#EXTINF:4.0,\n720p_0000.ts\n"), 0o644)
+	if _, err := dashManifest(dir, variants, nil, nil, 4); err == nil {
+		t.Error("manifest over TS segments")
+	}
+}
@@ -0,0 +1,1039 @@
+package main
+
+// Media processing jobs.
+//
+// Every step a video needs (MP4 conversion, HLS stream, previews, timeline,
+// subtitle extraction) and every directory scan is a MediaJob held in a
+// durable queue. Jobs are stored one JSON file each under
+// <data>/media-jobs, so queued work survives restarts. A fixed pool of
+// workers runs them by priority, retries failed attempts with exponential
+// backoff and streams the ffmpeg progress to WatchMediaJob callers.
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/media/mediapb"
+	"github.com/globulario/services/golang/security"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+const (
+	defaultMaxMediaJobs     = 2
+	defaultMediaJobAttempts = 3
+	mediaJobRetryDelay      = time.Minute // doubled after each failed attempt
+	mediaJobMaxRetryDelay   = time.Hour
+	mediaJobRetention       = 7 * 24 * time.Hour // finished jobs are forgotten after this
+	mediaJobPollInterval    = 30 * time.Second
+
+	mediaJobPriorityScheduled = 0  // scheduled scans and the jobs they queue
+	mediaJobPriorityManual    = 5  // StartProcessVideo
+	mediaJobPriorityRequest   = 10 // explicit conversion requests
+)
+
+var (
+	errMediaJobNotFound = errors.New("media job not found")
+	errMediaJobFinished = errors.New("media job already finished")
+)
+
+// mediaJobSpec describes a job to enqueue.
+type mediaJobSpec struct {
+	Path        string
+	Operation   mediapb.MediaJobOperation
+	Priority    int32
+	Deferred    bool   // only start within the conversion window
+	After       string // id of a job that must end first
+	StreamAfter bool   // MP4: queue the HLS conversion of the result
+	Force       bool   // queue again even if the last attempt failed
+}
+
+// mediaJobQueue is the durable job queue. The zero value is not usable;
+// see openMediaJobQueue.
+type mediaJobQueue struct {
+	dir         string
+	workers     int
+	maxAttempts int32
+
+	// run executes one attempt; progress is reported through the context
+	// (see withJobProgress).
+	run func(ctx context.Context, job *mediapb.MediaJob) error
+	// windowOpen gates deferred jobs; nil means always open.
+	windowOpen func() bool
+	now        func() time.Time
+
+	mu       sync.Mutex
+	jobs     map[string]*mediapb.MediaJob
+	cancels  map[string]context.CancelFunc
+	watchers map[string][]chan *mediapb.MediaJob
+	wake     chan struct{}
+}
+
+// openMediaJobQueue loads the jobs stored in dir. Jobs that were running
+// when the process stopped are queued again.
+func openMediaJobQueue(dir string) (*mediaJobQueue, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	q := &mediaJobQueue{
+		dir:         dir,
+		workers:     defaultMaxMediaJobs,
+		maxAttempts: defaultMediaJobAttempts,
+		now:         time.Now,
+		jobs:        make(map[string]*mediapb.MediaJob),
+		cancels:     make(map[string]context.CancelFunc),
+		watchers:    make(map[string][]chan *mediapb.MediaJob),
+		wake:        make(chan struct{}, 1),
+	}
+
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.IsDir() || filepath.Ext(e.Name()) != ".json" {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		job := new(mediapb.MediaJob)
+		if err := protojson.Unmarshal(data, job); err != nil || job.Id == "" {
+			logger.Warn("media job: skipping unreadable job file", "file", e.Name(), "err", err)
+			continue
+		}
+		q.jobs[job.Id] = job
+	}
+
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.jobs {
+		if job.State == mediapb.MediaJobState_MEDIA_JOB_RUNNING {
+			job.State = mediapb.MediaJobState_MEDIA_JOB_QUEUED
+			job.Progress = 0
+			job.UpdatedAt = q.now().Unix()
+			q.save(job)
+		}
+	}
+	q.purge()
+	return q, nil
+}
+
+// start launches the workers; they stop with ctx.
+func (q *mediaJobQueue) start(ctx context.Context) {
+	workers := q.workers
+	if workers <= 0 {
+		workers = defaultMaxMediaJobs
+	}
+	for i := 0; i < workers; i++ {
+		go q.work(ctx)
+	}
+	go func() {
+		ticker := time.NewTicker(time.Hour)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				q.mu.Lock()
+				q.purge()
+				q.mu.Unlock()
+			}
+		}
+	}()
+}
+
+func (q *mediaJobQueue) work(ctx context.Context) {
+	for {
+		if job, jobCtx, ok := q.claim(ctx); ok {
+			q.execute(ctx, jobCtx, job)
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-q.wake:
+		case <-time.After(mediaJobPollInterval):
+		}
+	}
+}
+
+// signal wakes an idle worker.
+func (q *mediaJobQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
+
+func isActiveMediaJob(job *mediapb.MediaJob) bool {
+	return job.State == mediapb.MediaJobState_MEDIA_JOB_QUEUED || job.State == mediapb.MediaJobState_MEDIA_JOB_RUNNING
+}
+
+// enqueue adds a job, or returns the queued or running job of the same
+// path and operation, raising its priority if needed. A key whose last job
+// failed is not queued again unless spec.Force is set.
+func (q *mediaJobQueue) enqueue(spec mediaJobSpec) (*mediapb.MediaJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	now := q.now().Unix()
+	var previous []*mediapb.MediaJob
+	for _, job := range q.jobs {
+		if job.Path != spec.Path || job.Operation != spec.Operation {
+			continue
+		}
+		if isActiveMediaJob(job) {
+			changed := false
+			if spec.Priority > job.Priority {
+				job.Priority, changed = spec.Priority, true
+			}
+			if job.Deferred && !spec.Deferred {
+				job.Deferred, changed = false, true
+			}
+			if spec.StreamAfter && !job.StreamAfter {
+				job.StreamAfter, changed = true, true
+			}
+			if changed {
+				job.UpdatedAt = now
+				q.save(job)
+				q.notify(job)
+				q.signal()
+			}
+			return proto.Clone(job).(*mediapb.MediaJob), nil
+		}
+		if job.State == mediapb.MediaJobState_MEDIA_JOB_FAILED && !spec.Force {
+			return proto.Clone(job).(*mediapb.MediaJob), nil
+		}
+		previous = append(previous, job)
+	}
+
+	job := &mediapb.MediaJob{
+		Id:          Utility.RandomUUID(),
+		Path:        spec.Path,
+		Operation:   spec.Operation,
+		State:       mediapb.MediaJobState_MEDIA_JOB_QUEUED,
+		Priority:    spec.Priority,
+		MaxAttempts: q.maxAttempts,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+		Deferred:    spec.Deferred,
+		After:       spec.After,
+		StreamAfter: spec.StreamAfter,
+	}
+	if err := q.save(job); err != nil {
+		return nil, err
+	}
+	// Only the latest job of a path and operation is kept.
+	for _, old := range previous {
+		q.remove(old.Id)
+	}
+	q.jobs[job.Id] = job
+	q.signal()
+	return proto.Clone(job).(*mediapb.MediaJob), nil
+}
+
+// next returns the job to run next: the highest priority, then the oldest,
+// among the queued jobs that are due, whose dependency has ended and, when
+// deferred, whose window is open. The caller holds q.mu.
+func (q *mediaJobQueue) next() *mediapb.MediaJob {
+	now := q.now().Unix()
+	windowOpen := q.windowOpen == nil || q.windowOpen()
+	var best *mediapb.MediaJob
+	for _, job := range q.jobs {
+		if job.State != mediapb.MediaJobState_MEDIA_JOB_QUEUED || job.NextAttemptAt > now {
+			continue
+		}
+		if job.Deferred && !windowOpen {
+			continue
+		}
+		if dep, ok := q.jobs[job.After]; ok && isActiveMediaJob(dep) {
+			continue
+		}
+		if best == nil || job.Priority > best.Priority ||
+			(job.Priority == best.Priority && (job.CreatedAt < best.CreatedAt || (job.CreatedAt == best.CreatedAt && job.Id < best.Id))) {
+			best = job
+		}
+	}
+	return best
+}
+
+// claim marks the next job running and returns a copy with its context.
+func (q *mediaJobQueue) claim(ctx context.Context) (*mediapb.MediaJob, context.Context, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if ctx.Err() != nil {
+		return nil, nil, false
+	}
+	job := q.next()
+	if job == nil {
+		return nil, nil, false
+	}
+	now := q.now().Unix()
+	job.State = mediapb.MediaJobState_MEDIA_JOB_RUNNING
+	job.Attempts++
+	job.Progress = 0
+	job.StartedAt = now
+	job.UpdatedAt = now
+	job.NextAttemptAt = 0
+	q.save(job)
+	q.notify(job)
+
+	jobCtx, cancel := context.WithCancel(ctx)
+	q.cancels[job.Id] = cancel
+	// Another idle worker may pick the next one.
+	q.signal()
+	return proto.Clone(job).(*mediapb.MediaJob), jobCtx, true
+}
+
+func (q *mediaJobQueue) execute(ctx, jobCtx context.Context, job *mediapb.MediaJob) {
+	id := job.Id
+	err := func() (err error) {
+		defer func() {
+			if r := recover(); r != nil {
+				err = fmt.Errorf("media job panic: %v", r)
+			}
+		}()
+		return q.run(withJobProgress(jobCtx, func(p float64) { q.progress(id, p) }), job)
+	}()
+	q.finish(ctx, id, err)
+}
+
+// retryDelay is the wait before the attempt following the given one.
+func retryDelay(attempt int32) time.Duration {
+	if attempt < 1 {
+		attempt = 1
+	}
+	if attempt > 10 {
+		return mediaJobMaxRetryDelay
+	}
+	return min(mediaJobRetryDelay<<(attempt-1), mediaJobMaxRetryDelay)
+}
+
+// finish records the outcome of an attempt.
+func (q *mediaJobQueue) finish(ctx context.Context, id string, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if cancel := q.cancels[id]; cancel != nil {
+		cancel()
+		delete(q.cancels, id)
+	}
+	job := q.jobs[id]
+	if job == nil {
+		return
+	}
+
+	now := q.now()
+	switch {
+	case job.State == mediapb.MediaJobState_MEDIA_JOB_CANCELED:
+		// Canceled while running; cancel already recorded it.
+	case err == nil:
+		job.State = mediapb.MediaJobState_MEDIA_JOB_DONE
+		job.Progress = 100
+		job.Error = ""
+		job.FinishedAt = now.Unix()
+	case ctx.Err() != nil:
+		// Interrupted by shutdown: run it again on the next start.
+		job.State = mediapb.MediaJobState_MEDIA_JOB_QUEUED
+		job.Attempts--
+		job.Progress = 0
+	default:
+		job.Error = err.Error()
+		if job.Attempts < job.MaxAttempts {
+			job.State = mediapb.MediaJobState_MEDIA_JOB_QUEUED
+			job.NextAttemptAt = now.Add(retryDelay(job.Attempts)).Unix()
+			logger.Warn("media job failed; retrying", "id", id, "path", job.Path, "operation", job.Operation.String(), "attempt", job.Attempts, "err", err)
+		} else {
+			job.State = mediapb.MediaJobState_MEDIA_JOB_FAILED
+			job.FinishedAt = now.Unix()
+			logger.Error("media job failed", "id", id, "path", job.Path, "operation", job.Operation.String(), "attempts", job.Attempts, "err", err)
+		}
+	}
+	job.UpdatedAt = now.Unix()
+	q.save(job)
+	q.notify(job)
+	if !isActiveMediaJob(job) {
+		q.closeWatchers(id)
+	}
+	q.signal()
+}
+
+// progress records the percentage of a running job. Small steps are
+// dropped; progress is not persisted.
+func (q *mediaJobQueue) progress(id string, p float64) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := q.jobs[id]
+	if job == nil || job.State != mediapb.MediaJobState_MEDIA_JOB_RUNNING {
+		return
+	}
+	p = float64(int(p*10)) / 10
+	if p >= job.Progress && p-job.Progress < 0.5 && p < 100 {
+		return
+	}
+	job.Progress = p
+	job.UpdatedAt = q.now().Unix()
+	q.notify(job)
+}
+
+// setNext records the job queued by id when it is done.
+func (q *mediaJobQueue) setNext(id, next string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if job := q.jobs[id]; job != nil {
+		job.NextJobId = next
+		q.save(job)
+	}
+}
+
+// cancel stops a queued or running job.
+func (q *mediaJobQueue) cancel(id string) (*mediapb.MediaJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := q.jobs[id]
+	if job == nil {
+		return nil, errMediaJobNotFound
+	}
+	if !isActiveMediaJob(job) {
+		return nil, errMediaJobFinished
+	}
+	q.cancelLocked(job)
+	return proto.Clone(job).(*mediapb.MediaJob), nil
+}
+
+func (q *mediaJobQueue) cancelLocked(job *mediapb.MediaJob) {
+	now := q.now().Unix()
+	job.State = mediapb.MediaJobState_MEDIA_JOB_CANCELED
+	job.FinishedAt = now
+	job.UpdatedAt = now
+	if cancel := q.cancels[job.Id]; cancel != nil {
+		cancel()
+	}
+	q.save(job)
+	q.notify(job)
+	q.closeWatchers(job.Id)
+	q.signal()
+}
+
+// cancelAll cancels every queued and running job.
+func (q *mediaJobQueue) cancelAll() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	n := 0
+	for _, job := range q.jobs {
+		if isActiveMediaJob(job) {
+			q.cancelLocked(job)
+			n++
+		}
+	}
+	return n
+}
+
+// reprioritize changes the priority of a queued or running job.
+func (q *mediaJobQueue) reprioritize(id string, priority int32) (*mediapb.MediaJob, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := q.jobs[id]
+	if job == nil {
+		return nil, errMediaJobNotFound
+	}
+	if !isActiveMediaJob(job) {
+		return nil, errMediaJobFinished
+	}
+	job.Priority = priority
+	job.UpdatedAt = q.now().Unix()
+	q.save(job)
+	q.notify(job)
+	q.signal()
+	return proto.Clone(job).(*mediapb.MediaJob), nil
+}
+
+// forgetFailed drops the failed jobs of path (all of them when path is
+// empty), so a later scan queues them again.
+func (q *mediaJobQueue) forgetFailed(path string) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for id, job := range q.jobs {
+		if job.State == mediapb.MediaJobState_MEDIA_JOB_FAILED && (path == "" || job.Path == path) {
+			q.remove(id)
+		}
+	}
+}
+
+func (q *mediaJobQueue) get(id string) (*mediapb.MediaJob, bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := q.jobs[id]
+	if job == nil {
+		return nil, false
+	}
+	return proto.Clone(job).(*mediapb.MediaJob), true
+}
+
+// running reports whether a job is running.
+func (q *mediaJobQueue) running() bool {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for _, job := range q.jobs {
+		if job.State == mediapb.MediaJobState_MEDIA_JOB_RUNNING {
+			return true
+		}
+	}
+	return false
+}
+
+// list returns the jobs in the given states (all when empty) under dir
+// (everywhere when empty): running jobs, then queued ones in run order,
+// then finished ones, latest first.
+func (q *mediaJobQueue) list(states []mediapb.MediaJobState, dir string) []*mediapb.MediaJob {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dir = strings.TrimSuffix(dir, "/")
+	var out []*mediapb.MediaJob
+	for _, job := range q.jobs {
+		if len(states) > 0 && !containsState(states, job.State) {
+			continue
+		}
+		if dir != "" && job.Path != dir && !strings.HasPrefix(job.Path, dir+"/") {
+			continue
+		}
+		out = append(out, proto.Clone(job).(*mediapb.MediaJob))
+	}
+	rank := func(j *mediapb.MediaJob) int {
+		switch j.State {
+		case mediapb.MediaJobState_MEDIA_JOB_RUNNING:
+			return 0
+		case mediapb.MediaJobState_MEDIA_JOB_QUEUED:
+			return 1
+		}
+		return 2
+	}
+	sort.Slice(out, func(i, j int) bool {
+		a, b := out[i], out[j]
+		if rank(a) != rank(b) {
+			return rank(a) < rank(b)
+		}
+		switch rank(a) {
+		case 0:
+			if a.StartedAt != b.StartedAt {
+				return a.StartedAt < b.StartedAt
+			}
+		case 1:
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+			if a.CreatedAt != b.CreatedAt {
+				return a.CreatedAt < b.CreatedAt
+			}
+		default:
+			if a.FinishedAt != b.FinishedAt {
+				return a.FinishedAt > b.FinishedAt
+			}
+		}
+		return a.Id < b.Id
+	})
+	return out
+}
+
+func containsState(states []mediapb.MediaJobState, s mediapb.MediaJobState) bool {
+	for _, v := range states {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
+
+// watch returns a channel receiving the updates of a job; it is closed once
+// the job has ended. Updates may be dropped for a slow reader, never the
+// closing of the channel. stop must be called when done.
+func (q *mediaJobQueue) watch(id string) (<-chan *mediapb.MediaJob, func(), error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	job := q.jobs[id]
+	if job == nil {
+		return nil, nil, errMediaJobNotFound
+	}
+	ch := make(chan *mediapb.MediaJob, 16)
+	ch <- proto.Clone(job).(*mediapb.MediaJob)
+	if !isActiveMediaJob(job) {
+		close(ch)
+		return ch, func() {}, nil
+	}
+	q.watchers[id] = append(q.watchers[id], ch)
+	stop := func() {
+		q.mu.Lock()
+		defer q.mu.Unlock()
+		list := q.watchers[id]
+		for i, c := range list {
+			if c == ch {
+				q.watchers[id] = append(list[:i:i], list[i+1:]...)
+				break
+			}
+		}
+	}
+	return ch, stop, nil
+}
+
+// wait blocks until a job has ended, following the jobs it queued when
+// done (an MP4 conversion and then its HLS stream), and returns the last.
+func (q *mediaJobQueue) wait(ctx context.Context, id string) (*mediapb.MediaJob, error) {
+	for {
+		ch, stop, err := q.watch(id)
+		if err != nil {
+			return nil, err
+		}
+		for open := true; open; {
+			select {
+			case <-ctx.Done():
+				stop()
+				return nil, ctx.Err()
+			case _, open = <-ch:
+			}
+		}
+		stop()
+		job, ok := q.get(id)
+		if !ok {
+			return nil, errMediaJobNotFound
+		}
+		if job.State != mediapb.MediaJobState_MEDIA_JOB_DONE || job.NextJobId == "" {
+			return job, nil
+		}
+		id = job.NextJobId
+	}
+}
+
+// notify sends a copy of job to its watchers. The caller holds q.mu.
+func (q *mediaJobQueue) notify(job *mediapb.MediaJob) {
+	list := q.watchers[job.Id]
+	if len(list) == 0 {
+		return
+	}
+	c := proto.Clone(job).(*mediapb.MediaJob)
+	for _, ch := range list {
+		select {
+		case ch <- c:
+		default:
+		}
+	}
+}
+
+func (q *mediaJobQueue) closeWatchers(id string) {
+	for _, ch := range q.watchers[id] {
+		close(ch)
+	}
+	delete(q.watchers, id)
+}
+
+func (q *mediaJobQueue) jobFile(id string) string {
+	return filepath.Join(q.dir, id+".json")
+}
+
+// save writes a job atomically. The caller holds q.mu.
+func (q *mediaJobQueue) save(job *mediapb.MediaJob) error {
+	data, err := protojson.Marshal(job)
+	if err != nil {
+		return err
+	}
+	file := q.jobFile(job.Id)
+	if err := os.WriteFile(file+".tmp", data, 0o644); err != nil {
+		logger.Warn("media job: save failed", "id", job.Id, "err", err)
+		return err
+	}
+	if err := os.Rename(file+".tmp", file); err != nil {
+		logger.Warn("media job: save failed", "id", job.Id, "err", err)
+		return err
+	}
+	return nil
+}
+
+// remove forgets a job. The caller holds q.mu.
+func (q *mediaJobQueue) remove(id string) {
+	delete(q.jobs, id)
+	if err := os.Remove(q.jobFile(id)); err != nil && !os.IsNotExist(err) {
+		logger.Warn("media job: remove failed", "id", id, "err", err)
+	}
+}
+
+// purge forgets the jobs that ended more than mediaJobRetention ago. The
+// caller holds q.mu.
+func (q *mediaJobQueue) purge() {
+	limit := q.now().Add(-mediaJobRetention).Unix()
+	for id, job := range q.jobs {
+		if !isActiveMediaJob(job) && job.FinishedAt < limit {
+			q.remove(id)
+		}
+	}
+}
+
+// --- Progress ----------------------------------------------------------------
+
+type jobProgressKey struct{}
+
+// withJobProgress returns a context whose ffmpeg runs report their
+// percentage to fn.
+func withJobProgress(ctx context.Context, fn func(float64)) context.Context {
+	return context.WithValue(ctx, jobProgressKey{}, fn)
+}
+
+// jobProgressFrom returns the progress callback of ctx, or nil.
+func jobProgressFrom(ctx context.Context) func(float64) {
+	fn, _ := ctx.Value(jobProgressKey{}).(func(float64))
+	return fn
+}
+
+// --- Conversion window -------------------------------------------------------
+
+// parseClock parses "HH:MM" into a duration since midnight.
+func parseClock(v string) (time.Duration, bool) {
+	values := strings.Split(v, ":")
+	if len(values) != 2 {
+		return 0, false
+	}
+	return time.Duration(Utility.ToInt(values[0]))*time.Hour + time.Duration(Utility.ToInt(values[1]))*time.Minute, true
+}
+
+// conversionWindowOpen reports whether now falls within the daily window
+// starting at startHour and lasting delay (both "HH:MM"). A zero or invalid
+// delay leaves the window always open.
+func conversionWindowOpen(now time.Time, startHour, delay string) bool {
+	length, ok := parseClock(delay)
+	if !ok || length <= 0 {
+		return true
+	}
+	offset, _ := parseClock(startHour)
+	start := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).Add(offset)
+	// The window opened yesterday may still be open.
+	for _, s := range []time.Time{start, start.AddDate(0, 0, -1)} {
+		if !now.Before(s) && now.Before(s.Add(length)) {
+			return true
+		}
+	}
+	return false
+}
+
+// --- Server side -------------------------------------------------------------
+
+// startMediaJobs opens the job store and starts its workers.
+func (srv *server) startMediaJobs(ctx context.Context) error {
+	q, err := openMediaJobQueue(filepath.Join(config.GetDataDir(), "media-jobs"))
+	if err != nil {
+		return err
+	}
+	if srv.MaxMediaJobs > 0 {
+		q.workers = srv.MaxMediaJobs
+	}
+	q.run = srv.runMediaJob
+	q.windowOpen = func() bool {
+		return !srv.AutomaticVideoConversion || conversionWindowOpen(time.Now(), srv.StartVideoConversionHour, srv.MaximumVideoConversionDelay)
+	}
+	srv.jobs = q
+	q.start(ctx)
+	return nil
+}
+
+// mediaJobLogMsg is the conversion log message of each operation.
+var mediaJobLogMsg = map[mediapb.MediaJobOperation]string{
+	mediapb.MediaJobOperation_MEDIA_JOB_MP4:       "Convert video to mp4 h.264",
+	mediapb.MediaJobOperation_MEDIA_JOB_HLS:       "Convert video to stream",
+	mediapb.MediaJobOperation_MEDIA_JOB_PREVIEW:   "Create video preview",
+	mediapb.MediaJobOperation_MEDIA_JOB_TIMELINE:  "Generate video time line",
+	mediapb.MediaJobOperation_MEDIA_JOB_SUBTITLES: "Extract subtitles",
+}
+
+// runMediaJob runs one attempt of a job and mirrors it in the conversion
+// logs; the error of the last attempt also goes to the conversion errors.
+func (srv *server) runMediaJob(ctx context.Context, job *mediapb.MediaJob) error {
+	var log *mediapb.VideoConversionLog
+	if msg, ok := mediaJobLogMsg[job.Operation]; ok {
+		log = &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: msg, Path: normalizeLogPath(job.Path), Status: "running"}
+		srv.videoConversionLogs.Store(log.LogTime, log)
+		srv.publishConvertionLogEvent(log)
+	}
+
+	err := srv.doMediaJob(ctx, job)
+
+	if log != nil {
+		log.Status = "done"
+		if err != nil {
+			log.Status = "fail"
+		}
+		srv.publishConvertionLogEvent(log)
+	}
+	if err != nil && ctx.Err() == nil && job.Attempts >= job.MaxAttempts {
+		srv.publishConvertionLogError(job.Path, err)
+	}
+	return err
+}
+
+func (srv *server) doMediaJob(ctx context.Context, job *mediapb.MediaJob) error {
+	switch job.Operation {
+	case mediapb.MediaJobOperation_MEDIA_JOB_SCAN:
+		token, err := security.GetLocalToken(srv.Mac)
+		if err != nil {
+			return err
+		}
+		return srv.scanVideos(ctx, token, []string{job.Path}, job.Priority, job.Deferred)
+
+	case mediapb.MediaJobOperation_MEDIA_JOB_PREVIEW:
+		return errors.Join(
+			srv.ensureFastStartMP4(ctx, job.Path),
+			srv.createVideoPreview(ctx, job.Path, 20, 128, false),
+			srv.generateVideoPreview(ctx, job.Path, 10, 320, 30, false),
+		)
+
+	case mediapb.MediaJobOperation_MEDIA_JOB_TIMELINE:
+		return srv.createVideoTimeLine(ctx, job.Path, 180, .2, false)
+
+	case mediapb.MediaJobOperation_MEDIA_JOB_SUBTITLES:
+		err := srv.extractSubtitleTracks(ctx, job.Path)
+		if errors.Is(err, errNoSubtitles) || errors.Is(err, errSubtitlesExist) {
+			return nil
+		}
+		return err
+
+	case mediapb.MediaJobOperation_MEDIA_JOB_MP4:
+		out, err := srv.createVideoMpeg4H264(ctx, job.Path)
+		if err != nil {
+			return err
+		}
+		if job.StreamAfter {
+			next, err := srv.jobs.enqueue(mediaJobSpec{
+				Path:      out,
+				Operation: mediapb.MediaJobOperation_MEDIA_JOB_HLS,
+				Priority:  job.Priority,
+				Deferred:  job.Deferred,
+				Force:     true,
+			})
+			if err != nil {
+				return err
+			}
+			srv.jobs.setNext(job.Id, next.Id)
+		}
+		return nil
+
+	case mediapb.MediaJobOperation_MEDIA_JOB_HLS:
+		return srv.createHlsStreamFromMpeg4H264(ctx, job.Path)
+	}
+	return fmt.Errorf("unknown media job operation %v", job.Operation)
+}
+
+// queueVideoJobs queues the jobs a video still needs: previews and
+// timeline when missing, then the MP4 conversion (preceded by subtitle
+// extraction) of files browsers cannot play, or their HLS stream when
+// automatic stream conversion is on.
+func (srv *server) queueVideoJobs(video string, priority int32, deferred bool) {
+	enqueue := func(spec mediaJobSpec) *mediapb.MediaJob {
+		spec.Path, spec.Priority, spec.Deferred = video, priority, deferred
+		job, err := srv.jobs.enqueue(spec)
+		if err != nil {
+			logger.Warn("queue media job failed", "path", video, "operation", spec.Operation.String(), "err", err)
+		}
+		return job
+	}
+
+	preview, timeline := srv.videoNeedsPreviews(video)
+	if preview {
+		enqueue(mediaJobSpec{Operation: mediapb.MediaJobOperation_MEDIA_JOB_PREVIEW})
+	}
+	if timeline {
+		enqueue(mediaJobSpec{Operation: mediapb.MediaJobOperation_MEDIA_JOB_TIMELINE})
+	}
+
+	if strings.HasSuffix(video, ".m3u8") || !strings.Contains(video, ".") {
+		return
+	}
+	dir := video[:strings.LastIndex(video, ".")]
+	if srv.pathExists(dir+"/playlist.m3u8") && srv.pathExists(video) {
+		return
+	}
+
+	lower := strings.ToLower(video)
+	if strings.HasSuffix(lower, ".mkv") || strings.HasSuffix(lower, ".avi") || srv.getCodec(video) == "hevc" {
+		subs := enqueue(mediaJobSpec{Operation: mediapb.MediaJobOperation_MEDIA_JOB_SUBTITLES})
+		after := ""
+		if subs != nil {
+			after = subs.Id
+		}
+		enqueue(mediaJobSpec{Operation: mediapb.MediaJobOperation_MEDIA_JOB_MP4, After: after, StreamAfter: srv.AutomaticStreamConversion})
+	} else if srv.AutomaticStreamConversion {
+		enqueue(mediaJobSpec{Operation: mediapb.MediaJobOperation_MEDIA_JOB_HLS})
+	}
+}
+
+// videoNeedsPreviews reports whether a video (file or HLS folder) misses
+// its previews or fast-start layout, and its timeline.
+func (srv *server) videoNeedsPreviews(video string) (preview, timeline bool) {
+	p := strings.TrimSuffix(filepath.ToSlash(video), "/playlist.m3u8")
+	if strings.Contains(p, "/.hidden/") || strings.Contains(p, "/.temp/") {
+		return false, false
+	}
+	if !srv.pathExists(p + "/playlist.m3u8") {
+		p = strings.TrimSuffix(p, path.Ext(p))
+	}
+	hidden := path.Dir(p) + "/.hidden/" + path.Base(p)
+
+	hasPreview, _ := srv.previewHasImages(hidden + "/__preview__")
+	preview = !hasPreview || !srv.pathExists(hidden+"/preview.gif") || !srv.pathExists(hidden+"/preview.mp4")
+	if !preview && strings.EqualFold(path.Ext(video), ".mp4") && !srv.isMinioPath(video) {
+		if fast, err := hasFastStartMoov(srv.formatPath(video)); err == nil && !fast {
+			preview = true
+		}
+	}
+	hasTimeline, _ := srv.timelineHasImages(hidden + "/__timeline__")
+	return preview, !hasTimeline
+}
+
+// enqueueScans queues a scan job for each directory.
+func (srv *server) enqueueScans(dirs []string, priority int32, deferred bool) error {
+	for _, dir := range dirs {
+		if _, err := srv.jobs.enqueue(mediaJobSpec{
+			Path:      dir,
+			Operation: mediapb.MediaJobOperation_MEDIA_JOB_SCAN,
+			Priority:  priority,
+			Deferred:  deferred,
+			Force:     true,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mediaJobStatus maps a queue error to a gRPC status.
+func mediaJobStatus(err error) error {
+	switch {
+	case errors.Is(err, errMediaJobNotFound):
+		return status.Errorf(codes.NotFound, "%s", err)
+	case errors.Is(err, errMediaJobFinished):
+		return status.Errorf(codes.FailedPrecondition, "%s", err)
+	}
+	return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+}
+
+func (srv *server) jobQueue() (*mediaJobQueue, error) {
+	if srv.jobs == nil {
+		return nil, status.Error(codes.Unavailable, "media job queue not started")
+	}
+	return srv.jobs, nil
+}
+
+// ListMediaJobs returns the media jobs, optionally filtered by state and path.
+func (srv *server) ListMediaJobs(ctx context.Context, rqst *mediapb.ListMediaJobsRequest) (*mediapb.ListMediaJobsResponse, error) {
+	q, err := srv.jobQueue()
+	if err != nil {
+		return nil, err
+	}
+	return &mediapb.ListMediaJobsResponse{Jobs: q.list(rqst.States, rqst.Path)}, nil
+}
+
+// WatchMediaJob streams the updates of a job, progress included, until it ends.
+func (srv *server) WatchMediaJob(rqst *mediapb.WatchMediaJobRequest, stream mediapb.MediaService_WatchMediaJobServer) error {
+	q, err := srv.jobQueue()
+	if err != nil {
+		return err
+	}
+	ch, stop, err := q.watch(rqst.Id)
+	if err != nil {
+		return mediaJobStatus(err)
+	}
+	defer stop()
+
+	var last *mediapb.MediaJob
+	for {
+		select {
+		case <-stream.Context().Done():
+			return stream.Context().Err()
+		case job, open := <-ch:
+			if !open {
+				// Updates may have been dropped: always end on the final state.
+				if final, ok := q.get(rqst.Id); ok && (last == nil || !proto.Equal(final, last)) {
+					return stream.Send(&mediapb.WatchMediaJobResponse{Job: final})
+				}
+				return nil
+			}
+			if err := stream.Send(&mediapb.WatchMediaJobResponse{Job: job}); err != nil {
+				return err
+			}
+			last = job
+		}
+	}
+}
+
+// CancelMediaJob cancels a queued or running job; a canceled job is not retried.
+func (srv *server) CancelMediaJob(ctx context.Context, rqst *mediapb.CancelMediaJobRequest) (*mediapb.CancelMediaJobResponse, error) {
+	q, err := srv.jobQueue()
+	if err != nil {
+		return nil, err
+	}
+	job, err := q.cancel(rqst.Id)
+	if err != nil {
+		return nil, mediaJobStatus(err)
+	}
+	return &mediapb.CancelMediaJobResponse{Job: job}, nil
+}
+
+// ReprioritizeMediaJob changes the priority of a queued or running job.
+func (srv *server) ReprioritizeMediaJob(ctx context.Context, rqst *mediapb.ReprioritizeMediaJobRequest) (*mediapb.ReprioritizeMediaJobResponse, error) {
+	q, err := srv.jobQueue()
+	if err != nil {
+		return nil, err
+	}
+	job, err := q.reprioritize(rqst.Id, rqst.Priority)
+	if err != nil {
+		return nil, mediaJobStatus(err)
+	}
+	return &mediapb.ReprioritizeMediaJobResponse{Job: job}, nil
+}
+
+// runMediaJobRequest queues a job at request priority, even if it failed
+// before, and waits until it and the jobs it queues have ended.
+func (srv *server) runMediaJobRequest(ctx context.Context, spec mediaJobSpec) error {
+	q, err := srv.jobQueue()
+	if err != nil {
+		return err
+	}
+	spec.Priority = mediaJobPriorityRequest
+	spec.Force = true
+	job, err := q.enqueue(spec)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	job, err = q.wait(ctx, job.Id)
+	if err != nil {
+		return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
+	switch job.State {
+	case mediapb.MediaJobState_MEDIA_JOB_DONE:
+		return nil
+	case mediapb.MediaJobState_MEDIA_JOB_CANCELED:
+		return status.Errorf(codes.Canceled, "media job %s was canceled", job.Id)
+	}
+	return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), errors.New(job.Error)))
+}
+
+// queuePreviewJobs queues the preview and timeline jobs of a new video.
+func (srv *server) queuePreviewJobs(video string) {
+	if srv.jobs == nil {
+		return
+	}
+	for _, op := range []mediapb.MediaJobOperation{mediapb.MediaJobOperation_MEDIA_JOB_PREVIEW, mediapb.MediaJobOperation_MEDIA_JOB_TIMELINE} {
+		if _, err := srv.jobs.enqueue(mediaJobSpec{Path: video, Operation: op, Priority: mediaJobPriorityManual, Force: true}); err != nil {
+			logger.Warn("queue media job failed", "path", video, "operation", op.String(), "err", err)
+		}
+	}
+}
@@ -0,0 +1,234 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/globulario/services/golang/media/mediapb"
+)
+
+const (
+	jobMP4       = mediapb.MediaJobOperation_MEDIA_JOB_MP4
+	jobSubtitles = mediapb.MediaJobOperation_MEDIA_JOB_SUBTITLES
+	jobQueued    = mediapb.MediaJobState_MEDIA_JOB_QUEUED
+	jobRunning   = mediapb.MediaJobState_MEDIA_JOB_RUNNING
+	jobDone      = mediapb.MediaJobState_MEDIA_JOB_DONE
+	jobFailed    = mediapb.MediaJobState_MEDIA_JOB_FAILED
+	jobCanceled  = mediapb.MediaJobState_MEDIA_JOB_CANCELED
+)
+
+// newTestJobQueue opens a queue on dir whose clock only moves when told
+// and whose jobs fail while *fail is set.
+func newTestJobQueue(t *testing.T, dir string) (*mediaJobQueue, *time.Time, *bool) {
+	q, err := openMediaJobQueue(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	fail := false
+	q.now = func() time.Time { return now }
+	q.run = func(ctx context.Context, job *mediapb.MediaJob) error {
+		if fail {
+			return errors.New("ffmpeg failed")
+		}
+		return nil
+	}
+	return q, &now, &fail
+}
+
+func mustEnqueue(t *testing.T, q *mediaJobQueue, spec mediaJobSpec) *mediapb.MediaJob {
+	t.Helper()
+	job, err := q.enqueue(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return job
+}
+
+// runNext runs the next job synchronously and returns its path, or "".
+func runNext(q *mediaJobQueue) string {
+	job, jobCtx, ok := q.claim(context.Background())
+	if !ok {
+		return ""
+	}
+	q.execute(context.Background(), jobCtx, job)
+	return job.Path
+}
+
+func TestMediaJobQueueOrder(t *testing.T) {
+	q, now, _ := newTestJobQueue(t, t.TempDir())
+
+	mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4})
+	*now = now.Add(time.Second)
+	mustEnqueue(t, q, mediaJobSpec{Path: "/b.mkv", Operation: jobMP4})
+	*now = now.Add(time.Second)
+	mustEnqueue(t, q, mediaJobSpec{Path: "/c.mkv", Operation: jobMP4, Priority: 5})
+	// Queuing a queued job again bumps its priority instead.
+	b := mustEnqueue(t, q, mediaJobSpec{Path: "/b.mkv", Operation: jobMP4, Priority: 7})
+	if b.Priority != 7 || len(q.list(nil, "")) != 3 {
+		t.Fatalf("dedupe: %+v, %d jobs", b, len(q.list(nil, "")))
+	}
+
+	var order []string
+	for p := runNext(q); p != ""; p = runNext(q) {
+		order = append(order, p)
+	}
+	if got := len(order); got != 3 || order[0] != "/b.mkv" || order[1] != "/c.mkv" || order[2] != "/a.mkv" {
+		t.Fatalf("order = %v", order)
+	}
+	if jobs := q.list([]mediapb.MediaJobState{jobDone}, "/"); len(jobs) != 3 || jobs[0].Progress != 100 {
+		t.Errorf("done jobs = %v", jobs)
+	}
+}
+
+func TestMediaJobRetry(t *testing.T) {
+	q, now, fail := newTestJobQueue(t, t.TempDir())
+	*fail = true
+	job := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4})
+
+	runNext(q)
+	got, _ := q.get(job.Id)
+	if got.State != jobQueued || got.Attempts != 1 || got.Error != "ffmpeg failed" || got.NextAttemptAt != now.Add(time.Minute).Unix() {
+		t.Fatalf("after a failed attempt: %+v", got)
+	}
+	if runNext(q) != "" {
+		t.Fatal("retried before its backoff")
+	}
+	*now = now.Add(time.Minute)
+	runNext(q)
+	if got, _ := q.get(job.Id); got.NextAttemptAt != now.Add(2*time.Minute).Unix() {
+		t.Fatalf("second backoff: %+v", got)
+	}
+	*now = now.Add(2 * time.Minute)
+	runNext(q)
+	if got, _ := q.get(job.Id); got.State != jobFailed || got.Attempts != 3 {
+		t.Fatalf("after the last attempt: %+v", got)
+	}
+
+	// A failed job is not queued again unless forced.
+	if again := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4}); again.Id != job.Id {
+		t.Error("failed job queued again")
+	}
+	*fail = false
+	forced := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4, Force: true})
+	if _, ok := q.get(job.Id); ok || forced.Id == job.Id {
+		t.Fatal("forced job did not replace the failed one")
+	}
+	runNext(q)
+	if got, _ := q.get(forced.Id); got.State != jobDone {
+		t.Errorf("forced job = %v", got.State)
+	}
+
+	if d := retryDelay(20); d != time.Hour {
+		t.Errorf("retryDelay(20) = %v", d)
+	}
+}
+
+func TestMediaJobAfterAndRestart(t *testing.T) {
+	dir := t.TempDir()
+	q, _, _ := newTestJobQueue(t, dir)
+	subs := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobSubtitles})
+	mp4 := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4, Priority: 9, After: subs.Id})
+
+	job, _, ok := q.claim(context.Background())
+	if !ok || job.Id != subs.Id {
+		t.Fatalf("claimed %v before its dependency", job)
+	}
+	if _, _, ok := q.claim(context.Background()); ok {
+		t.Fatal("dependent job started while its dependency runs")
+	}
+
+	// Restart: the running job is queued again, nothing is lost.
+	q, _, _ = newTestJobQueue(t, dir)
+	if got, ok := q.get(subs.Id); !ok || got.State != jobQueued || got.Attempts != 1 {
+		t.Fatalf("reloaded running job = %+v", got)
+	}
+	if got, ok := q.get(mp4.Id); !ok || got.After != subs.Id || got.Priority != 9 {
+		t.Fatalf("reloaded job = %+v", got)
+	}
+	runNext(q)
+	runNext(q)
+	if got, _ := q.get(mp4.Id); got.State != jobDone {
+		t.Errorf("dependent job = %v", got.State)
+	}
+}
+
+func TestMediaJobCancelWatch(t *testing.T) {
+	q, _, _ := newTestJobQueue(t, t.TempDir())
+	started := make(chan struct{})
+	q.run = func(ctx context.Context, job *mediapb.MediaJob) error {
+		jobProgressFrom(ctx)(42.17)
+		close(started)
+		<-ctx.Done()
+		return ctx.Err()
+	}
+	job := mustEnqueue(t, q, mediaJobSpec{Path: "/a.mkv", Operation: jobMP4})
+	if _, err := q.reprioritize(job.Id, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	ch, stop, err := q.watch(job.Id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer stop()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	q.start(ctx)
+	<-started
+	if _, err := q.cancel(job.Id); err != nil {
+		t.Fatal(err)
+	}
+
+	var progress float64
+	var last *mediapb.MediaJob
+	for j := range ch {
+		if j.State == jobRunning && j.Progress > progress {
+			progress = j.Progress
+		}
+		last = j
+	}
+	if progress != 42.1 || last.State != jobCanceled || last.Priority != 3 {
+		t.Errorf("watched progress %v, last %+v", progress, last)
+	}
+
+	// The attempt returns after the cancel: the job stays canceled.
+	deadline := time.Now().Add(5 * time.Second)
+	for q.running() && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	if got, _ := q.get(job.Id); got.State != jobCanceled || got.NextAttemptAt != 0 {
+		t.Errorf("canceled job = %+v", got)
+	}
+	if _, err := q.cancel(job.Id); !errors.Is(err, errMediaJobFinished) {
+		t.Errorf("cancel twice: %v", err)
+	}
+	if _, err := q.reprioritize("nope", 1); !errors.Is(err, errMediaJobNotFound) {
+		t.Errorf("reprioritize unknown: %v", err)
+	}
+}
+
+func TestConversionWindowOpen(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 3, 1, h, m, 0, 0, time.UTC) }
+	for _, c := range []struct {
+		now          time.Time
+		start, delay string
+		want         bool
+	}{
+		{at(15, 0), "01:00", "00:00", true}, // no delay: always open
+		{at(15, 0), "01:00", "", true},
+		{at(1, 30), "01:00", "02:00", true},
+		{at(3, 0), "01:00", "02:00", false},
+		{at(0, 59), "01:00", "02:00", false},
+		{at(23, 30), "22:00", "04:00", true},
+		{at(1, 30), "22:00", "04:00", true}, // window opened yesterday
+		{at(2, 0), "22:00", "04:00", false},
+	} {
+		if got := conversionWindowOpen(c.now, c.start, c.delay); got != c.want {
+			t.Errorf("conversionWindowOpen(%s, %s, %s) = %v", c.now.Format("15:04"), c.start, c.delay, got)
+		}
+	}
+}
@@ -1034,41 +1034,6 @@ func (srv *server) clearRestoreFailure(path string) {
 	srv.restoreFailureMu.Unlock()
 }
 
-func (srv *server) queueProcess(dirs []string) {
-	srv.processPendingMu.Lock()
-	defer srv.processPendingMu.Unlock()
-	if srv.processPending {
-		srv.pendingProcessDirs = append([]string{}, dirs...)
-		return
-	}
-	srv.processPending = true
-	srv.pendingProcessDirs = append([]string{}, dirs...)
-}
-
-func (srv *server) popPendingProcessDirs() []string {
-	srv.processPendingMu.Lock()
-	defer srv.processPendingMu.Unlock()
-	if !srv.processPending {
-		return nil
-	}
-	dirs := append([]string{}, srv.pendingProcessDirs...)
-	srv.processPending = false
-	srv.pendingProcessDirs = nil
-	return dirs
-}
-
-func (srv *server) processVideoLoop(token string, dirs []string) {
-	current := append([]string{}, dirs...)
-	for {
-		processVideos(srv, token, current)
-		next := srv.popPendingProcessDirs()
-		if len(next) == 0 {
-			break
-		}
-		current = next
-	}
-}
-
 // enrichTitleFromIMDB populates Poster/ratings/cast from TMDb (primary) or IMDb (fallback).
 func (srv *server) enrichTitleFromIMDB(t *titlepb.Title, videoPath string) error {
 	if t == nil || t.ID == "" {
@@ -1516,11 +1481,7 @@ func (srv *server) processVideoInfo(token, infoPath string) error {
 			if err := srv.createVideoInfo(token, strings.ReplaceAll(dir, "/", "/"), mediaPath, infoPath); err != nil {
 				return err
 			}
-			go func(p string) {
-				srv.createVideoPreview(p, 20, 128, false)
-				srv.generateVideoPreview(p, 10, 320, 30, true)
-				srv.createVideoTimeLine(p, 180, .2, false)
-			}(strings.ReplaceAll(mediaPath, "/.hidden/", "/"))
+			srv.queuePreviewJobs(strings.ReplaceAll(mediaPath, "/.hidden/", "/"))
 		}
 	case "mp3":
 		if srv.pathExists(mediaPath) {
@@ -1539,21 +1500,15 @@ func (srv *server) processVideoInfo(token, infoPath string) error {
 	return nil
 }
 
-func processVideos(srv *server, token string, dirs []string) {
-	// Fast path: don’t even scan if we’re already processing
-	if srv.isProcessing {
-		return
-	}
-
+// scanVideos is the SCAN job: it consumes pending info.json files,
+// restores titles from their metadata cache and queues the jobs each video
+// still needs (see queueVideoJobs).
+func (srv *server) scanVideos(ctx context.Context, token string, dirs []string, priority int32, deferred bool) error {
 	client, err := getTitleClient()
 	if err != nil {
-		logger.Error("getTitleClient failed", "err", err)
-		return
+		return err
 	}
 
-	srv.isProcessing = true
-	defer func() { srv.isProcessing = false }()
-
 	// Step 1: consume pending info.json files
 	videoInfos := srv.getVideoInfoPaths(dirs)
 	for _, info := range videoInfos {
@@ -1598,6 +1553,9 @@ func processVideos(srv *server, token string, dirs []string) {
 
 	srv.cleanHiddenOrphans(dirs)
 	for _, vp := range videoPaths {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
 		if err := srv.restoreVideoInfos(client, token, vp, srv.Domain); err != nil {
 			if errors.Is(err, errRestoreCooldown) {
 				continue
@@ -1606,110 +1564,14 @@ func processVideos(srv *server, token string, dirs []string) {
 		}
 	}
 
-	// Step 2: previews & timelines
+	// Step 3: previews, timelines and conversions
 	for _, video := range videoPaths {
-		if strings.HasSuffix(strings.ToLower(video), ".mp4") {
-			fastLog := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Ensure fast-start MP4", Path: normalizeLogPath(video), Status: "running"}
-			srv.videoConversionLogs.Store(fastLog.LogTime, fastLog)
-			srv.publishConvertionLogEvent(fastLog)
-			if err := srv.ensureFastStartMP4(video); err != nil {
-				fastLog.Status = "fail"
-				srv.publishConvertionLogEvent(fastLog)
-				srv.publishConvertionLogError(fastLog.Path, err)
-			} else {
-				fastLog.Status = "done"
-				srv.publishConvertionLogEvent(fastLog)
-			}
-		}
-		log := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Create video preview", Path: normalizeLogPath(video), Status: "running"}
-		srv.videoConversionLogs.Store(log.LogTime, log)
-		srv.publishConvertionLogEvent(log)
-		if err := srv.createVideoPreview(video, 20, 128, false); err != nil {
-			log.Status = "fail"
-			srv.publishConvertionLogEvent(log)
-			srv.publishConvertionLogError(log.Path, err)
-		} else {
-			log.Status = "done"
-			srv.publishConvertionLogEvent(log)
-		}
-
-		g := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Generate video Gif image", Path: normalizeLogPath(video), Status: "running"}
-		srv.videoConversionLogs.Store(g.LogTime, g)
-		srv.publishConvertionLogEvent(g)
-		if err := srv.generateVideoPreview(video, 10, 320, 30, false); err != nil {
-			g.Status = "fail"
-			srv.publishConvertionLogEvent(g)
-			srv.publishConvertionLogError(g.Path, err)
-		} else {
-			g.Status = "done"
-			srv.publishConvertionLogEvent(g)
-		}
-
-		t := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Generate video time line", Path: normalizeLogPath(video), Status: "running"}
-		srv.videoConversionLogs.Store(t.LogTime, t)
-		srv.publishConvertionLogEvent(t)
-		if err := srv.createVideoTimeLine(video, 180, .2, false); err != nil {
-			t.Status = "fail"
-			srv.publishConvertionLogEvent(t)
-			srv.publishConvertionLogError(t.Path, err)
-		} else {
-			t.Status = "done"
-			srv.publishConvertionLogEvent(t)
-		}
-		if !srv.isProcessing || srv.isExpired() {
-			break
-		}
-	}
-
-	// Step 3: conversions & HLS
-	for _, video := range videoPaths {
-		if strings.HasSuffix(video, ".m3u8") || !strings.Contains(video, ".") {
-			continue
-		}
-
-		dir := video[:strings.LastIndex(video, ".")]
-		if srv.pathExists(dir+"/playlist.m3u8") && srv.pathExists(video) {
-			continue
-		}
-
-		if _, hasFail := srv.videoConversionErrors.Load(video); hasFail {
-			continue
-		}
-
-		// Transcode to mp4/h264 when needed
-		if strings.HasSuffix(strings.ToLower(video), ".mkv") || strings.HasSuffix(strings.ToLower(video), ".avi") || srv.getCodec(video) == "hevc" {
-			log := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Convert video to mp4 h.264", Path: normalizeLogPath(video), Status: "running"}
-			srv.videoConversionLogs.Store(log.LogTime, log)
-			srv.publishConvertionLogEvent(log)
-			out, err := srv.createVideoMpeg4H264(video)
-			if err != nil {
-				log.Status = "fail"
-				srv.publishConvertionLogEvent(log)
-				srv.publishConvertionLogError(video, err)
-			} else {
-				video = out
-				log.Status = "done"
-				srv.publishConvertionLogEvent(log)
-			}
-		}
-
-		if srv.AutomaticStreamConversion {
-			log := &mediapb.VideoConversionLog{LogTime: time.Now().Unix(), Msg: "Convert video to mp4", Path: normalizeLogPath(video), Status: "running"}
-			srv.videoConversionLogs.Store(log.LogTime, log)
-			srv.publishConvertionLogEvent(log)
-			if err := srv.createHlsStreamFromMpeg4H264(video); err != nil {
-				log.Status = "fail"
-				srv.publishConvertionLogEvent(log)
-				srv.publishConvertionLogError(video, err)
-			} else {
-				log.Status = "done"
-				srv.publishConvertionLogEvent(log)
-			}
-		}
-		if !srv.isProcessing || srv.isExpired() {
-			break
+		if ctx.Err() != nil {
+			return ctx.Err()
 		}
+		srv.queueVideoJobs(video, priority, deferred)
 	}
+	return nil
 }
 
 func (srv *server) collectDirectoryMetadataFiles(dir string) []string {
@@ -3132,7 +2994,7 @@ func (srv *server) CreateVideoTimeLine(ctx context.Context, rqst *mediapb.Create
 	srv.videoConversionLogs.Store(log.LogTime, log)
 	srv.publishConvertionLogEvent(log)
 
-	if err := srv.createVideoTimeLine(logicalPath, int(rqst.Width), rqst.Fps, true); err != nil {
+	if err := srv.createVideoTimeLine(ctx, logicalPath, int(rqst.Width), rqst.Fps, true); err != nil {
 		log.Status = "fail"
 		srv.publishConvertionLogEvent(log)
 		srv.publishConvertionLogError(rqst.Path, err)
@@ -3169,26 +3031,7 @@ func (srv *server) ConvertVideoToMpeg4H264(ctx context.Context, rqst *mediapb.Co
 	}
 
 	convert := func(path string) error {
-		log := &mediapb.VideoConversionLog{
-			LogTime: time.Now().Unix(),
-			Msg:     "Convert video to mp4",
-			Path:    path,
-			Status:  "running",
-		}
-		srv.videoConversionLogs.Store(log.LogTime, log)
-		srv.publishConvertionLogEvent(log)
-
-		_, err := srv.createVideoMpeg4H264(path)
-		if err != nil {
-			srv.publishConvertionLogError(path, err)
-			log.Status = "fail"
-			srv.publishConvertionLogEvent(log)
-			return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-		}
-
-		log.Status = "done"
-		srv.publishConvertionLogEvent(log)
-		return nil
+		return srv.runMediaJobRequest(ctx, mediaJobSpec{Path: path, Operation: mediapb.MediaJobOperation_MEDIA_JOB_MP4})
 	}
 
 	if !info.IsDir {
@@ -3244,49 +3087,11 @@ func (srv *server) ConvertVideoToHls(ctx context.Context, rqst *mediapb.ConvertV
 	}
 
 	convertAndStream := func(path string) error {
-		// 1) Optional pre-conversion to MP4/H.264
+		// Pre-convert to MP4/H.264 first when needed; the HLS job follows it.
 		if needsPreconversion(path) {
-			log := &mediapb.VideoConversionLog{
-				LogTime: time.Now().Unix(),
-				Msg:     "Convert video to mp4",
-				Path:    path,
-				Status:  "running",
-			}
-			srv.videoConversionLogs.Store(log.LogTime, log)
-			srv.publishConvertionLogEvent(log)
-
-			newPath, err := srv.createVideoMpeg4H264(path)
-			if err != nil {
-				srv.publishConvertionLogError(path, err)
-				log.Status = "fail"
-				srv.publishConvertionLogEvent(log)
-				return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-			}
-			log.Status = "done"
-			srv.publishConvertionLogEvent(log)
-			path = newPath
+			return srv.runMediaJobRequest(ctx, mediaJobSpec{Path: path, Operation: mediapb.MediaJobOperation_MEDIA_JOB_MP4, StreamAfter: true})
 		}
-
-		// 2) HLS packaging
-		log := &mediapb.VideoConversionLog{
-			LogTime: time.Now().Unix(),
-			Msg:     "Convert video to stream",
-			Path:    path,
-			Status:  "running",
-		}
-		srv.videoConversionLogs.Store(log.LogTime, log)
-		srv.publishConvertionLogEvent(log)
-
-		if err := srv.createHlsStreamFromMpeg4H264(path); err != nil {
-			srv.publishConvertionLogError(path, err)
-			log.Status = "fail"
-			srv.publishConvertionLogEvent(log)
-			return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-		}
-
-		log.Status = "done"
-		srv.publishConvertionLogEvent(log)
-		return nil
+		return srv.runMediaJobRequest(ctx, mediaJobSpec{Path: path, Operation: mediapb.MediaJobOperation_MEDIA_JOB_HLS})
 	}
 
 	if !info.IsDir {
@@ -3741,7 +3546,7 @@ func (srv *server) uploadedVideo(
 			Pid:    pid,
 			Result: "remux to fast-start mp4: " + filepath.Base(tmpMedia),
 		})
-		if err := srv.ensureFastStartMP4(tmpMedia); err != nil {
+		if err := srv.ensureFastStartMP4(context.Background(), tmpMedia); err != nil {
 			_ = stream.Send(&mediapb.UploadVideoResponse{
 				Pid:    pid,
 				Result: "fast-start remux failed: " + err.Error(),
@@ -3790,13 +3595,8 @@ func (srv *server) uploadedVideo(
 				fmt.Println("fail to generate playlist with error ", err)
 			}
 
-			// Fire-and-forget: previews (handles both local and MinIO paths)
-			go func(target string) {
-				target = strings.ReplaceAll(target, "/.hidden/", "/")
-				_ = srv.createVideoPreview(target, 20, 128, false)
-				_ = srv.generateVideoPreview(target, 10, 320, 30, true)
-				_ = srv.createVideoTimeLine(target, 180, .2, false)
-			}(outLogical)
+			// Previews (handles both local and MinIO paths)
+			srv.queuePreviewJobs(strings.ReplaceAll(outLogical, "/.hidden/", "/"))
 		}
 
 	case "mp3":
@@ -3957,7 +3757,7 @@ func (srv *server) StartProcessAudio(ctx context.Context, rqst *mediapb.StartPro
 // It responds with a boolean value encapsulated in IsProcessVideoResponse.
 // This method implements the gRPC endpoint for checking video processing status.
 func (srv *server) IsProcessVideo(ctx context.Context, _ *mediapb.IsProcessVideoRequest) (*mediapb.IsProcessVideoResponse, error) {
-	return &mediapb.IsProcessVideoResponse{IsProcessVideo: srv.isProcessing}, nil
+	return &mediapb.IsProcessVideoResponse{IsProcessVideo: srv.jobs != nil && srv.jobs.running()}, nil
 }
 
 // StopProcessVideo stops the ongoing video processing by setting the isProcessing flag to false
@@ -3983,12 +3783,12 @@ func (srv *server) CreateVideoPreview(ctx context.Context, rqst *mediapb.CreateV
 	log := slog.With("path", rqst.Path)
 	log.Info("create video preview: start")
 
-	if err := srv.createVideoPreview(path, int(rqst.Nb), int(rqst.Height), true); err != nil {
+	if err := srv.createVideoPreview(ctx, path, int(rqst.Nb), int(rqst.Height), true); err != nil {
 		log.Error("create video preview: failed", "err", err)
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 
-	if err := srv.generateVideoPreview(path, 10, 320, 30, true); err != nil {
+	if err := srv.generateVideoPreview(ctx, path, 10, 320, 30, true); err != nil {
 		log.Error("generate gif preview: failed", "err", err)
 		srv.publishConvertionLogError(rqst.Path, err)
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
@@ -4026,13 +3826,11 @@ func (srv *server) GeneratePlaylist(ctx context.Context, rqst *mediapb.GenerateP
 
 // StartProcessVideo initiates the video processing workflow for the specified directories or path.
 // If no path is provided in the request, it processes videos in the public, user, and application directories.
-// The method checks if a video conversion is already running and returns an error if so.
-// Video processing is performed asynchronously in a goroutine. After processing, it regenerates playlists
-// and refreshes VTT (WebVTT) files for thumbnails in the affected directories.
+// Each directory is queued as a scan job, which in turn queues a job for every preview, timeline
+// and conversion its videos still need (see ListMediaJobs).
 // Returns an empty StartProcessVideoResponse on success, or an error if the operation cannot be started.
 func (srv *server) StartProcessVideo(ctx context.Context, rqst *mediapb.StartProcessVideoRequest) (*mediapb.StartProcessVideoResponse, error) {
-	_, token, err := security.GetClientId(ctx)
-	if err != nil {
+	if _, _, err := security.GetClientId(ctx); err != nil {
 		return nil, err
 	}
 
@@ -4052,15 +3850,13 @@ func (srv *server) StartProcessVideo(ctx context.Context, rqst *mediapb.StartPro
 		}
 	}
 
-	if srv.isProcessing {
-		srv.queueProcess(dirs)
-		return &mediapb.StartProcessVideoResponse{}, nil
+	if _, err := srv.jobQueue(); err != nil {
+		return nil, err
 	}
-
 	slog.With("dirs", strings.Join(dirs, ",")).Info("start process video")
-
-	go srv.processVideoLoop(token, dirs)
-
+	if err := srv.enqueueScans(dirs, mediaJobPriorityManual, false); err != nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	}
 	return &mediapb.StartProcessVideoResponse{}, nil
 }
 
@@ -4299,6 +4095,9 @@ func (srv *server) ClearVideoConversionErrors(ctx context.Context, rqst *mediapb
 		srv.videoConversionErrors.Delete(key)
 		return true
 	})
+	if srv.jobs != nil {
+		srv.jobs.forgetFailed("")
+	}
 
 	return &mediapb.ClearVideoConversionErrorsResponse{}, nil
 }
@@ -4306,6 +4105,9 @@ func (srv *server) ClearVideoConversionErrors(ctx context.Context, rqst *mediapb
 // Clear a specific video conversion error
 func (srv *server) ClearVideoConversionError(ctx context.Context, rqst *mediapb.ClearVideoConversionErrorRequest) (*mediapb.ClearVideoConversionErrorResponse, error) {
 	srv.videoConversionErrors.Delete(rqst.Path)
+	if srv.jobs != nil {
+		srv.jobs.forgetFailed(rqst.Path)
+	}
 	return &mediapb.ClearVideoConversionErrorResponse{}, nil
 }
 
@@ -4356,18 +4158,20 @@ func (srv *server) GetVideoConversionLogs(ctx context.Context, rqst *mediapb.Get
 }
 
 // processVideosScheduled is used by the scheduler (gocron).
-// It reconstructs the default directories and obtains a local token
-// before calling the main processVideoLoop.
+// It queues deferred scans of the default directories; their jobs only
+// start within the conversion window.
 func processVideosScheduled(s *server) {
-	// Best-effort local token; if it fails we still try with empty token.
-	token, _ := security.GetLocalToken(s.Mac)
-
+	if s.jobs == nil {
+		return
+	}
 	// Same roots as StartProcessVideo when rqst.Path == "".
 	dirs := append([]string{}, s.normalizeDirList(config.GetPublicDirs())...)
 	dirs = append(dirs, "/users")
 	dirs = append(dirs, "/applications")
 
-	s.processVideoLoop(token, dirs)
+	if err := s.enqueueScans(dirs, mediaJobPriorityScheduled, true); err != nil {
+		logger.Error("scheduled video scan failed", "err", err)
+	}
 }
 
 // Set the maximum delay when conversion can run, it will finish actual conversion but it will not begin new conversion past this delay.
@@ -4437,17 +4241,13 @@ func (srv *server) SetVideoStreamConversion(ctx context.Context, rqst *mediapb.S
 	return &mediapb.SetVideoStreamConversionResponse{}, nil
 }
 
-// Stop process video on the server.
+// Stop process video on the server: cancels the queued and running media jobs.
 func (srv *server) StopProcessVideo(ctx context.Context, rqst *mediapb.StopProcessVideoRequest) (*mediapb.StopProcessVideoResponse, error) {
 
-	srv.isProcessing = false
-
-	// kill current procession...
-	err := Utility.KillProcessByName("ffmpeg")
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+	// Canceling a running job stops its ffmpeg process.
+	if srv.jobs != nil {
+		n := srv.jobs.cancelAll()
+		logger.Info("stop process video", "canceled", n)
 	}
 
 	return &mediapb.StopProcessVideoResponse{}, nil
@@ -131,12 +131,20 @@ type server struct {
 	CacheReplicationFactor int
 	HasEnableGPU           bool
 
+	// HLS output (see hls.go)
+	HlsLadder       []HlsRendition // Renditions of converted streams; empty uses 1080/720/480/360p.
+	HlsAudioBitrate int            // kbit/s per audio rendition; 0 uses 128.
+	HlsDash         bool           // Write CMAF segments and a DASH manifest.mpd too.
+
+	// Processing jobs (see jobs.go)
+	MaxMediaJobs int // Jobs run at once; 0 uses 2.
+
 	videoConversionErrors *sync.Map
 	videoConversionLogs   *sync.Map
 	scheduler             *gocron.Scheduler
 	ffmpegTokens          chan struct{}
+	jobs                  *mediaJobQueue
 
-	isProcessing                bool
 	isProcessingAudio           bool
 	AutomaticVideoConversion    bool
 	AutomaticStreamConversion   bool
@@ -144,9 +152,6 @@ type server struct {
 	MaximumVideoConversionDelay string
 	restoreFailureMu            sync.Mutex
 	restoreFailures             map[string]time.Time
-	processPendingMu            sync.Mutex
-	processPending              bool
-	pendingProcessDirs          []string
 
 	MinioConfig *config.MinioProxyConfig
 
@@ -874,6 +879,7 @@ func main() {
 	srv.AutomaticVideoConversion = false
 	srv.MaximumVideoConversionDelay = "00:00"
 	srv.StartVideoConversionHour = "00:00"
+	srv.MaxMediaJobs = defaultMaxMediaJobs
 
 	// Register method→action mappings with the global resolver for interceptor use.
 	policy.GlobalResolver().Register([]policy.Permission{
@@ -899,6 +905,10 @@ func main() {
 		{Method: "/media.MediaService/ClearVideoConversionErrors", Action: "media.clearerrors"},
 		{Method: "/media.MediaService/ClearVideoConversionError", Action: "media.clearerror"},
 		{Method: "/media.MediaService/ClearVideoConversionLogs", Action: "media.clearlogs"},
+		{Method: "/media.MediaService/ListMediaJobs", Action: "media.jobs"},
+		{Method: "/media.MediaService/WatchMediaJob", Action: "media.jobs"},
+		{Method: "/media.MediaService/CancelMediaJob", Action: "media.jobs.manage"},
+		{Method: "/media.MediaService/ReprioritizeMediaJob", Action: "media.jobs.manage"},
 	})
 
 	// Handle --describe flag (requires minimal service setup, no config access)
@@ -1063,8 +1073,13 @@ func main() {
 		}
 	}()
 
+	// Processing jobs
+	if err := srv.startMediaJobs(context.Background()); err != nil {
+		logger.Error("media job queue failed to start", "err", err)
+	}
+
 	// Schedule automatic video conversions
-	srv.scheduler.Every(1).Day().At(srv.StartVideoConversionHour).Do(processVideos, srv)
+	srv.scheduler.Every(1).Day().At(srv.StartVideoConversionHour).Do(processVideosScheduled, srv)
 	if srv.AutomaticVideoConversion {
 		srv.scheduler.Start()
 	}
@@ -195,6 +195,12 @@ func detectContentType(path string) string {
 		return "video/mp4"
 	case strings.HasSuffix(lower, ".m3u8"):
 		return "application/x-mpegURL"
+	case strings.HasSuffix(lower, ".mpd"):
+		return "application/dash+xml"
+	case strings.HasSuffix(lower, ".ts"):
+		return "video/mp2t"
+	case strings.HasSuffix(lower, ".m4s"):
+		return "video/iso.segment"
 	case strings.HasSuffix(lower, ".vtt"):
 		return "text/vtt"
 	case strings.HasSuffix(lower, ".json"):
@@ -22,6 +22,120 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// What a media job does to its path.
+type MediaJobOperation int32
+
+const (
+	MediaJobOperation_MEDIA_JOB_SCAN      MediaJobOperation = 0 // Index a directory and queue the jobs its videos need.
+	MediaJobOperation_MEDIA_JOB_MP4       MediaJobOperation = 1 // Convert to MP4/H.264.
+	MediaJobOperation_MEDIA_JOB_HLS       MediaJobOperation = 2 // Convert an MP4 to an HLS stream folder.
+	MediaJobOperation_MEDIA_JOB_PREVIEW   MediaJobOperation = 3 // Fast-start, preview images and animated preview.
+	MediaJobOperation_MEDIA_JOB_TIMELINE  MediaJobOperation = 4 // Timeline thumbnails and their VTT file.
+	MediaJobOperation_MEDIA_JOB_SUBTITLES MediaJobOperation = 5 // Extract text subtitle tracks to VTT files.
+)
+
+// Enum value maps for MediaJobOperation.
+var (
+	MediaJobOperation_name = map[int32]string{
+		0: "MEDIA_JOB_SCAN",
+		1: "MEDIA_JOB_MP4",
+		2: "MEDIA_JOB_HLS",
+		3: "MEDIA_JOB_PREVIEW",
+		4: "MEDIA_JOB_TIMELINE",
+		5: "MEDIA_JOB_SUBTITLES",
+	}
+	MediaJobOperation_value = map[string]int32{
+		"MEDIA_JOB_SCAN":      0,
+		"MEDIA_JOB_MP4":       1,
+		"MEDIA_JOB_HLS":       2,
+		"MEDIA_JOB_PREVIEW":   3,
+		"MEDIA_JOB_TIMELINE":  4,
+		"MEDIA_JOB_SUBTITLES": 5,
+	}
+)
+
+func (x MediaJobOperation) Enum() *MediaJobOperation {
+	p := new(MediaJobOperation)
+	*p = x
+	return p
+}
+
+func (x MediaJobOperation) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaJobOperation) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_proto_enumTypes[0].Descriptor()
+}
+
+func (MediaJobOperation) Type() protoreflect.EnumType {
+	return &file_media_proto_enumTypes[0]
+}
+
+func (x MediaJobOperation) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MediaJobOperation.Descriptor instead.
+func (MediaJobOperation) EnumDescriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{0}
+}
+
+type MediaJobState int32
+
+const (
+	MediaJobState_MEDIA_JOB_QUEUED   MediaJobState = 0
+	MediaJobState_MEDIA_JOB_RUNNING  MediaJobState = 1
+	MediaJobState_MEDIA_JOB_DONE     MediaJobState = 2
+	MediaJobState_MEDIA_JOB_FAILED   MediaJobState = 3 // Failed its last attempt.
+	MediaJobState_MEDIA_JOB_CANCELED MediaJobState = 4
+)
+
+// Enum value maps for MediaJobState.
+var (
+	MediaJobState_name = map[int32]string{
+		0: "MEDIA_JOB_QUEUED",
+		1: "MEDIA_JOB_RUNNING",
+		2: "MEDIA_JOB_DONE",
+		3: "MEDIA_JOB_FAILED",
+		4: "MEDIA_JOB_CANCELED",
+	}
+	MediaJobState_value = map[string]int32{
+		"MEDIA_JOB_QUEUED":   0,
+		"MEDIA_JOB_RUNNING":  1,
+		"MEDIA_JOB_DONE":     2,
+		"MEDIA_JOB_FAILED":   3,
+		"MEDIA_JOB_CANCELED": 4,
+	}
+)
+
+func (x MediaJobState) Enum() *MediaJobState {
+	p := new(MediaJobState)
+	*p = x
+	return p
+}
+
+func (x MediaJobState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (MediaJobState) Descriptor() protoreflect.EnumDescriptor {
+	return file_media_proto_enumTypes[1].Descriptor()
+}
+
+func (MediaJobState) Type() protoreflect.EnumType {
+	return &file_media_proto_enumTypes[1]
+}
+
+func (x MediaJobState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use MediaJobState.Descriptor instead.
+func (MediaJobState) EnumDescriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{1}
+}
+
 // Request to create a video preview.
 type CreateVideoPreviewRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2534,6 +2648,559 @@ func (x *ListChannelsResponse) GetChannels() []*Channel {
 	return nil
 }
 
+// A unit of media processing: one operation on one file or directory.
+// Jobs are kept on disk and survive restarts; a job running when the
+// service stopped is queued again.
+type MediaJob struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`
+	Operation     MediaJobOperation      `protobuf:"varint,3,opt,name=operation,proto3,enum=media.MediaJobOperation" json:"operation,omitempty"`
+	State         MediaJobState          `protobuf:"varint,4,opt,name=state,proto3,enum=media.MediaJobState" json:"state,omitempty"`
+	Priority      int32                  `protobuf:"varint,5,opt,name=priority,proto3" json:"priority,omitempty"` // Higher runs first.
+	Attempts      int32                  `protobuf:"varint,6,opt,name=attempts,proto3" json:"attempts,omitempty"` // Attempts started so far.
+	MaxAttempts   int32                  `protobuf:"varint,7,opt,name=max_attempts,json=maxAttempts,proto3" json:"max_attempts,omitempty"`
+	Error         string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`                            // Error of the last failed attempt.
+	Progress      float64                `protobuf:"fixed64,9,opt,name=progress,proto3" json:"progress,omitempty"`                    // Percent of the running attempt, 0-100.
+	CreatedAt     int64                  `protobuf:"varint,10,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"` // Unix seconds.
+	UpdatedAt     int64                  `protobuf:"varint,11,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	StartedAt     int64                  `protobuf:"varint,12,opt,name=started_at,json=startedAt,proto3" json:"started_at,omitempty"` // Start of the last attempt.
+	FinishedAt    int64                  `protobuf:"varint,13,opt,name=finished_at,json=finishedAt,proto3" json:"finished_at,omitempty"`
+	NextAttemptAt int64                  `protobuf:"varint,14,opt,name=next_attempt_at,json=nextAttemptAt,proto3" json:"next_attempt_at,omitempty"` // Not started before, after a failed attempt.
+	Deferred      bool                   `protobuf:"varint,15,opt,name=deferred,proto3" json:"deferred,omitempty"`                                  // Queued by the scheduled scan; only starts within the conversion window.
+	After         string                 `protobuf:"bytes,16,opt,name=after,proto3" json:"after,omitempty"`                                         // Id of a job that must end first.
+	StreamAfter   bool                   `protobuf:"varint,17,opt,name=stream_after,json=streamAfter,proto3" json:"stream_after,omitempty"`         // MP4 job: queue the HLS conversion of the result when done.
+	NextJobId     string                 `protobuf:"bytes,18,opt,name=next_job_id,json=nextJobId,proto3" json:"next_job_id,omitempty"`              // Job queued by this one when done, e.g. the HLS conversion.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *MediaJob) Reset() {
+	*x = MediaJob{}
+	mi := &file_media_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *MediaJob) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*MediaJob) ProtoMessage() {}
+
+func (x *MediaJob) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use MediaJob.ProtoReflect.Descriptor instead.
+func (*MediaJob) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *MediaJob) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *MediaJob) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *MediaJob) GetOperation() MediaJobOperation {
+	if x != nil {
+		return x.Operation
+	}
+	return MediaJobOperation_MEDIA_JOB_SCAN
+}
+
+func (x *MediaJob) GetState() MediaJobState {
+	if x != nil {
+		return x.State
+	}
+	return MediaJobState_MEDIA_JOB_QUEUED
+}
+
+func (x *MediaJob) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+func (x *MediaJob) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *MediaJob) GetMaxAttempts() int32 {
+	if x != nil {
+		return x.MaxAttempts
+	}
+	return 0
+}
+
+func (x *MediaJob) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *MediaJob) GetProgress() float64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *MediaJob) GetCreatedAt() int64 {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return 0
+}
+
+func (x *MediaJob) GetUpdatedAt() int64 {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return 0
+}
+
+func (x *MediaJob) GetStartedAt() int64 {
+	if x != nil {
+		return x.StartedAt
+	}
+	return 0
+}
+
+func (x *MediaJob) GetFinishedAt() int64 {
+	if x != nil {
+		return x.FinishedAt
+	}
+	return 0
+}
+
+func (x *MediaJob) GetNextAttemptAt() int64 {
+	if x != nil {
+		return x.NextAttemptAt
+	}
+	return 0
+}
+
+func (x *MediaJob) GetDeferred() bool {
+	if x != nil {
+		return x.Deferred
+	}
+	return false
+}
+
+func (x *MediaJob) GetAfter() string {
+	if x != nil {
+		return x.After
+	}
+	return ""
+}
+
+func (x *MediaJob) GetStreamAfter() bool {
+	if x != nil {
+		return x.StreamAfter
+	}
+	return false
+}
+
+func (x *MediaJob) GetNextJobId() string {
+	if x != nil {
+		return x.NextJobId
+	}
+	return ""
+}
+
+type ListMediaJobsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	States        []MediaJobState        `protobuf:"varint,1,rep,packed,name=states,proto3,enum=media.MediaJobState" json:"states,omitempty"` // Empty lists every state.
+	Path          string                 `protobuf:"bytes,2,opt,name=path,proto3" json:"path,omitempty"`                                      // Optional path prefix.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMediaJobsRequest) Reset() {
+	*x = ListMediaJobsRequest{}
+	mi := &file_media_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMediaJobsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMediaJobsRequest) ProtoMessage() {}
+
+func (x *ListMediaJobsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMediaJobsRequest.ProtoReflect.Descriptor instead.
+func (*ListMediaJobsRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *ListMediaJobsRequest) GetStates() []MediaJobState {
+	if x != nil {
+		return x.States
+	}
+	return nil
+}
+
+func (x *ListMediaJobsRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type ListMediaJobsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Jobs          []*MediaJob            `protobuf:"bytes,1,rep,name=jobs,proto3" json:"jobs,omitempty"` // Running first, then by priority and age.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMediaJobsResponse) Reset() {
+	*x = ListMediaJobsResponse{}
+	mi := &file_media_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMediaJobsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMediaJobsResponse) ProtoMessage() {}
+
+func (x *ListMediaJobsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMediaJobsResponse.ProtoReflect.Descriptor instead.
+func (*ListMediaJobsResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *ListMediaJobsResponse) GetJobs() []*MediaJob {
+	if x != nil {
+		return x.Jobs
+	}
+	return nil
+}
+
+type WatchMediaJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMediaJobRequest) Reset() {
+	*x = WatchMediaJobRequest{}
+	mi := &file_media_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMediaJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMediaJobRequest) ProtoMessage() {}
+
+func (x *WatchMediaJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMediaJobRequest.ProtoReflect.Descriptor instead.
+func (*WatchMediaJobRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *WatchMediaJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+// WatchMediaJob streams the job on every change, progress included, and
+// ends once the job is done, failed or canceled.
+type WatchMediaJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *MediaJob              `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchMediaJobResponse) Reset() {
+	*x = WatchMediaJobResponse{}
+	mi := &file_media_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchMediaJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchMediaJobResponse) ProtoMessage() {}
+
+func (x *WatchMediaJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchMediaJobResponse.ProtoReflect.Descriptor instead.
+func (*WatchMediaJobResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *WatchMediaJobResponse) GetJob() *MediaJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type CancelMediaJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMediaJobRequest) Reset() {
+	*x = CancelMediaJobRequest{}
+	mi := &file_media_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMediaJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMediaJobRequest) ProtoMessage() {}
+
+func (x *CancelMediaJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMediaJobRequest.ProtoReflect.Descriptor instead.
+func (*CancelMediaJobRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{59}
+}
+
+func (x *CancelMediaJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type CancelMediaJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *MediaJob              `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CancelMediaJobResponse) Reset() {
+	*x = CancelMediaJobResponse{}
+	mi := &file_media_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CancelMediaJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CancelMediaJobResponse) ProtoMessage() {}
+
+func (x *CancelMediaJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CancelMediaJobResponse.ProtoReflect.Descriptor instead.
+func (*CancelMediaJobResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{60}
+}
+
+func (x *CancelMediaJobResponse) GetJob() *MediaJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
+type ReprioritizeMediaJobRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Priority      int32                  `protobuf:"varint,2,opt,name=priority,proto3" json:"priority,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReprioritizeMediaJobRequest) Reset() {
+	*x = ReprioritizeMediaJobRequest{}
+	mi := &file_media_proto_msgTypes[61]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReprioritizeMediaJobRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprioritizeMediaJobRequest) ProtoMessage() {}
+
+func (x *ReprioritizeMediaJobRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[61]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprioritizeMediaJobRequest.ProtoReflect.Descriptor instead.
+func (*ReprioritizeMediaJobRequest) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{61}
+}
+
+func (x *ReprioritizeMediaJobRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ReprioritizeMediaJobRequest) GetPriority() int32 {
+	if x != nil {
+		return x.Priority
+	}
+	return 0
+}
+
+type ReprioritizeMediaJobResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Job           *MediaJob              `protobuf:"bytes,1,opt,name=job,proto3" json:"job,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReprioritizeMediaJobResponse) Reset() {
+	*x = ReprioritizeMediaJobResponse{}
+	mi := &file_media_proto_msgTypes[62]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReprioritizeMediaJobResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReprioritizeMediaJobResponse) ProtoMessage() {}
+
+func (x *ReprioritizeMediaJobResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_media_proto_msgTypes[62]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReprioritizeMediaJobResponse.ProtoReflect.Descriptor instead.
+func (*ReprioritizeMediaJobResponse) Descriptor() ([]byte, []int) {
+	return file_media_proto_rawDescGZIP(), []int{62}
+}
+
+func (x *ReprioritizeMediaJobResponse) GetJob() *MediaJob {
+	if x != nil {
+		return x.Job
+	}
+	return nil
+}
+
 var File_media_proto protoreflect.FileDescriptor
 
 const file_media_proto_rawDesc = "" +
@@ -2679,7 +3346,62 @@ const file_media_proto_rawDesc = "" +
 	"\x04path\x10\x01R\x04path\x12\x1c\n" +
 	"\textractor\x18\x02 \x01(\tR\textractor\"B\n" +
 	"\x14ListChannelsResponse\x12*\n" +
-	"\bchannels\x18\x01 \x03(\v2\x0e.media.ChannelR\bchannels2\xa7\x1d\n" +
+	"\bchannels\x18\x01 \x03(\v2\x0e.media.ChannelR\bchannels\"\xba\x04\n" +
+	"\bMediaJob\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\x126\n" +
+	"\toperation\x18\x03 \x01(\x0e2\x18.media.MediaJobOperationR\toperation\x12*\n" +
+	"\x05state\x18\x04 \x01(\x0e2\x14.media.MediaJobStateR\x05state\x12\x1a\n" +
+	"\bpriority\x18\x05 \x01(\x05R\bpriority\x12\x1a\n" +
+	"\battempts\x18\x06 \x01(\x05R\battempts\x12!\n" +
+	"\fmax_attempts\x18\a \x01(\x05R\vmaxAttempts\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12\x1a\n" +
+	"\bprogress\x18\t \x01(\x01R\bprogress\x12\x1d\n" +
+	"\n" +
+	"created_at\x18\n" +
+	" \x01(\x03R\tcreatedAt\x12\x1d\n" +
+	"\n" +
+	"updated_at\x18\v \x01(\x03R\tupdatedAt\x12\x1d\n" +
+	"\n" +
+	"started_at\x18\f \x01(\x03R\tstartedAt\x12\x1f\n" +
+	"\vfinished_at\x18\r \x01(\x03R\n" +
+	"finishedAt\x12&\n" +
+	"\x0fnext_attempt_at\x18\x0e \x01(\x03R\rnextAttemptAt\x12\x1a\n" +
+	"\bdeferred\x18\x0f \x01(\bR\bdeferred\x12\x14\n" +
+	"\x05after\x18\x10 \x01(\tR\x05after\x12!\n" +
+	"\fstream_after\x18\x11 \x01(\bR\vstreamAfter\x12\x1e\n" +
+	"\vnext_job_id\x18\x12 \x01(\tR\tnextJobId\"X\n" +
+	"\x14ListMediaJobsRequest\x12,\n" +
+	"\x06states\x18\x01 \x03(\x0e2\x14.media.MediaJobStateR\x06states\x12\x12\n" +
+	"\x04path\x18\x02 \x01(\tR\x04path\"<\n" +
+	"\x15ListMediaJobsResponse\x12#\n" +
+	"\x04jobs\x18\x01 \x03(\v2\x0f.media.MediaJobR\x04jobs\"&\n" +
+	"\x14WatchMediaJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\":\n" +
+	"\x15WatchMediaJobResponse\x12!\n" +
+	"\x03job\x18\x01 \x01(\v2\x0f.media.MediaJobR\x03job\"'\n" +
+	"\x15CancelMediaJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\";\n" +
+	"\x16CancelMediaJobResponse\x12!\n" +
+	"\x03job\x18\x01 \x01(\v2\x0f.media.MediaJobR\x03job\"I\n" +
+	"\x1bReprioritizeMediaJobRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x1a\n" +
+	"\bpriority\x18\x02 \x01(\x05R\bpriority\"A\n" +
+	"\x1cReprioritizeMediaJobResponse\x12!\n" +
+	"\x03job\x18\x01 \x01(\v2\x0f.media.MediaJobR\x03job*\x95\x01\n" +
+	"\x11MediaJobOperation\x12\x12\n" +
+	"\x0eMEDIA_JOB_SCAN\x10\x00\x12\x11\n" +
+	"\rMEDIA_JOB_MP4\x10\x01\x12\x11\n" +
+	"\rMEDIA_JOB_HLS\x10\x02\x12\x15\n" +
+	"\x11MEDIA_JOB_PREVIEW\x10\x03\x12\x16\n" +
+	"\x12MEDIA_JOB_TIMELINE\x10\x04\x12\x17\n" +
+	"\x13MEDIA_JOB_SUBTITLES\x10\x05*~\n" +
+	"\rMediaJobState\x12\x14\n" +
+	"\x10MEDIA_JOB_QUEUED\x10\x00\x12\x15\n" +
+	"\x11MEDIA_JOB_RUNNING\x10\x01\x12\x12\n" +
+	"\x0eMEDIA_JOB_DONE\x10\x02\x12\x14\n" +
+	"\x10MEDIA_JOB_FAILED\x10\x03\x12\x16\n" +
+	"\x12MEDIA_JOB_CANCELED\x10\x042\xc4!\n" +
 	"\fMediaService\x12W\n" +
 	"\x04Stop\x12\x12.media.StopRequest\x1a\x13.media.StopResponse\"&\x82\xb5\x18\"\n" +
 	"\n" +
@@ -2735,7 +3457,17 @@ const file_media_proto_rawDesc = "" +
 	"media.list\x12\x04read\x1a\x14/media/channels/{id}*\x06viewer\x12x\n" +
 	"\fListChannels\x12\x1a.media.ListChannelsRequest\x1a\x1b.media.ListChannelsResponse\"/\x82\xb5\x18+\n" +
 	"\n" +
-	"media.list\x12\x04read\"\x0f/media/channels*\x06viewerB5Z3github.com/globulario/services/golang/media/mediapbb\x06proto3"
+	"media.list\x12\x04read\"\x0f/media/channels*\x06viewer\x12w\n" +
+	"\rListMediaJobs\x12\x1b.media.ListMediaJobsRequest\x1a\x1c.media.ListMediaJobsResponse\"+\x82\xb5\x18'\n" +
+	"\n" +
+	"media.jobs\x12\x04read\"\v/media/jobs*\x06viewer\x12~\n" +
+	"\rWatchMediaJob\x12\x1b.media.WatchMediaJobRequest\x1a\x1c.media.WatchMediaJobResponse\"0\x82\xb5\x18,\n" +
+	"\n" +
+	"media.jobs\x12\x04read\x1a\x10/media/jobs/{id}*\x06viewer0\x01\x12\x86\x01\n" +
+	"\x0eCancelMediaJob\x12\x1c.media.CancelMediaJobRequest\x1a\x1d.media.CancelMediaJobResponse\"7\x82\xb5\x183\n" +
+	"\x11media.jobs.manage\x12\x05admin\x1a\x10/media/jobs/{id}*\x05admin\x12\x98\x01\n" +
+	"\x14ReprioritizeMediaJob\x12\".media.ReprioritizeMediaJobRequest\x1a#.media.ReprioritizeMediaJobResponse\"7\x82\xb5\x183\n" +
+	"\x11media.jobs.manage\x12\x05admin\x1a\x10/media/jobs/{id}*\x05adminB5Z3github.com/globulario/services/golang/media/mediapbb\x06proto3"
 
 var (
 	file_media_proto_rawDescOnce sync.Once
@@ -2749,125 +3481,152 @@ func file_media_proto_rawDescGZIP() []byte {
 	return file_media_proto_rawDescData
 }
 
-var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 54)
+var file_media_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_media_proto_msgTypes = make([]protoimpl.MessageInfo, 63)
 var file_media_proto_goTypes = []any{
-	(*CreateVideoPreviewRequest)(nil),              // 0: media.CreateVideoPreviewRequest
-	(*CreateVideoPreviewResponse)(nil),             // 1: media.CreateVideoPreviewResponse
-	(*CreateVideoTimeLineRequest)(nil),             // 2: media.CreateVideoTimeLineRequest
-	(*CreateVideoTimeLineResponse)(nil),            // 3: media.CreateVideoTimeLineResponse
-	(*ConvertVideoToMpeg4H264Request)(nil),         // 4: media.ConvertVideoToMpeg4H264Request
-	(*ConvertVideoToMpeg4H264Response)(nil),        // 5: media.ConvertVideoToMpeg4H264Response
-	(*ConvertVideoToHlsRequest)(nil),               // 6: media.ConvertVideoToHlsRequest
-	(*ConvertVideoToHlsResponse)(nil),              // 7: media.ConvertVideoToHlsResponse
-	(*StartProcessVideoRequest)(nil),               // 8: media.StartProcessVideoRequest
-	(*StartProcessVideoResponse)(nil),              // 9: media.StartProcessVideoResponse
-	(*StartProcessAudioRequest)(nil),               // 10: media.StartProcessAudioRequest
-	(*StartProcessAudioResponse)(nil),              // 11: media.StartProcessAudioResponse
-	(*IsProcessVideoRequest)(nil),                  // 12: media.IsProcessVideoRequest
-	(*IsProcessVideoResponse)(nil),                 // 13: media.IsProcessVideoResponse
-	(*UploadVideoRequest)(nil),                     // 14: media.UploadVideoRequest
-	(*UploadVideoResponse)(nil),                    // 15: media.UploadVideoResponse
-	(*StopProcessVideoRequest)(nil),                // 16: media.StopProcessVideoRequest
-	(*StopProcessVideoResponse)(nil),               // 17: media.StopProcessVideoResponse
-	(*SetVideoConversionRequest)(nil),              // 18: media.SetVideoConversionRequest
-	(*SetVideoConversionResponse)(nil),             // 19: media.SetVideoConversionResponse
-	(*SetVideoStreamConversionRequest)(nil),        // 20: media.SetVideoStreamConversionRequest
-	(*SetVideoStreamConversionResponse)(nil),       // 21: media.SetVideoStreamConversionResponse
-	(*SetStartVideoConversionHourRequest)(nil),     // 22: media.SetStartVideoConversionHourRequest
-	(*SetStartVideoConversionHourResponse)(nil),    // 23: media.SetStartVideoConversionHourResponse
-	(*SetMaximumVideoConversionDelayRequest)(nil),  // 24: media.SetMaximumVideoConversionDelayRequest
-	(*SetMaximumVideoConversionDelayResponse)(nil), // 25: media.SetMaximumVideoConversionDelayResponse
-	(*VideoConversionError)(nil),                   // 26: media.VideoConversionError
-	(*GetVideoConversionErrorsRequest)(nil),        // 27: media.GetVideoConversionErrorsRequest
-	(*GetVideoConversionErrorsResponse)(nil),       // 28: media.GetVideoConversionErrorsResponse
-	(*ClearVideoConversionErrorsRequest)(nil),      // 29: media.ClearVideoConversionErrorsRequest
-	(*ClearVideoConversionErrorsResponse)(nil),     // 30: media.ClearVideoConversionErrorsResponse
-	(*ClearVideoConversionErrorRequest)(nil),       // 31: media.ClearVideoConversionErrorRequest
-	(*ClearVideoConversionErrorResponse)(nil),      // 32: media.ClearVideoConversionErrorResponse
-	(*ClearVideoConversionLogsRequest)(nil),        // 33: media.ClearVideoConversionLogsRequest
-	(*ClearVideoConversionLogsResponse)(nil),       // 34: media.ClearVideoConversionLogsResponse
-	(*VideoConversionLog)(nil),                     // 35: media.VideoConversionLog
-	(*GetVideoConversionLogsRequest)(nil),          // 36: media.GetVideoConversionLogsRequest
-	(*GetVideoConversionLogsResponse)(nil),         // 37: media.GetVideoConversionLogsResponse
-	(*GeneratePlaylistRequest)(nil),                // 38: media.GeneratePlaylistRequest
-	(*GeneratePlaylistResponse)(nil),               // 39: media.GeneratePlaylistResponse
-	(*CreateVttFileRequest)(nil),                   // 40: media.CreateVttFileRequest
-	(*CreateVttFileResponse)(nil),                  // 41: media.CreateVttFileResponse
-	(*ListMediaFilesRequest)(nil),                  // 42: media.ListMediaFilesRequest
-	(*MediaFile)(nil),                              // 43: media.MediaFile
-	(*StopRequest)(nil),                            // 44: media.StopRequest
-	(*StopResponse)(nil),                           // 45: media.StopResponse
-	(*ChannelItem)(nil),                            // 46: media.ChannelItem
-	(*Channel)(nil),                                // 47: media.Channel
-	(*SyncChannelFromPlaylistRequest)(nil),         // 48: media.SyncChannelFromPlaylistRequest
-	(*SyncChannelFromPlaylistResponse)(nil),        // 49: media.SyncChannelFromPlaylistResponse
-	(*GetChannelRequest)(nil),                      // 50: media.GetChannelRequest
-	(*GetChannelResponse)(nil),                     // 51: media.GetChannelResponse
-	(*ListChannelsRequest)(nil),                    // 52: media.ListChannelsRequest
-	(*ListChannelsResponse)(nil),                   // 53: media.ListChannelsResponse
+	(MediaJobOperation)(0),                         // 0: media.MediaJobOperation
+	(MediaJobState)(0),                             // 1: media.MediaJobState
+	(*CreateVideoPreviewRequest)(nil),              // 2: media.CreateVideoPreviewRequest
+	(*CreateVideoPreviewResponse)(nil),             // 3: media.CreateVideoPreviewResponse
+	(*CreateVideoTimeLineRequest)(nil),             // 4: media.CreateVideoTimeLineRequest
+	(*CreateVideoTimeLineResponse)(nil),            // 5: media.CreateVideoTimeLineResponse
+	(*ConvertVideoToMpeg4H264Request)(nil),         // 6: media.ConvertVideoToMpeg4H264Request
+	(*ConvertVideoToMpeg4H264Response)(nil),        // 7: media.ConvertVideoToMpeg4H264Response
+	(*ConvertVideoToHlsRequest)(nil),               // 8: media.ConvertVideoToHlsRequest
+	(*ConvertVideoToHlsResponse)(nil),              // 9: media.ConvertVideoToHlsResponse
+	(*StartProcessVideoRequest)(nil),               // 10: media.StartProcessVideoRequest
+	(*StartProcessVideoResponse)(nil),              // 11: media.StartProcessVideoResponse
+	(*StartProcessAudioRequest)(nil),               // 12: media.StartProcessAudioRequest
+	(*StartProcessAudioResponse)(nil),              // 13: media.StartProcessAudioResponse
+	(*IsProcessVideoRequest)(nil),                  // 14: media.IsProcessVideoRequest
+	(*IsProcessVideoResponse)(nil),                 // 15: media.IsProcessVideoResponse
+	(*UploadVideoRequest)(nil),                     // 16: media.UploadVideoRequest
+	(*UploadVideoResponse)(nil),                    // 17: media.UploadVideoResponse
+	(*StopProcessVideoRequest)(nil),                // 18: media.StopProcessVideoRequest
+	(*StopProcessVideoResponse)(nil),               // 19: media.StopProcessVideoResponse
+	(*SetVideoConversionRequest)(nil),              // 20: media.SetVideoConversionRequest
+	(*SetVideoConversionResponse)(nil),             // 21: media.SetVideoConversionResponse
+	(*SetVideoStreamConversionRequest)(nil),        // 22: media.SetVideoStreamConversionRequest
+	(*SetVideoStreamConversionResponse)(nil),       // 23: media.SetVideoStreamConversionResponse
+	(*SetStartVideoConversionHourRequest)(nil),     // 24: media.SetStartVideoConversionHourRequest
+	(*SetStartVideoConversionHourResponse)(nil),    // 25: media.SetStartVideoConversionHourResponse
+	(*SetMaximumVideoConversionDelayRequest)(nil),  // 26: media.SetMaximumVideoConversionDelayRequest
+	(*SetMaximumVideoConversionDelayResponse)(nil), // 27: media.SetMaximumVideoConversionDelayResponse
+	(*VideoConversionError)(nil),                   // 28: media.VideoConversionError
+	(*GetVideoConversionErrorsRequest)(nil),        // 29: media.GetVideoConversionErrorsRequest
+	(*GetVideoConversionErrorsResponse)(nil),       // 30: media.GetVideoConversionErrorsResponse
+	(*ClearVideoConversionErrorsRequest)(nil),      // 31: media.ClearVideoConversionErrorsRequest
+	(*ClearVideoConversionErrorsResponse)(nil),     // 32: media.ClearVideoConversionErrorsResponse
+	(*ClearVideoConversionErrorRequest)(nil),       // 33: media.ClearVideoConversionErrorRequest
+	(*ClearVideoConversionErrorResponse)(nil),      // 34: media.ClearVideoConversionErrorResponse
+	(*ClearVideoConversionLogsRequest)(nil),        // 35: media.ClearVideoConversionLogsRequest
+	(*ClearVideoConversionLogsResponse)(nil),       // 36: media.ClearVideoConversionLogsResponse
+	(*VideoConversionLog)(nil),                     // 37: media.VideoConversionLog
+	(*GetVideoConversionLogsRequest)(nil),          // 38: media.GetVideoConversionLogsRequest
+	(*GetVideoConversionLogsResponse)(nil),         // 39: media.GetVideoConversionLogsResponse
+	(*GeneratePlaylistRequest)(nil),                // 40: media.GeneratePlaylistRequest
+	(*GeneratePlaylistResponse)(nil),               // 41: media.GeneratePlaylistResponse
+	(*CreateVttFileRequest)(nil),                   // 42: media.CreateVttFileRequest
+	(*CreateVttFileResponse)(nil),                  // 43: media.CreateVttFileResponse
+	(*ListMediaFilesRequest)(nil),                  // 44: media.ListMediaFilesRequest
+	(*MediaFile)(nil),                              // 45: media.MediaFile
+	(*StopRequest)(nil),                            // 46: media.StopRequest
+	(*StopResponse)(nil),                           // 47: media.StopResponse
+	(*ChannelItem)(nil),                            // 48: media.ChannelItem
+	(*Channel)(nil),                                // 49: media.Channel
+	(*SyncChannelFromPlaylistRequest)(nil),         // 50: media.SyncChannelFromPlaylistRequest
+	(*SyncChannelFromPlaylistResponse)(nil),        // 51: media.SyncChannelFromPlaylistResponse
+	(*GetChannelRequest)(nil),                      // 52: media.GetChannelRequest
+	(*GetChannelResponse)(nil),                     // 53: media.GetChannelResponse
+	(*ListChannelsRequest)(nil),                    // 54: media.ListChannelsRequest
+	(*ListChannelsResponse)(nil),                   // 55: media.ListChannelsResponse
+	(*MediaJob)(nil),                               // 56: media.MediaJob
+	(*ListMediaJobsRequest)(nil),                   // 57: media.ListMediaJobsRequest
+	(*ListMediaJobsResponse)(nil),                  // 58: media.ListMediaJobsResponse
+	(*WatchMediaJobRequest)(nil),                   // 59: media.WatchMediaJobRequest
+	(*WatchMediaJobResponse)(nil),                  // 60: media.WatchMediaJobResponse
+	(*CancelMediaJobRequest)(nil),                  // 61: media.CancelMediaJobRequest
+	(*CancelMediaJobResponse)(nil),                 // 62: media.CancelMediaJobResponse
+	(*ReprioritizeMediaJobRequest)(nil),            // 63: media.ReprioritizeMediaJobRequest
+	(*ReprioritizeMediaJobResponse)(nil),           // 64: media.ReprioritizeMediaJobResponse
 }
 var file_media_proto_depIdxs = []int32{
-	26, // 0: media.GetVideoConversionErrorsResponse.errors:type_name -> media.VideoConversionError
-	35, // 1: media.GetVideoConversionLogsResponse.logs:type_name -> media.VideoConversionLog
-	46, // 2: media.Channel.items:type_name -> media.ChannelItem
-	47, // 3: media.SyncChannelFromPlaylistResponse.channel:type_name -> media.Channel
-	47, // 4: media.GetChannelResponse.channel:type_name -> media.Channel
-	47, // 5: media.ListChannelsResponse.channels:type_name -> media.Channel
-	44, // 6: media.MediaService.Stop:input_type -> media.StopRequest
-	14, // 7: media.MediaService.UploadVideo:input_type -> media.UploadVideoRequest
-	0,  // 8: media.MediaService.CreateVideoPreview:input_type -> media.CreateVideoPreviewRequest
-	2,  // 9: media.MediaService.CreateVideoTimeLine:input_type -> media.CreateVideoTimeLineRequest
-	4,  // 10: media.MediaService.ConvertVideoToMpeg4H264:input_type -> media.ConvertVideoToMpeg4H264Request
-	6,  // 11: media.MediaService.ConvertVideoToHls:input_type -> media.ConvertVideoToHlsRequest
-	8,  // 12: media.MediaService.StartProcessVideo:input_type -> media.StartProcessVideoRequest
-	10, // 13: media.MediaService.StartProcessAudio:input_type -> media.StartProcessAudioRequest
-	16, // 14: media.MediaService.StopProcessVideo:input_type -> media.StopProcessVideoRequest
-	12, // 15: media.MediaService.IsProcessVideo:input_type -> media.IsProcessVideoRequest
-	18, // 16: media.MediaService.SetVideoConversion:input_type -> media.SetVideoConversionRequest
-	20, // 17: media.MediaService.SetVideoStreamConversion:input_type -> media.SetVideoStreamConversionRequest
-	22, // 18: media.MediaService.SetStartVideoConversionHour:input_type -> media.SetStartVideoConversionHourRequest
-	24, // 19: media.MediaService.SetMaximumVideoConversionDelay:input_type -> media.SetMaximumVideoConversionDelayRequest
-	27, // 20: media.MediaService.GetVideoConversionErrors:input_type -> media.GetVideoConversionErrorsRequest
-	29, // 21: media.MediaService.ClearVideoConversionErrors:input_type -> media.ClearVideoConversionErrorsRequest
-	31, // 22: media.MediaService.ClearVideoConversionError:input_type -> media.ClearVideoConversionErrorRequest
-	33, // 23: media.MediaService.ClearVideoConversionLogs:input_type -> media.ClearVideoConversionLogsRequest
-	36, // 24: media.MediaService.GetVideoConversionLogs:input_type -> media.GetVideoConversionLogsRequest
-	38, // 25: media.MediaService.GeneratePlaylist:input_type -> media.GeneratePlaylistRequest
-	40, // 26: media.MediaService.CreateVttFile:input_type -> media.CreateVttFileRequest
-	42, // 27: media.MediaService.ListMediaFiles:input_type -> media.ListMediaFilesRequest
-	48, // 28: media.MediaService.SyncChannelFromPlaylist:input_type -> media.SyncChannelFromPlaylistRequest
-	50, // 29: media.MediaService.GetChannel:input_type -> media.GetChannelRequest
-	52, // 30: media.MediaService.ListChannels:input_type -> media.ListChannelsRequest
-	45, // 31: media.MediaService.Stop:output_type -> media.StopResponse
-	15, // 32: media.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
-	1,  // 33: media.MediaService.CreateVideoPreview:output_type -> media.CreateVideoPreviewResponse
-	3,  // 34: media.MediaService.CreateVideoTimeLine:output_type -> media.CreateVideoTimeLineResponse
-	5,  // 35: media.MediaService.ConvertVideoToMpeg4H264:output_type -> media.ConvertVideoToMpeg4H264Response
-	7,  // 36: media.MediaService.ConvertVideoToHls:output_type -> media.ConvertVideoToHlsResponse
-	9,  // 37: media.MediaService.StartProcessVideo:output_type -> media.StartProcessVideoResponse
-	11, // 38: media.MediaService.StartProcessAudio:output_type -> media.StartProcessAudioResponse
-	17, // 39: media.MediaService.StopProcessVideo:output_type -> media.StopProcessVideoResponse
-	13, // 40: media.MediaService.IsProcessVideo:output_type -> media.IsProcessVideoResponse
-	19, // 41: media.MediaService.SetVideoConversion:output_type -> media.SetVideoConversionResponse
-	21, // 42: media.MediaService.SetVideoStreamConversion:output_type -> media.SetVideoStreamConversionResponse
-	23, // 43: media.MediaService.SetStartVideoConversionHour:output_type -> media.SetStartVideoConversionHourResponse
-	25, // 44: media.MediaService.SetMaximumVideoConversionDelay:output_type -> media.SetMaximumVideoConversionDelayResponse
-	28, // 45: media.MediaService.GetVideoConversionErrors:output_type -> media.GetVideoConversionErrorsResponse
-	30, // 46: media.MediaService.ClearVideoConversionErrors:output_type -> media.ClearVideoConversionErrorsResponse
-	32, // 47: media.MediaService.ClearVideoConversionError:output_type -> media.ClearVideoConversionErrorResponse
-	34, // 48: media.MediaService.ClearVideoConversionLogs:output_type -> media.ClearVideoConversionLogsResponse
-	37, // 49: media.MediaService.GetVideoConversionLogs:output_type -> media.GetVideoConversionLogsResponse
-	39, // 50: media.MediaService.GeneratePlaylist:output_type -> media.GeneratePlaylistResponse
-	41, // 51: media.MediaService.CreateVttFile:output_type -> media.CreateVttFileResponse
-	43, // 52: media.MediaService.ListMediaFiles:output_type -> media.MediaFile
-	49, // 53: media.MediaService.SyncChannelFromPlaylist:output_type -> media.SyncChannelFromPlaylistResponse
-	51, // 54: media.MediaService.GetChannel:output_type -> media.GetChannelResponse
-	53, // 55: media.MediaService.ListChannels:output_type -> media.ListChannelsResponse
-	31, // [31:56] is the sub-list for method output_type
-	6,  // [6:31] is the sub-list for method input_type
-	6,  // [6:6] is the sub-list for extension type_name
-	6,  // [6:6] is the sub-list for extension extendee
-	0,  // [0:6] is the sub-list for field type_name
+	28, // 0: media.GetVideoConversionErrorsResponse.errors:type_name -> media.VideoConversionError
+	37, // 1: media.GetVideoConversionLogsResponse.logs:type_name -> media.VideoConversionLog
+	48, // 2: media.Channel.items:type_name -> media.ChannelItem
+	49, // 3: media.SyncChannelFromPlaylistResponse.channel:type_name -> media.Channel
+	49, // 4: media.GetChannelResponse.channel:type_name -> media.Channel
+	49, // 5: media.ListChannelsResponse.channels:type_name -> media.Channel
+	0,  // 6: media.MediaJob.operation:type_name -> media.MediaJobOperation
+	1,  // 7: media.MediaJob.state:type_name -> media.MediaJobState
+	1,  // 8: media.ListMediaJobsRequest.states:type_name -> media.MediaJobState
+	56, // 9: media.ListMediaJobsResponse.jobs:type_name -> media.MediaJob
+	56, // 10: media.WatchMediaJobResponse.job:type_name -> media.MediaJob
+	56, // 11: media.CancelMediaJobResponse.job:type_name -> media.MediaJob
+	56, // 12: media.ReprioritizeMediaJobResponse.job:type_name -> media.MediaJob
+	46, // 13: media.MediaService.Stop:input_type -> media.StopRequest
+	16, // 14: media.MediaService.UploadVideo:input_type -> media.UploadVideoRequest
+	2,  // 15: media.MediaService.CreateVideoPreview:input_type -> media.CreateVideoPreviewRequest
+	4,  // 16: media.MediaService.CreateVideoTimeLine:input_type -> media.CreateVideoTimeLineRequest
+	6,  // 17: media.MediaService.ConvertVideoToMpeg4H264:input_type -> media.ConvertVideoToMpeg4H264Request
+	8,  // 18: media.MediaService.ConvertVideoToHls:input_type -> media.ConvertVideoToHlsRequest
+	10, // 19: media.MediaService.StartProcessVideo:input_type -> media.StartProcessVideoRequest
+	12, // 20: media.MediaService.StartProcessAudio:input_type -> media.StartProcessAudioRequest
+	18, // 21: media.MediaService.StopProcessVideo:input_type -> media.StopProcessVideoRequest
+	14, // 22: media.MediaService.IsProcessVideo:input_type -> media.IsProcessVideoRequest
+	20, // 23: media.MediaService.SetVideoConversion:input_type -> media.SetVideoConversionRequest
+	22, // 24: media.MediaService.SetVideoStreamConversion:input_type -> media.SetVideoStreamConversionRequest
+	24, // 25: media.MediaService.SetStartVideoConversionHour:input_type -> media.SetStartVideoConversionHourRequest
+	26, // 26: media.MediaService.SetMaximumVideoConversionDelay:input_type -> media.SetMaximumVideoConversionDelayRequest
+	29, // 27: media.MediaService.GetVideoConversionErrors:input_type -> media.GetVideoConversionErrorsRequest
+	31, // 28: media.MediaService.ClearVideoConversionErrors:input_type -> media.ClearVideoConversionErrorsRequest
+	33, // 29: media.MediaService.ClearVideoConversionError:input_type -> media.ClearVideoConversionErrorRequest
+	35, // 30: media.MediaService.ClearVideoConversionLogs:input_type -> media.ClearVideoConversionLogsRequest
+	38, // 31: media.MediaService.GetVideoConversionLogs:input_type -> media.GetVideoConversionLogsRequest
+	40, // 32: media.MediaService.GeneratePlaylist:input_type -> media.GeneratePlaylistRequest
+	42, // 33: media.MediaService.CreateVttFile:input_type -> media.CreateVttFileRequest
+	44, // 34: media.MediaService.ListMediaFiles:input_type -> media.ListMediaFilesRequest
+	50, // 35: media.MediaService.SyncChannelFromPlaylist:input_type -> media.SyncChannelFromPlaylistRequest
+	52, // 36: media.MediaService.GetChannel:input_type -> media.GetChannelRequest
+	54, // 37: media.MediaService.ListChannels:input_type -> media.ListChannelsRequest
+	57, // 38: media.MediaService.ListMediaJobs:input_type -> media.ListMediaJobsRequest
+	59, // 39: media.MediaService.WatchMediaJob:input_type -> media.WatchMediaJobRequest
+	61, // 40: media.MediaService.CancelMediaJob:input_type -> media.CancelMediaJobRequest
+	63, // 41: media.MediaService.ReprioritizeMediaJob:input_type -> media.ReprioritizeMediaJobRequest
+	47, // 42: media.MediaService.Stop:output_type -> media.StopResponse
+	17, // 43: media.MediaService.UploadVideo:output_type -> media.UploadVideoResponse
+	3,  // 44: media.MediaService.CreateVideoPreview:output_type -> media.CreateVideoPreviewResponse
+	5,  // 45: media.MediaService.CreateVideoTimeLine:output_type -> media.CreateVideoTimeLineResponse
+	7,  // 46: media.MediaService.ConvertVideoToMpeg4H264:output_type -> media.ConvertVideoToMpeg4H264Response
+	9,  // 47: media.MediaService.ConvertVideoToHls:output_type -> media.ConvertVideoToHlsResponse
+	11, // 48: media.MediaService.StartProcessVideo:output_type -> media.StartProcessVideoResponse
+	13, // 49: media.MediaService.StartProcessAudio:output_type -> media.StartProcessAudioResponse
+	19, // 50: media.MediaService.StopProcessVideo:output_type -> media.StopProcessVideoResponse
+	15, // 51: media.MediaService.IsProcessVideo:output_type -> media.IsProcessVideoResponse
+	21, // 52: media.MediaService.SetVideoConversion:output_type -> media.SetVideoConversionResponse
+	23, // 53: media.MediaService.SetVideoStreamConversion:output_type -> media.SetVideoStreamConversionResponse
+	25, // 54: media.MediaService.SetStartVideoConversionHour:output_type -> media.SetStartVideoConversionHourResponse
+	27, // 55: media.MediaService.SetMaximumVideoConversionDelay:output_type -> media.SetMaximumVideoConversionDelayResponse
+	30, // 56: media.MediaService.GetVideoConversionErrors:output_type -> media.GetVideoConversionErrorsResponse
+	32, // 57: media.MediaService.ClearVideoConversionErrors:output_type -> media.ClearVideoConversionErrorsResponse
+	34, // 58: media.MediaService.ClearVideoConversionError:output_type -> media.ClearVideoConversionErrorResponse
+	36, // 59: media.MediaService.ClearVideoConversionLogs:output_type -> media.ClearVideoConversionLogsResponse
+	39, // 60: media.MediaService.GetVideoConversionLogs:output_type -> media.GetVideoConversionLogsResponse
+	41, // 61: media.MediaService.GeneratePlaylist:output_type -> media.GeneratePlaylistResponse
+	43, // 62: media.MediaService.CreateVttFile:output_type -> media.CreateVttFileResponse
+	45, // 63: media.MediaService.ListMediaFiles:output_type -> media.MediaFile
+	51, // 64: media.MediaService.SyncChannelFromPlaylist:output_type -> media.SyncChannelFromPlaylistResponse
+	53, // 65: media.MediaService.GetChannel:output_type -> media.GetChannelResponse
+	55, // 66: media.MediaService.ListChannels:output_type -> media.ListChannelsResponse
+	58, // 67: media.MediaService.ListMediaJobs:output_type -> media.ListMediaJobsResponse
+	60, // 68: media.MediaService.WatchMediaJob:output_type -> media.WatchMediaJobResponse
+	62, // 69: media.MediaService.CancelMediaJob:output_type -> media.CancelMediaJobResponse
+	64, // 70: media.MediaService.ReprioritizeMediaJob:output_type -> media.ReprioritizeMediaJobResponse
+	42, // [42:71] is the sub-list for method output_type
+	13, // [13:42] is the sub-list for method input_type
+	13, // [13:13] is the sub-list for extension type_name
+	13, // [13:13] is the sub-list for extension extendee
+	0,  // [0:13] is the sub-list for field type_name
 }
 
 func init() { file_media_proto_init() }
@@ -2880,13 +3639,14 @@ func file_media_proto_init() {
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_media_proto_rawDesc), len(file_media_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   54,
+			NumEnums:      2,
+			NumMessages:   63,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_media_proto_goTypes,
 		DependencyIndexes: file_media_proto_depIdxs,
+		EnumInfos:         file_media_proto_enumTypes,
 		MessageInfos:      file_media_proto_msgTypes,
 	}.Build()
 	File_media_proto = out.File
@@ -44,6 +44,10 @@ const (
 	MediaService_SyncChannelFromPlaylist_FullMethodName        = "/media.MediaService/SyncChannelFromPlaylist"
 	MediaService_GetChannel_FullMethodName                     = "/media.MediaService/GetChannel"
 	MediaService_ListChannels_FullMethodName                   = "/media.MediaService/ListChannels"
+	MediaService_ListMediaJobs_FullMethodName                  = "/media.MediaService/ListMediaJobs"
+	MediaService_WatchMediaJob_FullMethodName                  = "/media.MediaService/WatchMediaJob"
+	MediaService_CancelMediaJob_FullMethodName                 = "/media.MediaService/CancelMediaJob"
+	MediaService_ReprioritizeMediaJob_FullMethodName           = "/media.MediaService/ReprioritizeMediaJob"
 )
 
 // MediaServiceClient is the client API for MediaService service.
@@ -104,6 +108,14 @@ type MediaServiceClient interface {
 	GetChannel(ctx context.Context, in *GetChannelRequest, opts ...grpc.CallOption) (*GetChannelResponse, error)
 	// List channels stored under a given root path.
 	ListChannels(ctx context.Context, in *ListChannelsRequest, opts ...grpc.CallOption) (*ListChannelsResponse, error)
+	// List the processing jobs.
+	ListMediaJobs(ctx context.Context, in *ListMediaJobsRequest, opts ...grpc.CallOption) (*ListMediaJobsResponse, error)
+	// Stream the state and ffmpeg progress of a job until it ends.
+	WatchMediaJob(ctx context.Context, in *WatchMediaJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMediaJobResponse], error)
+	// Cancel a queued or running job.
+	CancelMediaJob(ctx context.Context, in *CancelMediaJobRequest, opts ...grpc.CallOption) (*CancelMediaJobResponse, error)
+	// Change the priority of a queued job.
+	ReprioritizeMediaJob(ctx context.Context, in *ReprioritizeMediaJobRequest, opts ...grpc.CallOption) (*ReprioritizeMediaJobResponse, error)
 }
 
 type mediaServiceClient struct {
@@ -382,6 +394,55 @@ func (c *mediaServiceClient) ListChannels(ctx context.Context, in *ListChannelsR
 	return out, nil
 }
 
+func (c *mediaServiceClient) ListMediaJobs(ctx context.Context, in *ListMediaJobsRequest, opts ...grpc.CallOption) (*ListMediaJobsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMediaJobsResponse)
+	err := c.cc.Invoke(ctx, MediaService_ListMediaJobs_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) WatchMediaJob(ctx context.Context, in *WatchMediaJobRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchMediaJobResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &MediaService_ServiceDesc.Streams[2], MediaService_WatchMediaJob_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchMediaJobRequest, WatchMediaJobResponse]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchMediaJobClient = grpc.ServerStreamingClient[WatchMediaJobResponse]
+
+func (c *mediaServiceClient) CancelMediaJob(ctx context.Context, in *CancelMediaJobRequest, opts ...grpc.CallOption) (*CancelMediaJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CancelMediaJobResponse)
+	err := c.cc.Invoke(ctx, MediaService_CancelMediaJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *mediaServiceClient) ReprioritizeMediaJob(ctx context.Context, in *ReprioritizeMediaJobRequest, opts ...grpc.CallOption) (*ReprioritizeMediaJobResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReprioritizeMediaJobResponse)
+	err := c.cc.Invoke(ctx, MediaService_ReprioritizeMediaJob_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // MediaServiceServer is the server API for MediaService service.
 // All implementations should embed UnimplementedMediaServiceServer
 // for forward compatibility.
@@ -440,6 +501,14 @@ type MediaServiceServer interface {
 	GetChannel(context.Context, *GetChannelRequest) (*GetChannelResponse, error)
 	// List channels stored under a given root path.
 	ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error)
+	// List the processing jobs.
+	ListMediaJobs(context.Context, *ListMediaJobsRequest) (*ListMediaJobsResponse, error)
+	// Stream the state and ffmpeg progress of a job until it ends.
+	WatchMediaJob(*WatchMediaJobRequest, grpc.ServerStreamingServer[WatchMediaJobResponse]) error
+	// Cancel a queued or running job.
+	CancelMediaJob(context.Context, *CancelMediaJobRequest) (*CancelMediaJobResponse, error)
+	// Change the priority of a queued job.
+	ReprioritizeMediaJob(context.Context, *ReprioritizeMediaJobRequest) (*ReprioritizeMediaJobResponse, error)
 }
 
 // UnimplementedMediaServiceServer should be embedded to have
@@ -524,6 +593,18 @@ func (UnimplementedMediaServiceServer) GetChannel(context.Context, *GetChannelRe
 func (UnimplementedMediaServiceServer) ListChannels(context.Context, *ListChannelsRequest) (*ListChannelsResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListChannels not implemented")
 }
+func (UnimplementedMediaServiceServer) ListMediaJobs(context.Context, *ListMediaJobsRequest) (*ListMediaJobsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMediaJobs not implemented")
+}
+func (UnimplementedMediaServiceServer) WatchMediaJob(*WatchMediaJobRequest, grpc.ServerStreamingServer[WatchMediaJobResponse]) error {
+	return status.Error(codes.Unimplemented, "method WatchMediaJob not implemented")
+}
+func (UnimplementedMediaServiceServer) CancelMediaJob(context.Context, *CancelMediaJobRequest) (*CancelMediaJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CancelMediaJob not implemented")
+}
+func (UnimplementedMediaServiceServer) ReprioritizeMediaJob(context.Context, *ReprioritizeMediaJobRequest) (*ReprioritizeMediaJobResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReprioritizeMediaJob not implemented")
+}
 func (UnimplementedMediaServiceServer) testEmbeddedByValue() {}
 
 // UnsafeMediaServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -980,6 +1061,71 @@ func _MediaService_ListChannels_Handler(srv interface{}, ctx context.Context, de
 	return interceptor(ctx, in, info, handler)
 }
 
+func _MediaService_ListMediaJobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMediaJobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ListMediaJobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ListMediaJobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ListMediaJobs(ctx, req.(*ListMediaJobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_WatchMediaJob_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchMediaJobRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(MediaServiceServer).WatchMediaJob(m, &grpc.GenericServerStream[WatchMediaJobRequest, WatchMediaJobResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type MediaService_WatchMediaJobServer = grpc.ServerStreamingServer[WatchMediaJobResponse]
+
+func _MediaService_CancelMediaJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelMediaJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).CancelMediaJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_CancelMediaJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).CancelMediaJob(ctx, req.(*CancelMediaJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _MediaService_ReprioritizeMediaJob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReprioritizeMediaJobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(MediaServiceServer).ReprioritizeMediaJob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: MediaService_ReprioritizeMediaJob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(MediaServiceServer).ReprioritizeMediaJob(ctx, req.(*ReprioritizeMediaJobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // MediaService_ServiceDesc is the grpc.ServiceDesc for MediaService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -1079,6 +1225,18 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "ListChannels",
 			Handler:    _MediaService_ListChannels_Handler,
 		},
+		{
+			MethodName: "ListMediaJobs",
+			Handler:    _MediaService_ListMediaJobs_Handler,
+		},
+		{
+			MethodName: "CancelMediaJob",
+			Handler:    _MediaService_CancelMediaJob_Handler,
+		},
+		{
+			MethodName: "ReprioritizeMediaJob",
+			Handler:    _MediaService_ReprioritizeMediaJob_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -1091,6 +1249,11 @@ var MediaService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _MediaService_ListMediaFiles_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "WatchMediaJob",
+			Handler:       _MediaService_WatchMediaJob_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "media.proto",
 }
@@ -0,0 +1,113 @@
+package persistence_client
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io/fs"
+	"path"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"github.com/globulario/services/golang/persistence/persistencepb"
+)
+
+// migrationFileRe matches <version>_<name>.<ext>, e.g. 0003_add_email.cql.
+var migrationFileRe = regexp.MustCompile(`^(\d+)_([^.]+)\.(sql|cql|json)$`)
+
+// migrationDialects maps a file extension to the dialect of its script.
+var migrationDialects = map[string]string{"sql": "sql", "cql": "cql", "json": "mongo"}
+
+/**
+ * Read the migration scripts of a directory: files named
+ * <version>_<name>.sql (SQL store), .cql (Scylla) or .json (MongoDB command
+ * array). Other files are ignored. A service can ship its scripts with
+ * embed.FS; the CLI uses os.DirFS.
+ */
+func LoadMigrations(fsys fs.FS, dir string) ([]*persistencepb.Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	migrations := make([]*persistencepb.Migration, 0)
+	for _, e := range entries {
+		if e.IsDir() {
+			continue
+		}
+		m := migrationFileRe.FindStringSubmatch(e.Name())
+		if m == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(m[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", e.Name(), err)
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, e.Name()))
+		if err != nil {
+			return nil, err
+		}
+		sum := sha256.Sum256(data)
+		migrations = append(migrations, &persistencepb.Migration{
+			Version:  version,
+			Name:     m[2],
+			Dialect:  migrationDialects[m[3]],
+			Script:   string(data),
+			Checksum: hex.EncodeToString(sum[:]),
+		})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+/**
+ * Apply the migrations of a set not applied yet to a database. The whole
+ * set is sent; the server skips the applied ones and the other dialects.
+ */
+func (client *Persistence_Client) ApplyMigrations(connectionId string, database string, migrations []*persistencepb.Migration) ([]*persistencepb.AppliedMigration, error) {
+	rqst := &persistencepb.ApplyMigrationsRqst{
+		Id:         connectionId,
+		Database:   database,
+		Migrations: migrations,
+	}
+
+	rsp, err := client.c.ApplyMigrations(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Applied, nil
+}
+
+/**
+ * Return the migrations of a set ApplyMigrations would run, in order.
+ */
+func (client *Persistence_Client) PlanMigrations(connectionId string, database string, migrations []*persistencepb.Migration) ([]*persistencepb.Migration, error) {
+	rqst := &persistencepb.PlanMigrationsRqst{
+		Id:         connectionId,
+		Database:   database,
+		Migrations: migrations,
+	}
+
+	rsp, err := client.c.PlanMigrations(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Pending, nil
+}
+
+/**
+ * Return the migrations applied to a database.
+ */
+func (client *Persistence_Client) ListMigrations(connectionId string, database string) ([]*persistencepb.AppliedMigration, error) {
+	rqst := &persistencepb.ListMigrationsRqst{
+		Id:       connectionId,
+		Database: database,
+	}
+
+	rsp, err := client.c.ListMigrations(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Migrations, nil
+}
@@ -0,0 +1,36 @@
+package persistence_client
+
+import (
+	"testing"
+	"testing/fstest"
+)
+
+func TestLoadMigrations(t *testing.T) {
+	fsys := fstest.MapFS{
+		"migrations/0002_add_email.cql":        {Data: []byte("ALTER TABLE accounts ADD email text;")},
+		"migrations/0001_create_accounts.sql":  {Data: []byte("CREATE TABLE accounts (_id TEXT PRIMARY KEY);")},
+		"migrations/0001_create_accounts.json": {Data: []byte(`[{"create":"accounts"}]`)},
+		"migrations/README.md":                 {Data: []byte("notes")},
+	}
+
+	migrations, err := LoadMigrations(fsys, "migrations")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(migrations) != 3 {
+		t.Fatalf("loaded %d migrations, want 3", len(migrations))
+	}
+	if migrations[2].Version != 2 || migrations[2].Name != "add_email" || migrations[2].Dialect != "cql" {
+		t.Errorf("unexpected migration %v", migrations[2])
+	}
+	dialects := map[string]bool{}
+	for _, m := range migrations[:2] {
+		dialects[m.Dialect] = true
+		if m.Version != 1 || len(m.Checksum) != 64 {
+			t.Errorf("unexpected migration %v", m)
+		}
+	}
+	if !dialects["sql"] || !dialects["mongo"] {
+		t.Errorf("dialects = %v, want sql and mongo", dialects)
+	}
+}
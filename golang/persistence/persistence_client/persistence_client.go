@@ -313,14 +313,19 @@ func (client *Persistence_Client) Ping(connectionId string) error {
 }
 
 func (client *Persistence_Client) FindOne(connectionId string, database string, collection string, jsonStr string, options string) (map[string]interface{}, error) {
+	return client.findOne(connectionId, "", database, collection, jsonStr, options)
+}
+
+func (client *Persistence_Client) findOne(connectionId string, transactionId string, database string, collection string, jsonStr string, options string) (map[string]interface{}, error) {
 
 	// Retreive a single value...
 	rqst := &persistencepb.FindOneRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      jsonStr,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         jsonStr,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	rsp, err := client.c.FindOne(client.GetCtx(), rqst)
@@ -337,14 +342,19 @@ func (client *Persistence_Client) FindOne(connectionId string, database string,
 }
 
 func (client *Persistence_Client) Find(connectionId string, database string, collection string, query string, options string) ([]interface{}, error) {
+	return client.find(connectionId, "", database, collection, query, options)
+}
+
+func (client *Persistence_Client) find(connectionId string, transactionId string, database string, collection string, query string, options string) ([]interface{}, error) {
 
 	// Retreive a single value...
 	rqst := &persistencepb.FindRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	stream, err := client.c.Find(client.GetCtx(), rqst)
@@ -382,13 +392,18 @@ func (client *Persistence_Client) Find(connectionId string, database string, col
  * Usefull function to query and transform document.
  */
 func (client *Persistence_Client) Aggregate(connectionId, database string, collection string, pipeline string, options string) ([]interface{}, error) {
+	return client.aggregate(connectionId, "", database, collection, pipeline, options)
+}
+
+func (client *Persistence_Client) aggregate(connectionId string, transactionId string, database string, collection string, pipeline string, options string) ([]interface{}, error) {
 	// Retreive a single value...
 	rqst := &persistencepb.AggregateRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Pipeline:   pipeline,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Pipeline:      pipeline,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	stream, err := client.c.Aggregate(client.GetCtx(), rqst)
@@ -429,13 +444,18 @@ func (client *Persistence_Client) Aggregate(connectionId, database string, colle
  * Count the number of document that match the query.
  */
 func (client *Persistence_Client) Count(connectionId string, database string, collection string, query string, options string) (int, error) {
+	return client.count(connectionId, "", database, collection, query, options)
+}
+
+func (client *Persistence_Client) count(connectionId string, transactionId string, database string, collection string, query string, options string) (int, error) {
 
 	rqst := &persistencepb.CountRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	rsp, err := client.c.Count(client.GetCtx(), rqst)
@@ -451,6 +471,10 @@ func (client *Persistence_Client) Count(connectionId string, database string, co
  * Insert one value in the database.
  */
 func (client *Persistence_Client) InsertOne(connectionId string, database string, collection string, entity interface{}, options string) (string, error) {
+	return client.insertOne(connectionId, "", database, collection, entity, options)
+}
+
+func (client *Persistence_Client) insertOne(connectionId string, transactionId string, database string, collection string, entity interface{}, options string) (string, error) {
 
 	// Try to marshal object...
 	data, err := Utility.ToJson(entity)
@@ -459,11 +483,12 @@ func (client *Persistence_Client) InsertOne(connectionId string, database string
 	}
 
 	rqst := &persistencepb.InsertOneRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Data:       string(data),
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Data:          string(data),
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	rsp, err := client.c.InsertOne(client.GetCtx(), rqst)
@@ -476,6 +501,10 @@ func (client *Persistence_Client) InsertOne(connectionId string, database string
 }
 
 func (client *Persistence_Client) InsertMany(connectionId string, database string, collection string, entities []interface{}, options string) error {
+	return client.insertMany(connectionId, "", database, collection, entities, options)
+}
+
+func (client *Persistence_Client) insertMany(connectionId string, transactionId string, database string, collection string, entities []interface{}, options string) error {
 
 	stream, err := client.c.InsertMany(client.GetCtx())
 	if err != nil {
@@ -503,10 +532,11 @@ func (client *Persistence_Client) InsertMany(connectionId string, database strin
 			return err
 		} else if bytesread > 0 {
 			rqst := &persistencepb.InsertManyRqst{
-				Id:         connectionId,
-				Database:   database,
-				Collection: collection,
-				Data:       data[0:bytesread],
+				Id:            connectionId,
+				Database:      database,
+				Collection:    collection,
+				Data:          data[0:bytesread],
+				TransactionId: transactionId,
 			}
 			// send the data to the server.
 			err = stream.Send(rqst)
@@ -531,6 +561,10 @@ func (client *Persistence_Client) InsertMany(connectionId string, database strin
  * Insert one value in the database.
  */
 func (client *Persistence_Client) ReplaceOne(connectionId string, database string, collection string, query string, entity interface{}, options string) error {
+	return client.replaceOne(connectionId, "", database, collection, query, entity, options)
+}
+
+func (client *Persistence_Client) replaceOne(connectionId string, transactionId string, database string, collection string, query string, entity interface{}, options string) error {
 
 	var value string
 	if reflect.TypeOf(entity).Kind() == reflect.String {
@@ -544,12 +578,13 @@ func (client *Persistence_Client) ReplaceOne(connectionId string, database strin
 	}
 
 	rqst := &persistencepb.ReplaceOneRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Value:      value,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Value:         value,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	_, err := client.c.ReplaceOne(client.GetCtx(), rqst)
@@ -558,6 +593,10 @@ func (client *Persistence_Client) ReplaceOne(connectionId string, database strin
 }
 
 func (client *Persistence_Client) UpdateOne(connectionId string, database string, collection string, query string, entity interface{}, options string) error {
+	return client.updateOne(connectionId, "", database, collection, query, entity, options)
+}
+
+func (client *Persistence_Client) updateOne(connectionId string, transactionId string, database string, collection string, query string, entity interface{}, options string) error {
 
 	var value string
 	if reflect.TypeOf(entity).Kind() == reflect.String {
@@ -571,12 +610,13 @@ func (client *Persistence_Client) UpdateOne(connectionId string, database string
 	}
 
 	rqst := &persistencepb.UpdateOneRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Value:      value,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Value:         value,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	_, err := client.c.UpdateOne(client.GetCtx(), rqst)
@@ -588,14 +628,19 @@ func (client *Persistence_Client) UpdateOne(connectionId string, database string
  * Update one or more document.
  */
 func (client *Persistence_Client) Update(connectionId string, database string, collection string, query string, value string, options string) error {
+	return client.update(connectionId, "", database, collection, query, value, options)
+}
+
+func (client *Persistence_Client) update(connectionId string, transactionId string, database string, collection string, query string, value string, options string) error {
 
 	rqst := &persistencepb.UpdateRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Value:      value,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Value:         value,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	_, err := client.c.Update(client.GetCtx(), rqst)
@@ -607,13 +652,18 @@ func (client *Persistence_Client) Update(connectionId string, database string, c
  * Delete one document from the db
  */
 func (client *Persistence_Client) DeleteOne(connectionId string, database string, collection string, query string, options string) error {
+	return client.deleteOne(connectionId, "", database, collection, query, options)
+}
+
+func (client *Persistence_Client) deleteOne(connectionId string, transactionId string, database string, collection string, query string, options string) error {
 
 	rqst := &persistencepb.DeleteOneRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	_, err := client.c.DeleteOne(client.GetCtx(), rqst)
@@ -629,13 +679,18 @@ func (client *Persistence_Client) DeleteOne(connectionId string, database string
  * Delete many document from the db.
  */
 func (client *Persistence_Client) Delete(connectionId string, database string, collection string, query string, options string) error {
+	return client.deleteMany(connectionId, "", database, collection, query, options)
+}
+
+func (client *Persistence_Client) deleteMany(connectionId string, transactionId string, database string, collection string, query string, options string) error {
 
 	rqst := &persistencepb.DeleteRqst{
-		Id:         connectionId,
-		Database:   database,
-		Collection: collection,
-		Query:      query,
-		Options:    options,
+		Id:            connectionId,
+		Database:      database,
+		Collection:    collection,
+		Query:         query,
+		Options:       options,
+		TransactionId: transactionId,
 	}
 
 	_, err := client.c.Delete(client.GetCtx(), rqst)
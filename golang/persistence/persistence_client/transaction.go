@@ -0,0 +1,108 @@
+package persistence_client
+
+import (
+	"github.com/globulario/services/golang/persistence/persistencepb"
+)
+
+/**
+ * A transaction opened on a connection of the persistence service. Every call
+ * made through it is applied at Commit or discarded at Rollback. The server
+ * rolls it back on its own after timeout seconds without a call.
+ */
+type Persistence_Transaction struct {
+	client       *Persistence_Client
+	connectionId string
+	id           string
+}
+
+/**
+ * Start a transaction on a connection (timeout in seconds, 0 for the server default).
+ */
+func (client *Persistence_Client) BeginTransaction(connectionId string, timeout int32) (*Persistence_Transaction, error) {
+	rqst := &persistencepb.BeginTransactionRqst{
+		Id:      connectionId,
+		Timeout: timeout,
+	}
+
+	rsp, err := client.c.BeginTransaction(client.GetCtx(), rqst)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Persistence_Transaction{client: client, connectionId: connectionId, id: rsp.TransactionId}, nil
+}
+
+/**
+ * Return the transaction id.
+ */
+func (tx *Persistence_Transaction) GetId() string {
+	return tx.id
+}
+
+/**
+ * Apply the writes of the transaction.
+ */
+func (tx *Persistence_Transaction) Commit() error {
+	rqst := &persistencepb.CommitRqst{
+		Id:            tx.connectionId,
+		TransactionId: tx.id,
+	}
+	_, err := tx.client.c.Commit(tx.client.GetCtx(), rqst)
+	return err
+}
+
+/**
+ * Discard the writes of the transaction.
+ */
+func (tx *Persistence_Transaction) Rollback() error {
+	rqst := &persistencepb.RollbackRqst{
+		Id:            tx.connectionId,
+		TransactionId: tx.id,
+	}
+	_, err := tx.client.c.Rollback(tx.client.GetCtx(), rqst)
+	return err
+}
+
+func (tx *Persistence_Transaction) FindOne(database string, collection string, query string, options string) (map[string]interface{}, error) {
+	return tx.client.findOne(tx.connectionId, tx.id, database, collection, query, options)
+}
+
+func (tx *Persistence_Transaction) Find(database string, collection string, query string, options string) ([]interface{}, error) {
+	return tx.client.find(tx.connectionId, tx.id, database, collection, query, options)
+}
+
+func (tx *Persistence_Transaction) Aggregate(database string, collection string, pipeline string, options string) ([]interface{}, error) {
+	return tx.client.aggregate(tx.connectionId, tx.id, database, collection, pipeline, options)
+}
+
+func (tx *Persistence_Transaction) Count(database string, collection string, query string, options string) (int, error) {
+	return tx.client.count(tx.connectionId, tx.id, database, collection, query, options)
+}
+
+func (tx *Persistence_Transaction) InsertOne(database string, collection string, entity interface{}, options string) (string, error) {
+	return tx.client.insertOne(tx.connectionId, tx.id, database, collection, entity, options)
+}
+
+func (tx *Persistence_Transaction) InsertMany(database string, collection string, entities []interface{}, options string) error {
+	return tx.client.insertMany(tx.connectionId, tx.id, database, collection, entities, options)
+}
+
+func (tx *Persistence_Transaction) ReplaceOne(database string, collection string, query string, entity interface{}, options string) error {
+	return tx.client.replaceOne(tx.connectionId, tx.id, database, collection, query, entity, options)
+}
+
+func (tx *Persistence_Transaction) UpdateOne(database string, collection string, query string, entity interface{}, options string) error {
+	return tx.client.updateOne(tx.connectionId, tx.id, database, collection, query, entity, options)
+}
+
+func (tx *Persistence_Transaction) Update(database string, collection string, query string, value string, options string) error {
+	return tx.client.update(tx.connectionId, tx.id, database, collection, query, value, options)
+}
+
+func (tx *Persistence_Transaction) DeleteOne(database string, collection string, query string, options string) error {
+	return tx.client.deleteOne(tx.connectionId, tx.id, database, collection, query, options)
+}
+
+func (tx *Persistence_Transaction) Delete(database string, collection string, query string, options string) error {
+	return tx.client.deleteMany(tx.connectionId, tx.id, database, collection, query, options)
+}
@@ -0,0 +1,52 @@
+package persistence_client
+
+import (
+	"context"
+	"io"
+
+	"github.com/globulario/services/golang/persistence/persistencepb"
+	"google.golang.org/grpc/metadata"
+)
+
+/**
+ * Stream the changes of a collection to fct until ctx is done, the stream
+ * ends or fct returns an error. filter is a JSON Mongo filter the changed
+ * document must match (empty for all). Pass the ResumeToken of the last
+ * event received to continue after it on reconnect, or an empty token to
+ * start from now.
+ */
+func (client *Persistence_Client) Watch(ctx context.Context, connectionId string, database string, collection string, filter string, resumeToken string, fct func(evt *persistencepb.WatchResp) error) error {
+	if ctx == nil {
+		ctx = client.GetCtx()
+	} else if md, ok := metadata.FromOutgoingContext(client.GetCtx()); ok {
+		ctx = metadata.NewOutgoingContext(ctx, md)
+	}
+
+	rqst := &persistencepb.WatchRqst{
+		Id:          connectionId,
+		Database:    database,
+		Collection:  collection,
+		Filter:      filter,
+		ResumeToken: resumeToken,
+	}
+
+	stream, err := client.c.Watch(ctx, rqst)
+	if err != nil {
+		return err
+	}
+
+	for {
+		evt, err := stream.Recv()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			if ctx.Err() != nil {
+				return nil
+			}
+			return err
+		}
+		if err := fct(evt); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,129 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+
+	"github.com/globulario/services/golang/persistence/persistence_store"
+	"github.com/globulario/services/golang/persistence/persistencepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// migratorFor resolves the store of a migration request.
+func (srv *server) migratorFor(id, database string) (persistence_store.Migrator, string, error) {
+	if id == "" {
+		return nil, "", grpcErr(errors.New("no connection id provided"))
+	}
+	if database == "" {
+		return nil, "", grpcErr(errors.New("no database provided"))
+	}
+	store, nid, err := srv.storeFor(id)
+	if err != nil {
+		return nil, "", grpcErr(err)
+	}
+	migrator, ok := store.(persistence_store.Migrator)
+	if !ok {
+		return nil, "", status.Errorf(codes.Unimplemented, "the %s store does not support migrations", store.GetStoreType())
+	}
+	return migrator, nid, nil
+}
+
+// migrationErr maps planning errors to gRPC status codes.
+func migrationErr(err error) error {
+	if errors.Is(err, persistence_store.ErrMigrationChecksumMismatch) || errors.Is(err, persistence_store.ErrMigrationOutOfOrder) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	return grpcErr(err)
+}
+
+func migrationsFromPb(in []*persistencepb.Migration) []*persistence_store.Migration {
+	out := make([]*persistence_store.Migration, 0, len(in))
+	for _, m := range in {
+		out = append(out, &persistence_store.Migration{
+			Version:  m.Version,
+			Name:     m.Name,
+			Dialect:  m.Dialect,
+			Script:   m.Script,
+			Checksum: m.Checksum,
+		})
+	}
+	return out
+}
+
+func appliedToPb(in []persistence_store.AppliedMigration) []*persistencepb.AppliedMigration {
+	out := make([]*persistencepb.AppliedMigration, 0, len(in))
+	for _, a := range in {
+		out = append(out, &persistencepb.AppliedMigration{
+			Version:    a.Version,
+			Name:       a.Name,
+			Checksum:   a.Checksum,
+			AppliedAt:  a.AppliedAt.UnixMilli(),
+			DurationMs: a.Duration.Milliseconds(),
+		})
+	}
+	return out
+}
+
+// ApplyMigrations runs the migrations of the set that the database ledger
+// does not list yet, in version order.
+func (srv *server) ApplyMigrations(ctx context.Context, rqst *persistencepb.ApplyMigrationsRqst) (*persistencepb.ApplyMigrationsRsp, error) {
+	migrator, nid, err := srv.migratorFor(rqst.Id, rqst.Database)
+	if err != nil {
+		return nil, err
+	}
+
+	srv.migrationLock.Lock()
+	defer srv.migrationLock.Unlock()
+
+	applied, err := persistence_store.ApplyMigrations(ctx, migrator, nid, norm(rqst.Database), migrationsFromPb(rqst.Migrations))
+	for _, a := range applied {
+		slog.Info("migration applied", "connection", nid, "database", rqst.Database, "version", a.Version, "name", a.Name, "duration", a.Duration)
+	}
+	if err != nil {
+		return nil, migrationErr(err)
+	}
+	return &persistencepb.ApplyMigrationsRsp{Applied: appliedToPb(applied)}, nil
+}
+
+// PlanMigrations returns the migrations ApplyMigrations would run.
+func (srv *server) PlanMigrations(ctx context.Context, rqst *persistencepb.PlanMigrationsRqst) (*persistencepb.PlanMigrationsRsp, error) {
+	migrator, nid, err := srv.migratorFor(rqst.Id, rqst.Database)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := migrator.AppliedMigrations(ctx, nid, norm(rqst.Database))
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	pending, err := persistence_store.PlanMigrations(migrator.MigrationDialect(), migrationsFromPb(rqst.Migrations), applied)
+	if err != nil {
+		return nil, migrationErr(err)
+	}
+
+	rsp := &persistencepb.PlanMigrationsRsp{Dialect: migrator.MigrationDialect()}
+	for _, m := range pending {
+		rsp.Pending = append(rsp.Pending, &persistencepb.Migration{
+			Version:  m.Version,
+			Name:     m.Name,
+			Dialect:  m.Dialect,
+			Script:   m.Script,
+			Checksum: m.Checksum,
+		})
+	}
+	return rsp, nil
+}
+
+// ListMigrations returns the ledger of a database.
+func (srv *server) ListMigrations(ctx context.Context, rqst *persistencepb.ListMigrationsRqst) (*persistencepb.ListMigrationsRsp, error) {
+	migrator, nid, err := srv.migratorFor(rqst.Id, rqst.Database)
+	if err != nil {
+		return nil, err
+	}
+	applied, err := migrator.AppliedMigrations(ctx, nid, norm(rqst.Database))
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	return &persistencepb.ListMigrationsRsp{Dialect: migrator.MigrationDialect(), Migrations: appliedToPb(applied)}, nil
+}
@@ -342,10 +342,11 @@ func (srv *server) Count(ctx context.Context, rqst *persistencepb.CountRqst) (*p
 	if rqst.Database == "" {
 		return nil, grpcErr(errors.New("no database provided"))
 	}
-	store, nid, err := srv.storeFor(rqst.Id)
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
 	if err != nil {
 		return nil, grpcErr(err)
 	}
+	defer release()
 	count, err := store.Count(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options)
 	if err != nil {
 		return nil, grpcErr(err)
@@ -361,10 +362,11 @@ func (srv *server) InsertOne(ctx context.Context, rqst *persistencepb.InsertOneR
 	if rqst.Database == "" {
 		return nil, grpcErr(errors.New("no database provided"))
 	}
-	store, nid, err := srv.storeFor(rqst.Id)
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
 	if err != nil {
 		return nil, grpcErr(err)
 	}
+	defer release()
 
 	entity := make(map[string]interface{})
 	if err := json.Unmarshal([]byte(rqst.Data), &entity); err != nil {
@@ -387,6 +389,7 @@ func (srv *server) InsertOne(ctx context.Context, rqst *persistencepb.InsertOneR
 func (srv *server) InsertMany(stream persistencepb.PersistenceService_InsertManyServer) error {
     var (
         lastID, lastDB, lastColl, lastOptions string
+        lastTx                                string
         buf                                   bytes.Buffer
         gotChunk                              bool
     )
@@ -413,6 +416,9 @@ func (srv *server) InsertMany(stream persistencepb.PersistenceService_InsertMany
         if opt := rqst.GetOptions(); opt != "" {
             lastOptions = opt
         }
+        if tx := rqst.GetTransactionId(); tx != "" {
+            lastTx = tx
+        }
 
         if len(rqst.Data) > 0 {
             if _, err := buf.Write(rqst.Data); err != nil {
@@ -433,10 +439,11 @@ func (srv *server) InsertMany(stream persistencepb.PersistenceService_InsertMany
     }
 
     // 3) Execute write(s)
-    store := srv.stores[lastID]
-    if store == nil {
-        return status.Errorf(codes.NotFound, "connection %q not found", lastID)
+    store, _, release, err := srv.storeForRequest(lastID, lastTx)
+    if err != nil {
+        return err
     }
+    defer release()
 
     if _, err := store.InsertMany(stream.Context(), lastID, lastDB, lastColl, entities, lastOptions); err != nil {
         return grpcErr(err)
@@ -455,11 +462,11 @@ func (srv *server) Find(rqst *persistencepb.FindRqst, stream persistencepb.Persi
 		return grpcErr(errors.New("no database provided"))
 	}
 
-	nid := norm(rqst.Id)
-	store := srv.stores[nid]
-	if store == nil {
-		return grpcErr(errors.New("Find no store connection exists for id " + nid))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return grpcErr(errors.New("Find " + err.Error()))
 	}
+	defer release()
 
 	results, err := store.Find(stream.Context(), nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options)
 	if err != nil {
@@ -499,12 +506,13 @@ func (srv *server) Aggregate(rqst *persistencepb.AggregateRqst, stream persisten
 		return grpcErr(errors.New("no database provided"))
 	}
 
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return grpcErr(errors.New("Aggregate no store connection exists for id " + norm(rqst.Id)))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return grpcErr(errors.New("Aggregate " + err.Error()))
 	}
+	defer release()
 
-	results, err := store.Aggregate(stream.Context(), norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Pipeline, rqst.Options)
+	results, err := store.Aggregate(stream.Context(), nid, norm(rqst.Database), rqst.Collection, rqst.Pipeline, rqst.Options)
 	if err != nil {
 		return grpcErr(err)
 	}
@@ -542,11 +550,11 @@ func (srv *server) FindOne(ctx context.Context, rqst *persistencepb.FindOneRqst)
 		return nil, grpcErr(errors.New("no database provided"))
 	}
 
-	nid := norm(rqst.Id)
-	store := srv.stores[nid]
-	if store == nil {
-		return nil, grpcErr(errors.New("FindOne no store connection exists for id " + nid))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("FindOne " + err.Error()))
 	}
+	defer release()
 
 	result, err := store.FindOne(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options)
 	if err != nil {
@@ -573,12 +581,13 @@ func (srv *server) Update(ctx context.Context, rqst *persistencepb.UpdateRqst) (
 		return nil, grpcErr(errors.New("no database provided"))
 	}
 
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return nil, grpcErr(errors.New("Update no store connection exists for id " + norm(rqst.Id)))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("Update " + err.Error()))
 	}
+	defer release()
 
-	if err := store.Update(ctx, norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
+	if err := store.Update(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
 		return nil, grpcErr(err)
 	}
 	return &persistencepb.UpdateRsp{Result: true}, nil
@@ -593,12 +602,13 @@ func (srv *server) UpdateOne(ctx context.Context, rqst *persistencepb.UpdateOneR
 		return nil, grpcErr(errors.New("no database provided"))
 	}
 
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return nil, grpcErr(errors.New("UpdateOne no store connection exists for id " + norm(rqst.Id)))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("UpdateOne " + err.Error()))
 	}
+	defer release()
 
-	if err := store.UpdateOne(ctx, norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
+	if err := store.UpdateOne(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
 		return nil, grpcErr(err)
 	}
 	return &persistencepb.UpdateOneRsp{Result: true}, nil
@@ -606,12 +616,13 @@ func (srv *server) UpdateOne(ctx context.Context, rqst *persistencepb.UpdateOneR
 
 // ReplaceOne replaces a single document matching a query.
 func (srv *server) ReplaceOne(ctx context.Context, rqst *persistencepb.ReplaceOneRqst) (*persistencepb.ReplaceOneRsp, error) {
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return nil, grpcErr(errors.New("ReplaceOne no store connection exists for id " + norm(rqst.Id) + " collection: " + rqst.Collection + " query: " + rqst.Query))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("ReplaceOne " + err.Error() + " collection: " + rqst.Collection + " query: " + rqst.Query))
 	}
+	defer release()
 
-	if err := store.ReplaceOne(ctx, norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
+	if err := store.ReplaceOne(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Value, rqst.Options); err != nil {
 		return nil, grpcErr(err)
 	}
 	return &persistencepb.ReplaceOneRsp{Result: true}, nil
@@ -619,11 +630,12 @@ func (srv *server) ReplaceOne(ctx context.Context, rqst *persistencepb.ReplaceOn
 
 // Delete deletes documents matching a query (one or many based on options).
 func (srv *server) Delete(ctx context.Context, rqst *persistencepb.DeleteRqst) (*persistencepb.DeleteRsp, error) {
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return nil, grpcErr(errors.New("Delete no store connection exists for id " + norm(rqst.Id)))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("Delete " + err.Error()))
 	}
-	if err := store.Delete(ctx, norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options); err != nil {
+	defer release()
+	if err := store.Delete(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options); err != nil {
 		return nil, grpcErr(err)
 	}
 	return &persistencepb.DeleteRsp{Result: true}, nil
@@ -631,11 +643,12 @@ func (srv *server) Delete(ctx context.Context, rqst *persistencepb.DeleteRqst) (
 
 // DeleteOne deletes a single document matching a query.
 func (srv *server) DeleteOne(ctx context.Context, rqst *persistencepb.DeleteOneRqst) (*persistencepb.DeleteOneRsp, error) {
-	store := srv.stores[norm(rqst.Id)]
-	if store == nil {
-		return nil, grpcErr(errors.New("DeleteOne no store connection exists for id " + norm(rqst.Id)))
+	store, nid, release, err := srv.storeForRequest(rqst.Id, rqst.TransactionId)
+	if err != nil {
+		return nil, grpcErr(errors.New("DeleteOne " + err.Error()))
 	}
-	if err := store.DeleteOne(ctx, norm(rqst.Id), norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options); err != nil {
+	defer release()
+	if err := store.DeleteOne(ctx, nid, norm(rqst.Database), rqst.Collection, rqst.Query, rqst.Options); err != nil {
 		return nil, grpcErr(err)
 	}
 	return &persistencepb.DeleteOneRsp{Result: true}, nil
@@ -13,6 +13,7 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/globulario/services/golang/config"
@@ -124,6 +125,12 @@ type server struct {
 	connections map[string]connection
 	// Active stores keyed by connection id
 	stores map[string]persistence_store.Store
+	// Open transactions keyed by transaction id
+	transactions map[string]*openTransaction
+	txLock       sync.Mutex
+
+	// Serializes schema migrations so two requests cannot apply the same set
+	migrationLock sync.Mutex
 }
 
 // -----------------------------------------------------------------------------
@@ -511,6 +518,73 @@ func main() {
 			},
 		},
 
+		// ---- Transactions
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/BeginTransaction",
+			"permission": "write",
+			"resources": []interface{}{
+				// BeginTransactionRqst.id
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "write"},
+			},
+		},
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/Commit",
+			"permission": "write",
+			"resources": []interface{}{
+				// CommitRqst.id
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "write"},
+			},
+		},
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/Rollback",
+			"permission": "write",
+			"resources": []interface{}{
+				// RollbackRqst.id
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "write"},
+			},
+		},
+
+		// ---- Change streams
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/Watch",
+			"permission": "read",
+			"resources": []interface{}{
+				// WatchRqst.id / database / collection
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "read"},
+				map[string]interface{}{"index": 0, "field": "Database", "permission": "read"},
+				map[string]interface{}{"index": 0, "field": "Collection", "permission": "read"},
+			},
+		},
+
+		// ---- Schema migrations
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/ApplyMigrations",
+			"permission": "admin",
+			"resources": []interface{}{
+				// ApplyMigrationsRqst.id / database
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "admin"},
+				map[string]interface{}{"index": 0, "field": "Database", "permission": "admin"},
+			},
+		},
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/PlanMigrations",
+			"permission": "read",
+			"resources": []interface{}{
+				// PlanMigrationsRqst.id / database
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "read"},
+				map[string]interface{}{"index": 0, "field": "Database", "permission": "read"},
+			},
+		},
+		map[string]interface{}{
+			"action":     "/persistence.PersistenceService/ListMigrations",
+			"permission": "read",
+			"resources": []interface{}{
+				// ListMigrationsRqst.id / database
+				map[string]interface{}{"index": 0, "field": "Id", "permission": "read"},
+				map[string]interface{}{"index": 0, "field": "Database", "permission": "read"},
+			},
+		},
+
 		// ---- Admin commands on the store
 		map[string]interface{}{
 			"action":     "/persistence.PersistenceService/RunAdminCmd",
@@ -536,6 +610,10 @@ func main() {
 		{Method: "/persistence.PersistenceService/ReplaceOne", Action: "persistence.write"},
 		{Method: "/persistence.PersistenceService/Delete", Action: "persistence.delete"},
 		{Method: "/persistence.PersistenceService/DeleteOne", Action: "persistence.delete"},
+		{Method: "/persistence.PersistenceService/BeginTransaction", Action: "persistence.write"},
+		{Method: "/persistence.PersistenceService/Commit", Action: "persistence.write"},
+		{Method: "/persistence.PersistenceService/Rollback", Action: "persistence.write"},
+		{Method: "/persistence.PersistenceService/Watch", Action: "persistence.read"},
 		{Method: "/persistence.PersistenceService/Stop", Action: "persistence.admin"},
 		{Method: "/persistence.PersistenceService/CreateConnection", Action: "persistence.admin"},
 		{Method: "/persistence.PersistenceService/DeleteConnection", Action: "persistence.admin"},
@@ -546,6 +624,9 @@ func main() {
 		{Method: "/persistence.PersistenceService/CreateCollection", Action: "persistence.admin"},
 		{Method: "/persistence.PersistenceService/DeleteCollection", Action: "persistence.admin"},
 		{Method: "/persistence.PersistenceService/RunAdminCmd", Action: "persistence.admin"},
+		{Method: "/persistence.PersistenceService/ApplyMigrations", Action: "persistence.admin"},
+		{Method: "/persistence.PersistenceService/PlanMigrations", Action: "persistence.read"},
+		{Method: "/persistence.PersistenceService/ListMigrations", Action: "persistence.read"},
 	})
 
 	// Register client ctor
@@ -0,0 +1,174 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/globulario/services/golang/persistence/persistence_store"
+	"github.com/globulario/services/golang/persistence/persistencepb"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// defaultTransactionTimeout is how long a transaction may stay idle before
+// the server rolls it back.
+const defaultTransactionTimeout = 60 * time.Second
+
+// openTransaction is a transaction started with BeginTransaction.
+type openTransaction struct {
+	id           string
+	connectionId string
+	tx           persistence_store.Transaction
+
+	mu    sync.Mutex // one request at a time (Mongo sessions are not goroutine-safe)
+	idle  time.Duration
+	timer *time.Timer
+}
+
+// txErr maps transaction errors to gRPC status codes.
+func txErr(err error) error {
+	if errors.Is(err, persistence_store.ErrTransactionsNotSupported) {
+		return status.Error(codes.Unimplemented, err.Error())
+	}
+	return grpcErr(err)
+}
+
+// takeTransaction removes a transaction from the open set.
+func (srv *server) takeTransaction(id string) *openTransaction {
+	srv.txLock.Lock()
+	defer srv.txLock.Unlock()
+	t := srv.transactions[id]
+	delete(srv.transactions, id)
+	return t
+}
+
+// expireTransaction rolls back a transaction left idle too long.
+func (srv *server) expireTransaction(id string) {
+	t := srv.takeTransaction(id)
+	if t == nil {
+		return
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if err := t.tx.Rollback(context.Background()); err != nil {
+		slog.Warn("rollback of idle transaction failed", "transaction", id, "err", err)
+		return
+	}
+	slog.Info("idle transaction rolled back", "transaction", id, "connection", t.connectionId)
+}
+
+// storeForRequest resolves the store a CRUD request runs on: its open
+// transaction when transactionId is set, the connection store otherwise.
+// release must be called once the request is done with the store.
+func (srv *server) storeForRequest(id, transactionId string) (persistence_store.Store, string, func(), error) {
+	if transactionId == "" {
+		store, nid, err := srv.storeFor(id)
+		return store, nid, func() {}, err
+	}
+
+	srv.txLock.Lock()
+	t := srv.transactions[transactionId]
+	srv.txLock.Unlock()
+	if t == nil {
+		return nil, "", nil, status.Errorf(codes.NotFound, "no open transaction with id %s", transactionId)
+	}
+	if t.connectionId != norm(id) {
+		return nil, "", nil, status.Errorf(codes.InvalidArgument, "transaction %s does not belong to connection %s", transactionId, id)
+	}
+
+	t.mu.Lock()
+	t.timer.Stop()
+	return t.tx, t.connectionId, func() {
+		t.timer.Reset(t.idle)
+		t.mu.Unlock()
+	}, nil
+}
+
+// BeginTransaction starts a transaction on a connection. CRUD requests that
+// carry the returned id run inside it until Commit or Rollback.
+func (srv *server) BeginTransaction(ctx context.Context, rqst *persistencepb.BeginTransactionRqst) (*persistencepb.BeginTransactionRsp, error) {
+	if rqst.Id == "" {
+		return nil, grpcErr(errors.New("no connection id provided"))
+	}
+	store, nid, err := srv.storeFor(rqst.Id)
+	if err != nil {
+		return nil, grpcErr(err)
+	}
+	transactional, ok := store.(persistence_store.Transactional)
+	if !ok {
+		return nil, txErr(persistence_store.ErrTransactionsNotSupported)
+	}
+
+	tx, err := transactional.BeginTransaction(ctx, nid)
+	if err != nil {
+		return nil, txErr(err)
+	}
+
+	idle := defaultTransactionTimeout
+	if rqst.Timeout > 0 {
+		idle = time.Duration(rqst.Timeout) * time.Second
+	}
+	t := &openTransaction{id: Utility.RandomUUID(), connectionId: nid, tx: tx, idle: idle}
+	t.timer = time.AfterFunc(idle, func() { srv.expireTransaction(t.id) })
+
+	srv.txLock.Lock()
+	if srv.transactions == nil {
+		srv.transactions = make(map[string]*openTransaction)
+	}
+	srv.transactions[t.id] = t
+	srv.txLock.Unlock()
+
+	return &persistencepb.BeginTransactionRsp{TransactionId: t.id}, nil
+}
+
+// endTransaction commits or rolls back an open transaction.
+func (srv *server) endTransaction(ctx context.Context, id, transactionId string, commit bool) error {
+	if transactionId == "" {
+		return status.Error(codes.InvalidArgument, "no transaction id provided")
+	}
+	srv.txLock.Lock()
+	t := srv.transactions[transactionId]
+	if t != nil && t.connectionId != norm(id) {
+		srv.txLock.Unlock()
+		return status.Errorf(codes.InvalidArgument, "transaction %s does not belong to connection %s", transactionId, id)
+	}
+	delete(srv.transactions, transactionId)
+	srv.txLock.Unlock()
+	if t == nil {
+		return status.Errorf(codes.NotFound, "no open transaction with id %s", transactionId)
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.timer.Stop()
+	if commit {
+		return t.tx.Commit(ctx)
+	}
+	return t.tx.Rollback(ctx)
+}
+
+// Commit applies the writes of a transaction.
+func (srv *server) Commit(ctx context.Context, rqst *persistencepb.CommitRqst) (*persistencepb.CommitRsp, error) {
+	if err := srv.endTransaction(ctx, rqst.Id, rqst.TransactionId, true); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, txErr(err)
+	}
+	return &persistencepb.CommitRsp{Result: true}, nil
+}
+
+// Rollback discards the writes of a transaction.
+func (srv *server) Rollback(ctx context.Context, rqst *persistencepb.RollbackRqst) (*persistencepb.RollbackRsp, error) {
+	if err := srv.endTransaction(ctx, rqst.Id, rqst.TransactionId, false); err != nil {
+		if _, ok := status.FromError(err); ok {
+			return nil, err
+		}
+		return nil, txErr(err)
+	}
+	return &persistencepb.RollbackRsp{Result: true}, nil
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+
+	"github.com/globulario/services/golang/persistence/persistence_store"
+	"github.com/globulario/services/golang/persistence/persistencepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// Watch streams the changes of a collection until the client goes away. A
+// client that reconnects passes the resume token of the last event it got.
+func (srv *server) Watch(rqst *persistencepb.WatchRqst, stream persistencepb.PersistenceService_WatchServer) error {
+	if rqst.Id == "" {
+		return grpcErr(errors.New("no connection id provided"))
+	}
+	if rqst.Database == "" || rqst.Collection == "" {
+		return grpcErr(errors.New("a database and a collection are required"))
+	}
+
+	store, nid, err := srv.storeFor(rqst.Id)
+	if err != nil {
+		return grpcErr(err)
+	}
+	watcher, ok := store.(persistence_store.Watcher)
+	if !ok {
+		return status.Errorf(codes.Unimplemented, "the %s store cannot watch collections", store.GetStoreType())
+	}
+
+	err = watcher.Watch(stream.Context(), nid, norm(rqst.Database), rqst.Collection, rqst.Filter, rqst.ResumeToken, func(evt *persistence_store.ChangeEvent) error {
+		document := ""
+		if evt.Document != nil {
+			data, err := json.Marshal(evt.Document)
+			if err != nil {
+				return err
+			}
+			document = string(data)
+		}
+		return stream.Send(&persistencepb.WatchResp{
+			ResumeToken: evt.Token,
+			Operation:   evt.Operation,
+			DocumentId:  evt.Id,
+			Document:    document,
+			Time:        evt.Time.UnixMilli(),
+		})
+	})
+	if errors.Is(err, persistence_store.ErrResumeTokenExpired) {
+		return status.Error(codes.OutOfRange, err.Error())
+	}
+	if err != nil {
+		return grpcErr(err)
+	}
+	return nil
+}
@@ -0,0 +1,120 @@
+package persistence_store
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// ---------- Schema migrations ----------
+//
+// A migration set is the list of scripts a service ships for a database.
+// Planning compares it with the ledger of the database: scripts of another
+// dialect are ignored, applied scripts must still have the checksum they
+// were applied with, and the remaining ones are pending. A pending script
+// older than the last applied one is refused rather than run out of order.
+
+// ErrMigrationChecksumMismatch is returned when an applied migration script
+// was modified since.
+var ErrMigrationChecksumMismatch = errors.New("migration checksum mismatch")
+
+// ErrMigrationOutOfOrder is returned when a pending migration has a lower
+// version than one already applied.
+var ErrMigrationOutOfOrder = errors.New("migration out of order")
+
+// MigrationChecksum returns the checksum of a migration script.
+func MigrationChecksum(script string) string {
+	sum := sha256.Sum256([]byte(script))
+	return hex.EncodeToString(sum[:])
+}
+
+// PlanMigrations returns the migrations of the dialect not applied yet, in
+// version order.
+func PlanMigrations(dialect string, migrations []*Migration, applied []AppliedMigration) ([]*Migration, error) {
+	set := make([]*Migration, 0, len(migrations))
+	seen := make(map[int64]bool)
+	for _, m := range migrations {
+		if m.Dialect != dialect {
+			continue
+		}
+		if seen[m.Version] {
+			return nil, fmt.Errorf("duplicate migration version %d", m.Version)
+		}
+		seen[m.Version] = true
+		checksum := MigrationChecksum(m.Script)
+		if m.Checksum != "" && m.Checksum != checksum {
+			return nil, fmt.Errorf("%w: migration %d (%s) does not match its checksum", ErrMigrationChecksumMismatch, m.Version, m.Name)
+		}
+		m.Checksum = checksum
+		set = append(set, m)
+	}
+	sort.Slice(set, func(i, j int) bool { return set[i].Version < set[j].Version })
+
+	done := make(map[int64]AppliedMigration, len(applied))
+	var last int64
+	for _, a := range applied {
+		done[a.Version] = a
+		if a.Version > last {
+			last = a.Version
+		}
+	}
+
+	pending := make([]*Migration, 0)
+	for _, m := range set {
+		a, ok := done[m.Version]
+		if ok {
+			if a.Checksum != m.Checksum {
+				return nil, fmt.Errorf("%w: migration %d (%s) was modified after it was applied", ErrMigrationChecksumMismatch, m.Version, m.Name)
+			}
+			continue
+		}
+		if m.Version < last {
+			return nil, fmt.Errorf("%w: migration %d (%s) is older than the applied migration %d", ErrMigrationOutOfOrder, m.Version, m.Name, last)
+		}
+		pending = append(pending, m)
+	}
+	return pending, nil
+}
+
+// ApplyMigrations runs the pending migrations of a database in order and
+// returns the ones applied. It stops at the first failure; the migrations
+// applied before it stay recorded.
+func ApplyMigrations(ctx context.Context, migrator Migrator, connectionId string, database string, migrations []*Migration) ([]AppliedMigration, error) {
+	applied, err := migrator.AppliedMigrations(ctx, connectionId, database)
+	if err != nil {
+		return nil, err
+	}
+	pending, err := PlanMigrations(migrator.MigrationDialect(), migrations, applied)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]AppliedMigration, 0, len(pending))
+	for _, m := range pending {
+		a, err := migrator.ApplyMigration(ctx, connectionId, database, m)
+		if err != nil {
+			return results, fmt.Errorf("migration %d (%s): %w", m.Version, m.Name, err)
+		}
+		results = append(results, *a)
+	}
+	return results, nil
+}
+
+// appliedNow builds the ledger entry of a migration that started at start.
+func appliedNow(m *Migration, start time.Time) *AppliedMigration {
+	checksum := m.Checksum
+	if checksum == "" {
+		checksum = MigrationChecksum(m.Script)
+	}
+	return &AppliedMigration{
+		Version:   m.Version,
+		Name:      m.Name,
+		Checksum:  checksum,
+		AppliedAt: start,
+		Duration:  time.Since(start),
+	}
+}
@@ -0,0 +1,87 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func TestPlanMigrations(t *testing.T) {
+	migrations := []*Migration{
+		{Version: 3, Name: "three", Dialect: "sql", Script: "C"},
+		{Version: 1, Name: "one", Dialect: "sql", Script: "A"},
+		{Version: 2, Name: "two", Dialect: "sql", Script: "B"},
+		{Version: 1, Name: "other", Dialect: "cql", Script: "X"},
+	}
+	applied := []AppliedMigration{{Version: 1, Checksum: MigrationChecksum("A")}}
+
+	pending, err := PlanMigrations("sql", migrations, applied)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(pending) != 2 || pending[0].Version != 2 || pending[1].Version != 3 {
+		t.Fatalf("pending = %v, want versions 2 and 3", pending)
+	}
+
+	edited := []AppliedMigration{{Version: 1, Checksum: MigrationChecksum("A'")}}
+	if _, err := PlanMigrations("sql", migrations, edited); !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Errorf("edited script: got %v, want ErrMigrationChecksumMismatch", err)
+	}
+
+	ahead := []AppliedMigration{{Version: 3, Checksum: MigrationChecksum("C")}}
+	if _, err := PlanMigrations("sql", migrations, ahead); !errors.Is(err, ErrMigrationOutOfOrder) {
+		t.Errorf("older pending script: got %v, want ErrMigrationOutOfOrder", err)
+	}
+
+	corrupt := []*Migration{{Version: 1, Dialect: "sql", Script: "A", Checksum: "00"}}
+	if _, err := PlanMigrations("sql", corrupt, nil); !errors.Is(err, ErrMigrationChecksumMismatch) {
+		t.Errorf("wrong checksum: got %v, want ErrMigrationChecksumMismatch", err)
+	}
+
+	duplicate := []*Migration{{Version: 1, Dialect: "sql", Script: "A"}, {Version: 1, Dialect: "sql", Script: "B"}}
+	if _, err := PlanMigrations("sql", duplicate, nil); err == nil {
+		t.Error("expected an error for duplicate versions")
+	}
+}
+
+func TestSqlStoreApplyMigrations(t *testing.T) {
+	store := newTestSqlStore(t)
+	ctx := context.Background()
+
+	migrations := []*Migration{
+		{Version: 1, Name: "accounts", Dialect: "sql", Script: "CREATE TABLE accounts (_id TEXT PRIMARY KEY, name TEXT);"},
+		{Version: 2, Name: "email", Dialect: "sql", Script: "ALTER TABLE accounts ADD COLUMN email TEXT; CREATE INDEX accounts_email ON accounts (email);"},
+	}
+	applied, err := ApplyMigrations(ctx, store, "test", "test_db", migrations)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 {
+		t.Fatalf("applied %d migrations, want 2", len(applied))
+	}
+	if _, err := store.InsertOne(ctx, "test", "test_db", "accounts", map[string]interface{}{"_id": "a1", "name": "alice", "email": "a@example.com"}, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Applying the same set again is a no-op.
+	if applied, err := ApplyMigrations(ctx, store, "test", "test_db", migrations); err != nil || len(applied) != 0 {
+		t.Fatalf("second run applied %v, %v; want nothing", applied, err)
+	}
+
+	// A failing script is rolled back entirely and stays pending.
+	migrations = append(migrations, &Migration{Version: 3, Name: "broken", Dialect: "sql",
+		Script: "CREATE TABLE roles (_id TEXT); INSERT INTO missing VALUES (1);"})
+	if _, err := ApplyMigrations(ctx, store, "test", "test_db", migrations); err == nil {
+		t.Fatal("expected the broken migration to fail")
+	}
+	if store.isTableExist("test", "test_db", "roles") {
+		t.Error("the failed migration left a table behind")
+	}
+	ledger, err := store.AppliedMigrations(ctx, "test", "test_db")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ledger) != 2 || ledger[0].Version != 1 || ledger[1].Checksum != MigrationChecksum(migrations[1].Script) {
+		t.Errorf("ledger = %+v, want versions 1 and 2", ledger)
+	}
+}
@@ -0,0 +1,105 @@
+package persistence_store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+const mongoMigrationsCollection = "schema_migrations"
+
+var _ Migrator = (*MongoStore)(nil)
+
+/**
+ * Return "mongo": a script is a JSON array of database commands (extended
+ * JSON), run in order with runCommand, e.g.
+ *
+ *	[{"create": "accounts"},
+ *	 {"createIndexes": "accounts", "indexes": [{"key": {"email": 1}, "name": "email", "unique": true}]}]
+ */
+func (store *MongoStore) MigrationDialect() string { return "mongo" }
+
+/**
+ * Return the ledger of a database, kept in its schema_migrations collection.
+ */
+func (store *MongoStore) AppliedMigrations(ctx context.Context, connectionId string, database string) ([]AppliedMigration, error) {
+	client := store.clients[connectionId]
+	if client == nil {
+		return nil, errors.New("No connection found with name " + connectionId)
+	}
+
+	cur, err := client.Database(database).Collection(mongoMigrationsCollection).Find(ctx, bson.M{}, options.Find().SetSort(bson.M{"_id": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cur.Close(context.Background())
+
+	applied := make([]AppliedMigration, 0)
+	for cur.Next(ctx) {
+		var entry struct {
+			Version    int64     `bson:"_id"`
+			Name       string    `bson:"name"`
+			Checksum   string    `bson:"checksum"`
+			AppliedAt  time.Time `bson:"applied_at"`
+			DurationMs int64     `bson:"duration_ms"`
+		}
+		if err := cur.Decode(&entry); err != nil {
+			return nil, err
+		}
+		applied = append(applied, AppliedMigration{
+			Version:   entry.Version,
+			Name:      entry.Name,
+			Checksum:  entry.Checksum,
+			AppliedAt: entry.AppliedAt,
+			Duration:  time.Duration(entry.DurationMs) * time.Millisecond,
+		})
+	}
+	return applied, cur.Err()
+}
+
+/**
+ * Run the commands of a script in order, then record it. A failing command
+ * leaves the previous ones applied and the migration unrecorded.
+ */
+func (store *MongoStore) ApplyMigration(ctx context.Context, connectionId string, database string, migration *Migration) (*AppliedMigration, error) {
+	client := store.clients[connectionId]
+	if client == nil {
+		return nil, errors.New("No connection found with name " + connectionId)
+	}
+
+	var commands []json.RawMessage
+	if err := json.Unmarshal([]byte(migration.Script), &commands); err != nil {
+		return nil, fmt.Errorf("a mongo migration must be a JSON array of commands: %w", err)
+	}
+
+	db := client.Database(database)
+	start := time.Now()
+	for i, raw := range commands {
+		// bson.D keeps the key order: the command name must come first.
+		var cmd bson.D
+		if err := bson.UnmarshalExtJSON(raw, false, &cmd); err != nil {
+			return nil, fmt.Errorf("command %d: %w", i, err)
+		}
+		if err := db.RunCommand(ctx, cmd).Err(); err != nil {
+			return nil, fmt.Errorf("command %d: %w", i, err)
+		}
+	}
+
+	applied := appliedNow(migration, start)
+	_, err := db.Collection(mongoMigrationsCollection).InsertOne(ctx, bson.M{
+		"_id":         applied.Version,
+		"name":        applied.Name,
+		"checksum":    applied.Checksum,
+		"applied_at":  applied.AppliedAt,
+		"duration_ms": applied.Duration.Milliseconds(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
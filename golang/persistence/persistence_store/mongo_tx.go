@@ -0,0 +1,127 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+/**
+ * A MongoStore bound to a client session with an open transaction. Only the
+ * document operations run in the session; collection and database management
+ * goes straight to the server, as MongoDB does not allow most of it inside a
+ * transaction.
+ */
+type mongoTransaction struct {
+	*MongoStore
+	session mongo.Session
+}
+
+var (
+	_ Transactional = (*MongoStore)(nil)
+	_ Transaction   = (*mongoTransaction)(nil)
+)
+
+/**
+ * Start a transaction. MongoDB supports them on replica sets and sharded
+ * clusters only; a standalone server returns ErrTransactionsNotSupported.
+ */
+func (store *MongoStore) BeginTransaction(ctx context.Context, connectionId string) (Transaction, error) {
+	client := store.clients[connectionId]
+	if client == nil {
+		return nil, errors.New("No connection found with name " + connectionId)
+	}
+
+	var hello bson.M
+	if err := client.Database("admin").RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return nil, err
+	}
+	if hello["setName"] == nil && hello["msg"] != "isdbgrid" {
+		return nil, fmt.Errorf("%w: mongo server is not a replica set member", ErrTransactionsNotSupported)
+	}
+
+	session, err := client.StartSession()
+	if err != nil {
+		return nil, err
+	}
+	if err := session.StartTransaction(); err != nil {
+		session.EndSession(ctx)
+		return nil, err
+	}
+	return &mongoTransaction{MongoStore: store, session: session}, nil
+}
+
+func (t *mongoTransaction) bind(ctx context.Context) context.Context {
+	return mongo.NewSessionContext(ctx, t.session)
+}
+
+/**
+ * Commit the transaction and end the session.
+ */
+func (t *mongoTransaction) Commit(ctx context.Context) error {
+	defer t.session.EndSession(ctx)
+	return t.session.CommitTransaction(ctx)
+}
+
+/**
+ * Abort the transaction and end the session.
+ */
+func (t *mongoTransaction) Rollback(ctx context.Context) error {
+	defer t.session.EndSession(ctx)
+	return t.session.AbortTransaction(ctx)
+}
+
+func (t *mongoTransaction) Connect(id string, host string, port int32, user string, password string, database string, timeout int32, optionsStr string) error {
+	return errors.New("cannot connect inside a transaction")
+}
+
+func (t *mongoTransaction) Disconnect(connectionId string) error {
+	return errors.New("cannot disconnect inside a transaction")
+}
+
+func (t *mongoTransaction) Count(ctx context.Context, connectionId string, database string, collection string, query string, optionsStr string) (int64, error) {
+	return t.MongoStore.Count(t.bind(ctx), connectionId, database, collection, query, optionsStr)
+}
+
+func (t *mongoTransaction) InsertOne(ctx context.Context, connectionId string, database string, collection string, entity interface{}, optionsStr string) (interface{}, error) {
+	return t.MongoStore.InsertOne(t.bind(ctx), connectionId, database, collection, entity, optionsStr)
+}
+
+func (t *mongoTransaction) InsertMany(ctx context.Context, connectionId string, database string, collection string, entities []interface{}, optionsStr string) ([]interface{}, error) {
+	return t.MongoStore.InsertMany(t.bind(ctx), connectionId, database, collection, entities, optionsStr)
+}
+
+func (t *mongoTransaction) Find(ctx context.Context, connectionId string, database string, collection string, query string, optionsStr string) ([]interface{}, error) {
+	return t.MongoStore.Find(t.bind(ctx), connectionId, database, collection, query, optionsStr)
+}
+
+func (t *mongoTransaction) FindOne(ctx context.Context, connectionId string, database string, collection string, query string, optionsStr string) (interface{}, error) {
+	return t.MongoStore.FindOne(t.bind(ctx), connectionId, database, collection, query, optionsStr)
+}
+
+func (t *mongoTransaction) Aggregate(ctx context.Context, connectionId string, database string, collection string, pipeline string, optionsStr string) ([]interface{}, error) {
+	return t.MongoStore.Aggregate(t.bind(ctx), connectionId, database, collection, pipeline, optionsStr)
+}
+
+func (t *mongoTransaction) Update(ctx context.Context, connectionId string, database string, collection string, query string, value string, optionsStr string) error {
+	return t.MongoStore.Update(t.bind(ctx), connectionId, database, collection, query, value, optionsStr)
+}
+
+func (t *mongoTransaction) UpdateOne(ctx context.Context, connectionId string, database string, collection string, query string, value string, optionsStr string) error {
+	return t.MongoStore.UpdateOne(t.bind(ctx), connectionId, database, collection, query, value, optionsStr)
+}
+
+func (t *mongoTransaction) ReplaceOne(ctx context.Context, connectionId string, database string, collection string, query string, value string, optionsStr string) error {
+	return t.MongoStore.ReplaceOne(t.bind(ctx), connectionId, database, collection, query, value, optionsStr)
+}
+
+func (t *mongoTransaction) Delete(ctx context.Context, connectionId string, database string, collection string, query string, optionsStr string) error {
+	return t.MongoStore.Delete(t.bind(ctx), connectionId, database, collection, query, optionsStr)
+}
+
+func (t *mongoTransaction) DeleteOne(ctx context.Context, connectionId string, database string, collection string, query string, optionsStr string) error {
+	return t.MongoStore.DeleteOne(t.bind(ctx), connectionId, database, collection, query, optionsStr)
+}
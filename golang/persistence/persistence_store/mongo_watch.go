@@ -0,0 +1,121 @@
+package persistence_store
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	Utility "github.com/globulario/utility"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+// changeStreamHistoryLost is the server error returned when a resume token
+// fell out of the oplog.
+const changeStreamHistoryLost = 286
+
+var _ Watcher = (*MongoStore)(nil)
+
+/**
+ * Rewrite a document filter so it applies to change events: _id matches the
+ * document key, other fields the document after the change. Deletes carry no
+ * document, so they only pass filters on _id.
+ */
+func changeStreamFilter(filter map[string]interface{}) bson.M {
+	out := bson.M{}
+	for key, value := range filter {
+		switch key {
+		case "$and", "$or", "$nor":
+			clauses, _ := value.([]interface{})
+			mapped := make([]interface{}, 0, len(clauses))
+			for _, clause := range clauses {
+				if m, ok := clause.(map[string]interface{}); ok {
+					mapped = append(mapped, changeStreamFilter(m))
+				}
+			}
+			out[key] = mapped
+		case "_id":
+			out["documentKey._id"] = value
+		default:
+			out["fullDocument."+key] = value
+		}
+	}
+	return out
+}
+
+/**
+ * Stream the changes of a collection with a change stream. MongoDB supports
+ * them on replica sets and sharded clusters only. The resume token is the
+ * _data field of the change stream token.
+ */
+func (store *MongoStore) Watch(ctx context.Context, connectionId string, database string, collection string, filter string, resumeToken string, fn func(*ChangeEvent) error) error {
+	client := store.clients[connectionId]
+	if client == nil {
+		return errors.New("No connection found with name " + connectionId)
+	}
+
+	match := bson.M{"operationType": bson.M{"$in": bson.A{"insert", "update", "replace", "delete"}}}
+	if filter = strings.TrimSpace(filter); filter != "" && filter != "{}" {
+		f := make(map[string]interface{})
+		if err := json.Unmarshal([]byte(filter), &f); err != nil {
+			return err
+		}
+		match = bson.M{"$and": bson.A{match, changeStreamFilter(f)}}
+	}
+
+	opts := options.ChangeStream().SetFullDocument(options.UpdateLookup)
+	if resumeToken != "" {
+		opts.SetResumeAfter(bson.M{"_data": resumeToken})
+	}
+
+	cs, err := client.Database(database).Collection(collection).Watch(ctx, mongo.Pipeline{{{Key: "$match", Value: match}}}, opts)
+	if err != nil {
+		return mongoWatchErr(err)
+	}
+	defer cs.Close(context.Background())
+
+	for cs.Next(ctx) {
+		var change struct {
+			OperationType string                 `bson:"operationType"`
+			DocumentKey   bson.M                 `bson:"documentKey"`
+			FullDocument  map[string]interface{} `bson:"fullDocument"`
+			ClusterTime   primitive.Timestamp    `bson:"clusterTime"`
+		}
+		if err := cs.Decode(&change); err != nil {
+			return err
+		}
+
+		id := change.DocumentKey["_id"]
+		if oid, ok := id.(primitive.ObjectID); ok {
+			id = oid.Hex()
+		}
+		evt := &ChangeEvent{
+			Token:      cs.ResumeToken().Lookup("_data").StringValue(),
+			Operation:  change.OperationType,
+			Collection: collection,
+			Id:         Utility.ToString(id),
+			Document:   change.FullDocument,
+			Time:       time.Unix(int64(change.ClusterTime.T), 0),
+		}
+		if err := fn(evt); err != nil {
+			return err
+		}
+	}
+	if ctx.Err() != nil {
+		return nil
+	}
+	return mongoWatchErr(cs.Err())
+}
+
+func mongoWatchErr(err error) error {
+	var se mongo.ServerError
+	if errors.As(err, &se) && se.HasErrorCode(changeStreamHistoryLost) {
+		return fmt.Errorf("%w: %v", ErrResumeTokenExpired, err)
+	}
+	return err
+}
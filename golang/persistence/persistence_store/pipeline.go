@@ -0,0 +1,953 @@
+package persistence_store
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	Utility "github.com/globulario/utility"
+)
+
+// pipeline.go holds the backend-neutral form of the Mongo aggregation
+// pipeline. The SQL store compiles it to a single SELECT statement
+// (see pipeline_sql.go) and the Scylla store feeds its pages through the
+// in-memory evaluator below, so a reporting query written for MongoDB runs
+// unchanged on every backend.
+//
+// Supported stages: $match, $project, $group ($sum, $avg, $min, $max,
+// $count), $sort, $skip, $limit, $lookup and $count.
+
+type stageKind int
+
+const (
+	stageMatch stageKind = iota
+	stageProject
+	stageGroup
+	stageSort
+	stageSkip
+	stageLimit
+	stageLookup
+	stageCount
+)
+
+// projectField is one entry of a $project stage.
+type projectField struct {
+	name       string      // output field name
+	include    bool        // keep the field (1/true) or drop it (0/false)
+	source     string      // "$field" reference copied under name, empty otherwise
+	literal    interface{} // {"$literal": v} value
+	hasLiteral bool
+}
+
+// groupAccumulator is one output field of a $group stage.
+type groupAccumulator struct {
+	name     string  // output field name
+	op       string  // $sum, $avg, $min, $max or $count
+	field    string  // source field when the argument is "$field"
+	constant float64 // constant argument of $sum (e.g. {"$sum": 1})
+}
+
+// groupKey is one component of a $group _id. A nil _id produces a single
+// group; a "$field" produces a scalar key; an object produces named parts.
+type groupKey struct {
+	name  string // part name, empty for a scalar key
+	field string // source field
+}
+
+type sortKey struct {
+	field string
+	desc  bool
+}
+
+type lookupSpec struct {
+	from         string
+	localField   string
+	foreignField string
+	as           string
+}
+
+// pipelineStage is a parsed aggregation stage.
+type pipelineStage struct {
+	kind stageKind
+
+	filter map[string]interface{} // $match
+
+	project        []projectField // $project
+	projectExclude bool           // $project is in exclusion mode
+	excludeId      bool           // $project has _id: 0
+
+	groupKeys      []groupKey // $group _id, empty for a single group
+	groupComposite bool       // $group _id is an object
+	accumulators   []groupAccumulator
+
+	sort []sortKey // $sort
+
+	n int64 // $skip / $limit
+
+	lookup lookupSpec // $lookup
+
+	countField string // $count
+}
+
+// orderedField keeps the key order of a JSON object, which matters for
+// $sort and $project and is lost by a plain map decode.
+type orderedField struct {
+	key   string
+	value json.RawMessage
+}
+
+func decodeOrdered(raw json.RawMessage) ([]orderedField, error) {
+	dec := json.NewDecoder(bytes.NewReader(raw))
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return nil, errors.New("expected a JSON object")
+	}
+	fields := make([]orderedField, 0)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return nil, err
+		}
+		key, _ := tok.(string)
+		var value json.RawMessage
+		if err := dec.Decode(&value); err != nil {
+			return nil, err
+		}
+		fields = append(fields, orderedField{key: key, value: value})
+	}
+	return fields, nil
+}
+
+// fieldRef returns the field name of a "$field" reference.
+func fieldRef(v interface{}) (string, bool) {
+	s, ok := v.(string)
+	if !ok || len(s) < 2 || s[0] != '$' {
+		return "", false
+	}
+	return s[1:], true
+}
+
+// parsePipeline decodes a JSON pipeline into stages.
+func parsePipeline(pipeline string) ([]pipelineStage, error) {
+	raw := make([]json.RawMessage, 0)
+	if err := json.Unmarshal([]byte(pipeline), &raw); err != nil {
+		return nil, fmt.Errorf("invalid pipeline: %w", err)
+	}
+
+	stages := make([]pipelineStage, 0, len(raw))
+	for i, r := range raw {
+		fields, err := decodeOrdered(r)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pipeline stage %d: %w", i, err)
+		}
+		if len(fields) != 1 {
+			return nil, fmt.Errorf("pipeline stage %d must have exactly one operator", i)
+		}
+		stage, err := parseStage(fields[0].key, fields[0].value)
+		if err != nil {
+			return nil, fmt.Errorf("pipeline stage %d (%s): %w", i, fields[0].key, err)
+		}
+		stages = append(stages, stage)
+	}
+	return stages, nil
+}
+
+func parseStage(op string, value json.RawMessage) (pipelineStage, error) {
+	switch op {
+	case "$match":
+		filter := make(map[string]interface{})
+		if err := json.Unmarshal(value, &filter); err != nil {
+			return pipelineStage{}, err
+		}
+		return pipelineStage{kind: stageMatch, filter: filter}, nil
+
+	case "$project":
+		return parseProject(value)
+
+	case "$group":
+		return parseGroup(value)
+
+	case "$sort":
+		fields, err := decodeOrdered(value)
+		if err != nil {
+			return pipelineStage{}, err
+		}
+		stage := pipelineStage{kind: stageSort}
+		for _, f := range fields {
+			var dir float64
+			if err := json.Unmarshal(f.value, &dir); err != nil || (dir != 1 && dir != -1) {
+				return pipelineStage{}, fmt.Errorf("sort direction of %q must be 1 or -1", f.key)
+			}
+			stage.sort = append(stage.sort, sortKey{field: f.key, desc: dir < 0})
+		}
+		return stage, nil
+
+	case "$skip", "$limit":
+		var n int64
+		if err := json.Unmarshal(value, &n); err != nil || n < 0 {
+			return pipelineStage{}, errors.New("expected a non-negative integer")
+		}
+		if op == "$skip" {
+			return pipelineStage{kind: stageSkip, n: n}, nil
+		}
+		return pipelineStage{kind: stageLimit, n: n}, nil
+
+	case "$lookup":
+		spec := make(map[string]string)
+		if err := json.Unmarshal(value, &spec); err != nil {
+			return pipelineStage{}, errors.New("only the from/localField/foreignField/as form is supported")
+		}
+		l := lookupSpec{from: spec["from"], localField: spec["localField"], foreignField: spec["foreignField"], as: spec["as"]}
+		if l.from == "" || l.localField == "" || l.foreignField == "" || l.as == "" {
+			return pipelineStage{}, errors.New("from, localField, foreignField and as are required")
+		}
+		return pipelineStage{kind: stageLookup, lookup: l}, nil
+
+	case "$count":
+		var name string
+		if err := json.Unmarshal(value, &name); err != nil || name == "" || strings.HasPrefix(name, "$") {
+			return pipelineStage{}, errors.New("expected a field name")
+		}
+		return pipelineStage{kind: stageCount, countField: name}, nil
+	}
+	return pipelineStage{}, errors.New("unsupported stage")
+}
+
+func parseProject(value json.RawMessage) (pipelineStage, error) {
+	fields, err := decodeOrdered(value)
+	if err != nil {
+		return pipelineStage{}, err
+	}
+	stage := pipelineStage{kind: stageProject}
+	included, excluded := 0, 0
+	for _, f := range fields {
+		var v interface{}
+		if err := json.Unmarshal(f.value, &v); err != nil {
+			return pipelineStage{}, err
+		}
+		p := projectField{name: f.key}
+		switch x := v.(type) {
+		case bool:
+			p.include = x
+		case float64:
+			p.include = x != 0
+		case string:
+			src, ok := fieldRef(x)
+			if !ok {
+				return pipelineStage{}, fmt.Errorf("field %q: expected a $field reference", f.key)
+			}
+			p.include, p.source = true, src
+		case map[string]interface{}:
+			lit, ok := x["$literal"]
+			if !ok || len(x) != 1 {
+				return pipelineStage{}, fmt.Errorf("field %q: only $literal expressions are supported", f.key)
+			}
+			p.include, p.literal, p.hasLiteral = true, lit, true
+		default:
+			return pipelineStage{}, fmt.Errorf("field %q: unsupported projection", f.key)
+		}
+
+		if f.key == "_id" && !p.include {
+			stage.excludeId = true
+			continue
+		}
+		if p.include {
+			included++
+		} else {
+			excluded++
+		}
+		stage.project = append(stage.project, p)
+	}
+	if included > 0 && excluded > 0 {
+		return pipelineStage{}, errors.New("cannot mix inclusion and exclusion")
+	}
+	stage.projectExclude = excluded > 0
+	return stage, nil
+}
+
+func parseGroup(value json.RawMessage) (pipelineStage, error) {
+	fields, err := decodeOrdered(value)
+	if err != nil {
+		return pipelineStage{}, err
+	}
+	stage := pipelineStage{kind: stageGroup}
+	hasId := false
+	for _, f := range fields {
+		if f.key == "_id" {
+			hasId = true
+			var id interface{}
+			if err := json.Unmarshal(f.value, &id); err != nil {
+				return pipelineStage{}, err
+			}
+			switch x := id.(type) {
+			case nil:
+			case string:
+				src, ok := fieldRef(x)
+				if !ok {
+					// A constant key groups everything together.
+					continue
+				}
+				stage.groupKeys = []groupKey{{field: src}}
+			case map[string]interface{}:
+				parts, err := decodeOrdered(f.value)
+				if err != nil {
+					return pipelineStage{}, err
+				}
+				stage.groupComposite = true
+				for _, p := range parts {
+					src, ok := fieldRef(x[p.key])
+					if !ok {
+						return pipelineStage{}, fmt.Errorf("_id.%s: expected a $field reference", p.key)
+					}
+					stage.groupKeys = append(stage.groupKeys, groupKey{name: p.key, field: src})
+				}
+			default:
+				// Numeric or boolean constant: single group.
+			}
+			continue
+		}
+
+		acc := make(map[string]interface{})
+		if err := json.Unmarshal(f.value, &acc); err != nil || len(acc) != 1 {
+			return pipelineStage{}, fmt.Errorf("field %q: expected a single accumulator", f.key)
+		}
+		for op, arg := range acc {
+			a := groupAccumulator{name: f.key, op: op}
+			switch op {
+			case "$count":
+			case "$sum", "$avg", "$min", "$max":
+				if src, ok := fieldRef(arg); ok {
+					a.field = src
+				} else if n, ok := toFloat(arg); ok && op == "$sum" {
+					a.constant = n
+				} else {
+					return pipelineStage{}, fmt.Errorf("field %q: %s expects a $field reference", f.key, op)
+				}
+			default:
+				return pipelineStage{}, fmt.Errorf("field %q: unsupported accumulator %s", f.key, op)
+			}
+			stage.accumulators = append(stage.accumulators, a)
+		}
+	}
+	if !hasId {
+		return pipelineStage{}, errors.New("the _id field is required")
+	}
+	return stage, nil
+}
+
+// ---------- Value helpers ----------
+
+// getPath resolves a dotted path inside a document.
+func getPath(doc map[string]interface{}, path string) (interface{}, bool) {
+	var cur interface{} = doc
+	for _, part := range strings.Split(path, ".") {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		cur, ok = m[part]
+		if !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+func toFloat(v interface{}) (float64, bool) {
+	switch x := v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return reflect.ValueOf(x).Convert(reflect.TypeOf(float64(0))).Float(), true
+	case json.Number:
+		f, err := x.Float64()
+		return f, err == nil
+	}
+	return 0, false
+}
+
+// typeRank orders values of different types the way Mongo does:
+// null < numbers < strings < objects < arrays < booleans.
+func typeRank(v interface{}) int {
+	if v == nil {
+		return 0
+	}
+	if _, ok := toFloat(v); ok {
+		return 1
+	}
+	switch v.(type) {
+	case string:
+		return 2
+	case map[string]interface{}:
+		return 3
+	case []interface{}:
+		return 4
+	case bool:
+		return 5
+	}
+	return 6
+}
+
+// compareValues returns -1, 0 or 1.
+func compareValues(a, b interface{}) int {
+	ra, rb := typeRank(a), typeRank(b)
+	if ra != rb {
+		if ra < rb {
+			return -1
+		}
+		return 1
+	}
+	switch ra {
+	case 1:
+		fa, _ := toFloat(a)
+		fb, _ := toFloat(b)
+		switch {
+		case fa < fb:
+			return -1
+		case fa > fb:
+			return 1
+		}
+		return 0
+	case 2:
+		return strings.Compare(a.(string), b.(string))
+	case 5:
+		ba, bb := a.(bool), b.(bool)
+		if ba == bb {
+			return 0
+		}
+		if !ba {
+			return -1
+		}
+		return 1
+	}
+	return strings.Compare(Utility.ToString(a), Utility.ToString(b))
+}
+
+func valuesEqual(a, b interface{}) bool {
+	if typeRank(a) == 1 && typeRank(b) == 1 {
+		return compareValues(a, b) == 0
+	}
+	return reflect.DeepEqual(a, b)
+}
+
+// keyOf returns a canonical string for grouping and join lookups.
+func keyOf(v interface{}) string {
+	if f, ok := toFloat(v); ok {
+		v = f
+	}
+	data, _ := json.Marshal(v)
+	return string(data)
+}
+
+// ---------- Filter evaluation ----------
+
+// matchDocument reports whether doc satisfies a Mongo filter.
+func matchDocument(doc map[string]interface{}, filter map[string]interface{}) (bool, error) {
+	for key, cond := range filter {
+		var ok bool
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			ok, err = matchLogical(doc, key, cond)
+		default:
+			value, exists := getPath(doc, key)
+			ok, err = matchField(value, exists, cond)
+		}
+		if err != nil || !ok {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func matchLogical(doc map[string]interface{}, op string, cond interface{}) (bool, error) {
+	clauses, ok := cond.([]interface{})
+	if !ok {
+		return false, fmt.Errorf("%s expects an array", op)
+	}
+	for _, c := range clauses {
+		sub, ok := c.(map[string]interface{})
+		if !ok {
+			return false, fmt.Errorf("%s expects an array of objects", op)
+		}
+		m, err := matchDocument(doc, sub)
+		if err != nil {
+			return false, err
+		}
+		switch {
+		case op == "$and" && !m:
+			return false, nil
+		case op == "$or" && m:
+			return true, nil
+		case op == "$nor" && m:
+			return false, nil
+		}
+	}
+	return op != "$or", nil
+}
+
+// isOperatorObject reports whether cond is an object of $operators.
+func isOperatorObject(cond interface{}) (map[string]interface{}, bool) {
+	m, ok := cond.(map[string]interface{})
+	if !ok || len(m) == 0 {
+		return nil, false
+	}
+	for k := range m {
+		if !strings.HasPrefix(k, "$") {
+			return nil, false
+		}
+	}
+	return m, true
+}
+
+// equalsOrContains implements Mongo equality, where an array field matches
+// when any of its elements is equal to the operand.
+func equalsOrContains(value interface{}, operand interface{}) bool {
+	if valuesEqual(value, operand) {
+		return true
+	}
+	if arr, ok := value.([]interface{}); ok {
+		for _, v := range arr {
+			if valuesEqual(v, operand) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func matchField(value interface{}, exists bool, cond interface{}) (bool, error) {
+	ops, ok := isOperatorObject(cond)
+	if !ok {
+		if cond == nil {
+			return value == nil, nil
+		}
+		return exists && equalsOrContains(value, cond), nil
+	}
+
+	for op, operand := range ops {
+		var ok bool
+		switch op {
+		case "$eq":
+			ok = (operand == nil && value == nil) || (exists && equalsOrContains(value, operand))
+		case "$ne":
+			ok = !((operand == nil && value == nil) || (exists && equalsOrContains(value, operand)))
+		case "$gt", "$gte", "$lt", "$lte":
+			if !exists || typeRank(value) != typeRank(operand) {
+				return false, nil
+			}
+			c := compareValues(value, operand)
+			ok = (op == "$gt" && c > 0) || (op == "$gte" && c >= 0) || (op == "$lt" && c < 0) || (op == "$lte" && c <= 0)
+		case "$in", "$nin":
+			list, isList := operand.([]interface{})
+			if !isList {
+				return false, fmt.Errorf("%s expects an array", op)
+			}
+			found := false
+			for _, item := range list {
+				if (item == nil && value == nil) || (exists && equalsOrContains(value, item)) {
+					found = true
+					break
+				}
+			}
+			ok = found == (op == "$in")
+		case "$exists":
+			ok = exists == Utility.ToBool(operand)
+		case "$regex":
+			pattern, isString := operand.(string)
+			if !isString {
+				return false, errors.New("$regex expects a string")
+			}
+			if flags, _ := ops["$options"].(string); strings.Contains(flags, "i") {
+				pattern = "(?i)" + pattern
+			}
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return false, err
+			}
+			s, isString := value.(string)
+			ok = exists && isString && re.MatchString(s)
+		case "$options":
+			ok = true
+		case "$not":
+			m, err := matchField(value, exists, operand)
+			if err != nil {
+				return false, err
+			}
+			ok = !m
+		default:
+			return false, fmt.Errorf("unsupported query operator %s", op)
+		}
+		if !ok {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// ---------- In-memory evaluation ----------
+
+// lookupFunc returns every document of a collection; it is used by $lookup.
+type lookupFunc func(collection string) ([]map[string]interface{}, error)
+
+// docStream is one operator of the evaluation chain. push returns false
+// when the operator will not accept more documents, which lets the caller
+// stop paging early (e.g. after a $limit).
+type docStream interface {
+	push(doc map[string]interface{}) (bool, error)
+	flush() error
+}
+
+type collectOp struct{ docs []interface{} }
+
+func (op *collectOp) push(doc map[string]interface{}) (bool, error) {
+	op.docs = append(op.docs, doc)
+	return true, nil
+}
+func (op *collectOp) flush() error { return nil }
+
+type matchOp struct {
+	filter map[string]interface{}
+	next   docStream
+}
+
+func (op *matchOp) push(doc map[string]interface{}) (bool, error) {
+	ok, err := matchDocument(doc, op.filter)
+	if err != nil || !ok {
+		return err == nil, err
+	}
+	return op.next.push(doc)
+}
+func (op *matchOp) flush() error { return op.next.flush() }
+
+type projectOp struct {
+	stage pipelineStage
+	next  docStream
+}
+
+func (op *projectOp) push(doc map[string]interface{}) (bool, error) {
+	out := make(map[string]interface{})
+	if op.stage.projectExclude {
+		for k, v := range doc {
+			out[k] = v
+		}
+		for _, p := range op.stage.project {
+			delete(out, p.name)
+		}
+	} else {
+		if id, ok := doc["_id"]; ok {
+			out["_id"] = id
+		}
+		for _, p := range op.stage.project {
+			switch {
+			case p.hasLiteral:
+				out[p.name] = p.literal
+			case p.source != "":
+				if v, ok := getPath(doc, p.source); ok {
+					out[p.name] = v
+				}
+			default:
+				if v, ok := getPath(doc, p.name); ok {
+					out[p.name] = v
+				}
+			}
+		}
+	}
+	if op.stage.excludeId {
+		delete(out, "_id")
+	}
+	return op.next.push(out)
+}
+func (op *projectOp) flush() error { return op.next.flush() }
+
+type skipOp struct {
+	n, seen int64
+	next    docStream
+}
+
+func (op *skipOp) push(doc map[string]interface{}) (bool, error) {
+	if op.seen < op.n {
+		op.seen++
+		return true, nil
+	}
+	return op.next.push(doc)
+}
+func (op *skipOp) flush() error { return op.next.flush() }
+
+type limitOp struct {
+	n, emitted int64
+	next       docStream
+}
+
+func (op *limitOp) push(doc map[string]interface{}) (bool, error) {
+	if op.emitted >= op.n {
+		return false, nil
+	}
+	op.emitted++
+	more, err := op.next.push(doc)
+	return more && op.emitted < op.n, err
+}
+func (op *limitOp) flush() error { return op.next.flush() }
+
+type lookupOp struct {
+	spec   lookupSpec
+	source lookupFunc
+	index  map[string][]interface{}
+	next   docStream
+}
+
+func (op *lookupOp) push(doc map[string]interface{}) (bool, error) {
+	if op.index == nil {
+		foreign, err := op.source(op.spec.from)
+		if err != nil {
+			return false, err
+		}
+		op.index = make(map[string][]interface{})
+		for _, f := range foreign {
+			v, _ := getPath(f, op.spec.foreignField)
+			values := []interface{}{v}
+			if arr, ok := v.([]interface{}); ok {
+				values = arr
+			}
+			for _, x := range values {
+				k := keyOf(x)
+				op.index[k] = append(op.index[k], f)
+			}
+		}
+	}
+
+	local, _ := getPath(doc, op.spec.localField)
+	locals := []interface{}{local}
+	if arr, ok := local.([]interface{}); ok {
+		locals = arr
+	}
+	matches := make([]interface{}, 0)
+	seen := make(map[interface{}]bool)
+	for _, l := range locals {
+		for _, f := range op.index[keyOf(l)] {
+			p := reflect.ValueOf(f).Pointer()
+			if !seen[p] {
+				seen[p] = true
+				matches = append(matches, f)
+			}
+		}
+	}
+
+	out := make(map[string]interface{}, len(doc)+1)
+	for k, v := range doc {
+		out[k] = v
+	}
+	out[op.spec.as] = matches
+	return op.next.push(out)
+}
+func (op *lookupOp) flush() error { return op.next.flush() }
+
+type groupState struct {
+	id     interface{}
+	values []interface{} // running value per accumulator
+	counts []int64       // number of contributing values per accumulator
+}
+
+type groupOp struct {
+	stage  pipelineStage
+	groups map[string]*groupState
+	order  []string
+	next   docStream
+}
+
+func (op *groupOp) push(doc map[string]interface{}) (bool, error) {
+	var id interface{}
+	if op.stage.groupComposite {
+		parts := make(map[string]interface{}, len(op.stage.groupKeys))
+		for _, k := range op.stage.groupKeys {
+			parts[k.name], _ = getPath(doc, k.field)
+		}
+		id = parts
+	} else if len(op.stage.groupKeys) == 1 {
+		id, _ = getPath(doc, op.stage.groupKeys[0].field)
+	}
+
+	key := keyOf(id)
+	g := op.groups[key]
+	if g == nil {
+		g = &groupState{id: id, values: make([]interface{}, len(op.stage.accumulators)), counts: make([]int64, len(op.stage.accumulators))}
+		op.groups[key] = g
+		op.order = append(op.order, key)
+	}
+
+	for i, acc := range op.stage.accumulators {
+		switch acc.op {
+		case "$count":
+			g.counts[i]++
+		case "$sum", "$avg":
+			n := acc.constant
+			if acc.field != "" {
+				v, _ := getPath(doc, acc.field)
+				f, ok := toFloat(v)
+				if !ok {
+					continue
+				}
+				n = f
+			}
+			sum, _ := g.values[i].(float64)
+			g.values[i] = sum + n
+			g.counts[i]++
+		case "$min", "$max":
+			v, ok := getPath(doc, acc.field)
+			if !ok || v == nil {
+				continue
+			}
+			if g.counts[i] == 0 {
+				g.values[i] = v
+			} else if c := compareValues(v, g.values[i]); (acc.op == "$min" && c < 0) || (acc.op == "$max" && c > 0) {
+				g.values[i] = v
+			}
+			g.counts[i]++
+		}
+	}
+	return true, nil
+}
+
+func (op *groupOp) flush() error {
+	for _, key := range op.order {
+		g := op.groups[key]
+		out := map[string]interface{}{"_id": g.id}
+		for i, acc := range op.stage.accumulators {
+			switch acc.op {
+			case "$count":
+				out[acc.name] = g.counts[i]
+			case "$sum":
+				sum, _ := g.values[i].(float64)
+				out[acc.name] = sum
+			case "$avg":
+				if g.counts[i] == 0 {
+					out[acc.name] = nil
+				} else {
+					out[acc.name] = g.values[i].(float64) / float64(g.counts[i])
+				}
+			default:
+				out[acc.name] = g.values[i]
+			}
+		}
+		more, err := op.next.push(out)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+	}
+	return op.next.flush()
+}
+
+type sortOp struct {
+	keys []sortKey
+	docs []map[string]interface{}
+	next docStream
+}
+
+func (op *sortOp) push(doc map[string]interface{}) (bool, error) {
+	op.docs = append(op.docs, doc)
+	return true, nil
+}
+
+func (op *sortOp) flush() error {
+	sort.SliceStable(op.docs, func(i, j int) bool {
+		for _, k := range op.keys {
+			a, _ := getPath(op.docs[i], k.field)
+			b, _ := getPath(op.docs[j], k.field)
+			c := compareValues(a, b)
+			if c == 0 {
+				continue
+			}
+			if k.desc {
+				return c > 0
+			}
+			return c < 0
+		}
+		return false
+	})
+	for _, doc := range op.docs {
+		more, err := op.next.push(doc)
+		if err != nil {
+			return err
+		}
+		if !more {
+			break
+		}
+	}
+	return op.next.flush()
+}
+
+type countOp struct {
+	field string
+	n     int64
+	next  docStream
+}
+
+func (op *countOp) push(doc map[string]interface{}) (bool, error) {
+	op.n++
+	return true, nil
+}
+
+func (op *countOp) flush() error {
+	if op.n > 0 {
+		if _, err := op.next.push(map[string]interface{}{op.field: op.n}); err != nil {
+			return err
+		}
+	}
+	return op.next.flush()
+}
+
+// pipelineEvaluator runs a pipeline over documents pushed one at a time,
+// so a backend can evaluate it while paging through a table.
+type pipelineEvaluator struct {
+	head docStream
+	out  *collectOp
+}
+
+func newPipelineEvaluator(stages []pipelineStage, source lookupFunc) *pipelineEvaluator {
+	out := &collectOp{docs: make([]interface{}, 0)}
+	var next docStream = out
+	for i := len(stages) - 1; i >= 0; i-- {
+		s := stages[i]
+		switch s.kind {
+		case stageMatch:
+			next = &matchOp{filter: s.filter, next: next}
+		case stageProject:
+			next = &projectOp{stage: s, next: next}
+		case stageGroup:
+			next = &groupOp{stage: s, groups: make(map[string]*groupState), next: next}
+		case stageSort:
+			next = &sortOp{keys: s.sort, next: next}
+		case stageSkip:
+			next = &skipOp{n: s.n, next: next}
+		case stageLimit:
+			next = &limitOp{n: s.n, next: next}
+		case stageLookup:
+			next = &lookupOp{spec: s.lookup, source: source, next: next}
+		case stageCount:
+			next = &countOp{field: s.countField, next: next}
+		}
+	}
+	return &pipelineEvaluator{head: next, out: out}
+}
+
+// Push feeds one document; it returns false once no more input is needed.
+func (e *pipelineEvaluator) Push(doc map[string]interface{}) (bool, error) {
+	return e.head.push(doc)
+}
+
+// Results flushes blocking stages and returns the pipeline output.
+func (e *pipelineEvaluator) Results() ([]interface{}, error) {
+	if err := e.head.flush(); err != nil {
+		return nil, err
+	}
+	return e.out.docs, nil
+}
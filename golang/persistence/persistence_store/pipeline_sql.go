@@ -0,0 +1,350 @@
+package persistence_store
+
+import (
+	"fmt"
+	"strings"
+)
+
+// pipeline_sql.go compiles a parsed aggregation pipeline into one SQLite
+// SELECT statement. Every stage wraps the previous one as a sub-query so
+// the column set is always known: the base table columns first, then the
+// names produced by $project, $group and $lookup.
+//
+// Ordering survives later stages through a hidden "__pos" column computed
+// with ROW_NUMBER() by $sort. $lookup results are built with
+// json_group_array and decoded back into arrays by the caller.
+//
+// Only the columns of the main table take part in the pipeline; array
+// fields stored in <table>_<field> side tables are not visible to it.
+
+// sqlPosColumn carries the $sort order through the following stages.
+const sqlPosColumn = "__pos"
+
+// sqlPipeline is a compiled pipeline ready to run with QueryContext.
+type sqlPipeline struct {
+	query       string
+	args        []interface{}
+	jsonColumns map[string]bool // columns holding a JSON array ($lookup)
+}
+
+type sqlPipelineCompiler struct {
+	columnsOf func(table string) ([]string, error)
+
+	query   string
+	args    []interface{}
+	cols    []string
+	ordered bool // rows carry the hidden order column
+	tail    bool // the outermost SELECT already ends with ORDER BY
+	level   int
+	json    map[string]bool
+}
+
+// quoteIdent quotes a column or table name for SQLite.
+func quoteIdent(name string) string {
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// compileSqlPipeline compiles stages against table. columnsOf returns the
+// columns of a table (empty when it does not exist).
+func compileSqlPipeline(table string, stages []pipelineStage, columnsOf func(table string) ([]string, error)) (*sqlPipeline, error) {
+	cols, err := columnsOf(table)
+	if err != nil {
+		return nil, err
+	}
+	if len(cols) == 0 {
+		return nil, nil
+	}
+
+	c := &sqlPipelineCompiler{columnsOf: columnsOf, cols: cols, json: make(map[string]bool)}
+	c.query = fmt.Sprintf("SELECT %s FROM %s", c.selectList(cols), quoteIdent(sanitizeIdentifier(table)))
+
+	for i, s := range stages {
+		var err error
+		switch s.kind {
+		case stageMatch:
+			err = c.match(s)
+		case stageProject:
+			c.project(s)
+		case stageGroup:
+			c.group(s)
+		case stageSort:
+			c.sort(s)
+		case stageSkip, stageLimit:
+			c.skipLimit(s)
+		case stageLookup:
+			err = c.lookup(s)
+		case stageCount:
+			c.count(s)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("pipeline stage %d: %w", i, err)
+		}
+	}
+
+	if c.ordered && !c.tail {
+		c.query += " ORDER BY " + quoteIdent(sqlPosColumn)
+	}
+	return &sqlPipeline{query: c.query, args: c.args, jsonColumns: c.json}, nil
+}
+
+func (c *sqlPipelineCompiler) selectList(cols []string) string {
+	quoted := make([]string, len(cols))
+	for i, col := range cols {
+		quoted[i] = quoteIdent(col)
+	}
+	return strings.Join(quoted, ", ")
+}
+
+func (c *sqlPipelineCompiler) hasColumn(name string) bool {
+	for _, col := range c.cols {
+		if col == name {
+			return true
+		}
+	}
+	return false
+}
+
+// wrap turns the current query into a sub-query and returns its alias.
+func (c *sqlPipelineCompiler) wrap() (from string, alias string) {
+	c.level++
+	alias = fmt.Sprintf("s%d", c.level)
+	return fmt.Sprintf("(%s) AS %s", c.query, alias), alias
+}
+
+// carried returns the current columns plus the hidden order column.
+func (c *sqlPipelineCompiler) carried() string {
+	list := c.selectList(c.cols)
+	if c.ordered {
+		list += ", " + quoteIdent(sqlPosColumn)
+	}
+	return list
+}
+
+func (c *sqlPipelineCompiler) orderSuffix() string {
+	c.tail = c.ordered
+	if c.ordered {
+		return " ORDER BY " + quoteIdent(sqlPosColumn)
+	}
+	return ""
+}
+
+func (c *sqlPipelineCompiler) match(s pipelineStage) error {
+	filter := &sqlFilter{resolve: func(path string) (sqlField, error) {
+		return sqlField{expr: c.column(path), isId: path == "_id"}, nil
+	}}
+	where, args, err := filter.compile(s.filter)
+	if err != nil {
+		return err
+	}
+	from, _ := c.wrap()
+	c.query = fmt.Sprintf("SELECT %s FROM %s WHERE %s%s", c.carried(), from, where, c.orderSuffix())
+	c.args = append(c.args, args...)
+	return nil
+}
+
+// column returns the SQL expression of a field, or NULL when the field does
+// not exist at this stage (a missing field behaves like null in Mongo).
+func (c *sqlPipelineCompiler) column(field string) string {
+	if c.hasColumn(field) {
+		return quoteIdent(field)
+	}
+	return "NULL"
+}
+
+func (c *sqlPipelineCompiler) project(s pipelineStage) {
+	exprs := make([]string, 0)
+	cols := make([]string, 0)
+	literals := make([]interface{}, 0)
+
+	if s.projectExclude {
+		excluded := make(map[string]bool)
+		for _, p := range s.project {
+			excluded[p.name] = true
+		}
+		if s.excludeId {
+			excluded["_id"] = true
+		}
+		for _, col := range c.cols {
+			if !excluded[col] {
+				exprs = append(exprs, quoteIdent(col))
+				cols = append(cols, col)
+			}
+		}
+	} else {
+		if !s.excludeId && c.hasColumn("_id") {
+			exprs = append(exprs, quoteIdent("_id"))
+			cols = append(cols, "_id")
+		}
+		for _, p := range s.project {
+			switch {
+			case p.hasLiteral:
+				exprs = append(exprs, "? AS "+quoteIdent(p.name))
+				literals = append(literals, p.literal)
+			case p.source != "":
+				if !c.hasColumn(p.source) {
+					continue
+				}
+				exprs = append(exprs, quoteIdent(p.source)+" AS "+quoteIdent(p.name))
+			default:
+				if !c.hasColumn(p.name) || p.name == "_id" {
+					continue
+				}
+				exprs = append(exprs, quoteIdent(p.name))
+			}
+			cols = append(cols, p.name)
+		}
+	}
+
+	if c.ordered {
+		exprs = append(exprs, quoteIdent(sqlPosColumn))
+	}
+	if len(exprs) == 0 {
+		exprs = append(exprs, "NULL AS "+quoteIdent("_"))
+	}
+	from, _ := c.wrap()
+	c.query = fmt.Sprintf("SELECT %s FROM %s%s", strings.Join(exprs, ", "), from, c.orderSuffix())
+	// Literal placeholders come before those of the inner query.
+	c.args = append(literals, c.args...)
+	c.cols = cols
+}
+
+func (c *sqlPipelineCompiler) group(s pipelineStage) {
+	exprs := make([]string, 0)
+	cols := make([]string, 0)
+	groupBy := make([]string, 0)
+
+	switch {
+	case s.groupComposite:
+		for _, k := range s.groupKeys {
+			name := "_id." + k.name
+			exprs = append(exprs, c.column(k.field)+" AS "+quoteIdent(name))
+			cols = append(cols, name)
+			groupBy = append(groupBy, c.column(k.field))
+		}
+	case len(s.groupKeys) == 1:
+		exprs = append(exprs, c.column(s.groupKeys[0].field)+" AS "+quoteIdent("_id"))
+		cols = append(cols, "_id")
+		groupBy = append(groupBy, c.column(s.groupKeys[0].field))
+	default:
+		exprs = append(exprs, "NULL AS "+quoteIdent("_id"))
+		cols = append(cols, "_id")
+	}
+
+	for _, acc := range s.accumulators {
+		var expr string
+		switch acc.op {
+		case "$count":
+			expr = "COUNT(*)"
+		case "$sum":
+			if acc.field == "" {
+				expr = fmt.Sprintf("COUNT(*) * %v", acc.constant)
+			} else {
+				expr = fmt.Sprintf("COALESCE(SUM(%s), 0)", c.column(acc.field))
+			}
+		case "$avg":
+			expr = fmt.Sprintf("AVG(%s)", c.column(acc.field))
+		case "$min":
+			expr = fmt.Sprintf("MIN(%s)", c.column(acc.field))
+		case "$max":
+			expr = fmt.Sprintf("MAX(%s)", c.column(acc.field))
+		}
+		exprs = append(exprs, expr+" AS "+quoteIdent(acc.name))
+		cols = append(cols, acc.name)
+	}
+
+	from, _ := c.wrap()
+	q := fmt.Sprintf("SELECT %s FROM %s", strings.Join(exprs, ", "), from)
+	if len(groupBy) > 0 {
+		q += " GROUP BY " + strings.Join(groupBy, ", ")
+	} else {
+		// An aggregate over no rows must not produce a group.
+		q += " HAVING COUNT(*) > 0"
+	}
+	c.query = q
+	c.cols = cols
+	c.ordered = false
+}
+
+func (c *sqlPipelineCompiler) sort(s pipelineStage) {
+	terms := make([]string, 0, len(s.sort)+1)
+	for _, k := range s.sort {
+		dir := "ASC"
+		if k.desc {
+			dir = "DESC"
+		}
+		terms = append(terms, c.column(k.field)+" "+dir)
+	}
+	if c.ordered {
+		terms = append(terms, quoteIdent(sqlPosColumn))
+	}
+	from, _ := c.wrap()
+	c.query = fmt.Sprintf("SELECT %s, ROW_NUMBER() OVER (ORDER BY %s) AS %s FROM %s",
+		c.selectList(c.cols), strings.Join(terms, ", "), quoteIdent(sqlPosColumn), from)
+	c.ordered, c.tail = true, false
+}
+
+func (c *sqlPipelineCompiler) skipLimit(s pipelineStage) {
+	from, _ := c.wrap()
+	q := fmt.Sprintf("SELECT %s FROM %s%s", c.carried(), from, c.orderSuffix())
+	if s.kind == stageLimit {
+		q += fmt.Sprintf(" LIMIT %d", s.n)
+	} else {
+		q += fmt.Sprintf(" LIMIT -1 OFFSET %d", s.n)
+	}
+	c.query = q
+}
+
+func (c *sqlPipelineCompiler) lookup(s pipelineStage) error {
+	foreign, err := c.columnsOf(s.lookup.from)
+	if err != nil {
+		return err
+	}
+
+	from, alias := c.wrap()
+	expr := "'[]'"
+	if len(foreign) > 0 && c.hasColumn(s.lookup.localField) {
+		pairs := make([]string, 0, len(foreign))
+		for _, col := range foreign {
+			pairs = append(pairs, fmt.Sprintf("'%s', f.%s", strings.ReplaceAll(col, "'", "''"), quoteIdent(col)))
+		}
+		expr = fmt.Sprintf("(SELECT json_group_array(json_object(%s)) FROM %s AS f WHERE f.%s = %s.%s)",
+			strings.Join(pairs, ", "),
+			quoteIdent(sanitizeIdentifier(s.lookup.from)),
+			quoteIdent(s.lookup.foreignField),
+			alias, quoteIdent(s.lookup.localField))
+		if !containsString(foreign, s.lookup.foreignField) {
+			expr = "'[]'"
+		}
+	}
+
+	cols := make([]string, 0, len(c.cols)+1)
+	for _, col := range c.cols {
+		if col != s.lookup.as {
+			cols = append(cols, col)
+		}
+	}
+	list := c.selectList(cols)
+	if c.ordered {
+		list += ", " + quoteIdent(sqlPosColumn)
+	}
+	c.query = fmt.Sprintf("SELECT %s, %s AS %s FROM %s%s", list, expr, quoteIdent(s.lookup.as), from, c.orderSuffix())
+	c.cols = append(cols, s.lookup.as)
+	c.json[s.lookup.as] = true
+	return nil
+}
+
+func (c *sqlPipelineCompiler) count(s pipelineStage) {
+	from, _ := c.wrap()
+	c.query = fmt.Sprintf("SELECT COUNT(*) AS %s FROM %s HAVING COUNT(*) > 0", quoteIdent(s.countField), from)
+	c.cols = []string{s.countField}
+	c.ordered = false
+}
+
+func containsString(list []string, s string) bool {
+	for _, v := range list {
+		if v == s {
+			return true
+		}
+	}
+	return false
+}
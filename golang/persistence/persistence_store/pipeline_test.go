@@ -0,0 +1,228 @@
+package persistence_store
+
+import (
+	"context"
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+var pipelineOrders = []map[string]interface{}{
+	{"_id": "o1", "customer": "alice", "region": "east", "amount": 10.0, "qty": 1.0},
+	{"_id": "o2", "customer": "bob", "region": "west", "amount": 25.0, "qty": 2.0},
+	{"_id": "o3", "customer": "alice", "region": "east", "amount": 5.0, "qty": 3.0},
+	{"_id": "o4", "customer": "carol", "region": "west", "amount": 40.0, "qty": 1.0},
+	{"_id": "o5", "customer": "bob", "region": "north", "amount": 15.0, "qty": 5.0},
+}
+
+var pipelineCustomers = []map[string]interface{}{
+	{"_id": "alice", "name": "Alice", "tier": "gold"},
+	{"_id": "bob", "name": "Bob", "tier": "silver"},
+}
+
+// pipelineCases run identically on the in-memory evaluator and on SQLite.
+var pipelineCases = []struct {
+	name     string
+	pipeline string
+	want     string
+}{
+	{
+		name:     "match sort project",
+		pipeline: `[{"$match":{"amount":{"$gte":10}}},{"$sort":{"amount":-1}},{"$project":{"_id":0,"customer":1,"amount":1}}]`,
+		want:     `[{"amount":40,"customer":"carol"},{"amount":25,"customer":"bob"},{"amount":15,"customer":"bob"},{"amount":10,"customer":"alice"}]`,
+	},
+	{
+		name:     "group accumulators",
+		pipeline: `[{"$group":{"_id":"$region","total":{"$sum":"$amount"},"n":{"$sum":1},"avg":{"$avg":"$amount"},"min":{"$min":"$qty"},"max":{"$max":"$qty"}}},{"$sort":{"_id":1}}]`,
+		want:     `[{"_id":"east","avg":7.5,"max":3,"min":1,"n":2,"total":15},{"_id":"north","avg":15,"max":5,"min":5,"n":1,"total":15},{"_id":"west","avg":32.5,"max":2,"min":1,"n":2,"total":65}]`,
+	},
+	{
+		name:     "composite group key",
+		pipeline: `[{"$match":{"customer":{"$in":["alice","bob"]}}},{"$group":{"_id":{"c":"$customer","r":"$region"},"n":{"$count":{}}}},{"$sort":{"_id.c":1,"_id.r":1}}]`,
+		want:     `[{"_id":{"c":"alice","r":"east"},"n":2},{"_id":{"c":"bob","r":"north"},"n":1},{"_id":{"c":"bob","r":"west"},"n":1}]`,
+	},
+	{
+		name:     "skip limit after sort",
+		pipeline: `[{"$sort":{"amount":1}},{"$skip":1},{"$limit":2},{"$project":{"amount":1}}]`,
+		want:     `[{"_id":"o1","amount":10},{"_id":"o5","amount":15}]`,
+	},
+	{
+		name:     "or and count",
+		pipeline: `[{"$match":{"$or":[{"region":"north"},{"amount":{"$lt":10}}]}},{"$count":"n"}]`,
+		want:     `[{"n":2}]`,
+	},
+	{
+		name:     "lookup",
+		pipeline: `[{"$match":{"_id":{"$in":["o1","o4"]}}},{"$lookup":{"from":"customers","localField":"customer","foreignField":"_id","as":"who"}},{"$sort":{"_id":1}},{"$project":{"who":1}}]`,
+		want:     `[{"_id":"o1","who":[{"_id":"alice","name":"Alice","tier":"gold"}]},{"_id":"o4","who":[]}]`,
+	},
+	{
+		name:     "empty group",
+		pipeline: `[{"$match":{"region":"south"}},{"$group":{"_id":null,"total":{"$sum":"$amount"}}}]`,
+		want:     `[]`,
+	},
+}
+
+// normalize round-trips through JSON so numeric types compare equal.
+func normalize(t *testing.T, v interface{}) interface{} {
+	t.Helper()
+	data, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var out interface{}
+	if err := json.Unmarshal(data, &out); err != nil {
+		t.Fatal(err)
+	}
+	return out
+}
+
+func TestParsePipelineErrors(t *testing.T) {
+	for _, p := range []string{
+		`{}`,
+		`[{"$unwind":"$tags"}]`,
+		`[{"$match":{},"$limit":1}]`,
+		`[{"$sort":{"a":2}}]`,
+		`[{"$project":{"a":1,"b":0}}]`,
+		`[{"$group":{"total":{"$sum":"$a"}}}]`,
+		`[{"$group":{"_id":"$a","x":{"$push":"$b"}}}]`,
+		`[{"$lookup":{"from":"b"}}]`,
+	} {
+		if _, err := parsePipeline(p); err == nil {
+			t.Errorf("parsePipeline(%s): expected an error", p)
+		}
+	}
+}
+
+func TestPipelineEvaluator(t *testing.T) {
+	lookup := func(collection string) ([]map[string]interface{}, error) {
+		if collection == "customers" {
+			return pipelineCustomers, nil
+		}
+		return nil, nil
+	}
+	for _, tc := range pipelineCases {
+		t.Run(tc.name, func(t *testing.T) {
+			stages, err := parsePipeline(tc.pipeline)
+			if err != nil {
+				t.Fatal(err)
+			}
+			eval := newPipelineEvaluator(stages, lookup)
+			for _, doc := range pipelineOrders {
+				more, err := eval.Push(doc)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !more {
+					break
+				}
+			}
+			got, err := eval.Results()
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want interface{}
+			_ = json.Unmarshal([]byte(tc.want), &want)
+			if g := normalize(t, got); !reflect.DeepEqual(g, want) {
+				t.Errorf("got %v, want %v", g, want)
+			}
+		})
+	}
+}
+
+func TestPipelineEvaluatorStopsAfterLimit(t *testing.T) {
+	stages, err := parsePipeline(`[{"$limit":2}]`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	eval := newPipelineEvaluator(stages, nil)
+	pushed := 0
+	for _, doc := range pipelineOrders {
+		pushed++
+		if more, _ := eval.Push(doc); !more {
+			break
+		}
+	}
+	if pushed != 2 {
+		t.Errorf("evaluator consumed %d documents, want 2", pushed)
+	}
+}
+
+func newTestSqlStore(t *testing.T) *SqlStore {
+	t.Helper()
+	store := new(SqlStore)
+	options := `{"skip_auth": true, "path": "` + t.TempDir() + `"}`
+	if err := store.Connect("test", "localhost", 0, "", "", "test_db", 0, options); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _ = store.Disconnect("test") })
+	return store
+}
+
+func TestSqlStoreAggregate(t *testing.T) {
+	store := newTestSqlStore(t)
+	ctx := context.Background()
+	for table, docs := range map[string][]map[string]interface{}{"orders": pipelineOrders, "customers": pipelineCustomers} {
+		entities := make([]interface{}, 0, len(docs))
+		for _, d := range docs {
+			entities = append(entities, d)
+		}
+		if _, err := store.InsertMany(ctx, "test", "test_db", table, entities, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	for _, tc := range pipelineCases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := store.Aggregate(ctx, "test", "test_db", "orders", tc.pipeline, "")
+			if err != nil {
+				t.Fatal(err)
+			}
+			var want interface{}
+			_ = json.Unmarshal([]byte(tc.want), &want)
+			if g := normalize(t, got); !reflect.DeepEqual(g, want) {
+				t.Errorf("got %v, want %v", g, want)
+			}
+		})
+	}
+
+	got, err := store.Aggregate(ctx, "test", "test_db", "missing", `[{"$count":"n"}]`, "")
+	if err != nil || len(got) != 0 {
+		t.Errorf("aggregate on a missing table: got %v, %v", got, err)
+	}
+}
+
+func TestRegexToLike(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"^abc":   "abc%",
+		"abc$":   "%abc",
+		"a_b":    `%a\_b%`,
+		`^a\.b$`: "a.b",
+	} {
+		got, err := regexToLike(pattern)
+		if err != nil || got != want {
+			t.Errorf("regexToLike(%q) = %q, %v; want %q", pattern, got, err, want)
+		}
+	}
+	if _, err := regexToLike("a.*b"); err == nil {
+		t.Error("expected an error for a non-literal pattern")
+	}
+}
+
+func TestRegexToGlob(t *testing.T) {
+	for pattern, want := range map[string]string{
+		"^abc":   "abc*",
+		"abc$":   "*abc",
+		"a_b%":   "*a_b%*",
+		`^a\*b$`: "a[*]b",
+		`\?\[x`:  "*[?][[]x*",
+	} {
+		got, err := regexToGlob(pattern)
+		if err != nil || got != want {
+			t.Errorf("regexToGlob(%q) = %q, %v; want %q", pattern, got, err, want)
+		}
+	}
+	if _, err := regexToGlob("a.*b"); err == nil {
+		t.Error("expected an error for a non-literal pattern")
+	}
+}
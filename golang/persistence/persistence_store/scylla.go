@@ -37,6 +37,8 @@ type ScyllaConnection struct {
 type ScyllaStore struct {
 	connections map[string]*ScyllaConnection // live connections keyed by connection id
 	lock        sync.Mutex                   // guards connections
+	batch       *scyllaBatch                 // set on the store returned by BeginTransaction
+	outboxes    sync.Map                     // sessions whose outbox table exists
 }
 
 func (store *ScyllaStore) GetStoreType() string { return "SCYLLA" }
@@ -1232,7 +1234,7 @@ func (store *ScyllaStore) backfillArrays(connectionId, keyspace, tableName, id s
 				continue
 			}
 			insArray := fmt.Sprintf("INSERT INTO %s.%s (%s_id, value) VALUES (?, ?);", keyspace, arrayTable, tableName)
-			if err := session.Query(insArray, id, el.Interface()).Exec(); err != nil {
+			if err := store.exec(context.Background(), session, insArray, id, el.Interface()); err != nil {
 				slog.Error("scylla: backfill insert array value failed", "table", keyspace+"."+arrayTable, "err", err)
 			}
 		}
@@ -1345,7 +1347,7 @@ func (store *ScyllaStore) insertData(connectionId, keyspace, tableName string, d
 							src, dst = _tid, id
 						}
 						insRef := fmt.Sprintf("INSERT INTO %s.%s (source_id, target_id) VALUES (?, ?);", keyspace, refTable)
-						if err := session.Query(insRef, src, dst).Exec(); err != nil {
+						if err := store.exec(context.Background(), session, insRef, src, dst); err != nil {
 							slog.Error("scylla: insert ref failed", "table", keyspace+"."+refTable, "err", err)
 						}
 						break
@@ -1374,7 +1376,7 @@ func (store *ScyllaStore) insertData(connectionId, keyspace, tableName string, d
 						break
 					}
 					insArray := fmt.Sprintf("INSERT INTO %s.%s (%s_id, value) VALUES (?, ?);", keyspace, arrayTable, tableName)
-					if err := session.Query(insArray, id, el.Interface()).Exec(); err != nil {
+					if err := store.exec(context.Background(), session, insArray, id, el.Interface()); err != nil {
 						slog.Error("scylla: insert array value failed", "table", keyspace+"."+arrayTable, "err", err)
 					}
 				}
@@ -1399,7 +1401,7 @@ func (store *ScyllaStore) insertData(connectionId, keyspace, tableName string, d
 					src, dst = _tid, id
 				}
 				insRef := fmt.Sprintf("INSERT INTO %s.%s (source_id, target_id) VALUES (?, ?);", keyspace, refTable)
-				if err := session.Query(insRef, src, dst).Exec(); err != nil {
+				if err := store.exec(context.Background(), session, insRef, src, dst); err != nil {
 					slog.Error("scylla: insert ref failed", "table", keyspace+"."+refTable, "err", err)
 				}
 				break
@@ -1456,7 +1458,7 @@ func (store *ScyllaStore) insertData(connectionId, keyspace, tableName string, d
 			ph[i] = "?"
 		}
 		query := fmt.Sprintf("INSERT INTO %s.%s (%s) VALUES (%s);", keyspace, tableName, insertCols, joinStrings(ph, ", "))
-		if err := session.Query(query, values...).Exec(); err != nil {
+		if err := store.exec(context.Background(), session, query, values...); err != nil {
 			slog.Error("scylla: insert entity failed", "table", keyspace+"."+tableName, "err", err)
 			return nil, err
 		}
@@ -1481,7 +1483,14 @@ func (store *ScyllaStore) InsertOne(
 			return nil, err
 		}
 	}
-	return store.insertData(connectionId, keyspace, table, entity)
+	result, err := store.insertData(connectionId, keyspace, table, entity)
+	if err != nil {
+		return nil, err
+	}
+	if err := store.recordChange(connectionId, keyspace, table, "insert", result); err != nil {
+		return nil, err
+	}
+	return result, nil
 }
 
 func (store *ScyllaStore) InsertMany(ctx context.Context, connectionId string, keyspace string, table string, entities []interface{}, options string) ([]interface{}, error) {
@@ -1490,7 +1499,11 @@ func (store *ScyllaStore) InsertMany(ctx context.Context, connectionId string, k
 		if err != nil {
 			return nil, err
 		}
-		if _, err := store.insertData(connectionId, keyspace, table, entity); err != nil {
+		result, err := store.insertData(connectionId, keyspace, table, entity)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.recordChange(connectionId, keyspace, table, "insert", result); err != nil {
 			return nil, err
 		}
 	}
@@ -1569,14 +1582,14 @@ func (store *ScyllaStore) deleteSideTables(connectionId, keyspace, baseTable str
 				}
 				if parts[0] == base {
 					q := fmt.Sprintf("DELETE FROM %s.%s WHERE source_id = ?", keyspace, tname)
-					if err := session.Query(q, id).Exec(); err != nil {
+					if err := store.exec(context.Background(), session, q, id); err != nil {
 						slog.Warn("scylla: side delete by source_id failed", "table", tname, "err", err)
 					}
 					continue
 				}
 				if parts[1] == base {
 					q := fmt.Sprintf("DELETE FROM %s.%s WHERE target_id = ?", keyspace, tname)
-					if err := session.Query(q, id).Exec(); err != nil {
+					if err := store.exec(context.Background(), session, q, id); err != nil {
 						slog.Warn("scylla: side delete by target_id failed", "table", tname, "err", err)
 					}
 					continue
@@ -1588,7 +1601,7 @@ func (store *ScyllaStore) deleteSideTables(connectionId, keyspace, baseTable str
 		keyCol := base + "_id"
 		if _, ok := cols[keyCol]; ok {
 			q := fmt.Sprintf("DELETE FROM %s.%s WHERE %s = ?", keyspace, tname, keyCol)
-			if err := session.Query(q, id).Exec(); err != nil {
+			if err := store.exec(context.Background(), session, q, id); err != nil {
 				slog.Warn("scylla: side delete by base_id failed", "table", tname, "pk", keyCol, "err", err)
 			}
 			continue
@@ -1612,7 +1625,7 @@ func (store *ScyllaStore) deleteEntity(connectionId, keyspace, table string, ent
 
 	store.deleteSideTables(connectionId, keyspace, table, id)
 
-	if err := session.Query(fmt.Sprintf("DELETE FROM %s.%s WHERE id = ?", keyspace, table), id).Exec(); err != nil {
+	if err := store.exec(context.Background(), session, fmt.Sprintf("DELETE FROM %s.%s WHERE id = ?", keyspace, table), id); err != nil {
 		slog.Error("scylla: delete entity failed", "table", keyspace+"."+table, "err", err)
 		return err
 	}
@@ -1642,8 +1655,11 @@ func (store *ScyllaStore) ReplaceOne(ctx context.Context, connectionId string, k
 			return err
 		}
 	}
-	_, err = store.insertData(connectionId, keyspace, table, data)
-	return err
+	result, err := store.insertData(connectionId, keyspace, table, data)
+	if err != nil {
+		return err
+	}
+	return store.recordChange(connectionId, keyspace, table, "replace", result)
 }
 
 func (store *ScyllaStore) Update(ctx context.Context, connectionId string, keyspace string, table string, query string, value string, options string) error {
@@ -1690,7 +1706,7 @@ func (store *ScyllaStore) Update(ctx context.Context, connectionId string, keysp
 			return err
 		}
 		if q != "" {
-			if err := session.Query(q, vals...).Exec(); err != nil {
+			if err := store.exec(context.Background(), session, q, vals...); err != nil {
 				return err
 			}
 		}
@@ -1713,14 +1729,14 @@ func (store *ScyllaStore) Update(ctx context.Context, connectionId string, keysp
 				// delete where the base id sits (source if base is first, else target)
 				if baseIsFirst {
 					delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE source_id = ?", keyspace, arrayTable)
-					_ = session.Query(delQ, entity["_id"]).Exec()
+					_ = store.exec(context.Background(), session, delQ, entity["_id"])
 				} else {
 					delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE target_id = ?", keyspace, arrayTable)
-					_ = session.Query(delQ, entity["_id"]).Exec()
+					_ = store.exec(context.Background(), session, delQ, entity["_id"])
 				}
 			} else if _, ok := cols[strings.ToLower(keyCol)]; ok {
 				delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE %s = ?", keyspace, arrayTable, keyCol)
-				_ = session.Query(delQ, entity["_id"]).Exec()
+				_ = store.exec(context.Background(), session, delQ, entity["_id"])
 			}
 
 			for _, raw := range values {
@@ -1748,7 +1764,7 @@ func (store *ScyllaStore) Update(ctx context.Context, connectionId string, keysp
 						src, dst = tid, entity["_id"]
 					}
 					ins := fmt.Sprintf("INSERT INTO %s.%s (source_id, target_id) VALUES (?, ?)", keyspace, arrayTable)
-					if err := session.Query(ins, src, dst).WithContext(ctx).Exec(); err != nil {
+					if err := store.exec(ctx, session, ins, src, dst); err != nil {
 						slog.Error("scylla: insert ref failed", "table", arrayTable, "err", err)
 					}
 					continue
@@ -1773,11 +1789,15 @@ func (store *ScyllaStore) Update(ctx context.Context, connectionId string, keysp
 				}
 
 				insQ := fmt.Sprintf("INSERT INTO %s.%s (%s_id, value) VALUES (?, ?)", keyspace, arrayTable, table)
-				if err := session.Query(insQ, entity["_id"], raw).WithContext(ctx).Exec(); err != nil {
+				if err := store.exec(ctx, session, insQ, entity["_id"], raw); err != nil {
 					slog.Error("scylla: insert scalar array value failed", "table", arrayTable, "err", err)
 				}
 			}
 		}
+
+		if err := store.recordChange(connectionId, keyspace, table, "update", withSet(entity, values_["$set"].(map[string]interface{}))); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1854,7 +1874,7 @@ func (store *ScyllaStore) UpdateOne(
 		cql := fmt.Sprintf("UPDATE %s.%s SET %s WHERE id = ?", keyspace, table, strings.Join(scalarSet, ", "))
 		args := append([]interface{}{}, scalarVals...)
 		args = append(args, entityID)
-		if err := session.Query(cql, args...).WithContext(ctx).Exec(); err != nil {
+		if err := store.exec(ctx, session, cql, args...); err != nil {
 			return err
 		}
 	}
@@ -1875,14 +1895,14 @@ func (store *ScyllaStore) UpdateOne(
 		if isRefTable {
 			if baseIsFirst {
 				delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE source_id = ?", keyspace, arrayTable)
-				_ = session.Query(delQ, entityID).WithContext(ctx).Exec()
+				_ = store.exec(ctx, session, delQ, entityID)
 			} else {
 				delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE target_id = ?", keyspace, arrayTable)
-				_ = session.Query(delQ, entityID).WithContext(ctx).Exec()
+				_ = store.exec(ctx, session, delQ, entityID)
 			}
 		} else if _, ok := cols[strings.ToLower(keyCol)]; ok {
 			delQ := fmt.Sprintf("DELETE FROM %s.%s WHERE %s = ?", keyspace, arrayTable, keyCol)
-			_ = session.Query(delQ, entityID).WithContext(ctx).Exec()
+			_ = store.exec(ctx, session, delQ, entityID)
 		}
 
 		for _, raw := range arrayValues[field] {
@@ -1910,7 +1930,7 @@ func (store *ScyllaStore) UpdateOne(
 					src, dst = tid, entityID
 				}
 				ins := fmt.Sprintf("INSERT INTO %s.%s (source_id, target_id) VALUES (?, ?)", keyspace, arrayTable)
-				if err := session.Query(ins, src, dst).WithContext(ctx).Exec(); err != nil {
+				if err := store.exec(ctx, session, ins, src, dst); err != nil {
 					slog.Error("scylla: insert ref failed", "table", arrayTable, "err", err)
 				}
 				continue
@@ -1935,13 +1955,13 @@ func (store *ScyllaStore) UpdateOne(
 			}
 
 			insQ := fmt.Sprintf("INSERT INTO %s.%s (%s_id, value) VALUES (?, ?)", keyspace, arrayTable, table)
-			if err := session.Query(insQ, entityID, raw).WithContext(ctx).Exec(); err != nil {
+			if err := store.exec(ctx, session, insQ, entityID, raw); err != nil {
 				slog.Error("scylla: insert scalar array value failed", "table", arrayTable, "err", err)
 			}
 		}
 	}
 
-	return nil
+	return store.recordChange(connectionId, keyspace, table, "update", withSet(entity, setMap))
 }
 
 func (store *ScyllaStore) Delete(ctx context.Context, connectionId string, keyspace string, table string, query string, options string) error {
@@ -1953,6 +1973,9 @@ func (store *ScyllaStore) Delete(ctx context.Context, connectionId string, keysp
 		if err := store.deleteEntity(connectionId, keyspace, table, e); err != nil {
 			return err
 		}
+		if err := store.recordChange(connectionId, keyspace, table, "delete", e); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -1966,6 +1989,7 @@ func (store *ScyllaStore) DeleteOne(ctx context.Context, connectionId string, ke
 		if err := store.deleteEntity(connectionId, keyspace, table, entities[0]); err != nil {
 			return err
 		}
+		return store.recordChange(connectionId, keyspace, table, "delete", entities[0])
 	}
 	return nil
 }
@@ -2156,8 +2180,54 @@ func (store *ScyllaStore) MigrateLinkTables(connectionId, keyspace string) error
 	return nil
 }
 
-// ---------- Not implemented (explicit) ----------
+// ---------- Aggregation ----------
 
+// aggregatePageSize is the number of rows fetched per page while an
+// aggregation pipeline is evaluated.
+const aggregatePageSize = 500
+
+// Aggregate evaluates a Mongo-style pipeline over the table. Rows are read
+// page by page and pushed through the in-memory evaluator (pipeline.go), so
+// only blocking stages ($group, $sort) keep documents around, and a leading
+// $limit stops the scan early.
 func (store *ScyllaStore) Aggregate(ctx context.Context, connectionId string, keyspace string, table string, pipeline string, optionsStr string) ([]interface{}, error) {
-	return nil, errors.New("not implemented")
+	stages, err := parsePipeline(pipeline)
+	if err != nil {
+		return nil, err
+	}
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	eval := newPipelineEvaluator(stages, func(collection string) ([]map[string]interface{}, error) {
+		return store.find(connectionId, keyspace, collection, "{}")
+	})
+
+	query := fmt.Sprintf("SELECT * FROM %s.%s", keyspace, table)
+	iter := session.Query(query).WithContext(ctx).PageSize(aggregatePageSize).Iter()
+	for {
+		row := make(map[string]interface{})
+		if !iter.MapScan(row) {
+			break
+		}
+		entity, err := store.initEntity(connectionId, keyspace, table, row)
+		if err != nil {
+			continue
+		}
+		more, err := eval.Push(entity)
+		if err != nil {
+			_ = iter.Close()
+			return nil, err
+		}
+		if !more {
+			break
+		}
+	}
+	if err := iter.Close(); err != nil {
+		slog.Error("scylla: aggregate scan failed", "table", table, "err", err)
+		return nil, err
+	}
+
+	return eval.Results()
 }
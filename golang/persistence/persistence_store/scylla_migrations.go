@@ -0,0 +1,94 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sort"
+	"time"
+)
+
+// ---------- Schema migrations ----------
+//
+// The ledger is <keyspace>.schema_migrations. CQL has no DDL transactions:
+// the statements of a script run one after the other and a failure leaves
+// the earlier ones applied, with no ledger entry. Write scripts that can be
+// run again (CREATE ... IF NOT EXISTS, ALTER guarded by the next version).
+
+const scyllaMigrationsTable = "schema_migrations"
+
+var _ Migrator = (*ScyllaStore)(nil)
+
+// MigrationDialect returns "cql": scripts are CQL statements separated by
+// semicolons, run in the keyspace of the database.
+func (store *ScyllaStore) MigrationDialect() string { return "cql" }
+
+func (store *ScyllaStore) ensureMigrations(ctx context.Context, connectionId, keyspace string) error {
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return err
+	}
+	stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.%s (
+		version BIGINT PRIMARY KEY, name TEXT, checksum TEXT, applied_at TIMESTAMP, duration_ms BIGINT
+	)`, keyspace, scyllaMigrationsTable)
+	return session.Query(stmt).WithContext(ctx).Exec()
+}
+
+// AppliedMigrations returns the ledger of a keyspace.
+func (store *ScyllaStore) AppliedMigrations(ctx context.Context, connectionId string, keyspace string) ([]AppliedMigration, error) {
+	if err := store.ensureMigrations(ctx, connectionId, keyspace); err != nil {
+		return nil, err
+	}
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	iter := session.Query(fmt.Sprintf("SELECT version, name, checksum, applied_at, duration_ms FROM %s.%s", keyspace, scyllaMigrationsTable)).WithContext(ctx).Iter()
+	applied := make([]AppliedMigration, 0)
+	var a AppliedMigration
+	var ms int64
+	for iter.Scan(&a.Version, &a.Name, &a.Checksum, &a.AppliedAt, &ms) {
+		a.Duration = time.Duration(ms) * time.Millisecond
+		applied = append(applied, a)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	// The version is the partition key: rows come back in token order.
+	sort.Slice(applied, func(i, j int) bool { return applied[i].Version < applied[j].Version })
+	return applied, nil
+}
+
+// ApplyMigration runs the statements of a script in order, then records it.
+func (store *ScyllaStore) ApplyMigration(ctx context.Context, connectionId string, keyspace string, migration *Migration) (*AppliedMigration, error) {
+	if store.batch != nil {
+		return nil, errors.New("migrations cannot run inside a transaction")
+	}
+	if err := store.ensureMigrations(ctx, connectionId, keyspace); err != nil {
+		return nil, err
+	}
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	for _, stmt := range splitCQLScript(migration.Script) {
+		if stmt == "" || stmt == ";" {
+			continue
+		}
+		if err := session.Query(stmt).WithContext(ctx).Exec(); err != nil {
+			slog.Error("scylla: migration statement failed", "keyspace", keyspace, "version", migration.Version, "stmt", stmt, "err", err)
+			return nil, err
+		}
+	}
+
+	applied := appliedNow(migration, start)
+	insert := fmt.Sprintf("INSERT INTO %s.%s (version, name, checksum, applied_at, duration_ms) VALUES (?, ?, ?, ?, ?)", keyspace, scyllaMigrationsTable)
+	if err := session.Query(insert, applied.Version, applied.Name, applied.Checksum, applied.AppliedAt, applied.Duration.Milliseconds()).WithContext(ctx).Exec(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,197 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gocql/gocql"
+)
+
+// ---------- Transactions (logged batches) ----------
+//
+// Scylla has no multi-statement transactions; a logged batch is the closest
+// guarantee: every statement is applied or none is. The store returned by
+// BeginTransaction queues its writes and Commit sends them as one batch.
+//
+// Limits:
+//   - reads made in the transaction do not see its queued writes;
+//   - schema changes (CREATE/ALTER) run immediately;
+//   - a batch lives in one keyspace; touching a second one fails with
+//     ErrTransactionsNotSupported.
+//
+// Statements of a batch share one write timestamp by default, and a delete
+// wins over an insert with the same timestamp. Each queued statement is
+// therefore given its own increasing timestamp so that "delete then insert"
+// sequences (ReplaceOne, array rewrites) keep their order.
+
+var (
+	cqlInsertRe = regexp.MustCompile(`(?is)^\s*INSERT\s+INTO\s.*\)\s*;?\s*$`)
+	cqlUpdateRe = regexp.MustCompile(`(?is)^\s*UPDATE\s+(\S+)\s+SET\s`)
+	cqlDeleteRe = regexp.MustCompile(`(?is)^\s*DELETE\s+FROM\s+(\S+)\s+WHERE\s`)
+)
+
+type scyllaBatch struct {
+	mu      sync.Mutex
+	session *gocql.Session
+	batch   *gocql.Batch
+	next    int64 // next write timestamp (microseconds)
+	changes []*scyllaChange
+	done    bool
+}
+
+// withTimestamp adds a USING TIMESTAMP clause to a write statement.
+func withTimestamp(stmt string, ts int64) (string, error) {
+	using := fmt.Sprintf("USING TIMESTAMP %d", ts)
+	switch {
+	case cqlInsertRe.MatchString(stmt):
+		return strings.TrimRight(strings.TrimSpace(stmt), ";") + " " + using, nil
+	case cqlUpdateRe.MatchString(stmt):
+		return cqlUpdateRe.ReplaceAllString(stmt, "UPDATE $1 "+using+" SET "), nil
+	case cqlDeleteRe.MatchString(stmt):
+		return cqlDeleteRe.ReplaceAllString(stmt, "DELETE FROM $1 "+using+" WHERE "), nil
+	}
+	return "", fmt.Errorf("%w: statement cannot be batched: %s", ErrTransactionsNotSupported, stmt)
+}
+
+func (b *scyllaBatch) add(session *gocql.Session, stmt string, args ...interface{}) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return errors.New("the transaction is already closed")
+	}
+	if b.session == nil {
+		b.session = session
+		b.batch = session.NewBatch(gocql.LoggedBatch)
+	} else if b.session != session {
+		return fmt.Errorf("%w: a scylla transaction cannot span keyspaces", ErrTransactionsNotSupported)
+	}
+	stmt, err := withTimestamp(stmt, b.next)
+	if err != nil {
+		return err
+	}
+	b.next++
+	b.batch.Query(stmt, args...)
+	return nil
+}
+
+// record queues an outbox row; it is added to the batch on Commit.
+func (b *scyllaBatch) record(session *gocql.Session, change *scyllaChange) error {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return errors.New("the transaction is already closed")
+	}
+	if b.session != nil && b.session != session {
+		return fmt.Errorf("%w: a scylla transaction cannot span keyspaces", ErrTransactionsNotSupported)
+	}
+	b.changes = append(b.changes, change)
+	return nil
+}
+
+// exec runs a data write, or queues it when the store is a transaction.
+func (store *ScyllaStore) exec(ctx context.Context, session *gocql.Session, stmt string, args ...interface{}) error {
+	if store.batch != nil {
+		return store.batch.add(session, stmt, args...)
+	}
+	return session.Query(stmt, args...).WithContext(ctx).Exec()
+}
+
+// Exec runs a CQL statement on a keyspace of the connection. Inside a
+// transaction, writes join its batch and schema statements run at once.
+func (store *ScyllaStore) Exec(ctx context.Context, connectionId, keyspace, stmt string, args ...interface{}) error {
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return err
+	}
+	if store.batch != nil && isSchemaStatement(stmt) {
+		return session.Query(stmt, args...).WithContext(ctx).Exec()
+	}
+	return store.exec(ctx, session, stmt, args...)
+}
+
+func isSchemaStatement(stmt string) bool {
+	fields := strings.Fields(stmt)
+	if len(fields) == 0 {
+		return false
+	}
+	switch strings.ToUpper(fields[0]) {
+	case "CREATE", "ALTER", "DROP":
+		return true
+	}
+	return false
+}
+
+// scyllaTransaction is a ScyllaStore whose writes are queued in a logged batch.
+type scyllaTransaction struct {
+	*ScyllaStore
+}
+
+var (
+	_ Transactional = (*ScyllaStore)(nil)
+	_ Transaction   = (*scyllaTransaction)(nil)
+)
+
+// BeginTransaction starts a logged batch on a connection.
+func (store *ScyllaStore) BeginTransaction(ctx context.Context, connectionId string) (Transaction, error) {
+	if store.batch != nil {
+		return nil, errors.New("nested transactions are not supported")
+	}
+	store.lock.Lock()
+	connection := store.connections[connectionId]
+	store.lock.Unlock()
+	if connection == nil {
+		return nil, errors.New("the connection " + connectionId + " does not exist")
+	}
+	return &scyllaTransaction{ScyllaStore: &ScyllaStore{
+		connections: map[string]*ScyllaConnection{connectionId: connection},
+		batch:       &scyllaBatch{next: time.Now().UnixMicro()},
+	}}, nil
+}
+
+// Commit sends the queued writes as one logged batch.
+func (t *scyllaTransaction) Commit(ctx context.Context) error {
+	b := t.batch
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return errors.New("the transaction is already closed")
+	}
+	b.done = true
+	if b.batch == nil || b.batch.Size() == 0 {
+		return nil
+	}
+	for _, change := range b.changes {
+		stmt, args := change.insert(gocql.TimeUUID())
+		if stmt, err := withTimestamp(stmt, b.next); err == nil {
+			b.next++
+			b.batch.Query(stmt, args...)
+		}
+	}
+	return b.session.ExecuteBatch(b.batch.WithContext(ctx))
+}
+
+// Rollback drops the queued writes. Schema changes already made stay.
+func (t *scyllaTransaction) Rollback(ctx context.Context) error {
+	b := t.batch
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.done {
+		return errors.New("the transaction is already closed")
+	}
+	b.done = true
+	b.batch = nil
+	return nil
+}
+
+func (t *scyllaTransaction) Connect(id string, host string, port int32, user string, password string, keyspace string, timeout int32, options_str string) error {
+	return errors.New("cannot connect inside a transaction")
+}
+
+func (t *scyllaTransaction) Disconnect(connectionId string) error {
+	return errors.New("cannot disconnect inside a transaction")
+}
@@ -0,0 +1,175 @@
+package persistence_store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	Utility "github.com/globulario/utility"
+	"github.com/gocql/gocql"
+)
+
+// ---------- Change streams (outbox) ----------
+//
+// Writes append a row to <keyspace>.persistence_outbox, partitioned by
+// collection and UTC day so a watcher reads one partition at a time. The
+// timeuuid seq orders the rows and is the resume token. Rows expire after
+// outboxRetention through the table default TTL.
+//
+// A timeuuid is taken from the clock of the writer, and a logged batch lands
+// some time after its timeuuids are taken. Watch therefore only reads rows
+// older than scyllaOutboxLag, so a late row is not skipped by a cursor that
+// already moved past it.
+
+const scyllaOutboxTable = "persistence_outbox"
+
+const scyllaOutboxLag = 2 * time.Second
+
+var _ Watcher = (*ScyllaStore)(nil)
+
+// scyllaChange is an outbox row queued by a transaction. Its seq is taken
+// when the transaction commits.
+type scyllaChange struct {
+	keyspace   string
+	collection string
+	operation  string
+	id         string
+	document   string
+}
+
+func (c *scyllaChange) insert(seq gocql.UUID) (string, []interface{}) {
+	stmt := fmt.Sprintf("INSERT INTO %s.%s (collection, bucket, seq, operation, doc_id, document) VALUES (?, ?, ?, ?, ?, ?)", c.keyspace, scyllaOutboxTable)
+	return stmt, []interface{}{c.collection, outboxBucket(seq.Time()), seq, c.operation, c.id, c.document}
+}
+
+// outboxBucket is the day partition of a change made at t.
+func outboxBucket(t time.Time) string {
+	return t.UTC().Format("2006-01-02")
+}
+
+// withSet returns a copy of entity with a $set patch applied. Reads made in a
+// transaction do not see its queued writes, so the updated document is
+// computed rather than read back.
+func withSet(entity map[string]interface{}, set map[string]interface{}) map[string]interface{} {
+	doc := make(map[string]interface{}, len(entity)+len(set))
+	for k, v := range entity {
+		doc[k] = v
+	}
+	for k, v := range set {
+		doc[k] = v
+	}
+	return doc
+}
+
+// ensureOutbox creates the outbox table of a keyspace once per session.
+func (store *ScyllaStore) ensureOutbox(session *gocql.Session, keyspace string) error {
+	if _, ok := store.outboxes.Load(session); ok {
+		return nil
+	}
+	stmt := fmt.Sprintf(`CREATE TABLE IF NOT EXISTS %s.%s (
+		collection TEXT, bucket TEXT, seq TIMEUUID, operation TEXT, doc_id TEXT, document TEXT,
+		PRIMARY KEY ((collection, bucket), seq)
+	) WITH default_time_to_live = %d`, keyspace, scyllaOutboxTable, int(outboxRetention.Seconds()))
+	if err := session.Query(stmt).Exec(); err != nil {
+		return err
+	}
+	store.outboxes.Store(session, true)
+	return nil
+}
+
+// recordChange appends a write to the outbox of the keyspace. Inside a
+// transaction the row is queued and sent with the batch on Commit.
+func (store *ScyllaStore) recordChange(connectionId, keyspace, table, operation string, doc map[string]interface{}) error {
+	if isInternalCollection(table) {
+		return nil
+	}
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return err
+	}
+	if err := store.ensureOutbox(session, keyspace); err != nil {
+		return err
+	}
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	id := doc["_id"]
+	if id == nil {
+		id = doc["id"]
+	}
+	change := &scyllaChange{keyspace: keyspace, collection: table, operation: operation, id: Utility.ToString(id), document: string(document)}
+
+	if store.batch != nil {
+		return store.batch.record(session, change)
+	}
+	stmt, args := change.insert(gocql.TimeUUID())
+	return session.Query(stmt, args...).Exec()
+}
+
+// Watch polls the outbox of the keyspace for the changes of a table. The
+// resume token is the timeuuid of the last event received.
+func (store *ScyllaStore) Watch(ctx context.Context, connectionId string, keyspace string, collection string, filter string, resumeToken string, fn func(*ChangeEvent) error) error {
+	match, err := changeFilter(filter)
+	if err != nil {
+		return err
+	}
+	session, err := store.getSession(connectionId, keyspace)
+	if err != nil {
+		return err
+	}
+	if err := store.ensureOutbox(session, keyspace); err != nil {
+		return err
+	}
+
+	cursor := gocql.MinTimeUUID(time.Now())
+	if resumeToken != "" {
+		cursor, err = gocql.ParseUUID(resumeToken)
+		if err != nil || cursor.Version() != 1 {
+			return fmt.Errorf("invalid resume token %q", resumeToken)
+		}
+		if time.Since(cursor.Time()) > outboxRetention {
+			return ErrResumeTokenExpired
+		}
+	}
+
+	query := fmt.Sprintf("SELECT seq, operation, doc_id, document FROM %s.%s WHERE collection = ? AND bucket = ? AND seq > ? AND seq < ? LIMIT ?", keyspace, scyllaOutboxTable)
+	next := func() ([]*ChangeEvent, error) {
+		upper := time.Now().Add(-scyllaOutboxLag)
+		events := make([]*ChangeEvent, 0)
+		for cursor.Time().Before(upper) && len(events) < outboxBatch {
+			day := cursor.Time().UTC()
+			iter := session.Query(query, collection, outboxBucket(day), cursor, gocql.MaxTimeUUID(upper), outboxBatch-len(events)).WithContext(ctx).Iter()
+			var seq gocql.UUID
+			var operation, id, document string
+			for iter.Scan(&seq, &operation, &id, &document) {
+				cursor = seq
+				evt := &ChangeEvent{Token: seq.String(), Operation: operation, Collection: collection, Id: id, Time: seq.Time()}
+				if document != "" {
+					_ = json.Unmarshal([]byte(document), &evt.Document)
+				}
+				events = append(events, evt)
+			}
+			if err := iter.Close(); err != nil {
+				if ctx.Err() != nil {
+					return nil, nil
+				}
+				return nil, err
+			}
+			if len(events) == outboxBatch {
+				break
+			}
+
+			// The day is read up to upper; move on to the next one once it
+			// is entirely visible.
+			nextDay := time.Date(day.Year(), day.Month(), day.Day()+1, 0, 0, 0, 0, time.UTC)
+			if nextDay.After(upper) {
+				break
+			}
+			cursor = gocql.MinTimeUUID(nextDay)
+		}
+		return events, nil
+	}
+	return pollChanges(ctx, next, match, fn)
+}
@@ -9,7 +9,9 @@ import (
 	"log/slog"
 	"os"
 	"reflect"
+	"sort"
 	"strings"
+	"sync"
 	"time"
 	"unicode"
 
@@ -51,7 +53,21 @@ type SqlConnection struct {
 //   - Each logical "database" is a .db file in the configured path.
 //   - Arrays and references are materialized in auxiliary tables like <table>_<field>.
 type SqlStore struct {
+	mu          sync.Mutex               // guards connections and their databases maps
 	connections map[string]SqlConnection // Map of SQL connections
+	tx          *sqlTx                   // set on the store returned by BeginTransaction
+	base        *SqlStore                // store a transaction was begun on; shares its databases maps
+}
+
+// lock takes the mutex guarding the connections, the one of the base store
+// for a transaction, since both see the same databases maps.
+func (store *SqlStore) lock() func() {
+	mu := &store.mu
+	if store.base != nil {
+		mu = &store.base.mu
+	}
+	mu.Lock()
+	return mu.Unlock
 }
 
 // GetStoreType returns the store type identifier ("SQL").
@@ -112,10 +128,12 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 		return err
 	}
 
+	unlock := store.lock()
 	if store.connections != nil {
 		if _, ok := store.connections[id]; ok {
 			if store.connections[id].databases != nil {
 				if _, ok := store.connections[id].databases[database]; ok {
+					unlock()
 					log.Debug("connection already established")
 					return nil
 				}
@@ -124,6 +142,7 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 	} else {
 		store.connections = make(map[string]SqlConnection)
 	}
+	unlock()
 
 	if len(database) == 0 {
 		return errors.New("the database is required")
@@ -168,6 +187,7 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 	}
 
 	// Ensure connection struct is present.
+	unlock = store.lock()
 	var connection SqlConnection
 	if existing, ok := store.connections[id]; ok {
 		connection = existing
@@ -186,6 +206,7 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 	databasePath := connection.Path + "/" + database + ".db"
 	db, err := sql.Open("sqlite3", databasePath)
 	if err != nil {
+		unlock()
 		log.Error("failed to open sqlite database", "path", databasePath, "err", err)
 		return err
 	}
@@ -194,9 +215,11 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 	}
 	connection.databases[database] = db
 	store.connections[id] = connection
+	unlock()
 
 	// Initialize user_data if needed (compat behavior).
-	count, _ := store.Count(context.Background(), id, "", "user_data", `SELECT * FROM user_data WHERE _id='`+user+`'`, "")
+	userQuery, _ := Utility.ToJson(map[string]interface{}{"_id": user})
+	count, _ := store.Count(context.Background(), id, database, "user_data", userQuery, "")
 	if count == 0 && id != "local_resource" {
 		_, _ = store.InsertOne(context.Background(), id, database, "user_data",
 			map[string]interface{}{"_id": user, "first_name": "", "last_name": "", "middle_name": "", "profile_picture": "", "email": ""}, "")
@@ -206,6 +229,33 @@ func (store *SqlStore) Connect(id string, host string, port int32, user string,
 	return nil
 }
 
+// database returns the handle of a database file of the connection, opening
+// it on first use.
+func (store *SqlStore) database(connectionId string, database string) (*sql.DB, error) {
+	defer store.lock()()
+
+	conn, exists := store.connections[connectionId]
+	if !exists {
+		return nil, fmt.Errorf("connection with ID %s does not exist", connectionId)
+	}
+
+	if conn.databases == nil {
+		conn.databases = make(map[string]*sql.DB, 0)
+	}
+
+	if conn.databases[database] == nil {
+		databasePath := conn.Path + "/" + database + ".db"
+		db, err := sql.Open("sqlite3", databasePath)
+		if err != nil {
+			return nil, fmt.Errorf("open %s: %w", databasePath, err)
+		}
+		conn.databases[database] = db
+		// persist mutation
+		store.connections[connectionId] = conn
+	}
+	return conn.databases[database], nil
+}
+
 // ExecContext executes a statement with optional parameters and optional transaction.
 // It returns a JSON string containing "lastId" and "rowsAffected".
 func (store *SqlStore) ExecContext(connectionId string, database string, query string, parameters []interface{}, tx_ int) (string, error) {
@@ -223,31 +273,27 @@ func (store *SqlStore) ExecContext(connectionId string, database string, query s
 		return "", errors.New("the database is required")
 	}
 
-	conn, exists := store.connections[connectionId]
-	if !exists {
-		return "", fmt.Errorf("connection with ID %s does not exist", connectionId)
-	}
-
-	if conn.databases == nil {
-		conn.databases = make(map[string]*sql.DB, 0)
+	db, err := store.database(connectionId, database)
+	if err != nil {
+		log.Error("failed to open sqlite database", "err", err)
+		return "", err
 	}
 
-	if conn.databases[database] == nil {
-		databasePath := conn.Path + "/" + database + ".db"
-		db, err := sql.Open("sqlite3", databasePath)
+	var result sql.Result
+	switch {
+	case store.tx != nil:
+		// Already inside a transaction opened with BeginTransaction.
+		ex, err := store.tx.executor(db, database)
 		if err != nil {
-			log.Error("failed to open sqlite database", "path", databasePath, "err", err)
 			return "", err
 		}
-		conn.databases[database] = db
-		// persist mutation
-		store.connections[connectionId] = conn
-	}
-
-	hasTx := tx_ == 1
-	var result sql.Result
-	if hasTx {
-		tx, err := conn.databases[database].BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+		result, err = ex.ExecContext(context.Background(), query, parameters...)
+		if err != nil {
+			log.Error("exec failed (transaction)", "err", err, "query", query)
+			return "", err
+		}
+	case tx_ == 1:
+		tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
 		if err != nil {
 			log.Error("failed to begin transaction", "err", err)
 			return "", err
@@ -263,9 +309,8 @@ func (store *SqlStore) ExecContext(connectionId string, database string, query s
 			return "", err
 		}
 		result = res
-	} else {
-		var err error
-		result, err = conn.databases[database].ExecContext(context.Background(), query, parameters...)
+	default:
+		result, err = db.ExecContext(context.Background(), query, parameters...)
 		if err != nil {
 			log.Error("exec failed", "err", err, "query", query)
 			return "", err
@@ -300,24 +345,16 @@ func (store *SqlStore) QueryContext(connectionId string, database string, query
 		return "", errors.New("the database is required")
 	}
 
-	conn, exists := store.connections[connectionId]
-	if !exists {
-		return "", fmt.Errorf("connection with ID %s does not exist", connectionId)
-	}
-
-	if conn.databases == nil {
-		conn.databases = make(map[string]*sql.DB, 0)
+	db, err := store.database(connectionId, database)
+	if err != nil {
+		log.Error("failed to open sqlite database", "err", err)
+		return "", err
 	}
-
-	if conn.databases[database] == nil {
-		databasePath := conn.Path + "/" + database + ".db"
-		db, err := sql.Open("sqlite3", databasePath)
-		if err != nil {
-			log.Error("failed to open sqlite database", "path", databasePath, "err", err)
+	var ex sqlExecutor = db
+	if store.tx != nil {
+		if ex, err = store.tx.executor(db, database); err != nil {
 			return "", err
 		}
-		conn.databases[database] = db
-		store.connections[connectionId] = conn
 	}
 
 	// Bind parameters.
@@ -329,7 +366,7 @@ func (store *SqlStore) QueryContext(connectionId string, database string, query
 		}
 	}
 
-	rows, err := conn.databases[database].QueryContext(context.Background(), query, parameters...)
+	rows, err := ex.QueryContext(context.Background(), query, parameters...)
 	if err != nil {
 		log.Error("query failed", "err", err, "query", query)
 		return "", err
@@ -414,6 +451,7 @@ func (store *SqlStore) QueryContext(connectionId string, database string, query
 func (store *SqlStore) Disconnect(connectionId string) error {
 	log := slog.With("component", "SqlStore", "method", "Disconnect", "id", connectionId)
 
+	defer store.lock()()
 	conn, exists := store.connections[connectionId]
 	if !exists {
 		return fmt.Errorf("connection with ID %s does not exist", connectionId)
@@ -446,25 +484,32 @@ func (store *SqlStore) DeleteDatabase(ctx context.Context, connectionId string,
 	if len(db) == 0 {
 		return errors.New("the database name is required")
 	}
+	unlock := store.lock()
 	databasePath := store.connections[connectionId].Path + "/" + sanitizeIdentifier(db) + ".db"
+	unlock()
 	return os.RemoveAll(databasePath)
 }
 
 // Count returns the number of records for a given query.
-// If query is "{}", a SELECT * FROM <table> is used.
+// A JSON filter is compiled to SELECT COUNT(*); a raw SQL SELECT is run as is
+// and its rows are counted.
 func (store *SqlStore) Count(ctx context.Context, connectionId string, db string, table string, query string, options string) (int64, error) {
-	safeTable := sanitizeIdentifier(table)
-	if len(query) == 0 || query == "{}" {
-		query = fmt.Sprintf(`SELECT * FROM "%s"`, safeTable)
-	} else if strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}") {
-		var err error
-		query, err = store.formatQuery(table, query)
+	var stmt, args string
+	if isJSONQuery(query) {
+		q, err := store.compileQuery(connectionId, db, table, query, options)
 		if err != nil {
 			return 0, err
 		}
+		inner, params := q.selectSQL("1")
+		stmt = "SELECT COUNT(*) FROM (" + inner + ")"
+		if args, err = Utility.ToJson(params); err != nil {
+			return 0, err
+		}
+	} else {
+		stmt, args = query, "[]"
 	}
 
-	str, err := store.QueryContext(connectionId, db, query, "[]")
+	str, err := store.QueryContext(connectionId, db, stmt, args)
 	if err != nil {
 		return 0, err
 	}
@@ -474,15 +519,21 @@ func (store *SqlStore) Count(ctx context.Context, connectionId string, db string
 		return 0, err
 	}
 	// Safe type assertion
-	dataRaw, ok := data["data"]
+	dataSlice, ok := data["data"].([]interface{})
 	if !ok {
 		return 0, nil
 	}
-	dataSlice, ok := dataRaw.([]interface{})
-	if !ok {
+	if !isJSONQuery(query) {
+		return int64(len(dataSlice)), nil
+	}
+	if len(dataSlice) == 0 {
+		return 0, nil
+	}
+	row, _ := dataSlice[0].([]interface{})
+	if len(row) == 0 {
 		return 0, nil
 	}
-	return int64(len(dataSlice)), nil
+	return int64(Utility.ToInt(row[0])), nil
 }
 
 func (store *SqlStore) isTableExist(connectionId string, db string, table string) bool {
@@ -584,6 +635,12 @@ func generateMainInsertSQL(tableName string, data map[string]interface{}) (strin
 
 // insertData writes one entity into the main table and its auxiliary array/reference tables.
 func (store *SqlStore) insertData(connectionId string, db string, tableName string, data map[string]interface{}) (map[string]interface{}, error) {
+	return store.insertEntity(connectionId, db, tableName, data, "insert")
+}
+
+// insertEntity inserts data and records it in the outbox as operation. An
+// entity that already exists is returned as is.
+func (store *SqlStore) insertEntity(connectionId string, db string, tableName string, data map[string]interface{}, operation string) (map[string]interface{}, error) {
 	safeTable := sanitizeIdentifier(tableName)
 	log := slog.With("component", "SqlStore", "method", "insertData", "table", safeTable)
 
@@ -611,10 +668,8 @@ func (store *SqlStore) insertData(connectionId string, db string, tableName stri
 
 	// If table exists and entity exists, return it (idempotent).
 	if store.isTableExist(connectionId, db, safeTable) {
-		// Use parameterized query - sanitize table name and use placeholder for id
-		query := fmt.Sprintf(`SELECT * FROM "%s" WHERE _id=?`, safeTable)
-		queryArgs := fmt.Sprintf(`["%s"]`, id)
-		if values, err := store.FindOne(context.Background(), connectionId, db, safeTable, query, queryArgs); err == nil {
+		query, _ := Utility.ToJson(map[string]interface{}{"_id": id})
+		if values, err := store.FindOne(context.Background(), connectionId, db, safeTable, query, ""); err == nil {
 			if valMap, ok := values.(map[string]interface{}); ok {
 				return valMap, nil
 			}
@@ -771,6 +826,9 @@ func (store *SqlStore) insertData(connectionId string, db string, tableName stri
 		}
 	}
 
+	if err := store.recordChange(connectionId, db, safeTable, operation, data); err != nil {
+		return nil, err
+	}
 	return data, nil
 }
 
@@ -843,20 +901,23 @@ func getIntValue(value interface{}) (int32, error) {
 }
 
 // recreateArrayOfObjects expands rows into structured objects, resolving array tables and reference tables.
-func (store *SqlStore) recreateArrayOfObjects(connectionId, db, tableName string, dataHeader map[string]interface{}, options []map[string]interface{}) ([]interface{}, error) {
+// The projection (may be nil) applies to both main columns and side-table fields.
+func (store *SqlStore) recreateArrayOfObjects(connectionId, db, tableName string, dataHeader map[string]interface{}, projection map[string]interface{}) ([]interface{}, error) {
 	data := dataHeader["data"]
 	header := dataHeader["header"]
 
 	var objects []interface{}
-	var projection map[string]interface{}
-	if len(options) > 0 {
-		for _, option := range options {
-			if option["Projection"] != nil {
-				projection = option["Projection"].(map[string]interface{})
-				projection["_id"] = 1 // ensure id presence
-			}
-		}
+
+	// Discover auxiliary tables that belong to this database.
+	tables, err := store.listTables(connectionId, db)
+	if err != nil {
+		return nil, err
 	}
+	tableNames := make([]string, 0, len(tables))
+	for t := range tables {
+		tableNames = append(tableNames, t)
+	}
+	sort.Strings(tableNames)
 
 	for _, dr := range data.([]interface{}) {
 		dataRow := dr.([]interface{})
@@ -869,8 +930,8 @@ func (store *SqlStore) recreateArrayOfObjects(connectionId, db, tableName string
 			value := dataRow[index]
 			typeInfos := fi["typeInfo"].(map[string]interface{})
 
-			allow := projection == nil || projection[fieldName] != nil
-			if !allow {
+			// _id is always kept: side tables are joined on it.
+			if fieldName != "_id" && !projectionAllows(projection, fieldName) {
 				continue
 			}
 			if isIntegerType(typeInfos["DatabaseTypeName"].(string)) {
@@ -886,165 +947,125 @@ func (store *SqlStore) recreateArrayOfObjects(connectionId, db, tableName string
 
 		objects = append(objects, object)
 
-		// Discover auxiliary tables that belong to this database.
-		query := "SELECT name FROM sqlite_master WHERE type='table'"
-		str, err := store.QueryContext(connectionId, db, query, "[]")
-		if err != nil {
-			return nil, err
-		}
-
-		tables := make(map[string]interface{})
-		if err := json.Unmarshal([]byte(str), &tables); err != nil {
-			return nil, err
-		}
-
 		domain, _ := config.GetDomain()
 		base := ensurePlural(tableName)
 		id := Utility.ToString(object["_id"])
 
-		for _, values := range tables["data"].([]interface{}) {
-			for _, value := range values.([]interface{}) {
-				t := value.(string)
-
-				// 1) Primitive array tables: <tableName>_<field>
-				if strings.HasPrefix(t, tableName+"_") {
-					field := strings.TrimPrefix(t, tableName+"_")
-					if object[field] != nil {
-						continue
+		for _, t := range tableNames {
+			// 1) Primitive array tables: <tableName>_<field>
+			if strings.HasPrefix(t, tableName+"_") {
+				field := strings.TrimPrefix(t, tableName+"_")
+				if object[field] != nil || !projectionAllows(projection, field) {
+					continue
+				}
+				q := fmt.Sprintf("SELECT value FROM %s WHERE %s=?", t, tableName+"_id")
+				paramsJSON, _ := Utility.ToJson([]interface{}{id})
+				if s, e := store.QueryContext(connectionId, db, q, paramsJSON); e == nil {
+					data := make(map[string]interface{})
+					if err := json.Unmarshal([]byte(s), &data); err != nil {
+						return nil, err
 					}
-					q := fmt.Sprintf("SELECT value FROM %s WHERE %s=?", t, tableName+"_id")
-					paramsJSON, _ := Utility.ToJson([]interface{}{id})
-					if s, e := store.QueryContext(connectionId, db, q, paramsJSON); e == nil {
-						data := make(map[string]interface{})
-						if err := json.Unmarshal([]byte(s), &data); err != nil {
-							return nil, err
-						}
-						array := make([]interface{}, 0, len(data["data"].([]interface{})))
-						for _, v := range data["data"].([]interface{}) {
-							array = append(array, v.([]interface{})[0])
-						}
-						object[field] = array
-						continue
+					array := make([]interface{}, 0, len(data["data"].([]interface{})))
+					for _, v := range data["data"].([]interface{}) {
+						array = append(array, v.([]interface{})[0])
 					}
-				}
-
-				// 2) Canonical ref tables: <left>_<right>, alphabetical
-				parts := strings.Split(t, "_")
-				if len(parts) != 2 {
+					object[field] = array
 					continue
 				}
-				left := parts[0]
-				right := parts[1]
+			}
 
-				if left != base && right != base {
-					continue
-				}
+			// 2) Canonical ref tables: <left>_<right>, alphabetical
+			parts := strings.Split(t, "_")
+			if len(parts) != 2 {
+				continue
+			}
+			left := parts[0]
+			right := parts[1]
 
-				// field name becomes the "other" token (plural)
-				other := left
-				colSelect := "source_id"
-				whereCol := "target_id"
-				if left == base {
-					other = right
-					colSelect = "target_id"
-					whereCol = "source_id"
-				}
+			if left != base && right != base {
+				continue
+			}
 
-				field := other // keep plural as field (roles, organizations, etc.)
-				if object[field] != nil {
-					continue
-				}
+			// field name becomes the "other" token (plural)
+			other := left
+			colSelect := "source_id"
+			whereCol := "target_id"
+			if left == base {
+				other = right
+				colSelect = "target_id"
+				whereCol = "source_id"
+			}
 
-				// read refs from the correct side
-				q := fmt.Sprintf("SELECT %s FROM %s WHERE %s=?", colSelect, t, whereCol)
-				paramsJSON, _ := Utility.ToJson([]interface{}{id})
-				if s, e := store.QueryContext(connectionId, db, q, paramsJSON); e == nil {
-					data := make(map[string]interface{})
-					if json.Unmarshal([]byte(s), &data) == nil {
-						arr := make([]interface{}, 0)
-						for _, row := range data["data"].([]interface{}) {
-							refID := Utility.ToString(row.([]interface{})[0])
-							if strings.Contains(refID, "@") {
-								if strings.Split(refID, "@")[0] != domain {
-									continue
-								}
-								refID = strings.Split(refID, "@")[0]
-							}
-							// $ref TypeName: capitalize the other token's first letter (compat with previous)
-							b := []byte(other)
-							if len(b) > 0 {
-								b[0] = byte(unicode.ToUpper(rune(b[0])))
+			field := other // keep plural as field (roles, organizations, etc.)
+			if object[field] != nil || !projectionAllows(projection, field) {
+				continue
+			}
+
+			// read refs from the correct side
+			q := fmt.Sprintf("SELECT %s FROM %s WHERE %s=?", colSelect, t, whereCol)
+			paramsJSON, _ := Utility.ToJson([]interface{}{id})
+			if s, e := store.QueryContext(connectionId, db, q, paramsJSON); e == nil {
+				data := make(map[string]interface{})
+				if json.Unmarshal([]byte(s), &data) == nil {
+					arr := make([]interface{}, 0)
+					for _, row := range data["data"].([]interface{}) {
+						refID := Utility.ToString(row.([]interface{})[0])
+						if strings.Contains(refID, "@") {
+							if strings.Split(refID, "@")[0] != domain {
+								continue
 							}
-							typeName := string(b)
-							arr = append(arr, map[string]interface{}{"$ref": typeName, "$id": refID, "$db": db})
+							refID = strings.Split(refID, "@")[0]
 						}
-						if len(arr) > 0 {
-							object[field] = arr
+						// $ref TypeName: capitalize the other token's first letter (compat with previous)
+						b := []byte(other)
+						if len(b) > 0 {
+							b[0] = byte(unicode.ToUpper(rune(b[0])))
 						}
+						typeName := string(b)
+						arr = append(arr, map[string]interface{}{"$ref": typeName, "$id": refID, "$db": db})
+					}
+					if len(arr) > 0 {
+						object[field] = arr
 					}
 				}
 			}
 		}
+		if projection != nil && !projectionAllows(projection, "_id") {
+			delete(object, "_id")
+		}
 	}
 
 	return objects, nil
 }
 
-func (store *SqlStore) formatQuery(table, query string) (string, error) {
-	safeTable := sanitizeIdentifier(table)
-	if query == "{}" {
-		return fmt.Sprintf("SELECT * FROM %s", safeTable), nil
-	}
-
-	parameters := make(map[string]interface{})
-	if err := json.Unmarshal([]byte(query), &parameters); err != nil {
-		return "", err
-	}
+// find runs a JSON filter or a raw SQL SELECT and rebuilds the objects.
+func (store *SqlStore) find(connectionId string, db string, table string, query string, options string, limit int64) ([]interface{}, error) {
+	table = sanitizeIdentifier(table)
 
-	q := fmt.Sprintf("SELECT * FROM %s WHERE ", safeTable)
-	for key, value := range parameters {
-		if key == "id" {
-			key = "_id"
+	var stmt, args string
+	var projection map[string]interface{}
+	if isJSONQuery(query) {
+		q, err := store.compileQuery(connectionId, db, table, query, options)
+		if err != nil {
+			return nil, err
 		}
-		if key == "_id" {
-			if s, ok := value.(string); ok && strings.Contains(s, "@") {
-				value = strings.Split(s, "@")[0]
-			}
+		if limit >= 0 && (q.opts.limit < 0 || q.opts.limit > limit) {
+			q.opts.limit = limit
 		}
-		switch reflect.TypeOf(value).Kind() {
-		case reflect.String:
-			q += fmt.Sprintf("%s = '%v' AND ", key, value)
-		case reflect.Slice:
-			if key == "$and" || key == "$or" {
-				q += store.getParameters(key, value.([]interface{}))
-			}
-		case reflect.Map:
-			for k, v := range value.(map[string]interface{}) {
-				if k == "$regex" {
-					q += fmt.Sprintf("%s LIKE '%v%%' AND ", key, v)
-				}
-			}
+		sqlStr, params := q.selectSQL("*")
+		if args, err = Utility.ToJson(params); err != nil {
+			return nil, err
 		}
-	}
-	q = strings.TrimSuffix(q, " AND ")
-	return q, nil
-}
-
-// FindOne returns a single object that matches the query.
-func (store *SqlStore) FindOne(ctx context.Context, connectionId string, database string, table string, query string, options string) (interface{}, error) {
-	table = sanitizeIdentifier(table)
-	if len(query) == 0 {
-		return nil, errors.New("query is empty")
-	}
-	if strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}") {
-		var err error
-		query, err = store.formatQuery(table, query)
+		stmt, projection = sqlStr, q.opts.projection
+	} else {
+		opts, err := parseFindOptions(options)
 		if err != nil {
 			return nil, err
 		}
+		stmt, args, projection = query, "[]", opts.projection
 	}
 
-	str, err := store.QueryContext(connectionId, database, query, "[]")
+	str, err := store.QueryContext(connectionId, db, stmt, args)
 	if err != nil {
 		return nil, err
 	}
@@ -1053,15 +1074,15 @@ func (store *SqlStore) FindOne(ctx context.Context, connectionId string, databas
 	if err := json.Unmarshal([]byte(str), &data); err != nil {
 		return nil, err
 	}
+	return store.recreateArrayOfObjects(connectionId, db, table, data, projection)
+}
 
-	options_ := make([]map[string]interface{}, 0)
-	if len(options) > 0 {
-		if err := json.Unmarshal([]byte(options), &options_); err != nil {
-			return nil, err
-		}
+// FindOne returns a single object that matches the query.
+func (store *SqlStore) FindOne(ctx context.Context, connectionId string, database string, table string, query string, options string) (interface{}, error) {
+	if len(query) == 0 {
+		return nil, errors.New("query is empty")
 	}
-
-	objects, err := store.recreateArrayOfObjects(connectionId, database, table, data, options_)
+	objects, err := store.find(connectionId, database, table, query, options, 1)
 	if err != nil {
 		return nil, err
 	}
@@ -1071,55 +1092,9 @@ func (store *SqlStore) FindOne(ctx context.Context, connectionId string, databas
 	return nil, errors.New("not found")
 }
 
-func (store *SqlStore) getParameters(condition string, values []interface{}) string {
-	query := ""
-	if condition == "$and" {
-		query += "("
-		for _, v := range values {
-			value := v.(map[string]interface{})
-			for key, v2 := range value {
-				if reflect.TypeOf(v2).Kind() == reflect.String {
-					query += fmt.Sprintf("%s = '%v' AND ", key, v2)
-				}
-			}
-		}
-		query = strings.TrimSuffix(query, " AND ")
-		query += ")"
-	}
-	return query
-}
-
 // Find returns a list of objects that match the query.
 func (store *SqlStore) Find(ctx context.Context, connectionId string, db string, table string, query string, options string) ([]any, error) {
-	table = sanitizeIdentifier(table)
-	if len(query) == 0 || query == "{}" {
-		query = fmt.Sprintf("SELECT * FROM %s", table)
-	} else if strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}") {
-		var err error
-		query, err = store.formatQuery(table, query)
-		if err != nil {
-			return nil, err
-		}
-	}
-
-	str, err := store.QueryContext(connectionId, db, query, "[]")
-	if err != nil {
-		return nil, err
-	}
-
-	data := make(map[string]any)
-	if err := json.Unmarshal([]byte(str), &data); err != nil {
-		return nil, err
-	}
-
-	options_ := make([]map[string]any, 0)
-	if len(options) > 0 {
-		if err := json.Unmarshal([]byte(options), &options_); err != nil {
-			return nil, err
-		}
-	}
-
-	objects, err := store.recreateArrayOfObjects(connectionId, db, table, data, options_)
+	objects, err := store.find(connectionId, db, table, query, options, -1)
 	if err != nil {
 		return nil, err
 	}
@@ -1150,144 +1125,146 @@ func (store *SqlStore) ReplaceOne(ctx context.Context, connectionId string, db s
 		}
 	}
 
-	if strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}") {
-		var err error
-		query, err = store.formatQuery(table, query)
-		if err != nil {
-			return err
-		}
-	}
-
 	// Delete then insert (simplifies array/reference sync).
-	_ = store.deleteOneSqlEntry(connectionId, db, table, query)
+	_, _ = store.deleteOneSqlEntry(connectionId, db, table, query)
 
-	_, err := store.insertData(connectionId, db, table, entity)
+	_, err := store.insertEntity(connectionId, db, table, entity, "replace")
 	return err
 }
 
-func generateSqlUpdateTableQuery(tableName string, fields []interface{}, whereClause string) (string, error) {
-	updateQuery := "UPDATE " + tableName + " SET "
-	for i, field := range fields {
-		updateQuery += field.(string) + " = ?"
-		if i < len(fields)-1 {
-			updateQuery += ", "
-		}
-	}
-	if whereClause != "" {
-		if strings.Contains(whereClause, "WHERE") {
-			whereClause = strings.Split(whereClause, "WHERE")[1]
-		}
-		updateQuery += " WHERE " + whereClause
-	}
-	return updateQuery, nil
-}
-
-// Update applies a $set patch to all entities matching the query.
-func (store *SqlStore) Update(ctx context.Context, connectionId string, db string, table string, query string, value string, options string) error {
+// update applies a $set patch to the entities matching the query (at most
+// one when one is true). Matching ids are resolved first so a $set on a
+// filtered field cannot change which rows are updated; primitive array
+// fields are synchronized in their side tables.
+func (store *SqlStore) update(connectionId string, db string, table string, query string, value string, one bool) error {
 	table = sanitizeIdentifier(table)
 	values_ := make(map[string]interface{})
 	if err := json.Unmarshal([]byte(value), &values_); err != nil {
 		return err
 	}
-	if values_["$set"] == nil {
-		return errors.New("no $set operator allowed in Update")
+	set, ok := values_["$set"].(map[string]interface{})
+	if !ok {
+		return errors.New("only the $set operator is supported by Update on the SQL store")
 	}
 
-	var err error
-	query, err = store.formatQuery(table, query)
+	q, err := store.compileQuery(connectionId, db, table, query, "")
 	if err != nil {
 		return err
 	}
-
-	// Fields (non-array only here).
-	fields := make([]interface{}, 0)
-	values := make([]interface{}, 0)
-	for key, v := range values_["$set"].(map[string]interface{}) {
-		if reflect.TypeOf(v).Kind() != reflect.Slice {
-			fields = append(fields, key)
-			values = append(values, v)
-		}
+	if one {
+		q.opts.limit = 1
 	}
-
-	q, err := generateSqlUpdateTableQuery(table, fields, query)
+	idsSQL, params := q.selectSQL(quoteIdent("_id"))
+	args, err := Utility.ToJson(params)
 	if err != nil {
 		return err
 	}
-
-	if _, err := store.ExecContext(connectionId, db, q, values, 0); err != nil {
+	str, err := store.QueryContext(connectionId, db, idsSQL, args)
+	if err != nil {
 		return err
 	}
-
-	// Array/reference sync for bulk update is not handled to keep parity with original behavior.
-	return nil
-}
-
-// UpdateOne applies a $set patch to a single entity and synchronizes primitive array tables.
-// (Reference arrays are best updated via ReplaceOne/InsertOne in this minimal change.)
-func (store *SqlStore) UpdateOne(ctx context.Context, connectionId string, db string, table string, query string, value string, options string) error {
-	table = sanitizeIdentifier(table)
-	values_ := make(map[string]interface{})
-	if err := json.Unmarshal([]byte(value), &values_); err != nil {
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(str), &data); err != nil {
 		return err
 	}
-	if values_["$set"] == nil {
-		return errors.New("no $set operator allowed in UpdateOne")
-	}
-
-	var err error
-	query, err = store.formatQuery(table, query)
-	if err != nil {
-		return err
+	rows, _ := data["data"].([]interface{})
+	if len(rows) == 0 {
+		if one {
+			return errors.New("not found")
+		}
+		return nil
 	}
 
-	currentEntity, err := store.FindOne(context.Background(), connectionId, db, table, query, "")
-	if err != nil {
-		return err
+	// Scalar fields go to the main table; arrays to <table>_<field>.
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
 	}
+	sort.Strings(keys)
 
-	fields := make([]interface{}, 0)
-	values := make([]interface{}, 0)
+	assignments := make([]string, 0, len(set))
+	fields := make([]interface{}, 0, len(set))
 	arrayFields := make([]string, 0)
-
-	for key, v := range values_["$set"].(map[string]interface{}) {
-		if reflect.TypeOf(v).Kind() == reflect.Slice {
+	for _, key := range keys {
+		v := set[key]
+		if v != nil && reflect.TypeOf(v).Kind() == reflect.Slice {
 			arrayFields = append(arrayFields, key)
-		} else {
-			fields = append(fields, key)
-			values = append(values, v)
+			continue
 		}
+		column := sanitizeIdentifier(key)
+		if column == "id" {
+			column = "_id"
+		}
+		assignments = append(assignments, quoteIdent(column)+" = ?")
+		fields = append(fields, v)
 	}
 
-	updateQuery, err := generateSqlUpdateTableQuery(table, fields, query)
-	if err != nil {
-		return err
-	}
-	if _, err := store.ExecContext(connectionId, db, updateQuery, values, 0); err != nil {
-		return err
-	}
-
-	// Sync primitive array tables for the updated entity.
-	for _, field := range arrayFields {
-		arrayTableName := table + "_" + field
-		if store.isTableExist(connectionId, db, arrayTableName) {
-			deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE %s_id=?", arrayTableName, table)
-			if _, err := store.ExecContext(connectionId, db, deleteQuery, []interface{}{currentEntity.(map[string]interface{})["_id"]}, 0); err != nil {
+	for _, r := range rows {
+		id := Utility.ToString(r.([]interface{})[0])
+		if len(assignments) > 0 {
+			stmt := fmt.Sprintf("UPDATE %s SET %s WHERE _id = ?", quoteIdent(table), strings.Join(assignments, ", "))
+			if _, err := store.ExecContext(connectionId, db, stmt, append(append([]interface{}{}, fields...), id), 0); err != nil {
 				return err
 			}
-			sliceValue := reflect.ValueOf(values_["$set"].(map[string]interface{})[field])
-			for i := 0; i < sliceValue.Len(); i++ {
-				element := sliceValue.Index(i)
-				insertQuery := fmt.Sprintf("INSERT INTO %s (value, %s_id) VALUES (?, ?);", arrayTableName, table)
-				if _, err := store.ExecContext(connectionId, db, insertQuery, []interface{}{element.Interface(), currentEntity.(map[string]interface{})["_id"]}, 0); err != nil {
-					return err
-				}
+		}
+		for _, field := range arrayFields {
+			if err := store.replaceArrayValues(connectionId, db, table, field, id, set[field]); err != nil {
+				return err
 			}
 		}
+
+		query, _ := Utility.ToJson(map[string]interface{}{"_id": id})
+		doc, err := store.FindOne(context.Background(), connectionId, db, table, query, "")
+		if err != nil {
+			return err
+		}
+		if err := store.recordChange(connectionId, db, table, "update", doc.(map[string]interface{})); err != nil {
+			return err
+		}
 	}
+	return nil
+}
+
+// replaceArrayValues rewrites the primitive array side table of one entity.
+func (store *SqlStore) replaceArrayValues(connectionId string, db string, table string, field string, id string, value interface{}) error {
+	arrayTableName := table + "_" + sanitizeIdentifier(field)
+	sliceValue := reflect.ValueOf(value)
 
+	if !store.isTableExist(connectionId, db, arrayTableName) {
+		if sliceValue.Len() == 0 {
+			return nil
+		}
+		sqlType := getSQLType(reflect.TypeOf(sliceValue.Index(0).Interface()))
+		createTableSQL := fmt.Sprintf("CREATE TABLE IF NOT EXISTS %s (value %s, %s_id TEXT)", arrayTableName, sqlType, table)
+		if _, err := store.ExecContext(connectionId, db, createTableSQL, nil, 0); err != nil {
+			return err
+		}
+	}
+
+	deleteQuery := fmt.Sprintf("DELETE FROM %s WHERE %s_id=?", arrayTableName, table)
+	if _, err := store.ExecContext(connectionId, db, deleteQuery, []interface{}{id}, 0); err != nil {
+		return err
+	}
+	for i := 0; i < sliceValue.Len(); i++ {
+		insertQuery := fmt.Sprintf("INSERT INTO %s (value, %s_id) VALUES (?, ?);", arrayTableName, table)
+		if _, err := store.ExecContext(connectionId, db, insertQuery, []interface{}{sliceValue.Index(i).Interface(), id}, 0); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// Update applies a $set patch to all entities matching the query.
+func (store *SqlStore) Update(ctx context.Context, connectionId string, db string, table string, query string, value string, options string) error {
+	return store.update(connectionId, db, table, query, value, false)
+}
+
+// UpdateOne applies a $set patch to a single entity and synchronizes primitive array tables.
+// (Reference arrays are best updated via ReplaceOne/InsertOne.)
+func (store *SqlStore) UpdateOne(ctx context.Context, connectionId string, db string, table string, query string, value string, options string) error {
+	return store.update(connectionId, db, table, query, value, true)
+}
+
 func (store *SqlStore) deleteSqlEntries(connectionId string, db string, table string, query string) error {
 	entities, err := store.Find(context.Background(), connectionId, db, table, query, "")
 	if err != nil {
@@ -1296,8 +1273,10 @@ func (store *SqlStore) deleteSqlEntries(connectionId string, db string, table st
 
 	for _, entity := range entities {
 		if m, ok := entity.(map[string]interface{}); ok && m["_id"] != nil {
-			q := fmt.Sprintf("SELECT * FROM '%s' WHERE _id='%s'", table, m["_id"].(string))
-			if err := store.deleteOneSqlEntry(connectionId, db, table, q); err != nil {
+			if err := store.deleteEntity(connectionId, db, table, Utility.ToString(m["_id"])); err != nil {
+				return err
+			}
+			if err := store.recordChange(connectionId, db, table, "delete", m); err != nil {
 				return err
 			}
 		}
@@ -1305,70 +1284,60 @@ func (store *SqlStore) deleteSqlEntries(connectionId string, db string, table st
 	return nil
 }
 
-func (store *SqlStore) deleteOneSqlEntry(connectionId string, db string, table string, query string) error {
+// deleteOneSqlEntry removes the first entity matching the query and returns it.
+func (store *SqlStore) deleteOneSqlEntry(connectionId string, db string, table string, query string) (map[string]interface{}, error) {
 	entity, err := store.FindOne(context.Background(), connectionId, db, table, query, "")
 	if err != nil {
-		return err
+		return nil, err
 	}
-
-	if strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}") {
-		if query, err = store.formatQuery(table, query); err != nil {
-			return err
-		}
+	m := entity.(map[string]interface{})
+	if err := store.deleteEntity(connectionId, db, table, Utility.ToString(m["_id"])); err != nil {
+		return nil, err
 	}
+	return m, nil
+}
 
-	// Delete the entity row.
-	del := strings.Replace(query, "SELECT *", "DELETE", 1)
-	if _, err := store.ExecContext(connectionId, db, del, nil, 0); err != nil {
+// deleteEntity removes one row and its related rows in array/reference tables:
+//   - primitive arrays: <table>_<field> WHERE <table>_id = ?
+//   - canonical refs: <left>_<right> WHERE (source_id|target_id) = ? depending on side
+func (store *SqlStore) deleteEntity(connectionId string, db string, table string, id string) error {
+	del := fmt.Sprintf("DELETE FROM %s WHERE _id=?", quoteIdent(table))
+	if _, err := store.ExecContext(connectionId, db, del, []interface{}{id}, 0); err != nil {
 		return err
 	}
 
-	// Delete related rows from array/reference tables.
-	// We will:
-	//  - remove primitive arrays: <table>_<field> WHERE <table>_id = ?
-	//  - remove canonical refs: <left>_<right> WHERE (source_id|target_id) = ? depending on side
-	id := Utility.ToString(entity.(map[string]interface{})["_id"])
 	base := ensurePlural(table)
 
 	// List tables once
-	list := "SELECT name FROM sqlite_master WHERE type='table'"
-	str, err := store.QueryContext(connectionId, db, list, "[]")
+	dbTables, err := store.listTables(connectionId, db)
 	if err != nil {
 		return err
 	}
-	dbTables := make(map[string]interface{})
-	if err := json.Unmarshal([]byte(str), &dbTables); err != nil {
-		return err
-	}
-
-	for _, values := range dbTables["data"].([]interface{}) {
-		for _, v := range values.([]interface{}) {
-			name := v.(string)
 
-			// Primitive arrays
-			if strings.HasPrefix(name, table+"_") {
-				delArr := fmt.Sprintf("DELETE FROM %s WHERE %s_id=?", name, table)
-				_, _ = store.ExecContext(connectionId, db, delArr, []interface{}{id}, 0)
-				continue
-			}
+	for name := range dbTables {
+		// Primitive arrays
+		if strings.HasPrefix(name, table+"_") {
+			delArr := fmt.Sprintf("DELETE FROM %s WHERE %s_id=?", name, table)
+			_, _ = store.ExecContext(connectionId, db, delArr, []interface{}{id}, 0)
+			continue
+		}
 
-			// Canonical refs
-			parts := strings.Split(name, "_")
-			if len(parts) != 2 {
-				continue
-			}
-			left := parts[0]
-			right := parts[1]
-			if left != base && right != base {
-				continue
-			}
-			if left == base {
-				delRef := fmt.Sprintf("DELETE FROM %s WHERE source_id=?", name)
-				_, _ = store.ExecContext(connectionId, db, delRef, []interface{}{id}, 0)
-			} else {
-				delRef := fmt.Sprintf("DELETE FROM %s WHERE target_id=?", name)
-				_, _ = store.ExecContext(connectionId, db, delRef, []interface{}{id}, 0)
-			}
+		// Canonical refs
+		parts := strings.Split(name, "_")
+		if len(parts) != 2 {
+			continue
+		}
+		left := parts[0]
+		right := parts[1]
+		if left != base && right != base {
+			continue
+		}
+		if left == base {
+			delRef := fmt.Sprintf("DELETE FROM %s WHERE source_id=?", name)
+			_, _ = store.ExecContext(connectionId, db, delRef, []interface{}{id}, 0)
+		} else {
+			delRef := fmt.Sprintf("DELETE FROM %s WHERE target_id=?", name)
+			_, _ = store.ExecContext(connectionId, db, delRef, []interface{}{id}, 0)
 		}
 	}
 
@@ -1382,12 +1351,106 @@ func (store *SqlStore) Delete(ctx context.Context, connectionId string, db strin
 
 // DeleteOne removes a single entity matching the query.
 func (store *SqlStore) DeleteOne(ctx context.Context, connectionId string, db string, table string, query string, options string) error {
-	return store.deleteOneSqlEntry(connectionId, db, sanitizeIdentifier(table), query)
+	table = sanitizeIdentifier(table)
+	entity, err := store.deleteOneSqlEntry(connectionId, db, table, query)
+	if err != nil {
+		return err
+	}
+	return store.recordChange(connectionId, db, table, "delete", entity)
+}
+
+// tableColumns returns the column names of a table, or none if it does not exist.
+func (store *SqlStore) tableColumns(connectionId string, db string, table string) ([]string, error) {
+	table = sanitizeIdentifier(table)
+	if !store.isTableExist(connectionId, db, table) {
+		return nil, nil
+	}
+	str, err := store.QueryContext(connectionId, db, fmt.Sprintf(`PRAGMA table_info("%s")`, table), "[]")
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(str), &data); err != nil {
+		return nil, err
+	}
+	rows, _ := data["data"].([]interface{})
+	columns := make([]string, 0, len(rows))
+	for _, r := range rows {
+		// PRAGMA table_info rows are (cid, name, type, notnull, dflt_value, pk).
+		if row, ok := r.([]interface{}); ok && len(row) > 1 {
+			columns = append(columns, Utility.ToString(row[1]))
+		}
+	}
+	return columns, nil
 }
 
-// Aggregate is not implemented for the SQLite variant.
-func (store *SqlStore) Aggregate(ctx context.Context, connectionId string, keyspace string, table string, pipeline string, optionsStr string) ([]interface{}, error) {
-	return nil, errors.New("not implemented")
+// Aggregate compiles a Mongo-style pipeline to a single SQL statement and runs it.
+// See pipeline_sql.go for the supported stages.
+func (store *SqlStore) Aggregate(ctx context.Context, connectionId string, db string, table string, pipeline string, optionsStr string) ([]interface{}, error) {
+	stages, err := parsePipeline(pipeline)
+	if err != nil {
+		return nil, err
+	}
+
+	compiled, err := compileSqlPipeline(table, stages, func(t string) ([]string, error) {
+		return store.tableColumns(connectionId, db, t)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if compiled == nil {
+		// Unknown table: same as an empty collection.
+		return []interface{}{}, nil
+	}
+
+	args, err := Utility.ToJson(compiled.args)
+	if err != nil {
+		return nil, err
+	}
+	str, err := store.QueryContext(connectionId, db, compiled.query, args)
+	if err != nil {
+		return nil, err
+	}
+
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(str), &data); err != nil {
+		return nil, err
+	}
+	header, _ := data["header"].([]interface{})
+	rows, _ := data["data"].([]interface{})
+
+	results := make([]interface{}, 0, len(rows))
+	for _, r := range rows {
+		row := r.([]interface{})
+		doc := make(map[string]interface{}, len(header))
+		for i, h := range header {
+			name := Utility.ToString(h.(map[string]interface{})["name"])
+			value := row[i]
+			switch {
+			case name == sqlPosColumn:
+				continue
+			case compiled.jsonColumns[name]:
+				array := make([]interface{}, 0)
+				if s, ok := value.(string); ok {
+					if err := json.Unmarshal([]byte(s), &array); err != nil {
+						return nil, err
+					}
+				}
+				doc[name] = array
+			case strings.HasPrefix(name, "_id."):
+				id, _ := doc["_id"].(map[string]interface{})
+				if id == nil {
+					id = make(map[string]interface{})
+					doc["_id"] = id
+				}
+				id[strings.TrimPrefix(name, "_id.")] = value
+			default:
+				doc[name] = value
+			}
+		}
+		results = append(results, doc)
+	}
+	return results, nil
 }
 
 // CreateTable creates a new table with provided fields (all TEXT except _id which is TEXT PRIMARY KEY).
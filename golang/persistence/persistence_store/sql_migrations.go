@@ -0,0 +1,87 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// The ledger lives in each database file, next to the tables it versions.
+const sqlMigrationsSchema = `CREATE TABLE IF NOT EXISTS __migrations (
+	version INTEGER PRIMARY KEY,
+	name TEXT NOT NULL,
+	checksum TEXT NOT NULL,
+	applied_at INTEGER NOT NULL,
+	duration_ms INTEGER NOT NULL
+)`
+
+var _ Migrator = (*SqlStore)(nil)
+
+// MigrationDialect returns "sql": scripts are SQLite statements separated
+// by semicolons.
+func (store *SqlStore) MigrationDialect() string { return "sql" }
+
+// AppliedMigrations returns the ledger of a database file.
+func (store *SqlStore) AppliedMigrations(ctx context.Context, connectionId string, database string) ([]AppliedMigration, error) {
+	db, err := store.database(connectionId, database)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, sqlMigrationsSchema); err != nil {
+		return nil, err
+	}
+	rows, err := db.QueryContext(ctx, "SELECT version, name, checksum, applied_at, duration_ms FROM __migrations ORDER BY version")
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	applied := make([]AppliedMigration, 0)
+	for rows.Next() {
+		var a AppliedMigration
+		var at, ms int64
+		if err := rows.Scan(&a.Version, &a.Name, &a.Checksum, &at, &ms); err != nil {
+			return nil, err
+		}
+		a.AppliedAt = time.UnixMilli(at)
+		a.Duration = time.Duration(ms) * time.Millisecond
+		applied = append(applied, a)
+	}
+	return applied, rows.Err()
+}
+
+// ApplyMigration runs a script and records it in one transaction: SQLite
+// DDL is transactional, so a failing script leaves the database untouched.
+func (store *SqlStore) ApplyMigration(ctx context.Context, connectionId string, database string, migration *Migration) (*AppliedMigration, error) {
+	if store.tx != nil {
+		return nil, errors.New("migrations cannot run inside a transaction")
+	}
+	db, err := store.database(connectionId, database)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := db.ExecContext(ctx, sqlMigrationsSchema); err != nil {
+		return nil, err
+	}
+
+	start := time.Now()
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.ExecContext(ctx, migration.Script); err != nil {
+		return nil, err
+	}
+	applied := appliedNow(migration, start)
+	if _, err := tx.ExecContext(ctx,
+		"INSERT INTO __migrations (version, name, checksum, applied_at, duration_ms) VALUES (?, ?, ?, ?, ?)",
+		applied.Version, applied.Name, applied.Checksum, applied.AppliedAt.UnixMilli(), applied.Duration.Milliseconds()); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return applied, nil
+}
@@ -0,0 +1,623 @@
+package persistence_store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// sql_query.go compiles the Mongo filter dialect into SQLite WHERE clauses
+// with bound parameters. Values never reach the SQL text; only quoted
+// identifiers do. The same compiler serves Find, FindOne, Count, Update,
+// Delete and the $match stage of Aggregate.
+//
+// Supported operators: implicit equality, $eq, $ne, $gt, $gte, $lt, $lte,
+// $in, $nin, $exists, $regex, $not, $and, $or and $nor. $regex takes
+// optionally anchored literals: with $options "i" they compile to LIKE,
+// which ignores the case of ASCII letters, otherwise to the case-sensitive
+// GLOB.
+//
+// Paths resolve against the main table first. A path naming a primitive
+// array (stored by insertData in <table>_<field>) matches when any element
+// matches. A dotted path "<field>.<column>" follows the canonical link table
+// of a reference array into the referenced table; "<field>.$id" (or _id/id)
+// compares the referenced ids directly.
+
+// sqlField is the SQL side of a filter path.
+type sqlField struct {
+	expr  string // expression holding the value
+	scope string // "FROM ... WHERE ..." of a side table, empty for a main column
+	isId  bool   // strip the "@domain" suffix of string operands
+}
+
+// any holds when at least one value satisfies pred.
+func (f sqlField) any(pred string) string {
+	if f.scope == "" {
+		return pred
+	}
+	return "EXISTS (SELECT 1 " + f.scope + " AND " + pred + ")"
+}
+
+// none holds when no value satisfies pred (a missing value included).
+func (f sqlField) none(pred string) string {
+	if f.scope == "" {
+		return fmt.Sprintf("(%s IS NULL OR NOT (%s))", f.expr, pred)
+	}
+	return "NOT EXISTS (SELECT 1 " + f.scope + " AND " + pred + ")"
+}
+
+func (f sqlField) present() string {
+	if f.scope == "" {
+		return f.expr + " IS NOT NULL"
+	}
+	return "EXISTS (SELECT 1 " + f.scope + ")"
+}
+
+func (f sqlField) absent() string {
+	if f.scope == "" {
+		return f.expr + " IS NULL"
+	}
+	return "NOT EXISTS (SELECT 1 " + f.scope + ")"
+}
+
+// bind validates an operand and applies id normalization.
+func (f sqlField) bind(v interface{}) (interface{}, error) {
+	switch x := v.(type) {
+	case map[string]interface{}, []interface{}:
+		return nil, fmt.Errorf("unsupported operand %v on SQL store", v)
+	case string:
+		if f.isId && strings.Contains(x, "@") {
+			return strings.Split(x, "@")[0], nil
+		}
+	}
+	return v, nil
+}
+
+// sqlFilter compiles filters; resolve maps a path to its SQL side.
+type sqlFilter struct {
+	resolve func(path string) (sqlField, error)
+}
+
+// compile turns a filter into a WHERE expression. Keys are visited in
+// sorted order so the statement text is stable.
+func (f *sqlFilter) compile(filter map[string]interface{}) (string, []interface{}, error) {
+	keys := make([]string, 0, len(filter))
+	for k := range filter {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clauses := make([]string, 0, len(keys))
+	args := make([]interface{}, 0)
+	for _, key := range keys {
+		var clause string
+		var a []interface{}
+		var err error
+		switch key {
+		case "$and", "$or", "$nor":
+			clause, a, err = f.compileLogical(key, filter[key])
+		default:
+			if strings.HasPrefix(key, "$") {
+				return "", nil, fmt.Errorf("unsupported query operator %s", key)
+			}
+			var field sqlField
+			if field, err = f.resolve(key); err == nil {
+				clause, a, err = f.compileField(field, filter[key])
+			}
+		}
+		if err != nil {
+			return "", nil, err
+		}
+		clauses = append(clauses, clause)
+		args = append(args, a...)
+	}
+	if len(clauses) == 0 {
+		return "1", args, nil
+	}
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+func (f *sqlFilter) compileLogical(op string, cond interface{}) (string, []interface{}, error) {
+	list, ok := cond.([]interface{})
+	if !ok || len(list) == 0 {
+		return "", nil, fmt.Errorf("%s expects a non-empty array", op)
+	}
+	parts := make([]string, 0, len(list))
+	args := make([]interface{}, 0)
+	for _, item := range list {
+		sub, ok := item.(map[string]interface{})
+		if !ok {
+			return "", nil, fmt.Errorf("%s expects an array of objects", op)
+		}
+		clause, a, err := f.compile(sub)
+		if err != nil {
+			return "", nil, err
+		}
+		parts = append(parts, "("+clause+")")
+		args = append(args, a...)
+	}
+	switch op {
+	case "$and":
+		return "(" + strings.Join(parts, " AND ") + ")", args, nil
+	case "$or":
+		return "(" + strings.Join(parts, " OR ") + ")", args, nil
+	}
+	return "NOT (" + strings.Join(parts, " OR ") + ")", args, nil
+}
+
+func (f *sqlFilter) compileField(field sqlField, cond interface{}) (string, []interface{}, error) {
+	ops, ok := isOperatorObject(cond)
+	if !ok {
+		ops = map[string]interface{}{"$eq": cond}
+	}
+
+	keys := make([]string, 0, len(ops))
+	for k := range ops {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	clauses := make([]string, 0, len(ops))
+	args := make([]interface{}, 0)
+	for _, op := range keys {
+		operand := ops[op]
+		switch op {
+		case "$eq", "$ne":
+			if operand == nil {
+				if op == "$eq" {
+					clauses = append(clauses, field.absent())
+				} else {
+					clauses = append(clauses, field.present())
+				}
+				continue
+			}
+			v, err := field.bind(operand)
+			if err != nil {
+				return "", nil, err
+			}
+			if op == "$eq" {
+				clauses = append(clauses, field.any(field.expr+" = ?"))
+			} else {
+				clauses = append(clauses, field.none(field.expr+" = ?"))
+			}
+			args = append(args, v)
+
+		case "$gt", "$gte", "$lt", "$lte":
+			v, err := field.bind(operand)
+			if err != nil {
+				return "", nil, err
+			}
+			sqlOp := map[string]string{"$gt": ">", "$gte": ">=", "$lt": "<", "$lte": "<="}[op]
+			clauses = append(clauses, field.any(fmt.Sprintf("%s %s ?", field.expr, sqlOp)))
+			args = append(args, v)
+
+		case "$in", "$nin":
+			list, ok := operand.([]interface{})
+			if !ok {
+				return "", nil, fmt.Errorf("%s expects an array", op)
+			}
+			values := make([]interface{}, 0, len(list))
+			hasNull := false
+			for _, item := range list {
+				if item == nil {
+					hasNull = true
+					continue
+				}
+				v, err := field.bind(item)
+				if err != nil {
+					return "", nil, err
+				}
+				values = append(values, v)
+			}
+			in := "0"
+			if len(values) > 0 {
+				in = fmt.Sprintf("%s IN (%s)", field.expr, strings.TrimSuffix(strings.Repeat("?, ", len(values)), ", "))
+			}
+			var clause string
+			if op == "$in" {
+				clause = field.any(in)
+				if hasNull {
+					clause = "(" + clause + " OR " + field.absent() + ")"
+				}
+			} else {
+				clause = field.none(in)
+				if hasNull {
+					clause = "(" + clause + " AND " + field.present() + ")"
+				}
+			}
+			clauses = append(clauses, clause)
+			args = append(args, values...)
+
+		case "$exists":
+			if b, _ := operand.(bool); b {
+				clauses = append(clauses, field.present())
+			} else {
+				clauses = append(clauses, field.absent())
+			}
+
+		case "$regex":
+			pattern, ok := operand.(string)
+			if !ok {
+				return "", nil, errors.New("$regex expects a string")
+			}
+			if flags, _ := ops["$options"].(string); strings.Contains(flags, "i") {
+				like, err := regexToLike(pattern)
+				if err != nil {
+					return "", nil, err
+				}
+				clauses = append(clauses, field.any(field.expr+` LIKE ? ESCAPE '\'`))
+				args = append(args, like)
+			} else {
+				glob, err := regexToGlob(pattern)
+				if err != nil {
+					return "", nil, err
+				}
+				clauses = append(clauses, field.any(field.expr+` GLOB ?`))
+				args = append(args, glob)
+			}
+
+		case "$options":
+			// Read with $regex.
+
+		case "$not":
+			clause, a, err := f.compileField(field, operand)
+			if err != nil {
+				return "", nil, err
+			}
+			clauses = append(clauses, "NOT ("+clause+")")
+			args = append(args, a...)
+
+		default:
+			return "", nil, fmt.Errorf("unsupported query operator %s", op)
+		}
+	}
+	if len(clauses) == 0 {
+		return "1", args, nil
+	}
+	return strings.Join(clauses, " AND "), args, nil
+}
+
+// regexLiteral splits the regular expressions SQLite can match: an
+// optionally anchored literal ("^abc", "abc$", "abc").
+func regexLiteral(pattern string) (literal string, start, end bool, err error) {
+	if strings.HasPrefix(pattern, "^") {
+		pattern, start = pattern[1:], true
+	}
+	if strings.HasSuffix(pattern, "$") && !strings.HasSuffix(pattern, `\$`) {
+		pattern, end = pattern[:len(pattern)-1], true
+	}
+
+	var b strings.Builder
+	for i := 0; i < len(pattern); i++ {
+		ch := pattern[i]
+		switch ch {
+		case '\\':
+			if i+1 >= len(pattern) {
+				return "", false, false, errors.New("invalid $regex: trailing backslash")
+			}
+			i++
+			ch = pattern[i]
+			if ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' {
+				return "", false, false, fmt.Errorf("unsupported $regex %q on SQL store", pattern)
+			}
+		case '.', '*', '+', '?', '(', ')', '[', ']', '{', '}', '|', '^', '$':
+			return "", false, false, fmt.Errorf("unsupported $regex %q on SQL store", pattern)
+		}
+		b.WriteByte(ch)
+	}
+	return b.String(), start, end, nil
+}
+
+// regexToLike converts a $regex to a LIKE pattern with '\' as escape.
+func regexToLike(pattern string) (string, error) {
+	literal, start, end, err := regexLiteral(pattern)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if !start {
+		b.WriteByte('%')
+	}
+	for i := 0; i < len(literal); i++ {
+		if ch := literal[i]; ch == '%' || ch == '_' || ch == '\\' {
+			b.WriteByte('\\')
+		}
+		b.WriteByte(literal[i])
+	}
+	if !end {
+		b.WriteByte('%')
+	}
+	return b.String(), nil
+}
+
+// regexToGlob converts a $regex to a GLOB pattern. GLOB has no escape
+// character, so its wildcards are matched literally as one-character sets.
+func regexToGlob(pattern string) (string, error) {
+	literal, start, end, err := regexLiteral(pattern)
+	if err != nil {
+		return "", err
+	}
+	var b strings.Builder
+	if !start {
+		b.WriteByte('*')
+	}
+	for i := 0; i < len(literal); i++ {
+		switch ch := literal[i]; ch {
+		case '*', '?', '[':
+			b.WriteString("[" + string(ch) + "]")
+		default:
+			b.WriteByte(ch)
+		}
+	}
+	if !end {
+		b.WriteByte('*')
+	}
+	return b.String(), nil
+}
+
+// ---------- Options ----------
+
+// findOptions are the sort/skip/limit/projection parts of an options string.
+type findOptions struct {
+	sort       []sortKey
+	skip       int64
+	limit      int64 // < 0 means no limit
+	projection map[string]interface{}
+}
+
+// parseFindOptions reads the options string passed to Find and friends.
+// It accepts a single object or the array of option objects sent by the
+// clients, e.g. [{"Projection":{"name":1}},{"Sort":{"name":-1}},{"Limit":10}].
+// Keys are case-insensitive, like the Mongo driver options they mirror.
+func parseFindOptions(options string) (*findOptions, error) {
+	opts := &findOptions{limit: -1}
+	options = strings.TrimSpace(options)
+	if options == "" {
+		return opts, nil
+	}
+
+	objects := make([]json.RawMessage, 0)
+	if strings.HasPrefix(options, "[") {
+		if err := json.Unmarshal([]byte(options), &objects); err != nil {
+			return nil, fmt.Errorf("invalid options: %w", err)
+		}
+	} else {
+		objects = append(objects, json.RawMessage(options))
+	}
+
+	for _, obj := range objects {
+		fields, err := decodeOrdered(obj)
+		if err != nil {
+			return nil, fmt.Errorf("invalid options: %w", err)
+		}
+		for _, f := range fields {
+			switch strings.ToLower(f.key) {
+			case "projection":
+				if err := json.Unmarshal(f.value, &opts.projection); err != nil {
+					return nil, fmt.Errorf("invalid projection: %w", err)
+				}
+			case "sort":
+				keys, err := decodeOrdered(f.value)
+				if err != nil {
+					return nil, fmt.Errorf("invalid sort: %w", err)
+				}
+				for _, k := range keys {
+					var dir float64
+					if err := json.Unmarshal(k.value, &dir); err != nil || (dir != 1 && dir != -1) {
+						return nil, fmt.Errorf("sort direction of %q must be 1 or -1", k.key)
+					}
+					opts.sort = append(opts.sort, sortKey{field: k.key, desc: dir < 0})
+				}
+			case "skip":
+				if err := json.Unmarshal(f.value, &opts.skip); err != nil || opts.skip < 0 {
+					return nil, errors.New("skip must be a non-negative integer")
+				}
+			case "limit":
+				if err := json.Unmarshal(f.value, &opts.limit); err != nil {
+					return nil, errors.New("limit must be an integer")
+				}
+				if opts.limit == 0 {
+					// Mongo treats a zero limit as no limit.
+					opts.limit = -1
+				}
+			}
+		}
+	}
+	return opts, nil
+}
+
+// projectionAllows reports whether a field survives the projection.
+func projectionAllows(projection map[string]interface{}, field string) bool {
+	if projection == nil {
+		return true
+	}
+	truthy := func(v interface{}) bool {
+		if b, ok := v.(bool); ok {
+			return b
+		}
+		n, ok := toFloat(v)
+		return !ok || n != 0
+	}
+
+	v, listed := projection[field]
+	if field == "_id" {
+		return !listed || truthy(v)
+	}
+	inclusion := false
+	for k, pv := range projection {
+		if k != "_id" && truthy(pv) {
+			inclusion = true
+			break
+		}
+	}
+	if inclusion {
+		return listed && truthy(v)
+	}
+	return !listed
+}
+
+// ---------- SqlStore query compilation ----------
+
+// sqlQuery is a compiled filter plus options for one table.
+type sqlQuery struct {
+	table string // quoted table name
+	where string
+	args  []interface{}
+	opts  *findOptions
+	order string
+}
+
+// selectSQL returns the SELECT statement and its arguments.
+func (q *sqlQuery) selectSQL(columns string) (string, []interface{}) {
+	stmt := fmt.Sprintf("SELECT %s FROM %s WHERE %s", columns, q.table, q.where)
+	args := append([]interface{}{}, q.args...)
+	if q.order != "" {
+		stmt += " ORDER BY " + q.order
+	}
+	if q.opts.limit >= 0 || q.opts.skip > 0 {
+		stmt += " LIMIT ? OFFSET ?"
+		args = append(args, q.opts.limit, q.opts.skip)
+	}
+	return stmt, args
+}
+
+// isJSONQuery distinguishes a Mongo-style filter from a raw SQL statement.
+func isJSONQuery(query string) bool {
+	query = strings.TrimSpace(query)
+	return query == "" || (strings.HasPrefix(query, "{") && strings.HasSuffix(query, "}"))
+}
+
+// compileQuery compiles a JSON filter and options against table. The
+// schema (main columns and side tables) is read once per call to resolve
+// paths.
+func (store *SqlStore) compileQuery(connectionId string, db string, table string, query string, options string) (*sqlQuery, error) {
+	table = sanitizeIdentifier(table)
+	filter := make(map[string]interface{})
+	if q := strings.TrimSpace(query); q != "" {
+		if err := json.Unmarshal([]byte(q), &filter); err != nil {
+			return nil, err
+		}
+	}
+	opts, err := parseFindOptions(options)
+	if err != nil {
+		return nil, err
+	}
+
+	columns, err := store.tableColumns(connectionId, db, table)
+	if err != nil {
+		return nil, err
+	}
+	tables, err := store.listTables(connectionId, db)
+	if err != nil {
+		return nil, err
+	}
+
+	main := quoteIdent(table)
+	hasColumn := func(name string) bool { return containsString(columns, name) }
+	aliases := 0
+	resolve := func(path string) (sqlField, error) {
+		if path == "id" {
+			path = "_id"
+		}
+		if hasColumn(path) {
+			return sqlField{expr: main + "." + quoteIdent(path), isId: path == "_id"}, nil
+		}
+
+		head, sub, dotted := strings.Cut(path, ".")
+		aliases++
+		alias := fmt.Sprintf("c%d", aliases)
+
+		// Primitive array side table: <table>_<field>(value, <table>_id).
+		arrayTable := table + "_" + sanitizeIdentifier(head)
+		if !dotted && tables[arrayTable] && store.hasColumn(connectionId, db, arrayTable, "value") {
+			return sqlField{
+				expr: alias + ".value",
+				scope: fmt.Sprintf("FROM %s AS %s WHERE %s.%s = %s.%s",
+					quoteIdent(arrayTable), alias, alias, quoteIdent(table+"_id"), main, quoteIdent("_id")),
+			}, nil
+		}
+
+		// Reference array: canonical link table into the referenced table.
+		linkTable, baseIsFirst := canonicalRefTable(table, sanitizeIdentifier(head))
+		if tables[linkTable] {
+			self, other := "target_id", "source_id"
+			if baseIsFirst {
+				self, other = "source_id", "target_id"
+			}
+			scope := fmt.Sprintf("FROM %s AS %s WHERE %s.%s = %s.%s", quoteIdent(linkTable), alias, alias, self, main, quoteIdent("_id"))
+			if !dotted || sub == "$id" || sub == "_id" || sub == "id" {
+				return sqlField{expr: alias + "." + other, scope: scope, isId: true}, nil
+			}
+			if strings.Contains(sub, ".") {
+				return sqlField{}, fmt.Errorf("unsupported path %q on SQL store", path)
+			}
+			target := ensurePlural(head)
+			targetColumns, err := store.tableColumns(connectionId, db, target)
+			if err != nil {
+				return sqlField{}, err
+			}
+			expr := "NULL"
+			if containsString(targetColumns, sub) {
+				expr = alias + "r." + quoteIdent(sub)
+			}
+			scope = fmt.Sprintf("FROM %s AS %s JOIN %s AS %sr ON %sr.%s = %s.%s WHERE %s.%s = %s.%s",
+				quoteIdent(linkTable), alias, quoteIdent(sanitizeIdentifier(target)), alias, alias, quoteIdent("_id"), alias, other,
+				alias, self, main, quoteIdent("_id"))
+			return sqlField{expr: expr, scope: scope, isId: sub == "_id"}, nil
+		}
+
+		// Unknown field: behaves like a missing value.
+		return sqlField{expr: "NULL"}, nil
+	}
+
+	where, args, err := (&sqlFilter{resolve: resolve}).compile(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	order := make([]string, 0, len(opts.sort))
+	for _, k := range opts.sort {
+		if k.field == "id" {
+			k.field = "_id"
+		}
+		if !hasColumn(k.field) {
+			continue
+		}
+		dir := "ASC"
+		if k.desc {
+			dir = "DESC"
+		}
+		order = append(order, main+"."+quoteIdent(k.field)+" "+dir)
+	}
+
+	return &sqlQuery{table: main, where: where, args: args, opts: opts, order: strings.Join(order, ", ")}, nil
+}
+
+func (store *SqlStore) hasColumn(connectionId string, db string, table string, column string) bool {
+	columns, err := store.tableColumns(connectionId, db, table)
+	return err == nil && containsString(columns, column)
+}
+
+// listTables returns the set of tables of a database.
+func (store *SqlStore) listTables(connectionId string, db string) (map[string]bool, error) {
+	str, err := store.QueryContext(connectionId, db, "SELECT name FROM sqlite_master WHERE type='table'", "[]")
+	if err != nil {
+		return nil, err
+	}
+	data := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(str), &data); err != nil {
+		return nil, err
+	}
+	tables := make(map[string]bool)
+	rows, _ := data["data"].([]interface{})
+	for _, r := range rows {
+		if row, ok := r.([]interface{}); ok && len(row) > 0 {
+			if name, ok := row[0].(string); ok {
+				tables[name] = true
+			}
+		}
+	}
+	return tables, nil
+}
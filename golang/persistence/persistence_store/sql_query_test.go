@@ -0,0 +1,169 @@
+package persistence_store
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	Utility "github.com/globulario/utility"
+)
+
+func seedAccounts(t *testing.T, store *SqlStore) {
+	t.Helper()
+	ctx := context.Background()
+	roles := []interface{}{
+		map[string]interface{}{"_id": "admin", "name": "Administrator", "level": 10.0},
+		map[string]interface{}{"_id": "guest", "name": "Guest", "level": 1.0},
+	}
+	if _, err := store.InsertMany(ctx, "test", "test_db", "roles", roles, ""); err != nil {
+		t.Fatal(err)
+	}
+	accounts := []interface{}{
+		map[string]interface{}{"_id": "a1", "name": "alice", "age": 31.0, "email": "alice@example.com",
+			"tags": []interface{}{"ops", "dev"}, "roles": []interface{}{map[string]interface{}{"$ref": "Roles", "$id": "admin"}}},
+		map[string]interface{}{"_id": "a2", "name": "bob", "age": 25.0,
+			"tags": []interface{}{"dev"}, "roles": []interface{}{map[string]interface{}{"$ref": "Roles", "$id": "guest"}}},
+		map[string]interface{}{"_id": "a3", "name": "carol", "age": 42.0, "email": "carol@example.com"},
+	}
+	if _, err := store.InsertMany(ctx, "test", "test_db", "accounts", accounts, ""); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func findIds(t *testing.T, store *SqlStore, query, options string) []string {
+	t.Helper()
+	results, err := store.Find(context.Background(), "test", "test_db", "accounts", query, options)
+	if err != nil {
+		t.Fatalf("Find(%s): %v", query, err)
+	}
+	ids := make([]string, 0, len(results))
+	for _, r := range results {
+		ids = append(ids, Utility.ToString(r.(map[string]interface{})["_id"]))
+	}
+	return ids
+}
+
+func TestSqlStoreFindOperators(t *testing.T) {
+	store := newTestSqlStore(t)
+	seedAccounts(t, store)
+
+	cases := []struct {
+		query string
+		want  []string
+	}{
+		{`{}`, []string{"a1", "a2", "a3"}},
+		{`{"name":"alice"}`, []string{"a1"}},
+		{`{"_id":"a2@example.com"}`, []string{"a2"}},
+		{`{"age":{"$gt":30}}`, []string{"a1", "a3"}},
+		{`{"age":{"$gte":25,"$lt":40}}`, []string{"a1", "a2"}},
+		{`{"name":{"$in":["bob","carol"]}}`, []string{"a2", "a3"}},
+		{`{"name":{"$nin":["bob","carol"]}}`, []string{"a1"}},
+		{`{"name":{"$ne":"bob"}}`, []string{"a1", "a3"}},
+		{`{"email":{"$exists":false}}`, []string{"a2"}},
+		{`{"email":{"$exists":true}}`, []string{"a1", "a3"}},
+		{`{"email":{"$regex":"^carol"}}`, []string{"a3"}},
+		{`{"email":{"$regex":"^CAROL"}}`, []string{}},
+		{`{"email":{"$regex":"^CAROL","$options":"i"}}`, []string{"a3"}},
+		{`{"roles.name":{"$regex":"^guest$"}}`, []string{}},
+		{`{"roles.name":{"$regex":"^guest$","$options":"i"}}`, []string{"a2"}},
+		{`{"$or":[{"name":"bob"},{"age":{"$gt":40}}]}`, []string{"a2", "a3"}},
+		{`{"$and":[{"age":{"$gt":20}},{"age":{"$lt":30}}]}`, []string{"a2"}},
+		{`{"missing":"x"}`, []string{}},
+		{`{"tags":"ops"}`, []string{"a1"}},
+		{`{"tags":{"$in":["dev"]}}`, []string{"a1", "a2"}},
+		{`{"tags":{"$ne":"ops"}}`, []string{"a2", "a3"}},
+		{`{"tags":{"$exists":false}}`, []string{"a3"}},
+		{`{"roles.$id":"admin"}`, []string{"a1"}},
+		{`{"roles.name":"Guest"}`, []string{"a2"}},
+		{`{"roles.level":{"$gte":5}}`, []string{"a1"}},
+		// Values are bound, never spliced into the SQL text.
+		{`{"name":"x' OR '1'='1"}`, []string{}},
+	}
+	for _, tc := range cases {
+		got := findIds(t, store, tc.query, "")
+		sort.Strings(got)
+		if len(got) != len(tc.want) {
+			t.Errorf("Find(%s) = %v, want %v", tc.query, got, tc.want)
+			continue
+		}
+		for i := range got {
+			if got[i] != tc.want[i] {
+				t.Errorf("Find(%s) = %v, want %v", tc.query, got, tc.want)
+				break
+			}
+		}
+	}
+
+	if _, err := store.Find(context.Background(), "test", "test_db", "accounts", `{"name":{"$where":"1"}}`, ""); err == nil {
+		t.Error("expected an error for an unsupported operator")
+	}
+}
+
+func TestSqlStoreFindOptions(t *testing.T) {
+	store := newTestSqlStore(t)
+	seedAccounts(t, store)
+
+	got := findIds(t, store, `{}`, `[{"Sort":{"age":-1}},{"Skip":1},{"Limit":1}]`)
+	if len(got) != 1 || got[0] != "a1" {
+		t.Errorf("sort/skip/limit: got %v, want [a1]", got)
+	}
+
+	results, err := store.Find(context.Background(), "test", "test_db", "accounts", `{"_id":"a1"}`, `[{"Projection":{"name":1}}]`)
+	if err != nil || len(results) != 1 {
+		t.Fatalf("projection: %v, %v", results, err)
+	}
+	doc := results[0].(map[string]interface{})
+	if doc["name"] != "alice" || doc["age"] != nil || doc["tags"] != nil || doc["_id"] != "a1" {
+		t.Errorf("projection kept unexpected fields: %v", doc)
+	}
+
+	one, err := store.FindOne(context.Background(), "test", "test_db", "accounts", `{"age":{"$lt":30}}`, "")
+	if err != nil || one.(map[string]interface{})["_id"] != "a2" {
+		t.Errorf("FindOne: %v, %v", one, err)
+	}
+
+	n, err := store.Count(context.Background(), "test", "test_db", "accounts", `{"tags":"dev"}`, "")
+	if err != nil || n != 2 {
+		t.Errorf("Count = %d, %v; want 2", n, err)
+	}
+}
+
+func TestSqlStoreUpdateDelete(t *testing.T) {
+	store := newTestSqlStore(t)
+	seedAccounts(t, store)
+	ctx := context.Background()
+
+	if err := store.Update(ctx, "test", "test_db", "accounts", `{"age":{"$lt":35}}`, `{"$set":{"age":50,"tags":["x"]}}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := findIds(t, store, `{"age":50}`, ""); len(got) != 2 {
+		t.Errorf("Update matched %v, want two accounts", got)
+	}
+	if got := findIds(t, store, `{"tags":"x"}`, ""); len(got) != 2 {
+		t.Errorf("Update did not sync array tables: %v", got)
+	}
+
+	if err := store.UpdateOne(ctx, "test", "test_db", "accounts", `{"name":"carol"}`, `{"$set":{"name":"caroline"}}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := findIds(t, store, `{"name":"caroline"}`, ""); len(got) != 1 || got[0] != "a3" {
+		t.Errorf("UpdateOne: got %v", got)
+	}
+
+	if err := store.Delete(ctx, "test", "test_db", "accounts", `{"age":{"$gte":50}}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if got := findIds(t, store, `{}`, ""); len(got) != 1 || got[0] != "a3" {
+		t.Errorf("after Delete: got %v, want [a3]", got)
+	}
+	if got := findIds(t, store, `{"tags":"x"}`, ""); len(got) != 0 {
+		t.Errorf("Delete left array rows behind: %v", got)
+	}
+
+	if err := store.DeleteOne(ctx, "test", "test_db", "accounts", `{"_id":"a3"}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if n, _ := store.Count(ctx, "test", "test_db", "accounts", `{}`, ""); n != 0 {
+		t.Errorf("Count after DeleteOne = %d, want 0", n)
+	}
+}
@@ -0,0 +1,127 @@
+package persistence_store
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// sqlExecutor is the part of *sql.DB and *sql.Tx used by the store.
+type sqlExecutor interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+	QueryContext(ctx context.Context, query string, args ...interface{}) (*sql.Rows, error)
+}
+
+// sqlTx holds the SQLite transactions of one BeginTransaction call. Each
+// database file has its own transaction, started the first time the
+// transaction touches it.
+type sqlTx struct {
+	mu   sync.Mutex
+	txs  map[string]*sql.Tx
+	done bool
+}
+
+func (t *sqlTx) executor(db *sql.DB, database string) (sqlExecutor, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return nil, errors.New("the transaction is already closed")
+	}
+	if tx, ok := t.txs[database]; ok {
+		return tx, nil
+	}
+	tx, err := db.BeginTx(context.Background(), &sql.TxOptions{Isolation: sql.LevelSerializable})
+	if err != nil {
+		return nil, err
+	}
+	t.txs[database] = tx
+	return tx, nil
+}
+
+// finish commits or rolls back every database transaction. A commit stops
+// at the first failure and rolls back the rest.
+func (t *sqlTx) finish(commit bool) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return errors.New("the transaction is already closed")
+	}
+	t.done = true
+
+	var firstErr error
+	for database, tx := range t.txs {
+		if commit && firstErr == nil {
+			if err := tx.Commit(); err != nil {
+				firstErr = fmt.Errorf("commit %s: %w", database, err)
+			}
+			continue
+		}
+		if err := tx.Rollback(); err != nil && firstErr == nil && !commit {
+			firstErr = fmt.Errorf("rollback %s: %w", database, err)
+		}
+	}
+	return firstErr
+}
+
+// sqlTransaction is a SqlStore bound to a transaction.
+type sqlTransaction struct {
+	*SqlStore
+}
+
+var (
+	_ Transactional = (*SqlStore)(nil)
+	_ Transaction   = (*sqlTransaction)(nil)
+)
+
+// BeginTransaction starts a transaction on a connection. Reads made through
+// the returned store see its own uncommitted writes.
+//
+// SQLite locks a database file for writing until the transaction ends, so
+// writes made outside of it wait (or time out) meanwhile. A commit spanning
+// several database files is not atomic across them.
+func (store *SqlStore) BeginTransaction(ctx context.Context, connectionId string) (Transaction, error) {
+	if store.tx != nil {
+		return nil, errors.New("nested transactions are not supported")
+	}
+	unlock := store.lock()
+	conn, exists := store.connections[connectionId]
+	if !exists {
+		unlock()
+		return nil, fmt.Errorf("connection with ID %s does not exist", connectionId)
+	}
+	if conn.databases == nil {
+		// Share the handle map so databases opened by the transaction are
+		// closed by Disconnect.
+		conn.databases = make(map[string]*sql.DB)
+		store.connections[connectionId] = conn
+	}
+	unlock()
+
+	return &sqlTransaction{SqlStore: &SqlStore{
+		connections: map[string]SqlConnection{connectionId: conn},
+		tx:          &sqlTx{txs: make(map[string]*sql.Tx)},
+		base:        store,
+	}}, nil
+}
+
+// Commit makes the writes of the transaction durable.
+func (t *sqlTransaction) Commit(ctx context.Context) error {
+	return t.tx.finish(true)
+}
+
+// Rollback discards the writes of the transaction.
+func (t *sqlTransaction) Rollback(ctx context.Context) error {
+	return t.tx.finish(false)
+}
+
+// Connect is not available inside a transaction.
+func (t *sqlTransaction) Connect(id string, host string, port int32, user string, password string, database string, timeout int32, options_str string) error {
+	return errors.New("cannot connect inside a transaction")
+}
+
+// Disconnect is not available inside a transaction.
+func (t *sqlTransaction) Disconnect(connectionId string) error {
+	return errors.New("cannot disconnect inside a transaction")
+}
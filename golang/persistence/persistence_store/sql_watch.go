@@ -0,0 +1,133 @@
+package persistence_store
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	Utility "github.com/globulario/utility"
+)
+
+// The outbox lives in each database file. seq is the resume token; it only
+// grows, and AUTOINCREMENT never reuses the seq of a pruned row.
+const sqlOutboxSchema = `CREATE TABLE IF NOT EXISTS __outbox (
+	seq INTEGER PRIMARY KEY AUTOINCREMENT,
+	collection TEXT NOT NULL,
+	operation TEXT NOT NULL,
+	doc_id TEXT,
+	document TEXT,
+	time INTEGER NOT NULL
+)`
+
+var _ Watcher = (*SqlStore)(nil)
+
+// recordChange appends a write to the outbox of the database. It goes
+// through ExecContext, so inside a transaction the row is committed or
+// rolled back along with the write.
+func (store *SqlStore) recordChange(connectionId string, db string, table string, operation string, doc map[string]interface{}) error {
+	if isInternalCollection(table) {
+		return nil
+	}
+	document, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	if _, err := store.ExecContext(connectionId, db, sqlOutboxSchema, nil, 0); err != nil {
+		return err
+	}
+
+	now := time.Now()
+	res, err := store.ExecContext(connectionId, db,
+		"INSERT INTO __outbox (collection, operation, doc_id, document, time) VALUES (?, ?, ?, ?, ?)",
+		[]interface{}{table, operation, Utility.ToString(doc["_id"]), string(document), now.UnixMilli()}, 0)
+	if err != nil {
+		return err
+	}
+
+	// Prune expired rows once in a while rather than on every write.
+	var result struct {
+		LastId int64 `json:"lastId"`
+	}
+	if json.Unmarshal([]byte(res), &result) == nil && result.LastId%1000 == 0 {
+		_, _ = store.ExecContext(connectionId, db, "DELETE FROM __outbox WHERE time < ?",
+			[]interface{}{now.Add(-outboxRetention).UnixMilli()}, 0)
+	}
+	return nil
+}
+
+// Watch polls the outbox of the database for the changes of a table. The
+// resume token is the outbox sequence number of the last event received.
+func (store *SqlStore) Watch(ctx context.Context, connectionId string, database string, collection string, filter string, resumeToken string, fn func(*ChangeEvent) error) error {
+	collection = sanitizeIdentifier(collection)
+	match, err := changeFilter(filter)
+	if err != nil {
+		return err
+	}
+	if _, err := store.ExecContext(connectionId, database, sqlOutboxSchema, nil, 0); err != nil {
+		return err
+	}
+	db, err := store.database(connectionId, database)
+	if err != nil {
+		return err
+	}
+
+	var cursor int64
+	if resumeToken != "" {
+		cursor, err = strconv.ParseInt(resumeToken, 10, 64)
+		if err != nil {
+			return fmt.Errorf("invalid resume token %q", resumeToken)
+		}
+		var oldest sql.NullInt64
+		if err := db.QueryRowContext(ctx, "SELECT MIN(seq) FROM __outbox").Scan(&oldest); err != nil {
+			return err
+		}
+		if oldest.Valid && cursor+1 < oldest.Int64 {
+			return ErrResumeTokenExpired
+		}
+	} else if err := db.QueryRowContext(ctx, "SELECT COALESCE(MAX(seq), 0) FROM __outbox").Scan(&cursor); err != nil {
+		return err
+	}
+
+	next := func() ([]*ChangeEvent, error) {
+		rows, err := db.QueryContext(ctx,
+			"SELECT seq, operation, doc_id, document, time FROM __outbox WHERE collection = ? AND seq > ? ORDER BY seq LIMIT ?",
+			collection, cursor, outboxBatch)
+		if err != nil {
+			if ctx.Err() != nil {
+				return nil, nil
+			}
+			return nil, err
+		}
+		defer rows.Close()
+
+		events := make([]*ChangeEvent, 0)
+		for rows.Next() {
+			var seq, ms int64
+			var operation string
+			var id, document sql.NullString
+			if err := rows.Scan(&seq, &operation, &id, &document, &ms); err != nil {
+				return nil, err
+			}
+			cursor = seq
+			evt := &ChangeEvent{
+				Token:      strconv.FormatInt(seq, 10),
+				Operation:  operation,
+				Collection: collection,
+				Id:         id.String,
+				Time:       time.UnixMilli(ms),
+			}
+			if document.Valid {
+				_ = json.Unmarshal([]byte(document.String), &evt.Document)
+			}
+			events = append(events, evt)
+		}
+		if err := rows.Err(); err != nil && ctx.Err() == nil {
+			return nil, err
+		}
+		return events, nil
+	}
+	return pollChanges(ctx, next, match, fn)
+}
@@ -2,8 +2,18 @@ package persistence_store
 
 import (
 	"context"
+	"errors"
+	"time"
 )
 
+// ErrTransactionsNotSupported is returned by BeginTransaction when the
+// backend (or its deployment) cannot group writes atomically.
+var ErrTransactionsNotSupported = errors.New("transactions are not supported by this store")
+
+// ErrResumeTokenExpired is returned by Watch when the changes following the
+// resume token are no longer retained.
+var ErrResumeTokenExpired = errors.New("the resume token has expired")
+
 /**
  * Represent a data store interface.
  */
@@ -113,3 +123,103 @@ type Store interface {
 	 */
 	RunAdminCmd(ctx context.Context, connectionId string, user string, password string, script string) error
 }
+
+/**
+ * Implemented by the stores able to group writes in a transaction.
+ */
+type Transactional interface {
+
+	/**
+	 * Start a transaction on a connection. Every call made through the
+	 * returned store belongs to it until Commit or Rollback.
+	 */
+	BeginTransaction(ctx context.Context, connectionId string) (Transaction, error)
+}
+
+/**
+ * A store bound to an open transaction.
+ */
+type Transaction interface {
+	Store
+
+	/**
+	 * Apply the writes made in the transaction.
+	 */
+	Commit(ctx context.Context) error
+
+	/**
+	 * Discard the writes made in the transaction.
+	 */
+	Rollback(ctx context.Context) error
+}
+
+/**
+ * A change made to a document of a watched collection.
+ */
+type ChangeEvent struct {
+	Token      string                 // resume token; pass it to Watch to continue after this event
+	Operation  string                 // "insert", "update", "replace" or "delete"
+	Collection string                 // collection the document belongs to
+	Id         string                 // _id of the document
+	Document   map[string]interface{} // document after the change, before it for a delete
+	Time       time.Time              // when the change was made
+}
+
+/**
+ * Implemented by the stores able to stream the changes of a collection.
+ */
+type Watcher interface {
+
+	/**
+	 * Call fn for every change of a collection whose document matches filter
+	 * (a Mongo filter, empty for all). Changes are streamed from resumeToken,
+	 * or from now when it is empty. Watch returns when ctx is done or when fn
+	 * returns an error.
+	 */
+	Watch(ctx context.Context, connectionId string, database string, collection string, filter string, resumeToken string, fn func(*ChangeEvent) error) error
+}
+
+/**
+ * A schema migration script. Scripts are applied in Version order, once per
+ * database; the checksum detects a script edited after it was applied.
+ */
+type Migration struct {
+	Version  int64  // position in the sequence, unique per dialect
+	Name     string // short description
+	Dialect  string // "sql", "cql" or "mongo"; see Migrator.MigrationDialect
+	Script   string // statements in the store dialect
+	Checksum string // hex SHA-256 of Script
+}
+
+/**
+ * A ledger entry: a migration applied to a database.
+ */
+type AppliedMigration struct {
+	Version   int64
+	Name      string
+	Checksum  string
+	AppliedAt time.Time
+	Duration  time.Duration
+}
+
+/**
+ * Implemented by the stores able to run schema migrations. Each store keeps
+ * the ledger of the migrations applied to a database in that database.
+ */
+type Migrator interface {
+
+	/**
+	 * The dialect of the migration scripts the store runs.
+	 */
+	MigrationDialect() string
+
+	/**
+	 * Return the ledger of a database, in version order.
+	 */
+	AppliedMigrations(ctx context.Context, connectionId string, database string) ([]AppliedMigration, error)
+
+	/**
+	 * Run a migration script on a database and record it in the ledger.
+	 */
+	ApplyMigration(ctx context.Context, connectionId string, database string, migration *Migration) (*AppliedMigration, error)
+}
@@ -0,0 +1,108 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+func TestSqlStoreTransaction(t *testing.T) {
+	store := newTestSqlStore(t)
+	seedAccounts(t, store)
+	ctx := context.Background()
+
+	// Rollback discards every write, side tables included.
+	tx, err := store.BeginTransaction(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.InsertOne(ctx, "test", "test_db", "accounts", map[string]interface{}{"_id": "a4", "name": "dave", "tags": []interface{}{"qa"}}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.DeleteOne(ctx, "test", "test_db", "accounts", `{"_id":"a1"}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	// Reads inside the transaction see its own writes.
+	if n, _ := tx.Count(ctx, "test", "test_db", "accounts", `{}`, ""); n != 3 {
+		t.Errorf("Count inside the transaction = %d, want 3", n)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := findIds(t, store, `{}`, ""); len(got) != 3 {
+		t.Errorf("after Rollback: %v, want the three seeded accounts", got)
+	}
+	if got := findIds(t, store, `{"tags":"qa"}`, ""); len(got) != 0 {
+		t.Errorf("Rollback left array rows behind: %v", got)
+	}
+
+	// Commit applies them.
+	tx, err = store.BeginTransaction(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.InsertOne(ctx, "test", "test_db", "accounts", map[string]interface{}{"_id": "a4", "name": "dave"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.UpdateOne(ctx, "test", "test_db", "accounts", `{"_id":"a2"}`, `{"$set":{"name":"robert"}}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if got := findIds(t, store, `{"name":{"$in":["dave","robert"]}}`, ""); len(got) != 2 {
+		t.Errorf("after Commit: %v, want [a2 a4]", got)
+	}
+
+	if err := tx.Commit(ctx); err == nil {
+		t.Error("expected an error when committing twice")
+	}
+	if _, err := tx.Find(ctx, "test", "test_db", "accounts", `{}`, ""); err == nil {
+		t.Error("expected an error when using a closed transaction")
+	}
+}
+
+// Transactions begun while the store opens databases share its connection
+// map; run with -race.
+func TestSqlStoreConcurrentTransactions(t *testing.T) {
+	store := newTestSqlStore(t)
+	ctx := context.Background()
+	var wg sync.WaitGroup
+	for i := range 8 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			tx, err := store.BeginTransaction(ctx, "test")
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			_, _ = tx.Count(ctx, "test", fmt.Sprintf("tx_db_%d", i), "accounts", `{}`, "")
+			_ = tx.Rollback(ctx)
+		}()
+		go func() {
+			defer wg.Done()
+			_, _ = store.Count(ctx, "test", fmt.Sprintf("db_%d", i), "accounts", `{}`, "")
+		}()
+	}
+	wg.Wait()
+}
+
+func TestWithTimestamp(t *testing.T) {
+	for stmt, want := range map[string]string{
+		"INSERT INTO ks.t (id, v) VALUES (?, ?);": "INSERT INTO ks.t (id, v) VALUES (?, ?) USING TIMESTAMP 7",
+		"UPDATE t SET a = ? WHERE id = ?":         "UPDATE t USING TIMESTAMP 7 SET a = ? WHERE id = ?",
+		"DELETE FROM ks.t_tags WHERE t_id = ?":    "DELETE FROM ks.t_tags USING TIMESTAMP 7 WHERE t_id = ?",
+		"delete from ks.t where source_id = ?":    "DELETE FROM ks.t USING TIMESTAMP 7 WHERE source_id = ?",
+	} {
+		got, err := withTimestamp(stmt, 7)
+		if err != nil || got != want {
+			t.Errorf("withTimestamp(%q) = %q, %v; want %q", stmt, got, err, want)
+		}
+	}
+	if _, err := withTimestamp("TRUNCATE ks.t", 7); !errors.Is(err, ErrTransactionsNotSupported) {
+		t.Errorf("expected ErrTransactionsNotSupported, got %v", err)
+	}
+}
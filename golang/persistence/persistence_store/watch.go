@@ -0,0 +1,84 @@
+package persistence_store
+
+import (
+	"context"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// ---------- Change streams ----------
+//
+// Mongo streams changes with native change streams. The SQL and Scylla stores
+// have no equivalent: their writes also append to an outbox table, which
+// Watch polls from the position encoded in the resume token.
+
+// watchPollInterval is how often an outbox is polled when it has nothing new.
+var watchPollInterval = 500 * time.Millisecond
+
+// outboxBatch is the number of outbox rows read per poll.
+const outboxBatch = 500
+
+// outboxRetention is how long outbox rows are kept; older resume tokens fail
+// with ErrResumeTokenExpired.
+const outboxRetention = 7 * 24 * time.Hour
+
+// isInternalCollection reports whether a collection is store bookkeeping
+// whose writes are not recorded in the outbox.
+func isInternalCollection(collection string) bool {
+	return strings.HasPrefix(collection, "__") || collection == scyllaOutboxTable
+}
+
+// changeFilter compiles the filter of a Watch call.
+func changeFilter(filter string) (func(*ChangeEvent) bool, error) {
+	filter = strings.TrimSpace(filter)
+	if filter == "" || filter == "{}" {
+		return func(*ChangeEvent) bool { return true }, nil
+	}
+	f := make(map[string]interface{})
+	if err := json.Unmarshal([]byte(filter), &f); err != nil {
+		return nil, err
+	}
+	// Reject unsupported operators up front rather than on the first event.
+	if _, err := matchDocument(map[string]interface{}{}, f); err != nil {
+		return nil, err
+	}
+	return func(evt *ChangeEvent) bool {
+		doc := evt.Document
+		if doc == nil {
+			doc = map[string]interface{}{"_id": evt.Id}
+		}
+		ok, _ := matchDocument(doc, f)
+		return ok
+	}, nil
+}
+
+// pollChanges feeds fn with the events returned by next, waiting
+// watchPollInterval whenever next has nothing new, until ctx is done or fn
+// fails. next is expected to advance its own cursor.
+func pollChanges(ctx context.Context, next func() ([]*ChangeEvent, error), match func(*ChangeEvent) bool, fn func(*ChangeEvent) error) error {
+	ticker := time.NewTicker(watchPollInterval)
+	defer ticker.Stop()
+	for {
+		events, err := next()
+		if err != nil {
+			return err
+		}
+		for _, evt := range events {
+			if !match(evt) {
+				continue
+			}
+			if err := fn(evt); err != nil {
+				return err
+			}
+		}
+		if len(events) == outboxBatch && ctx.Err() == nil {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
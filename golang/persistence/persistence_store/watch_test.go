@@ -0,0 +1,108 @@
+package persistence_store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+// watchAll collects the events of a Watch call until it has been quiet for a
+// few poll intervals.
+func watchAll(t *testing.T, store *SqlStore, filter, token string) []*ChangeEvent {
+	t.Helper()
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+	events := make([]*ChangeEvent, 0)
+	err := store.Watch(ctx, "test", "test_db", "accounts", filter, token, func(evt *ChangeEvent) error {
+		events = append(events, evt)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Watch(%s, %s): %v", filter, token, err)
+	}
+	return events
+}
+
+func TestSqlStoreWatch(t *testing.T) {
+	interval := watchPollInterval
+	watchPollInterval = 10 * time.Millisecond
+	t.Cleanup(func() { watchPollInterval = interval })
+
+	store := newTestSqlStore(t)
+	seedAccounts(t, store)
+	ctx := context.Background()
+
+	// A token of 0 replays the outbox from its start; other collections are
+	// left out.
+	events := watchAll(t, store, "", "0")
+	if len(events) != 3 {
+		t.Fatalf("got %d events, want the three seeded accounts", len(events))
+	}
+	for _, evt := range events {
+		if evt.Operation != "insert" || evt.Collection != "accounts" || evt.Document["_id"] != evt.Id {
+			t.Errorf("unexpected event %+v", evt)
+		}
+	}
+	token := events[2].Token
+
+	if err := store.UpdateOne(ctx, "test", "test_db", "accounts", `{"_id":"a2"}`, `{"$set":{"age":26}}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.DeleteOne(ctx, "test", "test_db", "accounts", `{"_id":"a3"}`, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.ReplaceOne(ctx, "test", "test_db", "accounts", `{"_id":"a1"}`, `{"_id":"a1","name":"alice","age":32}`, ""); err != nil {
+		t.Fatal(err)
+	}
+
+	// Writes of a rolled back transaction never reach the outbox.
+	tx, err := store.BeginTransaction(ctx, "test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tx.InsertOne(ctx, "test", "test_db", "accounts", map[string]interface{}{"_id": "a9", "name": "zed"}, ""); err != nil {
+		t.Fatal(err)
+	}
+	if err := tx.Rollback(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	events = watchAll(t, store, "", token)
+	want := []struct{ op, id string }{{"update", "a2"}, {"delete", "a3"}, {"replace", "a1"}}
+	if len(events) != len(want) {
+		t.Fatalf("resumed with %d events, want %d", len(events), len(want))
+	}
+	for i, w := range want {
+		if events[i].Operation != w.op || events[i].Id != w.id {
+			t.Errorf("event %d = %s %s, want %s %s", i, events[i].Operation, events[i].Id, w.op, w.id)
+		}
+	}
+	if age, _ := toFloat(events[0].Document["age"]); age != 26 {
+		t.Errorf("update event document = %v, want the updated account", events[0].Document)
+	}
+	if events[1].Document["name"] != "carol" {
+		t.Errorf("delete event document = %v, want the deleted account", events[1].Document)
+	}
+
+	// The filter applies to the document of the event.
+	events = watchAll(t, store, `{"name":{"$in":["bob","carol"]}}`, token)
+	if len(events) != 2 || events[0].Id != "a2" || events[1].Id != "a3" {
+		t.Errorf("filtered events = %+v, want a2 and a3", events)
+	}
+
+	// Without a token only new changes are streamed.
+	if events := watchAll(t, store, "", ""); len(events) != 0 {
+		t.Errorf("got %d events without a token, want none", len(events))
+	}
+
+	if err := store.Watch(ctx, "test", "test_db", "accounts", "", "not-a-token", func(*ChangeEvent) error { return nil }); err == nil {
+		t.Error("expected an error for an invalid resume token")
+	}
+
+	// Watch stops with the error of its callback.
+	stop := errors.New("stop")
+	if err := store.Watch(ctx, "test", "test_db", "accounts", "", "0", func(*ChangeEvent) error { return stop }); !errors.Is(err, stop) {
+		t.Errorf("Watch returned %v, want the callback error", err)
+	}
+}
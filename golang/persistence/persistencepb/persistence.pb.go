@@ -463,11 +463,12 @@ func (x *PingConnectionRsp) GetResult() string {
 // Request to insert multiple documents/records.
 type InsertManyRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // The connection id
-	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`     // Database name
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"` // Collection or table name
-	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`             // Data to be inserted
-	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`       // Additional options for insertion
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // The connection id
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`                                // Database name
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                            // Collection or table name
+	Data          []byte                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                        // Data to be inserted
+	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`                                  // Additional options for insertion
+	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -537,6 +538,13 @@ func (x *InsertManyRqst) GetOptions() string {
 	return ""
 }
 
+func (x *InsertManyRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for an insert many request.
 type InsertManyRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -577,11 +585,12 @@ func (*InsertManyRsp) Descriptor() ([]byte, []int) {
 // Request to insert a single document/record.
 type InsertOneRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // The connection id
-	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`     // Database name
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"` // Collection or table name
-	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`             // Data to be inserted
-	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`       // Additional options for insertion
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // The connection id
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`                                // Database name
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                            // Collection or table name
+	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`                                        // Data to be inserted
+	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`                                  // Additional options for insertion
+	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -651,6 +660,13 @@ func (x *InsertOneRqst) GetOptions() string {
 	return ""
 }
 
+func (x *InsertOneRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for an insert one request.
 type InsertOneRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -699,11 +715,12 @@ func (x *InsertOneRsp) GetId() string {
 // Request to find multiple documents/records based on a query.
 type FindRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // The connection id
-	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`     // Database name
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"` // Collection or table name
-	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`           // Query string in JSON format
-	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`       // Additional options for the find operation
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // The connection id
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`                                // Database name
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                            // Collection or table name
+	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`                                      // Query string in JSON format
+	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`                                  // Additional options for the find operation
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -773,6 +790,13 @@ func (x *FindRqst) GetOptions() string {
 	return ""
 }
 
+func (x *FindRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a find request.
 type FindResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -826,6 +850,7 @@ type FindOneRqst struct {
 	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
 	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -895,6 +920,13 @@ func (x *FindOneRqst) GetOptions() string {
 	return ""
 }
 
+func (x *FindOneRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a find one request.
 type FindOneResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -948,6 +980,7 @@ type AggregateRqst struct {
 	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
 	Pipeline      string                 `protobuf:"bytes,4,opt,name=pipeline,proto3" json:"pipeline,omitempty"` // JSON string representing the aggregation pipeline
 	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1017,6 +1050,13 @@ func (x *AggregateRqst) GetOptions() string {
 	return ""
 }
 
+func (x *AggregateRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for an aggregate request.
 type AggregateResp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1071,6 +1111,7 @@ type UpdateRqst struct {
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"` // Query to select documents
 	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"` // Update values
 	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1147,6 +1188,13 @@ func (x *UpdateRqst) GetOptions() string {
 	return ""
 }
 
+func (x *UpdateRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for an update request.
 type UpdateRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1201,6 +1249,7 @@ type UpdateOneRqst struct {
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
 	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"`
 	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1277,6 +1326,13 @@ func (x *UpdateOneRqst) GetOptions() string {
 	return ""
 }
 
+func (x *UpdateOneRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for an update one request.
 type UpdateOneRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1331,6 +1387,7 @@ type ReplaceOneRqst struct {
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"` // Query to select the document
 	Value         string                 `protobuf:"bytes,5,opt,name=value,proto3" json:"value,omitempty"` // New value for the document
 	Options       string                 `protobuf:"bytes,6,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,7,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1407,6 +1464,13 @@ func (x *ReplaceOneRqst) GetOptions() string {
 	return ""
 }
 
+func (x *ReplaceOneRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a replace one request.
 type ReplaceOneRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1460,6 +1524,7 @@ type DeleteRqst struct {
 	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"` // Query to select documents for deletion
 	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1529,6 +1594,13 @@ func (x *DeleteRqst) GetOptions() string {
 	return ""
 }
 
+func (x *DeleteRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a delete request.
 type DeleteRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1582,6 +1654,7 @@ type DeleteOneRqst struct {
 	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`
 	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`
 	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`
+	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -1651,6 +1724,13 @@ func (x *DeleteOneRqst) GetOptions() string {
 	return ""
 }
 
+func (x *DeleteOneRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a delete one request.
 type DeleteOneRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2115,11 +2195,12 @@ func (x *DeleteCollectionRsp) GetResult() bool {
 // Request to count documents in a collection.
 type CountRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                 // Connection ID
-	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`     // Database name
-	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"` // Collection name
-	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`           // Filter query for counting
-	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`       // Additional options for the count operation
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                            // Connection ID
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`                                // Database name
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                            // Collection name
+	Query         string                 `protobuf:"bytes,4,opt,name=query,proto3" json:"query,omitempty"`                                      // Filter query for counting
+	Options       string                 `protobuf:"bytes,5,opt,name=options,proto3" json:"options,omitempty"`                                  // Additional options for the count operation
+	TransactionId string                 `protobuf:"bytes,6,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Open transaction to run in (see BeginTransaction)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -2189,6 +2270,13 @@ func (x *CountRqst) GetOptions() string {
 	return ""
 }
 
+func (x *CountRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
 // Response for a count request.
 type CountRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -2536,27 +2624,29 @@ func (x *DisconnectRsp) GetResult() bool {
 	return false
 }
 
-// Request to stop a service or process.
-type StopRequest struct {
+// Request to start a transaction on a connection.
+type BeginTransactionRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`            // Connection ID
+	Timeout       int32                  `protobuf:"varint,2,opt,name=timeout,proto3" json:"timeout,omitempty"` // Seconds of inactivity before the server rolls it back (0 = default)
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopRequest) Reset() {
-	*x = StopRequest{}
+func (x *BeginTransactionRqst) Reset() {
+	*x = BeginTransactionRqst{}
 	mi := &file_persistence_proto_msgTypes[43]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopRequest) String() string {
+func (x *BeginTransactionRqst) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopRequest) ProtoMessage() {}
+func (*BeginTransactionRqst) ProtoMessage() {}
 
-func (x *StopRequest) ProtoReflect() protoreflect.Message {
+func (x *BeginTransactionRqst) ProtoReflect() protoreflect.Message {
 	mi := &file_persistence_proto_msgTypes[43]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2568,32 +2658,47 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
-func (*StopRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use BeginTransactionRqst.ProtoReflect.Descriptor instead.
+func (*BeginTransactionRqst) Descriptor() ([]byte, []int) {
 	return file_persistence_proto_rawDescGZIP(), []int{43}
 }
 
-// Response for a stop request.
-type StopResponse struct {
+func (x *BeginTransactionRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BeginTransactionRqst) GetTimeout() int32 {
+	if x != nil {
+		return x.Timeout
+	}
+	return 0
+}
+
+// Response for a begin transaction request.
+type BeginTransactionRsp struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	TransactionId string                 `protobuf:"bytes,1,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"` // Pass it to the CRUD requests, then to Commit or Rollback
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopResponse) Reset() {
-	*x = StopResponse{}
+func (x *BeginTransactionRsp) Reset() {
+	*x = BeginTransactionRsp{}
 	mi := &file_persistence_proto_msgTypes[44]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopResponse) String() string {
+func (x *BeginTransactionRsp) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopResponse) ProtoMessage() {}
+func (*BeginTransactionRsp) ProtoMessage() {}
 
-func (x *StopResponse) ProtoReflect() protoreflect.Message {
+func (x *BeginTransactionRsp) ProtoReflect() protoreflect.Message {
 	mi := &file_persistence_proto_msgTypes[44]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -2605,194 +2710,1115 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
-func (*StopResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use BeginTransactionRsp.ProtoReflect.Descriptor instead.
+func (*BeginTransactionRsp) Descriptor() ([]byte, []int) {
 	return file_persistence_proto_rawDescGZIP(), []int{44}
 }
 
-var File_persistence_proto protoreflect.FileDescriptor
+func (x *BeginTransactionRsp) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
 
-const file_persistence_proto_rawDesc = "" +
-	"\n" +
-	"\x11persistence.proto\x12\vpersistence\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13globular_auth.proto\"\xea\x01\n" +
-	"\n" +
-	"Connection\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
-	"\x04host\x18\x03 \x01(\tR\x04host\x12,\n" +
-	"\x05store\x18\x05 \x01(\x0e2\x16.persistence.StoreTypeR\x05store\x12\x12\n" +
-	"\x04user\x18\x06 \x01(\tR\x04user\x12\x1a\n" +
-	"\bpassword\x18\a \x01(\tR\bpassword\x12\x12\n" +
-	"\x04port\x18\b \x01(\x05R\x04port\x12\x18\n" +
-	"\atimeout\x18\t \x01(\x05R\atimeout\x12\x18\n" +
-	"\aoptions\x18\n" +
-	" \x01(\tR\aoptions\"c\n" +
-	"\x14CreateConnectionRqst\x127\n" +
-	"\n" +
-	"connection\x18\x01 \x01(\v2\x17.persistence.ConnectionR\n" +
-	"connection\x12\x12\n" +
-	"\x04save\x18\x02 \x01(\bR\x04save\"-\n" +
-	"\x13CreateConnectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\":\n" +
-	"\x14DeleteConnectionRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\"-\n" +
-	"\x13DeleteConnectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"8\n" +
-	"\x12PingConnectionRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\"+\n" +
-	"\x11PingConnectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\tR\x06result\"\x9e\x01\n" +
-	"\x0eInsertManyRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x12\n" +
-	"\x04data\x18\x04 \x01(\fR\x04data\x12\x18\n" +
-	"\aoptions\x18\x05 \x01(\tR\aoptions\"\x0f\n" +
-	"\rInsertManyRsp\"\x9d\x01\n" +
-	"\rInsertOneRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x12\n" +
-	"\x04data\x18\x04 \x01(\tR\x04data\x12\x18\n" +
-	"\aoptions\x18\x05 \x01(\tR\aoptions\"\x1e\n" +
-	"\fInsertOneRsp\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\"\x9a\x01\n" +
-	"\bFindRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\"\x1e\n" +
-	"\bFindResp\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\x9d\x01\n" +
-	"\vFindOneRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\">\n" +
-	"\vFindOneResp\x12/\n" +
-	"\x06result\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06result\"\xa5\x01\n" +
-	"\rAggregateRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x1a\n" +
-	"\bpipeline\x18\x04 \x01(\tR\bpipeline\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\"#\n" +
-	"\rAggregateResp\x12\x12\n" +
-	"\x04data\x18\x01 \x01(\fR\x04data\"\xb2\x01\n" +
-	"\n" +
-	"UpdateRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
-	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\"#\n" +
-	"\tUpdateRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\xb5\x01\n" +
-	"\rUpdateOneRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
-	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\"&\n" +
-	"\fUpdateOneRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\xb6\x01\n" +
-	"\x0eReplaceOneRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
-	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
-	"\aoptions\x18\x06 \x01(\tR\aoptions\"'\n" +
-	"\rReplaceOneRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\x9c\x01\n" +
-	"\n" +
-	"DeleteRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
-	"\aoptions\x18\x05 \x01(\tR\aoptions\"#\n" +
-	"\tDeleteRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\x9f\x01\n" +
-	"\rDeleteOneRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x14\n" +
-	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
-	"\aoptions\x18\x05 \x01(\tR\aoptions\"&\n" +
-	"\fDeleteOneRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"T\n" +
-	"\x12CreateDatabaseRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"+\n" +
-	"\x11CreateDatabaseRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"T\n" +
-	"\x12DeleteDatabaseRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"+\n" +
-	"\x11DeleteDatabaseRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\x96\x01\n" +
-	"\x14CreateCollectionRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\x12\x1a\n" +
-	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
-	"\n" +
-	"collection\x18\x03 \x01(\tR\n" +
-	"collection\x12\x1e\n" +
-	"\n" +
+// Request to commit a transaction.
+type CommitRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitRqst) Reset() {
+	*x = CommitRqst{}
+	mi := &file_persistence_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitRqst) ProtoMessage() {}
+
+func (x *CommitRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitRqst.ProtoReflect.Descriptor instead.
+func (*CommitRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *CommitRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CommitRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// Response for a commit request.
+type CommitRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CommitRsp) Reset() {
+	*x = CommitRsp{}
+	mi := &file_persistence_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CommitRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CommitRsp) ProtoMessage() {}
+
+func (x *CommitRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CommitRsp.ProtoReflect.Descriptor instead.
+func (*CommitRsp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *CommitRsp) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+// Request to roll back a transaction.
+type RollbackRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID
+	TransactionId string                 `protobuf:"bytes,2,opt,name=transaction_id,json=transactionId,proto3" json:"transaction_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackRqst) Reset() {
+	*x = RollbackRqst{}
+	mi := &file_persistence_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRqst) ProtoMessage() {}
+
+func (x *RollbackRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRqst.ProtoReflect.Descriptor instead.
+func (*RollbackRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{47}
+}
+
+func (x *RollbackRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RollbackRqst) GetTransactionId() string {
+	if x != nil {
+		return x.TransactionId
+	}
+	return ""
+}
+
+// Response for a rollback request.
+type RollbackRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RollbackRsp) Reset() {
+	*x = RollbackRsp{}
+	mi := &file_persistence_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RollbackRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RollbackRsp) ProtoMessage() {}
+
+func (x *RollbackRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RollbackRsp.ProtoReflect.Descriptor instead.
+func (*RollbackRsp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{48}
+}
+
+func (x *RollbackRsp) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+// Request to watch the changes of a collection.
+type WatchRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                      // Connection ID
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`                          // Database name
+	Collection    string                 `protobuf:"bytes,3,opt,name=collection,proto3" json:"collection,omitempty"`                      // Collection or table name
+	Filter        string                 `protobuf:"bytes,4,opt,name=filter,proto3" json:"filter,omitempty"`                              // Mongo filter (JSON) the changed document must match; empty for all
+	ResumeToken   string                 `protobuf:"bytes,5,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // Token of the last event received; empty to start from now
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchRqst) Reset() {
+	*x = WatchRqst{}
+	mi := &file_persistence_proto_msgTypes[49]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchRqst) ProtoMessage() {}
+
+func (x *WatchRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[49]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchRqst.ProtoReflect.Descriptor instead.
+func (*WatchRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{49}
+}
+
+func (x *WatchRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *WatchRqst) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *WatchRqst) GetCollection() string {
+	if x != nil {
+		return x.Collection
+	}
+	return ""
+}
+
+func (x *WatchRqst) GetFilter() string {
+	if x != nil {
+		return x.Filter
+	}
+	return ""
+}
+
+func (x *WatchRqst) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+// A change made to a watched collection.
+type WatchResp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	ResumeToken   string                 `protobuf:"bytes,1,opt,name=resume_token,json=resumeToken,proto3" json:"resume_token,omitempty"` // Pass it to Watch to continue after this event
+	Operation     string                 `protobuf:"bytes,2,opt,name=operation,proto3" json:"operation,omitempty"`                        // insert, update, replace or delete
+	DocumentId    string                 `protobuf:"bytes,3,opt,name=document_id,json=documentId,proto3" json:"document_id,omitempty"`    // _id of the changed document
+	Document      string                 `protobuf:"bytes,4,opt,name=document,proto3" json:"document,omitempty"`                          // Document after the change (before it for a delete), JSON
+	Time          int64                  `protobuf:"varint,5,opt,name=time,proto3" json:"time,omitempty"`                                 // Unix time of the change, in milliseconds
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchResp) Reset() {
+	*x = WatchResp{}
+	mi := &file_persistence_proto_msgTypes[50]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchResp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchResp) ProtoMessage() {}
+
+func (x *WatchResp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[50]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchResp.ProtoReflect.Descriptor instead.
+func (*WatchResp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{50}
+}
+
+func (x *WatchResp) GetResumeToken() string {
+	if x != nil {
+		return x.ResumeToken
+	}
+	return ""
+}
+
+func (x *WatchResp) GetOperation() string {
+	if x != nil {
+		return x.Operation
+	}
+	return ""
+}
+
+func (x *WatchResp) GetDocumentId() string {
+	if x != nil {
+		return x.DocumentId
+	}
+	return ""
+}
+
+func (x *WatchResp) GetDocument() string {
+	if x != nil {
+		return x.Document
+	}
+	return ""
+}
+
+func (x *WatchResp) GetTime() int64 {
+	if x != nil {
+		return x.Time
+	}
+	return 0
+}
+
+// A schema migration script.
+type Migration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`  // Position in the sequence, unique per dialect
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`         // Short description
+	Dialect       string                 `protobuf:"bytes,3,opt,name=dialect,proto3" json:"dialect,omitempty"`   // sql, cql or mongo; scripts of another dialect than the store are ignored
+	Script        string                 `protobuf:"bytes,4,opt,name=script,proto3" json:"script,omitempty"`     // Statements in the store dialect
+	Checksum      string                 `protobuf:"bytes,5,opt,name=checksum,proto3" json:"checksum,omitempty"` // Hex SHA-256 of script; verified when set
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Migration) Reset() {
+	*x = Migration{}
+	mi := &file_persistence_proto_msgTypes[51]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Migration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Migration) ProtoMessage() {}
+
+func (x *Migration) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[51]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Migration.ProtoReflect.Descriptor instead.
+func (*Migration) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{51}
+}
+
+func (x *Migration) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *Migration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *Migration) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *Migration) GetScript() string {
+	if x != nil {
+		return x.Script
+	}
+	return ""
+}
+
+func (x *Migration) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+// A migration recorded in the ledger of a database.
+type AppliedMigration struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Version       int64                  `protobuf:"varint,1,opt,name=version,proto3" json:"version,omitempty"`
+	Name          string                 `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	Checksum      string                 `protobuf:"bytes,3,opt,name=checksum,proto3" json:"checksum,omitempty"`
+	AppliedAt     int64                  `protobuf:"varint,4,opt,name=applied_at,json=appliedAt,proto3" json:"applied_at,omitempty"` // Unix time, in milliseconds
+	DurationMs    int64                  `protobuf:"varint,5,opt,name=duration_ms,json=durationMs,proto3" json:"duration_ms,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *AppliedMigration) Reset() {
+	*x = AppliedMigration{}
+	mi := &file_persistence_proto_msgTypes[52]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *AppliedMigration) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*AppliedMigration) ProtoMessage() {}
+
+func (x *AppliedMigration) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[52]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use AppliedMigration.ProtoReflect.Descriptor instead.
+func (*AppliedMigration) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{52}
+}
+
+func (x *AppliedMigration) GetVersion() int64 {
+	if x != nil {
+		return x.Version
+	}
+	return 0
+}
+
+func (x *AppliedMigration) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *AppliedMigration) GetChecksum() string {
+	if x != nil {
+		return x.Checksum
+	}
+	return ""
+}
+
+func (x *AppliedMigration) GetAppliedAt() int64 {
+	if x != nil {
+		return x.AppliedAt
+	}
+	return 0
+}
+
+func (x *AppliedMigration) GetDurationMs() int64 {
+	if x != nil {
+		return x.DurationMs
+	}
+	return 0
+}
+
+// Request to apply the pending migrations of a database.
+type ApplyMigrationsRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Migrations    []*Migration           `protobuf:"bytes,3,rep,name=migrations,proto3" json:"migrations,omitempty"` // The whole migration set, applied or not
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyMigrationsRqst) Reset() {
+	*x = ApplyMigrationsRqst{}
+	mi := &file_persistence_proto_msgTypes[53]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyMigrationsRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyMigrationsRqst) ProtoMessage() {}
+
+func (x *ApplyMigrationsRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[53]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyMigrationsRqst.ProtoReflect.Descriptor instead.
+func (*ApplyMigrationsRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{53}
+}
+
+func (x *ApplyMigrationsRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ApplyMigrationsRqst) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *ApplyMigrationsRqst) GetMigrations() []*Migration {
+	if x != nil {
+		return x.Migrations
+	}
+	return nil
+}
+
+// Response for an apply migrations request.
+type ApplyMigrationsRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Applied       []*AppliedMigration    `protobuf:"bytes,1,rep,name=applied,proto3" json:"applied,omitempty"` // Migrations applied by this request
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ApplyMigrationsRsp) Reset() {
+	*x = ApplyMigrationsRsp{}
+	mi := &file_persistence_proto_msgTypes[54]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ApplyMigrationsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ApplyMigrationsRsp) ProtoMessage() {}
+
+func (x *ApplyMigrationsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[54]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ApplyMigrationsRsp.ProtoReflect.Descriptor instead.
+func (*ApplyMigrationsRsp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{54}
+}
+
+func (x *ApplyMigrationsRsp) GetApplied() []*AppliedMigration {
+	if x != nil {
+		return x.Applied
+	}
+	return nil
+}
+
+// Request to list the pending migrations of a database without applying them.
+type PlanMigrationsRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	Migrations    []*Migration           `protobuf:"bytes,3,rep,name=migrations,proto3" json:"migrations,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanMigrationsRqst) Reset() {
+	*x = PlanMigrationsRqst{}
+	mi := &file_persistence_proto_msgTypes[55]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanMigrationsRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanMigrationsRqst) ProtoMessage() {}
+
+func (x *PlanMigrationsRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[55]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanMigrationsRqst.ProtoReflect.Descriptor instead.
+func (*PlanMigrationsRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{55}
+}
+
+func (x *PlanMigrationsRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PlanMigrationsRqst) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+func (x *PlanMigrationsRqst) GetMigrations() []*Migration {
+	if x != nil {
+		return x.Migrations
+	}
+	return nil
+}
+
+// Response for a plan migrations request.
+type PlanMigrationsRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dialect       string                 `protobuf:"bytes,1,opt,name=dialect,proto3" json:"dialect,omitempty"` // Dialect of the store
+	Pending       []*Migration           `protobuf:"bytes,2,rep,name=pending,proto3" json:"pending,omitempty"` // Migrations ApplyMigrations would run, in order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PlanMigrationsRsp) Reset() {
+	*x = PlanMigrationsRsp{}
+	mi := &file_persistence_proto_msgTypes[56]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PlanMigrationsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PlanMigrationsRsp) ProtoMessage() {}
+
+func (x *PlanMigrationsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[56]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PlanMigrationsRsp.ProtoReflect.Descriptor instead.
+func (*PlanMigrationsRsp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{56}
+}
+
+func (x *PlanMigrationsRsp) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *PlanMigrationsRsp) GetPending() []*Migration {
+	if x != nil {
+		return x.Pending
+	}
+	return nil
+}
+
+// Request to list the ledger of a database.
+type ListMigrationsRqst struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection ID
+	Database      string                 `protobuf:"bytes,2,opt,name=database,proto3" json:"database,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMigrationsRqst) Reset() {
+	*x = ListMigrationsRqst{}
+	mi := &file_persistence_proto_msgTypes[57]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMigrationsRqst) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMigrationsRqst) ProtoMessage() {}
+
+func (x *ListMigrationsRqst) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[57]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMigrationsRqst.ProtoReflect.Descriptor instead.
+func (*ListMigrationsRqst) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{57}
+}
+
+func (x *ListMigrationsRqst) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ListMigrationsRqst) GetDatabase() string {
+	if x != nil {
+		return x.Database
+	}
+	return ""
+}
+
+// Response for a list migrations request.
+type ListMigrationsRsp struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Dialect       string                 `protobuf:"bytes,1,opt,name=dialect,proto3" json:"dialect,omitempty"`       // Dialect of the store
+	Migrations    []*AppliedMigration    `protobuf:"bytes,2,rep,name=migrations,proto3" json:"migrations,omitempty"` // Applied migrations, in version order
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListMigrationsRsp) Reset() {
+	*x = ListMigrationsRsp{}
+	mi := &file_persistence_proto_msgTypes[58]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListMigrationsRsp) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListMigrationsRsp) ProtoMessage() {}
+
+func (x *ListMigrationsRsp) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[58]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListMigrationsRsp.ProtoReflect.Descriptor instead.
+func (*ListMigrationsRsp) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{58}
+}
+
+func (x *ListMigrationsRsp) GetDialect() string {
+	if x != nil {
+		return x.Dialect
+	}
+	return ""
+}
+
+func (x *ListMigrationsRsp) GetMigrations() []*AppliedMigration {
+	if x != nil {
+		return x.Migrations
+	}
+	return nil
+}
+
+// Request to stop a service or process.
+type StopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_persistence_proto_msgTypes[59]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[59]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{59}
+}
+
+// Response for a stop request.
+type StopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
+	mi := &file_persistence_proto_msgTypes[60]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopResponse) ProtoMessage() {}
+
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_persistence_proto_msgTypes[60]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return file_persistence_proto_rawDescGZIP(), []int{60}
+}
+
+var File_persistence_proto protoreflect.FileDescriptor
+
+const file_persistence_proto_rawDesc = "" +
+	"\n" +
+	"\x11persistence.proto\x12\vpersistence\x1a\x1cgoogle/protobuf/struct.proto\x1a\x13globular_auth.proto\"\xea\x01\n" +
+	"\n" +
+	"Connection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x12\n" +
+	"\x04host\x18\x03 \x01(\tR\x04host\x12,\n" +
+	"\x05store\x18\x05 \x01(\x0e2\x16.persistence.StoreTypeR\x05store\x12\x12\n" +
+	"\x04user\x18\x06 \x01(\tR\x04user\x12\x1a\n" +
+	"\bpassword\x18\a \x01(\tR\bpassword\x12\x12\n" +
+	"\x04port\x18\b \x01(\x05R\x04port\x12\x18\n" +
+	"\atimeout\x18\t \x01(\x05R\atimeout\x12\x18\n" +
+	"\aoptions\x18\n" +
+	" \x01(\tR\aoptions\"c\n" +
+	"\x14CreateConnectionRqst\x127\n" +
+	"\n" +
+	"connection\x18\x01 \x01(\v2\x17.persistence.ConnectionR\n" +
+	"connection\x12\x12\n" +
+	"\x04save\x18\x02 \x01(\bR\x04save\"-\n" +
+	"\x13CreateConnectionRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\":\n" +
+	"\x14DeleteConnectionRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\"-\n" +
+	"\x13DeleteConnectionRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"8\n" +
+	"\x12PingConnectionRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\"+\n" +
+	"\x11PingConnectionRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\tR\x06result\"\xc5\x01\n" +
+	"\x0eInsertManyRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\fR\x04data\x12\x18\n" +
+	"\aoptions\x18\x05 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\"\x0f\n" +
+	"\rInsertManyRsp\"\xc4\x01\n" +
+	"\rInsertOneRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x18\n" +
+	"\aoptions\x18\x05 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\"\x1e\n" +
+	"\fInsertOneRsp\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\"\xc1\x01\n" +
+	"\bFindRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\"\x1e\n" +
+	"\bFindResp\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xc4\x01\n" +
+	"\vFindOneRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\">\n" +
+	"\vFindOneResp\x12/\n" +
+	"\x06result\x18\x01 \x01(\v2\x17.google.protobuf.StructR\x06result\"\xcc\x01\n" +
+	"\rAggregateRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x1a\n" +
+	"\bpipeline\x18\x04 \x01(\tR\bpipeline\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\"#\n" +
+	"\rAggregateResp\x12\x12\n" +
+	"\x04data\x18\x01 \x01(\fR\x04data\"\xd9\x01\n" +
+	"\n" +
+	"UpdateRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\"#\n" +
+	"\tUpdateRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xdc\x01\n" +
+	"\rUpdateOneRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\"&\n" +
+	"\fUpdateOneRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xdd\x01\n" +
+	"\x0eReplaceOneRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x14\n" +
+	"\x05value\x18\x05 \x01(\tR\x05value\x12\x18\n" +
+	"\aoptions\x18\x06 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\a \x01(\tR\rtransactionId\"'\n" +
+	"\rReplaceOneRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xc3\x01\n" +
+	"\n" +
+	"DeleteRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
+	"\aoptions\x18\x05 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\"#\n" +
+	"\tDeleteRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xc6\x01\n" +
+	"\rDeleteOneRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x14\n" +
+	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
+	"\aoptions\x18\x05 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\"&\n" +
+	"\fDeleteOneRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"T\n" +
+	"\x12CreateDatabaseRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"+\n" +
+	"\x11CreateDatabaseRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"T\n" +
+	"\x12DeleteDatabaseRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"+\n" +
+	"\x11DeleteDatabaseRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\x96\x01\n" +
+	"\x14CreateCollectionRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x1e\n" +
+	"\n" +
 	"optionsStr\x18\x04 \x01(\tR\n" +
 	"optionsStr\"-\n" +
 	"\x13CreateCollectionRsp\x12\x16\n" +
@@ -2806,7 +3832,7 @@ const file_persistence_proto_rawDesc = "" +
 	"collection\x18\x03 \x01(\tR\n" +
 	"collection\"-\n" +
 	"\x13DeleteCollectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\x9b\x01\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xc2\x01\n" +
 	"\tCountRqst\x12\"\n" +
 	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
 	"\n" +
@@ -2816,7 +3842,8 @@ const file_persistence_proto_rawDesc = "" +
 	"collection\x18\x03 \x01(\tR\n" +
 	"collection\x12\x14\n" +
 	"\x05query\x18\x04 \x01(\tR\x05query\x12\x18\n" +
-	"\aoptions\x18\x05 \x01(\tR\aoptions\"\"\n" +
+	"\aoptions\x18\x05 \x01(\tR\aoptions\x12%\n" +
+	"\x0etransaction_id\x18\x06 \x01(\tR\rtransactionId\"\"\n" +
 	"\bCountRsp\x12\x16\n" +
 	"\x06result\x18\x01 \x01(\x03R\x06result\"\x91\x01\n" +
 	"\x0fRunAdminCmdRqst\x126\n" +
@@ -2841,14 +3868,98 @@ const file_persistence_proto_rawDesc = "" +
 	"\n" +
 	"connection\x10\x01R\fconnectionId\"'\n" +
 	"\rDisconnectRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\r\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"T\n" +
+	"\x14BeginTransactionRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x18\n" +
+	"\atimeout\x18\x02 \x01(\x05R\atimeout\"<\n" +
+	"\x13BeginTransactionRsp\x12%\n" +
+	"\x0etransaction_id\x18\x01 \x01(\tR\rtransactionId\"W\n" +
+	"\n" +
+	"CommitRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\"#\n" +
+	"\tCommitRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"Y\n" +
+	"\fRollbackRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12%\n" +
+	"\x0etransaction_id\x18\x02 \x01(\tR\rtransactionId\"%\n" +
+	"\vRollbackRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xa6\x01\n" +
+	"\tWatchRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x12\x1e\n" +
+	"\n" +
+	"collection\x18\x03 \x01(\tR\n" +
+	"collection\x12\x16\n" +
+	"\x06filter\x18\x04 \x01(\tR\x06filter\x12!\n" +
+	"\fresume_token\x18\x05 \x01(\tR\vresumeToken\"\x9d\x01\n" +
+	"\tWatchResp\x12!\n" +
+	"\fresume_token\x18\x01 \x01(\tR\vresumeToken\x12\x1c\n" +
+	"\toperation\x18\x02 \x01(\tR\toperation\x12\x1f\n" +
+	"\vdocument_id\x18\x03 \x01(\tR\n" +
+	"documentId\x12\x1a\n" +
+	"\bdocument\x18\x04 \x01(\tR\bdocument\x12\x12\n" +
+	"\x04time\x18\x05 \x01(\x03R\x04time\"\x87\x01\n" +
+	"\tMigration\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x18\n" +
+	"\adialect\x18\x03 \x01(\tR\adialect\x12\x16\n" +
+	"\x06script\x18\x04 \x01(\tR\x06script\x12\x1a\n" +
+	"\bchecksum\x18\x05 \x01(\tR\bchecksum\"\x9c\x01\n" +
+	"\x10AppliedMigration\x12\x18\n" +
+	"\aversion\x18\x01 \x01(\x03R\aversion\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12\x1a\n" +
+	"\bchecksum\x18\x03 \x01(\tR\bchecksum\x12\x1d\n" +
+	"\n" +
+	"applied_at\x18\x04 \x01(\x03R\tappliedAt\x12\x1f\n" +
+	"\vduration_ms\x18\x05 \x01(\x03R\n" +
+	"durationMs\"\x8d\x01\n" +
+	"\x13ApplyMigrationsRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x126\n" +
+	"\n" +
+	"migrations\x18\x03 \x03(\v2\x16.persistence.MigrationR\n" +
+	"migrations\"M\n" +
+	"\x12ApplyMigrationsRsp\x127\n" +
+	"\aapplied\x18\x01 \x03(\v2\x1d.persistence.AppliedMigrationR\aapplied\"\x8c\x01\n" +
+	"\x12PlanMigrationsRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\x126\n" +
+	"\n" +
+	"migrations\x18\x03 \x03(\v2\x16.persistence.MigrationR\n" +
+	"migrations\"_\n" +
+	"\x11PlanMigrationsRsp\x12\x18\n" +
+	"\adialect\x18\x01 \x01(\tR\adialect\x120\n" +
+	"\apending\x18\x02 \x03(\v2\x16.persistence.MigrationR\apending\"T\n" +
+	"\x12ListMigrationsRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\x12\x1a\n" +
+	"\bdatabase\x18\x02 \x01(\tR\bdatabase\"l\n" +
+	"\x11ListMigrationsRsp\x12\x18\n" +
+	"\adialect\x18\x01 \x01(\tR\adialect\x12=\n" +
+	"\n" +
+	"migrations\x18\x02 \x03(\v2\x1d.persistence.AppliedMigrationR\n" +
+	"migrations\"\r\n" +
 	"\vStopRequest\"\x0e\n" +
 	"\fStopResponse*+\n" +
 	"\tStoreType\x12\t\n" +
 	"\x05MONGO\x10\x00\x12\a\n" +
 	"\x03SQL\x10\x01\x12\n" +
 	"\n" +
-	"\x06SCYLLA\x10\x022\xca\x1d\n" +
+	"\x06SCYLLA\x10\x022\xb4&\n" +
 	"\x12PersistenceService\x12p\n" +
 	"\x04Stop\x12\x18.persistence.StopRequest\x1a\x19.persistence.StopResponse\"3\x82\xb5\x18/\n" +
 	"\x11persistence.admin\x12\x05admin\x1a\f/persistence*\x05admin\x12\xa1\x01\n" +
@@ -2894,7 +4005,21 @@ const file_persistence_proto_rawDesc = "" +
 	"\x06Delete\x12\x17.persistence.DeleteRqst\x1a\x16.persistence.DeleteRsp\"u\x82\xb5\x18q\n" +
 	"\x12persistence.delete\x12\x06delete\x1aK/persistence/connections/{id}/databases/{database}/collections/{collection}*\x06editor\x12\xb9\x01\n" +
 	"\tDeleteOne\x12\x1a.persistence.DeleteOneRqst\x1a\x19.persistence.DeleteOneRsp\"u\x82\xb5\x18q\n" +
-	"\x12persistence.delete\x12\x06delete\x1aK/persistence/connections/{id}/databases/{database}/collections/{collection}*\x06editor\x12\x98\x01\n" +
+	"\x12persistence.delete\x12\x06delete\x1aK/persistence/connections/{id}/databases/{database}/collections/{collection}*\x06editor\x12\x9e\x01\n" +
+	"\x10BeginTransaction\x12!.persistence.BeginTransactionRqst\x1a .persistence.BeginTransactionRsp\"E\x82\xb5\x18A\n" +
+	"\x11persistence.write\x12\x05write\x1a\x1d/persistence/connections/{id}*\x06editor\x12\x80\x01\n" +
+	"\x06Commit\x12\x17.persistence.CommitRqst\x1a\x16.persistence.CommitRsp\"E\x82\xb5\x18A\n" +
+	"\x11persistence.write\x12\x05write\x1a\x1d/persistence/connections/{id}*\x06editor\x12\x86\x01\n" +
+	"\bRollback\x12\x19.persistence.RollbackRqst\x1a\x18.persistence.RollbackRsp\"E\x82\xb5\x18A\n" +
+	"\x11persistence.write\x12\x05write\x1a\x1d/persistence/connections/{id}*\x06editor\x12\xac\x01\n" +
+	"\x05Watch\x12\x16.persistence.WatchRqst\x1a\x16.persistence.WatchResp\"q\x82\xb5\x18m\n" +
+	"\x10persistence.read\x12\x04read\x1aK/persistence/connections/{id}/databases/{database}/collections/{collection}*\x06viewer0\x01\x12\xaf\x01\n" +
+	"\x0fApplyMigrations\x12 .persistence.ApplyMigrationsRqst\x1a\x1f.persistence.ApplyMigrationsRsp\"Y\x82\xb5\x18U\n" +
+	"\x11persistence.admin\x12\x05admin\x1a2/persistence/connections/{id}/databases/{database}*\x05admin\x12\xab\x01\n" +
+	"\x0ePlanMigrations\x12\x1f.persistence.PlanMigrationsRqst\x1a\x1e.persistence.PlanMigrationsRsp\"X\x82\xb5\x18T\n" +
+	"\x10persistence.read\x12\x04read\x1a2/persistence/connections/{id}/databases/{database}*\x06viewer\x12\xab\x01\n" +
+	"\x0eListMigrations\x12\x1f.persistence.ListMigrationsRqst\x1a\x1e.persistence.ListMigrationsRsp\"X\x82\xb5\x18T\n" +
+	"\x10persistence.read\x12\x04read\x1a2/persistence/connections/{id}/databases/{database}*\x06viewer\x12\x98\x01\n" +
 	"\vRunAdminCmd\x12\x1c.persistence.RunAdminCmdRqst\x1a\x1b.persistence.RunAdminCmdRsp\"N\x82\xb5\x18J\n" +
 	"\x11persistence.admin\x12\x05admin\x1a'/persistence/connections/{connectionId}*\x05adminBAZ?github.com/globulario/services/golang/persistence/persistencepbb\x06proto3"
 
@@ -2911,7 +4036,7 @@ func file_persistence_proto_rawDescGZIP() []byte {
 }
 
 var file_persistence_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_persistence_proto_msgTypes = make([]protoimpl.MessageInfo, 45)
+var file_persistence_proto_msgTypes = make([]protoimpl.MessageInfo, 61)
 var file_persistence_proto_goTypes = []any{
 	(StoreType)(0),               // 0: persistence.StoreType
 	(*Connection)(nil),           // 1: persistence.Connection
@@ -2957,63 +4082,98 @@ var file_persistence_proto_goTypes = []any{
 	(*ConnectRsp)(nil),           // 41: persistence.ConnectRsp
 	(*DisconnectRqst)(nil),       // 42: persistence.DisconnectRqst
 	(*DisconnectRsp)(nil),        // 43: persistence.DisconnectRsp
-	(*StopRequest)(nil),          // 44: persistence.StopRequest
-	(*StopResponse)(nil),         // 45: persistence.StopResponse
-	(*structpb.Struct)(nil),      // 46: google.protobuf.Struct
+	(*BeginTransactionRqst)(nil), // 44: persistence.BeginTransactionRqst
+	(*BeginTransactionRsp)(nil),  // 45: persistence.BeginTransactionRsp
+	(*CommitRqst)(nil),           // 46: persistence.CommitRqst
+	(*CommitRsp)(nil),            // 47: persistence.CommitRsp
+	(*RollbackRqst)(nil),         // 48: persistence.RollbackRqst
+	(*RollbackRsp)(nil),          // 49: persistence.RollbackRsp
+	(*WatchRqst)(nil),            // 50: persistence.WatchRqst
+	(*WatchResp)(nil),            // 51: persistence.WatchResp
+	(*Migration)(nil),            // 52: persistence.Migration
+	(*AppliedMigration)(nil),     // 53: persistence.AppliedMigration
+	(*ApplyMigrationsRqst)(nil),  // 54: persistence.ApplyMigrationsRqst
+	(*ApplyMigrationsRsp)(nil),   // 55: persistence.ApplyMigrationsRsp
+	(*PlanMigrationsRqst)(nil),   // 56: persistence.PlanMigrationsRqst
+	(*PlanMigrationsRsp)(nil),    // 57: persistence.PlanMigrationsRsp
+	(*ListMigrationsRqst)(nil),   // 58: persistence.ListMigrationsRqst
+	(*ListMigrationsRsp)(nil),    // 59: persistence.ListMigrationsRsp
+	(*StopRequest)(nil),          // 60: persistence.StopRequest
+	(*StopResponse)(nil),         // 61: persistence.StopResponse
+	(*structpb.Struct)(nil),      // 62: google.protobuf.Struct
 }
 var file_persistence_proto_depIdxs = []int32{
 	0,  // 0: persistence.Connection.store:type_name -> persistence.StoreType
 	1,  // 1: persistence.CreateConnectionRqst.connection:type_name -> persistence.Connection
-	46, // 2: persistence.FindOneResp.result:type_name -> google.protobuf.Struct
-	44, // 3: persistence.PersistenceService.Stop:input_type -> persistence.StopRequest
-	28, // 4: persistence.PersistenceService.CreateDatabase:input_type -> persistence.CreateDatabaseRqst
-	40, // 5: persistence.PersistenceService.Connect:input_type -> persistence.ConnectRqst
-	42, // 6: persistence.PersistenceService.Disconnect:input_type -> persistence.DisconnectRqst
-	30, // 7: persistence.PersistenceService.DeleteDatabase:input_type -> persistence.DeleteDatabaseRqst
-	32, // 8: persistence.PersistenceService.CreateCollection:input_type -> persistence.CreateCollectionRqst
-	34, // 9: persistence.PersistenceService.DeleteCollection:input_type -> persistence.DeleteCollectionRqst
-	2,  // 10: persistence.PersistenceService.CreateConnection:input_type -> persistence.CreateConnectionRqst
-	4,  // 11: persistence.PersistenceService.DeleteConnection:input_type -> persistence.DeleteConnectionRqst
-	6,  // 12: persistence.PersistenceService.Ping:input_type -> persistence.PingConnectionRqst
-	36, // 13: persistence.PersistenceService.Count:input_type -> persistence.CountRqst
-	10, // 14: persistence.PersistenceService.InsertOne:input_type -> persistence.InsertOneRqst
-	8,  // 15: persistence.PersistenceService.InsertMany:input_type -> persistence.InsertManyRqst
-	12, // 16: persistence.PersistenceService.Find:input_type -> persistence.FindRqst
-	14, // 17: persistence.PersistenceService.FindOne:input_type -> persistence.FindOneRqst
-	16, // 18: persistence.PersistenceService.Aggregate:input_type -> persistence.AggregateRqst
-	18, // 19: persistence.PersistenceService.Update:input_type -> persistence.UpdateRqst
-	20, // 20: persistence.PersistenceService.UpdateOne:input_type -> persistence.UpdateOneRqst
-	22, // 21: persistence.PersistenceService.ReplaceOne:input_type -> persistence.ReplaceOneRqst
-	24, // 22: persistence.PersistenceService.Delete:input_type -> persistence.DeleteRqst
-	26, // 23: persistence.PersistenceService.DeleteOne:input_type -> persistence.DeleteOneRqst
-	38, // 24: persistence.PersistenceService.RunAdminCmd:input_type -> persistence.RunAdminCmdRqst
-	45, // 25: persistence.PersistenceService.Stop:output_type -> persistence.StopResponse
-	29, // 26: persistence.PersistenceService.CreateDatabase:output_type -> persistence.CreateDatabaseRsp
-	41, // 27: persistence.PersistenceService.Connect:output_type -> persistence.ConnectRsp
-	43, // 28: persistence.PersistenceService.Disconnect:output_type -> persistence.DisconnectRsp
-	31, // 29: persistence.PersistenceService.DeleteDatabase:output_type -> persistence.DeleteDatabaseRsp
-	33, // 30: persistence.PersistenceService.CreateCollection:output_type -> persistence.CreateCollectionRsp
-	35, // 31: persistence.PersistenceService.DeleteCollection:output_type -> persistence.DeleteCollectionRsp
-	3,  // 32: persistence.PersistenceService.CreateConnection:output_type -> persistence.CreateConnectionRsp
-	5,  // 33: persistence.PersistenceService.DeleteConnection:output_type -> persistence.DeleteConnectionRsp
-	7,  // 34: persistence.PersistenceService.Ping:output_type -> persistence.PingConnectionRsp
-	37, // 35: persistence.PersistenceService.Count:output_type -> persistence.CountRsp
-	11, // 36: persistence.PersistenceService.InsertOne:output_type -> persistence.InsertOneRsp
-	9,  // 37: persistence.PersistenceService.InsertMany:output_type -> persistence.InsertManyRsp
-	13, // 38: persistence.PersistenceService.Find:output_type -> persistence.FindResp
-	15, // 39: persistence.PersistenceService.FindOne:output_type -> persistence.FindOneResp
-	17, // 40: persistence.PersistenceService.Aggregate:output_type -> persistence.AggregateResp
-	19, // 41: persistence.PersistenceService.Update:output_type -> persistence.UpdateRsp
-	21, // 42: persistence.PersistenceService.UpdateOne:output_type -> persistence.UpdateOneRsp
-	23, // 43: persistence.PersistenceService.ReplaceOne:output_type -> persistence.ReplaceOneRsp
-	25, // 44: persistence.PersistenceService.Delete:output_type -> persistence.DeleteRsp
-	27, // 45: persistence.PersistenceService.DeleteOne:output_type -> persistence.DeleteOneRsp
-	39, // 46: persistence.PersistenceService.RunAdminCmd:output_type -> persistence.RunAdminCmdRsp
-	25, // [25:47] is the sub-list for method output_type
-	3,  // [3:25] is the sub-list for method input_type
-	3,  // [3:3] is the sub-list for extension type_name
-	3,  // [3:3] is the sub-list for extension extendee
-	0,  // [0:3] is the sub-list for field type_name
+	62, // 2: persistence.FindOneResp.result:type_name -> google.protobuf.Struct
+	52, // 3: persistence.ApplyMigrationsRqst.migrations:type_name -> persistence.Migration
+	53, // 4: persistence.ApplyMigrationsRsp.applied:type_name -> persistence.AppliedMigration
+	52, // 5: persistence.PlanMigrationsRqst.migrations:type_name -> persistence.Migration
+	52, // 6: persistence.PlanMigrationsRsp.pending:type_name -> persistence.Migration
+	53, // 7: persistence.ListMigrationsRsp.migrations:type_name -> persistence.AppliedMigration
+	60, // 8: persistence.PersistenceService.Stop:input_type -> persistence.StopRequest
+	28, // 9: persistence.PersistenceService.CreateDatabase:input_type -> persistence.CreateDatabaseRqst
+	40, // 10: persistence.PersistenceService.Connect:input_type -> persistence.ConnectRqst
+	42, // 11: persistence.PersistenceService.Disconnect:input_type -> persistence.DisconnectRqst
+	30, // 12: persistence.PersistenceService.DeleteDatabase:input_type -> persistence.DeleteDatabaseRqst
+	32, // 13: persistence.PersistenceService.CreateCollection:input_type -> persistence.CreateCollectionRqst
+	34, // 14: persistence.PersistenceService.DeleteCollection:input_type -> persistence.DeleteCollectionRqst
+	2,  // 15: persistence.PersistenceService.CreateConnection:input_type -> persistence.CreateConnectionRqst
+	4,  // 16: persistence.PersistenceService.DeleteConnection:input_type -> persistence.DeleteConnectionRqst
+	6,  // 17: persistence.PersistenceService.Ping:input_type -> persistence.PingConnectionRqst
+	36, // 18: persistence.PersistenceService.Count:input_type -> persistence.CountRqst
+	10, // 19: persistence.PersistenceService.InsertOne:input_type -> persistence.InsertOneRqst
+	8,  // 20: persistence.PersistenceService.InsertMany:input_type -> persistence.InsertManyRqst
+	12, // 21: persistence.PersistenceService.Find:input_type -> persistence.FindRqst
+	14, // 22: persistence.PersistenceService.FindOne:input_type -> persistence.FindOneRqst
+	16, // 23: persistence.PersistenceService.Aggregate:input_type -> persistence.AggregateRqst
+	18, // 24: persistence.PersistenceService.Update:input_type -> persistence.UpdateRqst
+	20, // 25: persistence.PersistenceService.UpdateOne:input_type -> persistence.UpdateOneRqst
+	22, // 26: persistence.PersistenceService.ReplaceOne:input_type -> persistence.ReplaceOneRqst
+	24, // 27: persistence.PersistenceService.Delete:input_type -> persistence.DeleteRqst
+	26, // 28: persistence.PersistenceService.DeleteOne:input_type -> persistence.DeleteOneRqst
+	44, // 29: persistence.PersistenceService.BeginTransaction:input_type -> persistence.BeginTransactionRqst
+	46, // 30: persistence.PersistenceService.Commit:input_type -> persistence.CommitRqst
+	48, // 31: persistence.PersistenceService.Rollback:input_type -> persistence.RollbackRqst
+	50, // 32: persistence.PersistenceService.Watch:input_type -> persistence.WatchRqst
+	54, // 33: persistence.PersistenceService.ApplyMigrations:input_type -> persistence.ApplyMigrationsRqst
+	56, // 34: persistence.PersistenceService.PlanMigrations:input_type -> persistence.PlanMigrationsRqst
+	58, // 35: persistence.PersistenceService.ListMigrations:input_type -> persistence.ListMigrationsRqst
+	38, // 36: persistence.PersistenceService.RunAdminCmd:input_type -> persistence.RunAdminCmdRqst
+	61, // 37: persistence.PersistenceService.Stop:output_type -> persistence.StopResponse
+	29, // 38: persistence.PersistenceService.CreateDatabase:output_type -> persistence.CreateDatabaseRsp
+	41, // 39: persistence.PersistenceService.Connect:output_type -> persistence.ConnectRsp
+	43, // 40: persistence.PersistenceService.Disconnect:output_type -> persistence.DisconnectRsp
+	31, // 41: persistence.PersistenceService.DeleteDatabase:output_type -> persistence.DeleteDatabaseRsp
+	33, // 42: persistence.PersistenceService.CreateCollection:output_type -> persistence.CreateCollectionRsp
+	35, // 43: persistence.PersistenceService.DeleteCollection:output_type -> persistence.DeleteCollectionRsp
+	3,  // 44: persistence.PersistenceService.CreateConnection:output_type -> persistence.CreateConnectionRsp
+	5,  // 45: persistence.PersistenceService.DeleteConnection:output_type -> persistence.DeleteConnectionRsp
+	7,  // 46: persistence.PersistenceService.Ping:output_type -> persistence.PingConnectionRsp
+	37, // 47: persistence.PersistenceService.Count:output_type -> persistence.CountRsp
+	11, // 48: persistence.PersistenceService.InsertOne:output_type -> persistence.InsertOneRsp
+	9,  // 49: persistence.PersistenceService.InsertMany:output_type -> persistence.InsertManyRsp
+	13, // 50: persistence.PersistenceService.Find:output_type -> persistence.FindResp
+	15, // 51: persistence.PersistenceService.FindOne:output_type -> persistence.FindOneResp
+	17, // 52: persistence.PersistenceService.Aggregate:output_type -> persistence.AggregateResp
+	19, // 53: persistence.PersistenceService.Update:output_type -> persistence.UpdateRsp
+	21, // 54: persistence.PersistenceService.UpdateOne:output_type -> persistence.UpdateOneRsp
+	23, // 55: persistence.PersistenceService.ReplaceOne:output_type -> persistence.ReplaceOneRsp
+	25, // 56: persistence.PersistenceService.Delete:output_type -> persistence.DeleteRsp
+	27, // 57: persistence.PersistenceService.DeleteOne:output_type -> persistence.DeleteOneRsp
+	45, // 58: persistence.PersistenceService.BeginTransaction:output_type -> persistence.BeginTransactionRsp
+	47, // 59: persistence.PersistenceService.Commit:output_type -> persistence.CommitRsp
+	49, // 60: persistence.PersistenceService.Rollback:output_type -> persistence.RollbackRsp
+	51, // 61: persistence.PersistenceService.Watch:output_type -> persistence.WatchResp
+	55, // 62: persistence.PersistenceService.ApplyMigrations:output_type -> persistence.ApplyMigrationsRsp
+	57, // 63: persistence.PersistenceService.PlanMigrations:output_type -> persistence.PlanMigrationsRsp
+	59, // 64: persistence.PersistenceService.ListMigrations:output_type -> persistence.ListMigrationsRsp
+	39, // 65: persistence.PersistenceService.RunAdminCmd:output_type -> persistence.RunAdminCmdRsp
+	37, // [37:66] is the sub-list for method output_type
+	8,  // [8:37] is the sub-list for method input_type
+	8,  // [8:8] is the sub-list for extension type_name
+	8,  // [8:8] is the sub-list for extension extendee
+	0,  // [0:8] is the sub-list for field type_name
 }
 
 func init() { file_persistence_proto_init() }
@@ -3027,7 +4187,7 @@ func file_persistence_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_persistence_proto_rawDesc), len(file_persistence_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   45,
+			NumMessages:   61,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -40,6 +40,13 @@ const (
 	PersistenceService_ReplaceOne_FullMethodName       = "/persistence.PersistenceService/ReplaceOne"
 	PersistenceService_Delete_FullMethodName           = "/persistence.PersistenceService/Delete"
 	PersistenceService_DeleteOne_FullMethodName        = "/persistence.PersistenceService/DeleteOne"
+	PersistenceService_BeginTransaction_FullMethodName = "/persistence.PersistenceService/BeginTransaction"
+	PersistenceService_Commit_FullMethodName           = "/persistence.PersistenceService/Commit"
+	PersistenceService_Rollback_FullMethodName         = "/persistence.PersistenceService/Rollback"
+	PersistenceService_Watch_FullMethodName            = "/persistence.PersistenceService/Watch"
+	PersistenceService_ApplyMigrations_FullMethodName  = "/persistence.PersistenceService/ApplyMigrations"
+	PersistenceService_PlanMigrations_FullMethodName   = "/persistence.PersistenceService/PlanMigrations"
+	PersistenceService_ListMigrations_FullMethodName   = "/persistence.PersistenceService/ListMigrations"
 	PersistenceService_RunAdminCmd_FullMethodName      = "/persistence.PersistenceService/RunAdminCmd"
 )
 
@@ -91,6 +98,20 @@ type PersistenceServiceClient interface {
 	Delete(ctx context.Context, in *DeleteRqst, opts ...grpc.CallOption) (*DeleteRsp, error)
 	// DeleteOne - Deletes a single document from a collection.
 	DeleteOne(ctx context.Context, in *DeleteOneRqst, opts ...grpc.CallOption) (*DeleteOneRsp, error)
+	// BeginTransaction - Starts a transaction; CRUD requests carrying its id run inside it.
+	BeginTransaction(ctx context.Context, in *BeginTransactionRqst, opts ...grpc.CallOption) (*BeginTransactionRsp, error)
+	// Commit - Applies the writes of a transaction.
+	Commit(ctx context.Context, in *CommitRqst, opts ...grpc.CallOption) (*CommitRsp, error)
+	// Rollback - Discards the writes of a transaction.
+	Rollback(ctx context.Context, in *RollbackRqst, opts ...grpc.CallOption) (*RollbackRsp, error)
+	// Watch - Streams the changes of a collection; reconnect with the last resume token.
+	Watch(ctx context.Context, in *WatchRqst, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResp], error)
+	// ApplyMigrations - Runs the migrations of a set not yet applied to a database.
+	ApplyMigrations(ctx context.Context, in *ApplyMigrationsRqst, opts ...grpc.CallOption) (*ApplyMigrationsRsp, error)
+	// PlanMigrations - Lists the migrations of a set ApplyMigrations would run.
+	PlanMigrations(ctx context.Context, in *PlanMigrationsRqst, opts ...grpc.CallOption) (*PlanMigrationsRsp, error)
+	// ListMigrations - Lists the migrations applied to a database.
+	ListMigrations(ctx context.Context, in *ListMigrationsRqst, opts ...grpc.CallOption) (*ListMigrationsRsp, error)
 	// RunAdminCmd - Executes an administrative command or script.
 	RunAdminCmd(ctx context.Context, in *RunAdminCmdRqst, opts ...grpc.CallOption) (*RunAdminCmdRsp, error)
 }
@@ -334,6 +355,85 @@ func (c *persistenceServiceClient) DeleteOne(ctx context.Context, in *DeleteOneR
 	return out, nil
 }
 
+func (c *persistenceServiceClient) BeginTransaction(ctx context.Context, in *BeginTransactionRqst, opts ...grpc.CallOption) (*BeginTransactionRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(BeginTransactionRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_BeginTransaction_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *persistenceServiceClient) Commit(ctx context.Context, in *CommitRqst, opts ...grpc.CallOption) (*CommitRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CommitRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_Commit_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *persistenceServiceClient) Rollback(ctx context.Context, in *RollbackRqst, opts ...grpc.CallOption) (*RollbackRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RollbackRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_Rollback_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *persistenceServiceClient) Watch(ctx context.Context, in *WatchRqst, opts ...grpc.CallOption) (grpc.ServerStreamingClient[WatchResp], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &PersistenceService_ServiceDesc.Streams[3], PersistenceService_Watch_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[WatchRqst, WatchResp]{ClientStream: stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersistenceService_WatchClient = grpc.ServerStreamingClient[WatchResp]
+
+func (c *persistenceServiceClient) ApplyMigrations(ctx context.Context, in *ApplyMigrationsRqst, opts ...grpc.CallOption) (*ApplyMigrationsRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ApplyMigrationsRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_ApplyMigrations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *persistenceServiceClient) PlanMigrations(ctx context.Context, in *PlanMigrationsRqst, opts ...grpc.CallOption) (*PlanMigrationsRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(PlanMigrationsRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_PlanMigrations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *persistenceServiceClient) ListMigrations(ctx context.Context, in *ListMigrationsRqst, opts ...grpc.CallOption) (*ListMigrationsRsp, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListMigrationsRsp)
+	err := c.cc.Invoke(ctx, PersistenceService_ListMigrations_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *persistenceServiceClient) RunAdminCmd(ctx context.Context, in *RunAdminCmdRqst, opts ...grpc.CallOption) (*RunAdminCmdRsp, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(RunAdminCmdRsp)
@@ -392,6 +492,20 @@ type PersistenceServiceServer interface {
 	Delete(context.Context, *DeleteRqst) (*DeleteRsp, error)
 	// DeleteOne - Deletes a single document from a collection.
 	DeleteOne(context.Context, *DeleteOneRqst) (*DeleteOneRsp, error)
+	// BeginTransaction - Starts a transaction; CRUD requests carrying its id run inside it.
+	BeginTransaction(context.Context, *BeginTransactionRqst) (*BeginTransactionRsp, error)
+	// Commit - Applies the writes of a transaction.
+	Commit(context.Context, *CommitRqst) (*CommitRsp, error)
+	// Rollback - Discards the writes of a transaction.
+	Rollback(context.Context, *RollbackRqst) (*RollbackRsp, error)
+	// Watch - Streams the changes of a collection; reconnect with the last resume token.
+	Watch(*WatchRqst, grpc.ServerStreamingServer[WatchResp]) error
+	// ApplyMigrations - Runs the migrations of a set not yet applied to a database.
+	ApplyMigrations(context.Context, *ApplyMigrationsRqst) (*ApplyMigrationsRsp, error)
+	// PlanMigrations - Lists the migrations of a set ApplyMigrations would run.
+	PlanMigrations(context.Context, *PlanMigrationsRqst) (*PlanMigrationsRsp, error)
+	// ListMigrations - Lists the migrations applied to a database.
+	ListMigrations(context.Context, *ListMigrationsRqst) (*ListMigrationsRsp, error)
 	// RunAdminCmd - Executes an administrative command or script.
 	RunAdminCmd(context.Context, *RunAdminCmdRqst) (*RunAdminCmdRsp, error)
 }
@@ -466,6 +580,27 @@ func (UnimplementedPersistenceServiceServer) Delete(context.Context, *DeleteRqst
 func (UnimplementedPersistenceServiceServer) DeleteOne(context.Context, *DeleteOneRqst) (*DeleteOneRsp, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteOne not implemented")
 }
+func (UnimplementedPersistenceServiceServer) BeginTransaction(context.Context, *BeginTransactionRqst) (*BeginTransactionRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method BeginTransaction not implemented")
+}
+func (UnimplementedPersistenceServiceServer) Commit(context.Context, *CommitRqst) (*CommitRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method Commit not implemented")
+}
+func (UnimplementedPersistenceServiceServer) Rollback(context.Context, *RollbackRqst) (*RollbackRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method Rollback not implemented")
+}
+func (UnimplementedPersistenceServiceServer) Watch(*WatchRqst, grpc.ServerStreamingServer[WatchResp]) error {
+	return status.Error(codes.Unimplemented, "method Watch not implemented")
+}
+func (UnimplementedPersistenceServiceServer) ApplyMigrations(context.Context, *ApplyMigrationsRqst) (*ApplyMigrationsRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ApplyMigrations not implemented")
+}
+func (UnimplementedPersistenceServiceServer) PlanMigrations(context.Context, *PlanMigrationsRqst) (*PlanMigrationsRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method PlanMigrations not implemented")
+}
+func (UnimplementedPersistenceServiceServer) ListMigrations(context.Context, *ListMigrationsRqst) (*ListMigrationsRsp, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListMigrations not implemented")
+}
 func (UnimplementedPersistenceServiceServer) RunAdminCmd(context.Context, *RunAdminCmdRqst) (*RunAdminCmdRsp, error) {
 	return nil, status.Error(codes.Unimplemented, "method RunAdminCmd not implemented")
 }
@@ -842,6 +977,125 @@ func _PersistenceService_DeleteOne_Handler(srv interface{}, ctx context.Context,
 	return interceptor(ctx, in, info, handler)
 }
 
+func _PersistenceService_BeginTransaction_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BeginTransactionRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).BeginTransaction(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_BeginTransaction_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).BeginTransaction(ctx, req.(*BeginTransactionRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersistenceService_Commit_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CommitRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).Commit(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_Commit_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).Commit(ctx, req.(*CommitRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersistenceService_Rollback_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RollbackRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).Rollback(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_Rollback_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).Rollback(ctx, req.(*RollbackRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersistenceService_Watch_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(WatchRqst)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(PersistenceServiceServer).Watch(m, &grpc.GenericServerStream[WatchRqst, WatchResp]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type PersistenceService_WatchServer = grpc.ServerStreamingServer[WatchResp]
+
+func _PersistenceService_ApplyMigrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ApplyMigrationsRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).ApplyMigrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_ApplyMigrations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).ApplyMigrations(ctx, req.(*ApplyMigrationsRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersistenceService_PlanMigrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PlanMigrationsRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).PlanMigrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_PlanMigrations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).PlanMigrations(ctx, req.(*PlanMigrationsRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _PersistenceService_ListMigrations_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListMigrationsRqst)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(PersistenceServiceServer).ListMigrations(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: PersistenceService_ListMigrations_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(PersistenceServiceServer).ListMigrations(ctx, req.(*ListMigrationsRqst))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _PersistenceService_RunAdminCmd_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(RunAdminCmdRqst)
 	if err := dec(in); err != nil {
@@ -939,6 +1193,30 @@ var PersistenceService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteOne",
 			Handler:    _PersistenceService_DeleteOne_Handler,
 		},
+		{
+			MethodName: "BeginTransaction",
+			Handler:    _PersistenceService_BeginTransaction_Handler,
+		},
+		{
+			MethodName: "Commit",
+			Handler:    _PersistenceService_Commit_Handler,
+		},
+		{
+			MethodName: "Rollback",
+			Handler:    _PersistenceService_Rollback_Handler,
+		},
+		{
+			MethodName: "ApplyMigrations",
+			Handler:    _PersistenceService_ApplyMigrations_Handler,
+		},
+		{
+			MethodName: "PlanMigrations",
+			Handler:    _PersistenceService_PlanMigrations_Handler,
+		},
+		{
+			MethodName: "ListMigrations",
+			Handler:    _PersistenceService_ListMigrations_Handler,
+		},
 		{
 			MethodName: "RunAdminCmd",
 			Handler:    _PersistenceService_RunAdminCmd_Handler,
@@ -960,6 +1238,11 @@ var PersistenceService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _PersistenceService_Aggregate_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "Watch",
+			Handler:       _PersistenceService_Watch_Handler,
+			ServerStreams: true,
+		},
 	},
 	Metadata: "persistence.proto",
 }
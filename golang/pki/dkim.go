@@ -0,0 +1,97 @@
+package pki
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// dkimKeyBits is the RSA size of generated DKIM keys. 2048 bits is what the
+// large receivers expect; its record is longer than the 255 bytes of one TXT
+// string, so the DNS service splits it into several strings of one record.
+const dkimKeyBits = 2048
+
+// EnsureDKIMKey creates <dir>/dkim/<selector>.key (RSA, PKCS#8) if missing
+// and returns its path and key. An existing key is reused so the published
+// DNS record stays valid across restarts.
+func EnsureDKIMKey(dir, selector string) (string, *rsa.PrivateKey, error) {
+	if selector == "" || strings.ContainsAny(selector, `/\`) {
+		return "", nil, fmt.Errorf("invalid dkim selector %q", selector)
+	}
+	dkimDir := filepath.Join(dir, "dkim")
+	kf := keyPath(dkimDir, selector)
+	if exists(kf) {
+		data, err := os.ReadFile(kf)
+		if err != nil {
+			return "", nil, err
+		}
+		key, err := DecodeDKIMKey(data)
+		if err != nil {
+			return "", nil, fmt.Errorf("dkim key %s: %w", kf, err)
+		}
+		return kf, key, nil
+	}
+
+	if err := ensureDir(dkimDir); err != nil {
+		return "", nil, err
+	}
+	key, err := GenerateDKIMKey()
+	if err != nil {
+		return "", nil, err
+	}
+	data, err := EncodeDKIMKey(key)
+	if err != nil {
+		return "", nil, err
+	}
+	if err := os.WriteFile(kf, data, 0o400); err != nil {
+		return "", nil, err
+	}
+	return kf, key, nil
+}
+
+// GenerateDKIMKey returns a new RSA key of dkimKeyBits.
+func GenerateDKIMKey() (*rsa.PrivateKey, error) {
+	return rsa.GenerateKey(rand.Reader, dkimKeyBits)
+}
+
+// EncodeDKIMKey returns key as a PKCS#8 PEM block.
+func EncodeDKIMKey(key *rsa.PrivateKey) ([]byte, error) {
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), nil
+}
+
+// DecodeDKIMKey parses an RSA key in a PEM block, PKCS#8 or PKCS#1.
+func DecodeDKIMKey(data []byte) (*rsa.PrivateKey, error) {
+	blk, _ := pem.Decode(data)
+	if blk == nil {
+		return nil, fmt.Errorf("no PEM block")
+	}
+	signer, err := parseAnyPrivateKey(blk)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := signer.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA key")
+	}
+	return key, nil
+}
+
+// DKIMRecord returns the TXT record value publishing pub, to be served at
+// <selector>._domainkey.<domain>.
+func DKIMRecord(pub *rsa.PublicKey) (string, error) {
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return "", err
+	}
+	return "v=DKIM1; k=rsa; p=" + base64.StdEncoding.EncodeToString(der), nil
+}
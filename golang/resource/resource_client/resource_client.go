@@ -1284,6 +1284,23 @@ func (client *Resource_Client) CreateApplication(token, id, name, domain, passwo
 	return nil
 }
 
+/**
+ * Create an application from a complete description, including its password
+ * and the redirect URIs of an OpenID Connect client.
+ */
+func (client *Resource_Client) RegisterApplication(token string, a *resourcepb.Application) error {
+	rqst := &resourcepb.CreateApplicationRqst{
+		Application: a,
+	}
+
+	// set the token in the context...
+	md := metadata.New(map[string]string{"token": string(token), "application": a.Id, "domain": client.domain, "organization": a.PublisherID})
+	ctx := metadata.NewOutgoingContext(client.GetCtx(), md)
+
+	_, err := client.c.CreateApplication(ctx, rqst)
+	return err
+}
+
 func (client *Resource_Client) UpdateApplication(token string, a *resourcepb.Application) error {
 	_, err := security.ValidateToken(token)
 	if err != nil {
@@ -10,6 +10,7 @@ import (
 	"time"
 
 	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/persistence/persistence_store"
 	"github.com/globulario/services/golang/rbac/rbacpb"
 	"github.com/globulario/services/golang/resource/resourcepb"
 	"github.com/globulario/services/golang/security"
@@ -1086,8 +1087,13 @@ func (srv *server) GetAccount(ctx context.Context, rqst *resourcepb.GetAccountRq
 			values = uuidVals
 		} else {
 			logger.Info("log", "args", []interface{}{"fail to retrieve account:", accountId, " from database with error:", err})
+			// Tell an unknown account from a store that does not answer.
+			code := codes.Internal
+			if count, cerr := p.Count(context.Background(), "local_resource", "local_resource", "Accounts", q, ""); cerr == nil && count == 0 {
+				code = codes.NotFound
+			}
 			return nil, status.Errorf(
-				codes.Internal,
+				code,
 				"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 		}
 	}
@@ -1963,20 +1969,12 @@ func (srv *server) RegisterAccount(ctx context.Context, rqst *resourcepb.Registe
 //  3. Deletes the reference from the group to the account ("groups" in "Accounts").
 //  4. Publishes update events for both the group and the account.
 func (srv *server) RemoveGroupMemberAccount(ctx context.Context, rqst *resourcepb.RemoveGroupMemberAccountRqst) (*resourcepb.RemoveGroupMemberAccountRsp, error) {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	// That service made user of persistence service.
-	err = srv.deleteReference(p, rqst.AccountId, rqst.GroupId, "accounts", "Groups")
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-	}
-
-	err = srv.deleteReference(p, rqst.GroupId, rqst.AccountId, "groups", "Accounts")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.AccountId, rqst.GroupId, "accounts", "Groups"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.GroupId, rqst.AccountId, "groups", "Accounts")
+	})
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -1994,17 +1992,12 @@ func (srv *server) RemoveGroupMemberAccount(ctx context.Context, rqst *resourcep
 // and returns a response indicating the result.
 // Returns an error if the persistence store cannot be accessed or if reference deletion fails.
 func (srv *server) RemoveOrganizationAccount(ctx context.Context, rqst *resourcepb.RemoveOrganizationAccountRqst) (*resourcepb.RemoveOrganizationAccountRsp, error) {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.AccountId, rqst.OrganizationId, "accounts", "Organizations")
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.OrganizationId, rqst.AccountId, "organizations", "Accounts")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.AccountId, rqst.OrganizationId, "accounts", "Organizations"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.OrganizationId, rqst.AccountId, "organizations", "Accounts")
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2020,17 +2013,12 @@ func (srv *server) RemoveOrganizationAccount(ctx context.Context, rqst *resource
 // After successful removal, it publishes update events for both the organization and the application.
 // Returns a response indicating the result of the operation or an error if the removal fails.
 func (srv *server) RemoveOrganizationApplication(ctx context.Context, rqst *resourcepb.RemoveOrganizationApplicationRqst) (*resourcepb.RemoveOrganizationApplicationRsp, error) {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.ApplicationId, rqst.OrganizationId, "applications", "Organizations")
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.OrganizationId, rqst.ApplicationId, "organizations", "Applications")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.ApplicationId, rqst.OrganizationId, "applications", "Organizations"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.OrganizationId, rqst.ApplicationId, "organizations", "Applications")
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -2046,17 +2034,12 @@ func (srv *server) RemoveOrganizationApplication(ctx context.Context, rqst *reso
 // After successful removal, it publishes update events for both the organization and the group.
 // Returns a response indicating the result of the operation or an error if any step fails.
 func (srv *server) RemoveOrganizationGroup(ctx context.Context, rqst *resourcepb.RemoveOrganizationGroupRqst) (*resourcepb.RemoveOrganizationGroupRsp, error) {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.GroupId, rqst.OrganizationId, "groups", "Organizations")
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.OrganizationId, rqst.GroupId, "organizations", "Groups")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.GroupId, rqst.OrganizationId, "groups", "Organizations"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.OrganizationId, rqst.GroupId, "organizations", "Groups")
+	})
 	if err != nil {
 		return nil, err
 	}
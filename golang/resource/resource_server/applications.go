@@ -380,7 +380,20 @@ func (srv *server) getApplications(query string, options string) ([]*resourcepb.
 			}
 		}
 
-		application := &resourcepb.Application{Id: values_["_id"].(string), Uuid: Utility.ToString(values_["uuid"]), Name: values_["name"].(string), Domain: values_["domain"].(string), Path: values_["path"].(string), CreationDate: creationDate, LastDeployed: lastDeployed, Alias: values_["alias"].(string), Icon: values_["icon"].(string), Description: values_["description"].(string), PublisherID: values_["PublisherID"].(string), Version: values_["version"].(string), Actions: actions, Keywords: keywords}
+		// OIDC redirect URIs, for applications registered as OIDC clients.
+		redirectUris := make([]string, 0)
+		switch uris := values_["redirect_uris"].(type) {
+		case primitive.A:
+			for _, u := range uris {
+				redirectUris = append(redirectUris, Utility.ToString(u))
+			}
+		case []interface{}:
+			for _, u := range uris {
+				redirectUris = append(redirectUris, Utility.ToString(u))
+			}
+		}
+
+		application := &resourcepb.Application{Id: values_["_id"].(string), Uuid: Utility.ToString(values_["uuid"]), Name: values_["name"].(string), Domain: values_["domain"].(string), Path: values_["path"].(string), CreationDate: creationDate, LastDeployed: lastDeployed, Alias: values_["alias"].(string), Icon: values_["icon"].(string), Description: values_["description"].(string), PublisherID: values_["PublisherID"].(string), Version: values_["version"].(string), Actions: actions, Keywords: keywords, RedirectUris: redirectUris}
 
 		// TODO validate token...
 		application.Password = values_["password"].(string)
@@ -682,6 +695,7 @@ func (srv *server) save_application(ctx context.Context, app *resourcepb.Applica
 	application["description"] = app.Description
 	application["actions"] = app.Actions
 	application["keywords"] = app.Keywords
+	application["redirect_uris"] = app.RedirectUris
 	application["icon"] = app.Icon
 	application["alias"] = app.Alias
 
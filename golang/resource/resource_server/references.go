@@ -38,6 +38,50 @@ func inferCollectionFromField(field string) string {
 	return f
 }
 
+// execLinkStatement runs a statement on a link table of the SQL or Scylla
+// store. p may be a transaction, in which case the statement joins it.
+func execLinkStatement(p persistence_store.Store, stmt string, args ...interface{}) error {
+	switch s := p.(type) {
+	case interface {
+		ExecContext(connectionId string, database string, query string, parameters []interface{}, tx_ int) (string, error)
+	}:
+		_, err := s.ExecContext("local_resource", "local_resource", stmt, args, 0)
+		return err
+	case interface {
+		Exec(ctx context.Context, connectionId, keyspace, stmt string, args ...interface{}) error
+	}:
+		return s.Exec(context.Background(), "local_resource", "local_resource", stmt, args...)
+	}
+	return errors.New("store " + p.GetStoreType() + " has no link tables")
+}
+
+// withTransaction runs fn in a transaction of the local_resource store and
+// commits it when fn succeeds, so multi-step writes are applied entirely or
+// not at all. Backends without transactions run fn on the store directly.
+func (srv *server) withTransaction(fn func(p persistence_store.Store) error) error {
+	p, err := srv.getPersistenceStore()
+	if err != nil {
+		return err
+	}
+	transactional, ok := p.(persistence_store.Transactional)
+	if !ok {
+		return fn(p)
+	}
+	tx, err := transactional.BeginTransaction(context.Background(), "local_resource")
+	if errors.Is(err, persistence_store.ErrTransactionsNotSupported) {
+		return fn(p)
+	} else if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		if rbErr := tx.Rollback(context.Background()); rbErr != nil {
+			logger.Warn("transaction rollback failed", "err", rbErr)
+		}
+		return err
+	}
+	return tx.Commit(context.Background())
+}
+
 /************************************************************/
 
 func (srv *server) deleteReference(p persistence_store.Store, refId, targetId, targetField, targetCollection string) error {
@@ -119,19 +163,8 @@ func (srv *server) deleteReference(p persistence_store.Store, refId, targetId, t
 			src, dst = rid, tid
 		}
 
-		if p.GetStoreType() == "SCYLLA" {
-			session := p.(*persistence_store.ScyllaStore).GetSession("local_resource")
-			if session == nil {
-				return errors.New("fail to get session for local_resource")
-			}
-			del := fmt.Sprintf("DELETE FROM %s WHERE source_id=? AND target_id=?", linkTable)
-			return session.Query(del, src, dst).Exec()
-		}
-
-		// SQL
 		del := fmt.Sprintf("DELETE FROM %s WHERE source_id=? AND target_id=?", linkTable)
-		_, err := p.(*persistence_store.SqlStore).ExecContext("local_resource", "local_resource", del, []interface{}{src, dst}, 0)
-		return err
+		return execLinkStatement(p, del, src, dst)
 	}
 
 	// Unknown store type — no-op to keep parity with existing behavior
@@ -139,14 +172,12 @@ func (srv *server) deleteReference(p persistence_store.Store, refId, targetId, t
 }
 
 func (srv *server) createCrossReferences(sourceId, sourceCollection, sourceField, targetId, targetCollection, targetField string) error {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return err
-	}
-	if err = srv.createReference(p, targetId, targetCollection, targetField, sourceId, sourceCollection); err != nil {
-		return err
-	}
-	return srv.createReference(p, sourceId, sourceCollection, sourceField, targetId, targetCollection)
+	return srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.createReference(p, targetId, targetCollection, targetField, sourceId, sourceCollection); err != nil {
+			return err
+		}
+		return srv.createReference(p, sourceId, sourceCollection, sourceField, targetId, targetCollection)
+	})
 }
 
 func (srv *server) createReference(p persistence_store.Store, id, sourceCollection, field, targetId, targetCollection string) error {
@@ -220,18 +251,8 @@ func (srv *server) createReference(p persistence_store.Store, id, sourceCollecti
 			linkTable,
 		)
 
-		if p.GetStoreType() == "SCYLLA" {
-			session := p.(*persistence_store.ScyllaStore).GetSession("local_resource")
-			if session == nil {
-				return errors.New("fail to get session for local_resource")
-			}
-			if err := session.Query(create).Exec(); err != nil {
-				return err
-			}
-		} else {
-			if _, err := p.(*persistence_store.SqlStore).ExecContext("local_resource", "local_resource", create, nil, 0); err != nil {
-				return err
-			}
+		if err := execLinkStatement(p, create); err != nil {
+			return err
 		}
 
 		// choose (source_id,target_id) according to canonical order
@@ -242,18 +263,13 @@ func (srv *server) createReference(p persistence_store.Store, id, sourceCollecti
 
 		// idempotent insert (Scylla INSERT is upsert; SQL has PK to avoid dup rows)
 		if p.GetStoreType() == "SCYLLA" {
-			session := p.(*persistence_store.ScyllaStore).GetSession("local_resource")
-			if session == nil {
-				return errors.New("fail to get session for local_resource")
-			}
 			q := fmt.Sprintf("INSERT INTO %s (source_id, target_id) VALUES (?,?)", linkTable)
-			return session.Query(q, src, dst).Exec()
+			return execLinkStatement(p, q, src, dst)
 		}
 
 		// SQL
 		q := fmt.Sprintf("INSERT OR IGNORE INTO %s (source_id, target_id) VALUES (?,?)", linkTable)
-		_, err := p.(*persistence_store.SqlStore).ExecContext("local_resource", "local_resource", q, []interface{}{src, dst}, 0)
-		return err
+		return execLinkStatement(p, q, src, dst)
 	}
 
 	return nil
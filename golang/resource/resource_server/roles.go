@@ -6,6 +6,7 @@ import (
 	"strings"
 
 	"github.com/globulario/services/golang/config"
+	"github.com/globulario/services/golang/persistence/persistence_store"
 	"github.com/globulario/services/golang/rbac/rbacpb"
 	"github.com/globulario/services/golang/resource/resourcepb"
 	"github.com/globulario/services/golang/security"
@@ -44,19 +45,12 @@ func (srv *server) RemoveGroupRole(ctx context.Context, rqst *resourcepb.RemoveG
 		rqst.GroupId = rqst.GroupId + "@" + srv.Domain
 	}
 
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.GroupId, rqst.RoleId, "roles", "Groups")
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-	}
-
-	err = srv.deleteReference(p, rqst.RoleId, rqst.GroupId, "groups", "Roles")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.GroupId, rqst.RoleId, "roles", "Groups"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.RoleId, rqst.GroupId, "groups", "Roles")
+	})
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -584,20 +578,12 @@ func (srv *server) GetRoles(rqst *resourcepb.GetRolesRqst, stream resourcepb.Res
 func (srv *server) RemoveAccountRole(ctx context.Context, rqst *resourcepb.RemoveAccountRoleRqst) (*resourcepb.RemoveAccountRoleRsp, error) {
 
 	// That service made user of persistence service.
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	// That service made user of persistence service.
-	err = srv.deleteReference(p, rqst.AccountId, rqst.RoleId, "accounts", "Roles")
-	if err != nil {
-		return nil, status.Errorf(
-			codes.Internal,
-			"%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
-	}
-
-	err = srv.deleteReference(p, rqst.RoleId, rqst.AccountId, "roles", "Accounts")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.AccountId, rqst.RoleId, "accounts", "Roles"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.RoleId, rqst.AccountId, "roles", "Accounts")
+	})
 	if err != nil {
 		return nil, status.Errorf(
 			codes.Internal,
@@ -615,17 +601,12 @@ func (srv *server) RemoveAccountRole(ctx context.Context, rqst *resourcepb.Remov
 // After successful removal, it publishes update events for both the organization and the role.
 // Returns a response indicating the result of the operation or an error if any step fails.
 func (srv *server) RemoveOrganizationRole(ctx context.Context, rqst *resourcepb.RemoveOrganizationRoleRqst) (*resourcepb.RemoveOrganizationRoleRsp, error) {
-	p, err := srv.getPersistenceStore()
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.RoleId, rqst.OrganizationId, "roles", "Organizations")
-	if err != nil {
-		return nil, err
-	}
-
-	err = srv.deleteReference(p, rqst.OrganizationId, rqst.RoleId, "organizations", "Roles")
+	err := srv.withTransaction(func(p persistence_store.Store) error {
+		if err := srv.deleteReference(p, rqst.RoleId, rqst.OrganizationId, "roles", "Organizations"); err != nil {
+			return err
+		}
+		return srv.deleteReference(p, rqst.OrganizationId, rqst.RoleId, "organizations", "Roles")
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -3131,7 +3131,11 @@ type Application struct {
 	// uuid is the application's opaque, immutable membership identity — minted once
 	// at creation, never derived from a mutable attribute (see Account.uuid).
 	// Distinct from `id` (a mutable handle). Additive during the identity migration.
-	Uuid          string `protobuf:"bytes,16,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	Uuid string `protobuf:"bytes,16,opt,name=uuid,proto3" json:"uuid,omitempty"`
+	// redirect_uris makes the application an OpenID Connect client of the
+	// authentication service: authorization responses are only sent to these
+	// exact URIs. Empty for applications that are not OIDC clients.
+	RedirectUris  []string `protobuf:"bytes,17,rep,name=redirect_uris,json=redirectUris,proto3" json:"redirect_uris,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -3278,6 +3282,13 @@ func (x *Application) GetUuid() string {
 	return ""
 }
 
+func (x *Application) GetRedirectUris() []string {
+	if x != nil {
+		return x.RedirectUris
+	}
+	return nil
+}
+
 // * Request to create or update an application.
 type CreateApplicationRqst struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -9059,7 +9070,7 @@ const file_resource_proto_rawDesc = "" +
 	"\x06roleId\x18\x01 \x01(\tB\f\x8a\xb5\x18\b\n" +
 	"\x04role\x10\x01R\x06roleId\"'\n" +
 	"\rDeleteRoleRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\xb1\x03\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xd6\x03\n" +
 	"\vApplication\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
 	"\x04name\x18\x02 \x01(\tR\x04name\x12\x16\n" +
@@ -9077,7 +9088,8 @@ const file_resource_proto_rawDesc = "" +
 	"\rcreation_date\x18\r \x01(\x03R\fcreationDate\x12#\n" +
 	"\rlast_deployed\x18\x0e \x01(\x03R\flastDeployed\x12\x1a\n" +
 	"\btypeName\x18\x0f \x01(\tR\btypeName\x12\x12\n" +
-	"\x04uuid\x18\x10 \x01(\tR\x04uuid\"P\n" +
+	"\x04uuid\x18\x10 \x01(\tR\x04uuid\x12#\n" +
+	"\rredirect_uris\x18\x11 \x03(\tR\fredirectUris\"P\n" +
 	"\x15CreateApplicationRqst\x127\n" +
 	"\vapplication\x18\x01 \x01(\v2\x15.resource.ApplicationR\vapplication\"\x16\n" +
 	"\x14CreateApplicationRsp\"j\n" +
@@ -299,6 +299,127 @@ func (client *Search_Client) SearchDocuments(paths []string, query string, langu
 
 }
 
+/**
+ * Search indexes and aliases together and return one page of hits ranked
+ * and sorted across all of them, with the requested facets. Pass the
+ * returned NextPageToken back in the request to get the next page.
+ */
+func (client *Search_Client) SearchDocumentsV2(rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error) {
+	return client.c.SearchDocumentsV2(client.GetCtx(), rqst)
+}
+
+/**
+ * Create or replace an alias over a set of index paths.
+ */
+func (client *Search_Client) SetIndexAlias(alias string, paths []string) error {
+	_, err := client.c.SetIndexAlias(client.GetCtx(), &searchpb.SetIndexAliasRequest{Alias: alias, Paths: paths})
+	return err
+}
+
+/**
+ * Remove an alias; the indexes behind it are left untouched.
+ */
+func (client *Search_Client) DeleteIndexAlias(alias string) error {
+	_, err := client.c.DeleteIndexAlias(client.GetCtx(), &searchpb.DeleteIndexAliasRequest{Alias: alias})
+	return err
+}
+
+/**
+ * Return the aliases and their index paths.
+ */
+func (client *Search_Client) ListIndexAliases() ([]*searchpb.IndexAlias, error) {
+	rsp, err := client.c.ListIndexAliases(client.GetCtx(), &searchpb.ListIndexAliasesRequest{})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Aliases, nil
+}
+
+// bulkIndexChunk is the number of documents sent per BulkIndex message.
+const bulkIndexChunk = 500
+
+/**
+ * Index documents in bulk. Each document is a JSON object holding its id in
+ * idField. Returns the outcome of each document, by position.
+ */
+func (client *Search_Client) BulkIndex(path string, idField string, docs []*searchpb.BulkDocument) (*searchpb.BulkIndexResponse, error) {
+	stream, err := client.c.BulkIndex(client.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	for start := 0; start < len(docs) || start == 0; start += bulkIndexChunk {
+		end := start + bulkIndexChunk
+		if end > len(docs) {
+			end = len(docs)
+		}
+		rqst := &searchpb.BulkIndexRequest{Path: path, IdField: idField, Documents: docs[start:end]}
+		if err := stream.Send(rqst); err != nil {
+			if err == io.EOF {
+				break // the server closed the stream; CloseAndRecv has the status
+			}
+			return nil, err
+		}
+	}
+	return stream.CloseAndRecv()
+}
+
+/**
+ * Create an empty index with an explicit mapping.
+ */
+func (client *Search_Client) CreateIndex(path string, mapping *searchpb.IndexMapping) error {
+	_, err := client.c.CreateIndex(client.GetCtx(), &searchpb.CreateIndexRequest{Path: path, Mapping: mapping})
+	return err
+}
+
+/**
+ * Return the mapping an index was created with, and its effective mapping
+ * as JSON.
+ */
+func (client *Search_Client) GetIndexMapping(path string) (*searchpb.IndexMapping, string, error) {
+	rsp, err := client.c.GetIndexMapping(client.GetCtx(), &searchpb.GetIndexMappingRequest{Path: path})
+	if err != nil {
+		return nil, "", err
+	}
+	return rsp.Mapping, rsp.MappingJson, nil
+}
+
+/**
+ * List the indexes whose path starts with prefix.
+ */
+func (client *Search_Client) ListIndexes(prefix string) ([]*searchpb.IndexInfo, error) {
+	rsp, err := client.c.ListIndexes(client.GetCtx(), &searchpb.ListIndexesRequest{Prefix: prefix})
+	if err != nil {
+		return nil, err
+	}
+	return rsp.Indexes, nil
+}
+
+/**
+ * Rebuild source into a new index at target, then point alias at it and
+ * optionally delete source. Returns the number of documents copied.
+ */
+func (client *Search_Client) ReindexTo(source, target string, mapping *searchpb.IndexMapping, alias string, deleteSource bool) (int64, error) {
+	rsp, err := client.c.ReindexTo(client.GetCtx(), &searchpb.ReindexToRequest{
+		Source:       source,
+		Target:       target,
+		Mapping:      mapping,
+		Alias:        alias,
+		DeleteSource: deleteSource,
+	})
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Documents, nil
+}
+
+/**
+ * Close an index and remove it from disk.
+ */
+func (client *Search_Client) DeleteIndex(path string) error {
+	_, err := client.c.DeleteIndex(client.GetCtx(), &searchpb.DeleteIndexRequest{Path: path})
+	return err
+}
+
 /**
  * Count the number of document in a given database.
  */
@@ -0,0 +1,595 @@
+package search_engine
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/mapping"
+	"github.com/globulario/services/golang/search/searchpb"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	// Language analyzers usable in mappings, by language code.
+	_ "github.com/blevesearch/bleve/v2/analysis/analyzer/simple"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/cjk"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/de"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/en"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/es"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/fr"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/it"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/nl"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/pt"
+	_ "github.com/blevesearch/bleve/v2/analysis/lang/ru"
+)
+
+// -----------------------------------------------------------------------------
+// admin.go — index management
+//
+// Indexes can be created with an explicit mapping, listed, inspected, rebuilt
+// into a new index and dropped. The engine remembers the indexes it has
+// opened in a registry file so ListIndexes also reports the ones that are not
+// open yet. Each document keeps its source object in the index, which is what
+// ReindexTo copies; documents indexed before sources were kept are rebuilt
+// from their stored fields.
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrUnknownIndex is returned when an index does not exist.
+	ErrUnknownIndex = errors.New("unknown index")
+
+	// ErrIndexExists is returned when creating an index over an existing one.
+	ErrIndexExists = errors.New("index already exists")
+
+	// ErrIndexInUse is returned when deleting an index an alias points to.
+	ErrIndexInUse = errors.New("index is used by an alias")
+
+	// ErrInvalidMapping wraps the errors of a malformed mapping.
+	ErrInvalidMapping = errors.New("invalid index mapping")
+)
+
+const (
+	// reindexPageSize is the number of documents copied per batch.
+	reindexPageSize = 500
+
+	// mappingKey keeps the mapping an index was created with.
+	mappingKey = "\x00globular:mapping"
+)
+
+// sourceKey is the internal key of the source object of a document.
+func sourceKey(id string) []byte {
+	return []byte("\x00globular:src:" + id)
+}
+
+// SetIndexRegistryFile loads the registry of known indexes from file, if
+// any, and saves it there when indexes are opened or deleted.
+func (engine *BleveSearchEngine) SetIndexRegistryFile(file string) error {
+	var paths []string
+	data, err := os.ReadFile(file)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &paths); err != nil {
+			return fmt.Errorf("read index registry %s: %w", file, err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("read index registry %s: %w", file, err)
+	}
+
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	engine.registryFile = file
+	engine.known = make(map[string]bool, len(paths))
+	for _, p := range paths {
+		engine.known[p] = true
+	}
+	for p := range engine.indexs {
+		engine.trackIndex(p)
+	}
+	return nil
+}
+
+// trackIndex adds a path to the registry. Callers hold engine.mu.
+func (engine *BleveSearchEngine) trackIndex(path string) {
+	if engine.known == nil {
+		engine.known = make(map[string]bool)
+	}
+	if engine.known[path] {
+		return
+	}
+	engine.known[path] = true
+	engine.saveRegistry()
+}
+
+// untrackIndex removes a path from the registry. Callers hold engine.mu.
+func (engine *BleveSearchEngine) untrackIndex(path string) {
+	if !engine.known[path] {
+		return
+	}
+	delete(engine.known, path)
+	engine.saveRegistry()
+}
+
+// saveRegistry writes the registry file. The registry only feeds
+// ListIndexes, so a failed write is logged rather than failing the caller.
+// Callers hold engine.mu.
+func (engine *BleveSearchEngine) saveRegistry() {
+	if engine.registryFile == "" {
+		return
+	}
+	paths := make([]string, 0, len(engine.known))
+	for p := range engine.known {
+		paths = append(paths, p)
+	}
+	sort.Strings(paths)
+	data, _ := json.MarshalIndent(paths, "", "  ")
+	err := os.MkdirAll(filepath.Dir(engine.registryFile), 0o755)
+	if err == nil {
+		tmp := engine.registryFile + ".tmp"
+		if err = os.WriteFile(tmp, data, 0o644); err == nil {
+			err = os.Rename(tmp, engine.registryFile)
+		}
+	}
+	if err != nil {
+		logger.Warn("save index registry failed", "file", engine.registryFile, "err", err)
+	}
+}
+
+// buildMapping converts a mapping of the API to a Bleve index mapping.
+func buildMapping(m *searchpb.IndexMapping) (*mapping.IndexMappingImpl, error) {
+	im := bleve.NewIndexMapping()
+	if m == nil {
+		return im, nil
+	}
+	if m.GetDefaultAnalyzer() != "" {
+		im.DefaultAnalyzer = m.GetDefaultAnalyzer()
+	}
+	im.DefaultMapping.Dynamic = !m.GetStrict()
+
+	for _, f := range m.GetFields() {
+		name := strings.TrimSpace(f.GetName())
+		if name == "" {
+			return nil, fmt.Errorf("%w: field without name", ErrInvalidMapping)
+		}
+
+		// "a.b.c" maps field c of sub-document a.b.
+		parts := strings.Split(name, ".")
+		dm := im.DefaultMapping
+		for _, part := range parts[:len(parts)-1] {
+			sub, ok := dm.Properties[part]
+			if !ok {
+				sub = bleve.NewDocumentMapping()
+				sub.Dynamic = dm.Dynamic
+				dm.AddSubDocumentMapping(part, sub)
+			}
+			dm = sub
+		}
+		last := parts[len(parts)-1]
+
+		if f.GetType() == searchpb.FieldType_FIELD_VECTOR {
+			if f.GetDims() <= 0 || f.GetDims() > maxVectorDims {
+				return nil, fmt.Errorf("%w: vector field %q needs between 1 and %d dims", ErrInvalidMapping, name, maxVectorDims)
+			}
+			if f.GetAnalyzer() != "" {
+				return nil, fmt.Errorf("%w: analyzer set on vector field %q", ErrInvalidMapping, name)
+			}
+			// Vectors are kept beside the index, not in it.
+			dm.AddSubDocumentMapping(last, bleve.NewDocumentDisabledMapping())
+			continue
+		}
+		if f.GetDims() != 0 || len(f.GetSourceFields()) > 0 {
+			return nil, fmt.Errorf("%w: dims and source_fields set on non-vector field %q", ErrInvalidMapping, name)
+		}
+
+		var fm *mapping.FieldMapping
+		switch f.GetType() {
+		case searchpb.FieldType_FIELD_TEXT:
+			fm = bleve.NewTextFieldMapping()
+		case searchpb.FieldType_FIELD_KEYWORD:
+			fm = bleve.NewKeywordFieldMapping()
+		case searchpb.FieldType_FIELD_NUMERIC:
+			fm = bleve.NewNumericFieldMapping()
+		case searchpb.FieldType_FIELD_DATETIME:
+			fm = bleve.NewDateTimeFieldMapping()
+		case searchpb.FieldType_FIELD_BOOLEAN:
+			fm = bleve.NewBooleanFieldMapping()
+		default:
+			return nil, fmt.Errorf("%w: field %q has unknown type %v", ErrInvalidMapping, name, f.GetType())
+		}
+		if f.GetAnalyzer() != "" {
+			if f.GetType() != searchpb.FieldType_FIELD_TEXT {
+				return nil, fmt.Errorf("%w: analyzer set on non-text field %q", ErrInvalidMapping, name)
+			}
+			fm.Analyzer = f.GetAnalyzer()
+		}
+		fm.Store = f.GetStore()
+		fm.Index = !f.GetIndexDisabled()
+		dm.AddFieldMappingsAt(last, fm)
+	}
+	if err := im.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidMapping, err)
+	}
+	return im, nil
+}
+
+// indexExists tells if there is an index at path.
+func indexExists(path string) bool {
+	return Utility.Exists(filepath.Join(path, "index_meta.json"))
+}
+
+// createIndex creates an index at path with a Bleve mapping and keeps the
+// API mapping it was built from, if any.
+func (engine *BleveSearchEngine) createIndex(path string, im mapping.IndexMapping, m *searchpb.IndexMapping) (bleve.Index, error) {
+	path = filepath.Clean(path)
+	engine.mu.RLock()
+	_, open := engine.indexs[path]
+	engine.mu.RUnlock()
+	if open || indexExists(path) {
+		return nil, fmt.Errorf("%w: %s", ErrIndexExists, path)
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("create index directory failed: %w", err)
+	}
+	if entries, err := os.ReadDir(path); err == nil && len(entries) == 0 {
+		_ = os.Remove(path) // bleve.New wants to create the directory itself
+	}
+	index, err := bleve.New(path, im)
+	if err != nil {
+		return nil, fmt.Errorf("create bleve index failed: %w", err)
+	}
+	if m != nil {
+		data, _ := protojson.Marshal(m)
+		if err := index.SetInternal([]byte(mappingKey), data); err != nil {
+			_ = index.Close()
+			_ = os.RemoveAll(path)
+			return nil, fmt.Errorf("store mapping failed: %w", err)
+		}
+	}
+	logger.Info("created bleve index", "path", path)
+
+	engine.mu.Lock()
+	engine.indexs[path] = index
+	engine.trackIndex(path)
+	engine.mu.Unlock()
+	engine.dropVectors(path)
+	return index, nil
+}
+
+// CreateIndex creates an empty index at path with the given mapping. A nil
+// mapping indexes every field dynamically, like indexes created on first use.
+func (engine *BleveSearchEngine) CreateIndex(path string, m *searchpb.IndexMapping) error {
+	if strings.TrimSpace(path) == "" {
+		return errors.New("index path is empty")
+	}
+	im, err := buildMapping(m)
+	if err != nil {
+		return err
+	}
+	_, err = engine.createIndex(path, im, m)
+	return err
+}
+
+// GetIndexMapping returns the mapping an index was created with, nil when it
+// was created implicitly, and the effective Bleve mapping as JSON.
+func (engine *BleveSearchEngine) GetIndexMapping(path string) (*searchpb.IndexMapping, string, error) {
+	index, err := engine.openIndex(path, false)
+	if err != nil {
+		return nil, "", err
+	}
+	data, err := json.Marshal(index.Mapping())
+	if err != nil {
+		return nil, "", fmt.Errorf("marshal mapping failed: %w", err)
+	}
+	return storedMapping(index), string(data), nil
+}
+
+// dirSize returns the size of the files under a directory.
+func dirSize(path string) int64 {
+	var size int64
+	_ = filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err == nil && !d.IsDir() {
+			if info, err := d.Info(); err == nil {
+				size += info.Size()
+			}
+		}
+		return nil
+	})
+	return size
+}
+
+// ListIndexes returns the known indexes whose path starts with prefix: the
+// registered, open and aliased ones that exist on disk.
+func (engine *BleveSearchEngine) ListIndexes(prefix string) []*searchpb.IndexInfo {
+	engine.mu.RLock()
+	paths := make(map[string]bool)
+	for p := range engine.known {
+		paths[p] = false
+	}
+	for p := range engine.indexs {
+		paths[p] = true
+	}
+	usedBy := make(map[string][]string)
+	for alias, targets := range engine.aliases {
+		for _, p := range targets {
+			if _, ok := paths[p]; !ok {
+				paths[p] = false
+			}
+			usedBy[p] = append(usedBy[p], alias)
+		}
+	}
+	engine.mu.RUnlock()
+
+	var out []*searchpb.IndexInfo
+	for p, open := range paths {
+		if !strings.HasPrefix(p, prefix) || !indexExists(p) {
+			continue
+		}
+		info := &searchpb.IndexInfo{Path: p, Open: open, DocCount: -1, SizeBytes: dirSize(p), Aliases: usedBy[p]}
+		sort.Strings(info.Aliases)
+		if open {
+			info.DocCount = int64(engine.Count(p))
+		}
+		out = append(out, info)
+	}
+	sort.Slice(out, func(i, j int) bool { return out[i].Path < out[j].Path })
+	return out
+}
+
+// DeleteIndex closes an index and removes it from disk. An index an alias
+// points to is not deleted; move the alias first.
+func (engine *BleveSearchEngine) DeleteIndex(path string) error {
+	path = filepath.Clean(path)
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	for alias, targets := range engine.aliases {
+		for _, p := range targets {
+			if p == path {
+				return fmt.Errorf("%w: %s", ErrIndexInUse, alias)
+			}
+		}
+	}
+	index, open := engine.indexs[path]
+	if !open && !indexExists(path) {
+		return fmt.Errorf("%w: %s", ErrUnknownIndex, path)
+	}
+	if open {
+		if err := index.Close(); err != nil {
+			logger.Warn("close index failed", "path", path, "err", err)
+		}
+		delete(engine.indexs, path)
+	}
+	if err := os.RemoveAll(path); err != nil {
+		return fmt.Errorf("remove index failed: %w", err)
+	}
+	engine.untrackIndex(path)
+	engine.dropVectors(path)
+	logger.Info("deleted bleve index", "path", path)
+	return nil
+}
+
+// unflatten rebuilds a nested object from dotted field names.
+func unflatten(fields map[string]interface{}) map[string]interface{} {
+	out := make(map[string]interface{})
+	for name, v := range fields {
+		parts := strings.Split(name, ".")
+		m := out
+		for _, part := range parts[:len(parts)-1] {
+			sub, ok := m[part].(map[string]interface{})
+			if !ok {
+				sub = make(map[string]interface{})
+				m[part] = sub
+			}
+			m = sub
+		}
+		m[parts[len(parts)-1]] = v
+	}
+	return out
+}
+
+// ReindexTo copies every document of source into a new index at target
+// built with mapping (the mapping of source when nil). When alias is set it
+// is then pointed at target alone, and source is deleted when deleteSource
+// is set. Writes to source during the copy are not carried over. Returns
+// the number of documents copied.
+func (engine *BleveSearchEngine) ReindexTo(ctx context.Context, source, target string, m *searchpb.IndexMapping, alias string, deleteSource bool) (int64, error) {
+	source, target = filepath.Clean(source), filepath.Clean(target)
+	if source == target {
+		return 0, errors.New("source and target are the same index")
+	}
+	if alias != "" && !validAlias(alias) {
+		return 0, errInvalidAlias
+	}
+	src, err := engine.openIndex(source, false)
+	if err != nil {
+		return 0, err
+	}
+
+	var im mapping.IndexMapping
+	if m != nil {
+		if im, err = buildMapping(m); err != nil {
+			return 0, err
+		}
+	} else {
+		// Same mapping as the source, typed mapping included.
+		m, _, _ = engine.GetIndexMapping(source)
+		data, err := json.Marshal(src.Mapping())
+		if err != nil {
+			return 0, fmt.Errorf("marshal mapping failed: %w", err)
+		}
+		copied := mapping.NewIndexMapping()
+		if err := json.Unmarshal(data, copied); err != nil {
+			return 0, fmt.Errorf("copy mapping failed: %w", err)
+		}
+		im = copied
+	}
+	dst, err := engine.createIndex(target, im, m)
+	if err != nil {
+		return 0, err
+	}
+	fail := func(err error) (int64, error) {
+		engine.mu.Lock()
+		_ = dst.Close()
+		delete(engine.indexs, target)
+		_ = os.RemoveAll(target)
+		engine.untrackIndex(target)
+		engine.mu.Unlock()
+		engine.dropVectors(target)
+		return 0, err
+	}
+
+	// Vectors are recomputed for the target mapping; those that cannot be
+	// (no embedder) are copied when the source has the same field.
+	dstFields := engine.vectorFields(dst, nil)
+
+	var copied int64
+	var after []string
+	for {
+		if err := ctx.Err(); err != nil {
+			return fail(err)
+		}
+		sr := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), reindexPageSize, 0, false)
+		sr.SortBy([]string{"_id"})
+		sr.Fields = []string{"*"}
+		if after != nil {
+			sr.SetSearchAfter(after)
+		}
+		res, err := src.SearchInContext(ctx, sr)
+		if err != nil {
+			return fail(fmt.Errorf("read source failed: %w", err))
+		}
+		if len(res.Hits) == 0 {
+			break
+		}
+		batch := dst.NewBatch()
+		for _, hit := range res.Hits {
+			var obj map[string]interface{}
+			srcData, _ := src.GetInternal(sourceKey(hit.ID))
+			if len(srcData) == 0 || json.Unmarshal(srcData, &obj) != nil {
+				obj = unflatten(hit.Fields)
+				srcData, _ = json.Marshal(obj)
+			}
+			vecs, err := engine.documentVectors(ctx, dstFields, obj)
+			if err != nil {
+				return fail(fmt.Errorf("index document %q failed: %w", hit.ID, err))
+			}
+			for _, f := range dstFields {
+				if _, ok := vecs[f.GetName()]; ok {
+					continue
+				}
+				raw, _ := src.GetInternal(vectorKey(f.GetName(), hit.ID))
+				if v := decodeVector(raw); len(v) == int(f.GetDims()) {
+					vecs[f.GetName()] = v
+				}
+			}
+			if err := batch.Index(hit.ID, obj); err != nil {
+				return fail(fmt.Errorf("index document %q failed: %w", hit.ID, err))
+			}
+			writeVectors(batch, hit.ID, dstFields, vecs)
+			batch.SetInternal(sourceKey(hit.ID), srcData)
+			if raw, err := src.GetInternal([]byte(hit.ID)); err == nil && raw != nil {
+				batch.SetInternal([]byte(hit.ID), raw)
+			}
+		}
+		if err := dst.Batch(batch); err != nil {
+			return fail(fmt.Errorf("write target failed: %w", err))
+		}
+		copied += int64(len(res.Hits))
+		after = res.Hits[len(res.Hits)-1].Sort
+	}
+
+	if alias != "" {
+		if err := engine.SetAlias(alias, []string{target}); err != nil {
+			return copied, fmt.Errorf("reindexed %d documents but alias swap failed: %w", copied, err)
+		}
+	}
+	if deleteSource {
+		if err := engine.DeleteIndex(source); err != nil {
+			return copied, fmt.Errorf("reindexed %d documents but source was kept: %w", copied, err)
+		}
+	}
+	logger.Info("reindexed", "source", source, "target", target, "documents", copied, "alias", alias)
+	return copied, nil
+}
+
+// IndexBatch indexes documents in one Bleve batch and returns one result
+// per document, in order. Each document is a JSON object holding its id in
+// idField.
+func (engine *BleveSearchEngine) IndexBatch(path string, idField string, docs []*searchpb.BulkDocument) ([]*searchpb.BulkIndexResult, error) {
+	if strings.TrimSpace(idField) == "" {
+		return nil, errors.New("id field name is empty")
+	}
+	index, err := engine.getIndex(path)
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([]*searchpb.BulkIndexResult, len(docs))
+	batch := index.NewBatch()
+	vectorFields := engine.vectorFields(index, nil)
+	var pending []*searchpb.BulkIndexResult
+	pendingVecs := make(map[string]map[string][]float32)
+	for i, doc := range docs {
+		res := &searchpb.BulkIndexResult{Position: int32(i)}
+		results[i] = res
+		id, obj, err := DocumentID(doc.GetJsonStr(), idField)
+		res.Id = id
+		if err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		vecs, err := engine.documentVectors(context.Background(), vectorFields, obj)
+		if err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		if err := batch.Index(id, obj); err != nil {
+			res.Error = err.Error()
+			continue
+		}
+		writeVectors(batch, id, vectorFields, vecs)
+		pendingVecs[id] = vecs
+		src, _ := json.Marshal(obj)
+		batch.SetInternal(sourceKey(id), src)
+		if doc.GetData() != "" {
+			batch.SetInternal([]byte(id), []byte(doc.GetData()))
+		} else {
+			batch.SetInternal([]byte(id), src)
+		}
+		pending = append(pending, res)
+	}
+	if len(pending) > 0 {
+		if err := index.Batch(batch); err != nil {
+			for _, res := range pending {
+				res.Error = err.Error()
+			}
+		} else if len(vectorFields) > 0 {
+			for id, vecs := range pendingVecs {
+				engine.cacheVectors(index.Name(), id, vectorFields, vecs)
+			}
+		}
+	}
+	return results, nil
+}
+
+// DocumentID parses a JSON object and returns the id it holds in idField.
+func DocumentID(jsonStr, idField string) (string, map[string]interface{}, error) {
+	var obj map[string]interface{}
+	if err := json.Unmarshal([]byte(jsonStr), &obj); err != nil {
+		return "", nil, fmt.Errorf("document must be a JSON object: %w", err)
+	}
+	rawID, ok := obj[idField]
+	if !ok {
+		return "", nil, fmt.Errorf("missing id field %q", idField)
+	}
+	id, ok := rawID.(string)
+	if !ok || strings.TrimSpace(id) == "" {
+		return "", nil, fmt.Errorf("id field %q must be a non-empty string", idField)
+	}
+	return id, obj, nil
+}
@@ -0,0 +1,121 @@
+package search_engine
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/globulario/services/golang/search/searchpb"
+)
+
+func countHits(t *testing.T, engine *BleveSearchEngine, rqst *searchpb.SearchDocumentsV2Request) uint64 {
+	t.Helper()
+	rsp, err := engine.SearchDocumentsV2(context.Background(), rqst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return rsp.Total
+}
+
+// TestIndexLifecycle walks an index through bulk indexing, a reindex with a
+// new mapping behind an alias, and deletion.
+func TestIndexLifecycle(t *testing.T) {
+	dir := t.TempDir()
+	engine := NewBleveSearchEngine()
+	t.Cleanup(func() { _ = engine.CloseAll() })
+	if err := engine.SetIndexRegistryFile(filepath.Join(dir, "indexes.json")); err != nil {
+		t.Fatal(err)
+	}
+	v1, v2 := filepath.Join(dir, "titles_v1"), filepath.Join(dir, "titles_v2")
+
+	if err := engine.CreateIndex(v1, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.CreateIndex(v1, nil); !errors.Is(err, ErrIndexExists) {
+		t.Errorf("second CreateIndex = %v, want ErrIndexExists", err)
+	}
+	results, err := engine.IndexBatch(v1, "id", []*searchpb.BulkDocument{
+		{JsonStr: `{"id":"t1","title":"Alien","genre":"Science Fiction","year":1979}`},
+		{JsonStr: `{"title":"no id"}`},
+		{JsonStr: `{"id":"t2","title":"Aliens","genre":"Science Fiction","year":1986}`, Data: `{"custom":true}`},
+		{JsonStr: `not json`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[0].Error != "" || results[1].Error == "" || results[2].Error != "" || results[3].Error == "" || results[2].Id != "t2" {
+		t.Fatalf("results = %v", results)
+	}
+	if err := engine.SetAlias("titles", []string{v1}); err != nil {
+		t.Fatal(err)
+	}
+
+	// The dynamic mapping analyzes genre, so an exact term filter misses.
+	exact := &searchpb.SearchDocumentsV2Request{
+		Paths:   []string{"titles"},
+		Filters: []*searchpb.SearchFilter{{Field: "genre", Terms: []string{"Science Fiction"}}},
+	}
+	if n := countHits(t, engine, exact); n != 0 {
+		t.Fatalf("exact genre hits = %d before the mapping change, want 0", n)
+	}
+
+	mapping := &searchpb.IndexMapping{
+		DefaultAnalyzer: "en",
+		Fields: []*searchpb.FieldMapping{
+			{Name: "genre", Type: searchpb.FieldType_FIELD_KEYWORD, Store: true},
+			{Name: "year", Type: searchpb.FieldType_FIELD_NUMERIC, Store: true},
+		},
+	}
+	n, err := engine.ReindexTo(context.Background(), v1, v2, mapping, "titles", true)
+	if err != nil || n != 2 {
+		t.Fatalf("ReindexTo = %d, %v", n, err)
+	}
+	if n := countHits(t, engine, exact); n != 2 {
+		t.Errorf("exact genre hits = %d after the mapping change, want 2", n)
+	}
+	// The en analyzer stems "aliens", so both titles match.
+	rsp, _ := engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{"titles"}, Query: "aliens", Sort: []*searchpb.SearchSort{{Field: "year", Descending: true}},
+	})
+	if len(rsp.GetHits()) != 2 || rsp.Hits[0].DocId != "t2" || rsp.Hits[0].Data != `{"custom":true}` {
+		t.Errorf("hits after reindex = %v; data must be carried over", rsp.GetHits())
+	}
+
+	got, js, err := engine.GetIndexMapping(v2)
+	if err != nil || got.GetDefaultAnalyzer() != "en" || len(got.GetFields()) != 2 || js == "" {
+		t.Errorf("GetIndexMapping = %v, %q, %v", got, js, err)
+	}
+	if _, _, err := engine.GetIndexMapping(v1); !errors.Is(err, ErrUnknownIndex) {
+		t.Errorf("source still exists after delete_source: %v", err)
+	}
+
+	list := engine.ListIndexes(dir)
+	if len(list) != 1 || list[0].Path != v2 || list[0].DocCount != 2 || len(list[0].Aliases) != 1 {
+		t.Errorf("ListIndexes = %v", list)
+	}
+	if err := engine.DeleteIndex(v2); !errors.Is(err, ErrIndexInUse) {
+		t.Errorf("DeleteIndex under an alias = %v, want ErrIndexInUse", err)
+	}
+	_ = engine.RemoveAlias("titles")
+	if err := engine.DeleteIndex(v2); err != nil {
+		t.Fatal(err)
+	}
+	if list := engine.ListIndexes(dir); len(list) != 0 {
+		t.Errorf("ListIndexes after delete = %v", list)
+	}
+}
+
+func TestBuildMappingRejects(t *testing.T) {
+	for name, m := range map[string]*searchpb.IndexMapping{
+		"no name":          {Fields: []*searchpb.FieldMapping{{Type: searchpb.FieldType_FIELD_TEXT}}},
+		"unknown analyzer": {Fields: []*searchpb.FieldMapping{{Name: "title", Analyzer: "klingon"}}},
+		"analyzer on num":  {Fields: []*searchpb.FieldMapping{{Name: "year", Type: searchpb.FieldType_FIELD_NUMERIC, Analyzer: "en"}}},
+		"vector no dims":   {Fields: []*searchpb.FieldMapping{{Name: "v", Type: searchpb.FieldType_FIELD_VECTOR}}},
+		"dims on text":     {Fields: []*searchpb.FieldMapping{{Name: "title", Dims: 8}}},
+	} {
+		if _, err := buildMapping(m); !errors.Is(err, ErrInvalidMapping) {
+			t.Errorf("%s: err = %v, want ErrInvalidMapping", name, err)
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package search_engine
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// -----------------------------------------------------------------------------
+// aliases.go — index aliases
+//
+// An alias names a set of index paths that are searched together, so callers
+// can query "titles" while the indexes behind it are added, split or rebuilt.
+// Aliases are resolved by SearchDocumentsV2 only; the path based methods keep
+// taking paths. When an alias file is set, aliases survive restarts.
+// -----------------------------------------------------------------------------
+
+var (
+	// ErrUnknownAlias is returned when removing an alias that does not exist.
+	ErrUnknownAlias = errors.New("unknown index alias")
+
+	errInvalidAlias = errors.New("alias must be a non-empty name without path separators")
+)
+
+// validAlias tells if a name can be used as an alias.
+func validAlias(alias string) bool {
+	return strings.TrimSpace(alias) != "" && !strings.ContainsAny(alias, `/\`)
+}
+
+// SetAliasFile loads the aliases stored in file, if any, and saves later
+// alias changes to it.
+func (engine *BleveSearchEngine) SetAliasFile(file string) error {
+	aliases := make(map[string][]string)
+	data, err := os.ReadFile(file)
+	switch {
+	case err == nil:
+		if err := json.Unmarshal(data, &aliases); err != nil {
+			return fmt.Errorf("read aliases %s: %w", file, err)
+		}
+	case !os.IsNotExist(err):
+		return fmt.Errorf("read aliases %s: %w", file, err)
+	}
+
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	engine.aliasFile = file
+	engine.aliases = aliases
+	return nil
+}
+
+// saveAliases writes the aliases to the alias file. Callers hold engine.mu.
+func (engine *BleveSearchEngine) saveAliases() error {
+	if engine.aliasFile == "" {
+		return nil
+	}
+	data, err := json.MarshalIndent(engine.aliases, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(engine.aliasFile), 0o755); err != nil {
+		return fmt.Errorf("save aliases: %w", err)
+	}
+	tmp := engine.aliasFile + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o644); err != nil {
+		return fmt.Errorf("save aliases: %w", err)
+	}
+	return os.Rename(tmp, engine.aliasFile)
+}
+
+// SetAlias creates or replaces an alias over index paths.
+func (engine *BleveSearchEngine) SetAlias(alias string, paths []string) error {
+	if !validAlias(alias) {
+		return errInvalidAlias
+	}
+	if len(paths) == 0 {
+		return errors.New("alias needs at least one index path")
+	}
+
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	clean := make([]string, 0, len(paths))
+	for _, p := range paths {
+		if _, isAlias := engine.aliases[p]; strings.TrimSpace(p) == "" || isAlias {
+			return fmt.Errorf("alias target %q is not an index path", p)
+		}
+		clean = append(clean, filepath.Clean(p))
+	}
+	if engine.aliases == nil {
+		engine.aliases = make(map[string][]string)
+	}
+	prev, existed := engine.aliases[alias]
+	engine.aliases[alias] = clean
+	if err := engine.saveAliases(); err != nil {
+		if existed {
+			engine.aliases[alias] = prev
+		} else {
+			delete(engine.aliases, alias)
+		}
+		return err
+	}
+	return nil
+}
+
+// RemoveAlias removes an alias; the indexes are left untouched.
+func (engine *BleveSearchEngine) RemoveAlias(alias string) error {
+	engine.mu.Lock()
+	defer engine.mu.Unlock()
+	prev, ok := engine.aliases[alias]
+	if !ok {
+		return ErrUnknownAlias
+	}
+	delete(engine.aliases, alias)
+	if err := engine.saveAliases(); err != nil {
+		engine.aliases[alias] = prev
+		return err
+	}
+	return nil
+}
+
+// Aliases returns a copy of the aliases.
+func (engine *BleveSearchEngine) Aliases() map[string][]string {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	out := make(map[string][]string, len(engine.aliases))
+	for alias, paths := range engine.aliases {
+		out[alias] = append([]string(nil), paths...)
+	}
+	return out
+}
+
+// resolvePaths expands the aliases among names into their index paths, in
+// order and without duplicates. Names that are not aliases are paths.
+func (engine *BleveSearchEngine) resolvePaths(names []string) []string {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	seen := make(map[string]bool)
+	var out []string
+	add := func(p string) {
+		p = filepath.Clean(p)
+		if !seen[p] {
+			seen[p] = true
+			out = append(out, p)
+		}
+	}
+	for _, name := range names {
+		if paths, ok := engine.aliases[name]; ok {
+			for _, p := range paths {
+				add(p)
+			}
+			continue
+		}
+		add(name)
+	}
+	return out
+}
@@ -5,6 +5,7 @@
 package search_engine
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,8 +25,19 @@ var logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level:
 
 // BleveSearchEngine implements SearchEngine on top of Bleve.
 type BleveSearchEngine struct {
-	mu     sync.RWMutex           // protects indexs
+	mu     sync.RWMutex           // protects indexs and aliases
 	indexs map[string]bleve.Index // path -> index
+
+	aliases   map[string][]string // alias -> index paths
+	aliasFile string              // where aliases are saved, if set
+
+	known        map[string]bool // paths of the indexes opened so far
+	registryFile string          // where known paths are saved, if set
+
+	embedder Embedder // embeds vector fields and queries; nil disables embedding
+
+	vecMu   sync.Mutex              // protects vectors
+	vectors map[string]*vectorCache // path -> vectors loaded by vector searches
 }
 
 // NewBleveSearchEngine creates a new Bleve-powered search engine.
@@ -36,6 +48,13 @@ func NewBleveSearchEngine() *BleveSearchEngine {
 // getIndex returns or opens/creates an index at the given path.
 // It validates the path and ensures the parent directory exists.
 func (engine *BleveSearchEngine) getIndex(path string) (bleve.Index, error) {
+	return engine.openIndex(path, true)
+}
+
+// openIndex returns or opens the index at the given path, creating it with
+// the default mapping when create is set. Without create, a missing index
+// is ErrUnknownIndex.
+func (engine *BleveSearchEngine) openIndex(path string, create bool) (bleve.Index, error) {
 	if strings.TrimSpace(path) == "" {
 		return nil, errors.New("index path is empty")
 	}
@@ -54,11 +73,16 @@ func (engine *BleveSearchEngine) getIndex(path string) (bleve.Index, error) {
 			_ = idx.Close()
 			delete(engine.indexs, path)
 			engine.mu.Unlock()
+			engine.dropVectors(path)
 			return nil, fmt.Errorf("index path does not exist: %s", path)
 		}
 		return idx, nil
 	}
 
+	if !create && !Utility.Exists(filepath.Join(path, "index_meta.json")) {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownIndex, path)
+	}
+
 	// Ensure directory exists before creating/opening the index.
 	if !Utility.Exists(path) {
 		if err := os.MkdirAll(path, 0o755); err != nil {
@@ -69,6 +93,9 @@ func (engine *BleveSearchEngine) getIndex(path string) (bleve.Index, error) {
 	// Try to open; if it fails because it doesn't exist, create it.
 	index, err := bleve.Open(path)
 	if err != nil {
+		if !create {
+			return nil, fmt.Errorf("open bleve index failed: %w", err)
+		}
 		mapping := bleve.NewIndexMapping()
 		index, err = bleve.New(path, mapping)
 		if err != nil {
@@ -81,6 +108,7 @@ func (engine *BleveSearchEngine) getIndex(path string) (bleve.Index, error) {
 
 	engine.mu.Lock()
 	engine.indexs[path] = index
+	engine.trackIndex(path)
 	engine.mu.Unlock()
 	return index, nil
 }
@@ -180,6 +208,12 @@ func (engine *BleveSearchEngine) DeleteDocument(path string, id string) error {
 	if err := index.Delete(id); err != nil {
 		return fmt.Errorf("delete document %q failed: %w", id, err)
 	}
+	_ = index.DeleteInternal([]byte(id))
+	_ = index.DeleteInternal(sourceKey(id))
+	for _, field := range vectorFieldNames(index) {
+		_ = index.DeleteInternal(vectorKey(field, id))
+	}
+	engine.uncacheVectors(index.Name(), id)
 	return nil
 }
 
@@ -199,9 +233,26 @@ func (engine *BleveSearchEngine) indexJsonObject(index bleve.Index, obj map[stri
 		return fmt.Errorf("id field %q must be a non-empty string", idField)
 	}
 
+	// The text fields are what the implicit vector field is embedded from.
+	vectorFields := engine.vectorFields(index, indexs)
+	vecs, err := engine.documentVectors(context.Background(), vectorFields, obj)
+	if err != nil {
+		return fmt.Errorf("index document %q failed: %w", id, err)
+	}
+
 	if err := index.Index(id, obj); err != nil {
 		return fmt.Errorf("index document %q failed: %w", id, err)
 	}
+	if err := engine.storeVectors(index, id, vectorFields, vecs); err != nil {
+		return err
+	}
+
+	// Keep the source object so the index can be rebuilt with ReindexTo.
+	if src, err := json.Marshal(obj); err == nil {
+		if err := index.SetInternal(sourceKey(id), src); err != nil {
+			return fmt.Errorf("store source of %q failed: %w", id, err)
+		}
+	}
 
 	// Persist original JSON alongside the index for retrieval.
 	if data != "" {
@@ -273,6 +324,7 @@ func (engine *BleveSearchEngine) CloseAll() error {
 		}
 	}
 	engine.indexs = make(map[string]bleve.Index)
+	engine.dropVectors("")
 	return firstErr
 }
 
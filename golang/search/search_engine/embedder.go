@@ -0,0 +1,107 @@
+package search_engine
+
+import (
+	"context"
+	"hash/fnv"
+	"math"
+	"strings"
+	"unicode"
+)
+
+// -----------------------------------------------------------------------------
+// embedder.go — text embeddings
+//
+// An Embedder turns texts into dense vectors for the KNN and HYBRID search
+// modes. The engine works without one: explicit vectors can still be indexed
+// and searched, and vector searches on a query string run as keyword searches.
+// -----------------------------------------------------------------------------
+
+// Embedder turns texts into vectors of a fixed number of dimensions.
+type Embedder interface {
+	// Name identifies the embedder and its settings.
+	Name() string
+	// Dimensions is the length of the vectors.
+	Dimensions() int
+	// Embed returns one vector per text, in order.
+	Embed(ctx context.Context, texts []string) ([][]float32, error)
+}
+
+// defaultHashingDimensions is the vector size of NewHashingEmbedder(0).
+const defaultHashingDimensions = 256
+
+// HashingEmbedder is a deterministic local embedder: each word and each
+// character trigram of a word is hashed to a signed dimension (feature
+// hashing) and the sum is normalized. It needs no model and no network, runs
+// on any CPU and gives the same vector for the same text on every node.
+// Texts sharing words or word parts end up close, which is enough for "find
+// similar" over titles and documents; it does not know synonyms.
+type HashingEmbedder struct {
+	dims int
+}
+
+// NewHashingEmbedder returns a hashing embedder of dims dimensions, 256 when
+// dims is not positive.
+func NewHashingEmbedder(dims int) *HashingEmbedder {
+	if dims <= 0 {
+		dims = defaultHashingDimensions
+	}
+	return &HashingEmbedder{dims: dims}
+}
+
+// Name implements Embedder.
+func (e *HashingEmbedder) Name() string { return "hashing" }
+
+// Dimensions implements Embedder.
+func (e *HashingEmbedder) Dimensions() int { return e.dims }
+
+// Embed implements Embedder.
+func (e *HashingEmbedder) Embed(ctx context.Context, texts []string) ([][]float32, error) {
+	out := make([][]float32, len(texts))
+	for i, text := range texts {
+		if err := ctx.Err(); err != nil {
+			return nil, err
+		}
+		out[i] = e.embed(text)
+	}
+	return out, nil
+}
+
+func (e *HashingEmbedder) embed(text string) []float32 {
+	v := make([]float32, e.dims)
+	add := func(feature string, weight float32) {
+		h := fnv.New64a()
+		_, _ = h.Write([]byte(feature))
+		sum := h.Sum64()
+		if sum>>63 == 1 {
+			weight = -weight
+		}
+		v[sum%uint64(e.dims)] += weight
+	}
+	words := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, w := range words {
+		add("w:"+w, 1)
+		runes := []rune("^" + w + "$")
+		for i := 0; i+3 <= len(runes); i++ {
+			add("t:"+string(runes[i:i+3]), 0.5)
+		}
+	}
+	normalize(v)
+	return v
+}
+
+// normalize scales v to unit length, unless it is zero.
+func normalize(v []float32) {
+	var sum float64
+	for _, x := range v {
+		sum += float64(x) * float64(x)
+	}
+	if sum == 0 {
+		return
+	}
+	n := float32(math.Sqrt(sum))
+	for i := range v {
+		v[i] /= n
+	}
+}
@@ -1,6 +1,8 @@
 package search_engine
 
 import (
+	"context"
+
 	"github.com/globulario/services/golang/search/searchpb"
 )
 
@@ -20,6 +22,42 @@ type SearchEngine interface {
 	// a SearchResults message with highlighted fragments.
 	SearchDocuments(paths []string, language string, fields []string, query string, offset, pageSize, snippetLength int32) (*searchpb.SearchResults, error)
 
+	// SearchDocumentsV2 searches the indexes behind paths and aliases
+	// together and returns one globally ranked page with facets and a token
+	// for the next page. Malformed requests wrap ErrInvalidSearch.
+	SearchDocumentsV2(ctx context.Context, rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error)
+
+	// SetAlias creates or replaces an alias naming a set of index paths.
+	SetAlias(alias string, paths []string) error
+
+	// RemoveAlias removes an alias; it returns ErrUnknownAlias if missing.
+	RemoveAlias(alias string) error
+
+	// Aliases returns the aliases and their index paths.
+	Aliases() map[string][]string
+
+	// IndexBatch indexes JSON objects in one batch and returns the outcome
+	// of each one, in order.
+	IndexBatch(path string, idField string, docs []*searchpb.BulkDocument) ([]*searchpb.BulkIndexResult, error)
+
+	// CreateIndex creates an empty index with an explicit mapping; it returns
+	// ErrIndexExists when there is one already.
+	CreateIndex(path string, mapping *searchpb.IndexMapping) error
+
+	// GetIndexMapping returns the creation mapping of an index (nil for
+	// implicit indexes) and its effective mapping as JSON.
+	GetIndexMapping(path string) (*searchpb.IndexMapping, string, error)
+
+	// ListIndexes returns the known indexes whose path starts with prefix.
+	ListIndexes(prefix string) []*searchpb.IndexInfo
+
+	// ReindexTo copies an index into a new one, then optionally points an
+	// alias at it and deletes the source. It returns the documents copied.
+	ReindexTo(ctx context.Context, source, target string, mapping *searchpb.IndexMapping, alias string, deleteSource bool) (int64, error)
+
+	// DeleteIndex closes an index and removes it from disk.
+	DeleteIndex(path string) error
+
 	// DeleteDocument deletes a document id from a specific index path.
 	DeleteDocument(path string, id string) error
 
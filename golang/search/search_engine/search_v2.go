@@ -0,0 +1,412 @@
+package search_engine
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/globulario/services/golang/search/searchpb"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/protobuf/proto"
+)
+
+// -----------------------------------------------------------------------------
+// search_v2.go — globally ranked search
+//
+// SearchDocumentsV2 runs one Bleve multi-search over every index behind the
+// requested paths and aliases, so hits are ranked, sorted and paged across
+// all of them rather than per index. Pages continue with search_after on the
+// sort keys of the last hit, which stays correct while documents are added.
+// -----------------------------------------------------------------------------
+
+const (
+	defaultPageSize  = 20
+	maxPageSize      = 1000
+	defaultFacetSize = 10
+	maxFuzziness     = 2
+)
+
+// ErrInvalidSearch wraps the errors caused by a malformed request.
+var ErrInvalidSearch = errors.New("invalid search request")
+
+func invalidf(format string, args ...interface{}) error {
+	return fmt.Errorf("%w: %s", ErrInvalidSearch, fmt.Sprintf(format, args...))
+}
+
+// pageToken is the position after the last hit of a page. fingerprint ties
+// it to the request it was issued for.
+type pageToken struct {
+	After       []string `json:"a"`
+	Fingerprint string   `json:"f"`
+}
+
+// requestFingerprint hashes what defines the result list of a request: all
+// fields but the page token and size.
+func requestFingerprint(rqst *searchpb.SearchDocumentsV2Request) string {
+	r := proto.Clone(rqst).(*searchpb.SearchDocumentsV2Request)
+	r.PageToken, r.PageSize = "", 0
+	data, _ := proto.MarshalOptions{Deterministic: true}.Marshal(r)
+	sum := sha256.Sum256(data)
+	return base64.RawURLEncoding.EncodeToString(sum[:12])
+}
+
+func encodePageToken(t pageToken) string {
+	data, _ := json.Marshal(t)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodePageToken(s string) (pageToken, error) {
+	var t pageToken
+	data, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil || json.Unmarshal(data, &t) != nil {
+		return t, invalidf("malformed page token")
+	}
+	return t, nil
+}
+
+// fieldQueries builds q for each field, OR-ed together; without fields q
+// applies to the default field.
+func fieldQueries(fields []string, build func(field string) query.Query) query.Query {
+	if len(fields) == 0 {
+		return build("")
+	}
+	if len(fields) == 1 {
+		return build(fields[0])
+	}
+	qs := make([]query.Query, 0, len(fields))
+	for _, f := range fields {
+		qs = append(qs, build(f))
+	}
+	return bleve.NewDisjunctionQuery(qs...)
+}
+
+// mainQuery builds the scoring query of a request.
+func mainQuery(rqst *searchpb.SearchDocumentsV2Request) (query.Query, error) {
+	q := strings.TrimSpace(rqst.GetQuery())
+	fuzziness := int(rqst.GetFuzziness())
+	if fuzziness < 0 || fuzziness > maxFuzziness {
+		return nil, invalidf("fuzziness must be between 0 and %d", maxFuzziness)
+	}
+	if q == "" || rqst.GetMode() == searchpb.SearchMode_MATCH_ALL {
+		return bleve.NewMatchAllQuery(), nil
+	}
+
+	switch rqst.GetMode() {
+	case searchpb.SearchMode_QUERY_STRING:
+		return bleve.NewQueryStringQuery(q), nil
+	case searchpb.SearchMode_MATCH:
+		return fieldQueries(rqst.GetFields(), func(field string) query.Query {
+			mq := bleve.NewMatchQuery(q)
+			mq.SetField(field)
+			mq.SetFuzziness(fuzziness)
+			return mq
+		}), nil
+	case searchpb.SearchMode_PHRASE:
+		return fieldQueries(rqst.GetFields(), func(field string) query.Query {
+			pq := bleve.NewMatchPhraseQuery(q)
+			pq.SetField(field)
+			return pq
+		}), nil
+	case searchpb.SearchMode_FUZZY:
+		if fuzziness == 0 {
+			fuzziness = 1
+		}
+		return fieldQueries(rqst.GetFields(), func(field string) query.Query {
+			fq := bleve.NewFuzzyQuery(strings.ToLower(q))
+			fq.SetField(field)
+			fq.SetFuzziness(fuzziness)
+			return fq
+		}), nil
+	case searchpb.SearchMode_PREFIX:
+		return fieldQueries(rqst.GetFields(), func(field string) query.Query {
+			pq := bleve.NewPrefixQuery(strings.ToLower(q))
+			pq.SetField(field)
+			return pq
+		}), nil
+	}
+	return nil, invalidf("unknown search mode %v", rqst.GetMode())
+}
+
+// filterQuery builds the query of a filter. It does not contribute to the
+// score of the hits.
+func filterQuery(f *searchpb.SearchFilter) (query.Query, error) {
+	if strings.TrimSpace(f.GetField()) == "" {
+		return nil, invalidf("filter without field")
+	}
+	var parts []query.Query
+	if len(f.GetTerms()) > 0 {
+		terms := make([]query.Query, 0, len(f.GetTerms()))
+		for _, term := range f.GetTerms() {
+			tq := bleve.NewTermQuery(term)
+			tq.SetField(f.GetField())
+			tq.SetBoost(0)
+			terms = append(terms, tq)
+		}
+		if len(terms) == 1 {
+			parts = append(parts, terms[0])
+		} else {
+			parts = append(parts, bleve.NewDisjunctionQuery(terms...))
+		}
+	}
+	if f.Min != nil || f.Max != nil {
+		nq := bleve.NewNumericRangeQuery(f.Min, f.Max)
+		nq.SetField(f.GetField())
+		nq.SetBoost(0)
+		parts = append(parts, nq)
+	}
+	if f.GetStart() != "" || f.GetEnd() != "" {
+		dq := bleve.NewDateRangeStringQuery(f.GetStart(), f.GetEnd())
+		dq.SetField(f.GetField())
+		dq.SetBoost(0)
+		parts = append(parts, dq)
+	}
+	switch len(parts) {
+	case 0:
+		return nil, invalidf("filter on %q has no terms or range", f.GetField())
+	case 1:
+		return parts[0], nil
+	}
+	return bleve.NewConjunctionQuery(parts...), nil
+}
+
+// buildQuery combines the main query and the filters of a request.
+func buildQuery(rqst *searchpb.SearchDocumentsV2Request) (query.Query, error) {
+	main, err := mainQuery(rqst)
+	if err != nil {
+		return nil, err
+	}
+	if len(rqst.GetFilters()) == 0 {
+		return main, nil
+	}
+	bq := bleve.NewBooleanQuery()
+	bq.AddMust(main)
+	for _, f := range rqst.GetFilters() {
+		fq, err := filterQuery(f)
+		if err != nil {
+			return nil, err
+		}
+		if f.GetExclude() {
+			bq.AddMustNot(fq)
+		} else {
+			bq.AddMust(fq)
+		}
+	}
+	return bq, nil
+}
+
+// sortOrder returns the Bleve sort of a request. It always ends with the
+// document id so that every hit has a distinct position for search_after.
+func sortOrder(sorts []*searchpb.SearchSort) ([]string, error) {
+	if len(sorts) == 0 {
+		return []string{"-_score", "_id"}, nil
+	}
+	order := make([]string, 0, len(sorts)+1)
+	hasID := false
+	for _, s := range sorts {
+		field := strings.TrimSpace(s.GetField())
+		if field == "" || strings.HasPrefix(field, "-") {
+			return nil, invalidf("invalid sort field %q", s.GetField())
+		}
+		hasID = hasID || field == "_id"
+		if s.GetDescending() {
+			field = "-" + field
+		}
+		order = append(order, field)
+	}
+	if !hasID {
+		order = append(order, "_id")
+	}
+	return order, nil
+}
+
+// facetRequest converts a facet of a request.
+func facetRequest(f *searchpb.SearchFacetRequest) (*bleve.FacetRequest, error) {
+	if strings.TrimSpace(f.GetField()) == "" {
+		return nil, invalidf("facet without field")
+	}
+	size := int(f.GetSize())
+	if size <= 0 {
+		size = defaultFacetSize
+	}
+	fr := bleve.NewFacetRequest(f.GetField(), size)
+	for _, r := range f.GetNumericRanges() {
+		fr.AddNumericRange(r.GetName(), r.Min, r.Max)
+	}
+	for _, r := range f.GetDateRanges() {
+		var start, end *string
+		if r.GetStart() != "" {
+			start = &r.Start
+		}
+		if r.GetEnd() != "" {
+			end = &r.End
+		}
+		fr.AddDateTimeRangeString(r.GetName(), start, end)
+	}
+	if err := fr.Validate(); err != nil {
+		return nil, invalidf("facet %q: %v", f.GetName(), err)
+	}
+	return fr, nil
+}
+
+// facetName is the name of a facet in the response.
+func facetName(f *searchpb.SearchFacetRequest) string {
+	if f.GetName() != "" {
+		return f.GetName()
+	}
+	return f.GetField()
+}
+
+// openPaths opens the indexes behind paths and aliases, by name and in
+// order. Unavailable indexes are skipped.
+func (engine *BleveSearchEngine) openPaths(paths []string) (map[string]bleve.Index, []bleve.Index, error) {
+	byName := make(map[string]bleve.Index)
+	var indexes []bleve.Index
+	for _, p := range engine.resolvePaths(paths) {
+		index, err := engine.getIndex(p)
+		if err != nil {
+			logger.Warn("skip index (unavailable)", "path", p, "err", err)
+			continue
+		}
+		byName[index.Name()] = index
+		indexes = append(indexes, index)
+	}
+	if len(indexes) == 0 {
+		return nil, nil, errors.New("none of the index paths is available")
+	}
+	return byName, indexes, nil
+}
+
+// SearchDocumentsV2 searches the indexes behind paths and aliases together
+// and returns one page of hits ranked and sorted across all of them.
+func (engine *BleveSearchEngine) SearchDocumentsV2(ctx context.Context, rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error) {
+	if len(rqst.GetPaths()) == 0 {
+		return nil, invalidf("no index paths supplied")
+	}
+	if m := rqst.GetMode(); m == searchpb.SearchMode_KNN || m == searchpb.SearchMode_HYBRID {
+		return engine.vectorSearch(ctx, rqst)
+	}
+	q, err := buildQuery(rqst)
+	if err != nil {
+		return nil, err
+	}
+	order, err := sortOrder(rqst.GetSort())
+	if err != nil {
+		return nil, err
+	}
+	size := int(rqst.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+
+	fingerprint := requestFingerprint(rqst)
+	var after []string
+	if rqst.GetPageToken() != "" {
+		t, err := decodePageToken(rqst.GetPageToken())
+		if err != nil {
+			return nil, err
+		}
+		if t.Fingerprint != fingerprint || len(t.After) != len(order) {
+			return nil, invalidf("page token belongs to another search")
+		}
+		after = t.After
+	}
+
+	// One more hit than the page tells if there is a next page.
+	sr := bleve.NewSearchRequestOptions(q, size+1, 0, false)
+	sr.SortBy(order)
+	if after != nil {
+		sr.SetSearchAfter(after)
+	}
+	if rqst.GetHighlight() {
+		sr.Highlight = bleve.NewHighlightWithStyle("html")
+	}
+	seenFacets := make(map[string]bool)
+	for _, f := range rqst.GetFacets() {
+		name := facetName(f)
+		if seenFacets[name] {
+			return nil, invalidf("duplicate facet %q", name)
+		}
+		seenFacets[name] = true
+		fr, err := facetRequest(f)
+		if err != nil {
+			return nil, err
+		}
+		sr.AddFacet(name, fr)
+	}
+
+	byName, indexes, err := engine.openPaths(rqst.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := bleve.MultiSearch(ctx, sr, nil, indexes...)
+	if err != nil {
+		return nil, fmt.Errorf("search failed: %w", err)
+	}
+
+	rsp := &searchpb.SearchDocumentsV2Response{Total: res.Total}
+	hits := res.Hits
+	if len(hits) > size {
+		hits = hits[:size]
+		rsp.NextPageToken = encodePageToken(pageToken{After: hits[size-1].Sort, Fingerprint: fingerprint})
+	}
+	for _, hit := range hits {
+		out := &searchpb.SearchHit{Index: hit.Index, DocId: hit.ID, Score: hit.Score}
+		if index := byName[hit.Index]; index != nil {
+			if raw, err := index.GetInternal([]byte(hit.ID)); err == nil {
+				out.Data = string(raw)
+			} else {
+				logger.Warn("get raw data failed", "path", hit.Index, "id", hit.ID, "err", err)
+			}
+		}
+		if len(hit.Fragments) > 0 {
+			if data, err := Utility.ToJson(hit.Fragments); err == nil {
+				out.Snippet = string(data)
+			}
+		}
+		rsp.Hits = append(rsp.Hits, out)
+	}
+
+	for _, f := range rqst.GetFacets() {
+		name := facetName(f)
+		fr := res.Facets[name]
+		if fr == nil {
+			continue
+		}
+		out := &searchpb.SearchFacetResult{
+			Name:    name,
+			Field:   fr.Field,
+			Total:   int64(fr.Total),
+			Missing: int64(fr.Missing),
+			Other:   int64(fr.Other),
+		}
+		for _, t := range fr.Terms.Terms() {
+			out.Terms = append(out.Terms, &searchpb.SearchTermCount{Term: t.Term, Count: int64(t.Count)})
+		}
+		// Ranges come back unordered; answer in request order.
+		counts := make(map[string]int64)
+		for _, r := range fr.NumericRanges {
+			counts[r.Name] = int64(r.Count)
+		}
+		for _, r := range fr.DateRanges {
+			counts[r.Name] = int64(r.Count)
+		}
+		for _, r := range f.GetNumericRanges() {
+			out.Ranges = append(out.Ranges, &searchpb.SearchRangeCount{Name: r.GetName(), Count: counts[r.GetName()]})
+		}
+		for _, r := range f.GetDateRanges() {
+			out.Ranges = append(out.Ranges, &searchpb.SearchRangeCount{Name: r.GetName(), Count: counts[r.GetName()]})
+		}
+		rsp.Facets = append(rsp.Facets, out)
+	}
+	return rsp, nil
+}
@@ -0,0 +1,165 @@
+package search_engine
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path/filepath"
+	"testing"
+
+	"github.com/globulario/services/golang/search/searchpb"
+)
+
+// newTestEngine returns an engine with two indexes of movies, "a" holding the
+// even years and "b" the odd ones, and an alias "movies" over both.
+func newTestEngine(t *testing.T) (*BleveSearchEngine, string, string) {
+	t.Helper()
+	dir := t.TempDir()
+	engine := NewBleveSearchEngine()
+	t.Cleanup(func() { _ = engine.CloseAll() })
+	if err := engine.SetAliasFile(filepath.Join(dir, "aliases.json")); err != nil {
+		t.Fatal(err)
+	}
+	a, b := filepath.Join(dir, "a"), filepath.Join(dir, "b")
+	for year := 2000; year < 2010; year++ {
+		genre := "drama"
+		if year%3 == 0 {
+			genre = "comedy"
+		}
+		path := a
+		if year%2 == 1 {
+			path = b
+		}
+		doc := fmt.Sprintf(`{"id":"m%d","title":"space movie %d","genre":%q,"year":%d}`, year, year, genre, year)
+		if err := engine.IndexJsonObject(path, doc, "en", "id", nil, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := engine.SetAlias("movies", []string{a, b}); err != nil {
+		t.Fatal(err)
+	}
+	return engine, a, b
+}
+
+// TestSearchDocumentsV2Paging verifies pages are sorted across indexes and
+// that the page token walks every hit once.
+func TestSearchDocumentsV2Paging(t *testing.T) {
+	engine, _, _ := newTestEngine(t)
+	rqst := &searchpb.SearchDocumentsV2Request{
+		Paths:    []string{"movies"},
+		Query:    "space",
+		Sort:     []*searchpb.SearchSort{{Field: "year", Descending: true}},
+		PageSize: 3,
+	}
+	var ids []string
+	for page := 0; ; page++ {
+		rsp, err := engine.SearchDocumentsV2(context.Background(), rqst)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rsp.Total != 10 {
+			t.Fatalf("total = %d, want 10", rsp.Total)
+		}
+		for _, hit := range rsp.Hits {
+			ids = append(ids, hit.DocId)
+			if hit.Data == "" {
+				t.Errorf("hit %s has no data", hit.DocId)
+			}
+		}
+		if rsp.NextPageToken == "" {
+			break
+		}
+		if page > 5 {
+			t.Fatal("paging does not end")
+		}
+		rqst.PageToken = rsp.NextPageToken
+	}
+	if len(ids) != 10 || ids[0] != "m2009" || ids[1] != "m2008" || ids[9] != "m2000" {
+		t.Errorf("ids = %v, want m2009 down to m2000", ids)
+	}
+
+	// A token only continues the search it was issued for.
+	rqst.Query = "movie"
+	if _, err := engine.SearchDocumentsV2(context.Background(), rqst); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("foreign page token: err = %v, want ErrInvalidSearch", err)
+	}
+}
+
+func TestSearchDocumentsV2FiltersAndFacets(t *testing.T) {
+	engine, a, _ := newTestEngine(t)
+	min, max := 2002.0, 2008.0
+	rsp, err := engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{"movies"},
+		Mode:  searchpb.SearchMode_MATCH_ALL,
+		Filters: []*searchpb.SearchFilter{
+			{Field: "year", Min: &min, Max: &max},
+			{Field: "genre", Terms: []string{"comedy"}, Exclude: true},
+		},
+		Facets: []*searchpb.SearchFacetRequest{
+			{Field: "genre"},
+			{Name: "decade", Field: "year", NumericRanges: []*searchpb.SearchNumericRange{{Name: "early", Max: &min}, {Name: "late", Min: &min}}},
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2002..2007 without the comedies 2003 and 2006.
+	if rsp.Total != 4 {
+		t.Errorf("total = %d, want 4", rsp.Total)
+	}
+	if len(rsp.Facets) != 2 || rsp.Facets[0].Name != "genre" || len(rsp.Facets[0].Terms) != 1 || rsp.Facets[0].Terms[0].Term != "drama" {
+		t.Fatalf("facets = %v", rsp.Facets)
+	}
+	if r := rsp.Facets[1].Ranges; len(r) != 2 || r[0].Name != "early" || r[0].Count != 0 || r[1].Count != 4 {
+		t.Errorf("range facet = %v", r)
+	}
+
+	// Fuzzy match on a field, against one index only.
+	rsp, err = engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths:  []string{a},
+		Query:  "dramma",
+		Mode:   searchpb.SearchMode_FUZZY,
+		Fields: []string{"genre"},
+	})
+	if err != nil || rsp.Total != 4 {
+		t.Errorf("fuzzy search = %v, %v; want the 4 even-year dramas", rsp.GetTotal(), err)
+	}
+
+	if _, err := engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{a}, Filters: []*searchpb.SearchFilter{{Field: "year"}},
+	}); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("empty filter: err = %v, want ErrInvalidSearch", err)
+	}
+}
+
+// TestAliasesPersist verifies aliases are saved and reloaded.
+func TestAliasesPersist(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "aliases.json")
+	engine := NewBleveSearchEngine()
+	if err := engine.SetAliasFile(file); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.SetAlias("titles", []string{"/data/search/titles_v1"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := engine.SetAlias("a/b", []string{"/x"}); err == nil {
+		t.Error("alias with a separator accepted")
+	}
+	if err := engine.SetAlias("all", []string{"titles"}); err == nil {
+		t.Error("alias over an alias accepted")
+	}
+
+	reloaded := NewBleveSearchEngine()
+	if err := reloaded.SetAliasFile(file); err != nil {
+		t.Fatal(err)
+	}
+	if got := reloaded.resolvePaths([]string{"titles", "/other"}); len(got) != 2 || got[0] != "/data/search/titles_v1" || got[1] != "/other" {
+		t.Errorf("resolvePaths = %v", got)
+	}
+	if err := reloaded.RemoveAlias("titles"); err != nil {
+		t.Fatal(err)
+	}
+	if err := reloaded.RemoveAlias("titles"); !errors.Is(err, ErrUnknownAlias) {
+		t.Errorf("second RemoveAlias = %v, want ErrUnknownAlias", err)
+	}
+}
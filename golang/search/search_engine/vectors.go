@@ -0,0 +1,675 @@
+package search_engine
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/blevesearch/bleve/v2"
+	"github.com/blevesearch/bleve/v2/search/query"
+	"github.com/globulario/services/golang/search/searchpb"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+)
+
+// -----------------------------------------------------------------------------
+// vectors.go — dense vector search
+//
+// Vector fields are declared in the index mapping (FIELD_VECTOR). A document
+// gives the vector itself as a number array, or the engine embeds the text of
+// its source fields. Vectors are kept as internal values of the index, so
+// they follow it through backups, reindexing and deletion, and are read into
+// memory on the first vector search. Search is exact (cosine similarity over
+// every candidate), which needs no native library and holds up to a few
+// hundred thousand vectors per index.
+//
+// When an embedder is set, indexes without vector fields get an implicit one,
+// defaultVectorField, embedded from the text fields given at indexing time.
+// Without an embedder, vector searches on a query string run as keyword
+// searches and the response says so.
+// -----------------------------------------------------------------------------
+
+const (
+	// defaultVectorField is the implicit vector field of the indexes that
+	// declare none.
+	defaultVectorField = "_embedding"
+
+	// maxVectorDims bounds the size of a vector field.
+	maxVectorDims = 4096
+
+	defaultK = 10
+
+	// rrfRank is the rank constant of reciprocal rank fusion.
+	rrfRank = 60
+)
+
+// vectorKey is the internal key of the vector of a document in a field.
+func vectorKey(field, id string) []byte {
+	return []byte("\x00globular:vec:" + field + "\x00" + id)
+}
+
+func encodeVector(v []float32) []byte {
+	out := make([]byte, 4*len(v))
+	for i, x := range v {
+		binary.LittleEndian.PutUint32(out[4*i:], math.Float32bits(x))
+	}
+	return out
+}
+
+func decodeVector(b []byte) []float32 {
+	if len(b) == 0 || len(b)%4 != 0 {
+		return nil
+	}
+	v := make([]float32, len(b)/4)
+	for i := range v {
+		v[i] = math.Float32frombits(binary.LittleEndian.Uint32(b[4*i:]))
+	}
+	return v
+}
+
+// cosine returns the cosine similarity of two vectors of the same length.
+func cosine(a, b []float32) float64 {
+	var dot, na, nb float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		na += float64(a[i]) * float64(a[i])
+		nb += float64(b[i]) * float64(b[i])
+	}
+	if na == 0 || nb == 0 {
+		return 0
+	}
+	return dot / math.Sqrt(na*nb)
+}
+
+// vectorCache holds the vectors of one index.
+type vectorCache struct {
+	fields map[string]map[string][]float32 // field -> document id -> vector
+}
+
+// SetEmbedder sets the embedder of vector fields and queries; nil disables
+// embedding.
+func (engine *BleveSearchEngine) SetEmbedder(e Embedder) {
+	engine.mu.Lock()
+	engine.embedder = e
+	engine.mu.Unlock()
+	if e != nil {
+		logger.Info("search embedder set", "embedder", e.Name(), "dimensions", e.Dimensions())
+	}
+}
+
+func (engine *BleveSearchEngine) getEmbedder() Embedder {
+	engine.mu.RLock()
+	defer engine.mu.RUnlock()
+	return engine.embedder
+}
+
+// storedMapping returns the mapping an index was created with, nil when it
+// was created implicitly.
+func storedMapping(index bleve.Index) *searchpb.IndexMapping {
+	raw, err := index.GetInternal([]byte(mappingKey))
+	if err != nil || len(raw) == 0 {
+		return nil
+	}
+	m := new(searchpb.IndexMapping)
+	if err := protojson.Unmarshal(raw, m); err != nil {
+		return nil
+	}
+	return m
+}
+
+// declaredVectorFields returns the vector fields of the mapping of an index.
+func declaredVectorFields(index bleve.Index) []*searchpb.FieldMapping {
+	var out []*searchpb.FieldMapping
+	for _, f := range storedMapping(index).GetFields() {
+		if f.GetType() == searchpb.FieldType_FIELD_VECTOR {
+			out = append(out, f)
+		}
+	}
+	return out
+}
+
+// vectorFieldNames returns the names of the vector fields a document of an
+// index can have, the implicit one included.
+func vectorFieldNames(index bleve.Index) []string {
+	names := []string{defaultVectorField}
+	for _, f := range declaredVectorFields(index) {
+		if f.GetName() != defaultVectorField {
+			names = append(names, f.GetName())
+		}
+	}
+	return names
+}
+
+// vectorFields returns the vector fields to compute for the documents of an
+// index: the declared ones, else the implicit one embedded from textFields
+// when there is an embedder.
+func (engine *BleveSearchEngine) vectorFields(index bleve.Index, textFields []string) []*searchpb.FieldMapping {
+	if fields := declaredVectorFields(index); len(fields) > 0 {
+		return fields
+	}
+	e := engine.getEmbedder()
+	if e == nil {
+		return nil
+	}
+	return []*searchpb.FieldMapping{{
+		Name:         defaultVectorField,
+		Type:         searchpb.FieldType_FIELD_VECTOR,
+		Dims:         int32(e.Dimensions()),
+		SourceFields: textFields,
+	}}
+}
+
+// lookupField returns the value of a dotted field name of an object.
+func lookupField(obj map[string]interface{}, name string) (interface{}, bool) {
+	parts := strings.Split(name, ".")
+	var cur interface{} = obj
+	for _, part := range parts {
+		m, ok := cur.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if cur, ok = m[part]; !ok {
+			return nil, false
+		}
+	}
+	return cur, true
+}
+
+// collectStrings appends the non-empty strings found in v, in key order.
+func collectStrings(v interface{}, out *[]string) {
+	switch v := v.(type) {
+	case string:
+		if strings.TrimSpace(v) != "" {
+			*out = append(*out, v)
+		}
+	case []interface{}:
+		for _, x := range v {
+			collectStrings(x, out)
+		}
+	case map[string]interface{}:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			collectStrings(v[k], out)
+		}
+	}
+}
+
+// embedText returns the text a vector is embedded from: the strings of the
+// source fields, or of the whole object when there are none.
+func embedText(obj map[string]interface{}, sourceFields []string) string {
+	var parts []string
+	if len(sourceFields) == 0 {
+		collectStrings(obj, &parts)
+	}
+	for _, name := range sourceFields {
+		if v, ok := lookupField(obj, name); ok {
+			collectStrings(v, &parts)
+		}
+	}
+	return strings.Join(parts, "\n")
+}
+
+// documentVectors computes the vectors of a document: the value of a vector
+// field when the document has one, else the embedding of its source text
+// when there is an embedder. Fields without either are left out.
+func (engine *BleveSearchEngine) documentVectors(ctx context.Context, fields []*searchpb.FieldMapping, obj map[string]interface{}) (map[string][]float32, error) {
+	out := make(map[string][]float32)
+	var toEmbed []*searchpb.FieldMapping
+	var texts []string
+	for _, f := range fields {
+		raw, ok := lookupField(obj, f.GetName())
+		if !ok {
+			if text := embedText(obj, f.GetSourceFields()); text != "" {
+				toEmbed = append(toEmbed, f)
+				texts = append(texts, text)
+			}
+			continue
+		}
+		arr, ok := raw.([]interface{})
+		if !ok {
+			return nil, fmt.Errorf("vector field %q must be an array of numbers", f.GetName())
+		}
+		v := make([]float32, len(arr))
+		for i, x := range arr {
+			n, ok := x.(float64)
+			if !ok {
+				return nil, fmt.Errorf("vector field %q must be an array of numbers", f.GetName())
+			}
+			v[i] = float32(n)
+		}
+		if len(v) != int(f.GetDims()) {
+			return nil, fmt.Errorf("vector field %q has %d dimensions, want %d", f.GetName(), len(v), f.GetDims())
+		}
+		out[f.GetName()] = v
+	}
+
+	e := engine.getEmbedder()
+	if len(texts) == 0 || e == nil {
+		return out, nil
+	}
+	vs, err := e.Embed(ctx, texts)
+	if err != nil {
+		return nil, fmt.Errorf("embed with %s: %w", e.Name(), err)
+	}
+	for i, f := range toEmbed {
+		if i >= len(vs) || len(vs[i]) != int(f.GetDims()) {
+			return nil, fmt.Errorf("embedder %s does not give the %d dimensions of vector field %q", e.Name(), f.GetDims(), f.GetName())
+		}
+		out[f.GetName()] = vs[i]
+	}
+	return out, nil
+}
+
+// writeVectors adds the vectors of a document to a batch and removes the
+// ones of the fields it no longer has.
+func writeVectors(batch *bleve.Batch, id string, fields []*searchpb.FieldMapping, vecs map[string][]float32) {
+	for _, f := range fields {
+		if v, ok := vecs[f.GetName()]; ok {
+			batch.SetInternal(vectorKey(f.GetName(), id), encodeVector(v))
+		} else {
+			batch.DeleteInternal(vectorKey(f.GetName(), id))
+		}
+	}
+}
+
+// storeVectors writes the vectors of a document to its index.
+func (engine *BleveSearchEngine) storeVectors(index bleve.Index, id string, fields []*searchpb.FieldMapping, vecs map[string][]float32) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	batch := index.NewBatch()
+	writeVectors(batch, id, fields, vecs)
+	if err := index.Batch(batch); err != nil {
+		return fmt.Errorf("store vectors of %q failed: %w", id, err)
+	}
+	engine.cacheVectors(index.Name(), id, fields, vecs)
+	return nil
+}
+
+// cacheVectors updates the loaded vectors of an index, if any, after a write.
+func (engine *BleveSearchEngine) cacheVectors(path, id string, fields []*searchpb.FieldMapping, vecs map[string][]float32) {
+	engine.vecMu.Lock()
+	defer engine.vecMu.Unlock()
+	c := engine.vectors[path]
+	if c == nil {
+		return
+	}
+	for _, f := range fields {
+		byID := c.fields[f.GetName()]
+		if byID == nil {
+			byID = make(map[string][]float32)
+			c.fields[f.GetName()] = byID
+		}
+		if v, ok := vecs[f.GetName()]; ok {
+			byID[id] = v
+		} else {
+			delete(byID, id)
+		}
+	}
+}
+
+// uncacheVectors removes a deleted document from the loaded vectors.
+func (engine *BleveSearchEngine) uncacheVectors(path, id string) {
+	engine.vecMu.Lock()
+	defer engine.vecMu.Unlock()
+	if c := engine.vectors[path]; c != nil {
+		for _, byID := range c.fields {
+			delete(byID, id)
+		}
+	}
+}
+
+// dropVectors forgets the loaded vectors of an index, or of every index when
+// path is empty.
+func (engine *BleveSearchEngine) dropVectors(path string) {
+	engine.vecMu.Lock()
+	defer engine.vecMu.Unlock()
+	if path == "" {
+		engine.vectors = nil
+		return
+	}
+	delete(engine.vectors, path)
+}
+
+// loadVectors reads the vectors of an index into memory, once. The lock is
+// held while reading so that writes made meanwhile are applied after.
+func (engine *BleveSearchEngine) loadVectors(ctx context.Context, index bleve.Index) error {
+	engine.vecMu.Lock()
+	defer engine.vecMu.Unlock()
+	if engine.vectors[index.Name()] != nil {
+		return nil
+	}
+	names := vectorFieldNames(index)
+	c := &vectorCache{fields: make(map[string]map[string][]float32)}
+	for _, name := range names {
+		c.fields[name] = make(map[string][]float32)
+	}
+	var after []string
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		sr := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), reindexPageSize, 0, false)
+		sr.SortBy([]string{"_id"})
+		if after != nil {
+			sr.SetSearchAfter(after)
+		}
+		res, err := index.SearchInContext(ctx, sr)
+		if err != nil {
+			return fmt.Errorf("read vectors of %s failed: %w", index.Name(), err)
+		}
+		if len(res.Hits) == 0 {
+			break
+		}
+		for _, hit := range res.Hits {
+			for _, name := range names {
+				if raw, err := index.GetInternal(vectorKey(name, hit.ID)); err == nil {
+					if v := decodeVector(raw); v != nil {
+						c.fields[name][hit.ID] = v
+					}
+				}
+			}
+		}
+		after = res.Hits[len(res.Hits)-1].Sort
+	}
+	if engine.vectors == nil {
+		engine.vectors = make(map[string]*vectorCache)
+	}
+	engine.vectors[index.Name()] = c
+	return nil
+}
+
+// matchingIDs returns the ids of the documents of an index matching q.
+func matchingIDs(ctx context.Context, index bleve.Index, q query.Query) (map[string]bool, error) {
+	ids := make(map[string]bool)
+	var after []string
+	for {
+		sr := bleve.NewSearchRequestOptions(q, reindexPageSize, 0, false)
+		sr.SortBy([]string{"_id"})
+		if after != nil {
+			sr.SetSearchAfter(after)
+		}
+		res, err := index.SearchInContext(ctx, sr)
+		if err != nil {
+			return nil, fmt.Errorf("filter %s failed: %w", index.Name(), err)
+		}
+		if len(res.Hits) == 0 {
+			return ids, nil
+		}
+		for _, hit := range res.Hits {
+			ids[hit.ID] = true
+		}
+		after = res.Hits[len(res.Hits)-1].Sort
+	}
+}
+
+// vectorFieldOf returns the vector field to search in an index: the named
+// one, else the only declared one, else the implicit one.
+func vectorFieldOf(index bleve.Index, name string) (string, error) {
+	if name != "" {
+		return name, nil
+	}
+	fields := declaredVectorFields(index)
+	switch len(fields) {
+	case 0:
+		return defaultVectorField, nil
+	case 1:
+		return fields[0].GetName(), nil
+	}
+	return "", invalidf("index %s has several vector fields; set vector_field", index.Name())
+}
+
+// rankedHit is a hit of a vector or hybrid search.
+type rankedHit struct {
+	index string
+	id    string
+	score float64
+}
+
+func sortRanked(hits []rankedHit) {
+	sort.Slice(hits, func(i, j int) bool {
+		if hits[i].score != hits[j].score {
+			return hits[i].score > hits[j].score
+		}
+		if hits[i].index != hits[j].index {
+			return hits[i].index < hits[j].index
+		}
+		return hits[i].id < hits[j].id
+	})
+}
+
+// nearest returns the k documents of an index closest to qv.
+func (engine *BleveSearchEngine) nearest(ctx context.Context, index bleve.Index, field string, qv []float32, allowed map[string]bool, exclude string, k int) ([]rankedHit, error) {
+	if err := engine.loadVectors(ctx, index); err != nil {
+		return nil, err
+	}
+	engine.vecMu.Lock()
+	defer engine.vecMu.Unlock()
+	c := engine.vectors[index.Name()]
+	if c == nil {
+		return nil, nil
+	}
+	var out []rankedHit
+	for id, v := range c.fields[field] {
+		if id == exclude || (allowed != nil && !allowed[id]) {
+			continue
+		}
+		if len(v) != len(qv) {
+			return nil, invalidf("vector field %q of %s has %d dimensions, the query vector %d", field, index.Name(), len(v), len(qv))
+		}
+		out = append(out, rankedHit{index: index.Name(), id: id, score: cosine(qv, v)})
+	}
+	sortRanked(out)
+	if len(out) > k {
+		out = out[:k]
+	}
+	return out, nil
+}
+
+// likeVector returns the vector of document id in the first index that has
+// one.
+func (engine *BleveSearchEngine) likeVector(ctx context.Context, indexes []bleve.Index, field, id string) ([]float32, error) {
+	for _, index := range indexes {
+		name, err := vectorFieldOf(index, field)
+		if err != nil {
+			return nil, err
+		}
+		if err := engine.loadVectors(ctx, index); err != nil {
+			return nil, err
+		}
+		var v []float32
+		engine.vecMu.Lock()
+		if c := engine.vectors[index.Name()]; c != nil {
+			v = c.fields[name][id]
+		}
+		engine.vecMu.Unlock()
+		if v != nil {
+			return v, nil
+		}
+	}
+	return nil, invalidf("document %q has no vector", id)
+}
+
+// fuseRanks merges ranked lists by reciprocal rank fusion: a hit scores the
+// sum of 1/(rrfRank+rank) over the lists it is in.
+func fuseRanks(lists ...[]rankedHit) []rankedHit {
+	scores := make(map[[2]string]float64)
+	for _, list := range lists {
+		for rank, h := range list {
+			scores[[2]string{h.index, h.id}] += 1 / float64(rrfRank+rank+1)
+		}
+	}
+	out := make([]rankedHit, 0, len(scores))
+	for key, score := range scores {
+		out = append(out, rankedHit{index: key[0], id: key[1], score: score})
+	}
+	sortRanked(out)
+	return out
+}
+
+// keywordFallback runs a vector search as a MATCH search, for when the
+// query string cannot be embedded.
+func (engine *BleveSearchEngine) keywordFallback(ctx context.Context, rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error) {
+	r := proto.Clone(rqst).(*searchpb.SearchDocumentsV2Request)
+	r.Mode = searchpb.SearchMode_MATCH
+	r.VectorField, r.Vector, r.LikeId, r.K = "", nil, "", 0
+	rsp, err := engine.SearchDocumentsV2(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	rsp.Degraded = true
+	return rsp, nil
+}
+
+// vectorSearch runs a KNN or HYBRID search. The k best hits across the
+// indexes are ranked once and returned page by page.
+func (engine *BleveSearchEngine) vectorSearch(ctx context.Context, rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error) {
+	if len(rqst.GetSort()) > 0 || len(rqst.GetFacets()) > 0 {
+		return nil, invalidf("sort and facets do not apply to %v searches", rqst.GetMode())
+	}
+	k := int(rqst.GetK())
+	if k <= 0 {
+		k = defaultK
+	}
+	if k > maxPageSize {
+		k = maxPageSize
+	}
+	size := int(rqst.GetPageSize())
+	if size <= 0 {
+		size = defaultPageSize
+	}
+	if size > maxPageSize {
+		size = maxPageSize
+	}
+	fingerprint := requestFingerprint(rqst)
+	offset := 0
+	if rqst.GetPageToken() != "" {
+		t, err := decodePageToken(rqst.GetPageToken())
+		if err != nil {
+			return nil, err
+		}
+		if t.Fingerprint != fingerprint || len(t.After) != 1 {
+			return nil, invalidf("page token belongs to another search")
+		}
+		if offset, err = strconv.Atoi(t.After[0]); err != nil || offset < 0 {
+			return nil, invalidf("malformed page token")
+		}
+	}
+
+	byName, indexes, err := engine.openPaths(rqst.GetPaths())
+	if err != nil {
+		return nil, err
+	}
+
+	text := strings.TrimSpace(rqst.GetQuery())
+	qv := rqst.GetVector()
+	if len(qv) == 0 && rqst.GetLikeId() != "" {
+		if qv, err = engine.likeVector(ctx, indexes, rqst.GetVectorField(), rqst.GetLikeId()); err != nil {
+			return nil, err
+		}
+	}
+	if len(qv) == 0 && text != "" {
+		e := engine.getEmbedder()
+		if e == nil {
+			return engine.keywordFallback(ctx, rqst)
+		}
+		vs, err := e.Embed(ctx, []string{text})
+		if err != nil || len(vs) != 1 {
+			return nil, fmt.Errorf("embed query with %s: %v", e.Name(), err)
+		}
+		qv = vs[0]
+	}
+	if len(qv) == 0 {
+		return nil, invalidf("%v search needs a vector, a like_id or a query", rqst.GetMode())
+	}
+
+	var filter query.Query
+	if len(rqst.GetFilters()) > 0 {
+		r := proto.Clone(rqst).(*searchpb.SearchDocumentsV2Request)
+		r.Mode, r.Query = searchpb.SearchMode_MATCH_ALL, ""
+		if filter, err = buildQuery(r); err != nil {
+			return nil, err
+		}
+	}
+
+	hybrid := rqst.GetMode() == searchpb.SearchMode_HYBRID && text != ""
+	window := k
+	if hybrid {
+		// Fusion needs more than the final k from each side.
+		window = 2 * k
+		if window < 50 {
+			window = 50
+		}
+		if window > maxPageSize {
+			window = maxPageSize
+		}
+	}
+	var ranked []rankedHit
+	for _, index := range indexes {
+		field, err := vectorFieldOf(index, rqst.GetVectorField())
+		if err != nil {
+			return nil, err
+		}
+		var allowed map[string]bool
+		if filter != nil {
+			if allowed, err = matchingIDs(ctx, index, filter); err != nil {
+				return nil, err
+			}
+		}
+		hits, err := engine.nearest(ctx, index, field, qv, allowed, rqst.GetLikeId(), window)
+		if err != nil {
+			return nil, err
+		}
+		ranked = append(ranked, hits...)
+	}
+	sortRanked(ranked)
+	if len(ranked) > window {
+		ranked = ranked[:window]
+	}
+
+	if hybrid {
+		kw := proto.Clone(rqst).(*searchpb.SearchDocumentsV2Request)
+		kw.Mode, kw.PageSize, kw.PageToken = searchpb.SearchMode_MATCH, int32(window), ""
+		res, err := engine.SearchDocumentsV2(ctx, kw)
+		if err != nil {
+			return nil, err
+		}
+		keyword := make([]rankedHit, 0, len(res.Hits))
+		for _, hit := range res.Hits {
+			keyword = append(keyword, rankedHit{index: hit.Index, id: hit.DocId})
+		}
+		ranked = fuseRanks(keyword, ranked)
+	}
+	if len(ranked) > k {
+		ranked = ranked[:k]
+	}
+
+	rsp := &searchpb.SearchDocumentsV2Response{Total: uint64(len(ranked))}
+	if offset > len(ranked) {
+		offset = len(ranked)
+	}
+	end := offset + size
+	if end < len(ranked) {
+		rsp.NextPageToken = encodePageToken(pageToken{After: []string{strconv.Itoa(end)}, Fingerprint: fingerprint})
+	} else {
+		end = len(ranked)
+	}
+	for _, h := range ranked[offset:end] {
+		out := &searchpb.SearchHit{Index: h.index, DocId: h.id, Score: h.score}
+		if index := byName[h.index]; index != nil {
+			if raw, err := index.GetInternal([]byte(h.id)); err == nil {
+				out.Data = string(raw)
+			}
+		}
+		rsp.Hits = append(rsp.Hits, out)
+	}
+	return rsp, nil
+}
@@ -0,0 +1,151 @@
+package search_engine
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/globulario/services/golang/search/searchpb"
+)
+
+func TestHashingEmbedder(t *testing.T) {
+	e := NewHashingEmbedder(0)
+	vs, err := e.Embed(context.Background(), []string{"Space Opera adventure", "space adventures", "cooking recipes", "Space Opera adventure"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(vs[0]) != defaultHashingDimensions {
+		t.Fatalf("dimensions = %d", len(vs[0]))
+	}
+	if cosine(vs[0], vs[3]) < 0.9999 {
+		t.Error("same text gives different vectors")
+	}
+	if near, far := cosine(vs[0], vs[1]), cosine(vs[0], vs[2]); near <= far {
+		t.Errorf("similarity to a related text %f <= to an unrelated one %f", near, far)
+	}
+}
+
+// TestKNNExplicitVectors searches a declared vector field holding the
+// vectors given by the documents.
+func TestKNNExplicitVectors(t *testing.T) {
+	dir := t.TempDir()
+	engine := NewBleveSearchEngine()
+	t.Cleanup(func() { _ = engine.CloseAll() })
+	path := filepath.Join(dir, "points")
+	if err := engine.CreateIndex(path, &searchpb.IndexMapping{Fields: []*searchpb.FieldMapping{
+		{Name: "v", Type: searchpb.FieldType_FIELD_VECTOR, Dims: 2},
+		{Name: "color", Type: searchpb.FieldType_FIELD_KEYWORD},
+	}}); err != nil {
+		t.Fatal(err)
+	}
+	results, err := engine.IndexBatch(path, "id", []*searchpb.BulkDocument{
+		{JsonStr: `{"id":"east","v":[1,0],"color":"red"}`},
+		{JsonStr: `{"id":"north-east","v":[1,1],"color":"blue"}`},
+		{JsonStr: `{"id":"north","v":[0,1],"color":"red"}`},
+		{JsonStr: `{"id":"bad","v":[1,2,3]}`},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if results[3].Error == "" {
+		t.Error("vector of the wrong size accepted")
+	}
+
+	rqst := &searchpb.SearchDocumentsV2Request{Paths: []string{path}, Mode: searchpb.SearchMode_KNN, Vector: []float32{1, 0.1}, K: 3, PageSize: 2}
+	rsp, err := engine.SearchDocumentsV2(context.Background(), rqst)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rsp.Total != 3 || len(rsp.Hits) != 2 || rsp.Hits[0].DocId != "east" || rsp.Hits[1].DocId != "north-east" || rsp.NextPageToken == "" {
+		t.Fatalf("first page = %v", rsp)
+	}
+	rqst.PageToken = rsp.NextPageToken
+	if rsp, err = engine.SearchDocumentsV2(context.Background(), rqst); err != nil || len(rsp.Hits) != 1 || rsp.Hits[0].DocId != "north" || rsp.NextPageToken != "" {
+		t.Fatalf("second page = %v, %v", rsp, err)
+	}
+
+	// Filters restrict the neighbours; the document itself is not similar to itself.
+	rsp, err = engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_KNN, LikeId: "east",
+		Filters: []*searchpb.SearchFilter{{Field: "color", Terms: []string{"red"}}},
+	})
+	if err != nil || len(rsp.Hits) != 1 || rsp.Hits[0].DocId != "north" {
+		t.Errorf("like east, red only = %v, %v", rsp, err)
+	}
+
+	// Deleted documents leave the loaded vectors.
+	if err := engine.DeleteDocument(path, "east"); err != nil {
+		t.Fatal(err)
+	}
+	rqst.PageToken = ""
+	if rsp, err = engine.SearchDocumentsV2(context.Background(), rqst); err != nil || rsp.Total != 2 || rsp.Hits[0].DocId != "north-east" {
+		t.Errorf("after delete = %v, %v", rsp, err)
+	}
+
+	if _, err := engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_KNN, Vector: []float32{1, 0, 0},
+	}); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("query vector of the wrong size: err = %v", err)
+	}
+}
+
+// TestEmbeddedSearch covers the implicit vector field, hybrid search and the
+// keyword fallback without an embedder.
+func TestEmbeddedSearch(t *testing.T) {
+	dir := t.TempDir()
+	path := filepath.Join(dir, "posts")
+	docs := []string{
+		`{"id":"p1","title":"Growing tomatoes on a balcony","author":"ann"}`,
+		`{"id":"p2","title":"Tomato sauce recipes","author":"bob"}`,
+		`{"id":"p3","title":"Rust ownership explained","author":"cid"}`,
+	}
+
+	// Without an embedder, vector searches on a query string degrade.
+	plain := NewBleveSearchEngine()
+	t.Cleanup(func() { _ = plain.CloseAll() })
+	for _, doc := range docs {
+		if err := plain.IndexJsonObject(path, doc, "en", "id", []string{"title"}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rsp, err := plain.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_KNN, Query: "ownership",
+	})
+	if err != nil || !rsp.Degraded || len(rsp.Hits) != 1 || rsp.Hits[0].DocId != "p3" {
+		t.Fatalf("degraded search = %v, %v", rsp, err)
+	}
+	_ = plain.CloseAll()
+
+	engine := NewBleveSearchEngine()
+	t.Cleanup(func() { _ = engine.CloseAll() })
+	engine.SetEmbedder(NewHashingEmbedder(128))
+	for _, doc := range docs {
+		if err := engine.IndexJsonObject(path, doc, "en", "id", []string{"title"}, ""); err != nil {
+			t.Fatal(err)
+		}
+	}
+	rsp, err = engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_KNN, Query: "tomatoes", K: 2,
+	})
+	if err != nil || rsp.Degraded || len(rsp.Hits) != 2 || rsp.Hits[0].DocId != "p1" || rsp.Hits[1].DocId != "p2" {
+		t.Fatalf("knn = %v, %v", rsp, err)
+	}
+	if rsp.Hits[0].Data == "" {
+		t.Error("hit without data")
+	}
+
+	// "tomato" matches p2 by keyword and p1 by shared trigrams; both lead p3.
+	rsp, err = engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_HYBRID, Query: "tomato", K: 3,
+	})
+	if err != nil || len(rsp.Hits) != 3 || rsp.Hits[2].DocId != "p3" {
+		t.Fatalf("hybrid = %v, %v", rsp, err)
+	}
+
+	if _, err := engine.SearchDocumentsV2(context.Background(), &searchpb.SearchDocumentsV2Request{
+		Paths: []string{path}, Mode: searchpb.SearchMode_KNN, Query: "x", Sort: []*searchpb.SearchSort{{Field: "author"}},
+	}); !errors.Is(err, ErrInvalidSearch) {
+		t.Errorf("sorted knn: err = %v", err)
+	}
+}
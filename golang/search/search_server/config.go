@@ -39,6 +39,11 @@ type Config struct {
 	CertAuthorityTrust string   `json:"CertAuthorityTrust"`
 
 	Permissions []any `json:"Permissions"`
+
+	// Embedder embeds text for vector search: "hashing", or empty to run
+	// vector searches on a query string as keyword searches.
+	Embedder            string `json:"Embedder"`
+	EmbeddingDimensions int    `json:"EmbeddingDimensions"`
 }
 
 func DefaultConfig() *Config {
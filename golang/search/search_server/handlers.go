@@ -6,7 +6,11 @@ package main
 
 import (
 	"context"
+	"errors"
+	"io"
 	"log/slog"
+	"path/filepath"
+	"sort"
 	"time"
 
 	"github.com/globulario/services/golang/config"
@@ -65,6 +69,10 @@ type server struct {
 	KeyFile            string
 	CertAuthorityTrust string
 
+	// Embeddings ("hashing" or empty; see search_engine.Embedder)
+	Embedder            string
+	EmbeddingDimensions int
+
 	// Runtime
 	grpcServer *grpc.Server
 
@@ -186,7 +194,21 @@ func (srv *server) Init() error {
 	}
 	srv.grpcServer = gs
 	// init the search engine (Bleve based)
-	srv.search_engine = search_engine.NewBleveSearchEngine()
+	engine := search_engine.NewBleveSearchEngine()
+	if err := engine.SetAliasFile(filepath.Join(config.GetDataDir(), "search", "aliases.json")); err != nil {
+		srv.logger.Warn("index aliases unavailable", "err", err)
+	}
+	if err := engine.SetIndexRegistryFile(filepath.Join(config.GetDataDir(), "search", "indexes.json")); err != nil {
+		srv.logger.Warn("index registry unavailable", "err", err)
+	}
+	switch srv.Embedder {
+	case "":
+	case "hashing":
+		engine.SetEmbedder(search_engine.NewHashingEmbedder(srv.EmbeddingDimensions))
+	default:
+		srv.logger.Warn("unknown embedder, vector searches fall back to keyword search", "embedder", srv.Embedder)
+	}
+	srv.search_engine = engine
 	return nil
 }
 
@@ -275,6 +297,159 @@ func (srv *server) SearchDocuments(rqst *searchpb.SearchDocumentsRequest, stream
 	return stream.Send(&searchpb.SearchDocumentsResponse{Results: results})
 }
 
+// SearchDocumentsV2 searches indexes and aliases together and returns one
+// globally ranked page with facets.
+func (srv *server) SearchDocumentsV2(ctx context.Context, rqst *searchpb.SearchDocumentsV2Request) (*searchpb.SearchDocumentsV2Response, error) {
+	rsp, err := srv.search_engine.SearchDocumentsV2(ctx, rqst)
+	if err != nil {
+		return nil, engineError("search failed", err)
+	}
+	return rsp, nil
+}
+
+// SetIndexAlias creates or replaces an alias over index paths.
+func (srv *server) SetIndexAlias(ctx context.Context, rqst *searchpb.SetIndexAliasRequest) (*searchpb.SetIndexAliasResponse, error) {
+	if err := srv.search_engine.SetAlias(rqst.Alias, rqst.Paths); err != nil {
+		return nil, status.Errorf(codes.InvalidArgument, "set alias: %v", err)
+	}
+	return &searchpb.SetIndexAliasResponse{}, nil
+}
+
+// DeleteIndexAlias removes an alias.
+func (srv *server) DeleteIndexAlias(ctx context.Context, rqst *searchpb.DeleteIndexAliasRequest) (*searchpb.DeleteIndexAliasResponse, error) {
+	if err := srv.search_engine.RemoveAlias(rqst.Alias); err != nil {
+		return nil, engineError("delete alias", err)
+	}
+	return &searchpb.DeleteIndexAliasResponse{}, nil
+}
+
+// ListIndexAliases returns the aliases, sorted by name.
+func (srv *server) ListIndexAliases(ctx context.Context, rqst *searchpb.ListIndexAliasesRequest) (*searchpb.ListIndexAliasesResponse, error) {
+	aliases := srv.search_engine.Aliases()
+	rsp := &searchpb.ListIndexAliasesResponse{Aliases: make([]*searchpb.IndexAlias, 0, len(aliases))}
+	for alias, paths := range aliases {
+		rsp.Aliases = append(rsp.Aliases, &searchpb.IndexAlias{Alias: alias, Paths: paths})
+	}
+	sort.Slice(rsp.Aliases, func(i, j int) bool { return rsp.Aliases[i].Alias < rsp.Aliases[j].Alias })
+	return rsp, nil
+}
+
+// engineError maps an engine error to a gRPC status.
+func engineError(msg string, err error) error {
+	code := codes.Internal
+	switch {
+	case errors.Is(err, search_engine.ErrInvalidSearch), errors.Is(err, search_engine.ErrInvalidMapping):
+		code = codes.InvalidArgument
+	case errors.Is(err, search_engine.ErrUnknownIndex), errors.Is(err, search_engine.ErrUnknownAlias):
+		code = codes.NotFound
+	case errors.Is(err, search_engine.ErrIndexExists):
+		code = codes.AlreadyExists
+	case errors.Is(err, search_engine.ErrIndexInUse):
+		code = codes.FailedPrecondition
+	case errors.Is(err, context.Canceled), errors.Is(err, context.DeadlineExceeded):
+		code = codes.Canceled
+	}
+	return status.Errorf(code, "%s: %v", msg, err)
+}
+
+// BulkIndex indexes a stream of documents and reports the outcome of each
+// one. Path and id field carry over from one message to the next.
+// When the shared index is active, documents are enqueued one by one.
+func (srv *server) BulkIndex(stream searchpb.SearchService_BulkIndexServer) error {
+	rsp := &searchpb.BulkIndexResponse{}
+	var path, idField string
+	var position int32
+	for {
+		rqst, err := stream.Recv()
+		if err == io.EOF {
+			return stream.SendAndClose(rsp)
+		}
+		if err != nil {
+			return err
+		}
+		if rqst.Path != "" {
+			path = rqst.Path
+		}
+		if rqst.IdField != "" {
+			idField = rqst.IdField
+		}
+		if path == "" || idField == "" {
+			return status.Error(codes.InvalidArgument, "path and id_field are required")
+		}
+
+		var results []*searchpb.BulkIndexResult
+		if srv.sharedIndex != nil {
+			for i, doc := range rqst.Documents {
+				res := &searchpb.BulkIndexResult{Position: int32(i)}
+				id, _, err := search_engine.DocumentID(doc.JsonStr, idField)
+				res.Id = id
+				if err == nil {
+					err = srv.sharedIndex.Enqueue(path, id, doc.JsonStr, doc.Data, idField, nil)
+				}
+				if err != nil {
+					res.Error = err.Error()
+				}
+				results = append(results, res)
+			}
+		} else {
+			results, err = srv.search_engine.IndexBatch(path, idField, rqst.Documents)
+			if err != nil {
+				return engineError("bulk index", err)
+			}
+		}
+
+		for _, res := range results {
+			res.Position += position
+			if res.Error == "" {
+				rsp.Indexed++
+			} else {
+				rsp.Failed++
+			}
+		}
+		rsp.Results = append(rsp.Results, results...)
+		position += int32(len(rqst.Documents))
+	}
+}
+
+// CreateIndex creates an empty index with an explicit mapping.
+func (srv *server) CreateIndex(ctx context.Context, rqst *searchpb.CreateIndexRequest) (*searchpb.CreateIndexResponse, error) {
+	if err := srv.search_engine.CreateIndex(rqst.Path, rqst.Mapping); err != nil {
+		return nil, engineError("create index", err)
+	}
+	return &searchpb.CreateIndexResponse{}, nil
+}
+
+// GetIndexMapping returns the mapping of an index.
+func (srv *server) GetIndexMapping(ctx context.Context, rqst *searchpb.GetIndexMappingRequest) (*searchpb.GetIndexMappingResponse, error) {
+	mapping, mappingJSON, err := srv.search_engine.GetIndexMapping(rqst.Path)
+	if err != nil {
+		return nil, engineError("get index mapping", err)
+	}
+	return &searchpb.GetIndexMappingResponse{Mapping: mapping, MappingJson: mappingJSON}, nil
+}
+
+// ListIndexes lists the indexes known to the service.
+func (srv *server) ListIndexes(ctx context.Context, rqst *searchpb.ListIndexesRequest) (*searchpb.ListIndexesResponse, error) {
+	return &searchpb.ListIndexesResponse{Indexes: srv.search_engine.ListIndexes(rqst.Prefix)}, nil
+}
+
+// ReindexTo rebuilds an index into a new one and swaps an alias to it.
+func (srv *server) ReindexTo(ctx context.Context, rqst *searchpb.ReindexToRequest) (*searchpb.ReindexToResponse, error) {
+	n, err := srv.search_engine.ReindexTo(ctx, rqst.Source, rqst.Target, rqst.Mapping, rqst.Alias, rqst.DeleteSource)
+	if err != nil {
+		return nil, engineError("reindex", err)
+	}
+	return &searchpb.ReindexToResponse{Documents: n}, nil
+}
+
+// DeleteIndex closes an index and removes it from disk.
+func (srv *server) DeleteIndex(ctx context.Context, rqst *searchpb.DeleteIndexRequest) (*searchpb.DeleteIndexResponse, error) {
+	if err := srv.search_engine.DeleteIndex(rqst.Path); err != nil {
+		return nil, engineError("delete index", err)
+	}
+	return &searchpb.DeleteIndexResponse{}, nil
+}
+
 // IndexJsonObject indexes a JSON object/array of objects.
 // When the shared index is active, the operation is enqueued to ScyllaDB
 // and processed by the writer instance. Otherwise falls back to local indexing.
@@ -58,6 +58,7 @@ func initializeServerDefaults() *server {
 	}
 
 	s.Domain, s.Address = globular.GetDefaultDomainAddress(s.Port)
+	s.Embedder, s.EmbeddingDimensions = cfg.Embedder, cfg.EmbeddingDimensions
 	return s
 }
 
@@ -92,6 +93,9 @@ func printUsage() {
 	fmt.Println("  • Full-text document indexing")
 	fmt.Println("  • JSON object indexing")
 	fmt.Println("  • Document search with query syntax")
+	fmt.Println("  • Globally ranked search across indexes and aliases, with facets, sort and paging")
+	fmt.Println("  • Bulk indexing, explicit field mappings, reindexing with alias swap")
+	fmt.Println("  • Vector fields with k-NN and hybrid search (local hashing embedder)")
 	fmt.Println("  • Document count and statistics")
 	fmt.Println("  • Document deletion and management")
 	fmt.Println("  • Search engine version information")
@@ -164,6 +168,16 @@ func main() {
 		{Method: "/search.SearchService/Count", Action: "search.count"},
 		{Method: "/search.SearchService/DeleteDocument", Action: "search.delete"},
 		{Method: "/search.SearchService/SearchDocuments", Action: "search.query"},
+		{Method: "/search.SearchService/SearchDocumentsV2", Action: "search.query"},
+		{Method: "/search.SearchService/SetIndexAlias", Action: "search.alias.write"},
+		{Method: "/search.SearchService/DeleteIndexAlias", Action: "search.alias.delete"},
+		{Method: "/search.SearchService/ListIndexAliases", Action: "search.alias.read"},
+		{Method: "/search.SearchService/BulkIndex", Action: "search.index.write"},
+		{Method: "/search.SearchService/CreateIndex", Action: "search.index.create"},
+		{Method: "/search.SearchService/GetIndexMapping", Action: "search.index.read"},
+		{Method: "/search.SearchService/ListIndexes", Action: "search.index.list"},
+		{Method: "/search.SearchService/ReindexTo", Action: "search.index.reindex"},
+		{Method: "/search.SearchService/DeleteIndex", Action: "search.index.delete"},
 	})
 
 	if *showDescribe {
@@ -26,6 +26,130 @@ const (
 	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
 )
 
+// How the query string of SearchDocumentsV2Request is interpreted.
+type SearchMode int32
+
+const (
+	SearchMode_QUERY_STRING SearchMode = 0 // Bleve query string syntax (+must -must_not field:value "phrase" fuzzy~).
+	SearchMode_MATCH        SearchMode = 1 // Analyzed match on the fields; fuzziness applies.
+	SearchMode_PHRASE       SearchMode = 2 // Analyzed phrase on the fields.
+	SearchMode_FUZZY        SearchMode = 3 // Fuzzy term on the fields; fuzziness defaults to 1.
+	SearchMode_PREFIX       SearchMode = 4 // Term prefix on the fields.
+	SearchMode_MATCH_ALL    SearchMode = 5 // Every document; the query string is ignored.
+	SearchMode_KNN          SearchMode = 6 // Nearest neighbours of the query vector, or of the embedded query string.
+	SearchMode_HYBRID       SearchMode = 7 // MATCH and KNN results fused by rank.
+)
+
+// Enum value maps for SearchMode.
+var (
+	SearchMode_name = map[int32]string{
+		0: "QUERY_STRING",
+		1: "MATCH",
+		2: "PHRASE",
+		3: "FUZZY",
+		4: "PREFIX",
+		5: "MATCH_ALL",
+		6: "KNN",
+		7: "HYBRID",
+	}
+	SearchMode_value = map[string]int32{
+		"QUERY_STRING": 0,
+		"MATCH":        1,
+		"PHRASE":       2,
+		"FUZZY":        3,
+		"PREFIX":       4,
+		"MATCH_ALL":    5,
+		"KNN":          6,
+		"HYBRID":       7,
+	}
+)
+
+func (x SearchMode) Enum() *SearchMode {
+	p := new(SearchMode)
+	*p = x
+	return p
+}
+
+func (x SearchMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (SearchMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_search_proto_enumTypes[0].Descriptor()
+}
+
+func (SearchMode) Type() protoreflect.EnumType {
+	return &file_search_proto_enumTypes[0]
+}
+
+func (x SearchMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use SearchMode.Descriptor instead.
+func (SearchMode) EnumDescriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{0}
+}
+
+// Type of a mapped field.
+type FieldType int32
+
+const (
+	FieldType_FIELD_TEXT     FieldType = 0 // Analyzed text.
+	FieldType_FIELD_KEYWORD  FieldType = 1 // Exact value, not analyzed.
+	FieldType_FIELD_NUMERIC  FieldType = 2
+	FieldType_FIELD_DATETIME FieldType = 3 // RFC 3339 dates.
+	FieldType_FIELD_BOOLEAN  FieldType = 4
+	FieldType_FIELD_VECTOR   FieldType = 5 // Dense vector, given as a number array or embedded from source_fields.
+)
+
+// Enum value maps for FieldType.
+var (
+	FieldType_name = map[int32]string{
+		0: "FIELD_TEXT",
+		1: "FIELD_KEYWORD",
+		2: "FIELD_NUMERIC",
+		3: "FIELD_DATETIME",
+		4: "FIELD_BOOLEAN",
+		5: "FIELD_VECTOR",
+	}
+	FieldType_value = map[string]int32{
+		"FIELD_TEXT":     0,
+		"FIELD_KEYWORD":  1,
+		"FIELD_NUMERIC":  2,
+		"FIELD_DATETIME": 3,
+		"FIELD_BOOLEAN":  4,
+		"FIELD_VECTOR":   5,
+	}
+)
+
+func (x FieldType) Enum() *FieldType {
+	p := new(FieldType)
+	*p = x
+	return p
+}
+
+func (x FieldType) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (FieldType) Descriptor() protoreflect.EnumDescriptor {
+	return file_search_proto_enumTypes[1].Descriptor()
+}
+
+func (FieldType) Type() protoreflect.EnumType {
+	return &file_search_proto_enumTypes[1]
+}
+
+func (x FieldType) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use FieldType.Descriptor instead.
+func (FieldType) EnumDescriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{1}
+}
+
 // Request for getting the version of the search engine.
 type GetEngineVersionRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -662,27 +786,35 @@ func (x *SearchDocumentsResponse) GetResults() *SearchResults {
 	return nil
 }
 
-// Request to stop the server.
-type StopRequest struct {
+// Restricts the matching documents without affecting their score. A filter
+// on terms, on a numeric range or on a date range, set on one field.
+type SearchFilter struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`      // Field to filter on.
+	Terms         []string               `protobuf:"bytes,2,rep,name=terms,proto3" json:"terms,omitempty"`      // Any of these exact terms.
+	Min           *float64               `protobuf:"fixed64,3,opt,name=min,proto3,oneof" json:"min,omitempty"`  // Numeric range, inclusive.
+	Max           *float64               `protobuf:"fixed64,4,opt,name=max,proto3,oneof" json:"max,omitempty"`  // Numeric range, exclusive.
+	Start         string                 `protobuf:"bytes,5,opt,name=start,proto3" json:"start,omitempty"`      // Date range start (RFC 3339), inclusive.
+	End           string                 `protobuf:"bytes,6,opt,name=end,proto3" json:"end,omitempty"`          // Date range end (RFC 3339), exclusive.
+	Exclude       bool                   `protobuf:"varint,7,opt,name=exclude,proto3" json:"exclude,omitempty"` // Keep the documents that do not match.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopRequest) Reset() {
-	*x = StopRequest{}
+func (x *SearchFilter) Reset() {
+	*x = SearchFilter{}
 	mi := &file_search_proto_msgTypes[12]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopRequest) String() string {
+func (x *SearchFilter) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopRequest) ProtoMessage() {}
+func (*SearchFilter) ProtoMessage() {}
 
-func (x *StopRequest) ProtoReflect() protoreflect.Message {
+func (x *SearchFilter) ProtoReflect() protoreflect.Message {
 	mi := &file_search_proto_msgTypes[12]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -694,32 +826,84 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
-func (*StopRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchFilter.ProtoReflect.Descriptor instead.
+func (*SearchFilter) Descriptor() ([]byte, []int) {
 	return file_search_proto_rawDescGZIP(), []int{12}
 }
 
-// Response for StopRequest.
-type StopResponse struct {
+func (x *SearchFilter) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetTerms() []string {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *SearchFilter) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+func (x *SearchFilter) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *SearchFilter) GetExclude() bool {
+	if x != nil {
+		return x.Exclude
+	}
+	return false
+}
+
+// Sort key of SearchDocumentsV2Request. The field "_score" sorts by
+// relevance and "_id" by document id.
+type SearchSort struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Field         string                 `protobuf:"bytes,1,opt,name=field,proto3" json:"field,omitempty"`
+	Descending    bool                   `protobuf:"varint,2,opt,name=descending,proto3" json:"descending,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopResponse) Reset() {
-	*x = StopResponse{}
+func (x *SearchSort) Reset() {
+	*x = SearchSort{}
 	mi := &file_search_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopResponse) String() string {
+func (x *SearchSort) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopResponse) ProtoMessage() {}
+func (*SearchSort) ProtoMessage() {}
 
-func (x *StopResponse) ProtoReflect() protoreflect.Message {
+func (x *SearchSort) ProtoReflect() protoreflect.Message {
 	mi := &file_search_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -731,59 +915,2284 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
-func (*StopResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use SearchSort.ProtoReflect.Descriptor instead.
+func (*SearchSort) Descriptor() ([]byte, []int) {
 	return file_search_proto_rawDescGZIP(), []int{13}
 }
 
-var File_search_proto protoreflect.FileDescriptor
+func (x *SearchSort) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
 
-const file_search_proto_rawDesc = "" +
-	"\n" +
-	"\fsearch.proto\x12\x06search\x1a\x13globular_auth.proto\"\x19\n" +
-	"\x17GetEngineVersionRequest\"4\n" +
-	"\x18GetEngineVersionResponse\x12\x18\n" +
-	"\amessage\x18\x01 \x01(\tR\amessage\"\xad\x01\n" +
-	"\x16IndexJsonObjectRequest\x12!\n" +
-	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05index\x10\x01R\x04path\x12\x18\n" +
-	"\ajsonStr\x18\x02 \x01(\tR\ajsonStr\x12\x1a\n" +
-	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x0e\n" +
-	"\x02id\x18\x04 \x01(\tR\x02id\x12\x16\n" +
-	"\x06indexs\x18\x05 \x03(\tR\x06indexs\x12\x12\n" +
-	"\x04data\x18\x06 \x01(\tR\x04data\"\x19\n" +
-	"\x17IndexJsonObjectResponse\"Z\n" +
-	"\x15DeleteDocumentRequest\x12!\n" +
-	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05index\x10\x01R\x04path\x12\x1e\n" +
-	"\x02id\x18\x02 \x01(\tB\x0e\x8a\xb5\x18\n" +
-	"\n" +
-	"\bdocumentR\x02id\"\x18\n" +
-	"\x16DeleteDocumentResponse\"1\n" +
-	"\fCountRequest\x12!\n" +
-	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05index\x10\x01R\x04path\"'\n" +
-	"\rCountResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\x05R\x06result\"f\n" +
-	"\fSearchResult\x12\x12\n" +
-	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x14\n" +
-	"\x05docId\x18\x02 \x01(\tR\x05docId\x12\x12\n" +
-	"\x04data\x18\x03 \x01(\tR\x04data\x12\x18\n" +
-	"\asnippet\x18\x04 \x01(\tR\asnippet\"?\n" +
-	"\rSearchResults\x12.\n" +
-	"\aresults\x18\x01 \x03(\v2\x14.search.SearchResultR\aresults\"\xd2\x01\n" +
-	"\x16SearchDocumentsRequest\x12\x14\n" +
-	"\x05paths\x18\x01 \x03(\tR\x05paths\x12\x14\n" +
-	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1a\n" +
-	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x16\n" +
-	"\x06fields\x18\x04 \x03(\tR\x06fields\x12\x16\n" +
-	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1a\n" +
-	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12$\n" +
-	"\rsnippetLength\x18\a \x01(\x05R\rsnippetLength\"J\n" +
-	"\x17SearchDocumentsResponse\x12/\n" +
-	"\aresults\x18\x01 \x01(\v2\x15.search.SearchResultsR\aresults\"\r\n" +
-	"\vStopRequest\"\x0e\n" +
-	"\fStopResponse2\xac\x06\n" +
+func (x *SearchSort) GetDescending() bool {
+	if x != nil {
+		return x.Descending
+	}
+	return false
+}
+
+// A named numeric range of a facet; min is inclusive and max exclusive.
+type SearchNumericRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Min           *float64               `protobuf:"fixed64,2,opt,name=min,proto3,oneof" json:"min,omitempty"`
+	Max           *float64               `protobuf:"fixed64,3,opt,name=max,proto3,oneof" json:"max,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchNumericRange) Reset() {
+	*x = SearchNumericRange{}
+	mi := &file_search_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchNumericRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchNumericRange) ProtoMessage() {}
+
+func (x *SearchNumericRange) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchNumericRange.ProtoReflect.Descriptor instead.
+func (*SearchNumericRange) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *SearchNumericRange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchNumericRange) GetMin() float64 {
+	if x != nil && x.Min != nil {
+		return *x.Min
+	}
+	return 0
+}
+
+func (x *SearchNumericRange) GetMax() float64 {
+	if x != nil && x.Max != nil {
+		return *x.Max
+	}
+	return 0
+}
+
+// A named date range of a facet (RFC 3339); start is inclusive and end
+// exclusive. An empty bound is open.
+type SearchDateRange struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Start         string                 `protobuf:"bytes,2,opt,name=start,proto3" json:"start,omitempty"`
+	End           string                 `protobuf:"bytes,3,opt,name=end,proto3" json:"end,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDateRange) Reset() {
+	*x = SearchDateRange{}
+	mi := &file_search_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDateRange) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDateRange) ProtoMessage() {}
+
+func (x *SearchDateRange) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDateRange.ProtoReflect.Descriptor instead.
+func (*SearchDateRange) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *SearchDateRange) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchDateRange) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *SearchDateRange) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+// Facet to compute over the matching documents: the top terms of a field,
+// or counts per numeric or date range when ranges are given.
+type SearchFacetRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`   // Facet name in the response.
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"` // Field to facet on.
+	Size          int32                  `protobuf:"varint,3,opt,name=size,proto3" json:"size,omitempty"`  // Number of terms (default 10).
+	NumericRanges []*SearchNumericRange  `protobuf:"bytes,4,rep,name=numeric_ranges,json=numericRanges,proto3" json:"numeric_ranges,omitempty"`
+	DateRanges    []*SearchDateRange     `protobuf:"bytes,5,rep,name=date_ranges,json=dateRanges,proto3" json:"date_ranges,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFacetRequest) Reset() {
+	*x = SearchFacetRequest{}
+	mi := &file_search_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacetRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacetRequest) ProtoMessage() {}
+
+func (x *SearchFacetRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacetRequest.ProtoReflect.Descriptor instead.
+func (*SearchFacetRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *SearchFacetRequest) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchFacetRequest) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchFacetRequest) GetSize() int32 {
+	if x != nil {
+		return x.Size
+	}
+	return 0
+}
+
+func (x *SearchFacetRequest) GetNumericRanges() []*SearchNumericRange {
+	if x != nil {
+		return x.NumericRanges
+	}
+	return nil
+}
+
+func (x *SearchFacetRequest) GetDateRanges() []*SearchDateRange {
+	if x != nil {
+		return x.DateRanges
+	}
+	return nil
+}
+
+// Search request returning one globally ranked page across indexes.
+type SearchDocumentsV2Request struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Paths     []string               `protobuf:"bytes,1,rep,name=paths,proto3" json:"paths,omitempty"` // Index paths or aliases to search in.
+	Query     string                 `protobuf:"bytes,2,opt,name=query,proto3" json:"query,omitempty"` // Query, interpreted according to mode.
+	Mode      SearchMode             `protobuf:"varint,3,opt,name=mode,proto3,enum=search.SearchMode" json:"mode,omitempty"`
+	Fields    []string               `protobuf:"bytes,4,rep,name=fields,proto3" json:"fields,omitempty"`        // Fields to match (non query-string modes); all fields when empty.
+	Fuzziness int32                  `protobuf:"varint,5,opt,name=fuzziness,proto3" json:"fuzziness,omitempty"` // Edit distance for MATCH and FUZZY (max 2).
+	Filters   []*SearchFilter        `protobuf:"bytes,6,rep,name=filters,proto3" json:"filters,omitempty"`
+	Sort      []*SearchSort          `protobuf:"bytes,7,rep,name=sort,proto3" json:"sort,omitempty"` // Default: relevance, then document id.
+	Facets    []*SearchFacetRequest  `protobuf:"bytes,8,rep,name=facets,proto3" json:"facets,omitempty"`
+	PageSize  int32                  `protobuf:"varint,9,opt,name=page_size,json=pageSize,proto3" json:"page_size,omitempty"`    // Default 20, max 1000.
+	PageToken string                 `protobuf:"bytes,10,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // Token of the previous page.
+	Highlight bool                   `protobuf:"varint,11,opt,name=highlight,proto3" json:"highlight,omitempty"`                 // Return highlighted fragments as the snippet.
+	// KNN and HYBRID modes. Sort and facets do not apply; filters do.
+	VectorField   string    `protobuf:"bytes,12,opt,name=vector_field,json=vectorField,proto3" json:"vector_field,omitempty"` // Vector field to search; the only one of each index when empty.
+	Vector        []float32 `protobuf:"fixed32,13,rep,packed,name=vector,proto3" json:"vector,omitempty"`                     // Query vector; the query string is embedded when empty.
+	LikeId        string    `protobuf:"bytes,14,opt,name=like_id,json=likeId,proto3" json:"like_id,omitempty"`                // Use the vector of this document ("find similar").
+	K             int32     `protobuf:"varint,15,opt,name=k,proto3" json:"k,omitempty"`                                       // Number of neighbours (default 10, max 1000).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsV2Request) Reset() {
+	*x = SearchDocumentsV2Request{}
+	mi := &file_search_proto_msgTypes[17]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsV2Request) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsV2Request) ProtoMessage() {}
+
+func (x *SearchDocumentsV2Request) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[17]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsV2Request.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsV2Request) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{17}
+}
+
+func (x *SearchDocumentsV2Request) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetQuery() string {
+	if x != nil {
+		return x.Query
+	}
+	return ""
+}
+
+func (x *SearchDocumentsV2Request) GetMode() SearchMode {
+	if x != nil {
+		return x.Mode
+	}
+	return SearchMode_QUERY_STRING
+}
+
+func (x *SearchDocumentsV2Request) GetFields() []string {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetFuzziness() int32 {
+	if x != nil {
+		return x.Fuzziness
+	}
+	return 0
+}
+
+func (x *SearchDocumentsV2Request) GetFilters() []*SearchFilter {
+	if x != nil {
+		return x.Filters
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetSort() []*SearchSort {
+	if x != nil {
+		return x.Sort
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetFacets() []*SearchFacetRequest {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetPageSize() int32 {
+	if x != nil {
+		return x.PageSize
+	}
+	return 0
+}
+
+func (x *SearchDocumentsV2Request) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *SearchDocumentsV2Request) GetHighlight() bool {
+	if x != nil {
+		return x.Highlight
+	}
+	return false
+}
+
+func (x *SearchDocumentsV2Request) GetVectorField() string {
+	if x != nil {
+		return x.VectorField
+	}
+	return ""
+}
+
+func (x *SearchDocumentsV2Request) GetVector() []float32 {
+	if x != nil {
+		return x.Vector
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Request) GetLikeId() string {
+	if x != nil {
+		return x.LikeId
+	}
+	return ""
+}
+
+func (x *SearchDocumentsV2Request) GetK() int32 {
+	if x != nil {
+		return x.K
+	}
+	return 0
+}
+
+// One hit of SearchDocumentsV2Response.
+type SearchHit struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Index         string                 `protobuf:"bytes,1,opt,name=index,proto3" json:"index,omitempty"`     // Path of the index the document is in.
+	DocId         string                 `protobuf:"bytes,2,opt,name=docId,proto3" json:"docId,omitempty"`     // Document ID.
+	Score         float64                `protobuf:"fixed64,3,opt,name=score,proto3" json:"score,omitempty"`   // Relevance score.
+	Data          string                 `protobuf:"bytes,4,opt,name=data,proto3" json:"data,omitempty"`       // Stored data of the document.
+	Snippet       string                 `protobuf:"bytes,5,opt,name=snippet,proto3" json:"snippet,omitempty"` // Highlighted fragments (JSON), when asked.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchHit) Reset() {
+	*x = SearchHit{}
+	mi := &file_search_proto_msgTypes[18]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchHit) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchHit) ProtoMessage() {}
+
+func (x *SearchHit) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[18]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchHit.ProtoReflect.Descriptor instead.
+func (*SearchHit) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{18}
+}
+
+func (x *SearchHit) GetIndex() string {
+	if x != nil {
+		return x.Index
+	}
+	return ""
+}
+
+func (x *SearchHit) GetDocId() string {
+	if x != nil {
+		return x.DocId
+	}
+	return ""
+}
+
+func (x *SearchHit) GetScore() float64 {
+	if x != nil {
+		return x.Score
+	}
+	return 0
+}
+
+func (x *SearchHit) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+func (x *SearchHit) GetSnippet() string {
+	if x != nil {
+		return x.Snippet
+	}
+	return ""
+}
+
+type SearchTermCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Term          string                 `protobuf:"bytes,1,opt,name=term,proto3" json:"term,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchTermCount) Reset() {
+	*x = SearchTermCount{}
+	mi := &file_search_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchTermCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchTermCount) ProtoMessage() {}
+
+func (x *SearchTermCount) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchTermCount.ProtoReflect.Descriptor instead.
+func (*SearchTermCount) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *SearchTermCount) GetTerm() string {
+	if x != nil {
+		return x.Term
+	}
+	return ""
+}
+
+func (x *SearchTermCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+type SearchRangeCount struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Count         int64                  `protobuf:"varint,2,opt,name=count,proto3" json:"count,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchRangeCount) Reset() {
+	*x = SearchRangeCount{}
+	mi := &file_search_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchRangeCount) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchRangeCount) ProtoMessage() {}
+
+func (x *SearchRangeCount) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchRangeCount.ProtoReflect.Descriptor instead.
+func (*SearchRangeCount) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *SearchRangeCount) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchRangeCount) GetCount() int64 {
+	if x != nil {
+		return x.Count
+	}
+	return 0
+}
+
+// Result of a SearchFacetRequest.
+type SearchFacetResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Field         string                 `protobuf:"bytes,2,opt,name=field,proto3" json:"field,omitempty"`
+	Total         int64                  `protobuf:"varint,3,opt,name=total,proto3" json:"total,omitempty"`     // Values counted.
+	Missing       int64                  `protobuf:"varint,4,opt,name=missing,proto3" json:"missing,omitempty"` // Documents without the field.
+	Other         int64                  `protobuf:"varint,5,opt,name=other,proto3" json:"other,omitempty"`     // Values outside the returned terms.
+	Terms         []*SearchTermCount     `protobuf:"bytes,6,rep,name=terms,proto3" json:"terms,omitempty"`
+	Ranges        []*SearchRangeCount    `protobuf:"bytes,7,rep,name=ranges,proto3" json:"ranges,omitempty"` // Numeric or date ranges, in request order.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchFacetResult) Reset() {
+	*x = SearchFacetResult{}
+	mi := &file_search_proto_msgTypes[21]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchFacetResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchFacetResult) ProtoMessage() {}
+
+func (x *SearchFacetResult) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[21]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchFacetResult.ProtoReflect.Descriptor instead.
+func (*SearchFacetResult) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{21}
+}
+
+func (x *SearchFacetResult) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *SearchFacetResult) GetField() string {
+	if x != nil {
+		return x.Field
+	}
+	return ""
+}
+
+func (x *SearchFacetResult) GetTotal() int64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchFacetResult) GetMissing() int64 {
+	if x != nil {
+		return x.Missing
+	}
+	return 0
+}
+
+func (x *SearchFacetResult) GetOther() int64 {
+	if x != nil {
+		return x.Other
+	}
+	return 0
+}
+
+func (x *SearchFacetResult) GetTerms() []*SearchTermCount {
+	if x != nil {
+		return x.Terms
+	}
+	return nil
+}
+
+func (x *SearchFacetResult) GetRanges() []*SearchRangeCount {
+	if x != nil {
+		return x.Ranges
+	}
+	return nil
+}
+
+// Response for SearchDocumentsV2Request.
+type SearchDocumentsV2Response struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Hits          []*SearchHit           `protobuf:"bytes,1,rep,name=hits,proto3" json:"hits,omitempty"`
+	Total         uint64                 `protobuf:"varint,2,opt,name=total,proto3" json:"total,omitempty"` // Number of matching documents.
+	Facets        []*SearchFacetResult   `protobuf:"bytes,3,rep,name=facets,proto3" json:"facets,omitempty"`
+	NextPageToken string                 `protobuf:"bytes,4,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Empty on the last page.
+	Degraded      bool                   `protobuf:"varint,5,opt,name=degraded,proto3" json:"degraded,omitempty"`                                 // A vector search ran as a keyword search (no embedder).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SearchDocumentsV2Response) Reset() {
+	*x = SearchDocumentsV2Response{}
+	mi := &file_search_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SearchDocumentsV2Response) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SearchDocumentsV2Response) ProtoMessage() {}
+
+func (x *SearchDocumentsV2Response) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SearchDocumentsV2Response.ProtoReflect.Descriptor instead.
+func (*SearchDocumentsV2Response) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *SearchDocumentsV2Response) GetHits() []*SearchHit {
+	if x != nil {
+		return x.Hits
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Response) GetTotal() uint64 {
+	if x != nil {
+		return x.Total
+	}
+	return 0
+}
+
+func (x *SearchDocumentsV2Response) GetFacets() []*SearchFacetResult {
+	if x != nil {
+		return x.Facets
+	}
+	return nil
+}
+
+func (x *SearchDocumentsV2Response) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+func (x *SearchDocumentsV2Response) GetDegraded() bool {
+	if x != nil {
+		return x.Degraded
+	}
+	return false
+}
+
+// An alias names a set of index paths searched together.
+type IndexAlias struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Paths         []string               `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexAlias) Reset() {
+	*x = IndexAlias{}
+	mi := &file_search_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexAlias) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexAlias) ProtoMessage() {}
+
+func (x *IndexAlias) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexAlias.ProtoReflect.Descriptor instead.
+func (*IndexAlias) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *IndexAlias) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *IndexAlias) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type SetIndexAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	Paths         []string               `protobuf:"bytes,2,rep,name=paths,proto3" json:"paths,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIndexAliasRequest) Reset() {
+	*x = SetIndexAliasRequest{}
+	mi := &file_search_proto_msgTypes[24]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIndexAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIndexAliasRequest) ProtoMessage() {}
+
+func (x *SetIndexAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[24]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIndexAliasRequest.ProtoReflect.Descriptor instead.
+func (*SetIndexAliasRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{24}
+}
+
+func (x *SetIndexAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *SetIndexAliasRequest) GetPaths() []string {
+	if x != nil {
+		return x.Paths
+	}
+	return nil
+}
+
+type SetIndexAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIndexAliasResponse) Reset() {
+	*x = SetIndexAliasResponse{}
+	mi := &file_search_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIndexAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIndexAliasResponse) ProtoMessage() {}
+
+func (x *SetIndexAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIndexAliasResponse.ProtoReflect.Descriptor instead.
+func (*SetIndexAliasResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{25}
+}
+
+type DeleteIndexAliasRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Alias         string                 `protobuf:"bytes,1,opt,name=alias,proto3" json:"alias,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexAliasRequest) Reset() {
+	*x = DeleteIndexAliasRequest{}
+	mi := &file_search_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexAliasRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexAliasRequest) ProtoMessage() {}
+
+func (x *DeleteIndexAliasRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexAliasRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIndexAliasRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *DeleteIndexAliasRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+type DeleteIndexAliasResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexAliasResponse) Reset() {
+	*x = DeleteIndexAliasResponse{}
+	mi := &file_search_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexAliasResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexAliasResponse) ProtoMessage() {}
+
+func (x *DeleteIndexAliasResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexAliasResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIndexAliasResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{27}
+}
+
+type ListIndexAliasesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexAliasesRequest) Reset() {
+	*x = ListIndexAliasesRequest{}
+	mi := &file_search_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexAliasesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexAliasesRequest) ProtoMessage() {}
+
+func (x *ListIndexAliasesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexAliasesRequest.ProtoReflect.Descriptor instead.
+func (*ListIndexAliasesRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{28}
+}
+
+type ListIndexAliasesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Aliases       []*IndexAlias          `protobuf:"bytes,1,rep,name=aliases,proto3" json:"aliases,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexAliasesResponse) Reset() {
+	*x = ListIndexAliasesResponse{}
+	mi := &file_search_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexAliasesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexAliasesResponse) ProtoMessage() {}
+
+func (x *ListIndexAliasesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexAliasesResponse.ProtoReflect.Descriptor instead.
+func (*ListIndexAliasesResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *ListIndexAliasesResponse) GetAliases() []*IndexAlias {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+// Mapping of one field; "a.b" maps field b of the object in field a.
+type FieldMapping struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Name          string                 `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Type          FieldType              `protobuf:"varint,2,opt,name=type,proto3,enum=search.FieldType" json:"type,omitempty"`
+	Analyzer      string                 `protobuf:"bytes,3,opt,name=analyzer,proto3" json:"analyzer,omitempty"`                                 // Text fields: standard, simple, keyword or a language (en, fr, es, de, it, nl, pt, ru, cjk).
+	Store         bool                   `protobuf:"varint,4,opt,name=store,proto3" json:"store,omitempty"`                                      // Store the value in the index.
+	IndexDisabled bool                   `protobuf:"varint,5,opt,name=index_disabled,json=indexDisabled,proto3" json:"index_disabled,omitempty"` // Keep the field out of the index.
+	Dims          int32                  `protobuf:"varint,6,opt,name=dims,proto3" json:"dims,omitempty"`                                        // Vector fields: number of dimensions.
+	SourceFields  []string               `protobuf:"bytes,7,rep,name=source_fields,json=sourceFields,proto3" json:"source_fields,omitempty"`     // Vector fields: text fields to embed; every string field when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *FieldMapping) Reset() {
+	*x = FieldMapping{}
+	mi := &file_search_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *FieldMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*FieldMapping) ProtoMessage() {}
+
+func (x *FieldMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use FieldMapping.ProtoReflect.Descriptor instead.
+func (*FieldMapping) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *FieldMapping) GetName() string {
+	if x != nil {
+		return x.Name
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetType() FieldType {
+	if x != nil {
+		return x.Type
+	}
+	return FieldType_FIELD_TEXT
+}
+
+func (x *FieldMapping) GetAnalyzer() string {
+	if x != nil {
+		return x.Analyzer
+	}
+	return ""
+}
+
+func (x *FieldMapping) GetStore() bool {
+	if x != nil {
+		return x.Store
+	}
+	return false
+}
+
+func (x *FieldMapping) GetIndexDisabled() bool {
+	if x != nil {
+		return x.IndexDisabled
+	}
+	return false
+}
+
+func (x *FieldMapping) GetDims() int32 {
+	if x != nil {
+		return x.Dims
+	}
+	return 0
+}
+
+func (x *FieldMapping) GetSourceFields() []string {
+	if x != nil {
+		return x.SourceFields
+	}
+	return nil
+}
+
+// How the documents of an index are indexed.
+type IndexMapping struct {
+	state           protoimpl.MessageState `protogen:"open.v1"`
+	DefaultAnalyzer string                 `protobuf:"bytes,1,opt,name=default_analyzer,json=defaultAnalyzer,proto3" json:"default_analyzer,omitempty"` // Analyzer of the unmapped text fields (standard when empty).
+	Fields          []*FieldMapping        `protobuf:"bytes,2,rep,name=fields,proto3" json:"fields,omitempty"`
+	Strict          bool                   `protobuf:"varint,3,opt,name=strict,proto3" json:"strict,omitempty"` // Index the mapped fields only.
+	unknownFields   protoimpl.UnknownFields
+	sizeCache       protoimpl.SizeCache
+}
+
+func (x *IndexMapping) Reset() {
+	*x = IndexMapping{}
+	mi := &file_search_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexMapping) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexMapping) ProtoMessage() {}
+
+func (x *IndexMapping) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexMapping.ProtoReflect.Descriptor instead.
+func (*IndexMapping) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *IndexMapping) GetDefaultAnalyzer() string {
+	if x != nil {
+		return x.DefaultAnalyzer
+	}
+	return ""
+}
+
+func (x *IndexMapping) GetFields() []*FieldMapping {
+	if x != nil {
+		return x.Fields
+	}
+	return nil
+}
+
+func (x *IndexMapping) GetStrict() bool {
+	if x != nil {
+		return x.Strict
+	}
+	return false
+}
+
+type CreateIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	Mapping       *IndexMapping          `protobuf:"bytes,2,opt,name=mapping,proto3" json:"mapping,omitempty"` // Dynamic mapping when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIndexRequest) Reset() {
+	*x = CreateIndexRequest{}
+	mi := &file_search_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIndexRequest) ProtoMessage() {}
+
+func (x *CreateIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIndexRequest.ProtoReflect.Descriptor instead.
+func (*CreateIndexRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *CreateIndexRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *CreateIndexRequest) GetMapping() *IndexMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+type CreateIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateIndexResponse) Reset() {
+	*x = CreateIndexResponse{}
+	mi := &file_search_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateIndexResponse) ProtoMessage() {}
+
+func (x *CreateIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateIndexResponse.ProtoReflect.Descriptor instead.
+func (*CreateIndexResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{33}
+}
+
+type GetIndexMappingRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIndexMappingRequest) Reset() {
+	*x = GetIndexMappingRequest{}
+	mi := &file_search_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndexMappingRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndexMappingRequest) ProtoMessage() {}
+
+func (x *GetIndexMappingRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndexMappingRequest.ProtoReflect.Descriptor instead.
+func (*GetIndexMappingRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *GetIndexMappingRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type GetIndexMappingResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Mapping       *IndexMapping          `protobuf:"bytes,1,opt,name=mapping,proto3" json:"mapping,omitempty"`                            // Mapping given at creation; empty for implicit indexes.
+	MappingJson   string                 `protobuf:"bytes,2,opt,name=mapping_json,json=mappingJson,proto3" json:"mapping_json,omitempty"` // Effective Bleve mapping.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetIndexMappingResponse) Reset() {
+	*x = GetIndexMappingResponse{}
+	mi := &file_search_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetIndexMappingResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetIndexMappingResponse) ProtoMessage() {}
+
+func (x *GetIndexMappingResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetIndexMappingResponse.ProtoReflect.Descriptor instead.
+func (*GetIndexMappingResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *GetIndexMappingResponse) GetMapping() *IndexMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *GetIndexMappingResponse) GetMappingJson() string {
+	if x != nil {
+		return x.MappingJson
+	}
+	return ""
+}
+
+// An index known to the service.
+type IndexInfo struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	DocCount      int64                  `protobuf:"varint,2,opt,name=doc_count,json=docCount,proto3" json:"doc_count,omitempty"` // -1 when the index is not open.
+	SizeBytes     int64                  `protobuf:"varint,3,opt,name=size_bytes,json=sizeBytes,proto3" json:"size_bytes,omitempty"`
+	Aliases       []string               `protobuf:"bytes,4,rep,name=aliases,proto3" json:"aliases,omitempty"` // Aliases that point to the index.
+	Open          bool                   `protobuf:"varint,5,opt,name=open,proto3" json:"open,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IndexInfo) Reset() {
+	*x = IndexInfo{}
+	mi := &file_search_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IndexInfo) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IndexInfo) ProtoMessage() {}
+
+func (x *IndexInfo) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IndexInfo.ProtoReflect.Descriptor instead.
+func (*IndexInfo) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *IndexInfo) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *IndexInfo) GetDocCount() int64 {
+	if x != nil {
+		return x.DocCount
+	}
+	return 0
+}
+
+func (x *IndexInfo) GetSizeBytes() int64 {
+	if x != nil {
+		return x.SizeBytes
+	}
+	return 0
+}
+
+func (x *IndexInfo) GetAliases() []string {
+	if x != nil {
+		return x.Aliases
+	}
+	return nil
+}
+
+func (x *IndexInfo) GetOpen() bool {
+	if x != nil {
+		return x.Open
+	}
+	return false
+}
+
+type ListIndexesRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Prefix        string                 `protobuf:"bytes,1,opt,name=prefix,proto3" json:"prefix,omitempty"` // Only the paths starting with prefix.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexesRequest) Reset() {
+	*x = ListIndexesRequest{}
+	mi := &file_search_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexesRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexesRequest) ProtoMessage() {}
+
+func (x *ListIndexesRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexesRequest.ProtoReflect.Descriptor instead.
+func (*ListIndexesRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *ListIndexesRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+type ListIndexesResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Indexes       []*IndexInfo           `protobuf:"bytes,1,rep,name=indexes,proto3" json:"indexes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListIndexesResponse) Reset() {
+	*x = ListIndexesResponse{}
+	mi := &file_search_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListIndexesResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListIndexesResponse) ProtoMessage() {}
+
+func (x *ListIndexesResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListIndexesResponse.ProtoReflect.Descriptor instead.
+func (*ListIndexesResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ListIndexesResponse) GetIndexes() []*IndexInfo {
+	if x != nil {
+		return x.Indexes
+	}
+	return nil
+}
+
+type DeleteIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexRequest) Reset() {
+	*x = DeleteIndexRequest{}
+	mi := &file_search_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexRequest) ProtoMessage() {}
+
+func (x *DeleteIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexRequest.ProtoReflect.Descriptor instead.
+func (*DeleteIndexRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *DeleteIndexRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+type DeleteIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteIndexResponse) Reset() {
+	*x = DeleteIndexResponse{}
+	mi := &file_search_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteIndexResponse) ProtoMessage() {}
+
+func (x *DeleteIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteIndexResponse.ProtoReflect.Descriptor instead.
+func (*DeleteIndexResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{40}
+}
+
+// Rebuild an index into a new one, typically with a new mapping, then
+// point an alias at it.
+type ReindexToRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Source        string                 `protobuf:"bytes,1,opt,name=source,proto3" json:"source,omitempty"`
+	Target        string                 `protobuf:"bytes,2,opt,name=target,proto3" json:"target,omitempty"`                                  // Path of the new index; must not exist.
+	Mapping       *IndexMapping          `protobuf:"bytes,3,opt,name=mapping,proto3" json:"mapping,omitempty"`                                // Mapping of the new index; the source mapping when empty.
+	Alias         string                 `protobuf:"bytes,4,opt,name=alias,proto3" json:"alias,omitempty"`                                    // Alias to point at target when done.
+	DeleteSource  bool                   `protobuf:"varint,5,opt,name=delete_source,json=deleteSource,proto3" json:"delete_source,omitempty"` // Delete source when done.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexToRequest) Reset() {
+	*x = ReindexToRequest{}
+	mi := &file_search_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexToRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexToRequest) ProtoMessage() {}
+
+func (x *ReindexToRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexToRequest.ProtoReflect.Descriptor instead.
+func (*ReindexToRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{41}
+}
+
+func (x *ReindexToRequest) GetSource() string {
+	if x != nil {
+		return x.Source
+	}
+	return ""
+}
+
+func (x *ReindexToRequest) GetTarget() string {
+	if x != nil {
+		return x.Target
+	}
+	return ""
+}
+
+func (x *ReindexToRequest) GetMapping() *IndexMapping {
+	if x != nil {
+		return x.Mapping
+	}
+	return nil
+}
+
+func (x *ReindexToRequest) GetAlias() string {
+	if x != nil {
+		return x.Alias
+	}
+	return ""
+}
+
+func (x *ReindexToRequest) GetDeleteSource() bool {
+	if x != nil {
+		return x.DeleteSource
+	}
+	return false
+}
+
+type ReindexToResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Documents     int64                  `protobuf:"varint,1,opt,name=documents,proto3" json:"documents,omitempty"` // Documents copied.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReindexToResponse) Reset() {
+	*x = ReindexToResponse{}
+	mi := &file_search_proto_msgTypes[42]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReindexToResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReindexToResponse) ProtoMessage() {}
+
+func (x *ReindexToResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[42]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReindexToResponse.ProtoReflect.Descriptor instead.
+func (*ReindexToResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{42}
+}
+
+func (x *ReindexToResponse) GetDocuments() int64 {
+	if x != nil {
+		return x.Documents
+	}
+	return 0
+}
+
+// A document of a bulk request.
+type BulkDocument struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	JsonStr       string                 `protobuf:"bytes,1,opt,name=json_str,json=jsonStr,proto3" json:"json_str,omitempty"` // The JSON object to index.
+	Data          string                 `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`                      // Data returned by searches; the object when empty.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkDocument) Reset() {
+	*x = BulkDocument{}
+	mi := &file_search_proto_msgTypes[43]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkDocument) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkDocument) ProtoMessage() {}
+
+func (x *BulkDocument) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[43]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkDocument.ProtoReflect.Descriptor instead.
+func (*BulkDocument) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{43}
+}
+
+func (x *BulkDocument) GetJsonStr() string {
+	if x != nil {
+		return x.JsonStr
+	}
+	return ""
+}
+
+func (x *BulkDocument) GetData() string {
+	if x != nil {
+		return x.Data
+	}
+	return ""
+}
+
+// One message of a BulkIndex stream.
+type BulkIndexRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Path          string                 `protobuf:"bytes,1,opt,name=path,proto3" json:"path,omitempty"`
+	IdField       string                 `protobuf:"bytes,2,opt,name=id_field,json=idField,proto3" json:"id_field,omitempty"` // Field holding the document id.
+	Language      string                 `protobuf:"bytes,3,opt,name=language,proto3" json:"language,omitempty"`
+	Documents     []*BulkDocument        `protobuf:"bytes,4,rep,name=documents,proto3" json:"documents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIndexRequest) Reset() {
+	*x = BulkIndexRequest{}
+	mi := &file_search_proto_msgTypes[44]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIndexRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIndexRequest) ProtoMessage() {}
+
+func (x *BulkIndexRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[44]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIndexRequest.ProtoReflect.Descriptor instead.
+func (*BulkIndexRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{44}
+}
+
+func (x *BulkIndexRequest) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *BulkIndexRequest) GetIdField() string {
+	if x != nil {
+		return x.IdField
+	}
+	return ""
+}
+
+func (x *BulkIndexRequest) GetLanguage() string {
+	if x != nil {
+		return x.Language
+	}
+	return ""
+}
+
+func (x *BulkIndexRequest) GetDocuments() []*BulkDocument {
+	if x != nil {
+		return x.Documents
+	}
+	return nil
+}
+
+// Outcome of one document; error is empty on success.
+type BulkIndexResult struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Position      int32                  `protobuf:"varint,1,opt,name=position,proto3" json:"position,omitempty"` // Position of the document in the whole stream.
+	Id            string                 `protobuf:"bytes,2,opt,name=id,proto3" json:"id,omitempty"`
+	Error         string                 `protobuf:"bytes,3,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIndexResult) Reset() {
+	*x = BulkIndexResult{}
+	mi := &file_search_proto_msgTypes[45]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIndexResult) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIndexResult) ProtoMessage() {}
+
+func (x *BulkIndexResult) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[45]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIndexResult.ProtoReflect.Descriptor instead.
+func (*BulkIndexResult) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{45}
+}
+
+func (x *BulkIndexResult) GetPosition() int32 {
+	if x != nil {
+		return x.Position
+	}
+	return 0
+}
+
+func (x *BulkIndexResult) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *BulkIndexResult) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+type BulkIndexResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Results       []*BulkIndexResult     `protobuf:"bytes,1,rep,name=results,proto3" json:"results,omitempty"`
+	Indexed       int32                  `protobuf:"varint,2,opt,name=indexed,proto3" json:"indexed,omitempty"`
+	Failed        int32                  `protobuf:"varint,3,opt,name=failed,proto3" json:"failed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *BulkIndexResponse) Reset() {
+	*x = BulkIndexResponse{}
+	mi := &file_search_proto_msgTypes[46]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BulkIndexResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BulkIndexResponse) ProtoMessage() {}
+
+func (x *BulkIndexResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[46]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BulkIndexResponse.ProtoReflect.Descriptor instead.
+func (*BulkIndexResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{46}
+}
+
+func (x *BulkIndexResponse) GetResults() []*BulkIndexResult {
+	if x != nil {
+		return x.Results
+	}
+	return nil
+}
+
+func (x *BulkIndexResponse) GetIndexed() int32 {
+	if x != nil {
+		return x.Indexed
+	}
+	return 0
+}
+
+func (x *BulkIndexResponse) GetFailed() int32 {
+	if x != nil {
+		return x.Failed
+	}
+	return 0
+}
+
+// Request to stop the server.
+type StopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_search_proto_msgTypes[47]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[47]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{47}
+}
+
+// Response for StopRequest.
+type StopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
+	mi := &file_search_proto_msgTypes[48]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopResponse) ProtoMessage() {}
+
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_search_proto_msgTypes[48]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return file_search_proto_rawDescGZIP(), []int{48}
+}
+
+var File_search_proto protoreflect.FileDescriptor
+
+const file_search_proto_rawDesc = "" +
+	"\n" +
+	"\fsearch.proto\x12\x06search\x1a\x13globular_auth.proto\"\x19\n" +
+	"\x17GetEngineVersionRequest\"4\n" +
+	"\x18GetEngineVersionResponse\x12\x18\n" +
+	"\amessage\x18\x01 \x01(\tR\amessage\"\xad\x01\n" +
+	"\x16IndexJsonObjectRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\x12\x18\n" +
+	"\ajsonStr\x18\x02 \x01(\tR\ajsonStr\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x0e\n" +
+	"\x02id\x18\x04 \x01(\tR\x02id\x12\x16\n" +
+	"\x06indexs\x18\x05 \x03(\tR\x06indexs\x12\x12\n" +
+	"\x04data\x18\x06 \x01(\tR\x04data\"\x19\n" +
+	"\x17IndexJsonObjectResponse\"Z\n" +
+	"\x15DeleteDocumentRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\x12\x1e\n" +
+	"\x02id\x18\x02 \x01(\tB\x0e\x8a\xb5\x18\n" +
+	"\n" +
+	"\bdocumentR\x02id\"\x18\n" +
+	"\x16DeleteDocumentResponse\"1\n" +
+	"\fCountRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\"'\n" +
+	"\rCountResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\x05R\x06result\"f\n" +
+	"\fSearchResult\x12\x12\n" +
+	"\x04rank\x18\x01 \x01(\x05R\x04rank\x12\x14\n" +
+	"\x05docId\x18\x02 \x01(\tR\x05docId\x12\x12\n" +
+	"\x04data\x18\x03 \x01(\tR\x04data\x12\x18\n" +
+	"\asnippet\x18\x04 \x01(\tR\asnippet\"?\n" +
+	"\rSearchResults\x12.\n" +
+	"\aresults\x18\x01 \x03(\v2\x14.search.SearchResultR\aresults\"\xd2\x01\n" +
+	"\x16SearchDocumentsRequest\x12\x14\n" +
+	"\x05paths\x18\x01 \x03(\tR\x05paths\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12\x16\n" +
+	"\x06offset\x18\x05 \x01(\x05R\x06offset\x12\x1a\n" +
+	"\bpageSize\x18\x06 \x01(\x05R\bpageSize\x12$\n" +
+	"\rsnippetLength\x18\a \x01(\x05R\rsnippetLength\"J\n" +
+	"\x17SearchDocumentsResponse\x12/\n" +
+	"\aresults\x18\x01 \x01(\v2\x15.search.SearchResultsR\aresults\"\xba\x01\n" +
+	"\fSearchFilter\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x14\n" +
+	"\x05terms\x18\x02 \x03(\tR\x05terms\x12\x15\n" +
+	"\x03min\x18\x03 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x04 \x01(\x01H\x01R\x03max\x88\x01\x01\x12\x14\n" +
+	"\x05start\x18\x05 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x06 \x01(\tR\x03end\x12\x18\n" +
+	"\aexclude\x18\a \x01(\bR\aexcludeB\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"B\n" +
+	"\n" +
+	"SearchSort\x12\x14\n" +
+	"\x05field\x18\x01 \x01(\tR\x05field\x12\x1e\n" +
+	"\n" +
+	"descending\x18\x02 \x01(\bR\n" +
+	"descending\"f\n" +
+	"\x12SearchNumericRange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x15\n" +
+	"\x03min\x18\x02 \x01(\x01H\x00R\x03min\x88\x01\x01\x12\x15\n" +
+	"\x03max\x18\x03 \x01(\x01H\x01R\x03max\x88\x01\x01B\x06\n" +
+	"\x04_minB\x06\n" +
+	"\x04_max\"M\n" +
+	"\x0fSearchDateRange\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05start\x18\x02 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x03 \x01(\tR\x03end\"\xcf\x01\n" +
+	"\x12SearchFacetRequest\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x12\n" +
+	"\x04size\x18\x03 \x01(\x05R\x04size\x12A\n" +
+	"\x0enumeric_ranges\x18\x04 \x03(\v2\x1a.search.SearchNumericRangeR\rnumericRanges\x128\n" +
+	"\vdate_ranges\x18\x05 \x03(\v2\x17.search.SearchDateRangeR\n" +
+	"dateRanges\"\xec\x03\n" +
+	"\x18SearchDocumentsV2Request\x12\x14\n" +
+	"\x05paths\x18\x01 \x03(\tR\x05paths\x12\x14\n" +
+	"\x05query\x18\x02 \x01(\tR\x05query\x12&\n" +
+	"\x04mode\x18\x03 \x01(\x0e2\x12.search.SearchModeR\x04mode\x12\x16\n" +
+	"\x06fields\x18\x04 \x03(\tR\x06fields\x12\x1c\n" +
+	"\tfuzziness\x18\x05 \x01(\x05R\tfuzziness\x12.\n" +
+	"\afilters\x18\x06 \x03(\v2\x14.search.SearchFilterR\afilters\x12&\n" +
+	"\x04sort\x18\a \x03(\v2\x12.search.SearchSortR\x04sort\x122\n" +
+	"\x06facets\x18\b \x03(\v2\x1a.search.SearchFacetRequestR\x06facets\x12\x1b\n" +
+	"\tpage_size\x18\t \x01(\x05R\bpageSize\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\n" +
+	" \x01(\tR\tpageToken\x12\x1c\n" +
+	"\thighlight\x18\v \x01(\bR\thighlight\x12!\n" +
+	"\fvector_field\x18\f \x01(\tR\vvectorField\x12\x16\n" +
+	"\x06vector\x18\r \x03(\x02R\x06vector\x12\x17\n" +
+	"\alike_id\x18\x0e \x01(\tR\x06likeId\x12\f\n" +
+	"\x01k\x18\x0f \x01(\x05R\x01k\"{\n" +
+	"\tSearchHit\x12\x14\n" +
+	"\x05index\x18\x01 \x01(\tR\x05index\x12\x14\n" +
+	"\x05docId\x18\x02 \x01(\tR\x05docId\x12\x14\n" +
+	"\x05score\x18\x03 \x01(\x01R\x05score\x12\x12\n" +
+	"\x04data\x18\x04 \x01(\tR\x04data\x12\x18\n" +
+	"\asnippet\x18\x05 \x01(\tR\asnippet\";\n" +
+	"\x0fSearchTermCount\x12\x12\n" +
+	"\x04term\x18\x01 \x01(\tR\x04term\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"<\n" +
+	"\x10SearchRangeCount\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05count\x18\x02 \x01(\x03R\x05count\"\xe4\x01\n" +
+	"\x11SearchFacetResult\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05field\x18\x02 \x01(\tR\x05field\x12\x14\n" +
+	"\x05total\x18\x03 \x01(\x03R\x05total\x12\x18\n" +
+	"\amissing\x18\x04 \x01(\x03R\amissing\x12\x14\n" +
+	"\x05other\x18\x05 \x01(\x03R\x05other\x12-\n" +
+	"\x05terms\x18\x06 \x03(\v2\x17.search.SearchTermCountR\x05terms\x120\n" +
+	"\x06ranges\x18\a \x03(\v2\x18.search.SearchRangeCountR\x06ranges\"\xcf\x01\n" +
+	"\x19SearchDocumentsV2Response\x12%\n" +
+	"\x04hits\x18\x01 \x03(\v2\x11.search.SearchHitR\x04hits\x12\x14\n" +
+	"\x05total\x18\x02 \x01(\x04R\x05total\x121\n" +
+	"\x06facets\x18\x03 \x03(\v2\x19.search.SearchFacetResultR\x06facets\x12&\n" +
+	"\x0fnext_page_token\x18\x04 \x01(\tR\rnextPageToken\x12\x1a\n" +
+	"\bdegraded\x18\x05 \x01(\bR\bdegraded\"8\n" +
+	"\n" +
+	"IndexAlias\x12\x14\n" +
+	"\x05alias\x18\x01 \x01(\tR\x05alias\x12\x14\n" +
+	"\x05paths\x18\x02 \x03(\tR\x05paths\"Q\n" +
+	"\x14SetIndexAliasRequest\x12#\n" +
+	"\x05alias\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05alias\x10\x01R\x05alias\x12\x14\n" +
+	"\x05paths\x18\x02 \x03(\tR\x05paths\"\x17\n" +
+	"\x15SetIndexAliasResponse\">\n" +
+	"\x17DeleteIndexAliasRequest\x12#\n" +
+	"\x05alias\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05alias\x10\x01R\x05alias\"\x1a\n" +
+	"\x18DeleteIndexAliasResponse\"\x19\n" +
+	"\x17ListIndexAliasesRequest\"H\n" +
+	"\x18ListIndexAliasesResponse\x12,\n" +
+	"\aaliases\x18\x01 \x03(\v2\x12.search.IndexAliasR\aaliases\"\xdb\x01\n" +
+	"\fFieldMapping\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12%\n" +
+	"\x04type\x18\x02 \x01(\x0e2\x11.search.FieldTypeR\x04type\x12\x1a\n" +
+	"\banalyzer\x18\x03 \x01(\tR\banalyzer\x12\x14\n" +
+	"\x05store\x18\x04 \x01(\bR\x05store\x12%\n" +
+	"\x0eindex_disabled\x18\x05 \x01(\bR\rindexDisabled\x12\x12\n" +
+	"\x04dims\x18\x06 \x01(\x05R\x04dims\x12#\n" +
+	"\rsource_fields\x18\a \x03(\tR\fsourceFields\"\x7f\n" +
+	"\fIndexMapping\x12)\n" +
+	"\x10default_analyzer\x18\x01 \x01(\tR\x0fdefaultAnalyzer\x12,\n" +
+	"\x06fields\x18\x02 \x03(\v2\x14.search.FieldMappingR\x06fields\x12\x16\n" +
+	"\x06strict\x18\x03 \x01(\bR\x06strict\"g\n" +
+	"\x12CreateIndexRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\x12.\n" +
+	"\amapping\x18\x02 \x01(\v2\x14.search.IndexMappingR\amapping\"\x15\n" +
+	"\x13CreateIndexResponse\";\n" +
+	"\x16GetIndexMappingRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\"l\n" +
+	"\x17GetIndexMappingResponse\x12.\n" +
+	"\amapping\x18\x01 \x01(\v2\x14.search.IndexMappingR\amapping\x12!\n" +
+	"\fmapping_json\x18\x02 \x01(\tR\vmappingJson\"\x89\x01\n" +
+	"\tIndexInfo\x12\x12\n" +
+	"\x04path\x18\x01 \x01(\tR\x04path\x12\x1b\n" +
+	"\tdoc_count\x18\x02 \x01(\x03R\bdocCount\x12\x1d\n" +
+	"\n" +
+	"size_bytes\x18\x03 \x01(\x03R\tsizeBytes\x12\x18\n" +
+	"\aaliases\x18\x04 \x03(\tR\aaliases\x12\x12\n" +
+	"\x04open\x18\x05 \x01(\bR\x04open\",\n" +
+	"\x12ListIndexesRequest\x12\x16\n" +
+	"\x06prefix\x18\x01 \x01(\tR\x06prefix\"B\n" +
+	"\x13ListIndexesResponse\x12+\n" +
+	"\aindexes\x18\x01 \x03(\v2\x11.search.IndexInfoR\aindexes\"7\n" +
+	"\x12DeleteIndexRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\"\x15\n" +
+	"\x13DeleteIndexResponse\"\xbc\x01\n" +
+	"\x10ReindexToRequest\x12%\n" +
+	"\x06source\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x06source\x12\x16\n" +
+	"\x06target\x18\x02 \x01(\tR\x06target\x12.\n" +
+	"\amapping\x18\x03 \x01(\v2\x14.search.IndexMappingR\amapping\x12\x14\n" +
+	"\x05alias\x18\x04 \x01(\tR\x05alias\x12#\n" +
+	"\rdelete_source\x18\x05 \x01(\bR\fdeleteSource\"1\n" +
+	"\x11ReindexToResponse\x12\x1c\n" +
+	"\tdocuments\x18\x01 \x01(\x03R\tdocuments\"=\n" +
+	"\fBulkDocument\x12\x19\n" +
+	"\bjson_str\x18\x01 \x01(\tR\ajsonStr\x12\x12\n" +
+	"\x04data\x18\x02 \x01(\tR\x04data\"\xa0\x01\n" +
+	"\x10BulkIndexRequest\x12!\n" +
+	"\x04path\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05index\x10\x01R\x04path\x12\x19\n" +
+	"\bid_field\x18\x02 \x01(\tR\aidField\x12\x1a\n" +
+	"\blanguage\x18\x03 \x01(\tR\blanguage\x122\n" +
+	"\tdocuments\x18\x04 \x03(\v2\x14.search.BulkDocumentR\tdocuments\"S\n" +
+	"\x0fBulkIndexResult\x12\x1a\n" +
+	"\bposition\x18\x01 \x01(\x05R\bposition\x12\x0e\n" +
+	"\x02id\x18\x02 \x01(\tR\x02id\x12\x14\n" +
+	"\x05error\x18\x03 \x01(\tR\x05error\"x\n" +
+	"\x11BulkIndexResponse\x121\n" +
+	"\aresults\x18\x01 \x03(\v2\x17.search.BulkIndexResultR\aresults\x12\x18\n" +
+	"\aindexed\x18\x02 \x01(\x05R\aindexed\x12\x16\n" +
+	"\x06failed\x18\x03 \x01(\x05R\x06failed\"\r\n" +
+	"\vStopRequest\"\x0e\n" +
+	"\fStopResponse*p\n" +
+	"\n" +
+	"SearchMode\x12\x10\n" +
+	"\fQUERY_STRING\x10\x00\x12\t\n" +
+	"\x05MATCH\x10\x01\x12\n" +
+	"\n" +
+	"\x06PHRASE\x10\x02\x12\t\n" +
+	"\x05FUZZY\x10\x03\x12\n" +
+	"\n" +
+	"\x06PREFIX\x10\x04\x12\r\n" +
+	"\tMATCH_ALL\x10\x05\x12\a\n" +
+	"\x03KNN\x10\x06\x12\n" +
+	"\n" +
+	"\x06HYBRID\x10\a*z\n" +
+	"\tFieldType\x12\x0e\n" +
+	"\n" +
+	"FIELD_TEXT\x10\x00\x12\x11\n" +
+	"\rFIELD_KEYWORD\x10\x01\x12\x11\n" +
+	"\rFIELD_NUMERIC\x10\x02\x12\x12\n" +
+	"\x0eFIELD_DATETIME\x10\x03\x12\x11\n" +
+	"\rFIELD_BOOLEAN\x10\x04\x12\x10\n" +
+	"\fFIELD_VECTOR\x10\x052\xab\x11\n" +
 	"\rSearchService\x12[\n" +
 	"\x04Stop\x12\x13.search.StopRequest\x1a\x14.search.StopResponse\"(\x82\xb5\x18$\n" +
 	"\vsearch.stop\x12\x05admin\x1a\a/search*\x05admin\x12\x82\x01\n" +
@@ -796,7 +3205,27 @@ const file_search_proto_rawDesc = "" +
 	"\x0eDeleteDocument\x12\x1d.search.DeleteDocumentRequest\x1a\x1e.search.DeleteDocumentResponse\"R\x82\xb5\x18N\n" +
 	"\x16search.document.delete\x12\x06delete\x1a%/search/indexes/{path}/documents/{id}*\x05admin\x12\x87\x01\n" +
 	"\x0fSearchDocuments\x12\x1e.search.SearchDocumentsRequest\x1a\x1f.search.SearchDocumentsResponse\"1\x82\xb5\x18-\n" +
-	"\fsearch.query\x12\x04read\"\x0f/search/indexes*\x06viewer0\x01B7Z5github.com/globulario/services/golang/search/searchpbb\x06proto3"
+	"\fsearch.query\x12\x04read\"\x0f/search/indexes*\x06viewer0\x01\x12\x8b\x01\n" +
+	"\x11SearchDocumentsV2\x12 .search.SearchDocumentsV2Request\x1a!.search.SearchDocumentsV2Response\"1\x82\xb5\x18-\n" +
+	"\fsearch.query\x12\x04read\"\x0f/search/indexes*\x06viewer\x12\x8d\x01\n" +
+	"\rSetIndexAlias\x12\x1c.search.SetIndexAliasRequest\x1a\x1d.search.SetIndexAliasResponse\"?\x82\xb5\x18;\n" +
+	"\x12search.alias.write\x12\x05write\x1a\x17/search/aliases/{alias}*\x05admin\x12\x98\x01\n" +
+	"\x10DeleteIndexAlias\x12\x1f.search.DeleteIndexAliasRequest\x1a .search.DeleteIndexAliasResponse\"A\x82\xb5\x18=\n" +
+	"\x13search.alias.delete\x12\x06delete\x1a\x17/search/aliases/{alias}*\x05admin\x12\x83\x01\n" +
+	"\tBulkIndex\x12\x18.search.BulkIndexRequest\x1a\x19.search.BulkIndexResponse\"?\x82\xb5\x18;\n" +
+	"\x12search.index.write\x12\x05write\x1a\x16/search/indexes/{path}*\x06editor(\x01\x12\x87\x01\n" +
+	"\vCreateIndex\x12\x1a.search.CreateIndexRequest\x1a\x1b.search.CreateIndexResponse\"?\x82\xb5\x18;\n" +
+	"\x13search.index.create\x12\x05write\x1a\x16/search/indexes/{path}*\x05admin\x12\x91\x01\n" +
+	"\x0fGetIndexMapping\x12\x1e.search.GetIndexMappingRequest\x1a\x1f.search.GetIndexMappingResponse\"=\x82\xb5\x189\n" +
+	"\x11search.index.read\x12\x04read\x1a\x16/search/indexes/{path}*\x06viewer\x12~\n" +
+	"\vListIndexes\x12\x1a.search.ListIndexesRequest\x1a\x1b.search.ListIndexesResponse\"6\x82\xb5\x182\n" +
+	"\x11search.index.list\x12\x04read\"\x0f/search/indexes*\x06viewer\x12\x84\x01\n" +
+	"\tReindexTo\x12\x18.search.ReindexToRequest\x1a\x19.search.ReindexToResponse\"B\x82\xb5\x18>\n" +
+	"\x14search.index.reindex\x12\x05admin\x1a\x18/search/indexes/{source}*\x05admin\x12\x88\x01\n" +
+	"\vDeleteIndex\x12\x1a.search.DeleteIndexRequest\x1a\x1b.search.DeleteIndexResponse\"@\x82\xb5\x18<\n" +
+	"\x13search.index.delete\x12\x06delete\x1a\x16/search/indexes/{path}*\x05admin\x12\x8d\x01\n" +
+	"\x10ListIndexAliases\x12\x1f.search.ListIndexAliasesRequest\x1a .search.ListIndexAliasesResponse\"6\x82\xb5\x182\n" +
+	"\x11search.alias.read\x12\x04read\"\x0f/search/aliases*\x06viewerB7Z5github.com/globulario/services/golang/search/searchpbb\x06proto3"
 
 var (
 	file_search_proto_rawDescOnce sync.Once
@@ -810,43 +3239,120 @@ func file_search_proto_rawDescGZIP() []byte {
 	return file_search_proto_rawDescData
 }
 
-var file_search_proto_msgTypes = make([]protoimpl.MessageInfo, 14)
+var file_search_proto_enumTypes = make([]protoimpl.EnumInfo, 2)
+var file_search_proto_msgTypes = make([]protoimpl.MessageInfo, 49)
 var file_search_proto_goTypes = []any{
-	(*GetEngineVersionRequest)(nil),  // 0: search.GetEngineVersionRequest
-	(*GetEngineVersionResponse)(nil), // 1: search.GetEngineVersionResponse
-	(*IndexJsonObjectRequest)(nil),   // 2: search.IndexJsonObjectRequest
-	(*IndexJsonObjectResponse)(nil),  // 3: search.IndexJsonObjectResponse
-	(*DeleteDocumentRequest)(nil),    // 4: search.DeleteDocumentRequest
-	(*DeleteDocumentResponse)(nil),   // 5: search.DeleteDocumentResponse
-	(*CountRequest)(nil),             // 6: search.CountRequest
-	(*CountResponse)(nil),            // 7: search.CountResponse
-	(*SearchResult)(nil),             // 8: search.SearchResult
-	(*SearchResults)(nil),            // 9: search.SearchResults
-	(*SearchDocumentsRequest)(nil),   // 10: search.SearchDocumentsRequest
-	(*SearchDocumentsResponse)(nil),  // 11: search.SearchDocumentsResponse
-	(*StopRequest)(nil),              // 12: search.StopRequest
-	(*StopResponse)(nil),             // 13: search.StopResponse
+	(SearchMode)(0),                   // 0: search.SearchMode
+	(FieldType)(0),                    // 1: search.FieldType
+	(*GetEngineVersionRequest)(nil),   // 2: search.GetEngineVersionRequest
+	(*GetEngineVersionResponse)(nil),  // 3: search.GetEngineVersionResponse
+	(*IndexJsonObjectRequest)(nil),    // 4: search.IndexJsonObjectRequest
+	(*IndexJsonObjectResponse)(nil),   // 5: search.IndexJsonObjectResponse
+	(*DeleteDocumentRequest)(nil),     // 6: search.DeleteDocumentRequest
+	(*DeleteDocumentResponse)(nil),    // 7: search.DeleteDocumentResponse
+	(*CountRequest)(nil),              // 8: search.CountRequest
+	(*CountResponse)(nil),             // 9: search.CountResponse
+	(*SearchResult)(nil),              // 10: search.SearchResult
+	(*SearchResults)(nil),             // 11: search.SearchResults
+	(*SearchDocumentsRequest)(nil),    // 12: search.SearchDocumentsRequest
+	(*SearchDocumentsResponse)(nil),   // 13: search.SearchDocumentsResponse
+	(*SearchFilter)(nil),              // 14: search.SearchFilter
+	(*SearchSort)(nil),                // 15: search.SearchSort
+	(*SearchNumericRange)(nil),        // 16: search.SearchNumericRange
+	(*SearchDateRange)(nil),           // 17: search.SearchDateRange
+	(*SearchFacetRequest)(nil),        // 18: search.SearchFacetRequest
+	(*SearchDocumentsV2Request)(nil),  // 19: search.SearchDocumentsV2Request
+	(*SearchHit)(nil),                 // 20: search.SearchHit
+	(*SearchTermCount)(nil),           // 21: search.SearchTermCount
+	(*SearchRangeCount)(nil),          // 22: search.SearchRangeCount
+	(*SearchFacetResult)(nil),         // 23: search.SearchFacetResult
+	(*SearchDocumentsV2Response)(nil), // 24: search.SearchDocumentsV2Response
+	(*IndexAlias)(nil),                // 25: search.IndexAlias
+	(*SetIndexAliasRequest)(nil),      // 26: search.SetIndexAliasRequest
+	(*SetIndexAliasResponse)(nil),     // 27: search.SetIndexAliasResponse
+	(*DeleteIndexAliasRequest)(nil),   // 28: search.DeleteIndexAliasRequest
+	(*DeleteIndexAliasResponse)(nil),  // 29: search.DeleteIndexAliasResponse
+	(*ListIndexAliasesRequest)(nil),   // 30: search.ListIndexAliasesRequest
+	(*ListIndexAliasesResponse)(nil),  // 31: search.ListIndexAliasesResponse
+	(*FieldMapping)(nil),              // 32: search.FieldMapping
+	(*IndexMapping)(nil),              // 33: search.IndexMapping
+	(*CreateIndexRequest)(nil),        // 34: search.CreateIndexRequest
+	(*CreateIndexResponse)(nil),       // 35: search.CreateIndexResponse
+	(*GetIndexMappingRequest)(nil),    // 36: search.GetIndexMappingRequest
+	(*GetIndexMappingResponse)(nil),   // 37: search.GetIndexMappingResponse
+	(*IndexInfo)(nil),                 // 38: search.IndexInfo
+	(*ListIndexesRequest)(nil),        // 39: search.ListIndexesRequest
+	(*ListIndexesResponse)(nil),       // 40: search.ListIndexesResponse
+	(*DeleteIndexRequest)(nil),        // 41: search.DeleteIndexRequest
+	(*DeleteIndexResponse)(nil),       // 42: search.DeleteIndexResponse
+	(*ReindexToRequest)(nil),          // 43: search.ReindexToRequest
+	(*ReindexToResponse)(nil),         // 44: search.ReindexToResponse
+	(*BulkDocument)(nil),              // 45: search.BulkDocument
+	(*BulkIndexRequest)(nil),          // 46: search.BulkIndexRequest
+	(*BulkIndexResult)(nil),           // 47: search.BulkIndexResult
+	(*BulkIndexResponse)(nil),         // 48: search.BulkIndexResponse
+	(*StopRequest)(nil),               // 49: search.StopRequest
+	(*StopResponse)(nil),              // 50: search.StopResponse
 }
 var file_search_proto_depIdxs = []int32{
-	8,  // 0: search.SearchResults.results:type_name -> search.SearchResult
-	9,  // 1: search.SearchDocumentsResponse.results:type_name -> search.SearchResults
-	12, // 2: search.SearchService.Stop:input_type -> search.StopRequest
-	0,  // 3: search.SearchService.GetEngineVersion:input_type -> search.GetEngineVersionRequest
-	2,  // 4: search.SearchService.IndexJsonObject:input_type -> search.IndexJsonObjectRequest
-	6,  // 5: search.SearchService.Count:input_type -> search.CountRequest
-	4,  // 6: search.SearchService.DeleteDocument:input_type -> search.DeleteDocumentRequest
-	10, // 7: search.SearchService.SearchDocuments:input_type -> search.SearchDocumentsRequest
-	13, // 8: search.SearchService.Stop:output_type -> search.StopResponse
-	1,  // 9: search.SearchService.GetEngineVersion:output_type -> search.GetEngineVersionResponse
-	3,  // 10: search.SearchService.IndexJsonObject:output_type -> search.IndexJsonObjectResponse
-	7,  // 11: search.SearchService.Count:output_type -> search.CountResponse
-	5,  // 12: search.SearchService.DeleteDocument:output_type -> search.DeleteDocumentResponse
-	11, // 13: search.SearchService.SearchDocuments:output_type -> search.SearchDocumentsResponse
-	8,  // [8:14] is the sub-list for method output_type
-	2,  // [2:8] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	10, // 0: search.SearchResults.results:type_name -> search.SearchResult
+	11, // 1: search.SearchDocumentsResponse.results:type_name -> search.SearchResults
+	16, // 2: search.SearchFacetRequest.numeric_ranges:type_name -> search.SearchNumericRange
+	17, // 3: search.SearchFacetRequest.date_ranges:type_name -> search.SearchDateRange
+	0,  // 4: search.SearchDocumentsV2Request.mode:type_name -> search.SearchMode
+	14, // 5: search.SearchDocumentsV2Request.filters:type_name -> search.SearchFilter
+	15, // 6: search.SearchDocumentsV2Request.sort:type_name -> search.SearchSort
+	18, // 7: search.SearchDocumentsV2Request.facets:type_name -> search.SearchFacetRequest
+	21, // 8: search.SearchFacetResult.terms:type_name -> search.SearchTermCount
+	22, // 9: search.SearchFacetResult.ranges:type_name -> search.SearchRangeCount
+	20, // 10: search.SearchDocumentsV2Response.hits:type_name -> search.SearchHit
+	23, // 11: search.SearchDocumentsV2Response.facets:type_name -> search.SearchFacetResult
+	25, // 12: search.ListIndexAliasesResponse.aliases:type_name -> search.IndexAlias
+	1,  // 13: search.FieldMapping.type:type_name -> search.FieldType
+	32, // 14: search.IndexMapping.fields:type_name -> search.FieldMapping
+	33, // 15: search.CreateIndexRequest.mapping:type_name -> search.IndexMapping
+	33, // 16: search.GetIndexMappingResponse.mapping:type_name -> search.IndexMapping
+	38, // 17: search.ListIndexesResponse.indexes:type_name -> search.IndexInfo
+	33, // 18: search.ReindexToRequest.mapping:type_name -> search.IndexMapping
+	45, // 19: search.BulkIndexRequest.documents:type_name -> search.BulkDocument
+	47, // 20: search.BulkIndexResponse.results:type_name -> search.BulkIndexResult
+	49, // 21: search.SearchService.Stop:input_type -> search.StopRequest
+	2,  // 22: search.SearchService.GetEngineVersion:input_type -> search.GetEngineVersionRequest
+	4,  // 23: search.SearchService.IndexJsonObject:input_type -> search.IndexJsonObjectRequest
+	8,  // 24: search.SearchService.Count:input_type -> search.CountRequest
+	6,  // 25: search.SearchService.DeleteDocument:input_type -> search.DeleteDocumentRequest
+	12, // 26: search.SearchService.SearchDocuments:input_type -> search.SearchDocumentsRequest
+	19, // 27: search.SearchService.SearchDocumentsV2:input_type -> search.SearchDocumentsV2Request
+	26, // 28: search.SearchService.SetIndexAlias:input_type -> search.SetIndexAliasRequest
+	28, // 29: search.SearchService.DeleteIndexAlias:input_type -> search.DeleteIndexAliasRequest
+	46, // 30: search.SearchService.BulkIndex:input_type -> search.BulkIndexRequest
+	34, // 31: search.SearchService.CreateIndex:input_type -> search.CreateIndexRequest
+	36, // 32: search.SearchService.GetIndexMapping:input_type -> search.GetIndexMappingRequest
+	39, // 33: search.SearchService.ListIndexes:input_type -> search.ListIndexesRequest
+	43, // 34: search.SearchService.ReindexTo:input_type -> search.ReindexToRequest
+	41, // 35: search.SearchService.DeleteIndex:input_type -> search.DeleteIndexRequest
+	30, // 36: search.SearchService.ListIndexAliases:input_type -> search.ListIndexAliasesRequest
+	50, // 37: search.SearchService.Stop:output_type -> search.StopResponse
+	3,  // 38: search.SearchService.GetEngineVersion:output_type -> search.GetEngineVersionResponse
+	5,  // 39: search.SearchService.IndexJsonObject:output_type -> search.IndexJsonObjectResponse
+	9,  // 40: search.SearchService.Count:output_type -> search.CountResponse
+	7,  // 41: search.SearchService.DeleteDocument:output_type -> search.DeleteDocumentResponse
+	13, // 42: search.SearchService.SearchDocuments:output_type -> search.SearchDocumentsResponse
+	24, // 43: search.SearchService.SearchDocumentsV2:output_type -> search.SearchDocumentsV2Response
+	27, // 44: search.SearchService.SetIndexAlias:output_type -> search.SetIndexAliasResponse
+	29, // 45: search.SearchService.DeleteIndexAlias:output_type -> search.DeleteIndexAliasResponse
+	48, // 46: search.SearchService.BulkIndex:output_type -> search.BulkIndexResponse
+	35, // 47: search.SearchService.CreateIndex:output_type -> search.CreateIndexResponse
+	37, // 48: search.SearchService.GetIndexMapping:output_type -> search.GetIndexMappingResponse
+	40, // 49: search.SearchService.ListIndexes:output_type -> search.ListIndexesResponse
+	44, // 50: search.SearchService.ReindexTo:output_type -> search.ReindexToResponse
+	42, // 51: search.SearchService.DeleteIndex:output_type -> search.DeleteIndexResponse
+	31, // 52: search.SearchService.ListIndexAliases:output_type -> search.ListIndexAliasesResponse
+	37, // [37:53] is the sub-list for method output_type
+	21, // [21:37] is the sub-list for method input_type
+	21, // [21:21] is the sub-list for extension type_name
+	21, // [21:21] is the sub-list for extension extendee
+	0,  // [0:21] is the sub-list for field type_name
 }
 
 func init() { file_search_proto_init() }
@@ -854,18 +3360,21 @@ func file_search_proto_init() {
 	if File_search_proto != nil {
 		return
 	}
+	file_search_proto_msgTypes[12].OneofWrappers = []any{}
+	file_search_proto_msgTypes[14].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_search_proto_rawDesc), len(file_search_proto_rawDesc)),
-			NumEnums:      0,
-			NumMessages:   14,
+			NumEnums:      2,
+			NumMessages:   49,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
 		GoTypes:           file_search_proto_goTypes,
 		DependencyIndexes: file_search_proto_depIdxs,
+		EnumInfos:         file_search_proto_enumTypes,
 		MessageInfos:      file_search_proto_msgTypes,
 	}.Build()
 	File_search_proto = out.File
@@ -23,12 +23,22 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	SearchService_Stop_FullMethodName             = "/search.SearchService/Stop"
-	SearchService_GetEngineVersion_FullMethodName = "/search.SearchService/GetEngineVersion"
-	SearchService_IndexJsonObject_FullMethodName  = "/search.SearchService/IndexJsonObject"
-	SearchService_Count_FullMethodName            = "/search.SearchService/Count"
-	SearchService_DeleteDocument_FullMethodName   = "/search.SearchService/DeleteDocument"
-	SearchService_SearchDocuments_FullMethodName  = "/search.SearchService/SearchDocuments"
+	SearchService_Stop_FullMethodName              = "/search.SearchService/Stop"
+	SearchService_GetEngineVersion_FullMethodName  = "/search.SearchService/GetEngineVersion"
+	SearchService_IndexJsonObject_FullMethodName   = "/search.SearchService/IndexJsonObject"
+	SearchService_Count_FullMethodName             = "/search.SearchService/Count"
+	SearchService_DeleteDocument_FullMethodName    = "/search.SearchService/DeleteDocument"
+	SearchService_SearchDocuments_FullMethodName   = "/search.SearchService/SearchDocuments"
+	SearchService_SearchDocumentsV2_FullMethodName = "/search.SearchService/SearchDocumentsV2"
+	SearchService_SetIndexAlias_FullMethodName     = "/search.SearchService/SetIndexAlias"
+	SearchService_DeleteIndexAlias_FullMethodName  = "/search.SearchService/DeleteIndexAlias"
+	SearchService_BulkIndex_FullMethodName         = "/search.SearchService/BulkIndex"
+	SearchService_CreateIndex_FullMethodName       = "/search.SearchService/CreateIndex"
+	SearchService_GetIndexMapping_FullMethodName   = "/search.SearchService/GetIndexMapping"
+	SearchService_ListIndexes_FullMethodName       = "/search.SearchService/ListIndexes"
+	SearchService_ReindexTo_FullMethodName         = "/search.SearchService/ReindexTo"
+	SearchService_DeleteIndex_FullMethodName       = "/search.SearchService/DeleteIndex"
+	SearchService_ListIndexAliases_FullMethodName  = "/search.SearchService/ListIndexAliases"
 )
 
 // SearchServiceClient is the client API for SearchService service.
@@ -54,6 +64,38 @@ type SearchServiceClient interface {
 	// *
 	// Search for documents based on the query.
 	SearchDocuments(ctx context.Context, in *SearchDocumentsRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[SearchDocumentsResponse], error)
+	// *
+	// Search indexes and aliases together and return one page ranked and
+	// sorted across all of them, with facets and a page token.
+	SearchDocumentsV2(ctx context.Context, in *SearchDocumentsV2Request, opts ...grpc.CallOption) (*SearchDocumentsV2Response, error)
+	// *
+	// Create or replace an alias over a set of index paths.
+	SetIndexAlias(ctx context.Context, in *SetIndexAliasRequest, opts ...grpc.CallOption) (*SetIndexAliasResponse, error)
+	// *
+	// Remove an alias; the indexes are left untouched.
+	DeleteIndexAlias(ctx context.Context, in *DeleteIndexAliasRequest, opts ...grpc.CallOption) (*DeleteIndexAliasResponse, error)
+	// *
+	// Index a stream of documents in batches and report the outcome of
+	// each one.
+	BulkIndex(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkIndexRequest, BulkIndexResponse], error)
+	// *
+	// Create an empty index with an explicit mapping.
+	CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*CreateIndexResponse, error)
+	// *
+	// Return the mapping of an index.
+	GetIndexMapping(ctx context.Context, in *GetIndexMappingRequest, opts ...grpc.CallOption) (*GetIndexMappingResponse, error)
+	// *
+	// List the indexes known to the service.
+	ListIndexes(ctx context.Context, in *ListIndexesRequest, opts ...grpc.CallOption) (*ListIndexesResponse, error)
+	// *
+	// Rebuild an index into a new one and swap an alias to it.
+	ReindexTo(ctx context.Context, in *ReindexToRequest, opts ...grpc.CallOption) (*ReindexToResponse, error)
+	// *
+	// Close an index and remove it from disk.
+	DeleteIndex(ctx context.Context, in *DeleteIndexRequest, opts ...grpc.CallOption) (*DeleteIndexResponse, error)
+	// *
+	// List the aliases.
+	ListIndexAliases(ctx context.Context, in *ListIndexAliasesRequest, opts ...grpc.CallOption) (*ListIndexAliasesResponse, error)
 }
 
 type searchServiceClient struct {
@@ -133,6 +175,109 @@ func (c *searchServiceClient) SearchDocuments(ctx context.Context, in *SearchDoc
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SearchService_SearchDocumentsClient = grpc.ServerStreamingClient[SearchDocumentsResponse]
 
+func (c *searchServiceClient) SearchDocumentsV2(ctx context.Context, in *SearchDocumentsV2Request, opts ...grpc.CallOption) (*SearchDocumentsV2Response, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SearchDocumentsV2Response)
+	err := c.cc.Invoke(ctx, SearchService_SearchDocumentsV2_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) SetIndexAlias(ctx context.Context, in *SetIndexAliasRequest, opts ...grpc.CallOption) (*SetIndexAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIndexAliasResponse)
+	err := c.cc.Invoke(ctx, SearchService_SetIndexAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) DeleteIndexAlias(ctx context.Context, in *DeleteIndexAliasRequest, opts ...grpc.CallOption) (*DeleteIndexAliasResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteIndexAliasResponse)
+	err := c.cc.Invoke(ctx, SearchService_DeleteIndexAlias_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) BulkIndex(ctx context.Context, opts ...grpc.CallOption) (grpc.ClientStreamingClient[BulkIndexRequest, BulkIndexResponse], error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	stream, err := c.cc.NewStream(ctx, &SearchService_ServiceDesc.Streams[1], SearchService_BulkIndex_FullMethodName, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &grpc.GenericClientStream[BulkIndexRequest, BulkIndexResponse]{ClientStream: stream}
+	return x, nil
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SearchService_BulkIndexClient = grpc.ClientStreamingClient[BulkIndexRequest, BulkIndexResponse]
+
+func (c *searchServiceClient) CreateIndex(ctx context.Context, in *CreateIndexRequest, opts ...grpc.CallOption) (*CreateIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CreateIndexResponse)
+	err := c.cc.Invoke(ctx, SearchService_CreateIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) GetIndexMapping(ctx context.Context, in *GetIndexMappingRequest, opts ...grpc.CallOption) (*GetIndexMappingResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetIndexMappingResponse)
+	err := c.cc.Invoke(ctx, SearchService_GetIndexMapping_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) ListIndexes(ctx context.Context, in *ListIndexesRequest, opts ...grpc.CallOption) (*ListIndexesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIndexesResponse)
+	err := c.cc.Invoke(ctx, SearchService_ListIndexes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) ReindexTo(ctx context.Context, in *ReindexToRequest, opts ...grpc.CallOption) (*ReindexToResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ReindexToResponse)
+	err := c.cc.Invoke(ctx, SearchService_ReindexTo_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) DeleteIndex(ctx context.Context, in *DeleteIndexRequest, opts ...grpc.CallOption) (*DeleteIndexResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(DeleteIndexResponse)
+	err := c.cc.Invoke(ctx, SearchService_DeleteIndex_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *searchServiceClient) ListIndexAliases(ctx context.Context, in *ListIndexAliasesRequest, opts ...grpc.CallOption) (*ListIndexAliasesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ListIndexAliasesResponse)
+	err := c.cc.Invoke(ctx, SearchService_ListIndexAliases_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // SearchServiceServer is the server API for SearchService service.
 // All implementations should embed UnimplementedSearchServiceServer
 // for forward compatibility.
@@ -156,6 +301,38 @@ type SearchServiceServer interface {
 	// *
 	// Search for documents based on the query.
 	SearchDocuments(*SearchDocumentsRequest, grpc.ServerStreamingServer[SearchDocumentsResponse]) error
+	// *
+	// Search indexes and aliases together and return one page ranked and
+	// sorted across all of them, with facets and a page token.
+	SearchDocumentsV2(context.Context, *SearchDocumentsV2Request) (*SearchDocumentsV2Response, error)
+	// *
+	// Create or replace an alias over a set of index paths.
+	SetIndexAlias(context.Context, *SetIndexAliasRequest) (*SetIndexAliasResponse, error)
+	// *
+	// Remove an alias; the indexes are left untouched.
+	DeleteIndexAlias(context.Context, *DeleteIndexAliasRequest) (*DeleteIndexAliasResponse, error)
+	// *
+	// Index a stream of documents in batches and report the outcome of
+	// each one.
+	BulkIndex(grpc.ClientStreamingServer[BulkIndexRequest, BulkIndexResponse]) error
+	// *
+	// Create an empty index with an explicit mapping.
+	CreateIndex(context.Context, *CreateIndexRequest) (*CreateIndexResponse, error)
+	// *
+	// Return the mapping of an index.
+	GetIndexMapping(context.Context, *GetIndexMappingRequest) (*GetIndexMappingResponse, error)
+	// *
+	// List the indexes known to the service.
+	ListIndexes(context.Context, *ListIndexesRequest) (*ListIndexesResponse, error)
+	// *
+	// Rebuild an index into a new one and swap an alias to it.
+	ReindexTo(context.Context, *ReindexToRequest) (*ReindexToResponse, error)
+	// *
+	// Close an index and remove it from disk.
+	DeleteIndex(context.Context, *DeleteIndexRequest) (*DeleteIndexResponse, error)
+	// *
+	// List the aliases.
+	ListIndexAliases(context.Context, *ListIndexAliasesRequest) (*ListIndexAliasesResponse, error)
 }
 
 // UnimplementedSearchServiceServer should be embedded to have
@@ -183,6 +360,36 @@ func (UnimplementedSearchServiceServer) DeleteDocument(context.Context, *DeleteD
 func (UnimplementedSearchServiceServer) SearchDocuments(*SearchDocumentsRequest, grpc.ServerStreamingServer[SearchDocumentsResponse]) error {
 	return status.Error(codes.Unimplemented, "method SearchDocuments not implemented")
 }
+func (UnimplementedSearchServiceServer) SearchDocumentsV2(context.Context, *SearchDocumentsV2Request) (*SearchDocumentsV2Response, error) {
+	return nil, status.Error(codes.Unimplemented, "method SearchDocumentsV2 not implemented")
+}
+func (UnimplementedSearchServiceServer) SetIndexAlias(context.Context, *SetIndexAliasRequest) (*SetIndexAliasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetIndexAlias not implemented")
+}
+func (UnimplementedSearchServiceServer) DeleteIndexAlias(context.Context, *DeleteIndexAliasRequest) (*DeleteIndexAliasResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteIndexAlias not implemented")
+}
+func (UnimplementedSearchServiceServer) BulkIndex(grpc.ClientStreamingServer[BulkIndexRequest, BulkIndexResponse]) error {
+	return status.Error(codes.Unimplemented, "method BulkIndex not implemented")
+}
+func (UnimplementedSearchServiceServer) CreateIndex(context.Context, *CreateIndexRequest) (*CreateIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateIndex not implemented")
+}
+func (UnimplementedSearchServiceServer) GetIndexMapping(context.Context, *GetIndexMappingRequest) (*GetIndexMappingResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetIndexMapping not implemented")
+}
+func (UnimplementedSearchServiceServer) ListIndexes(context.Context, *ListIndexesRequest) (*ListIndexesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListIndexes not implemented")
+}
+func (UnimplementedSearchServiceServer) ReindexTo(context.Context, *ReindexToRequest) (*ReindexToResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReindexTo not implemented")
+}
+func (UnimplementedSearchServiceServer) DeleteIndex(context.Context, *DeleteIndexRequest) (*DeleteIndexResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteIndex not implemented")
+}
+func (UnimplementedSearchServiceServer) ListIndexAliases(context.Context, *ListIndexAliasesRequest) (*ListIndexAliasesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListIndexAliases not implemented")
+}
 func (UnimplementedSearchServiceServer) testEmbeddedByValue() {}
 
 // UnsafeSearchServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -304,6 +511,175 @@ func _SearchService_SearchDocuments_Handler(srv interface{}, stream grpc.ServerS
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type SearchService_SearchDocumentsServer = grpc.ServerStreamingServer[SearchDocumentsResponse]
 
+func _SearchService_SearchDocumentsV2_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SearchDocumentsV2Request)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).SearchDocumentsV2(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_SearchDocumentsV2_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).SearchDocumentsV2(ctx, req.(*SearchDocumentsV2Request))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_SetIndexAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIndexAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).SetIndexAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_SetIndexAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).SetIndexAlias(ctx, req.(*SetIndexAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_DeleteIndexAlias_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIndexAliasRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).DeleteIndexAlias(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_DeleteIndexAlias_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).DeleteIndexAlias(ctx, req.(*DeleteIndexAliasRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_BulkIndex_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(SearchServiceServer).BulkIndex(&grpc.GenericServerStream[BulkIndexRequest, BulkIndexResponse]{ServerStream: stream})
+}
+
+// This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
+type SearchService_BulkIndexServer = grpc.ClientStreamingServer[BulkIndexRequest, BulkIndexResponse]
+
+func _SearchService_CreateIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).CreateIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_CreateIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).CreateIndex(ctx, req.(*CreateIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_GetIndexMapping_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetIndexMappingRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).GetIndexMapping(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_GetIndexMapping_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).GetIndexMapping(ctx, req.(*GetIndexMappingRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_ListIndexes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIndexesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).ListIndexes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_ListIndexes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).ListIndexes(ctx, req.(*ListIndexesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_ReindexTo_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReindexToRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).ReindexTo(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_ReindexTo_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).ReindexTo(ctx, req.(*ReindexToRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_DeleteIndex_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteIndexRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).DeleteIndex(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_DeleteIndex_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).DeleteIndex(ctx, req.(*DeleteIndexRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _SearchService_ListIndexAliases_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ListIndexAliasesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(SearchServiceServer).ListIndexAliases(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: SearchService_ListIndexAliases_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(SearchServiceServer).ListIndexAliases(ctx, req.(*ListIndexAliasesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // SearchService_ServiceDesc is the grpc.ServiceDesc for SearchService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -331,6 +707,42 @@ var SearchService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteDocument",
 			Handler:    _SearchService_DeleteDocument_Handler,
 		},
+		{
+			MethodName: "SearchDocumentsV2",
+			Handler:    _SearchService_SearchDocumentsV2_Handler,
+		},
+		{
+			MethodName: "SetIndexAlias",
+			Handler:    _SearchService_SetIndexAlias_Handler,
+		},
+		{
+			MethodName: "DeleteIndexAlias",
+			Handler:    _SearchService_DeleteIndexAlias_Handler,
+		},
+		{
+			MethodName: "CreateIndex",
+			Handler:    _SearchService_CreateIndex_Handler,
+		},
+		{
+			MethodName: "GetIndexMapping",
+			Handler:    _SearchService_GetIndexMapping_Handler,
+		},
+		{
+			MethodName: "ListIndexes",
+			Handler:    _SearchService_ListIndexes_Handler,
+		},
+		{
+			MethodName: "ReindexTo",
+			Handler:    _SearchService_ReindexTo_Handler,
+		},
+		{
+			MethodName: "DeleteIndex",
+			Handler:    _SearchService_DeleteIndex_Handler,
+		},
+		{
+			MethodName: "ListIndexAliases",
+			Handler:    _SearchService_ListIndexAliases_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -338,6 +750,11 @@ var SearchService_ServiceDesc = grpc.ServiceDesc{
 			Handler:       _SearchService_SearchDocuments_Handler,
 			ServerStreams: true,
 		},
+		{
+			StreamName:    "BulkIndex",
+			Handler:       _SearchService_BulkIndex_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "search.proto",
 }
@@ -97,6 +97,7 @@ var bootstrapAllowedMethods = map[string]bool{
 
 	// Authentication (required for getting initial tokens)
 	"/authentication.AuthenticationService/Authenticate": true,
+	"/authentication.AuthenticationService/VerifyMfa":    true,
 
 	// Resource service (required for node identity during cluster formation)
 	"/resource.ResourceService/UpsertNodeIdentity":  true,
@@ -163,7 +163,7 @@ func readSessionTimeout() (int, error) {
 // GenerateToken creates a v1-conformant JWT token with opaque principal identity.
 // v1 Breaking Change: Removed userDomain parameter - identity MUST NOT include domain.
 func GenerateToken(timeout int, mac, userId, userName, email string) (string, error) {
-	return generateTokenWithUUID(timeout, mac, userId, userName, email, "")
+	return generateTokenWithUUID(timeout, mac, userId, userName, email, "", nil)
 }
 
 // GenerateTokenWithAccountUUID is GenerateToken plus the account's opaque
@@ -172,10 +172,43 @@ func GenerateToken(timeout int, mac, userId, userName, email string) (string, er
 // GenerateToken's single signing implementation (single-issuance-point invariant).
 // Service/sa tokens use GenerateToken and carry no account uuid.
 func GenerateTokenWithAccountUUID(timeout int, mac, userId, userName, email, accountUUID string) (string, error) {
-	return generateTokenWithUUID(timeout, mac, userId, userName, email, accountUUID)
+	return generateTokenWithUUID(timeout, mac, userId, userName, email, accountUUID, nil)
 }
 
-func generateTokenWithUUID(timeout int, mac, userId, userName, email, accountUUID string) (string, error) {
+// ScopeMFAPending marks the token handed out after the first factor of a
+// login that still owes a second one. It proves the password and nothing
+// else: ValidateToken rejects it, so it cannot be used as a session, and only
+// ValidateMFAPendingToken accepts it.
+const ScopeMFAPending = "mfa_pending"
+
+// ErrMFAPending is returned by ValidateToken for a token carrying ScopeMFAPending.
+var ErrMFAPending = errors.New("validate token: multi-factor verification is pending")
+
+// ErrNotSessionToken is returned by ValidateToken for share-link and approval
+// tokens: they are signed with the same issuer keys but are capabilities, not
+// sessions, whatever audience the caller expects.
+var ErrNotSessionToken = errors.New("validate token: not a session token")
+
+// GenerateMFAPendingToken issues a ScopeMFAPending token for an account. Each
+// one carries a random jti so it can be consumed once.
+func GenerateMFAPendingToken(timeout int, mac, userId, userName, email, accountUUID string) (string, error) {
+	return generateTokenWithUUID(timeout, mac, userId, userName, email, accountUUID, []string{ScopeMFAPending})
+}
+
+// ValidateMFAPendingToken validates a token issued by GenerateMFAPendingToken
+// and returns its claims. Session tokens are rejected.
+func ValidateMFAPendingToken(tokenStr string) (*Claims, error) {
+	claims, err := validateTokenInternal(tokenStr, "", true)
+	if err != nil {
+		return claims, err
+	}
+	if !Utility.Contains(claims.Scopes, ScopeMFAPending) {
+		return claims, errors.New("validate token: not a multi-factor pending token")
+	}
+	return claims, nil
+}
+
+func generateTokenWithUUID(timeout int, mac, userId, userName, email, accountUUID string, scopes []string) (string, error) {
 	// Normalize/secure timeout
 	if timeout <= 0 {
 		if cfgTimeout, err := readSessionTimeout(); err == nil && cfgTimeout > 0 {
@@ -232,6 +265,7 @@ func generateTokenWithUUID(timeout int, mac, userId, userName, email, accountUUI
 		Username:    userName,    // Display name only
 		Email:       email,       // Contact only
 		Address:     address,     // Informational only
+		Scopes:      scopes,
 		// REMOVED per v1: Domain, UserDomain (routing labels, not identity)
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(expiresAt),
@@ -246,6 +280,14 @@ func generateTokenWithUUID(timeout int, mac, userId, userName, email, accountUUI
 	if audience != "" {
 		claims.Audience = jwt.ClaimStrings{audience}
 	}
+	pending := Utility.Contains(scopes, ScopeMFAPending)
+	if pending {
+		jti, err := randomJTI()
+		if err != nil {
+			return "", fmt.Errorf("generate token: %w", err)
+		}
+		claims.RegisteredClaims.ID = jti
+	}
 
 	// Load issuer private key + kid from your keystore
 	if GetIssuerSigningKey == nil {
@@ -267,7 +309,7 @@ func generateTokenWithUUID(timeout int, mac, userId, userName, email, accountUUI
 	}
 
 	// Paranoid self-validate before returning
-	if _, err := ValidateToken(signed); err != nil {
+	if _, err := validateTokenInternal(signed, "", pending); err != nil {
 		logger.Error("generate token: self-validate failed", "err", err)
 		return "", fmt.Errorf("generate token: self-validate failed: %w", err)
 	}
@@ -285,20 +327,21 @@ func GenerateServiceToken(mac string) (string, error) {
 // Signature is verified using the **issuer's public key** looked up by iss + kid.
 // v2 Note: Does NOT validate audience - use ValidateTokenWithAudience for proper validation.
 func ValidateToken(tokenStr string) (*Claims, error) {
-	return validateTokenInternal(tokenStr, "")
+	return validateTokenInternal(tokenStr, "", false)
 }
 
 // ValidateTokenWithAudience validates a token and enforces audience matching.
 // v2 Conformance: Prevents cross-service token replay (security violation INV-4)
 // Audience should be the MAC address of the current service.
 func ValidateTokenWithAudience(tokenStr string, expectedAudience string) (*Claims, error) {
-	return validateTokenInternal(tokenStr, expectedAudience)
+	return validateTokenInternal(tokenStr, expectedAudience, false)
 }
 
 // validateTokenInternal is the core validation logic. Enforces EdDSA algorithm
 // and resolves the signing key by issuer+kid — unknown issuers fail closed.
-//
-func validateTokenInternal(tokenStr string, expectedAudience string) (*Claims, error) {
+// ScopeMFAPending tokens are refused unless allowPending is set, and share-link
+// or approval tokens always are.
+func validateTokenInternal(tokenStr string, expectedAudience string, allowPending bool) (*Claims, error) {
 	claims := &Claims{}
 
 	keyFunc := func(t *jwt.Token) (interface{}, error) {
@@ -338,6 +381,16 @@ func validateTokenInternal(tokenStr string, expectedAudience string) (*Claims, e
 	// jwt.WithAudience("") would reject tokens that carry a non-empty audience.
 
 	parsed, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc, parseOpts...)
+	// Checked before the parse error: callers such as RefreshToken accept an
+	// expired token, and an expired pending token must not pass as one.
+	if !allowPending && Utility.Contains(claims.Scopes, ScopeMFAPending) {
+		return claims, ErrMFAPending
+	}
+	for _, aud := range claims.Audience {
+		if strings.HasPrefix(aud, ShareAudiencePrefix) || strings.HasPrefix(aud, ApprovalAudiencePrefix) {
+			return claims, ErrNotSessionToken
+		}
+	}
 	if err != nil {
 		return claims, fmt.Errorf("validate token: parse: %w", err)
 	}
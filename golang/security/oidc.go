@@ -0,0 +1,109 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_security.oidc
+// @awareness file_role=ed25519_signing_and_key_publication_for_the_openid_connect_provider
+// @awareness implements=globular.platform:intent.security.tokens_certificates_keys.cluster_trust_contract
+// @awareness risk=high
+package security
+
+import (
+	"context"
+	"crypto/ed25519"
+	"errors"
+	"fmt"
+	"path"
+
+	"github.com/globulario/services/golang/config"
+	"github.com/golang-jwt/jwt/v5"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// SignIssuerClaims signs arbitrary claims with this node's Ed25519 issuer key
+// (the key cluster session tokens are signed with) and sets the kid header.
+// It is used for tokens handed to third parties, such as OpenID Connect ID and
+// access tokens, whose claims do not follow the Globular session layout.
+func SignIssuerClaims(claims jwt.Claims) (string, error) {
+	if GetIssuerSigningKey == nil {
+		return "", errors.New("sign claims: GetIssuerSigningKey not configured")
+	}
+	issuer, err := config.GetMacAddress()
+	if err != nil {
+		return "", fmt.Errorf("sign claims: get mac address: %w", err)
+	}
+	priv, kid, err := GetIssuerSigningKey(issuer)
+	if err != nil {
+		return "", fmt.Errorf("sign claims: get issuer signing key: %w", err)
+	}
+	token := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	if kid != "" {
+		token.Header["kid"] = kid
+	}
+	return token.SignedString(priv)
+}
+
+// IssuerPublicKeys returns the Ed25519 public keys that may have signed a
+// token in this cluster, keyed by kid: this node's current key plus every key
+// peers have published in etcd. Peer keys are best effort; the local key is
+// always returned when it can be loaded.
+func IssuerPublicKeys() (map[string]ed25519.PublicKey, error) {
+	keys := make(map[string]ed25519.PublicKey)
+
+	if GetIssuerSigningKey != nil {
+		if issuer, err := config.GetMacAddress(); err == nil {
+			if priv, kid, err := GetIssuerSigningKey(issuer); err == nil {
+				pub := priv.Public().(ed25519.PublicKey)
+				if kid == "" {
+					kid = kidFromPub(pub)
+				}
+				keys[kid] = pub
+			}
+		}
+	}
+
+	cli, err := config.GetEtcdClient()
+	if err != nil {
+		if len(keys) == 0 {
+			return nil, err
+		}
+		return keys, nil
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyringRequestTimeout)
+	defer cancel()
+	res, err := cli.Get(ctx, peerPublicKeyPrefix+"/", clientv3.WithPrefix(), clientv3.WithSerializable())
+	if err != nil {
+		if len(keys) == 0 {
+			return nil, err
+		}
+		return keys, nil
+	}
+	var current []ed25519.PublicKey
+	for _, kv := range res.Kvs {
+		pub, err := parseEd25519PublicPEM(kv.Value)
+		if err != nil {
+			continue
+		}
+		kid := path.Base(string(kv.Key))
+		if kid == peerPublicKeyCurrent {
+			current = append(current, pub)
+			continue
+		}
+		keys[kid] = pub
+	}
+	// The "current" alias normally duplicates a kid entry; only keep it when
+	// the issuer never published its key under a kid.
+	for _, pub := range current {
+		known := false
+		for _, k := range keys {
+			if k.Equal(pub) {
+				known = true
+				break
+			}
+		}
+		if !known {
+			keys[kidFromPub(pub)] = pub
+		}
+	}
+	if len(keys) == 0 {
+		return nil, errors.New("issuer public keys: no keys available")
+	}
+	return keys, nil
+}
@@ -0,0 +1,188 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_security.secret
+// @awareness file_role=at_rest_encryption_of_small_secrets_with_cluster_data_keys
+// @awareness implements=globular.platform:intent.security.tokens_certificates_keys.cluster_trust_contract
+// @awareness risk=high
+package security
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hkdf"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/globulario/services/golang/config"
+	clientv3 "go.etcd.io/etcd/client/v3"
+)
+
+// sealedSecretPrefix versions the SealSecret format:
+// "gsec1$<kid>$<base64url(nonce|ciphertext)>", kid naming the data key.
+const sealedSecretPrefix = "gsec1"
+
+// Data keys live in etcd, shared by every node and independent of the
+// signing keys, so a secret sealed on one node opens on all of them and
+// survives a key rotation. The current entry names the key new secrets are
+// sealed with; older keys stay to open what they sealed.
+const (
+	secretKeyPrefix  = "/globular/security/secret_keys"
+	secretKeyCurrent = "current"
+)
+
+// ErrUnknownSecretKey is returned by OpenSecret for a secret sealed under a
+// data key the cluster no longer holds.
+var ErrUnknownSecretKey = errors.New("secret: sealed with an unknown data key")
+
+// loadSecretDataKey returns the data key kid, or the current one when kid is
+// empty, creating it on first use. Tests replace it.
+var loadSecretDataKey = loadSecretDataKeyEtcd
+
+var secretKeyCache sync.Map // kid -> []byte; data keys never change
+
+func loadSecretDataKeyEtcd(kid string) ([]byte, string, error) {
+	if k, ok := secretKeyCache.Load(kid); ok && kid != "" {
+		return k.([]byte), kid, nil
+	}
+	cli, err := config.GetEtcdClient()
+	if err != nil {
+		return nil, "", fmt.Errorf("secret: etcd: %w", err)
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), keyringRequestTimeout)
+	defer cancel()
+
+	if kid == "" {
+		current := path.Join(secretKeyPrefix, secretKeyCurrent)
+		resp, err := cli.Get(ctx, current)
+		if err != nil {
+			return nil, "", fmt.Errorf("secret: get current data key: %w", err)
+		}
+		if len(resp.Kvs) == 0 {
+			// First use in the cluster: create a key, unless another node
+			// just did, and take whichever won.
+			key := make([]byte, 32)
+			id := make([]byte, 12)
+			if _, err := rand.Read(key); err != nil {
+				return nil, "", fmt.Errorf("secret: generate data key: %w", err)
+			}
+			if _, err := rand.Read(id); err != nil {
+				return nil, "", fmt.Errorf("secret: generate data key: %w", err)
+			}
+			newKid := base64.RawURLEncoding.EncodeToString(id)
+			txn, err := cli.Txn(ctx).
+				If(clientv3.Compare(clientv3.CreateRevision(current), "=", 0)).
+				Then(clientv3.OpPut(path.Join(secretKeyPrefix, newKid), base64.RawURLEncoding.EncodeToString(key)),
+					clientv3.OpPut(current, newKid)).
+				Else(clientv3.OpGet(current)).
+				Commit()
+			if err != nil {
+				return nil, "", fmt.Errorf("secret: create data key: %w", err)
+			}
+			if txn.Succeeded {
+				secretKeyCache.Store(newKid, key)
+				return key, newKid, nil
+			}
+			resp = (*clientv3.GetResponse)(txn.Responses[0].GetResponseRange())
+		}
+		if len(resp.Kvs) == 0 {
+			return nil, "", errors.New("secret: no current data key")
+		}
+		kid = string(resp.Kvs[0].Value)
+		if k, ok := secretKeyCache.Load(kid); ok {
+			return k.([]byte), kid, nil
+		}
+	}
+
+	resp, err := cli.Get(ctx, path.Join(secretKeyPrefix, kid))
+	if err != nil {
+		return nil, "", fmt.Errorf("secret: get data key %s: %w", kid, err)
+	}
+	if len(resp.Kvs) == 0 {
+		return nil, "", ErrUnknownSecretKey
+	}
+	key, err := base64.RawURLEncoding.DecodeString(string(resp.Kvs[0].Value))
+	if err != nil || len(key) != 32 {
+		return nil, "", fmt.Errorf("secret: data key %s is malformed", kid)
+	}
+	secretKeyCache.Store(kid, key)
+	return key, kid, nil
+}
+
+// secretKey derives the AES-256 key for purpose from the cluster data key
+// kid, or from the current one when kid is empty.
+func secretKey(purpose, kid string) ([]byte, string, error) {
+	dataKey, kid, err := loadSecretDataKey(kid)
+	if err != nil {
+		return nil, "", err
+	}
+	key, err := hkdf.Key(sha256.New, dataKey, nil, "globular secret "+purpose, 32)
+	if err != nil {
+		return nil, "", fmt.Errorf("secret: derive key: %w", err)
+	}
+	return key, kid, nil
+}
+
+func secretAEAD(key []byte) (cipher.AEAD, error) {
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// SealSecret encrypts plaintext with AES-256-GCM under a key derived from the
+// current cluster data key in etcd. purpose separates keys between uses, and
+// binds the ciphertext to them: OpenSecret must be given the same.
+func SealSecret(purpose string, plaintext []byte) (string, error) {
+	key, kid, err := secretKey(purpose, "")
+	if err != nil {
+		return "", err
+	}
+	aead, err := secretAEAD(key)
+	if err != nil {
+		return "", fmt.Errorf("secret: %w", err)
+	}
+	nonce := make([]byte, aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", fmt.Errorf("secret: nonce: %w", err)
+	}
+	sealed := aead.Seal(nonce, nonce, plaintext, []byte(purpose))
+	return strings.Join([]string{sealedSecretPrefix, kid, base64.RawURLEncoding.EncodeToString(sealed)}, "$"), nil
+}
+
+// OpenSecret decrypts a value produced by SealSecret for the same purpose.
+func OpenSecret(purpose, sealed string) ([]byte, error) {
+	parts := strings.Split(sealed, "$")
+	if len(parts) != 3 || parts[0] != sealedSecretPrefix {
+		return nil, errors.New("secret: malformed sealed value")
+	}
+	data, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, errors.New("secret: malformed sealed value")
+	}
+	if parts[1] == "" {
+		return nil, errors.New("secret: malformed sealed value")
+	}
+	key, _, err := secretKey(purpose, parts[1])
+	if err != nil {
+		return nil, err
+	}
+	aead, err := secretAEAD(key)
+	if err != nil {
+		return nil, fmt.Errorf("secret: %w", err)
+	}
+	if len(data) < aead.NonceSize() {
+		return nil, errors.New("secret: malformed sealed value")
+	}
+	plain, err := aead.Open(nil, data[:aead.NonceSize()], data[aead.NonceSize():], []byte(purpose))
+	if err != nil {
+		return nil, errors.New("secret: cannot decrypt sealed value")
+	}
+	return plain, nil
+}
@@ -0,0 +1,138 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// secretTestEnv replaces the etcd data keys with an in-memory set.
+func secretTestEnv(t *testing.T) {
+	t.Helper()
+	keys := map[string][]byte{}
+	current := ""
+	prev := loadSecretDataKey
+	t.Cleanup(func() { loadSecretDataKey = prev })
+	loadSecretDataKey = func(kid string) ([]byte, string, error) {
+		if kid == "" {
+			if current == "" {
+				current = "k1"
+				keys[current] = []byte(strings.Repeat("k", 32))
+			}
+			kid = current
+		}
+		key, ok := keys[kid]
+		if !ok {
+			return nil, "", ErrUnknownSecretKey
+		}
+		return key, kid, nil
+	}
+}
+
+func TestSealSecretRoundTrip(t *testing.T) {
+	secretTestEnv(t)
+
+	sealed, err := SealSecret("totp", []byte("JBSWY3DPEHPK3PXP"))
+	if err != nil {
+		t.Fatalf("seal: %v", err)
+	}
+	if strings.Contains(sealed, "JBSWY3DPEHPK3PXP") {
+		t.Fatalf("plaintext visible in %q", sealed)
+	}
+	plain, err := OpenSecret("totp", sealed)
+	if err != nil || string(plain) != "JBSWY3DPEHPK3PXP" {
+		t.Fatalf("open = %q, %v", plain, err)
+	}
+
+	// The data key does not depend on the node's signing key: the secret
+	// still opens after a rotation, or on another node.
+	approvalTestEnv(t)
+	if plain, err := OpenSecret("totp", sealed); err != nil || string(plain) != "JBSWY3DPEHPK3PXP" {
+		t.Errorf("open with another signing key = %q, %v", plain, err)
+	}
+
+	// Another purpose derives another key.
+	if _, err := OpenSecret("other", sealed); err == nil {
+		t.Error("opened under another purpose")
+	}
+
+	// A tampered ciphertext fails authentication.
+	tampered := sealed[:len(sealed)-2] + "AA"
+	if _, err := OpenSecret("totp", tampered); err == nil {
+		t.Error("tampered value opened")
+	}
+
+	// Sealed under a data key the cluster does not hold.
+	parts := strings.Split(sealed, "$")
+	parts[1] = "other-kid"
+	if _, err := OpenSecret("totp", strings.Join(parts, "$")); !errors.Is(err, ErrUnknownSecretKey) {
+		t.Errorf("unknown kid: err = %v", err)
+	}
+}
+
+// signTestToken signs claims with the key installed by approvalTestEnv.
+func signTestToken(t *testing.T, claims *Claims) string {
+	t.Helper()
+	priv, kid, err := GetIssuerSigningKey("00:11:22:33:44:55")
+	if err != nil {
+		t.Fatal(err)
+	}
+	claims.Issuer = "00:11:22:33:44:55"
+	tok := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	tok.Header["kid"] = kid
+	s, err := tok.SignedString(priv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return s
+}
+
+func TestMFAPendingTokenIsNotASession(t *testing.T) {
+	approvalTestEnv(t)
+
+	now := time.Now()
+	pending := signTestToken(t, &Claims{
+		ID:     "alice",
+		Scopes: []string{ScopeMFAPending},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        "jti-1",
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	})
+	if _, err := ValidateToken(pending); !errors.Is(err, ErrMFAPending) {
+		t.Errorf("ValidateToken(pending) err = %v", err)
+	}
+	claims, err := ValidateMFAPendingToken(pending)
+	if err != nil || claims.ID != "alice" {
+		t.Fatalf("ValidateMFAPendingToken = %+v, %v", claims, err)
+	}
+
+	// Expired pending tokens must not look like expired sessions, which
+	// RefreshToken would renew.
+	expired := signTestToken(t, &Claims{
+		ID:     "alice",
+		Scopes: []string{ScopeMFAPending},
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(-time.Hour)),
+		},
+	})
+	if _, err := ValidateToken(expired); !errors.Is(err, ErrMFAPending) {
+		t.Errorf("ValidateToken(expired pending) err = %v", err)
+	}
+
+	session := signTestToken(t, &Claims{
+		ID: "alice",
+		RegisteredClaims: jwt.RegisteredClaims{
+			ExpiresAt: jwt.NewNumericDate(now.Add(time.Minute)),
+		},
+	})
+	if _, err := ValidateToken(session); err != nil {
+		t.Errorf("ValidateToken(session) err = %v", err)
+	}
+	if _, err := ValidateMFAPendingToken(session); err == nil {
+		t.Error("session accepted as a pending token")
+	}
+}
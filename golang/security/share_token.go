@@ -0,0 +1,141 @@
+// @awareness namespace=globular.platform
+// @awareness component=platform_security.share_token
+// @awareness file_role=audience_bound_expiring_capability_token_for_file_share_links
+// @awareness implements=globular.platform:intent.security.tokens_certificates_keys.cluster_trust_contract
+// @awareness risk=high
+package security
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// ShareAudiencePrefix is prepended to the cluster id to form the audience of
+// share-link tokens. ValidateToken refuses any token with this audience, so a
+// share link is never accepted as a session, and session tokens are never
+// accepted as share links.
+const ShareAudiencePrefix = "share:"
+
+// maxShareLifetime bounds how long a share link can live.
+const maxShareLifetime = 365 * 24 * time.Hour
+
+// ShareClaims is the signed payload of a share-link token: a capability for
+// the path, valid until expiry for whoever holds the token. The link id is
+// the jti; revocation, passwords and download limits are kept with the link
+// record and checked by the verifier. The token has no subject: it does not
+// act as anyone, and its creator is kept in a private claim.
+type ShareClaims struct {
+	Path        string   `json:"path"`        // shared file or directory
+	Permissions []string `json:"permissions"` // e.g. "read", "list"
+	Creator     string   `json:"creator"`     // account that created the link
+	jwt.RegisteredClaims
+}
+
+// MintShareToken signs a share-link token for link id on path with the
+// cluster issuer's Ed25519 key. creator is recorded in the creator claim.
+func MintShareToken(id, creator, path string, permissions []string, expiresAt time.Time) (string, error) {
+	if strings.TrimSpace(id) == "" || strings.TrimSpace(path) == "" {
+		return "", errors.New("share token: id and path are required")
+	}
+	now := time.Now()
+	if !expiresAt.After(now) {
+		return "", errors.New("share token: expiry must be in the future")
+	}
+	if expiresAt.Sub(now) > maxShareLifetime {
+		return "", fmt.Errorf("share token: lifetime exceeds max %s", maxShareLifetime)
+	}
+
+	issuer, err := approvalGetIssuer()
+	if err != nil {
+		return "", fmt.Errorf("share token: get issuer mac: %w", err)
+	}
+	clusterID, err := approvalGetClusterID()
+	if err != nil {
+		return "", fmt.Errorf("share token: get cluster id: %w", err)
+	}
+	claims := &ShareClaims{
+		Path:        path,
+		Permissions: permissions,
+		Creator:     creator,
+		RegisteredClaims: jwt.RegisteredClaims{
+			ID:        id,
+			Issuer:    issuer,
+			Audience:  jwt.ClaimStrings{ShareAudiencePrefix + clusterID},
+			IssuedAt:  jwt.NewNumericDate(now),
+			NotBefore: jwt.NewNumericDate(now),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
+		},
+	}
+
+	if GetIssuerSigningKey == nil {
+		return "", errors.New("share token: GetIssuerSigningKey not configured")
+	}
+	priv, kid, err := GetIssuerSigningKey(issuer)
+	if err != nil {
+		return "", fmt.Errorf("share token: get issuer signing key: %w", err)
+	}
+	tok := jwt.NewWithClaims(jwt.SigningMethodEdDSA, claims)
+	if kid != "" {
+		tok.Header["kid"] = kid
+	}
+	signed, err := tok.SignedString(priv)
+	if err != nil {
+		return "", fmt.Errorf("share token: sign: %w", err)
+	}
+	return signed, nil
+}
+
+// ValidateShareToken verifies the signature, audience, expiry and
+// not-before of a share-link token and returns its claims. It does not know
+// about revocation: callers must still check the link record.
+func ValidateShareToken(tokenStr string) (*ShareClaims, error) {
+	if strings.TrimSpace(tokenStr) == "" {
+		return nil, errors.New("share token: token is empty")
+	}
+	claims := &ShareClaims{}
+	keyFunc := func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodEdDSA {
+			return nil, fmt.Errorf("share token: unexpected signing method: %v", t.Header["alg"])
+		}
+		if claims.Issuer == "" {
+			return nil, errors.New("share token: missing issuer")
+		}
+		var kid string
+		if k, ok := t.Header["kid"].(string); ok {
+			kid = k
+		}
+		if GetPeerPublicKey == nil {
+			return nil, errors.New("share token: GetPeerPublicKey not configured")
+		}
+		pub, err := GetPeerPublicKey(claims.Issuer, kid)
+		if err != nil {
+			return nil, fmt.Errorf("share token: get public key (iss=%s,kid=%s): %w", claims.Issuer, kid, err)
+		}
+		return pub, nil
+	}
+
+	clusterID, err := approvalGetClusterID()
+	if err != nil {
+		return nil, fmt.Errorf("share token: get cluster id: %w", err)
+	}
+	parsed, err := jwt.ParseWithClaims(tokenStr, claims, keyFunc,
+		jwt.WithLeeway(tokenExpirySkew),
+		jwt.WithAudience(ShareAudiencePrefix+clusterID),
+		jwt.WithIssuedAt(),
+		jwt.WithExpirationRequired(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("share token: parse: %w", err)
+	}
+	if !parsed.Valid {
+		return nil, errors.New("share token: signature invalid")
+	}
+	if claims.ID == "" || claims.Path == "" {
+		return nil, errors.New("share token: id or path is empty")
+	}
+	return claims, nil
+}
@@ -0,0 +1,78 @@
+package security
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestShareTokenRoundTrip(t *testing.T) {
+	approvalTestEnv(t)
+
+	tok, err := MintShareToken("link-1", "alice", "/users/alice/photos", []string{"read", "list"}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("mint: %v", err)
+	}
+	claims, err := ValidateShareToken(tok)
+	if err != nil {
+		t.Fatalf("validate: %v", err)
+	}
+	if claims.ID != "link-1" || claims.Creator != "alice" || claims.Subject != "" || claims.Path != "/users/alice/photos" || len(claims.Permissions) != 2 {
+		t.Errorf("claims = %+v", claims)
+	}
+
+	// A tampered payload breaks the signature.
+	parts := strings.Split(tok, ".")
+	parts[1] = parts[1][:len(parts[1])-2] + "AA"
+	if _, err := ValidateShareToken(strings.Join(parts, ".")); err == nil {
+		t.Error("tampered token accepted")
+	}
+}
+
+func TestShareTokenRejects(t *testing.T) {
+	approvalTestEnv(t)
+
+	if _, err := MintShareToken("link-1", "alice", "/a", nil, time.Now().Add(-time.Minute)); err == nil {
+		t.Error("expired link minted")
+	}
+	if _, err := MintShareToken("link-1", "alice", "/a", nil, time.Now().Add(2*maxShareLifetime)); err == nil {
+		t.Error("link beyond max lifetime minted")
+	}
+	if _, err := MintShareToken("", "alice", "/a", nil, time.Now().Add(time.Hour)); err == nil {
+		t.Error("link without id minted")
+	}
+
+	// Approval tokens carry another audience.
+	approval, err := MintApprovalToken(sampleMintRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateShareToken(approval); err == nil {
+		t.Error("approval token accepted as a share link")
+	}
+}
+
+// A share link is a capability for its path, never a session of its creator.
+func TestShareTokenIsNotASession(t *testing.T) {
+	approvalTestEnv(t)
+
+	tok, err := MintShareToken("link-1", "alice", "/users/alice/photos", []string{"read"}, time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if claims, err := ValidateToken(tok); !errors.Is(err, ErrNotSessionToken) {
+		t.Errorf("ValidateToken(share token) = %+v, %v; want ErrNotSessionToken", claims, err)
+	}
+	if _, err := ValidateTokenWithAudience(tok, ShareAudiencePrefix+"test.cluster.local"); !errors.Is(err, ErrNotSessionToken) {
+		t.Errorf("ValidateTokenWithAudience(share token) = %v; want ErrNotSessionToken", err)
+	}
+
+	approval, err := MintApprovalToken(sampleMintRequest())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := ValidateToken(approval); !errors.Is(err, ErrNotSessionToken) {
+		t.Errorf("ValidateToken(approval token) = %v; want ErrNotSessionToken", err)
+	}
+}
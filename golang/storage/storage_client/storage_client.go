@@ -528,3 +528,170 @@ func (client *Storage_Client) DeleteConnection(connectionId string) error {
 	_, err := client.c.DeleteConnection(ctx, rqst)
 	return err
 }
+
+// SetItemWithTTL saves an item that the store expires after ttl (rounded up
+// to seconds).
+func (client *Storage_Client) SetItemWithTTL(connectionId string, key string, data []byte, ttl time.Duration) error {
+	rqst := &storagepb.SetItemRequest{
+		Id:         connectionId,
+		Key:        key,
+		Value:      data,
+		TtlSeconds: int64((ttl + time.Second - 1) / time.Second),
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	_, err := client.c.SetItem(ctx, rqst)
+	return err
+}
+
+// GetCapabilities returns the optional operations the store supports.
+func (client *Storage_Client) GetCapabilities(connectionId string) (*storagepb.GetCapabilitiesResponse, error) {
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	return client.c.GetCapabilities(ctx, &storagepb.GetCapabilitiesRequest{Id: connectionId})
+}
+
+// CompareAndSwap replaces the value of key by newValue if it is oldValue; an
+// empty oldValue means the key must not exist.
+func (client *Storage_Client) CompareAndSwap(connectionId string, key string, oldValue, newValue []byte) (bool, error) {
+	rqst := &storagepb.CompareAndSwapRequest{
+		Id:       connectionId,
+		Key:      key,
+		OldValue: oldValue,
+		NewValue: newValue,
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	rsp, err := client.c.CompareAndSwap(ctx, rqst)
+	if err != nil {
+		return false, err
+	}
+	return rsp.Swapped, nil
+}
+
+// SetIfAbsent saves an item if its key does not exist yet; a ttl of 0 never
+// expires.
+func (client *Storage_Client) SetIfAbsent(connectionId string, key string, data []byte, ttl time.Duration) (bool, error) {
+	rqst := &storagepb.SetIfAbsentRequest{
+		Id:         connectionId,
+		Key:        key,
+		Value:      data,
+		TtlSeconds: int64((ttl + time.Second - 1) / time.Second),
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	rsp, err := client.c.SetIfAbsent(ctx, rqst)
+	if err != nil {
+		return false, err
+	}
+	return rsp.Set, nil
+}
+
+// Increment adds delta to the counter stored at key and returns its new value.
+func (client *Storage_Client) Increment(connectionId string, key string, delta int64) (int64, error) {
+	rqst := &storagepb.IncrementRequest{
+		Id:    connectionId,
+		Key:   key,
+		Delta: delta,
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	rsp, err := client.c.Increment(ctx, rqst)
+	if err != nil {
+		return 0, err
+	}
+	return rsp.Value, nil
+}
+
+// GetItems returns the values of several keys; missing keys are left out.
+func (client *Storage_Client) GetItems(connectionId string, keys []string) (map[string][]byte, error) {
+	rqst := &storagepb.GetItemsRequest{
+		Id:   connectionId,
+		Keys: keys,
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	rsp, err := client.c.GetItems(ctx, rqst)
+	if err != nil {
+		return nil, err
+	}
+	values := make(map[string][]byte, len(rsp.Items))
+	for _, item := range rsp.Items {
+		values[item.Key] = item.Value
+	}
+	return values, nil
+}
+
+// SetItems saves several items at once.
+func (client *Storage_Client) SetItems(connectionId string, items map[string][]byte) error {
+	rqst := &storagepb.SetItemsRequest{
+		Id:    connectionId,
+		Items: make([]*storagepb.Item, 0, len(items)),
+	}
+	for key, value := range items {
+		rqst.Items = append(rqst.Items, &storagepb.Item{Key: key, Value: value})
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	_, err := client.c.SetItems(ctx, rqst)
+	return err
+}
+
+// RemoveItems removes several items at once.
+func (client *Storage_Client) RemoveItems(connectionId string, keys []string) error {
+	rqst := &storagepb.RemoveItemsRequest{
+		Id:   connectionId,
+		Keys: keys,
+	}
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	_, err := client.c.RemoveItems(ctx, rqst)
+	return err
+}
+
+// Scan returns one page of the items selected by rqst; rqst.Id is set to
+// connectionId.
+func (client *Storage_Client) Scan(connectionId string, rqst *storagepb.ScanRequest) (*storagepb.ScanResponse, error) {
+	rqst.Id = connectionId
+
+	ctx, cancel := client.newRPCContext()
+	defer cancel()
+
+	return client.c.Scan(ctx, rqst)
+}
+
+// ScanPrefix calls fn with every item whose key starts with prefix, one page
+// at a time, until fn returns an error or the scan ends.
+func (client *Storage_Client) ScanPrefix(connectionId string, prefix string, keysOnly bool, fn func(*storagepb.Item) error) error {
+	rqst := &storagepb.ScanRequest{Prefix: prefix, KeysOnly: keysOnly}
+	for {
+		rsp, err := client.Scan(connectionId, rqst)
+		if err != nil {
+			return err
+		}
+		for _, item := range rsp.Items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if rsp.NextPageToken == "" {
+			return nil
+		}
+		rqst.PageToken = rsp.NextPageToken
+	}
+}
@@ -0,0 +1,207 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/globulario/services/golang/storage/storage_store"
+	"github.com/globulario/services/golang/storage/storagepb"
+	Utility "github.com/globulario/utility"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// openStore returns the open store of a connection, or the error the
+// handlers report when there is none.
+func (srv *server) openStore(id, op string) (storage_store.Store, error) {
+	if _, ok := srv.Connections[id]; !ok {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(),
+			errors.New(op+": no connection found with id "+id)))
+	}
+	store := srv.stores[id]
+	if store == nil {
+		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(),
+			errors.New(op+": no store found for connection id "+id)))
+	}
+	return store, nil
+}
+
+// capabilities of a store; stores that do not report them get what their
+// interfaces offer, never atomicity.
+func capabilities(store storage_store.Store) storage_store.Capabilities {
+	if r, ok := store.(storage_store.CapabilityReporter); ok {
+		return r.Capabilities()
+	}
+	_, ttl := store.(storage_store.TTLStore)
+	return storage_store.Capabilities{TTL: ttl}
+}
+
+// atomicStore returns the conditional writes of a store. A backend that
+// cannot run them atomically is refused rather than emulated with a
+// read-then-write that would race.
+func atomicStore(store storage_store.Store, op string) (storage_store.AtomicStore, error) {
+	atomic, ok := store.(storage_store.AtomicStore)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "%s: store does not support conditional writes", op)
+	}
+	if !capabilities(store).AtomicCAS {
+		return nil, status.Errorf(codes.FailedPrecondition, "%s: store cannot apply conditional writes atomically", op)
+	}
+	return atomic, nil
+}
+
+// ttlOf converts a ttl_seconds field.
+func ttlOf(seconds int64) (time.Duration, error) {
+	if seconds < 0 {
+		return 0, status.Errorf(codes.InvalidArgument, "ttl_seconds must not be negative")
+	}
+	return time.Duration(seconds) * time.Second, nil
+}
+
+// storeErr maps a store error to a status.
+func storeErr(err error) error {
+	if errors.Is(err, storage_store.ErrNotInteger) {
+		return status.Errorf(codes.InvalidArgument, "%v", err)
+	}
+	return status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
+}
+
+// GetCapabilities reports the optional operations of a store.
+func (srv *server) GetCapabilities(ctx context.Context, rqst *storagepb.GetCapabilitiesRequest) (*storagepb.GetCapabilitiesResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "getCapabilities")
+	if err != nil {
+		return nil, err
+	}
+	caps := capabilities(store)
+	return &storagepb.GetCapabilitiesResponse{Ttl: caps.TTL, AtomicCas: caps.AtomicCAS, AtomicBatch: caps.AtomicBatch}, nil
+}
+
+// CompareAndSwap replaces the value of a key if it still holds old_value.
+func (srv *server) CompareAndSwap(ctx context.Context, rqst *storagepb.CompareAndSwapRequest) (*storagepb.CompareAndSwapResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "compareAndSwap")
+	if err != nil {
+		return nil, err
+	}
+	atomic, err := atomicStore(store, "compareAndSwap")
+	if err != nil {
+		return nil, err
+	}
+	swapped, err := atomic.CompareAndSwap(rqst.GetKey(), rqst.GetOldValue(), rqst.GetNewValue())
+	if err != nil {
+		return nil, storeErr(err)
+	}
+	return &storagepb.CompareAndSwapResponse{Swapped: swapped}, nil
+}
+
+// SetIfAbsent writes an item only if its key does not exist yet.
+func (srv *server) SetIfAbsent(ctx context.Context, rqst *storagepb.SetIfAbsentRequest) (*storagepb.SetIfAbsentResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "setIfAbsent")
+	if err != nil {
+		return nil, err
+	}
+	atomic, err := atomicStore(store, "setIfAbsent")
+	if err != nil {
+		return nil, err
+	}
+	ttl, err := ttlOf(rqst.GetTtlSeconds())
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 && !capabilities(store).TTL {
+		return nil, status.Errorf(codes.Unimplemented, "setIfAbsent: store does not support ttl")
+	}
+	set, err := atomic.SetIfAbsent(rqst.GetKey(), rqst.GetValue(), ttl)
+	if err != nil {
+		return nil, storeErr(err)
+	}
+	return &storagepb.SetIfAbsentResponse{Set: set}, nil
+}
+
+// Increment adds a delta to a counter and returns its new value.
+func (srv *server) Increment(ctx context.Context, rqst *storagepb.IncrementRequest) (*storagepb.IncrementResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "increment")
+	if err != nil {
+		return nil, err
+	}
+	atomic, err := atomicStore(store, "increment")
+	if err != nil {
+		return nil, err
+	}
+	n, err := atomic.Increment(rqst.GetKey(), rqst.GetDelta())
+	if err != nil {
+		return nil, storeErr(err)
+	}
+	return &storagepb.IncrementResponse{Value: n}, nil
+}
+
+// GetItems returns the items of several keys; missing keys are left out.
+func (srv *server) GetItems(ctx context.Context, rqst *storagepb.GetItemsRequest) (*storagepb.GetItemsResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "getItems")
+	if err != nil {
+		return nil, err
+	}
+	var values map[string][]byte
+	if batch, ok := store.(storage_store.BatchStore); ok {
+		if values, err = batch.GetItems(rqst.GetKeys()); err != nil {
+			return nil, storeErr(err)
+		}
+	} else {
+		// Reads need no atomicity; fall back to one GetItem per key.
+		values = make(map[string][]byte, len(rqst.GetKeys()))
+		for _, key := range rqst.GetKeys() {
+			val, err := store.GetItem(key)
+			if err != nil {
+				return nil, storeErr(err)
+			}
+			if len(val) > 0 {
+				values[key] = val
+			}
+		}
+	}
+
+	items := make([]*storagepb.Item, 0, len(values))
+	for _, key := range rqst.GetKeys() {
+		if val, ok := values[key]; ok {
+			items = append(items, &storagepb.Item{Key: key, Value: val})
+			delete(values, key)
+		}
+	}
+	return &storagepb.GetItemsResponse{Items: items}, nil
+}
+
+// SetItems writes several items at once.
+func (srv *server) SetItems(ctx context.Context, rqst *storagepb.SetItemsRequest) (*storagepb.SetItemsResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "setItems")
+	if err != nil {
+		return nil, err
+	}
+	batch, ok := store.(storage_store.BatchStore)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "setItems: store does not support batches")
+	}
+	items := make(map[string][]byte, len(rqst.GetItems()))
+	for _, item := range rqst.GetItems() {
+		items[item.GetKey()] = item.GetValue()
+	}
+	if err := batch.SetItems(items); err != nil {
+		return nil, storeErr(err)
+	}
+	return &storagepb.SetItemsResponse{Result: true}, nil
+}
+
+// RemoveItems deletes several items at once.
+func (srv *server) RemoveItems(ctx context.Context, rqst *storagepb.RemoveItemsRequest) (*storagepb.RemoveItemsResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "removeItems")
+	if err != nil {
+		return nil, err
+	}
+	batch, ok := store.(storage_store.BatchStore)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "removeItems: store does not support batches")
+	}
+	if err := batch.RemoveItems(rqst.GetKeys()); err != nil {
+		return nil, storeErr(err)
+	}
+	return &storagepb.RemoveItemsResponse{Result: true}, nil
+}
@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/globulario/services/golang/storage/storage_store"
+	"github.com/globulario/services/golang/storage/storagepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// racyStore has conditional writes it cannot run atomically.
+type racyStore struct {
+	*storage_store.BigCache_store
+}
+
+func (racyStore) Capabilities() storage_store.Capabilities {
+	return storage_store.Capabilities{TTL: true}
+}
+
+func newAtomicTestServer(t *testing.T) *server {
+	t.Helper()
+	cache := storage_store.NewBigCache_store()
+	if err := cache.Open(`{"shards":16,"hardMaxCacheSizeMB":8}`); err != nil {
+		t.Fatal(err)
+	}
+	racy := storage_store.NewBigCache_store()
+	if err := racy.Open(`{"shards":16,"hardMaxCacheSizeMB":8}`); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		_ = cache.Close()
+		_ = racy.Close()
+	})
+	return &server{
+		Connections: map[string]connection{
+			"cache": {Id: "cache", Type: storagepb.StoreType_BIG_CACHE},
+			"racy":  {Id: "racy", Type: storagepb.StoreType_BIG_CACHE},
+		},
+		stores: map[string]storage_store.Store{"cache": cache, "racy": racyStore{racy}},
+	}
+}
+
+func TestConditionalWriteHandlers(t *testing.T) {
+	srv := newAtomicTestServer(t)
+	ctx := context.Background()
+
+	caps, err := srv.GetCapabilities(ctx, &storagepb.GetCapabilitiesRequest{Id: "cache"})
+	if err != nil || !caps.GetAtomicCas() || !caps.GetTtl() {
+		t.Fatalf("GetCapabilities = %v, %v", caps, err)
+	}
+
+	set, err := srv.SetIfAbsent(ctx, &storagepb.SetIfAbsentRequest{Id: "cache", Key: "lock", Value: []byte("a"), TtlSeconds: 30})
+	if err != nil || !set.GetSet() {
+		t.Fatalf("SetIfAbsent = %v, %v", set, err)
+	}
+	swap, err := srv.CompareAndSwap(ctx, &storagepb.CompareAndSwapRequest{Id: "cache", Key: "lock", OldValue: []byte("x"), NewValue: []byte("b")})
+	if err != nil || swap.GetSwapped() {
+		t.Errorf("CompareAndSwap with a stale value = %v, %v", swap, err)
+	}
+	if _, err := srv.Increment(ctx, &storagepb.IncrementRequest{Id: "cache", Key: "lock", Delta: 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("Increment on text: got %v, want InvalidArgument", err)
+	}
+
+	// Conditional writes are refused, not emulated, when the store cannot
+	// run them atomically.
+	if _, err := srv.CompareAndSwap(ctx, &storagepb.CompareAndSwapRequest{Id: "racy", Key: "lock", NewValue: []byte("a")}); status.Code(err) != codes.FailedPrecondition {
+		t.Errorf("CompareAndSwap on a racy store: got %v, want FailedPrecondition", err)
+	}
+	if _, err := srv.SetItem(ctx, &storagepb.SetItemRequest{Id: "cache", Key: "k", TtlSeconds: -1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("negative ttl: got %v, want InvalidArgument", err)
+	}
+}
+
+func TestBatchHandlers(t *testing.T) {
+	srv := newAtomicTestServer(t)
+	ctx := context.Background()
+
+	_, err := srv.SetItems(ctx, &storagepb.SetItemsRequest{Id: "cache", Items: []*storagepb.Item{
+		{Key: "a", Value: []byte("1")}, {Key: "b", Value: []byte("2")}, {Key: "c", Value: []byte("3")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := srv.RemoveItems(ctx, &storagepb.RemoveItemsRequest{Id: "cache", Keys: []string{"b"}}); err != nil {
+		t.Fatal(err)
+	}
+	rsp, err := srv.GetItems(ctx, &storagepb.GetItemsRequest{Id: "cache", Keys: []string{"c", "b", "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	items := rsp.GetItems()
+	if len(items) != 2 || items[0].GetKey() != "c" || items[1].GetKey() != "a" || string(items[1].GetValue()) != "1" {
+		t.Errorf("GetItems = %v, want c and a in request order", items)
+	}
+}
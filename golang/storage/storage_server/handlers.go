@@ -343,7 +343,20 @@ func (srv *server) SetItem(ctx context.Context, rqst *storagepb.SetItemRequest)
 			errors.New("setItem: no store found for connection id "+rqst.GetId())))
 	}
 
-	if err := store.SetItem(rqst.GetKey(), rqst.GetValue()); err != nil {
+	ttl, err := ttlOf(rqst.GetTtlSeconds())
+	if err != nil {
+		return nil, err
+	}
+	if ttl > 0 {
+		expiring, ok := store.(storage_store.TTLStore)
+		if !ok {
+			return nil, status.Errorf(codes.Unimplemented, "setItem: store does not support ttl")
+		}
+		err = expiring.SetItemWithTTL(rqst.GetKey(), rqst.GetValue(), ttl)
+	} else {
+		err = store.SetItem(rqst.GetKey(), rqst.GetValue())
+	}
+	if err != nil {
 		return nil, status.Errorf(codes.Internal, "%s", Utility.JsonErrorStr(Utility.FunctionName(), Utility.FileLine(), err))
 	}
 	return &storagepb.SetItemResponse{Result: true}, nil
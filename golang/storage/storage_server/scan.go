@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	"github.com/globulario/services/golang/storage/storage_store"
+	"github.com/globulario/services/golang/storage/storagepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// maxScanLimit caps the page size a client can ask for.
+const maxScanLimit = 10000
+
+// Scan returns a page of the items of a prefix or key range.
+func (srv *server) Scan(ctx context.Context, rqst *storagepb.ScanRequest) (*storagepb.ScanResponse, error) {
+	store, err := srv.openStore(rqst.GetId(), "scan")
+	if err != nil {
+		return nil, err
+	}
+	scanner, ok := store.(storage_store.Scanner)
+	if !ok {
+		return nil, status.Errorf(codes.Unimplemented, "scan: store does not support scans")
+	}
+	if rqst.GetLimit() < 0 || rqst.GetLimit() > maxScanLimit {
+		return nil, status.Errorf(codes.InvalidArgument, "scan: limit must be between 0 and %d", maxScanLimit)
+	}
+
+	page, err := scanner.Scan(storage_store.ScanOptions{
+		Prefix:    rqst.GetPrefix(),
+		Start:     rqst.GetStart(),
+		End:       rqst.GetEnd(),
+		Limit:     int(rqst.GetLimit()),
+		PageToken: rqst.GetPageToken(),
+		KeysOnly:  rqst.GetKeysOnly(),
+	})
+	if errors.Is(err, storage_store.ErrInvalidPageToken) {
+		return nil, status.Errorf(codes.InvalidArgument, "scan: %v", err)
+	}
+	if err != nil {
+		return nil, storeErr(err)
+	}
+
+	items := make([]*storagepb.Item, 0, len(page.Items))
+	for _, item := range page.Items {
+		items = append(items, &storagepb.Item{Key: item.Key, Value: item.Value})
+	}
+	return &storagepb.ScanResponse{Items: items, NextPageToken: page.NextPageToken}, nil
+}
@@ -0,0 +1,41 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"github.com/globulario/services/golang/storage/storagepb"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestScanHandler(t *testing.T) {
+	srv := newAtomicTestServer(t)
+	ctx := context.Background()
+
+	_, err := srv.SetItems(ctx, &storagepb.SetItemsRequest{Id: "cache", Items: []*storagepb.Item{
+		{Key: "session/bob/1", Value: []byte("1")}, {Key: "session/bob/2", Value: []byte("2")}, {Key: "user/bob", Value: []byte("u")},
+	}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rsp, err := srv.Scan(ctx, &storagepb.ScanRequest{Id: "cache", Prefix: "session/bob/", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rsp.GetItems()) != 1 || rsp.GetItems()[0].GetKey() != "session/bob/1" || rsp.GetNextPageToken() == "" {
+		t.Fatalf("first page = %v", rsp)
+	}
+	rsp, err = srv.Scan(ctx, &storagepb.ScanRequest{Id: "cache", Prefix: "session/bob/", Limit: 1, PageToken: rsp.GetNextPageToken()})
+	if err != nil || len(rsp.GetItems()) != 1 || rsp.GetItems()[0].GetKey() != "session/bob/2" || rsp.GetNextPageToken() != "" {
+		t.Fatalf("last page = %v, %v", rsp, err)
+	}
+
+	if _, err := srv.Scan(ctx, &storagepb.ScanRequest{Id: "cache", PageToken: "%%%"}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("bad page token: got %v, want InvalidArgument", err)
+	}
+	if _, err := srv.Scan(ctx, &storagepb.ScanRequest{Id: "cache", Limit: maxScanLimit + 1}); status.Code(err) != codes.InvalidArgument {
+		t.Errorf("oversized limit: got %v, want InvalidArgument", err)
+	}
+}
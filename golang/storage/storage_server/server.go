@@ -90,6 +90,8 @@ func printUsage() {
 	fmt.Println("  • Key-value operations (Set, Get, Remove)")
 	fmt.Println("  • Bulk operations (Clear, Drop)")
 	fmt.Println("  • Large item support for big values")
+	fmt.Println("  • TTL, compare-and-swap, counters and multi-key operations")
+	fmt.Println("  • Paginated prefix and range scans")
 	fmt.Println("  • RBAC permissions (admin, read, write)")
 	fmt.Println()
 	fmt.Println("EXAMPLES:")
@@ -148,6 +150,14 @@ func main() {
 		{Method: "/storage.StorageService/RemoveItem", Action: "storage.removeitem"},
 		{Method: "/storage.StorageService/Clear", Action: "storage.clear"},
 		{Method: "/storage.StorageService/Drop", Action: "storage.drop"},
+		{Method: "/storage.StorageService/GetCapabilities", Action: "storage.getcapabilities"},
+		{Method: "/storage.StorageService/CompareAndSwap", Action: "storage.compareandswap"},
+		{Method: "/storage.StorageService/SetIfAbsent", Action: "storage.setifabsent"},
+		{Method: "/storage.StorageService/Increment", Action: "storage.increment"},
+		{Method: "/storage.StorageService/GetItems", Action: "storage.getitems"},
+		{Method: "/storage.StorageService/SetItems", Action: "storage.setitems"},
+		{Method: "/storage.StorageService/RemoveItems", Action: "storage.removeitems"},
+		{Method: "/storage.StorageService/Scan", Action: "storage.scan"},
 	})
 
 	// Enable debug logging if requested
@@ -1,6 +1,7 @@
 package storage_store
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -128,6 +129,25 @@ func (store *Badger_store) setItem(key string, val []byte) error {
 	})
 }
 
+// setItemWithTTL writes a value that badger expires after ttl.
+func (store *Badger_store) setItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	if store.db == nil {
+		return errors.New("badger: setItemWithTTL: db is not open")
+	}
+	return store.db.Update(func(txn *badger.Txn) error {
+		return txn.SetEntry(badgerEntry(key, val, ttl))
+	})
+}
+
+// badgerEntry builds the entry of a write, with a ttl when ttl > 0.
+func badgerEntry(key string, val []byte, ttl time.Duration) *badger.Entry {
+	e := badger.NewEntry([]byte(key), val)
+	if ttl > 0 {
+		e = e.WithTTL(ttl)
+	}
+	return e
+}
+
 // getItem reads the value for a key. Returns (nil, nil) if the key does not exist.
 func (store *Badger_store) getItem(key string) ([]byte, error) {
 	if store.db == nil {
@@ -229,4 +249,190 @@ func (store *Badger_store) runValueLogGCOnce() error {
     }
     // Badger recommends iterating until it returns ErrNoRewrite.
     return store.db.RunValueLogGC(0.5) // 50% reclaim threshold
-}
\ No newline at end of file
+}
+
+// compareAndSwap replaces the value of key by newVal if it is oldVal, in a
+// single transaction.
+func (store *Badger_store) compareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	if store.db == nil {
+		return false, errors.New("badger: compareAndSwap: db is not open")
+	}
+	swapped := false
+	err := store.db.Update(func(txn *badger.Txn) error {
+		item, err := txn.Get([]byte(key))
+		switch {
+		case errors.Is(err, badger.ErrKeyNotFound):
+			if len(oldVal) > 0 {
+				return nil
+			}
+		case err != nil:
+			return err
+		default:
+			if len(oldVal) == 0 {
+				return nil
+			}
+			cur, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			if !bytes.Equal(cur, oldVal) {
+				return nil
+			}
+		}
+		swapped = true
+		return txn.Set([]byte(key), newVal)
+	})
+	if err != nil {
+		return false, err
+	}
+	return swapped, nil
+}
+
+// setIfAbsent writes key only if it does not exist yet.
+func (store *Badger_store) setIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	if store.db == nil {
+		return false, errors.New("badger: setIfAbsent: db is not open")
+	}
+	set := false
+	err := store.db.Update(func(txn *badger.Txn) error {
+		_, err := txn.Get([]byte(key))
+		if err == nil {
+			return nil
+		}
+		if !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+		set = true
+		return txn.SetEntry(badgerEntry(key, val, ttl))
+	})
+	if err != nil {
+		return false, err
+	}
+	return set, nil
+}
+
+// increment adds delta to the counter stored at key.
+func (store *Badger_store) increment(key string, delta int64) (int64, error) {
+	if store.db == nil {
+		return 0, errors.New("badger: increment: db is not open")
+	}
+	var n int64
+	err := store.db.Update(func(txn *badger.Txn) error {
+		var cur []byte
+		item, err := txn.Get([]byte(key))
+		if err == nil {
+			if cur, err = item.ValueCopy(nil); err != nil {
+				return err
+			}
+		} else if !errors.Is(err, badger.ErrKeyNotFound) {
+			return err
+		}
+		val, next, err := incrementValue(cur, delta)
+		if err != nil {
+			return err
+		}
+		n = next
+		return txn.Set([]byte(key), val)
+	})
+	return n, err
+}
+
+// getItems reads several keys from one snapshot.
+func (store *Badger_store) getItems(keys []string) (map[string][]byte, error) {
+	if store.db == nil {
+		return nil, errors.New("badger: getItems: db is not open")
+	}
+	out := make(map[string][]byte, len(keys))
+	err := store.db.View(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			item, err := txn.Get([]byte(key))
+			if errors.Is(err, badger.ErrKeyNotFound) {
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			val, err := item.ValueCopy(nil)
+			if err != nil {
+				return err
+			}
+			out[key] = val
+		}
+		return nil
+	})
+	return out, err
+}
+
+// setItems writes several keys in one transaction.
+func (store *Badger_store) setItems(items map[string][]byte) error {
+	if store.db == nil {
+		return errors.New("badger: setItems: db is not open")
+	}
+	return store.db.Update(func(txn *badger.Txn) error {
+		for key, val := range items {
+			if err := txn.Set([]byte(key), val); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// removeItems deletes several keys in one transaction.
+func (store *Badger_store) removeItems(keys []string) error {
+	if store.db == nil {
+		return errors.New("badger: removeItems: db is not open")
+	}
+	return store.db.Update(func(txn *badger.Txn) error {
+		for _, key := range keys {
+			if err := txn.Delete([]byte(key)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// scan walks the keys of opts in order with a badger iterator.
+func (store *Badger_store) scan(opts ScanOptions) (*ScanPage, error) {
+	if store.db == nil {
+		return nil, errors.New("badger: scan: db is not open")
+	}
+	lo, hi, err := opts.bounds()
+	if err != nil {
+		return nil, err
+	}
+	page := &ScanPage{Items: make([]ScanItem, 0)}
+	err = store.db.View(func(txn *badger.Txn) error {
+		iopts := badger.DefaultIteratorOptions
+		iopts.PrefetchValues = !opts.KeysOnly
+		iopts.Prefix = []byte(opts.Prefix)
+		it := txn.NewIterator(iopts)
+		defer it.Close()
+
+		for it.Seek([]byte(lo)); it.Valid(); it.Next() {
+			key := string(it.Item().Key())
+			if hi != "" && key >= hi {
+				break
+			}
+			if len(page.Items) == opts.limit() {
+				page.NextPageToken = encodeKeyToken(page.Items[len(page.Items)-1].Key)
+				break
+			}
+			item := ScanItem{Key: key}
+			if !opts.KeysOnly {
+				val, err := it.Item().ValueCopy(nil)
+				if err != nil {
+					return err
+				}
+				item.Value = val
+			}
+			page.Items = append(page.Items, item)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return page, nil
+}
@@ -1,6 +1,7 @@
 package storage_store
 
 import (
+	"bytes"
 	"encoding/json"
 	"errors"
 	"io"
@@ -22,6 +23,10 @@ type BigCache_store struct {
 	actions chan map[string]interface{}
 	mu      sync.Mutex
 	isOpen  bool
+
+	// per-key deadlines; bigcache itself only evicts after its LifeWindow,
+	// which stays the upper bound of every TTL.
+	expiries map[string]time.Time
 }
 
 // open initializes bigcache. Accepts either a raw JSON options string or "" for defaults.
@@ -129,3 +134,151 @@ func getBool(m map[string]interface{}, k string) (bool, bool) {
 	}
 	return false, false
 }
+
+// live returns the value of key and whether it exists, evicting it first if
+// its deadline passed.
+func (store *BigCache_store) live(key string) ([]byte, bool, error) {
+	if store.cache == nil {
+		return nil, false, errors.New("bigcache: store is closed")
+	}
+	if deadline, ok := store.expiries[key]; ok && !time.Now().Before(deadline) {
+		delete(store.expiries, key)
+		if err := store.cache.Delete(key); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+			return nil, false, err
+		}
+		return nil, false, nil
+	}
+	val, err := store.cache.Get(key)
+	if errors.Is(err, bigcache.ErrEntryNotFound) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+// set writes key->val with a deadline when ttl > 0.
+func (store *BigCache_store) set(key string, val []byte, ttl time.Duration) error {
+	if store.cache == nil {
+		return errors.New("bigcache: store is closed")
+	}
+	if err := store.cache.Set(key, val); err != nil {
+		return err
+	}
+	if ttl > 0 {
+		if store.expiries == nil {
+			store.expiries = make(map[string]time.Time)
+		}
+		store.expiries[key] = time.Now().Add(ttl)
+	} else {
+		delete(store.expiries, key)
+	}
+	return nil
+}
+
+// remove deletes key; a missing key is not an error.
+func (store *BigCache_store) remove(key string) error {
+	if store.cache == nil {
+		return errors.New("bigcache: store is closed")
+	}
+	delete(store.expiries, key)
+	if err := store.cache.Delete(key); err != nil && !errors.Is(err, bigcache.ErrEntryNotFound) {
+		return err
+	}
+	return nil
+}
+
+func (store *BigCache_store) setItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	return store.set(key, val, ttl)
+}
+
+// compareAndSwap replaces the value of key by newVal if it is oldVal; the run
+// loop serializes it with every other operation on the cache.
+func (store *BigCache_store) compareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	cur, exists, err := store.live(key)
+	if err != nil {
+		return false, err
+	}
+	if len(oldVal) == 0 {
+		if exists {
+			return false, nil
+		}
+	} else if !exists || !bytes.Equal(cur, oldVal) {
+		return false, nil
+	}
+	return true, store.set(key, newVal, 0)
+}
+
+func (store *BigCache_store) setIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	_, exists, err := store.live(key)
+	if err != nil || exists {
+		return false, err
+	}
+	return true, store.set(key, val, ttl)
+}
+
+func (store *BigCache_store) increment(key string, delta int64) (int64, error) {
+	cur, _, err := store.live(key)
+	if err != nil {
+		return 0, err
+	}
+	val, n, err := incrementValue(cur, delta)
+	if err != nil {
+		return 0, err
+	}
+	// Keep the deadline of the counter, if any.
+	if err := store.cache.Set(key, val); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+func (store *BigCache_store) getItems(keys []string) (map[string][]byte, error) {
+	out := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		val, exists, err := store.live(key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			out[key] = val
+		}
+	}
+	return out, nil
+}
+
+func (store *BigCache_store) setItems(items map[string][]byte) error {
+	for key, val := range items {
+		if err := store.set(key, val, 0); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (store *BigCache_store) removeItems(keys []string) error {
+	for _, key := range keys {
+		if err := store.remove(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// scan sorts the live keys of the cache and cuts the page of opts; bigcache
+// has no ordered iterator, so every page walks the whole cache.
+func (store *BigCache_store) scan(opts ScanOptions) (*ScanPage, error) {
+	if store.cache == nil {
+		return nil, errors.New("bigcache: scan on closed store")
+	}
+	keys := make([]string, 0)
+	iterator := store.cache.Iterator()
+	for iterator.SetNext() {
+		entry, err := iterator.Value()
+		if err == nil {
+			keys = append(keys, entry.Key())
+		}
+	}
+	return pageOf(keys, opts, store.live)
+}
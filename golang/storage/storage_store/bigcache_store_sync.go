@@ -2,8 +2,7 @@ package storage_store
 
 import (
 	"errors"
-
-	"github.com/allegro/bigcache/v3"
+	"time"
 )
 
 // run serializes operations; exits cleanly on "Close".
@@ -19,7 +18,7 @@ func (store *BigCache_store) run() {
 				action["result"].(chan error) <- errors.New("bigcache: setItem on closed store")
 				continue
 			}
-			action["result"].(chan error) <- store.cache.Set(action["key"].(string), action["val"].([]byte))
+			action["result"].(chan error) <- store.set(action["key"].(string), action["val"].([]byte), 0)
 
 		case "GetItem":
 			if store.cache == nil {
@@ -28,10 +27,7 @@ func (store *BigCache_store) run() {
 				}
 				continue
 			}
-			val, err := store.cache.Get(action["key"].(string))
-			if errors.Is(err, bigcache.ErrEntryNotFound) {
-				val, err = nil, nil
-			}
+			val, _, err := store.live(action["key"].(string))
 			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"val": val, "err": err}
 
 		case "RemoveItem":
@@ -39,13 +35,14 @@ func (store *BigCache_store) run() {
 				action["result"].(chan error) <- errors.New("bigcache: removeItem on closed store")
 				continue
 			}
-			action["result"].(chan error) <- store.cache.Delete(action["key"].(string))
+			action["result"].(chan error) <- store.remove(action["key"].(string))
 
 		case "Clear":
 			if store.cache == nil {
 				action["result"].(chan error) <- errors.New("bigcache: clear on closed store")
 				continue
 			}
+			store.expiries = nil
 			action["result"].(chan error) <- store.cache.Reset()
 
 		case "Drop":
@@ -53,6 +50,7 @@ func (store *BigCache_store) run() {
 				action["result"].(chan error) <- nil
 				continue
 			}
+			store.expiries = nil
 			action["result"].(chan error) <- store.cache.Reset()
 
 		case "Close":
@@ -60,6 +58,10 @@ func (store *BigCache_store) run() {
 			action["result"].(chan error) <- store.close()
 			return
 
+		case "Scan":
+			page, err := store.scan(action["options"].(ScanOptions))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"page": page, "err": err}
+
 		case "GetAllKeys":
 			if store.cache == nil {
 				action["results"].(chan map[string]interface{}) <- map[string]interface{}{
@@ -71,12 +73,45 @@ func (store *BigCache_store) run() {
 			var keys []string
 			for iterator.SetNext() {
 				entry, err := iterator.Value()
-				if err == nil {
-					keys = append(keys, entry.Key())
+				if err != nil {
+					continue
+				}
+				if deadline, ok := store.expiries[entry.Key()]; ok && !time.Now().Before(deadline) {
+					continue
 				}
+				keys = append(keys, entry.Key())
 			}
 			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"keys": keys, "err": nil}
 
+		case "SetItemWithTTL":
+			val, _ := action["val"].([]byte)
+			action["result"].(chan error) <- store.setItemWithTTL(action["key"].(string), val, action["ttl"].(time.Duration))
+
+		case "CompareAndSwap":
+			oldVal, _ := action["old"].([]byte)
+			newVal, _ := action["new"].([]byte)
+			ok, err := store.compareAndSwap(action["key"].(string), oldVal, newVal)
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"ok": ok, "err": err}
+
+		case "SetIfAbsent":
+			val, _ := action["val"].([]byte)
+			ok, err := store.setIfAbsent(action["key"].(string), val, action["ttl"].(time.Duration))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"ok": ok, "err": err}
+
+		case "Increment":
+			n, err := store.increment(action["key"].(string), action["delta"].(int64))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"n": n, "err": err}
+
+		case "GetItems":
+			vals, err := store.getItems(action["keys"].([]string))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"vals": vals, "err": err}
+
+		case "SetItems":
+			action["result"].(chan error) <- store.setItems(action["items"].(map[string][]byte))
+
+		case "RemoveItems":
+			action["result"].(chan error) <- store.removeItems(action["keys"].([]string))
+
 		default:
 			// Unknown action
 			bcLogger.Error("BigCache_store.run: unknown action", "action", action["name"])
@@ -151,6 +186,7 @@ func (st *BigCache_store) Drop() error {
         st.cache.Reset() // clear all entries
         st.cache = nil
     }
+    st.expiries = nil
     st.isOpen = false
     return nil
 }
@@ -165,3 +201,86 @@ func (store *BigCache_store) GetAllKeys() ([]string, error) {
 	}
 	return results["keys"].([]string), nil
 }
+
+// Capabilities reports TTL and atomic conditional writes and
+// batches: the cache lives in this process and the run loop serializes every
+// operation. A TTL longer than the configured LifeWindow is cut short.
+func (store *BigCache_store) Capabilities() Capabilities {
+	return Capabilities{TTL: true, AtomicCAS: true, AtomicBatch: true}
+}
+
+// SetItemWithTTL writes key->val that expires after ttl.
+func (store *BigCache_store) SetItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	action := map[string]interface{}{"name": "SetItemWithTTL", "result": make(chan error), "key": key, "val": val, "ttl": ttl}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// CompareAndSwap replaces the value of key by newVal if it is oldVal.
+func (store *BigCache_store) CompareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	action := map[string]interface{}{"name": "CompareAndSwap", "results": make(chan map[string]interface{}), "key": key, "old": oldVal, "new": newVal}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return false, results["err"].(error)
+	}
+	return results["ok"].(bool), nil
+}
+
+// SetIfAbsent writes key->val if key does not exist yet.
+func (store *BigCache_store) SetIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	action := map[string]interface{}{"name": "SetIfAbsent", "results": make(chan map[string]interface{}), "key": key, "val": val, "ttl": ttl}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return false, results["err"].(error)
+	}
+	return results["ok"].(bool), nil
+}
+
+// Increment adds delta to the counter stored at key.
+func (store *BigCache_store) Increment(key string, delta int64) (int64, error) {
+	action := map[string]interface{}{"name": "Increment", "results": make(chan map[string]interface{}), "key": key, "delta": delta}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return 0, results["err"].(error)
+	}
+	return results["n"].(int64), nil
+}
+
+// GetItems returns the values of several keys.
+func (store *BigCache_store) GetItems(keys []string) (map[string][]byte, error) {
+	action := map[string]interface{}{"name": "GetItems", "results": make(chan map[string]interface{}), "keys": keys}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return nil, results["err"].(error)
+	}
+	return results["vals"].(map[string][]byte), nil
+}
+
+// SetItems writes several keys at once.
+func (store *BigCache_store) SetItems(items map[string][]byte) error {
+	action := map[string]interface{}{"name": "SetItems", "result": make(chan error), "items": items}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// RemoveItems deletes several keys at once.
+func (store *BigCache_store) RemoveItems(keys []string) error {
+	action := map[string]interface{}{"name": "RemoveItems", "result": make(chan error), "keys": keys}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// Scan returns a page of the items selected by opts, in key order.
+func (store *BigCache_store) Scan(opts ScanOptions) (*ScanPage, error) {
+	action := map[string]interface{}{"name": "Scan", "results": make(chan map[string]interface{}), "options": opts}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return nil, results["err"].(error)
+	}
+	return results["page"].(*ScanPage), nil
+}
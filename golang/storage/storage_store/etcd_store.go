@@ -113,4 +113,229 @@ func (s *Etcd_store) getAllKeys() ([]string, error) {
 	}
 
 	return keys, nil
-}
\ No newline at end of file
+}
+
+// etcdIncrementRetries bounds the optimistic retries of increment.
+const etcdIncrementRetries = 16
+
+// grant returns the lease option of a write that expires after ttl.
+// etcd leases count in whole seconds.
+func (s *Etcd_store) grant(ctx context.Context, ttl time.Duration) ([]clientv3.OpOption, clientv3.LeaseID, error) {
+	if ttl <= 0 {
+		return nil, 0, nil
+	}
+	lease, err := s.client.Grant(ctx, ttlSeconds(ttl))
+	if err != nil {
+		return nil, 0, err
+	}
+	return []clientv3.OpOption{clientv3.WithLease(lease.ID)}, lease.ID, nil
+}
+
+func (s *Etcd_store) setItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	if s.client == nil {
+		return errors.New("etcd: setItemWithTTL on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts, _, err := s.grant(ctx, ttl)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Put(ctx, key, string(val), opts...)
+	return err
+}
+
+// compareAndSwap replaces the value of key by newVal if it is oldVal, in a
+// single etcd transaction.
+func (s *Etcd_store) compareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	if s.client == nil {
+		return false, errors.New("etcd: compareAndSwap on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	cmp := clientv3.Compare(clientv3.Value(key), "=", string(oldVal))
+	if len(oldVal) == 0 {
+		cmp = clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+	}
+	rsp, err := s.client.Txn(ctx).If(cmp).Then(clientv3.OpPut(key, string(newVal))).Commit()
+	if err != nil {
+		return false, err
+	}
+	return rsp.Succeeded, nil
+}
+
+func (s *Etcd_store) setIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	if s.client == nil {
+		return false, errors.New("etcd: setIfAbsent on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	opts, lease, err := s.grant(ctx, ttl)
+	if err != nil {
+		return false, err
+	}
+	rsp, err := s.client.Txn(ctx).
+		If(clientv3.Compare(clientv3.CreateRevision(key), "=", 0)).
+		Then(clientv3.OpPut(key, string(val), opts...)).
+		Commit()
+	if err == nil && rsp.Succeeded {
+		return true, nil
+	}
+	if lease != 0 {
+		_, _ = s.client.Revoke(ctx, lease)
+	}
+	if err != nil {
+		return false, err
+	}
+	return false, nil
+}
+
+// increment reads the counter and writes it back only if no other writer
+// changed it in between, retrying on contention.
+func (s *Etcd_store) increment(key string, delta int64) (int64, error) {
+	if s.client == nil {
+		return 0, errors.New("etcd: increment on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	for i := 0; i < etcdIncrementRetries; i++ {
+		rsp, err := s.client.Get(ctx, key)
+		if err != nil {
+			return 0, err
+		}
+		var cur []byte
+		cmp := clientv3.Compare(clientv3.CreateRevision(key), "=", 0)
+		var opts []clientv3.OpOption
+		if len(rsp.Kvs) > 0 {
+			cur = rsp.Kvs[0].Value
+			cmp = clientv3.Compare(clientv3.ModRevision(key), "=", rsp.Kvs[0].ModRevision)
+			opts = append(opts, clientv3.WithIgnoreLease())
+		}
+		val, n, err := incrementValue(cur, delta)
+		if err != nil {
+			return 0, err
+		}
+		txn, err := s.client.Txn(ctx).If(cmp).Then(clientv3.OpPut(key, string(val), opts...)).Commit()
+		if err != nil {
+			return 0, err
+		}
+		if txn.Succeeded {
+			return n, nil
+		}
+	}
+	return 0, errors.New("etcd: increment: too many concurrent writers on key " + key)
+}
+
+// getItems reads several keys from one revision.
+func (s *Etcd_store) getItems(keys []string) (map[string][]byte, error) {
+	if s.client == nil {
+		return nil, errors.New("etcd: getItems on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ops := make([]clientv3.Op, 0, len(keys))
+	for _, key := range keys {
+		ops = append(ops, clientv3.OpGet(key))
+	}
+	rsp, err := s.client.Txn(ctx).Then(ops...).Commit()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(keys))
+	for _, r := range rsp.Responses {
+		for _, kv := range r.GetResponseRange().GetKvs() {
+			out[string(kv.Key)] = kv.Value
+		}
+	}
+	return out, nil
+}
+
+// setItems writes several keys in one transaction; etcd limits the number of
+// operations of a transaction (--max-txn-ops, 128 by default).
+func (s *Etcd_store) setItems(items map[string][]byte) error {
+	if s.client == nil {
+		return errors.New("etcd: setItems on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	ops := make([]clientv3.Op, 0, len(items))
+	for key, val := range items {
+		ops = append(ops, clientv3.OpPut(key, string(val)))
+	}
+	_, err := s.client.Txn(ctx).Then(ops...).Commit()
+	return err
+}
+
+// removeItems deletes several keys in one transaction.
+func (s *Etcd_store) removeItems(keys []string) error {
+	if s.client == nil {
+		return errors.New("etcd: removeItems on nil client")
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	seen := make(map[string]bool, len(keys))
+	ops := make([]clientv3.Op, 0, len(keys))
+	for _, key := range keys {
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		ops = append(ops, clientv3.OpDelete(key))
+	}
+	_, err := s.client.Txn(ctx).Then(ops...).Commit()
+	return err
+}
+
+// scan reads one page of the keys of opts with a sorted, limited range get.
+func (s *Etcd_store) scan(opts ScanOptions) (*ScanPage, error) {
+	if s.client == nil {
+		return nil, errors.New("etcd: scan on nil client")
+	}
+	lo, hi, err := opts.bounds()
+	if err != nil {
+		return nil, err
+	}
+	if lo == "" {
+		lo = "\x00"
+	}
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+
+	getOpts := []clientv3.OpOption{
+		clientv3.WithLimit(int64(opts.limit()) + 1),
+		clientv3.WithSort(clientv3.SortByKey, clientv3.SortAscend),
+	}
+	if hi != "" {
+		getOpts = append(getOpts, clientv3.WithRange(hi))
+	} else {
+		getOpts = append(getOpts, clientv3.WithFromKey())
+	}
+	if opts.KeysOnly {
+		getOpts = append(getOpts, clientv3.WithKeysOnly())
+	}
+	rsp, err := s.client.Get(ctx, lo, getOpts...)
+	if err != nil {
+		return nil, err
+	}
+
+	page := &ScanPage{Items: make([]ScanItem, 0, len(rsp.Kvs))}
+	for _, kv := range rsp.Kvs {
+		if len(page.Items) == opts.limit() {
+			page.NextPageToken = encodeKeyToken(page.Items[len(page.Items)-1].Key)
+			break
+		}
+		item := ScanItem{Key: string(kv.Key)}
+		if !opts.KeysOnly {
+			item.Value = kv.Value
+		}
+		page.Items = append(page.Items, item)
+	}
+	return page, nil
+}
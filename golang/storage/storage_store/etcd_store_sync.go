@@ -1,6 +1,9 @@
 package storage_store
 
-import "errors"
+import (
+	"errors"
+	"time"
+)
 
 // NewEtcd_store creates the store and starts its run loop.
 func NewEtcd_store() *Etcd_store {
@@ -41,10 +44,43 @@ func (store *Etcd_store) run() {
 			action["result"].(chan error) <- store.close()
 			return // stop loop cleanly
 
+		case "Scan":
+			page, err := store.scan(action["options"].(ScanOptions))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"page": page, "err": err}
+
 		case "GetAllKeys":
 			// Not supported by etcd KV
 			keys, err := store.getAllKeys()
 			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"keys": keys, "err": err}
+		case "SetItemWithTTL":
+			val, _ := action["val"].([]byte)
+			action["result"].(chan error) <- store.setItemWithTTL(action["key"].(string), val, action["ttl"].(time.Duration))
+
+		case "CompareAndSwap":
+			oldVal, _ := action["old"].([]byte)
+			newVal, _ := action["new"].([]byte)
+			ok, err := store.compareAndSwap(action["key"].(string), oldVal, newVal)
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"ok": ok, "err": err}
+
+		case "SetIfAbsent":
+			val, _ := action["val"].([]byte)
+			ok, err := store.setIfAbsent(action["key"].(string), val, action["ttl"].(time.Duration))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"ok": ok, "err": err}
+
+		case "Increment":
+			n, err := store.increment(action["key"].(string), action["delta"].(int64))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"n": n, "err": err}
+
+		case "GetItems":
+			vals, err := store.getItems(action["keys"].([]string))
+			action["results"].(chan map[string]interface{}) <- map[string]interface{}{"vals": vals, "err": err}
+
+		case "SetItems":
+			action["result"].(chan error) <- store.setItems(action["items"].(map[string][]byte))
+
+		case "RemoveItems":
+			action["result"].(chan error) <- store.removeItems(action["keys"].([]string))
+
 		default:
 			// Unknown action
 			bcLogger.Error("Etcd_store.run: unknown action", "action", action["name"])
@@ -112,4 +148,86 @@ func (store *Etcd_store) GetAllKeys() ([]string, error) {
 		return nil, results["err"].(error)
 	}
 	return results["keys"].([]string), nil
-}
\ No newline at end of file
+}
+
+// Capabilities reports TTL through leases and atomic conditional
+// writes and batches through etcd transactions.
+func (store *Etcd_store) Capabilities() Capabilities {
+	return Capabilities{TTL: true, AtomicCAS: true, AtomicBatch: true}
+}
+
+// SetItemWithTTL writes key->val that expires after ttl.
+func (store *Etcd_store) SetItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	action := map[string]interface{}{"name": "SetItemWithTTL", "result": make(chan error), "key": key, "val": val, "ttl": ttl}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// CompareAndSwap replaces the value of key by newVal if it is oldVal.
+func (store *Etcd_store) CompareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	action := map[string]interface{}{"name": "CompareAndSwap", "results": make(chan map[string]interface{}), "key": key, "old": oldVal, "new": newVal}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return false, results["err"].(error)
+	}
+	return results["ok"].(bool), nil
+}
+
+// SetIfAbsent writes key->val if key does not exist yet.
+func (store *Etcd_store) SetIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	action := map[string]interface{}{"name": "SetIfAbsent", "results": make(chan map[string]interface{}), "key": key, "val": val, "ttl": ttl}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return false, results["err"].(error)
+	}
+	return results["ok"].(bool), nil
+}
+
+// Increment adds delta to the counter stored at key.
+func (store *Etcd_store) Increment(key string, delta int64) (int64, error) {
+	action := map[string]interface{}{"name": "Increment", "results": make(chan map[string]interface{}), "key": key, "delta": delta}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return 0, results["err"].(error)
+	}
+	return results["n"].(int64), nil
+}
+
+// GetItems returns the values of several keys.
+func (store *Etcd_store) GetItems(keys []string) (map[string][]byte, error) {
+	action := map[string]interface{}{"name": "GetItems", "results": make(chan map[string]interface{}), "keys": keys}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return nil, results["err"].(error)
+	}
+	return results["vals"].(map[string][]byte), nil
+}
+
+// SetItems writes several keys at once.
+func (store *Etcd_store) SetItems(items map[string][]byte) error {
+	action := map[string]interface{}{"name": "SetItems", "result": make(chan error), "items": items}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// RemoveItems deletes several keys at once.
+func (store *Etcd_store) RemoveItems(keys []string) error {
+	action := map[string]interface{}{"name": "RemoveItems", "result": make(chan error), "keys": keys}
+	store.actions <- action
+	return <-action["result"].(chan error)
+}
+
+// Scan returns a page of the items selected by opts, in key order.
+func (store *Etcd_store) Scan(opts ScanOptions) (*ScanPage, error) {
+	action := map[string]interface{}{"name": "Scan", "results": make(chan map[string]interface{}), "options": opts}
+	store.actions <- action
+	results := <-action["results"].(chan map[string]interface{})
+	if results["err"] != nil {
+		return nil, results["err"].(error)
+	}
+	return results["page"].(*ScanPage), nil
+}
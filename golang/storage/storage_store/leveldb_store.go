@@ -1,6 +1,8 @@
 package storage_store
 
 import (
+	"bytes"
+	"encoding/binary"
 	"encoding/json"
 	"errors"
 	"io"
@@ -9,6 +11,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/syndtr/goleveldb/leveldb"
 	"github.com/syndtr/goleveldb/leveldb/util"
@@ -24,6 +27,11 @@ func SetLevelDBLogger(l *slog.Logger) {
 	}
 }
 
+// leveldbTTLPrefix is the reserved key space of the expiry index: leveldb
+// has no TTL, so the deadline of a key is kept under prefix+key and checked
+// when the key is read.
+const leveldbTTLPrefix = "\x00ttl\x00"
+
 type LevelDB_store struct {
 	path    string
 	db      *leveldb.DB
@@ -110,7 +118,10 @@ func (store *LevelDB_store) setItem(key string, val []byte) error {
 	if err != nil {
 		return err
 	}
-	return db.Put([]byte(key), val, nil)
+	batch := new(leveldb.Batch)
+	batch.Put([]byte(key), val)
+	batch.Delete(leveldbTTLKey(key))
+	return db.Write(batch, nil)
 }
 
 // getItem returns value for exact key; if key ends with "*" it returns a JSON
@@ -131,8 +142,15 @@ func (store *LevelDB_store) getItem(key string) ([]byte, error) {
 		iter := db.NewIterator(util.BytesPrefix(prefix), nil)
 		defer iter.Release()
 
+		now := time.Now()
 		values := make([]string, 0, 16)
 		for iter.First(); iter.Valid(); iter.Next() {
+			if isLeveldbTTLKey(iter.Key()) {
+				continue
+			}
+			if dead, err := leveldbExpired(db, string(iter.Key()), now); err != nil || dead {
+				continue
+			}
 			values = append(values, string(iter.Value()))
 		}
 		// Return JSON array of stringified values (preserving prior behavior)
@@ -140,6 +158,9 @@ func (store *LevelDB_store) getItem(key string) ([]byte, error) {
 		return out, nil
 	}
 
+	if dead, err := store.expire(db, key); err != nil || dead {
+		return nil, err
+	}
 	val, err := db.Get([]byte(key), nil)
 	if err == leveldb.ErrNotFound {
 		return nil, nil
@@ -164,8 +185,12 @@ func (store *LevelDB_store) removeItem(key string) error {
 
 		batch := new(leveldb.Batch)
 		for iter.First(); iter.Valid(); iter.Next() {
+			if isLeveldbTTLKey(iter.Key()) {
+				continue
+			}
 			k := append([]byte(nil), iter.Key()...) // copy key
 			batch.Delete(k)
+			batch.Delete(leveldbTTLKey(string(k)))
 		}
 		if batch.Len() > 0 {
 			return db.Write(batch, nil)
@@ -173,7 +198,10 @@ func (store *LevelDB_store) removeItem(key string) error {
 		return nil
 	}
 
-	return db.Delete([]byte(key), nil)
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(key))
+	batch.Delete(leveldbTTLKey(key))
+	return db.Write(batch, nil)
 }
 
 // clear erases all data by deleting the DB directory and recreating it.
@@ -221,14 +249,294 @@ func (store *LevelDB_store) getAllKeys() ([]string, error) {
 	if err != nil {
 		return nil, err
 	}
-	defer db.Close()
+	if err := store.sweepExpired(db); err != nil {
+		return nil, err
+	}
 
 	iter := db.NewIterator(nil, nil)
 	defer iter.Release()
 
 	keys := make([]string, 0, 16)
 	for iter.First(); iter.Valid(); iter.Next() {
+		if isLeveldbTTLKey(iter.Key()) {
+			continue
+		}
 		keys = append(keys, string(iter.Key()))
 	}
 	return keys, nil
 }
+
+// leveldbTTLKey returns the expiry index key of key.
+func leveldbTTLKey(key string) []byte {
+	return []byte(leveldbTTLPrefix + key)
+}
+
+func isLeveldbTTLKey(key []byte) bool {
+	return bytes.HasPrefix(key, []byte(leveldbTTLPrefix))
+}
+
+// leveldbExpired tells if key has a deadline before now.
+func leveldbExpired(db *leveldb.DB, key string, now time.Time) (bool, error) {
+	raw, err := db.Get(leveldbTTLKey(key), nil)
+	if err == leveldb.ErrNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if len(raw) != 8 {
+		return false, nil
+	}
+	return now.UnixNano() >= int64(binary.BigEndian.Uint64(raw)), nil
+}
+
+// expire deletes key if its deadline passed and reports whether it did.
+// Callers must hold store.mu.
+func (store *LevelDB_store) expire(db *leveldb.DB, key string) (bool, error) {
+	dead, err := leveldbExpired(db, key, time.Now())
+	if err != nil || !dead {
+		return false, err
+	}
+	batch := new(leveldb.Batch)
+	batch.Delete([]byte(key))
+	batch.Delete(leveldbTTLKey(key))
+	return true, db.Write(batch, nil)
+}
+
+// sweepExpired deletes every key whose deadline passed. Callers must hold
+// store.mu.
+func (store *LevelDB_store) sweepExpired(db *leveldb.DB) error {
+	iter := db.NewIterator(util.BytesPrefix([]byte(leveldbTTLPrefix)), nil)
+	defer iter.Release()
+
+	now := time.Now().UnixNano()
+	batch := new(leveldb.Batch)
+	for iter.First(); iter.Valid(); iter.Next() {
+		if len(iter.Value()) == 8 && now >= int64(binary.BigEndian.Uint64(iter.Value())) {
+			batch.Delete(append([]byte(nil), iter.Key()...))
+			batch.Delete([]byte(strings.TrimPrefix(string(iter.Key()), leveldbTTLPrefix)))
+		}
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if batch.Len() == 0 {
+		return nil
+	}
+	return db.Write(batch, nil)
+}
+
+// leveldbPut adds key to batch with its deadline, or clears it when ttl <= 0.
+func leveldbPut(batch *leveldb.Batch, key string, val []byte, ttl time.Duration) {
+	batch.Put([]byte(key), val)
+	if ttl <= 0 {
+		batch.Delete(leveldbTTLKey(key))
+		return
+	}
+	deadline := make([]byte, 8)
+	binary.BigEndian.PutUint64(deadline, uint64(time.Now().Add(ttl).UnixNano()))
+	batch.Put(leveldbTTLKey(key), deadline)
+}
+
+// setItemWithTTL writes key->val with a deadline in the expiry index.
+func (store *LevelDB_store) setItemWithTTL(key string, val []byte, ttl time.Duration) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	leveldbPut(batch, key, val, ttl)
+	return db.Write(batch, nil)
+}
+
+// current returns the live value of key and whether it exists. Callers must
+// hold store.mu.
+func (store *LevelDB_store) current(db *leveldb.DB, key string) ([]byte, bool, error) {
+	if dead, err := store.expire(db, key); err != nil || dead {
+		return nil, false, err
+	}
+	val, err := db.Get([]byte(key), nil)
+	if err == leveldb.ErrNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return val, true, nil
+}
+
+// compareAndSwap replaces the value of key by newVal if it is oldVal. The
+// read and the write run under store.mu, and leveldb locks its directory to
+// one process, so no other writer can interleave.
+func (store *LevelDB_store) compareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return false, err
+	}
+	cur, exists, err := store.current(db, key)
+	if err != nil {
+		return false, err
+	}
+	if len(oldVal) == 0 {
+		if exists {
+			return false, nil
+		}
+	} else if !exists || !bytes.Equal(cur, oldVal) {
+		return false, nil
+	}
+	batch := new(leveldb.Batch)
+	leveldbPut(batch, key, newVal, 0)
+	return true, db.Write(batch, nil)
+}
+
+// setIfAbsent writes key only if it does not exist yet.
+func (store *LevelDB_store) setIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return false, err
+	}
+	_, exists, err := store.current(db, key)
+	if err != nil || exists {
+		return false, err
+	}
+	batch := new(leveldb.Batch)
+	leveldbPut(batch, key, val, ttl)
+	return true, db.Write(batch, nil)
+}
+
+// increment adds delta to the counter stored at key.
+func (store *LevelDB_store) increment(key string, delta int64) (int64, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return 0, err
+	}
+	cur, _, err := store.current(db, key)
+	if err != nil {
+		return 0, err
+	}
+	val, n, err := incrementValue(cur, delta)
+	if err != nil {
+		return 0, err
+	}
+	// Keep the deadline of the counter, if any.
+	if err := db.Put([]byte(key), val, nil); err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// getItems reads several keys.
+func (store *LevelDB_store) getItems(keys []string) (map[string][]byte, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return nil, err
+	}
+	out := make(map[string][]byte, len(keys))
+	for _, key := range keys {
+		val, exists, err := store.current(db, key)
+		if err != nil {
+			return nil, err
+		}
+		if exists {
+			out[key] = val
+		}
+	}
+	return out, nil
+}
+
+// setItems writes several keys in one atomic batch.
+func (store *LevelDB_store) setItems(items map[string][]byte) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	for key, val := range items {
+		leveldbPut(batch, key, val, 0)
+	}
+	return db.Write(batch, nil)
+}
+
+// removeItems deletes several keys in one atomic batch.
+func (store *LevelDB_store) removeItems(keys []string) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return err
+	}
+	batch := new(leveldb.Batch)
+	for _, key := range keys {
+		batch.Delete([]byte(key))
+		batch.Delete(leveldbTTLKey(key))
+	}
+	return db.Write(batch, nil)
+}
+
+// scan walks the keys of opts in order with a leveldb iterator, leaving out
+// the expiry index and expired keys.
+func (store *LevelDB_store) scan(opts ScanOptions) (*ScanPage, error) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+
+	db, err := store.getDb()
+	if err != nil {
+		return nil, err
+	}
+	lo, hi, err := opts.bounds()
+	if err != nil {
+		return nil, err
+	}
+	rng := &util.Range{Start: []byte(lo)}
+	if hi != "" {
+		rng.Limit = []byte(hi)
+	}
+	iter := db.NewIterator(rng, nil)
+	defer iter.Release()
+
+	now := time.Now()
+	page := &ScanPage{Items: make([]ScanItem, 0)}
+	for iter.Next() {
+		if isLeveldbTTLKey(iter.Key()) {
+			continue
+		}
+		key := string(iter.Key())
+		if dead, err := leveldbExpired(db, key, now); err != nil {
+			return nil, err
+		} else if dead {
+			continue
+		}
+		if len(page.Items) == opts.limit() {
+			page.NextPageToken = encodeKeyToken(page.Items[len(page.Items)-1].Key)
+			break
+		}
+		item := ScanItem{Key: key}
+		if !opts.KeysOnly {
+			item.Value = append([]byte(nil), iter.Value()...)
+		}
+		page.Items = append(page.Items, item)
+	}
+	if err := iter.Error(); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
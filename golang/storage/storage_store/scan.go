@@ -0,0 +1,147 @@
+package storage_store
+
+import (
+	"encoding/base64"
+	"errors"
+	"sort"
+	"strings"
+)
+
+// DefaultScanLimit is the page size of a Scan without a limit.
+const DefaultScanLimit = 1000
+
+// ErrInvalidPageToken is returned by Scan for a page token it did not issue.
+var ErrInvalidPageToken = errors.New("invalid page token")
+
+// ScanOptions select the items of a Scan. Prefix and the [Start, End) range
+// combine; an empty End has no upper bound.
+type ScanOptions struct {
+	Prefix    string
+	Start     string
+	End       string
+	Limit     int
+	PageToken string
+	KeysOnly  bool
+}
+
+// ScanItem is a key and, unless the scan is keys only, its value.
+type ScanItem struct {
+	Key   string
+	Value []byte
+}
+
+// ScanPage is one page of a Scan. NextPageToken is empty on the last page.
+type ScanPage struct {
+	Items         []ScanItem
+	NextPageToken string
+}
+
+/**
+ * A store that can list its keys by prefix or range, page by page.
+ */
+type Scanner interface {
+	// Scan returns the next page of the items selected by opts.
+	Scan(opts ScanOptions) (*ScanPage, error)
+}
+
+// limit returns the page size of the options.
+func (opts ScanOptions) limit() int {
+	if opts.Limit <= 0 {
+		return DefaultScanLimit
+	}
+	return opts.Limit
+}
+
+// bounds returns the key range [lo, hi) of an ordered scan, starting after
+// the key of the page token. An empty hi has no upper bound.
+func (opts ScanOptions) bounds() (lo, hi string, err error) {
+	lo = opts.Start
+	if opts.Prefix > lo {
+		lo = opts.Prefix
+	}
+	hi = opts.End
+	if end := prefixEnd(opts.Prefix); end != "" && (hi == "" || end < hi) {
+		hi = end
+	}
+	if opts.PageToken != "" {
+		last, err := decodeKeyToken(opts.PageToken)
+		if err != nil {
+			return "", "", err
+		}
+		if after := last + "\x00"; after > lo {
+			lo = after
+		}
+	}
+	return lo, hi, nil
+}
+
+// match tells if key belongs to the scan, ignoring the page token.
+func (opts ScanOptions) match(key string) bool {
+	if !strings.HasPrefix(key, opts.Prefix) || key < opts.Start {
+		return false
+	}
+	return opts.End == "" || key < opts.End
+}
+
+// prefixEnd returns the smallest key greater than every key with prefix, or
+// "" when there is none.
+func prefixEnd(prefix string) string {
+	end := []byte(prefix)
+	for i := len(end) - 1; i >= 0; i-- {
+		if end[i] < 0xff {
+			end[i]++
+			return string(end[:i+1])
+		}
+	}
+	return ""
+}
+
+// encodeKeyToken returns the page token of an ordered scan that stopped at
+// key.
+func encodeKeyToken(key string) string {
+	return base64.RawURLEncoding.EncodeToString([]byte(key))
+}
+
+func decodeKeyToken(token string) (string, error) {
+	b, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", ErrInvalidPageToken
+	}
+	return string(b), nil
+}
+
+// pageOf sorts the matching keys of an unordered store and cuts the page
+// the options ask for. value is only called for the keys of the page.
+func pageOf(keys []string, opts ScanOptions, value func(key string) ([]byte, bool, error)) (*ScanPage, error) {
+	lo, hi, err := opts.bounds()
+	if err != nil {
+		return nil, err
+	}
+	selected := make([]string, 0, len(keys))
+	for _, key := range keys {
+		if key >= lo && (hi == "" || key < hi) && opts.match(key) {
+			selected = append(selected, key)
+		}
+	}
+	sort.Strings(selected)
+
+	page := &ScanPage{Items: make([]ScanItem, 0, opts.limit())}
+	for _, key := range selected {
+		if len(page.Items) == opts.limit() {
+			page.NextPageToken = encodeKeyToken(page.Items[len(page.Items)-1].Key)
+			break
+		}
+		val, ok, err := value(key)
+		if err != nil {
+			return nil, err
+		}
+		if !ok {
+			continue
+		}
+		if opts.KeysOnly {
+			val = nil
+		}
+		page.Items = append(page.Items, ScanItem{Key: key, Value: val})
+	}
+	return page, nil
+}
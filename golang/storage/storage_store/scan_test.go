@@ -0,0 +1,89 @@
+package storage_store
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestPrefixEnd(t *testing.T) {
+	cases := map[string]string{
+		"":             "",
+		"session/":     "session0",
+		"a\xff":        "b",
+		"\xff\xff":     "",
+		"session/bob/": "session/bob0",
+	}
+	for prefix, want := range cases {
+		if got := prefixEnd(prefix); got != want {
+			t.Errorf("prefixEnd(%q) = %q, want %q", prefix, got, want)
+		}
+	}
+}
+
+// scanAll pages through a scan and returns the keys in order.
+func scanAll(t *testing.T, scanner Scanner, opts ScanOptions) ([]string, int) {
+	t.Helper()
+	keys := make([]string, 0)
+	pages := 0
+	for {
+		page, err := scanner.Scan(opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		pages++
+		for _, item := range page.Items {
+			keys = append(keys, item.Key)
+			if opts.KeysOnly && item.Value != nil {
+				t.Errorf("keys only scan returned the value of %s", item.Key)
+			}
+			if !opts.KeysOnly && string(item.Value) != "v:"+item.Key {
+				t.Errorf("value of %s = %q", item.Key, item.Value)
+			}
+		}
+		if page.NextPageToken == "" {
+			return keys, pages
+		}
+		opts.PageToken = page.NextPageToken
+	}
+}
+
+func TestStoreScan(t *testing.T) {
+	for name, store := range localStores(t) {
+		for _, user := range []string{"alice", "bob", "carol"} {
+			for i := 0; i < 5; i++ {
+				key := fmt.Sprintf("session/%s/%02d", user, i)
+				if err := store.SetItem(key, []byte("v:"+key)); err != nil {
+					t.Fatal(err)
+				}
+			}
+		}
+		if err := store.(TTLStore).SetItemWithTTL("session/bob/99", []byte("gone"), time.Millisecond); err != nil {
+			t.Fatal(err)
+		}
+		time.Sleep(1100 * time.Millisecond)
+		scanner := store.(Scanner)
+
+		keys, pages := scanAll(t, scanner, ScanOptions{Prefix: "session/bob/", Limit: 2})
+		if len(keys) != 5 || keys[0] != "session/bob/00" || keys[4] != "session/bob/04" {
+			t.Errorf("%s: prefix scan = %q", name, keys)
+		}
+		if pages != 3 {
+			t.Errorf("%s: prefix scan took %d pages, want 3", name, pages)
+		}
+
+		keys, _ = scanAll(t, scanner, ScanOptions{Start: "session/alice/03", End: "session/bob/01", Limit: 10, KeysOnly: true})
+		if fmt.Sprint(keys) != "[session/alice/03 session/alice/04 session/bob/00]" {
+			t.Errorf("%s: range scan = %q", name, keys)
+		}
+
+		if keys, _ := scanAll(t, scanner, ScanOptions{}); len(keys) != 15 {
+			t.Errorf("%s: full scan returned %d keys, want 15", name, len(keys))
+		}
+
+		if _, err := scanner.Scan(ScanOptions{PageToken: "%%%"}); !errors.Is(err, ErrInvalidPageToken) {
+			t.Errorf("%s: bad page token: got %v, want ErrInvalidPageToken", name, err)
+		}
+	}
+}
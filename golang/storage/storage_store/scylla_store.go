@@ -1,6 +1,7 @@
 package storage_store
 
 import (
+	"bytes"
 	"context"
 	"crypto/tls"
 	"crypto/x509"
@@ -11,6 +12,7 @@ import (
 	"log/slog"
 	"net"
 	"os"
+	"strconv"
 	"strings"
 	"time"
 
@@ -453,6 +455,205 @@ func (s *ScyllaStore) removeItem(key string) error {
 	return s.session.Query(cql, key).Consistency(s.queryConsistency()).Exec()
 }
 
+// scyllaIncrementRetries bounds the lightweight-transaction retries of
+// increment.
+const scyllaIncrementRetries = 16
+
+// compareAndSwap replaces the value of key by newVal if it is oldVal with a
+// lightweight transaction. LWTs are linearizable among themselves only: a
+// plain setItem racing with them can still be lost.
+func (s *ScyllaStore) compareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	if s.session == nil {
+		return false, errors.New("scylla not open")
+	}
+	if len(oldVal) == 0 {
+		return s.insertIfNotExists(key, newVal, 0)
+	}
+	cql := fmt.Sprintf(`UPDATE "%s"."%s" SET v = ?, updated_at = toTimestamp(now()) WHERE k = ? IF v = ?`, s.keyspace, s.table)
+	return s.session.Query(cql, newVal, key, oldVal).Consistency(s.queryConsistency()).MapScanCAS(map[string]interface{}{})
+}
+
+// setIfAbsent writes key only if it does not exist yet.
+func (s *ScyllaStore) setIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	if s.session == nil {
+		return false, errors.New("scylla not open")
+	}
+	return s.insertIfNotExists(key, val, ttlSeconds(ttl))
+}
+
+func (s *ScyllaStore) insertIfNotExists(key string, val []byte, ttl int64) (bool, error) {
+	cql := fmt.Sprintf(`INSERT INTO "%s"."%s" (k, v, updated_at) VALUES (?, ?, toTimestamp(now())) IF NOT EXISTS`, s.keyspace, s.table)
+	args := []interface{}{key, val}
+	if ttl > 0 {
+		cql += " USING TTL ?"
+		args = append(args, ttl)
+	}
+	return s.session.Query(cql, args...).Consistency(s.queryConsistency()).MapScanCAS(map[string]interface{}{})
+}
+
+// increment reads the counter and its remaining TTL, then writes it back
+// with a lightweight transaction conditioned on the value read.
+func (s *ScyllaStore) increment(key string, delta int64) (int64, error) {
+	if s.session == nil {
+		return 0, errors.New("scylla not open")
+	}
+	sel := fmt.Sprintf(`SELECT v, TTL(v) FROM "%s"."%s" WHERE k = ?`, s.keyspace, s.table)
+	for i := 0; i < scyllaIncrementRetries; i++ {
+		var cur []byte
+		var ttl int
+		exists := true
+		if err := s.session.Query(sel, key).Consistency(s.queryConsistency()).Scan(&cur, &ttl); err != nil {
+			if err != gocql.ErrNotFound {
+				return 0, err
+			}
+			exists = false
+		}
+		val, n, err := incrementValue(cur, delta)
+		if err != nil {
+			return 0, err
+		}
+		var applied bool
+		if !exists {
+			applied, err = s.insertIfNotExists(key, val, 0)
+		} else {
+			cql := fmt.Sprintf(`UPDATE "%s"."%s" USING TTL ? SET v = ?, updated_at = toTimestamp(now()) WHERE k = ? IF v = ?`, s.keyspace, s.table)
+			applied, err = s.session.Query(cql, ttl, val, key, cur).Consistency(s.queryConsistency()).MapScanCAS(map[string]interface{}{})
+		}
+		if err != nil {
+			return 0, err
+		}
+		if applied {
+			return n, nil
+		}
+	}
+	return 0, fmt.Errorf("scylla: increment: too many concurrent writers on key %s", key)
+}
+
+// getItems reads several keys with one IN query.
+func (s *ScyllaStore) getItems(keys []string) (map[string][]byte, error) {
+	if s.session == nil {
+		return nil, errors.New("scylla not open")
+	}
+	out := make(map[string][]byte, len(keys))
+	if len(keys) == 0 {
+		return out, nil
+	}
+	cql := fmt.Sprintf(`SELECT k, v FROM "%s"."%s" WHERE k IN ?`, s.keyspace, s.table)
+	iter := s.session.Query(cql, keys).Consistency(s.queryConsistency()).Iter()
+	var k string
+	var v []byte
+	for iter.Scan(&k, &v) {
+		out[k] = bytes.Clone(v)
+	}
+	if err := iter.Close(); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// setItems writes several keys in a logged batch: every write is eventually
+// applied, but readers can observe the batch half applied.
+func (s *ScyllaStore) setItems(items map[string][]byte) error {
+	if s.session == nil {
+		return errors.New("scylla not open")
+	}
+	cql := fmt.Sprintf(`INSERT INTO "%s"."%s" (k, v, updated_at) VALUES (?, ?, toTimestamp(now()))`, s.keyspace, s.table)
+	batch := s.session.NewBatch(gocql.LoggedBatch)
+	batch.SetConsistency(s.queryConsistency())
+	for key, val := range items {
+		batch.Query(cql, key, val)
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	return s.session.ExecuteBatch(batch)
+}
+
+// removeItems deletes several keys in a logged batch.
+func (s *ScyllaStore) removeItems(keys []string) error {
+	if s.session == nil {
+		return errors.New("scylla not open")
+	}
+	cql := fmt.Sprintf(`DELETE FROM "%s"."%s" WHERE k = ?`, s.keyspace, s.table)
+	batch := s.session.NewBatch(gocql.LoggedBatch)
+	batch.SetConsistency(s.queryConsistency())
+	for _, key := range keys {
+		batch.Query(cql, key)
+	}
+	if batch.Size() == 0 {
+		return nil
+	}
+	return s.session.ExecuteBatch(batch)
+}
+
+// scyllaScanBudget bounds the rows a scan page reads, as a multiple of its
+// limit, so a sparse prefix returns a short page with a token instead of
+// walking the whole ring in one call.
+const scyllaScanBudget = 10
+
+// scan walks the token ring from the token of the page token. Keys are
+// partition keys, so prefixes and ranges are filtered here and pages come in
+// token order, not key order.
+func (s *ScyllaStore) scan(opts ScanOptions) (*ScanPage, error) {
+	if s.session == nil {
+		return nil, errors.New("scylla not open")
+	}
+	var cursor int64
+	started := opts.PageToken != ""
+	if started {
+		raw, err := decodeKeyToken(opts.PageToken)
+		if err != nil {
+			return nil, err
+		}
+		if cursor, err = strconv.ParseInt(raw, 10, 64); err != nil {
+			return nil, ErrInvalidPageToken
+		}
+	}
+
+	cols := "k, token(k), v"
+	if opts.KeysOnly {
+		cols = "k, token(k)"
+	}
+	limit := opts.limit()
+	page := &ScanPage{Items: make([]ScanItem, 0)}
+	for scanned := 0; scanned < scyllaScanBudget*limit; {
+		var q *gocql.Query
+		if started {
+			q = s.session.Query(fmt.Sprintf(`SELECT %s FROM "%s"."%s" WHERE token(k) > ? LIMIT ?`, cols, s.keyspace, s.table), cursor, limit)
+		} else {
+			q = s.session.Query(fmt.Sprintf(`SELECT %s FROM "%s"."%s" LIMIT ?`, cols, s.keyspace, s.table), limit)
+		}
+		iter := q.Consistency(s.queryConsistency()).Iter()
+		rows := 0
+		var k string
+		var tok int64
+		var v []byte
+		dest := []interface{}{&k, &tok}
+		if !opts.KeysOnly {
+			dest = append(dest, &v)
+		}
+		for len(page.Items) < limit && iter.Scan(dest...) {
+			rows++
+			cursor, started = tok, true
+			if opts.match(k) {
+				page.Items = append(page.Items, ScanItem{Key: k, Value: bytes.Clone(v)})
+			}
+		}
+		if err := iter.Close(); err != nil {
+			return nil, fmt.Errorf("scan: %w", err)
+		}
+		scanned += rows
+		if rows < limit && len(page.Items) < limit {
+			return page, nil // end of the ring
+		}
+		if len(page.Items) == limit {
+			break
+		}
+	}
+	page.NextPageToken = encodeKeyToken(strconv.FormatInt(cursor, 10))
+	return page, nil
+}
+
 func (s *ScyllaStore) queryConsistency() gocql.Consistency {
 	if s.consistency == 0 {
 		return gocql.Quorum
@@ -531,11 +732,28 @@ func (s *ScyllaStore) Run(ctx context.Context) {
 				val := act.args[1].([]byte)
 				var ttl int
 				if len(act.args) > 2 {
-					if v, ok := act.args[2].(int); ok {
-						ttl = v
+					if v, ok := act.args[2].(time.Duration); ok {
+						ttl = int(ttlSeconds(v))
 					}
 				}
 				err = s.setItem(key, val, ttl)
+			case "compareandswap":
+				oldVal, _ := act.args[1].([]byte)
+				newVal, _ := act.args[2].([]byte)
+				res, err = s.compareAndSwap(act.args[0].(string), oldVal, newVal)
+			case "setifabsent":
+				val, _ := act.args[1].([]byte)
+				res, err = s.setIfAbsent(act.args[0].(string), val, act.args[2].(time.Duration))
+			case "increment":
+				res, err = s.increment(act.args[0].(string), act.args[1].(int64))
+			case "getitems":
+				res, err = s.getItems(act.args[0].([]string))
+			case "setitems":
+				err = s.setItems(act.args[0].(map[string][]byte))
+			case "removeitems":
+				err = s.removeItems(act.args[0].([]string))
+			case "scan":
+				res, err = s.scan(act.args[0].(ScanOptions))
 			case "getitem":
 				key := act.args[0].(string)
 				res, err = s.getItem(key)
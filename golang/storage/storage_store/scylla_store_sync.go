@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"strings"
+	"time"
 )
 
 // NewScylla_store constructs a ScyllaStore and starts its serialized action loop.
@@ -58,10 +59,10 @@ func (store *ScyllaStore) SetItem(key string, val []byte) error {
 	return <-errCh
 }
 
-// SetItemWithTTL stores a value with a TTL in seconds.
-func (store *ScyllaStore) SetItemWithTTL(key string, val []byte, ttlSeconds int) error {
+// SetItemWithTTL stores a value that expires after ttl, rounded up to seconds.
+func (store *ScyllaStore) SetItemWithTTL(key string, val []byte, ttl time.Duration) error {
 	errCh := make(chan error, 1)
-	store.actions <- action{name: "setitem", args: []any{key, val, ttlSeconds}, errCh: errCh}
+	store.actions <- action{name: "setitem", args: []any{key, val, ttl}, errCh: errCh}
 	return <-errCh
 }
 
@@ -121,3 +122,83 @@ func (store *ScyllaStore) GetAllKeys() ([]string, error) {
 	}
 	return nil, nil
 }
+
+// Capabilities reports TTL and atomic conditional writes through lightweight
+// transactions. Batches are logged, not isolated.
+func (store *ScyllaStore) Capabilities() Capabilities {
+	return Capabilities{TTL: true, AtomicCAS: true, AtomicBatch: false}
+}
+
+// CompareAndSwap replaces the value of key by newVal if it is oldVal.
+func (store *ScyllaStore) CompareAndSwap(key string, oldVal, newVal []byte) (bool, error) {
+	resCh := make(chan any, 1)
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "compareandswap", args: []any{key, oldVal, newVal}, resCh: resCh, errCh: errCh}
+	if err := <-errCh; err != nil {
+		return false, err
+	}
+	ok, _ := (<-resCh).(bool)
+	return ok, nil
+}
+
+// SetIfAbsent stores a value if key does not exist yet.
+func (store *ScyllaStore) SetIfAbsent(key string, val []byte, ttl time.Duration) (bool, error) {
+	resCh := make(chan any, 1)
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "setifabsent", args: []any{key, val, ttl}, resCh: resCh, errCh: errCh}
+	if err := <-errCh; err != nil {
+		return false, err
+	}
+	ok, _ := (<-resCh).(bool)
+	return ok, nil
+}
+
+// Increment adds delta to the counter stored at key.
+func (store *ScyllaStore) Increment(key string, delta int64) (int64, error) {
+	resCh := make(chan any, 1)
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "increment", args: []any{key, delta}, resCh: resCh, errCh: errCh}
+	if err := <-errCh; err != nil {
+		return 0, err
+	}
+	n, _ := (<-resCh).(int64)
+	return n, nil
+}
+
+// GetItems loads the values of several keys.
+func (store *ScyllaStore) GetItems(keys []string) (map[string][]byte, error) {
+	resCh := make(chan any, 1)
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "getitems", args: []any{keys}, resCh: resCh, errCh: errCh}
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	vals, _ := (<-resCh).(map[string][]byte)
+	return vals, nil
+}
+
+// SetItems stores several values at once.
+func (store *ScyllaStore) SetItems(items map[string][]byte) error {
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "setitems", args: []any{items}, errCh: errCh}
+	return <-errCh
+}
+
+// RemoveItems deletes several keys at once.
+func (store *ScyllaStore) RemoveItems(keys []string) error {
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "removeitems", args: []any{keys}, errCh: errCh}
+	return <-errCh
+}
+
+// Scan returns a page of the items selected by opts, in token order.
+func (store *ScyllaStore) Scan(opts ScanOptions) (*ScanPage, error) {
+	resCh := make(chan any, 1)
+	errCh := make(chan error, 1)
+	store.actions <- action{name: "scan", args: []any{opts}, resCh: resCh, errCh: errCh}
+	if err := <-errCh; err != nil {
+		return nil, err
+	}
+	page, _ := (<-resCh).(*ScanPage)
+	return page, nil
+}
@@ -1,6 +1,12 @@
 package storage_store
 
-import "log/slog"
+import (
+	"errors"
+	"log/slog"
+	"strconv"
+	"strings"
+	"time"
+)
 
 // SetLogger allows the host service to inject its slog logger.
 func SetLogger(l *slog.Logger) {
@@ -37,3 +43,92 @@ type Store interface {
 	// Get all keys in the store.
 	GetAllKeys() ([]string, error)
 }
+
+// ErrNotInteger is returned by Increment when the current value of the key
+// is not a decimal integer.
+var ErrNotInteger = errors.New("value is not an integer")
+
+// Capabilities reports which optional operations a store implements and how.
+type Capabilities struct {
+	// TTL is true when SetItemWithTTL and SetIfAbsent expire keys.
+	TTL bool
+
+	// AtomicCAS is true when CompareAndSwap, SetIfAbsent and Increment are
+	// atomic against every writer of the store. Callers must not emulate
+	// them with GetItem/SetItem when it is false.
+	AtomicCAS bool
+
+	// AtomicBatch is true when SetItems and RemoveItems apply all keys or
+	// none, and readers never observe a batch half applied.
+	AtomicBatch bool
+}
+
+/**
+ * A store that can report its optional capabilities.
+ */
+type CapabilityReporter interface {
+	// Capabilities of the store.
+	Capabilities() Capabilities
+}
+
+/**
+ * A store whose keys can expire.
+ */
+type TTLStore interface {
+	// Set item that expires after ttl; a ttl <= 0 never expires.
+	SetItemWithTTL(key string, val []byte, ttl time.Duration) error
+}
+
+/**
+ * A store with conditional writes.
+ */
+type AtomicStore interface {
+	// Replace the value of key by new if it is old. An empty old value
+	// means the key must not exist. Returns false when the value differs.
+	CompareAndSwap(key string, old, new []byte) (bool, error)
+
+	// Set item if key does not exist yet; a ttl <= 0 never expires.
+	SetIfAbsent(key string, val []byte, ttl time.Duration) (bool, error)
+
+	// Add delta to the decimal integer stored at key (0 when missing) and
+	// return the new value.
+	Increment(key string, delta int64) (int64, error)
+}
+
+/**
+ * A store with multi-key operations.
+ */
+type BatchStore interface {
+	// Get the items of keys; missing keys are left out of the result.
+	GetItems(keys []string) (map[string][]byte, error)
+
+	// Set several items.
+	SetItems(items map[string][]byte) error
+
+	// Remove several items.
+	RemoveItems(keys []string) error
+}
+
+// incrementValue parses the current value of a counter, adds delta and
+// returns the value to store along with the new count.
+func incrementValue(cur []byte, delta int64) ([]byte, int64, error) {
+	var n int64
+	if len(cur) > 0 {
+		v, err := strconv.ParseInt(strings.TrimSpace(string(cur)), 10, 64)
+		if err != nil {
+			return nil, 0, ErrNotInteger
+		}
+		n = v
+	}
+	n += delta
+	return []byte(strconv.FormatInt(n, 10)), n, nil
+}
+
+// ttlSeconds rounds a ttl up to whole seconds for backends that count in
+// seconds; 0 means no expiry.
+func ttlSeconds(ttl time.Duration) int64 {
+	if ttl <= 0 {
+		return 0
+	}
+	return int64((ttl + time.Second - 1) / time.Second)
+}
@@ -0,0 +1,138 @@
+package storage_store
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+)
+
+// localStores opens the backends that need no external service.
+func localStores(t *testing.T) map[string]Store {
+	t.Helper()
+	options := func(name string) string {
+		b, _ := json.Marshal(map[string]string{"path": t.TempDir(), "name": name})
+		return string(b)
+	}
+	stores := map[string]Store{
+		"badger":   NewBadger_store(),
+		"leveldb":  NewLevelDB_store(),
+		"bigcache": NewBigCache_store(),
+	}
+	for name, store := range stores {
+		opts := options(name)
+		if name == "bigcache" {
+			opts = `{"shards":16,"hardMaxCacheSizeMB":8}`
+		}
+		if err := store.Open(opts); err != nil {
+			t.Fatalf("%s: open: %v", name, err)
+		}
+	}
+	t.Cleanup(func() {
+		for _, store := range stores {
+			_ = store.Close()
+		}
+	})
+	return stores
+}
+
+func TestStoreCapabilities(t *testing.T) {
+	for name, store := range localStores(t) {
+		caps := store.(CapabilityReporter).Capabilities()
+		if !caps.TTL || !caps.AtomicCAS || !caps.AtomicBatch {
+			t.Errorf("%s: capabilities = %+v, want all of them", name, caps)
+		}
+	}
+	if (&ScyllaStore{}).Capabilities().AtomicBatch {
+		t.Error("scylla batches must not be reported as atomic")
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	stores := localStores(t)
+	for name, store := range stores {
+		if err := store.(TTLStore).SetItemWithTTL("session", []byte("s1"), 1500*time.Millisecond); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := store.SetItem("user", []byte("u1")); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if val, err := store.GetItem("session"); err != nil || string(val) != "s1" {
+			t.Fatalf("%s: GetItem(session) = %q, %v before expiry", name, val, err)
+		}
+	}
+
+	time.Sleep(2 * time.Second)
+	for name, store := range stores {
+		if val, err := store.GetItem("session"); err != nil || len(val) != 0 {
+			t.Errorf("%s: GetItem(session) = %q, %v after expiry", name, val, err)
+		}
+		if val, err := store.GetItem("user"); err != nil || string(val) != "u1" {
+			t.Errorf("%s: GetItem(user) = %q, %v; keys without ttl must stay", name, val, err)
+		}
+		keys, err := store.GetAllKeys()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(keys) != 1 || keys[0] != "user" {
+			t.Errorf("%s: GetAllKeys = %q, want [user]", name, keys)
+		}
+	}
+}
+
+func TestStoreConditionalWrites(t *testing.T) {
+	for name, store := range localStores(t) {
+		atomic := store.(AtomicStore)
+
+		if ok, err := atomic.SetIfAbsent("lock", []byte("a"), time.Minute); err != nil || !ok {
+			t.Fatalf("%s: first SetIfAbsent = %v, %v", name, ok, err)
+		}
+		if ok, err := atomic.SetIfAbsent("lock", []byte("b"), time.Minute); err != nil || ok {
+			t.Errorf("%s: second SetIfAbsent = %v, %v; want false", name, ok, err)
+		}
+
+		if ok, err := atomic.CompareAndSwap("lock", []byte("b"), []byte("c")); err != nil || ok {
+			t.Errorf("%s: CompareAndSwap with a stale value = %v, %v; want false", name, ok, err)
+		}
+		if ok, err := atomic.CompareAndSwap("lock", []byte("a"), []byte("c")); err != nil || !ok {
+			t.Errorf("%s: CompareAndSwap = %v, %v; want true", name, ok, err)
+		}
+		if val, _ := store.GetItem("lock"); string(val) != "c" {
+			t.Errorf("%s: lock = %q after swap, want c", name, val)
+		}
+		if ok, err := atomic.CompareAndSwap("missing", nil, []byte("x")); err != nil || !ok {
+			t.Errorf("%s: CompareAndSwap on a missing key = %v, %v; want true", name, ok, err)
+		}
+		if ok, err := atomic.CompareAndSwap("missing", nil, []byte("y")); err != nil || ok {
+			t.Errorf("%s: CompareAndSwap expecting no key = %v, %v; want false", name, ok, err)
+		}
+
+		for _, step := range []struct{ delta, want int64 }{{5, 5}, {-2, 3}} {
+			if n, err := atomic.Increment("hits", step.delta); err != nil || n != step.want {
+				t.Errorf("%s: Increment(%d) = %d, %v; want %d", name, step.delta, n, err, step.want)
+			}
+		}
+		if _, err := atomic.Increment("lock", 1); !errors.Is(err, ErrNotInteger) {
+			t.Errorf("%s: Increment on text = %v, want ErrNotInteger", name, err)
+		}
+	}
+}
+
+func TestStoreBatch(t *testing.T) {
+	for name, store := range localStores(t) {
+		batch := store.(BatchStore)
+		if err := batch.SetItems(map[string][]byte{"a": []byte("1"), "b": []byte("2"), "c": []byte("3")}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if err := batch.RemoveItems([]string{"b", "nope"}); err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		vals, err := batch.GetItems([]string{"a", "b", "c", "nope"})
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if len(vals) != 2 || string(vals["a"]) != "1" || string(vals["c"]) != "3" {
+			t.Errorf("%s: GetItems = %q, want a and c", name, vals)
+		}
+	}
+}
@@ -506,9 +506,10 @@ func (x *DeleteConnectionRsp) GetResult() bool {
 // Request to save an item in the key-value store.
 type SetItemRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // Connection identifier.
-	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`     // Key for the item.
-	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"` // Data to store.
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // Connection identifier.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                                  // Key for the item.
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`                              // Data to store.
+	TtlSeconds    int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Expire the item after that many seconds; 0 keeps it.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -564,6 +565,13 @@ func (x *SetItemRequest) GetValue() []byte {
 	return nil
 }
 
+func (x *SetItemRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
 type SetItemResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // Result of the set item operation.
@@ -1173,27 +1181,28 @@ func (x *GetAllKeysResponse) GetKeys() []string {
 	return nil
 }
 
-// Request to stop the storage service.
-type StopRequest struct {
+// Request the optional operations a store supports.
+type GetCapabilitiesRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"` // Connection identifier.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopRequest) Reset() {
-	*x = StopRequest{}
+func (x *GetCapabilitiesRequest) Reset() {
+	*x = GetCapabilitiesRequest{}
 	mi := &file_storage_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopRequest) String() string {
+func (x *GetCapabilitiesRequest) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopRequest) ProtoMessage() {}
+func (*GetCapabilitiesRequest) ProtoMessage() {}
 
-func (x *StopRequest) ProtoReflect() protoreflect.Message {
+func (x *GetCapabilitiesRequest) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1205,31 +1214,41 @@ func (x *StopRequest) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
-func (*StopRequest) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCapabilitiesRequest.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesRequest) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{23}
 }
 
-type StopResponse struct {
+func (x *GetCapabilitiesRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+type GetCapabilitiesResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
+	Ttl           bool                   `protobuf:"varint,1,opt,name=ttl,proto3" json:"ttl,omitempty"`                                    // Items can expire (SetItem ttl_seconds, SetIfAbsent).
+	AtomicCas     bool                   `protobuf:"varint,2,opt,name=atomic_cas,json=atomicCas,proto3" json:"atomic_cas,omitempty"`       // CompareAndSwap, SetIfAbsent and Increment are atomic.
+	AtomicBatch   bool                   `protobuf:"varint,3,opt,name=atomic_batch,json=atomicBatch,proto3" json:"atomic_batch,omitempty"` // SetItems and RemoveItems apply all keys or none.
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StopResponse) Reset() {
-	*x = StopResponse{}
+func (x *GetCapabilitiesResponse) Reset() {
+	*x = GetCapabilitiesResponse{}
 	mi := &file_storage_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StopResponse) String() string {
+func (x *GetCapabilitiesResponse) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StopResponse) ProtoMessage() {}
+func (*GetCapabilitiesResponse) ProtoMessage() {}
 
-func (x *StopResponse) ProtoReflect() protoreflect.Message {
+func (x *GetCapabilitiesResponse) ProtoReflect() protoreflect.Message {
 	mi := &file_storage_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -1241,85 +1260,1067 @@ func (x *StopResponse) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
-func (*StopResponse) Descriptor() ([]byte, []int) {
+// Deprecated: Use GetCapabilitiesResponse.ProtoReflect.Descriptor instead.
+func (*GetCapabilitiesResponse) Descriptor() ([]byte, []int) {
 	return file_storage_proto_rawDescGZIP(), []int{24}
 }
 
-var File_storage_proto protoreflect.FileDescriptor
+func (x *GetCapabilitiesResponse) GetTtl() bool {
+	if x != nil {
+		return x.Ttl
+	}
+	return false
+}
 
-const file_storage_proto_rawDesc = "" +
-	"\n" +
-	"\rstorage.proto\x12\astorage\x1a\x13globular_auth.proto\"X\n" +
-	"\n" +
-	"Connection\x12\x0e\n" +
-	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
-	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
-	"\x04type\x18\x03 \x01(\x0e2\x12.storage.StoreTypeR\x04type\"C\n" +
-	"\bOpenRqst\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\x12\x18\n" +
-	"\aoptions\x18\x02 \x01(\tR\aoptions\"!\n" +
-	"\aOpenRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"*\n" +
-	"\tCloseRqst\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\"\"\n" +
-	"\bCloseRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"K\n" +
-	"\x14CreateConnectionRqst\x123\n" +
-	"\n" +
-	"connection\x18\x01 \x01(\v2\x13.storage.ConnectionR\n" +
-	"connection\"-\n" +
-	"\x13CreateConnectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\":\n" +
-	"\x14DeleteConnectionRqst\x12\"\n" +
-	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
-	"\n" +
-	"connection\x10\x01R\x02id\"-\n" +
-	"\x13DeleteConnectionRsp\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"W\n" +
-	"\x0eSetItemRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\fR\x05value\")\n" +
-	"\x0fSetItemResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"\\\n" +
-	"\x13SetLargeItemRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x03 \x01(\fR\x05value\".\n" +
-	"\x14SetLargeItemResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"A\n" +
-	"\x0eGetItemRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\")\n" +
-	"\x0fGetItemResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\fR\x06result\"D\n" +
-	"\x11RemoveItemRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\x12\x10\n" +
-	"\x03key\x18\x02 \x01(\tR\x03key\",\n" +
-	"\x12RemoveItemResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"-\n" +
-	"\fClearRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\"'\n" +
-	"\rClearResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\",\n" +
-	"\vDropRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\"&\n" +
-	"\fDropResponse\x12\x16\n" +
-	"\x06result\x18\x01 \x01(\bR\x06result\"2\n" +
-	"\x11GetAllKeysRequest\x12\x1d\n" +
-	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
-	"\x05store\x10\x01R\x02id\"(\n" +
-	"\x12GetAllKeysResponse\x12\x12\n" +
-	"\x04keys\x18\x01 \x03(\tR\x04keys\"\r\n" +
+func (x *GetCapabilitiesResponse) GetAtomicCas() bool {
+	if x != nil {
+		return x.AtomicCas
+	}
+	return false
+}
+
+func (x *GetCapabilitiesResponse) GetAtomicBatch() bool {
+	if x != nil {
+		return x.AtomicBatch
+	}
+	return false
+}
+
+// Request to replace the value of a key if it still holds an expected value.
+type CompareAndSwapRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                             // Connection identifier.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                           // Key of the item.
+	OldValue      []byte                 `protobuf:"bytes,3,opt,name=old_value,json=oldValue,proto3" json:"old_value,omitempty"` // Expected current value; empty means the key must not exist.
+	NewValue      []byte                 `protobuf:"bytes,4,opt,name=new_value,json=newValue,proto3" json:"new_value,omitempty"` // Value to store.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareAndSwapRequest) Reset() {
+	*x = CompareAndSwapRequest{}
+	mi := &file_storage_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareAndSwapRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareAndSwapRequest) ProtoMessage() {}
+
+func (x *CompareAndSwapRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareAndSwapRequest.ProtoReflect.Descriptor instead.
+func (*CompareAndSwapRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *CompareAndSwapRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *CompareAndSwapRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *CompareAndSwapRequest) GetOldValue() []byte {
+	if x != nil {
+		return x.OldValue
+	}
+	return nil
+}
+
+func (x *CompareAndSwapRequest) GetNewValue() []byte {
+	if x != nil {
+		return x.NewValue
+	}
+	return nil
+}
+
+type CompareAndSwapResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Swapped       bool                   `protobuf:"varint,1,opt,name=swapped,proto3" json:"swapped,omitempty"` // False when the current value differed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CompareAndSwapResponse) Reset() {
+	*x = CompareAndSwapResponse{}
+	mi := &file_storage_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CompareAndSwapResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CompareAndSwapResponse) ProtoMessage() {}
+
+func (x *CompareAndSwapResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CompareAndSwapResponse.ProtoReflect.Descriptor instead.
+func (*CompareAndSwapResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *CompareAndSwapResponse) GetSwapped() bool {
+	if x != nil {
+		return x.Swapped
+	}
+	return false
+}
+
+// Request to save an item only if its key does not exist yet.
+type SetIfAbsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                    // Connection identifier.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`                                  // Key for the item.
+	Value         []byte                 `protobuf:"bytes,3,opt,name=value,proto3" json:"value,omitempty"`                              // Data to store.
+	TtlSeconds    int64                  `protobuf:"varint,4,opt,name=ttl_seconds,json=ttlSeconds,proto3" json:"ttl_seconds,omitempty"` // Expire the item after that many seconds; 0 keeps it.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIfAbsentRequest) Reset() {
+	*x = SetIfAbsentRequest{}
+	mi := &file_storage_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIfAbsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIfAbsentRequest) ProtoMessage() {}
+
+func (x *SetIfAbsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIfAbsentRequest.ProtoReflect.Descriptor instead.
+func (*SetIfAbsentRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *SetIfAbsentRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetIfAbsentRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *SetIfAbsentRequest) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *SetIfAbsentRequest) GetTtlSeconds() int64 {
+	if x != nil {
+		return x.TtlSeconds
+	}
+	return 0
+}
+
+type SetIfAbsentResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Set           bool                   `protobuf:"varint,1,opt,name=set,proto3" json:"set,omitempty"` // False when the key already existed.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetIfAbsentResponse) Reset() {
+	*x = SetIfAbsentResponse{}
+	mi := &file_storage_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetIfAbsentResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetIfAbsentResponse) ProtoMessage() {}
+
+func (x *SetIfAbsentResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetIfAbsentResponse.ProtoReflect.Descriptor instead.
+func (*SetIfAbsentResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SetIfAbsentResponse) GetSet() bool {
+	if x != nil {
+		return x.Set
+	}
+	return false
+}
+
+// Request to add a delta to a decimal integer counter.
+type IncrementRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`        // Connection identifier.
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`      // Key of the counter; a missing key counts as 0.
+	Delta         int64                  `protobuf:"varint,3,opt,name=delta,proto3" json:"delta,omitempty"` // Amount to add (may be negative).
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementRequest) Reset() {
+	*x = IncrementRequest{}
+	mi := &file_storage_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementRequest) ProtoMessage() {}
+
+func (x *IncrementRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementRequest.ProtoReflect.Descriptor instead.
+func (*IncrementRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *IncrementRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *IncrementRequest) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *IncrementRequest) GetDelta() int64 {
+	if x != nil {
+		return x.Delta
+	}
+	return 0
+}
+
+type IncrementResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Value         int64                  `protobuf:"varint,1,opt,name=value,proto3" json:"value,omitempty"` // Value of the counter after the increment.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *IncrementResponse) Reset() {
+	*x = IncrementResponse{}
+	mi := &file_storage_proto_msgTypes[30]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *IncrementResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*IncrementResponse) ProtoMessage() {}
+
+func (x *IncrementResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[30]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use IncrementResponse.ProtoReflect.Descriptor instead.
+func (*IncrementResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{30}
+}
+
+func (x *IncrementResponse) GetValue() int64 {
+	if x != nil {
+		return x.Value
+	}
+	return 0
+}
+
+// A key and its value.
+type Item struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           string                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Value         []byte                 `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_storage_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *Item) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *Item) GetValue() []byte {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+// Request to retrieve several items at once.
+type GetItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // Connection identifier.
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"` // Keys of the items.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemsRequest) Reset() {
+	*x = GetItemsRequest{}
+	mi := &file_storage_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsRequest) ProtoMessage() {}
+
+func (x *GetItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsRequest.ProtoReflect.Descriptor instead.
+func (*GetItemsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *GetItemsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *GetItemsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type GetItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"` // Items found; missing keys are left out.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemsResponse) Reset() {
+	*x = GetItemsResponse{}
+	mi := &file_storage_proto_msgTypes[33]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemsResponse) ProtoMessage() {}
+
+func (x *GetItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[33]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemsResponse.ProtoReflect.Descriptor instead.
+func (*GetItemsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{33}
+}
+
+func (x *GetItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// Request to save several items at once.
+type SetItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`       // Connection identifier.
+	Items         []*Item                `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"` // Items to store.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemsRequest) Reset() {
+	*x = SetItemsRequest{}
+	mi := &file_storage_proto_msgTypes[34]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemsRequest) ProtoMessage() {}
+
+func (x *SetItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[34]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemsRequest.ProtoReflect.Descriptor instead.
+func (*SetItemsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{34}
+}
+
+func (x *SetItemsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *SetItemsRequest) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type SetItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // Result of the operation.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SetItemsResponse) Reset() {
+	*x = SetItemsResponse{}
+	mi := &file_storage_proto_msgTypes[35]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SetItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SetItemsResponse) ProtoMessage() {}
+
+func (x *SetItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[35]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SetItemsResponse.ProtoReflect.Descriptor instead.
+func (*SetItemsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{35}
+}
+
+func (x *SetItemsResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+// Request to remove several items at once.
+type RemoveItemsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`     // Connection identifier.
+	Keys          []string               `protobuf:"bytes,2,rep,name=keys,proto3" json:"keys,omitempty"` // Keys of the items.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemsRequest) Reset() {
+	*x = RemoveItemsRequest{}
+	mi := &file_storage_proto_msgTypes[36]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemsRequest) ProtoMessage() {}
+
+func (x *RemoveItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[36]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemsRequest.ProtoReflect.Descriptor instead.
+func (*RemoveItemsRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{36}
+}
+
+func (x *RemoveItemsRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *RemoveItemsRequest) GetKeys() []string {
+	if x != nil {
+		return x.Keys
+	}
+	return nil
+}
+
+type RemoveItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Result        bool                   `protobuf:"varint,1,opt,name=result,proto3" json:"result,omitempty"` // Result of the operation.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveItemsResponse) Reset() {
+	*x = RemoveItemsResponse{}
+	mi := &file_storage_proto_msgTypes[37]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveItemsResponse) ProtoMessage() {}
+
+func (x *RemoveItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[37]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveItemsResponse.ProtoReflect.Descriptor instead.
+func (*RemoveItemsResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{37}
+}
+
+func (x *RemoveItemsResponse) GetResult() bool {
+	if x != nil {
+		return x.Result
+	}
+	return false
+}
+
+// Request a page of the items whose keys match a prefix and/or a range.
+type ScanRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`                                // Connection identifier.
+	Prefix        string                 `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`                        // Keys starting with prefix; empty matches every key.
+	Start         string                 `protobuf:"bytes,3,opt,name=start,proto3" json:"start,omitempty"`                          // Lowest key (inclusive); empty has no lower bound.
+	End           string                 `protobuf:"bytes,4,opt,name=end,proto3" json:"end,omitempty"`                              // Highest key (exclusive); empty has no upper bound.
+	Limit         int32                  `protobuf:"varint,5,opt,name=limit,proto3" json:"limit,omitempty"`                         // Maximum items of the page; 0 uses the server default.
+	PageToken     string                 `protobuf:"bytes,6,opt,name=page_token,json=pageToken,proto3" json:"page_token,omitempty"` // next_page_token of the previous page; empty starts the scan.
+	KeysOnly      bool                   `protobuf:"varint,7,opt,name=keys_only,json=keysOnly,proto3" json:"keys_only,omitempty"`   // Leave the values out.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanRequest) Reset() {
+	*x = ScanRequest{}
+	mi := &file_storage_proto_msgTypes[38]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanRequest) ProtoMessage() {}
+
+func (x *ScanRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[38]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanRequest.ProtoReflect.Descriptor instead.
+func (*ScanRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{38}
+}
+
+func (x *ScanRequest) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetStart() string {
+	if x != nil {
+		return x.Start
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetEnd() string {
+	if x != nil {
+		return x.End
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetLimit() int32 {
+	if x != nil {
+		return x.Limit
+	}
+	return 0
+}
+
+func (x *ScanRequest) GetPageToken() string {
+	if x != nil {
+		return x.PageToken
+	}
+	return ""
+}
+
+func (x *ScanRequest) GetKeysOnly() bool {
+	if x != nil {
+		return x.KeysOnly
+	}
+	return false
+}
+
+type ScanResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`                                        // Items of the page, in key order (token order on Scylla).
+	NextPageToken string                 `protobuf:"bytes,2,opt,name=next_page_token,json=nextPageToken,proto3" json:"next_page_token,omitempty"` // Token of the next page; empty on the last page.
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ScanResponse) Reset() {
+	*x = ScanResponse{}
+	mi := &file_storage_proto_msgTypes[39]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ScanResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ScanResponse) ProtoMessage() {}
+
+func (x *ScanResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[39]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ScanResponse.ProtoReflect.Descriptor instead.
+func (*ScanResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{39}
+}
+
+func (x *ScanResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+func (x *ScanResponse) GetNextPageToken() string {
+	if x != nil {
+		return x.NextPageToken
+	}
+	return ""
+}
+
+// Request to stop the storage service.
+type StopRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopRequest) Reset() {
+	*x = StopRequest{}
+	mi := &file_storage_proto_msgTypes[40]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopRequest) ProtoMessage() {}
+
+func (x *StopRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[40]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopRequest.ProtoReflect.Descriptor instead.
+func (*StopRequest) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{40}
+}
+
+type StopResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StopResponse) Reset() {
+	*x = StopResponse{}
+	mi := &file_storage_proto_msgTypes[41]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StopResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StopResponse) ProtoMessage() {}
+
+func (x *StopResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_storage_proto_msgTypes[41]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StopResponse.ProtoReflect.Descriptor instead.
+func (*StopResponse) Descriptor() ([]byte, []int) {
+	return file_storage_proto_rawDescGZIP(), []int{41}
+}
+
+var File_storage_proto protoreflect.FileDescriptor
+
+const file_storage_proto_rawDesc = "" +
+	"\n" +
+	"\rstorage.proto\x12\astorage\x1a\x13globular_auth.proto\"X\n" +
+	"\n" +
+	"Connection\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x12\n" +
+	"\x04name\x18\x02 \x01(\tR\x04name\x12&\n" +
+	"\x04type\x18\x03 \x01(\x0e2\x12.storage.StoreTypeR\x04type\"C\n" +
+	"\bOpenRqst\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x18\n" +
+	"\aoptions\x18\x02 \x01(\tR\aoptions\"!\n" +
+	"\aOpenRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"*\n" +
+	"\tCloseRqst\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\"\"\n" +
+	"\bCloseRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"K\n" +
+	"\x14CreateConnectionRqst\x123\n" +
+	"\n" +
+	"connection\x18\x01 \x01(\v2\x13.storage.ConnectionR\n" +
+	"connection\"-\n" +
+	"\x13CreateConnectionRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\":\n" +
+	"\x14DeleteConnectionRqst\x12\"\n" +
+	"\x02id\x18\x01 \x01(\tB\x12\x8a\xb5\x18\x0e\n" +
+	"\n" +
+	"connection\x10\x01R\x02id\"-\n" +
+	"\x13DeleteConnectionRsp\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"x\n" +
+	"\x0eSetItemRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\")\n" +
+	"\x0fSetItemResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\\\n" +
+	"\x13SetLargeItemRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\".\n" +
+	"\x14SetLargeItemResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"A\n" +
+	"\x0eGetItemRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\")\n" +
+	"\x0fGetItemResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\fR\x06result\"D\n" +
+	"\x11RemoveItemRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\",\n" +
+	"\x12RemoveItemResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"-\n" +
+	"\fClearRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\"'\n" +
+	"\rClearResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\",\n" +
+	"\vDropRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\"&\n" +
+	"\fDropResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"2\n" +
+	"\x11GetAllKeysRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\"(\n" +
+	"\x12GetAllKeysResponse\x12\x12\n" +
+	"\x04keys\x18\x01 \x03(\tR\x04keys\"7\n" +
+	"\x16GetCapabilitiesRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\"m\n" +
+	"\x17GetCapabilitiesResponse\x12\x10\n" +
+	"\x03ttl\x18\x01 \x01(\bR\x03ttl\x12\x1d\n" +
+	"\n" +
+	"atomic_cas\x18\x02 \x01(\bR\tatomicCas\x12!\n" +
+	"\fatomic_batch\x18\x03 \x01(\bR\vatomicBatch\"\x82\x01\n" +
+	"\x15CompareAndSwapRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x1b\n" +
+	"\told_value\x18\x03 \x01(\fR\boldValue\x12\x1b\n" +
+	"\tnew_value\x18\x04 \x01(\fR\bnewValue\"2\n" +
+	"\x16CompareAndSwapResponse\x12\x18\n" +
+	"\aswapped\x18\x01 \x01(\bR\aswapped\"|\n" +
+	"\x12SetIfAbsentRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x03 \x01(\fR\x05value\x12\x1f\n" +
+	"\vttl_seconds\x18\x04 \x01(\x03R\n" +
+	"ttlSeconds\"'\n" +
+	"\x13SetIfAbsentResponse\x12\x10\n" +
+	"\x03set\x18\x01 \x01(\bR\x03set\"Y\n" +
+	"\x10IncrementRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x14\n" +
+	"\x05delta\x18\x03 \x01(\x03R\x05delta\")\n" +
+	"\x11IncrementResponse\x12\x14\n" +
+	"\x05value\x18\x01 \x01(\x03R\x05value\".\n" +
+	"\x04Item\x12\x10\n" +
+	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\fR\x05value\"D\n" +
+	"\x0fGetItemsRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\"7\n" +
+	"\x10GetItemsResponse\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.storage.ItemR\x05items\"U\n" +
+	"\x0fSetItemsRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12#\n" +
+	"\x05items\x18\x02 \x03(\v2\r.storage.ItemR\x05items\"*\n" +
+	"\x10SetItemsResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"G\n" +
+	"\x12RemoveItemsRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x12\n" +
+	"\x04keys\x18\x02 \x03(\tR\x04keys\"-\n" +
+	"\x13RemoveItemsResponse\x12\x16\n" +
+	"\x06result\x18\x01 \x01(\bR\x06result\"\xbe\x01\n" +
+	"\vScanRequest\x12\x1d\n" +
+	"\x02id\x18\x01 \x01(\tB\r\x8a\xb5\x18\t\n" +
+	"\x05store\x10\x01R\x02id\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12\x14\n" +
+	"\x05start\x18\x03 \x01(\tR\x05start\x12\x10\n" +
+	"\x03end\x18\x04 \x01(\tR\x03end\x12\x14\n" +
+	"\x05limit\x18\x05 \x01(\x05R\x05limit\x12\x1d\n" +
+	"\n" +
+	"page_token\x18\x06 \x01(\tR\tpageToken\x12\x1b\n" +
+	"\tkeys_only\x18\a \x01(\bR\bkeysOnly\"[\n" +
+	"\fScanResponse\x12#\n" +
+	"\x05items\x18\x01 \x03(\v2\r.storage.ItemR\x05items\x12&\n" +
+	"\x0fnext_page_token\x18\x02 \x01(\tR\rnextPageToken\"\r\n" +
 	"\vStopRequest\"\x0e\n" +
 	"\fStopResponse*P\n" +
 	"\tStoreType\x12\f\n" +
@@ -1327,7 +2328,7 @@ const file_storage_proto_rawDesc = "" +
 	"\tBIG_CACHE\x10\x01\x12\r\n" +
 	"\tBADGER_DB\x10\x02\x12\r\n" +
 	"\tSCYLLA_DB\x10\x03\x12\b\n" +
-	"\x04ETCD\x10\x042\x99\f\n" +
+	"\x04ETCD\x10\x042\x89\x15\n" +
 	"\x0eStorageService\x12_\n" +
 	"\x04Stop\x12\x14.storage.StopRequest\x1a\x15.storage.StopResponse\"*\x82\xb5\x18&\n" +
 	"\fstorage.stop\x12\x05admin\x1a\b/storage*\x05admin\x12c\n" +
@@ -1354,7 +2355,23 @@ const file_storage_proto_rawDesc = "" +
 	"\fstorage.drop\x12\x05admin\x1a\x14/storage/stores/{id}*\x05admin\x12\x87\x01\n" +
 	"\n" +
 	"GetAllKeys\x12\x1a.storage.GetAllKeysRequest\x1a\x1b.storage.GetAllKeysResponse\">\x82\xb5\x18:\n" +
-	"\x0fstorage.getitem\x12\x04read\x1a\x19/storage/stores/{id}/keys*\x06viewer0\x01B9Z7github.com/globulario/services/golang/storage/storagepbb\x06proto3"
+	"\x0fstorage.getitem\x12\x04read\x1a\x19/storage/stores/{id}/keys*\x06viewer0\x01\x12\x97\x01\n" +
+	"\x0fGetCapabilities\x12\x1f.storage.GetCapabilitiesRequest\x1a .storage.GetCapabilitiesResponse\"A\x82\xb5\x18=\n" +
+	"\x17storage.getcapabilities\x12\x04read\x1a\x14/storage/stores/{id}*\x06viewer\x12\xa0\x01\n" +
+	"\x0eCompareAndSwap\x12\x1e.storage.CompareAndSwapRequest\x1a\x1f.storage.CompareAndSwapResponse\"M\x82\xb5\x18I\n" +
+	"\x16storage.compareandswap\x12\x05write\x1a /storage/stores/{id}/items/{key}*\x06editor\x12\x94\x01\n" +
+	"\vSetIfAbsent\x12\x1b.storage.SetIfAbsentRequest\x1a\x1c.storage.SetIfAbsentResponse\"J\x82\xb5\x18F\n" +
+	"\x13storage.setifabsent\x12\x05write\x1a /storage/stores/{id}/items/{key}*\x06editor\x12\x8c\x01\n" +
+	"\tIncrement\x12\x19.storage.IncrementRequest\x1a\x1a.storage.IncrementResponse\"H\x82\xb5\x18D\n" +
+	"\x11storage.increment\x12\x05write\x1a /storage/stores/{id}/items/{key}*\x06editor\x12\x81\x01\n" +
+	"\bGetItems\x12\x18.storage.GetItemsRequest\x1a\x19.storage.GetItemsResponse\"@\x82\xb5\x18<\n" +
+	"\x10storage.getitems\x12\x04read\x1a\x1a/storage/stores/{id}/items*\x06viewer\x12\x82\x01\n" +
+	"\bSetItems\x12\x18.storage.SetItemsRequest\x1a\x19.storage.SetItemsResponse\"A\x82\xb5\x18=\n" +
+	"\x10storage.setitems\x12\x05write\x1a\x1a/storage/stores/{id}/items*\x06editor\x12\x8e\x01\n" +
+	"\vRemoveItems\x12\x1b.storage.RemoveItemsRequest\x1a\x1c.storage.RemoveItemsResponse\"D\x82\xb5\x18@\n" +
+	"\x13storage.removeitems\x12\x05write\x1a\x1a/storage/stores/{id}/items*\x06editor\x12q\n" +
+	"\x04Scan\x12\x14.storage.ScanRequest\x1a\x15.storage.ScanResponse\"<\x82\xb5\x188\n" +
+	"\fstorage.scan\x12\x04read\x1a\x1a/storage/stores/{id}/items*\x06viewerB9Z7github.com/globulario/services/golang/storage/storagepbb\x06proto3"
 
 var (
 	file_storage_proto_rawDescOnce sync.Once
@@ -1369,67 +2386,103 @@ func file_storage_proto_rawDescGZIP() []byte {
 }
 
 var file_storage_proto_enumTypes = make([]protoimpl.EnumInfo, 1)
-var file_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
+var file_storage_proto_msgTypes = make([]protoimpl.MessageInfo, 42)
 var file_storage_proto_goTypes = []any{
-	(StoreType)(0),               // 0: storage.StoreType
-	(*Connection)(nil),           // 1: storage.Connection
-	(*OpenRqst)(nil),             // 2: storage.OpenRqst
-	(*OpenRsp)(nil),              // 3: storage.OpenRsp
-	(*CloseRqst)(nil),            // 4: storage.CloseRqst
-	(*CloseRsp)(nil),             // 5: storage.CloseRsp
-	(*CreateConnectionRqst)(nil), // 6: storage.CreateConnectionRqst
-	(*CreateConnectionRsp)(nil),  // 7: storage.CreateConnectionRsp
-	(*DeleteConnectionRqst)(nil), // 8: storage.DeleteConnectionRqst
-	(*DeleteConnectionRsp)(nil),  // 9: storage.DeleteConnectionRsp
-	(*SetItemRequest)(nil),       // 10: storage.SetItemRequest
-	(*SetItemResponse)(nil),      // 11: storage.SetItemResponse
-	(*SetLargeItemRequest)(nil),  // 12: storage.SetLargeItemRequest
-	(*SetLargeItemResponse)(nil), // 13: storage.SetLargeItemResponse
-	(*GetItemRequest)(nil),       // 14: storage.GetItemRequest
-	(*GetItemResponse)(nil),      // 15: storage.GetItemResponse
-	(*RemoveItemRequest)(nil),    // 16: storage.RemoveItemRequest
-	(*RemoveItemResponse)(nil),   // 17: storage.RemoveItemResponse
-	(*ClearRequest)(nil),         // 18: storage.ClearRequest
-	(*ClearResponse)(nil),        // 19: storage.ClearResponse
-	(*DropRequest)(nil),          // 20: storage.DropRequest
-	(*DropResponse)(nil),         // 21: storage.DropResponse
-	(*GetAllKeysRequest)(nil),    // 22: storage.GetAllKeysRequest
-	(*GetAllKeysResponse)(nil),   // 23: storage.GetAllKeysResponse
-	(*StopRequest)(nil),          // 24: storage.StopRequest
-	(*StopResponse)(nil),         // 25: storage.StopResponse
+	(StoreType)(0),                  // 0: storage.StoreType
+	(*Connection)(nil),              // 1: storage.Connection
+	(*OpenRqst)(nil),                // 2: storage.OpenRqst
+	(*OpenRsp)(nil),                 // 3: storage.OpenRsp
+	(*CloseRqst)(nil),               // 4: storage.CloseRqst
+	(*CloseRsp)(nil),                // 5: storage.CloseRsp
+	(*CreateConnectionRqst)(nil),    // 6: storage.CreateConnectionRqst
+	(*CreateConnectionRsp)(nil),     // 7: storage.CreateConnectionRsp
+	(*DeleteConnectionRqst)(nil),    // 8: storage.DeleteConnectionRqst
+	(*DeleteConnectionRsp)(nil),     // 9: storage.DeleteConnectionRsp
+	(*SetItemRequest)(nil),          // 10: storage.SetItemRequest
+	(*SetItemResponse)(nil),         // 11: storage.SetItemResponse
+	(*SetLargeItemRequest)(nil),     // 12: storage.SetLargeItemRequest
+	(*SetLargeItemResponse)(nil),    // 13: storage.SetLargeItemResponse
+	(*GetItemRequest)(nil),          // 14: storage.GetItemRequest
+	(*GetItemResponse)(nil),         // 15: storage.GetItemResponse
+	(*RemoveItemRequest)(nil),       // 16: storage.RemoveItemRequest
+	(*RemoveItemResponse)(nil),      // 17: storage.RemoveItemResponse
+	(*ClearRequest)(nil),            // 18: storage.ClearRequest
+	(*ClearResponse)(nil),           // 19: storage.ClearResponse
+	(*DropRequest)(nil),             // 20: storage.DropRequest
+	(*DropResponse)(nil),            // 21: storage.DropResponse
+	(*GetAllKeysRequest)(nil),       // 22: storage.GetAllKeysRequest
+	(*GetAllKeysResponse)(nil),      // 23: storage.GetAllKeysResponse
+	(*GetCapabilitiesRequest)(nil),  // 24: storage.GetCapabilitiesRequest
+	(*GetCapabilitiesResponse)(nil), // 25: storage.GetCapabilitiesResponse
+	(*CompareAndSwapRequest)(nil),   // 26: storage.CompareAndSwapRequest
+	(*CompareAndSwapResponse)(nil),  // 27: storage.CompareAndSwapResponse
+	(*SetIfAbsentRequest)(nil),      // 28: storage.SetIfAbsentRequest
+	(*SetIfAbsentResponse)(nil),     // 29: storage.SetIfAbsentResponse
+	(*IncrementRequest)(nil),        // 30: storage.IncrementRequest
+	(*IncrementResponse)(nil),       // 31: storage.IncrementResponse
+	(*Item)(nil),                    // 32: storage.Item
+	(*GetItemsRequest)(nil),         // 33: storage.GetItemsRequest
+	(*GetItemsResponse)(nil),        // 34: storage.GetItemsResponse
+	(*SetItemsRequest)(nil),         // 35: storage.SetItemsRequest
+	(*SetItemsResponse)(nil),        // 36: storage.SetItemsResponse
+	(*RemoveItemsRequest)(nil),      // 37: storage.RemoveItemsRequest
+	(*RemoveItemsResponse)(nil),     // 38: storage.RemoveItemsResponse
+	(*ScanRequest)(nil),             // 39: storage.ScanRequest
+	(*ScanResponse)(nil),            // 40: storage.ScanResponse
+	(*StopRequest)(nil),             // 41: storage.StopRequest
+	(*StopResponse)(nil),            // 42: storage.StopResponse
 }
 var file_storage_proto_depIdxs = []int32{
 	0,  // 0: storage.Connection.type:type_name -> storage.StoreType
 	1,  // 1: storage.CreateConnectionRqst.connection:type_name -> storage.Connection
-	24, // 2: storage.StorageService.Stop:input_type -> storage.StopRequest
-	2,  // 3: storage.StorageService.Open:input_type -> storage.OpenRqst
-	4,  // 4: storage.StorageService.Close:input_type -> storage.CloseRqst
-	6,  // 5: storage.StorageService.CreateConnection:input_type -> storage.CreateConnectionRqst
-	8,  // 6: storage.StorageService.DeleteConnection:input_type -> storage.DeleteConnectionRqst
-	10, // 7: storage.StorageService.SetItem:input_type -> storage.SetItemRequest
-	12, // 8: storage.StorageService.SetLargeItem:input_type -> storage.SetLargeItemRequest
-	14, // 9: storage.StorageService.GetItem:input_type -> storage.GetItemRequest
-	16, // 10: storage.StorageService.RemoveItem:input_type -> storage.RemoveItemRequest
-	18, // 11: storage.StorageService.Clear:input_type -> storage.ClearRequest
-	20, // 12: storage.StorageService.Drop:input_type -> storage.DropRequest
-	22, // 13: storage.StorageService.GetAllKeys:input_type -> storage.GetAllKeysRequest
-	25, // 14: storage.StorageService.Stop:output_type -> storage.StopResponse
-	3,  // 15: storage.StorageService.Open:output_type -> storage.OpenRsp
-	5,  // 16: storage.StorageService.Close:output_type -> storage.CloseRsp
-	7,  // 17: storage.StorageService.CreateConnection:output_type -> storage.CreateConnectionRsp
-	9,  // 18: storage.StorageService.DeleteConnection:output_type -> storage.DeleteConnectionRsp
-	11, // 19: storage.StorageService.SetItem:output_type -> storage.SetItemResponse
-	13, // 20: storage.StorageService.SetLargeItem:output_type -> storage.SetLargeItemResponse
-	15, // 21: storage.StorageService.GetItem:output_type -> storage.GetItemResponse
-	17, // 22: storage.StorageService.RemoveItem:output_type -> storage.RemoveItemResponse
-	19, // 23: storage.StorageService.Clear:output_type -> storage.ClearResponse
-	21, // 24: storage.StorageService.Drop:output_type -> storage.DropResponse
-	23, // 25: storage.StorageService.GetAllKeys:output_type -> storage.GetAllKeysResponse
-	14, // [14:26] is the sub-list for method output_type
-	2,  // [2:14] is the sub-list for method input_type
-	2,  // [2:2] is the sub-list for extension type_name
-	2,  // [2:2] is the sub-list for extension extendee
-	0,  // [0:2] is the sub-list for field type_name
+	32, // 2: storage.GetItemsResponse.items:type_name -> storage.Item
+	32, // 3: storage.SetItemsRequest.items:type_name -> storage.Item
+	32, // 4: storage.ScanResponse.items:type_name -> storage.Item
+	41, // 5: storage.StorageService.Stop:input_type -> storage.StopRequest
+	2,  // 6: storage.StorageService.Open:input_type -> storage.OpenRqst
+	4,  // 7: storage.StorageService.Close:input_type -> storage.CloseRqst
+	6,  // 8: storage.StorageService.CreateConnection:input_type -> storage.CreateConnectionRqst
+	8,  // 9: storage.StorageService.DeleteConnection:input_type -> storage.DeleteConnectionRqst
+	10, // 10: storage.StorageService.SetItem:input_type -> storage.SetItemRequest
+	12, // 11: storage.StorageService.SetLargeItem:input_type -> storage.SetLargeItemRequest
+	14, // 12: storage.StorageService.GetItem:input_type -> storage.GetItemRequest
+	16, // 13: storage.StorageService.RemoveItem:input_type -> storage.RemoveItemRequest
+	18, // 14: storage.StorageService.Clear:input_type -> storage.ClearRequest
+	20, // 15: storage.StorageService.Drop:input_type -> storage.DropRequest
+	22, // 16: storage.StorageService.GetAllKeys:input_type -> storage.GetAllKeysRequest
+	24, // 17: storage.StorageService.GetCapabilities:input_type -> storage.GetCapabilitiesRequest
+	26, // 18: storage.StorageService.CompareAndSwap:input_type -> storage.CompareAndSwapRequest
+	28, // 19: storage.StorageService.SetIfAbsent:input_type -> storage.SetIfAbsentRequest
+	30, // 20: storage.StorageService.Increment:input_type -> storage.IncrementRequest
+	33, // 21: storage.StorageService.GetItems:input_type -> storage.GetItemsRequest
+	35, // 22: storage.StorageService.SetItems:input_type -> storage.SetItemsRequest
+	37, // 23: storage.StorageService.RemoveItems:input_type -> storage.RemoveItemsRequest
+	39, // 24: storage.StorageService.Scan:input_type -> storage.ScanRequest
+	42, // 25: storage.StorageService.Stop:output_type -> storage.StopResponse
+	3,  // 26: storage.StorageService.Open:output_type -> storage.OpenRsp
+	5,  // 27: storage.StorageService.Close:output_type -> storage.CloseRsp
+	7,  // 28: storage.StorageService.CreateConnection:output_type -> storage.CreateConnectionRsp
+	9,  // 29: storage.StorageService.DeleteConnection:output_type -> storage.DeleteConnectionRsp
+	11, // 30: storage.StorageService.SetItem:output_type -> storage.SetItemResponse
+	13, // 31: storage.StorageService.SetLargeItem:output_type -> storage.SetLargeItemResponse
+	15, // 32: storage.StorageService.GetItem:output_type -> storage.GetItemResponse
+	17, // 33: storage.StorageService.RemoveItem:output_type -> storage.RemoveItemResponse
+	19, // 34: storage.StorageService.Clear:output_type -> storage.ClearResponse
+	21, // 35: storage.StorageService.Drop:output_type -> storage.DropResponse
+	23, // 36: storage.StorageService.GetAllKeys:output_type -> storage.GetAllKeysResponse
+	25, // 37: storage.StorageService.GetCapabilities:output_type -> storage.GetCapabilitiesResponse
+	27, // 38: storage.StorageService.CompareAndSwap:output_type -> storage.CompareAndSwapResponse
+	29, // 39: storage.StorageService.SetIfAbsent:output_type -> storage.SetIfAbsentResponse
+	31, // 40: storage.StorageService.Increment:output_type -> storage.IncrementResponse
+	34, // 41: storage.StorageService.GetItems:output_type -> storage.GetItemsResponse
+	36, // 42: storage.StorageService.SetItems:output_type -> storage.SetItemsResponse
+	38, // 43: storage.StorageService.RemoveItems:output_type -> storage.RemoveItemsResponse
+	40, // 44: storage.StorageService.Scan:output_type -> storage.ScanResponse
+	25, // [25:45] is the sub-list for method output_type
+	5,  // [5:25] is the sub-list for method input_type
+	5,  // [5:5] is the sub-list for extension type_name
+	5,  // [5:5] is the sub-list for extension extendee
+	0,  // [0:5] is the sub-list for field type_name
 }
 
 func init() { file_storage_proto_init() }
@@ -1443,7 +2496,7 @@ func file_storage_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_storage_proto_rawDesc), len(file_storage_proto_rawDesc)),
 			NumEnums:      1,
-			NumMessages:   25,
+			NumMessages:   42,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -31,6 +31,14 @@ const (
 	StorageService_Clear_FullMethodName            = "/storage.StorageService/Clear"
 	StorageService_Drop_FullMethodName             = "/storage.StorageService/Drop"
 	StorageService_GetAllKeys_FullMethodName       = "/storage.StorageService/GetAllKeys"
+	StorageService_GetCapabilities_FullMethodName  = "/storage.StorageService/GetCapabilities"
+	StorageService_CompareAndSwap_FullMethodName   = "/storage.StorageService/CompareAndSwap"
+	StorageService_SetIfAbsent_FullMethodName      = "/storage.StorageService/SetIfAbsent"
+	StorageService_Increment_FullMethodName        = "/storage.StorageService/Increment"
+	StorageService_GetItems_FullMethodName         = "/storage.StorageService/GetItems"
+	StorageService_SetItems_FullMethodName         = "/storage.StorageService/SetItems"
+	StorageService_RemoveItems_FullMethodName      = "/storage.StorageService/RemoveItems"
+	StorageService_Scan_FullMethodName             = "/storage.StorageService/Scan"
 )
 
 // StorageServiceClient is the client API for StorageService service.
@@ -63,6 +71,22 @@ type StorageServiceClient interface {
 	Drop(ctx context.Context, in *DropRequest, opts ...grpc.CallOption) (*DropResponse, error)
 	// Get all keys in the store.
 	GetAllKeys(ctx context.Context, in *GetAllKeysRequest, opts ...grpc.CallOption) (grpc.ServerStreamingClient[GetAllKeysResponse], error)
+	// Reports the optional operations the store supports.
+	GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error)
+	// Replaces the value of a key if it still holds the expected value.
+	CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error)
+	// Saves an item only if its key does not exist yet.
+	SetIfAbsent(ctx context.Context, in *SetIfAbsentRequest, opts ...grpc.CallOption) (*SetIfAbsentResponse, error)
+	// Atomically adds a delta to a counter.
+	Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error)
+	// Retrieves several items at once.
+	GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error)
+	// Saves several items at once.
+	SetItems(ctx context.Context, in *SetItemsRequest, opts ...grpc.CallOption) (*SetItemsResponse, error)
+	// Removes several items at once.
+	RemoveItems(ctx context.Context, in *RemoveItemsRequest, opts ...grpc.CallOption) (*RemoveItemsResponse, error)
+	// Lists the items of a prefix or key range, page by page.
+	Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error)
 }
 
 type storageServiceClient struct {
@@ -214,6 +238,86 @@ func (c *storageServiceClient) GetAllKeys(ctx context.Context, in *GetAllKeysReq
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageService_GetAllKeysClient = grpc.ServerStreamingClient[GetAllKeysResponse]
 
+func (c *storageServiceClient) GetCapabilities(ctx context.Context, in *GetCapabilitiesRequest, opts ...grpc.CallOption) (*GetCapabilitiesResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetCapabilitiesResponse)
+	err := c.cc.Invoke(ctx, StorageService_GetCapabilities_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) CompareAndSwap(ctx context.Context, in *CompareAndSwapRequest, opts ...grpc.CallOption) (*CompareAndSwapResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(CompareAndSwapResponse)
+	err := c.cc.Invoke(ctx, StorageService_CompareAndSwap_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) SetIfAbsent(ctx context.Context, in *SetIfAbsentRequest, opts ...grpc.CallOption) (*SetIfAbsentResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetIfAbsentResponse)
+	err := c.cc.Invoke(ctx, StorageService_SetIfAbsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) Increment(ctx context.Context, in *IncrementRequest, opts ...grpc.CallOption) (*IncrementResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(IncrementResponse)
+	err := c.cc.Invoke(ctx, StorageService_Increment_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) GetItems(ctx context.Context, in *GetItemsRequest, opts ...grpc.CallOption) (*GetItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(GetItemsResponse)
+	err := c.cc.Invoke(ctx, StorageService_GetItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) SetItems(ctx context.Context, in *SetItemsRequest, opts ...grpc.CallOption) (*SetItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(SetItemsResponse)
+	err := c.cc.Invoke(ctx, StorageService_SetItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) RemoveItems(ctx context.Context, in *RemoveItemsRequest, opts ...grpc.CallOption) (*RemoveItemsResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(RemoveItemsResponse)
+	err := c.cc.Invoke(ctx, StorageService_RemoveItems_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *storageServiceClient) Scan(ctx context.Context, in *ScanRequest, opts ...grpc.CallOption) (*ScanResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ScanResponse)
+	err := c.cc.Invoke(ctx, StorageService_Scan_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // StorageServiceServer is the server API for StorageService service.
 // All implementations should embed UnimplementedStorageServiceServer
 // for forward compatibility.
@@ -244,6 +348,22 @@ type StorageServiceServer interface {
 	Drop(context.Context, *DropRequest) (*DropResponse, error)
 	// Get all keys in the store.
 	GetAllKeys(*GetAllKeysRequest, grpc.ServerStreamingServer[GetAllKeysResponse]) error
+	// Reports the optional operations the store supports.
+	GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error)
+	// Replaces the value of a key if it still holds the expected value.
+	CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error)
+	// Saves an item only if its key does not exist yet.
+	SetIfAbsent(context.Context, *SetIfAbsentRequest) (*SetIfAbsentResponse, error)
+	// Atomically adds a delta to a counter.
+	Increment(context.Context, *IncrementRequest) (*IncrementResponse, error)
+	// Retrieves several items at once.
+	GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error)
+	// Saves several items at once.
+	SetItems(context.Context, *SetItemsRequest) (*SetItemsResponse, error)
+	// Removes several items at once.
+	RemoveItems(context.Context, *RemoveItemsRequest) (*RemoveItemsResponse, error)
+	// Lists the items of a prefix or key range, page by page.
+	Scan(context.Context, *ScanRequest) (*ScanResponse, error)
 }
 
 // UnimplementedStorageServiceServer should be embedded to have
@@ -289,6 +409,30 @@ func (UnimplementedStorageServiceServer) Drop(context.Context, *DropRequest) (*D
 func (UnimplementedStorageServiceServer) GetAllKeys(*GetAllKeysRequest, grpc.ServerStreamingServer[GetAllKeysResponse]) error {
 	return status.Error(codes.Unimplemented, "method GetAllKeys not implemented")
 }
+func (UnimplementedStorageServiceServer) GetCapabilities(context.Context, *GetCapabilitiesRequest) (*GetCapabilitiesResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetCapabilities not implemented")
+}
+func (UnimplementedStorageServiceServer) CompareAndSwap(context.Context, *CompareAndSwapRequest) (*CompareAndSwapResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method CompareAndSwap not implemented")
+}
+func (UnimplementedStorageServiceServer) SetIfAbsent(context.Context, *SetIfAbsentRequest) (*SetIfAbsentResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetIfAbsent not implemented")
+}
+func (UnimplementedStorageServiceServer) Increment(context.Context, *IncrementRequest) (*IncrementResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Increment not implemented")
+}
+func (UnimplementedStorageServiceServer) GetItems(context.Context, *GetItemsRequest) (*GetItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetItems not implemented")
+}
+func (UnimplementedStorageServiceServer) SetItems(context.Context, *SetItemsRequest) (*SetItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method SetItems not implemented")
+}
+func (UnimplementedStorageServiceServer) RemoveItems(context.Context, *RemoveItemsRequest) (*RemoveItemsResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveItems not implemented")
+}
+func (UnimplementedStorageServiceServer) Scan(context.Context, *ScanRequest) (*ScanResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method Scan not implemented")
+}
 func (UnimplementedStorageServiceServer) testEmbeddedByValue() {}
 
 // UnsafeStorageServiceServer may be embedded to opt out of forward compatibility for this service.
@@ -500,6 +644,150 @@ func _StorageService_GetAllKeys_Handler(srv interface{}, stream grpc.ServerStrea
 // This type alias is provided for backwards compatibility with existing code that references the prior non-generic stream type by name.
 type StorageService_GetAllKeysServer = grpc.ServerStreamingServer[GetAllKeysResponse]
 
+func _StorageService_GetCapabilities_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetCapabilitiesRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).GetCapabilities(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_GetCapabilities_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).GetCapabilities(ctx, req.(*GetCapabilitiesRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_CompareAndSwap_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CompareAndSwapRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).CompareAndSwap(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_CompareAndSwap_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).CompareAndSwap(ctx, req.(*CompareAndSwapRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_SetIfAbsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetIfAbsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).SetIfAbsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_SetIfAbsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).SetIfAbsent(ctx, req.(*SetIfAbsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_Increment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(IncrementRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).Increment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_Increment_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).Increment(ctx, req.(*IncrementRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_GetItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).GetItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_GetItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).GetItems(ctx, req.(*GetItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_SetItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SetItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).SetItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_SetItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).SetItems(ctx, req.(*SetItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_RemoveItems_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveItemsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).RemoveItems(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_RemoveItems_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).RemoveItems(ctx, req.(*RemoveItemsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _StorageService_Scan_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(StorageServiceServer).Scan(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: StorageService_Scan_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(StorageServiceServer).Scan(ctx, req.(*ScanRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // StorageService_ServiceDesc is the grpc.ServiceDesc for StorageService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -543,6 +831,38 @@ var StorageService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "Drop",
 			Handler:    _StorageService_Drop_Handler,
 		},
+		{
+			MethodName: "GetCapabilities",
+			Handler:    _StorageService_GetCapabilities_Handler,
+		},
+		{
+			MethodName: "CompareAndSwap",
+			Handler:    _StorageService_CompareAndSwap_Handler,
+		},
+		{
+			MethodName: "SetIfAbsent",
+			Handler:    _StorageService_SetIfAbsent_Handler,
+		},
+		{
+			MethodName: "Increment",
+			Handler:    _StorageService_Increment_Handler,
+		},
+		{
+			MethodName: "GetItems",
+			Handler:    _StorageService_GetItems_Handler,
+		},
+		{
+			MethodName: "SetItems",
+			Handler:    _StorageService_SetItems_Handler,
+		},
+		{
+			MethodName: "RemoveItems",
+			Handler:    _StorageService_RemoveItems_Handler,
+		},
+		{
+			MethodName: "Scan",
+			Handler:    _StorageService_Scan_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -111,6 +111,7 @@ var PrefixPolicies = []PrefixPolicy{
 	{"/globular/dns/v1/zones", RestoreAsUnverified, "configured DNS zones"},
 	{"/globular/cluster/minio/config", RestoreAsUnverified, "MinIO config"},
 	{"/globular/cluster/public-dirs/", RestoreAsUnverified, "public directory mappings"},
+	{"/globular/file/share-links/", RestoreAsUnverified, "share link records — tokens stay bounded by their own expiry"},
 	{"/globular/applications/", RestoreAsUnverified, "application registrations"},
 	{"/globular/backup/artifacts/", RestoreAsUnverified, "backup artifact metadata — points at real backups"},
 	{"/globular/ai/claude-md", RestoreAsUnverified, "AI operating context"},